@@ -22,12 +22,17 @@ import (
 	"strings"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/services/chaintime"
 	"github.com/wealdtech/ethdo/util"
 )
 
+// ErrUnknownValidator is returned when a validator is not present in the
+// chain information.
+var ErrUnknownValidator = errors.New("unknown validator")
+
 type ChainInfo struct {
 	Version                        uint64
 	Validators                     []*ValidatorInfo
@@ -35,6 +40,7 @@ type ChainInfo struct {
 	Epoch                          phase0.Epoch
 	GenesisForkVersion             phase0.Version
 	CurrentForkVersion             phase0.Version
+	ExitForkVersion                phase0.Version
 	BLSToExecutionChangeDomainType phase0.DomainType
 	VoluntaryExitDomainType        phase0.DomainType
 }
@@ -46,6 +52,7 @@ type chainInfoJSON struct {
 	Epoch                          string           `json:"epoch"`
 	GenesisForkVersion             string           `json:"genesis_fork_version"`
 	CurrentForkVersion             string           `json:"current_fork_version"`
+	ExitForkVersion                string           `json:"exit_fork_version,omitempty"`
 	BLSToExecutionChangeDomainType string           `json:"bls_to_execution_change_domain_type"`
 	VoluntaryExitDomainType        string           `json:"voluntary_exit_domain_type"`
 }
@@ -63,6 +70,7 @@ func (c *ChainInfo) MarshalJSON() ([]byte, error) {
 		Epoch:                          fmt.Sprintf("%d", c.Epoch),
 		GenesisForkVersion:             fmt.Sprintf("%#x", c.GenesisForkVersion),
 		CurrentForkVersion:             fmt.Sprintf("%#x", c.CurrentForkVersion),
+		ExitForkVersion:                fmt.Sprintf("%#x", c.ExitForkVersion),
 		BLSToExecutionChangeDomainType: fmt.Sprintf("%#x", c.BLSToExecutionChangeDomainType),
 		VoluntaryExitDomainType:        fmt.Sprintf("%#x", c.VoluntaryExitDomainType),
 	})
@@ -142,6 +150,20 @@ func (c *ChainInfo) UnmarshalJSON(input []byte) error {
 	}
 	copy(c.CurrentForkVersion[:], currentForkVersionBytes)
 
+	if data.ExitForkVersion == "" {
+		// Older files do not contain the exit fork version, so derive it.
+		c.ExitForkVersion = exitForkVersionFromNetwork(c.GenesisForkVersion, c.CurrentForkVersion, c.Epoch)
+	} else {
+		exitForkVersionBytes, err := hex.DecodeString(strings.TrimPrefix(data.ExitForkVersion, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "exit fork version invalid")
+		}
+		if len(exitForkVersionBytes) != phase0.ForkVersionLength {
+			return errors.New("exit fork version incorrect length")
+		}
+		copy(c.ExitForkVersion[:], exitForkVersionBytes)
+	}
+
 	if data.BLSToExecutionChangeDomainType == "" {
 		return errors.New("bls to execution domain type missing")
 	}
@@ -220,7 +242,7 @@ func (c *ChainInfo) FetchValidatorInfo(ctx context.Context, id string) (*Validat
 	}
 
 	if validatorInfo == nil {
-		return nil, errors.New("unknown validator")
+		return nil, ErrUnknownValidator
 	}
 
 	return validatorInfo, nil
@@ -241,10 +263,11 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	}
 
 	// Obtain validators.
-	validators, err := consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, "head", nil)
+	validatorsResponse, err := consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head"})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain validators")
 	}
+	validators := validatorsResponse.Data
 
 	for _, validator := range validators {
 		res.Validators = append(res.Validators, &ValidatorInfo{
@@ -256,17 +279,19 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	}
 
 	// Genesis validators root obtained from beacon node.
-	genesis, err := consensusClient.(consensusclient.GenesisProvider).Genesis(ctx)
+	genesisResponse, err := consensusClient.(consensusclient.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain genesis information")
 	}
+	genesis := genesisResponse.Data
 	res.GenesisValidatorsRoot = genesis.GenesisValidatorsRoot
 
 	// Fetch the genesis fork version from the specification.
-	spec, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	specResponse, err := consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 	tmp, exists := spec["GENESIS_FORK_VERSION"]
 	if !exists {
 		return nil, errors.New("genesis fork version not known by chain")
@@ -278,15 +303,17 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	}
 
 	// Fetch the current fork version from the fork schedule.
-	forkSchedule, err := consensusClient.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx)
+	forkScheduleResponse, err := consensusClient.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain fork schedule")
 	}
+	forkSchedule := forkScheduleResponse.Data
 	for i := range forkSchedule {
 		if forkSchedule[i].Epoch <= res.Epoch {
 			res.CurrentForkVersion = forkSchedule[i].CurrentVersion
 		}
 	}
+	res.ExitForkVersion, _ = VoluntaryExitForkVersion(spec, forkSchedule, res.Epoch)
 
 	blsToExecutionChangeDomainType, exists := spec["DOMAIN_BLS_TO_EXECUTION_CHANGE"].(phase0.DomainType)
 	if !exists {
@@ -0,0 +1,58 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// ExitForkVersionSupplied is the source of a fork version supplied by the user.
+	ExitForkVersionSupplied = "supplied"
+	// ExitForkVersionCurrent is the source of a fork version from the current fork.
+	ExitForkVersionCurrent = "current fork"
+	// ExitForkVersionCapella is the source of a fork version from the Capella fork.
+	ExitForkVersionCapella = "capella fork (EIP-7044)"
+)
+
+// VoluntaryExitForkVersion returns the fork version with which a voluntary
+// exit is signed at the given epoch, along with its source.
+//
+// From Deneb onwards voluntary exits are signed with the Capella fork version
+// (EIP-7044) so that they remain valid across future forks.  Because the Capella
+// fork version is also the current fork version during Capella, the Capella fork
+// version is used as soon as Capella is active.  Prior to that the current fork
+// version is used.
+func VoluntaryExitForkVersion(spec map[string]any,
+	forkSchedule []*phase0.Fork,
+	epoch phase0.Epoch,
+) (
+	phase0.Version,
+	string,
+) {
+	capellaEpoch, hasCapellaEpoch := spec["CAPELLA_FORK_EPOCH"].(uint64)
+	capellaVersion, hasCapellaVersion := spec["CAPELLA_FORK_VERSION"].(phase0.Version)
+	if hasCapellaEpoch && hasCapellaVersion && phase0.Epoch(capellaEpoch) <= epoch {
+		return capellaVersion, ExitForkVersionCapella
+	}
+
+	currentVersion := phase0.Version{}
+	for i := range forkSchedule {
+		if forkSchedule[i].Epoch <= epoch {
+			currentVersion = forkSchedule[i].CurrentVersion
+		}
+	}
+
+	return currentVersion, ExitForkVersionCurrent
+}
@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestVoluntaryExitForkVersion(t *testing.T) {
+	spec := map[string]any{
+		"CAPELLA_FORK_EPOCH":   uint64(200),
+		"CAPELLA_FORK_VERSION": phase0.Version{0x03, 0x00, 0x00, 0x00},
+	}
+	forkSchedule := []*phase0.Fork{
+		{Epoch: 0, CurrentVersion: phase0.Version{0x00, 0x00, 0x00, 0x00}},
+		{Epoch: 100, CurrentVersion: phase0.Version{0x02, 0x00, 0x00, 0x00}},
+		{Epoch: 200, CurrentVersion: phase0.Version{0x03, 0x00, 0x00, 0x00}},
+		{Epoch: 300, CurrentVersion: phase0.Version{0x04, 0x00, 0x00, 0x00}},
+		{Epoch: 400, CurrentVersion: phase0.Version{0x05, 0x00, 0x00, 0x00}},
+	}
+
+	tests := []struct {
+		name        string
+		spec        map[string]any
+		epoch       phase0.Epoch
+		forkVersion phase0.Version
+		forkSource  string
+	}{
+		{
+			name:        "Genesis",
+			spec:        spec,
+			epoch:       0,
+			forkVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCurrent,
+		},
+		{
+			name:        "Bellatrix",
+			spec:        spec,
+			epoch:       150,
+			forkVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCurrent,
+		},
+		{
+			name:        "Capella",
+			spec:        spec,
+			epoch:       200,
+			forkVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCapella,
+		},
+		{
+			name:        "Deneb",
+			spec:        spec,
+			epoch:       300,
+			forkVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCapella,
+		},
+		{
+			name:        "Electra",
+			spec:        spec,
+			epoch:       500,
+			forkVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCapella,
+		},
+		{
+			name:        "NoCapella",
+			spec:        map[string]any{},
+			epoch:       500,
+			forkVersion: phase0.Version{0x05, 0x00, 0x00, 0x00},
+			forkSource:  beacon.ExitForkVersionCurrent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forkVersion, forkSource := beacon.VoluntaryExitForkVersion(test.spec, forkSchedule, test.epoch)
+			require.Equal(t, test.forkVersion, forkVersion)
+			require.Equal(t, test.forkSource, forkSource)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// networkFork is a fork in a network's fork schedule.
+type networkFork struct {
+	name    string
+	epoch   phase0.Epoch
+	version phase0.Version
+}
+
+// networkInfo is the information about a network required to sign operations offline.
+type networkInfo struct {
+	genesisTime           time.Time
+	genesisValidatorsRoot phase0.Root
+	genesisForkVersion    phase0.Version
+	forks                 []*networkFork
+}
+
+const (
+	slotsPerEpoch = 32
+	slotDuration  = 12 * time.Second
+)
+
+var (
+	voluntaryExitDomainType        = phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+	blsToExecutionChangeDomainType = phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+)
+
+// networkInfos are the networks for which information is bundled.
+var networkInfos = map[string]*networkInfo{
+	"mainnet": {
+		genesisTime:           time.Unix(1606824023, 0),
+		genesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95},
+		genesisForkVersion:    phase0.Version{0x00, 0x00, 0x00, 0x00},
+		forks: []*networkFork{
+			{name: "altair", epoch: 74240, version: phase0.Version{0x01, 0x00, 0x00, 0x00}},
+			{name: "bellatrix", epoch: 144896, version: phase0.Version{0x02, 0x00, 0x00, 0x00}},
+			{name: "capella", epoch: 194048, version: phase0.Version{0x03, 0x00, 0x00, 0x00}},
+			{name: "deneb", epoch: 269568, version: phase0.Version{0x04, 0x00, 0x00, 0x00}},
+			{name: "electra", epoch: 364032, version: phase0.Version{0x05, 0x00, 0x00, 0x00}},
+		},
+	},
+	"goerli": {
+		genesisTime:           time.Unix(1616508000, 0),
+		genesisValidatorsRoot: phase0.Root{0x04, 0x3d, 0xb0, 0xd9, 0xa8, 0x38, 0x13, 0x55, 0x1e, 0xe2, 0xf3, 0x34, 0x50, 0xd2, 0x37, 0x97, 0x75, 0x7d, 0x43, 0x09, 0x11, 0xa9, 0x32, 0x05, 0x30, 0xad, 0x8a, 0x0e, 0xab, 0xc4, 0x3e, 0xfb},
+		genesisForkVersion:    phase0.Version{0x00, 0x00, 0x10, 0x20},
+		forks: []*networkFork{
+			{name: "altair", epoch: 36660, version: phase0.Version{0x01, 0x00, 0x10, 0x20}},
+			{name: "bellatrix", epoch: 112260, version: phase0.Version{0x02, 0x00, 0x10, 0x20}},
+			{name: "capella", epoch: 162304, version: phase0.Version{0x03, 0x00, 0x10, 0x20}},
+			{name: "deneb", epoch: 231680, version: phase0.Version{0x04, 0x00, 0x10, 0x20}},
+		},
+	},
+	"holesky": {
+		genesisTime:           time.Unix(1695902400, 0),
+		genesisValidatorsRoot: phase0.Root{0x91, 0x43, 0xaa, 0x7c, 0x61, 0x5a, 0x7f, 0x71, 0x15, 0xe2, 0xb6, 0xaa, 0xc3, 0x19, 0xc0, 0x35, 0x29, 0xdf, 0x82, 0x42, 0xae, 0x70, 0x5f, 0xba, 0x9d, 0xf3, 0x9b, 0x79, 0xc5, 0x9f, 0xa8, 0xb1},
+		genesisForkVersion:    phase0.Version{0x01, 0x01, 0x70, 0x00},
+		forks: []*networkFork{
+			{name: "altair", epoch: 0, version: phase0.Version{0x02, 0x01, 0x70, 0x00}},
+			{name: "bellatrix", epoch: 0, version: phase0.Version{0x03, 0x01, 0x70, 0x00}},
+			{name: "capella", epoch: 256, version: phase0.Version{0x04, 0x01, 0x70, 0x00}},
+			{name: "deneb", epoch: 29696, version: phase0.Version{0x05, 0x01, 0x70, 0x00}},
+			{name: "electra", epoch: 115968, version: phase0.Version{0x06, 0x01, 0x70, 0x00}},
+		},
+	},
+	"hoodi": {
+		genesisTime:           time.Unix(1742213400, 0),
+		genesisValidatorsRoot: phase0.Root{0x21, 0x2f, 0x13, 0xfc, 0x4d, 0xf0, 0x78, 0xb6, 0xcb, 0x7d, 0xb2, 0x28, 0xf1, 0xc8, 0x30, 0x75, 0x66, 0xdc, 0xec, 0xf9, 0x00, 0x86, 0x74, 0x01, 0xa9, 0x20, 0x23, 0xd7, 0xba, 0x99, 0xcb, 0x5f},
+		genesisForkVersion:    phase0.Version{0x10, 0x00, 0x09, 0x10},
+		forks: []*networkFork{
+			{name: "altair", epoch: 0, version: phase0.Version{0x20, 0x00, 0x09, 0x10}},
+			{name: "bellatrix", epoch: 0, version: phase0.Version{0x30, 0x00, 0x09, 0x10}},
+			{name: "capella", epoch: 0, version: phase0.Version{0x40, 0x00, 0x09, 0x10}},
+			{name: "deneb", epoch: 0, version: phase0.Version{0x50, 0x00, 0x09, 0x10}},
+			{name: "electra", epoch: 2048, version: phase0.Version{0x60, 0x00, 0x09, 0x10}},
+		},
+	},
+	"sepolia": {
+		genesisTime:           time.Unix(1655733600, 0),
+		genesisValidatorsRoot: phase0.Root{0xd8, 0xea, 0x17, 0x1f, 0x3c, 0x94, 0xae, 0xa2, 0x1e, 0xbc, 0x42, 0xa1, 0xed, 0x61, 0x05, 0x2a, 0xcf, 0x3f, 0x92, 0x09, 0xc0, 0x0e, 0x4e, 0xfb, 0xaa, 0xdd, 0xac, 0x09, 0xed, 0x9b, 0x80, 0x78},
+		genesisForkVersion:    phase0.Version{0x90, 0x00, 0x00, 0x69},
+		forks: []*networkFork{
+			{name: "altair", epoch: 50, version: phase0.Version{0x90, 0x00, 0x00, 0x70}},
+			{name: "bellatrix", epoch: 100, version: phase0.Version{0x90, 0x00, 0x00, 0x71}},
+			{name: "capella", epoch: 56832, version: phase0.Version{0x90, 0x00, 0x00, 0x72}},
+			{name: "deneb", epoch: 132608, version: phase0.Version{0x90, 0x00, 0x00, 0x73}},
+			{name: "electra", epoch: 222464, version: phase0.Version{0x90, 0x00, 0x00, 0x74}},
+		},
+	},
+}
+
+// networkAliases are alternative names for networks.
+var networkAliases = map[string]string{
+	"prater": "goerli",
+}
+
+// Networks returns the names of the networks for which information is bundled.
+func Networks() []string {
+	res := make([]string, 0, len(networkInfos))
+	for name := range networkInfos {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// GenesisForkVersion returns the genesis fork version of a network from the
+// bundled network information.  This is the fork version with which deposits
+// are signed.
+func GenesisForkVersion(network string) (phase0.Version, error) {
+	name := strings.ToLower(network)
+	if alias, exists := networkAliases[name]; exists {
+		name = alias
+	}
+	info, exists := networkInfos[name]
+	if !exists {
+		return phase0.Version{}, fmt.Errorf("unknown network %s; known networks are %s", network, strings.Join(Networks(), ", "))
+	}
+
+	return info.genesisForkVersion, nil
+}
+
+// ObtainChainInfoFromNetwork obtains the chain information for a network from
+// the bundled network information, as it stands at the given time.
+// The chain information contains no validators.
+func ObtainChainInfoFromNetwork(network string, at time.Time) (*ChainInfo, error) {
+	name := strings.ToLower(network)
+	if alias, exists := networkAliases[name]; exists {
+		name = alias
+	}
+	info, exists := networkInfos[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown network %s; known networks are %s", network, strings.Join(Networks(), ", "))
+	}
+
+	res := &ChainInfo{
+		Version:                        2,
+		Validators:                     make([]*ValidatorInfo, 0),
+		GenesisValidatorsRoot:          info.genesisValidatorsRoot,
+		GenesisForkVersion:             info.genesisForkVersion,
+		CurrentForkVersion:             info.genesisForkVersion,
+		BLSToExecutionChangeDomainType: blsToExecutionChangeDomainType,
+		VoluntaryExitDomainType:        voluntaryExitDomainType,
+	}
+	if at.After(info.genesisTime) {
+		res.Epoch = phase0.Epoch(uint64(at.Sub(info.genesisTime)/slotDuration) / slotsPerEpoch)
+	}
+
+	for _, fork := range info.forks {
+		if fork.epoch > res.Epoch {
+			break
+		}
+		res.CurrentForkVersion = fork.version
+	}
+	res.ExitForkVersion = exitForkVersionFromNetwork(res.GenesisForkVersion, res.CurrentForkVersion, res.Epoch)
+
+	return res, nil
+}
+
+// exitForkVersionFromNetwork returns the fork version with which voluntary exits
+// are signed at the given epoch, using the bundled information for the network
+// with the given genesis fork version.  If the network is not known the current
+// fork version is returned.
+func exitForkVersionFromNetwork(genesisForkVersion phase0.Version,
+	currentForkVersion phase0.Version,
+	epoch phase0.Epoch,
+) phase0.Version {
+	for _, info := range networkInfos {
+		if info.genesisForkVersion != genesisForkVersion {
+			continue
+		}
+		spec := make(map[string]any)
+		for _, fork := range info.forks {
+			if fork.name == "capella" {
+				spec["CAPELLA_FORK_EPOCH"] = uint64(fork.epoch)
+				spec["CAPELLA_FORK_VERSION"] = fork.version
+			}
+		}
+		if version, source := VoluntaryExitForkVersion(spec, nil, epoch); source == ExitForkVersionCapella {
+			return version
+		}
+	}
+
+	return currentForkVersion
+}
+
+// ForkActive returns true if the named fork is active at the given time on the
+// network with the given genesis fork version.
+// The second return value is false if the network is not known.
+func ForkActive(genesisForkVersion phase0.Version, fork string, at time.Time) (bool, bool) {
+	for _, info := range networkInfos {
+		if info.genesisForkVersion != genesisForkVersion {
+			continue
+		}
+		epoch := phase0.Epoch(0)
+		if at.After(info.genesisTime) {
+			epoch = phase0.Epoch(uint64(at.Sub(info.genesisTime)/slotDuration) / slotsPerEpoch)
+		}
+		for _, networkFork := range info.forks {
+			if networkFork.name == fork {
+				return networkFork.epoch <= epoch, true
+			}
+		}
+
+		return false, true
+	}
+
+	return false, false
+}
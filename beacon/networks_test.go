@@ -0,0 +1,214 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestObtainChainInfoFromNetwork(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		at          time.Time
+		epoch       phase0.Epoch
+		forkVersion phase0.Version
+		exitVersion phase0.Version
+		genesisFork phase0.Version
+		genesisRoot string
+		err         string
+	}{
+		{
+			name:    "Unknown",
+			network: "unknown",
+			at:      time.Unix(1700000000, 0),
+			err:     "unknown network unknown; known networks are goerli, holesky, hoodi, mainnet, sepolia",
+		},
+		{
+			name:        "MainnetBellatrix",
+			network:     "mainnet",
+			at:          time.Unix(1606824023+150000*384, 0),
+			epoch:       150000,
+			forkVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+			exitVersion: phase0.Version{0x02, 0x00, 0x00, 0x00},
+			genesisFork: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			genesisRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		},
+		{
+			name:        "MainnetDeneb",
+			network:     "Mainnet",
+			at:          time.Unix(1606824023+300000*384, 0),
+			epoch:       300000,
+			forkVersion: phase0.Version{0x04, 0x00, 0x00, 0x00},
+			exitVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			genesisFork: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			genesisRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+		},
+		{
+			name:        "Prater",
+			network:     "prater",
+			at:          time.Unix(1616508000+200000*384, 0),
+			epoch:       200000,
+			forkVersion: phase0.Version{0x03, 0x00, 0x10, 0x20},
+			exitVersion: phase0.Version{0x03, 0x00, 0x10, 0x20},
+			genesisFork: phase0.Version{0x00, 0x00, 0x10, 0x20},
+			genesisRoot: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
+		},
+		{
+			name:        "BeforeGenesis",
+			network:     "holesky",
+			at:          time.Unix(1600000000, 0),
+			epoch:       0,
+			forkVersion: phase0.Version{0x03, 0x01, 0x70, 0x00},
+			exitVersion: phase0.Version{0x03, 0x01, 0x70, 0x00},
+			genesisFork: phase0.Version{0x01, 0x01, 0x70, 0x00},
+			genesisRoot: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+		},
+		{
+			name:        "HoodiElectra",
+			network:     "hoodi",
+			at:          time.Unix(1742213400+3000*384, 0),
+			epoch:       3000,
+			forkVersion: phase0.Version{0x60, 0x00, 0x09, 0x10},
+			exitVersion: phase0.Version{0x40, 0x00, 0x09, 0x10},
+			genesisFork: phase0.Version{0x10, 0x00, 0x09, 0x10},
+			genesisRoot: "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := beacon.ObtainChainInfoFromNetwork(test.network, test.at)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.epoch, res.Epoch)
+			require.Equal(t, test.forkVersion, res.CurrentForkVersion)
+			require.Equal(t, test.exitVersion, res.ExitForkVersion)
+			require.Equal(t, test.genesisFork, res.GenesisForkVersion)
+			require.Equal(t, test.genesisRoot, res.GenesisValidatorsRoot.String())
+			require.Empty(t, res.Validators)
+		})
+	}
+}
+
+func TestGenesisForkVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		forkVersion phase0.Version
+		err         string
+	}{
+		{
+			name:    "Unknown",
+			network: "unknown",
+			err:     "unknown network unknown; known networks are goerli, holesky, hoodi, mainnet, sepolia",
+		},
+		{
+			name:        "Mainnet",
+			network:     "Mainnet",
+			forkVersion: phase0.Version{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "Holesky",
+			network:     "holesky",
+			forkVersion: phase0.Version{0x01, 0x01, 0x70, 0x00},
+		},
+		{
+			name:        "Hoodi",
+			network:     "Hoodi",
+			forkVersion: phase0.Version{0x10, 0x00, 0x09, 0x10},
+		},
+		{
+			name:        "Alias",
+			network:     "prater",
+			forkVersion: phase0.Version{0x00, 0x00, 0x10, 0x20},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forkVersion, err := beacon.GenesisForkVersion(test.network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.forkVersion, forkVersion)
+			}
+		})
+	}
+}
+
+func TestForkActive(t *testing.T) {
+	tests := []struct {
+		name    string
+		version phase0.Version
+		fork    string
+		at      time.Time
+		active  bool
+		known   bool
+	}{
+		{
+			name:    "UnknownNetwork",
+			version: phase0.Version{0x01, 0x02, 0x03, 0x04},
+			fork:    "electra",
+			at:      time.Unix(1800000000, 0),
+		},
+		{
+			name:    "MainnetPreElectra",
+			version: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			fork:    "electra",
+			at:      time.Unix(1606824023+364031*384, 0),
+			known:   true,
+		},
+		{
+			name:    "MainnetElectra",
+			version: phase0.Version{0x00, 0x00, 0x00, 0x00},
+			fork:    "electra",
+			at:      time.Unix(1606824023+364032*384, 0),
+			active:  true,
+			known:   true,
+		},
+		{
+			name:    "GoerliNoElectra",
+			version: phase0.Version{0x00, 0x00, 0x10, 0x20},
+			fork:    "electra",
+			at:      time.Unix(1800000000, 0),
+			known:   true,
+		},
+		{
+			name:    "HoleskyBeforeGenesis",
+			version: phase0.Version{0x01, 0x01, 0x70, 0x00},
+			fork:    "bellatrix",
+			at:      time.Unix(1600000000, 0),
+			active:  true,
+			known:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			active, known := beacon.ForkActive(test.version, test.fork, test.at)
+			require.Equal(t, test.active, active)
+			require.Equal(t, test.known, known)
+		})
+	}
+}
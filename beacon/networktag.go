@@ -0,0 +1,133 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// networkTagField is the field in generated signed operations that holds the
+// genesis validators root of the network for which they were generated.
+const networkTagField = "genesis_validators_root"
+
+// NetworkName returns the name of the network with the given genesis validators root,
+// or a hex representation of the root if the network is not known.
+func NetworkName(genesisValidatorsRoot phase0.Root) string {
+	for _, name := range Networks() {
+		if networkInfos[name].genesisValidatorsRoot == genesisValidatorsRoot {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("%#x", genesisValidatorsRoot)
+}
+
+// AddNetworkTag adds the genesis validators root of the network to the JSON of
+// a signed operation, or an array of signed operations.
+func AddNetworkTag(data []byte, genesisValidatorsRoot phase0.Root) ([]byte, error) {
+	tag, err := json.Marshal(genesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal genesis validators root")
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var operations []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &operations); err != nil {
+			return nil, errors.Wrap(err, "failed to parse operations")
+		}
+		for _, operation := range operations {
+			operation[networkTagField] = tag
+		}
+
+		return json.Marshal(operations)
+	}
+
+	var operation map[string]json.RawMessage
+	if err := json.Unmarshal(data, &operation); err != nil {
+		return nil, errors.Wrap(err, "failed to parse operation")
+	}
+	operation[networkTagField] = tag
+
+	return json.Marshal(operation)
+}
+
+// ObtainNetworkTag obtains the genesis validators root of the network from the
+// JSON of a signed operation, or an array of signed operations.
+// It returns nil if the operations do not contain the information.
+func ObtainNetworkTag(data []byte) (*phase0.Root, error) {
+	var operations []map[string]json.RawMessage
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &operations); err != nil {
+			return nil, errors.Wrap(err, "failed to parse operations")
+		}
+	} else {
+		var operation map[string]json.RawMessage
+		if err := json.Unmarshal(data, &operation); err != nil {
+			return nil, errors.Wrap(err, "failed to parse operation")
+		}
+		operations = append(operations, operation)
+	}
+
+	var res *phase0.Root
+	for _, operation := range operations {
+		tag, exists := operation[networkTagField]
+		if !exists {
+			continue
+		}
+		root := phase0.Root{}
+		if err := json.Unmarshal(tag, &root); err != nil {
+			return nil, errors.Wrap(err, "invalid genesis validators root in operation")
+		}
+		if res != nil && *res != root {
+			return nil, errors.New("operations are for multiple networks")
+		}
+		res = &root
+	}
+
+	return res, nil
+}
+
+// VerifyNetwork confirms that the beacon node is on the network with the
+// given genesis validators root.
+func VerifyNetwork(ctx context.Context,
+	consensusClient consensusclient.Service,
+	genesisValidatorsRoot phase0.Root,
+) error {
+	genesisProvider, isProvider := consensusClient.(consensusclient.GenesisProvider)
+	if !isProvider {
+		return errors.New("consensus client does not provide genesis")
+	}
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	genesis := genesisResponse.Data
+
+	if genesis.GenesisValidatorsRoot != genesisValidatorsRoot {
+		return fmt.Errorf("operations are for network %s but beacon node is on network %s",
+			NetworkName(genesisValidatorsRoot),
+			NetworkName(genesis.GenesisValidatorsRoot),
+		)
+	}
+
+	return nil
+}
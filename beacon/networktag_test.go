@@ -0,0 +1,131 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/mock"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+var (
+	mainnetRoot = phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95}
+	mockRoot    = phase0.Root{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f}
+)
+
+func TestNetworkName(t *testing.T) {
+	require.Equal(t, "mainnet", beacon.NetworkName(mainnetRoot))
+	require.Equal(t, "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", beacon.NetworkName(mockRoot))
+}
+
+func TestNetworkTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output string
+		err    string
+	}{
+		{
+			name:  "Invalid",
+			input: `{`,
+			err:   "failed to parse operation: unexpected end of JSON input",
+		},
+		{
+			name:   "Single",
+			input:  `{"message":{"epoch":"1","validator_index":"2"},"signature":"0x00"}`,
+			output: `{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","message":{"epoch":"1","validator_index":"2"},"signature":"0x00"}`,
+		},
+		{
+			name:   "Array",
+			input:  `[{"message":{"epoch":"1","validator_index":"2"},"signature":"0x00"},{"message":{"epoch":"1","validator_index":"3"},"signature":"0x00"}]`,
+			output: `[{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","message":{"epoch":"1","validator_index":"2"},"signature":"0x00"},{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","message":{"epoch":"1","validator_index":"3"},"signature":"0x00"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := beacon.AddNetworkTag([]byte(test.input), mainnetRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.output, string(res))
+
+			root, err := beacon.ObtainNetworkTag(res)
+			require.NoError(t, err)
+			require.Equal(t, mainnetRoot, *root)
+		})
+	}
+}
+
+func TestObtainNetworkTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   *phase0.Root
+		err   string
+	}{
+		{
+			name:  "Invalid",
+			input: `[`,
+			err:   "failed to parse operations: unexpected end of JSON input",
+		},
+		{
+			name:  "Untagged",
+			input: `[{"message":{"epoch":"1","validator_index":"2"},"signature":"0x00"}]`,
+		},
+		{
+			name:  "RootInvalid",
+			input: `{"genesis_validators_root":"0x00","message":{"epoch":"1","validator_index":"2"},"signature":"0x00"}`,
+			err:   "invalid genesis validators root in operation: incorrect length",
+		},
+		{
+			name:  "MultipleNetworks",
+			input: `[{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"},{"genesis_validators_root":"0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}]`,
+			err:   "operations are for multiple networks",
+		},
+		{
+			name:  "PartiallyTagged",
+			input: `[{"message":{}},{"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}]`,
+			res:   &mainnetRoot,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := beacon.ObtainNetworkTag([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	ctx := context.Background()
+	consensusClient, err := mock.New(ctx, mock.WithGenesisTime(time.Now()))
+	require.NoError(t, err)
+
+	require.NoError(t, beacon.VerifyNetwork(ctx, consensusClient, mockRoot))
+	require.EqualError(t, beacon.VerifyNetwork(ctx, consensusClient, mainnetRoot), "operations are for network mainnet but beacon node is on network 0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+}
@@ -0,0 +1,69 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompositeapprove
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet bool
+
+	// Input.
+	composite   string
+	operation   string
+	member      string
+	account     string
+	passphrases []string
+	expiry      time.Duration
+
+	// Output.
+	approval *util.CompositeApproval
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		composite:   strings.ToLower(viper.GetString("composite")),
+		operation:   strings.ToLower(viper.GetString("operation")),
+		member:      viper.GetString("member"),
+		account:     viper.GetString("account"),
+		passphrases: util.GetPassphrases(),
+		expiry:      viper.GetDuration("expiry"),
+	}
+
+	if c.composite == "" {
+		return nil, errors.New("composite is required")
+	}
+	if c.operation == "" {
+		return nil, errors.New("operation is required")
+	}
+	if c.member == "" {
+		return nil, errors.New("member is required")
+	}
+	if c.account == "" {
+		return nil, errors.New("account is required")
+	}
+	if c.expiry <= 0 {
+		return nil, errors.New("expiry must be positive")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompositeapprove
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "CompositeMissing",
+			vars: map[string]interface{}{
+				"operation": "exit",
+				"member":    "Validators/1",
+				"account":   "Approvers/1",
+				"expiry":    "24h",
+			},
+			err: "composite is required",
+		},
+		{
+			name: "OperationMissing",
+			vars: map[string]interface{}{
+				"composite": "ops",
+				"member":    "Validators/1",
+				"account":   "Approvers/1",
+				"expiry":    "24h",
+			},
+			err: "operation is required",
+		},
+		{
+			name: "MemberMissing",
+			vars: map[string]interface{}{
+				"composite": "ops",
+				"operation": "exit",
+				"account":   "Approvers/1",
+				"expiry":    "24h",
+			},
+			err: "member is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"composite": "ops",
+				"operation": "exit",
+				"member":    "Validators/1",
+				"expiry":    "24h",
+			},
+			err: "account is required",
+		},
+		{
+			name: "ExpiryMissing",
+			vars: map[string]interface{}{
+				"composite": "ops",
+				"operation": "exit",
+				"member":    "Validators/1",
+				"account":   "Approvers/1",
+			},
+			err: "expiry must be positive",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"composite": "Ops",
+				"operation": "Exit",
+				"member":    "Validators/1",
+				"account":   "Approvers/1",
+				"expiry":    "24h",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "ops", cmd.composite)
+				require.Equal(t, "exit", cmd.operation)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompositeapprove
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type dataIn struct {
+	timeout     time.Duration
+	composite   string
+	operation   string
+	member      string
+	expiry      time.Duration
+	account     e2wtypes.Account
+	passphrases []string
+}
+
+func input(ctx context.Context) (*dataIn, error) {
+	var err error
+	data := &dataIn{}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	data.timeout = viper.GetDuration("timeout")
+
+	// Composite.
+	data.composite = viper.GetString("composite")
+	if data.composite == "" {
+		return nil, errors.New("composite is required")
+	}
+
+	// Operation.
+	data.operation = strings.ToLower(viper.GetString("operation"))
+	switch data.operation {
+	case "":
+		return nil, errors.New("operation is required")
+	case util.CompositeOperationExit, util.CompositeOperationKey:
+	default:
+		return nil, errors.New("operation must be one of exit or key")
+	}
+
+	// Member.
+	data.member = viper.GetString("member")
+	if data.member == "" {
+		return nil, errors.New("member is required")
+	}
+
+	// Expiry.
+	data.expiry = viper.GetDuration("expiry")
+	if data.expiry <= 0 {
+		return nil, errors.New("expiry must be positive")
+	}
+
+	// Approver account.
+	_, data.account, err = util.WalletAndAccountFromInput(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain approver account")
+	}
+
+	// Passphrases.
+	data.passphrases = util.GetPassphrases()
+
+	return data, nil
+}
@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompositeapprove
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+
+	composites, err := util.Composites()
+	if err != nil {
+		return nil, err
+	}
+	composite, exists := composites[data.composite]
+	if !exists {
+		return nil, fmt.Errorf("composite %q not found", data.composite)
+	}
+
+	memberName, memberPubKey, err := member(ctx, data.member)
+	if err != nil {
+		return nil, err
+	}
+	if !composite.Covers(data.operation, memberName, memberPubKey) {
+		return nil, fmt.Errorf("composite %q does not cover %s of %s", data.composite, data.operation, data.member)
+	}
+
+	approverPubKey, err := util.BestPublicKey(data.account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain approver public key")
+	}
+	isApprover, err := composite.IsApprover(ctx, approverPubKey.Marshal())
+	if err != nil {
+		return nil, err
+	}
+	if !isApprover {
+		return nil, fmt.Errorf("account is not an approver of composite %q", data.composite)
+	}
+
+	alreadyUnlocked, err := util.UnlockAccount(ctx, data.account, data.passphrases)
+	if err != nil {
+		return nil, err
+	}
+	if !alreadyUnlocked {
+		// Because we unlocked the accout we should re-lock it when we're done.
+		defer func() {
+			if locker, isLocker := data.account.(e2wtypes.AccountLocker); isLocker {
+				if err := locker.Lock(ctx); err != nil {
+					util.Log.Trace().Err(err).Msg("Failed to lock account")
+				}
+			}
+		}()
+	}
+
+	approval, err := util.SignCompositeApproval(ctx, data.account, data.composite, data.operation, memberPubKey, time.Now().Add(data.expiry))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataOut{
+		approval: approval,
+	}, nil
+}
+
+// member returns the name, if available, and public key of the member.
+func member(ctx context.Context, input string) (string, []byte, error) {
+	if strings.HasPrefix(input, "0x") {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return "", nil, errors.Wrap(err, "invalid member public key")
+		}
+		return "", pubKey, nil
+	}
+
+	_, account, err := util.WalletAndAccountFromPath(ctx, input)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to obtain member account")
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to obtain member public key")
+	}
+
+	return input, pubKey.Marshal(), nil
+}
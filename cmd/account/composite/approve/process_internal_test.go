@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcompositeapprove
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(ctx,
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	approverPubKey, err := util.BestPublicKey(interop0)
+	require.NoError(t, err)
+
+	member := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("composites", map[string]interface{}{
+		"ops": map[string]interface{}{
+			"threshold":  1,
+			"accounts":   []string{member},
+			"approvers":  []string{fmt.Sprintf("%#x", approverPubKey.Marshal())},
+			"operations": []string{"exit"},
+		},
+		"others": map[string]interface{}{
+			"threshold":  1,
+			"accounts":   []string{member},
+			"approvers":  []string{"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+			"operations": []string{"key"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "CompositeUnknown",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				composite:   "unknown",
+				operation:   "exit",
+				member:      member,
+				expiry:      time.Hour,
+				account:     interop0,
+				passphrases: []string{"pass"},
+			},
+			err: `composite "unknown" not found`,
+		},
+		{
+			name: "OperationNotCovered",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				composite:   "ops",
+				operation:   "key",
+				member:      member,
+				expiry:      time.Hour,
+				account:     interop0,
+				passphrases: []string{"pass"},
+			},
+			err: `composite "ops" does not cover key of ` + member,
+		},
+		{
+			name: "NotApprover",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				composite:   "others",
+				operation:   "key",
+				member:      member,
+				expiry:      time.Hour,
+				account:     interop0,
+				passphrases: []string{"pass"},
+			},
+			err: `account is not an approver of composite "others"`,
+		},
+		{
+			name: "PassphraseIncorrect",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				composite:   "ops",
+				operation:   "exit",
+				member:      member,
+				expiry:      time.Hour,
+				account:     interop0,
+				passphrases: []string{"wrong"},
+			},
+			err: "failed to unlock account",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				composite:   "ops",
+				operation:   "exit",
+				member:      member,
+				expiry:      time.Hour,
+				account:     interop0,
+				passphrases: []string{"pass"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(ctx, test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, res.approval)
+				require.NoError(t, res.approval.Verify())
+				require.NoError(t, util.CheckCompositeApprovals(ctx, "exit", "", hexToBytes(member), []string{mustOutput(t, res)}))
+			}
+		})
+	}
+}
+
+func mustOutput(t *testing.T, data *dataOut) string {
+	t.Helper()
+	res, err := output(context.Background(), data)
+	require.NoError(t, err)
+
+	return res
+}
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
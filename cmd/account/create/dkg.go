@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcreate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// participantPollInterval is the interval between checks that a participant
+// holds its share of a newly generated distributed account.
+var participantPollInterval = time.Second
+
+// participantAccount is a participant's view of a distributed account.
+type participantAccount struct {
+	shareKey         []byte
+	compositeKey     []byte
+	signingThreshold uint32
+}
+
+// verifyDistributedAccount waits until all participants of a distributed
+// account generated by Dirk hold their share of the account, then verifies
+// that they agree on its composite public key and that the composite public
+// key is the one generated by their shares.
+func verifyDistributedAccount(ctx context.Context, data *dataIn, account e2wtypes.Account) error {
+	compositeKeyProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider)
+	if !isProvider {
+		return errors.New("account does not provide a composite public key")
+	}
+	participantsProvider, isProvider := account.(e2wtypes.AccountParticipantsProvider)
+	if !isProvider {
+		return errors.New("account does not provide its participants")
+	}
+	thresholdProvider, isProvider := account.(e2wtypes.AccountSigningThresholdProvider)
+	if !isProvider {
+		return errors.New("account does not provide its signing threshold")
+	}
+
+	participants := participantsProvider.Participants()
+	if uint32(len(participants)) != data.participants {
+		return fmt.Errorf("account has %d participants, expected %d", len(participants), data.participants)
+	}
+	if thresholdProvider.SigningThreshold() != data.signingThreshold {
+		return fmt.Errorf("account has signing threshold %d, expected %d", thresholdProvider.SigningThreshold(), data.signingThreshold)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("%s/%s", data.wallet.Name(), data.accountName)
+	accounts := make(map[uint64]*participantAccount, len(participants))
+	for id, endpoint := range participants {
+		participant, err := awaitParticipantAccount(ctx, path, endpoint)
+		if err != nil {
+			return errors.Wrapf(err, "participant %d (%s)", id, endpoint)
+		}
+		accounts[id] = participant
+	}
+
+	return checkParticipantAccounts(compositeKeyProvider.CompositePublicKey().Marshal(), data.signingThreshold, accounts)
+}
+
+// awaitParticipantAccount waits until a participant holds its share of a
+// distributed account, and returns the participant's view of the account.
+func awaitParticipantAccount(ctx context.Context, path string, endpoint string) (*participantAccount, error) {
+	_, accountName, err := e2wallet.WalletAndAccountNames(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		account, err := participantAccountAtEndpoint(ctx, path, accountName, endpoint)
+		if err == nil {
+			return account, nil
+		}
+		util.Log.Trace().Str("endpoint", endpoint).Err(err).Msg("Participant account not yet available")
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(err, "timed out waiting for account")
+		case <-time.After(participantPollInterval):
+		}
+	}
+}
+
+func participantAccountAtEndpoint(ctx context.Context, path string, accountName string, endpoint string) (*participantAccount, error) {
+	wallet, err := util.RemoteWalletAtEndpoint(ctx, path, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	accountByNameProvider, isProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isProvider {
+		return nil, errors.New("wallet cannot obtain accounts by name")
+	}
+	account, err := accountByNameProvider.AccountByName(ctx, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeKeyProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider)
+	if !isProvider {
+		return nil, errors.New("account is not distributed")
+	}
+	thresholdProvider, isProvider := account.(e2wtypes.AccountSigningThresholdProvider)
+	if !isProvider {
+		return nil, errors.New("account does not provide its signing threshold")
+	}
+
+	return &participantAccount{
+		shareKey:         account.PublicKey().Marshal(),
+		compositeKey:     compositeKeyProvider.CompositePublicKey().Marshal(),
+		signingThreshold: thresholdProvider.SigningThreshold(),
+	}, nil
+}
+
+// checkParticipantAccounts checks that the participants' views of a
+// distributed account agree with each other, and that their public key
+// shares generate the composite public key.
+func checkParticipantAccounts(compositeKey []byte,
+	signingThreshold uint32,
+	accounts map[uint64]*participantAccount,
+) error {
+	if uint32(len(accounts)) < signingThreshold {
+		return fmt.Errorf("%d participants available, at least %d required", len(accounts), signingThreshold)
+	}
+
+	ids := make([]uint64, 0, len(accounts))
+	for id := range accounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	shares := make(map[uint64][]byte, len(accounts))
+	for _, id := range ids {
+		account := accounts[id]
+		if !bytes.Equal(account.compositeKey, compositeKey) {
+			return fmt.Errorf("participant %d has composite public key %#x, expected %#x", id, account.compositeKey, compositeKey)
+		}
+		if account.signingThreshold != signingThreshold {
+			return fmt.Errorf("participant %d has signing threshold %d, expected %d", id, account.signingThreshold, signingThreshold)
+		}
+		shares[id] = account.shareKey
+	}
+
+	recoveredKey, err := util.RecoverPublicKey(shares)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(recoveredKey, compositeKey) {
+		return fmt.Errorf("participants' public key shares generate composite public key %#x, expected %#x", recoveredKey, compositeKey)
+	}
+
+	return nil
+}
+
+// recordWatchOnlyAccount records a distributed account generated by Dirk as
+// a local watch-only account.
+func recordWatchOnlyAccount(ctx context.Context, data *dataIn, account e2wtypes.Account) (e2wtypes.Account, error) {
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain composite public key")
+	}
+
+	metadata := &util.WatchOnlyMetadata{
+		Source: "dirk",
+		Details: map[string]string{
+			"remote":            data.remote,
+			"account":           fmt.Sprintf("%s/%s", data.wallet.Name(), data.accountName),
+			"signing_threshold": fmt.Sprintf("%d", data.signingThreshold),
+			"participants":      fmt.Sprintf("%d", data.participants),
+		},
+	}
+	if err := util.ImportWatchOnlyAccount(ctx, data.localWallet, data.localAccountName, pubKey.Marshal(), metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to record watch-only account")
+	}
+
+	// Reopen the wallet to pick up the new account.
+	storeProvider, isStoreProvider := data.localWallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not provide its store")
+	}
+	wallet, err := e2wallet.OpenWallet(data.localWallet.Name(), e2wallet.WithStore(storeProvider.Store()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reopen wallet")
+	}
+	accountByNameProvider, isAccountByNameProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isAccountByNameProvider {
+		return nil, errors.New("wallet cannot obtain accounts by name")
+	}
+	localAccount, err := accountByNameProvider.AccountByName(ctx, data.localAccountName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain watch-only account")
+	}
+
+	return localAccount, nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountcreate
+
+import (
+	"fmt"
+	"testing"
+
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestCheckParticipantAccounts(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	// Generate a 2-of-3 threshold key.
+	var masterKey bls.SecretKey
+	masterKey.SetByCSPRNG()
+	masterKeys := masterKey.GetMasterSecretKey(2)
+	compositeKey := masterKey.GetPublicKey().Serialize()
+	shares := make(map[uint64][]byte)
+	for id := uint64(1); id <= 3; id++ {
+		var share bls.SecretKey
+		require.NoError(t, share.Set(masterKeys, util.BLSID(id)))
+		shares[id] = share.GetPublicKey().Serialize()
+	}
+	var otherKey bls.SecretKey
+	otherKey.SetByCSPRNG()
+	otherCompositeKey := otherKey.GetPublicKey().Serialize()
+
+	accounts := func(compositeKey []byte, signingThreshold uint32, ids ...uint64) map[uint64]*participantAccount {
+		res := make(map[uint64]*participantAccount)
+		for _, id := range ids {
+			res[id] = &participantAccount{
+				shareKey:         shares[id],
+				compositeKey:     compositeKey,
+				signingThreshold: signingThreshold,
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name             string
+		compositeKey     []byte
+		signingThreshold uint32
+		accounts         map[uint64]*participantAccount
+		err              string
+	}{
+		{
+			name:             "TooFewParticipants",
+			compositeKey:     compositeKey,
+			signingThreshold: 2,
+			accounts:         accounts(compositeKey, 2, 1),
+			err:              "1 participants available, at least 2 required",
+		},
+		{
+			name:             "CompositeKeyMismatch",
+			compositeKey:     compositeKey,
+			signingThreshold: 2,
+			accounts: func() map[uint64]*participantAccount {
+				res := accounts(compositeKey, 2, 1, 2, 3)
+				res[2].compositeKey = otherCompositeKey
+				return res
+			}(),
+			err: fmt.Sprintf("participant 2 has composite public key %#x, expected %#x", otherCompositeKey, compositeKey),
+		},
+		{
+			name:             "SigningThresholdMismatch",
+			compositeKey:     compositeKey,
+			signingThreshold: 2,
+			accounts: func() map[uint64]*participantAccount {
+				res := accounts(compositeKey, 2, 1, 2, 3)
+				res[3].signingThreshold = 3
+				return res
+			}(),
+			err: "participant 3 has signing threshold 3, expected 2",
+		},
+		{
+			name:             "SharesMismatch",
+			compositeKey:     otherCompositeKey,
+			signingThreshold: 2,
+			accounts:         accounts(otherCompositeKey, 2, 1, 2, 3),
+			err:              fmt.Sprintf("participants' public key shares generate composite public key %#x, expected %#x", compositeKey, otherCompositeKey),
+		},
+		{
+			name:             "Threshold",
+			compositeKey:     compositeKey,
+			signingThreshold: 2,
+			accounts:         accounts(compositeKey, 2, 1, 3),
+		},
+		{
+			name:             "Good",
+			compositeKey:     compositeKey,
+			signingThreshold: 2,
+			accounts:         accounts(compositeKey, 2, 1, 2, 3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkParticipantAccounts(test.compositeKey, test.signingThreshold, test.accounts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -36,6 +36,10 @@ type dataIn struct {
 	signingThreshold uint32
 	// For pathed accounts.
 	path string
+	// For distributed accounts generated by Dirk.
+	remote           string
+	localWallet      e2wtypes.Wallet
+	localAccountName string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -92,5 +96,47 @@ func input(ctx context.Context) (*dataIn, error) {
 	// Path.
 	data.path = viper.GetString("path")
 
+	// Local watch-only account.
+	data.remote = viper.GetString("remote")
+	if viper.GetString("local-account") != "" {
+		if err := inputLocalAccount(ctx, data); err != nil {
+			return nil, err
+		}
+	}
+
 	return data, nil
 }
+
+// inputLocalAccount obtains input for recording a distributed account
+// generated by Dirk as a local watch-only account.
+func inputLocalAccount(ctx context.Context, data *dataIn) error {
+	var err error
+
+	if data.participants < 2 {
+		return errors.New("local-account can only be supplied for distributed accounts")
+	}
+	if data.remote == "" {
+		return errors.New("local-account can only be supplied with remote")
+	}
+	_, data.localAccountName, err = e2wallet.WalletAndAccountNames(viper.GetString("local-account"))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain local account name")
+	}
+	if data.localAccountName == "" {
+		return errors.New("local account name is required")
+	}
+
+	// The local store is not set up when using a remote account manager,
+	// but it holds the watch-only account.
+	if err := util.SetupLocalStore(); err != nil {
+		return errors.Wrap(err, "failed to set up local store")
+	}
+	ctx, cancel := context.WithTimeout(ctx, data.timeout)
+	defer cancel()
+	data.localWallet, err = util.LocalWalletFromPath(ctx, viper.GetString("local-account"))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain local wallet")
+	}
+
+	return nil
+}
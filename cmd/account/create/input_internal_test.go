@@ -119,6 +119,30 @@ func TestInput(t *testing.T) {
 			},
 			err: "signing threshold must be at least one",
 		},
+		{
+			name: "LocalAccountNotDistributed",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Test account",
+				"passphrase":        "ce%NohGhah4ye5ra",
+				"participants":      1,
+				"signing-threshold": 1,
+				"local-account":     "Test wallet/Watched account",
+			},
+			err: "local-account can only be supplied for distributed accounts",
+		},
+		{
+			name: "LocalAccountNoRemote",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"account":           "Test wallet/Test account",
+				"passphrase":        "ce%NohGhah4ye5ra",
+				"participants":      3,
+				"signing-threshold": 2,
+				"local-account":     "Test wallet/Watched account",
+			},
+			err: "local-account can only be supplied with remote",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
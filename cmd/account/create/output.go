@@ -23,6 +23,11 @@ import (
 
 type dataOut struct {
 	account e2wtypes.Account
+	// For distributed accounts generated by Dirk.
+	participants    uint32
+	verified        bool
+	localWalletName string
+	localAccount    e2wtypes.Account
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -34,7 +39,14 @@ func output(_ context.Context, data *dataOut) (string, error) {
 	}
 
 	if pubKeyProvider, ok := data.account.(e2wtypes.AccountCompositePublicKeyProvider); ok {
-		return fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal()), nil
+		res := fmt.Sprintf("%#x", pubKeyProvider.CompositePublicKey().Marshal())
+		if data.verified {
+			res = fmt.Sprintf("%s\nComposite public key verified with %d participants", res, data.participants)
+		}
+		if data.localAccount != nil {
+			res = fmt.Sprintf("%s\nRecorded as watch-only account %s/%s", res, data.localWalletName, data.localAccount.Name())
+		}
+		return res, nil
 	}
 
 	if pubKeyProvider, ok := data.account.(e2wtypes.AccountPublicKeyProvider); ok {
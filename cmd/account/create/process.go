@@ -143,5 +143,24 @@ func processDistributed(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.Wrap(err, "failed to create account")
 	}
 	results.account = account
+
+	if data.remote != "" {
+		// Dirk generates the account through distributed key generation
+		// across the participants; confirm that it has done so correctly.
+		if err := verifyDistributedAccount(ctx, data, account); err != nil {
+			return nil, errors.Wrap(err, "failed to verify distributed account")
+		}
+		results.participants = data.participants
+		results.verified = true
+
+		if data.localWallet != nil {
+			results.localAccount, err = recordWatchOnlyAccount(ctx, data, account)
+			if err != nil {
+				return nil, err
+			}
+			results.localWalletName = data.localWallet.Name()
+		}
+	}
+
 	return results, nil
 }
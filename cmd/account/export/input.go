@@ -0,0 +1,103 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type dataIn struct {
+	timeout     time.Duration
+	verbose     bool
+	walletName  string
+	accounts    []e2wtypes.Account
+	passphrases []string
+	approvals   []string
+	// Keystore options.
+	keystorePassphrase string
+	encryptor          *keystorev4.Encryptor
+	dir                string
+}
+
+func input(ctx context.Context) (*dataIn, error) {
+	var err error
+	data := &dataIn{}
+
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	data.timeout = viper.GetDuration("timeout")
+
+	data.verbose = viper.GetBool("verbose")
+
+	// Format.
+	if viper.GetString("format") != "keystore" {
+		return nil, fmt.Errorf("unsupported export format %q", viper.GetString("format"))
+	}
+
+	// Key derivation function.
+	switch viper.GetString("kdf") {
+	case "scrypt", "pbkdf2":
+		data.encryptor = keystorev4.New(keystorev4.WithCipher(viper.GetString("kdf")))
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %q", viper.GetString("kdf"))
+	}
+
+	// Keystore passphrase.
+	data.keystorePassphrase = viper.GetString("keystore-passphrase")
+	if data.keystorePassphrase == "" {
+		return nil, errors.New("keystore-passphrase is required")
+	}
+	if !util.AcceptablePassphrase(data.keystorePassphrase) {
+		return nil, errors.New("supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	// Directory.
+	data.dir = viper.GetString("dir")
+	if data.dir == "" {
+		return nil, errors.New("dir is required")
+	}
+
+	// Accounts.
+	if viper.GetString("account") == "" {
+		return nil, errors.New("account is required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, data.timeout)
+	defer cancel()
+	wallet, accounts, err := util.WalletAndAccountsFromPath(ctx, viper.GetString("account"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts")
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("no matching accounts")
+	}
+	data.walletName = wallet.Name()
+	data.accounts = accounts
+
+	// Passphrases.
+	data.passphrases = util.GetPassphrases()
+
+	// Approvals.
+	data.approvals = viper.GetStringSlice("approvals")
+
+	return data, nil
+}
@@ -0,0 +1,154 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Interop 0", "Interop 1"} {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(), name, key.Marshal(), []byte("pass"))
+		require.NoError(t, err)
+	}
+
+	good := map[string]interface{}{
+		"timeout":             "5s",
+		"account":             "Test wallet/Interop.*",
+		"passphrase":          "pass",
+		"format":              "keystore",
+		"kdf":                 "scrypt",
+		"keystore-passphrase": "ce%NohGhah4ye5ra",
+		"dir":                 "validator_keys",
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		vars := make(map[string]interface{}, len(good))
+		for k, v := range good {
+			vars[k] = v
+		}
+		if value == nil {
+			delete(vars, key)
+		} else {
+			vars[key] = value
+		}
+		return vars
+	}
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		accounts int
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: with("timeout", nil),
+			err:  "timeout is required",
+		},
+		{
+			name: "FormatInvalid",
+			vars: with("format", "json"),
+			err:  `unsupported export format "json"`,
+		},
+		{
+			name: "KDFInvalid",
+			vars: with("kdf", "argon2"),
+			err:  `unsupported key derivation function "argon2"`,
+		},
+		{
+			name: "KeystorePassphraseMissing",
+			vars: with("keystore-passphrase", nil),
+			err:  "keystore-passphrase is required",
+		},
+		{
+			name: "KeystorePassphraseWeak",
+			vars: with("keystore-passphrase", "pass"),
+			err:  "supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "DirMissing",
+			vars: with("dir", nil),
+			err:  "dir is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: with("account", nil),
+			err:  "account is required",
+		},
+		{
+			name: "WalletUnknown",
+			vars: with("account", "Unknown/Interop 0"),
+			err:  "failed to obtain accounts: failed to open wallet for account: wallet not found",
+		},
+		{
+			name: "AccountsNotFound",
+			vars: with("account", "Test wallet/Other.*"),
+			err:  "no matching accounts",
+		},
+		{
+			name:     "Good",
+			vars:     good,
+			accounts: 2,
+		},
+		{
+			name:     "GoodSingle",
+			vars:     with("account", "Test wallet/Interop 1"),
+			accounts: 1,
+		},
+		{
+			name:     "GoodWallet",
+			vars:     with("account", "Test wallet"),
+			accounts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, 5*time.Second, res.timeout)
+				require.Equal(t, "Test wallet", res.walletName)
+				require.Len(t, res.accounts, test.accounts)
+				require.Equal(t, []string{"pass"}, res.passphrases)
+			}
+		})
+	}
+}
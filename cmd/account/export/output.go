@@ -0,0 +1,50 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type dataOut struct {
+	verbose bool
+	exports []*export
+}
+
+// export is the export of a single account.
+type export struct {
+	account string
+	file    string
+}
+
+func output(_ context.Context, data *dataOut) (string, error) {
+	if data == nil {
+		return "", errors.New("no data")
+	}
+
+	if !data.verbose {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	for _, export := range data.exports {
+		builder.WriteString(fmt.Sprintf("Exported %s to %s\n", export.account, export.file))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// fileNameUnsafe matches characters that should not be used in file names.
+var fileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if len(data.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+
+	if err := os.MkdirAll(data.dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create directory")
+	}
+
+	// All keystores in an export share a timestamp, as with the deposit CLI.
+	timestamp := time.Now().Unix()
+
+	results := &dataOut{
+		verbose: data.verbose,
+		exports: make([]*export, 0, len(data.accounts)),
+	}
+	files := make(map[string]string, len(data.accounts))
+	for _, account := range data.accounts {
+		accountName := fmt.Sprintf("%s/%s", data.walletName, account.Name())
+		file := filepath.Join(data.dir, keystoreFileName(account, timestamp))
+		if existing, exists := files[file]; exists {
+			return nil, fmt.Errorf("accounts %s and %s would both be exported to %s", existing, accountName, file)
+		}
+		files[file] = accountName
+
+		if err := exportAccount(ctx, data, account, accountName, file); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to export %s", accountName))
+		}
+		results.exports = append(results.exports, &export{
+			account: accountName,
+			file:    file,
+		})
+	}
+
+	return results, nil
+}
+
+// keystoreFileName returns the name of the keystore file for an account.
+// Accounts with a path are named as by the deposit CLI.
+func keystoreFileName(account e2wtypes.Account, timestamp int64) string {
+	name := fileNameUnsafe.ReplaceAllString(account.Name(), "_")
+	if pathProvider, isProvider := account.(e2wtypes.AccountPathProvider); isProvider && pathProvider.Path() != "" {
+		name = strings.ReplaceAll(pathProvider.Path(), "/", "_")
+	}
+
+	return fmt.Sprintf("keystore-%s-%d.json", name, timestamp)
+}
+
+// exportAccount exports a single account as a keystore.
+func exportAccount(ctx context.Context,
+	data *dataIn,
+	account e2wtypes.Account,
+	accountName string,
+	file string,
+) error {
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain public key")
+	}
+	if err := util.CheckCompositeApprovals(ctx, util.CompositeOperationKey, accountName, pubKey.Marshal(), data.approvals); err != nil {
+		return err
+	}
+
+	ks, err := util.NewAccountKeystore(ctx, account, accountName, data.passphrases, data.encryptor, data.keystorePassphrase)
+	if err != nil {
+		return err
+	}
+	out, err := json.Marshal(ks)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal keystore JSON")
+	}
+
+	// Do not overwrite existing keystores.
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to create %s", file))
+	}
+	if _, err := f.Write(out); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", file))
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", file))
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountexport
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	interop1, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 1",
+		hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "PassphrasesMissing",
+			dataIn: &dataIn{
+				timeout:  5 * time.Second,
+				accounts: []e2wtypes.Account{interop0},
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "PassphraseIncorrect",
+			dataIn: &dataIn{
+				timeout:            5 * time.Second,
+				walletName:         "Test",
+				accounts:           []e2wtypes.Account{interop0},
+				passphrases:        []string{"wrong"},
+				keystorePassphrase: "keystore secret",
+				encryptor:          keystorev4.New(keystorev4.WithCost(t, 10)),
+			},
+			err: "failed to export Test/Interop 0: failed to unlock account",
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				timeout:            5 * time.Second,
+				walletName:         "Test",
+				accounts:           []e2wtypes.Account{interop0, interop1},
+				passphrases:        []string{"ce%NohGhah4ye5ra", "pass"},
+				keystorePassphrase: "keystore secret",
+				encryptor:          keystorev4.New(keystorev4.WithCipher("scrypt"), keystorev4.WithCost(t, 10)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.dataIn != nil {
+				test.dataIn.dir = filepath.Join(t.TempDir(), "validator_keys")
+			}
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.exports, len(test.dataIn.accounts))
+
+			for i, account := range test.dataIn.accounts {
+				require.Equal(t, "Test/"+account.Name(), res.exports[i].account)
+				data, err := os.ReadFile(res.exports[i].file)
+				require.NoError(t, err)
+				ks := &util.Keystore{}
+				require.NoError(t, json.Unmarshal(data, ks))
+				require.Equal(t, uint(4), ks.Version)
+				require.Equal(t, "Test/"+account.Name(), ks.Description)
+				require.Equal(t, "scrypt", ks.Crypto["kdf"].(map[string]any)["function"])
+
+				// Decrypted key must match the account.
+				secret, err := keystorev4.New().Decrypt(ks.Crypto, "keystore secret")
+				require.NoError(t, err)
+				key, err := e2types.BLSPrivateKeyFromBytes(secret)
+				require.NoError(t, err)
+				pubKey := account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal()
+				require.Equal(t, pubKey, key.PublicKey().Marshal())
+				require.Equal(t, hex.EncodeToString(pubKey), ks.PubKey)
+			}
+		})
+	}
+}
+
+func TestProcessComposite(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("composites", map[string]interface{}{
+		"ops": map[string]interface{}{
+			"threshold": 1,
+			"accounts":  []string{"Test/*"},
+			"approvers": []string{"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+		},
+	})
+
+	dir := t.TempDir()
+	_, err = process(context.Background(), &dataIn{
+		timeout:            5 * time.Second,
+		walletName:         "Test",
+		accounts:           []e2wtypes.Account{interop0},
+		passphrases:        []string{"pass"},
+		keystorePassphrase: "keystore secret",
+		encryptor:          keystorev4.New(keystorev4.WithCost(t, 10)),
+		dir:                dir,
+	})
+	require.EqualError(t, err, `failed to export Test/Interop 0: key of Test/Interop 0 requires 1 approvals from composite "ops" but 0 obtained`)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestKeystoreFileName(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "keystore-Interop_0-1700000000.json", keystoreFileName(interop0, 1700000000))
+}
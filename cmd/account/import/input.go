@@ -16,7 +16,10 @@ package accountimport
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -36,6 +39,28 @@ type dataIn struct {
 	walletPassphrase   string
 	keystore           []byte
 	keystorePassphrase []byte
+	// For watch-only imports from Dirk.
+	fromDirk    string
+	remote      string
+	dirkAccount e2wtypes.Account
+	// For batch imports from a directory of keystores.
+	keystoreDir string
+	keystores   []*keystoreFile
+	dryRun      bool
+}
+
+// keystoreFile is an EIP-2335 keystore file to be imported.
+type keystoreFile struct {
+	// file is the name of the file, without its directory.
+	file string
+	// accountName is the name of the account to create.
+	accountName string
+	data        []byte
+	// passphrase is the passphrase of the keystore, or nil if its passphrase
+	// file could not be read.
+	passphrase []byte
+	// passphraseErr is the reason that the passphrase could not be obtained.
+	passphraseErr string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -47,6 +72,10 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.timeout = viper.GetDuration("timeout")
 
+	if viper.GetString("keystore-dir") != "" {
+		return inputFromKeystoreDir(ctx, data)
+	}
+
 	// Account name.
 	if viper.GetString("account") == "" {
 		return nil, errors.New("account is required")
@@ -59,6 +88,10 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("account name is required")
 	}
 
+	if viper.GetString("from-dirk") != "" {
+		return inputFromDirk(ctx, data)
+	}
+
 	// Wallet.
 	ctx, cancel := context.WithTimeout(ctx, data.timeout)
 	defer cancel()
@@ -105,6 +138,183 @@ func input(ctx context.Context) (*dataIn, error) {
 	return data, nil
 }
 
+// inputFromDirk obtains input for a watch-only import of an account held by Dirk.
+func inputFromDirk(ctx context.Context, data *dataIn) (*dataIn, error) {
+	var err error
+
+	if viper.GetString("key") != "" || viper.GetString("keystore") != "" {
+		return nil, errors.New("only one of key, keystore and from-dirk is required")
+	}
+	data.fromDirk = viper.GetString("from-dirk")
+	_, dirkAccountName, err := e2wallet.WalletAndAccountNames(data.fromDirk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain Dirk account name")
+	}
+	if dirkAccountName == "" {
+		return nil, errors.New("Dirk account name is required")
+	}
+	data.remote = viper.GetString("remote")
+	if data.remote == "" {
+		return nil, errors.New("remote is required to import from Dirk")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.timeout)
+	defer cancel()
+
+	// The local store is not set up when using a remote account manager,
+	// but it is the destination for the import.
+	if err := util.SetupLocalStore(); err != nil {
+		return nil, errors.Wrap(err, "failed to set up local store")
+	}
+	data.wallet, err = util.LocalWalletFromPath(ctx, viper.GetString("account"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	dirkWallet, err := util.RemoteWalletFromPath(ctx, data.fromDirk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain Dirk wallet")
+	}
+	accountByNameProvider, isAccountByNameProvider := dirkWallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isAccountByNameProvider {
+		return nil, errors.New("Dirk wallet cannot obtain accounts by name")
+	}
+	data.dirkAccount, err = accountByNameProvider.AccountByName(ctx, dirkAccountName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain Dirk account")
+	}
+
+	return data, nil
+}
+
+// inputFromKeystoreDir obtains input for a batch import of the keystores in a
+// directory.
+func inputFromKeystoreDir(ctx context.Context, data *dataIn) (*dataIn, error) {
+	var err error
+
+	if viper.GetString("key") != "" || viper.GetString("keystore") != "" || viper.GetString("from-dirk") != "" {
+		return nil, errors.New("only one of key, keystore, keystore-dir and from-dirk is required")
+	}
+	data.keystoreDir = viper.GetString("keystore-dir")
+	data.dryRun = viper.GetBool("dry-run")
+
+	// Wallet.
+	ctx, cancel := context.WithTimeout(ctx, data.timeout)
+	defer cancel()
+	data.wallet, err = util.WalletFromInput(ctx)
+	cancel()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	// Passphrase.
+	data.passphrase, err = util.GetOptionalPassphrase()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain passphrase")
+	}
+
+	// Wallet passphrase.
+	data.walletPassphrase = util.GetWalletPassphrase()
+
+	// Keystore passphrases.
+	passphrase := viper.GetString("keystore-passphrase")
+	passphraseFile := viper.GetString("keystore-passphrase-file")
+	if passphrase == "" && passphraseFile == "" {
+		return nil, errors.New("must supply keystore passphrase with keystore-passphrase or keystore-passphrase-file when supplying keystore-dir")
+	}
+	if passphrase != "" && passphraseFile != "" {
+		return nil, errors.New("only one of keystore-passphrase and keystore-passphrase-file is required")
+	}
+	passphraseDir := ""
+	if passphraseFile != "" {
+		info, err := os.Stat(passphraseFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to access keystore passphrase file")
+		}
+		if info.IsDir() {
+			passphraseDir = passphraseFile
+		} else {
+			passphrase, err = readPassphraseFile(passphraseFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	data.keystores, err = obtainKeystores(data.keystoreDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, keystore := range data.keystores {
+		if passphraseDir == "" {
+			keystore.passphrase = []byte(passphrase)
+			continue
+		}
+		// Per-keystore passphrases are in files with the same name as the
+		// keystore, but with a .txt extension.
+		filePassphrase, err := readPassphraseFile(filepath.Join(passphraseDir, keystore.accountName+".txt"))
+		if err != nil {
+			keystore.passphraseErr = err.Error()
+			continue
+		}
+		keystore.passphrase = []byte(filePassphrase)
+	}
+
+	return data, nil
+}
+
+// obtainKeystores obtains the EIP-2335 keystores in a directory.  JSON files
+// that are not keystores, such as deposit data files, are ignored.
+func obtainKeystores(dir string) ([]*keystoreFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keystore directory")
+	}
+
+	keystores := make([]*keystoreFile, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		fileData, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", entry.Name()))
+		}
+		keystore := make(map[string]any)
+		if err := json.Unmarshal(fileData, &keystore); err != nil {
+			continue
+		}
+		if _, exists := keystore["crypto"]; !exists {
+			continue
+		}
+		keystores = append(keystores, &keystoreFile{
+			file:        entry.Name(),
+			accountName: strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			data:        fileData,
+		})
+	}
+	if len(keystores) == 0 {
+		return nil, fmt.Errorf("no keystores found in %s", dir)
+	}
+
+	return keystores, nil
+}
+
+// readPassphraseFile reads a passphrase from a file, removing any trailing
+// line ending.
+func readPassphraseFile(path string) (string, error) {
+	fileData, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read keystore passphrase file")
+	}
+	passphrase := strings.TrimRight(string(fileData), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("keystore passphrase file %s is empty", path)
+	}
+
+	return passphrase, nil
+}
+
 // obtainKeystore obtains keystore from an input, could be JSON itself or a path to JSON.
 func obtainKeystore(input string) ([]byte, error) {
 	var err error
@@ -15,6 +15,10 @@ package accountimport
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -134,6 +138,35 @@ func TestInput(t *testing.T) {
 			},
 			err: "must supply keystore passphrase with keystore-passphrase when supplying keystore",
 		},
+		{
+			name: "FromDirkAndKey",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"account":   "Test wallet/Test account",
+				"key":       "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"from-dirk": "Dirk wallet/Dirk account",
+			},
+			err: "only one of key, keystore and from-dirk is required",
+		},
+		{
+			name: "FromDirkAccountMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"account":   "Test wallet/Test account",
+				"from-dirk": "Dirk wallet",
+				"remote":    "localhost:9091",
+			},
+			err: "Dirk account name is required",
+		},
+		{
+			name: "FromDirkRemoteMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"account":   "Test wallet/Test account",
+				"from-dirk": "Dirk wallet/Dirk account",
+			},
+			err: "remote is required to import from Dirk",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -170,3 +203,173 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+// writeTestKeystore writes an EIP-2335 keystore for the given key to a file.
+func writeTestKeystore(t *testing.T, dir string, name string, key []byte, passphrase string) {
+	t.Helper()
+
+	privKey, err := e2types.BLSPrivateKeyFromBytes(key)
+	require.NoError(t, err)
+	crypto, err := keystorev4.New(keystorev4.WithCost(t, 10)).Encrypt(key, passphrase)
+	require.NoError(t, err)
+	data, err := json.Marshal(map[string]any{
+		"crypto":  crypto,
+		"pubkey":  fmt.Sprintf("%x", privKey.PublicKey().Marshal()),
+		"path":    "m/12381/3600/0/0/0",
+		"uuid":    "6b1e9c1e-2f6a-4b8e-9c1e-2f6a4b8e9c1e",
+		"version": 4,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), data, 0o600))
+}
+
+func TestInputKeystoreDir(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	_, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+	keystoreDir := filepath.Join(base, "keys")
+	require.NoError(t, os.Mkdir(keystoreDir, 0o700))
+	writeTestKeystore(t, keystoreDir, "keystore-1", hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), "pass1")
+	writeTestKeystore(t, keystoreDir, "keystore-2", hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), "pass2")
+	require.NoError(t, os.WriteFile(filepath.Join(keystoreDir, "deposit_data-1.json"), []byte(`[{"pubkey":"00"}]`), 0o600))
+	emptyDir := filepath.Join(base, "empty")
+	require.NoError(t, os.Mkdir(emptyDir, 0o700))
+	passphraseDir := filepath.Join(base, "passphrases")
+	require.NoError(t, os.Mkdir(passphraseDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(passphraseDir, "keystore-1.txt"), []byte("pass1\n"), 0o600))
+	passphraseFile := filepath.Join(base, "passphrase.txt")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("shared\r\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		passphrases []string
+		errs        []string
+		err         string
+	}{
+		{
+			name: "WithKey",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"wallet":       "Test wallet",
+				"keystore-dir": keystoreDir,
+				"key":          "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+			},
+			err: "only one of key, keystore, keystore-dir and from-dirk is required",
+		},
+		{
+			name: "WalletUnknown",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"wallet":              "Unknown",
+				"keystore-dir":        keystoreDir,
+				"keystore-passphrase": "shared",
+			},
+			err: "failed to obtain wallet: wallet not found",
+		},
+		{
+			name: "KeystorePassphraseMissing",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"wallet":       "Test wallet",
+				"keystore-dir": keystoreDir,
+			},
+			err: "must supply keystore passphrase with keystore-passphrase or keystore-passphrase-file when supplying keystore-dir",
+		},
+		{
+			name: "KeystorePassphraseMultiple",
+			vars: map[string]interface{}{
+				"timeout":                  "5s",
+				"wallet":                   "Test wallet",
+				"keystore-dir":             keystoreDir,
+				"keystore-passphrase":      "shared",
+				"keystore-passphrase-file": passphraseFile,
+			},
+			err: "only one of keystore-passphrase and keystore-passphrase-file is required",
+		},
+		{
+			name: "KeystoreDirMissing",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"wallet":              "Test wallet",
+				"keystore-dir":        filepath.Join(base, "missing"),
+				"keystore-passphrase": "shared",
+			},
+			err: fmt.Sprintf("failed to read keystore directory: open %s: no such file or directory", filepath.Join(base, "missing")),
+		},
+		{
+			name: "KeystoreDirEmpty",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"wallet":              "Test wallet",
+				"keystore-dir":        emptyDir,
+				"keystore-passphrase": "shared",
+			},
+			err: fmt.Sprintf("no keystores found in %s", emptyDir),
+		},
+		{
+			name: "SharedPassphrase",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"wallet":              "Test wallet",
+				"keystore-dir":        keystoreDir,
+				"keystore-passphrase": "shared",
+			},
+			passphrases: []string{"shared", "shared"},
+			errs:        []string{"", ""},
+		},
+		{
+			name: "SharedPassphraseFile",
+			vars: map[string]interface{}{
+				"timeout":                  "5s",
+				"wallet":                   "Test wallet",
+				"keystore-dir":             keystoreDir,
+				"keystore-passphrase-file": passphraseFile,
+			},
+			passphrases: []string{"shared", "shared"},
+			errs:        []string{"", ""},
+		},
+		{
+			name: "PassphraseDir",
+			vars: map[string]interface{}{
+				"timeout":                  "5s",
+				"wallet":                   "Test wallet",
+				"keystore-dir":             keystoreDir,
+				"keystore-passphrase-file": passphraseDir,
+			},
+			passphrases: []string{"pass1", ""},
+			errs: []string{
+				"",
+				fmt.Sprintf("failed to read keystore passphrase file: open %s: no such file or directory", filepath.Join(passphraseDir, "keystore-2.txt")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := input(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.keystores, len(test.passphrases))
+			for i, keystore := range res.keystores {
+				require.Equal(t, fmt.Sprintf("keystore-%d", i+1), keystore.accountName)
+				require.Equal(t, test.passphrases[i], string(keystore.passphrase))
+				require.Equal(t, test.errs[i], keystore.passphraseErr)
+			}
+		})
+	}
+}
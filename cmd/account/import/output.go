@@ -16,6 +16,7 @@ package accountimport
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -23,12 +24,36 @@ import (
 
 type dataOut struct {
 	account e2wtypes.Account
+	// For batch imports from a directory of keystores.
+	dryRun          bool
+	keystoreResults []*keystoreResult
+}
+
+// keystoreStatus is the result of importing a keystore.
+type keystoreStatus int
+
+const (
+	keystoreFailed keystoreStatus = iota
+	keystoreSkipped
+	keystoreImported
+)
+
+// keystoreResult is the result of importing a keystore in a batch import.
+type keystoreResult struct {
+	file        string
+	accountName string
+	pubKey      string
+	status      keystoreStatus
+	reason      string
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
+	if data.keystoreResults != nil {
+		return outputKeystoreResults(data), nil
+	}
 	if data.account == nil {
 		return "", errors.New("no account")
 	}
@@ -43,3 +68,49 @@ func output(_ context.Context, data *dataOut) (string, error) {
 
 	return "", errors.New("no public key available")
 }
+
+// outputKeystoreResults outputs the result of each keystore in a batch import,
+// followed by a summary.
+func outputKeystoreResults(data *dataOut) string {
+	builder := strings.Builder{}
+
+	imported := 0
+	skipped := 0
+	failed := 0
+	for _, res := range data.keystoreResults {
+		switch res.status {
+		case keystoreImported:
+			imported++
+			if data.dryRun {
+				builder.WriteString(fmt.Sprintf("%s: would import as %q (%s)\n", res.file, res.accountName, res.pubKey))
+			} else {
+				builder.WriteString(fmt.Sprintf("%s: imported as %q (%s)\n", res.file, res.accountName, res.pubKey))
+			}
+		case keystoreSkipped:
+			skipped++
+			builder.WriteString(fmt.Sprintf("%s: skipped: %s\n", res.file, res.reason))
+		default:
+			failed++
+			builder.WriteString(fmt.Sprintf("%s: failed: %s\n", res.file, res.reason))
+		}
+	}
+	if data.dryRun {
+		builder.WriteString(fmt.Sprintf("Would import %d, skipped %d, failed %d", imported, skipped, failed))
+	} else {
+		builder.WriteString(fmt.Sprintf("Imported %d, skipped %d, failed %d", imported, skipped, failed))
+	}
+
+	return builder.String()
+}
+
+// keystoreFailures returns the number of keystores that failed to import.
+func keystoreFailures(data *dataOut) int {
+	failures := 0
+	for _, res := range data.keystoreResults {
+		if res.status == keystoreFailed {
+			failures++
+		}
+	}
+
+	return failures
+}
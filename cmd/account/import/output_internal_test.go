@@ -111,3 +111,48 @@ func TestOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputKeystoreResults(t *testing.T) {
+	keystoreResults := []*keystoreResult{
+		{file: "keystore-1.json", accountName: "keystore-1", pubKey: "0xa99a", status: keystoreImported},
+		{file: "keystore-2.json", accountName: "keystore-2", status: keystoreSkipped, reason: "account name already exists"},
+		{file: "keystore-3.json", accountName: "keystore-3", status: keystoreFailed, reason: "failed to decrypt keystore: invalid checksum"},
+	}
+
+	tests := []struct {
+		name    string
+		dataOut *dataOut
+		res     string
+	}{
+		{
+			name: "Good",
+			dataOut: &dataOut{
+				keystoreResults: keystoreResults,
+			},
+			res: `keystore-1.json: imported as "keystore-1" (0xa99a)
+keystore-2.json: skipped: account name already exists
+keystore-3.json: failed: failed to decrypt keystore: invalid checksum
+Imported 1, skipped 1, failed 1`,
+		},
+		{
+			name: "DryRun",
+			dataOut: &dataOut{
+				dryRun:          true,
+				keystoreResults: keystoreResults,
+			},
+			res: `keystore-1.json: would import as "keystore-1" (0xa99a)
+keystore-2.json: skipped: account name already exists
+keystore-3.json: failed: failed to decrypt keystore: invalid checksum
+Would import 1, skipped 1, failed 1`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output(context.Background(), test.dataOut)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+			require.Equal(t, 1, keystoreFailures(test.dataOut))
+		})
+	}
+}
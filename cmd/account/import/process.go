@@ -17,10 +17,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-ecodec"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
 	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
@@ -31,6 +34,10 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
 	}
+	if data.dirkAccount != nil {
+		// Watch-only accounts hold no private key, so need no passphrase.
+		return processFromDirk(ctx, data)
+	}
 	if data.passphrase == "" {
 		return nil, errors.New("passphrase is required")
 	}
@@ -49,6 +56,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		}()
 	}
 
+	if data.keystoreDir != "" {
+		return processFromKeystoreDir(ctx, data)
+	}
 	if len(data.key) > 0 {
 		return processFromKey(ctx, data)
 	}
@@ -119,3 +129,145 @@ func processFromKeystore(ctx context.Context, data *dataIn) (*dataOut, error) {
 	// We have the key from the keystore; import it.
 	return processFromKey(ctx, data)
 }
+
+func processFromKeystoreDir(ctx context.Context, data *dataIn) (*dataOut, error) {
+	importer, isImporter := data.wallet.(e2wtypes.WalletAccountImporter)
+	if !isImporter {
+		return nil, fmt.Errorf("%s wallets do not support importing accounts", data.wallet.Type())
+	}
+
+	// Obtain the existing accounts, to avoid importing duplicates.
+	names := make(map[string]bool)
+	pubKeys := make(map[string]string)
+	for account := range data.wallet.Accounts(ctx) {
+		names[account.Name()] = true
+		if pubKeyProvider, isProvider := account.(e2wtypes.AccountPublicKeyProvider); isProvider {
+			pubKeys[fmt.Sprintf("%#x", pubKeyProvider.PublicKey().Marshal())] = account.Name()
+		}
+	}
+
+	results := &dataOut{
+		dryRun:          data.dryRun,
+		keystoreResults: make([]*keystoreResult, 0, len(data.keystores)),
+	}
+	for _, keystore := range data.keystores {
+		res := &keystoreResult{
+			file:        keystore.file,
+			accountName: keystore.accountName,
+		}
+		results.keystoreResults = append(results.keystoreResults, res)
+
+		if names[keystore.accountName] {
+			res.status = keystoreSkipped
+			res.reason = "account name already exists"
+			continue
+		}
+		if keystore.passphrase == nil {
+			res.status = keystoreFailed
+			res.reason = keystore.passphraseErr
+			continue
+		}
+		key, err := decryptKeystore(keystore)
+		if err != nil {
+			res.status = keystoreFailed
+			res.reason = err.Error()
+			continue
+		}
+		res.pubKey = fmt.Sprintf("%#x", key.PublicKey().Marshal())
+
+		if existing, exists := pubKeys[res.pubKey]; exists {
+			res.status = keystoreSkipped
+			res.reason = fmt.Sprintf("public key already present as account %q", existing)
+			continue
+		}
+
+		if !data.dryRun {
+			if _, err := importer.ImportAccount(ctx, keystore.accountName, key.Marshal(), []byte(data.passphrase)); err != nil {
+				res.status = keystoreFailed
+				res.reason = errors.Wrap(err, "failed to import account").Error()
+				continue
+			}
+		}
+		res.status = keystoreImported
+		names[keystore.accountName] = true
+		pubKeys[res.pubKey] = keystore.accountName
+	}
+
+	return results, nil
+}
+
+// decryptKeystore decrypts an EIP-2335 keystore, checking that the private key
+// matches the public key in the keystore if present.
+func decryptKeystore(keystore *keystoreFile) (*e2types.BLSPrivateKey, error) {
+	ks := make(map[string]any)
+	if err := json.Unmarshal(keystore.data, &ks); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal keystore")
+	}
+	crypto, isMap := ks["crypto"].(map[string]any)
+	if !isMap {
+		return nil, errors.New("keystore crypto is invalid")
+	}
+	secret, err := keystorev4.New().Decrypt(crypto, string(keystore.passphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt keystore")
+	}
+	key, err := e2types.BLSPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid private key")
+	}
+
+	if pubKey, isString := ks["pubkey"].(string); isString && pubKey != "" {
+		if !strings.EqualFold(strings.TrimPrefix(pubKey, "0x"), fmt.Sprintf("%x", key.PublicKey().Marshal())) {
+			return nil, errors.New("private key does not match keystore public key")
+		}
+	}
+
+	return key, nil
+}
+
+func processFromDirk(ctx context.Context, data *dataIn) (*dataOut, error) {
+	pubKey, err := util.BestPublicKey(data.dirkAccount)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain Dirk account public key")
+	}
+
+	metadata := &util.WatchOnlyMetadata{
+		Source: "dirk",
+		Details: map[string]string{
+			"remote":  data.remote,
+			"account": data.fromDirk,
+		},
+	}
+	if thresholdProvider, isProvider := data.dirkAccount.(e2wtypes.AccountSigningThresholdProvider); isProvider {
+		metadata.Details["signing_threshold"] = fmt.Sprintf("%d", thresholdProvider.SigningThreshold())
+	}
+	if participantsProvider, isProvider := data.dirkAccount.(e2wtypes.AccountParticipantsProvider); isProvider {
+		metadata.Details["participants"] = fmt.Sprintf("%d", len(participantsProvider.Participants()))
+	}
+
+	if err := util.ImportWatchOnlyAccount(ctx, data.wallet, data.accountName, pubKey.Marshal(), metadata); err != nil {
+		return nil, errors.Wrap(err, "failed to import watch-only account")
+	}
+
+	// Reopen the wallet to pick up the new account.
+	storeProvider, isStoreProvider := data.wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not provide its store")
+	}
+	wallet, err := e2wallet.OpenWallet(data.wallet.Name(), e2wallet.WithStore(storeProvider.Store()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reopen wallet")
+	}
+	accountByNameProvider, isAccountByNameProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isAccountByNameProvider {
+		return nil, errors.New("wallet cannot obtain accounts by name")
+	}
+	account, err := accountByNameProvider.AccountByName(ctx, data.accountName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain imported account")
+	}
+
+	return &dataOut{
+		account: account,
+	}, nil
+}
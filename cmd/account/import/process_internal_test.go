@@ -14,7 +14,9 @@
 package accountimport
 
 import (
+	"bytes"
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -23,6 +25,7 @@ import (
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
 	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestProcess(t *testing.T) {
@@ -35,6 +38,21 @@ func TestProcess(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	// An account standing in for one held by Dirk.
+	dirkWallet, err := nd.CreateWallet(context.Background(),
+		"Dirk",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, dirkWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	dirkAccount, err := dirkWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Dirk account",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name   string
 		dataIn *dataIn
@@ -79,6 +97,29 @@ func TestProcess(t *testing.T) {
 				key:              hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
 			},
 		},
+		{
+			name: "FromDirk",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      testNDWallet,
+				accountName: "Watch only",
+				fromDirk:    "Dirk/Dirk account",
+				remote:      "localhost:9091",
+				dirkAccount: dirkAccount,
+			},
+		},
+		{
+			name: "FromDirkDuplicate",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      testNDWallet,
+				accountName: "Watch only",
+				fromDirk:    "Dirk/Dirk account",
+				remote:      "localhost:9091",
+				dirkAccount: dirkAccount,
+			},
+			err: `failed to import watch-only account: account with name "Watch only" already exists`,
+		},
 	}
 
 	for _, test := range tests {
@@ -93,3 +134,107 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessKeystoreDir(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	wallet, err := nd.CreateWallet(context.Background(), "Test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	_, err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Existing",
+		hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+	writeTestKeystore(t, base, "keystore-1", hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), "pass1")
+	writeTestKeystore(t, base, "keystore-2", hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), "pass2")
+	keystores, err := obtainKeystores(base)
+	require.NoError(t, err)
+	keystoreData := func(i int) []byte {
+		return keystores[i].data
+	}
+
+	tests := []struct {
+		name      string
+		keystores []*keystoreFile
+		dryRun    bool
+		statuses  []keystoreStatus
+		reasons   []string
+	}{
+		{
+			name: "PassphraseMissing",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: keystoreData(0), passphraseErr: "no passphrase"},
+			},
+			statuses: []keystoreStatus{keystoreFailed},
+			reasons:  []string{"no passphrase"},
+		},
+		{
+			name: "PassphraseIncorrect",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: keystoreData(0), passphrase: []byte("wrong")},
+			},
+			statuses: []keystoreStatus{keystoreFailed},
+			reasons:  []string{"failed to decrypt keystore: invalid checksum"},
+		},
+		{
+			name: "PublicKeyMismatch",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: bytes.Replace(keystoreData(0), []byte(`"pubkey":"`), []byte(`"pubkey":"00`), 1), passphrase: []byte("pass1")},
+			},
+			statuses: []keystoreStatus{keystoreFailed},
+			reasons:  []string{"private key does not match keystore public key"},
+		},
+		{
+			name: "DryRun",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: keystoreData(0), passphrase: []byte("pass1")},
+				{file: "keystore-2.json", accountName: "keystore-2", data: keystoreData(1), passphrase: []byte("pass2")},
+			},
+			dryRun:   true,
+			statuses: []keystoreStatus{keystoreImported, keystoreSkipped},
+			reasons:  []string{"", `public key already present as account "Existing"`},
+		},
+		{
+			name: "Good",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: keystoreData(0), passphrase: []byte("pass1")},
+				{file: "keystore-2.json", accountName: "keystore-2", data: keystoreData(1), passphrase: []byte("pass2")},
+			},
+			statuses: []keystoreStatus{keystoreImported, keystoreSkipped},
+			reasons:  []string{"", `public key already present as account "Existing"`},
+		},
+		{
+			name: "Repeat",
+			keystores: []*keystoreFile{
+				{file: "keystore-1.json", accountName: "keystore-1", data: keystoreData(0), passphrase: []byte("pass1")},
+			},
+			statuses: []keystoreStatus{keystoreSkipped},
+			reasons:  []string{"account name already exists"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      wallet,
+				passphrase:  "ce%NohGhah4ye5ra",
+				keystoreDir: base,
+				keystores:   test.keystores,
+				dryRun:      test.dryRun,
+			})
+			require.NoError(t, err)
+			require.Len(t, res.keystoreResults, len(test.statuses))
+			for i, keystoreResult := range res.keystoreResults {
+				require.Equal(t, test.statuses[i], keystoreResult.status)
+				require.Equal(t, test.reasons[i], keystoreResult.reason)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ package accountimport
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -37,6 +38,11 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
+	if dataOut.keystoreResults != nil {
+		// Batch imports always report their results.
+		return outputKeystoreBatch(ctx, dataOut)
+	}
+
 	if !viper.GetBool("verbose") {
 		return "", nil
 	}
@@ -48,3 +54,26 @@ func Run(cmd *cobra.Command) (string, error) {
 
 	return results, nil
 }
+
+// outputKeystoreBatch outputs the results of a batch import, failing if any
+// keystores failed to import.
+func outputKeystoreBatch(ctx context.Context, dataOut *dataOut) (string, error) {
+	failures := keystoreFailures(dataOut)
+
+	if !viper.GetBool("quiet") {
+		results, err := output(ctx, dataOut)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to obtain output")
+		}
+		if failures == 0 {
+			return results, nil
+		}
+		fmt.Println(results)
+	}
+
+	if failures > 0 {
+		return "", fmt.Errorf("%d keystores failed to import", failures)
+	}
+
+	return "", nil
+}
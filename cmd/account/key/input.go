@@ -15,6 +15,7 @@ package accountkey
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,7 +27,9 @@ import (
 type dataIn struct {
 	timeout     time.Duration
 	account     e2wtypes.Account
+	accountName string
 	passphrases []string
+	approvals   []string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -39,13 +42,18 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.timeout = viper.GetDuration("timeout")
 
 	// Account.
-	_, data.account, err = util.WalletAndAccountFromInput(ctx)
+	wallet, account, err := util.WalletAndAccountFromInput(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain acount")
 	}
+	data.account = account
+	data.accountName = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
 
 	// Passphrases.
 	data.passphrases = util.GetPassphrases()
 
+	// Approvals.
+	data.approvals = viper.GetStringSlice("approvals")
+
 	return data, nil
 }
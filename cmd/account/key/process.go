@@ -31,6 +31,14 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 
 	results := &dataOut{}
 
+	pubKey, err := util.BestPublicKey(data.account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public key")
+	}
+	if err := util.CheckCompositeApprovals(ctx, util.CompositeOperationKey, data.accountName, pubKey.Marshal(), data.approvals); err != nil {
+		return nil, err
+	}
+
 	privateKeyProvider, isPrivateKeyProvider := data.account.(e2wtypes.AccountPrivateKeyProvider)
 	if !isPrivateKeyProvider {
 		return nil, errors.New("account does not provide its private key")
@@ -42,6 +50,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 			return nil, errors.Wrap(err, "failed to find out if account is locked")
 		}
 		if !unlocked {
+			if err := util.CheckNotWatchOnly(data.account); err != nil {
+				return nil, err
+			}
 			for _, passphrase := range data.passphrases {
 				err = locker.Unlock(ctx, []byte(passphrase))
 				if err == nil {
@@ -85,3 +85,39 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessComposite(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("composites", map[string]interface{}{
+		"ops": map[string]interface{}{
+			"threshold": 1,
+			"accounts":  []string{"Test/*"},
+			"approvers": []string{"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+		},
+	})
+
+	_, err = process(context.Background(), &dataIn{
+		timeout:     5 * time.Second,
+		account:     interop0,
+		accountName: "Test/Interop 0",
+		passphrases: []string{"pass"},
+	})
+	require.EqualError(t, err, `key of Test/Interop 0 requires 1 approvals from composite "ops" but 0 obtained`)
+}
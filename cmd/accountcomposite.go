@@ -0,0 +1,41 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// accountCompositeCmd represents the account composite command.
+var accountCompositeCmd = &cobra.Command{
+	Use:   "composite",
+	Short: "Manage approvals for composite account groups",
+	Long: `Manage approvals for composite account groups.  Composites are defined in the configuration file, and require a number of approvals before destructive operations (exit, key) are carried out on their member accounts.  For example:
+
+composites:
+  operations:
+    threshold: 2
+    accounts:
+      - Validators/*
+    approvers:
+      - Approvers/Alice
+      - "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"`,
+}
+
+func init() {
+	accountCmd.AddCommand(accountCompositeCmd)
+}
+
+func accountCompositeFlags(_ *cobra.Command) {
+}
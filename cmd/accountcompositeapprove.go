@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountcompositeapprove "github.com/wealdtech/ethdo/cmd/account/composite/approve"
+)
+
+var accountCompositeApproveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve an operation on a member of a composite",
+	Long: `Approve an operation on a member of a composite, creating an approval token that can be passed to the operation with --approvals.  For example:
+
+    ethdo account composite approve --composite=operations --operation=exit --member="Validators/1" --account="Approvers/Alice" --passphrase="secret"
+
+In quiet mode this will return 0 if the approval is created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountcompositeapprove.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCompositeCmd.AddCommand(accountCompositeApproveCmd)
+	accountCompositeFlags(accountCompositeApproveCmd)
+	accountCompositeApproveCmd.Flags().String("composite", "", "Name of the composite for which to provide approval")
+	accountCompositeApproveCmd.Flags().String("operation", "", "Operation to approve (exit, key)")
+	accountCompositeApproveCmd.Flags().String("member", "", "Member account on which the operation is approved")
+	accountCompositeApproveCmd.Flags().Duration("expiry", 24*time.Hour, "Time for which the approval remains valid")
+}
+
+func accountCompositeApproveBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("composite", cmd.Flags().Lookup("composite")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("operation", cmd.Flags().Lookup("operation")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("member", cmd.Flags().Lookup("member")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("expiry", cmd.Flags().Lookup("expiry")); err != nil {
+		panic(err)
+	}
+}
@@ -28,6 +28,10 @@ var accountCreateCmd = &cobra.Command{
 
     ethdo account create --account="primary/operations" --passphrase="my secret"
 
+When used with a Dirk wallet and more than one participant the account is generated by distributed key generation across the Dirk instances.  ethdo waits until each participant holds its share of the account, and verifies that the participants agree on the composite public key and that their shares generate it.  The account can also be recorded in a local wallet as a watch-only account, for example:
+
+    ethdo account create --remote=dirk1:9091 --account="dirk wallet/validator 1" --participants=3 --signing-threshold=2 --local-account="watched/validator 1"
+
 In quiet mode this will return 0 if the account is created successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := accountcreate.Run(cmd)
@@ -49,6 +53,7 @@ func init() {
 	accountFlags(accountCreateCmd)
 	accountCreateCmd.Flags().Uint32("participants", 1, "Number of participants (1 for non-distributed accounts, >1 for distributed accounts)")
 	accountCreateCmd.Flags().Uint32("signing-threshold", 1, "Signing threshold (1 for non-distributed accounts)")
+	accountCreateCmd.Flags().String("local-account", "", "Local account in which to record a distributed account generated by Dirk as watch-only")
 }
 
 func accountCreateBindings(cmd *cobra.Command) {
@@ -58,4 +63,7 @@ func accountCreateBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("signing-threshold", cmd.Flags().Lookup("signing-threshold")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("local-account", cmd.Flags().Lookup("local-account")); err != nil {
+		panic(err)
+	}
 }
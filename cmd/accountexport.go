@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountexport "github.com/wealdtech/ethdo/cmd/account/export"
+)
+
+var accountExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export accounts as EIP-2335 keystores",
+	Long: `Export one or more accounts as EIP-2335 keystores, for use with any validator client.  For example:
+
+    ethdo account export --account="Validators/.*" --passphrase="my account secret" --keystore-passphrase="my keystore secret" --dir=validator_keys
+
+The account name can be a regular expression to export multiple accounts from a wallet.  Keystores are written to the directory in the layout used by the deposit CLI, with accounts that have a derivation path named after it, for example keystore-m_12381_3600_0_0_0-1700000000.json.  Existing keystores are not overwritten.
+
+The keystores are encrypted with scrypt by default, as with the deposit CLI; --kdf=pbkdf2 can be used for validator clients that prefer it.
+
+In quiet mode this will return 0 if the accounts are exported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accountexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountExportCmd)
+	accountFlags(accountExportCmd)
+	accountExportCmd.Flags().String("format", "keystore", "Format of the export (keystore)")
+	accountExportCmd.Flags().String("keystore-passphrase", "", "Passphrase with which to encrypt the keystores")
+	accountExportCmd.Flags().String("kdf", "scrypt", "Key derivation function of the keystores (scrypt, pbkdf2)")
+	accountExportCmd.Flags().String("dir", "validator_keys", "Directory in which to write the keystores")
+	accountExportCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the accounts are members, as JSON or files containing JSON")
+}
+
+func accountExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("format", cmd.Flags().Lookup("format")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kdf", cmd.Flags().Lookup("kdf")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dir", cmd.Flags().Lookup("dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
+}
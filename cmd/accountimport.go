@@ -28,6 +28,18 @@ var accountImportCmd = &cobra.Command{
 
     ethdo account import --account="primary/testing" --key="0x..." --passphrase="my secret"
 
+A watch-only account, holding the public key but no private key, can be created from an account held by Dirk.  For example:
+
+    ethdo account import --account="primary/testing" --from-dirk="Validators/1" --remote=dirk.example.com:9091 --client-cert=client.crt --client-key=client.key --server-ca-cert=ca.crt
+
+Watch-only accounts can be used by commands that only require the public key of an account, but cannot sign.
+
+A directory of EIP-2335 keystores, for example as generated by staking-deposit-cli, can be imported in a single batch.  Each account is named after its keystore file, and the keystores are decrypted with a shared passphrase or with per-keystore passphrase files.  For example:
+
+    ethdo account import --wallet=Validators --keystore-dir=validator_keys --keystore-passphrase-file=passwords --passphrase="my secret" --dry-run
+
+A summary of the keystores that were imported, skipped as already present, or failed is output.
+
 In quiet mode this will return 0 if the account is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := accountimport.Run(cmd)
@@ -50,6 +62,11 @@ func init() {
 	accountImportCmd.Flags().String("key", "", "Private key of the account to import (0x...)")
 	accountImportCmd.Flags().String("keystore", "", "Keystore, or path to keystore ")
 	accountImportCmd.Flags().String("keystore-passphrase", "", "Passphrase of keystore")
+	accountImportCmd.Flags().String("from-dirk", "", "Dirk account from which to create a watch-only account (<wallet>/<account>)")
+	accountImportCmd.Flags().String("keystore-dir", "", "Directory of keystores to import")
+	accountImportCmd.Flags().String("keystore-passphrase-file", "", "File containing the passphrase of the keystores, or directory of per-keystore passphrase files")
+	accountImportCmd.Flags().String("wallet", "", "Wallet in to which to import keystores from keystore-dir")
+	accountImportCmd.Flags().Bool("dry-run", false, "Report the keystores that would be imported from keystore-dir without importing them")
 }
 
 func accountImportBindings(cmd *cobra.Command) {
@@ -62,4 +79,19 @@ func accountImportBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-dirk", cmd.Flags().Lookup("from-dirk")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-dir", cmd.Flags().Lookup("keystore-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-passphrase-file", cmd.Flags().Lookup("keystore-passphrase-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("wallet", cmd.Flags().Lookup("wallet")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
 }
@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -77,6 +79,19 @@ In quiet mode this will return 0 if the account exists, otherwise 1.`,
 				fmt.Printf("Path: %s\n", pathProvider.Path())
 			}
 		}
+		if metadata := ethdoutil.WatchOnlyAccountMetadata(wallet, account); metadata != nil {
+			fmt.Printf("Watch-only: %s\n", metadata.Source)
+			if viper.GetBool("verbose") {
+				keys := make([]string, 0, len(metadata.Details))
+				for k := range metadata.Details {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					fmt.Printf(" %s: %s\n", k, metadata.Details[k])
+				}
+			}
+		}
 
 		os.Exit(_exitSuccess)
 	},
@@ -48,4 +48,11 @@ In quiet mode this will return 0 if the key can be obtained, otherwise 1.`,
 func init() {
 	accountCmd.AddCommand(accountKeyCmd)
 	accountFlags(accountKeyCmd)
+	accountKeyCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the account is a member, as JSON or files containing JSON")
+}
+
+func accountKeyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -41,6 +42,7 @@ In quiet mode this will return 0 if the account is unlocked, otherwise 1.`,
 
 		locker, isLocker := account.(e2wtypes.AccountLocker)
 		assert(isLocker, "Account does not support unlocking")
+		errCheck(util.CheckNotWatchOnly(account), "Failed to unlock account")
 
 		unlocked := false
 		for _, passphrase := range getPassphrases() {
@@ -0,0 +1,128 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstart
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	"github.com/wealdtech/ethdo/services/agent/socket"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.checkExisting(ctx); err != nil {
+		return err
+	}
+
+	if c.foreground {
+		return c.serve(ctx)
+	}
+
+	return c.spawn(ctx)
+}
+
+// checkExisting ensures that no agent is already serving on the socket,
+// removing the socket if it was left behind by an agent that has stopped.
+func (c *command) checkExisting(ctx context.Context) error {
+	if _, err := os.Stat(c.socket); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to access agent socket")
+	}
+
+	if c.running(ctx) {
+		return fmt.Errorf("agent already running with socket %s", c.socket)
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Removing stale agent socket %s\n", c.socket)
+	}
+	if err := os.Remove(c.socket); err != nil {
+		return errors.Wrap(err, "failed to remove stale agent socket")
+	}
+
+	return nil
+}
+
+// running returns true if an agent is responding on the socket.
+func (c *command) running(ctx context.Context) bool {
+	client, err := socket.New(ctx,
+		socket.WithPath(c.socket),
+		socket.WithTimeout(c.timeout),
+	)
+	if err != nil {
+		return false
+	}
+	_, err = client.Status(ctx)
+
+	return err == nil
+}
+
+// serve runs the agent until it is interrupted.
+func (c *command) serve(ctx context.Context) error {
+	// The agent outlives the terminal from which it was started.
+	signal.Ignore(syscall.SIGHUP)
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	store, err := memory.New(ctx, memory.WithMaxLifetime(c.maxLifetime))
+	if err != nil {
+		return errors.Wrap(err, "failed to create key store")
+	}
+
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Agent listening on %s\n", c.socket)
+	}
+
+	return socket.Serve(ctx, c.socket, store)
+}
+
+// spawn starts the agent as a background process, and waits for it to
+// become available.
+func (c *command) spawn(ctx context.Context) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain executable")
+	}
+
+	agentCmd := exec.Command(executable,
+		"agent", "start",
+		"--foreground",
+		fmt.Sprintf("--agent-socket=%s", c.socket),
+		fmt.Sprintf("--max-lifetime=%s", c.maxLifetime),
+	)
+	if err := agentCmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start agent")
+	}
+	if err := agentCmd.Process.Release(); err != nil {
+		return errors.Wrap(err, "failed to release agent process")
+	}
+
+	deadline := time.Now().Add(c.timeout)
+	for time.Now().Before(deadline) {
+		if c.running(ctx) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return errors.New("agent did not start")
+}
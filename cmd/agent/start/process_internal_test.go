@@ -0,0 +1,58 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstart
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	"github.com/wealdtech/ethdo/services/agent/socket"
+)
+
+func TestCheckExisting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+
+	// No socket.
+	c := &command{
+		timeout: time.Second,
+		socket:  filepath.Join(dir, "missing.sock"),
+	}
+	require.NoError(t, c.checkExisting(ctx))
+
+	// Stale socket.
+	c.socket = filepath.Join(dir, "stale.sock")
+	require.NoError(t, os.WriteFile(c.socket, nil, 0o600))
+	require.NoError(t, c.checkExisting(ctx))
+	require.NoFileExists(t, c.socket)
+
+	// Running agent.
+	c.socket = filepath.Join(dir, "agent.sock")
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+	go func() {
+		_ = socket.Serve(ctx, c.socket, store)
+	}()
+	require.Eventually(t, func() bool {
+		return c.running(ctx)
+	}, time.Second, 10*time.Millisecond)
+	require.EqualError(t, c.checkExisting(ctx), "agent already running with socket "+c.socket)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet      bool
+	verbose    bool
+	debug      bool
+	jsonOutput bool
+
+	timeout time.Duration
+
+	// Data access.
+	agent agent.Service
+
+	// Output.
+	socket string
+	status *agent.Status
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		jsonOutput: viper.GetBool("json"),
+		timeout:    viper.GetDuration("timeout"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	var err error
+	c.socket, err = util.AgentSocket()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
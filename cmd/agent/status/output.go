@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type jsonOutput struct {
+	Socket      string     `json:"socket"`
+	Started     time.Time  `json:"started"`
+	MaxLifetime string     `json:"max_lifetime"`
+	Keys        []*keyJSON `json:"keys"`
+}
+
+type keyJSON struct {
+	Wallet    string    `json:"wallet"`
+	Account   string    `json:"account"`
+	PublicKey string    `json:"public_key"`
+	Expiry    time.Time `json:"expiry"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Socket:      c.socket,
+		Started:     c.status.Started,
+		MaxLifetime: c.status.MaxLifetime.String(),
+		Keys:        make([]*keyJSON, 0, len(c.status.Keys)),
+	}
+	for _, key := range c.status.Keys {
+		output.Keys = append(output.Keys, &keyJSON{
+			Wallet:    key.Wallet,
+			Account:   key.Account,
+			PublicKey: fmt.Sprintf("%#x", key.PublicKey),
+			Expiry:    key.Expiry,
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Socket: %s\n", c.socket))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Started: %s\n", c.status.Started.Format(time.RFC3339)))
+		builder.WriteString(fmt.Sprintf("Maximum key lifetime: %s\n", c.status.MaxLifetime))
+	}
+	builder.WriteString(fmt.Sprintf("Keys: %d\n", len(c.status.Keys)))
+	now := time.Now()
+	for _, key := range c.status.Keys {
+		builder.WriteString(fmt.Sprintf("  %s/%s: expires in %s\n", key.Wallet, key.Account, key.Expiry.Sub(now).Round(time.Second)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("    Public key: %#x\n", key.PublicKey))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
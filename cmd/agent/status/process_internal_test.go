@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentstatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// No agent.
+	viper.Reset()
+	viper.Set("agent-socket", "/nonexistent/agent.sock")
+	c := &command{
+		timeout: time.Second,
+	}
+	require.EqualError(t, c.process(ctx), "agent is not running")
+
+	store, err := memory.New(ctx, memory.WithMaxLifetime(30*time.Minute))
+	require.NoError(t, err)
+	privKey, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+	require.NoError(t, store.AddKeys(ctx, []*agent.Key{
+		{
+			Wallet:    "Wallet",
+			Account:   "Account",
+			PublicKey: privKey.PublicKey().Marshal(),
+			SecretKey: privKey.Marshal(),
+		},
+	}, time.Minute))
+
+	c = &command{
+		timeout: time.Second,
+		socket:  "/tmp/agent.sock",
+		agent:   store,
+	}
+	require.NoError(t, c.process(ctx))
+	require.Len(t, c.status.Keys, 1)
+
+	res, err := c.output(ctx)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res, "Socket: /tmp/agent.sock\nKeys: 1\n  Wallet/Account: expires in "))
+
+	c.jsonOutput = true
+	res, err = c.output(ctx)
+	require.NoError(t, err)
+	require.Contains(t, res, `"max_lifetime":"30m0s"`)
+	require.Contains(t, res, fmt.Sprintf(`"public_key":"%#x"`, privKey.PublicKey().Marshal()))
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	agentstart "github.com/wealdtech/ethdo/cmd/agent/start"
+)
+
+var agentStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the key agent",
+	Long: `Start the key agent in the background.  For example:
+
+    ethdo agent start
+
+The agent listens on a socket that is only accessible by the current user, by default $HOME/.ethdo-agent.sock; an alternative can be supplied with --agent-socket, which must then also be supplied to other commands that use the agent.  Keys are added to the agent with "ethdo wallet unlock", and are held for at most --max-lifetime.  The agent can be run in the foreground with --foreground, in which case it stops when interrupted.
+
+In quiet mode this will return 0 if the agent is started, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := agentstart.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(agentStartCmd)
+	agentStartCmd.Flags().Bool("foreground", false, "run the agent in the foreground rather than as a background process")
+	agentStartCmd.Flags().Duration("max-lifetime", time.Hour, "the maximum time for which the agent holds a key")
+}
+
+func agentStartBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("foreground", cmd.Flags().Lookup("foreground")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-lifetime", cmd.Flags().Lookup("max-lifetime")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd represents the alias command.
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage aliases",
+	Long:  "Manage aliases, which are friendly names for validators and addresses that can be supplied in place of them to any --validator, --validators or withdrawal address flag",
+}
+
+func init() {
+	RootCmd.AddCommand(aliasCmd)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliaslist
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Aliases []*alias `json:"aliases"`
+}
+
+type alias struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliaslist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the aliases as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// CSVHeader returns the header of the CSV output.  This matches the format
+// read by "alias set --file".
+func (*command) CSVHeader() []string {
+	return []string{
+		"name",
+		"value",
+	}
+}
+
+// CSVRecords returns the aliases as CSV.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	records := make([][]string, 0, len(c.results.Aliases))
+	for _, alias := range c.results.Aliases {
+		records = append(records, []string{
+			alias.Name,
+			alias.Value,
+		})
+	}
+
+	return records, nil
+}
+
+// RenderText renders the aliases as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	for _, alias := range c.results.Aliases {
+		builder.WriteString(fmt.Sprintf("%s: %s\n", alias.Name, alias.Value))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
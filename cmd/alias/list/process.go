@@ -0,0 +1,40 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliaslist
+
+import (
+	"context"
+	"sort"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	aliases := util.Aliases()
+
+	c.results = &results{
+		Aliases: make([]*alias, 0, len(aliases)),
+	}
+	for name, value := range aliases {
+		c.results.Aliases = append(c.results.Aliases, &alias{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(c.results.Aliases, func(i, j int) bool {
+		return c.results.Aliases[i].Name < c.results.Aliases[j].Name
+	})
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliaslist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		aliases map[string]any
+		output  string
+	}{
+		{
+			name:   "Empty",
+			vars:   map[string]interface{}{},
+			output: "",
+		},
+		{
+			name: "Text",
+			vars: map[string]interface{}{},
+			aliases: map[string]any{
+				"treasury": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"node-1":   "12345",
+			},
+			output: "node-1: 12345\ntreasury: 0x30C99930617B7b793beaB603ecEB08691005f2E5",
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"output": "json",
+			},
+			aliases: map[string]any{
+				"node-1": "12345",
+			},
+			output: `{"aliases":[{"name":"node-1","value":"12345"}]}`,
+		},
+		{
+			name: "CSV",
+			vars: map[string]interface{}{
+				"output": "csv",
+			},
+			aliases: map[string]any{
+				"node-1": "12345",
+			},
+			output: "name,value\nnode-1,12345",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			if test.aliases != nil {
+				viper.Set("aliases", test.aliases)
+			}
+
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(context.Background()))
+			output, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.output, output)
+		})
+	}
+}
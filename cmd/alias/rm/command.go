@@ -0,0 +1,50 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasrm
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	configFile string
+	names      []string
+
+	// Output.
+	removed map[string]string
+}
+
+func newCommand(_ context.Context, configFile string) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		configFile: configFile,
+		names:      viper.GetStringSlice("name"),
+	}
+
+	if len(c.names) == 0 {
+		return nil, errors.New("name is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,45 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasrm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	aliases, err := util.ReadAliases(c.configFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read aliases")
+	}
+
+	c.removed = make(map[string]string, len(c.names))
+	for _, name := range c.names {
+		value, exists := aliases[name]
+		if !exists {
+			return fmt.Errorf("alias %s not found", name)
+		}
+		c.removed[name] = value
+		delete(aliases, name)
+	}
+
+	if err := util.WriteAliases(c.configFile, aliases); err != nil {
+		return errors.Wrap(err, "failed to write aliases")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasrm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProcess(t *testing.T) {
+	existing := "timeout: 10s\naliases:\n  node-1: \"12345\"\n  node-2: \"12346\"\n"
+
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		aliases map[string]string
+		output  string
+		err     string
+	}{
+		{
+			name: "NameMissing",
+			vars: map[string]interface{}{},
+			err:  "name is required",
+		},
+		{
+			name: "Unknown",
+			vars: map[string]interface{}{
+				"name": []string{"node-1", "node-3"},
+			},
+			err: "alias node-3 not found",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"name":    []string{"node-1"},
+				"verbose": true,
+			},
+			aliases: map[string]string{
+				"node-2": "12346",
+			},
+			output: "Removed alias node-1: 12345",
+		},
+		{
+			name: "All",
+			vars: map[string]interface{}{
+				"name": []string{"node-1", "node-2"},
+			},
+			aliases: map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			configFile := filepath.Join(t.TempDir(), ".ethdo.yaml")
+			require.NoError(t, os.WriteFile(configFile, []byte(existing), 0o600))
+
+			cmd, err := newCommand(context.Background(), configFile)
+			if err == nil {
+				err = cmd.process(context.Background())
+			}
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			aliases, err := util.ReadAliases(configFile)
+			require.NoError(t, err)
+			require.Equal(t, test.aliases, aliases)
+			output, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.output, output)
+		})
+	}
+}
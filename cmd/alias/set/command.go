@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasset
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	configFile string
+	name       string
+	value      string
+	file       string
+
+	// Processing.
+	aliases map[string]string
+
+	// Output.
+	added    []string
+	replaced []string
+}
+
+func newCommand(_ context.Context, configFile string) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		configFile: configFile,
+		name:       viper.GetString("name"),
+		value:      viper.GetString("value"),
+		file:       viper.GetString("file"),
+	}
+
+	if c.file != "" {
+		if c.name != "" || c.value != "" {
+			return nil, errors.New("file cannot be supplied with name or value")
+		}
+		data, err := os.ReadFile(c.file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read aliases file")
+		}
+		c.aliases, err = util.ParseAliasesCSV(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(c.aliases) == 0 {
+			return nil, errors.New("aliases file does not contain any aliases")
+		}
+
+		return c, nil
+	}
+
+	if err := util.ValidateAliasName(c.name); err != nil {
+		return nil, err
+	}
+	if err := util.ValidateAliasValue(c.value); err != nil {
+		return nil, err
+	}
+	c.aliases = map[string]string{
+		c.name: c.value,
+	}
+
+	return c, nil
+}
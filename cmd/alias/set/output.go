@@ -0,0 +1,37 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet || !c.verbose {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	for _, name := range c.added {
+		builder.WriteString(fmt.Sprintf("Added alias %s: %s\n", name, c.aliases[name]))
+	}
+	for _, name := range c.replaced {
+		builder.WriteString(fmt.Sprintf("Replaced alias %s: %s\n", name, c.aliases[name]))
+	}
+	builder.WriteString(fmt.Sprintf("Aliases written to %s", c.configFile))
+
+	return builder.String(), nil
+}
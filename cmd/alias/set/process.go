@@ -0,0 +1,46 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasset
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	aliases, err := util.ReadAliases(c.configFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read aliases")
+	}
+
+	for name, value := range c.aliases {
+		if _, exists := aliases[name]; exists {
+			c.replaced = append(c.replaced, name)
+		} else {
+			c.added = append(c.added, name)
+		}
+		aliases[name] = value
+	}
+	sort.Strings(c.added)
+	sort.Strings(c.replaced)
+
+	if err := util.WriteAliases(c.configFile, aliases); err != nil {
+		return errors.Wrap(err, "failed to write aliases")
+	}
+
+	return nil
+}
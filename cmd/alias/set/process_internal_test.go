@@ -0,0 +1,128 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aliasset
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "aliases.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte("name,value\nnode-1,12346\ntreasury,0x30C99930617B7b793beaB603ecEB08691005f2E5\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty.csv")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("name,value\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		existing string
+		aliases  map[string]string
+		output   string
+		err      string
+	}{
+		{
+			name: "NameMissing",
+			vars: map[string]interface{}{
+				"value": "12345",
+			},
+			err: "alias name is required",
+		},
+		{
+			name: "ValueInvalid",
+			vars: map[string]interface{}{
+				"name":  "node-1",
+				"value": "Wallet/Account",
+			},
+			err: `invalid alias value "Wallet/Account"; values must be a validator index, range of indices, public key or address`,
+		},
+		{
+			name: "FileAndName",
+			vars: map[string]interface{}{
+				"name": "node-1",
+				"file": csvFile,
+			},
+			err: "file cannot be supplied with name or value",
+		},
+		{
+			name: "FileEmpty",
+			vars: map[string]interface{}{
+				"file": emptyFile,
+			},
+			err: "aliases file does not contain any aliases",
+		},
+		{
+			name: "New",
+			vars: map[string]interface{}{
+				"name":    "node-1",
+				"value":   "12345",
+				"verbose": true,
+			},
+			existing: "timeout: 10s\n",
+			aliases: map[string]string{
+				"node-1": "12345",
+			},
+			output: "Added alias node-1: 12345\nAliases written to ",
+		},
+		{
+			name: "File",
+			vars: map[string]interface{}{
+				"file":    csvFile,
+				"verbose": true,
+			},
+			existing: "aliases:\n  node-1: \"12345\"\n  node-2: \"12347\"\n",
+			aliases: map[string]string{
+				"node-1":   "12346",
+				"node-2":   "12347",
+				"treasury": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+			},
+			output: "Added alias treasury: 0x30C99930617B7b793beaB603ecEB08691005f2E5\nReplaced alias node-1: 12346\nAliases written to ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			configFile := filepath.Join(t.TempDir(), ".ethdo.yaml")
+			if test.existing != "" {
+				require.NoError(t, os.WriteFile(configFile, []byte(test.existing), 0o600))
+			}
+
+			cmd, err := newCommand(context.Background(), configFile)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, cmd.process(context.Background()))
+
+			aliases, err := util.ReadAliases(configFile)
+			require.NoError(t, err)
+			require.Equal(t, test.aliases, aliases)
+			output, err := cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.output+configFile, output)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	aliasrm "github.com/wealdtech/ethdo/cmd/alias/rm"
+)
+
+var aliasRmCmd = &cobra.Command{
+	Use:   "rm",
+	Short: "Remove aliases",
+	Long: `Remove one or more aliases from the configuration file.  For example:
+
+    ethdo alias rm --name=node-1,node-2
+
+In quiet mode this will return 0 if the aliases are removed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		res, err := aliasrm.Run(cmd, configFile)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasRmCmd)
+	aliasRmCmd.Flags().StringSlice("name", nil, "Names of the aliases to remove")
+}
+
+func aliasRmBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("name", cmd.Flags().Lookup("name")); err != nil {
+		panic(err)
+	}
+}
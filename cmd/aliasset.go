@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	aliasset "github.com/wealdtech/ethdo/cmd/alias/set"
+)
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set an alias",
+	Long: `Set an alias for a validator index, range of validator indices, validator public key or address, storing it in the configuration file.  For example:
+
+    ethdo alias set --name=node-1 --value=12345
+
+Aliases can be imported from a CSV file containing one "name,value" pair per line with --file.  Existing aliases of the same name are replaced.
+
+Once set, an alias can be used in place of its value with any --validator, --validators or withdrawal address flag, for example:
+
+    ethdo validator info --validator=node-1
+
+Alias names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'.  An alias takes precedence over a wallet of the same name.
+
+In quiet mode this will return 0 if the aliases are set, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		res, err := aliasset.Run(cmd, configFile)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasSetCmd.Flags().String("name", "", "Name of the alias")
+	aliasSetCmd.Flags().String("value", "", "Validator index, range of validator indices, validator public key or address for which the name is an alias")
+	aliasSetCmd.Flags().String("file", "", "CSV file of aliases to import, with one name,value pair per line")
+}
+
+func aliasSetBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("name", cmd.Flags().Lookup("name")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("value", cmd.Flags().Lookup("value")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactfetch
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	source      string
+	digest      []byte
+	file        string
+	ipfsGateway string
+	passphrase  string
+	timeout     time.Duration
+
+	// Output.
+	data []byte
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		timeout:     viper.GetDuration("timeout"),
+		source:      viper.GetString("source"),
+		file:        viper.GetString("file"),
+		ipfsGateway: viper.GetString("ipfs-gateway"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.source == "" {
+		return nil, errors.New("source is required")
+	}
+
+	if viper.GetString("digest") == "" {
+		return nil, errors.New("digest is required")
+	}
+	var err error
+	c.digest, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("digest"), "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid digest")
+	}
+	if len(c.digest) != 32 {
+		return nil, errors.New("digest must be 32 bytes")
+	}
+
+	c.passphrase, err = util.GetOptionalPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactfetch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	digest := "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"source": "ipfs://bafkreitest",
+				"digest": digest,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "SourceMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"digest":  digest,
+			},
+			err: "source is required",
+		},
+		{
+			name: "DigestMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"source":  "ipfs://bafkreitest",
+			},
+			err: "digest is required",
+		},
+		{
+			name: "DigestInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"source":  "ipfs://bafkreitest",
+				"digest":  "0xinvalid",
+			},
+			err: "invalid digest: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "DigestShort",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"source":  "ipfs://bafkreitest",
+				"digest":  "0x0102",
+			},
+			err: "digest must be 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"source":     "ipfs://bafkreitest",
+				"digest":     digest,
+				"passphrase": "secret",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactfetch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-ecodec"
+)
+
+func (c *command) process(ctx context.Context) error {
+	data, err := util.FetchArtifact(ctx, c.source, c.ipfsGateway, c.timeout)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyDigest(data, c.digest); err != nil {
+		return err
+	}
+
+	if c.passphrase != "" {
+		data, err = ecodec.Decrypt(data, []byte(c.passphrase))
+		if err != nil {
+			return errors.Wrap(err, "failed to decrypt artifact")
+		}
+	}
+	c.data = data
+
+	if c.file != "" {
+		if err := os.WriteFile(c.file, c.data, 0o600); err != nil {
+			return errors.Wrap(err, "failed to write artifact")
+		}
+	}
+
+	return nil
+}
+
+// verifyDigest confirms that the data matches the expected digest.
+func verifyDigest(data []byte, expected []byte) error {
+	digest := sha256.Sum256(data)
+	if !bytes.Equal(digest[:], expected) {
+		return fmt.Errorf("digest mismatch: expected %#x, obtained %#x", expected, digest)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactfetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-ecodec"
+)
+
+func TestProcess(t *testing.T) {
+	plain := []byte(`{"exits":[],"version":1}`)
+	encrypted, err := ecodec.Encrypt(plain, []byte("secret"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	plainFile := filepath.Join(dir, "plain")
+	require.NoError(t, os.WriteFile(plainFile, plain, 0o600))
+	encryptedFile := filepath.Join(dir, "encrypted")
+	require.NoError(t, os.WriteFile(encryptedFile, encrypted, 0o600))
+	plainDigest := sha256.Sum256(plain)
+	encryptedDigest := sha256.Sum256(encrypted)
+
+	tests := []struct {
+		name       string
+		source     string
+		digest     []byte
+		passphrase string
+		err        string
+	}{
+		{
+			name:   "Plain",
+			source: plainFile,
+			digest: plainDigest[:],
+		},
+		{
+			name:       "Encrypted",
+			source:     encryptedFile,
+			digest:     encryptedDigest[:],
+			passphrase: "secret",
+		},
+		{
+			name:   "DigestMismatch",
+			source: plainFile,
+			digest: encryptedDigest[:],
+			err:    "digest mismatch",
+		},
+		{
+			name:       "PassphraseIncorrect",
+			source:     encryptedFile,
+			digest:     encryptedDigest[:],
+			passphrase: "wrong",
+			err:        "failed to decrypt artifact",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "output")
+			c := &command{
+				timeout:    time.Second,
+				source:     test.source,
+				digest:     test.digest,
+				passphrase: test.passphrase,
+				file:       output,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, plain, c.data)
+			written, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.Equal(t, plain, written)
+		})
+	}
+}
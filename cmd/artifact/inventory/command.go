@@ -0,0 +1,112 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactinventory
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+type command struct {
+	quiet      bool
+	verbose    bool
+	debug      bool
+	jsonOutput bool
+
+	// Input.
+	directory                string
+	validators               []string
+	connection               string
+	allowInsecureConnections bool
+	timeout                  time.Duration
+
+	// Beacon node.
+	consensusClient consensusclient.Service
+	chainInfo       *beacon.ChainInfo
+	forkVersions    []phase0.Version
+
+	// Output.
+	artifacts    []*artifact
+	unrecognised []string
+	coverage     []*coverage
+	missing      []phase0.ValidatorIndex
+	complete     bool
+}
+
+// artifactType is the type of an artifact.
+type artifactType string
+
+const (
+	artifactTypeExit                 artifactType = "exit"
+	artifactTypeBLSToExecutionChange artifactType = "bls-change"
+	artifactTypeDeposit              artifactType = "deposit"
+)
+
+// artifactStatus is the status of an artifact.
+type artifactStatus string
+
+const (
+	artifactStatusValid   artifactStatus = "valid"
+	artifactStatusStale   artifactStatus = "stale"
+	artifactStatusInvalid artifactStatus = "invalid"
+)
+
+// artifact is a single operation found in an artifact file.
+type artifact struct {
+	File      string
+	Type      artifactType
+	Validator string
+	Status    artifactStatus
+	Detail    string
+
+	// index is the index of the validator, if it is on the chain.
+	index *phase0.ValidatorIndex
+}
+
+// coverage is the set of valid artifacts held for a validator.
+type coverage struct {
+	Validator phase0.ValidatorIndex
+	Types     []artifactType
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		jsonOutput:               viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		directory:                viper.GetString("directory"),
+		validators:               viper.GetStringSlice("validators"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.directory == "" {
+		return nil, errors.New("directory is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactinventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Artifacts    []*artifactJSON `json:"artifacts"`
+	Unrecognised []string        `json:"unrecognised"`
+	Coverage     []*coverageJSON `json:"coverage,omitempty"`
+	Missing      []string        `json:"missing,omitempty"`
+	Complete     bool            `json:"complete"`
+}
+
+type artifactJSON struct {
+	File      string `json:"file"`
+	Type      string `json:"type"`
+	Validator string `json:"validator"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+type coverageJSON struct {
+	Validator string   `json:"validator"`
+	Types     []string `json:"types"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Artifacts:    make([]*artifactJSON, 0, len(c.artifacts)),
+		Unrecognised: c.unrecognised,
+		Complete:     c.complete,
+	}
+	for _, artifact := range c.artifacts {
+		output.Artifacts = append(output.Artifacts, &artifactJSON{
+			File:      artifact.File,
+			Type:      string(artifact.Type),
+			Validator: artifact.Validator,
+			Status:    string(artifact.Status),
+			Detail:    artifact.Detail,
+		})
+	}
+	if c.coverage != nil {
+		output.Coverage = make([]*coverageJSON, 0, len(c.coverage))
+		for _, entry := range c.coverage {
+			types := make([]string, 0, len(entry.Types))
+			for _, artifactType := range entry.Types {
+				types = append(types, string(artifactType))
+			}
+			output.Coverage = append(output.Coverage, &coverageJSON{
+				Validator: fmt.Sprintf("%d", entry.Validator),
+				Types:     types,
+			})
+		}
+	}
+	if c.missing != nil {
+		output.Missing = make([]string, 0, len(c.missing))
+		for _, index := range c.missing {
+			output.Missing = append(output.Missing, fmt.Sprintf("%d", index))
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	counts := make(map[artifactStatus]int)
+	for _, artifact := range c.artifacts {
+		counts[artifact.Status]++
+	}
+	builder.WriteString(fmt.Sprintf("Artifacts: %d (%d valid, %d stale, %d invalid)\n",
+		len(c.artifacts),
+		counts[artifactStatusValid],
+		counts[artifactStatusStale],
+		counts[artifactStatusInvalid],
+	))
+
+	for _, status := range []artifactStatus{artifactStatusStale, artifactStatusInvalid} {
+		if counts[status] == 0 {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s%s artifacts:\n", strings.ToUpper(string(status[:1])), status[1:]))
+		for _, artifact := range c.artifacts {
+			if artifact.Status == status {
+				builder.WriteString(formatArtifact(artifact))
+			}
+		}
+	}
+
+	if c.verbose && counts[artifactStatusValid] > 0 {
+		builder.WriteString("Valid artifacts:\n")
+		for _, artifact := range c.artifacts {
+			if artifact.Status == artifactStatusValid {
+				builder.WriteString(formatArtifact(artifact))
+			}
+		}
+	}
+
+	if len(c.unrecognised) > 0 {
+		builder.WriteString("Unrecognised files:\n")
+		for _, file := range c.unrecognised {
+			builder.WriteString(fmt.Sprintf("  %s\n", file))
+		}
+	}
+
+	if c.coverage != nil {
+		builder.WriteString(fmt.Sprintf("Validators covered: %d\n", len(c.coverage)))
+		if c.verbose {
+			for _, entry := range c.coverage {
+				types := make([]string, 0, len(entry.Types))
+				for _, artifactType := range entry.Types {
+					types = append(types, string(artifactType))
+				}
+				builder.WriteString(fmt.Sprintf("  %d: %s\n", entry.Validator, strings.Join(types, ", ")))
+			}
+		}
+		builder.WriteString(fmt.Sprintf("Validators without artifacts: %d\n", len(c.missing)))
+		for _, index := range c.missing {
+			builder.WriteString(fmt.Sprintf("  %d\n", index))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func formatArtifact(artifact *artifact) string {
+	if artifact.Detail == "" {
+		return fmt.Sprintf("  %s: %s for validator %s\n", artifact.File, artifact.Type, artifact.Validator)
+	}
+
+	return fmt.Sprintf("  %s: %s for validator %s: %s\n", artifact.File, artifact.Type, artifact.Validator, artifact.Detail)
+}
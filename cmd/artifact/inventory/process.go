@@ -0,0 +1,540 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactinventory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// depositData is deposit data in the launchpad format.
+type depositData struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	ForkVersion           string `json:"fork_version"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.scan(ctx); err != nil {
+		return err
+	}
+
+	if err := c.calculateCoverage(ctx); err != nil {
+		return err
+	}
+
+	c.complete = len(c.missing) == 0
+	for _, artifact := range c.artifacts {
+		if artifact.Status != artifactStatusValid {
+			c.complete = false
+		}
+	}
+
+	return nil
+}
+
+// scan walks the directory, assessing every artifact found.
+func (c *command) scan(ctx context.Context) error {
+	byIndex := make(map[phase0.ValidatorIndex]*beacon.ValidatorInfo, len(c.chainInfo.Validators))
+	byPubkey := make(map[phase0.BLSPubKey]*beacon.ValidatorInfo, len(c.chainInfo.Validators))
+	for _, validator := range c.chainInfo.Validators {
+		byIndex[validator.Index] = validator
+		byPubkey[validator.Pubkey] = validator
+	}
+
+	c.artifacts = make([]*artifact, 0)
+	c.unrecognised = make([]string, 0)
+	err := filepath.WalkDir(c.directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+
+		artifacts := c.assessFile(ctx, path, data, byIndex, byPubkey)
+		if len(artifacts) == 0 {
+			c.unrecognised = append(c.unrecognised, path)
+		}
+		c.artifacts = append(c.artifacts, artifacts...)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to scan directory")
+	}
+
+	return nil
+}
+
+// assessFile assesses all of the operations in a single file.
+// It returns no artifacts if the file does not contain any recognised operations.
+func (c *command) assessFile(_ context.Context,
+	filename string,
+	data []byte,
+	byIndex map[phase0.ValidatorIndex]*beacon.ValidatorInfo,
+	byPubkey map[phase0.BLSPubKey]*beacon.ValidatorInfo,
+) []*artifact {
+	data = bytes.TrimSpace(data)
+	var operations []json.RawMessage
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &operations); err != nil {
+			return nil
+		}
+	} else {
+		operations = append(operations, data)
+	}
+
+	// Operations tagged for another network cannot be used on this chain.
+	networkDetail := ""
+	network, err := beacon.ObtainNetworkTag(data)
+	switch {
+	case err != nil:
+		networkDetail = err.Error()
+	case network != nil && *network != c.chainInfo.GenesisValidatorsRoot:
+		networkDetail = fmt.Sprintf("operation is for network %s", beacon.NetworkName(*network))
+	}
+
+	artifacts := make([]*artifact, 0, len(operations))
+	for _, operation := range operations {
+		var res *artifact
+		switch classify(operation) {
+		case artifactTypeExit:
+			res = c.assessExit(operation, byIndex)
+		case artifactTypeBLSToExecutionChange:
+			res = c.assessBLSToExecutionChange(operation, byIndex)
+		case artifactTypeDeposit:
+			res = c.assessDeposit(operation, byPubkey)
+		default:
+			continue
+		}
+		res.File = filename
+		if networkDetail != "" && res.Type != artifactTypeDeposit {
+			res.Status = artifactStatusInvalid
+			res.Detail = networkDetail
+		}
+		artifacts = append(artifacts, res)
+	}
+
+	return artifacts
+}
+
+// classify returns the type of a single operation.
+func classify(operation json.RawMessage) artifactType {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(operation, &fields); err != nil {
+		return ""
+	}
+
+	if message, exists := fields["message"]; exists {
+		var messageFields map[string]json.RawMessage
+		if err := json.Unmarshal(message, &messageFields); err != nil {
+			return ""
+		}
+		if hasFields(messageFields, "validator_index", "from_bls_pubkey", "to_execution_address") {
+			return artifactTypeBLSToExecutionChange
+		}
+		if hasFields(messageFields, "validator_index", "epoch") {
+			return artifactTypeExit
+		}
+
+		return ""
+	}
+
+	if hasFields(fields, "pubkey", "withdrawal_credentials", "amount", "signature") {
+		return artifactTypeDeposit
+	}
+
+	return ""
+}
+
+func hasFields(fields map[string]json.RawMessage, names ...string) bool {
+	for _, name := range names {
+		if _, exists := fields[name]; !exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// assessExit assesses a signed voluntary exit.
+func (c *command) assessExit(operation json.RawMessage,
+	byIndex map[phase0.ValidatorIndex]*beacon.ValidatorInfo,
+) *artifact {
+	res := &artifact{
+		Type:   artifactTypeExit,
+		Status: artifactStatusInvalid,
+	}
+
+	op := &phase0.SignedVoluntaryExit{}
+	if err := json.Unmarshal(operation, op); err != nil {
+		res.Detail = fmt.Sprintf("failed to parse exit: %v", err)
+		return res
+	}
+	res.Validator = fmt.Sprintf("%d", op.Message.ValidatorIndex)
+
+	validator, exists := byIndex[op.Message.ValidatorIndex]
+	if !exists {
+		res.Detail = "unknown validator"
+		return res
+	}
+	res.index = &validator.Index
+
+	root, err := op.Message.HashTreeRoot()
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to generate message root: %v", err)
+		return res
+	}
+
+	// Check against the fork version accepted by the chain first, then any
+	// other fork version to find out if the exit was signed for the wrong fork.
+	if !c.verify(root, op.Signature, validator.Pubkey, c.chainInfo.VoluntaryExitDomainType, c.chainInfo.ExitForkVersion) {
+		for _, forkVersion := range c.forkVersions {
+			if forkVersion == c.chainInfo.ExitForkVersion {
+				continue
+			}
+			if c.verify(root, op.Signature, validator.Pubkey, c.chainInfo.VoluntaryExitDomainType, forkVersion) {
+				res.Status = artifactStatusStale
+				res.Detail = fmt.Sprintf("signed for fork version %#x rather than %#x", forkVersion, c.chainInfo.ExitForkVersion)
+				return res
+			}
+		}
+		res.Detail = "signature does not verify"
+		return res
+	}
+
+	if validator.State == apiv1.ValidatorStateActiveExiting ||
+		validator.State == apiv1.ValidatorStateActiveSlashed ||
+		validator.State.HasExited() {
+		res.Status = artifactStatusStale
+		res.Detail = fmt.Sprintf("validator is %s", validator.State)
+		return res
+	}
+
+	res.Status = artifactStatusValid
+
+	return res
+}
+
+// assessBLSToExecutionChange assesses a signed BLS to execution change.
+func (c *command) assessBLSToExecutionChange(operation json.RawMessage,
+	byIndex map[phase0.ValidatorIndex]*beacon.ValidatorInfo,
+) *artifact {
+	res := &artifact{
+		Type:   artifactTypeBLSToExecutionChange,
+		Status: artifactStatusInvalid,
+	}
+
+	op := &capella.SignedBLSToExecutionChange{}
+	if err := json.Unmarshal(operation, op); err != nil {
+		res.Detail = fmt.Sprintf("failed to parse BLS to execution change: %v", err)
+		return res
+	}
+	res.Validator = fmt.Sprintf("%d", op.Message.ValidatorIndex)
+
+	validator, exists := byIndex[op.Message.ValidatorIndex]
+	if !exists {
+		res.Detail = "unknown validator"
+		return res
+	}
+	res.index = &validator.Index
+
+	if len(validator.WithdrawalCredentials) != 32 {
+		res.Detail = "validator has invalid withdrawal credentials"
+		return res
+	}
+
+	if validator.WithdrawalCredentials[0] != 0x00 {
+		res.Status = artifactStatusStale
+		res.Detail = fmt.Sprintf("validator already has withdrawal credentials %#x", validator.WithdrawalCredentials)
+		return res
+	}
+
+	pubkeyHash := sha256.Sum256(op.Message.FromBLSPubkey[:])
+	if !bytes.Equal(pubkeyHash[1:], validator.WithdrawalCredentials[1:]) {
+		res.Detail = "from BLS public key does not match withdrawal credentials"
+		return res
+	}
+
+	root, err := op.Message.HashTreeRoot()
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to generate message root: %v", err)
+		return res
+	}
+
+	if !c.verify(root, op.Signature, op.Message.FromBLSPubkey, c.chainInfo.BLSToExecutionChangeDomainType, c.chainInfo.GenesisForkVersion) {
+		res.Detail = "signature does not verify"
+		return res
+	}
+
+	res.Status = artifactStatusValid
+
+	return res
+}
+
+// assessDeposit assesses deposit data.
+func (c *command) assessDeposit(operation json.RawMessage,
+	byPubkey map[phase0.BLSPubKey]*beacon.ValidatorInfo,
+) *artifact {
+	res := &artifact{
+		Type:   artifactTypeDeposit,
+		Status: artifactStatusInvalid,
+	}
+
+	deposit := &depositData{}
+	if err := json.Unmarshal(operation, deposit); err != nil {
+		res.Detail = fmt.Sprintf("failed to parse deposit data: %v", err)
+		return res
+	}
+
+	pubkey, err := decodeHex(deposit.PublicKey, phase0.PublicKeyLength)
+	if err != nil {
+		res.Detail = fmt.Sprintf("invalid public key: %v", err)
+		return res
+	}
+	msg := &phase0.DepositMessage{
+		Amount: phase0.Gwei(deposit.Amount),
+	}
+	copy(msg.PublicKey[:], pubkey)
+	res.Validator = fmt.Sprintf("%#x", msg.PublicKey)
+
+	if msg.WithdrawalCredentials, err = decodeHex(deposit.WithdrawalCredentials, 32); err != nil {
+		res.Detail = fmt.Sprintf("invalid withdrawal credentials: %v", err)
+		return res
+	}
+	signatureBytes, err := decodeHex(deposit.Signature, phase0.SignatureLength)
+	if err != nil {
+		res.Detail = fmt.Sprintf("invalid signature: %v", err)
+		return res
+	}
+	var signature phase0.BLSSignature
+	copy(signature[:], signatureBytes)
+
+	if deposit.ForkVersion != "" {
+		forkVersion, err := decodeHex(deposit.ForkVersion, phase0.ForkVersionLength)
+		if err != nil {
+			res.Detail = fmt.Sprintf("invalid fork version: %v", err)
+			return res
+		}
+		if !bytes.Equal(forkVersion, c.chainInfo.GenesisForkVersion[:]) {
+			res.Detail = fmt.Sprintf("deposit is for fork version %#x rather than %#x", forkVersion, c.chainInfo.GenesisForkVersion)
+			return res
+		}
+	}
+
+	root, err := msg.HashTreeRoot()
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to generate message root: %v", err)
+		return res
+	}
+	var domainType phase0.DomainType
+	copy(domainType[:], e2types.DomainDeposit[:])
+	var zeroRoot phase0.Root
+	if !verifySignature(root, signature, msg.PublicKey, domainType, c.chainInfo.GenesisForkVersion, zeroRoot) {
+		res.Detail = "signature does not verify"
+		return res
+	}
+
+	if validator, exists := byPubkey[msg.PublicKey]; exists {
+		res.index = &validator.Index
+		res.Validator = fmt.Sprintf("%d", validator.Index)
+		res.Status = artifactStatusStale
+		res.Detail = "validator has already been deposited"
+		return res
+	}
+
+	res.Status = artifactStatusValid
+
+	return res
+}
+
+// decodeHex decodes a hex string, with or without prefix, of a given length.
+func decodeHex(input string, length int) ([]byte, error) {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) != length {
+		return nil, fmt.Errorf("expected %d bytes, found %d", length, len(res))
+	}
+
+	return res, nil
+}
+
+// verify verifies the signature of an operation on this chain.
+func (c *command) verify(root phase0.Root,
+	signature phase0.BLSSignature,
+	pubkey phase0.BLSPubKey,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+) bool {
+	return verifySignature(root, signature, pubkey, domainType, forkVersion, c.chainInfo.GenesisValidatorsRoot)
+}
+
+// verifySignature verifies a signature over an object root.
+func verifySignature(root phase0.Root,
+	signature phase0.BLSSignature,
+	pubkey phase0.BLSPubKey,
+	domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) bool {
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainType(domainType), forkVersion[:], genesisValidatorsRoot[:]))
+	container := &phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return false
+	}
+	key, err := e2types.BLSPublicKeyFromBytes(pubkey[:])
+	if err != nil {
+		return false
+	}
+
+	return sig.Verify(signingRoot[:], key)
+}
+
+// calculateCoverage calculates the artifacts held for the requested validators.
+func (c *command) calculateCoverage(ctx context.Context) error {
+	if len(c.validators) == 0 {
+		return nil
+	}
+
+	validators, err := util.ParseValidators(ctx, c.consensusClient.(consensusclient.ValidatorsProvider), c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+	c.calculateCoverageFor(indices)
+
+	return nil
+}
+
+// calculateCoverageFor calculates the artifacts held for the given validators.
+func (c *command) calculateCoverageFor(indices []phase0.ValidatorIndex) {
+	held := make(map[phase0.ValidatorIndex]map[artifactType]bool)
+	for _, artifact := range c.artifacts {
+		if artifact.Status != artifactStatusValid || artifact.index == nil {
+			continue
+		}
+		if _, exists := held[*artifact.index]; !exists {
+			held[*artifact.index] = make(map[artifactType]bool)
+		}
+		held[*artifact.index][artifact.Type] = true
+	}
+
+	c.coverage = make([]*coverage, 0)
+	c.missing = make([]phase0.ValidatorIndex, 0)
+	for _, index := range indices {
+		types, exists := held[index]
+		if !exists {
+			c.missing = append(c.missing, index)
+			continue
+		}
+		entry := &coverage{
+			Validator: index,
+			Types:     make([]artifactType, 0, len(types)),
+		}
+		for artifactType := range types {
+			entry.Types = append(entry.Types, artifactType)
+		}
+		sort.Slice(entry.Types, func(i, j int) bool { return entry.Types[i] < entry.Types[j] })
+		c.coverage = append(c.coverage, entry)
+	}
+	sort.Slice(c.coverage, func(i, j int) bool { return c.coverage[i].Validator < c.coverage[j].Validator })
+	sort.Slice(c.missing, func(i, j int) bool { return c.missing[i] < c.missing[j] })
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to consensus node")
+	}
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, chainTime)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain chain information")
+	}
+
+	forkScheduleResponse, err := c.consensusClient.(consensusclient.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	forkSchedule := forkScheduleResponse.Data
+	c.forkVersions = make([]phase0.Version, 0, len(forkSchedule))
+	for _, fork := range forkSchedule {
+		c.forkVersions = append(c.forkVersions, fork.CurrentVersion)
+	}
+
+	return nil
+}
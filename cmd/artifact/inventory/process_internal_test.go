@@ -0,0 +1,291 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactinventory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func sign(t *testing.T,
+	key *e2types.BLSPrivateKey,
+	root phase0.Root,
+	domainType e2types.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) phase0.BLSSignature {
+	t.Helper()
+
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(domainType, forkVersion[:], genesisValidatorsRoot[:]))
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+	require.NoError(t, err)
+
+	var res phase0.BLSSignature
+	copy(res[:], key.Sign(signingRoot[:]).Marshal())
+
+	return res
+}
+
+func signedExit(t *testing.T,
+	key *e2types.BLSPrivateKey,
+	index phase0.ValidatorIndex,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) *phase0.SignedVoluntaryExit {
+	t.Helper()
+
+	msg := &phase0.VoluntaryExit{ValidatorIndex: index}
+	root, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	return &phase0.SignedVoluntaryExit{
+		Message:   msg,
+		Signature: sign(t, key, root, e2types.DomainVoluntaryExit, forkVersion, genesisValidatorsRoot),
+	}
+}
+
+func signedChange(t *testing.T,
+	key *e2types.BLSPrivateKey,
+	index phase0.ValidatorIndex,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) *capella.SignedBLSToExecutionChange {
+	t.Helper()
+
+	msg := &capella.BLSToExecutionChange{
+		ValidatorIndex:     index,
+		ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+	}
+	copy(msg.FromBLSPubkey[:], key.PublicKey().Marshal())
+	root, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+
+	return &capella.SignedBLSToExecutionChange{
+		Message:   msg,
+		Signature: sign(t, key, root, e2types.DomainBlsToExecutionChange, forkVersion, genesisValidatorsRoot),
+	}
+}
+
+func deposit(t *testing.T,
+	key *e2types.BLSPrivateKey,
+	forkVersion phase0.Version,
+) *depositData {
+	t.Helper()
+
+	msg := &phase0.DepositMessage{
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                32000000000,
+	}
+	copy(msg.PublicKey[:], key.PublicKey().Marshal())
+	msg.WithdrawalCredentials[0] = 0x01
+	root, err := msg.HashTreeRoot()
+	require.NoError(t, err)
+	signature := sign(t, key, root, e2types.DomainDeposit, forkVersion, phase0.Root{})
+
+	return &depositData{
+		PublicKey:             hex.EncodeToString(msg.PublicKey[:]),
+		WithdrawalCredentials: hex.EncodeToString(msg.WithdrawalCredentials),
+		Amount:                uint64(msg.Amount),
+		Signature:             hex.EncodeToString(signature[:]),
+		ForkVersion:           hex.EncodeToString(forkVersion[:]),
+	}
+}
+
+func writeJSON(t *testing.T, dir string, name string, data any) {
+	t.Helper()
+
+	output, err := json.Marshal(data)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), output, 0o600))
+}
+
+func TestScan(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	genesisForkVersion := phase0.Version{0x00, 0x00, 0x00, 0x01}
+	bellatrixForkVersion := phase0.Version{0x02, 0x00, 0x00, 0x01}
+	capellaForkVersion := phase0.Version{0x03, 0x00, 0x00, 0x01}
+	genesisValidatorsRoot := phase0.Root{0x01}
+
+	keys := make([]*e2types.BLSPrivateKey, 6)
+	for i := range keys {
+		var err error
+		keys[i], err = e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+	}
+
+	chainInfo := &beacon.ChainInfo{
+		Validators:                     make([]*beacon.ValidatorInfo, 0),
+		GenesisValidatorsRoot:          genesisValidatorsRoot,
+		GenesisForkVersion:             genesisForkVersion,
+		CurrentForkVersion:             capellaForkVersion,
+		ExitForkVersion:                capellaForkVersion,
+		BLSToExecutionChangeDomainType: phase0.DomainType(e2types.DomainBlsToExecutionChange),
+		VoluntaryExitDomainType:        phase0.DomainType(e2types.DomainVoluntaryExit),
+	}
+	for i := 0; i < 5; i++ {
+		validator := &beacon.ValidatorInfo{
+			Index:                 phase0.ValidatorIndex(i),
+			State:                 apiv1.ValidatorStateActiveOngoing,
+			WithdrawalCredentials: make([]byte, 32),
+		}
+		copy(validator.Pubkey[:], keys[i].PublicKey().Marshal())
+		// Validators 0 and 1 have execution credentials, the others BLS credentials.
+		if i < 2 {
+			validator.WithdrawalCredentials[0] = 0x01
+		} else {
+			hash := sha256.Sum256(validator.Pubkey[:])
+			copy(validator.WithdrawalCredentials[1:], hash[1:])
+		}
+		chainInfo.Validators = append(chainInfo.Validators, validator)
+	}
+	chainInfo.Validators[4].State = apiv1.ValidatorStateExitedUnslashed
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "old"), 0o700))
+	writeJSON(t, dir, "exit-operations.json", []*phase0.SignedVoluntaryExit{
+		signedExit(t, keys[0], 0, capellaForkVersion, genesisValidatorsRoot),
+		signedExit(t, keys[4], 4, capellaForkVersion, genesisValidatorsRoot),
+		signedExit(t, keys[0], 1, capellaForkVersion, genesisValidatorsRoot),
+	})
+	writeJSON(t, dir, "old/exit.json", signedExit(t, keys[1], 1, bellatrixForkVersion, genesisValidatorsRoot))
+	writeJSON(t, dir, "change-operations.json", []*capella.SignedBLSToExecutionChange{
+		signedChange(t, keys[2], 2, genesisForkVersion, genesisValidatorsRoot),
+		signedChange(t, keys[0], 0, genesisForkVersion, genesisValidatorsRoot),
+		signedChange(t, keys[2], 3, genesisForkVersion, genesisValidatorsRoot),
+	})
+	tagged, err := json.Marshal(signedChange(t, keys[3], 3, genesisForkVersion, genesisValidatorsRoot))
+	require.NoError(t, err)
+	tagged, err = beacon.AddNetworkTag(tagged, phase0.Root{0x02})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other-network.json"), tagged, 0o600))
+	writeJSON(t, dir, "deposit_data.json", []*depositData{
+		deposit(t, keys[5], genesisForkVersion),
+		deposit(t, keys[0], genesisForkVersion),
+		deposit(t, keys[5], bellatrixForkVersion),
+	})
+	writeJSON(t, dir, "offline-preparation.json", chainInfo)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+
+	c := &command{
+		directory: dir,
+		chainInfo: chainInfo,
+		forkVersions: []phase0.Version{
+			genesisForkVersion,
+			bellatrixForkVersion,
+			capellaForkVersion,
+		},
+	}
+	require.NoError(t, c.scan(ctx))
+
+	type summary struct {
+		file      string
+		artifact  artifactType
+		validator string
+		status    artifactStatus
+		detail    string
+	}
+	summaries := make([]summary, 0, len(c.artifacts))
+	for _, artifact := range c.artifacts {
+		file, err := filepath.Rel(dir, artifact.File)
+		require.NoError(t, err)
+		summaries = append(summaries, summary{
+			file:      filepath.ToSlash(file),
+			artifact:  artifact.Type,
+			validator: artifact.Validator,
+			status:    artifact.Status,
+			detail:    artifact.Detail,
+		})
+	}
+	require.Equal(t, []summary{
+		{"change-operations.json", artifactTypeBLSToExecutionChange, "2", artifactStatusValid, ""},
+		{"change-operations.json", artifactTypeBLSToExecutionChange, "0", artifactStatusStale, fmt.Sprintf("validator already has withdrawal credentials %#x", chainInfo.Validators[0].WithdrawalCredentials)},
+		{"change-operations.json", artifactTypeBLSToExecutionChange, "3", artifactStatusInvalid, "from BLS public key does not match withdrawal credentials"},
+		{"deposit_data.json", artifactTypeDeposit, fmt.Sprintf("%#x", keys[5].PublicKey().Marshal()), artifactStatusValid, ""},
+		{"deposit_data.json", artifactTypeDeposit, "0", artifactStatusStale, "validator has already been deposited"},
+		{"deposit_data.json", artifactTypeDeposit, fmt.Sprintf("%#x", keys[5].PublicKey().Marshal()), artifactStatusInvalid, "deposit is for fork version 0x02000001 rather than 0x00000001"},
+		{"exit-operations.json", artifactTypeExit, "0", artifactStatusValid, ""},
+		{"exit-operations.json", artifactTypeExit, "4", artifactStatusStale, "validator is exited_unslashed"},
+		{"exit-operations.json", artifactTypeExit, "1", artifactStatusInvalid, "signature does not verify"},
+		{"old/exit.json", artifactTypeExit, "1", artifactStatusStale, "signed for fork version 0x02000001 rather than 0x03000001"},
+		{"other-network.json", artifactTypeBLSToExecutionChange, "3", artifactStatusInvalid, "operation is for network 0x0200000000000000000000000000000000000000000000000000000000000000"},
+	}, summaries)
+	require.Equal(t, []string{filepath.Join(dir, "offline-preparation.json")}, c.unrecognised)
+
+	c.calculateCoverageFor([]phase0.ValidatorIndex{3, 2, 1, 0})
+	require.Len(t, c.coverage, 2)
+	require.Equal(t, phase0.ValidatorIndex(0), c.coverage[0].Validator)
+	require.Equal(t, []artifactType{artifactTypeExit}, c.coverage[0].Types)
+	require.Equal(t, phase0.ValidatorIndex(2), c.coverage[1].Validator)
+	require.Equal(t, []artifactType{artifactTypeBLSToExecutionChange}, c.coverage[1].Types)
+	require.Equal(t, []phase0.ValidatorIndex{1, 3}, c.missing)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected artifactType
+	}{
+		{
+			name:  "NotObject",
+			input: `"exit"`,
+		},
+		{
+			name:     "Exit",
+			input:    `{"message":{"epoch":"1","validator_index":"2"},"signature":"0x00"}`,
+			expected: artifactTypeExit,
+		},
+		{
+			name:     "BLSToExecutionChange",
+			input:    `{"message":{"validator_index":"2","from_bls_pubkey":"0x00","to_execution_address":"0x00"},"signature":"0x00"}`,
+			expected: artifactTypeBLSToExecutionChange,
+		},
+		{
+			name:     "Deposit",
+			input:    `{"pubkey":"00","withdrawal_credentials":"00","amount":32000000000,"signature":"00"}`,
+			expected: artifactTypeDeposit,
+		},
+		{
+			name:  "UnknownMessage",
+			input: `{"message":{"slot":"1"},"signature":"0x00"}`,
+		},
+		{
+			name:  "Unknown",
+			input: `{"version":"2","validators":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, classify(json.RawMessage(test.input)))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactinventory
+
+import (
+	"github.com/wealdtech/ethdo/util"
+)
+
+// schemaVersion is the version of the JSON output of this command.  It must be
+// increased whenever a change is made to the output that is not backwards-compatible.
+const schemaVersion = 1
+
+// Schema returns the JSON schema for the JSON output of this command.
+func Schema() (*util.JSONSchema, error) {
+	return util.GenerateJSONSchema("artifact/inventory", schemaVersion, &jsonOutput{})
+}
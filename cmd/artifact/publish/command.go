@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactpublish
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet      bool
+	verbose    bool
+	debug      bool
+	jsonOutput bool
+
+	// Input.
+	file        string
+	destination string
+	ipfsAPI     string
+	passphrase  string
+	timeout     time.Duration
+
+	// Output.
+	location  string
+	digest    [32]byte
+	size      int
+	encrypted bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		jsonOutput:  viper.GetBool("json"),
+		timeout:     viper.GetDuration("timeout"),
+		file:        viper.GetString("file"),
+		destination: viper.GetString("destination"),
+		ipfsAPI:     viper.GetString("ipfs-api"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	if c.destination == "" {
+		return nil, errors.New("destination is required")
+	}
+	if !strings.EqualFold(c.destination, "ipfs") &&
+		!strings.HasPrefix(c.destination, "http://") &&
+		!strings.HasPrefix(c.destination, "https://") {
+		return nil, errors.New(`destination must be an HTTP(S) URL or "ipfs"`)
+	}
+
+	var err error
+	c.passphrase, err = util.GetOptionalPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
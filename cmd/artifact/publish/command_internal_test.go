@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactpublish
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"file":        "exits.json",
+				"destination": "ipfs",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"destination": "ipfs",
+			},
+			err: "file is required",
+		},
+		{
+			name: "DestinationMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"file":    "exits.json",
+			},
+			err: "destination is required",
+		},
+		{
+			name: "DestinationInvalid",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"file":        "exits.json",
+				"destination": "ftp://example.com/exits.json",
+			},
+			err: `destination must be an HTTP(S) URL or "ipfs"`,
+		},
+		{
+			name: "MultiplePassphrases",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"file":        "exits.json",
+				"destination": "ipfs",
+				"passphrase":  []string{"a", "b"},
+			},
+			err: "multiple passphrases supplied",
+		},
+		{
+			name: "GoodIPFS",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"file":        "exits.json",
+				"destination": "ipfs",
+			},
+		},
+		{
+			name: "GoodHTTP",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"file":        "exits.json",
+				"destination": "https://example.com/exits.json",
+				"passphrase":  "secret",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
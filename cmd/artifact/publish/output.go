@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactpublish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Location  string `json:"location"`
+	Digest    string `json:"digest"`
+	Size      int    `json:"size"`
+	Encrypted bool   `json:"encrypted"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Location:  c.location,
+		Digest:    fmt.Sprintf("%#x", c.digest),
+		Size:      c.size,
+		Encrypted: c.encrypted,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("Location: ")
+	builder.WriteString(c.location)
+	builder.WriteString("\n")
+
+	builder.WriteString(fmt.Sprintf("Digest: %#x\n", c.digest))
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Size: %d bytes\n", c.size))
+		builder.WriteString(fmt.Sprintf("Encrypted: %t\n", c.encrypted))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
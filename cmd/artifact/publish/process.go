@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactpublish
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-ecodec"
+)
+
+func (c *command) process(ctx context.Context) error {
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to read artifact")
+	}
+
+	if c.passphrase != "" {
+		data, err = ecodec.Encrypt(data, []byte(c.passphrase))
+		if err != nil {
+			return errors.Wrap(err, "failed to encrypt artifact")
+		}
+		c.encrypted = true
+	}
+
+	// The digest covers the data as published, so it can be verified before decryption.
+	c.digest = sha256.Sum256(data)
+	c.size = len(data)
+
+	if strings.EqualFold(c.destination, "ipfs") {
+		cid, err := util.PublishArtifactIPFS(ctx, c.ipfsAPI, c.timeout, filepath.Base(c.file), data)
+		if err != nil {
+			return errors.Wrap(err, "failed to publish artifact to IPFS")
+		}
+		c.location = util.IPFSLocation(cid)
+
+		return nil
+	}
+
+	if err := util.PublishArtifactHTTP(ctx, c.destination, c.timeout, data); err != nil {
+		return errors.Wrap(err, "failed to publish artifact")
+	}
+	c.location = c.destination
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactpublish
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-ecodec"
+)
+
+func TestProcess(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v0/add" {
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received, _ = io.ReadAll(file)
+			_, _ = w.Write([]byte(`{"Hash":"bafkreitest"}`))
+			return
+		}
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "exits.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"exits":[],"version":1}`), 0o600))
+
+	t.Run("HTTP", func(t *testing.T) {
+		c := &command{
+			timeout:     time.Second,
+			file:        file,
+			destination: server.URL + "/exits.json",
+		}
+		require.NoError(t, c.process(context.Background()))
+		require.Equal(t, server.URL+"/exits.json", c.location)
+		require.Equal(t, []byte(`{"exits":[],"version":1}`), received)
+		require.Equal(t, sha256.Sum256(received), c.digest)
+		require.False(t, c.encrypted)
+	})
+
+	t.Run("IPFSEncrypted", func(t *testing.T) {
+		c := &command{
+			timeout:     time.Second,
+			file:        file,
+			destination: "ipfs",
+			ipfsAPI:     server.URL,
+			passphrase:  "secret",
+		}
+		require.NoError(t, c.process(context.Background()))
+		require.Equal(t, "ipfs://bafkreitest", c.location)
+		require.True(t, c.encrypted)
+		require.Equal(t, sha256.Sum256(received), c.digest)
+
+		decrypted, err := ecodec.Decrypt(received, []byte("secret"))
+		require.NoError(t, err)
+		require.Equal(t, []byte(`{"exits":[],"version":1}`), decrypted)
+	})
+
+	t.Run("FileMissing", func(t *testing.T) {
+		c := &command{
+			timeout:     time.Second,
+			file:        filepath.Join(t.TempDir(), "missing.json"),
+			destination: server.URL,
+		}
+		require.ErrorContains(t, c.process(context.Background()), "failed to read artifact")
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	artifactfetch "github.com/wealdtech/ethdo/cmd/artifact/fetch"
+)
+
+var artifactFetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Fetch and verify a published artifact",
+	Long: `Fetch an artifact published with "ethdo artifact publish" and verify it against its digest.  For example:
+
+    ethdo artifact fetch --source=ipfs://bafkrei... --digest=0x... --passphrase=secret --file=exit-operations.json
+
+The source can be an HTTP(S) URL, an IPFS location or a local file.  If a passphrase is supplied the artifact is decrypted after it has been verified.  If no file is supplied the artifact is written to the console.
+
+In quiet mode this will return 0 if the artifact is fetched and verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := artifactfetch.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactFetchCmd)
+	artifactFetchCmd.Flags().String("source", "", "the location of the artifact")
+	artifactFetchCmd.Flags().String("digest", "", "the expected digest of the artifact")
+	artifactFetchCmd.Flags().String("file", "", "the file to which to write the artifact")
+	artifactFetchCmd.Flags().String("ipfs-gateway", "http://localhost:8080", "the URL of the IPFS gateway used to fetch IPFS artifacts")
+}
+
+func artifactFetchBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("source", cmd.Flags().Lookup("source")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("digest", cmd.Flags().Lookup("digest")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ipfs-gateway", cmd.Flags().Lookup("ipfs-gateway")); err != nil {
+		panic(err)
+	}
+}
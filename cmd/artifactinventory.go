@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	artifactinventory "github.com/wealdtech/ethdo/cmd/artifact/inventory"
+)
+
+var artifactInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Inventory a directory of pre-signed artifacts",
+	Long: `Scan a directory of previously generated artifacts and report on their state.  For example:
+
+    ethdo artifact inventory --directory=/path/to/artifacts --validators=1000-1099
+
+Pre-signed exits, BLS to execution changes and deposit data are recognised.  Each operation is checked against the chain and reported as valid, stale (for example an exit signed for the wrong fork, or a BLS change for a validator that already has execution credentials) or invalid.  If validators are supplied the report also shows which of them have no valid artifact.
+
+In quiet mode this will return 0 if all artifacts are valid and all supplied validators are covered, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := artifactinventory.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactInventoryCmd)
+	artifactInventoryCmd.Flags().String("directory", "", "the directory containing the artifacts")
+	artifactInventoryCmd.Flags().StringSlice("validators", nil, "the indices, index ranges, public keys or accounts of validators that should have artifacts")
+}
+
+func artifactInventoryBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("directory", cmd.Flags().Lookup("directory")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
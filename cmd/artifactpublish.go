@@ -0,0 +1,66 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	artifactpublish "github.com/wealdtech/ethdo/cmd/artifact/publish"
+)
+
+var artifactPublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish an artifact to an HTTP endpoint or IPFS",
+	Long: `Publish a generated artifact, such as deposit data, pre-signed exits or credential changes, to an HTTP endpoint or IPFS.  For example:
+
+    ethdo artifact publish --file=exit-operations.json --destination=ipfs --passphrase=secret
+
+If a passphrase is supplied the artifact is encrypted before it is published.  The location and digest of the published artifact are printed, and can be passed to "ethdo artifact fetch" to retrieve and verify the artifact.
+
+In quiet mode this will return 0 if the artifact is published, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := artifactpublish.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	artifactCmd.AddCommand(artifactPublishCmd)
+	artifactPublishCmd.Flags().String("file", "", "the file containing the artifact to publish")
+	artifactPublishCmd.Flags().String("destination", "", `the URL to which to publish the artifact, or "ipfs" to publish to IPFS`)
+	artifactPublishCmd.Flags().String("ipfs-api", "http://localhost:5001", "the URL of the HTTP API of the IPFS node")
+}
+
+func artifactPublishBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("destination", cmd.Flags().Lookup("destination")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ipfs-api", cmd.Flags().Lookup("ipfs-api")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinclusion
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validator  string
+	epoch      string
+	jsonOutput bool
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	validatorsProvider         eth2client.ValidatorsProvider
+	attesterDutiesProvider     eth2client.AttesterDutiesProvider
+	signedBeaconBlockProvider  eth2client.SignedBeaconBlockProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+	beaconCommitteesProvider   eth2client.BeaconCommitteesProvider
+	// committeeSizes are the sizes of the committees at the slot of the duty.
+	committeeSizes map[phase0.CommitteeIndex]uint64
+
+	// Results.
+	duty      *apiv1.AttesterDuty
+	inclusion *inclusion
+	reward    *util.AttestationReward
+	// rewardErr is the reason that the reward could not be obtained.
+	rewardErr string
+}
+
+// inclusion is the inclusion of an attestation in a block.
+type inclusion struct {
+	slot             phase0.Slot
+	attestationIndex int
+	distance         phase0.Slot
+	headCorrect      bool
+	headTimely       bool
+	targetCorrect    bool
+	targetTimely     bool
+	sourceTimely     bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+	c.epoch = viper.GetString("epoch")
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinclusion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	ValidatorIndex    uint64      `json:"validator_index"`
+	Slot              uint64      `json:"slot"`
+	CommitteeIndex    uint64      `json:"committee_index"`
+	Included          bool        `json:"included"`
+	InclusionSlot     uint64      `json:"inclusion_slot,omitempty"`
+	AttestationIndex  int         `json:"attestation_index,omitempty"`
+	InclusionDistance uint64      `json:"inclusion_distance,omitempty"`
+	HeadCorrect       bool        `json:"head_correct"`
+	HeadTimely        bool        `json:"head_timely"`
+	TargetCorrect     bool        `json:"target_correct"`
+	TargetTimely      bool        `json:"target_timely"`
+	SourceCorrect     bool        `json:"source_correct"`
+	SourceTimely      bool        `json:"source_timely"`
+	Reward            *rewardJSON `json:"reward,omitempty"`
+	RewardError       string      `json:"reward_error,omitempty"`
+}
+
+type rewardJSON struct {
+	Head           int64  `json:"head"`
+	Target         int64  `json:"target"`
+	Source         int64  `json:"source"`
+	InclusionDelay *int64 `json:"inclusion_delay,omitempty"`
+	Inactivity     int64  `json:"inactivity"`
+	Total          int64  `json:"total"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		ValidatorIndex: uint64(c.duty.ValidatorIndex),
+		Slot:           uint64(c.duty.Slot),
+		CommitteeIndex: uint64(c.duty.CommitteeIndex),
+		Included:       c.inclusion != nil,
+		RewardError:    c.rewardErr,
+	}
+	if c.inclusion != nil {
+		output.InclusionSlot = uint64(c.inclusion.slot)
+		output.AttestationIndex = c.inclusion.attestationIndex
+		output.InclusionDistance = uint64(c.inclusion.distance)
+		output.HeadCorrect = c.inclusion.headCorrect
+		output.HeadTimely = c.inclusion.headTimely
+		output.TargetCorrect = c.inclusion.targetCorrect
+		output.TargetTimely = c.inclusion.targetTimely
+		// An attestation with an incorrect source cannot be included.
+		output.SourceCorrect = true
+		output.SourceTimely = c.inclusion.sourceTimely
+	}
+	if c.reward != nil {
+		output.Reward = &rewardJSON{
+			Head:           c.reward.Head,
+			Target:         c.reward.Target,
+			Source:         c.reward.Source,
+			InclusionDelay: c.reward.InclusionDelay,
+			Inactivity:     c.reward.Inactivity,
+			Total:          c.reward.Total(),
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validator: %d\n", c.duty.ValidatorIndex))
+	builder.WriteString(fmt.Sprintf("Attestation duty: slot %d, committee %d\n", c.duty.Slot, c.duty.CommitteeIndex))
+	if c.inclusion == nil {
+		builder.WriteString("Attestation not included\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("Included in block at slot %d, attestation %d\n", c.inclusion.slot, c.inclusion.attestationIndex))
+		builder.WriteString(fmt.Sprintf("Inclusion distance: %d\n", c.inclusion.distance))
+		builder.WriteString(fmt.Sprintf("Head vote: %s\n", voteState(c.inclusion.headCorrect, c.inclusion.headTimely)))
+		builder.WriteString(fmt.Sprintf("Target vote: %s\n", voteState(c.inclusion.targetCorrect, c.inclusion.targetTimely)))
+		builder.WriteString(fmt.Sprintf("Source vote: %s\n", voteState(true, c.inclusion.sourceTimely)))
+	}
+
+	switch {
+	case c.reward != nil:
+		builder.WriteString(fmt.Sprintf("Reward: %d Gwei", c.reward.Total()))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (head %d, target %d, source %d", c.reward.Head, c.reward.Target, c.reward.Source))
+			if c.reward.InclusionDelay != nil {
+				builder.WriteString(fmt.Sprintf(", inclusion delay %d", *c.reward.InclusionDelay))
+			}
+			builder.WriteString(fmt.Sprintf(", inactivity %d)", c.reward.Inactivity))
+		}
+		builder.WriteString("\n")
+	case c.verbose:
+		builder.WriteString(fmt.Sprintf("Reward: unavailable (%s)\n", c.rewardErr))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// voteState describes the state of a vote.
+func voteState(correct bool, timely bool) string {
+	switch {
+	case !correct:
+		return "incorrect"
+	case !timely:
+		return "correct, late"
+	default:
+		return "correct"
+	}
+}
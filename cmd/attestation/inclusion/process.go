@@ -0,0 +1,256 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinclusion
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return err
+	}
+
+	dutiesResponse, err := c.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: []phase0.ValidatorIndex{validator.Index}})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attester duties")
+	}
+	duties := dutiesResponse.Data
+	if len(duties) == 0 {
+		return fmt.Errorf("validator does not have an attester duty in epoch %d", epoch)
+	}
+	c.duty = duties[0]
+
+	c.inclusion, err = c.findInclusion(ctx, epoch)
+	if err != nil {
+		return err
+	}
+
+	rewards, found, err := util.AttestationRewards(ctx, c.eth2Client, c.timeout, epoch, []phase0.ValidatorIndex{validator.Index})
+	switch {
+	case err != nil:
+		c.rewardErr = err.Error()
+	case !found || len(rewards) == 0:
+		c.rewardErr = "not reported by node"
+	default:
+		c.reward = rewards[0]
+	}
+	if c.rewardErr != "" && c.debug {
+		fmt.Fprintf(os.Stderr, "Failed to obtain attestation reward: %s\n", c.rewardErr)
+	}
+
+	return nil
+}
+
+// findInclusion finds the block that includes the attestation for the duty,
+// returning nil if the attestation has not been included.
+func (c *command) findInclusion(ctx context.Context, epoch phase0.Epoch) (*inclusion, error) {
+	// Attestations can be included up to the end of the following epoch.
+	lastSlot := c.chainTime.LastSlotOfEpoch(epoch + 1)
+	if currentSlot := c.chainTime.CurrentSlot(); lastSlot > currentSlot {
+		lastSlot = currentSlot
+	}
+
+	for slot := c.duty.Slot + 1; slot <= lastSlot; slot++ {
+		blockSlot, attestations, found, err := util.BlockAttestations(ctx, c.eth2Client, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block at slot %d", slot))
+		}
+		if !found || blockSlot != slot {
+			// Empty slot.
+			continue
+		}
+		index, err := locateAttestation(attestations, c.duty, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+			return c.committeeSize(ctx, committeeIndex)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if index == -1 {
+			continue
+		}
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Attestation found in block at slot %d, index %d\n", slot, index)
+		}
+
+		res := &inclusion{
+			slot:             slot,
+			attestationIndex: index,
+			distance:         slot - c.duty.Slot,
+		}
+		attestation := attestations[index]
+		headRoot, err := c.canonicalRoot(ctx, attestation.Data.Slot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain head vote root")
+		}
+		res.headCorrect = headRoot == attestation.Data.BeaconBlockRoot
+		targetRoot, err := c.canonicalRoot(ctx, c.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain target vote root")
+		}
+		res.targetCorrect = targetRoot == attestation.Data.Target.Root
+		assessTimeliness(res, c.chainTime.SlotsPerEpoch(), epoch >= c.chainTime.DenebInitialEpoch())
+
+		return res, nil
+	}
+
+	return nil, nil
+}
+
+// locateAttestation returns the index of the attestation that contains the
+// vote for the given duty, or -1 if there is no such attestation.
+func locateAttestation(attestations []*util.BlockAttestation,
+	duty *apiv1.AttesterDuty,
+	committeeSize func(phase0.CommitteeIndex) (uint64, error),
+) (
+	int,
+	error,
+) {
+	for i, attestation := range attestations {
+		if attestation.Data.Slot != duty.Slot {
+			continue
+		}
+		attested, err := attestation.Attested(duty.CommitteeIndex, duty.ValidatorCommitteeIndex, committeeSize)
+		if err != nil {
+			return -1, err
+		}
+		if attested {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// committeeSize returns the size of the given committee at the slot of the
+// duty.  This is required to locate votes in attestations from Electra
+// onwards, which can cover multiple committees.
+func (c *command) committeeSize(ctx context.Context, committeeIndex phase0.CommitteeIndex) (uint64, error) {
+	if c.committeeSizes == nil {
+		committeesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", c.duty.Slot)})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to obtain beacon committees")
+		}
+		committees := committeesResponse.Data
+		c.committeeSizes = make(map[phase0.CommitteeIndex]uint64)
+		for _, committee := range committees {
+			if committee.Slot == c.duty.Slot {
+				c.committeeSizes[committee.Index] = uint64(len(committee.Validators))
+			}
+		}
+	}
+
+	size, exists := c.committeeSizes[committeeIndex]
+	if !exists {
+		return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, c.duty.Slot)
+	}
+
+	return size, nil
+}
+
+// assessTimeliness sets the timeliness of each vote of the included
+// attestation, according to its inclusion distance.
+func assessTimeliness(res *inclusion, slotsPerEpoch uint64, deneb bool) {
+	res.sourceTimely = uint64(res.distance) <= uint64(math.Sqrt(float64(slotsPerEpoch)))
+	// From Deneb the target vote is timely whenever the attestation is included.
+	res.targetTimely = res.targetCorrect && (deneb || uint64(res.distance) <= slotsPerEpoch)
+	res.headTimely = res.headCorrect && res.distance == 1
+}
+
+// canonicalRoot returns the root of the canonical block at the given slot or,
+// if the slot is empty, the most recent canonical block before it.
+func (c *command) canonicalRoot(ctx context.Context, slot phase0.Slot) (phase0.Root, error) {
+	for {
+		header, err := util.ResponseData(c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return phase0.Root{}, err
+		}
+		if header != nil && header.Canonical {
+			return header.Root, nil
+		}
+		if slot == 0 {
+			return phase0.Root{}, errors.New("no canonical block found")
+		}
+		slot--
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committees")
+	}
+
+	return nil
+}
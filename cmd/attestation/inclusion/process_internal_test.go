@@ -0,0 +1,252 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinclusion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func testAttestation(slot phase0.Slot, index phase0.CommitteeIndex, bits ...uint64) *util.BlockAttestation {
+	aggregationBits := bitfield.NewBitlist(8)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return util.NewBlockAttestation(&phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Index:  index,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	})
+}
+
+// testElectraAttestation creates an attestation covering committees of 8
+// members each.
+func testElectraAttestation(slot phase0.Slot, committeeIndices []phase0.CommitteeIndex, bits ...uint64) *util.BlockAttestation {
+	aggregationBits := bitfield.NewBitlist(uint64(8 * len(committeeIndices)))
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return &util.BlockAttestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+		CommitteeIndices: committeeIndices,
+	}
+}
+
+func testCommitteeSize(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+	if committeeIndex > 4 {
+		return 0, fmt.Errorf("no committee %d", committeeIndex)
+	}
+
+	return 8, nil
+}
+
+func TestLocateAttestation(t *testing.T) {
+	duty := &apiv1.AttesterDuty{
+		Slot:                    100,
+		CommitteeIndex:          2,
+		ValidatorCommitteeIndex: 3,
+	}
+
+	tests := []struct {
+		name         string
+		attestations []*util.BlockAttestation
+		expected     int
+		err          string
+	}{
+		{
+			name:     "Empty",
+			expected: -1,
+		},
+		{
+			name: "Found",
+			attestations: []*util.BlockAttestation{
+				testAttestation(99, 2, 3),
+				testAttestation(100, 1, 3),
+				testAttestation(100, 2, 1, 2),
+				testAttestation(100, 2, 3, 4),
+			},
+			expected: 3,
+		},
+		{
+			name: "NotSet",
+			attestations: []*util.BlockAttestation{
+				testAttestation(100, 2, 1, 2),
+			},
+			expected: -1,
+		},
+		{
+			name: "Electra",
+			attestations: []*util.BlockAttestation{
+				testElectraAttestation(100, []phase0.CommitteeIndex{2, 3}, 11),
+				testElectraAttestation(100, []phase0.CommitteeIndex{0, 2}, 11),
+			},
+			expected: 1,
+		},
+		{
+			name: "ElectraNotSet",
+			attestations: []*util.BlockAttestation{
+				testElectraAttestation(100, []phase0.CommitteeIndex{0, 2}, 3),
+			},
+			expected: -1,
+		},
+		{
+			name: "ElectraCommitteeSizeError",
+			attestations: []*util.BlockAttestation{
+				testElectraAttestation(100, []phase0.CommitteeIndex{5, 2}, 11),
+			},
+			err: "failed to obtain size of committee 5: no committee 5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := locateAttestation(test.attestations, duty, testCommitteeSize)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestAssessTimeliness(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *inclusion
+		deneb    bool
+		expected *inclusion
+	}{
+		{
+			name:     "Optimal",
+			input:    &inclusion{distance: 1, headCorrect: true, targetCorrect: true},
+			expected: &inclusion{distance: 1, headCorrect: true, headTimely: true, targetCorrect: true, targetTimely: true, sourceTimely: true},
+		},
+		{
+			name:     "HeadLate",
+			input:    &inclusion{distance: 2, headCorrect: true, targetCorrect: true},
+			expected: &inclusion{distance: 2, headCorrect: true, targetCorrect: true, targetTimely: true, sourceTimely: true},
+		},
+		{
+			name:     "SourceLate",
+			input:    &inclusion{distance: 6, headCorrect: true, targetCorrect: true},
+			expected: &inclusion{distance: 6, headCorrect: true, targetCorrect: true, targetTimely: true},
+		},
+		{
+			name:     "TargetLate",
+			input:    &inclusion{distance: 40, headCorrect: true, targetCorrect: true},
+			expected: &inclusion{distance: 40, headCorrect: true, targetCorrect: true},
+		},
+		{
+			name:     "TargetLateDeneb",
+			input:    &inclusion{distance: 40, headCorrect: true, targetCorrect: true},
+			deneb:    true,
+			expected: &inclusion{distance: 40, headCorrect: true, targetCorrect: true, targetTimely: true},
+		},
+		{
+			name:     "Incorrect",
+			input:    &inclusion{distance: 1},
+			expected: &inclusion{distance: 1, sourceTimely: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assessTimeliness(test.input, 32, test.deneb)
+			require.Equal(t, test.expected, test.input)
+		})
+	}
+}
+
+func TestOutputTxt(t *testing.T) {
+	duty := &apiv1.AttesterDuty{
+		ValidatorIndex: 1234,
+		Slot:           100,
+		CommitteeIndex: 2,
+	}
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected string
+	}{
+		{
+			name: "Included",
+			command: &command{
+				duty:      duty,
+				inclusion: &inclusion{slot: 102, attestationIndex: 5, distance: 2, headCorrect: true, targetCorrect: true, targetTimely: true, sourceTimely: true},
+				reward:    &util.AttestationReward{Head: 0, Target: 4000, Source: 2000},
+			},
+			expected: `Validator: 1234
+Attestation duty: slot 100, committee 2
+Included in block at slot 102, attestation 5
+Inclusion distance: 2
+Head vote: correct, late
+Target vote: correct
+Source vote: correct
+Reward: 6000 Gwei`,
+		},
+		{
+			name: "Missed",
+			command: &command{
+				verbose: true,
+				duty:    duty,
+				reward:  &util.AttestationReward{Target: -4000, Source: -2000},
+			},
+			expected: `Validator: 1234
+Attestation duty: slot 100, committee 2
+Attestation not included
+Reward: -6000 Gwei (head 0, target -4000, source -2000, inactivity 0)`,
+		},
+		{
+			name: "NoReward",
+			command: &command{
+				verbose:   true,
+				duty:      duty,
+				rewardErr: "not reported by node",
+			},
+			expected: `Validator: 1234
+Attestation duty: slot 100, committee 2
+Attestation not included
+Reward: unavailable (not reported by node)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.outputTxt(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
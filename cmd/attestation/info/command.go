@@ -0,0 +1,103 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinfo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	blockID        string
+	committeeIndex *phase0.CommitteeIndex
+	aggregate      bool
+	format         output.Format
+
+	// Data access.
+	eth2Client                eth2client.Service
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+	beaconCommitteesProvider  eth2client.BeaconCommitteesProvider
+
+	// Results.
+	slot         phase0.Slot
+	attestations []*attestationInfo
+}
+
+// attestationInfo is the information about an attestation in a block.
+type attestationInfo struct {
+	index            int
+	data             *phase0.AttestationData
+	aggregationBits  bitfield.Bitlist
+	committeeIndices []phase0.CommitteeIndex
+	// validators are the validators represented in the aggregate, if requested.
+	validators []phase0.ValidatorIndex
+	// validatorsErr is the reason that the validators could not be resolved.
+	validatorsErr string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+	if viper.GetString("committee-index") != "" {
+		index, err := strconv.ParseUint(viper.GetString("committee-index"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid committee index")
+		}
+		committeeIndex := phase0.CommitteeIndex(index)
+		c.committeeIndex = &committeeIndex
+	}
+	c.aggregate = viper.GetBool("aggregate")
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
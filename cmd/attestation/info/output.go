@@ -0,0 +1,197 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type jsonOutput struct {
+	Slot         uint64             `json:"slot"`
+	Attestations []*attestationJSON `json:"attestations"`
+}
+
+type attestationJSON struct {
+	Index            int      `json:"index"`
+	Slot             uint64   `json:"slot"`
+	CommitteeIndices []uint64 `json:"committee_indices"`
+	AggregationBits  string   `json:"aggregation_bits"`
+	Attesters        uint64   `json:"attesters"`
+	CommitteeSize    uint64   `json:"committee_size"`
+	BeaconBlockRoot  string   `json:"beacon_block_root"`
+	SourceEpoch      uint64   `json:"source_epoch"`
+	SourceRoot       string   `json:"source_root"`
+	TargetEpoch      uint64   `json:"target_epoch"`
+	TargetRoot       string   `json:"target_root"`
+	Validators       []uint64 `json:"validators,omitempty"`
+	ValidatorsError  string   `json:"validators_error,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the attestations as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	output := &jsonOutput{
+		Slot:         uint64(c.slot),
+		Attestations: make([]*attestationJSON, 0, len(c.attestations)),
+	}
+	for _, attestation := range c.attestations {
+		committeeIndices := make([]uint64, 0, len(attestation.committeeIndices))
+		for _, index := range attestation.committeeIndices {
+			committeeIndices = append(committeeIndices, uint64(index))
+		}
+		var validators []uint64
+		if attestation.validators != nil {
+			validators = make([]uint64, 0, len(attestation.validators))
+			for _, validator := range attestation.validators {
+				validators = append(validators, uint64(validator))
+			}
+		}
+		output.Attestations = append(output.Attestations, &attestationJSON{
+			Index:            attestation.index,
+			Slot:             uint64(attestation.data.Slot),
+			CommitteeIndices: committeeIndices,
+			AggregationBits:  fmt.Sprintf("%#x", []byte(attestation.aggregationBits)),
+			Attesters:        attestation.aggregationBits.Count(),
+			CommitteeSize:    attestation.aggregationBits.Len(),
+			BeaconBlockRoot:  fmt.Sprintf("%#x", attestation.data.BeaconBlockRoot),
+			SourceEpoch:      uint64(attestation.data.Source.Epoch),
+			SourceRoot:       fmt.Sprintf("%#x", attestation.data.Source.Root),
+			TargetEpoch:      uint64(attestation.data.Target.Epoch),
+			TargetRoot:       fmt.Sprintf("%#x", attestation.data.Target.Root),
+			Validators:       validators,
+			ValidatorsError:  attestation.validatorsErr,
+		})
+	}
+
+	return json.Marshal(output)
+}
+
+// csvHeader is the header of the CSV output.
+var csvHeader = []string{
+	"slot",
+	"index",
+	"attestation_slot",
+	"committee_indices",
+	"attesters",
+	"committee_size",
+	"aggregation_bits",
+	"beacon_block_root",
+	"source_epoch",
+	"source_root",
+	"target_epoch",
+	"target_root",
+	"validators",
+}
+
+// CSVHeader returns the header of the CSV output.
+func (*command) CSVHeader() []string {
+	return csvHeader
+}
+
+// CSVRecords returns a record for each attestation.  Multiple committee
+// indices and validators are separated by spaces.  Validators are only
+// supplied when aggregates are resolved.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	records := make([][]string, 0, len(c.attestations))
+	for _, attestation := range c.attestations {
+		committeeIndices := make([]string, 0, len(attestation.committeeIndices))
+		for _, index := range attestation.committeeIndices {
+			committeeIndices = append(committeeIndices, fmt.Sprintf("%d", index))
+		}
+		records = append(records, []string{
+			fmt.Sprintf("%d", c.slot),
+			fmt.Sprintf("%d", attestation.index),
+			fmt.Sprintf("%d", attestation.data.Slot),
+			strings.Join(committeeIndices, " "),
+			fmt.Sprintf("%d", attestation.aggregationBits.Count()),
+			fmt.Sprintf("%d", attestation.aggregationBits.Len()),
+			fmt.Sprintf("%#x", []byte(attestation.aggregationBits)),
+			fmt.Sprintf("%#x", attestation.data.BeaconBlockRoot),
+			fmt.Sprintf("%d", attestation.data.Source.Epoch),
+			fmt.Sprintf("%#x", attestation.data.Source.Root),
+			fmt.Sprintf("%d", attestation.data.Target.Epoch),
+			fmt.Sprintf("%#x", attestation.data.Target.Root),
+			validatorsString(attestation.validators),
+		})
+	}
+
+	return records, nil
+}
+
+// RenderText renders the attestations as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.slot))
+	if c.committeeIndex != nil {
+		builder.WriteString(fmt.Sprintf("Attestations for committee %d: %d\n", *c.committeeIndex, len(c.attestations)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Attestations: %d\n", len(c.attestations)))
+	}
+
+	for _, attestation := range c.attestations {
+		builder.WriteString(fmt.Sprintf("Attestation %d:\n", attestation.index))
+		builder.WriteString(fmt.Sprintf("  Slot: %d\n", attestation.data.Slot))
+		builder.WriteString(fmt.Sprintf("  Committee indices: %s\n", committeeIndicesString(attestation.committeeIndices)))
+		builder.WriteString(fmt.Sprintf("  Attesters: %d/%d\n", attestation.aggregationBits.Count(), attestation.aggregationBits.Len()))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Aggregation bits: %#x\n", []byte(attestation.aggregationBits)))
+			builder.WriteString(fmt.Sprintf("  Beacon block root: %#x\n", attestation.data.BeaconBlockRoot))
+			builder.WriteString(fmt.Sprintf("  Source: epoch %d, root %#x\n", attestation.data.Source.Epoch, attestation.data.Source.Root))
+			builder.WriteString(fmt.Sprintf("  Target: epoch %d, root %#x\n", attestation.data.Target.Epoch, attestation.data.Target.Root))
+		}
+		if c.aggregate {
+			if attestation.validatorsErr != "" {
+				builder.WriteString(fmt.Sprintf("  Validators: unavailable (%s)\n", attestation.validatorsErr))
+			} else {
+				builder.WriteString(fmt.Sprintf("  Validators: %s\n", validatorsString(attestation.validators)))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// committeeIndicesString returns a comma-separated list of committee indices.
+func committeeIndicesString(indices []phase0.CommitteeIndex) string {
+	res := make([]string, 0, len(indices))
+	for _, index := range indices {
+		res = append(res, fmt.Sprintf("%d", index))
+	}
+
+	return strings.Join(res, ", ")
+}
+
+// validatorsString returns a space-separated list of validator indices.
+func validatorsString(validators []phase0.ValidatorIndex) string {
+	res := make([]string, 0, len(validators))
+	for _, validator := range validators {
+		res = append(res, fmt.Sprintf("%d", validator))
+	}
+
+	return strings.Join(res, " ")
+}
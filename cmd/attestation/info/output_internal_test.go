@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutputCSV(t *testing.T) {
+	aggregationBits := bitfield.NewBitlist(4)
+	aggregationBits.SetBitAt(0, true)
+	aggregationBits.SetBitAt(2, true)
+	data := &phase0.AttestationData{
+		Slot:            99,
+		BeaconBlockRoot: phase0.Root{0x01},
+		Source: &phase0.Checkpoint{
+			Epoch: 2,
+			Root:  phase0.Root{0x02},
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: 3,
+			Root:  phase0.Root{0x03},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected string
+	}{
+		{
+			name: "Empty",
+			command: &command{
+				slot: 100,
+			},
+			expected: "slot,index,attestation_slot,committee_indices,attesters,committee_size,aggregation_bits,beacon_block_root,source_epoch,source_root,target_epoch,target_root,validators",
+		},
+		{
+			name: "Attestations",
+			command: &command{
+				slot: 100,
+				attestations: []*attestationInfo{
+					{
+						index:            0,
+						data:             data,
+						aggregationBits:  aggregationBits,
+						committeeIndices: []phase0.CommitteeIndex{1, 5},
+						validators:       []phase0.ValidatorIndex{10, 12},
+					},
+				},
+			},
+			expected: "slot,index,attestation_slot,committee_indices,attesters,committee_size,aggregation_bits,beacon_block_root,source_epoch,source_root,target_epoch,target_root,validators\n" +
+				"100,0,99,1 5,2,4,0x15,0x0100000000000000000000000000000000000000000000000000000000000000,2,0x0200000000000000000000000000000000000000000000000000000000000000,3,0x0300000000000000000000000000000000000000000000000000000000000000,10 12",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output.Render(context.Background(), test.command, output.CSV)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinfo
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	attestations, err := c.blockAttestations(ctx)
+	if err != nil {
+		return err
+	}
+	c.attestations = selectAttestations(attestations, c.committeeIndex)
+
+	if c.aggregate {
+		c.resolveValidators(ctx)
+	}
+
+	return nil
+}
+
+// blockAttestations obtains the attestations in the block.  From Electra a
+// single attestation can cover multiple committees.
+func (c *command) blockAttestations(ctx context.Context) ([]*attestationInfo, error) {
+	slot, blockAttestations, found, err := util.BlockAttestations(ctx, c.eth2Client, c.blockID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("empty beacon block")
+	}
+
+	c.slot = slot
+	attestations := make([]*attestationInfo, 0, len(blockAttestations))
+	for i, attestation := range blockAttestations {
+		attestations = append(attestations, &attestationInfo{
+			index:            i,
+			data:             attestation.Data,
+			aggregationBits:  attestation.AggregationBits,
+			committeeIndices: attestation.CommitteeIndices,
+		})
+	}
+
+	return attestations, nil
+}
+
+// selectAttestations selects the attestations that cover the given committee.
+// If no committee is given then all attestations are selected.
+func selectAttestations(attestations []*attestationInfo, committeeIndex *phase0.CommitteeIndex) []*attestationInfo {
+	if committeeIndex == nil {
+		return attestations
+	}
+
+	res := make([]*attestationInfo, 0)
+	for _, attestation := range attestations {
+		for _, index := range attestation.committeeIndices {
+			if index == *committeeIndex {
+				res = append(res, attestation)
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// resolveValidators resolves the aggregation bits of the attestations to the
+// validators represented in each aggregate, using the committees of their slots.
+func (c *command) resolveValidators(ctx context.Context) {
+	// Map is slot -> committee index -> validators.
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, attestation := range c.attestations {
+		slotCommittees, exists := committees[attestation.data.Slot]
+		if !exists {
+			var err error
+			slotCommittees, err = c.slotCommittees(ctx, attestation.data.Slot)
+			if err != nil {
+				attestation.validatorsErr = err.Error()
+				continue
+			}
+			committees[attestation.data.Slot] = slotCommittees
+		}
+
+		attestationCommittees := make([][]phase0.ValidatorIndex, 0, len(attestation.committeeIndices))
+		for _, index := range attestation.committeeIndices {
+			committee, exists := slotCommittees[index]
+			if !exists {
+				attestation.validatorsErr = fmt.Sprintf("committee %d not found at slot %d", index, attestation.data.Slot)
+				break
+			}
+			attestationCommittees = append(attestationCommittees, committee)
+		}
+		if attestation.validatorsErr != "" {
+			continue
+		}
+
+		validators, err := util.AttestationValidators(attestation.aggregationBits, attestationCommittees...)
+		if err != nil {
+			attestation.validatorsErr = err.Error()
+			continue
+		}
+		attestation.validators = validators
+	}
+}
+
+// slotCommittees obtains the committees for a slot.
+func (c *command) slotCommittees(ctx context.Context, slot phase0.Slot) (map[phase0.CommitteeIndex][]phase0.ValidatorIndex, error) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching committees for slot %d\n", slot)
+	}
+	beaconCommitteesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", slot)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	beaconCommittees := beaconCommitteesResponse.Data
+
+	res := make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	for _, beaconCommittee := range beaconCommittees {
+		if beaconCommittee.Slot == slot {
+			res[beaconCommittee.Index] = beaconCommittee.Validators
+		}
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+
+	return nil
+}
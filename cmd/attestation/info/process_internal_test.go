@@ -0,0 +1,188 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestationinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+// testCommitteesProvider provides fixed beacon committees.
+type testCommitteesProvider struct {
+	committees map[string][]*apiv1.BeaconCommittee
+}
+
+func (p *testCommitteesProvider) BeaconCommittees(_ context.Context,
+	opts *api.BeaconCommitteesOpts,
+) (
+	*api.Response[[]*apiv1.BeaconCommittee],
+	error,
+) {
+	committees, exists := p.committees[opts.State]
+	if !exists {
+		return nil, errors.New("state not found")
+	}
+
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data: committees,
+	}, nil
+}
+
+// bitlist creates a bitlist of the given length with the given bits set.
+func bitlist(length uint64, set ...uint64) bitfield.Bitlist {
+	res := bitfield.NewBitlist(length)
+	for _, i := range set {
+		res.SetBitAt(i, true)
+	}
+
+	return res
+}
+
+// testAttestation creates an attestation for the given slot and committees.
+func testAttestation(index int, slot phase0.Slot, bits bitfield.Bitlist, committeeIndices ...phase0.CommitteeIndex) *attestationInfo {
+	return &attestationInfo{
+		index: index,
+		data: &phase0.AttestationData{
+			Slot:   slot,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+		aggregationBits:  bits,
+		committeeIndices: committeeIndices,
+	}
+}
+
+func TestSelectAttestations(t *testing.T) {
+	attestations := []*attestationInfo{
+		testAttestation(0, 10, bitlist(4, 0), 0),
+		testAttestation(1, 10, bitlist(4, 1), 1),
+		testAttestation(2, 10, bitlist(8, 1), 0, 1),
+	}
+	committee0 := phase0.CommitteeIndex(0)
+	committee1 := phase0.CommitteeIndex(1)
+	committee2 := phase0.CommitteeIndex(2)
+
+	tests := []struct {
+		name           string
+		committeeIndex *phase0.CommitteeIndex
+		indices        []int
+	}{
+		{
+			name:    "All",
+			indices: []int{0, 1, 2},
+		},
+		{
+			name:           "Committee0",
+			committeeIndex: &committee0,
+			indices:        []int{0, 2},
+		},
+		{
+			name:           "Committee1",
+			committeeIndex: &committee1,
+			indices:        []int{1, 2},
+		},
+		{
+			name:           "None",
+			committeeIndex: &committee2,
+			indices:        []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := selectAttestations(attestations, test.committeeIndex)
+			indices := make([]int, 0, len(res))
+			for _, attestation := range res {
+				indices = append(indices, attestation.index)
+			}
+			require.Equal(t, test.indices, indices)
+		})
+	}
+}
+
+func TestResolveValidators(t *testing.T) {
+	provider := &testCommitteesProvider{
+		committees: map[string][]*apiv1.BeaconCommittee{
+			"10": {
+				{Slot: 10, Index: 0, Validators: []phase0.ValidatorIndex{100, 101, 102, 103}},
+				{Slot: 10, Index: 1, Validators: []phase0.ValidatorIndex{110, 111, 112, 113}},
+				{Slot: 11, Index: 0, Validators: []phase0.ValidatorIndex{200, 201, 202, 203}},
+			},
+		},
+	}
+	c := &command{
+		beaconCommitteesProvider: provider,
+		aggregate:                true,
+		slot:                     12,
+		attestations: []*attestationInfo{
+			testAttestation(0, 10, bitlist(4, 0, 2), 0),
+			testAttestation(1, 10, bitlist(8, 1, 4, 7), 0, 1),
+			testAttestation(2, 10, bitlist(3, 0), 1),
+			testAttestation(3, 10, bitlist(4, 0), 2),
+			testAttestation(4, 9, bitlist(4, 0), 0),
+		},
+	}
+	c.resolveValidators(context.Background())
+
+	require.Equal(t, []phase0.ValidatorIndex{100, 102}, c.attestations[0].validators)
+	require.Equal(t, []phase0.ValidatorIndex{101, 110, 113}, c.attestations[1].validators)
+	require.Equal(t, "aggregation bits cover 3 validators but committees contain 4", c.attestations[2].validatorsErr)
+	require.Equal(t, "committee 2 not found at slot 10", c.attestations[3].validatorsErr)
+	require.Equal(t, "failed to obtain beacon committees: state not found", c.attestations[4].validatorsErr)
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Slot: 12
+Attestations: 5
+Attestation 0:
+  Slot: 10
+  Committee indices: 0
+  Attesters: 2/4
+  Validators: 100 102
+Attestation 1:
+  Slot: 10
+  Committee indices: 0, 1
+  Attesters: 3/8
+  Validators: 101 110 113
+Attestation 2:
+  Slot: 10
+  Committee indices: 1
+  Attesters: 1/3
+  Validators: unavailable (aggregation bits cover 3 validators but committees contain 4)
+Attestation 3:
+  Slot: 10
+  Committee indices: 2
+  Attesters: 1/4
+  Validators: unavailable (committee 2 not found at slot 10)
+Attestation 4:
+  Slot: 9
+  Committee indices: 0
+  Attesters: 1/4
+  Validators: unavailable (failed to obtain beacon committees: state not found)`, res)
+
+	c.format = output.JSON
+	c.attestations = c.attestations[:1]
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`{"slot":12,"attestations":[{"index":0,"slot":10,"committee_indices":[0],"aggregation_bits":"0x15","attesters":2,"committee_size":4,"beacon_block_root":"%#x","source_epoch":0,"source_root":"%#x","target_epoch":0,"target_root":"%#x","validators":[100,102]}]}`, phase0.Root{}, phase0.Root{}, phase0.Root{}), res)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attestationinclusion "github.com/wealdtech/ethdo/cmd/attestation/inclusion"
+)
+
+var attestationInclusionCmd = &cobra.Command{
+	Use:   "inclusion",
+	Short: "Obtain information about the inclusion of a validator's attestation",
+	Long: `Obtain information about the inclusion of a validator's attestation in an epoch.  For example:
+
+    ethdo attestation inclusion --validator=Validators/1 --epoch=12345
+
+The block that included the attestation is reported, along with the inclusion distance, whether the head, target and source votes were correct and timely, and the resulting reward or penalty.
+
+In quiet mode this will return 0 if the attestation was included, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := attestationinclusion.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attestationCmd.AddCommand(attestationInclusionCmd)
+	attestationInclusionCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
+	attestationInclusionCmd.Flags().String("epoch", "-1", "the epoch of the attestation")
+}
+
+func attestationInclusionBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+}
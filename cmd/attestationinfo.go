@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attestationinfo "github.com/wealdtech/ethdo/cmd/attestation/info"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var attestationInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about the attestations in a block",
+	Long: `Obtain information about the attestations in a block.  For example:
+
+    ethdo attestation info --blockid=12345 --committee-index=3 --aggregate
+
+Attestations can be restricted to those that cover a single committee with --committee-index.  With --aggregate the aggregation bits of each attestation are resolved to the indices of the validators represented in the aggregate, using the committees of the attestation's slot.  From Electra an attestation can cover multiple committees, in which case the validators of all of its committees are resolved.
+
+In quiet mode this will return 0 if the block is present, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,csv,yaml"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := attestationinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attestationCmd.AddCommand(attestationInfoCmd)
+	attestationInfoCmd.Flags().String("blockid", "head", "the ID of the block containing the attestations")
+	attestationInfoCmd.Flags().String("committee-index", "", "only show attestations that cover the given committee")
+	attestationInfoCmd.Flags().Bool("aggregate", false, "resolve the validators represented in each aggregate attestation")
+}
+
+func attestationInfoBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("committee-index", cmd.Flags().Lookup("committee-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("aggregate", cmd.Flags().Lookup("aggregate")); err != nil {
+		panic(err)
+	}
+}
@@ -37,6 +37,10 @@ type dataIn struct {
 	chainTime  chaintime.Service
 	epoch      spec.Epoch
 	validator  string
+	validators []string
+	// maxMissed is the number of validators that can miss their attestations
+	// before the command fails; -1 if there is no limit.
+	maxMissed int
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -51,8 +55,20 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.debug = viper.GetBool("debug")
 
 	data.validator = viper.GetString("validator")
-	if data.validator == "" {
-		return nil, errors.New("validator is required")
+	data.validators = viper.GetStringSlice("validators")
+	if data.validator == "" && len(data.validators) == 0 {
+		return nil, errors.New("validator or validators is required")
+	}
+	if data.validator != "" && len(data.validators) > 0 {
+		return nil, errors.New("only one of validator and validators can be supplied")
+	}
+
+	data.maxMissed = -1
+	if viper.IsSet("max-missed") {
+		data.maxMissed = viper.GetInt("max-missed")
+		if data.maxMissed < -1 {
+			return nil, errors.New("invalid max-missed")
+		}
 	}
 
 	// Ethereum 2 client.
@@ -66,7 +66,25 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{
 				"timeout": "5s",
 			},
-			err: "validator is required",
+			err: "validator or validators is required",
+		},
+		{
+			name: "ValidatorAndValidators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validator":  "1",
+				"validators": []string{"2", "3"},
+			},
+			err: "only one of validator and validators can be supplied",
+		},
+		{
+			name: "MaxMissedInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"2", "3"},
+				"max-missed": -2,
+			},
+			err: "invalid max-missed",
 		},
 		{
 			name: "Good",
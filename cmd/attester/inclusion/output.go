@@ -20,13 +20,14 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataOut struct {
 	debug            bool
 	quiet            bool
 	verbose          bool
-	attestation      *phase0.Attestation
+	attestation      *util.BlockAttestation
 	slot             phase0.Slot
 	attestationIndex uint64
 	inclusionDelay   phase0.Slot
@@ -36,6 +37,50 @@ type dataOut struct {
 	sourceTimely     bool
 	targetCorrect    bool
 	targetTimely     bool
+	// batch contains the results for each validator when multiple validators
+	// are supplied.
+	batch []*inclusion
+}
+
+// inclusion is the inclusion of a single validator's attestation.
+type inclusion struct {
+	validator        phase0.ValidatorIndex
+	hasDuty          bool
+	attestation      *util.BlockAttestation
+	slot             phase0.Slot
+	attestationIndex uint64
+	inclusionDelay   phase0.Slot
+	found            bool
+	headCorrect      bool
+	headTimely       bool
+	sourceTimely     bool
+	targetCorrect    bool
+	targetTimely     bool
+}
+
+// missed returns the number of validators that missed their attestations,
+// and the number of validators that had attestation duties.
+func (d *dataOut) missed() (int, int) {
+	if d.batch == nil {
+		if d.found {
+			return 0, 1
+		}
+		return 1, 1
+	}
+
+	missed := 0
+	attesters := 0
+	for _, inclusion := range d.batch {
+		if !inclusion.hasDuty {
+			continue
+		}
+		attesters++
+		if !inclusion.found {
+			missed++
+		}
+	}
+
+	return missed, attesters
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -44,6 +89,13 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return buf.String(), errors.New("no data")
 	}
 
+	if data.batch != nil {
+		if !data.quiet {
+			outputBatch(&buf, data)
+		}
+		return buf.String(), nil
+	}
+
 	if !data.quiet {
 		if data.found {
 			buf.WriteString("Attestation included in block ")
@@ -90,3 +142,41 @@ func output(_ context.Context, data *dataOut) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+// outputBatch outputs the results for multiple validators.
+func outputBatch(buf *strings.Builder, data *dataOut) {
+	for _, inclusion := range data.batch {
+		buf.WriteString(fmt.Sprintf("Validator %d: ", inclusion.validator))
+		switch {
+		case !inclusion.hasDuty:
+			buf.WriteString("no attestation duty\n")
+		case !inclusion.found:
+			buf.WriteString("attestation not found\n")
+		default:
+			buf.WriteString(fmt.Sprintf("attestation included in block %d, index %d", inclusion.slot, inclusion.attestationIndex))
+			if data.verbose {
+				buf.WriteString(fmt.Sprintf(", inclusion delay %d, head %s, source %s, target %s",
+					inclusion.inclusionDelay,
+					voteState(inclusion.headCorrect, inclusion.headTimely),
+					voteState(true, inclusion.sourceTimely),
+					voteState(inclusion.targetCorrect, inclusion.targetTimely),
+				))
+			}
+			buf.WriteString("\n")
+		}
+	}
+	missed, attesters := data.missed()
+	buf.WriteString(fmt.Sprintf("Missed: %d/%d", missed, attesters))
+}
+
+// voteState describes the state of a vote.
+func voteState(correct bool, timely bool) string {
+	switch {
+	case !correct:
+		return "incorrect"
+	case !timely:
+		return "late"
+	default:
+		return "correct"
+	}
+}
@@ -68,6 +68,54 @@ Source timely: ✕
 Target correct: ✓
 Target timely: ✓`,
 		},
+		{
+			name: "Batch",
+			dataOut: &dataOut{
+				batch: []*inclusion{
+					{
+						validator:        1,
+						hasDuty:          true,
+						found:            true,
+						slot:             123,
+						attestationIndex: 4,
+						inclusionDelay:   1,
+					},
+					{
+						validator: 2,
+						hasDuty:   true,
+					},
+					{
+						validator: 3,
+					},
+				},
+			},
+			res: `Validator 1: attestation included in block 123, index 4
+Validator 2: attestation not found
+Validator 3: no attestation duty
+Missed: 1/2`,
+		},
+		{
+			name: "BatchVerbose",
+			dataOut: &dataOut{
+				verbose: true,
+				batch: []*inclusion{
+					{
+						validator:        1,
+						hasDuty:          true,
+						found:            true,
+						slot:             123,
+						attestationIndex: 4,
+						inclusionDelay:   2,
+						headCorrect:      true,
+						sourceTimely:     true,
+						targetCorrect:    true,
+						targetTimely:     true,
+					},
+				},
+			},
+			res: `Validator 1: attestation included in block 123, index 4, inclusion delay 2, head late, source correct, target correct
+Missed: 0/1`,
+		},
 	}
 
 	for _, test := range tests {
@@ -82,3 +130,46 @@ Target timely: ✓`,
 		})
 	}
 }
+
+func TestMissed(t *testing.T) {
+	tests := []struct {
+		name      string
+		dataOut   *dataOut
+		missed    int
+		attesters int
+	}{
+		{
+			name:      "SingleFound",
+			dataOut:   &dataOut{found: true},
+			missed:    0,
+			attesters: 1,
+		},
+		{
+			name:      "SingleMissed",
+			dataOut:   &dataOut{},
+			missed:    1,
+			attesters: 1,
+		},
+		{
+			name: "Batch",
+			dataOut: &dataOut{
+				batch: []*inclusion{
+					{validator: 1, hasDuty: true, found: true},
+					{validator: 2, hasDuty: true},
+					{validator: 3, hasDuty: true},
+					{validator: 4},
+				},
+			},
+			missed:    2,
+			attesters: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			missed, attesters := test.dataOut.missed()
+			require.Equal(t, test.missed, missed)
+			require.Equal(t, test.attesters, attesters)
+		})
+	}
+}
@@ -17,9 +17,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -31,11 +33,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	validator, err := util.ParseValidator(ctx, data.eth2Client.(eth2client.ValidatorsProvider), data.validator, "head")
-	if err != nil {
-		return nil, err
-	}
-
+	var err error
 	data.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithSpecProvider(data.eth2Client.(eth2client.SpecProvider)),
 		standardchaintime.WithGenesisTimeProvider(data.eth2Client.(eth2client.GenesisTimeProvider)),
@@ -49,6 +47,16 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		quiet:   data.quiet,
 		verbose: data.verbose,
 	}
+	cache := newChainCache()
+
+	if len(data.validators) > 0 {
+		return processBatch(ctx, data, results, cache)
+	}
+
+	validator, err := util.ParseValidator(ctx, data.eth2Client.(eth2client.ValidatorsProvider), data.validator, "head")
+	if err != nil {
+		return nil, err
+	}
 
 	duty, err := duty(ctx, data.eth2Client, validator, data.epoch)
 	if err != nil {
@@ -58,70 +66,232 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		fmt.Printf("Duty is %s\n", duty.String())
 	}
 
-	startSlot := duty.Slot + 1
-	endSlot := startSlot + 32
-	for slot := startSlot; slot < endSlot; slot++ {
-		signedBlock, err := data.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain block")
-		}
-		if signedBlock == nil {
-			continue
-		}
-		blockSlot, err := signedBlock.Slot()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain block slot")
-		}
-		if blockSlot != slot {
+	inclusion, err := findInclusion(ctx, data, duty, cache)
+	if err != nil {
+		return nil, err
+	}
+	results.found = inclusion.found
+	results.attestation = inclusion.attestation
+	results.slot = inclusion.slot
+	results.attestationIndex = inclusion.attestationIndex
+	results.inclusionDelay = inclusion.inclusionDelay
+	results.sourceTimely = inclusion.sourceTimely
+	results.targetCorrect = inclusion.targetCorrect
+	results.targetTimely = inclusion.targetTimely
+	results.headCorrect = inclusion.headCorrect
+	results.headTimely = inclusion.headTimely
+
+	return results, nil
+}
+
+// processBatch finds the inclusion of attestations for multiple validators.
+func processBatch(ctx context.Context,
+	data *dataIn,
+	results *dataOut,
+	cache *chainCache,
+) (
+	*dataOut,
+	error,
+) {
+	validators, err := util.ParseValidators(ctx, data.eth2Client.(eth2client.ValidatorsProvider), data.validators, "head")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(validators, func(i int, j int) bool {
+		return validators[i].Index < validators[j].Index
+	})
+
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+	dutiesResponse, err := data.eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: data.epoch, Indices: indices})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attester duties")
+	}
+	duties := dutiesResponse.Data
+	validatorDuties := make(map[phase0.ValidatorIndex]*apiv1.AttesterDuty, len(duties))
+	for _, duty := range duties {
+		validatorDuties[duty.ValidatorIndex] = duty
+	}
+
+	results.batch = make([]*inclusion, 0, len(validators))
+	for _, validator := range validators {
+		duty, exists := validatorDuties[validator.Index]
+		if !exists {
+			// Validator is not active, so cannot have missed its attestation.
+			results.batch = append(results.batch, &inclusion{
+				validator: validator.Index,
+			})
 			continue
 		}
 		if data.debug {
-			fmt.Printf("Fetched block for slot %d\n", slot)
+			fmt.Printf("Duty is %s\n", duty.String())
 		}
-		attestations, err := signedBlock.Attestations()
+		inclusion, err := findInclusion(ctx, data, duty, cache)
+		if err != nil {
+			return nil, err
+		}
+		results.batch = append(results.batch, inclusion)
+	}
+
+	return results, nil
+}
+
+// chainCache caches the attestations in blocks and the sizes of committees,
+// as validators in a batch share them.
+type chainCache struct {
+	// attestations are the attestations in the block at each slot; nil if
+	// there is no block at the slot.
+	attestations map[phase0.Slot][]*util.BlockAttestation
+	// committeeSizes are the sizes of the committees at each slot.
+	committeeSizes map[phase0.Slot]map[phase0.CommitteeIndex]uint64
+}
+
+func newChainCache() *chainCache {
+	return &chainCache{
+		attestations:   make(map[phase0.Slot][]*util.BlockAttestation),
+		committeeSizes: make(map[phase0.Slot]map[phase0.CommitteeIndex]uint64),
+	}
+}
+
+// findInclusion finds the inclusion of the attestation for an attester duty.
+func findInclusion(ctx context.Context,
+	data *dataIn,
+	duty *apiv1.AttesterDuty,
+	cache *chainCache,
+) (
+	*inclusion,
+	error,
+) {
+	res := &inclusion{
+		validator: duty.ValidatorIndex,
+		hasDuty:   true,
+	}
+
+	// From Electra an attestation can cover multiple committees, in which case
+	// the sizes of the committees are required to locate the validator's vote.
+	committeeSize := func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+		return committeeSizeAtSlot(ctx, data, cache, duty.Slot, committeeIndex)
+	}
+
+	startSlot := duty.Slot + 1
+	endSlot := startSlot + 32
+	for slot := startSlot; slot < endSlot; slot++ {
+		attestations, err := attestationsAtSlot(ctx, data, cache, slot)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain block attestations")
+			return nil, err
 		}
 		for i, attestation := range attestations {
-			if attestation.Data.Slot == duty.Slot &&
-				attestation.Data.Index == duty.CommitteeIndex &&
-				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
-				headCorrect := false
-				targetCorrect := false
-				if data.verbose {
-					headCorrect, err = calcHeadCorrect(ctx, data, attestation)
-					if err != nil {
-						return nil, errors.Wrap(err, "failed to obtain head correct result")
-					}
-					targetCorrect, err = calcTargetCorrect(ctx, data, attestation)
-					if err != nil {
-						return nil, errors.Wrap(err, "failed to obtain target correct result")
-					}
+			if attestation.Data.Slot != duty.Slot {
+				continue
+			}
+			attested, err := attestation.Attested(duty.CommitteeIndex, duty.ValidatorCommitteeIndex, committeeSize)
+			if err != nil {
+				return nil, err
+			}
+			if !attested {
+				continue
+			}
+			headCorrect := false
+			targetCorrect := false
+			if data.verbose {
+				headCorrect, err = calcHeadCorrect(ctx, data, attestation.Data)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to obtain head correct result")
 				}
-				results.found = true
-				results.attestation = attestation
-				results.slot = slot
-				results.attestationIndex = uint64(i)
-				results.inclusionDelay = slot - duty.Slot
-				results.sourceTimely = results.inclusionDelay <= 5 // sqrt(32)
-				results.targetCorrect = targetCorrect
-				results.targetTimely = targetCorrect && results.inclusionDelay <= 32
-				results.headCorrect = headCorrect
-				results.headTimely = headCorrect && results.inclusionDelay == 1
-				if data.debug {
-					fmt.Printf("Attestation is %s\n", attestation.String())
+				targetCorrect, err = calcTargetCorrect(ctx, data, attestation.Data)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to obtain target correct result")
 				}
-				return results, nil
 			}
+			res.found = true
+			res.attestation = attestation
+			res.slot = slot
+			res.attestationIndex = uint64(i)
+			res.inclusionDelay = slot - duty.Slot
+			res.sourceTimely = res.inclusionDelay <= 5 // sqrt(32)
+			res.targetCorrect = targetCorrect
+			res.targetTimely = targetCorrect && res.inclusionDelay <= 32
+			res.headCorrect = headCorrect
+			res.headTimely = headCorrect && res.inclusionDelay == 1
+			if data.debug {
+				fmt.Printf("Attestation data is %s\n", attestation.Data.String())
+			}
+			return res, nil
 		}
 	}
-	return results, nil
+
+	return res, nil
+}
+
+// attestationsAtSlot returns the attestations in the block at the given slot,
+// or nil if there is no block at that slot.
+func attestationsAtSlot(ctx context.Context,
+	data *dataIn,
+	cache *chainCache,
+	slot phase0.Slot,
+) (
+	[]*util.BlockAttestation,
+	error,
+) {
+	if attestations, exists := cache.attestations[slot]; exists {
+		return attestations, nil
+	}
+
+	blockSlot, attestations, found, err := util.BlockAttestations(ctx, data.eth2Client, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block")
+	}
+	if !found || blockSlot != slot {
+		attestations = nil
+	}
+	if attestations != nil && data.debug {
+		fmt.Printf("Fetched block for slot %d\n", slot)
+	}
+	cache.attestations[slot] = attestations
+
+	return attestations, nil
+}
+
+// committeeSizeAtSlot returns the size of the given committee at the given slot.
+func committeeSizeAtSlot(ctx context.Context,
+	data *dataIn,
+	cache *chainCache,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+) (
+	uint64,
+	error,
+) {
+	sizes, exists := cache.committeeSizes[slot]
+	if !exists {
+		committeesResponse, err := data.eth2Client.(eth2client.BeaconCommitteesProvider).BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", slot)})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to obtain beacon committees")
+		}
+		committees := committeesResponse.Data
+		sizes = make(map[phase0.CommitteeIndex]uint64)
+		for _, committee := range committees {
+			if committee.Slot == slot {
+				sizes[committee.Index] = uint64(len(committee.Validators))
+			}
+		}
+		cache.committeeSizes[slot] = sizes
+	}
+
+	size, exists := sizes[committeeIndex]
+	if !exists {
+		return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, slot)
+	}
+
+	return size, nil
 }
 
-func calcHeadCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
-	slot := attestation.Data.Slot
+func calcHeadCorrect(ctx context.Context, data *dataIn, attestationData *phase0.AttestationData) (bool, error) {
+	slot := attestationData.Slot
 	for {
-		header, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+		header, err := util.ResponseData(data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", slot)}))
 		if err != nil {
 			return false, err
 		}
@@ -135,15 +305,15 @@ func calcHeadCorrect(ctx context.Context, data *dataIn, attestation *phase0.Atte
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.BeaconBlockRoot[:]), nil
+		return bytes.Equal(header.Root[:], attestationData.BeaconBlockRoot[:]), nil
 	}
 }
 
-func calcTargetCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
+func calcTargetCorrect(ctx context.Context, data *dataIn, attestationData *phase0.AttestationData) (bool, error) {
 	// Start with first slot of the target epoch.
-	slot := data.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+	slot := data.chainTime.FirstSlotOfEpoch(attestationData.Target.Epoch)
 	for {
-		header, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+		header, err := util.ResponseData(data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", slot)}))
 		if err != nil {
 			return false, err
 		}
@@ -157,16 +327,17 @@ func calcTargetCorrect(ctx context.Context, data *dataIn, attestation *phase0.At
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.Target.Root[:]), nil
+		return bytes.Equal(header.Root[:], attestationData.Target.Root[:]), nil
 	}
 }
 
-func duty(ctx context.Context, eth2Client eth2client.Service, validator *api.Validator, epoch phase0.Epoch) (*api.AttesterDuty, error) {
+func duty(ctx context.Context, eth2Client eth2client.Service, validator *apiv1.Validator, epoch phase0.Epoch) (*apiv1.AttesterDuty, error) {
 	// Find the attesting slot for the given epoch.
-	duties, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, epoch, []phase0.ValidatorIndex{validator.Index})
+	dutiesResponse, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: []phase0.ValidatorIndex{validator.Index}})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain attester duties")
 	}
+	duties := dutiesResponse.Data
 
 	if len(duties) == 0 {
 		return nil, errors.New("validator does not have duty for that epoch")
@@ -15,6 +15,7 @@ package attesterinclusion
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -37,13 +38,20 @@ func Run(cmd *cobra.Command) (string, error) {
 		return "", errors.Wrap(err, "failed to process")
 	}
 
-	if viper.GetBool("quiet") {
-		return "", nil
+	results := ""
+	if !viper.GetBool("quiet") {
+		results, err = output(ctx, dataOut)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to obtain output")
+		}
 	}
 
-	results, err := output(ctx, dataOut)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to obtain output")
+	missed, attesters := dataOut.missed()
+	switch {
+	case dataIn.maxMissed >= 0 && missed > dataIn.maxMissed:
+		return results, fmt.Errorf("%d of %d validators missed attestations, more than the maximum of %d", missed, attesters, dataIn.maxMissed)
+	case dataIn.maxMissed < 0 && viper.GetBool("quiet") && missed > 0:
+		return "", errors.New("attestation not found")
 	}
 
 	return results, nil
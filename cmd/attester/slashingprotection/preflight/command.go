@@ -0,0 +1,106 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingprotectionpreflight
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/slashingprotection"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	validator          string
+	slashingProtection string
+	sourceEpoch        string
+	targetEpoch        string
+	signingRoot        *phase0.Root
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient           consensusclient.Service
+	chainTime                 chaintime.Service
+	validatorsProvider        consensusclient.ValidatorsProvider
+	finalityProvider          consensusclient.FinalityProvider
+	genesisProvider           consensusclient.GenesisProvider
+	slashingProtectionService slashingprotection.Service
+
+	// Output.
+	validatorInfo *apiv1.Validator
+	source        phase0.Epoch
+	target        phase0.Epoch
+	reason        string
+	safe          bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+		slashingProtection:       viper.GetString("slashing-protection"),
+		sourceEpoch:              viper.GetString("source-epoch"),
+		targetEpoch:              viper.GetString("target-epoch"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	if c.slashingProtection == "" {
+		return nil, errors.New("slashing protection data is required")
+	}
+
+	if viper.GetString("signing-root") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("signing-root"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signing root")
+		}
+		if len(data) != phase0.RootLength {
+			return nil, errors.New("signing root must be 32 bytes")
+		}
+		c.signingRoot = &phase0.Root{}
+		copy(c.signingRoot[:], data)
+	}
+
+	return c, nil
+}
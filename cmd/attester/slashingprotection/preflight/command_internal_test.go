@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingprotectionpreflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validator":           "1",
+				"slashing-protection": "interchange.json",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"slashing-protection": "interchange.json",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "SlashingProtectionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+			},
+			err: "slashing protection data is required",
+		},
+		{
+			name: "SigningRootInvalid",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"validator":           "1",
+				"slashing-protection": "interchange.json",
+				"signing-root":        "invalid",
+			},
+			err: "invalid signing root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "SigningRootShort",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"validator":           "1",
+				"slashing-protection": "interchange.json",
+				"signing-root":        "0x0102",
+			},
+			err: "signing root must be 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"validator":           "1",
+				"slashing-protection": "interchange.json",
+			},
+		},
+		{
+			name: "GoodSigningRoot",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"validator":           "1",
+				"slashing-protection": "https://example.com/interchange.json",
+				"source-epoch":        "10",
+				"target-epoch":        "11",
+				"signing-root":        "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingprotectionpreflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Index       string `json:"index"`
+	Pubkey      string `json:"pubkey"`
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+	Safe        bool   `json:"safe"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	res := &jsonOutput{
+		Index:       fmt.Sprintf("%d", c.validatorInfo.Index),
+		Pubkey:      fmt.Sprintf("%#x", c.validatorInfo.Validator.PublicKey),
+		SourceEpoch: fmt.Sprintf("%d", c.source),
+		TargetEpoch: fmt.Sprintf("%d", c.target),
+		Safe:        c.safe,
+		Reason:      c.reason,
+	}
+	if c.signingRoot != nil {
+		res.SigningRoot = fmt.Sprintf("%#x", *c.signingRoot)
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validator: %d\n", c.validatorInfo.Index))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Public key: %#x\n", c.validatorInfo.Validator.PublicKey))
+	}
+	builder.WriteString(fmt.Sprintf("Source epoch: %d\n", c.source))
+	builder.WriteString(fmt.Sprintf("Target epoch: %d\n", c.target))
+	if c.verbose && c.signingRoot != nil {
+		builder.WriteString(fmt.Sprintf("Signing root: %#x\n", *c.signingRoot))
+	}
+	if c.safe {
+		builder.WriteString("Result: safe to sign\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("Result: not safe to sign: %s\n", c.reason))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
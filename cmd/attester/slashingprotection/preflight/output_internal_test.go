@@ -0,0 +1,87 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingprotectionpreflight
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	validatorInfo := &apiv1.Validator{
+		Index: 1,
+		Validator: &phase0.Validator{
+			PublicKey: phase0.BLSPubKey{0x01},
+		},
+	}
+	signingRoot := phase0.Root{0x02}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:         true,
+				validatorInfo: validatorInfo,
+			},
+		},
+		{
+			name: "Safe",
+			command: &command{
+				validatorInfo: validatorInfo,
+				source:        10,
+				target:        11,
+				safe:          true,
+			},
+			res: "Validator: 1\nSource epoch: 10\nTarget epoch: 11\nResult: safe to sign",
+		},
+		{
+			name: "Unsafe",
+			command: &command{
+				validatorInfo: validatorInfo,
+				source:        10,
+				target:        11,
+				reason:        "an attestation has already been signed with target epoch 11",
+			},
+			res: "Validator: 1\nSource epoch: 10\nTarget epoch: 11\nResult: not safe to sign: an attestation has already been signed with target epoch 11",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:          true,
+				validatorInfo: validatorInfo,
+				source:        10,
+				target:        11,
+				signingRoot:   &signingRoot,
+				reason:        "an attestation has already been signed with target epoch 11",
+			},
+			res: `{"index":"1","pubkey":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","source_epoch":"10","target_epoch":"11","signing_root":"0x0200000000000000000000000000000000000000000000000000000000000000","safe":false,"reason":"an attestation has already been signed with target epoch 11"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
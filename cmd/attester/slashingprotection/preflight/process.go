@@ -0,0 +1,138 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingprotectionpreflight
+
+import (
+	"context"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+
+	if err := c.obtainEpochs(ctx); err != nil {
+		return err
+	}
+
+	c.reason, err = c.slashingProtectionService.CheckAttestation(ctx,
+		c.validatorInfo.Validator.PublicKey,
+		c.source,
+		c.target,
+		c.signingRoot,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to check attestation")
+	}
+	c.safe = c.reason == ""
+
+	return nil
+}
+
+// obtainEpochs obtains the source and target epochs of the attestation,
+// defaulting to the current justified checkpoint and the current epoch.
+func (c *command) obtainEpochs(ctx context.Context) error {
+	var err error
+	c.target, err = util.ParseEpoch(ctx, c.chainTime, c.targetEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse target epoch")
+	}
+
+	if c.sourceEpoch != "" {
+		c.source, err = util.ParseEpoch(ctx, c.chainTime, c.sourceEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse source epoch")
+		}
+
+		return nil
+	}
+
+	finalityResponse, err := c.finalityProvider.Finality(ctx, &api.FinalityOpts{State: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	finality := finalityResponse.Data
+	c.source = finality.Justified.Epoch
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.finalityProvider, isProvider = c.consensusClient.(consensusclient.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality information")
+	}
+	c.genesisProvider, isProvider = c.consensusClient.(consensusclient.GenesisProvider)
+	if !isProvider {
+		return errors.New("connection does not provide genesis information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	genesisResponse, err := c.genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	genesis := genesisResponse.Data
+
+	// Set up slashing protection, ensuring that it is for the same network as the beacon node.
+	c.slashingProtectionService, err = interchange.New(ctx,
+		interchange.WithSource(c.slashingProtection),
+		interchange.WithTimeout(c.timeout),
+		interchange.WithGenesisValidatorsRoot(genesis.GenesisValidatorsRoot),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create slashing protection service")
+	}
+
+	return nil
+}
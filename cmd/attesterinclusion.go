@@ -28,19 +28,19 @@ var attesterInclusionCmd = &cobra.Command{
 
     ethdo attester inclusion --validator=Validators/00001 --epoch=12345
 
-In quiet mode this will return 0 if an attestation from the attester is found on the block of the given epoch, otherwise 1.`,
+Multiple validators can be checked at the same time with --validators, for example:
+
+    ethdo attester inclusion --validators=1,2,100-199 --epoch=12345
+
+With --max-missed this will return 1 if more than the given number of validators missed their attestations in the epoch, otherwise 0, making it suitable for use as an alarm.
+
+In quiet mode without --max-missed this will return 0 if attestations from all of the attesters are found on the blocks of the given epoch, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := attesterinclusion.Run(cmd)
-		if err != nil {
-			return err
-		}
-		if viper.GetBool("quiet") {
-			return nil
-		}
 		if res != "" {
 			fmt.Println(res)
 		}
-		return nil
+		return err
 	},
 }
 
@@ -50,6 +50,8 @@ func init() {
 	attesterInclusionCmd.Flags().String("epoch", "-1", "the epoch for which to obtain the inclusion")
 	attesterInclusionCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
 	attesterInclusionCmd.Flags().String("index", "", "the index of the attester")
+	attesterInclusionCmd.Flags().StringSlice("validators", nil, "the indices, index ranges, public keys or accounts of multiple validators")
+	attesterInclusionCmd.Flags().Int("max-missed", -1, "the maximum number of validators that can miss their attestations before returning an error (-1 for no maximum)")
 }
 
 func attesterInclusionBindings(cmd *cobra.Command) {
@@ -62,4 +64,10 @@ func attesterInclusionBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("index", cmd.Flags().Lookup("index")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-missed", cmd.Flags().Lookup("max-missed")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,29 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// attesterSlashingProtectionCmd represents the attester slashing-protection command.
+var attesterSlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Check attestations against slashing protection data",
+	Long:  `Check attestations against slashing protection data.`,
+}
+
+func init() {
+	attesterCmd.AddCommand(attesterSlashingProtectionCmd)
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attesterslashingprotectionpreflight "github.com/wealdtech/ethdo/cmd/attester/slashingprotection/preflight"
+)
+
+var attesterSlashingProtectionPreflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check that an attestation is safe to sign",
+	Long: `Check that an attestation is safe to sign against EIP-3076 slashing protection data, without signing it.  For example:
+
+    ethdo attester slashing-protection preflight --validator=primary/validator --slashing-protection=interchange.json --source-epoch=1000 --target-epoch=1001
+
+The slashing protection data can be a local file or a URL that returns interchange data, for example exported from a validator client or remote signer.  If not supplied, the source epoch defaults to the current justified epoch and the target epoch to the current epoch.
+
+In quiet mode this will return 0 if the attestation is safe to sign, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := attesterslashingprotectionpreflight.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attesterSlashingProtectionCmd.AddCommand(attesterSlashingProtectionPreflightCmd)
+	attesterFlags(attesterSlashingProtectionPreflightCmd)
+	attesterSlashingProtectionPreflightCmd.Flags().String("validator", "", "Validator to check")
+	attesterSlashingProtectionPreflightCmd.Flags().String("slashing-protection", "", "File or URL of EIP-3076 slashing protection interchange data")
+	attesterSlashingProtectionPreflightCmd.Flags().String("source-epoch", "", "Source epoch of the attestation (defaults to the current justified epoch)")
+	attesterSlashingProtectionPreflightCmd.Flags().String("target-epoch", "", "Target epoch of the attestation (defaults to the current epoch)")
+	attesterSlashingProtectionPreflightCmd.Flags().String("signing-root", "", "Signing root of the attestation")
+}
+
+func attesterSlashingProtectionPreflightBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("slashing-protection", cmd.Flags().Lookup("slashing-protection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("source-epoch", cmd.Flags().Lookup("source-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-epoch", cmd.Flags().Lookup("target-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-root", cmd.Flags().Lookup("signing-root")); err != nil {
+		panic(err)
+	}
+}
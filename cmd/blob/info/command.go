@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobinfo
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	blockID       string
+	versionedHash *deneb.VersionedHash
+	index         *deneb.BlobIndex
+	searchSlots   uint64
+	blobFile      string
+	jsonOutput    bool
+
+	// Data access.
+	eth2Client            eth2client.Service
+	beaconHeadersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Results.
+	blobs []*blobInfo
+}
+
+// blobInfo is the information about a blob.
+type blobInfo struct {
+	slot          phase0.Slot
+	blockRoot     phase0.Root
+	index         deneb.BlobIndex
+	versionedHash deneb.VersionedHash
+	commitment    deneb.KZGCommitment
+	proof         deneb.KZGProof
+	dataSize      int
+	blob          *deneb.Blob
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if viper.GetString("versioned-hash") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("versioned-hash"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid versioned hash")
+		}
+		if len(data) != len(deneb.VersionedHash{}) {
+			return nil, fmt.Errorf("versioned hash is %d bytes, expected %d", len(data), len(deneb.VersionedHash{}))
+		}
+		versionedHash := deneb.VersionedHash(data)
+		c.versionedHash = &versionedHash
+	}
+	if c.blockID == "" && c.versionedHash == nil {
+		c.blockID = "head"
+	}
+	if viper.GetString("index") != "" {
+		index, err := strconv.ParseUint(viper.GetString("index"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid index")
+		}
+		blobIndex := deneb.BlobIndex(index)
+		c.index = &blobIndex
+	}
+	c.searchSlots = viper.GetUint64("search-slots")
+	if c.blockID == "" && c.searchSlots == 0 {
+		return nil, errors.New("search-slots must be greater than 0 to search for a versioned hash")
+	}
+	c.blobFile = viper.GetString("blob-file")
+	if c.blobFile != "" && c.index == nil && c.versionedHash == nil {
+		return nil, errors.New("blob-file requires a single blob; select it with index or versioned-hash")
+	}
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
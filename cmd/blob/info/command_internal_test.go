@@ -0,0 +1,127 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		blockID string
+		err     string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "Default",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			blockID: "head",
+		},
+		{
+			name: "VersionedHashInvalid",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"versioned-hash": "0xinvalid",
+			},
+			err: "invalid versioned hash: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "VersionedHashShort",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"versioned-hash": "0x0102",
+			},
+			err: "versioned hash is 2 bytes, expected 32",
+		},
+		{
+			name: "VersionedHashSearch",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"versioned-hash": "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014",
+				"search-slots":   32,
+			},
+		},
+		{
+			name: "VersionedHashSearchSlotsZero",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"versioned-hash": "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014",
+			},
+			err: "search-slots must be greater than 0 to search for a versioned hash",
+		},
+		{
+			name: "VersionedHashInBlock",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"blockid":        "123",
+				"versioned-hash": "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014",
+			},
+			blockID: "123",
+		},
+		{
+			name: "IndexInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"index":   "-1",
+			},
+			err: "invalid index: strconv.ParseUint: parsing \"-1\": invalid syntax",
+		},
+		{
+			name: "BlobFileWithoutSelection",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"blob-file": "blob.ssz",
+			},
+			err: "blob-file requires a single blob; select it with index or versioned-hash",
+		},
+		{
+			name: "BlobFile",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"index":     "1",
+				"blob-file": "blob.ssz",
+			},
+			blockID: "head",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.blockID, c.blockID)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type blobJSON struct {
+	Slot          uint64 `json:"slot"`
+	BlockRoot     string `json:"block_root"`
+	Index         uint64 `json:"index"`
+	VersionedHash string `json:"versioned_hash"`
+	Size          int    `json:"size"`
+	DataSize      int    `json:"data_size"`
+	KZGCommitment string `json:"kzg_commitment"`
+	KZGProof      string `json:"kzg_proof"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := make([]*blobJSON, 0, len(c.blobs))
+	for _, blob := range c.blobs {
+		output = append(output, &blobJSON{
+			Slot:          uint64(blob.slot),
+			BlockRoot:     fmt.Sprintf("%#x", blob.blockRoot),
+			Index:         uint64(blob.index),
+			VersionedHash: fmt.Sprintf("%#x", blob.versionedHash),
+			Size:          util.BytesPerBlob,
+			DataSize:      blob.dataSize,
+			KZGCommitment: fmt.Sprintf("%#x", blob.commitment),
+			KZGProof:      fmt.Sprintf("%#x", blob.proof),
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	if len(c.blobs) == 0 {
+		return "No blobs", nil
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.blobs[0].slot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Block root: %#x\n", c.blobs[0].blockRoot))
+	}
+	for _, blob := range c.blobs {
+		builder.WriteString(fmt.Sprintf("Blob %d:\n", blob.index))
+		builder.WriteString(fmt.Sprintf("  Versioned hash: %#x\n", blob.versionedHash))
+		builder.WriteString(fmt.Sprintf("  Size: %d bytes (%d bytes of data)\n", util.BytesPerBlob, blob.dataSize))
+		builder.WriteString(fmt.Sprintf("  KZG commitment: %#x\n", blob.commitment))
+		builder.WriteString(fmt.Sprintf("  KZG proof: %#x\n", blob.proof))
+	}
+	if c.blobFile != "" {
+		builder.WriteString(fmt.Sprintf("Blob data written to %s\n", c.blobFile))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
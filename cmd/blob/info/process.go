@@ -0,0 +1,175 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobinfo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var sidecars []*util.BlobSidecar
+	var err error
+	if c.blockID != "" {
+		sidecars, err = c.blockSidecars(ctx, c.blockID)
+	} else {
+		sidecars, err = c.searchSidecars(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.blobs, err = c.selectBlobs(sidecars)
+	if err != nil {
+		return err
+	}
+	if len(c.blobs) == 0 && (c.index != nil || c.versionedHash != nil) {
+		return errors.New("blob not found")
+	}
+
+	if c.blobFile != "" {
+		if err := os.WriteFile(c.blobFile, c.blobs[0].blob[:], 0o600); err != nil {
+			return errors.Wrap(err, "failed to write blob")
+		}
+	}
+
+	return nil
+}
+
+// blockSidecars obtains the blob sidecars for a block.
+func (c *command) blockSidecars(ctx context.Context, blockID string) ([]*util.BlobSidecar, error) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching blob sidecars for block %s\n", blockID)
+	}
+	sidecars, found, err := util.BlobSidecars(ctx, c.eth2Client, c.timeout, blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain blob sidecars")
+	}
+	if !found {
+		return nil, fmt.Errorf("block %s not found", blockID)
+	}
+
+	return sidecars, nil
+}
+
+// searchSidecars searches back from the head of the chain for the blob sidecar
+// with the requested versioned hash.
+func (c *command) searchSidecars(ctx context.Context) ([]*util.BlobSidecar, error) {
+	header, err := util.ResponseData(c.beaconHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: "head"}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain head block header")
+	}
+	if header == nil || header.Header == nil || header.Header.Message == nil {
+		return nil, errors.New("empty head block header")
+	}
+	headSlot := header.Header.Message.Slot
+
+	for i := uint64(0); i < c.searchSlots && i <= uint64(headSlot); i++ {
+		slot := headSlot - phase0.Slot(i)
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Searching blob sidecars at slot %d\n", slot)
+		}
+		sidecars, found, err := util.BlobSidecars(ctx, c.eth2Client, c.timeout, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain blob sidecars for slot %d", slot))
+		}
+		if !found {
+			// Empty slot.
+			continue
+		}
+		for _, sidecar := range sidecars {
+			if util.KZGCommitmentToVersionedHash(sidecar.KZGCommitment[:]) == *c.versionedHash {
+				return sidecars, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("versioned hash not found in the last %d slots", c.searchSlots)
+}
+
+// selectBlobs selects the blobs to report from the sidecars of a block.
+func (c *command) selectBlobs(sidecars []*util.BlobSidecar) ([]*blobInfo, error) {
+	blobs := make([]*blobInfo, 0, len(sidecars))
+	for _, sidecar := range sidecars {
+		if c.index != nil && sidecar.Index != *c.index {
+			continue
+		}
+		versionedHash := util.KZGCommitmentToVersionedHash(sidecar.KZGCommitment[:])
+		if c.versionedHash != nil && versionedHash != *c.versionedHash {
+			continue
+		}
+
+		info := &blobInfo{
+			index:         sidecar.Index,
+			versionedHash: versionedHash,
+			commitment:    sidecar.KZGCommitment,
+			proof:         sidecar.KZGProof,
+			dataSize:      blobDataSize(sidecar.Blob[:]),
+			blob:          &sidecar.Blob,
+		}
+		if sidecar.SignedBlockHeader != nil && sidecar.SignedBlockHeader.Message != nil {
+			info.slot = sidecar.SignedBlockHeader.Message.Slot
+			root, err := sidecar.SignedBlockHeader.Message.HashTreeRoot()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain block root")
+			}
+			info.blockRoot = root
+		}
+		blobs = append(blobs, info)
+	}
+
+	return blobs, nil
+}
+
+// blobDataSize returns the size of the data in a blob, ignoring trailing zero
+// bytes that pad the blob to its fixed size.
+func blobDataSize(blob []byte) int {
+	return len(bytes.TrimRight(blob, "\x00"))
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.beaconHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block header information")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testSidecars returns blob sidecars for a block at slot 5 with two blobs.
+func testSidecars() []*util.BlobSidecar {
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot: 5,
+		},
+	}
+	empty := &util.BlobSidecar{
+		Index:             0,
+		KZGCommitment:     deneb.KZGCommitment{0xc0},
+		KZGProof:          deneb.KZGProof{0xc0},
+		SignedBlockHeader: header,
+	}
+	data := &util.BlobSidecar{
+		Index:             1,
+		KZGCommitment:     deneb.KZGCommitment{0x80, 0x01},
+		KZGProof:          deneb.KZGProof{0x80, 0x02},
+		SignedBlockHeader: header,
+	}
+	copy(data.Blob[:], []byte("hello"))
+
+	return []*util.BlobSidecar{empty, data}
+}
+
+func TestSelectBlobs(t *testing.T) {
+	emptyHash := util.KZGCommitmentToVersionedHash(testSidecars()[0].KZGCommitment[:])
+	index := deneb.BlobIndex(1)
+	missingIndex := deneb.BlobIndex(2)
+
+	tests := []struct {
+		name          string
+		index         *deneb.BlobIndex
+		versionedHash *deneb.VersionedHash
+		indices       []deneb.BlobIndex
+		dataSizes     []int
+	}{
+		{
+			name:      "All",
+			indices:   []deneb.BlobIndex{0, 1},
+			dataSizes: []int{0, 5},
+		},
+		{
+			name:      "Index",
+			index:     &index,
+			indices:   []deneb.BlobIndex{1},
+			dataSizes: []int{5},
+		},
+		{
+			name:    "IndexMissing",
+			index:   &missingIndex,
+			indices: []deneb.BlobIndex{},
+		},
+		{
+			name:          "VersionedHash",
+			versionedHash: &emptyHash,
+			indices:       []deneb.BlobIndex{0},
+			dataSizes:     []int{0},
+		},
+		{
+			name:          "IndexAndVersionedHashMismatch",
+			index:         &index,
+			versionedHash: &emptyHash,
+			indices:       []deneb.BlobIndex{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				index:         test.index,
+				versionedHash: test.versionedHash,
+			}
+			blobs, err := c.selectBlobs(testSidecars())
+			require.NoError(t, err)
+			indices := make([]deneb.BlobIndex, 0, len(blobs))
+			for i, blob := range blobs {
+				indices = append(indices, blob.index)
+				require.Equal(t, test.dataSizes[i], blob.dataSize)
+				require.Equal(t, phase0.Slot(5), blob.slot)
+			}
+			require.Equal(t, test.indices, indices)
+		})
+	}
+}
+
+func TestOutput(t *testing.T) {
+	c := &command{}
+	var err error
+	c.blobs, err = c.selectBlobs(testSidecars()[:1])
+	require.NoError(t, err)
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Slot: 5
+Blob 0:
+  Versioned hash: 0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014
+  Size: 131072 bytes (0 bytes of data)
+  KZG commitment: 0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000
+  KZG proof: 0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000`, res)
+
+	c.jsonOutput = true
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, fmt.Sprintf(`[{"slot":5,"block_root":"%#x","index":0,"versioned_hash":"0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014","size":131072,"data_size":0,"kzg_commitment":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","kzg_proof":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}]`, c.blobs[0].blockRoot), res)
+
+	c.jsonOutput = false
+	c.blobs = nil
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "No blobs", res)
+}
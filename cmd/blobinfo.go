@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blobinfo "github.com/wealdtech/ethdo/cmd/blob/info"
+)
+
+var blobInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about blobs",
+	Long: `Obtain information about the blobs of a block, or a single blob.  For example:
+
+    ethdo blob info --blockid=12345
+
+A single blob can be selected with --index, or with --versioned-hash as referenced by the blob transaction that carried it.  If a versioned hash is supplied without a block ID then the most recent --search-slots slots are searched for the blob.
+
+The raw data of a single selected blob can be written to a file with --blob-file.
+
+In quiet mode this will return 0 if the blob information is present, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blobinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blobCmd.AddCommand(blobInfoCmd)
+	blobFlags(blobInfoCmd)
+	blobInfoCmd.Flags().String("blockid", "", "the ID of the block whose blobs to fetch (defaults to head)")
+	blobInfoCmd.Flags().String("versioned-hash", "", "the versioned hash of the blob to fetch")
+	blobInfoCmd.Flags().String("index", "", "the index of the blob within the block to fetch")
+	blobInfoCmd.Flags().Uint64("search-slots", 32, "the number of recent slots to search for a versioned hash if no block ID is supplied")
+	blobInfoCmd.Flags().String("blob-file", "", "write the raw data of the selected blob to the named file")
+}
+
+func blobInfoBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("versioned-hash", cmd.Flags().Lookup("versioned-hash")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("index", cmd.Flags().Lookup("index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("search-slots", cmd.Flags().Lookup("search-slots")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blob-file", cmd.Flags().Lookup("blob-file")); err != nil {
+		panic(err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -60,6 +61,8 @@ type command struct {
 	headRoots map[phase0.Slot]phase0.Root
 	// Target roots provides the root of the target epoch at given slots.
 	targetRoots map[phase0.Slot]phase0.Root
+	// Committee sizes provides the size of committees at given slots.
+	committeeSizes *util.BeaconCommitteeSizeCache
 
 	// Block info.
 	// Map is slot -> committee index -> validator committee index -> votes.
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -33,7 +34,7 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	block, err := c.blocksProvider.SignedBeaconBlock(ctx, c.blockID)
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: c.blockID}))
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain beacon block")
 	}
@@ -57,8 +58,12 @@ func (c *command) process(ctx context.Context) error {
 	// Calculate how many parents we need to fetch.
 	minSlot := slot
 	for _, attestation := range attestations {
-		if attestation.Data.Slot < minSlot {
-			minSlot = attestation.Data.Slot
+		data, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
+		if data.Slot < minSlot {
+			minSlot = data.Slot
 		}
 	}
 	if c.debug {
@@ -97,10 +102,14 @@ func (c *command) analyzeAttestations(ctx context.Context, block *spec.Versioned
 		if c.debug {
 			fmt.Printf("Processing attestation %d\n", i)
 		}
+		data, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
 		analysis := &attestationAnalysis{
-			Head:     attestation.Data.BeaconBlockRoot,
-			Target:   attestation.Data.Target.Root,
-			Distance: int(slot - attestation.Data.Slot),
+			Head:     data.BeaconBlockRoot,
+			Target:   data.Target.Root,
+			Distance: int(slot - data.Slot),
 		}
 
 		root, err := attestation.HashTreeRoot()
@@ -110,54 +119,60 @@ func (c *command) analyzeAttestations(ctx context.Context, block *spec.Versioned
 		if info, exists := c.priorAttestations[fmt.Sprintf("%#x", root)]; exists {
 			analysis.Duplicate = info
 		} else {
-			data := attestation.Data
+			committeeVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+				return c.committeeSizes.Fetch(ctx, data.Slot, committeeIndex)
+			})
+			if err != nil {
+				return err
+			}
 			_, exists := blockVotes[data.Slot]
 			if !exists {
 				blockVotes[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
 			}
-			_, exists = blockVotes[data.Slot][data.Index]
-			if !exists {
-				blockVotes[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
-			}
+			for committeeIndex, votes := range committeeVotes {
+				_, exists = blockVotes[data.Slot][committeeIndex]
+				if !exists {
+					blockVotes[data.Slot][committeeIndex] = bitfield.NewBitlist(votes.Len())
+				}
 
-			// Count new votes.
-			analysis.PossibleVotes = int(attestation.AggregationBits.Len())
-			for j := uint64(0); j < attestation.AggregationBits.Len(); j++ {
-				if attestation.AggregationBits.BitAt(j) {
-					analysis.Votes++
-					if blockVotes[data.Slot][data.Index].BitAt(j) {
-						// Already attested to in this block; skip.
-						continue
+				// Count new votes.
+				analysis.PossibleVotes += int(votes.Len())
+				for j := uint64(0); j < votes.Len(); j++ {
+					if votes.BitAt(j) {
+						analysis.Votes++
+						if blockVotes[data.Slot][committeeIndex].BitAt(j) {
+							// Already attested to in this block; skip.
+							continue
+						}
+						if c.votes[data.Slot][committeeIndex].BitAt(j) {
+							// Already attested to in a previous block; skip.
+							continue
+						}
+						analysis.NewVotes++
+						blockVotes[data.Slot][committeeIndex].SetBitAt(j, true)
 					}
-					if c.votes[data.Slot][data.Index].BitAt(j) {
-						// Already attested to in a previous block; skip.
-						continue
-					}
-					analysis.NewVotes++
-					blockVotes[data.Slot][data.Index].SetBitAt(j, true)
 				}
 			}
 			// Calculate head correct.
-			var err error
-			analysis.HeadCorrect, err = c.calcHeadCorrect(ctx, attestation)
+			analysis.HeadCorrect, err = c.calcHeadCorrect(ctx, data)
 			if err != nil {
 				return err
 			}
 
 			// Calculate head timely.
-			analysis.HeadTimely = attestation.Data.Slot == slot-1
+			analysis.HeadTimely = data.Slot == slot-1
 
 			// Calculate source timely.
-			analysis.SourceTimely = attestation.Data.Slot >= slot-5
+			analysis.SourceTimely = data.Slot >= slot-5
 
 			// Calculate target correct.
-			analysis.TargetCorrect, err = c.calcTargetCorrect(ctx, attestation)
+			analysis.TargetCorrect, err = c.calcTargetCorrect(ctx, data)
 			if err != nil {
 				return err
 			}
 
 			// Calculate target timely.
-			analysis.TargetTimely = attestation.Data.Slot >= slot-32
+			analysis.TargetTimely = data.Slot >= slot-32
 		}
 
 		// Calculate score and value.
@@ -186,7 +201,7 @@ func (c *command) fetchParents(ctx context.Context, block *spec.VersionedSignedB
 	}
 
 	// Obtain the parent block.
-	parentBlock, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%#x", parentRoot))
+	parentBlock, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%#x", parentRoot)}))
 	if err != nil {
 		return err
 	}
@@ -209,7 +224,7 @@ func (c *command) fetchParents(ctx context.Context, block *spec.VersionedSignedB
 	return c.fetchParents(ctx, parentBlock, minSlot)
 }
 
-func (c *command) processParentBlock(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
+func (c *command) processParentBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
 	attestations, err := block.Attestations()
 	if err != nil {
 		return err
@@ -232,18 +247,29 @@ func (c *command) processParentBlock(_ context.Context, block *spec.VersionedSig
 			Index: i,
 		}
 
-		data := attestation.Data
+		data, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
+		committeeVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+			return c.committeeSizes.Fetch(ctx, data.Slot, committeeIndex)
+		})
+		if err != nil {
+			return err
+		}
 		_, exists := c.votes[data.Slot]
 		if !exists {
 			c.votes[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
 		}
-		_, exists = c.votes[data.Slot][data.Index]
-		if !exists {
-			c.votes[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
-		}
-		for j := uint64(0); j < attestation.AggregationBits.Len(); j++ {
-			if attestation.AggregationBits.BitAt(j) {
-				c.votes[data.Slot][data.Index].SetBitAt(j, true)
+		for committeeIndex, votes := range committeeVotes {
+			_, exists = c.votes[data.Slot][committeeIndex]
+			if !exists {
+				c.votes[data.Slot][committeeIndex] = bitfield.NewBitlist(votes.Len())
+			}
+			for j := uint64(0); j < votes.Len(); j++ {
+				if votes.BitAt(j) {
+					c.votes[data.Slot][committeeIndex].SetBitAt(j, true)
+				}
 			}
 		}
 	}
@@ -283,16 +309,22 @@ func (c *command) setup(ctx context.Context) error {
 	if !isProvider {
 		return errors.New("connection does not provide beacon block header information")
 	}
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	c.committeeSizes = util.NewBeaconCommitteeSizeCache(beaconCommitteesProvider)
 
 	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
 		return errors.New("connection does not provide spec information")
 	}
 
-	spec, err := specProvider.Spec(ctx)
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 
 	tmp, exists := spec["TIMELY_SOURCE_WEIGHT"]
 	if !exists {
@@ -357,12 +389,12 @@ func (c *command) setup(ctx context.Context) error {
 	return nil
 }
 
-func (c *command) calcHeadCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
-	slot := attestation.Data.Slot
+func (c *command) calcHeadCorrect(ctx context.Context, data *phase0.AttestationData) (bool, error) {
+	slot := data.Slot
 	root, exists := c.headRoots[slot]
 	if !exists {
 		for {
-			header, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+			header, err := util.ResponseData(c.blockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", slot)}))
 			if err != nil {
 				return false, err
 			}
@@ -376,22 +408,22 @@ func (c *command) calcHeadCorrect(ctx context.Context, attestation *phase0.Attes
 				slot--
 				continue
 			}
-			c.headRoots[attestation.Data.Slot] = header.Root
+			c.headRoots[data.Slot] = header.Root
 			root = header.Root
 			break
 		}
 	}
 
-	return bytes.Equal(root[:], attestation.Data.BeaconBlockRoot[:]), nil
+	return bytes.Equal(root[:], data.BeaconBlockRoot[:]), nil
 }
 
-func (c *command) calcTargetCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
-	root, exists := c.targetRoots[attestation.Data.Slot]
+func (c *command) calcTargetCorrect(ctx context.Context, data *phase0.AttestationData) (bool, error) {
+	root, exists := c.targetRoots[data.Slot]
 	if !exists {
 		// Start with first slot of the target epoch.
-		slot := c.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+		slot := c.chainTime.FirstSlotOfEpoch(data.Target.Epoch)
 		for {
-			header, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, fmt.Sprintf("%d", slot))
+			header, err := util.ResponseData(c.blockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", slot)}))
 			if err != nil {
 				return false, err
 			}
@@ -405,48 +437,28 @@ func (c *command) calcTargetCorrect(ctx context.Context, attestation *phase0.Att
 				slot--
 				continue
 			}
-			c.targetRoots[attestation.Data.Slot] = header.Root
+			c.targetRoots[data.Slot] = header.Root
 			root = header.Root
 			break
 		}
 	}
-	return bytes.Equal(root[:], attestation.Data.Target.Root[:]), nil
+	return bytes.Equal(root[:], data.Target.Root[:]), nil
 }
 
 func (c *command) analyzeSyncCommittees(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
 	c.analysis.SyncCommitee = &syncCommitteeAnalysis{}
-	switch block.Version {
-	case spec.DataVersionPhase0:
-		return nil
-	case spec.DataVersionAltair:
-		c.analysis.SyncCommitee.Contributions = int(block.Altair.Message.Body.SyncAggregate.SyncCommitteeBits.Count())
-		c.analysis.SyncCommitee.PossibleContributions = int(block.Altair.Message.Body.SyncAggregate.SyncCommitteeBits.Len())
-		c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
-		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
-		c.analysis.Value += c.analysis.SyncCommitee.Value
+	if block.Version == spec.DataVersionPhase0 {
 		return nil
-	case spec.DataVersionBellatrix:
-		c.analysis.SyncCommitee.Contributions = int(block.Bellatrix.Message.Body.SyncAggregate.SyncCommitteeBits.Count())
-		c.analysis.SyncCommitee.PossibleContributions = int(block.Bellatrix.Message.Body.SyncAggregate.SyncCommitteeBits.Len())
-		c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
-		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
-		c.analysis.Value += c.analysis.SyncCommitee.Value
-		return nil
-	case spec.DataVersionCapella:
-		c.analysis.SyncCommitee.Contributions = int(block.Capella.Message.Body.SyncAggregate.SyncCommitteeBits.Count())
-		c.analysis.SyncCommitee.PossibleContributions = int(block.Capella.Message.Body.SyncAggregate.SyncCommitteeBits.Len())
-		c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
-		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
-		c.analysis.Value += c.analysis.SyncCommitee.Value
-		return nil
-	case spec.DataVersionDeneb:
-		c.analysis.SyncCommitee.Contributions = int(block.Deneb.Message.Body.SyncAggregate.SyncCommitteeBits.Count())
-		c.analysis.SyncCommitee.PossibleContributions = int(block.Deneb.Message.Body.SyncAggregate.SyncCommitteeBits.Len())
-		c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
-		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
-		c.analysis.Value += c.analysis.SyncCommitee.Value
-		return nil
-	default:
-		return fmt.Errorf("unsupported block version %d", block.Version)
 	}
+	syncAggregate, err := block.SyncAggregate()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync aggregate")
+	}
+	c.analysis.SyncCommitee.Contributions = int(syncAggregate.SyncCommitteeBits.Count())
+	c.analysis.SyncCommitee.PossibleContributions = int(syncAggregate.SyncCommitteeBits.Len())
+	c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
+	c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
+	c.analysis.Value += c.analysis.SyncCommitee.Value
+
+	return nil
 }
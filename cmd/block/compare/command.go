@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	compareConnection        string
+	allowInsecureConnections bool
+
+	// Operation.
+	blockID        string
+	compareBlockID string
+
+	// Data access.
+	eth2Client        eth2client.Service
+	compareEth2Client eth2client.Service
+
+	// Output.
+	left        *blockSource
+	right       *blockSource
+	differences []*difference
+}
+
+// blockSource is a block and where it came from.
+type blockSource struct {
+	blockID    string
+	connection string
+	version    string
+	data       any
+}
+
+// difference is a single difference between the two blocks.
+type difference struct {
+	path         string
+	left         any
+	leftPresent  bool
+	right        any
+	rightPresent bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		json:        viper.GetBool("json"),
+		differences: make([]*difference, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.compareConnection = viper.GetString("compare-connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+	c.compareBlockID = viper.GetString("compare-blockid")
+	if c.compareBlockID == "" && c.compareConnection == "" {
+		return nil, errors.New("one of compare-blockid or compare-connection is required")
+	}
+	if c.compareBlockID == "" {
+		c.compareBlockID = c.blockID
+	}
+
+	return c, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		vars           map[string]interface{}
+		err            string
+		compareBlockID string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"blockid":         "1",
+				"compare-blockid": "2",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "BlockIDMissing",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"compare-blockid": "2",
+			},
+			err: "blockid is required",
+		},
+		{
+			name: "CompareMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"blockid": "1",
+			},
+			err: "one of compare-blockid or compare-connection is required",
+		},
+		{
+			name: "CompareBlockID",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"blockid":         "1",
+				"compare-blockid": "2",
+			},
+			compareBlockID: "2",
+		},
+		{
+			name: "CompareConnection",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"blockid":            "1",
+				"compare-connection": "http://localhost:5052",
+			},
+			compareBlockID: "1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.compareBlockID, c.compareBlockID)
+			}
+		})
+	}
+}
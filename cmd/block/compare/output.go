@@ -0,0 +1,140 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxValueLength is the maximum length of a value in text output, unless
+// verbose output is requested.
+const maxValueLength = 66
+
+type jsonOutput struct {
+	Left        *blockSourceJSON  `json:"left"`
+	Right       *blockSourceJSON  `json:"right"`
+	Identical   bool              `json:"identical"`
+	Differences []*differenceJSON `json:"differences"`
+}
+
+type blockSourceJSON struct {
+	BlockID    string `json:"block_id"`
+	Connection string `json:"connection"`
+	Version    string `json:"version"`
+}
+
+type differenceJSON struct {
+	Path  string `json:"path"`
+	Left  any    `json:"left,omitempty"`
+	Right any    `json:"right,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Left: &blockSourceJSON{
+			BlockID:    c.left.blockID,
+			Connection: c.left.connection,
+			Version:    c.left.version,
+		},
+		Right: &blockSourceJSON{
+			BlockID:    c.right.blockID,
+			Connection: c.right.connection,
+			Version:    c.right.version,
+		},
+		Identical:   len(c.differences) == 0,
+		Differences: make([]*differenceJSON, 0, len(c.differences)),
+	}
+	for _, difference := range c.differences {
+		output.Differences = append(output.Differences, &differenceJSON{
+			Path:  difference.path,
+			Left:  difference.left,
+			Right: difference.right,
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Left: block %s from %s (%s)\n", c.left.blockID, c.left.connection, c.left.version))
+	builder.WriteString(fmt.Sprintf("Right: block %s from %s (%s)\n", c.right.blockID, c.right.connection, c.right.version))
+
+	if len(c.differences) == 0 {
+		builder.WriteString("Blocks are identical")
+		return builder.String(), nil
+	}
+
+	builder.WriteString(fmt.Sprintf("Differences: %d\n", len(c.differences)))
+	for _, difference := range c.differences {
+		builder.WriteString(fmt.Sprintf("  %s: %s => %s\n",
+			difference.path,
+			c.formatValue(difference.left, difference.leftPresent),
+			c.formatValue(difference.right, difference.rightPresent),
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// formatValue formats a value for text output.
+func (c *command) formatValue(value any, present bool) string {
+	if !present {
+		return "(absent)"
+	}
+
+	var res string
+	switch v := value.(type) {
+	case map[string]any:
+		res = fmt.Sprintf("{%d fields}", len(v))
+	case []any:
+		res = fmt.Sprintf("[%d items]", len(v))
+	case string:
+		res = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			res = fmt.Sprintf("%v", v)
+		} else {
+			res = string(data)
+		}
+	}
+
+	if !c.verbose && len(res) > maxValueLength {
+		res = fmt.Sprintf("%s...", res[:maxValueLength])
+	}
+
+	return res
+}
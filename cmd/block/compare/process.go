@@ -0,0 +1,140 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.left, err = c.obtainBlock(ctx, c.eth2Client, c.connection, c.blockID)
+	if err != nil {
+		return err
+	}
+	compareConnection := c.compareConnection
+	if compareConnection == "" {
+		compareConnection = c.connection
+	}
+	c.right, err = c.obtainBlock(ctx, c.compareEth2Client, compareConnection, c.compareBlockID)
+	if err != nil {
+		return err
+	}
+
+	if c.left.version != c.right.version {
+		c.differences = append(c.differences, &difference{
+			path:         "version",
+			left:         c.left.version,
+			leftPresent:  true,
+			right:        c.right.version,
+			rightPresent: true,
+		})
+	}
+	c.differences = append(c.differences, compareValues("", c.left.data, c.right.data)...)
+
+	return nil
+}
+
+// obtainBlock obtains a block from a beacon node.
+func (*command) obtainBlock(ctx context.Context,
+	eth2Client eth2client.Service,
+	connection string,
+	blockID string,
+) (
+	*blockSource,
+	error,
+) {
+	if connection == "" {
+		connection = eth2Client.Address()
+	}
+
+	block, err := util.ResponseData(eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain block %s from %s", blockID, connection)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found at %s", blockID, connection)
+	}
+
+	data, err := util.SignedBeaconBlockValue(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode block")
+	}
+
+	return &blockSource{
+		blockID:    blockID,
+		connection: connection,
+		version:    block.Version.String(),
+		data:       data,
+	}, nil
+}
+
+// compareValues returns the differences between two decoded JSON values.
+func compareValues(path string, left any, right any) []*difference {
+	diffs := util.DiffValues(path, left, right)
+	differences := make([]*difference, 0, len(diffs))
+	for _, diff := range diffs {
+		differences = append(differences, &difference{
+			path:         diff.Path,
+			left:         diff.Left,
+			leftPresent:  diff.LeftPresent,
+			right:        diff.Right,
+			rightPresent: diff.RightPresent,
+		})
+	}
+
+	return differences
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	if c.compareConnection == "" {
+		c.compareEth2Client = c.eth2Client
+		return nil
+	}
+
+	c.compareEth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.compareConnection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to comparison beacon node")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockcompare
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     string
+		right    string
+		expected []*difference
+	}{
+		{
+			name:     "Identical",
+			left:     `{"message":{"slot":"1","body":{"graffiti":"0x00"}},"signature":"0x01"}`,
+			right:    `{"message":{"slot":"1","body":{"graffiti":"0x00"}},"signature":"0x01"}`,
+			expected: []*difference{},
+		},
+		{
+			name:  "Value",
+			left:  `{"message":{"slot":"1","body":{"graffiti":"0x00"}},"signature":"0x01"}`,
+			right: `{"message":{"slot":"1","body":{"graffiti":"0x01"}},"signature":"0x02"}`,
+			expected: []*difference{
+				{path: "message.body.graffiti", left: "0x00", leftPresent: true, right: "0x01", rightPresent: true},
+				{path: "signature", left: "0x01", leftPresent: true, right: "0x02", rightPresent: true},
+			},
+		},
+		{
+			name:  "Field",
+			left:  `{"message":{"slot":"1"}}`,
+			right: `{"message":{"slot":"1","proposer_index":"2"}}`,
+			expected: []*difference{
+				{path: "message.proposer_index", right: "2", rightPresent: true},
+			},
+		},
+		{
+			name:  "Array",
+			left:  `{"transactions":["0x01","0x02","0x03"]}`,
+			right: `{"transactions":["0x01","0x04"]}`,
+			expected: []*difference{
+				{path: "transactions[1]", left: "0x02", leftPresent: true, right: "0x04", rightPresent: true},
+				{path: "transactions[2]", left: "0x03", leftPresent: true},
+			},
+		},
+		{
+			name:  "Type",
+			left:  `{"data":{"a":"1"}}`,
+			right: `{"data":["1"]}`,
+			expected: []*difference{
+				{path: "data", left: map[string]any{"a": "1"}, leftPresent: true, right: []any{"1"}, rightPresent: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var left any
+			require.NoError(t, json.Unmarshal([]byte(test.left), &left))
+			var right any
+			require.NoError(t, json.Unmarshal([]byte(test.right), &right))
+			require.Equal(t, test.expected, compareValues("", left, right))
+		})
+	}
+}
@@ -0,0 +1,438 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-string2eth"
+)
+
+// blindedBody contains the parts of a blinded block body common to all forks.
+type blindedBody struct {
+	graffiti              [32]byte
+	eth1Data              *phase0.ETH1Data
+	syncAggregate         *altair.SyncAggregate
+	attestations          []*phase0.Attestation
+	attesterSlashings     []*phase0.AttesterSlashing
+	proposerSlashings     []*phase0.ProposerSlashing
+	deposits              []*phase0.Deposit
+	voluntaryExits        []*phase0.SignedVoluntaryExit
+	blsToExecutionChanges []*capella.SignedBLSToExecutionChange
+	blobKzgCommitments    []deneb.KZGCommitment
+	header                *payloadHeader
+	// electraAttestations replace attestations from Electra onwards.
+	electraAttestations []*electra.Attestation
+	executionRequests   *electra.ExecutionRequests
+}
+
+// payloadHeader contains the parts of an execution payload header common to all forks.
+type payloadHeader struct {
+	blockNumber      uint64
+	baseFeePerGas    *big.Int
+	blockHash        phase0.Hash32
+	parentHash       phase0.Hash32
+	feeRecipient     bellatrix.ExecutionAddress
+	gasLimit         uint64
+	gasUsed          uint64
+	timestamp        uint64
+	prevRandao       [32]byte
+	receiptsRoot     phase0.Root
+	stateRoot        phase0.Root
+	extraData        []byte
+	logsBloom        [256]byte
+	transactionsRoot phase0.Root
+	withdrawalsRoot  *phase0.Root
+	blobGasUsed      *uint64
+	excessBlobGas    *uint64
+}
+
+func outputBlindedBlockText(ctx context.Context,
+	data *dataOut,
+	signedBlock *api.VersionedSignedBlindedBeaconBlock,
+) (
+	string,
+	error,
+) {
+	if signedBlock == nil {
+		return "", errors.New("no block supplied")
+	}
+
+	body, err := blindedBlockBody(signedBlock)
+	if err != nil {
+		return "", err
+	}
+
+	slot, err := signedBlock.Slot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain slot")
+	}
+	proposerIndex, err := signedBlock.ProposerIndex()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain proposer index")
+	}
+	blockRoot, err := signedBlock.Root()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain block root")
+	}
+	bodyRoot, err := signedBlock.BodyRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate body root")
+	}
+	parentRoot, err := signedBlock.ParentRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain parent root")
+	}
+	stateRoot, err := signedBlock.StateRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain state root")
+	}
+
+	res := strings.Builder{}
+
+	res.WriteString("Block type: blinded (execution payload header only)\n")
+
+	// General info.
+	tmp, err := outputBlockGeneral(ctx,
+		data.verbose,
+		slot,
+		proposerIndex,
+		blockRoot,
+		bodyRoot,
+		parentRoot,
+		stateRoot,
+		body.graffiti[:],
+		data.genesisTime,
+		data.slotDuration,
+		data.slotsPerEpoch)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Eth1 data.
+	if data.verbose {
+		tmp, err := outputBlockETH1Data(ctx, body.eth1Data)
+		if err != nil {
+			return "", err
+		}
+		res.WriteString(tmp)
+	}
+
+	// Sync aggregate.
+	tmp, err = outputBlockSyncAggregate(ctx, data.eth2Client, data.verbose, body.syncAggregate, phase0.Epoch(uint64(slot)/data.slotsPerEpoch))
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attestations.
+	if signedBlock.Version >= spec.DataVersionElectra {
+		tmp, err = outputElectraBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, slot, stateRoot, body.electraAttestations)
+	} else {
+		tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, slot, stateRoot, body.attestations)
+	}
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attester slashings.
+	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, body.attesterSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.proposerSlashings)))
+
+	tmp, err = outputBlockDeposits(ctx, data.verbose, body.deposits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Voluntary exits.
+	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, body.voluntaryExits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	if signedBlock.Version >= spec.DataVersionCapella {
+		tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, body.blsToExecutionChanges)
+		if err != nil {
+			return "", err
+		}
+		res.WriteString(tmp)
+	}
+
+	if signedBlock.Version >= spec.DataVersionDeneb {
+		res.WriteString(fmt.Sprintf("Blob KZG commitments: %d\n", len(body.blobKzgCommitments)))
+	}
+
+	res.WriteString(outputBlockExecutionPayloadHeader(data.verbose, body.header))
+
+	// Execution requests are only present from Electra onwards.
+	tmp, err = outputElectraExecutionRequests(ctx, data.verbose, body.executionRequests)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	return res.String(), nil
+}
+
+// blindedBlockBody extracts the common parts of a blinded block body.
+func blindedBlockBody(signedBlock *api.VersionedSignedBlindedBeaconBlock) (*blindedBody, error) {
+	switch signedBlock.Version {
+	case spec.DataVersionBellatrix:
+		if signedBlock.Bellatrix == nil || signedBlock.Bellatrix.Message == nil || signedBlock.Bellatrix.Message.Body == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+		body := signedBlock.Bellatrix.Message.Body
+		res := &blindedBody{
+			graffiti:          body.Graffiti,
+			eth1Data:          body.ETH1Data,
+			syncAggregate:     body.SyncAggregate,
+			attestations:      body.Attestations,
+			attesterSlashings: body.AttesterSlashings,
+			proposerSlashings: body.ProposerSlashings,
+			deposits:          body.Deposits,
+			voluntaryExits:    body.VoluntaryExits,
+		}
+		if header := body.ExecutionPayloadHeader; header != nil {
+			res.header = &payloadHeader{
+				blockNumber:      header.BlockNumber,
+				baseFeePerGas:    leBytesToBigInt(header.BaseFeePerGas),
+				blockHash:        header.BlockHash,
+				parentHash:       header.ParentHash,
+				feeRecipient:     header.FeeRecipient,
+				gasLimit:         header.GasLimit,
+				gasUsed:          header.GasUsed,
+				timestamp:        header.Timestamp,
+				prevRandao:       header.PrevRandao,
+				receiptsRoot:     header.ReceiptsRoot,
+				stateRoot:        header.StateRoot,
+				extraData:        header.ExtraData,
+				logsBloom:        header.LogsBloom,
+				transactionsRoot: header.TransactionsRoot,
+			}
+		}
+		return res, nil
+	case spec.DataVersionCapella:
+		if signedBlock.Capella == nil || signedBlock.Capella.Message == nil || signedBlock.Capella.Message.Body == nil {
+			return nil, errors.New("no capella block")
+		}
+		body := signedBlock.Capella.Message.Body
+		res := &blindedBody{
+			graffiti:              body.Graffiti,
+			eth1Data:              body.ETH1Data,
+			syncAggregate:         body.SyncAggregate,
+			attestations:          body.Attestations,
+			attesterSlashings:     body.AttesterSlashings,
+			proposerSlashings:     body.ProposerSlashings,
+			deposits:              body.Deposits,
+			voluntaryExits:        body.VoluntaryExits,
+			blsToExecutionChanges: body.BLSToExecutionChanges,
+		}
+		if header := body.ExecutionPayloadHeader; header != nil {
+			withdrawalsRoot := header.WithdrawalsRoot
+			res.header = &payloadHeader{
+				blockNumber:      header.BlockNumber,
+				baseFeePerGas:    leBytesToBigInt(header.BaseFeePerGas),
+				blockHash:        header.BlockHash,
+				parentHash:       header.ParentHash,
+				feeRecipient:     header.FeeRecipient,
+				gasLimit:         header.GasLimit,
+				gasUsed:          header.GasUsed,
+				timestamp:        header.Timestamp,
+				prevRandao:       header.PrevRandao,
+				receiptsRoot:     header.ReceiptsRoot,
+				stateRoot:        header.StateRoot,
+				extraData:        header.ExtraData,
+				logsBloom:        header.LogsBloom,
+				transactionsRoot: header.TransactionsRoot,
+				withdrawalsRoot:  &withdrawalsRoot,
+			}
+		}
+		return res, nil
+	case spec.DataVersionDeneb:
+		if signedBlock.Deneb == nil || signedBlock.Deneb.Message == nil || signedBlock.Deneb.Message.Body == nil {
+			return nil, errors.New("no deneb block")
+		}
+		body := signedBlock.Deneb.Message.Body
+		res := &blindedBody{
+			graffiti:              body.Graffiti,
+			eth1Data:              body.ETH1Data,
+			syncAggregate:         body.SyncAggregate,
+			attestations:          body.Attestations,
+			attesterSlashings:     body.AttesterSlashings,
+			proposerSlashings:     body.ProposerSlashings,
+			deposits:              body.Deposits,
+			voluntaryExits:        body.VoluntaryExits,
+			blsToExecutionChanges: body.BLSToExecutionChanges,
+			blobKzgCommitments:    body.BlobKZGCommitments,
+		}
+		if header := body.ExecutionPayloadHeader; header != nil {
+			withdrawalsRoot := header.WithdrawalsRoot
+			blobGasUsed := header.BlobGasUsed
+			excessBlobGas := header.ExcessBlobGas
+			res.header = &payloadHeader{
+				blockNumber:      header.BlockNumber,
+				blockHash:        header.BlockHash,
+				parentHash:       header.ParentHash,
+				feeRecipient:     header.FeeRecipient,
+				gasLimit:         header.GasLimit,
+				gasUsed:          header.GasUsed,
+				timestamp:        header.Timestamp,
+				prevRandao:       header.PrevRandao,
+				receiptsRoot:     header.ReceiptsRoot,
+				stateRoot:        header.StateRoot,
+				extraData:        header.ExtraData,
+				logsBloom:        header.LogsBloom,
+				transactionsRoot: header.TransactionsRoot,
+				withdrawalsRoot:  &withdrawalsRoot,
+				blobGasUsed:      &blobGasUsed,
+				excessBlobGas:    &excessBlobGas,
+			}
+			if header.BaseFeePerGas != nil {
+				res.header.baseFeePerGas = header.BaseFeePerGas.ToBig()
+			}
+		}
+		return res, nil
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		block := signedBlock.Electra
+		if signedBlock.Version == spec.DataVersionFulu {
+			block = signedBlock.Fulu
+		}
+		if block == nil || block.Message == nil || block.Message.Body == nil {
+			return nil, fmt.Errorf("no %s block", signedBlock.Version)
+		}
+		body := block.Message.Body
+		res := &blindedBody{
+			graffiti:              body.Graffiti,
+			eth1Data:              body.ETH1Data,
+			syncAggregate:         body.SyncAggregate,
+			electraAttestations:   body.Attestations,
+			attesterSlashings:     electraAttesterSlashings(body.AttesterSlashings),
+			proposerSlashings:     body.ProposerSlashings,
+			deposits:              body.Deposits,
+			voluntaryExits:        body.VoluntaryExits,
+			blsToExecutionChanges: body.BLSToExecutionChanges,
+			blobKzgCommitments:    body.BlobKZGCommitments,
+			executionRequests:     body.ExecutionRequests,
+		}
+		if header := body.ExecutionPayloadHeader; header != nil {
+			withdrawalsRoot := header.WithdrawalsRoot
+			blobGasUsed := header.BlobGasUsed
+			excessBlobGas := header.ExcessBlobGas
+			res.header = &payloadHeader{
+				blockNumber:      header.BlockNumber,
+				blockHash:        header.BlockHash,
+				parentHash:       header.ParentHash,
+				feeRecipient:     header.FeeRecipient,
+				gasLimit:         header.GasLimit,
+				gasUsed:          header.GasUsed,
+				timestamp:        header.Timestamp,
+				prevRandao:       header.PrevRandao,
+				receiptsRoot:     header.ReceiptsRoot,
+				stateRoot:        header.StateRoot,
+				extraData:        header.ExtraData,
+				logsBloom:        header.LogsBloom,
+				transactionsRoot: header.TransactionsRoot,
+				withdrawalsRoot:  &withdrawalsRoot,
+				blobGasUsed:      &blobGasUsed,
+				excessBlobGas:    &excessBlobGas,
+			}
+			if header.BaseFeePerGas != nil {
+				res.header.baseFeePerGas = header.BaseFeePerGas.ToBig()
+			}
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("no blinded form for %s blocks", signedBlock.Version)
+	}
+}
+
+func outputBlockExecutionPayloadHeader(verbose bool, header *payloadHeader) string {
+	// If the block number is 0 then we're before the merge.
+	if header == nil || header.blockNumber == 0 {
+		return ""
+	}
+
+	res := strings.Builder{}
+	if !verbose {
+		res.WriteString(fmt.Sprintf("Execution block number: %d\n", header.blockNumber))
+		res.WriteString(fmt.Sprintf("Execution block hash: %#x\n", header.blockHash))
+		return res.String()
+	}
+
+	res.WriteString("Execution payload header:\n")
+	res.WriteString(fmt.Sprintf("  Execution block number: %d\n", header.blockNumber))
+	if header.baseFeePerGas != nil {
+		res.WriteString(fmt.Sprintf("  Base fee per gas: %s\n", string2eth.WeiToString(header.baseFeePerGas, true)))
+	}
+	res.WriteString(fmt.Sprintf("  Block hash: %#x\n", header.blockHash))
+	res.WriteString(fmt.Sprintf("  Parent hash: %#x\n", header.parentHash))
+	res.WriteString(fmt.Sprintf("  Fee recipient: %s\n", header.feeRecipient.String()))
+	res.WriteString(fmt.Sprintf("  Gas limit: %d\n", header.gasLimit))
+	res.WriteString(fmt.Sprintf("  Gas used: %d\n", header.gasUsed))
+	if header.blobGasUsed != nil {
+		res.WriteString(fmt.Sprintf("  Blob gas used: %d\n", *header.blobGasUsed))
+	}
+	if header.excessBlobGas != nil {
+		res.WriteString(fmt.Sprintf("  Excess blob gas: %d\n", *header.excessBlobGas))
+	}
+	res.WriteString(fmt.Sprintf("  Timestamp: %s (%d)\n", time.Unix(int64(header.timestamp), 0).String(), header.timestamp))
+	res.WriteString(fmt.Sprintf("  Prev RANDAO: %#x\n", header.prevRandao))
+	res.WriteString(fmt.Sprintf("  Receipts root: %#x\n", header.receiptsRoot))
+	res.WriteString(fmt.Sprintf("  State root: %#x\n", header.stateRoot))
+	if utf8.Valid(header.extraData) {
+		res.WriteString(fmt.Sprintf("  Extra data: %s\n", string(header.extraData)))
+	} else {
+		res.WriteString(fmt.Sprintf("  Extra data: %#x\n", header.extraData))
+	}
+	res.WriteString(fmt.Sprintf("  Logs bloom: %#x\n", header.logsBloom))
+	res.WriteString(fmt.Sprintf("  Transactions root: %#x\n", header.transactionsRoot))
+	if header.withdrawalsRoot != nil {
+		res.WriteString(fmt.Sprintf("  Withdrawals root: %#x\n", *header.withdrawalsRoot))
+	}
+
+	return res.String()
+}
+
+// leBytesToBigInt converts a little-endian 32-byte value to a big integer.
+func leBytesToBigInt(input [32]byte) *big.Int {
+	beBytes := make([]byte, len(input))
+	for i := range input {
+		beBytes[i] = input[len(input)-1-i]
+	}
+
+	return new(big.Int).SetBytes(beBytes)
+}
@@ -0,0 +1,82 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputBlindedBlockText(t *testing.T) {
+	data := &dataOut{
+		genesisTime:   time.Unix(1606824023, 0),
+		slotDuration:  12 * time.Second,
+		slotsPerEpoch: 32,
+	}
+
+	tests := []struct {
+		name        string
+		signedBlock *api.VersionedSignedBlindedBeaconBlock
+		contains    []string
+		err         string
+	}{
+		{
+			name: "Nil",
+			err:  "no block supplied",
+		},
+		{
+			name: "Phase0",
+			signedBlock: &api.VersionedSignedBlindedBeaconBlock{
+				Version: spec.DataVersionPhase0,
+			},
+			err: "no blinded form for phase0 blocks",
+		},
+		{
+			name: "MissingBlock",
+			signedBlock: &api.VersionedSignedBlindedBeaconBlock{
+				Version: spec.DataVersionCapella,
+			},
+			err: "no capella block",
+		},
+		{
+			name:        "Good",
+			signedBlock: testBlindedBlock(),
+			contains: []string{
+				"Block type: blinded (execution payload header only)\n",
+				"Slot: 5\n",
+				"Execution block number: 7\n",
+				"Execution block hash: 0x0400000000000000000000000000000000000000000000000000000000000000\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := outputBlindedBlockText(context.Background(), data, test.signedBlock)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			for _, contains := range test.contains {
+				require.Contains(t, res, contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+var (
+	// verifyBlobs is true if the blob sidecars of a block should be verified.
+	verifyBlobs bool
+	// kzgSetup is the trusted setup used to verify KZG proofs.
+	kzgSetup *util.KZGSetup
+)
+
+const (
+	blobValid      = "valid"
+	blobInvalid    = "invalid"
+	blobNotChecked = "not checked"
+	blobMissing    = "missing"
+)
+
+// blobVerification is the result of verifying a blob sidecar.
+type blobVerification struct {
+	Index          deneb.BlobIndex `json:"index"`
+	InclusionProof string          `json:"inclusion_proof"`
+	KZGProof       string          `json:"kzg_proof"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// verifyBlockBlobs verifies the blob sidecars of a block against the
+// commitments and body root of the block.
+func verifyBlockBlobs(ctx context.Context,
+	blockID string,
+	commitments []deneb.KZGCommitment,
+	bodyRoot phase0.Root,
+) (
+	[]*blobVerification,
+	error,
+) {
+	if len(commitments) == 0 {
+		return []*blobVerification{}, nil
+	}
+
+	sidecars, _, err := util.BlobSidecars(ctx, results.eth2Client, timeout, blockID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain blob sidecars")
+	}
+	sidecarsByIndex := make(map[deneb.BlobIndex]*util.BlobSidecar, len(sidecars))
+	for _, sidecar := range sidecars {
+		sidecarsByIndex[sidecar.Index] = sidecar
+	}
+
+	verifications := make([]*blobVerification, 0, len(commitments))
+	for i, commitment := range commitments {
+		index := deneb.BlobIndex(i)
+		sidecar, exists := sidecarsByIndex[index]
+		if !exists {
+			verifications = append(verifications, &blobVerification{
+				Index:          index,
+				InclusionProof: blobMissing,
+				KZGProof:       blobMissing,
+			})
+			continue
+		}
+		verifications = append(verifications, verifyBlobSidecar(sidecar, commitment, bodyRoot))
+	}
+
+	return verifications, nil
+}
+
+// verifyBlobSidecar verifies a single blob sidecar.
+func verifyBlobSidecar(sidecar *util.BlobSidecar,
+	commitment deneb.KZGCommitment,
+	bodyRoot phase0.Root,
+) *blobVerification {
+	res := &blobVerification{
+		Index:          sidecar.Index,
+		InclusionProof: blobInvalid,
+		KZGProof:       blobNotChecked,
+	}
+
+	switch {
+	case !bytes.Equal(sidecar.KZGCommitment[:], commitment[:]):
+		res.Error = "sidecar commitment does not match block"
+	case sidecar.SignedBlockHeader == nil || sidecar.SignedBlockHeader.Message == nil:
+		res.Error = "sidecar missing block header"
+	case !bytes.Equal(sidecar.SignedBlockHeader.Message.BodyRoot[:], bodyRoot[:]):
+		res.Error = "sidecar body root does not match block"
+	case util.VerifyKZGCommitmentInclusionProof(sidecar.KZGCommitment, sidecar.Index, sidecar.KZGCommitmentInclusionProof, bodyRoot):
+		res.InclusionProof = blobValid
+	}
+
+	if kzgSetup != nil {
+		valid, err := kzgSetup.VerifyBlobKZGProof(sidecar.Blob[:], sidecar.KZGCommitment[:], sidecar.KZGProof[:])
+		switch {
+		case err != nil:
+			res.KZGProof = blobInvalid
+			if res.Error == "" {
+				res.Error = err.Error()
+			}
+		case valid:
+			res.KZGProof = blobValid
+		default:
+			res.KZGProof = blobInvalid
+		}
+	}
+
+	return res
+}
+
+// outputBlobVerifications outputs the results of verifying blob sidecars.
+func outputBlobVerifications(verifications []*blobVerification) string {
+	if len(verifications) == 0 {
+		return ""
+	}
+
+	res := strings.Builder{}
+	res.WriteString("Blob verification:\n")
+	for _, verification := range verifications {
+		res.WriteString(fmt.Sprintf("  Blob %d: inclusion proof %s, KZG proof %s", verification.Index, verification.InclusionProof, verification.KZGProof))
+		if verification.Error != "" {
+			res.WriteString(fmt.Sprintf(" (%s)", verification.Error))
+		}
+		res.WriteString("\n")
+	}
+
+	return res.String()
+}
+
+// addBlobVerifications adds the results of verifying blob sidecars to the JSON of a block.
+func addBlobVerifications(data []byte, verifications []*blobVerification) ([]byte, error) {
+	block := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, errors.Wrap(err, "failed to parse block JSON")
+	}
+
+	encoded, err := json.Marshal(verifications)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate blob verification JSON")
+	}
+	block["blob_verifications"] = encoded
+
+	return json.Marshal(block)
+}
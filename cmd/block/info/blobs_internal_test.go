@@ -0,0 +1,217 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testTrustedSetup is a trusted setup whose secret is 1, for testing only.
+const testTrustedSetup = `{"g2_monomial":["0x93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8","0x93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"]}`
+
+// testBlobSidecars returns the body root of a block with two empty blobs,
+// along with sidecars for the blobs.
+func testBlobSidecars(t *testing.T) (phase0.Root, []*util.BlobSidecar) {
+	t.Helper()
+
+	// The commitment and proof of an empty blob are both the point at infinity.
+	commitment := deneb.KZGCommitment{0xc0}
+	body := &deneb.BeaconBlockBody{
+		ETH1Data: &phase0.ETH1Data{
+			BlockHash: make([]byte, 32),
+		},
+		SyncAggregate: &altair.SyncAggregate{
+			SyncCommitteeBits: bitfield.NewBitvector512(),
+		},
+		ExecutionPayload: &deneb.ExecutionPayload{
+			BaseFeePerGas: uint256.NewInt(7),
+		},
+		BlobKZGCommitments: []deneb.KZGCommitment{commitment, commitment},
+	}
+	bodyRoot, err := body.HashTreeRoot()
+	require.NoError(t, err)
+	tree, err := body.GetTree()
+	require.NoError(t, err)
+
+	sidecars := make([]*util.BlobSidecar, 0, len(body.BlobKZGCommitments))
+	for i := range body.BlobKZGCommitments {
+		proof, err := tree.Prove((16+11)*2*4096 + i)
+		require.NoError(t, err)
+		sidecar := &util.BlobSidecar{
+			Index:         deneb.BlobIndex(i),
+			KZGCommitment: commitment,
+			KZGProof:      deneb.KZGProof{0xc0},
+			SignedBlockHeader: &phase0.SignedBeaconBlockHeader{
+				Message: &phase0.BeaconBlockHeader{
+					BodyRoot: bodyRoot,
+				},
+			},
+		}
+		for _, hash := range proof.Hashes {
+			sidecar.KZGCommitmentInclusionProof = append(sidecar.KZGCommitmentInclusionProof, phase0.Root(hash))
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+
+	return bodyRoot, sidecars
+}
+
+func TestVerifyBlobSidecar(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	setup, err := util.ParseKZGSetup([]byte(testTrustedSetup))
+	require.NoError(t, err)
+
+	bodyRoot, sidecars := testBlobSidecars(t)
+	misplaced := *sidecars[1]
+	misplaced.Index = 2
+	badProof := *sidecars[1]
+	badProof.KZGProof = deneb.KZGProof{0x01}
+
+	tests := []struct {
+		name       string
+		sidecar    *util.BlobSidecar
+		commitment deneb.KZGCommitment
+		bodyRoot   phase0.Root
+		setup      *util.KZGSetup
+		res        *blobVerification
+	}{
+		{
+			name:       "NoSetup",
+			sidecar:    sidecars[0],
+			commitment: sidecars[0].KZGCommitment,
+			bodyRoot:   bodyRoot,
+			res: &blobVerification{
+				Index:          0,
+				InclusionProof: blobValid,
+				KZGProof:       blobNotChecked,
+			},
+		},
+		{
+			name:       "Setup",
+			sidecar:    sidecars[1],
+			commitment: sidecars[1].KZGCommitment,
+			bodyRoot:   bodyRoot,
+			setup:      setup,
+			res: &blobVerification{
+				Index:          1,
+				InclusionProof: blobValid,
+				KZGProof:       blobValid,
+			},
+		},
+		{
+			name:       "WrongIndex",
+			sidecar:    &misplaced,
+			commitment: sidecars[1].KZGCommitment,
+			bodyRoot:   bodyRoot,
+			res: &blobVerification{
+				Index:          2,
+				InclusionProof: blobInvalid,
+				KZGProof:       blobNotChecked,
+			},
+		},
+		{
+			name:       "CommitmentMismatch",
+			sidecar:    sidecars[0],
+			commitment: deneb.KZGCommitment{0x01},
+			bodyRoot:   bodyRoot,
+			res: &blobVerification{
+				Index:          0,
+				InclusionProof: blobInvalid,
+				KZGProof:       blobNotChecked,
+				Error:          "sidecar commitment does not match block",
+			},
+		},
+		{
+			name:       "BodyRootMismatch",
+			sidecar:    sidecars[0],
+			commitment: sidecars[0].KZGCommitment,
+			bodyRoot:   phase0.Root{0x01},
+			res: &blobVerification{
+				Index:          0,
+				InclusionProof: blobInvalid,
+				KZGProof:       blobNotChecked,
+				Error:          "sidecar body root does not match block",
+			},
+		},
+		{
+			name:       "KZGProofInvalid",
+			sidecar:    &badProof,
+			commitment: sidecars[1].KZGCommitment,
+			bodyRoot:   bodyRoot,
+			setup:      setup,
+			res: &blobVerification{
+				Index:          1,
+				InclusionProof: blobValid,
+				KZGProof:       blobInvalid,
+				Error:          "invalid proof: err mclBnG1_deserialize 010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kzgSetup = test.setup
+			defer func() { kzgSetup = nil }()
+			res := verifyBlobSidecar(test.sidecar, test.commitment, test.bodyRoot)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestOutputBlobVerifications(t *testing.T) {
+	verifications := []*blobVerification{
+		{
+			Index:          0,
+			InclusionProof: blobValid,
+			KZGProof:       blobNotChecked,
+		},
+		{
+			Index:          1,
+			InclusionProof: blobInvalid,
+			KZGProof:       blobNotChecked,
+			Error:          "sidecar body root does not match block",
+		},
+		{
+			Index:          2,
+			InclusionProof: blobMissing,
+			KZGProof:       blobMissing,
+		},
+	}
+
+	require.Equal(t, "", outputBlobVerifications(nil))
+	require.Equal(t, `Blob verification:
+  Blob 0: inclusion proof valid, KZG proof not checked
+  Blob 1: inclusion proof invalid, KZG proof not checked (sidecar body root does not match block)
+  Blob 2: inclusion proof missing, KZG proof missing
+`, outputBlobVerifications(verifications))
+
+	data, err := addBlobVerifications([]byte(`{"message":{}}`), verifications[:2])
+	require.NoError(t, err)
+	res := make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.JSONEq(t, `[{"index":"0","inclusion_proof":"valid","kzg_proof":"not checked"},{"index":"1","inclusion_proof":"invalid","kzg_proof":"not checked","error":"sidecar body root does not match block"}]`, string(res["blob_verifications"]))
+
+	_, err = addBlobVerifications([]byte(`invalid`), verifications)
+	require.EqualError(t, err, "failed to parse block JSON: invalid character 'i' looking for beginning of value")
+}
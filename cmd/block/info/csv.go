@@ -0,0 +1,247 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// blockCSVHeader is the header of the CSV output.
+var blockCSVHeader = []string{
+	"slot",
+	"proposer_index",
+	"block_root",
+	"parent_root",
+	"state_root",
+	"graffiti",
+	"attestations",
+	"attester_slashings",
+	"proposer_slashings",
+	"deposits",
+	"voluntary_exits",
+	"bls_to_execution_changes",
+	"sync_committee_participants",
+	"execution_block_number",
+	"execution_block_hash",
+	"fee_recipient",
+	"gas_used",
+	"gas_limit",
+	"base_fee_per_gas",
+	"transactions",
+	"blob_kzg_commitments",
+}
+
+// blockCSVData is the data in a block's CSV record.
+type blockCSVData struct {
+	slot                  phase0.Slot
+	proposerIndex         phase0.ValidatorIndex
+	root                  phase0.Root
+	parentRoot            phase0.Root
+	stateRoot             phase0.Root
+	graffiti              []byte
+	attestations          int
+	attesterSlashings     int
+	proposerSlashings     int
+	deposits              int
+	voluntaryExits        int
+	blsToExecutionChanges *int
+	syncAggregate         *altair.SyncAggregate
+	payload               *blockCSVPayload
+	blobKZGCommitments    *int
+}
+
+// blockCSVPayload is the execution payload data in a block's CSV record.
+type blockCSVPayload struct {
+	blockNumber   uint64
+	blockHash     phase0.Hash32
+	feeRecipient  bellatrix.ExecutionAddress
+	gasUsed       uint64
+	gasLimit      uint64
+	baseFeePerGas *big.Int
+	transactions  int
+}
+
+// record returns the CSV record for the block.  Fields that do not exist in
+// the block's fork are left empty.
+func (d *blockCSVData) record() []string {
+	optionalCount := func(val *int) string {
+		if val == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", *val)
+	}
+
+	record := []string{
+		fmt.Sprintf("%d", d.slot),
+		fmt.Sprintf("%d", d.proposerIndex),
+		fmt.Sprintf("%#x", d.root),
+		fmt.Sprintf("%#x", d.parentRoot),
+		fmt.Sprintf("%#x", d.stateRoot),
+		util.DecodeGraffiti(d.graffiti).Text,
+		fmt.Sprintf("%d", d.attestations),
+		fmt.Sprintf("%d", d.attesterSlashings),
+		fmt.Sprintf("%d", d.proposerSlashings),
+		fmt.Sprintf("%d", d.deposits),
+		fmt.Sprintf("%d", d.voluntaryExits),
+		optionalCount(d.blsToExecutionChanges),
+	}
+
+	if d.syncAggregate != nil {
+		record = append(record, fmt.Sprintf("%d", d.syncAggregate.SyncCommitteeBits.Count()))
+	} else {
+		record = append(record, "")
+	}
+
+	// A payload with block number 0 is prior to the merge.
+	if d.payload != nil && d.payload.blockNumber != 0 {
+		record = append(record,
+			fmt.Sprintf("%d", d.payload.blockNumber),
+			fmt.Sprintf("%#x", d.payload.blockHash),
+			d.payload.feeRecipient.String(),
+			fmt.Sprintf("%d", d.payload.gasUsed),
+			fmt.Sprintf("%d", d.payload.gasLimit),
+			d.payload.baseFeePerGas.String(),
+			fmt.Sprintf("%d", d.payload.transactions),
+		)
+	} else {
+		record = append(record, "", "", "", "", "", "", "")
+	}
+
+	return append(record, optionalCount(d.blobKZGCommitments))
+}
+
+// blockCSVDataFromBlock obtains the CSV data for a block decoded by the client.
+func blockCSVDataFromBlock(signedBlock *spec.VersionedSignedBeaconBlock) (*blockCSVData, error) {
+	data := &blockCSVData{}
+	var err error
+	if data.root, err = signedBlock.Root(); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block root")
+	}
+
+	switch signedBlock.Version {
+	case spec.DataVersionPhase0:
+		block := signedBlock.Phase0.Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+	case spec.DataVersionAltair:
+		block := signedBlock.Altair.Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+		data.syncAggregate = body.SyncAggregate
+	case spec.DataVersionBellatrix:
+		block := signedBlock.Bellatrix.Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+		data.syncAggregate = body.SyncAggregate
+		if payload := body.ExecutionPayload; payload != nil {
+			data.payload = &blockCSVPayload{
+				blockNumber:   payload.BlockNumber,
+				blockHash:     payload.BlockHash,
+				feeRecipient:  payload.FeeRecipient,
+				gasUsed:       payload.GasUsed,
+				gasLimit:      payload.GasLimit,
+				baseFeePerGas: leBytesToBigInt(payload.BaseFeePerGas),
+				transactions:  len(payload.Transactions),
+			}
+		}
+	case spec.DataVersionCapella:
+		block := signedBlock.Capella.Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+		blsToExecutionChanges := len(body.BLSToExecutionChanges)
+		data.blsToExecutionChanges = &blsToExecutionChanges
+		data.syncAggregate = body.SyncAggregate
+		if payload := body.ExecutionPayload; payload != nil {
+			data.payload = &blockCSVPayload{
+				blockNumber:   payload.BlockNumber,
+				blockHash:     payload.BlockHash,
+				feeRecipient:  payload.FeeRecipient,
+				gasUsed:       payload.GasUsed,
+				gasLimit:      payload.GasLimit,
+				baseFeePerGas: leBytesToBigInt(payload.BaseFeePerGas),
+				transactions:  len(payload.Transactions),
+			}
+		}
+	case spec.DataVersionDeneb:
+		block := signedBlock.Deneb.Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+		blsToExecutionChanges := len(body.BLSToExecutionChanges)
+		data.blsToExecutionChanges = &blsToExecutionChanges
+		data.syncAggregate = body.SyncAggregate
+		if payload := body.ExecutionPayload; payload != nil {
+			data.payload = &blockCSVPayload{
+				blockNumber:   payload.BlockNumber,
+				blockHash:     payload.BlockHash,
+				feeRecipient:  payload.FeeRecipient,
+				gasUsed:       payload.GasUsed,
+				gasLimit:      payload.GasLimit,
+				baseFeePerGas: payload.BaseFeePerGas.ToBig(),
+				transactions:  len(payload.Transactions),
+			}
+		}
+		blobKZGCommitments := len(body.BlobKZGCommitments)
+		data.blobKZGCommitments = &blobKZGCommitments
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		block := electraBlock(signedBlock).Message
+		data.slot, data.proposerIndex, data.parentRoot, data.stateRoot = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot
+		body := block.Body
+		data.graffiti = body.Graffiti[:]
+		data.attestations, data.attesterSlashings, data.proposerSlashings = len(body.Attestations), len(body.AttesterSlashings), len(body.ProposerSlashings)
+		data.deposits, data.voluntaryExits = len(body.Deposits), len(body.VoluntaryExits)
+		blsToExecutionChanges := len(body.BLSToExecutionChanges)
+		data.blsToExecutionChanges = &blsToExecutionChanges
+		data.syncAggregate = body.SyncAggregate
+		if payload := body.ExecutionPayload; payload != nil {
+			data.payload = &blockCSVPayload{
+				blockNumber:   payload.BlockNumber,
+				blockHash:     payload.BlockHash,
+				feeRecipient:  payload.FeeRecipient,
+				gasUsed:       payload.GasUsed,
+				gasLimit:      payload.GasLimit,
+				baseFeePerGas: payload.BaseFeePerGas.ToBig(),
+				transactions:  len(payload.Transactions),
+			}
+		}
+		blobKZGCommitments := len(body.BlobKZGCommitments)
+		data.blobKZGCommitments = &blobKZGCommitments
+	default:
+		return nil, errors.New("unknown block version")
+	}
+
+	return data, nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	bitfield "github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+)
+
+func testCSVCapellaBlock() *spec.VersionedSignedBeaconBlock {
+	syncCommitteeBits := bitfield.NewBitvector512()
+	syncCommitteeBits.SetBitAt(1, true)
+	syncCommitteeBits.SetBitAt(5, true)
+	graffiti := [32]byte{}
+	copy(graffiti[:], "test, graffiti")
+	baseFeePerGas := [32]byte{}
+	baseFeePerGas[0] = 0x07
+
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Slot:          100,
+				ProposerIndex: 12,
+				ParentRoot:    phase0.Root{0x01},
+				StateRoot:     phase0.Root{0x02},
+				Body: &capella.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+					Graffiti: graffiti,
+					Attestations: []*phase0.Attestation{
+						{
+							AggregationBits: bitfield.NewBitlist(8),
+							Data: &phase0.AttestationData{
+								Source: &phase0.Checkpoint{},
+								Target: &phase0.Checkpoint{},
+							},
+						},
+					},
+					SyncAggregate: &altair.SyncAggregate{
+						SyncCommitteeBits: syncCommitteeBits,
+					},
+					ExecutionPayload: &capella.ExecutionPayload{
+						BlockNumber:   1000,
+						BlockHash:     phase0.Hash32{0x03},
+						FeeRecipient:  bellatrix.ExecutionAddress{0x04},
+						GasUsed:       21000,
+						GasLimit:      30000000,
+						BaseFeePerGas: baseFeePerGas,
+						Transactions:  []bellatrix.Transaction{{0x01}, {0x02}},
+					},
+					BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+				},
+			},
+		},
+	}
+}
+
+func testCSVElectraBlock(version spec.DataVersion) *spec.VersionedSignedBeaconBlock {
+	syncCommitteeBits := bitfield.NewBitvector512()
+	syncCommitteeBits.SetBitAt(1, true)
+	block := &electra.SignedBeaconBlock{
+		Message: &electra.BeaconBlock{
+			Slot:          200,
+			ProposerIndex: 13,
+			ParentRoot:    phase0.Root{0x01},
+			StateRoot:     phase0.Root{0x02},
+			Body: &electra.BeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+				Attestations: []*electra.Attestation{
+					{
+						AggregationBits: bitfield.NewBitlist(8),
+						Data: &phase0.AttestationData{
+							Source: &phase0.Checkpoint{},
+							Target: &phase0.Checkpoint{},
+						},
+						CommitteeBits: bitfield.NewBitvector64(),
+					},
+				},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: syncCommitteeBits,
+				},
+				ExecutionPayload: &deneb.ExecutionPayload{
+					BlockNumber:   2000,
+					BlockHash:     phase0.Hash32{0x03},
+					FeeRecipient:  bellatrix.ExecutionAddress{0x04},
+					GasUsed:       21000,
+					GasLimit:      36000000,
+					BaseFeePerGas: uint256.NewInt(9),
+					Transactions:  []bellatrix.Transaction{{0x01}},
+				},
+				BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+				BlobKZGCommitments:    []deneb.KZGCommitment{{0x05}, {0x06}},
+				ExecutionRequests:     &electra.ExecutionRequests{},
+			},
+		},
+	}
+
+	res := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+	if version == spec.DataVersionFulu {
+		res.Fulu = block
+	} else {
+		res.Electra = block
+	}
+
+	return res
+}
+
+func TestBlockCSVDataFromBlock(t *testing.T) {
+	tests := []struct {
+		name        string
+		signedBlock *spec.VersionedSignedBeaconBlock
+		prefix      string
+		suffix      string
+		err         string
+	}{
+		{
+			name: "UnknownVersion",
+			signedBlock: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionUnknown,
+			},
+			err: "failed to obtain block root: unknown version",
+		},
+		{
+			name:        "Capella",
+			signedBlock: testCSVCapellaBlock(),
+			prefix:      "100,12,0x",
+			suffix:      `,"test, graffiti",1,0,0,0,0,0,2,1000,0x0300000000000000000000000000000000000000000000000000000000000000,0x0400000000000000000000000000000000000000,21000,30000000,7,2,`,
+		},
+		{
+			name:        "Electra",
+			signedBlock: testCSVElectraBlock(spec.DataVersionElectra),
+			prefix:      "200,13,0x",
+			suffix:      `,1,0,0,0,0,0,1,2000,0x0300000000000000000000000000000000000000000000000000000000000000,0x0400000000000000000000000000000000000000,21000,36000000,9,1,2`,
+		},
+		{
+			name:        "Fulu",
+			signedBlock: testCSVElectraBlock(spec.DataVersionFulu),
+			prefix:      "200,13,0x",
+			suffix:      `,1,0,0,0,0,0,1,2000,0x0300000000000000000000000000000000000000000000000000000000000000,0x0400000000000000000000000000000000000000,21000,36000000,9,1,2`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := blockCSVDataFromBlock(test.signedBlock)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			record := data.record()
+			require.Len(t, record, len(blockCSVHeader))
+			require.Equal(t, "0x0100000000000000000000000000000000000000000000000000000000000000", record[3])
+			require.Equal(t, "0x0200000000000000000000000000000000000000000000000000000000000000", record[4])
+			res, err := utiloutput.CSVString(nil, [][]string{record})
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(res, test.prefix), res)
+			require.True(t, strings.HasSuffix(res, test.suffix), res)
+		})
+	}
+}
+
+func TestBlockCSVRecordMissingFields(t *testing.T) {
+	data := &blockCSVData{
+		slot: 5,
+	}
+	record := data.record()
+	require.Len(t, record, len(blockCSVHeader))
+	// Fields that are not present in the block's fork are empty.
+	for _, i := range []int{11, 12, 13, 14, 15, 16, 17, 18, 19, 20} {
+		require.Empty(t, record[i], blockCSVHeader[i])
+	}
+}
@@ -0,0 +1,588 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+)
+
+// maxDiffValueLength is the maximum length of a value in text diff output,
+// unless verbose output is requested.
+const maxDiffValueLength = 66
+
+// Paths of the parts of the block that are diffed separately.
+const (
+	attestationsPath = "message.body.attestations"
+	payloadPath      = "message.body.execution_payload"
+	transactionsPath = "message.body.execution_payload.transactions"
+)
+
+// diffRoots are the roots within the block that are reported in the roots
+// section of a diff.
+var diffRoots = []struct {
+	name string
+	path string
+}{
+	{"parent_root", "message.parent_root"},
+	{"state_root", "message.state_root"},
+}
+
+// diffBlock is a block that is being diffed.
+type diffBlock struct {
+	blockID   string
+	version   string
+	root      string
+	bodyRoot  string
+	canonical *bool
+	data      any
+}
+
+// blockDiff is the difference between two blocks.
+type blockDiff struct {
+	left  *diffBlock
+	right *diffBlock
+	// roots are the roots that differ.
+	roots []*util.Difference
+	// attestations are the indices of the attestations only in each block.
+	leftAttestations  []int
+	rightAttestations []int
+	// hasPayload is true if either block has an execution payload.
+	hasPayload bool
+	payload    []*util.Difference
+	// transactions are the indices of the transactions only in each block.
+	leftTransactions  []int
+	rightTransactions []int
+	// other are the remaining differences.
+	other []*util.Difference
+}
+
+// identical returns true if there are no differences between the blocks.
+func (d *blockDiff) identical() bool {
+	return d.left.version == d.right.version &&
+		len(d.roots) == 0 &&
+		len(d.leftAttestations) == 0 &&
+		len(d.rightAttestations) == 0 &&
+		len(d.payload) == 0 &&
+		len(d.leftTransactions) == 0 &&
+		len(d.rightTransactions) == 0 &&
+		len(d.other) == 0
+}
+
+// processDiff outputs the differences between two blocks.
+func processDiff(ctx context.Context, data *dataIn) (*dataOut, error) {
+	left, err := obtainDiffBlock(ctx, data.blockID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := obtainDiffBlock(ctx, data.diffBlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffBlocks(left, right)
+	if data.quiet {
+		if diff.identical() {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+
+	if err := renderBlock(ctx, &blockDiffRenderer{diff: diff}); err != nil {
+		return nil, errors.Wrap(err, "failed to output diff")
+	}
+
+	return &dataOut{}, nil
+}
+
+// obtainDiffBlock obtains a block to diff.
+func obtainDiffBlock(ctx context.Context, blockID string) (*diffBlock, error) {
+	block, err := util.ResponseData(results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain block %s", blockID)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", blockID)
+	}
+	data, err := util.SignedBeaconBlockValue(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode block %s", blockID)
+	}
+
+	res := &diffBlock{
+		blockID: blockID,
+		version: block.Version.String(),
+		data:    data,
+	}
+
+	// The block and body roots are not part of the block, so come from its header.
+	if provider, isProvider := results.eth2Client.(eth2client.BeaconBlockHeadersProvider); isProvider {
+		header, err := util.ResponseData(provider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: blockID}))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to obtain header for block %s", blockID)
+		}
+		if header != nil && header.Header != nil && header.Header.Message != nil {
+			res.root = fmt.Sprintf("%#x", header.Root)
+			res.bodyRoot = fmt.Sprintf("%#x", header.Header.Message.BodyRoot)
+			canonical := header.Canonical
+			res.canonical = &canonical
+		}
+	}
+
+	return res, nil
+}
+
+// diffBlocks generates the difference between two blocks.
+func diffBlocks(left *diffBlock, right *diffBlock) *blockDiff {
+	diff := &blockDiff{
+		left:  left,
+		right: right,
+		roots: make([]*util.Difference, 0),
+	}
+
+	if left.root != right.root {
+		diff.roots = append(diff.roots, rootDifference("block_root", left.root, right.root))
+	}
+	if left.bodyRoot != right.bodyRoot {
+		diff.roots = append(diff.roots, rootDifference("body_root", left.bodyRoot, right.bodyRoot))
+	}
+	for _, root := range diffRoots {
+		leftRoot, _ := lookupValue(left.data, root.path)
+		rightRoot, _ := lookupValue(right.data, root.path)
+		diff.roots = append(diff.roots, util.DiffValues(root.name, leftRoot, rightRoot)...)
+	}
+
+	leftAttestations, _ := lookupValue(left.data, attestationsPath)
+	rightAttestations, _ := lookupValue(right.data, attestationsPath)
+	diff.leftAttestations, diff.rightAttestations = util.DiffLists(asList(leftAttestations), asList(rightAttestations))
+
+	_, leftHasPayload := lookupValue(left.data, payloadPath)
+	_, rightHasPayload := lookupValue(right.data, payloadPath)
+	diff.hasPayload = leftHasPayload || rightHasPayload
+	leftTransactions, _ := lookupValue(left.data, transactionsPath)
+	rightTransactions, _ := lookupValue(right.data, transactionsPath)
+	diff.leftTransactions, diff.rightTransactions = util.DiffLists(asList(leftTransactions), asList(rightTransactions))
+
+	diff.payload = make([]*util.Difference, 0)
+	diff.other = make([]*util.Difference, 0)
+	for _, difference := range util.DiffValues("", left.data, right.data) {
+		switch {
+		case isRootPath(difference.Path),
+			hasPathPrefix(difference.Path, attestationsPath),
+			hasPathPrefix(difference.Path, transactionsPath):
+			// Handled above.
+		case hasPathPrefix(difference.Path, payloadPath):
+			diff.payload = append(diff.payload, difference)
+		default:
+			diff.other = append(diff.other, difference)
+		}
+	}
+
+	return diff
+}
+
+// isRootPath returns true if the path is one of the roots in the roots section.
+func isRootPath(path string) bool {
+	for _, root := range diffRoots {
+		if path == root.path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rootDifference creates a difference for a root obtained from a block header.
+func rootDifference(name string, left string, right string) *util.Difference {
+	return &util.Difference{
+		Path:         name,
+		Left:         left,
+		LeftPresent:  left != "",
+		Right:        right,
+		RightPresent: right != "",
+	}
+}
+
+// lookupValue looks up a dot-separated path in a decoded JSON value.
+func lookupValue(value any, path string) (any, bool) {
+	for _, key := range strings.Split(path, ".") {
+		valueMap, isMap := value.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+		var exists bool
+		value, exists = valueMap[key]
+		if !exists {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// asList returns a decoded JSON value as a list.
+func asList(value any) []any {
+	list, isList := value.([]any)
+	if !isList {
+		return []any{}
+	}
+
+	return list
+}
+
+// hasPathPrefix returns true if the path is the prefix or within it.
+func hasPathPrefix(path string, prefix string) bool {
+	return path == prefix ||
+		strings.HasPrefix(path, prefix+".") ||
+		strings.HasPrefix(path, prefix+"[")
+}
+
+// attestationSummary is a summary of an attestation in a block.
+type attestationSummary struct {
+	Index           int    `json:"index"`
+	Slot            string `json:"slot"`
+	CommitteeIndex  string `json:"committee_index"`
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Attesters       int    `json:"attesters"`
+}
+
+// summarizeAttestation summarizes the attestation at the given index of a block.
+func summarizeAttestation(block *diffBlock, index int) *attestationSummary {
+	attestations, _ := lookupValue(block.data, attestationsPath)
+	summary := &attestationSummary{
+		Index: index,
+	}
+	attestation := asList(attestations)[index]
+	if slot, exists := lookupValue(attestation, "data.slot"); exists {
+		summary.Slot = fmt.Sprintf("%v", slot)
+	}
+	if committeeIndex, exists := lookupValue(attestation, "data.index"); exists {
+		summary.CommitteeIndex = fmt.Sprintf("%v", committeeIndex)
+	}
+	if root, exists := lookupValue(attestation, "data.beacon_block_root"); exists {
+		summary.BeaconBlockRoot = fmt.Sprintf("%v", root)
+	}
+	if bits, exists := lookupValue(attestation, "aggregation_bits"); exists {
+		if bitsStr, isString := bits.(string); isString {
+			if data, err := hex.DecodeString(strings.TrimPrefix(bitsStr, "0x")); err == nil && len(data) > 0 {
+				summary.Attesters = int(bitfield.Bitlist(data).Count())
+			}
+		}
+	}
+
+	return summary
+}
+
+// blockDiffRenderer renders the difference between two blocks.
+type blockDiffRenderer struct {
+	diff *blockDiff
+}
+
+type blockDiffJSON struct {
+	Left             *diffBlockJSON            `json:"left"`
+	Right            *diffBlockJSON            `json:"right"`
+	Identical        bool                      `json:"identical"`
+	Roots            []*differenceJSON         `json:"roots"`
+	Attestations     *attestationsDiffJSON     `json:"attestations"`
+	ExecutionPayload *executionPayloadDiffJSON `json:"execution_payload,omitempty"`
+	Differences      []*differenceJSON         `json:"differences"`
+}
+
+type diffBlockJSON struct {
+	BlockID   string `json:"block_id"`
+	Version   string `json:"version"`
+	Root      string `json:"root,omitempty"`
+	BodyRoot  string `json:"body_root,omitempty"`
+	Canonical *bool  `json:"canonical,omitempty"`
+}
+
+type attestationsDiffJSON struct {
+	LeftOnly  []*attestationSummary `json:"left_only"`
+	RightOnly []*attestationSummary `json:"right_only"`
+}
+
+type executionPayloadDiffJSON struct {
+	Differences  []*differenceJSON     `json:"differences"`
+	Transactions *transactionsDiffJSON `json:"transactions"`
+}
+
+type transactionsDiffJSON struct {
+	LeftOnly  []int `json:"left_only"`
+	RightOnly []int `json:"right_only"`
+}
+
+type differenceJSON struct {
+	Path  string `json:"path"`
+	Left  any    `json:"left,omitempty"`
+	Right any    `json:"right,omitempty"`
+}
+
+// RenderJSON renders the difference between the blocks as JSON.
+func (r *blockDiffRenderer) RenderJSON(_ context.Context) ([]byte, error) {
+	diff := r.diff
+	output := &blockDiffJSON{
+		Left:        diffBlockToJSON(diff.left),
+		Right:       diffBlockToJSON(diff.right),
+		Identical:   diff.identical(),
+		Roots:       differencesToJSON(diff.roots),
+		Differences: differencesToJSON(diff.other),
+		Attestations: &attestationsDiffJSON{
+			LeftOnly:  make([]*attestationSummary, 0, len(diff.leftAttestations)),
+			RightOnly: make([]*attestationSummary, 0, len(diff.rightAttestations)),
+		},
+	}
+	for _, index := range diff.leftAttestations {
+		output.Attestations.LeftOnly = append(output.Attestations.LeftOnly, summarizeAttestation(diff.left, index))
+	}
+	for _, index := range diff.rightAttestations {
+		output.Attestations.RightOnly = append(output.Attestations.RightOnly, summarizeAttestation(diff.right, index))
+	}
+	if diff.hasPayload {
+		output.ExecutionPayload = &executionPayloadDiffJSON{
+			Differences: differencesToJSON(diff.payload),
+			Transactions: &transactionsDiffJSON{
+				LeftOnly:  diff.leftTransactions,
+				RightOnly: diff.rightTransactions,
+			},
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return data, nil
+}
+
+func diffBlockToJSON(block *diffBlock) *diffBlockJSON {
+	return &diffBlockJSON{
+		BlockID:   block.blockID,
+		Version:   block.version,
+		Root:      block.root,
+		BodyRoot:  block.bodyRoot,
+		Canonical: block.canonical,
+	}
+}
+
+func differencesToJSON(differences []*util.Difference) []*differenceJSON {
+	res := make([]*differenceJSON, 0, len(differences))
+	for _, difference := range differences {
+		res = append(res, &differenceJSON{
+			Path:  difference.Path,
+			Left:  difference.Left,
+			Right: difference.Right,
+		})
+	}
+
+	return res
+}
+
+// RenderText renders the difference between the blocks as text.
+func (r *blockDiffRenderer) RenderText(_ context.Context) (string, error) {
+	diff := r.diff
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Left: %s\n", diffBlockText(diff.left)))
+	res.WriteString(fmt.Sprintf("Right: %s\n", diffBlockText(diff.right)))
+	if diff.identical() {
+		res.WriteString("Blocks are identical\n")
+		return res.String(), nil
+	}
+
+	if len(diff.roots) > 0 {
+		res.WriteString("Roots:\n")
+		writeDifferencesText(&res, diff.roots)
+	}
+
+	if len(diff.leftAttestations) > 0 || len(diff.rightAttestations) > 0 {
+		res.WriteString(fmt.Sprintf("Attestations: %d only in left, %d only in right\n", len(diff.leftAttestations), len(diff.rightAttestations)))
+		for _, index := range diff.leftAttestations {
+			res.WriteString(fmt.Sprintf("  < %s\n", attestationSummaryText(summarizeAttestation(diff.left, index))))
+		}
+		for _, index := range diff.rightAttestations {
+			res.WriteString(fmt.Sprintf("  > %s\n", attestationSummaryText(summarizeAttestation(diff.right, index))))
+		}
+	}
+
+	if len(diff.payload) > 0 || len(diff.leftTransactions) > 0 || len(diff.rightTransactions) > 0 {
+		res.WriteString("Execution payload:\n")
+		if len(diff.leftTransactions) > 0 || len(diff.rightTransactions) > 0 {
+			res.WriteString(fmt.Sprintf("  Transactions: %d only in left, %d only in right\n", len(diff.leftTransactions), len(diff.rightTransactions)))
+		}
+		writeDifferencesText(&res, diff.payload)
+	}
+
+	if len(diff.other) > 0 {
+		res.WriteString(fmt.Sprintf("Other differences: %d\n", len(diff.other)))
+		writeDifferencesText(&res, diff.other)
+	}
+
+	return res.String(), nil
+}
+
+func diffBlockText(block *diffBlock) string {
+	res := fmt.Sprintf("block %s (%s)", block.blockID, block.version)
+	if block.root != "" {
+		res = fmt.Sprintf("%s, root %s", res, block.root)
+	}
+	if block.canonical != nil && !*block.canonical {
+		res = fmt.Sprintf("%s, not canonical", res)
+	}
+
+	return res
+}
+
+func attestationSummaryText(summary *attestationSummary) string {
+	return fmt.Sprintf("%d: slot %s, committee %s, head %s, %d attesters",
+		summary.Index,
+		summary.Slot,
+		summary.CommitteeIndex,
+		summary.BeaconBlockRoot,
+		summary.Attesters,
+	)
+}
+
+func writeDifferencesText(res *strings.Builder, differences []*util.Difference) {
+	for _, difference := range differences {
+		res.WriteString(fmt.Sprintf("  %s: %s => %s\n",
+			difference.Path,
+			diffValueText(difference.Left, difference.LeftPresent),
+			diffValueText(difference.Right, difference.RightPresent),
+		))
+	}
+}
+
+// diffValueText formats a value for text output.
+func diffValueText(value any, present bool) string {
+	if !present {
+		return "(absent)"
+	}
+
+	var res string
+	switch v := value.(type) {
+	case map[string]any:
+		res = fmt.Sprintf("{%d fields}", len(v))
+	case []any:
+		res = fmt.Sprintf("[%d items]", len(v))
+	case string:
+		res = v
+	default:
+		res = fmt.Sprintf("%v", v)
+	}
+
+	if !results.verbose && len(res) > maxDiffValueLength {
+		res = fmt.Sprintf("%s...", res[:maxDiffValueLength])
+	}
+
+	return res
+}
+
+// blockDiffCSVHeader is the header of the CSV diff output.
+var blockDiffCSVHeader = []string{
+	"section",
+	"path",
+	"left",
+	"right",
+}
+
+// CSVHeader returns the header of the CSV diff output.
+func (*blockDiffRenderer) CSVHeader() []string {
+	return blockDiffCSVHeader
+}
+
+// CSVRecords returns a CSV record for each difference between the blocks.
+func (r *blockDiffRenderer) CSVRecords(_ context.Context) ([][]string, error) {
+	diff := r.diff
+	records := make([][]string, 0)
+	addDifferences := func(section string, differences []*util.Difference) {
+		for _, difference := range differences {
+			records = append(records, []string{
+				section,
+				difference.Path,
+				diffValueCSV(difference.Left, difference.LeftPresent),
+				diffValueCSV(difference.Right, difference.RightPresent),
+			})
+		}
+	}
+
+	addDifferences("roots", diff.roots)
+	for _, index := range diff.leftAttestations {
+		records = append(records, []string{"attestations", fmt.Sprintf("%s[%d]", attestationsPath, index), "present", ""})
+	}
+	for _, index := range diff.rightAttestations {
+		records = append(records, []string{"attestations", fmt.Sprintf("%s[%d]", attestationsPath, index), "", "present"})
+	}
+	addDifferences("execution_payload", diff.payload)
+	for _, index := range diff.leftTransactions {
+		records = append(records, []string{"execution_payload", fmt.Sprintf("%s[%d]", transactionsPath, index), "present", ""})
+	}
+	for _, index := range diff.rightTransactions {
+		records = append(records, []string{"execution_payload", fmt.Sprintf("%s[%d]", transactionsPath, index), "", "present"})
+	}
+	addDifferences("other", diff.other)
+
+	return records, nil
+}
+
+// diffValueCSV formats a value for CSV output.
+func diffValueCSV(value any, present bool) string {
+	if !present {
+		return ""
+	}
+	if str, isString := value.(string); isString {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
+
+// validateDiff validates the options for a diff.
+func validateDiff(data *dataIn) error {
+	if data.diffBlockID == "" {
+		return nil
+	}
+
+	switch {
+	case data.rangeMode:
+		return errors.New("diff cannot be supplied with a slot range")
+	case data.stream:
+		return errors.New("diff cannot be supplied with stream")
+	case data.blinded:
+		return errors.New("diff cannot be supplied with blinded")
+	case data.format == utiloutput.SSZ:
+		return errors.New("diff cannot be supplied with SSZ output")
+	case data.decodeTransactions || data.verifyBlobs:
+		return errors.New("diff cannot be supplied with decode-transactions or verify-blobs")
+	case len(data.jsonFields) > 0:
+		return errors.New("diff cannot be supplied with fields")
+	}
+
+	return nil
+}
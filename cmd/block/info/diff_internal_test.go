@@ -0,0 +1,154 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+)
+
+func TestValidateDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		data *dataIn
+		err  string
+	}{
+		{
+			name: "NoDiff",
+			data: &dataIn{stream: true},
+		},
+		{
+			name: "Good",
+			data: &dataIn{diffBlockID: "123", format: utiloutput.JSON},
+		},
+		{
+			name: "Range",
+			data: &dataIn{diffBlockID: "123", rangeMode: true},
+			err:  "diff cannot be supplied with a slot range",
+		},
+		{
+			name: "Stream",
+			data: &dataIn{diffBlockID: "123", stream: true},
+			err:  "diff cannot be supplied with stream",
+		},
+		{
+			name: "Blinded",
+			data: &dataIn{diffBlockID: "123", blinded: true},
+			err:  "diff cannot be supplied with blinded",
+		},
+		{
+			name: "SSZ",
+			data: &dataIn{diffBlockID: "123", format: utiloutput.SSZ},
+			err:  "diff cannot be supplied with SSZ output",
+		},
+		{
+			name: "DecodeTransactions",
+			data: &dataIn{diffBlockID: "123", decodeTransactions: true},
+			err:  "diff cannot be supplied with decode-transactions or verify-blobs",
+		},
+		{
+			name: "Fields",
+			data: &dataIn{diffBlockID: "123", jsonFields: []string{"message.slot"}},
+			err:  "diff cannot be supplied with fields",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDiff(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func testDiffBlock(t *testing.T, blockID string, root string, data string) *diffBlock {
+	t.Helper()
+
+	value, err := util.DecodeJSONValue([]byte(data))
+	require.NoError(t, err)
+
+	return &diffBlock{
+		blockID:  blockID,
+		version:  "deneb",
+		root:     root,
+		bodyRoot: root,
+		data:     value,
+	}
+}
+
+func TestDiffBlocks(t *testing.T) {
+	left := testDiffBlock(t, "100", "0x01", `{"message":{"slot":"100","parent_root":"0xaa","state_root":"0xbb","body":{"graffiti":"0x00","attestations":[{"aggregation_bits":"0x0f","data":{"slot":"99","index":"1","beacon_block_root":"0xaa"}},{"aggregation_bits":"0x03","data":{"slot":"99","index":"2","beacon_block_root":"0xaa"}}],"execution_payload":{"block_hash":"0x10","transactions":["0x01","0x02"]}}},"signature":"0x05"}`)
+	right := testDiffBlock(t, "0x02", "0x02", `{"message":{"slot":"100","parent_root":"0xaa","state_root":"0xcc","body":{"graffiti":"0x01","attestations":[{"aggregation_bits":"0x03","data":{"slot":"99","index":"2","beacon_block_root":"0xaa"}},{"aggregation_bits":"0x07","data":{"slot":"99","index":"1","beacon_block_root":"0xaa"}}],"execution_payload":{"block_hash":"0x11","transactions":["0x02","0x03"]}}},"signature":"0x06"}`)
+
+	diff := diffBlocks(left, right)
+	require.False(t, diff.identical())
+	require.Equal(t, []*util.Difference{
+		{Path: "block_root", Left: "0x01", LeftPresent: true, Right: "0x02", RightPresent: true},
+		{Path: "body_root", Left: "0x01", LeftPresent: true, Right: "0x02", RightPresent: true},
+		{Path: "state_root", Left: "0xbb", LeftPresent: true, Right: "0xcc", RightPresent: true},
+	}, diff.roots)
+	require.Equal(t, []int{0}, diff.leftAttestations)
+	require.Equal(t, []int{1}, diff.rightAttestations)
+	require.True(t, diff.hasPayload)
+	require.Equal(t, []int{0}, diff.leftTransactions)
+	require.Equal(t, []int{1}, diff.rightTransactions)
+	require.Equal(t, []*util.Difference{
+		{Path: "message.body.execution_payload.block_hash", Left: "0x10", LeftPresent: true, Right: "0x11", RightPresent: true},
+	}, diff.payload)
+	require.Equal(t, []*util.Difference{
+		{Path: "message.body.graffiti", Left: "0x00", LeftPresent: true, Right: "0x01", RightPresent: true},
+		{Path: "signature", Left: "0x05", LeftPresent: true, Right: "0x06", RightPresent: true},
+	}, diff.other)
+
+	summary := summarizeAttestation(left, 0)
+	require.Equal(t, &attestationSummary{
+		Index:           0,
+		Slot:            "99",
+		CommitteeIndex:  "1",
+		BeaconBlockRoot: "0xaa",
+		Attesters:       3,
+	}, summary)
+}
+
+func TestBlockDiffRenderer(t *testing.T) {
+	results = &dataOut{}
+	left := testDiffBlock(t, "100", "0x01", `{"message":{"slot":"100","state_root":"0xbb","body":{"attestations":[]}},"signature":"0x05"}`)
+	right := testDiffBlock(t, "0x02", "0x01", `{"message":{"slot":"100","state_root":"0xbb","body":{"attestations":[]}},"signature":"0x06"}`)
+
+	renderer := &blockDiffRenderer{diff: diffBlocks(left, right)}
+	text, err := renderer.RenderText(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Left: block 100 (deneb), root 0x01\nRight: block 0x02 (deneb), root 0x01\nOther differences: 1\n  signature: 0x05 => 0x06\n", text)
+
+	data, err := renderer.RenderJSON(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `{"left":{"block_id":"100","version":"deneb","root":"0x01","body_root":"0x01"},"right":{"block_id":"0x02","version":"deneb","root":"0x01","body_root":"0x01"},"identical":false,"roots":[],"attestations":{"left_only":[],"right_only":[]},"differences":[{"path":"signature","left":"0x05","right":"0x06"}]}`, string(data))
+
+	records, err := renderer.CSVRecords(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"other", "signature", "0x05", "0x06"}}, records)
+
+	identical := &blockDiffRenderer{diff: diffBlocks(left, left)}
+	text, err = identical.RenderText(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Left: block 100 (deneb), root 0x01\nRight: block 100 (deneb), root 0x01\nBlocks are identical\n", text)
+}
@@ -0,0 +1,347 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-string2eth"
+)
+
+// electraBlock returns the block of a versioned block from Electra onwards.
+// Fulu blocks share the structure of Electra blocks.
+func electraBlock(signedBlock *spec.VersionedSignedBeaconBlock) *electra.SignedBeaconBlock {
+	if signedBlock.Version == spec.DataVersionFulu {
+		return signedBlock.Fulu
+	}
+
+	return signedBlock.Electra
+}
+
+// electraAttesterSlashings converts attester slashings from Electra onwards,
+// which can cover the members of multiple committees, to their earlier form
+// for output.
+func electraAttesterSlashings(slashings []*electra.AttesterSlashing) []*phase0.AttesterSlashing {
+	res := make([]*phase0.AttesterSlashing, 0, len(slashings))
+	for _, slashing := range slashings {
+		res = append(res, &phase0.AttesterSlashing{
+			Attestation1: &phase0.IndexedAttestation{
+				AttestingIndices: slashing.Attestation1.AttestingIndices,
+				Data:             slashing.Attestation1.Data,
+				Signature:        slashing.Attestation1.Signature,
+			},
+			Attestation2: &phase0.IndexedAttestation{
+				AttestingIndices: slashing.Attestation2.AttestingIndices,
+				Data:             slashing.Attestation2.Data,
+				Signature:        slashing.Attestation2.Signature,
+			},
+		})
+	}
+
+	return res
+}
+
+// outputElectraBlockText outputs a block from Electra onwards as text.
+func outputElectraBlockText(ctx context.Context,
+	data *dataOut,
+	signedBlock *electra.SignedBeaconBlock,
+	blobs []*deneb.BlobSidecar,
+) (
+	string,
+	error,
+) {
+	if signedBlock == nil {
+		return "", errors.New("no block supplied")
+	}
+
+	body := signedBlock.Message.Body
+
+	res := strings.Builder{}
+
+	// General info.
+	blockRoot, err := signedBlock.Message.HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain block root")
+	}
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate body root")
+	}
+
+	tmp, err := outputBlockGeneral(ctx,
+		data.verbose,
+		signedBlock.Message.Slot,
+		signedBlock.Message.ProposerIndex,
+		blockRoot,
+		bodyRoot,
+		signedBlock.Message.ParentRoot,
+		signedBlock.Message.StateRoot,
+		body.Graffiti[:],
+		data.genesisTime,
+		data.slotDuration,
+		data.slotsPerEpoch)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Eth1 data.
+	if data.verbose {
+		tmp, err := outputBlockETH1Data(ctx, body.ETH1Data)
+		if err != nil {
+			return "", err
+		}
+		res.WriteString(tmp)
+	}
+
+	// Sync aggregate.
+	tmp, err = outputBlockSyncAggregate(ctx, data.eth2Client, data.verbose, body.SyncAggregate, phase0.Epoch(uint64(signedBlock.Message.Slot)/data.slotsPerEpoch))
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attestations.
+	tmp, err = outputElectraBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, body.Attestations)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attester slashings.
+	tmp, err = outputBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, electraAttesterSlashings(body.AttesterSlashings))
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
+	// Add verbose proposer slashings.
+
+	tmp, err = outputBlockDeposits(ctx, data.verbose, body.Deposits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Voluntary exits.
+	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, body.VoluntaryExits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, body.BLSToExecutionChanges)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputDenebBlockExecutionPayload(ctx, data.verbose, body.ExecutionPayload)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputElectraExecutionRequests(ctx, data.verbose, body.ExecutionRequests)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputDenebBlobInfo(ctx, data.verbose, &deneb.BeaconBlockBody{BlobKZGCommitments: body.BlobKZGCommitments}, blobs)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	return res.String(), nil
+}
+
+func outputElectraBlockAttestations(ctx context.Context,
+	eth2Client eth2client.Service,
+	verbose bool,
+	slotsPerEpoch uint64,
+	slot phase0.Slot,
+	stateRoot phase0.Root,
+	attestations []*electra.Attestation,
+) (
+	string,
+	error,
+) {
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Attestations: %d\n", len(attestations)))
+	if !verbose {
+		return res.String(), nil
+	}
+	beaconCommitteesProvider, isProvider := eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return res.String(), nil
+	}
+
+	validatorCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	checker := newVoteChecker(ctx, eth2Client, slotsPerEpoch, slot, stateRoot)
+	correctSource := 0
+	correctTarget := 0
+	correctHead := 0
+	for i, att := range attestations {
+		res.WriteString(fmt.Sprintf("  %d:\n", i))
+
+		// Fetch committees for this slot if not already obtained.
+		committees, exists := validatorCommittees[att.Data.Slot]
+		if !exists {
+			validatorCommittees[att.Data.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			beaconCommitteesResponse, err := beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", att.Data.Slot)})
+			if err == nil {
+				for _, beaconCommittee := range beaconCommitteesResponse.Data {
+					if _, exists := validatorCommittees[beaconCommittee.Slot]; !exists {
+						validatorCommittees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+					}
+					validatorCommittees[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
+				}
+			}
+			committees = validatorCommittees[att.Data.Slot]
+		}
+
+		sourceCorrect := checker.sourceCorrect(ctx, att.Data)
+		targetCorrect := checker.targetCorrect(ctx, att.Data)
+		headCorrect := checker.headCorrect(ctx, att.Data)
+		if sourceCorrect == voteCorrect {
+			correctSource++
+		}
+		if targetCorrect == voteCorrect {
+			correctTarget++
+		}
+		if headCorrect == voteCorrect {
+			correctHead++
+		}
+
+		committeeIndices := att.CommitteeBits.BitIndices()
+		res.WriteString(fmt.Sprintf("    Committee indices: %s\n", intsToString(committeeIndices)))
+		res.WriteString(fmt.Sprintf("    Attesters: %d/%d\n", att.AggregationBits.Count(), att.AggregationBits.Len()))
+		res.WriteString(fmt.Sprintf("    Aggregation bits: %s\n", bitlistToString(att.AggregationBits)))
+		if indices, complete := electraAttestingIndices(att, committees); complete {
+			res.WriteString(fmt.Sprintf("    Attesting indices: %s\n", indices))
+		}
+		res.WriteString(fmt.Sprintf("    Slot: %d\n", att.Data.Slot))
+		res.WriteString(fmt.Sprintf("    Beacon block root: %#x (%s)\n", att.Data.BeaconBlockRoot, headCorrect))
+		res.WriteString(fmt.Sprintf("    Source epoch: %d\n", att.Data.Source.Epoch))
+		res.WriteString(fmt.Sprintf("    Source root: %#x (%s)\n", att.Data.Source.Root, sourceCorrect))
+		res.WriteString(fmt.Sprintf("    Target epoch: %d\n", att.Data.Target.Epoch))
+		res.WriteString(fmt.Sprintf("    Target root: %#x (%s)\n", att.Data.Target.Root, targetCorrect))
+	}
+	if len(attestations) > 0 {
+		res.WriteString(fmt.Sprintf("Correct source votes: %d/%d (%0.2f%%)\n", correctSource, len(attestations), 100.0*float64(correctSource)/float64(len(attestations))))
+		res.WriteString(fmt.Sprintf("Correct target votes: %d/%d (%0.2f%%)\n", correctTarget, len(attestations), 100.0*float64(correctTarget)/float64(len(attestations))))
+		res.WriteString(fmt.Sprintf("Correct head votes: %d/%d (%0.2f%%)\n", correctHead, len(attestations), 100.0*float64(correctHead)/float64(len(attestations))))
+	}
+
+	return res.String(), nil
+}
+
+// electraAttestingIndices returns the attesting indices of an attestation.
+// From Electra the aggregation bits cover the members of each committee in
+// the committee bits in turn.
+// It returns false if the committees of the attestation are not all known.
+func electraAttestingIndices(att *electra.Attestation,
+	committees map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+) (
+	string,
+	bool,
+) {
+	indices := make([]phase0.ValidatorIndex, 0)
+	for _, committeeIndex := range att.CommitteeBits.BitIndices() {
+		committee, exists := committees[phase0.CommitteeIndex(committeeIndex)]
+		if !exists {
+			return "", false
+		}
+		indices = append(indices, committee...)
+	}
+	if uint64(len(indices)) != att.AggregationBits.Len() {
+		return "", false
+	}
+
+	return attestingIndices(att.AggregationBits, indices), true
+}
+
+func outputElectraExecutionRequests(_ context.Context,
+	verbose bool,
+	requests *electra.ExecutionRequests,
+) (
+	string,
+	error,
+) {
+	if requests == nil {
+		return "", nil
+	}
+
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Deposit requests: %d\n", len(requests.Deposits)))
+	if verbose {
+		for i, request := range requests.Deposits {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Index: %d\n", request.Index))
+			res.WriteString(fmt.Sprintf("    Public key: %#x\n", request.Pubkey))
+			res.WriteString(fmt.Sprintf("    Amount: %s\n", string2eth.GWeiToString(uint64(request.Amount), true)))
+			res.WriteString(fmt.Sprintf("    Withdrawal credentials: %#x\n", request.WithdrawalCredentials))
+			res.WriteString(fmt.Sprintf("    Signature: %#x\n", request.Signature))
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("Withdrawal requests: %d\n", len(requests.Withdrawals)))
+	if verbose {
+		for i, request := range requests.Withdrawals {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Source address: %s\n", request.SourceAddress.String()))
+			res.WriteString(fmt.Sprintf("    Validator: %#x\n", request.ValidatorPubkey))
+			if request.Amount == 0 {
+				res.WriteString("    Amount: full withdrawal\n")
+			} else {
+				res.WriteString(fmt.Sprintf("    Amount: %s\n", string2eth.GWeiToString(uint64(request.Amount), true)))
+			}
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("Consolidation requests: %d\n", len(requests.Consolidations)))
+	if verbose {
+		for i, request := range requests.Consolidations {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Source address: %s\n", request.SourceAddress.String()))
+			res.WriteString(fmt.Sprintf("    Source validator: %#x\n", request.SourcePubkey))
+			res.WriteString(fmt.Sprintf("    Target validator: %#x\n", request.TargetPubkey))
+		}
+	}
+
+	return res.String(), nil
+}
+
+func intsToString(input []int) string {
+	res := make([]string, len(input))
+	for i := range input {
+		res[i] = fmt.Sprintf("%d", input[i])
+	}
+
+	return strings.Join(res, " ")
+}
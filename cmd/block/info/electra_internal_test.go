@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectraAttestingIndices(t *testing.T) {
+	tests := []struct {
+		name       string
+		committees map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+		indices    string
+		complete   bool
+	}{
+		{
+			name: "MultipleCommittees",
+			committees: map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+				0: {10, 11, 12},
+				2: {20, 21},
+			},
+			indices:  "10 12 20",
+			complete: true,
+		},
+		{
+			name: "CommitteeMissing",
+			committees: map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+				0: {10, 11, 12},
+			},
+		},
+		{
+			name: "CommitteeSizeMismatch",
+			committees: map[phase0.CommitteeIndex][]phase0.ValidatorIndex{
+				0: {10, 11, 12},
+				2: {20, 21, 22},
+			},
+		},
+	}
+
+	// Committees 0 and 2, with bits 0, 2 and 3 of 5 set.
+	att := &electra.Attestation{
+		AggregationBits: bitfield.Bitlist{0x2d},
+		Data:            &phase0.AttestationData{},
+		CommitteeBits:   bitfield.Bitvector64{0x05, 0, 0, 0, 0, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indices, complete := electraAttestingIndices(att, test.committees)
+			require.Equal(t, test.complete, complete)
+			require.Equal(t, test.indices, indices)
+		})
+	}
+}
+
+func TestOutputElectraExecutionRequests(t *testing.T) {
+	data := `{"deposits":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"0x0100000000000000000000000d369bb49efa5100fd3b86a9f828c55da04d2d50","amount":"32000000000","signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","index":"5"}],"withdrawals":[{"source_address":"0x0d369bb49efa5100fd3b86a9f828c55da04d2d50","validator_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","amount":"0"}],"consolidations":[]}`
+	requests := &electra.ExecutionRequests{}
+	require.NoError(t, json.Unmarshal([]byte(data), requests))
+
+	res, err := outputElectraExecutionRequests(context.Background(), false, requests)
+	require.NoError(t, err)
+	require.Equal(t, "Deposit requests: 1\nWithdrawal requests: 1\nConsolidation requests: 0\n", res)
+
+	res, err = outputElectraExecutionRequests(context.Background(), true, requests)
+	require.NoError(t, err)
+	require.Contains(t, res, "    Index: 5\n")
+	require.Contains(t, res, "    Amount: 32 Ether\n")
+	require.Contains(t, res, "    Amount: full withdrawal\n")
+
+	res, err = outputElectraExecutionRequests(context.Background(), true, nil)
+	require.NoError(t, err)
+	require.Equal(t, "", res)
+}
@@ -15,12 +15,17 @@ package blockinfo
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+	"golang.org/x/term"
 )
 
 type dataIn struct {
@@ -31,12 +36,38 @@ type dataIn struct {
 	debug   bool
 	// Operation.
 	eth2Client eth2client.Service
-	jsonOutput bool
-	sszOutput  bool
+	format     utiloutput.Format
+	jsonFields []string
+	sszFile    string
+	blobsFile  string
+	rawOutput  bool
 	// Chain information.
-	blockID   string
-	blockTime string
-	stream    bool
+	blockID     string
+	blockTime   string
+	stream      bool
+	streamTopic string
+	blinded     bool
+	relay       string
+	// Transactions.
+	decodeTransactions bool
+	// Blobs.
+	verifyBlobs bool
+	kzgSetup    *util.KZGSetup
+	// Slot range.
+	rangeMode bool
+	fromSlot  string
+	toSlot    string
+	epoch     string
+	sszDir    string
+	// Diff.
+	diffBlockID string
+}
+
+// streamTopics are the event topics that can be streamed.
+var streamTopics = map[string]bool{
+	"head":                 true,
+	"block":                true,
+	"finalized_checkpoint": true,
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -49,13 +80,94 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.jsonOutput = viper.GetBool("json")
-	data.sszOutput = viper.GetBool("ssz")
+	var err error
+	data.format, err = utiloutput.FromViper()
+	if err != nil {
+		return nil, err
+	}
+	data.jsonFields = viper.GetStringSlice("fields")
+	data.sszFile = viper.GetString("ssz-file")
+	data.blobsFile = viper.GetString("ssz-blobs-file")
+	data.rawOutput = viper.GetBool("raw")
+	if data.blobsFile != "" && data.sszFile == "" {
+		return nil, errors.New("ssz-blobs-file can only be supplied with ssz-file")
+	}
+	if data.sszFile != "" && data.rawOutput {
+		return nil, errors.New("only one of ssz-file and raw can be supplied")
+	}
+	if data.sszFile != "" || data.rawOutput {
+		// Both of these are forms of SSZ output.
+		if data.format != utiloutput.Text && data.format != utiloutput.SSZ {
+			return nil, fmt.Errorf("ssz-file and raw cannot be supplied with %s output", data.format)
+		}
+		data.format = utiloutput.SSZ
+	}
+	if data.rawOutput && term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, errors.New("raw output is binary so requires output to be redirected")
+	}
 	data.blockID = viper.GetString("blockid")
 	data.blockTime = viper.GetString("block-time")
 	data.stream = viper.GetBool("stream")
+	if data.stream && data.sszFile != "" {
+		return nil, errors.New("ssz-file cannot be supplied with stream")
+	}
+	data.streamTopic = viper.GetString("stream-topic")
+	if data.streamTopic == "" {
+		data.streamTopic = "head"
+	}
+	if !streamTopics[data.streamTopic] {
+		return nil, fmt.Errorf("unsupported stream topic %s", data.streamTopic)
+	}
+	if data.streamTopic != "head" && !data.stream {
+		return nil, errors.New("stream-topic can only be supplied with stream")
+	}
+	data.fromSlot = viper.GetString("from-slot")
+	data.toSlot = viper.GetString("to-slot")
+	data.epoch = viper.GetString("epoch")
+	data.sszDir = viper.GetString("ssz-dir")
+	data.rangeMode = data.fromSlot != "" || data.toSlot != "" || data.epoch != ""
+	if err := validateRange(data); err != nil {
+		return nil, err
+	}
+	data.blinded = viper.GetBool("blinded")
+	data.relay = viper.GetString("relay")
+	if data.relay != "" && !data.blinded {
+		return nil, errors.New("relay can only be supplied with blinded")
+	}
+	data.decodeTransactions = viper.GetBool("decode-transactions")
+	if data.decodeTransactions && data.format == utiloutput.SSZ {
+		return nil, errors.New("decode-transactions cannot be supplied with SSZ output")
+	}
+	data.verifyBlobs = viper.GetBool("verify-blobs")
+	if data.verifyBlobs && data.format == utiloutput.SSZ {
+		return nil, errors.New("verify-blobs cannot be supplied with SSZ output")
+	}
+	data.diffBlockID = viper.GetString("diff")
+	if err := validateDiff(data); err != nil {
+		return nil, err
+	}
+	if data.format == utiloutput.CSV && data.diffBlockID == "" {
+		if data.blinded {
+			return nil, errors.New("CSV output cannot be supplied with blinded")
+		}
+		if data.decodeTransactions || data.verifyBlobs {
+			return nil, errors.New("CSV output cannot be supplied with decode-transactions or verify-blobs")
+		}
+	}
+	if viper.GetString("trusted-setup") != "" && !data.verifyBlobs {
+		return nil, errors.New("trusted-setup can only be supplied with verify-blobs")
+	}
+	if data.verifyBlobs {
+		if viper.GetString("trusted-setup") == "" {
+			data.kzgSetup, err = util.MainnetKZGSetup()
+		} else {
+			data.kzgSetup, err = util.LoadKZGSetup(viper.GetString("trusted-setup"))
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load trusted setup")
+		}
+	}
 
-	var err error
 	data.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
 		Address:       viper.GetString("connection"),
 		Timeout:       viper.GetDuration("timeout"),
@@ -68,3 +180,49 @@ func input(ctx context.Context) (*dataIn, error) {
 
 	return data, nil
 }
+
+// validateRange validates the options for a slot range.
+func validateRange(data *dataIn) error {
+	if !data.rangeMode {
+		if data.sszDir != "" {
+			return errors.New("ssz-dir can only be supplied with a slot range")
+		}
+		return nil
+	}
+
+	if data.epoch != "" && (data.fromSlot != "" || data.toSlot != "") {
+		return errors.New("epoch cannot be supplied with from-slot or to-slot")
+	}
+	if data.toSlot != "" && data.fromSlot == "" {
+		return errors.New("to-slot requires from-slot")
+	}
+	for _, slot := range []string{data.fromSlot, data.toSlot} {
+		if slot == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(slot, 10, 64); err != nil {
+			return fmt.Errorf("invalid slot %s", slot)
+		}
+	}
+	if data.stream {
+		return errors.New("slot range cannot be supplied with stream")
+	}
+	if data.blockTime != "" {
+		return errors.New("slot range cannot be supplied with block-time")
+	}
+	if data.sszFile != "" {
+		return errors.New("ssz-file cannot be supplied with a slot range; use ssz-dir")
+	}
+	if data.sszDir != "" {
+		if data.rawOutput {
+			return errors.New("only one of ssz-dir and raw can be supplied")
+		}
+		// Writing to a directory is a form of SSZ output.
+		if data.format != utiloutput.Text && data.format != utiloutput.SSZ {
+			return fmt.Errorf("ssz-dir cannot be supplied with %s output", data.format)
+		}
+		data.format = utiloutput.SSZ
+	}
+
+	return nil
+}
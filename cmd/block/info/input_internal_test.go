@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/testutil"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -74,6 +75,132 @@ func TestInput(t *testing.T) {
 			},
 			err: "failed to connect to beacon node: failed to confirm node connection: failed to fetch genesis: failed to request genesis: failed to call GET endpoint: Get \"http://localhost:1/eth/v1/beacon/genesis\": dial tcp 127.0.0.1:1: connect: connection refused",
 		},
+		{
+			name: "RelayWithoutBlinded",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"relay":      "https://relay.example.com/",
+			},
+			err: "relay can only be supplied with blinded",
+		},
+		{
+			name: "DecodeTransactionsWithSSZ",
+			vars: map[string]interface{}{
+				"timeout":             "5s",
+				"connection":          os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz":                 true,
+				"decode-transactions": true,
+			},
+			err: "decode-transactions cannot be supplied with SSZ output",
+		},
+		{
+			name: "VerifyBlobsWithSSZ",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"connection":   os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz":          true,
+				"verify-blobs": true,
+			},
+			err: "verify-blobs cannot be supplied with SSZ output",
+		},
+		{
+			name: "CSVWithSSZ",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz":        true,
+				"output":     "csv",
+			},
+			err: "ssz cannot be supplied with csv output",
+		},
+		{
+			name: "RawWithYAML",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"raw":        true,
+				"output":     "yaml",
+			},
+			err: "ssz-file and raw cannot be supplied with yaml output",
+		},
+		{
+			name: "CSVWithBlinded",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"blinded":    true,
+				"output":     "csv",
+			},
+			err: "CSV output cannot be supplied with blinded",
+		},
+		{
+			name: "TrustedSetupWithoutVerifyBlobs",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"connection":    os.Getenv("ETHDO_TEST_CONNECTION"),
+				"trusted-setup": "trusted_setup.txt",
+			},
+			err: "trusted-setup can only be supplied with verify-blobs",
+		},
+		{
+			name: "TrustedSetupMissing",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"connection":    os.Getenv("ETHDO_TEST_CONNECTION"),
+				"verify-blobs":  true,
+				"trusted-setup": "/nonexistent/trusted_setup.txt",
+			},
+			err: "failed to load trusted setup: failed to read trusted setup: open /nonexistent/trusted_setup.txt: no such file or directory",
+		},
+		{
+			name: "BlobsFileWithoutSSZFile",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"connection":     os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz-blobs-file": "blobs.ssz",
+			},
+			err: "ssz-blobs-file can only be supplied with ssz-file",
+		},
+		{
+			name: "SSZFileAndRaw",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz-file":   "block.ssz",
+				"raw":        true,
+			},
+			err: "only one of ssz-file and raw can be supplied",
+		},
+		{
+			name: "SSZFileStream",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ssz-file":   "block.ssz",
+				"stream":     true,
+			},
+			err: "ssz-file cannot be supplied with stream",
+		},
+		{
+			name: "StreamTopicInvalid",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"connection":   os.Getenv("ETHDO_TEST_CONNECTION"),
+				"stream":       true,
+				"stream-topic": "chain_reorg",
+			},
+			err: "unsupported stream topic chain_reorg",
+		},
+		{
+			name: "StreamTopicWithoutStream",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"connection":   os.Getenv("ETHDO_TEST_CONNECTION"),
+				"stream-topic": "finalized_checkpoint",
+			},
+			err: "stream-topic can only be supplied with stream",
+		},
 		{
 			name: "BlockIDNil",
 			vars: map[string]interface{}{
@@ -117,3 +244,83 @@ func TestInput(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   *dataIn
+		format utiloutput.Format
+		err    string
+	}{
+		{
+			name: "NoRange",
+			data: &dataIn{},
+		},
+		{
+			name: "SSZDirWithoutRange",
+			data: &dataIn{sszDir: "blocks"},
+			err:  "ssz-dir can only be supplied with a slot range",
+		},
+		{
+			name: "EpochAndSlot",
+			data: &dataIn{rangeMode: true, epoch: "10", fromSlot: "320"},
+			err:  "epoch cannot be supplied with from-slot or to-slot",
+		},
+		{
+			name: "ToSlotWithoutFromSlot",
+			data: &dataIn{rangeMode: true, toSlot: "320"},
+			err:  "to-slot requires from-slot",
+		},
+		{
+			name: "SlotInvalid",
+			data: &dataIn{rangeMode: true, fromSlot: "bad"},
+			err:  "invalid slot bad",
+		},
+		{
+			name: "Stream",
+			data: &dataIn{rangeMode: true, fromSlot: "320", stream: true},
+			err:  "slot range cannot be supplied with stream",
+		},
+		{
+			name: "BlockTime",
+			data: &dataIn{rangeMode: true, fromSlot: "320", blockTime: "1700000000"},
+			err:  "slot range cannot be supplied with block-time",
+		},
+		{
+			name: "SSZFile",
+			data: &dataIn{rangeMode: true, fromSlot: "320", sszFile: "block.ssz"},
+			err:  "ssz-file cannot be supplied with a slot range; use ssz-dir",
+		},
+		{
+			name: "SSZDirAndRaw",
+			data: &dataIn{rangeMode: true, fromSlot: "320", sszDir: "blocks", rawOutput: true},
+			err:  "only one of ssz-dir and raw can be supplied",
+		},
+		{
+			name: "Slots",
+			data: &dataIn{rangeMode: true, fromSlot: "320", toSlot: "351"},
+		},
+		{
+			name: "SSZDirWithJSON",
+			data: &dataIn{rangeMode: true, epoch: "10", sszDir: "blocks", format: utiloutput.JSON},
+			err:  "ssz-dir cannot be supplied with json output",
+		},
+		{
+			name:   "EpochSSZDir",
+			data:   &dataIn{rangeMode: true, epoch: "10", sszDir: "blocks", format: utiloutput.Text},
+			format: utiloutput.SSZ,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateRange(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.format, test.data.format)
+		})
+	}
+}
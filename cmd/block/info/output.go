@@ -16,7 +16,6 @@ package blockinfo
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sort"
@@ -25,6 +24,7 @@ import (
 	"unicode/utf8"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
@@ -32,6 +32,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-string2eth"
 )
 
@@ -80,13 +81,8 @@ func outputBlockGeneral(_ context.Context,
 		res.WriteString(fmt.Sprintf("Parent root: %#x\n", parentRoot))
 		res.WriteString(fmt.Sprintf("State root: %#x\n", stateRoot))
 	}
-	if len(graffiti) > 0 && hex.EncodeToString(graffiti) != "0000000000000000000000000000000000000000000000000000000000000000" {
-		graffiti = bytes.TrimRight(graffiti, "\u0000")
-		if utf8.Valid(graffiti) {
-			res.WriteString(fmt.Sprintf("Graffiti: %s\n", string(graffiti)))
-		} else {
-			res.WriteString(fmt.Sprintf("Graffiti: %#x\n", graffiti))
-		}
+	if graffitiInfo := util.DecodeGraffiti(graffiti); graffitiInfo.Encoding != util.GraffitiEncodingEmpty {
+		res.WriteString(fmt.Sprintf("Graffiti: %s\n", graffitiInfo.Text))
 	}
 
 	return res.String(), nil
@@ -102,7 +98,17 @@ func outputBlockETH1Data(_ context.Context, eth1Data *phase0.ETH1Data) (string,
 	return res.String(), nil
 }
 
-func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*phase0.Attestation) (string, error) {
+func outputBlockAttestations(ctx context.Context,
+	eth2Client eth2client.Service,
+	verbose bool,
+	slotsPerEpoch uint64,
+	slot phase0.Slot,
+	stateRoot phase0.Root,
+	attestations []*phase0.Attestation,
+) (
+	string,
+	error,
+) {
 	res := strings.Builder{}
 
 	validatorCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
@@ -110,17 +116,22 @@ func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service,
 	if verbose {
 		beaconCommitteesProvider, isProvider := eth2Client.(eth2client.BeaconCommitteesProvider)
 		if isProvider {
+			checker := newVoteChecker(ctx, eth2Client, slotsPerEpoch, slot, stateRoot)
+			correctSource := 0
+			correctTarget := 0
+			correctHead := 0
 			for i, att := range attestations {
 				res.WriteString(fmt.Sprintf("  %d:\n", i))
 
 				// Fetch committees for this epoch if not already obtained.
 				committees, exists := validatorCommittees[att.Data.Slot]
 				if !exists {
-					beaconCommittees, err := beaconCommitteesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", att.Data.Slot))
+					beaconCommitteesResponse, err := beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", att.Data.Slot)})
 					if err != nil {
 						// Failed to get it; create an empty committee to stop us continually attempting to re-fetch.
 						validatorCommittees[att.Data.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
 					} else {
+						beaconCommittees := beaconCommitteesResponse.Data
 						for _, beaconCommittee := range beaconCommittees {
 							if _, exists := validatorCommittees[beaconCommittee.Slot]; !exists {
 								validatorCommittees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
@@ -131,6 +142,19 @@ func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service,
 					committees = validatorCommittees[att.Data.Slot]
 				}
 
+				sourceCorrect := checker.sourceCorrect(ctx, att.Data)
+				targetCorrect := checker.targetCorrect(ctx, att.Data)
+				headCorrect := checker.headCorrect(ctx, att.Data)
+				if sourceCorrect == voteCorrect {
+					correctSource++
+				}
+				if targetCorrect == voteCorrect {
+					correctTarget++
+				}
+				if headCorrect == voteCorrect {
+					correctHead++
+				}
+
 				res.WriteString(fmt.Sprintf("    Committee index: %d\n", att.Data.Index))
 				res.WriteString(fmt.Sprintf("    Attesters: %d/%d\n", att.AggregationBits.Count(), att.AggregationBits.Len()))
 				res.WriteString(fmt.Sprintf("    Aggregation bits: %s\n", bitlistToString(att.AggregationBits)))
@@ -138,11 +162,16 @@ func outputBlockAttestations(ctx context.Context, eth2Client eth2client.Service,
 					res.WriteString(fmt.Sprintf("    Attesting indices: %s\n", attestingIndices(att.AggregationBits, committees[att.Data.Index])))
 				}
 				res.WriteString(fmt.Sprintf("    Slot: %d\n", att.Data.Slot))
-				res.WriteString(fmt.Sprintf("    Beacon block root: %#x\n", att.Data.BeaconBlockRoot))
+				res.WriteString(fmt.Sprintf("    Beacon block root: %#x (%s)\n", att.Data.BeaconBlockRoot, headCorrect))
 				res.WriteString(fmt.Sprintf("    Source epoch: %d\n", att.Data.Source.Epoch))
-				res.WriteString(fmt.Sprintf("    Source root: %#x\n", att.Data.Source.Root))
+				res.WriteString(fmt.Sprintf("    Source root: %#x (%s)\n", att.Data.Source.Root, sourceCorrect))
 				res.WriteString(fmt.Sprintf("    Target epoch: %d\n", att.Data.Target.Epoch))
-				res.WriteString(fmt.Sprintf("    Target root: %#x\n", att.Data.Target.Root))
+				res.WriteString(fmt.Sprintf("    Target root: %#x (%s)\n", att.Data.Target.Root, targetCorrect))
+			}
+			if len(attestations) > 0 {
+				res.WriteString(fmt.Sprintf("Correct source votes: %d/%d (%0.2f%%)\n", correctSource, len(attestations), 100.0*float64(correctSource)/float64(len(attestations))))
+				res.WriteString(fmt.Sprintf("Correct target votes: %d/%d (%0.2f%%)\n", correctTarget, len(attestations), 100.0*float64(correctTarget)/float64(len(attestations))))
+				res.WriteString(fmt.Sprintf("Correct head votes: %d/%d (%0.2f%%)\n", correctHead, len(attestations), 100.0*float64(correctHead)/float64(len(attestations))))
 			}
 		}
 	}
@@ -166,10 +195,11 @@ func outputBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Ser
 
 			res.WriteString(fmt.Sprintf("  %d:\n", i))
 			res.WriteString(fmt.Sprintln("    Slashed validators:"))
-			validators, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, "head", slashedIndices)
+			validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: slashedIndices})
 			if err != nil {
 				return "", errors.Wrap(err, "failed to obtain beacon committees")
 			}
+			validators := validatorsResponse.Data
 			for k, v := range validators {
 				res.WriteString(fmt.Sprintf("      %#x (%d)\n", v.Validator.PublicKey[:], k))
 			}
@@ -223,10 +253,11 @@ func outputBlockVoluntaryExits(ctx context.Context, eth2Client eth2client.Servic
 	if verbose {
 		for i, voluntaryExit := range voluntaryExits {
 			res.WriteString(fmt.Sprintf("  %d:\n", i))
-			validators, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, "head", []phase0.ValidatorIndex{voluntaryExit.Message.ValidatorIndex})
+			validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: []phase0.ValidatorIndex{voluntaryExit.Message.ValidatorIndex}})
 			if err != nil {
 				res.WriteString(fmt.Sprintf("  Error: failed to obtain validators: %v\n", err))
 			} else {
+				validators := validatorsResponse.Data
 				res.WriteString(fmt.Sprintf("    Validator: %#x (%d)\n", validators[voluntaryExit.Message.ValidatorIndex].Validator.PublicKey, voluntaryExit.Message.ValidatorIndex))
 				res.WriteString(fmt.Sprintf("    Epoch: %d\n", voluntaryExit.Message.Epoch))
 			}
@@ -243,10 +274,11 @@ func outputBlockBLSToExecutionChanges(ctx context.Context, eth2Client eth2client
 	if verbose {
 		for i, op := range ops {
 			res.WriteString(fmt.Sprintf("  %d:\n", i))
-			validators, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, "head", []phase0.ValidatorIndex{op.Message.ValidatorIndex})
+			validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: []phase0.ValidatorIndex{op.Message.ValidatorIndex}})
 			if err != nil {
 				res.WriteString(fmt.Sprintf("  Error: failed to obtain validators: %v\n", err))
 			} else {
+				validators := validatorsResponse.Data
 				res.WriteString(fmt.Sprintf("    Validator: %#x (%d)\n", validators[op.Message.ValidatorIndex].Validator.PublicKey, op.Message.ValidatorIndex))
 				res.WriteString(fmt.Sprintf("    BLS public key: %#x\n", op.Message.FromBLSPubkey))
 				res.WriteString(fmt.Sprintf("    Execution address: %s\n", op.Message.ToExecutionAddress.String()))
@@ -265,9 +297,9 @@ func outputBlockSyncAggregate(ctx context.Context, eth2Client eth2client.Service
 	if verbose {
 		specProvider, isProvider := eth2Client.(eth2client.SpecProvider)
 		if isProvider {
-			config, err := specProvider.Spec(ctx)
+			configResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 			if err == nil {
-				slotsPerEpoch := config["SLOTS_PER_EPOCH"].(uint64)
+				slotsPerEpoch := configResponse.Data["SLOTS_PER_EPOCH"].(uint64)
 
 				res.WriteString("  Contributions: ")
 				res.WriteString(bitvectorToString(syncAggregate.SyncCommitteeBits))
@@ -275,10 +307,11 @@ func outputBlockSyncAggregate(ctx context.Context, eth2Client eth2client.Service
 
 				syncCommitteesProvider, isProvider := eth2Client.(eth2client.SyncCommitteesProvider)
 				if isProvider {
-					syncCommittee, err := syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", uint64(epoch)*slotsPerEpoch))
+					syncCommitteeResponse, err := syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{State: fmt.Sprintf("%d", uint64(epoch)*slotsPerEpoch)})
 					if err != nil {
 						res.WriteString(fmt.Sprintf("  Error: failed to obtain sync committee: %v\n", err))
 					} else {
+						syncCommittee := syncCommitteeResponse.Data
 						res.WriteString("  Contributing validators:")
 						for i := uint64(0); i < syncAggregate.SyncCommitteeBits.Len(); i++ {
 							if syncAggregate.SyncCommitteeBits.BitAt(i) {
@@ -348,7 +381,7 @@ func outputCapellaBlockText(ctx context.Context, data *dataOut, signedBlock *cap
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
@@ -452,7 +485,7 @@ func outputDenebBlockText(ctx context.Context,
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
@@ -555,7 +588,7 @@ func outputBellatrixBlockText(ctx context.Context, data *dataOut, signedBlock *b
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
@@ -646,7 +679,7 @@ func outputAltairBlockText(ctx context.Context, data *dataOut, signedBlock *alta
 	res.WriteString(tmp)
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
@@ -723,7 +756,7 @@ func outputPhase0BlockText(ctx context.Context, data *dataOut, signedBlock *phas
 	}
 
 	// Attestations.
-	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	tmp, err = outputBlockAttestations(ctx, data.eth2Client, data.verbose, data.slotsPerEpoch, signedBlock.Message.Slot, signedBlock.Message.StateRoot, signedBlock.Message.Body.Attestations)
 	if err != nil {
 		return "", err
 	}
@@ -777,6 +810,9 @@ func outputCapellaBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%d\n", payload.BlockNumber))
 		res.WriteString("Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("", payload.Transactions))
+		}
 	} else {
 		res.WriteString("Execution payload:\n")
 		res.WriteString("  Execution block number: ")
@@ -816,6 +852,9 @@ func outputCapellaBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%#x\n", payload.LogsBloom))
 		res.WriteString("  Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("  ", payload.Transactions))
+		}
 		res.WriteString("  Withdrawals: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Withdrawals)))
 	}
@@ -845,6 +884,9 @@ func outputDenebBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%d\n", payload.BlockNumber))
 		res.WriteString("Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("", payload.Transactions))
+		}
 	} else {
 		res.WriteString("Execution payload:\n")
 		res.WriteString("  Execution block number: ")
@@ -879,6 +921,9 @@ func outputDenebBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%#x\n", payload.LogsBloom))
 		res.WriteString("  Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("  ", payload.Transactions))
+		}
 		res.WriteString("  Withdrawals: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Withdrawals)))
 		res.WriteString("  Excess blob gas: ")
@@ -901,7 +946,7 @@ func outputDenebBlobInfo(_ context.Context,
 	}
 
 	if !verbose {
-		return fmt.Sprintf("Blobs: %d\n", len(body.BlobKzgCommitments)), nil
+		return fmt.Sprintf("Blobs: %d\n", len(body.BlobKZGCommitments)), nil
 	}
 
 	res := strings.Builder{}
@@ -911,8 +956,8 @@ func outputDenebBlobInfo(_ context.Context,
 			res.WriteString("Blobs\n")
 		}
 		res.WriteString(fmt.Sprintf("  Index: %d\n", blob.Index))
-		res.WriteString(fmt.Sprintf("  KZG commitment: %s\n", blob.KzgCommitment.String()))
-		res.WriteString(fmt.Sprintf("  KZG proof: %s\n", blob.KzgProof.String()))
+		res.WriteString(fmt.Sprintf("  KZG commitment: %s\n", blob.KZGCommitment.String()))
+		res.WriteString(fmt.Sprintf("  KZG proof: %s\n", blob.KZGProof.String()))
 	}
 
 	return res.String(), nil
@@ -940,6 +985,9 @@ func outputBellatrixBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%d\n", payload.BlockNumber))
 		res.WriteString("Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("", payload.Transactions))
+		}
 	} else {
 		res.WriteString("Execution payload:\n")
 		res.WriteString("  Execution block number: ")
@@ -975,6 +1023,9 @@ func outputBellatrixBlockExecutionPayload(_ context.Context,
 		res.WriteString(fmt.Sprintf("%#x\n", payload.LogsBloom))
 		res.WriteString("  Transactions: ")
 		res.WriteString(fmt.Sprintf("%d\n", len(payload.Transactions)))
+		if decodeTransactions {
+			res.WriteString(outputTransactions("  ", payload.Transactions))
+		}
 	}
 
 	return res.String(), nil
@@ -14,32 +14,53 @@
 package blockinfo
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
-	"github.com/attestantio/go-eth2-client/spec/altair"
-	"github.com/attestantio/go-eth2-client/spec/bellatrix"
-	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
+// errEmptyBlock is returned when there is no block for a given block ID.
+var errEmptyBlock = errors.New("empty beacon block")
+
 var (
-	jsonOutput bool
-	sszOutput  bool
-	results    *dataOut
+	outputFormat utiloutput.Format
+	// blockStream renders the blocks that are output, so that a range or
+	// stream of blocks is output as a single CSV table or YAML stream.
+	blockStream *utiloutput.Stream
+	sszFile     string
+	blobsFile   string
+	rawOutput   bool
+	blinded     bool
+	relay       string
+	streamTopic string
+	timeout     time.Duration
+	results     *dataOut
 )
 
+// textOutput returns true if blocks are output as text.
+func textOutput() bool {
+	return outputFormat == utiloutput.Text
+}
+
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
@@ -48,24 +69,39 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no block ID or block time")
 	}
 
+	outputFormat = data.format
+	blockStream = utiloutput.NewStream(data.format)
+	sszFile = data.sszFile
+	blobsFile = data.blobsFile
+	rawOutput = data.rawOutput
+	decodeTransactions = data.decodeTransactions
+	verifyBlobs = data.verifyBlobs
+	kzgSetup = data.kzgSetup
+	timeout = data.timeout
 	results = &dataOut{
 		debug:      data.debug,
 		verbose:    data.verbose,
 		eth2Client: data.eth2Client,
 	}
 
-	config, err := results.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	configResponse, err := results.eth2Client.(eth2client.SpecProvider).Spec(ctx, &eth2api.SpecOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to obtain configuration information")
 	}
-	genesis, err := results.eth2Client.(eth2client.GenesisProvider).Genesis(ctx)
+	config := configResponse.Data
+	genesisResponse, err := results.eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &eth2api.GenesisOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to obtain genesis information")
 	}
+	genesis := genesisResponse.Data
 	results.genesisTime = genesis.GenesisTime
 	results.slotDuration = config["SECONDS_PER_SLOT"].(time.Duration)
 	results.slotsPerEpoch = config["SLOTS_PER_EPOCH"].(uint64)
 
+	if data.rangeMode {
+		return processRange(ctx, data)
+	}
+
 	if data.blockTime != "" {
 		data.blockID, err = timeToBlockID(ctx, data.eth2Client, data.blockTime)
 		if err != nil {
@@ -73,7 +109,34 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		}
 	}
 
-	signedBlock, err := results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, data.blockID)
+	if data.diffBlockID != "" {
+		return processDiff(ctx, data)
+	}
+
+	if data.blinded {
+		blindedBlock, found, err := util.SignedBlindedBeaconBlock(ctx, results.eth2Client, data.timeout, data.blockID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain blinded beacon block")
+		}
+		if !found {
+			if data.quiet {
+				os.Exit(1)
+			}
+			return nil, errors.New("empty beacon block")
+		}
+		if blindedBlock.Version >= spec.DataVersionBellatrix {
+			if data.quiet {
+				os.Exit(0)
+			}
+			if err := outputBlindedBlock(ctx, data.relay, data.timeout, blindedBlock); err != nil {
+				return nil, errors.Wrap(err, "failed to output block")
+			}
+			return streamBlocks(ctx, data)
+		}
+		// Blocks prior to bellatrix have no execution payload, so fall through to the full block.
+	}
+
+	signedBlock, err := util.ResponseData(results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: data.blockID}))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain beacon block")
 	}
@@ -87,42 +150,117 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		os.Exit(0)
 	}
 
-	switch signedBlock.Version {
-	case spec.DataVersionPhase0:
-		if err := outputPhase0Block(ctx, data.jsonOutput, signedBlock.Phase0); err != nil {
-			return nil, errors.Wrap(err, "failed to output block")
+	if err := outputBlock(ctx, data.blockID, signedBlock); err != nil {
+		return nil, errors.Wrap(err, "failed to output block")
+	}
+
+	return streamBlocks(ctx, data)
+}
+
+// processRange outputs the blocks in a range of slots.  Empty slots are skipped.
+func processRange(ctx context.Context, data *dataIn) (*dataOut, error) {
+	fromSlot, toSlot, err := slotRange(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if data.debug {
+		fmt.Fprintf(os.Stderr, "Slot range is %d to %d\n", fromSlot, toSlot)
+	}
+
+	if data.quiet {
+		// Exit with success if any block in the range is present.
+		for slot := fromSlot; slot <= toSlot; slot++ {
+			signedBlockResponse, err := results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)})
+			if err == nil && signedBlockResponse.Data != nil {
+				os.Exit(0)
+			}
+		}
+		os.Exit(1)
+	}
+
+	if data.sszDir != "" {
+		if err := os.MkdirAll(data.sszDir, 0o700); err != nil {
+			return nil, errors.Wrap(err, "failed to create SSZ directory")
+		}
+	}
+
+	blinded = data.blinded
+	relay = data.relay
+	outputs := 0
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		blockID := fmt.Sprintf("%d", slot)
+		if data.sszDir != "" {
+			sszFile = filepath.Join(data.sszDir, fmt.Sprintf("%d.ssz", slot))
 		}
-	case spec.DataVersionAltair:
-		if err := outputAltairBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Altair); err != nil {
-			return nil, errors.Wrap(err, "failed to output block")
+		if textOutput() && outputs > 0 {
+			// Separate text output for each block.
+			fmt.Println("")
 		}
-	case spec.DataVersionBellatrix:
-		if err := outputBellatrixBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Bellatrix); err != nil {
-			return nil, errors.Wrap(err, "failed to output block")
+
+		if blinded {
+			err = outputBlindedBlockByID(ctx, blockID)
+		} else {
+			err = outputBlockByID(ctx, blockID)
 		}
-	case spec.DataVersionCapella:
-		if err := outputCapellaBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Capella); err != nil {
-			return nil, errors.Wrap(err, "failed to output block")
+		if errors.Is(err, errEmptyBlock) {
+			if data.debug {
+				fmt.Fprintf(os.Stderr, "No block at slot %d\n", slot)
+			}
+			continue
 		}
-	case spec.DataVersionDeneb:
-		blobs, err := results.eth2Client.(eth2client.BeaconBlockBlobsProvider).BeaconBlockBlobs(ctx, data.blockID)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to obtain blobs")
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to output block at slot %d", slot))
 		}
-		if err := outputDenebBlock(ctx, data.jsonOutput, data.sszOutput, signedBlock.Deneb, blobs); err != nil {
-			return nil, errors.Wrap(err, "failed to output block")
+		outputs++
+	}
+
+	return &dataOut{}, nil
+}
+
+// slotRange returns the first and last slots of the range to output.
+func slotRange(ctx context.Context, data *dataIn) (phase0.Slot, phase0.Slot, error) {
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(data.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(data.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	if data.epoch != "" {
+		epoch, err := util.ParseEpoch(ctx, chainTime, data.epoch)
+		if err != nil {
+			return 0, 0, err
 		}
-	default:
-		return nil, errors.New("unknown block version")
+		return chainTime.FirstSlotOfEpoch(epoch), chainTime.FirstSlotOfEpoch(epoch+1) - 1, nil
 	}
 
+	// Slots have been validated as part of the input.
+	fromSlot, _ := strconv.ParseUint(data.fromSlot, 10, 64)
+	toSlot := uint64(chainTime.CurrentSlot())
+	if data.toSlot != "" {
+		toSlot, _ = strconv.ParseUint(data.toSlot, 10, 64)
+	}
+	if fromSlot > toSlot {
+		return 0, 0, errors.New("from-slot cannot be after to-slot")
+	}
+
+	return phase0.Slot(fromSlot), phase0.Slot(toSlot), nil
+}
+
+func streamBlocks(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data.stream {
-		jsonOutput = data.jsonOutput
-		sszOutput = data.sszOutput
-		if !jsonOutput && !sszOutput {
+		blinded = data.blinded
+		relay = data.relay
+		streamTopic = data.streamTopic
+		timeout = data.timeout
+		if textOutput() {
 			fmt.Println("")
 		}
-		err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, []string{"head"}, headEventHandler)
+		err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, &eth2api.EventsOpts{
+			Topics:  []string{streamTopic},
+			Handler: blockEventHandler,
+		})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start block stream")
 		}
@@ -132,176 +270,261 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	return &dataOut{}, nil
 }
 
-func headEventHandler(event *api.Event) {
+func blockEventHandler(event *api.Event) {
 	ctx := context.Background()
 
-	// Only interested in head events.
-	if event.Topic != "head" {
+	blockID, isBlockEvent := eventBlockID(event, streamTopic)
+	if !isBlockEvent {
 		return
 	}
 
-	blockID := fmt.Sprintf("%#x", event.Data.(*api.HeadEvent).Block[:])
-	signedBlock, err := results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, blockID)
-	if err != nil {
-		if !jsonOutput && !sszOutput {
-			fmt.Printf("Failed to obtain block: %v\n", err)
-		}
-		return
+	var err error
+	if blinded {
+		err = outputBlindedBlockByID(ctx, blockID)
+	} else {
+		err = outputBlockByID(ctx, blockID)
 	}
-	if signedBlock == nil {
-		if !jsonOutput && !sszOutput {
-			fmt.Println("Empty beacon block")
-		}
+	if err != nil && textOutput() {
+		fmt.Printf("Failed to output block: %v\n", err)
 		return
 	}
 
-	switch signedBlock.Version {
-	case spec.DataVersionPhase0:
-		err = outputPhase0Block(ctx, jsonOutput, signedBlock.Phase0)
-	case spec.DataVersionAltair:
-		err = outputAltairBlock(ctx, jsonOutput, sszOutput, signedBlock.Altair)
-	case spec.DataVersionBellatrix:
-		err = outputBellatrixBlock(ctx, jsonOutput, sszOutput, signedBlock.Bellatrix)
-	case spec.DataVersionCapella:
-		err = outputCapellaBlock(ctx, jsonOutput, sszOutput, signedBlock.Capella)
-	case spec.DataVersionDeneb:
-		var blobs []*deneb.BlobSidecar
-		blobs, err = results.eth2Client.(eth2client.BeaconBlockBlobsProvider).BeaconBlockBlobs(ctx, blockID)
-		if err == nil {
-			err = outputDenebBlock(context.Background(), jsonOutput, sszOutput, signedBlock.Deneb, blobs)
-		}
+	if textOutput() {
+		fmt.Println("")
+	}
+}
+
+// eventBlockID returns the ID of the block referenced by an event for the
+// given topic.  It returns false if the event is not for the topic.
+func eventBlockID(event *api.Event, topic string) (string, bool) {
+	if event.Topic != topic {
+		return "", false
+	}
+
+	switch data := event.Data.(type) {
+	case *api.HeadEvent:
+		return fmt.Sprintf("%#x", data.Block[:]), true
+	case *api.BlockEvent:
+		return fmt.Sprintf("%#x", data.Block[:]), true
+	case *api.FinalizedCheckpointEvent:
+		return fmt.Sprintf("%#x", data.Block[:]), true
 	default:
-		err = errors.New("unknown block version")
+		return "", false
 	}
-	if err != nil && !jsonOutput && !sszOutput {
-		fmt.Printf("Failed to output block: %v\n", err)
-		return
+}
+
+func outputBlockByID(ctx context.Context, blockID string) error {
+	signedBlock, err := util.ResponseData(results.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &eth2api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	if signedBlock == nil {
+		return errEmptyBlock
 	}
 
-	if !jsonOutput && !sszOutput {
-		fmt.Println("")
+	return outputBlock(ctx, blockID, signedBlock)
+}
+
+func outputBlindedBlockByID(ctx context.Context, blockID string) error {
+	blindedBlock, found, err := util.SignedBlindedBeaconBlock(ctx, results.eth2Client, timeout, blockID)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain blinded block")
+	}
+	if !found {
+		return errEmptyBlock
 	}
+	if blindedBlock.Version < spec.DataVersionBellatrix {
+		return outputBlockByID(ctx, blockID)
+	}
+
+	return outputBlindedBlock(ctx, relay, timeout, blindedBlock)
 }
 
-func outputPhase0Block(ctx context.Context, jsonOutput bool, signedBlock *phase0.SignedBeaconBlock) error {
-	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
+// outputBlock outputs a block decoded by the client.
+func outputBlock(ctx context.Context,
+	blockID string,
+	signedBlock *spec.VersionedSignedBeaconBlock,
+) error {
+	renderer := &blockRenderer{
+		signedBlock: signedBlock,
+	}
+	switch signedBlock.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella:
+	case spec.DataVersionDeneb, spec.DataVersionElectra, spec.DataVersionFulu:
+		// Blobs are shown in text output, and can be written alongside SSZ output.
+		if outputFormat == utiloutput.Text || (outputFormat == utiloutput.SSZ && blobsFile != "") {
+			blobsResponse, err := results.eth2Client.(eth2client.BlobSidecarsProvider).BlobSidecars(ctx, &eth2api.BlobSidecarsOpts{Block: blockID})
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain blobs")
+			}
+			renderer.blobs = blobsResponse.Data
 		}
-		fmt.Printf("%s\n", string(data))
-	default:
-		data, err := outputPhase0BlockText(ctx, results, signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate text")
+		if verifyBlobs {
+			bodyRoot, err := signedBlock.BodyRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate body root")
+			}
+			commitments, err := signedBlock.BlobKZGCommitments()
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain blob KZG commitments")
+			}
+			renderer.verifications, err = verifyBlockBlobs(ctx, blockID, commitments, bodyRoot)
+			if err != nil {
+				return err
+			}
 		}
-		fmt.Print(data)
+	default:
+		return errors.New("unknown block version")
 	}
+
+	if err := renderBlock(ctx, renderer); err != nil {
+		return err
+	}
+	if outputFormat == utiloutput.SSZ {
+		return outputBlobsSSZ(renderer.blobs)
+	}
+
 	return nil
 }
 
-func outputAltairBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *altair.SignedBeaconBlock) error {
-	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
-	case sszOutput:
-		data, err := signedBlock.MarshalSSZ()
-		if err != nil {
-			return errors.Wrap(err, "failed to generate SSZ")
-		}
-		fmt.Printf("%x\n", data)
-	default:
-		data, err := outputAltairBlockText(ctx, results, signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate text")
+// outputBlindedBlock outputs a blinded block.  If a relay is supplied then
+// text output also states if the relay delivered the block's execution payload.
+func outputBlindedBlock(ctx context.Context,
+	relay string,
+	timeout time.Duration,
+	blindedBlock *eth2api.VersionedSignedBlindedBeaconBlock,
+) error {
+	if err := renderBlock(ctx, &blindedBlockRenderer{
+		blindedBlock: blindedBlock,
+	}); err != nil {
+		return err
+	}
+
+	if relay != "" && textOutput() {
+		delivery, err := obtainRelayDelivery(ctx, relay, timeout, blindedBlock)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "Unable to obtain payload delivery from relay: %v\n", err)
+		case delivery == nil:
+			fmt.Println("Relay: did not deliver execution payload")
+		default:
+			fmt.Printf("Relay: delivered execution payload with value %s\n", string2eth.WeiToString(delivery.Value, true))
 		}
-		fmt.Print(data)
+		fmt.Println("Execution payload: not available (relays do not provide delivered payloads)")
 	}
+
 	return nil
 }
 
-func outputBellatrixBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *bellatrix.SignedBeaconBlock) error {
-	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
-	case sszOutput:
-		data, err := signedBlock.MarshalSSZ()
-		if err != nil {
-			return errors.Wrap(err, "failed to generate SSZ")
+// renderBlock outputs a block in the requested format.
+func renderBlock(ctx context.Context, renderer utiloutput.Renderer) error {
+	if outputFormat == utiloutput.SSZ {
+		// SSZ can be written to a file or as binary, so is streamed directly
+		// to its destination.
+		sszRenderer, isRenderer := renderer.(utiloutput.SSZStreamRenderer)
+		if !isRenderer {
+			return errors.New("SSZ output is not supported for this block")
 		}
-		fmt.Printf("%x\n", data)
-	default:
-		data, err := outputBellatrixBlockText(ctx, results, signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate text")
-		}
-		fmt.Print(data)
+		return outputSSZ(func(w io.Writer) error {
+			return sszRenderer.WriteSSZ(ctx, w)
+		})
 	}
+
+	res, err := blockStream.Render(ctx, renderer)
+	if err != nil {
+		return err
+	}
+	if textOutput() {
+		// Text output carries its own trailing newline.
+		fmt.Print(res)
+	} else {
+		fmt.Println(res)
+	}
+
 	return nil
 }
 
-func outputCapellaBlock(ctx context.Context, jsonOutput bool, sszOutput bool, signedBlock *capella.SignedBeaconBlock) error {
-	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
-	case sszOutput:
-		data, err := signedBlock.MarshalSSZ()
-		if err != nil {
-			return errors.Wrap(err, "failed to generate SSZ")
-		}
-		fmt.Printf("%x\n", data)
-	default:
-		data, err := outputCapellaBlockText(ctx, results, signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate text")
+// encodeSSZ writes the SSZ encoding of an item, recording the time taken.
+func encodeSSZ(w io.Writer, item util.SSZMarshaler) error {
+	defer util.TimePhase("SSZ encoding")()
+
+	return util.NewSSZEncoder(w).Encode(item)
+}
+
+// outputSSZ outputs SSZ data, either to the SSZ file, as raw binary or as hex.
+// The data is written to its destination as it is generated, rather than being
+// held in memory.
+func outputSSZ(write func(w io.Writer) error) error {
+	if sszFile != "" {
+		return writeSSZFile(sszFile, "SSZ file", write)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	var w io.Writer = out
+	if !rawOutput {
+		w = hex.NewEncoder(out)
+	}
+	if err := write(w); err != nil {
+		return err
+	}
+	if !rawOutput {
+		if _, err := out.WriteString("\n"); err != nil {
+			return errors.Wrap(err, "failed to write SSZ")
 		}
-		fmt.Print(data)
 	}
+	if err := out.Flush(); err != nil {
+		return errors.Wrap(err, "failed to write SSZ")
+	}
+
 	return nil
 }
 
-func outputDenebBlock(ctx context.Context,
-	jsonOutput bool,
-	sszOutput bool,
-	signedBlock *deneb.SignedBeaconBlock,
-	blobs []*deneb.BlobSidecar,
-) error {
-	switch {
-	case jsonOutput:
-		data, err := json.Marshal(signedBlock)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate JSON")
-		}
-		fmt.Printf("%s\n", string(data))
-	case sszOutput:
-		data, err := signedBlock.MarshalSSZ()
-		if err != nil {
-			return errors.Wrap(err, "failed to generate SSZ")
-		}
-		fmt.Printf("%x\n", data)
-	default:
-		data, err := outputDenebBlockText(ctx, results, signedBlock, blobs)
-		if err != nil {
-			return errors.Wrap(err, "failed to generate text")
+// writeSSZFile writes SSZ data to a file.  The file is removed if the data
+// cannot be written in full, so that a partial file is not left behind.
+func writeSSZFile(name string, kind string, write func(w io.Writer) error) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to create %s", kind))
+	}
+	w := bufio.NewWriter(f)
+	err = write(w)
+	if err == nil {
+		if flushErr := w.Flush(); flushErr != nil {
+			err = errors.Wrap(flushErr, fmt.Sprintf("failed to write %s", kind))
 		}
-		fmt.Print(data)
 	}
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		err = errors.Wrap(closeErr, fmt.Sprintf("failed to write %s", kind))
+	}
+	if err != nil {
+		_ = os.Remove(name)
+		return err
+	}
+
 	return nil
 }
 
+// outputBlobsSSZ writes the SSZ of blob sidecars to the blobs file, if supplied.
+// Blob sidecars are of fixed size, so their concatenation is the SSZ of the list.
+// Each sidecar is written as it is encoded, so only one is held in memory at a time.
+func outputBlobsSSZ(blobs []*deneb.BlobSidecar) error {
+	if blobsFile == "" {
+		return nil
+	}
+
+	return writeSSZFile(blobsFile, "blob sidecars SSZ file", func(w io.Writer) error {
+		defer util.TimePhase("SSZ encoding")()
+		encoder := util.NewSSZEncoder(w)
+		for _, blob := range blobs {
+			if err := encoder.Encode(blob); err != nil {
+				return errors.Wrap(err, "failed to generate blob sidecar SSZ")
+			}
+		}
+
+		return nil
+	})
+}
+
 func timeToBlockID(ctx context.Context, eth2Client eth2client.Service, input string) (string, error) {
 	var timestamp time.Time
 
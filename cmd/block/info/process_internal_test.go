@@ -15,10 +15,16 @@ package blockinfo
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
+	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/auto"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
@@ -62,3 +68,102 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestEventBlockID(t *testing.T) {
+	root := phase0.Root{0x01, 0x02}
+	rootStr := "0x0102000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name    string
+		event   *api.Event
+		topic   string
+		blockID string
+		found   bool
+	}{
+		{
+			name:    "Head",
+			event:   &api.Event{Topic: "head", Data: &api.HeadEvent{Block: root}},
+			topic:   "head",
+			blockID: rootStr,
+			found:   true,
+		},
+		{
+			name:    "Block",
+			event:   &api.Event{Topic: "block", Data: &api.BlockEvent{Block: root}},
+			topic:   "block",
+			blockID: rootStr,
+			found:   true,
+		},
+		{
+			name:    "FinalizedCheckpoint",
+			event:   &api.Event{Topic: "finalized_checkpoint", Data: &api.FinalizedCheckpointEvent{Block: root}},
+			topic:   "finalized_checkpoint",
+			blockID: rootStr,
+			found:   true,
+		},
+		{
+			name:  "OtherTopic",
+			event: &api.Event{Topic: "head", Data: &api.HeadEvent{Block: root}},
+			topic: "finalized_checkpoint",
+		},
+		{
+			name:  "UnknownData",
+			event: &api.Event{Topic: "chain_reorg", Data: &api.ChainReorgEvent{}},
+			topic: "chain_reorg",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blockID, found := eventBlockID(test.event, test.topic)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.blockID, blockID)
+		})
+	}
+}
+
+func TestOutputBlobsSSZ(t *testing.T) {
+	blobs := []*deneb.BlobSidecar{
+		{Index: 0, SignedBlockHeader: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{Slot: 5}}},
+		{Index: 1, SignedBlockHeader: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{Slot: 5}}},
+	}
+	expected := make([]byte, 0)
+	for _, blob := range blobs {
+		data, err := blob.MarshalSSZ()
+		require.NoError(t, err)
+		expected = append(expected, data...)
+	}
+
+	blobsFile = filepath.Join(t.TempDir(), "blobs.ssz")
+	defer func() { blobsFile = "" }()
+	require.NoError(t, outputBlobsSSZ(blobs))
+	data, err := os.ReadFile(blobsFile)
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+}
+
+func TestWriteSSZFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "block.ssz")
+
+	require.NoError(t, writeSSZFile(name, "SSZ file", func(w io.Writer) error {
+		_, err := w.Write([]byte{0x01, 0x02})
+		return err
+	}))
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02}, data)
+
+	// A failed write does not leave a partial file behind.
+	err = writeSSZFile(name, "SSZ file", func(w io.Writer) error {
+		_, _ = w.Write([]byte{0x03})
+		return errors.New("failed to obtain SSZ")
+	})
+	require.EqualError(t, err, "failed to obtain SSZ")
+	_, err = os.Stat(name)
+	require.True(t, os.IsNotExist(err))
+
+	err = writeSSZFile(filepath.Join(name, "missing", "block.ssz"), "SSZ file", func(_ io.Writer) error {
+		return nil
+	})
+	require.ErrorContains(t, err, "failed to create SSZ file")
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// obtainRelayDelivery obtains the relay's record of delivering the execution
+// payload of a blinded block, using the read-only relay data API.
+// It returns nil if the relay did not deliver the payload.
+// Relays do not make delivered execution payloads available, so this cannot
+// be used to reconstruct the full block.
+func obtainRelayDelivery(ctx context.Context,
+	relay string,
+	timeout time.Duration,
+	blindedBlock *api.VersionedSignedBlindedBeaconBlock,
+) (
+	*util.RelayBidTrace,
+	error,
+) {
+	slot, err := blindedBlock.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain slot")
+	}
+	blockHash, err := blindedBlock.ExecutionBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+
+	traces, err := util.ObtainRelayBidTraces(ctx, relay, fmt.Sprintf("proposer_payload_delivered?slot=%d", slot), timeout)
+	if err != nil {
+		return nil, err
+	}
+	for _, trace := range traces {
+		if trace.BlockHash == blockHash {
+			return trace, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlindedBlock returns a blinded block whose execution payload header
+// matches testPayload().
+func testBlindedBlock() *api.VersionedSignedBlindedBeaconBlock {
+	payload := testPayload()
+	return &api.VersionedSignedBlindedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &apiv1capella.SignedBlindedBeaconBlock{
+			Message: &apiv1capella.BlindedBeaconBlock{
+				Slot:          5,
+				ProposerIndex: 6,
+				Body: &apiv1capella.BlindedBeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+					ProposerSlashings:     []*phase0.ProposerSlashing{},
+					AttesterSlashings:     []*phase0.AttesterSlashing{},
+					Attestations:          []*phase0.Attestation{},
+					Deposits:              []*phase0.Deposit{},
+					VoluntaryExits:        []*phase0.SignedVoluntaryExit{},
+					BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+					SyncAggregate: &altair.SyncAggregate{
+						SyncCommitteeBits: bitfield.NewBitvector512(),
+					},
+					ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
+						ParentHash:    payload.ParentHash,
+						FeeRecipient:  payload.FeeRecipient,
+						StateRoot:     payload.StateRoot,
+						ReceiptsRoot:  payload.ReceiptsRoot,
+						LogsBloom:     payload.LogsBloom,
+						PrevRandao:    payload.PrevRandao,
+						BlockNumber:   payload.BlockNumber,
+						GasLimit:      payload.GasLimit,
+						GasUsed:       payload.GasUsed,
+						Timestamp:     payload.Timestamp,
+						ExtraData:     payload.ExtraData,
+						BaseFeePerGas: payload.BaseFeePerGas,
+						BlockHash:     payload.BlockHash,
+						// Roots of empty transaction and withdrawal lists.
+						TransactionsRoot: phase0.Root{0x7f, 0xfe, 0x24, 0x1e, 0xa6, 0x01, 0x87, 0xfd, 0xb0, 0x18, 0x7b, 0xfa, 0x22, 0xde, 0x35, 0xd1, 0xf9, 0xbe, 0xd7, 0xab, 0x06, 0x1d, 0x94, 0x01, 0xfd, 0x47, 0xe3, 0x4a, 0x54, 0xfb, 0xed, 0xe1},
+						WithdrawalsRoot:  phase0.Root{0x79, 0x29, 0x30, 0xbb, 0xd5, 0xba, 0xac, 0x43, 0xbc, 0xc7, 0x98, 0xee, 0x49, 0xaa, 0x81, 0x85, 0xef, 0x76, 0xbb, 0x3b, 0x44, 0xba, 0x62, 0xb9, 0x1d, 0x86, 0xae, 0x56, 0x9e, 0x4b, 0xb5, 0x35},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testPayload() *capella.ExecutionPayload {
+	return &capella.ExecutionPayload{
+		ParentHash:    phase0.Hash32{0x01},
+		FeeRecipient:  bellatrix.ExecutionAddress{0x02},
+		BlockNumber:   7,
+		GasLimit:      30000000,
+		Timestamp:     1700000000,
+		ExtraData:     []byte{},
+		BaseFeePerGas: [32]byte{0x03},
+		BlockHash:     phase0.Hash32{0x04},
+		Transactions:  []bellatrix.Transaction{},
+		Withdrawals:   []*capella.Withdrawal{},
+	}
+}
+
+func TestObtainRelayDelivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("slot") {
+		case "5":
+			_, _ = w.Write([]byte(`[{"slot":"5","block_hash":"0x0400000000000000000000000000000000000000000000000000000000000000","value":"1000"}]`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	res, err := obtainRelayDelivery(context.Background(), server.URL+"/", time.Second, testBlindedBlock())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), res.Value)
+
+	otherBlock := testBlindedBlock()
+	otherBlock.Capella.Message.Slot = 6
+	res, err = obtainRelayDelivery(context.Background(), server.URL, time.Second, otherBlock)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	_, err = obtainRelayDelivery(context.Background(), server.URL+"/missing", time.Second, testBlindedBlock())
+	require.EqualError(t, err, "relay returned status 404: ")
+}
@@ -0,0 +1,220 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/pkg/errors"
+)
+
+// blockRenderer renders a block that has been decoded by the client.
+type blockRenderer struct {
+	signedBlock *spec.VersionedSignedBeaconBlock
+	// blobs are the blob sidecars of the block, if obtained.
+	blobs []*deneb.BlobSidecar
+	// verifications are the results of verifying the blob sidecars, if requested.
+	verifications []*blobVerification
+}
+
+// RenderText renders the block as text.
+func (r *blockRenderer) RenderText(ctx context.Context) (string, error) {
+	var res string
+	var err error
+	switch r.signedBlock.Version {
+	case spec.DataVersionPhase0:
+		res, err = outputPhase0BlockText(ctx, results, r.signedBlock.Phase0)
+	case spec.DataVersionAltair:
+		res, err = outputAltairBlockText(ctx, results, r.signedBlock.Altair)
+	case spec.DataVersionBellatrix:
+		res, err = outputBellatrixBlockText(ctx, results, r.signedBlock.Bellatrix)
+	case spec.DataVersionCapella:
+		res, err = outputCapellaBlockText(ctx, results, r.signedBlock.Capella)
+	case spec.DataVersionDeneb:
+		res, err = outputDenebBlockText(ctx, results, r.signedBlock.Deneb, r.blobs)
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		res, err = outputElectraBlockText(ctx, results, electraBlock(r.signedBlock), r.blobs)
+	default:
+		return "", errors.New("unknown block version")
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate text")
+	}
+
+	return res + outputBlobVerifications(r.verifications), nil
+}
+
+// RenderJSON renders the block as JSON, with decoded transactions and blob
+// verifications if requested.
+func (r *blockRenderer) RenderJSON(_ context.Context) ([]byte, error) {
+	var data []byte
+	var err error
+	var transactions []bellatrix.Transaction
+	hasPayload := false
+	switch r.signedBlock.Version {
+	case spec.DataVersionPhase0:
+		data, err = json.Marshal(r.signedBlock.Phase0)
+	case spec.DataVersionAltair:
+		data, err = json.Marshal(r.signedBlock.Altair)
+	case spec.DataVersionBellatrix:
+		data, err = json.Marshal(r.signedBlock.Bellatrix)
+		transactions = r.signedBlock.Bellatrix.Message.Body.ExecutionPayload.Transactions
+		hasPayload = true
+	case spec.DataVersionCapella:
+		data, err = json.Marshal(r.signedBlock.Capella)
+		transactions = r.signedBlock.Capella.Message.Body.ExecutionPayload.Transactions
+		hasPayload = true
+	case spec.DataVersionDeneb:
+		data, err = json.Marshal(r.signedBlock.Deneb)
+		transactions = r.signedBlock.Deneb.Message.Body.ExecutionPayload.Transactions
+		hasPayload = true
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		block := electraBlock(r.signedBlock)
+		data, err = json.Marshal(block)
+		transactions = block.Message.Body.ExecutionPayload.Transactions
+		hasPayload = true
+	default:
+		return nil, errors.New("unknown block version")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate JSON")
+	}
+
+	if decodeTransactions && hasPayload {
+		data, err = addDecodedTransactions(data, transactions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if verifyBlobs && r.signedBlock.Version >= spec.DataVersionDeneb {
+		data, err = addBlobVerifications(data, r.verifications)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// WriteSSZ writes the block as SSZ.
+func (r *blockRenderer) WriteSSZ(_ context.Context, w io.Writer) error {
+	var err error
+	switch r.signedBlock.Version {
+	case spec.DataVersionPhase0:
+		err = encodeSSZ(w, r.signedBlock.Phase0)
+	case spec.DataVersionAltair:
+		err = encodeSSZ(w, r.signedBlock.Altair)
+	case spec.DataVersionBellatrix:
+		err = encodeSSZ(w, r.signedBlock.Bellatrix)
+	case spec.DataVersionCapella:
+		err = encodeSSZ(w, r.signedBlock.Capella)
+	case spec.DataVersionDeneb:
+		err = encodeSSZ(w, r.signedBlock.Deneb)
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		err = encodeSSZ(w, electraBlock(r.signedBlock))
+	default:
+		return errors.New("unknown block version")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to generate SSZ")
+	}
+
+	return nil
+}
+
+// CSVHeader returns the header of the block's CSV output.
+func (*blockRenderer) CSVHeader() []string {
+	return blockCSVHeader
+}
+
+// CSVRecords returns the CSV record of the block.
+func (r *blockRenderer) CSVRecords(_ context.Context) ([][]string, error) {
+	data, err := blockCSVDataFromBlock(r.signedBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return [][]string{data.record()}, nil
+}
+
+// blindedBlockRenderer renders a blinded block.  Blinded blocks are not
+// rendered as CSV.
+type blindedBlockRenderer struct {
+	blindedBlock *eth2api.VersionedSignedBlindedBeaconBlock
+}
+
+// RenderText renders the blinded block as text.
+func (r *blindedBlockRenderer) RenderText(ctx context.Context) (string, error) {
+	res, err := outputBlindedBlockText(ctx, results, r.blindedBlock)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate text")
+	}
+
+	return res, nil
+}
+
+// RenderJSON renders the blinded block as JSON.
+func (r *blindedBlockRenderer) RenderJSON(_ context.Context) ([]byte, error) {
+	var data []byte
+	var err error
+	switch r.blindedBlock.Version {
+	case spec.DataVersionBellatrix:
+		data, err = json.Marshal(r.blindedBlock.Bellatrix)
+	case spec.DataVersionCapella:
+		data, err = json.Marshal(r.blindedBlock.Capella)
+	case spec.DataVersionDeneb:
+		data, err = json.Marshal(r.blindedBlock.Deneb)
+	case spec.DataVersionElectra:
+		data, err = json.Marshal(r.blindedBlock.Electra)
+	case spec.DataVersionFulu:
+		data, err = json.Marshal(r.blindedBlock.Fulu)
+	default:
+		return nil, errors.New("unknown block version")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return data, nil
+}
+
+// WriteSSZ writes the blinded block as SSZ.
+func (r *blindedBlockRenderer) WriteSSZ(_ context.Context, w io.Writer) error {
+	var err error
+	switch r.blindedBlock.Version {
+	case spec.DataVersionBellatrix:
+		err = encodeSSZ(w, r.blindedBlock.Bellatrix)
+	case spec.DataVersionCapella:
+		err = encodeSSZ(w, r.blindedBlock.Capella)
+	case spec.DataVersionDeneb:
+		err = encodeSSZ(w, r.blindedBlock.Deneb)
+	case spec.DataVersionElectra:
+		err = encodeSSZ(w, r.blindedBlock.Electra)
+	case spec.DataVersionFulu:
+		err = encodeSSZ(w, r.blindedBlock.Fulu)
+	default:
+		return errors.New("unknown block version")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to generate SSZ")
+	}
+
+	return nil
+}
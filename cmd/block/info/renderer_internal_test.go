@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	utiloutput "github.com/wealdtech/ethdo/util/output"
+)
+
+func TestBlockRenderer(t *testing.T) {
+	renderer := &blockRenderer{
+		signedBlock: testCSVCapellaBlock(),
+	}
+
+	tests := []struct {
+		name     string
+		format   utiloutput.Format
+		fields   []string
+		expected string
+		prefix   string
+	}{
+		{
+			name:     "JSONFields",
+			format:   utiloutput.JSON,
+			fields:   []string{"message.slot", "message.proposer_index"},
+			expected: `{"message":{"proposer_index":"12","slot":"100"}}`,
+		},
+		{
+			name:     "YAMLFields",
+			format:   utiloutput.YAML,
+			fields:   []string{"message.slot"},
+			expected: "message:\n    slot: \"100\"",
+		},
+		{
+			name:   "NDJSON",
+			format: utiloutput.NDJSON,
+			prefix: `{"message":{"slot":"100","proposer_index":"12",`,
+		},
+		{
+			name:   "CSV",
+			format: utiloutput.CSV,
+			prefix: strings.Join(blockCSVHeader, ",") + "\n100,12,",
+		},
+		{
+			name:   "SSZ",
+			format: utiloutput.SSZ,
+			prefix: "0x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("fields", test.fields)
+			defer viper.Reset()
+			res, err := utiloutput.Render(context.Background(), renderer, test.format)
+			require.NoError(t, err)
+			if test.expected != "" {
+				require.Equal(t, test.expected, res)
+			}
+			require.True(t, strings.HasPrefix(res, test.prefix), res)
+		})
+	}
+}
+
+func TestBlindedBlockRenderer(t *testing.T) {
+	renderer := &blindedBlockRenderer{
+		blindedBlock: &eth2api.VersionedSignedBlindedBeaconBlock{
+			Version: spec.DataVersionPhase0,
+		},
+	}
+
+	_, err := renderer.RenderJSON(context.Background())
+	require.EqualError(t, err, "unknown block version")
+	err = renderer.WriteSSZ(context.Background(), io.Discard)
+	require.EqualError(t, err, "unknown block version")
+	_, err = utiloutput.Render(context.Background(), renderer, utiloutput.CSV)
+	require.EqualError(t, err, "csv output is not supported")
+}
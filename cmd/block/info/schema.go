@@ -0,0 +1,108 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	apiv1electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// schemaVersion is the version of the JSON output of this command.  It must be
+// increased whenever a change is made to the output that is not backwards-compatible.
+const schemaVersion = 5
+
+// Schema returns the JSON schema for the JSON output of this command.
+func Schema() (*util.JSONSchema, error) {
+	schema, err := util.GenerateJSONSchema("block/info", schemaVersion,
+		&phase0.SignedBeaconBlock{},
+		&altair.SignedBeaconBlock{},
+		&bellatrix.SignedBeaconBlock{},
+		&capella.SignedBeaconBlock{},
+		&deneb.SignedBeaconBlock{},
+		&electra.SignedBeaconBlock{},
+		&apiv1bellatrix.SignedBlindedBeaconBlock{},
+		&apiv1capella.SignedBlindedBeaconBlock{},
+		&apiv1deneb.SignedBlindedBeaconBlock{},
+		&apiv1electra.SignedBlindedBeaconBlock{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blocks with execution payloads can have their transactions decoded.
+	decodedTransactions, err := decodedTransactionsSchema()
+	if err != nil {
+		return nil, err
+	}
+	// Blocks with blobs can have their blob sidecars verified.
+	blobVerifications, err := util.JSONSchemaFromSample([]*blobVerification{})
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range schema.OneOf {
+		body := blockBodySchema(block)
+		if _, exists := body.Properties["execution_payload"]; !exists {
+			continue
+		}
+		block.Properties["decoded_transactions"] = decodedTransactions
+		if _, exists := body.Properties["blob_kzg_commitments"]; exists {
+			block.Properties["blob_verifications"] = blobVerifications
+		}
+	}
+
+	return schema, nil
+}
+
+// decodedTransactionsSchema returns the schema for decoded transactions, each
+// of which is either a transaction or the error encountered decoding it.
+func decodedTransactionsSchema() (*util.JSONSchema, error) {
+	transaction, err := util.JSONSchemaFromSample(&util.Transaction{})
+	if err != nil {
+		return nil, err
+	}
+	transactionError, err := util.JSONSchemaFromSample(&transactionError{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &util.JSONSchema{
+		Type: "array",
+		Items: &util.JSONSchema{
+			OneOf: []*util.JSONSchema{transaction, transactionError},
+		},
+	}, nil
+}
+
+// blockBodySchema returns the schema for the body of a signed block.
+func blockBodySchema(block *util.JSONSchema) *util.JSONSchema {
+	message, exists := block.Properties["message"]
+	if !exists {
+		return &util.JSONSchema{}
+	}
+	body, exists := message.Properties["body"]
+	if !exists {
+		return &util.JSONSchema{}
+	}
+
+	return body
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-string2eth"
+)
+
+// decodeTransactions is true if execution payload transactions should be decoded.
+var decodeTransactions bool
+
+// transactionError is the JSON representation of a transaction that could not be decoded.
+type transactionError struct {
+	Error string `json:"error"`
+}
+
+// outputTransactions outputs the decoded transactions of an execution payload.
+func outputTransactions(indent string, transactions []bellatrix.Transaction) string {
+	res := strings.Builder{}
+	for i, transaction := range transactions {
+		tx, err := util.DecodeTransaction(transaction)
+		if err != nil {
+			res.WriteString(fmt.Sprintf("%s  Transaction %d: failed to decode: %v\n", indent, i, err))
+			continue
+		}
+		res.WriteString(fmt.Sprintf("%s  Transaction %d: %#x\n", indent, i, tx.Hash))
+		res.WriteString(fmt.Sprintf("%s    Type: %s (%d)\n", indent, tx.TypeName(), tx.Type))
+		res.WriteString(fmt.Sprintf("%s    From: %s\n", indent, tx.From.String()))
+		if tx.To == nil {
+			res.WriteString(fmt.Sprintf("%s    To: contract creation\n", indent))
+		} else {
+			res.WriteString(fmt.Sprintf("%s    To: %s\n", indent, tx.To.String()))
+		}
+		res.WriteString(fmt.Sprintf("%s    Value: %s\n", indent, string2eth.WeiToString(tx.Value, true)))
+		res.WriteString(fmt.Sprintf("%s    Gas: %d\n", indent, tx.Gas))
+		if len(tx.BlobVersionedHashes) > 0 {
+			res.WriteString(fmt.Sprintf("%s    Blob versioned hashes:\n", indent))
+			for _, hash := range tx.BlobVersionedHashes {
+				res.WriteString(fmt.Sprintf("%s      %#x\n", indent, hash))
+			}
+		}
+	}
+
+	return res.String()
+}
+
+// addDecodedTransactions adds the decoded transactions of an execution
+// payload to the JSON of a block.
+func addDecodedTransactions(data []byte, transactions []bellatrix.Transaction) ([]byte, error) {
+	block := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &block); err != nil {
+		return nil, errors.Wrap(err, "failed to parse block JSON")
+	}
+
+	decoded := make([]interface{}, 0, len(transactions))
+	for _, transaction := range transactions {
+		tx, err := util.DecodeTransaction(transaction)
+		if err != nil {
+			decoded = append(decoded, &transactionError{Error: err.Error()})
+			continue
+		}
+		decoded = append(decoded, tx)
+	}
+	decodedData, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate decoded transactions JSON")
+	}
+	block["decoded_transactions"] = decodedData
+
+	return json.Marshal(block)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+// blobTransaction is a signed blob transaction with two versioned hashes.
+const blobTransaction = "0x03f8b80107843b9aca008506fc23ac00825208943535353535353535353535353535353535353535880de0b6b3a764000080c064f842a001000000000000000000000000000000000000000000000000000000000000aaa001000000000000000000000000000000000000000000000000000000000000bb80a09d2d304374a5d5571d7d8c39db99c8eed149767dcaf70be1a356a08376caa34fa0664523f575a4e5b2e5351ab64f07e7cdba6172a21f975a7bfaba16c9fc03e150"
+
+func TestOutputTransactions(t *testing.T) {
+	tests := []struct {
+		name         string
+		indent       string
+		transactions []bellatrix.Transaction
+		res          string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:   "Blob",
+			indent: "  ",
+			transactions: []bellatrix.Transaction{
+				testutil.HexToBytes(blobTransaction),
+			},
+			res: `    Transaction 0: 0xde2c2400973750ddfde03c81f8acf4b59f7a6374a23aa3a8fb098d0212312901
+      Type: blob (3)
+      From: 0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf
+      To: 0x3535353535353535353535353535353535353535
+      Value: 1 Ether
+      Gas: 21000
+      Blob versioned hashes:
+        0x01000000000000000000000000000000000000000000000000000000000000aa
+        0x01000000000000000000000000000000000000000000000000000000000000bb
+`,
+		},
+		{
+			name: "Invalid",
+			transactions: []bellatrix.Transaction{
+				{0x7f, 0xc0},
+			},
+			res: "  Transaction 0: failed to decode: unsupported transaction type 127\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, outputTransactions(test.indent, test.transactions))
+		})
+	}
+}
+
+func TestAddDecodedTransactions(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		transactions []bellatrix.Transaction
+		err          string
+		decoded      int
+	}{
+		{
+			name: "BadJSON",
+			data: "[",
+			err:  "failed to parse block JSON: unexpected end of JSON input",
+		},
+		{
+			name: "Empty",
+			data: `{"message":{},"signature":"0x"}`,
+		},
+		{
+			name: "Transactions",
+			data: `{"message":{},"signature":"0x"}`,
+			transactions: []bellatrix.Transaction{
+				testutil.HexToBytes(blobTransaction),
+				{0x7f, 0xc0},
+			},
+			decoded: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := addDecodedTransactions([]byte(test.data), test.transactions)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			block := make(map[string]json.RawMessage)
+			require.NoError(t, json.Unmarshal(res, &block))
+			require.Contains(t, block, "message")
+			decoded := make([]map[string]interface{}, 0)
+			require.NoError(t, json.Unmarshal(block["decoded_transactions"], &decoded))
+			require.Len(t, decoded, test.decoded)
+			if test.decoded > 0 {
+				require.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", decoded[0]["from"])
+				require.Equal(t, "unsupported transaction type 127", decoded[1]["error"])
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// voteCorrectness is the correctness of a single vote in an attestation.
+type voteCorrectness int
+
+const (
+	voteUnknown voteCorrectness = iota
+	voteCorrect
+	voteIncorrect
+)
+
+func (v voteCorrectness) String() string {
+	switch v {
+	case voteCorrect:
+		return "correct"
+	case voteIncorrect:
+		return "incorrect"
+	default:
+		return "unknown"
+	}
+}
+
+// voteChecker checks the correctness of attestation votes against the canonical chain.
+type voteChecker struct {
+	slotsPerEpoch uint64
+	epoch         phase0.Epoch
+	headerCache   *util.BeaconBlockHeaderCache
+	finality      *apiv1.Finality
+}
+
+func newVoteChecker(ctx context.Context,
+	eth2Client eth2client.Service,
+	slotsPerEpoch uint64,
+	slot phase0.Slot,
+	stateRoot phase0.Root,
+) *voteChecker {
+	checker := &voteChecker{
+		slotsPerEpoch: slotsPerEpoch,
+		epoch:         phase0.Epoch(uint64(slot) / slotsPerEpoch),
+	}
+
+	if provider, isProvider := eth2Client.(eth2client.BeaconBlockHeadersProvider); isProvider {
+		checker.headerCache = util.NewBeaconBlockHeaderCache(provider)
+	}
+
+	// The justified checkpoints do not change when a block is processed, so
+	// the block's post-state provides those used to validate its attestations.
+	if provider, isProvider := eth2Client.(eth2client.FinalityProvider); isProvider {
+		finalityResponse, err := provider.Finality(ctx, &api.FinalityOpts{State: fmt.Sprintf("%#x", stateRoot)})
+		if err == nil {
+			checker.finality = finalityResponse.Data
+		}
+	}
+
+	return checker
+}
+
+// sourceCorrect returns the correctness of the source vote of an attestation.
+func (v *voteChecker) sourceCorrect(_ context.Context, data *phase0.AttestationData) voteCorrectness {
+	if v.finality == nil {
+		return voteUnknown
+	}
+
+	// Attestations for the current epoch use the current justified checkpoint,
+	// those for the previous epoch use the previous justified checkpoint.
+	checkpoint := v.finality.PreviousJustified
+	if data.Target.Epoch == v.epoch {
+		checkpoint = v.finality.Justified
+	}
+	if checkpoint == nil {
+		return voteUnknown
+	}
+	if data.Source.Epoch == checkpoint.Epoch && data.Source.Root == checkpoint.Root {
+		return voteCorrect
+	}
+
+	return voteIncorrect
+}
+
+// targetCorrect returns the correctness of the target vote of an attestation.
+func (v *voteChecker) targetCorrect(ctx context.Context, data *phase0.AttestationData) voteCorrectness {
+	root, found := v.canonicalRoot(ctx, phase0.Slot(uint64(data.Target.Epoch)*v.slotsPerEpoch))
+	if !found {
+		return voteUnknown
+	}
+	if root == data.Target.Root {
+		return voteCorrect
+	}
+
+	return voteIncorrect
+}
+
+// headCorrect returns the correctness of the head vote of an attestation.
+func (v *voteChecker) headCorrect(ctx context.Context, data *phase0.AttestationData) voteCorrectness {
+	root, found := v.canonicalRoot(ctx, data.Slot)
+	if !found {
+		return voteUnknown
+	}
+	if root == data.BeaconBlockRoot {
+		return voteCorrect
+	}
+
+	return voteIncorrect
+}
+
+// canonicalRoot returns the root of the canonical block at the given slot,
+// or the most recent canonical block prior to it if the slot is empty.
+func (v *voteChecker) canonicalRoot(ctx context.Context, slot phase0.Slot) (phase0.Root, bool) {
+	if v.headerCache == nil {
+		return phase0.Root{}, false
+	}
+
+	for {
+		header, err := v.headerCache.Fetch(ctx, slot)
+		if err != nil {
+			return phase0.Root{}, false
+		}
+		if header != nil && header.Canonical {
+			return header.Root, true
+		}
+		if slot == 0 {
+			return phase0.Root{}, false
+		}
+		slot--
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockinfo
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceCorrect(t *testing.T) {
+	finality := &apiv1.Finality{
+		PreviousJustified: &phase0.Checkpoint{
+			Epoch: 9,
+			Root:  phase0.Root{0x09},
+		},
+		Justified: &phase0.Checkpoint{
+			Epoch: 10,
+			Root:  phase0.Root{0x0a},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		finality *apiv1.Finality
+		data     *phase0.AttestationData
+		res      voteCorrectness
+	}{
+		{
+			name: "NoFinality",
+			data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x0a}},
+				Target: &phase0.Checkpoint{Epoch: 11},
+			},
+			res: voteUnknown,
+		},
+		{
+			name:     "CurrentCorrect",
+			finality: finality,
+			data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 10, Root: phase0.Root{0x0a}},
+				Target: &phase0.Checkpoint{Epoch: 11},
+			},
+			res: voteCorrect,
+		},
+		{
+			name:     "CurrentIncorrect",
+			finality: finality,
+			data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}},
+				Target: &phase0.Checkpoint{Epoch: 11},
+			},
+			res: voteIncorrect,
+		},
+		{
+			name:     "PreviousCorrect",
+			finality: finality,
+			data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x09}},
+				Target: &phase0.Checkpoint{Epoch: 10},
+			},
+			res: voteCorrect,
+		},
+		{
+			name:     "PreviousWrongRoot",
+			finality: finality,
+			data: &phase0.AttestationData{
+				Source: &phase0.Checkpoint{Epoch: 9, Root: phase0.Root{0x01}},
+				Target: &phase0.Checkpoint{Epoch: 10},
+			},
+			res: voteIncorrect,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checker := &voteChecker{
+				slotsPerEpoch: 32,
+				epoch:         11,
+				finality:      test.finality,
+			}
+			require.Equal(t, test.res, checker.sourceCorrect(context.Background(), test.data))
+		})
+	}
+}
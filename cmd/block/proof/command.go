@@ -0,0 +1,100 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproof
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	slot   phase0.Slot
+	state  string
+	anchor string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client          eth2client.Service
+	specProvider        eth2client.SpecProvider
+	beaconStateProvider eth2client.BeaconStateProvider
+
+	// Output.
+	proof *util.BlockRootProof
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		state:   viper.GetString("state"),
+		anchor:  viper.GetString("anchor"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if viper.GetString("slot") == "" {
+		return nil, errors.New("slot is required")
+	}
+	slot, err := strconv.ParseUint(viper.GetString("slot"), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slot")
+	}
+	c.slot = phase0.Slot(slot)
+
+	if c.state == "" {
+		c.state = "head"
+	}
+
+	switch c.anchor {
+	case "":
+		c.anchor = util.BlockRootProofAnchorState
+	case util.BlockRootProofAnchorState, util.BlockRootProofAnchorBlock:
+	default:
+		return nil, fmt.Errorf("anchor must be %q or %q", util.BlockRootProofAnchorState, util.BlockRootProofAnchorBlock)
+	}
+
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproof
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the proof as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.proof)
+}
+
+// RenderText renders the proof as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.proof.Slot))
+	builder.WriteString(fmt.Sprintf("Block root: %#x\n", c.proof.BlockRoot))
+	builder.WriteString(fmt.Sprintf("State slot: %d\n", c.proof.StateSlot))
+	builder.WriteString(fmt.Sprintf("Source: %s\n", c.proof.Source))
+	builder.WriteString(fmt.Sprintf("%s root: %#x\n", strings.ToUpper(c.proof.Anchor[:1])+c.proof.Anchor[1:], c.proof.Root))
+	builder.WriteString(fmt.Sprintf("Generalized index: %d\n", c.proof.GeneralizedIndex))
+	builder.WriteString("Branch:\n")
+	for _, root := range c.proof.Branch {
+		builder.WriteString(fmt.Sprintf("  %#x\n", root))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,427 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproof
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Indices of fields in the beacon state and related containers.
+const (
+	blockRootsFieldIndex          = 5
+	historicalSummariesFieldIndex = 27
+	blockSummaryRootFieldIndex    = 0
+	headerStateRootFieldIndex     = 3
+)
+
+// stateSummary is the information from a state used to generate proofs.
+type stateSummary struct {
+	slot                phase0.Slot
+	blockRoots          []phase0.Root
+	historicalSummaries []*capella.HistoricalSummary
+	latestBlockHeader   *phase0.BeaconBlockHeader
+	container           ssz.HashRoot
+}
+
+// parameters are the chain parameters used to locate historical roots.
+type parameters struct {
+	slotsPerHistoricalRoot uint64
+	historicalRootsLimit   uint64
+	capellaForkSlot        phase0.Slot
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	params, err := c.obtainParameters(ctx)
+	if err != nil {
+		return err
+	}
+
+	state, err := c.fetchState(ctx, c.state)
+	if err != nil {
+		return err
+	}
+
+	var historicalBlockRoots []phase0.Root
+	if useHistoricalSummaries(c.slot, state.slot, params) {
+		// The block roots for the slot's period are in the state at the end of the period.
+		periodEnd := (uint64(c.slot)/params.slotsPerHistoricalRoot + 1) * params.slotsPerHistoricalRoot
+		historicalState, err := c.fetchState(ctx, fmt.Sprintf("%d", periodEnd))
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain historical block roots (an archive node may be required)")
+		}
+		historicalBlockRoots = historicalState.blockRoots
+	}
+
+	c.proof, err = generateProof(c.slot, state, historicalBlockRoots, c.anchor, params)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// useHistoricalSummaries returns true if the block root for the slot is no
+// longer in the state's block roots vector.
+func useHistoricalSummaries(slot phase0.Slot, stateSlot phase0.Slot, params *parameters) bool {
+	return uint64(stateSlot) > uint64(slot)+params.slotsPerHistoricalRoot
+}
+
+// generateProof generates the proof of the block root at the slot against the state.
+func generateProof(slot phase0.Slot,
+	state *stateSummary,
+	historicalBlockRoots []phase0.Root,
+	anchor string,
+	params *parameters,
+) (
+	*util.BlockRootProof,
+	error,
+) {
+	if slot >= state.slot {
+		return nil, fmt.Errorf("slot %d is not before state slot %d", slot, state.slot)
+	}
+
+	fieldRoots, err := util.ContainerFieldRoots(state.container)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain state field roots")
+	}
+	stateDepth := util.MerkleDepth(uint64(len(fieldRoots)))
+	stateRoot, err := util.MerkleRoot(fieldRoots, stateDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &util.BlockRootProof{
+		Slot:      slot,
+		StateSlot: state.slot,
+		Anchor:    anchor,
+	}
+	vectorDepth := util.MerkleDepth(params.slotsPerHistoricalRoot)
+	vectorIndex := uint64(slot) % params.slotsPerHistoricalRoot
+
+	var fieldIndex uint64
+	var gindex uint64
+	if useHistoricalSummaries(slot, state.slot, params) {
+		proof.Source = util.BlockRootProofSourceHistoricalSummaries
+		fieldIndex = historicalSummariesFieldIndex
+		gindex, err = historicalSummaryBranch(proof, slot, state, historicalBlockRoots, vectorDepth, vectorIndex, params)
+	} else {
+		proof.Source = util.BlockRootProofSourceBlockRoots
+		fieldIndex = blockRootsFieldIndex
+		gindex, err = blockRootsBranch(proof, state.blockRoots, vectorDepth, vectorIndex)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fieldIndex >= uint64(len(fieldRoots)) {
+		return nil, fmt.Errorf("state at slot %d does not have field %d", state.slot, fieldIndex)
+	}
+
+	// Link the field to the state root.
+	stateBranch, err := util.MerkleBranch(fieldRoots, stateDepth, fieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	proof.Branch = append(proof.Branch, stateBranch...)
+	if gindex, err = util.ConcatGeneralizedIndices(1<<stateDepth|fieldIndex, gindex); err != nil {
+		return nil, err
+	}
+	proof.Root = stateRoot
+
+	if anchor == util.BlockRootProofAnchorBlock {
+		if gindex, err = anchorToBlock(proof, state, stateRoot, gindex); err != nil {
+			return nil, err
+		}
+	}
+	proof.GeneralizedIndex = gindex
+
+	if !proof.Verify() {
+		return nil, errors.New("generated proof does not verify")
+	}
+
+	return proof, nil
+}
+
+// blockRootsBranch adds the branch of the block root within the block roots
+// vector to the proof, returning its generalized index within the vector.
+func blockRootsBranch(proof *util.BlockRootProof,
+	blockRoots []phase0.Root,
+	vectorDepth int,
+	vectorIndex uint64,
+) (
+	uint64,
+	error,
+) {
+	if vectorIndex >= uint64(len(blockRoots)) {
+		return 0, errors.New("state does not contain block roots")
+	}
+	branch, err := util.MerkleBranch(blockRoots, vectorDepth, vectorIndex)
+	if err != nil {
+		return 0, err
+	}
+	proof.BlockRoot = blockRoots[vectorIndex]
+	proof.Branch = branch
+
+	return 1<<vectorDepth | vectorIndex, nil
+}
+
+// historicalSummaryBranch adds the branch of the block root through the
+// historical summaries list to the proof, returning its generalized index
+// within the list.
+func historicalSummaryBranch(proof *util.BlockRootProof,
+	slot phase0.Slot,
+	state *stateSummary,
+	historicalBlockRoots []phase0.Root,
+	vectorDepth int,
+	vectorIndex uint64,
+	params *parameters,
+) (
+	uint64,
+	error,
+) {
+	if state.historicalSummaries == nil {
+		return 0, fmt.Errorf("state at slot %d does not have historical summaries", state.slot)
+	}
+	period := uint64(slot) / params.slotsPerHistoricalRoot
+	capellaPeriod := uint64(params.capellaForkSlot) / params.slotsPerHistoricalRoot
+	if period < capellaPeriod {
+		return 0, fmt.Errorf("slot %d is before Capella so is not covered by historical summaries", slot)
+	}
+	summaryIndex := period - capellaPeriod
+	if summaryIndex >= uint64(len(state.historicalSummaries)) {
+		return 0, fmt.Errorf("state at slot %d does not yet have a historical summary for slot %d", state.slot, slot)
+	}
+	summary := state.historicalSummaries[summaryIndex]
+
+	// Block root within the historical block roots.
+	blockSummaryRoot, err := util.MerkleRoot(historicalBlockRoots, vectorDepth)
+	if err != nil {
+		return 0, err
+	}
+	if blockSummaryRoot != summary.BlockSummaryRoot {
+		return 0, fmt.Errorf("historical block roots do not match summary root %#x", summary.BlockSummaryRoot)
+	}
+	gindex, err := blockRootsBranch(proof, historicalBlockRoots, vectorDepth, vectorIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	// Block summary root within the summary.
+	proof.Branch = append(proof.Branch, summary.StateSummaryRoot)
+	if gindex, err = util.ConcatGeneralizedIndices(2|blockSummaryRootFieldIndex, gindex); err != nil {
+		return 0, err
+	}
+
+	// Summary within the list, and the list's length.
+	summaryRoots := make([]phase0.Root, len(state.historicalSummaries))
+	for i, historicalSummary := range state.historicalSummaries {
+		summaryRoots[i] = sha256.Sum256(append(historicalSummary.BlockSummaryRoot[:], historicalSummary.StateSummaryRoot[:]...))
+	}
+	listDepth := util.MerkleDepth(params.historicalRootsLimit)
+	listBranch, err := util.MerkleBranch(summaryRoots, listDepth, summaryIndex)
+	if err != nil {
+		return 0, err
+	}
+	proof.Branch = append(proof.Branch, listBranch...)
+	if gindex, err = util.ConcatGeneralizedIndices(1<<listDepth|summaryIndex, gindex); err != nil {
+		return 0, err
+	}
+	lengthRoot := phase0.Root{}
+	ssz.MarshalUint64(lengthRoot[:0], uint64(len(state.historicalSummaries)))
+	proof.Branch = append(proof.Branch, lengthRoot)
+
+	// The list data is the left child of the list root.
+	return util.ConcatGeneralizedIndices(2, gindex)
+}
+
+// anchorToBlock extends the proof from the state root to the root of the
+// block that produced the state, returning the new generalized index.
+func anchorToBlock(proof *util.BlockRootProof,
+	state *stateSummary,
+	stateRoot phase0.Root,
+	gindex uint64,
+) (
+	uint64,
+	error,
+) {
+	if state.latestBlockHeader == nil || state.latestBlockHeader.Slot != state.slot {
+		return 0, fmt.Errorf("no block at state slot %d to anchor the proof", state.slot)
+	}
+
+	// The state root in the latest block header is filled in at the next slot.
+	header := *state.latestBlockHeader
+	header.StateRoot = stateRoot
+	headerRoots, err := util.ContainerFieldRoots(&header)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain block header field roots")
+	}
+	headerDepth := util.MerkleDepth(uint64(len(headerRoots)))
+	headerBranch, err := util.MerkleBranch(headerRoots, headerDepth, headerStateRootFieldIndex)
+	if err != nil {
+		return 0, err
+	}
+	proof.Branch = append(proof.Branch, headerBranch...)
+	if proof.Root, err = header.HashTreeRoot(); err != nil {
+		return 0, errors.Wrap(err, "failed to obtain block root")
+	}
+
+	return util.ConcatGeneralizedIndices(1<<headerDepth|headerStateRootFieldIndex, gindex)
+}
+
+// fetchState fetches a state and obtains the information required for proofs.
+func (c *command) fetchState(ctx context.Context, stateID string) (*stateSummary, error) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching state %s\n", stateID)
+	}
+	stateResponse, err := c.beaconStateProvider.BeaconState(ctx, &api.BeaconStateOpts{State: stateID})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain state %s", stateID))
+	}
+	state := stateResponse.Data
+	if state == nil || state.IsEmpty() {
+		return nil, fmt.Errorf("no state %s", stateID)
+	}
+
+	return summarizeState(state)
+}
+
+// summarizeState obtains the parts of a versioned state used for proofs.
+func summarizeState(state *spec.VersionedBeaconState) (*stateSummary, error) {
+	summary := &stateSummary{}
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		s := state.Phase0
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionAltair:
+		s := state.Altair
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionBellatrix:
+		s := state.Bellatrix
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionCapella:
+		s := state.Capella
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionDeneb:
+		s := state.Deneb
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionElectra:
+		s := state.Electra
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	case spec.DataVersionFulu:
+		s := state.Fulu
+		summary.slot = s.Slot
+		summary.blockRoots = s.BlockRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.latestBlockHeader = s.LatestBlockHeader
+		summary.container = s
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+
+	return summary, nil
+}
+
+func (c *command) obtainParameters(ctx context.Context) (*parameters, error) {
+	specDataResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+
+	params := &parameters{
+		slotsPerHistoricalRoot: 8192,
+		historicalRootsLimit:   16777216,
+	}
+	if val, exists := specData["SLOTS_PER_HISTORICAL_ROOT"].(uint64); exists {
+		params.slotsPerHistoricalRoot = val
+	}
+	if val, exists := specData["HISTORICAL_ROOTS_LIMIT"].(uint64); exists {
+		params.historicalRootsLimit = val
+	}
+	slotsPerEpoch, exists := specData["SLOTS_PER_EPOCH"].(uint64)
+	if !exists {
+		return nil, errors.New("SLOTS_PER_EPOCH not found in spec")
+	}
+	capellaForkEpoch, exists := specData["CAPELLA_FORK_EPOCH"].(uint64)
+	if !exists {
+		return nil, errors.New("CAPELLA_FORK_EPOCH not found in spec")
+	}
+	params.capellaForkSlot = phase0.Slot(capellaForkEpoch * slotsPerEpoch)
+
+	return params, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec")
+	}
+	c.beaconStateProvider, isProvider = c.eth2Client.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon state")
+	}
+
+	return nil
+}
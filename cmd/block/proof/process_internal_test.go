@@ -0,0 +1,291 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproof
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testRoots returns distinct roots for a historical period.
+func testRoots(period uint64) []phase0.Root {
+	res := make([]phase0.Root, 8192)
+	for i := range res {
+		data := make([]byte, 16)
+		binary.LittleEndian.PutUint64(data, period)
+		binary.LittleEndian.PutUint64(data[8:], uint64(i))
+		res[i] = sha256.Sum256(data)
+	}
+
+	return res
+}
+
+// testState returns a Deneb state at the given slot with historical
+// summaries for the complete periods before it.
+func testState(t *testing.T, slot phase0.Slot) (*deneb.BeaconState, *stateSummary) {
+	t.Helper()
+
+	syncCommittee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 512),
+	}
+	state := &deneb.BeaconState{
+		Slot:              slot,
+		Fork:              &phase0.Fork{},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{Slot: slot, ParentRoot: phase0.Root{0x01}, BodyRoot: phase0.Root{0x02}},
+		BlockRoots:        testRoots(uint64(slot) / 8192),
+		StateRoots:        testRoots(1000),
+		ETH1Data:          &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		Validators: []*phase0.Validator{
+			{WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 32000000000},
+		},
+		Balances:                     []phase0.Gwei{32000000000},
+		RANDAOMixes:                  make([]phase0.Root, 65536),
+		Slashings:                    make([]phase0.Gwei, 8192),
+		PreviousEpochParticipation:   []altair.ParticipationFlags{7},
+		CurrentEpochParticipation:    []altair.ParticipationFlags{3},
+		JustificationBits:            bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint:  &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:   &phase0.Checkpoint{},
+		FinalizedCheckpoint:          &phase0.Checkpoint{},
+		InactivityScores:             []uint64{0},
+		CurrentSyncCommittee:         syncCommittee,
+		NextSyncCommittee:            syncCommittee,
+		LatestExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{BaseFeePerGas: uint256.NewInt(7)},
+	}
+	for period := uint64(0); period < uint64(slot)/8192; period++ {
+		blockSummaryRoot, err := util.MerkleRoot(testRoots(period), 13)
+		require.NoError(t, err)
+		state.HistoricalSummaries = append(state.HistoricalSummaries, &capella.HistoricalSummary{
+			BlockSummaryRoot: blockSummaryRoot,
+			StateSummaryRoot: phase0.Root{byte(period)},
+		})
+	}
+
+	return state, &stateSummary{
+		slot:                state.Slot,
+		blockRoots:          state.BlockRoots,
+		historicalSummaries: state.HistoricalSummaries,
+		latestBlockHeader:   state.LatestBlockHeader,
+		container:           state,
+	}
+}
+
+func TestGenerateProof(t *testing.T) {
+	params := &parameters{
+		slotsPerHistoricalRoot: 8192,
+		historicalRootsLimit:   16777216,
+	}
+	stateSlot := phase0.Slot(3*8192 + 100)
+	state, summary := testState(t, stateSlot)
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	header := *state.LatestBlockHeader
+	header.StateRoot = stateRoot
+	blockRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		slot                 phase0.Slot
+		historicalBlockRoots []phase0.Root
+		anchor               string
+		params               *parameters
+		source               string
+		root                 phase0.Root
+		blockRoot            phase0.Root
+		gindex               uint64
+		branchLen            int
+		err                  string
+	}{
+		{
+			name:   "FutureSlot",
+			slot:   stateSlot,
+			anchor: util.BlockRootProofAnchorState,
+			params: params,
+			err:    "slot 24676 is not before state slot 24676",
+		},
+		{
+			name:      "Recent",
+			slot:      stateSlot - 10,
+			anchor:    util.BlockRootProofAnchorState,
+			params:    params,
+			source:    util.BlockRootProofSourceBlockRoots,
+			root:      stateRoot,
+			blockRoot: testRoots(3)[90],
+			gindex:    (32+5)*8192 + 90,
+			branchLen: 18,
+		},
+		{
+			name:      "OldestInBlockRoots",
+			slot:      stateSlot - 8192,
+			anchor:    util.BlockRootProofAnchorState,
+			params:    params,
+			source:    util.BlockRootProofSourceBlockRoots,
+			root:      stateRoot,
+			blockRoot: testRoots(3)[100],
+			gindex:    (32+5)*8192 + 100,
+			branchLen: 18,
+		},
+		{
+			name:                 "Historical",
+			slot:                 8192 + 5,
+			historicalBlockRoots: testRoots(1),
+			anchor:               util.BlockRootProofAnchorState,
+			params:               params,
+			source:               util.BlockRootProofSourceHistoricalSummaries,
+			root:                 stateRoot,
+			blockRoot:            testRoots(1)[5],
+			gindex:               (((32+27)*2*16777216+1)*2)*8192 + 5,
+			branchLen:            44,
+		},
+		{
+			name:                 "HistoricalMismatch",
+			slot:                 8192 + 5,
+			historicalBlockRoots: testRoots(2),
+			anchor:               util.BlockRootProofAnchorState,
+			params:               params,
+			err:                  "historical block roots do not match summary root",
+		},
+		{
+			name:                 "HistoricalBeforeCapella",
+			slot:                 8192 + 5,
+			historicalBlockRoots: testRoots(1),
+			anchor:               util.BlockRootProofAnchorState,
+			params: &parameters{
+				slotsPerHistoricalRoot: 8192,
+				historicalRootsLimit:   16777216,
+				capellaForkSlot:        2 * 8192,
+			},
+			err: "slot 8197 is before Capella so is not covered by historical summaries",
+		},
+		{
+			name:      "BlockAnchor",
+			slot:      stateSlot - 10,
+			anchor:    util.BlockRootProofAnchorBlock,
+			params:    params,
+			source:    util.BlockRootProofSourceBlockRoots,
+			root:      blockRoot,
+			blockRoot: testRoots(3)[90],
+			gindex:    ((8+3)*32+5)*8192 + 90,
+			branchLen: 21,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := generateProof(test.slot, summary, test.historicalBlockRoots, test.anchor, test.params)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.slot, proof.Slot)
+			require.Equal(t, stateSlot, proof.StateSlot)
+			require.Equal(t, test.source, proof.Source)
+			require.Equal(t, test.anchor, proof.Anchor)
+			require.Equal(t, test.root, proof.Root)
+			require.Equal(t, test.blockRoot, proof.BlockRoot)
+			require.Equal(t, test.gindex, proof.GeneralizedIndex)
+			require.Len(t, proof.Branch, test.branchLen)
+			require.True(t, proof.Verify())
+		})
+	}
+}
+
+func TestGenerateProofNoBlock(t *testing.T) {
+	params := &parameters{
+		slotsPerHistoricalRoot: 8192,
+		historicalRootsLimit:   16777216,
+	}
+	_, summary := testState(t, 3*8192+100)
+	summary.latestBlockHeader = &phase0.BeaconBlockHeader{Slot: 3*8192 + 99}
+
+	_, err := generateProof(3*8192+90, summary, nil, util.BlockRootProofAnchorBlock, params)
+	require.EqualError(t, err, "no block at state slot 24676 to anchor the proof")
+}
+
+func TestUseHistoricalSummaries(t *testing.T) {
+	params := &parameters{
+		slotsPerHistoricalRoot: 8192,
+	}
+	require.False(t, useHistoricalSummaries(10000, 10001, params))
+	require.False(t, useHistoricalSummaries(10000, 18192, params))
+	require.True(t, useHistoricalSummaries(10000, 18193, params))
+}
+
+func TestGenerateProofElectra(t *testing.T) {
+	params := &parameters{
+		slotsPerHistoricalRoot: 8192,
+		historicalRootsLimit:   16777216,
+	}
+	stateSlot := phase0.Slot(3*8192 + 100)
+	denebState, _ := testState(t, stateSlot)
+	state := &electra.BeaconState{
+		Slot:                         denebState.Slot,
+		Fork:                         denebState.Fork,
+		LatestBlockHeader:            denebState.LatestBlockHeader,
+		BlockRoots:                   denebState.BlockRoots,
+		StateRoots:                   denebState.StateRoots,
+		ETH1Data:                     denebState.ETH1Data,
+		Validators:                   denebState.Validators,
+		Balances:                     denebState.Balances,
+		RANDAOMixes:                  denebState.RANDAOMixes,
+		Slashings:                    denebState.Slashings,
+		PreviousEpochParticipation:   denebState.PreviousEpochParticipation,
+		CurrentEpochParticipation:    denebState.CurrentEpochParticipation,
+		JustificationBits:            denebState.JustificationBits,
+		PreviousJustifiedCheckpoint:  denebState.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:   denebState.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:          denebState.FinalizedCheckpoint,
+		InactivityScores:             denebState.InactivityScores,
+		CurrentSyncCommittee:         denebState.CurrentSyncCommittee,
+		NextSyncCommittee:            denebState.NextSyncCommittee,
+		LatestExecutionPayloadHeader: denebState.LatestExecutionPayloadHeader,
+		HistoricalSummaries:          denebState.HistoricalSummaries,
+	}
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+
+	summary, err := summarizeState(&spec.VersionedBeaconState{
+		Version: spec.DataVersionElectra,
+		Electra: state,
+	})
+	require.NoError(t, err)
+
+	proof, err := generateProof(stateSlot-10, summary, nil, util.BlockRootProofAnchorState, params)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(stateRoot), proof.Root)
+	require.Equal(t, testRoots(3)[90], proof.BlockRoot)
+	// The Electra state has more than 32 fields, so is one level deeper.
+	require.Equal(t, uint64((64+5)*8192+90), proof.GeneralizedIndex)
+	require.Len(t, proof.Branch, 19)
+	require.True(t, proof.Verify())
+
+	proof, err = generateProof(8192+5, summary, testRoots(1), util.BlockRootProofAnchorState, params)
+	require.NoError(t, err)
+	require.Equal(t, util.BlockRootProofSourceHistoricalSummaries, proof.Source)
+	require.True(t, proof.Verify())
+}
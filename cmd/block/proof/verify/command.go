@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproofverify
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	proofInput string
+	root       *phase0.Root
+	blockRoot  *phase0.Root
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Valid  bool     `json:"valid"`
+	Checks []*check `json:"checks"`
+}
+
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		proofInput: viper.GetString("proof"),
+	}
+
+	if c.proofInput == "" {
+		return nil, errors.New("proof is required")
+	}
+
+	var err error
+	if viper.GetString("root") != "" {
+		if c.root, err = parseRoot(viper.GetString("root")); err != nil {
+			return nil, errors.Wrap(err, "invalid root")
+		}
+	}
+	if viper.GetString("block-root") != "" {
+		if c.blockRoot, err = parseRoot(viper.GetString("block-root")); err != nil {
+			return nil, errors.Wrap(err, "invalid block root")
+		}
+	}
+
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseRoot(input string) (*phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != len(phase0.Root{}) {
+		return nil, errors.New("root must be 32 bytes")
+	}
+	root := phase0.Root(data)
+
+	return &root, nil
+}
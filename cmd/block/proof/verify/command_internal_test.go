@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproofverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "ProofMissing",
+			vars: map[string]interface{}{},
+			err:  "proof is required",
+		},
+		{
+			name: "RootInvalid",
+			vars: map[string]interface{}{
+				"proof": "proof.json",
+				"root":  "0x01",
+			},
+			err: "invalid root: root must be 32 bytes",
+		},
+		{
+			name: "BlockRootInvalid",
+			vars: map[string]interface{}{
+				"proof":      "proof.json",
+				"block-root": "invalid",
+			},
+			err: "invalid block root: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"proof":      "proof.json",
+				"root":       "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"block-root": "0x0000000000000000000000000000000000000000000000000000000000000002",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproofverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the checks as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the checks as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, check := range c.results.Checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("[%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+	if c.results.Valid {
+		builder.WriteString("Result: proof is valid\n")
+	} else {
+		builder.WriteString("Result: proof is not valid\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproofverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// slotsPerHistoricalRoot is the number of block roots in a block roots
+// vector, as per the mainnet preset used by all public networks.
+const slotsPerHistoricalRoot = 8192
+
+func (c *command) process(_ context.Context) error {
+	if !strings.HasPrefix(c.proofInput, "{") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(c.proofInput)
+		if err != nil {
+			return errors.Wrap(err, "failed to read proof file")
+		}
+		c.proofInput = string(data)
+	}
+
+	proof := &util.BlockRootProof{}
+	if err := json.Unmarshal([]byte(c.proofInput), proof); err != nil {
+		return errors.Wrap(err, "failed to parse proof")
+	}
+
+	c.results = &results{
+		Checks: []*check{
+			branchCheck(proof),
+			slotCheck(proof),
+		},
+	}
+	if c.blockRoot != nil {
+		c.results.Checks = append(c.results.Checks, &check{
+			Name:   "block root",
+			Passed: proof.BlockRoot == *c.blockRoot,
+			Detail: fmt.Sprintf("proof is for block root %#x, expected %#x", proof.BlockRoot, *c.blockRoot),
+		})
+	}
+	if c.root != nil {
+		c.results.Checks = append(c.results.Checks, &check{
+			Name:   fmt.Sprintf("%s root", proof.Anchor),
+			Passed: proof.Root == *c.root,
+			Detail: fmt.Sprintf("proof is against root %#x, expected %#x", proof.Root, *c.root),
+		})
+	}
+
+	c.results.Valid = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Valid = false
+		}
+	}
+
+	return nil
+}
+
+// branchCheck checks that the branch links the block root to the root.
+func branchCheck(proof *util.BlockRootProof) *check {
+	res := &check{
+		Name:   "branch",
+		Passed: proof.Verify(),
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("block root %#x is at generalized index %d of %s root %#x", proof.BlockRoot, proof.GeneralizedIndex, proof.Anchor, proof.Root)
+	} else {
+		res.Detail = fmt.Sprintf("branch does not link block root to %s root", proof.Anchor)
+	}
+
+	return res
+}
+
+// slotCheck checks that the position of the block root in the generalized
+// index matches the slot of the proof.
+func slotCheck(proof *util.BlockRootProof) *check {
+	res := &check{
+		Name: "slot",
+	}
+	if proof.Slot >= proof.StateSlot {
+		res.Detail = fmt.Sprintf("slot %d is not before state slot %d", proof.Slot, proof.StateSlot)
+		return res
+	}
+	position := proof.GeneralizedIndex % slotsPerHistoricalRoot
+	res.Passed = position == uint64(proof.Slot)%slotsPerHistoricalRoot
+	if res.Passed {
+		res.Detail = fmt.Sprintf("generalized index is for slot %d", proof.Slot)
+	} else {
+		res.Detail = fmt.Sprintf("generalized index is for position %d of its block roots, slot %d is at position %d", position, proof.Slot, uint64(proof.Slot)%slotsPerHistoricalRoot)
+	}
+
+	return res
+}
@@ -0,0 +1,155 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockproofverify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testProof generates a proof of a block root within a block roots vector.
+func testProof(t *testing.T, slot phase0.Slot) *util.BlockRootProof {
+	t.Helper()
+
+	blockRoots := make([]phase0.Root, slotsPerHistoricalRoot)
+	for i := range blockRoots {
+		blockRoots[i] = sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+	}
+	index := uint64(slot) % slotsPerHistoricalRoot
+	branch, err := util.MerkleBranch(blockRoots, 13, index)
+	require.NoError(t, err)
+	root, err := util.MerkleRoot(blockRoots, 13)
+	require.NoError(t, err)
+
+	return &util.BlockRootProof{
+		Slot:             slot,
+		BlockRoot:        blockRoots[index],
+		StateSlot:        slot + 100,
+		Source:           util.BlockRootProofSourceBlockRoots,
+		Anchor:           util.BlockRootProofAnchorState,
+		Root:             root,
+		GeneralizedIndex: slotsPerHistoricalRoot | index,
+		Branch:           branch,
+	}
+}
+
+func TestProcess(t *testing.T) {
+	proof := testProof(t, 8192+5)
+	proofJSON, err := json.Marshal(proof)
+	require.NoError(t, err)
+	proofFile := filepath.Join(t.TempDir(), "proof.json")
+	require.NoError(t, os.WriteFile(proofFile, proofJSON, 0o600))
+
+	badBranch := testProof(t, 8192+5)
+	badBranch.Branch[3] = phase0.Root{}
+	badBranchJSON, err := json.Marshal(badBranch)
+	require.NoError(t, err)
+
+	wrongSlot := testProof(t, 8192+5)
+	wrongSlot.Slot = 8192 + 6
+	wrongSlotJSON, err := json.Marshal(wrongSlot)
+	require.NoError(t, err)
+
+	otherRoot := phase0.Root{0x01}
+
+	tests := []struct {
+		name      string
+		input     string
+		root      *phase0.Root
+		blockRoot *phase0.Root
+		valid     bool
+		failed    []string
+		err       string
+	}{
+		{
+			name:  "MissingFile",
+			input: filepath.Join(t.TempDir(), "missing.json"),
+			err:   "failed to read proof file",
+		},
+		{
+			name:  "InvalidJSON",
+			input: `{"slot":"x"}`,
+			err:   "failed to parse proof: invalid slot",
+		},
+		{
+			name:  "File",
+			input: proofFile,
+			valid: true,
+		},
+		{
+			name:      "JSON",
+			input:     string(proofJSON),
+			root:      &proof.Root,
+			blockRoot: &proof.BlockRoot,
+			valid:     true,
+		},
+		{
+			name:   "BadBranch",
+			input:  string(badBranchJSON),
+			failed: []string{"branch"},
+		},
+		{
+			name:   "WrongSlot",
+			input:  string(wrongSlotJSON),
+			failed: []string{"slot"},
+		},
+		{
+			name:   "UntrustedRoot",
+			input:  string(proofJSON),
+			root:   &otherRoot,
+			failed: []string{"state root"},
+		},
+		{
+			name:      "WrongBlockRoot",
+			input:     string(proofJSON),
+			blockRoot: &otherRoot,
+			failed:    []string{"block root"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				proofInput: test.input,
+				root:       test.root,
+				blockRoot:  test.blockRoot,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.valid, c.results.Valid)
+			failed := make([]string, 0)
+			for _, check := range c.results.Checks {
+				if !check.Passed {
+					failed = append(failed, check.Name)
+				}
+			}
+			if test.failed == nil {
+				test.failed = []string{}
+			}
+			require.Equal(t, test.failed, failed)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockstats
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	blockID    string
+	jsonOutput bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+	validatorsProvider eth2client.ValidatorsProvider
+	committeeSizes     *util.BeaconCommitteeSizeCache
+
+	// Results.
+	stats *blockStats
+}
+
+type blockStats struct {
+	slot           phase0.Slot
+	root           phase0.Root
+	proposerIndex  phase0.ValidatorIndex
+	proposerPubKey phase0.BLSPubKey
+	graffiti       []byte
+
+	attestations        int
+	committees          int
+	attestingValidators uint64
+	possibleValidators  uint64
+
+	// Sync committee statistics, from Altair onwards.
+	hasSyncAggregate     bool
+	syncCommitteeSigners uint64
+	syncCommitteeSize    uint64
+	syncCommitteeBits    bitfield.Bitvector512
+
+	// Execution statistics, from Bellatrix onwards.
+	hasExecutionPayload bool
+	gasUsed             uint64
+	gasLimit            uint64
+	transactions        int
+
+	// Blob statistics, from Deneb onwards.
+	hasBlobs bool
+	blobs    int
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonOutput struct {
+	Slot                     uint64             `json:"slot"`
+	Root                     string             `json:"root"`
+	ProposerIndex            uint64             `json:"proposer_index"`
+	ProposerPubKey           string             `json:"proposer_pubkey"`
+	Graffiti                 string             `json:"graffiti"`
+	Attestations             int                `json:"attestations"`
+	Committees               int                `json:"committees"`
+	AttestingValidators      uint64             `json:"attesting_validators"`
+	PossibleValidators       uint64             `json:"possible_attesting_validators"`
+	AttestationParticipation float64            `json:"attestation_participation"`
+	SyncCommittee            *syncCommitteeJSON `json:"sync_committee,omitempty"`
+	Execution                *executionJSON     `json:"execution,omitempty"`
+	Blobs                    *int               `json:"blobs,omitempty"`
+}
+
+type syncCommitteeJSON struct {
+	Bits          string  `json:"bits"`
+	Participants  uint64  `json:"participants"`
+	Size          uint64  `json:"size"`
+	Participation float64 `json:"participation"`
+}
+
+type executionJSON struct {
+	GasUsed      uint64  `json:"gas_used"`
+	GasLimit     uint64  `json:"gas_limit"`
+	GasUsage     float64 `json:"gas_usage"`
+	Transactions int     `json:"transactions"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Slot:                     uint64(c.stats.slot),
+		Root:                     fmt.Sprintf("%#x", c.stats.root),
+		ProposerIndex:            uint64(c.stats.proposerIndex),
+		ProposerPubKey:           fmt.Sprintf("%#x", c.stats.proposerPubKey),
+		Graffiti:                 util.DecodeGraffiti(c.stats.graffiti).Text,
+		Attestations:             c.stats.attestations,
+		Committees:               c.stats.committees,
+		AttestingValidators:      c.stats.attestingValidators,
+		PossibleValidators:       c.stats.possibleValidators,
+		AttestationParticipation: percentage(c.stats.attestingValidators, c.stats.possibleValidators),
+	}
+	if c.stats.hasSyncAggregate {
+		output.SyncCommittee = &syncCommitteeJSON{
+			Bits:          fmt.Sprintf("%#x", c.stats.syncCommitteeBits.Bytes()),
+			Participants:  c.stats.syncCommitteeSigners,
+			Size:          c.stats.syncCommitteeSize,
+			Participation: percentage(c.stats.syncCommitteeSigners, c.stats.syncCommitteeSize),
+		}
+	}
+	if c.stats.hasExecutionPayload {
+		output.Execution = &executionJSON{
+			GasUsed:      c.stats.gasUsed,
+			GasLimit:     c.stats.gasLimit,
+			GasUsage:     percentage(c.stats.gasUsed, c.stats.gasLimit),
+			Transactions: c.stats.transactions,
+		}
+	}
+	if c.stats.hasBlobs {
+		blobs := c.stats.blobs
+		output.Blobs = &blobs
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.stats.slot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Root: %#x\n", c.stats.root))
+	}
+	builder.WriteString(fmt.Sprintf("Proposer: %d (%#x)\n", c.stats.proposerIndex, c.stats.proposerPubKey))
+	if graffitiInfo := util.DecodeGraffiti(c.stats.graffiti); graffitiInfo.Encoding != util.GraffitiEncodingEmpty {
+		builder.WriteString(fmt.Sprintf("Graffiti: %s\n", graffitiInfo.Text))
+	}
+
+	builder.WriteString(fmt.Sprintf("Attestations: %d, covering %d committees\n", c.stats.attestations, c.stats.committees))
+	builder.WriteString(fmt.Sprintf("Attestation participation: %d/%d (%.2f%%)\n",
+		c.stats.attestingValidators,
+		c.stats.possibleValidators,
+		percentage(c.stats.attestingValidators, c.stats.possibleValidators),
+	))
+
+	if c.stats.hasSyncAggregate {
+		builder.WriteString(fmt.Sprintf("Sync committee participation: %d/%d (%.2f%%)\n",
+			c.stats.syncCommitteeSigners,
+			c.stats.syncCommitteeSize,
+			percentage(c.stats.syncCommitteeSigners, c.stats.syncCommitteeSize),
+		))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Sync committee bits: %s\n", bitString(c.stats.syncCommitteeBits)))
+		}
+	}
+
+	if c.stats.hasExecutionPayload {
+		builder.WriteString(fmt.Sprintf("Gas used: %d/%d (%.2f%%)\n",
+			c.stats.gasUsed,
+			c.stats.gasLimit,
+			percentage(c.stats.gasUsed, c.stats.gasLimit),
+		))
+		builder.WriteString(fmt.Sprintf("Transactions: %d\n", c.stats.transactions))
+	}
+
+	if c.stats.hasBlobs {
+		builder.WriteString(fmt.Sprintf("Blobs: %d\n", c.stats.blobs))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// percentage returns the percentage of the total represented by the value.
+func percentage(value uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(value) / float64(total)
+}
+
+// bitString returns a string of 0s and 1s representing a bitvector.
+func bitString(bits bitfield.Bitvector512) string {
+	builder := strings.Builder{}
+	for i := uint64(0); i < bits.Len(); i++ {
+		if bits.BitAt(i) {
+			builder.WriteString("1")
+		} else {
+			builder.WriteString("0")
+		}
+	}
+
+	return builder.String()
+}
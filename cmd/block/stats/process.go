@@ -0,0 +1,241 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockstats
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: c.blockID}))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain beacon block")
+	}
+	if block == nil {
+		return errors.New("empty beacon block")
+	}
+
+	c.stats, err = calculateStats(block, func(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (uint64, error) {
+		return c.committeeSizes.Fetch(ctx, slot, committeeIndex)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Resolve the proposer's public key.
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: []phase0.ValidatorIndex{c.stats.proposerIndex}})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer")
+	}
+	validators := validatorsResponse.Data
+	proposer, exists := validators[c.stats.proposerIndex]
+	if !exists || proposer.Validator == nil {
+		return fmt.Errorf("proposer %d not found", c.stats.proposerIndex)
+	}
+	c.stats.proposerPubKey = proposer.Validator.PublicKey
+
+	return nil
+}
+
+// calculateStats calculates the statistics for a block.  committeeSize provides
+// the size of a committee at a given slot.
+func calculateStats(block *spec.VersionedSignedBeaconBlock,
+	committeeSize func(phase0.Slot, phase0.CommitteeIndex) (uint64, error),
+) (
+	*blockStats,
+	error,
+) {
+	stats := &blockStats{}
+
+	var err error
+	stats.slot, err = block.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain slot")
+	}
+	stats.root, err = block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain root")
+	}
+
+	stats.proposerIndex, err = block.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer index")
+	}
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain graffiti")
+	}
+	stats.graffiti = graffiti[:]
+
+	if block.Version >= spec.DataVersionBellatrix {
+		if err := calculateExecutionPayloadStats(stats, block); err != nil {
+			return nil, err
+		}
+	}
+
+	if block.Version >= spec.DataVersionDeneb {
+		commitments, err := block.BlobKZGCommitments()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain blob KZG commitments")
+		}
+		stats.hasBlobs = true
+		stats.blobs = len(commitments)
+	}
+
+	attestations, err := block.Attestations()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attestations")
+	}
+	if err := calculateAttestationStats(stats, attestations, committeeSize); err != nil {
+		return nil, err
+	}
+
+	if block.Version != spec.DataVersionPhase0 {
+		syncAggregate, err := block.SyncAggregate()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain sync aggregate")
+		}
+		calculateSyncCommitteeStats(stats, syncAggregate)
+	}
+
+	return stats, nil
+}
+
+// calculateExecutionPayloadStats calculates the execution payload statistics for a block.
+func calculateExecutionPayloadStats(stats *blockStats, block *spec.VersionedSignedBeaconBlock) error {
+	payload, err := block.ExecutionPayload()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution payload")
+	}
+	stats.hasExecutionPayload = true
+	stats.gasUsed, err = payload.GasUsed()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain gas used")
+	}
+	stats.gasLimit, err = payload.GasLimit()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain gas limit")
+	}
+	transactions, err := payload.Transactions()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain transactions")
+	}
+	stats.transactions = len(transactions)
+
+	return nil
+}
+
+// calculateAttestationStats calculates the attestation statistics for a block.
+// Votes are combined per committee, so a validator whose vote is included in
+// multiple attestations is only counted once.
+func calculateAttestationStats(stats *blockStats,
+	attestations []*spec.VersionedAttestation,
+	committeeSize func(phase0.Slot, phase0.CommitteeIndex) (uint64, error),
+) error {
+	stats.attestations = len(attestations)
+
+	// Map is slot -> committee index -> aggregated votes.
+	votes := make(map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist)
+	for _, attestation := range attestations {
+		data, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
+		attestationVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+			return committeeSize(data.Slot, committeeIndex)
+		})
+		if err != nil {
+			return err
+		}
+		if _, exists := votes[data.Slot]; !exists {
+			votes[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
+		}
+		for committeeIndex, attestationCommitteeVotes := range attestationVotes {
+			if _, exists := votes[data.Slot][committeeIndex]; !exists {
+				votes[data.Slot][committeeIndex] = bitfield.NewBitlist(attestationCommitteeVotes.Len())
+			}
+			committeeVotes := votes[data.Slot][committeeIndex]
+			for i := uint64(0); i < attestationCommitteeVotes.Len() && i < committeeVotes.Len(); i++ {
+				if attestationCommitteeVotes.BitAt(i) {
+					committeeVotes.SetBitAt(i, true)
+				}
+			}
+		}
+	}
+
+	for _, committees := range votes {
+		for _, committeeVotes := range committees {
+			stats.committees++
+			stats.attestingValidators += committeeVotes.Count()
+			stats.possibleValidators += committeeVotes.Len()
+		}
+	}
+
+	return nil
+}
+
+// calculateSyncCommitteeStats calculates the sync committee statistics for a block.
+func calculateSyncCommitteeStats(stats *blockStats, syncAggregate *altair.SyncAggregate) {
+	stats.hasSyncAggregate = true
+	stats.syncCommitteeSize = syncAggregate.SyncCommitteeBits.Len()
+	stats.syncCommitteeSigners = syncAggregate.SyncCommitteeBits.Count()
+	stats.syncCommitteeBits = syncAggregate.SyncCommitteeBits
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	c.committeeSizes = util.NewBeaconCommitteeSizeCache(beaconCommitteesProvider)
+
+	return nil
+}
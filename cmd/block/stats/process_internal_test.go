@@ -0,0 +1,315 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockstats
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+// bitlist creates a bitlist of the given length with the given bits set.
+func bitlist(length uint64, set ...uint64) bitfield.Bitlist {
+	res := bitfield.NewBitlist(length)
+	for _, i := range set {
+		res.SetBitAt(i, true)
+	}
+
+	return res
+}
+
+// attestation creates an attestation for the given committee with the given votes.
+func attestation(slot phase0.Slot, index phase0.CommitteeIndex, bits bitfield.Bitlist) *phase0.Attestation {
+	return &phase0.Attestation{
+		AggregationBits: bits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Index:  index,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	}
+}
+
+// electraAttestation creates an attestation for the given committees with the given votes.
+func electraAttestation(slot phase0.Slot, committeeBits bitfield.Bitvector64, bits bitfield.Bitlist) *spec.VersionedAttestation {
+	return &spec.VersionedAttestation{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.Attestation{
+			AggregationBits: bits,
+			Data: &phase0.AttestationData{
+				Slot:   slot,
+				Source: &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{},
+			},
+			CommitteeBits: committeeBits,
+		},
+	}
+}
+
+// committeeSize provides committees of 8 members.
+func committeeSize(_ phase0.Slot, _ phase0.CommitteeIndex) (uint64, error) {
+	return 8, nil
+}
+
+func TestCalculateAttestationStats(t *testing.T) {
+	tests := []struct {
+		name                string
+		attestations        []*phase0.Attestation
+		electraAttestations []*spec.VersionedAttestation
+		committees          int
+		attesting           uint64
+		possible            uint64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name: "Single",
+			attestations: []*phase0.Attestation{
+				attestation(1, 0, bitlist(8, 0, 1, 2)),
+			},
+			committees: 1,
+			attesting:  3,
+			possible:   8,
+		},
+		{
+			name: "Overlapping",
+			attestations: []*phase0.Attestation{
+				attestation(1, 0, bitlist(8, 0, 1, 2)),
+				attestation(1, 0, bitlist(8, 2, 3)),
+			},
+			committees: 1,
+			attesting:  4,
+			possible:   8,
+		},
+		{
+			name: "MultipleCommittees",
+			attestations: []*phase0.Attestation{
+				attestation(1, 0, bitlist(8, 0, 1, 2)),
+				attestation(1, 1, bitlist(4, 0, 1, 2, 3)),
+				attestation(2, 0, bitlist(8, 7)),
+			},
+			committees: 3,
+			attesting:  8,
+			possible:   20,
+		},
+		{
+			name: "Electra",
+			electraAttestations: []*spec.VersionedAttestation{
+				// Committees 0 and 2.
+				electraAttestation(1, bitfield.Bitvector64{0x05, 0, 0, 0, 0, 0, 0, 0}, bitlist(16, 0, 1, 8)),
+				// Committee 2.
+				electraAttestation(1, bitfield.Bitvector64{0x04, 0, 0, 0, 0, 0, 0, 0}, bitlist(8, 0, 7)),
+			},
+			committees: 2,
+			attesting:  4,
+			possible:   16,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attestations := test.electraAttestations
+			for _, attestation := range test.attestations {
+				attestations = append(attestations, &spec.VersionedAttestation{
+					Version: spec.DataVersionPhase0,
+					Phase0:  attestation,
+				})
+			}
+			stats := &blockStats{}
+			require.NoError(t, calculateAttestationStats(stats, attestations, committeeSize))
+			require.Equal(t, len(attestations), stats.attestations)
+			require.Equal(t, test.committees, stats.committees)
+			require.Equal(t, test.attesting, stats.attestingValidators)
+			require.Equal(t, test.possible, stats.possibleValidators)
+		})
+	}
+}
+
+func TestCalculateStats(t *testing.T) {
+	syncBits := bitfield.NewBitvector512()
+	for i := uint64(0); i < 500; i++ {
+		syncBits.SetBitAt(i, true)
+	}
+	graffiti := [32]byte{}
+	copy(graffiti[:], "test graffiti")
+
+	tests := []struct {
+		name     string
+		block    *spec.VersionedSignedBeaconBlock
+		err      string
+		expected *blockStats
+	}{
+		{
+			name: "UnknownVersion",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersion(99),
+			},
+			err: "failed to obtain slot: unknown version",
+		},
+		{
+			name: "Phase0",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionPhase0,
+				Phase0: &phase0.SignedBeaconBlock{
+					Message: &phase0.BeaconBlock{
+						Slot:          10,
+						ProposerIndex: 5,
+						Body: &phase0.BeaconBlockBody{
+							ETH1Data: &phase0.ETH1Data{
+								BlockHash: make([]byte, 32),
+							},
+							Graffiti: graffiti,
+							Attestations: []*phase0.Attestation{
+								attestation(9, 0, bitlist(8, 0, 1)),
+							},
+						},
+					},
+				},
+			},
+			expected: &blockStats{
+				slot:                10,
+				proposerIndex:       5,
+				graffiti:            graffiti[:],
+				attestations:        1,
+				committees:          1,
+				attestingValidators: 2,
+				possibleValidators:  8,
+			},
+		},
+		{
+			name: "Deneb",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionDeneb,
+				Deneb: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						Slot:          20,
+						ProposerIndex: 6,
+						Body: &deneb.BeaconBlockBody{
+							ETH1Data: &phase0.ETH1Data{
+								BlockHash: make([]byte, 32),
+							},
+							SyncAggregate: &altair.SyncAggregate{
+								SyncCommitteeBits: syncBits,
+							},
+							ExecutionPayload: &deneb.ExecutionPayload{
+								GasUsed:       15000000,
+								GasLimit:      30000000,
+								BaseFeePerGas: uint256.NewInt(7),
+								Transactions: []bellatrix.Transaction{
+									{0x01},
+									{0x02},
+								},
+							},
+							BlobKZGCommitments: []deneb.KZGCommitment{{}, {}, {}},
+						},
+					},
+				},
+			},
+			expected: &blockStats{
+				slot:                 20,
+				proposerIndex:        6,
+				hasSyncAggregate:     true,
+				syncCommitteeSigners: 500,
+				syncCommitteeSize:    512,
+				syncCommitteeBits:    syncBits,
+				hasExecutionPayload:  true,
+				gasUsed:              15000000,
+				gasLimit:             30000000,
+				transactions:         2,
+				hasBlobs:             true,
+				blobs:                3,
+			},
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Slot:          30,
+						ProposerIndex: 7,
+						Body: &electra.BeaconBlockBody{
+							ETH1Data: &phase0.ETH1Data{
+								BlockHash: make([]byte, 32),
+							},
+							Graffiti: graffiti,
+							Attestations: []*electra.Attestation{
+								electraAttestation(29, bitfield.Bitvector64{0x03, 0, 0, 0, 0, 0, 0, 0}, bitlist(16, 0, 1, 8)).Electra,
+							},
+							SyncAggregate: &altair.SyncAggregate{
+								SyncCommitteeBits: syncBits,
+							},
+							ExecutionPayload: &deneb.ExecutionPayload{
+								GasUsed:       20000000,
+								GasLimit:      36000000,
+								BaseFeePerGas: uint256.NewInt(7),
+								Transactions: []bellatrix.Transaction{
+									{0x01},
+								},
+							},
+							BlobKZGCommitments: []deneb.KZGCommitment{{}},
+							ExecutionRequests:  &electra.ExecutionRequests{},
+						},
+					},
+				},
+			},
+			expected: &blockStats{
+				slot:                 30,
+				proposerIndex:        7,
+				graffiti:             graffiti[:],
+				attestations:         1,
+				committees:           2,
+				attestingValidators:  3,
+				possibleValidators:   16,
+				hasSyncAggregate:     true,
+				syncCommitteeSigners: 500,
+				syncCommitteeSize:    512,
+				syncCommitteeBits:    syncBits,
+				hasExecutionPayload:  true,
+				gasUsed:              20000000,
+				gasLimit:             36000000,
+				transactions:         1,
+				hasBlobs:             true,
+				blobs:                1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats, err := calculateStats(test.block, committeeSize)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				// Root is calculated, so take it from the result.
+				test.expected.root = stats.root
+				if test.expected.graffiti == nil {
+					test.expected.graffiti = make([]byte, 32)
+				}
+				require.Equal(t, test.expected, stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockcompare "github.com/wealdtech/ethdo/cmd/block/compare"
+)
+
+var blockCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare two blocks",
+	Long: `Compare two blocks, or the same block as seen by two beacon nodes, and report the differences between them.  For example:
+
+    ethdo block compare --blockid=12345 --compare-blockid=0x1234...
+
+or
+
+    ethdo block compare --blockid=12345 --connection=http://node1:5052 --compare-connection=http://node2:5052
+
+In quiet mode this will return 0 if the blocks are identical, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockcompare.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockCompareCmd)
+	blockFlags(blockCompareCmd)
+	blockCompareCmd.Flags().String("blockid", "head", "the ID of the block to fetch")
+	blockCompareCmd.Flags().String("compare-blockid", "", "the ID of the block with which to compare (defaults to blockid)")
+	blockCompareCmd.Flags().String("compare-connection", "", "the beacon node from which to fetch the block with which to compare (defaults to connection)")
+}
+
+func blockCompareBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("compare-blockid", cmd.Flags().Lookup("compare-blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("compare-connection", cmd.Flags().Lookup("compare-connection")); err != nil {
+		panic(err)
+	}
+}
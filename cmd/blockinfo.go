@@ -19,6 +19,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	blockinfo "github.com/wealdtech/ethdo/cmd/block/info"
+	"github.com/wealdtech/ethdo/util/output"
 )
 
 var blockInfoCmd = &cobra.Command{
@@ -28,7 +29,24 @@ var blockInfoCmd = &cobra.Command{
 
     ethdo block info --blockid=12345
 
+Blocks can be streamed as they become the head of the chain with --stream.  With --stream-topic=block all blocks are streamed as they are received, including those that do not become the head, and with --stream-topic=finalized_checkpoint the block of each new finalized checkpoint is streamed.
+
+The SSZ of the block can be written to a file with --ssz-file, along with the SSZ of its blob sidecars with --ssz-blobs-file.  Alternatively, --raw outputs the SSZ as binary rather than hex, for piping into other tools.
+
+A range of blocks can be output with --from-slot and --to-slot, or all blocks in an epoch with --epoch.  Empty slots are skipped.  With --json each block is output as a single line of JSON, and with --ssz-dir the SSZ of each block is written to a file named after its slot in the given directory.
+
+The blinded block, containing only the execution payload header, can be fetched with --blinded.  If a relay is supplied with --relay then the output states if the relay delivered the block's execution payload.  Relays do not provide delivered execution payloads, so the full block cannot be reconstructed.
+
+The transactions in the execution payload can be decoded with --decode-transactions, showing the type, sender, recipient, value, gas and blob versioned hashes of each transaction.  With --json the decoded transactions are added to the output as "decoded_transactions".
+
+The blob sidecars of a block can be verified with --verify-blobs, which checks that each sidecar's commitment matches the block and that its inclusion proof is valid against the block body root.  The KZG proof of each blob is also checked against its commitment, using the mainnet trusted setup unless a different setup file is supplied with --trusted-setup.  With --json the results are added to the output as "blob_verifications".
+
+Two blocks can be compared with --diff, which takes the ID of a second block and outputs the differences between them, for example a block that was reorged out and its replacement.  Differing roots, the attestations present in only one of the blocks, differences in the execution payload and any other differing fields are shown.  In quiet mode with --diff this will return 0 if the blocks are identical, otherwise 1.
+
+Blocks can be output in any of the formats supported by --output, which are text, json, ndjson, ssz, csv and yaml.  Ranges output with --output=csv contain a single header, and with --output=yaml are separated as YAML documents.
+
 In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,csv,yaml"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := blockinfo.Run(cmd)
 		if err != nil {
@@ -50,7 +68,24 @@ func init() {
 	blockInfoCmd.Flags().String("blockid", "head", "the ID of the block to fetch")
 	blockInfoCmd.Flags().String("block-time", "", "the time of the block to fetch (format YYYY-MM-DDTHH:MM:SS, or a hex or decimal timestamp")
 	blockInfoCmd.Flags().Bool("stream", false, "continually stream blocks as they arrive")
+	blockInfoCmd.Flags().String("stream-topic", "head", "the event topic to follow when streaming blocks ('head', 'block' or 'finalized_checkpoint')")
 	blockInfoCmd.Flags().Bool("ssz", false, "output data in SSZ format")
+	blockInfoCmd.Flags().String("ssz-file", "", "write the SSZ of the block to the named file rather than outputting it")
+	blockInfoCmd.Flags().String("ssz-blobs-file", "", "write the SSZ of the block's blob sidecars to the named file (requires ssz-file)")
+	blockInfoCmd.Flags().Bool("raw", false, "output SSZ data as binary rather than hex (requires output to be redirected)")
+	blockInfoCmd.Flags().String("from-slot", "", "the first slot of a range of blocks to fetch")
+	blockInfoCmd.Flags().String("to-slot", "", "the last slot of a range of blocks to fetch (defaults to the current slot)")
+	blockInfoCmd.Flags().String("epoch", "", "fetch all blocks in the given epoch")
+	blockInfoCmd.Flags().String("ssz-dir", "", "write the SSZ of each block in a range to a file in the named directory")
+	blockInfoCmd.Flags().Bool("blinded", false, "fetch the blinded block, containing only the execution payload header")
+	blockInfoCmd.Flags().String("relay", "", "the URL of a relay to query for delivery of the execution payload of a blinded block")
+	blockInfoCmd.Flags().Bool("decode-transactions", false, "decode the transactions in the execution payload")
+	blockInfoCmd.Flags().Bool("verify-blobs", false, "verify the blob sidecars of the block")
+	blockInfoCmd.Flags().String("trusted-setup", "", "the KZG trusted setup file with which to verify blob KZG proofs, if not the mainnet setup (requires verify-blobs)")
+	blockInfoCmd.Flags().String("diff", "", "the ID of a block with which to diff the block")
+	if err := blockInfoCmd.Flags().MarkDeprecated("ssz", "use --output=ssz"); err != nil {
+		panic(err)
+	}
 }
 
 func blockInfoBindings(cmd *cobra.Command) {
@@ -63,7 +98,49 @@ func blockInfoBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("stream", cmd.Flags().Lookup("stream")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("stream-topic", cmd.Flags().Lookup("stream-topic")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("ssz", cmd.Flags().Lookup("ssz")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("ssz-file", cmd.Flags().Lookup("ssz-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ssz-blobs-file", cmd.Flags().Lookup("ssz-blobs-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("raw", cmd.Flags().Lookup("raw")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-slot", cmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-slot", cmd.Flags().Lookup("to-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ssz-dir", cmd.Flags().Lookup("ssz-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blinded", cmd.Flags().Lookup("blinded")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("relay", cmd.Flags().Lookup("relay")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("decode-transactions", cmd.Flags().Lookup("decode-transactions")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("verify-blobs", cmd.Flags().Lookup("verify-blobs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("trusted-setup", cmd.Flags().Lookup("trusted-setup")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("diff", cmd.Flags().Lookup("diff")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockproof "github.com/wealdtech/ethdo/cmd/block/proof"
+)
+
+var blockProofCmd = &cobra.Command{
+	Use:   "proof",
+	Short: "Generate a proof of a historical block root",
+	Long: `Generate a merkle proof of the block root at a historical slot against a recent state.  For example:
+
+    ethdo block proof --slot=7000000
+
+Slots within the state's block roots vector are proved directly; older slots are proved through the state's historical summaries, which requires the state at the end of the slot's historical period and so may require an archive node.  By default the proof is against the state root; supplying --anchor=block extends the proof to the root of the block that produced the state, as provided by the EIP-4788 beacon roots contract.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockproof.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockProofCmd)
+	blockFlags(blockProofCmd)
+	blockProofCmd.Flags().String("slot", "", "the historical slot for which to prove the block root")
+	blockProofCmd.Flags().String("state", "head", "the state against which to generate the proof")
+	blockProofCmd.Flags().String("anchor", "state", "the root against which to generate the proof (state or block)")
+}
+
+func blockProofBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("state", cmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("anchor", cmd.Flags().Lookup("anchor")); err != nil {
+		panic(err)
+	}
+}
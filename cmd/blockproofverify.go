@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockproofverify "github.com/wealdtech/ethdo/cmd/block/proof/verify"
+)
+
+var blockProofVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a proof of a historical block root",
+	Long: `Verify a merkle proof of a historical block root, as generated by "block proof".  For example:
+
+    ethdo block proof verify --proof=proof.json --root=0x...
+
+The proof can be supplied as a file or directly as JSON.  Verification does not require a beacon node; supplying --root checks the proof against a trusted state or block root, and supplying --block-root checks the block root being proved.
+
+In quiet mode this will return 0 if the proof is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockproofverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockProofCmd.AddCommand(blockProofVerifyCmd)
+	blockFlags(blockProofVerifyCmd)
+	blockProofVerifyCmd.Flags().String("proof", "", "the proof to verify, as a file or JSON")
+	blockProofVerifyCmd.Flags().String("root", "", "the trusted state or block root against which the proof should verify")
+	blockProofVerifyCmd.Flags().String("block-root", "", "the block root that the proof should prove")
+}
+
+func blockProofVerifyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("root", cmd.Flags().Lookup("root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("block-root", cmd.Flags().Lookup("block-root")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockstats "github.com/wealdtech/ethdo/cmd/block/stats"
+)
+
+var blockStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Obtain statistics about a block",
+	Long: `Obtain summary statistics about a block.  For example:
+
+    ethdo block stats --blockid=12345
+
+This reports the proposer of the block, its graffiti, the participation of the attestations and sync aggregate that it contains, its gas usage, and the number of blobs it carries.
+
+In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := blockstats.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockStatsCmd)
+	blockFlags(blockStatsCmd)
+	blockStatsCmd.Flags().String("blockid", "head", "the ID of the block to fetch")
+}
+
+func blockStatsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainapr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	days       int
+	validators []string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Days       []*day   `json:"days"`
+	Network    *aprData `json:"network"`
+	Validators *aprData `json:"validators,omitempty"`
+}
+
+type day struct {
+	Date       string   `json:"date"`
+	StartEpoch uint64   `json:"start_epoch"`
+	EndEpoch   uint64   `json:"end_epoch"`
+	Network    *aprData `json:"network"`
+	Validators *aprData `json:"validators,omitempty"`
+}
+
+type aprData struct {
+	// Validators is the number of validator days included in the calculation.
+	Validators int `json:"validators"`
+	// Stake is the total effective balance of the included validators.
+	Stake phase0.Gwei `json:"stake"`
+	// Rewards is the total change in balance of the included validators.
+	Rewards int64 `json:"rewards"`
+	// APR is the annualised rate of return.
+	APR float64 `json:"apr"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	var err error
+	c.days, err = parseWindow(viper.GetString("window"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.validators = viper.GetStringSlice("validators")
+
+	return c, nil
+}
+
+// parseWindow parses a window, either as a number of days (e.g. "90d") or a
+// duration that is a whole number of days (e.g. "48h"), returning the number of days.
+func parseWindow(input string) (int, error) {
+	if input == "" {
+		return 0, errors.New("window is required")
+	}
+
+	var days int
+	if strings.HasSuffix(input, "d") {
+		val, err := strconv.Atoi(strings.TrimSuffix(input, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", input)
+		}
+		days = val
+	} else {
+		duration, err := time.ParseDuration(input)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", input)
+		}
+		if duration%(24*time.Hour) != 0 {
+			return 0, errors.New("window must be a whole number of days")
+		}
+		days = int(duration / (24 * time.Hour))
+	}
+	if days < 1 {
+		return 0, errors.New("window must be at least 1 day")
+	}
+
+	return days, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainapr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		days int
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"window": "90d",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "WindowMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "window is required",
+		},
+		{
+			name: "WindowInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "invalid",
+			},
+			err: `invalid window "invalid"`,
+		},
+		{
+			name: "WindowDaysInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "xd",
+			},
+			err: `invalid window "xd"`,
+		},
+		{
+			name: "WindowPartialDay",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "36h",
+			},
+			err: "window must be a whole number of days",
+		},
+		{
+			name: "WindowZero",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "0d",
+			},
+			err: "window must be at least 1 day",
+		},
+		{
+			name: "WindowDays",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "90d",
+			},
+			days: 90,
+		},
+		{
+			name: "WindowDuration",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"window":  "168h",
+			},
+			days: 7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.days, cmd.days)
+			}
+		})
+	}
+}
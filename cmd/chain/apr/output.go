@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainapr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		for _, day := range c.results.Days {
+			builder.WriteString(fmt.Sprintf("%s (epochs %d-%d): network %s", day.Date, day.StartEpoch, day.EndEpoch, formatAPR(day.Network.APR)))
+			if day.Validators != nil {
+				builder.WriteString(fmt.Sprintf(", validators %s", formatAPR(day.Validators.APR)))
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Network APR over %d days: %s\n", len(c.results.Days), formatAPR(c.results.Network.APR)))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("  Stake: %s\n", string2eth.GWeiToString(uint64(c.results.Network.Stake), true)))
+		builder.WriteString(fmt.Sprintf("  Rewards: %s\n", formatGwei(c.results.Network.Rewards)))
+	}
+	if c.results.Validators != nil {
+		builder.WriteString(fmt.Sprintf("Validators APR over %d days: %s\n", len(c.results.Days), formatAPR(c.results.Validators.APR)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Stake: %s\n", string2eth.GWeiToString(uint64(c.results.Validators.Stake), true)))
+			builder.WriteString(fmt.Sprintf("  Rewards: %s\n", formatGwei(c.results.Validators.Rewards)))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// formatAPR formats an APR as a percentage.
+func formatAPR(apr float64) string {
+	return fmt.Sprintf("%.2f%%", apr*100)
+}
+
+// formatGwei formats a signed Gwei value.
+func formatGwei(val int64) string {
+	if val < 0 {
+		return "-" + string2eth.GWeiToString(uint64(-val), true)
+	}
+	return string2eth.GWeiToString(uint64(val), true)
+}
@@ -0,0 +1,290 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainapr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// maxBlockLookback is the maximum number of slots to step back when looking
+// for a block that contains withdrawals.
+const maxBlockLookback = 64
+
+// boundary is the state of the chain at the start of a day.
+type boundary struct {
+	epoch      phase0.Epoch
+	validators map[phase0.ValidatorIndex]*apiv1.Validator
+	// nextWithdrawalIndex is the index of the next validator to be swept for withdrawals.
+	nextWithdrawalIndex phase0.ValidatorIndex
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var include func(phase0.ValidatorIndex) bool
+	if len(c.validators) > 0 {
+		validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validators")
+		}
+		indices := make(map[phase0.ValidatorIndex]bool, len(validators))
+		for _, validator := range validators {
+			indices[validator.Index] = true
+		}
+		include = func(index phase0.ValidatorIndex) bool {
+			return indices[index]
+		}
+	}
+
+	epochDuration := c.chainTime.SlotDuration() * time.Duration(c.chainTime.SlotsPerEpoch())
+	epochsPerDay := phase0.Epoch((24 * time.Hour) / epochDuration)
+	if epochsPerDay == 0 {
+		return errors.New("epochs are longer than a day; cannot calculate daily APR")
+	}
+	endEpoch := c.chainTime.CurrentEpoch()
+	if endEpoch < phase0.Epoch(c.days)*epochsPerDay {
+		return fmt.Errorf("chain is not old enough for a window of %d days", c.days)
+	}
+	startEpoch := endEpoch - phase0.Epoch(c.days)*epochsPerDay
+	annualisation := float64(365*24*time.Hour) / float64(time.Duration(epochsPerDay)*epochDuration)
+
+	c.results = &results{
+		Days:    make([]*day, 0, c.days),
+		Network: &aprData{},
+	}
+	if include != nil {
+		c.results.Validators = &aprData{}
+	}
+
+	start, err := c.obtainBoundary(ctx, startEpoch)
+	if err != nil {
+		return err
+	}
+	for epoch := startEpoch + epochsPerDay; epoch <= endEpoch; epoch += epochsPerDay {
+		end, err := c.obtainBoundary(ctx, epoch)
+		if err != nil {
+			return err
+		}
+
+		day := &day{
+			Date:       c.chainTime.StartOfEpoch(start.epoch).UTC().Format("2006-01-02"),
+			StartEpoch: uint64(start.epoch),
+			EndEpoch:   uint64(end.epoch),
+			Network:    dailyAPR(start, end, nil, annualisation),
+		}
+		c.results.Network.add(day.Network)
+		if include != nil {
+			day.Validators = dailyAPR(start, end, include, annualisation)
+			c.results.Validators.add(day.Validators)
+		}
+		c.results.Days = append(c.results.Days, day)
+
+		start = end
+	}
+
+	c.results.Network.calculate(annualisation)
+	if c.results.Validators != nil {
+		c.results.Validators.calculate(annualisation)
+	}
+
+	return nil
+}
+
+// obtainBoundary obtains the state of the chain at the start of the given epoch.
+func (c *command) obtainBoundary(ctx context.Context, epoch phase0.Epoch) (*boundary, error) {
+	slot := c.chainTime.FirstSlotOfEpoch(epoch)
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Obtaining validators at slot %d\n", slot)
+	}
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", slot)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+
+	nextWithdrawalIndex, err := c.obtainNextWithdrawalIndex(ctx, slot, len(validators))
+	if err != nil {
+		return nil, err
+	}
+
+	return &boundary{
+		epoch:               epoch,
+		validators:          validators,
+		nextWithdrawalIndex: nextWithdrawalIndex,
+	}, nil
+}
+
+// obtainNextWithdrawalIndex obtains the index of the next validator to be swept
+// for withdrawals as at the given slot.  It steps back through empty slots and
+// blocks without withdrawals until it finds a block with withdrawals.
+func (c *command) obtainNextWithdrawalIndex(ctx context.Context,
+	slot phase0.Slot,
+	validators int,
+) (
+	phase0.ValidatorIndex,
+	error,
+) {
+	if validators == 0 {
+		return 0, nil
+	}
+
+	for i := 0; i < maxBlockLookback && phase0.Slot(i) <= slot; i++ {
+		block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot-phase0.Slot(i))}))
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to obtain block")
+		}
+		if block != nil {
+			if block.Version < spec.DataVersionCapella {
+				// Prior to Capella the sweep starts at the first validator.
+				return 0, nil
+			}
+			withdrawals, err := block.Withdrawals()
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to obtain withdrawals")
+			}
+			if len(withdrawals) > 0 {
+				return phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % validators), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no block with withdrawals found within %d slots of slot %d", maxBlockLookback, slot)
+}
+
+// dailyAPR calculates the APR between two boundaries for validators that pass
+// the include filter, or all validators if the filter is nil.
+//
+// Only validators that are active at both boundaries are included.  Validators
+// that were passed by the withdrawals sweep between the boundaries are excluded,
+// as any withdrawal would show up as a loss of balance.
+func dailyAPR(start *boundary,
+	end *boundary,
+	include func(phase0.ValidatorIndex) bool,
+	annualisation float64,
+) *aprData {
+	res := &aprData{}
+
+	for index, startValidator := range start.validators {
+		if include != nil && !include(index) {
+			continue
+		}
+		if !isActive(startValidator, start.epoch) {
+			continue
+		}
+		endValidator, exists := end.validators[index]
+		if !exists || !isActive(endValidator, end.epoch) {
+			continue
+		}
+		if swept(index, start.nextWithdrawalIndex, end.nextWithdrawalIndex, len(end.validators)) {
+			continue
+		}
+
+		res.Validators++
+		res.Stake += startValidator.Validator.EffectiveBalance
+		res.Rewards += int64(endValidator.Balance) - int64(startValidator.Balance)
+	}
+	res.calculate(annualisation)
+
+	return res
+}
+
+// isActive returns true if the validator is active at the given epoch.
+func isActive(validator *apiv1.Validator, epoch phase0.Epoch) bool {
+	return validator.Validator != nil &&
+		validator.Validator.ActivationEpoch <= epoch &&
+		validator.Validator.ExitEpoch > epoch
+}
+
+// swept returns true if the validator index lies in the range [from, to) of
+// the withdrawals sweep, allowing for the sweep wrapping around.
+func swept(index phase0.ValidatorIndex,
+	from phase0.ValidatorIndex,
+	to phase0.ValidatorIndex,
+	validators int,
+) bool {
+	if validators == 0 {
+		return false
+	}
+	n := uint64(validators)
+	distance := (uint64(index) + n - uint64(from)%n) % n
+	span := (uint64(to)%n + n - uint64(from)%n) % n
+
+	return distance < span
+}
+
+// add adds the rewards and stake of the supplied data to this data.
+func (d *aprData) add(other *aprData) {
+	d.Validators += other.Validators
+	d.Stake += other.Stake
+	d.Rewards += other.Rewards
+}
+
+// calculate calculates the APR from the rewards and stake.
+func (d *aprData) calculate(annualisation float64) {
+	if d.Stake == 0 {
+		d.APR = 0
+		return
+	}
+	d.APR = float64(d.Rewards) / float64(d.Stake) * annualisation
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block information")
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainapr
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlocksProvider provides fixed blocks.
+type testBlocksProvider struct {
+	blocks map[string]*spec.VersionedSignedBeaconBlock
+}
+
+func (p *testBlocksProvider) SignedBeaconBlock(_ context.Context,
+	opts *api.SignedBeaconBlockOpts,
+) (
+	*api.Response[*spec.VersionedSignedBeaconBlock],
+	error,
+) {
+	block, exists := p.blocks[opts.Block]
+	if !exists {
+		return nil, &api.Error{StatusCode: http.StatusNotFound}
+	}
+
+	return &api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data: block,
+	}, nil
+}
+
+func TestSwept(t *testing.T) {
+	tests := []struct {
+		name       string
+		index      phase0.ValidatorIndex
+		from       phase0.ValidatorIndex
+		to         phase0.ValidatorIndex
+		validators int
+		expected   bool
+	}{
+		{
+			name:       "NoValidators",
+			index:      1,
+			from:       0,
+			to:         5,
+			validators: 0,
+		},
+		{
+			name:       "NoSweep",
+			index:      3,
+			from:       3,
+			to:         3,
+			validators: 10,
+		},
+		{
+			name:       "Start",
+			index:      3,
+			from:       3,
+			to:         6,
+			validators: 10,
+			expected:   true,
+		},
+		{
+			name:       "End",
+			index:      6,
+			from:       3,
+			to:         6,
+			validators: 10,
+		},
+		{
+			name:       "Before",
+			index:      2,
+			from:       3,
+			to:         6,
+			validators: 10,
+		},
+		{
+			name:       "WrappedLow",
+			index:      1,
+			from:       8,
+			to:         2,
+			validators: 10,
+			expected:   true,
+		},
+		{
+			name:       "WrappedHigh",
+			index:      9,
+			from:       8,
+			to:         2,
+			validators: 10,
+			expected:   true,
+		},
+		{
+			name:       "WrappedOutside",
+			index:      5,
+			from:       8,
+			to:         2,
+			validators: 10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, swept(test.index, test.from, test.to, test.validators))
+		})
+	}
+}
+
+func validator(index phase0.ValidatorIndex,
+	activationEpoch phase0.Epoch,
+	exitEpoch phase0.Epoch,
+	balance phase0.Gwei,
+) *apiv1.Validator {
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Validator: &phase0.Validator{
+			EffectiveBalance: 32000000000,
+			ActivationEpoch:  activationEpoch,
+			ExitEpoch:        exitEpoch,
+		},
+	}
+}
+
+func TestDailyAPR(t *testing.T) {
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+	start := &boundary{
+		epoch: 225,
+		validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+			0: validator(0, 0, farFuture, 32000000000),
+			1: validator(1, 0, farFuture, 32000000000),
+			2: validator(2, 0, farFuture, 32100000000),
+			3: validator(3, 0, 300, 32000000000),
+			4: validator(4, 400, farFuture, 32000000000),
+		},
+		nextWithdrawalIndex: 2,
+	}
+	end := &boundary{
+		epoch: 450,
+		validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+			0: validator(0, 0, farFuture, 32002000000),
+			1: validator(1, 0, farFuture, 31999000000),
+			2: validator(2, 0, farFuture, 32000000000),
+			3: validator(3, 0, 300, 32000000000),
+			4: validator(4, 400, farFuture, 32000000000),
+			5: validator(5, 500, farFuture, 32000000000),
+		},
+		nextWithdrawalIndex: 3,
+	}
+
+	tests := []struct {
+		name     string
+		include  func(phase0.ValidatorIndex) bool
+		expected *aprData
+	}{
+		{
+			name: "Network",
+			expected: &aprData{
+				Validators: 2,
+				Stake:      64000000000,
+				Rewards:    1000000,
+				APR:        1000000.0 / 64000000000.0 * 365,
+			},
+		},
+		{
+			name: "Validator",
+			include: func(index phase0.ValidatorIndex) bool {
+				return index == 1
+			},
+			expected: &aprData{
+				Validators: 1,
+				Stake:      32000000000,
+				Rewards:    -1000000,
+				APR:        -1000000.0 / 32000000000.0 * 365,
+			},
+		},
+		{
+			name: "Excluded",
+			include: func(index phase0.ValidatorIndex) bool {
+				return index == 2 || index == 3
+			},
+			expected: &aprData{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := dailyAPR(start, end, test.include, 365)
+			require.Equal(t, test.expected.Validators, res.Validators)
+			require.Equal(t, test.expected.Stake, res.Stake)
+			require.Equal(t, test.expected.Rewards, res.Rewards)
+			require.InDelta(t, test.expected.APR, res.APR, 1e-12)
+		})
+	}
+}
+
+func TestObtainNextWithdrawalIndex(t *testing.T) {
+	withdrawals := []*capella.Withdrawal{
+		{Index: 1, ValidatorIndex: 5},
+		{Index: 2, ValidatorIndex: 6},
+	}
+
+	tests := []struct {
+		name       string
+		blocks     map[string]*spec.VersionedSignedBeaconBlock
+		slot       phase0.Slot
+		validators int
+		expected   phase0.ValidatorIndex
+		err        string
+	}{
+		{
+			name:       "NoValidators",
+			validators: 0,
+		},
+		{
+			name: "Altair",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"10": {
+					Version: spec.DataVersionAltair,
+					Altair:  &altair.SignedBeaconBlock{},
+				},
+			},
+			slot:       10,
+			validators: 10,
+			expected:   0,
+		},
+		{
+			name: "Deneb",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"10": {
+					Version: spec.DataVersionDeneb,
+					Deneb: &deneb.SignedBeaconBlock{
+						Message: &deneb.BeaconBlock{
+							Body: &deneb.BeaconBlockBody{
+								ExecutionPayload: &deneb.ExecutionPayload{
+									Withdrawals: withdrawals,
+								},
+							},
+						},
+					},
+				},
+			},
+			slot:       10,
+			validators: 10,
+			expected:   7,
+		},
+		{
+			name: "ElectraAfterEmptySlot",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"9": {
+					Version: spec.DataVersionElectra,
+					Electra: &electra.SignedBeaconBlock{
+						Message: &electra.BeaconBlock{
+							Body: &electra.BeaconBlockBody{
+								ExecutionPayload: &deneb.ExecutionPayload{
+									Withdrawals: withdrawals,
+								},
+							},
+						},
+					},
+				},
+			},
+			slot:       10,
+			validators: 7,
+			expected:   0,
+		},
+		{
+			name: "ElectraMissing",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"10": {
+					Version: spec.DataVersionElectra,
+				},
+			},
+			slot:       10,
+			validators: 10,
+			err:        "failed to obtain withdrawals: no electra block",
+		},
+		{
+			name:       "NotFound",
+			slot:       10,
+			validators: 10,
+			err:        "no block with withdrawals found within 64 slots of slot 10",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				blocksProvider: &testBlocksProvider{blocks: test.blocks},
+			}
+			res, err := c.obtainNextWithdrawalIndex(context.Background(), test.slot, test.validators)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
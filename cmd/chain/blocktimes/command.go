@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainblocktimes
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	duration  time.Duration
+	maxBlocks int
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	eventsProvider eth2client.EventsProvider
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	observations []*observation
+}
+
+// observation is the arrival of a single block.
+type observation struct {
+	slot   phase0.Slot
+	root   phase0.Root
+	delay  time.Duration
+	client string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:        viper.GetBool("quiet"),
+		verbose:      viper.GetBool("verbose"),
+		debug:        viper.GetBool("debug"),
+		json:         viper.GetBool("json"),
+		observations: make([]*observation, 0),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.duration = viper.GetDuration("duration")
+	if c.duration < 0 {
+		return nil, errors.New("duration cannot be negative")
+	}
+	c.maxBlocks = viper.GetInt("blocks")
+	if c.maxBlocks < 0 {
+		return nil, errors.New("blocks cannot be negative")
+	}
+	if c.duration == 0 && c.maxBlocks == 0 {
+		return nil, errors.New("one of duration or blocks is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,236 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainblocktimes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// distribution is the distribution of a set of block arrival delays.
+type distribution struct {
+	count  int
+	min    time.Duration
+	mean   time.Duration
+	median time.Duration
+	p90    time.Duration
+	max    time.Duration
+	// histogram is the number of blocks arriving in each second of the slot.
+	histogram []int
+}
+
+type jsonOutput struct {
+	Blocks    int                          `json:"blocks"`
+	FirstSlot phase0.Slot                  `json:"first_slot"`
+	LastSlot  phase0.Slot                  `json:"last_slot"`
+	Overall   *distributionJSON            `json:"overall"`
+	Clients   map[string]*distributionJSON `json:"clients"`
+}
+
+type distributionJSON struct {
+	Count     int   `json:"count"`
+	MinMS     int64 `json:"min_ms"`
+	MeanMS    int64 `json:"mean_ms"`
+	MedianMS  int64 `json:"median_ms"`
+	P90MS     int64 `json:"p90_ms"`
+	MaxMS     int64 `json:"max_ms"`
+	Histogram []int `json:"histogram"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Blocks:  len(c.observations),
+		Clients: make(map[string]*distributionJSON),
+	}
+	if len(c.observations) > 0 {
+		output.FirstSlot, output.LastSlot = c.slotRange()
+		output.Overall = c.distribution("").json()
+		for _, client := range c.clients() {
+			output.Clients[client] = c.distribution(client).json()
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.observations) == 0 {
+		return "No blocks observed", nil
+	}
+
+	builder := strings.Builder{}
+
+	firstSlot, lastSlot := c.slotRange()
+	builder.WriteString(fmt.Sprintf("Blocks observed: %d (slots %d to %d)\n", len(c.observations), firstSlot, lastSlot))
+	c.outputDistributionText(&builder, "All clients", c.distribution(""))
+	for _, client := range c.clients() {
+		c.outputDistributionText(&builder, client, c.distribution(client))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputDistributionText(builder *strings.Builder, name string, dist *distribution) {
+	builder.WriteString(fmt.Sprintf("%s: %d blocks, min %s, mean %s, median %s, 90th percentile %s, max %s\n",
+		name,
+		dist.count,
+		formatDelay(dist.min),
+		formatDelay(dist.mean),
+		formatDelay(dist.median),
+		formatDelay(dist.p90),
+		formatDelay(dist.max),
+	))
+	if c.verbose {
+		for i, count := range dist.histogram {
+			builder.WriteString(fmt.Sprintf("  %2ds-%2ds: %d\n", i, i+1, count))
+		}
+	}
+}
+
+// slotRange returns the first and last slots observed.
+func (c *command) slotRange() (phase0.Slot, phase0.Slot) {
+	first := c.observations[0].slot
+	last := c.observations[0].slot
+	for _, observation := range c.observations {
+		if observation.slot < first {
+			first = observation.slot
+		}
+		if observation.slot > last {
+			last = observation.slot
+		}
+	}
+
+	return first, last
+}
+
+// clients returns the clients observed, ordered by number of blocks.
+func (c *command) clients() []string {
+	counts := make(map[string]int)
+	for _, observation := range c.observations {
+		counts[observation.client]++
+	}
+	clients := make([]string, 0, len(counts))
+	for client := range counts {
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i int, j int) bool {
+		if counts[clients[i]] != counts[clients[j]] {
+			return counts[clients[i]] > counts[clients[j]]
+		}
+		return clients[i] < clients[j]
+	})
+
+	return clients
+}
+
+// distribution calculates the distribution of delays for the given
+// client, or for all clients if client is empty.
+func (c *command) distribution(client string) *distribution {
+	delays := make([]time.Duration, 0, len(c.observations))
+	for _, observation := range c.observations {
+		if client == "" || observation.client == client {
+			delays = append(delays, observation.delay)
+		}
+	}
+
+	return calculateDistribution(delays, c.chainTime.SlotDuration())
+}
+
+// calculateDistribution calculates the distribution of delays within a slot.
+func calculateDistribution(delays []time.Duration, slotDuration time.Duration) *distribution {
+	dist := &distribution{
+		count:     len(delays),
+		histogram: make([]int, int(slotDuration/time.Second)),
+	}
+	if len(delays) == 0 {
+		return dist
+	}
+
+	sorted := make([]time.Duration, len(delays))
+	copy(sorted, delays)
+	sort.Slice(sorted, func(i int, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	total := time.Duration(0)
+	for _, delay := range sorted {
+		total += delay
+		bucket := int(delay / time.Second)
+		if bucket >= len(dist.histogram) {
+			bucket = len(dist.histogram) - 1
+		}
+		if bucket >= 0 {
+			dist.histogram[bucket]++
+		}
+	}
+
+	dist.min = sorted[0]
+	dist.max = sorted[len(sorted)-1]
+	dist.mean = total / time.Duration(len(sorted))
+	dist.median = percentile(sorted, 50)
+	dist.p90 = percentile(sorted, 90)
+
+	return dist
+}
+
+// percentile returns the given percentile of sorted delays, using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, pct int) time.Duration {
+	rank := (pct*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func (d *distribution) json() *distributionJSON {
+	return &distributionJSON{
+		Count:     d.count,
+		MinMS:     d.min.Milliseconds(),
+		MeanMS:    d.mean.Milliseconds(),
+		MedianMS:  d.median.Milliseconds(),
+		P90MS:     d.p90.Milliseconds(),
+		MaxMS:     d.max.Milliseconds(),
+		Histogram: d.histogram,
+	}
+}
+
+// formatDelay formats a delay in seconds to millisecond precision.
+func formatDelay(delay time.Duration) string {
+	return fmt.Sprintf("%.3fs", delay.Seconds())
+}
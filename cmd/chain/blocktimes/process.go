@@ -0,0 +1,210 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainblocktimes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// unknownClient is the client reported for blocks whose proposer client
+// cannot be identified.
+const unknownClient = "unknown"
+
+// consensusClients are the names of consensus clients as they appear in
+// graffiti, mapped to their display names.
+var consensusClients = map[string]string{
+	"grandine":   "Grandine",
+	"lighthouse": "Lighthouse",
+	"lodestar":   "Lodestar",
+	"nimbus":     "Nimbus",
+	"prysm":      "Prysm",
+	"teku":       "Teku",
+}
+
+// arrival is the time at which a block event was received.
+type arrival struct {
+	event *apiv1.BlockEvent
+	time  time.Time
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	// The session ends when the duration passes, or the user interrupts it.
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	if c.duration > 0 {
+		var timeoutCancel context.CancelFunc
+		ctx, timeoutCancel = context.WithTimeout(ctx, c.duration)
+		defer timeoutCancel()
+	}
+
+	arrivals := make(chan *arrival, 64)
+	if err := c.eventsProvider.Events(ctx, &api.EventsOpts{
+		Topics: []string{"block"},
+		Handler: func(event *apiv1.Event) {
+			// Timestamp the event as soon as it is received.
+			now := time.Now()
+			blockEvent, isBlockEvent := event.Data.(*apiv1.BlockEvent)
+			if !isBlockEvent {
+				return
+			}
+			select {
+			case arrivals <- &arrival{event: blockEvent, time: now}:
+			default:
+				// Processing has fallen behind, so drop the event rather than skew later timings.
+				fmt.Fprintf(os.Stderr, "Dropped event for slot %d\n", blockEvent.Slot)
+			}
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to connect for events")
+	}
+
+	if !c.quiet && !c.json {
+		fmt.Fprintf(os.Stderr, "Listening for blocks; interrupt to finish early\n")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case arrival := <-arrivals:
+			if err := c.observe(ctx, arrival); err != nil {
+				return err
+			}
+			if c.maxBlocks > 0 && len(c.observations) >= c.maxBlocks {
+				return nil
+			}
+		}
+	}
+}
+
+// observe records the arrival of a block.
+func (c *command) observe(ctx context.Context, arrival *arrival) error {
+	delay := arrival.time.Sub(c.chainTime.StartOfSlot(arrival.event.Slot))
+	if delay < 0 || delay > c.chainTime.SlotDuration() {
+		// The block is not for the current slot, for example it has been
+		// fetched when the node is syncing, so its arrival time says nothing
+		// about its propagation.
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Ignoring block for slot %d received after %v\n", arrival.event.Slot, delay)
+		}
+		return nil
+	}
+
+	client := unknownClient
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%#x", arrival.event.Block)})
+	switch {
+	case err != nil:
+		if ctx.Err() != nil {
+			// Session finished whilst fetching the block.
+			return nil
+		}
+		// The client is not critical, so carry on without it.
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Failed to obtain block for slot %d: %v\n", arrival.event.Slot, err)
+		}
+	default:
+		client = blockClient(blockResponse.Data)
+	}
+
+	c.observations = append(c.observations, &observation{
+		slot:   arrival.event.Slot,
+		root:   arrival.event.Block,
+		delay:  delay,
+		client: client,
+	})
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Block for slot %d from %s received after %v\n", arrival.event.Slot, client, delay)
+	}
+
+	return nil
+}
+
+// blockClient returns the consensus client that proposed a block, as
+// identified by its graffiti.
+func blockClient(block *spec.VersionedSignedBeaconBlock) string {
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return unknownClient
+	}
+
+	return graffitiClient(graffiti[:])
+}
+
+// graffitiClient returns the consensus client identified by graffiti.
+func graffitiClient(graffiti []byte) string {
+	info := util.DecodeGraffiti(graffiti)
+	if info.ConsensusClient != "" {
+		return info.ConsensusClient
+	}
+	for _, name := range info.Clients {
+		if client, exists := consensusClients[strings.ToLower(name)]; exists {
+			return client
+		}
+	}
+
+	return unknownClient
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainblocktimes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraffitiClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		graffiti string
+		expected string
+	}{
+		{
+			name:     "Empty",
+			expected: "unknown",
+		},
+		{
+			name:     "ClientVersion",
+			graffiti: "GE1234LHabcd my validator",
+			expected: "Lighthouse",
+		},
+		{
+			name:     "Name",
+			graffiti: "teku/v23.10.0",
+			expected: "Teku",
+		},
+		{
+			name:     "ExecutionOnly",
+			graffiti: "geth rocks",
+			expected: "unknown",
+		},
+		{
+			name:     "Other",
+			graffiti: "hello world",
+			expected: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graffiti := make([]byte, 32)
+			copy(graffiti, test.graffiti)
+			require.Equal(t, test.expected, graffitiClient(graffiti))
+		})
+	}
+}
+
+func TestBlockClient(t *testing.T) {
+	graffiti := [32]byte{}
+	copy(graffiti[:], "teku/v23.10.0")
+
+	tests := []struct {
+		name     string
+		block    *spec.VersionedSignedBeaconBlock
+		expected string
+	}{
+		{
+			name: "Deneb",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionDeneb,
+				Deneb: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						Body: &deneb.BeaconBlockBody{
+							Graffiti: graffiti,
+						},
+					},
+				},
+			},
+			expected: "Teku",
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Body: &electra.BeaconBlockBody{
+							Graffiti: graffiti,
+						},
+					},
+				},
+			},
+			expected: "Teku",
+		},
+		{
+			name: "ElectraMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+			},
+			expected: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, blockClient(test.block))
+		})
+	}
+}
+
+func TestCalculateDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		delays   []time.Duration
+		expected *distribution
+	}{
+		{
+			name: "Empty",
+			expected: &distribution{
+				histogram: []int{0, 0, 0, 0},
+			},
+		},
+		{
+			name:   "Single",
+			delays: []time.Duration{1500 * time.Millisecond},
+			expected: &distribution{
+				count:     1,
+				min:       1500 * time.Millisecond,
+				mean:      1500 * time.Millisecond,
+				median:    1500 * time.Millisecond,
+				p90:       1500 * time.Millisecond,
+				max:       1500 * time.Millisecond,
+				histogram: []int{0, 1, 0, 0},
+			},
+		},
+		{
+			name: "Multiple",
+			delays: []time.Duration{
+				4 * time.Second,
+				500 * time.Millisecond,
+				time.Second,
+				1500 * time.Millisecond,
+				3 * time.Second,
+			},
+			expected: &distribution{
+				count:     5,
+				min:       500 * time.Millisecond,
+				mean:      2 * time.Second,
+				median:    1500 * time.Millisecond,
+				p90:       4 * time.Second,
+				max:       4 * time.Second,
+				histogram: []int{1, 2, 0, 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, calculateDistribution(test.delays, 4*time.Second))
+		})
+	}
+}
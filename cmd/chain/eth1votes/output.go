@@ -25,7 +25,7 @@ import (
 
 type jsonOutput struct {
 	Period    uint64           `json:"period"`
-	Epoch     phase0.Epoch     `json:"epoch"`
+	Epoch     uint64           `json:"epoch"`
 	Slot      phase0.Slot      `json:"slot"`
 	Incumbent *phase0.ETH1Data `json:"incumbent"`
 	Votes     []*vote          `json:"votes"`
@@ -58,7 +58,7 @@ func (c *command) outputJSON(_ context.Context) (string, error) {
 
 	output := &jsonOutput{
 		Period:    c.period,
-		Epoch:     c.epoch,
+		Epoch:     uint64(c.epoch),
 		Slot:      c.slot,
 		Incumbent: c.incumbent,
 		Votes:     votes,
@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainexitrate
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	epoch     string
+	threshold uint64
+	history   uint64
+	watch     bool
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+	eventsProvider eth2client.EventsProvider
+
+	// Processing.
+	epochExits map[phase0.Epoch]uint64
+
+	// Output.
+	results *results
+}
+
+// results is the report for a single epoch.  Alert is set if the number of
+// voluntary exits in the epoch exceeds the threshold.
+type results struct {
+	Epoch     uint64        `json:"epoch"`
+	Exits     uint64        `json:"exits"`
+	Threshold uint64        `json:"threshold"`
+	Alert     bool          `json:"alert"`
+	History   []*epochExits `json:"history"`
+	// Average is the mean number of exits per epoch over the history.
+	Average float64 `json:"average"`
+}
+
+type epochExits struct {
+	Epoch uint64 `json:"epoch"`
+	Exits uint64 `json:"exits"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		epochExits: make(map[phase0.Epoch]uint64),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.epoch = viper.GetString("epoch")
+	c.threshold = viper.GetUint64("threshold")
+	c.history = viper.GetUint64("history")
+	c.watch = viper.GetBool("watch")
+	if c.watch && c.epoch != "" {
+		return nil, errors.New("epoch cannot be supplied with watch")
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
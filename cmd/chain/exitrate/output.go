@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainexitrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the report as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the report as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epoch %d: %d voluntary exits\n", c.results.Epoch, c.results.Exits))
+	if c.results.Alert {
+		builder.WriteString(fmt.Sprintf("ALERT: exceeds threshold of %d\n", c.results.Threshold))
+	} else if c.verbose {
+		builder.WriteString(fmt.Sprintf("Within threshold of %d\n", c.results.Threshold))
+	}
+
+	if len(c.results.History) > 0 {
+		exits := make([]string, 0, len(c.results.History))
+		for _, item := range c.results.History {
+			exits = append(exits, fmt.Sprintf("%d", item.Exits))
+		}
+		builder.WriteString(fmt.Sprintf("Previous %d epochs (%d-%d): %s (average %.2f)\n",
+			len(c.results.History),
+			c.results.History[0].Epoch,
+			c.results.History[len(c.results.History)-1].Epoch,
+			strings.Join(exits, " "),
+			c.results.Average,
+		))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
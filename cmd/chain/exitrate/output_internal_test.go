@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainexitrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutput(t *testing.T) {
+	history := []*epochExits{
+		{Epoch: 98, Exits: 1},
+		{Epoch: 99, Exits: 2},
+	}
+
+	tests := []struct {
+		name   string
+		c      *command
+		format output.Format
+		res    string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+			res: "",
+		},
+		{
+			name: "Text",
+			c: &command{
+				results: newResults(100, 3, 10, history),
+			},
+			format: output.Text,
+			res:    "Epoch 100: 3 voluntary exits\nPrevious 2 epochs (98-99): 1 2 (average 1.50)",
+		},
+		{
+			name: "TextVerbose",
+			c: &command{
+				verbose: true,
+				results: newResults(100, 3, 10, []*epochExits{}),
+			},
+			format: output.Text,
+			res:    "Epoch 100: 3 voluntary exits\nWithin threshold of 10",
+		},
+		{
+			name: "TextAlert",
+			c: &command{
+				results: newResults(100, 30, 10, history),
+			},
+			format: output.Text,
+			res:    "Epoch 100: 30 voluntary exits\nALERT: exceeds threshold of 10\nPrevious 2 epochs (98-99): 1 2 (average 1.50)",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				results: newResults(100, 30, 10, history),
+			},
+			format: output.JSON,
+			res:    `{"epoch":100,"exits":30,"threshold":10,"alert":true,"history":[{"epoch":98,"exits":1},{"epoch":99,"exits":2}],"average":1.5}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.format = test.format
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
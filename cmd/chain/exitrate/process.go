@@ -0,0 +1,246 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainexitrate
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epochStr := c.epoch
+	if epochStr == "" {
+		// Default to the last complete epoch.
+		epochStr = "last"
+	}
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, epochStr)
+	if err != nil {
+		return err
+	}
+
+	c.results, err = c.report(ctx, epoch)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// watchEpochs reports on each epoch as it completes, until the context is
+// cancelled.
+func (c *command) watchEpochs(ctx context.Context) error {
+	stream := output.NewStream(c.format)
+	render := func() error {
+		if c.quiet {
+			return nil
+		}
+		res, err := stream.Render(ctx, c)
+		if err != nil {
+			return errors.Wrap(err, "failed to render report")
+		}
+		fmt.Println(res)
+
+		return nil
+	}
+	if err := render(); err != nil {
+		return err
+	}
+
+	epochs := make(chan phase0.Epoch, 1)
+	lastEpoch := phase0.Epoch(c.results.Epoch)
+	if err := c.eventsProvider.Events(ctx, &api.EventsOpts{
+		Topics: []string{"head"},
+		Handler: func(event *apiv1.Event) {
+			headEvent, isHeadEvent := event.Data.(*apiv1.HeadEvent)
+			if !isHeadEvent {
+				return
+			}
+			// Only report on an epoch once the chain has moved past it.
+			epoch := c.chainTime.SlotToEpoch(headEvent.Slot)
+			if epoch == 0 || epoch-1 <= lastEpoch {
+				return
+			}
+			lastEpoch = epoch - 1
+			select {
+			case epochs <- lastEpoch:
+			default:
+				// A report is already pending.
+			}
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to connect for events")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case epoch := <-epochs:
+			var err error
+			c.results, err = c.report(ctx, epoch)
+			if err != nil {
+				return err
+			}
+			if err := render(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// report generates the report for the given epoch.
+func (c *command) report(ctx context.Context, epoch phase0.Epoch) (*results, error) {
+	exits, err := c.exitsInEpoch(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*epochExits, 0, c.history)
+	firstEpoch := phase0.Epoch(0)
+	if phase0.Epoch(c.history) < epoch {
+		firstEpoch = epoch - phase0.Epoch(c.history)
+	}
+	for historyEpoch := firstEpoch; historyEpoch < epoch; historyEpoch++ {
+		historyExits, err := c.exitsInEpoch(ctx, historyEpoch)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, &epochExits{
+			Epoch: uint64(historyEpoch),
+			Exits: historyExits,
+		})
+	}
+
+	// Drop epochs that are no longer required by the history.
+	for cachedEpoch := range c.epochExits {
+		if cachedEpoch < firstEpoch {
+			delete(c.epochExits, cachedEpoch)
+		}
+	}
+
+	return newResults(epoch, exits, c.threshold, history), nil
+}
+
+// newResults creates the results for an epoch.
+func newResults(epoch phase0.Epoch,
+	exits uint64,
+	threshold uint64,
+	history []*epochExits,
+) *results {
+	res := &results{
+		Epoch:     uint64(epoch),
+		Exits:     exits,
+		Threshold: threshold,
+		Alert:     exits > threshold,
+		History:   history,
+	}
+	if len(history) > 0 {
+		total := uint64(0)
+		for _, item := range history {
+			total += item.Exits
+		}
+		res.Average = float64(total) / float64(len(history))
+	}
+
+	return res
+}
+
+// exitsInEpoch returns the number of voluntary exits included in blocks in the epoch.
+func (c *command) exitsInEpoch(ctx context.Context, epoch phase0.Epoch) (uint64, error) {
+	if exits, exists := c.epochExits[epoch]; exists {
+		return exits, nil
+	}
+
+	exits := uint64(0)
+	for slot := c.chainTime.FirstSlotOfEpoch(epoch); slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// No block at this slot.
+			continue
+		}
+		voluntaryExits, err := blockVoluntaryExits(block)
+		if err != nil {
+			return 0, err
+		}
+		exits += uint64(len(voluntaryExits))
+	}
+	c.epochExits[epoch] = exits
+
+	return exits, nil
+}
+
+// blockVoluntaryExits returns the voluntary exits included in a block.
+func blockVoluntaryExits(block *spec.VersionedSignedBeaconBlock) ([]*phase0.SignedVoluntaryExit, error) {
+	voluntaryExits, err := block.VoluntaryExits()
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain voluntary exits for %v block", block.Version))
+	}
+
+	return voluntaryExits, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	if c.watch {
+		c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+		if !isProvider {
+			return errors.New("connection does not provide events")
+		}
+	}
+
+	return nil
+}
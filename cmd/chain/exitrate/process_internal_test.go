@@ -0,0 +1,122 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainexitrate
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResults(t *testing.T) {
+	history := []*epochExits{
+		{Epoch: 98, Exits: 1},
+		{Epoch: 99, Exits: 2},
+	}
+
+	tests := []struct {
+		name      string
+		exits     uint64
+		threshold uint64
+		history   []*epochExits
+		expected  *results
+	}{
+		{
+			name:      "NoHistory",
+			exits:     3,
+			threshold: 10,
+			history:   []*epochExits{},
+			expected: &results{
+				Epoch:     100,
+				Exits:     3,
+				Threshold: 10,
+				History:   []*epochExits{},
+			},
+		},
+		{
+			name:      "AtThreshold",
+			exits:     10,
+			threshold: 10,
+			history:   history,
+			expected: &results{
+				Epoch:     100,
+				Exits:     10,
+				Threshold: 10,
+				History:   history,
+				Average:   1.5,
+			},
+		},
+		{
+			name:      "AboveThreshold",
+			exits:     11,
+			threshold: 10,
+			history:   history,
+			expected: &results{
+				Epoch:     100,
+				Exits:     11,
+				Threshold: 10,
+				Alert:     true,
+				History:   history,
+				Average:   1.5,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := newResults(100, test.exits, test.threshold, test.history)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestBlockVoluntaryExits(t *testing.T) {
+	exits := []*phase0.SignedVoluntaryExit{
+		{Message: &phase0.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}},
+		{Message: &phase0.VoluntaryExit{Epoch: 1, ValidatorIndex: 3}},
+	}
+
+	res, err := blockVoluntaryExits(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Body: &capella.BeaconBlockBody{
+					VoluntaryExits: exits,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, exits, res)
+
+	res, err = blockVoluntaryExits(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Body: &electra.BeaconBlockBody{
+					VoluntaryExits: exits,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, exits, res)
+
+	_, err = blockVoluntaryExits(&spec.VersionedSignedBeaconBlock{})
+	require.EqualError(t, err, "failed to obtain voluntary exits for unknown block: unknown version")
+}
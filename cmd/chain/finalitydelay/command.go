@@ -0,0 +1,112 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinalitydelay
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	fromEpoch string
+	toEpoch   string
+	threshold uint64
+
+	// Data access.
+	eth2Client                eth2client.Service
+	chainTime                 chaintime.Service
+	finalityProvider          eth2client.FinalityProvider
+	beaconCommitteesProvider  eth2client.BeaconCommitteesProvider
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	FromEpoch uint64      `json:"from_epoch"`
+	ToEpoch   uint64      `json:"to_epoch"`
+	Threshold uint64      `json:"threshold"`
+	Incidents []*incident `json:"incidents"`
+}
+
+// incident is a period of consecutive epochs in which finality lagged by more than the threshold.
+type incident struct {
+	StartEpoch     uint64 `json:"start_epoch"`
+	EndEpoch       uint64 `json:"end_epoch"`
+	StartTimestamp int64  `json:"start_timestamp"`
+	EndTimestamp   int64  `json:"end_timestamp"`
+	// Ongoing is true if the incident had not ended by the end of the range.
+	Ongoing  bool   `json:"ongoing"`
+	MaxDelay uint64 `json:"max_delay"`
+	// Participation values are percentages of validators with attestations included on-chain.
+	MinParticipation  float64          `json:"min_participation"`
+	MeanParticipation float64          `json:"mean_participation"`
+	Epochs            []*epochFinality `json:"epochs"`
+}
+
+// epochFinality is the state of finality at the start of an epoch.
+type epochFinality struct {
+	Epoch          uint64  `json:"epoch"`
+	FinalizedEpoch uint64  `json:"finalized_epoch"`
+	Delay          uint64  `json:"delay"`
+	Participation  float64 `json:"participation"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	c.threshold = viper.GetUint64("threshold")
+	if c.threshold < 2 {
+		return nil, errors.New("threshold must be at least 2")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinalitydelay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.results.Incidents) == 0 {
+		builder.WriteString(fmt.Sprintf("No finality delays of more than %d epochs in epochs %d-%d", c.results.Threshold, c.results.FromEpoch, c.results.ToEpoch))
+		return builder.String(), nil
+	}
+
+	builder.WriteString(fmt.Sprintf("Finality delays of more than %d epochs in epochs %d-%d: %d\n", c.results.Threshold, c.results.FromEpoch, c.results.ToEpoch, len(c.results.Incidents)))
+	for i, incident := range c.results.Incidents {
+		builder.WriteString(fmt.Sprintf("Incident %d: epochs %d-%d (%s to %s)",
+			i+1,
+			incident.StartEpoch,
+			incident.EndEpoch,
+			time.Unix(incident.StartTimestamp, 0).Format("2006-01-02 15:04:05"),
+			time.Unix(incident.EndTimestamp, 0).Format("2006-01-02 15:04:05"),
+		))
+		if incident.Ongoing {
+			builder.WriteString(", ongoing at end of range")
+		}
+		builder.WriteString("\n")
+		builder.WriteString(fmt.Sprintf("  Epochs affected: %d\n", len(incident.Epochs)))
+		builder.WriteString(fmt.Sprintf("  Maximum delay: %d epochs\n", incident.MaxDelay))
+		builder.WriteString(fmt.Sprintf("  Participation: minimum %.2f%%, mean %.2f%%\n", incident.MinParticipation, incident.MeanParticipation))
+		if c.verbose {
+			for _, epoch := range incident.Epochs {
+				builder.WriteString(fmt.Sprintf("    Epoch %d: finalized epoch %d, delay %d epochs, participation %.2f%%\n", epoch.Epoch, epoch.FinalizedEpoch, epoch.Delay, epoch.Participation))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
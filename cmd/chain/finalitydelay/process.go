@@ -0,0 +1,275 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinalitydelay
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// slotCommittees maps slots and committee indices to the validators in each committee.
+type slotCommittees map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch must not be before from epoch")
+	}
+
+	epochs := make([]*epochFinality, 0, int(toEpoch-fromEpoch)+1)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if c.debug && (epoch-fromEpoch)%100 == 0 {
+			fmt.Fprintf(os.Stderr, "Processing epoch %d of %d-%d\n", epoch, fromEpoch, toEpoch)
+		}
+		finalityResponse, err := c.finalityProvider.Finality(ctx, &api.FinalityOpts{State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain finality for epoch %d", epoch))
+		}
+		finality := finalityResponse.Data
+		if finality == nil || finality.Finalized == nil {
+			return fmt.Errorf("no finality returned for epoch %d", epoch)
+		}
+		epochs = append(epochs, &epochFinality{
+			Epoch:          uint64(epoch),
+			FinalizedEpoch: uint64(finality.Finalized.Epoch),
+			Delay:          finalityDelay(epoch, finality.Finalized.Epoch),
+		})
+	}
+
+	c.results = &results{
+		FromEpoch: uint64(fromEpoch),
+		ToEpoch:   uint64(toEpoch),
+		Threshold: c.threshold,
+		Incidents: findIncidents(epochs, c.threshold),
+	}
+
+	for _, incident := range c.results.Incidents {
+		incident.StartTimestamp = c.chainTime.StartOfEpoch(phase0.Epoch(incident.StartEpoch)).Unix()
+		incident.EndTimestamp = c.chainTime.StartOfEpoch(phase0.Epoch(incident.EndEpoch + 1)).Unix()
+		if err := c.incidentParticipation(ctx, incident); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// incidentParticipation calculates the participation for each epoch of an incident.
+func (c *command) incidentParticipation(ctx context.Context, incident *incident) error {
+	committees := make(slotCommittees)
+	expected := make(map[phase0.Epoch]int)
+	for epoch := phase0.Epoch(incident.StartEpoch); epoch <= phase0.Epoch(incident.EndEpoch); epoch++ {
+		beaconCommitteesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain beacon committees for epoch %d", epoch))
+		}
+		beaconCommittees := beaconCommitteesResponse.Data
+		for _, committee := range beaconCommittees {
+			if c.chainTime.SlotToEpoch(committee.Slot) != epoch {
+				continue
+			}
+			if _, exists := committees[committee.Slot]; !exists {
+				committees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			}
+			committees[committee.Slot][committee.Index] = committee.Validators
+			expected[epoch] += len(committee.Validators)
+		}
+	}
+
+	// Attestations for an epoch can be included up to the end of the following epoch.
+	votes := make(map[phase0.Epoch]map[phase0.ValidatorIndex]struct{})
+	lastSlot := c.chainTime.LastSlotOfEpoch(phase0.Epoch(incident.EndEpoch + 1))
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := c.chainTime.FirstSlotOfEpoch(phase0.Epoch(incident.StartEpoch)) + 1; slot <= lastSlot; slot++ {
+		block, err := util.ResponseData(c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return err
+		}
+		recordVotes(votes, committees, attestations)
+	}
+
+	for _, epoch := range incident.Epochs {
+		if expected[phase0.Epoch(epoch.Epoch)] > 0 {
+			epoch.Participation = 100 * float64(len(votes[phase0.Epoch(epoch.Epoch)])) / float64(expected[phase0.Epoch(epoch.Epoch)])
+		}
+	}
+	summariseParticipation(incident)
+
+	return nil
+}
+
+// finalityDelay returns the number of epochs by which finality trails the given epoch.
+func finalityDelay(epoch phase0.Epoch, finalizedEpoch phase0.Epoch) uint64 {
+	if finalizedEpoch >= epoch {
+		return 0
+	}
+
+	return uint64(epoch - finalizedEpoch)
+}
+
+// findIncidents groups consecutive epochs whose finality delay is above the
+// threshold in to incidents.
+func findIncidents(epochs []*epochFinality, threshold uint64) []*incident {
+	res := make([]*incident, 0)
+	var current *incident
+	for i, epoch := range epochs {
+		if epoch.Delay <= threshold {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &incident{
+				StartEpoch: epoch.Epoch,
+				Epochs:     make([]*epochFinality, 0),
+			}
+			res = append(res, current)
+		}
+		current.EndEpoch = epoch.Epoch
+		current.Epochs = append(current.Epochs, epoch)
+		if epoch.Delay > current.MaxDelay {
+			current.MaxDelay = epoch.Delay
+		}
+		current.Ongoing = i == len(epochs)-1
+	}
+
+	return res
+}
+
+// recordVotes records the validators that attested in each epoch.
+func recordVotes(votes map[phase0.Epoch]map[phase0.ValidatorIndex]struct{},
+	committees slotCommittees,
+	attestations []*spec.VersionedAttestation,
+) {
+	for _, attestation := range attestations {
+		if attestation == nil {
+			continue
+		}
+		data, err := attestation.Data()
+		if err != nil || data == nil || data.Target == nil {
+			continue
+		}
+		attestationVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+			committee, exists := committees[data.Slot][committeeIndex]
+			if !exists {
+				return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, data.Slot)
+			}
+
+			return uint64(len(committee)), nil
+		})
+		if err != nil {
+			continue
+		}
+		epoch := data.Target.Epoch
+		for committeeIndex, committeeVotes := range attestationVotes {
+			committee, exists := committees[data.Slot][committeeIndex]
+			if !exists {
+				continue
+			}
+			if _, exists := votes[epoch]; !exists {
+				votes[epoch] = make(map[phase0.ValidatorIndex]struct{})
+			}
+			for i := range committee {
+				if committeeVotes.BitAt(uint64(i)) {
+					votes[epoch][committee[i]] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// summariseParticipation calculates the minimum and mean participation of an incident.
+func summariseParticipation(incident *incident) {
+	if len(incident.Epochs) == 0 {
+		return
+	}
+
+	incident.MinParticipation = incident.Epochs[0].Participation
+	total := 0.0
+	for _, epoch := range incident.Epochs {
+		if epoch.Participation < incident.MinParticipation {
+			incident.MinParticipation = epoch.Participation
+		}
+		total += epoch.Participation
+	}
+	incident.MeanParticipation = total / float64(len(incident.Epochs))
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality information")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	return nil
+}
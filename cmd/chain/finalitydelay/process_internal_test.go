@@ -0,0 +1,187 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinalitydelay
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityDelay(t *testing.T) {
+	require.Equal(t, uint64(0), finalityDelay(0, 0))
+	require.Equal(t, uint64(0), finalityDelay(5, 6))
+	require.Equal(t, uint64(2), finalityDelay(10, 8))
+	require.Equal(t, uint64(7), finalityDelay(10, 3))
+}
+
+func epochsWithDelays(start phase0.Epoch, delays ...uint64) []*epochFinality {
+	res := make([]*epochFinality, 0, len(delays))
+	for i, delay := range delays {
+		epoch := start + phase0.Epoch(i)
+		res = append(res, &epochFinality{
+			Epoch:          uint64(epoch),
+			FinalizedEpoch: uint64(epoch) - delay,
+			Delay:          delay,
+		})
+	}
+
+	return res
+}
+
+func TestFindIncidents(t *testing.T) {
+	tests := []struct {
+		name      string
+		epochs    []*epochFinality
+		threshold uint64
+		expected  []*incident
+	}{
+		{
+			name:      "Empty",
+			epochs:    []*epochFinality{},
+			threshold: 2,
+			expected:  []*incident{},
+		},
+		{
+			name:      "None",
+			epochs:    epochsWithDelays(100, 2, 2, 2, 2),
+			threshold: 2,
+			expected:  []*incident{},
+		},
+		{
+			name:      "Single",
+			epochs:    epochsWithDelays(100, 2, 3, 4, 5, 2),
+			threshold: 2,
+			expected: []*incident{
+				{StartEpoch: 101, EndEpoch: 103, MaxDelay: 5, Epochs: epochsWithDelays(101, 3, 4, 5)},
+			},
+		},
+		{
+			name:      "Multiple",
+			epochs:    epochsWithDelays(100, 3, 2, 2, 4, 3, 2),
+			threshold: 2,
+			expected: []*incident{
+				{StartEpoch: 100, EndEpoch: 100, MaxDelay: 3, Epochs: epochsWithDelays(100, 3)},
+				{StartEpoch: 103, EndEpoch: 104, MaxDelay: 4, Epochs: epochsWithDelays(103, 4, 3)},
+			},
+		},
+		{
+			name:      "Ongoing",
+			epochs:    epochsWithDelays(100, 2, 3, 4),
+			threshold: 2,
+			expected: []*incident{
+				{StartEpoch: 101, EndEpoch: 102, MaxDelay: 4, Ongoing: true, Epochs: epochsWithDelays(101, 3, 4)},
+			},
+		},
+		{
+			name:      "HigherThreshold",
+			epochs:    epochsWithDelays(100, 3, 4, 5, 3),
+			threshold: 3,
+			expected: []*incident{
+				{StartEpoch: 101, EndEpoch: 102, MaxDelay: 5, Epochs: epochsWithDelays(101, 4, 5)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, findIncidents(test.epochs, test.threshold))
+		})
+	}
+}
+
+func TestRecordVotes(t *testing.T) {
+	committees := slotCommittees{
+		32: {
+			0: {1, 2, 3, 4},
+			1: {5, 6, 7, 8},
+		},
+		64: {
+			0: {9, 10},
+		},
+	}
+
+	bits := func(set ...uint64) bitfield.Bitlist {
+		res := bitfield.NewBitlist(4)
+		for _, i := range set {
+			res.SetBitAt(i, true)
+		}
+		return res
+	}
+	attestation := func(slot phase0.Slot, index phase0.CommitteeIndex, epoch phase0.Epoch, set ...uint64) *spec.VersionedAttestation {
+		return &spec.VersionedAttestation{
+			Version: spec.DataVersionDeneb,
+			Deneb: &phase0.Attestation{
+				AggregationBits: bits(set...),
+				Data: &phase0.AttestationData{
+					Slot:   slot,
+					Index:  index,
+					Source: &phase0.Checkpoint{},
+					Target: &phase0.Checkpoint{Epoch: epoch},
+				},
+			},
+		}
+	}
+
+	votes := make(map[phase0.Epoch]map[phase0.ValidatorIndex]struct{})
+	recordVotes(votes, committees, []*spec.VersionedAttestation{
+		attestation(32, 0, 1, 0, 2),
+		attestation(32, 1, 1, 3),
+		// Duplicate vote.
+		attestation(32, 0, 1, 0),
+		attestation(64, 0, 2, 1),
+		// Unknown committee.
+		attestation(32, 5, 1, 0),
+		nil,
+		// Multiple committees.
+		{
+			Version: spec.DataVersionElectra,
+			Electra: &electra.Attestation{
+				AggregationBits: bitfield.Bitlist{0x42, 0x01},
+				Data: &phase0.AttestationData{
+					Slot:   32,
+					Source: &phase0.Checkpoint{},
+					Target: &phase0.Checkpoint{Epoch: 1},
+				},
+				CommitteeBits: bitfield.Bitvector64{0x03, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	})
+
+	require.Equal(t, map[phase0.Epoch]map[phase0.ValidatorIndex]struct{}{
+		1: {1: {}, 2: {}, 3: {}, 7: {}, 8: {}},
+		2: {10: {}},
+	}, votes)
+}
+
+func TestSummariseParticipation(t *testing.T) {
+	data := &incident{
+		Epochs: []*epochFinality{
+			{Epoch: 1, Participation: 60},
+			{Epoch: 2, Participation: 40},
+			{Epoch: 3, Participation: 50},
+		},
+	}
+	summariseParticipation(data)
+	require.InDelta(t, 40.0, data.MinParticipation, 0.0001)
+	require.InDelta(t, 50.0, data.MeanParticipation, 0.0001)
+
+	empty := &incident{}
+	summariseParticipation(empty)
+	require.Zero(t, empty.MinParticipation)
+}
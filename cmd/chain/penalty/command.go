@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpenalty
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	balances   []phase0.Gwei
+	electra    bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Electra            bool                `json:"electra"`
+	Epoch              uint64              `json:"epoch"`
+	TotalActiveBalance phase0.Gwei         `json:"total_active_balance"`
+	ExistingSlashed    phase0.Gwei         `json:"existing_slashed_balance"`
+	OperationSlashed   phase0.Gwei         `json:"operation_slashed_balance"`
+	Validators         []*validatorPenalty `json:"validators"`
+	InitialPenalty     phase0.Gwei         `json:"initial_penalty"`
+	CorrelationPenalty phase0.Gwei         `json:"correlation_penalty"`
+	AttestationPenalty phase0.Gwei         `json:"attestation_penalty"`
+	TotalPenalty       phase0.Gwei         `json:"total_penalty"`
+}
+
+type validatorPenalty struct {
+	Name               string      `json:"name"`
+	Balance            phase0.Gwei `json:"balance"`
+	EffectiveBalance   phase0.Gwei `json:"effective_balance"`
+	InitialPenalty     phase0.Gwei `json:"initial_penalty"`
+	CorrelationPenalty phase0.Gwei `json:"correlation_penalty"`
+	AttestationPenalty phase0.Gwei `json:"attestation_penalty"`
+	TotalPenalty       phase0.Gwei `json:"total_penalty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	for _, balanceStr := range viper.GetStringSlice("balances") {
+		balanceStr = strings.TrimSpace(balanceStr)
+		if balanceStr != "" && strings.ContainsAny(balanceStr[len(balanceStr)-1:], "0123456789.") {
+			// Balances without a unit are in Ether.
+			balanceStr = fmt.Sprintf("%s Ether", balanceStr)
+		}
+		balance, err := string2eth.StringToGWei(balanceStr)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid balance %q", balanceStr))
+		}
+		c.balances = append(c.balances, phase0.Gwei(balance))
+	}
+	if len(c.validators) == 0 && len(c.balances) == 0 {
+		return nil, errors.New("one of validators or balances is required")
+	}
+
+	c.electra = viper.GetBool("electra")
+
+	return c, nil
+}
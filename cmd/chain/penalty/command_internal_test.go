@@ -0,0 +1,87 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpenalty
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		balances []phase0.Gwei
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validators": []string{"1"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "Missing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "one of validators or balances is required",
+		},
+		{
+			name: "BalanceInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"balances": []string{"invalid"},
+			},
+			err: `invalid balance "invalid": failed to parse numeric value of  invalid`,
+		},
+		{
+			name: "Validators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1", "2"},
+			},
+		},
+		{
+			name: "Balances",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"balances": []string{"32", "33.5", "2048 Ether", "1000000gwei"},
+			},
+			balances: []phase0.Gwei{32000000000, 33500000000, 2048000000000, 1000000},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.balances, cmd.balances)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpenalty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.Electra {
+		builder.WriteString("Formulas: Electra\n")
+	} else {
+		builder.WriteString("Formulas: Bellatrix\n")
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.results.Epoch))
+		builder.WriteString(fmt.Sprintf("Total active balance: %s\n", string2eth.GWeiToString(uint64(c.results.TotalActiveBalance), true)))
+		builder.WriteString(fmt.Sprintf("Existing slashed balance: %s\n", string2eth.GWeiToString(uint64(c.results.ExistingSlashed), true)))
+	}
+	builder.WriteString(fmt.Sprintf("Operation slashed balance: %s\n", string2eth.GWeiToString(uint64(c.results.OperationSlashed), true)))
+
+	if c.verbose {
+		for _, validator := range c.results.Validators {
+			builder.WriteString(fmt.Sprintf("Validator %s:\n", validator.Name))
+			builder.WriteString(fmt.Sprintf("  Balance: %s\n", string2eth.GWeiToString(uint64(validator.Balance), true)))
+			builder.WriteString(fmt.Sprintf("  Effective balance: %s\n", string2eth.GWeiToString(uint64(validator.EffectiveBalance), true)))
+			builder.WriteString(fmt.Sprintf("  Initial penalty: %s\n", string2eth.GWeiToString(uint64(validator.InitialPenalty), true)))
+			builder.WriteString(fmt.Sprintf("  Correlation penalty: %s\n", string2eth.GWeiToString(uint64(validator.CorrelationPenalty), true)))
+			builder.WriteString(fmt.Sprintf("  Attestation penalty: %s\n", string2eth.GWeiToString(uint64(validator.AttestationPenalty), true)))
+			builder.WriteString(fmt.Sprintf("  Total penalty: %s\n", string2eth.GWeiToString(uint64(validator.TotalPenalty), true)))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Initial penalty: %s\n", string2eth.GWeiToString(uint64(c.results.InitialPenalty), true)))
+	builder.WriteString(fmt.Sprintf("Correlation penalty: %s\n", string2eth.GWeiToString(uint64(c.results.CorrelationPenalty), true)))
+	builder.WriteString(fmt.Sprintf("Attestation penalty: %s\n", string2eth.GWeiToString(uint64(c.results.AttestationPenalty), true)))
+	builder.WriteString(fmt.Sprintf("Total penalty: %s\n", string2eth.GWeiToString(uint64(c.results.TotalPenalty), true)))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,363 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpenalty
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Incentivization weights, from
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#incentivization-weights
+const (
+	timelySourceWeight = 14
+	timelyTargetWeight = 26
+	weightDenominator  = 64
+)
+
+// parameters are the chain parameters used to calculate penalties.
+type parameters struct {
+	electra                        bool
+	effectiveBalanceIncrement      phase0.Gwei
+	maxEffectiveBalance            phase0.Gwei
+	minSlashingPenaltyQuotient     uint64
+	proportionalSlashingMultiplier uint64
+	epochsPerSlashingsVector       uint64
+	baseRewardFactor               uint64
+}
+
+// entry is a validator involved in the slashing operation.
+type entry struct {
+	name             string
+	balance          phase0.Gwei
+	effectiveBalance phase0.Gwei
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epoch := c.chainTime.CurrentEpoch()
+	params, err := c.obtainParameters(ctx, epoch)
+	if err != nil {
+		return err
+	}
+
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+	totalActiveBalance, existingSlashed := chainBalances(params, epoch, validators)
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Total active balance: %d\n", totalActiveBalance)
+		fmt.Fprintf(os.Stderr, "Existing slashed balance: %d\n", existingSlashed)
+	}
+
+	entries := make([]*entry, 0, len(c.validators)+len(c.balances))
+	if len(c.validators) > 0 {
+		operationValidators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+		if err != nil {
+			return err
+		}
+		for _, validator := range operationValidators {
+			entries = append(entries, &entry{
+				name:             fmt.Sprintf("%d", validator.Index),
+				balance:          validator.Balance,
+				effectiveBalance: validator.Validator.EffectiveBalance,
+			})
+		}
+	}
+	for i, balance := range c.balances {
+		entries = append(entries, &entry{
+			name:             fmt.Sprintf("balance %d", i),
+			balance:          balance,
+			effectiveBalance: effectiveBalance(params, balance),
+		})
+	}
+
+	c.results = calculate(params, entries, totalActiveBalance, existingSlashed)
+	c.results.Epoch = uint64(epoch)
+
+	return nil
+}
+
+// chainBalances returns the total active balance of the chain, and the total
+// effective balance of existing slashed validators whose slashings will still
+// be counted at the midpoint of the withdrawability delay of a validator
+// slashed in the given epoch.
+func chainBalances(params *parameters,
+	epoch phase0.Epoch,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+) (
+	phase0.Gwei,
+	phase0.Gwei,
+) {
+	totalActiveBalance := phase0.Gwei(0)
+	existingSlashed := phase0.Gwei(0)
+	midpoint := epoch + phase0.Epoch(params.epochsPerSlashingsVector/2)
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+		// The slashed balance recorded for a validator is its effective balance at the time of
+		// slashing; its current effective balance is used as an approximation.
+		if validator.Validator.Slashed && validator.Validator.WithdrawableEpoch > midpoint {
+			existingSlashed += validator.Validator.EffectiveBalance
+		}
+	}
+	if totalActiveBalance < params.effectiveBalanceIncrement {
+		totalActiveBalance = params.effectiveBalanceIncrement
+	}
+
+	return totalActiveBalance, existingSlashed
+}
+
+// calculate calculates the penalties for the slashing of the given validators.
+func calculate(params *parameters,
+	entries []*entry,
+	totalActiveBalance phase0.Gwei,
+	existingSlashed phase0.Gwei,
+) *results {
+	res := &results{
+		Electra:            params.electra,
+		TotalActiveBalance: totalActiveBalance,
+		ExistingSlashed:    existingSlashed,
+		Validators:         make([]*validatorPenalty, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		res.OperationSlashed += entry.effectiveBalance
+	}
+
+	// Total slashed balance as it will stand at the midpoint of the withdrawability delay.
+	adjustedSlashed := (existingSlashed + res.OperationSlashed) * phase0.Gwei(params.proportionalSlashingMultiplier)
+	if adjustedSlashed > totalActiveBalance {
+		adjustedSlashed = totalActiveBalance
+	}
+
+	baseRewardPerIncrement := uint64(params.effectiveBalanceIncrement) * params.baseRewardFactor / integerSquareRoot(uint64(totalActiveBalance))
+
+	for _, entry := range entries {
+		remaining := entry.balance
+		penalty := &validatorPenalty{
+			Name:             entry.name,
+			Balance:          entry.balance,
+			EffectiveBalance: entry.effectiveBalance,
+		}
+
+		penalty.InitialPenalty = capPenalty(entry.effectiveBalance/phase0.Gwei(params.minSlashingPenaltyQuotient), &remaining)
+
+		// Subsequent penalties are based on the effective balance after the initial penalty.
+		postEffectiveBalance := updatedEffectiveBalance(params, entry.effectiveBalance, remaining)
+		increments := uint64(postEffectiveBalance / params.effectiveBalanceIncrement)
+
+		// Missed source and target penalties until the validator is withdrawable.
+		baseReward := increments * baseRewardPerIncrement
+		perEpoch := baseReward*timelySourceWeight/weightDenominator + baseReward*timelyTargetWeight/weightDenominator
+		penalty.AttestationPenalty = capPenalty(phase0.Gwei(perEpoch*params.epochsPerSlashingsVector), &remaining)
+
+		var correlationPenalty phase0.Gwei
+		if params.electra {
+			penaltyPerIncrement := adjustedSlashed / (totalActiveBalance / params.effectiveBalanceIncrement)
+			correlationPenalty = penaltyPerIncrement * phase0.Gwei(increments)
+		} else {
+			// Use big integers to avoid overflow.
+			numerator := new(big.Int).Mul(new(big.Int).SetUint64(increments), new(big.Int).SetUint64(uint64(adjustedSlashed)))
+			quotient := new(big.Int).Div(numerator, new(big.Int).SetUint64(uint64(totalActiveBalance)))
+			correlationPenalty = phase0.Gwei(quotient.Uint64()) * params.effectiveBalanceIncrement
+		}
+		penalty.CorrelationPenalty = capPenalty(correlationPenalty, &remaining)
+
+		penalty.TotalPenalty = penalty.InitialPenalty + penalty.AttestationPenalty + penalty.CorrelationPenalty
+
+		res.InitialPenalty += penalty.InitialPenalty
+		res.AttestationPenalty += penalty.AttestationPenalty
+		res.CorrelationPenalty += penalty.CorrelationPenalty
+		res.TotalPenalty += penalty.TotalPenalty
+		res.Validators = append(res.Validators, penalty)
+	}
+
+	return res
+}
+
+// capPenalty caps a penalty at the remaining balance, and reduces the
+// remaining balance accordingly.
+func capPenalty(penalty phase0.Gwei, remaining *phase0.Gwei) phase0.Gwei {
+	if penalty > *remaining {
+		penalty = *remaining
+	}
+	*remaining -= penalty
+
+	return penalty
+}
+
+// effectiveBalance calculates the effective balance for a new balance.
+func effectiveBalance(params *parameters, balance phase0.Gwei) phase0.Gwei {
+	res := balance - balance%params.effectiveBalanceIncrement
+	if res > params.maxEffectiveBalance {
+		res = params.maxEffectiveBalance
+	}
+
+	return res
+}
+
+// updatedEffectiveBalance calculates the effective balance following a
+// reduction in balance, taking hysteresis in to account.
+func updatedEffectiveBalance(params *parameters, current phase0.Gwei, balance phase0.Gwei) phase0.Gwei {
+	// Hysteresis quotient is 4, downward multiplier is 1.
+	downwardThreshold := params.effectiveBalanceIncrement / 4
+	if balance+downwardThreshold < current {
+		return effectiveBalance(params, balance)
+	}
+
+	return current
+}
+
+// integerSquareRoot returns the largest integer whose square is not greater than the input.
+func integerSquareRoot(n uint64) uint64 {
+	return new(big.Int).Sqrt(new(big.Int).SetUint64(n)).Uint64()
+}
+
+// obtainParameters obtains the chain parameters used to calculate penalties
+// for a slashing at the given epoch.
+func (c *command) obtainParameters(ctx context.Context, epoch phase0.Epoch) (*parameters, error) {
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	return parametersFromSpec(specResponse.Data, epoch, c.electra)
+}
+
+// parametersFromSpec obtains the chain parameters from the spec, falling back
+// to mainnet values for those that are not present.  The Electra formulas are
+// used if Electra is active at the given epoch, or if forced.
+func parametersFromSpec(spec map[string]any, epoch phase0.Epoch, forceElectra bool) (*parameters, error) {
+	params := &parameters{
+		electra:                        forceElectra,
+		effectiveBalanceIncrement:      1000000000,
+		maxEffectiveBalance:            32000000000,
+		minSlashingPenaltyQuotient:     32,
+		proportionalSlashingMultiplier: 3,
+		epochsPerSlashingsVector:       8192,
+		baseRewardFactor:               64,
+	}
+
+	electraForkEpoch, exists, err := specUint64(spec, "ELECTRA_FORK_EPOCH")
+	if err != nil {
+		return nil, err
+	}
+	if exists && epoch >= phase0.Epoch(electraForkEpoch) {
+		params.electra = true
+	}
+
+	maxEffectiveBalanceName := "MAX_EFFECTIVE_BALANCE"
+	minSlashingPenaltyQuotientName := "MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX"
+	if params.electra {
+		// Electra values may not be present in the spec, so fall back to the mainnet values.
+		params.maxEffectiveBalance = 2048000000000
+		params.minSlashingPenaltyQuotient = 4096
+		maxEffectiveBalanceName = "MAX_EFFECTIVE_BALANCE_ELECTRA"
+		minSlashingPenaltyQuotientName = "MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA"
+	}
+
+	values := []struct {
+		name   string
+		target *uint64
+	}{
+		{name: "EFFECTIVE_BALANCE_INCREMENT", target: (*uint64)(&params.effectiveBalanceIncrement)},
+		{name: maxEffectiveBalanceName, target: (*uint64)(&params.maxEffectiveBalance)},
+		{name: minSlashingPenaltyQuotientName, target: &params.minSlashingPenaltyQuotient},
+		{name: "PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX", target: &params.proportionalSlashingMultiplier},
+		{name: "EPOCHS_PER_SLASHINGS_VECTOR", target: &params.epochsPerSlashingsVector},
+		{name: "BASE_REWARD_FACTOR", target: &params.baseRewardFactor},
+	}
+	for _, value := range values {
+		val, exists, err := specUint64(spec, value.name)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			*value.target = val
+		}
+	}
+
+	// Zero values would result in division by zero when calculating penalties.
+	if params.effectiveBalanceIncrement == 0 || params.minSlashingPenaltyQuotient == 0 {
+		return nil, errors.New("spec contains invalid penalty parameters")
+	}
+
+	return params, nil
+}
+
+// specUint64 obtains a uint64 value from the spec.  The second return value
+// is false if the value is not present.
+func specUint64(spec map[string]any, name string) (uint64, bool, error) {
+	val, exists := spec[name]
+	if !exists {
+		return 0, false, nil
+	}
+	res, isUint64 := val.(uint64)
+	if !isUint64 {
+		return 0, false, fmt.Errorf("%s in spec has unexpected type %T", name, val)
+	}
+
+	return res, true, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
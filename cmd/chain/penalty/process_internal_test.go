@@ -0,0 +1,229 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpenalty
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate(t *testing.T) {
+	bellatrix := &parameters{
+		effectiveBalanceIncrement:      1000000000,
+		maxEffectiveBalance:            32000000000,
+		minSlashingPenaltyQuotient:     32,
+		proportionalSlashingMultiplier: 3,
+		epochsPerSlashingsVector:       8192,
+		baseRewardFactor:               64,
+	}
+	electra := &parameters{
+		electra:                        true,
+		effectiveBalanceIncrement:      1000000000,
+		maxEffectiveBalance:            2048000000000,
+		minSlashingPenaltyQuotient:     4096,
+		proportionalSlashingMultiplier: 3,
+		epochsPerSlashingsVector:       8192,
+		baseRewardFactor:               64,
+	}
+	single := []*entry{
+		{
+			name:             "1",
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+		},
+	}
+
+	tests := []struct {
+		name               string
+		params             *parameters
+		entries            []*entry
+		totalActiveBalance phase0.Gwei
+		existingSlashed    phase0.Gwei
+		initial            phase0.Gwei
+		correlation        phase0.Gwei
+		attestation        phase0.Gwei
+		total              phase0.Gwei
+	}{
+		{
+			name:               "Empty",
+			params:             bellatrix,
+			entries:            []*entry{},
+			totalActiveBalance: 32000000000000000,
+		},
+		{
+			name:               "Single",
+			params:             bellatrix,
+			entries:            single,
+			totalActiveBalance: 32000000000000000,
+			initial:            1000000000,
+			attestation:        56647680,
+			total:              1056647680,
+		},
+		{
+			name:               "SingleElectra",
+			params:             electra,
+			entries:            single,
+			totalActiveBalance: 32000000000000000,
+			initial:            7812500,
+			correlation:        96000,
+			attestation:        58490880,
+			total:              66399380,
+		},
+		{
+			name:               "MassSlashing",
+			params:             bellatrix,
+			entries:            single,
+			totalActiveBalance: 32000000000000000,
+			existingSlashed:    12000000000000000,
+			initial:            1000000000,
+			correlation:        30943352320,
+			attestation:        56647680,
+			total:              32000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := calculate(test.params, test.entries, test.totalActiveBalance, test.existingSlashed)
+			require.Equal(t, test.initial, res.InitialPenalty)
+			require.Equal(t, test.correlation, res.CorrelationPenalty)
+			require.Equal(t, test.attestation, res.AttestationPenalty)
+			require.Equal(t, test.total, res.TotalPenalty)
+			require.Len(t, res.Validators, len(test.entries))
+		})
+	}
+}
+
+func TestEffectiveBalance(t *testing.T) {
+	params := &parameters{
+		effectiveBalanceIncrement: 1000000000,
+		maxEffectiveBalance:       32000000000,
+	}
+
+	require.Equal(t, phase0.Gwei(31000000000), effectiveBalance(params, 31999999999))
+	require.Equal(t, phase0.Gwei(32000000000), effectiveBalance(params, 40000000000))
+	// Hysteresis means that small drops do not alter the effective balance.
+	require.Equal(t, phase0.Gwei(32000000000), updatedEffectiveBalance(params, 32000000000, 31800000000))
+	require.Equal(t, phase0.Gwei(31000000000), updatedEffectiveBalance(params, 32000000000, 31700000000))
+}
+
+func TestParametersFromSpec(t *testing.T) {
+	bellatrix := &parameters{
+		effectiveBalanceIncrement:      1000000000,
+		maxEffectiveBalance:            32000000000,
+		minSlashingPenaltyQuotient:     32,
+		proportionalSlashingMultiplier: 3,
+		epochsPerSlashingsVector:       8192,
+		baseRewardFactor:               64,
+	}
+	electra := &parameters{
+		electra:                        true,
+		effectiveBalanceIncrement:      1000000000,
+		maxEffectiveBalance:            2048000000000,
+		minSlashingPenaltyQuotient:     4096,
+		proportionalSlashingMultiplier: 3,
+		epochsPerSlashingsVector:       8192,
+		baseRewardFactor:               64,
+	}
+	spec := map[string]any{
+		"EFFECTIVE_BALANCE_INCREMENT":                uint64(1000000000),
+		"MAX_EFFECTIVE_BALANCE":                      uint64(32000000000),
+		"MAX_EFFECTIVE_BALANCE_ELECTRA":              uint64(2048000000000),
+		"MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX":    uint64(32),
+		"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA":      uint64(4096),
+		"PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX": uint64(3),
+		"EPOCHS_PER_SLASHINGS_VECTOR":                uint64(8192),
+		"BASE_REWARD_FACTOR":                         uint64(64),
+		"ELECTRA_FORK_EPOCH":                         uint64(364032),
+	}
+
+	tests := []struct {
+		name         string
+		spec         map[string]any
+		epoch        phase0.Epoch
+		forceElectra bool
+		params       *parameters
+		err          string
+	}{
+		{
+			name:   "Empty",
+			spec:   map[string]any{},
+			epoch:  100,
+			params: bellatrix,
+		},
+		{
+			name:   "PreElectra",
+			spec:   spec,
+			epoch:  364031,
+			params: bellatrix,
+		},
+		{
+			name:   "Electra",
+			spec:   spec,
+			epoch:  364032,
+			params: electra,
+		},
+		{
+			name:         "ElectraForced",
+			spec:         spec,
+			epoch:        100,
+			forceElectra: true,
+			params:       electra,
+		},
+		{
+			name: "ElectraValuesMissing",
+			spec: map[string]any{
+				"MAX_EFFECTIVE_BALANCE":                   uint64(32000000000),
+				"MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX": uint64(32),
+				"ELECTRA_FORK_EPOCH":                      uint64(0),
+			},
+			params: electra,
+		},
+		{
+			name: "ElectraForkEpochInvalid",
+			spec: map[string]any{
+				"ELECTRA_FORK_EPOCH": "364032",
+			},
+			err: "ELECTRA_FORK_EPOCH in spec has unexpected type string",
+		},
+		{
+			name: "ValueInvalid",
+			spec: map[string]any{
+				"BASE_REWARD_FACTOR": int64(64),
+			},
+			err: "BASE_REWARD_FACTOR in spec has unexpected type int64",
+		},
+		{
+			name: "ValueZero",
+			spec: map[string]any{
+				"EFFECTIVE_BALANCE_INCREMENT": uint64(0),
+			},
+			err: "spec contains invalid penalty parameters",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := parametersFromSpec(test.spec, test.epoch, test.forceElectra)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.params, params)
+		})
+	}
+}
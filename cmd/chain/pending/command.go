@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpending
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validator string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	chainTime          chaintime.Service
+
+	// Parameters.
+	params *parameters
+
+	// Processing.
+	filter *apiv1.Validator
+
+	// Output.
+	epoch                     phase0.Epoch
+	slot                      phase0.Slot
+	deposits                  []*pendingDeposit
+	partialWithdrawals        []*pendingPartialWithdrawal
+	consolidations            []*pendingConsolidation
+	totalDeposits             int
+	totalPartialWithdrawals   int
+	totalConsolidations       int
+	activationExitChurnLimit  phase0.Gwei
+	pendingDepositsBalance    phase0.Gwei
+	pendingWithdrawalsBalance phase0.Gwei
+}
+
+// pendingDeposit is an entry in the pending deposits queue.
+type pendingDeposit struct {
+	Pubkey                phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Slot                  phase0.Slot
+	ETA                   phase0.Epoch
+}
+
+// pendingPartialWithdrawal is an entry in the pending partial withdrawals queue.
+type pendingPartialWithdrawal struct {
+	ValidatorIndex    phase0.ValidatorIndex
+	Amount            phase0.Gwei
+	WithdrawableEpoch phase0.Epoch
+	ETA               phase0.Slot
+}
+
+// pendingConsolidation is an entry in the pending consolidations queue.
+type pendingConsolidation struct {
+	SourceIndex phase0.ValidatorIndex
+	TargetIndex phase0.ValidatorIndex
+	ETA         phase0.Epoch
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.validator = viper.GetString("validator")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
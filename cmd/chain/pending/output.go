@@ -0,0 +1,162 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpending
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonPendingDeposit struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Slot                  string `json:"slot"`
+	ETAEpoch              string `json:"eta_epoch"`
+	ETATimestamp          int64  `json:"eta_timestamp"`
+}
+
+type jsonPendingPartialWithdrawal struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Amount            string `json:"amount"`
+	WithdrawableEpoch string `json:"withdrawable_epoch"`
+	ETASlot           string `json:"eta_slot"`
+	ETATimestamp      int64  `json:"eta_timestamp"`
+}
+
+type jsonPendingConsolidation struct {
+	SourceIndex  string `json:"source_index"`
+	TargetIndex  string `json:"target_index"`
+	ETAEpoch     string `json:"eta_epoch"`
+	ETATimestamp int64  `json:"eta_timestamp"`
+}
+
+type jsonOutput struct {
+	Epoch                     string                          `json:"epoch"`
+	ActivationExitChurnLimit  string                          `json:"activation_exit_churn_limit"`
+	TotalDeposits             int                             `json:"total_deposits"`
+	TotalPartialWithdrawals   int                             `json:"total_partial_withdrawals"`
+	TotalConsolidations       int                             `json:"total_consolidations"`
+	PendingDeposits           []*jsonPendingDeposit           `json:"pending_deposits"`
+	PendingPartialWithdrawals []*jsonPendingPartialWithdrawal `json:"pending_partial_withdrawals"`
+	PendingConsolidations     []*jsonPendingConsolidation     `json:"pending_consolidations"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Epoch:                     fmt.Sprintf("%d", c.epoch),
+		ActivationExitChurnLimit:  fmt.Sprintf("%d", c.activationExitChurnLimit),
+		TotalDeposits:             c.totalDeposits,
+		TotalPartialWithdrawals:   c.totalPartialWithdrawals,
+		TotalConsolidations:       c.totalConsolidations,
+		PendingDeposits:           make([]*jsonPendingDeposit, 0, len(c.deposits)),
+		PendingPartialWithdrawals: make([]*jsonPendingPartialWithdrawal, 0, len(c.partialWithdrawals)),
+		PendingConsolidations:     make([]*jsonPendingConsolidation, 0, len(c.consolidations)),
+	}
+	for _, deposit := range c.deposits {
+		output.PendingDeposits = append(output.PendingDeposits, &jsonPendingDeposit{
+			Pubkey:                fmt.Sprintf("%#x", deposit.Pubkey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", deposit.WithdrawalCredentials),
+			Amount:                fmt.Sprintf("%d", deposit.Amount),
+			Slot:                  fmt.Sprintf("%d", deposit.Slot),
+			ETAEpoch:              fmt.Sprintf("%d", deposit.ETA),
+			ETATimestamp:          c.chainTime.StartOfEpoch(deposit.ETA).Unix(),
+		})
+	}
+	for _, withdrawal := range c.partialWithdrawals {
+		output.PendingPartialWithdrawals = append(output.PendingPartialWithdrawals, &jsonPendingPartialWithdrawal{
+			ValidatorIndex:    fmt.Sprintf("%d", withdrawal.ValidatorIndex),
+			Amount:            fmt.Sprintf("%d", withdrawal.Amount),
+			WithdrawableEpoch: fmt.Sprintf("%d", withdrawal.WithdrawableEpoch),
+			ETASlot:           fmt.Sprintf("%d", withdrawal.ETA),
+			ETATimestamp:      c.chainTime.StartOfSlot(withdrawal.ETA).Unix(),
+		})
+	}
+	for _, consolidation := range c.consolidations {
+		output.PendingConsolidations = append(output.PendingConsolidations, &jsonPendingConsolidation{
+			SourceIndex:  fmt.Sprintf("%d", consolidation.SourceIndex),
+			TargetIndex:  fmt.Sprintf("%d", consolidation.TargetIndex),
+			ETAEpoch:     fmt.Sprintf("%d", consolidation.ETA),
+			ETATimestamp: c.chainTime.StartOfEpoch(consolidation.ETA).Unix(),
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	// Individual entries are shown if requested, or if filtering by validator.
+	showEntries := c.verbose || c.filter != nil
+
+	builder.WriteString(fmt.Sprintf("Pending deposits: %d (%s)\n", c.totalDeposits, string2eth.GWeiToString(uint64(c.pendingDepositsBalance), true)))
+	if showEntries {
+		for _, deposit := range c.deposits {
+			builder.WriteString(fmt.Sprintf("  %#x: %s expected in epoch %d (%s)\n",
+				deposit.Pubkey,
+				string2eth.GWeiToString(uint64(deposit.Amount), true),
+				deposit.ETA,
+				c.chainTime.StartOfEpoch(deposit.ETA).Format("2006-01-02 15:04:05"),
+			))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Pending partial withdrawals: %d (%s)\n", c.totalPartialWithdrawals, string2eth.GWeiToString(uint64(c.pendingWithdrawalsBalance), true)))
+	if showEntries {
+		for _, withdrawal := range c.partialWithdrawals {
+			builder.WriteString(fmt.Sprintf("  Validator %d: %s expected in slot %d (%s)\n",
+				withdrawal.ValidatorIndex,
+				string2eth.GWeiToString(uint64(withdrawal.Amount), true),
+				withdrawal.ETA,
+				c.chainTime.StartOfSlot(withdrawal.ETA).Format("2006-01-02 15:04:05"),
+			))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Pending consolidations: %d\n", c.totalConsolidations))
+	if showEntries {
+		for _, consolidation := range c.consolidations {
+			builder.WriteString(fmt.Sprintf("  Validator %d to validator %d expected in epoch %d (%s)\n",
+				consolidation.SourceIndex,
+				consolidation.TargetIndex,
+				consolidation.ETA,
+				c.chainTime.StartOfEpoch(consolidation.ETA).Format("2006-01-02 15:04:05"),
+			))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,417 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpending
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+// parameters are the chain parameters that define processing of the pending queues.
+type parameters struct {
+	slotsPerEpoch                        uint64
+	effectiveBalanceIncrement            phase0.Gwei
+	minPerEpochChurnLimit                phase0.Gwei
+	maxPerEpochActivationExitChurnLimit  phase0.Gwei
+	churnLimitQuotient                   uint64
+	maxPendingDepositsPerEpoch           int
+	maxPendingPartialsPerWithdrawalSweep int
+}
+
+type pendingDepositJSON struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Slot                  string `json:"slot"`
+}
+
+type pendingPartialWithdrawalJSON struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Amount            string `json:"amount"`
+	WithdrawableEpoch string `json:"withdrawable_epoch"`
+}
+
+type pendingConsolidationJSON struct {
+	SourceIndex string `json:"source_index"`
+	TargetIndex string `json:"target_index"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.slot = c.chainTime.CurrentSlot()
+	c.epoch = c.chainTime.CurrentEpoch()
+
+	if c.validator != "" {
+		var err error
+		c.filter, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validator")
+		}
+	}
+
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+	c.activationExitChurnLimit = c.params.activationExitChurnLimit(totalActiveBalance(validators, c.epoch))
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Activation and exit churn limit is %d\n", c.activationExitChurnLimit)
+	}
+
+	deposits, err := c.obtainPendingDeposits(ctx)
+	if err != nil {
+		return err
+	}
+	c.params.depositETAs(deposits, c.epoch, c.activationExitChurnLimit)
+	c.totalDeposits = len(deposits)
+	for _, deposit := range deposits {
+		c.pendingDepositsBalance += deposit.Amount
+	}
+
+	partialWithdrawals, err := c.obtainPendingPartialWithdrawals(ctx)
+	if err != nil {
+		return err
+	}
+	c.params.partialWithdrawalETAs(partialWithdrawals, c.slot)
+	c.totalPartialWithdrawals = len(partialWithdrawals)
+	for _, withdrawal := range partialWithdrawals {
+		c.pendingWithdrawalsBalance += withdrawal.Amount
+	}
+
+	consolidations, err := c.obtainPendingConsolidations(ctx)
+	if err != nil {
+		return err
+	}
+	consolidationETAs(consolidations, validators, c.epoch)
+	c.totalConsolidations = len(consolidations)
+
+	c.filterEntries(deposits, partialWithdrawals, consolidations)
+
+	return nil
+}
+
+// filterEntries filters the queue entries by the requested validator, if any.
+func (c *command) filterEntries(deposits []*pendingDeposit,
+	partialWithdrawals []*pendingPartialWithdrawal,
+	consolidations []*pendingConsolidation,
+) {
+	if c.filter == nil {
+		c.deposits = deposits
+		c.partialWithdrawals = partialWithdrawals
+		c.consolidations = consolidations
+
+		return
+	}
+
+	c.deposits = make([]*pendingDeposit, 0)
+	for _, deposit := range deposits {
+		if deposit.Pubkey == c.filter.Validator.PublicKey {
+			c.deposits = append(c.deposits, deposit)
+		}
+	}
+	c.partialWithdrawals = make([]*pendingPartialWithdrawal, 0)
+	for _, withdrawal := range partialWithdrawals {
+		if withdrawal.ValidatorIndex == c.filter.Index {
+			c.partialWithdrawals = append(c.partialWithdrawals, withdrawal)
+		}
+	}
+	c.consolidations = make([]*pendingConsolidation, 0)
+	for _, consolidation := range consolidations {
+		if consolidation.SourceIndex == c.filter.Index || consolidation.TargetIndex == c.filter.Index {
+			c.consolidations = append(c.consolidations, consolidation)
+		}
+	}
+}
+
+func (c *command) obtainPendingDeposits(ctx context.Context) ([]*pendingDeposit, error) {
+	data := make([]*pendingDepositJSON, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/beacon/states/head/pending_deposits", &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	if !found {
+		return nil, errors.New("beacon node does not provide pending deposits; is the chain past the Electra fork?")
+	}
+
+	res := make([]*pendingDeposit, 0, len(data))
+	for i := range data {
+		pubkey, err := bytesutil.FromHexString(data[i].Pubkey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit public key")
+		}
+		withdrawalCredentials, err := bytesutil.FromHexString(data[i].WithdrawalCredentials)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit withdrawal credentials")
+		}
+		amount, err := strconv.ParseUint(data[i].Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit amount")
+		}
+		slot, err := strconv.ParseUint(data[i].Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit slot")
+		}
+		deposit := &pendingDeposit{
+			WithdrawalCredentials: withdrawalCredentials,
+			Amount:                phase0.Gwei(amount),
+			Slot:                  phase0.Slot(slot),
+		}
+		copy(deposit.Pubkey[:], pubkey)
+		res = append(res, deposit)
+	}
+
+	return res, nil
+}
+
+func (c *command) obtainPendingPartialWithdrawals(ctx context.Context) ([]*pendingPartialWithdrawal, error) {
+	data := make([]*pendingPartialWithdrawalJSON, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/beacon/states/head/pending_partial_withdrawals", &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending partial withdrawals")
+	}
+	if !found {
+		return nil, errors.New("beacon node does not provide pending partial withdrawals; is the chain past the Electra fork?")
+	}
+
+	res := make([]*pendingPartialWithdrawal, 0, len(data))
+	for i := range data {
+		index, err := strconv.ParseUint(data[i].ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal validator index")
+		}
+		amount, err := strconv.ParseUint(data[i].Amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal amount")
+		}
+		epoch, err := strconv.ParseUint(data[i].WithdrawableEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending partial withdrawal withdrawable epoch")
+		}
+		res = append(res, &pendingPartialWithdrawal{
+			ValidatorIndex:    phase0.ValidatorIndex(index),
+			Amount:            phase0.Gwei(amount),
+			WithdrawableEpoch: phase0.Epoch(epoch),
+		})
+	}
+
+	return res, nil
+}
+
+func (c *command) obtainPendingConsolidations(ctx context.Context) ([]*pendingConsolidation, error) {
+	data := make([]*pendingConsolidationJSON, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/beacon/states/head/pending_consolidations", &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending consolidations")
+	}
+	if !found {
+		return nil, errors.New("beacon node does not provide pending consolidations; is the chain past the Electra fork?")
+	}
+
+	res := make([]*pendingConsolidation, 0, len(data))
+	for i := range data {
+		source, err := strconv.ParseUint(data[i].SourceIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending consolidation source index")
+		}
+		target, err := strconv.ParseUint(data[i].TargetIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending consolidation target index")
+		}
+		res = append(res, &pendingConsolidation{
+			SourceIndex: phase0.ValidatorIndex(source),
+			TargetIndex: phase0.ValidatorIndex(target),
+		})
+	}
+
+	return res, nil
+}
+
+// totalActiveBalance returns the total effective balance of active validators.
+func totalActiveBalance(validators map[phase0.ValidatorIndex]*apiv1.Validator, epoch phase0.Epoch) phase0.Gwei {
+	total := phase0.Gwei(0)
+	for _, validator := range validators {
+		if validator.Validator == nil {
+			continue
+		}
+		if validator.Validator.ActivationEpoch <= epoch && epoch < validator.Validator.ExitEpoch {
+			total += validator.Validator.EffectiveBalance
+		}
+	}
+
+	return total
+}
+
+// activationExitChurnLimit returns the per-epoch churn limit for activations and exits.
+func (p *parameters) activationExitChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	churn := totalActiveBalance / phase0.Gwei(p.churnLimitQuotient)
+	if churn < p.minPerEpochChurnLimit {
+		churn = p.minPerEpochChurnLimit
+	}
+	churn -= churn % p.effectiveBalanceIncrement
+	if churn > p.maxPerEpochActivationExitChurnLimit {
+		churn = p.maxPerEpochActivationExitChurnLimit
+	}
+
+	return churn
+}
+
+// depositETAs sets the epoch at which each pending deposit is expected to be
+// processed, based on the churn limit and the number of deposits that can be
+// processed each epoch.  Deposits must also be finalized before they can be
+// processed, which is assumed to take three epochs.
+func (p *parameters) depositETAs(deposits []*pendingDeposit, epoch phase0.Epoch, churn phase0.Gwei) {
+	eta := epoch + 1
+	budget := churn
+	count := 0
+	for _, deposit := range deposits {
+		if deposit.Slot != 0 {
+			finalizedEpoch := phase0.Epoch(uint64(deposit.Slot)/p.slotsPerEpoch) + 3
+			if finalizedEpoch > eta {
+				// Processing stalls until the deposit is finalized, and unused churn is lost.
+				eta = finalizedEpoch
+				budget = churn
+				count = 0
+			}
+		}
+		for count == p.maxPendingDepositsPerEpoch || deposit.Amount > budget {
+			eta++
+			budget += churn
+			count = 0
+		}
+		budget -= deposit.Amount
+		count++
+		deposit.ETA = eta
+	}
+}
+
+// partialWithdrawalETAs sets the slot at which each pending partial withdrawal
+// is expected to be processed, assuming that every slot contains a block.
+func (p *parameters) partialWithdrawalETAs(withdrawals []*pendingPartialWithdrawal, slot phase0.Slot) {
+	eta := slot + 1
+	count := 0
+	for _, withdrawal := range withdrawals {
+		withdrawableSlot := phase0.Slot(uint64(withdrawal.WithdrawableEpoch) * p.slotsPerEpoch)
+		if withdrawableSlot > eta {
+			eta = withdrawableSlot
+			count = 0
+		}
+		if count == p.maxPendingPartialsPerWithdrawalSweep {
+			eta++
+			count = 0
+		}
+		count++
+		withdrawal.ETA = eta
+	}
+}
+
+// consolidationETAs sets the epoch at which each pending consolidation is
+// expected to be processed.  Consolidations are processed in order once their
+// source validator is withdrawable.
+func consolidationETAs(consolidations []*pendingConsolidation,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	epoch phase0.Epoch,
+) {
+	eta := epoch + 1
+	for _, consolidation := range consolidations {
+		if source, exists := validators[consolidation.SourceIndex]; exists && source.Validator != nil {
+			if source.Validator.WithdrawableEpoch > eta {
+				eta = source.Validator.WithdrawableEpoch
+			}
+		}
+		consolidation.ETA = eta
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	specDataResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+	c.params = &parameters{
+		slotsPerEpoch:                        32,
+		effectiveBalanceIncrement:            1000000000,
+		minPerEpochChurnLimit:                128000000000,
+		maxPerEpochActivationExitChurnLimit:  256000000000,
+		churnLimitQuotient:                   65536,
+		maxPendingDepositsPerEpoch:           16,
+		maxPendingPartialsPerWithdrawalSweep: 8,
+	}
+	if val, exists := specData["SLOTS_PER_EPOCH"].(uint64); exists {
+		c.params.slotsPerEpoch = val
+	}
+	if val, exists := specData["EFFECTIVE_BALANCE_INCREMENT"].(uint64); exists {
+		c.params.effectiveBalanceIncrement = phase0.Gwei(val)
+	}
+	if val, exists := specData["MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA"].(uint64); exists {
+		c.params.minPerEpochChurnLimit = phase0.Gwei(val)
+	}
+	if val, exists := specData["MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT"].(uint64); exists {
+		c.params.maxPerEpochActivationExitChurnLimit = phase0.Gwei(val)
+	}
+	if val, exists := specData["CHURN_LIMIT_QUOTIENT"].(uint64); exists {
+		c.params.churnLimitQuotient = val
+	}
+	if val, exists := specData["MAX_PENDING_DEPOSITS_PER_EPOCH"].(uint64); exists {
+		c.params.maxPendingDepositsPerEpoch = int(val)
+	}
+	if val, exists := specData["MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP"].(uint64); exists {
+		c.params.maxPendingPartialsPerWithdrawalSweep = int(val)
+	}
+
+	return nil
+}
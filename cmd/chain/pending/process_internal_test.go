@@ -0,0 +1,154 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpending
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testParameters() *parameters {
+	return &parameters{
+		slotsPerEpoch:                        32,
+		effectiveBalanceIncrement:            1000000000,
+		minPerEpochChurnLimit:                128000000000,
+		maxPerEpochActivationExitChurnLimit:  256000000000,
+		churnLimitQuotient:                   65536,
+		maxPendingDepositsPerEpoch:           2,
+		maxPendingPartialsPerWithdrawalSweep: 2,
+	}
+}
+
+func TestActivationExitChurnLimit(t *testing.T) {
+	tests := []struct {
+		name               string
+		totalActiveBalance phase0.Gwei
+		expected           phase0.Gwei
+	}{
+		{
+			name:               "Minimum",
+			totalActiveBalance: 1000000000000000,
+			expected:           128000000000,
+		},
+		{
+			name:               "Rounded",
+			totalActiveBalance: 10000000000000000,
+			expected:           152000000000,
+		},
+		{
+			name:               "Maximum",
+			totalActiveBalance: 20000000000000000,
+			expected:           256000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, testParameters().activationExitChurnLimit(test.totalActiveBalance))
+		})
+	}
+}
+
+func TestDepositETAs(t *testing.T) {
+	tests := []struct {
+		name     string
+		deposits []*pendingDeposit
+		expected []phase0.Epoch
+	}{
+		{
+			name:     "Empty",
+			deposits: []*pendingDeposit{},
+			expected: []phase0.Epoch{},
+		},
+		{
+			name: "CountLimited",
+			deposits: []*pendingDeposit{
+				{Amount: 32000000000},
+				{Amount: 32000000000},
+				{Amount: 32000000000},
+			},
+			expected: []phase0.Epoch{11, 11, 12},
+		},
+		{
+			name: "ChurnLimited",
+			deposits: []*pendingDeposit{
+				{Amount: 100000000000},
+				{Amount: 28000000000},
+				{Amount: 1000000000},
+			},
+			expected: []phase0.Epoch{12, 12, 13},
+		},
+		{
+			name: "Unfinalized",
+			deposits: []*pendingDeposit{
+				{Amount: 32000000000},
+				{Amount: 32000000000, Slot: 640},
+				{Amount: 32000000000},
+			},
+			expected: []phase0.Epoch{11, 23, 23},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testParameters().depositETAs(test.deposits, 10, 64000000000)
+			etas := make([]phase0.Epoch, 0, len(test.deposits))
+			for _, deposit := range test.deposits {
+				etas = append(etas, deposit.ETA)
+			}
+			require.Equal(t, test.expected, etas)
+		})
+	}
+}
+
+func TestPartialWithdrawalETAs(t *testing.T) {
+	withdrawals := []*pendingPartialWithdrawal{
+		{ValidatorIndex: 1, WithdrawableEpoch: 3},
+		{ValidatorIndex: 2, WithdrawableEpoch: 3},
+		{ValidatorIndex: 3, WithdrawableEpoch: 3},
+		{ValidatorIndex: 4, WithdrawableEpoch: 5},
+		{ValidatorIndex: 5, WithdrawableEpoch: 5},
+	}
+	testParameters().partialWithdrawalETAs(withdrawals, 100)
+
+	etas := make([]phase0.Slot, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		etas = append(etas, withdrawal.ETA)
+	}
+	require.Equal(t, []phase0.Slot{101, 101, 102, 160, 160}, etas)
+}
+
+func TestConsolidationETAs(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Index: 1, Validator: &phase0.Validator{WithdrawableEpoch: 5}},
+		2: {Index: 2, Validator: &phase0.Validator{WithdrawableEpoch: 20}},
+		3: {Index: 3, Validator: &phase0.Validator{WithdrawableEpoch: 15}},
+	}
+	consolidations := []*pendingConsolidation{
+		{SourceIndex: 1, TargetIndex: 4},
+		{SourceIndex: 2, TargetIndex: 4},
+		{SourceIndex: 3, TargetIndex: 4},
+		{SourceIndex: 9, TargetIndex: 4},
+	}
+	consolidationETAs(consolidations, validators, 10)
+
+	etas := make([]phase0.Epoch, 0, len(consolidations))
+	for _, consolidation := range consolidations {
+		etas = append(etas, consolidation.ETA)
+	}
+	require.Equal(t, []phase0.Epoch{11, 20, 20, 20}, etas)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	fromEpoch string
+	toEpoch   string
+	operators *operatorMap
+
+	// Data access.
+	eth2Client                eth2client.Service
+	chainTime                 chaintime.Service
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	FromSlot         phase0.Slot      `json:"from_slot"`
+	ToSlot           phase0.Slot      `json:"to_slot"`
+	Blocks           int              `json:"blocks"`
+	MissedSlots      int              `json:"missed_slots"`
+	IdentifiedBlocks int              `json:"identified_blocks"`
+	Clients          []*clientStats   `json:"clients"`
+	LabelledBlocks   int              `json:"labelled_blocks"`
+	Operators        []*operatorStats `json:"operators,omitempty"`
+	// OperatorHHI is the Herfindahl-Hirschman index of labelled operators' shares of all blocks.
+	OperatorHHI float64 `json:"operator_hhi"`
+	// OperatorsForOneThird is the smallest number of labelled operators that together
+	// proposed more than a third of all blocks, or 0 if there is no such set.
+	OperatorsForOneThird int `json:"operators_for_one_third"`
+}
+
+type clientStats struct {
+	Client string `json:"client"`
+	Blocks int    `json:"blocks"`
+	// Share is the share of identified blocks proposed by the client.
+	Share            float64 `json:"share"`
+	HighConfidence   int     `json:"high_confidence_blocks"`
+	MediumConfidence int     `json:"medium_confidence_blocks"`
+	LowConfidence    int     `json:"low_confidence_blocks"`
+	Confidence       string  `json:"confidence"`
+}
+
+type operatorStats struct {
+	Operator string `json:"operator"`
+	Blocks   int    `json:"blocks"`
+	// Share is the share of all blocks proposed by the operator.
+	Share float64 `json:"share"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	c.operators = newOperatorMap()
+	if viper.GetString("operators-file") != "" {
+		var err error
+		c.operators, err = readOperatorsFile(viper.GetString("operators-file"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
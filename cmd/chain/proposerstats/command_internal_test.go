@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	operatorsFile := filepath.Join(dir, "operators.csv")
+	require.NoError(t, os.WriteFile(operatorsFile, []byte("# Operators\n1,Operator A\n100-199,Operator B\n"), 0o600))
+	badOperatorsFile := filepath.Join(dir, "bad.csv")
+	require.NoError(t, os.WriteFile(badOperatorsFile, []byte("1\n"), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"from-epoch": "-10",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FromEpochMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "from epoch is required",
+		},
+		{
+			name: "OperatorsFileMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"from-epoch":     "-10",
+				"operators-file": filepath.Join(dir, "missing.csv"),
+			},
+			err: "failed to read operators file: open " + filepath.Join(dir, "missing.csv") + ": no such file or directory",
+		},
+		{
+			name: "OperatorsFileInvalid",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"from-epoch":     "-10",
+				"operators-file": badOperatorsFile,
+			},
+			err: "invalid operators file line 1: expected <key>,<operator>",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"from-epoch":     "-10",
+				"operators-file": operatorsFile,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Confidence levels for client identification.
+const (
+	confidenceHigh   = "high"
+	confidenceMedium = "medium"
+	confidenceLow    = "low"
+)
+
+// Thresholds for inferring a client from a block packing profile.
+const (
+	// minProfileSamples is the minimum number of blocks identified by graffiti
+	// with a given packing profile before the profile is used to infer clients.
+	minProfileSamples = 10
+	// profileMajority is the share of blocks identified by graffiti with a given
+	// packing profile that must come from a single client for the profile to be
+	// used to infer that client.
+	profileMajority = 2.0 / 3.0
+)
+
+// consensusClients are the consensus clients that can be identified by name in graffiti.
+var consensusClients = map[string]string{
+	"grandine":   "Grandine",
+	"lighthouse": "Lighthouse",
+	"lodestar":   "Lodestar",
+	"nimbus":     "Nimbus",
+	"prysm":      "Prysm",
+	"teku":       "Teku",
+}
+
+// identifyClient identifies the consensus client that proposed a block from
+// its graffiti.  Client version graffiti provides a high confidence
+// identification; graffiti that mentions exactly one consensus client by name
+// provides a medium confidence identification.
+func identifyClient(graffiti []byte) (string, string) {
+	info := util.DecodeGraffiti(graffiti)
+	if info.ConsensusClient != "" {
+		return info.ConsensusClient, confidenceHigh
+	}
+
+	client := ""
+	for _, name := range info.Clients {
+		if consensusClient, exists := consensusClients[name]; exists {
+			if client != "" {
+				// Multiple consensus clients mentioned; ambiguous.
+				return "", ""
+			}
+			client = consensusClient
+		}
+	}
+	if client != "" {
+		return client, confidenceMedium
+	}
+
+	return "", ""
+}
+
+// packedAttestation is the position of an attestation packed in to a block.
+type packedAttestation struct {
+	slot           phase0.Slot
+	committeeIndex phase0.CommitteeIndex
+}
+
+// packingProfile returns a coarse fingerprint of the way in which a block's
+// attestations are packed, which differs between clients.  It returns an
+// empty string if the block does not contain enough attestations to provide
+// a fingerprint.
+func packingProfile(attestations []*packedAttestation) string {
+	if len(attestations) < 2 {
+		return ""
+	}
+
+	ascending := true
+	descending := true
+	for i := 1; i < len(attestations); i++ {
+		switch {
+		case attestations[i].slot > attestations[i-1].slot:
+			descending = false
+		case attestations[i].slot < attestations[i-1].slot:
+			ascending = false
+		}
+	}
+
+	order := "mixed"
+	switch {
+	case ascending && descending:
+		// All attestations are for the same slot, which says nothing about ordering.
+		return ""
+	case ascending:
+		order = "ascending"
+	case descending:
+		order = "descending"
+	}
+
+	// Clients differ in whether they pack attestations for multiple committees
+	// of a slot in order of committee index.
+	committeeOrder := "unordered"
+	ordered := true
+	for i := 1; i < len(attestations); i++ {
+		if attestations[i].slot == attestations[i-1].slot && attestations[i].committeeIndex < attestations[i-1].committeeIndex {
+			ordered = false
+			break
+		}
+	}
+	if ordered {
+		committeeOrder = "ordered"
+	}
+
+	return strings.Join([]string{order, committeeOrder}, "/")
+}
+
+// profileClients infers the client for each packing profile from the blocks
+// that have been identified by their graffiti.
+func profileClients(proposals []*proposal) map[string]string {
+	counts := make(map[string]map[string]int)
+	for _, proposal := range proposals {
+		if proposal.profile == "" || proposal.client == "" {
+			continue
+		}
+		if _, exists := counts[proposal.profile]; !exists {
+			counts[proposal.profile] = make(map[string]int)
+		}
+		counts[proposal.profile][proposal.client]++
+	}
+
+	res := make(map[string]string)
+	for profile, clients := range counts {
+		total := 0
+		bestClient := ""
+		bestCount := 0
+		for client, count := range clients {
+			total += count
+			if count > bestCount || (count == bestCount && client < bestClient) {
+				bestClient = client
+				bestCount = count
+			}
+		}
+		if total >= minProfileSamples && float64(bestCount) >= profileMajority*float64(total) {
+			res[profile] = bestClient
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,147 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+// operatorMap maps validators and fee recipients to the operators that run them.
+type operatorMap struct {
+	indices       map[phase0.ValidatorIndex]string
+	ranges        []*indexRange
+	feeRecipients map[bellatrix.ExecutionAddress]string
+}
+
+type indexRange struct {
+	from     phase0.ValidatorIndex
+	to       phase0.ValidatorIndex
+	operator string
+}
+
+func newOperatorMap() *operatorMap {
+	return &operatorMap{
+		indices:       make(map[phase0.ValidatorIndex]string),
+		ranges:        make([]*indexRange, 0),
+		feeRecipients: make(map[bellatrix.ExecutionAddress]string),
+	}
+}
+
+// readOperatorsFile reads a file mapping validators to operators.  Each line
+// of the file is of the form "<key>,<operator>", where the key is a validator
+// index, an inclusive range of validator indices such as "100-199", or a fee
+// recipient address.  Blank lines and lines starting with "#" are ignored.
+func readOperatorsFile(path string) (*operatorMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read operators file")
+	}
+
+	return parseOperators(string(data))
+}
+
+func parseOperators(data string) (*operatorMap, error) {
+	res := newOperatorMap()
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, operator, found := strings.Cut(line, ",")
+		key = strings.TrimSpace(key)
+		operator = strings.TrimSpace(operator)
+		if !found || key == "" || operator == "" {
+			return nil, fmt.Errorf("invalid operators file line %d: expected <key>,<operator>", i+1)
+		}
+		if err := res.add(key, operator); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid operators file line %d", i+1))
+		}
+	}
+
+	return res, nil
+}
+
+func (m *operatorMap) add(key string, operator string) error {
+	if strings.HasPrefix(key, "0x") {
+		address, err := bytesutil.FromHexString(key)
+		if err != nil {
+			return errors.Wrap(err, "invalid fee recipient")
+		}
+		if len(address) != bellatrix.ExecutionAddressLength {
+			return errors.New("fee recipient must be 20 bytes")
+		}
+		var feeRecipient bellatrix.ExecutionAddress
+		copy(feeRecipient[:], address)
+		m.feeRecipients[feeRecipient] = operator
+
+		return nil
+	}
+
+	if fromStr, toStr, isRange := strings.Cut(key, "-"); isRange {
+		from, err := strconv.ParseUint(strings.TrimSpace(fromStr), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid validator index %q", fromStr)
+		}
+		to, err := strconv.ParseUint(strings.TrimSpace(toStr), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid validator index %q", toStr)
+		}
+		if to < from {
+			return fmt.Errorf("invalid validator range %q", key)
+		}
+		m.ranges = append(m.ranges, &indexRange{
+			from:     phase0.ValidatorIndex(from),
+			to:       phase0.ValidatorIndex(to),
+			operator: operator,
+		})
+
+		return nil
+	}
+
+	index, err := strconv.ParseUint(key, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid validator index %q", key)
+	}
+	m.indices[phase0.ValidatorIndex(index)] = operator
+
+	return nil
+}
+
+// operator returns the operator for a proposal, or an empty string if it is
+// not known.  Validator indices take precedence over fee recipients.
+func (m *operatorMap) operator(proposerIndex phase0.ValidatorIndex, feeRecipient *bellatrix.ExecutionAddress) string {
+	if operator, exists := m.indices[proposerIndex]; exists {
+		return operator
+	}
+	for _, r := range m.ranges {
+		if proposerIndex >= r.from && proposerIndex <= r.to {
+			return r.operator
+		}
+	}
+	if feeRecipient != nil {
+		if operator, exists := m.feeRecipients[*feeRecipient]; exists {
+			return operator
+		}
+	}
+
+	return ""
+}
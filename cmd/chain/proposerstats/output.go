@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Slots: %d-%d\n", c.results.FromSlot, c.results.ToSlot))
+		builder.WriteString(fmt.Sprintf("Missed slots: %d\n", c.results.MissedSlots))
+	}
+	builder.WriteString(fmt.Sprintf("Blocks: %d\n", c.results.Blocks))
+	builder.WriteString(fmt.Sprintf("Blocks with identified client: %d (%s)\n", c.results.IdentifiedBlocks, percentage(c.results.IdentifiedBlocks, c.results.Blocks)))
+
+	if len(c.results.Clients) > 0 {
+		builder.WriteString("Estimated client shares:\n")
+		for _, client := range c.results.Clients {
+			builder.WriteString(fmt.Sprintf("  %s: %.2f%% (%d blocks, %s confidence)\n", client.Client, client.Share*100, client.Blocks, client.Confidence))
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("    High confidence blocks: %d\n", client.HighConfidence))
+				builder.WriteString(fmt.Sprintf("    Medium confidence blocks: %d\n", client.MediumConfidence))
+				builder.WriteString(fmt.Sprintf("    Low confidence blocks: %d\n", client.LowConfidence))
+			}
+		}
+	}
+
+	if len(c.results.Operators) > 0 {
+		builder.WriteString(fmt.Sprintf("Blocks with labelled operator: %d (%s)\n", c.results.LabelledBlocks, percentage(c.results.LabelledBlocks, c.results.Blocks)))
+		builder.WriteString("Operator shares:\n")
+		for _, operator := range c.results.Operators {
+			builder.WriteString(fmt.Sprintf("  %s: %.2f%% (%d blocks)\n", operator.Operator, operator.Share*100, operator.Blocks))
+		}
+		builder.WriteString(fmt.Sprintf("Operator concentration (HHI): %.4f\n", c.results.OperatorHHI))
+		if c.results.OperatorsForOneThird > 0 {
+			builder.WriteString(fmt.Sprintf("Operators proposing more than a third of blocks: %d\n", c.results.OperatorsForOneThird))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func percentage(value int, total int) string {
+	if total == 0 {
+		return "0.00%"
+	}
+
+	return fmt.Sprintf("%.2f%%", float64(value)*100/float64(total))
+}
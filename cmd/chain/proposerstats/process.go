@@ -0,0 +1,280 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// proposal is the information about a proposed block used for analysis.
+type proposal struct {
+	slot          phase0.Slot
+	proposerIndex phase0.ValidatorIndex
+	feeRecipient  *bellatrix.ExecutionAddress
+	profile       string
+	client        string
+	confidence    string
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch must not be before from epoch")
+	}
+	fromSlot := c.chainTime.FirstSlotOfEpoch(fromEpoch)
+	toSlot := c.chainTime.LastSlotOfEpoch(toEpoch)
+	if toSlot > c.chainTime.CurrentSlot() {
+		toSlot = c.chainTime.CurrentSlot()
+	}
+
+	proposals := make([]*proposal, 0)
+	missed := 0
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		if c.debug && (slot-fromSlot)%1000 == 0 {
+			fmt.Fprintf(os.Stderr, "Processing slot %d of %d-%d\n", slot, fromSlot, toSlot)
+		}
+		block, err := util.ResponseData(c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			missed++
+			continue
+		}
+		summary, err := summariseBlock(block)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to summarise block for slot %d", slot))
+		}
+		proposals = append(proposals, summary)
+	}
+
+	c.results = analyse(proposals, c.operators)
+	c.results.FromSlot = fromSlot
+	c.results.ToSlot = toSlot
+	c.results.MissedSlots = missed
+
+	return nil
+}
+
+// summariseBlock obtains the information required for analysis from a block.
+func summariseBlock(block *spec.VersionedSignedBeaconBlock) (*proposal, error) {
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain slot")
+	}
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer index")
+	}
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain graffiti")
+	}
+	res := &proposal{
+		slot:          slot,
+		proposerIndex: proposerIndex,
+	}
+	if block.Version >= spec.DataVersionBellatrix {
+		payload, err := block.ExecutionPayload()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain execution payload")
+		}
+		if !payload.IsEmpty() {
+			feeRecipient, err := payload.FeeRecipient()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain fee recipient")
+			}
+			res.feeRecipient = &feeRecipient
+		}
+	}
+
+	attestations, err := block.Attestations()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attestations")
+	}
+	packedAttestations := make([]*packedAttestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		data, err := attestation.Data()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain attestation data")
+		}
+		committeeIndices, err := util.AttestationCommitteeIndices(attestation)
+		if err != nil {
+			return nil, err
+		}
+		packed := &packedAttestation{
+			slot: data.Slot,
+		}
+		if len(committeeIndices) > 0 {
+			packed.committeeIndex = committeeIndices[0]
+		}
+		packedAttestations = append(packedAttestations, packed)
+	}
+	res.profile = packingProfile(packedAttestations)
+	res.client, res.confidence = identifyClient(graffiti[:])
+
+	return res, nil
+}
+
+// analyse generates statistics for a set of proposals.  Proposals whose client
+// cannot be identified from their graffiti are given a low confidence
+// identification if their packing profile is strongly associated with a
+// single client.
+func analyse(proposals []*proposal, operators *operatorMap) *results {
+	profiles := profileClients(proposals)
+
+	res := &results{
+		Blocks:    len(proposals),
+		Clients:   make([]*clientStats, 0),
+		Operators: make([]*operatorStats, 0),
+	}
+	clients := make(map[string]*clientStats)
+	operatorBlocks := make(map[string]int)
+	for _, proposal := range proposals {
+		client := proposal.client
+		confidence := proposal.confidence
+		if client == "" && proposal.profile != "" {
+			if profileClient, exists := profiles[proposal.profile]; exists {
+				client = profileClient
+				confidence = confidenceLow
+			}
+		}
+		if client != "" {
+			res.IdentifiedBlocks++
+			stats, exists := clients[client]
+			if !exists {
+				stats = &clientStats{
+					Client: client,
+				}
+				clients[client] = stats
+				res.Clients = append(res.Clients, stats)
+			}
+			stats.Blocks++
+			switch confidence {
+			case confidenceHigh:
+				stats.HighConfidence++
+			case confidenceMedium:
+				stats.MediumConfidence++
+			default:
+				stats.LowConfidence++
+			}
+		}
+
+		if operators != nil {
+			if operator := operators.operator(proposal.proposerIndex, proposal.feeRecipient); operator != "" {
+				res.LabelledBlocks++
+				operatorBlocks[operator]++
+			}
+		}
+	}
+
+	for _, stats := range res.Clients {
+		stats.Share = float64(stats.Blocks) / float64(res.IdentifiedBlocks)
+		switch {
+		case stats.HighConfidence*2 >= stats.Blocks:
+			stats.Confidence = confidenceHigh
+		case (stats.HighConfidence+stats.MediumConfidence)*2 >= stats.Blocks:
+			stats.Confidence = confidenceMedium
+		default:
+			stats.Confidence = confidenceLow
+		}
+	}
+	sort.Slice(res.Clients, func(i int, j int) bool {
+		if res.Clients[i].Blocks != res.Clients[j].Blocks {
+			return res.Clients[i].Blocks > res.Clients[j].Blocks
+		}
+		return res.Clients[i].Client < res.Clients[j].Client
+	})
+
+	for operator, blocks := range operatorBlocks {
+		share := float64(blocks) / float64(res.Blocks)
+		res.Operators = append(res.Operators, &operatorStats{
+			Operator: operator,
+			Blocks:   blocks,
+			Share:    share,
+		})
+		res.OperatorHHI += share * share
+	}
+	sort.Slice(res.Operators, func(i int, j int) bool {
+		if res.Operators[i].Blocks != res.Operators[j].Blocks {
+			return res.Operators[i].Blocks > res.Operators[j].Blocks
+		}
+		return res.Operators[i].Operator < res.Operators[j].Operator
+	})
+	total := 0
+	for i, operator := range res.Operators {
+		total += operator.Blocks
+		if total*3 > res.Blocks {
+			res.OperatorsForOneThird = i + 1
+			break
+		}
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	return nil
+}
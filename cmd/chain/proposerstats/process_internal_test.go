@@ -0,0 +1,284 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainproposerstats
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentifyClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		graffiti   string
+		client     string
+		confidence string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:       "ClientVersion",
+			graffiti:   "GE1234LH5678 my validator",
+			client:     "Lighthouse",
+			confidence: confidenceHigh,
+		},
+		{
+			name:       "Name",
+			graffiti:   "teku/v24.1.0",
+			client:     "Teku",
+			confidence: confidenceMedium,
+		},
+		{
+			name:     "ExecutionClientOnly",
+			graffiti: "geth",
+		},
+		{
+			name:     "Ambiguous",
+			graffiti: "prysm or lighthouse",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graffiti := make([]byte, 32)
+			copy(graffiti, test.graffiti)
+			client, confidence := identifyClient(graffiti)
+			require.Equal(t, test.client, client)
+			require.Equal(t, test.confidence, confidence)
+		})
+	}
+}
+
+func attestations(slots []phase0.Slot, indices []phase0.CommitteeIndex) []*packedAttestation {
+	res := make([]*packedAttestation, len(slots))
+	for i := range slots {
+		res[i] = &packedAttestation{
+			slot:           slots[i],
+			committeeIndex: indices[i],
+		}
+	}
+
+	return res
+}
+
+func TestPackingProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		attestations []*packedAttestation
+		profile      string
+	}{
+		{
+			name:         "Single",
+			attestations: attestations([]phase0.Slot{1}, []phase0.CommitteeIndex{0}),
+		},
+		{
+			name:         "SameSlot",
+			attestations: attestations([]phase0.Slot{1, 1}, []phase0.CommitteeIndex{0, 1}),
+		},
+		{
+			name:         "Descending",
+			attestations: attestations([]phase0.Slot{3, 2, 2, 1}, []phase0.CommitteeIndex{0, 0, 1, 0}),
+			profile:      "descending/ordered",
+		},
+		{
+			name:         "AscendingUnordered",
+			attestations: attestations([]phase0.Slot{1, 2, 2}, []phase0.CommitteeIndex{0, 1, 0}),
+			profile:      "ascending/unordered",
+		},
+		{
+			name:         "Mixed",
+			attestations: attestations([]phase0.Slot{2, 3, 1}, []phase0.CommitteeIndex{0, 0, 0}),
+			profile:      "mixed/ordered",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.profile, packingProfile(test.attestations))
+		})
+	}
+}
+
+func TestSummariseBlock(t *testing.T) {
+	graffiti := [32]byte{}
+	copy(graffiti[:], "teku/v24.1.0")
+	feeRecipient := bellatrix.ExecutionAddress{0x01}
+
+	tests := []struct {
+		name     string
+		block    *spec.VersionedSignedBeaconBlock
+		expected *proposal
+		err      string
+	}{
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.SignedBeaconBlock{
+					Message: &altair.BeaconBlock{
+						Slot:          10,
+						ProposerIndex: 1,
+						Body: &altair.BeaconBlockBody{
+							Graffiti: graffiti,
+							Attestations: []*phase0.Attestation{
+								{Data: &phase0.AttestationData{Slot: 9, Index: 2}},
+							},
+						},
+					},
+				},
+			},
+			expected: &proposal{
+				slot:          10,
+				proposerIndex: 1,
+				profile:       packingProfile(attestations([]phase0.Slot{9}, []phase0.CommitteeIndex{2})),
+				client:        "Teku",
+				confidence:    confidenceMedium,
+			},
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Slot:          20,
+						ProposerIndex: 2,
+						Body: &electra.BeaconBlockBody{
+							Graffiti: graffiti,
+							Attestations: []*electra.Attestation{
+								{
+									Data:          &phase0.AttestationData{Slot: 19},
+									CommitteeBits: bitfield.Bitvector64{0x0c, 0, 0, 0, 0, 0, 0, 0},
+								},
+							},
+							ExecutionPayload: &deneb.ExecutionPayload{
+								FeeRecipient: feeRecipient,
+							},
+						},
+					},
+				},
+			},
+			expected: &proposal{
+				slot:          20,
+				proposerIndex: 2,
+				feeRecipient:  &feeRecipient,
+				profile:       packingProfile(attestations([]phase0.Slot{19}, []phase0.CommitteeIndex{2})),
+				client:        "Teku",
+				confidence:    confidenceMedium,
+			},
+		},
+		{
+			name: "ElectraMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+			},
+			err: "failed to obtain slot: no electra block",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := summariseBlock(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestParseOperators(t *testing.T) {
+	operators, err := parseOperators("# Comment\n\n1,Operator A\n100-199, Operator B\n0x000102030405060708090a0b0c0d0e0f10111213,Operator C\n")
+	require.NoError(t, err)
+
+	feeRecipient := bellatrix.ExecutionAddress{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13}
+	require.Equal(t, "Operator A", operators.operator(1, nil))
+	require.Equal(t, "Operator B", operators.operator(150, &feeRecipient))
+	require.Equal(t, "Operator C", operators.operator(2, &feeRecipient))
+	require.Equal(t, "", operators.operator(2, nil))
+
+	_, err = parseOperators("a,Operator")
+	require.EqualError(t, err, `invalid operators file line 1: invalid validator index "a"`)
+	_, err = parseOperators("10-5,Operator")
+	require.EqualError(t, err, `invalid operators file line 1: invalid validator range "10-5"`)
+	_, err = parseOperators("0x0001,Operator")
+	require.EqualError(t, err, "invalid operators file line 1: fee recipient must be 20 bytes")
+}
+
+func TestAnalyse(t *testing.T) {
+	proposals := make([]*proposal, 0)
+	// Twelve blocks identified as Lighthouse by graffiti, with a common packing profile.
+	for i := 0; i < 12; i++ {
+		proposals = append(proposals, &proposal{
+			proposerIndex: phase0.ValidatorIndex(i),
+			profile:       "descending/ordered",
+			client:        "Lighthouse",
+			confidence:    confidenceHigh,
+		})
+	}
+	// Four blocks identified as Teku by name.
+	for i := 12; i < 16; i++ {
+		proposals = append(proposals, &proposal{
+			proposerIndex: phase0.ValidatorIndex(i),
+			profile:       "ascending/ordered",
+			client:        "Teku",
+			confidence:    confidenceMedium,
+		})
+	}
+	// Three unidentified blocks with the Lighthouse profile, and one with an unknown profile.
+	for i := 16; i < 19; i++ {
+		proposals = append(proposals, &proposal{
+			proposerIndex: phase0.ValidatorIndex(i),
+			profile:       "descending/ordered",
+		})
+	}
+	proposals = append(proposals, &proposal{
+		proposerIndex: 19,
+		profile:       "mixed/unordered",
+	})
+
+	operators, err := parseOperators("0-9,Operator A\n10-11,Operator B\n")
+	require.NoError(t, err)
+
+	res := analyse(proposals, operators)
+	require.Equal(t, 20, res.Blocks)
+	require.Equal(t, 19, res.IdentifiedBlocks)
+	require.Len(t, res.Clients, 2)
+	require.Equal(t, "Lighthouse", res.Clients[0].Client)
+	require.Equal(t, 15, res.Clients[0].Blocks)
+	require.Equal(t, 12, res.Clients[0].HighConfidence)
+	require.Equal(t, 3, res.Clients[0].LowConfidence)
+	require.Equal(t, confidenceHigh, res.Clients[0].Confidence)
+	require.InDelta(t, 15.0/19.0, res.Clients[0].Share, 0.0001)
+	require.Equal(t, "Teku", res.Clients[1].Client)
+	require.Equal(t, confidenceMedium, res.Clients[1].Confidence)
+
+	require.Equal(t, 12, res.LabelledBlocks)
+	require.Len(t, res.Operators, 2)
+	require.Equal(t, "Operator A", res.Operators[0].Operator)
+	require.Equal(t, 10, res.Operators[0].Blocks)
+	require.InDelta(t, 0.5*0.5+0.1*0.1, res.OperatorHHI, 0.0001)
+	require.Equal(t, 1, res.OperatorsForOneThird)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
@@ -42,9 +43,20 @@ type command struct {
 	validatorsProvider eth2client.ValidatorsProvider
 	chainTime          chaintime.Service
 
+	// Chain parameters.
+	minPerEpochChurnLimit           uint64
+	churnLimitQuotient              uint64
+	maxPerEpochActivationChurnLimit uint64
+	maxSeedLookahead                uint64
+
 	// Output.
-	activationQueue int
-	exitQueue       int
+	activeValidators int
+	activationQueue  int
+	exitQueue        int
+	activationChurn  uint64
+	exitChurn        uint64
+	activationWait   phase0.Epoch
+	exitWait         phase0.Epoch
 }
 
 func newCommand(_ context.Context) (*command, error) {
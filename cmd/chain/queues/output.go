@@ -18,11 +18,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/hako/durafmt"
 )
 
 type jsonOutput struct {
-	ActivationQueue int `json:"activation_queue"`
-	ExitQueue       int `json:"exit_queue"`
+	ActivationQueue  int    `json:"activation_queue"`
+	ExitQueue        int    `json:"exit_queue"`
+	ActiveValidators int    `json:"active_validators"`
+	ActivationChurn  uint64 `json:"activation_churn"`
+	ExitChurn        uint64 `json:"exit_churn"`
+	ActivationWait   uint64 `json:"activation_wait_epochs"`
+	ExitWait         uint64 `json:"exit_wait_epochs"`
 }
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -38,8 +47,13 @@ func (c *command) output(ctx context.Context) (string, error) {
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
 	output := &jsonOutput{
-		ActivationQueue: c.activationQueue,
-		ExitQueue:       c.exitQueue,
+		ActivationQueue:  c.activationQueue,
+		ExitQueue:        c.exitQueue,
+		ActiveValidators: c.activeValidators,
+		ActivationChurn:  c.activationChurn,
+		ExitChurn:        c.exitChurn,
+		ActivationWait:   uint64(c.activationWait),
+		ExitWait:         uint64(c.exitWait),
 	}
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -58,6 +72,20 @@ func (c *command) outputText(_ context.Context) (string, error) {
 	if c.exitQueue > 0 {
 		builder.WriteString(fmt.Sprintf("Exit queue: %d\n", c.exitQueue))
 	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Active validators: %d\n", c.activeValidators))
+	}
+	builder.WriteString(fmt.Sprintf("Activation churn limit: %d validators per epoch\n", c.activationChurn))
+	builder.WriteString(fmt.Sprintf("Exit churn limit: %d validators per epoch\n", c.exitChurn))
+	builder.WriteString(fmt.Sprintf("Estimated activation wait: %s\n", c.epochsDuration(c.activationWait)))
+	builder.WriteString(fmt.Sprintf("Estimated exit wait: %s\n", c.epochsDuration(c.exitWait)))
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
+
+// epochsDuration formats a number of epochs along with its approximate duration.
+func (c *command) epochsDuration(epochs phase0.Epoch) string {
+	duration := time.Duration(uint64(epochs)*c.chainTime.SlotsPerEpoch()) * c.chainTime.SlotDuration()
+
+	return fmt.Sprintf("%d epochs (%s)", epochs, durafmt.Parse(duration).LimitFirstN(2).String())
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -34,15 +36,19 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), nil)
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch))})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
 	}
+	validators := validatorsResponse.Data
 
 	for _, validator := range validators {
 		if validator.Validator == nil {
 			continue
 		}
+		if validator.Validator.ActivationEpoch <= epoch && epoch < validator.Validator.ExitEpoch {
+			c.activeValidators++
+		}
 		if validator.Validator.ActivationEligibilityEpoch <= epoch && validator.Validator.ActivationEpoch > epoch {
 			c.activationQueue++
 		}
@@ -51,9 +57,37 @@ func (c *command) process(ctx context.Context) error {
 		}
 	}
 
+	c.exitChurn = c.churnLimit(c.activeValidators)
+	c.activationChurn = c.exitChurn
+	if epoch >= c.chainTime.DenebInitialEpoch() && c.activationChurn > c.maxPerEpochActivationChurnLimit {
+		c.activationChurn = c.maxPerEpochActivationChurnLimit
+	}
+	c.activationWait = c.queueWait(c.activationQueue, c.activationChurn)
+	c.exitWait = c.queueWait(c.exitQueue, c.exitChurn)
+
 	return nil
 }
 
+// churnLimit returns the number of validators that can be activated or exited
+// each epoch.
+func (c *command) churnLimit(activeValidators int) uint64 {
+	churn := uint64(activeValidators) / c.churnLimitQuotient
+	if churn < c.minPerEpochChurnLimit {
+		churn = c.minPerEpochChurnLimit
+	}
+
+	return churn
+}
+
+// queueWait returns the number of epochs that a validator joining the back of
+// a queue is expected to wait before being processed, including the delay
+// between processing and the validator's activation or exit taking effect.
+func (c *command) queueWait(queue int, churn uint64) phase0.Epoch {
+	queueEpochs := (uint64(queue) + churn) / churn
+
+	return phase0.Epoch(queueEpochs + c.maxSeedLookahead)
+}
+
 func (c *command) setup(ctx context.Context) error {
 	var err error
 
@@ -82,5 +116,27 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.New("connection does not provide validator information")
 	}
 
+	specDataResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+	c.minPerEpochChurnLimit = 4
+	if val, exists := specData["MIN_PER_EPOCH_CHURN_LIMIT"].(uint64); exists {
+		c.minPerEpochChurnLimit = val
+	}
+	c.churnLimitQuotient = 65536
+	if val, exists := specData["CHURN_LIMIT_QUOTIENT"].(uint64); exists {
+		c.churnLimitQuotient = val
+	}
+	c.maxPerEpochActivationChurnLimit = 8
+	if val, exists := specData["MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"].(uint64); exists {
+		c.maxPerEpochActivationChurnLimit = val
+	}
+	c.maxSeedLookahead = 4
+	if val, exists := specData["MAX_SEED_LOOKAHEAD"].(uint64); exists {
+		c.maxSeedLookahead = val
+	}
+
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -64,3 +65,65 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestChurnLimit(t *testing.T) {
+	c := &command{
+		minPerEpochChurnLimit: 4,
+		churnLimitQuotient:    65536,
+	}
+
+	require.Equal(t, uint64(4), c.churnLimit(0))
+	require.Equal(t, uint64(4), c.churnLimit(327679))
+	require.Equal(t, uint64(5), c.churnLimit(327680))
+	require.Equal(t, uint64(15), c.churnLimit(1000000))
+}
+
+func TestQueueWait(t *testing.T) {
+	c := &command{
+		maxSeedLookahead: 4,
+	}
+
+	tests := []struct {
+		name  string
+		queue int
+		churn uint64
+		wait  phase0.Epoch
+	}{
+		{
+			name:  "Empty",
+			queue: 0,
+			churn: 8,
+			wait:  5,
+		},
+		{
+			name:  "PartEpoch",
+			queue: 6,
+			churn: 8,
+			wait:  5,
+		},
+		{
+			name:  "FullEpoch",
+			queue: 7,
+			churn: 8,
+			wait:  5,
+		},
+		{
+			name:  "OverFullEpoch",
+			queue: 8,
+			churn: 8,
+			wait:  6,
+		},
+		{
+			name:  "Long",
+			queue: 14798,
+			churn: 8,
+			wait:  1854,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.wait, c.queueWait(test.queue, test.churn))
+		})
+	}
+}
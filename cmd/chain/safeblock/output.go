@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsafeblock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonBlockInfo struct {
+	Epoch                string `json:"epoch"`
+	Root                 string `json:"root"`
+	Slot                 string `json:"slot"`
+	ExecutionBlockHash   string `json:"execution_block_hash,omitempty"`
+	ExecutionBlockNumber string `json:"execution_block_number,omitempty"`
+}
+
+type jsonOutput struct {
+	Safe      *jsonBlockInfo `json:"safe"`
+	Finalized *jsonBlockInfo `json:"finalized"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Safe:      newJSONBlockInfo(c.safe),
+		Finalized: newJSONBlockInfo(c.finalized),
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func newJSONBlockInfo(info *blockInfo) *jsonBlockInfo {
+	res := &jsonBlockInfo{
+		Epoch: fmt.Sprintf("%d", info.Epoch),
+		Root:  fmt.Sprintf("%#x", info.Root),
+		Slot:  fmt.Sprintf("%d", info.Slot),
+	}
+	if !info.PreMerge {
+		res.ExecutionBlockHash = fmt.Sprintf("%#x", info.ExecutionHash)
+		res.ExecutionBlockNumber = fmt.Sprintf("%d", info.ExecutionBlock)
+	}
+
+	return res
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	c.outputBlockInfo(&builder, "Safe", c.safe)
+	c.outputBlockInfo(&builder, "Finalized", c.finalized)
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputBlockInfo(builder *strings.Builder, name string, info *blockInfo) {
+	if info.PreMerge {
+		builder.WriteString(fmt.Sprintf("%s execution block: none (pre-merge)\n", name))
+	} else {
+		builder.WriteString(fmt.Sprintf("%s execution block: %d\n", name, info.ExecutionBlock))
+		builder.WriteString(fmt.Sprintf("%s execution block hash: %#x\n", name, info.ExecutionHash))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("%s checkpoint epoch: %d\n", name, info.Epoch))
+		builder.WriteString(fmt.Sprintf("%s beacon block slot: %d\n", name, info.Slot))
+		builder.WriteString(fmt.Sprintf("%s beacon block root: %#x\n", name, info.Root))
+	}
+}
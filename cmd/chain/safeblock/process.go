@@ -0,0 +1,135 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsafeblock
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	finalityResponse, err := c.finalityProvider.Finality(ctx, &api.FinalityOpts{State: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	finality := finalityResponse.Data
+
+	// The safe block is the block of the current justified checkpoint, as per
+	// the fork choice safe block specification.
+	c.safe, err = c.checkpointBlockInfo(ctx, finality.Justified)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain safe block")
+	}
+
+	c.finalized, err = c.checkpointBlockInfo(ctx, finality.Finalized)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finalized block")
+	}
+
+	return nil
+}
+
+// checkpointBlockInfo obtains block information for the block referenced by a checkpoint.
+func (c *command) checkpointBlockInfo(ctx context.Context, checkpoint *phase0.Checkpoint) (*blockInfo, error) {
+	blockID := fmt.Sprintf("%#x", checkpoint.Root)
+	if checkpoint.Root == (phase0.Root{}) {
+		// Checkpoint at genesis.
+		blockID = "genesis"
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching block %s for checkpoint at epoch %d\n", blockID, checkpoint.Epoch)
+	}
+
+	block, err := util.ResponseData(c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block")
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", blockID)
+	}
+
+	info := &blockInfo{
+		Epoch: checkpoint.Epoch,
+	}
+	info.Slot, err = block.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block slot")
+	}
+	info.Root, err = block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block root")
+	}
+
+	if block.Version == spec.DataVersionPhase0 || block.Version == spec.DataVersionAltair {
+		info.PreMerge = true
+
+		return info, nil
+	}
+
+	// Bellatrix and later blocks all carry an execution payload.
+	info.ExecutionHash, err = block.ExecutionBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+	info.ExecutionBlock, err = block.ExecutionBlockNumber()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block number")
+	}
+	if block.Version == spec.DataVersionBellatrix {
+		// Bellatrix blocks prior to the merge have empty execution payloads.
+		info.PreMerge = info.ExecutionHash == phase0.Hash32{}
+	}
+
+	return info, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality information")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
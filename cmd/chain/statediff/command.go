@@ -0,0 +1,196 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstatediff
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// Sections of the diff that can be selected.
+const (
+	sectionValidators    = "validators"
+	sectionBalances      = "balances"
+	sectionJustification = "justification"
+	sectionFork          = "fork"
+	sectionHistorical    = "historical"
+)
+
+var allSections = []string{
+	sectionValidators,
+	sectionBalances,
+	sectionJustification,
+	sectionFork,
+	sectionHistorical,
+}
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	fromEpoch  string
+	toEpoch    string
+	validators map[phase0.ValidatorIndex]bool
+	sections   map[string]bool
+
+	// Data access.
+	eth2Client          eth2client.Service
+	chainTime           chaintime.Service
+	beaconStateProvider eth2client.BeaconStateProvider
+
+	// Output.
+	diff *stateDiff
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		json:     viper.GetBool("json"),
+		sections: make(map[string]bool),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	if len(viper.GetStringSlice("validators")) > 0 {
+		c.validators = make(map[phase0.ValidatorIndex]bool)
+		for _, validator := range viper.GetStringSlice("validators") {
+			index, err := strconv.ParseUint(strings.TrimSpace(validator), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validator index %q", validator)
+			}
+			c.validators[phase0.ValidatorIndex(index)] = true
+		}
+	}
+
+	sections := viper.GetStringSlice("sections")
+	if len(sections) == 0 {
+		sections = allSections
+	}
+	for _, section := range sections {
+		section = strings.ToLower(strings.TrimSpace(section))
+		valid := false
+		for _, known := range allSections {
+			if section == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown section %q", section)
+		}
+		c.sections[section] = true
+	}
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
+
+// stateDiff is the difference between two states.
+type stateDiff struct {
+	FromEpoch     uint64             `json:"from_epoch"`
+	ToEpoch       uint64             `json:"to_epoch"`
+	FromSlot      phase0.Slot        `json:"from_slot"`
+	ToSlot        phase0.Slot        `json:"to_slot"`
+	Validators    *validatorsDiff    `json:"validators,omitempty"`
+	Balances      *balancesDiff      `json:"balances,omitempty"`
+	Justification *justificationDiff `json:"justification,omitempty"`
+	Fork          *forkDiff          `json:"fork,omitempty"`
+	Historical    *historicalDiff    `json:"historical,omitempty"`
+}
+
+type validatorsDiff struct {
+	Added   []phase0.ValidatorIndex `json:"added"`
+	Changed []*validatorChange      `json:"changed"`
+}
+
+type validatorChange struct {
+	Index   phase0.ValidatorIndex `json:"index"`
+	Changes []*fieldChange        `json:"changes"`
+}
+
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type balancesDiff struct {
+	FromTotal       phase0.Gwei    `json:"from_total"`
+	ToTotal         phase0.Gwei    `json:"to_total"`
+	Increased       int            `json:"increased"`
+	Decreased       int            `json:"decreased"`
+	Unchanged       int            `json:"unchanged"`
+	New             int            `json:"new"`
+	LargestIncrease *balanceChange `json:"largest_increase,omitempty"`
+	LargestDecrease *balanceChange `json:"largest_decrease,omitempty"`
+}
+
+type balanceChange struct {
+	Index phase0.ValidatorIndex `json:"index"`
+	Delta int64                 `json:"delta"`
+}
+
+type justificationDiff struct {
+	FromBits              string             `json:"from_bits"`
+	ToBits                string             `json:"to_bits"`
+	FromPreviousJustified *phase0.Checkpoint `json:"from_previous_justified"`
+	ToPreviousJustified   *phase0.Checkpoint `json:"to_previous_justified"`
+	FromCurrentJustified  *phase0.Checkpoint `json:"from_current_justified"`
+	ToCurrentJustified    *phase0.Checkpoint `json:"to_current_justified"`
+	FromFinalized         *phase0.Checkpoint `json:"from_finalized"`
+	ToFinalized           *phase0.Checkpoint `json:"to_finalized"`
+}
+
+type forkDiff struct {
+	From    *phase0.Fork `json:"from"`
+	To      *phase0.Fork `json:"to"`
+	Changed bool         `json:"changed"`
+}
+
+type historicalDiff struct {
+	HistoricalRootsAppended     []phase0.Root                `json:"historical_roots_appended"`
+	HistoricalSummariesAppended []*capella.HistoricalSummary `json:"historical_summaries_appended"`
+}
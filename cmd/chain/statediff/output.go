@@ -0,0 +1,151 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstatediff
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.diff)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Comparing epoch %d (slot %d) with epoch %d (slot %d)\n", c.diff.FromEpoch, c.diff.FromSlot, c.diff.ToEpoch, c.diff.ToSlot))
+
+	if c.diff.Fork != nil {
+		if c.diff.Fork.Changed {
+			builder.WriteString(fmt.Sprintf("Fork: %#x -> %#x (epoch %d)\n", c.diff.Fork.From.CurrentVersion, c.diff.Fork.To.CurrentVersion, c.diff.Fork.To.Epoch))
+		} else {
+			builder.WriteString(fmt.Sprintf("Fork: unchanged (%#x)\n", c.diff.Fork.To.CurrentVersion))
+		}
+	}
+
+	if c.diff.Justification != nil {
+		j := c.diff.Justification
+		builder.WriteString(fmt.Sprintf("Justification bits: %s -> %s\n", j.FromBits, j.ToBits))
+		outputCheckpointChange(&builder, "Previous justified", j.FromPreviousJustified, j.ToPreviousJustified)
+		outputCheckpointChange(&builder, "Current justified", j.FromCurrentJustified, j.ToCurrentJustified)
+		outputCheckpointChange(&builder, "Finalized", j.FromFinalized, j.ToFinalized)
+	}
+
+	if c.diff.Historical != nil {
+		builder.WriteString(fmt.Sprintf("Historical roots appended: %d\n", len(c.diff.Historical.HistoricalRootsAppended)))
+		if c.verbose {
+			for _, root := range c.diff.Historical.HistoricalRootsAppended {
+				builder.WriteString(fmt.Sprintf("  %#x\n", root))
+			}
+		}
+		builder.WriteString(fmt.Sprintf("Historical summaries appended: %d\n", len(c.diff.Historical.HistoricalSummariesAppended)))
+		if c.verbose {
+			for _, summary := range c.diff.Historical.HistoricalSummariesAppended {
+				builder.WriteString(fmt.Sprintf("  Block summary root %#x, state summary root %#x\n", summary.BlockSummaryRoot, summary.StateSummaryRoot))
+			}
+		}
+	}
+
+	if c.diff.Validators != nil {
+		builder.WriteString(fmt.Sprintf("Validators added: %d\n", len(c.diff.Validators.Added)))
+		builder.WriteString(fmt.Sprintf("Validators changed: %d\n", len(c.diff.Validators.Changed)))
+		if c.verbose {
+			for _, validator := range c.diff.Validators.Changed {
+				builder.WriteString(fmt.Sprintf("  %d:\n", validator.Index))
+				for _, change := range validator.Changes {
+					builder.WriteString(fmt.Sprintf("    %s: %s -> %s\n", change.Field, change.From, change.To))
+				}
+			}
+		} else {
+			fieldCounts := make(map[string]int)
+			fields := make([]string, 0)
+			for _, validator := range c.diff.Validators.Changed {
+				for _, change := range validator.Changes {
+					if _, exists := fieldCounts[change.Field]; !exists {
+						fields = append(fields, change.Field)
+					}
+					fieldCounts[change.Field]++
+				}
+			}
+			for _, field := range fields {
+				builder.WriteString(fmt.Sprintf("  %s: %d\n", field, fieldCounts[field]))
+			}
+		}
+	}
+
+	if c.diff.Balances != nil {
+		b := c.diff.Balances
+		builder.WriteString(fmt.Sprintf("Total balance: %s -> %s (%s)\n",
+			string2eth.GWeiToString(uint64(b.FromTotal), true),
+			string2eth.GWeiToString(uint64(b.ToTotal), true),
+			signedGWeiToString(int64(b.ToTotal)-int64(b.FromTotal)),
+		))
+		builder.WriteString(fmt.Sprintf("Balances increased: %d\n", b.Increased))
+		builder.WriteString(fmt.Sprintf("Balances decreased: %d\n", b.Decreased))
+		builder.WriteString(fmt.Sprintf("Balances unchanged: %d\n", b.Unchanged))
+		if b.New > 0 {
+			builder.WriteString(fmt.Sprintf("New balances: %d\n", b.New))
+		}
+		if b.LargestIncrease != nil {
+			builder.WriteString(fmt.Sprintf("Largest increase: validator %d (%s)\n", b.LargestIncrease.Index, signedGWeiToString(b.LargestIncrease.Delta)))
+		}
+		if b.LargestDecrease != nil {
+			builder.WriteString(fmt.Sprintf("Largest decrease: validator %d (%s)\n", b.LargestDecrease.Index, signedGWeiToString(b.LargestDecrease.Delta)))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func outputCheckpointChange(builder *strings.Builder, name string, from *phase0.Checkpoint, to *phase0.Checkpoint) {
+	if from == nil || to == nil {
+		return
+	}
+	if from.Epoch == to.Epoch && from.Root == to.Root {
+		builder.WriteString(fmt.Sprintf("%s checkpoint: unchanged (epoch %d)\n", name, to.Epoch))
+		return
+	}
+	builder.WriteString(fmt.Sprintf("%s checkpoint: epoch %d -> epoch %d\n", name, from.Epoch, to.Epoch))
+}
+
+// signedGWeiToString returns a signed string representation of a Gwei delta.
+func signedGWeiToString(delta int64) string {
+	if delta < 0 {
+		return "-" + string2eth.GWeiToString(uint64(-delta), true)
+	}
+
+	return "+" + string2eth.GWeiToString(uint64(delta), true)
+}
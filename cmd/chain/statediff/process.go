@@ -0,0 +1,408 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstatediff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// minTimeout is the minimum timeout for this command, as beacon states are large.
+var minTimeout = 2 * time.Minute
+
+// stateSummary contains the parts of a beacon state that are compared.
+type stateSummary struct {
+	slot                phase0.Slot
+	fork                *phase0.Fork
+	justificationBits   bitfield.Bitvector4
+	previousJustified   *phase0.Checkpoint
+	currentJustified    *phase0.Checkpoint
+	finalized           *phase0.Checkpoint
+	historicalRoots     []phase0.Root
+	historicalSummaries []*capella.HistoricalSummary
+	validators          []*phase0.Validator
+	balances            []phase0.Gwei
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "invalid from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "invalid to epoch")
+	}
+	if fromEpoch >= toEpoch {
+		return errors.New("from epoch must be before to epoch")
+	}
+
+	from, err := c.fetchState(ctx, fromEpoch)
+	if err != nil {
+		return err
+	}
+	to, err := c.fetchState(ctx, toEpoch)
+	if err != nil {
+		return err
+	}
+
+	c.diff = diffStates(from, to, c.sections, c.validators)
+	c.diff.FromEpoch = uint64(fromEpoch)
+	c.diff.ToEpoch = uint64(toEpoch)
+
+	return nil
+}
+
+// fetchState fetches the state at the start of the given epoch.
+func (c *command) fetchState(ctx context.Context, epoch phase0.Epoch) (*stateSummary, error) {
+	slot := c.chainTime.FirstSlotOfEpoch(epoch)
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching state at slot %d\n", slot)
+	}
+	stateResponse, err := c.beaconStateProvider.BeaconState(ctx, &api.BeaconStateOpts{State: fmt.Sprintf("%d", slot)})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain state at slot %d", slot))
+	}
+	state := stateResponse.Data
+	if state == nil || state.IsEmpty() {
+		return nil, fmt.Errorf("no state at slot %d", slot)
+	}
+
+	return summarizeState(state)
+}
+
+// summarizeState obtains the parts of a versioned state that are compared.
+func summarizeState(state *spec.VersionedBeaconState) (*stateSummary, error) {
+	summary := &stateSummary{}
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		s := state.Phase0
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionAltair:
+		s := state.Altair
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionBellatrix:
+		s := state.Bellatrix
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionCapella:
+		s := state.Capella
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionDeneb:
+		s := state.Deneb
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionElectra:
+		s := state.Electra
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	case spec.DataVersionFulu:
+		s := state.Fulu
+		summary.slot = s.Slot
+		summary.fork = s.Fork
+		summary.justificationBits = s.JustificationBits
+		summary.previousJustified = s.PreviousJustifiedCheckpoint
+		summary.currentJustified = s.CurrentJustifiedCheckpoint
+		summary.finalized = s.FinalizedCheckpoint
+		summary.historicalRoots = s.HistoricalRoots
+		summary.historicalSummaries = s.HistoricalSummaries
+		summary.validators = s.Validators
+		summary.balances = s.Balances
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+
+	return summary, nil
+}
+
+// diffStates generates the difference between two states.
+func diffStates(from *stateSummary,
+	to *stateSummary,
+	sections map[string]bool,
+	validators map[phase0.ValidatorIndex]bool,
+) *stateDiff {
+	diff := &stateDiff{
+		FromSlot: from.slot,
+		ToSlot:   to.slot,
+	}
+
+	if sections[sectionValidators] {
+		diff.Validators = diffValidators(from.validators, to.validators, validators)
+	}
+	if sections[sectionBalances] {
+		diff.Balances = diffBalances(from.balances, to.balances, validators)
+	}
+	if sections[sectionJustification] {
+		diff.Justification = &justificationDiff{
+			FromBits:              fmt.Sprintf("%#x", from.justificationBits.Bytes()),
+			ToBits:                fmt.Sprintf("%#x", to.justificationBits.Bytes()),
+			FromPreviousJustified: from.previousJustified,
+			ToPreviousJustified:   to.previousJustified,
+			FromCurrentJustified:  from.currentJustified,
+			ToCurrentJustified:    to.currentJustified,
+			FromFinalized:         from.finalized,
+			ToFinalized:           to.finalized,
+		}
+	}
+	if sections[sectionFork] {
+		diff.Fork = &forkDiff{
+			From:    from.fork,
+			To:      to.fork,
+			Changed: !forksEqual(from.fork, to.fork),
+		}
+	}
+	if sections[sectionHistorical] {
+		diff.Historical = &historicalDiff{
+			HistoricalRootsAppended:     make([]phase0.Root, 0),
+			HistoricalSummariesAppended: make([]*capella.HistoricalSummary, 0),
+		}
+		if len(to.historicalRoots) > len(from.historicalRoots) {
+			diff.Historical.HistoricalRootsAppended = to.historicalRoots[len(from.historicalRoots):]
+		}
+		if len(to.historicalSummaries) > len(from.historicalSummaries) {
+			diff.Historical.HistoricalSummariesAppended = to.historicalSummaries[len(from.historicalSummaries):]
+		}
+	}
+
+	return diff
+}
+
+func diffValidators(from []*phase0.Validator,
+	to []*phase0.Validator,
+	filter map[phase0.ValidatorIndex]bool,
+) *validatorsDiff {
+	diff := &validatorsDiff{
+		Changed: make([]*validatorChange, 0),
+		Added:   make([]phase0.ValidatorIndex, 0),
+	}
+
+	for i := range to {
+		index := phase0.ValidatorIndex(i)
+		if filter != nil && !filter[index] {
+			continue
+		}
+		if i >= len(from) {
+			diff.Added = append(diff.Added, index)
+			continue
+		}
+		changes := validatorFieldChanges(from[i], to[i])
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, &validatorChange{
+				Index:   index,
+				Changes: changes,
+			})
+		}
+	}
+
+	return diff
+}
+
+func validatorFieldChanges(from *phase0.Validator, to *phase0.Validator) []*fieldChange {
+	changes := make([]*fieldChange, 0)
+	if !bytes.Equal(from.WithdrawalCredentials, to.WithdrawalCredentials) {
+		changes = append(changes, &fieldChange{
+			Field: "withdrawal_credentials",
+			From:  fmt.Sprintf("%#x", from.WithdrawalCredentials),
+			To:    fmt.Sprintf("%#x", to.WithdrawalCredentials),
+		})
+	}
+	if from.EffectiveBalance != to.EffectiveBalance {
+		changes = append(changes, &fieldChange{
+			Field: "effective_balance",
+			From:  fmt.Sprintf("%d", from.EffectiveBalance),
+			To:    fmt.Sprintf("%d", to.EffectiveBalance),
+		})
+	}
+	if from.Slashed != to.Slashed {
+		changes = append(changes, &fieldChange{
+			Field: "slashed",
+			From:  fmt.Sprintf("%t", from.Slashed),
+			To:    fmt.Sprintf("%t", to.Slashed),
+		})
+	}
+	epochChanges := []struct {
+		field string
+		from  phase0.Epoch
+		to    phase0.Epoch
+	}{
+		{"activation_eligibility_epoch", from.ActivationEligibilityEpoch, to.ActivationEligibilityEpoch},
+		{"activation_epoch", from.ActivationEpoch, to.ActivationEpoch},
+		{"exit_epoch", from.ExitEpoch, to.ExitEpoch},
+		{"withdrawable_epoch", from.WithdrawableEpoch, to.WithdrawableEpoch},
+	}
+	for _, epochChange := range epochChanges {
+		if epochChange.from != epochChange.to {
+			changes = append(changes, &fieldChange{
+				Field: epochChange.field,
+				From:  fmt.Sprintf("%d", epochChange.from),
+				To:    fmt.Sprintf("%d", epochChange.to),
+			})
+		}
+	}
+
+	return changes
+}
+
+func diffBalances(from []phase0.Gwei,
+	to []phase0.Gwei,
+	filter map[phase0.ValidatorIndex]bool,
+) *balancesDiff {
+	diff := &balancesDiff{}
+
+	for i := range to {
+		index := phase0.ValidatorIndex(i)
+		if filter != nil && !filter[index] {
+			continue
+		}
+		diff.ToTotal += to[i]
+		if i >= len(from) {
+			diff.New++
+			continue
+		}
+		diff.FromTotal += from[i]
+
+		delta := int64(to[i]) - int64(from[i])
+		switch {
+		case delta > 0:
+			diff.Increased++
+			if diff.LargestIncrease == nil || delta > diff.LargestIncrease.Delta {
+				diff.LargestIncrease = &balanceChange{Index: index, Delta: delta}
+			}
+		case delta < 0:
+			diff.Decreased++
+			if diff.LargestDecrease == nil || delta < diff.LargestDecrease.Delta {
+				diff.LargestDecrease = &balanceChange{Index: index, Delta: delta}
+			}
+		default:
+			diff.Unchanged++
+		}
+	}
+
+	return diff
+}
+
+func forksEqual(from *phase0.Fork, to *phase0.Fork) bool {
+	if from == nil || to == nil {
+		return from == to
+	}
+
+	return from.PreviousVersion == to.PreviousVersion &&
+		from.CurrentVersion == to.CurrentVersion &&
+		from.Epoch == to.Epoch
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.beaconStateProvider, isProvider = c.eth2Client.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon states")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainstatediff
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffStates(t *testing.T) {
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+	from := &stateSummary{
+		slot: 32,
+		fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x01},
+			CurrentVersion:  phase0.Version{0x01},
+		},
+		validators: []*phase0.Validator{
+			{EffectiveBalance: 32000000000, ExitEpoch: farFuture},
+			{EffectiveBalance: 32000000000, ExitEpoch: farFuture},
+		},
+		balances: []phase0.Gwei{32000000000, 32000000000},
+	}
+	to := &stateSummary{
+		slot: 64,
+		fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x01},
+			CurrentVersion:  phase0.Version{0x02},
+			Epoch:           2,
+		},
+		historicalSummaries: []*capella.HistoricalSummary{{}},
+		validators: []*phase0.Validator{
+			{EffectiveBalance: 32000000000, ExitEpoch: farFuture},
+			{EffectiveBalance: 31000000000, ExitEpoch: 10, Slashed: true},
+			{EffectiveBalance: 32000000000, ExitEpoch: farFuture},
+		},
+		balances: []phase0.Gwei{32000001000, 31000000000, 32000000000},
+	}
+
+	sections := map[string]bool{
+		sectionValidators: true,
+		sectionBalances:   true,
+		sectionFork:       true,
+		sectionHistorical: true,
+	}
+
+	diff := diffStates(from, to, sections, nil)
+	require.Nil(t, diff.Justification)
+	require.True(t, diff.Fork.Changed)
+	require.Len(t, diff.Historical.HistoricalRootsAppended, 0)
+	require.Len(t, diff.Historical.HistoricalSummariesAppended, 1)
+	require.Equal(t, []phase0.ValidatorIndex{2}, diff.Validators.Added)
+	require.Len(t, diff.Validators.Changed, 1)
+	require.Equal(t, phase0.ValidatorIndex(1), diff.Validators.Changed[0].Index)
+	require.Len(t, diff.Validators.Changed[0].Changes, 3)
+	require.Equal(t, 1, diff.Balances.Increased)
+	require.Equal(t, 1, diff.Balances.Decreased)
+	require.Equal(t, 1, diff.Balances.New)
+	require.Equal(t, int64(1000), diff.Balances.LargestIncrease.Delta)
+	require.Equal(t, int64(-1000000000), diff.Balances.LargestDecrease.Delta)
+
+	// Filter to a single validator.
+	diff = diffStates(from, to, sections, map[phase0.ValidatorIndex]bool{0: true})
+	require.Len(t, diff.Validators.Added, 0)
+	require.Len(t, diff.Validators.Changed, 0)
+	require.Equal(t, 1, diff.Balances.Increased)
+	require.Equal(t, 0, diff.Balances.Decreased)
+	require.Nil(t, diff.Balances.LargestDecrease)
+}
+
+func TestSummarizeState(t *testing.T) {
+	fork := &phase0.Fork{CurrentVersion: phase0.Version{0x05}}
+	validators := []*phase0.Validator{{EffectiveBalance: 32000000000}}
+	balances := []phase0.Gwei{32000000000}
+	historicalSummaries := []*capella.HistoricalSummary{{}}
+
+	summary, err := summarizeState(&spec.VersionedBeaconState{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.BeaconState{
+			Slot:                100,
+			Fork:                fork,
+			HistoricalSummaries: historicalSummaries,
+			Validators:          validators,
+			Balances:            balances,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(100), summary.slot)
+	require.Equal(t, fork, summary.fork)
+	require.Equal(t, historicalSummaries, summary.historicalSummaries)
+	require.Equal(t, validators, summary.validators)
+	require.Equal(t, balances, summary.balances)
+
+	_, err = summarizeState(&spec.VersionedBeaconState{
+		Version: spec.DataVersion(99),
+	})
+	require.EqualError(t, err, "unhandled state version unknown")
+}
@@ -0,0 +1,108 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsupply
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	epoch           string
+	samples         uint64
+	interval        uint64
+	withdrawalsFrom string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	WithdrawalsFrom *uint64   `json:"withdrawals_from,omitempty"`
+	Samples         []*sample `json:"samples"`
+}
+
+// sample is the supply of Ether on the beacon chain at the start of an epoch.
+type sample struct {
+	Epoch            uint64 `json:"epoch"`
+	Validators       int    `json:"validators"`
+	ActiveValidators int    `json:"active_validators"`
+	// Staked is the balance of active validators.
+	Staked phase0.Gwei `json:"staked"`
+	// PendingDeposits is the balance of validators awaiting activation plus
+	// any deposits in the pending deposits queue.
+	PendingDeposits phase0.Gwei `json:"pending_deposits"`
+	// Balance is the balance of all validators.
+	Balance phase0.Gwei `json:"balance"`
+	// EffectiveBalance is the effective balance of all validators.
+	EffectiveBalance phase0.Gwei `json:"effective_balance"`
+	// Withdrawn is the amount withdrawn since the withdrawals-from epoch.
+	Withdrawn *phase0.Gwei `json:"withdrawn,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.epoch = viper.GetString("epoch")
+
+	c.samples = viper.GetUint64("samples")
+	if c.samples == 0 {
+		return nil, errors.New("samples must be at least 1")
+	}
+	c.interval = viper.GetUint64("interval")
+	if c.samples > 1 && c.interval == 0 {
+		return nil, errors.New("interval is required for multiple samples")
+	}
+
+	c.withdrawalsFrom = viper.GetString("withdrawals-from")
+
+	return c, nil
+}
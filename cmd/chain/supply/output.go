@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsupply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, sample := range c.results.Samples {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", sample.Epoch))
+		builder.WriteString(fmt.Sprintf("  Validators: %d (%d active)\n", sample.Validators, sample.ActiveValidators))
+		builder.WriteString(fmt.Sprintf("  Staked: %s\n", string2eth.GWeiToString(uint64(sample.Staked), true)))
+		builder.WriteString(fmt.Sprintf("  Pending deposits: %s\n", string2eth.GWeiToString(uint64(sample.PendingDeposits), true)))
+		builder.WriteString(fmt.Sprintf("  Balance: %s\n", string2eth.GWeiToString(uint64(sample.Balance), true)))
+		builder.WriteString(fmt.Sprintf("  Effective balance: %s\n", string2eth.GWeiToString(uint64(sample.EffectiveBalance), true)))
+		if sample.Withdrawn != nil {
+			builder.WriteString(fmt.Sprintf("  Withdrawn since epoch %d: %s\n", *c.results.WithdrawalsFrom, string2eth.GWeiToString(uint64(*sample.Withdrawn), true)))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,259 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsupply
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type pendingDepositJSON struct {
+	Amount string `json:"amount"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+
+	epochs, err := sampleEpochs(epoch, c.samples, c.interval)
+	if err != nil {
+		return err
+	}
+
+	c.results = &results{
+		Samples: make([]*sample, 0, len(epochs)),
+	}
+
+	// Withdrawals are accumulated from block to block, so keep track of
+	// where we have reached.
+	var withdrawn phase0.Gwei
+	var withdrawalsSlot phase0.Slot
+	if c.withdrawalsFrom != "" {
+		withdrawalsFrom, err := util.ParseEpoch(ctx, c.chainTime, c.withdrawalsFrom)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse withdrawals-from epoch")
+		}
+		if withdrawalsFrom > epochs[0] {
+			return errors.New("withdrawals-from epoch must not be after the first sampled epoch")
+		}
+		withdrawalsFromEpoch := uint64(withdrawalsFrom)
+		c.results.WithdrawalsFrom = &withdrawalsFromEpoch
+		withdrawalsSlot = c.chainTime.FirstSlotOfEpoch(withdrawalsFrom)
+	}
+
+	for _, epoch := range epochs {
+		sample, err := c.obtainSample(ctx, epoch)
+		if err != nil {
+			return err
+		}
+
+		if c.results.WithdrawalsFrom != nil {
+			endSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+			amount, err := c.obtainWithdrawals(ctx, withdrawalsSlot, endSlot)
+			if err != nil {
+				return err
+			}
+			withdrawn += amount
+			withdrawalsSlot = endSlot
+			sampleWithdrawn := withdrawn
+			sample.Withdrawn = &sampleWithdrawn
+		}
+
+		c.results.Samples = append(c.results.Samples, sample)
+	}
+
+	return nil
+}
+
+// sampleEpochs returns the epochs to sample in increasing order, ending at the given epoch.
+func sampleEpochs(epoch phase0.Epoch, samples uint64, interval uint64) ([]phase0.Epoch, error) {
+	if (samples-1)*interval > uint64(epoch) {
+		return nil, fmt.Errorf("chain is not old enough for %d samples %d epochs apart", samples, interval)
+	}
+
+	res := make([]phase0.Epoch, 0, samples)
+	for i := samples; i > 0; i-- {
+		res = append(res, epoch-phase0.Epoch((i-1)*interval))
+	}
+
+	return res, nil
+}
+
+// obtainSample obtains the supply at the start of the given epoch.
+func (c *command) obtainSample(ctx context.Context, epoch phase0.Epoch) (*sample, error) {
+	slot := c.chainTime.FirstSlotOfEpoch(epoch)
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Obtaining validators at slot %d\n", slot)
+	}
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", slot)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+
+	res := summariseValidators(epoch, validators)
+
+	pendingDeposits, err := c.obtainPendingDeposits(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	res.PendingDeposits += pendingDeposits
+
+	return res, nil
+}
+
+// summariseValidators summarises the balances of the validators at the given epoch.
+func summariseValidators(epoch phase0.Epoch, validators map[phase0.ValidatorIndex]*apiv1.Validator) *sample {
+	res := &sample{
+		Epoch:      uint64(epoch),
+		Validators: len(validators),
+	}
+
+	for _, validator := range validators {
+		res.Balance += validator.Balance
+		res.EffectiveBalance += validator.Validator.EffectiveBalance
+		switch {
+		case validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch:
+			res.ActiveValidators++
+			res.Staked += validator.Balance
+		case validator.Validator.ActivationEpoch > epoch:
+			res.PendingDeposits += validator.Balance
+		}
+	}
+
+	return res
+}
+
+// obtainPendingDeposits obtains the total of the pending deposits queue at the given slot.
+// States prior to Electra do not have the queue, in which case this returns 0.
+func (c *command) obtainPendingDeposits(ctx context.Context, slot phase0.Slot) (phase0.Gwei, error) {
+	data := make([]*pendingDepositJSON, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, fmt.Sprintf("/eth/v1/beacon/states/%d/pending_deposits", slot), &data)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	if !found {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "No pending deposits queue at slot %d\n", slot)
+		}
+		return 0, nil
+	}
+
+	var res phase0.Gwei
+	for i := range data {
+		amount, err := strconv.ParseUint(data[i].Amount, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "invalid pending deposit amount")
+		}
+		res += phase0.Gwei(amount)
+	}
+
+	return res, nil
+}
+
+// obtainWithdrawals obtains the total withdrawn in blocks from the start slot
+// up to but not including the end slot.
+func (c *command) obtainWithdrawals(ctx context.Context, startSlot phase0.Slot, endSlot phase0.Slot) (phase0.Gwei, error) {
+	var res phase0.Gwei
+	for slot := startSlot; slot < endSlot; slot++ {
+		if c.debug && slot%1000 == 0 {
+			fmt.Fprintf(os.Stderr, "Obtaining withdrawals at slot %d\n", slot)
+		}
+		block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			// No block at this slot.
+			continue
+		}
+		withdrawals, err := blockWithdrawals(block)
+		if err != nil {
+			return 0, err
+		}
+		for _, withdrawal := range withdrawals {
+			res += withdrawal.Amount
+		}
+	}
+
+	return res, nil
+}
+
+// blockWithdrawals returns the withdrawals in a block.
+func blockWithdrawals(block *spec.VersionedSignedBeaconBlock) ([]*capella.Withdrawal, error) {
+	if block.Version < spec.DataVersionCapella {
+		// No withdrawals prior to Capella.
+		return nil, nil
+	}
+	withdrawals, err := block.Withdrawals()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain withdrawals")
+	}
+
+	return withdrawals, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block information")
+	}
+
+	return nil
+}
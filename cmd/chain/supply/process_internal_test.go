@@ -0,0 +1,175 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsupply
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleEpochs(t *testing.T) {
+	tests := []struct {
+		name     string
+		epoch    phase0.Epoch
+		samples  uint64
+		interval uint64
+		expected []phase0.Epoch
+		err      string
+	}{
+		{
+			name:     "Single",
+			epoch:    1000,
+			samples:  1,
+			expected: []phase0.Epoch{1000},
+		},
+		{
+			name:     "Series",
+			epoch:    1000,
+			samples:  3,
+			interval: 225,
+			expected: []phase0.Epoch{550, 775, 1000},
+		},
+		{
+			name:     "Genesis",
+			epoch:    450,
+			samples:  3,
+			interval: 225,
+			expected: []phase0.Epoch{0, 225, 450},
+		},
+		{
+			name:     "TooOld",
+			epoch:    449,
+			samples:  3,
+			interval: 225,
+			err:      "chain is not old enough for 3 samples 225 epochs apart",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := sampleEpochs(test.epoch, test.samples, test.interval)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestSummariseValidators(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		// Active.
+		0: {
+			Index:   0,
+			Balance: 32_100_000_000,
+			Validator: &phase0.Validator{
+				EffectiveBalance: 32_000_000_000,
+				ActivationEpoch:  0,
+				ExitEpoch:        0xffffffffffffffff,
+			},
+		},
+		// Pending activation.
+		1: {
+			Index:   1,
+			Balance: 32_000_000_000,
+			Validator: &phase0.Validator{
+				EffectiveBalance: 32_000_000_000,
+				ActivationEpoch:  0xffffffffffffffff,
+				ExitEpoch:        0xffffffffffffffff,
+			},
+		},
+		// Exited.
+		2: {
+			Index:   2,
+			Balance: 31_000_000_000,
+			Validator: &phase0.Validator{
+				EffectiveBalance: 31_000_000_000,
+				ActivationEpoch:  0,
+				ExitEpoch:        50,
+			},
+		},
+	}
+
+	res := summariseValidators(100, validators)
+	require.Equal(t, &sample{
+		Epoch:            100,
+		Validators:       3,
+		ActiveValidators: 1,
+		Staked:           32_100_000_000,
+		PendingDeposits:  32_000_000_000,
+		Balance:          95_100_000_000,
+		EffectiveBalance: 95_000_000_000,
+	}, res)
+}
+
+func TestBlockWithdrawals(t *testing.T) {
+	withdrawals := []*capella.Withdrawal{
+		{Index: 1, ValidatorIndex: 2, Amount: 10},
+		{Index: 2, ValidatorIndex: 3, Amount: 20},
+	}
+
+	res, err := blockWithdrawals(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionBellatrix,
+		Bellatrix: &bellatrix.SignedBeaconBlock{
+			Message: &bellatrix.BeaconBlock{},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	res, err = blockWithdrawals(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Body: &capella.BeaconBlockBody{
+					ExecutionPayload: &capella.ExecutionPayload{
+						Withdrawals: withdrawals,
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, withdrawals, res)
+
+	res, err = blockWithdrawals(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Body: &electra.BeaconBlockBody{
+					ExecutionPayload: &deneb.ExecutionPayload{
+						Withdrawals: withdrawals,
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, withdrawals, res)
+
+	_, err = blockWithdrawals(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+	})
+	require.EqualError(t, err, "failed to obtain withdrawals: no electra block")
+}
@@ -27,7 +27,6 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
-	json    bool
 	// Input
 	connection               string
 	allowInsecureConnections bool
@@ -46,7 +45,6 @@ func input(_ context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
-	data.json = viper.GetBool("json")
 
 	haveInput := false
 	if viper.GetString("timestamp") != "" {
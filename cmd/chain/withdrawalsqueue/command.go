@@ -0,0 +1,92 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainwithdrawalsqueue
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	count int
+	find  string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+	chainTime          chaintime.Service
+
+	// Parameters.
+	params *parameters
+
+	// Output.
+	slot               phase0.Slot
+	nextValidatorIndex phase0.ValidatorIndex
+	withdrawals        []*withdrawal
+	found              *withdrawal
+	foundIndex         phase0.ValidatorIndex
+	foundPosition      int
+}
+
+// withdrawal is an expected withdrawal from the sweep.
+type withdrawal struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Amount         phase0.Gwei
+	Slot           phase0.Slot
+	Full           bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.count = viper.GetInt("count")
+	if c.count < 1 {
+		return nil, errors.New("count must be at least 1")
+	}
+	c.find = viper.GetString("find")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainwithdrawalsqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type jsonWithdrawal struct {
+	ValidatorIndex string `json:"validator_index"`
+	Amount         string `json:"amount"`
+	Slot           string `json:"slot"`
+	Type           string `json:"type"`
+}
+
+type jsonFind struct {
+	ValidatorIndex  string          `json:"validator_index"`
+	WithdrawalsToGo string          `json:"withdrawals_to_go,omitempty"`
+	BlocksToGo      string          `json:"blocks_to_go,omitempty"`
+	Withdrawal      *jsonWithdrawal `json:"withdrawal,omitempty"`
+}
+
+type jsonOutput struct {
+	Slot               string            `json:"slot"`
+	NextValidatorIndex string            `json:"next_validator_index"`
+	Withdrawals        []*jsonWithdrawal `json:"withdrawals"`
+	Find               *jsonFind         `json:"find,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Slot:               fmt.Sprintf("%d", c.slot),
+		NextValidatorIndex: fmt.Sprintf("%d", c.nextValidatorIndex),
+		Withdrawals:        make([]*jsonWithdrawal, 0, len(c.withdrawals)),
+	}
+	for _, withdrawal := range c.withdrawals {
+		output.Withdrawals = append(output.Withdrawals, newJSONWithdrawal(withdrawal))
+	}
+	if c.find != "" {
+		output.Find = &jsonFind{
+			ValidatorIndex: fmt.Sprintf("%d", c.foundIndex),
+		}
+		if c.found != nil {
+			output.Find.WithdrawalsToGo = fmt.Sprintf("%d", c.foundPosition)
+			output.Find.BlocksToGo = fmt.Sprintf("%d", c.found.Slot-c.slot)
+			output.Find.Withdrawal = newJSONWithdrawal(c.found)
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func newJSONWithdrawal(withdrawal *withdrawal) *jsonWithdrawal {
+	return &jsonWithdrawal{
+		ValidatorIndex: fmt.Sprintf("%d", withdrawal.ValidatorIndex),
+		Amount:         fmt.Sprintf("%d", withdrawal.Amount),
+		Slot:           fmt.Sprintf("%d", withdrawal.Slot),
+		Type:           withdrawalType(withdrawal),
+	}
+}
+
+func withdrawalType(withdrawal *withdrawal) string {
+	if withdrawal.Full {
+		return "full"
+	}
+
+	return "partial"
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Next withdrawal validator index: %d\n", c.nextValidatorIndex))
+	}
+
+	for _, withdrawal := range c.withdrawals {
+		builder.WriteString(fmt.Sprintf("Slot %d: validator %d withdraws %s (%s)", withdrawal.Slot, withdrawal.ValidatorIndex, string2eth.GWeiToString(uint64(withdrawal.Amount), true), withdrawalType(withdrawal)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" at %s", c.chainTime.StartOfSlot(withdrawal.Slot).Format("2006-01-02 15:04:05")))
+		}
+		builder.WriteString("\n")
+	}
+
+	if c.find != "" {
+		if c.found == nil {
+			builder.WriteString(fmt.Sprintf("Validator %d is not due a withdrawal in the current sweep\n", c.foundIndex))
+		} else {
+			builder.WriteString(fmt.Sprintf("Validator %d is %d withdrawals (%d blocks) away; expected to withdraw %s in slot %d at %s\n",
+				c.foundIndex,
+				c.foundPosition,
+				c.found.Slot-c.slot,
+				string2eth.GWeiToString(uint64(c.found.Amount), true),
+				c.found.Slot,
+				c.chainTime.StartOfSlot(c.found.Slot).Format("2006-01-02 15:04:05"),
+			))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
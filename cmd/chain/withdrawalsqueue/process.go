@@ -0,0 +1,255 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainwithdrawalsqueue
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// parameters are the chain parameters that define the withdrawals sweep.
+type parameters struct {
+	slotsPerEpoch                    uint64
+	maxWithdrawalsPerPayload         int
+	maxValidatorsPerWithdrawalsSweep int
+	maxEffectiveBalance              phase0.Gwei
+	maxEffectiveBalanceElectra       phase0.Gwei
+	// compounding is true if validators with compounding withdrawal
+	// credentials are included in the sweep, which is the case from Electra.
+	compounding bool
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	block := blockResponse.Data
+	c.slot, err = block.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Slot is %d\n", c.slot)
+	}
+
+	validatorsMapResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", c.slot)})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validatorsMap := validatorsMapResponse.Data
+	validators := make([]*apiv1.Validator, len(validatorsMap))
+	for _, validator := range validatorsMap {
+		validators[validator.Index] = validator
+	}
+
+	if block.Version < spec.DataVersionCapella {
+		return errors.New("chain has not reached Capella; no withdrawals")
+	}
+	withdrawals, err := block.Withdrawals()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block withdrawals")
+	}
+	c.params.compounding = block.Version >= spec.DataVersionElectra
+	if len(withdrawals) == 0 {
+		return errors.New("block without withdrawals; cannot obtain next withdrawal validator index")
+	}
+	c.nextValidatorIndex = phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % len(validators))
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Next withdrawal validator index is %d\n", c.nextValidatorIndex)
+	}
+
+	sweep := c.params.sweep(validators, c.nextValidatorIndex, c.slot)
+	if len(sweep) > c.count {
+		c.withdrawals = sweep[:c.count]
+	} else {
+		c.withdrawals = sweep
+	}
+
+	if c.find != "" {
+		validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.find, fmt.Sprintf("%d", c.slot))
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validator")
+		}
+		c.foundIndex = validator.Index
+		for i := range sweep {
+			if sweep[i].ValidatorIndex == validator.Index {
+				c.found = sweep[i]
+				c.foundPosition = i
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// sweep simulates a full cycle of the withdrawals sweep, starting with the block
+// after the given slot, and returns the expected withdrawals in order.
+//
+// This assumes that every slot contains a block, and that validator balances
+// do not change during the sweep.  From Electra, pending partial withdrawals
+// are processed ahead of the sweep and are not included.
+func (p *parameters) sweep(validators []*apiv1.Validator,
+	nextValidatorIndex phase0.ValidatorIndex,
+	slot phase0.Slot,
+) []*withdrawal {
+	res := make([]*withdrawal, 0)
+	if len(validators) == 0 {
+		return res
+	}
+
+	sweepBound := len(validators)
+	if p.maxValidatorsPerWithdrawalsSweep > 0 && p.maxValidatorsPerWithdrawalsSweep < sweepBound {
+		sweepBound = p.maxValidatorsPerWithdrawalsSweep
+	}
+
+	index := int(nextValidatorIndex) % len(validators)
+	for scanned := 0; scanned < len(validators); {
+		slot++
+		epoch := phase0.Epoch(uint64(slot) / p.slotsPerEpoch)
+		blockWithdrawals := 0
+		for i := 0; i < sweepBound && scanned < len(validators); i++ {
+			if withdrawal := p.expectedWithdrawal(validators[index], epoch); withdrawal != nil {
+				withdrawal.Slot = slot
+				res = append(res, withdrawal)
+				blockWithdrawals++
+			}
+			index = (index + 1) % len(validators)
+			scanned++
+			if blockWithdrawals == p.maxWithdrawalsPerPayload {
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// expectedWithdrawal returns the withdrawal for the validator if it is due one.
+func (p *parameters) expectedWithdrawal(validator *apiv1.Validator, epoch phase0.Epoch) *withdrawal {
+	if validator == nil || validator.Validator == nil {
+		return nil
+	}
+	maxEffectiveBalance := p.maxEffectiveBalance
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix:
+	case compoundingWithdrawalPrefix:
+		if !p.compounding {
+			return nil
+		}
+		maxEffectiveBalance = p.maxEffectiveBalanceElectra
+	default:
+		return nil
+	}
+
+	switch {
+	case validator.Validator.WithdrawableEpoch <= epoch && validator.Balance > 0:
+		return &withdrawal{
+			ValidatorIndex: validator.Index,
+			Amount:         validator.Balance,
+			Full:           true,
+		}
+	case validator.Validator.EffectiveBalance == maxEffectiveBalance && validator.Balance > maxEffectiveBalance:
+		return &withdrawal{
+			ValidatorIndex: validator.Index,
+			Amount:         validator.Balance - maxEffectiveBalance,
+		}
+	default:
+		return nil
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block information")
+	}
+
+	specDataResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+	c.params = &parameters{
+		slotsPerEpoch:                    32,
+		maxWithdrawalsPerPayload:         16,
+		maxValidatorsPerWithdrawalsSweep: 16384,
+		maxEffectiveBalance:              32000000000,
+		maxEffectiveBalanceElectra:       2048000000000,
+	}
+	if val, exists := specData["SLOTS_PER_EPOCH"].(uint64); exists {
+		c.params.slotsPerEpoch = val
+	}
+	if val, exists := specData["MAX_WITHDRAWALS_PER_PAYLOAD"].(uint64); exists {
+		c.params.maxWithdrawalsPerPayload = int(val)
+	}
+	if val, exists := specData["MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP"].(uint64); exists {
+		c.params.maxValidatorsPerWithdrawalsSweep = int(val)
+	}
+	if val, exists := specData["MAX_EFFECTIVE_BALANCE"].(uint64); exists {
+		c.params.maxEffectiveBalance = phase0.Gwei(val)
+	}
+	if val, exists := specData["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		c.params.maxEffectiveBalanceElectra = phase0.Gwei(val)
+	}
+
+	return nil
+}
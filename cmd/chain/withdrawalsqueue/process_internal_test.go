@@ -0,0 +1,174 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainwithdrawalsqueue
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidator(index phase0.ValidatorIndex, prefix byte, balance phase0.Gwei, effectiveBalance phase0.Gwei, withdrawableEpoch phase0.Epoch) *apiv1.Validator {
+	withdrawalCredentials := make([]byte, 32)
+	withdrawalCredentials[0] = prefix
+
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Validator: &phase0.Validator{
+			WithdrawalCredentials: withdrawalCredentials,
+			EffectiveBalance:      effectiveBalance,
+			WithdrawableEpoch:     withdrawableEpoch,
+		},
+	}
+}
+
+func TestSweep(t *testing.T) {
+	params := &parameters{
+		slotsPerEpoch:                    32,
+		maxWithdrawalsPerPayload:         2,
+		maxValidatorsPerWithdrawalsSweep: 3,
+		maxEffectiveBalance:              32000000000,
+	}
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+
+	validators := []*apiv1.Validator{
+		// Partially withdrawable.
+		testValidator(0, 0x01, 32100000000, 32000000000, farFuture),
+		// BLS credentials.
+		testValidator(1, 0x00, 32100000000, 32000000000, farFuture),
+		// Partially withdrawable.
+		testValidator(2, 0x01, 32200000000, 32000000000, farFuture),
+		// Fully withdrawable.
+		testValidator(3, 0x01, 31000000000, 31000000000, 0),
+		// Partially withdrawable.
+		testValidator(4, 0x01, 32300000000, 32000000000, farFuture),
+		// Not at maximum effective balance.
+		testValidator(5, 0x01, 31500000000, 31000000000, farFuture),
+		// Fully withdrawable, but only from epoch 3.
+		testValidator(6, 0x01, 16000000000, 16000000000, 3),
+	}
+
+	tests := []struct {
+		name               string
+		validators         []*apiv1.Validator
+		nextValidatorIndex phase0.ValidatorIndex
+		slot               phase0.Slot
+		expected           []*withdrawal
+	}{
+		{
+			name:     "Empty",
+			expected: []*withdrawal{},
+		},
+		{
+			name:               "FromStart",
+			validators:         validators,
+			nextValidatorIndex: 0,
+			slot:               100,
+			expected: []*withdrawal{
+				{ValidatorIndex: 0, Amount: 100000000, Slot: 101},
+				{ValidatorIndex: 2, Amount: 200000000, Slot: 101},
+				{ValidatorIndex: 3, Amount: 31000000000, Slot: 102, Full: true},
+				{ValidatorIndex: 4, Amount: 300000000, Slot: 102},
+				{ValidatorIndex: 6, Amount: 16000000000, Slot: 103, Full: true},
+			},
+		},
+		{
+			name:               "Wrap",
+			validators:         validators,
+			nextValidatorIndex: 5,
+			slot:               100,
+			expected: []*withdrawal{
+				{ValidatorIndex: 6, Amount: 16000000000, Slot: 101, Full: true},
+				{ValidatorIndex: 0, Amount: 100000000, Slot: 101},
+				{ValidatorIndex: 2, Amount: 200000000, Slot: 102},
+				{ValidatorIndex: 3, Amount: 31000000000, Slot: 102, Full: true},
+				{ValidatorIndex: 4, Amount: 300000000, Slot: 103},
+			},
+		},
+		{
+			name:               "SweepBound",
+			validators:         validators,
+			nextValidatorIndex: 5,
+			slot:               64,
+			expected: []*withdrawal{
+				{ValidatorIndex: 0, Amount: 100000000, Slot: 65},
+				{ValidatorIndex: 2, Amount: 200000000, Slot: 66},
+				{ValidatorIndex: 3, Amount: 31000000000, Slot: 66, Full: true},
+				{ValidatorIndex: 4, Amount: 300000000, Slot: 67},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := params.sweep(test.validators, test.nextValidatorIndex, test.slot)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestSweepCompounding(t *testing.T) {
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+
+	validators := []*apiv1.Validator{
+		// Partially withdrawable.
+		testValidator(0, 0x01, 32100000000, 32000000000, farFuture),
+		// Compounding, partially withdrawable above the Electra maximum.
+		testValidator(1, 0x02, 2048500000000, 2048000000000, farFuture),
+		// Compounding, below the Electra maximum.
+		testValidator(2, 0x02, 64100000000, 64000000000, farFuture),
+		// Compounding, fully withdrawable.
+		testValidator(3, 0x02, 40000000000, 40000000000, 0),
+	}
+
+	tests := []struct {
+		name        string
+		compounding bool
+		expected    []*withdrawal
+	}{
+		{
+			name: "PreElectra",
+			expected: []*withdrawal{
+				{ValidatorIndex: 0, Amount: 100000000, Slot: 101},
+			},
+		},
+		{
+			name:        "Electra",
+			compounding: true,
+			expected: []*withdrawal{
+				{ValidatorIndex: 0, Amount: 100000000, Slot: 101},
+				{ValidatorIndex: 1, Amount: 500000000, Slot: 101},
+				{ValidatorIndex: 3, Amount: 40000000000, Slot: 101, Full: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := &parameters{
+				slotsPerEpoch:                    32,
+				maxWithdrawalsPerPayload:         16,
+				maxValidatorsPerWithdrawalsSweep: 16384,
+				maxEffectiveBalance:              32000000000,
+				maxEffectiveBalanceElectra:       2048000000000,
+				compounding:                      test.compounding,
+			}
+			res := params.sweep(validators, 0, 100)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
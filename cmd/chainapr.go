@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainapr "github.com/wealdtech/ethdo/cmd/chain/apr"
+)
+
+var chainAPRCmd = &cobra.Command{
+	Use:   "apr",
+	Short: "Calculate realised staking APR",
+	Long: `Calculate the realised network-wide staking APR over a trailing window, with a daily series.  For example:
+
+    ethdo chain apr --window=90d
+
+or, additionally for a set of validators:
+
+    ethdo chain apr --window=30d --validators=1,2,3
+
+In quiet mode this will return 0 if the APR can be calculated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainapr.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainAPRCmd)
+	chainFlags(chainAPRCmd)
+	chainAPRCmd.Flags().String("window", "90d", "the trailing window over which to calculate APR, in days (e.g. 90d)")
+	chainAPRCmd.Flags().StringSlice("validators", nil, "validators for which to additionally calculate APR")
+}
+
+func chainAPRBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("window", cmd.Flags().Lookup("window")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainblocktimes "github.com/wealdtech/ethdo/cmd/chain/blocktimes"
+)
+
+var chainBlockTimesCmd = &cobra.Command{
+	Use:   "blocktimes",
+	Short: "Report the times at which blocks arrive within their slots",
+	Long: `Report the distribution of the times at which blocks arrive within their slots, as seen by the beacon node.  For example:
+
+    ethdo chain blocktimes --duration=1h
+
+This listens for blocks for the given duration, or until the given number of blocks have been seen with --blocks, or until interrupted.  Blocks are grouped by the consensus client of their proposer as identified by their graffiti.
+
+In quiet mode this will return 0 if any blocks were observed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainblocktimes.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainBlockTimesCmd)
+	chainFlags(chainBlockTimesCmd)
+	chainBlockTimesCmd.Flags().Duration("duration", 10*time.Minute, "the time for which to listen for blocks (0 to listen until the number of blocks is reached)")
+	chainBlockTimesCmd.Flags().Int("blocks", 0, "the number of blocks after which to stop listening (0 for no limit)")
+}
+
+func chainBlockTimesBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("duration", cmd.Flags().Lookup("duration")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blocks", cmd.Flags().Lookup("blocks")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainexitrate "github.com/wealdtech/ethdo/cmd/chain/exitrate"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var chainExitRateCmd = &cobra.Command{
+	Use:   "exitrate",
+	Short: "Monitor the rate of voluntary exits",
+	Long: `Monitor the number of voluntary exits entering the beacon chain state each epoch, alerting if it exceeds a threshold as a possible mass exit.  For example:
+
+    ethdo chain exitrate --threshold=20
+
+The report includes the number of exits in each of the previous epochs, as set by --history, for context.  With --watch the command reports on each epoch as it completes until interrupted, for which --output=ndjson provides one JSON report per line.
+
+In quiet mode this will return 0 if the number of exits is within the threshold, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,yaml"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainexitrate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainExitRateCmd)
+	chainFlags(chainExitRateCmd)
+	chainExitRateCmd.Flags().String("epoch", "", "the epoch to check (defaults to the last complete epoch)")
+	chainExitRateCmd.Flags().Uint64("threshold", 10, "the number of voluntary exits in an epoch above which to alert")
+	chainExitRateCmd.Flags().Uint64("history", 8, "the number of previous epochs to report for context")
+	chainExitRateCmd.Flags().Bool("watch", false, "report on each epoch as it completes")
+}
+
+func chainExitRateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", cmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("history", cmd.Flags().Lookup("history")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("watch", cmd.Flags().Lookup("watch")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainfinalitydelay "github.com/wealdtech/ethdo/cmd/chain/finalitydelay"
+)
+
+var chainFinalityDelayCmd = &cobra.Command{
+	Use:   "finality-delay",
+	Short: "Report historical finality incidents",
+	Long: `Report periods in which finality lagged by more than a given number of epochs over a range of epochs.  For example:
+
+    ethdo chain finality-delay --from-epoch=6980 --to-epoch=7000
+
+For each incident the start and end epochs, the maximum delay and the attestation participation during the incident are reported.  Finality is obtained from the state at the start of each epoch, so the beacon node must have access to historical states for the range.
+
+In quiet mode this will return 0 if the report can be generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainfinalitydelay.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainFinalityDelayCmd)
+	chainFlags(chainFinalityDelayCmd)
+	chainFinalityDelayCmd.Flags().String("from-epoch", "", "the first epoch of the range to analyse")
+	chainFinalityDelayCmd.Flags().String("to-epoch", "", "the last epoch of the range to analyse (defaults to current)")
+	chainFinalityDelayCmd.Flags().Uint64("threshold", 2, "the finality delay, in epochs, above which an epoch is considered part of an incident")
+}
+
+func chainFinalityDelayBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", cmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainpenalty "github.com/wealdtech/ethdo/cmd/chain/penalty"
+)
+
+var chainPenaltyCmd = &cobra.Command{
+	Use:   "penalty",
+	Short: "Calculate penalties for a slashing",
+	Long: `Calculate the penalties that would be applied if a set of validators were slashed now.  For example:
+
+    ethdo chain penalty --validators=1,2,3
+
+or, for hypothetical validators with the given balances:
+
+    ethdo chain penalty --balances=32,32,2048 --electra
+
+The penalty formulas introduced in Electra are used if the chain has reached the Electra fork, or if --electra is supplied.
+
+In quiet mode this will return 0 if the penalties can be calculated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainpenalty.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainPenaltyCmd)
+	chainFlags(chainPenaltyCmd)
+	chainPenaltyCmd.Flags().StringSlice("validators", nil, "the validators to be slashed")
+	chainPenaltyCmd.Flags().StringSlice("balances", nil, "the balances of hypothetical validators to be slashed, in Ether unless otherwise specified")
+	chainPenaltyCmd.Flags().Bool("electra", false, "use the penalty formulas introduced in Electra before the chain reaches the Electra fork")
+}
+
+func chainPenaltyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("balances", cmd.Flags().Lookup("balances")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("electra", cmd.Flags().Lookup("electra")); err != nil {
+		panic(err)
+	}
+}
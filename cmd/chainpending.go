@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainpending "github.com/wealdtech/ethdo/cmd/chain/pending"
+)
+
+var chainPendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "Show pending deposits, partial withdrawals and consolidations",
+	Long: `Show the queues of pending deposits, partial withdrawals and consolidations in the beacon state, along with the expected time at which each entry will be processed.  For example:
+
+    ethdo chain pending --validator=12345
+
+Individual entries are shown if a validator is supplied, or with --verbose.
+
+In quiet mode this will return 0 if the pending queues can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainpending.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainPendingCmd)
+	chainFlags(chainPendingCmd)
+	chainPendingCmd.Flags().String("validator", "", "only show entries for the given validator")
+}
+
+func chainPendingBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
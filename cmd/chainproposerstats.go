@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainproposerstats "github.com/wealdtech/ethdo/cmd/chain/proposerstats"
+)
+
+var chainProposerStatsCmd = &cobra.Command{
+	Use:   "proposerstats",
+	Short: "Estimate proposer client diversity and operator concentration",
+	Long: `Estimate the client diversity and operator concentration of block proposers over a range of epochs.  For example:
+
+    ethdo chain proposerstats --from-epoch=-1575 --operators-file=operators.csv
+
+Clients are identified from graffiti where possible, and otherwise inferred from the way in which blocks pack their attestations.  Each identification is given a confidence level.  Operators are identified from a user-supplied file mapping validator indices, ranges of validator indices or fee recipients to operator names.
+
+In quiet mode this will return 0 if the statistics can be calculated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainproposerstats.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainProposerStatsCmd)
+	chainFlags(chainProposerStatsCmd)
+	chainProposerStatsCmd.Flags().String("from-epoch", "", "the first epoch of the range to analyse")
+	chainProposerStatsCmd.Flags().String("to-epoch", "", "the last epoch of the range to analyse (defaults to current)")
+	chainProposerStatsCmd.Flags().String("operators-file", "", "a file mapping validator indices, index ranges or fee recipients to operators")
+}
+
+func chainProposerStatsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("operators-file", cmd.Flags().Lookup("operators-file")); err != nil {
+		panic(err)
+	}
+}
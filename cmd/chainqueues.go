@@ -22,12 +22,15 @@ import (
 )
 
 var chainQueuesCmd = &cobra.Command{
-	Use:   "queues",
-	Short: "Show chain queues",
-	Long: `Show beacon chain activation and exit queues.  For example:
+	Use:     "queues",
+	Aliases: []string{"queue"},
+	Short:   "Show chain queues",
+	Long: `Show beacon chain activation and exit queues, along with the churn limits and the estimated time for a new validator to activate or for a validator to exit.  For example:
 
     ethdo chain queues
 
+Estimates are derived from the number of validators in each queue and the churn limits of the chain, and assume that the queues are not changed by later activations or exits.
+
 In quiet mode this will return 0 if the entry and exit queues are 0, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := chainqueues.Run(cmd)
@@ -0,0 +1,50 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainsafeblock "github.com/wealdtech/ethdo/cmd/chain/safeblock"
+)
+
+var chainSafeBlockCmd = &cobra.Command{
+	Use:   "safeblock",
+	Short: "Show safe and finalized execution blocks",
+	Long: `Show the safe and finalized execution blocks as derived from the beacon chain's justified and finalized checkpoints.  For example:
+
+    ethdo chain safeblock
+
+In quiet mode this will return 0 if the safe and finalized blocks can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainsafeblock.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainSafeBlockCmd)
+	chainFlags(chainSafeBlockCmd)
+}
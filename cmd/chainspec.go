@@ -21,6 +21,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -46,8 +47,9 @@ In quiet mode this will return 0 if the chain specification can be obtained, oth
 		})
 		errCheck(err, "Failed to connect to Ethereum consensus node")
 
-		spec, err := eth2Client.(eth2client.SpecProvider).Spec(ctx)
+		specResponse, err := eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
 		errCheck(err, "Failed to obtain chain specification")
+		spec := specResponse.Data
 
 		if viper.GetBool("quiet") {
 			return
@@ -95,3 +97,10 @@ func init() {
 
 func chainSpecBindings(_ *cobra.Command) {
 }
+
+// chainSpecSchemaVersion is the version of the JSON output of the chain spec command.
+const chainSpecSchemaVersion = 1
+
+func chainSpecSchema() (*util.JSONSchema, error) {
+	return util.GenerateJSONSchema("chain/spec", chainSpecSchemaVersion, map[string]any{})
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainstatediff "github.com/wealdtech/ethdo/cmd/chain/statediff"
+)
+
+var chainStateDiffCmd = &cobra.Command{
+	Use:   "statediff",
+	Short: "Show differences between the beacon state at two epochs",
+	Long: `Show differences between the beacon state at the start of two epochs.  For example:
+
+    ethdo chain statediff --from-epoch=1000 --to-epoch=1001
+
+The sections of the state to compare can be limited with --sections, and the validators to compare can be limited with --validators.
+
+In quiet mode this will return 0 if the states can be compared, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainstatediff.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainStateDiffCmd)
+	chainFlags(chainStateDiffCmd)
+	chainStateDiffCmd.Flags().String("from-epoch", "", "the earlier epoch of the states to compare")
+	chainStateDiffCmd.Flags().String("to-epoch", "", "the later epoch of the states to compare (defaults to current)")
+	chainStateDiffCmd.Flags().StringSlice("validators", nil, "the indices of validators to compare (defaults to all)")
+	chainStateDiffCmd.Flags().StringSlice("sections", nil, "the sections of the state to compare: validators, balances, justification, fork, historical (defaults to all)")
+}
+
+func chainStateDiffBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("sections", cmd.Flags().Lookup("sections")); err != nil {
+		panic(err)
+	}
+}
@@ -21,10 +21,13 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 	string2eth "github.com/wealdtech/go-string2eth"
@@ -33,7 +36,7 @@ import (
 var chainStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Obtain status about a chain",
-	Long: `Obtain status about a chain.  For example:
+	Long: `Obtain status about a chain: the current and head slots and epochs, the sync status of the beacon node, the justified and finalized checkpoints, the current fork and the participation of validators in the previous epoch.  For example:
 
     ethdo chain status
 
@@ -57,8 +60,20 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 
 		finalityProvider, isProvider := eth2Client.(eth2client.FinalityProvider)
 		assert(isProvider, "beacon node does not provide finality; cannot report on chain status")
-		finality, err := finalityProvider.Finality(ctx, "head")
+		finalityResponse, err := finalityProvider.Finality(ctx, &api.FinalityOpts{State: "head"})
 		errCheck(err, "Failed to obtain finality information")
+		finality := finalityResponse.Data
+
+		syncStateResponse, err := eth2Client.(eth2client.NodeSyncingProvider).NodeSyncing(ctx, &api.NodeSyncingOpts{})
+		errCheck(err, "Failed to obtain node sync state")
+		syncState := syncStateResponse.Data
+
+		forkResponse, err := eth2Client.(eth2client.ForkProvider).Fork(ctx, &api.ForkOpts{State: "head"})
+		errCheck(err, "Failed to obtain fork information")
+		fork := forkResponse.Data
+		specResponse, err := eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+		errCheck(err, "Failed to obtain spec")
+		spec := specResponse.Data
 
 		slot := chainTime.CurrentSlot()
 
@@ -83,6 +98,18 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		res.WriteString(fmt.Sprintf("%d", epoch))
 		res.WriteString("\n")
 
+		res.WriteString("Head slot: ")
+		res.WriteString(fmt.Sprintf("%d", syncState.HeadSlot))
+		res.WriteString("\n")
+
+		res.WriteString("Head epoch: ")
+		res.WriteString(fmt.Sprintf("%d", chainTime.SlotToEpoch(syncState.HeadSlot)))
+		res.WriteString("\n")
+
+		res.WriteString("Node sync status: ")
+		res.WriteString(chainStatusSyncState(syncState))
+		res.WriteString("\n")
+
 		if viper.GetBool("verbose") {
 			res.WriteString("Epoch slots: ")
 			res.WriteString(fmt.Sprintf("%d", epochStartSlot))
@@ -107,6 +134,9 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		res.WriteString(fmt.Sprintf("%d", finality.Justified.Epoch))
 		res.WriteString("\n")
 		if viper.GetBool("verbose") {
+			res.WriteString("Justified root: ")
+			res.WriteString(fmt.Sprintf("%#x", finality.Justified.Root))
+			res.WriteString("\n")
 			distance := epoch - finality.Justified.Epoch
 			res.WriteString("Justified epoch distance: ")
 			res.WriteString(fmt.Sprintf("%d", distance))
@@ -117,17 +147,33 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		res.WriteString(fmt.Sprintf("%d", finality.Finalized.Epoch))
 		res.WriteString("\n")
 		if viper.GetBool("verbose") {
+			res.WriteString("Finalized root: ")
+			res.WriteString(fmt.Sprintf("%#x", finality.Finalized.Root))
+			res.WriteString("\n")
 			distance := epoch - finality.Finalized.Epoch
 			res.WriteString("Finalized epoch distance: ")
 			res.WriteString(fmt.Sprintf("%d", distance))
 			res.WriteString("\n")
 		}
 
+		res.WriteString("Fork: ")
+		res.WriteString(fmt.Sprintf("%s (version %#x, from epoch %d)", forkName(spec, fork.CurrentVersion), fork.CurrentVersion, fork.Epoch))
+		res.WriteString("\n")
+
+		if epoch > 0 {
+			participation, err := chainStatusParticipation(ctx, eth2Client, chainTime, epoch-1, syncState.HeadSlot)
+			errCheck(err, "Failed to obtain participation")
+			res.WriteString("Participation: ")
+			res.WriteString(fmt.Sprintf("%.2f%% (epoch %d)", participation, epoch-1))
+			res.WriteString("\n")
+		}
+
 		if viper.GetBool("verbose") {
 			validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
 			if isProvider {
-				validators, err := validatorsProvider.Validators(ctx, "head", nil)
+				validatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head"})
 				errCheck(err, "Failed to obtain validators information")
+				validators := validatorsResponse.Data
 				// Stats of inteest.
 				totalBalance := phase0.Gwei(0)
 				activeEffectiveBalance := phase0.Gwei(0)
@@ -194,3 +240,128 @@ func init() {
 	chainCmd.AddCommand(chainStatusCmd)
 	chainFlags(chainStatusCmd)
 }
+
+// chainStatusSyncState describes the sync state of the beacon node.
+func chainStatusSyncState(syncState *apiv1.SyncState) string {
+	switch {
+	case syncState.IsSyncing || syncState.SyncDistance > 1:
+		return fmt.Sprintf("syncing (%d slots behind)", syncState.SyncDistance)
+	case syncState.IsOptimistic:
+		return "optimistic"
+	default:
+		return "synced"
+	}
+}
+
+// forkName returns the name of the fork with the given version, as found in
+// the spec, or "unknown" if it is not present.
+func forkName(spec map[string]interface{}, version phase0.Version) string {
+	for k, v := range spec {
+		if !strings.HasSuffix(k, "_FORK_VERSION") {
+			continue
+		}
+		if specVersion, isVersion := v.(phase0.Version); isVersion && specVersion == version {
+			if k == "GENESIS_FORK_VERSION" {
+				return "phase0"
+			}
+			return strings.ToLower(strings.TrimSuffix(k, "_FORK_VERSION"))
+		}
+	}
+
+	return "unknown"
+}
+
+// chainStatusParticipation returns the percentage of validators with duties in
+// the given epoch whose attestations have been included in blocks up to the
+// head slot.
+func chainStatusParticipation(ctx context.Context,
+	eth2Client eth2client.Service,
+	chainTime chaintime.Service,
+	epoch phase0.Epoch,
+	headSlot phase0.Slot,
+) (
+	float64,
+	error,
+) {
+	committeesProvider, isProvider := eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return 0, errors.New("connection does not provide beacon committees")
+	}
+	blocksProvider, isProvider := eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return 0, errors.New("connection does not provide signed beacon blocks")
+	}
+
+	firstSlot := chainTime.FirstSlotOfEpoch(epoch)
+	committeesResponse, err := committeesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", firstSlot)})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	committees := committeesResponse.Data
+	epochCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+	expected := 0
+	for _, committee := range committees {
+		if chainTime.SlotToEpoch(committee.Slot) != epoch {
+			continue
+		}
+		if _, exists := epochCommittees[committee.Slot]; !exists {
+			epochCommittees[committee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+		}
+		epochCommittees[committee.Slot][committee.Index] = committee.Validators
+		expected += len(committee.Validators)
+	}
+	if expected == 0 {
+		return 0, nil
+	}
+
+	// Attestations for the epoch can be included up to the end of the following epoch.
+	lastSlot := chainTime.LastSlotOfEpoch(epoch + 1)
+	if lastSlot > headSlot {
+		lastSlot = headSlot
+	}
+	votes := make(map[phase0.ValidatorIndex]struct{}, expected)
+	for slot := firstSlot + 1; slot <= lastSlot; slot++ {
+		block, err := util.ResponseData(blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if block == nil {
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return 0, err
+		}
+		for _, attestation := range attestations {
+			data, err := attestation.Data()
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to obtain attestation data")
+			}
+			slotCommittees, exists := epochCommittees[data.Slot]
+			if !exists {
+				continue
+			}
+			attestationVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+				committee, exists := slotCommittees[committeeIndex]
+				if !exists {
+					return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, data.Slot)
+				}
+
+				return uint64(len(committee)), nil
+			})
+			if err != nil {
+				return 0, err
+			}
+			for committeeIndex, committeeVotes := range attestationVotes {
+				committee := slotCommittees[committeeIndex]
+				for i := range committee {
+					if committeeVotes.BitAt(uint64(i)) {
+						votes[committee[i]] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return 100 * float64(len(votes)) / float64(expected), nil
+}
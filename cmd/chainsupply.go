@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainsupply "github.com/wealdtech/ethdo/cmd/chain/supply"
+)
+
+var chainSupplyCmd = &cobra.Command{
+	Use:   "supply",
+	Short: "Report the Ether held on the beacon chain",
+	Long: `Report the Ether held on the beacon chain at the start of an epoch: the balance staked by active validators, the balance pending activation, and the total and effective balances of all validators.  For example:
+
+    ethdo chain supply --epoch=300000
+
+A time series can be obtained by sampling at intervals, for example daily over a week:
+
+    ethdo chain supply --samples=7 --interval=225
+
+The amount withdrawn from the beacon chain since an epoch can also be reported with --withdrawals-from.  This requires every block from that epoch onwards to be fetched, so can take a long time for distant epochs.
+
+In quiet mode this will return 0 if the supply can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainsupply.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainSupplyCmd)
+	chainFlags(chainSupplyCmd)
+	chainSupplyCmd.Flags().String("epoch", "", "the epoch at which to report the supply (defaults to the current epoch)")
+	chainSupplyCmd.Flags().Uint64("samples", 1, "the number of samples to report, ending at the epoch")
+	chainSupplyCmd.Flags().Uint64("interval", 225, "the number of epochs between samples")
+	chainSupplyCmd.Flags().String("withdrawals-from", "", "the epoch from which to total withdrawals")
+}
+
+func chainSupplyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("samples", cmd.Flags().Lookup("samples")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("interval", cmd.Flags().Lookup("interval")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawals-from", cmd.Flags().Lookup("withdrawals-from")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainwithdrawalsqueue "github.com/wealdtech/ethdo/cmd/chain/withdrawalsqueue"
+)
+
+var chainWithdrawalsQueueCmd = &cobra.Command{
+	Use:   "withdrawalsqueue",
+	Short: "Show upcoming withdrawals",
+	Long: `Show the upcoming withdrawals from the withdrawals sweep.  For example:
+
+    ethdo chain withdrawalsqueue --count=32
+
+A validator can be supplied with --find to show how far it is from being swept.
+
+In quiet mode this will return 0 if the withdrawals queue can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := chainwithdrawalsqueue.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainWithdrawalsQueueCmd)
+	chainFlags(chainWithdrawalsQueueCmd)
+	chainWithdrawalsQueueCmd.Flags().Int("count", 16, "number of upcoming withdrawals to show")
+	chainWithdrawalsQueueCmd.Flags().String("find", "", "validator for which to find the upcoming withdrawal")
+}
+
+func chainWithdrawalsQueueBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("count", cmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("find", cmd.Flags().Lookup("find")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositgenerate
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	mnemonic         string
+	sets             []*validatorSet
+	startIndex       uint64
+	forkVersion      phase0.Version
+	depositContract  []byte
+	genesisFile      string
+	transactionsFile string
+	verify           bool
+
+	// Output.
+	deposits   []*deposit
+	mismatches []string
+}
+
+// validatorSet is a set of validators with the same deposit amount and type
+// of withdrawal credentials.
+type validatorSet struct {
+	count             uint64
+	amount            phase0.Gwei
+	credentialsPrefix byte
+	address           []byte
+}
+
+type deposit struct {
+	index       uint64
+	depositData *phase0.DepositData
+	root        phase0.Root
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:            viper.GetBool("quiet"),
+		verbose:          viper.GetBool("verbose"),
+		debug:            viper.GetBool("debug"),
+		startIndex:       viper.GetUint64("start-index"),
+		genesisFile:      viper.GetString("genesis-file"),
+		transactionsFile: viper.GetString("transactions-file"),
+		verify:           viper.GetBool("verify"),
+	}
+
+	c.mnemonic = viper.GetString("mnemonic")
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	if len(viper.GetStringSlice("sets")) == 0 {
+		return nil, errors.New("sets is required")
+	}
+	for _, input := range viper.GetStringSlice("sets") {
+		set, err := parseSet(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid set %q", input)
+		}
+		c.sets = append(c.sets, set)
+	}
+
+	// The fork version is required, as devnets have their own.
+	if viper.GetString("forkversion") == "" {
+		return nil, errors.New("forkversion is required")
+	}
+	forkVersion, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
+	if err != nil || len(forkVersion) != phase0.ForkVersionLength {
+		return nil, errors.New("fork version must be exactly 4 bytes in length")
+	}
+	copy(c.forkVersion[:], forkVersion)
+
+	if viper.GetString("deposit-contract") != "" {
+		c.depositContract, err = parseAddress(viper.GetString("deposit-contract"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deposit contract")
+		}
+	}
+
+	if c.genesisFile == "" && c.transactionsFile == "" {
+		return nil, errors.New("genesis-file or transactions-file is required")
+	}
+
+	return c, nil
+}
+
+// parseSet parses a set of validators in the form
+// count:amount:credentials[:address].
+func parseSet(input string) (*validatorSet, error) {
+	parts := strings.Split(input, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, errors.New("set must be of the form count:amount:credentials[:address]")
+	}
+
+	count, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || count == 0 {
+		return nil, errors.New("count must be a positive integer")
+	}
+	set := &validatorSet{
+		count: count,
+	}
+
+	amount, err := string2eth.StringToGWei(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid amount")
+	}
+	set.amount = phase0.Gwei(amount)
+	// These are hard-coded, to allow deposits to be generated without a connection to the beacon node.
+	if set.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+		return nil, errors.New("amount must be at least 1 Ether")
+	}
+
+	switch strings.ToLower(parts[2]) {
+	case "0x00":
+		set.credentialsPrefix = 0x00 // BLS_WITHDRAWAL_PREFIX
+		if len(parts) == 4 {
+			return nil, errors.New("address cannot be supplied for BLS withdrawal credentials")
+		}
+	case "0x01":
+		set.credentialsPrefix = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+	case "0x02":
+		set.credentialsPrefix = 0x02 // COMPOUNDING_WITHDRAWAL_PREFIX
+	default:
+		return nil, fmt.Errorf("unsupported withdrawal credentials %q", parts[2])
+	}
+	if set.credentialsPrefix != 0x00 {
+		if len(parts) != 4 {
+			return nil, errors.New("address is required for execution withdrawal credentials")
+		}
+		set.address, err = parseAddress(parts[3])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if set.credentialsPrefix == 0x02 {
+		if set.amount > 2048000000000 { // MAX_EFFECTIVE_BALANCE_ELECTRA
+			return nil, errors.New("amount must be at most 2048 Ether")
+		}
+	} else if set.amount > 32000000000 { // MIN_ACTIVATION_BALANCE
+		return nil, errors.New("amount must be at most 32 Ether for non-compounding withdrawal credentials")
+	}
+
+	return set, nil
+}
+
+func parseAddress(input string) ([]byte, error) {
+	address, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(address) != 20 {
+		return nil, errors.New("address must be exactly 20 bytes in length")
+	}
+
+	return address, nil
+}
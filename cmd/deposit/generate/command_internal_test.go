@@ -0,0 +1,212 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositgenerate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "MnemonicMissing",
+			vars: map[string]interface{}{
+				"sets":         []string{"1:32ether:0x00"},
+				"forkversion":  "0x10000038",
+				"genesis-file": "genesis.txt",
+			},
+			err: "mnemonic is required",
+		},
+		{
+			name: "SetsMissing",
+			vars: map[string]interface{}{
+				"mnemonic":     testMnemonic,
+				"forkversion":  "0x10000038",
+				"genesis-file": "genesis.txt",
+			},
+			err: "sets is required",
+		},
+		{
+			name: "SetInvalid",
+			vars: map[string]interface{}{
+				"mnemonic":     testMnemonic,
+				"sets":         []string{"1:32ether"},
+				"forkversion":  "0x10000038",
+				"genesis-file": "genesis.txt",
+			},
+			err: `invalid set "1:32ether": set must be of the form count:amount:credentials[:address]`,
+		},
+		{
+			name: "ForkVersionMissing",
+			vars: map[string]interface{}{
+				"mnemonic":     testMnemonic,
+				"sets":         []string{"1:32ether:0x00"},
+				"genesis-file": "genesis.txt",
+			},
+			err: "forkversion is required",
+		},
+		{
+			name: "ForkVersionInvalid",
+			vars: map[string]interface{}{
+				"mnemonic":     testMnemonic,
+				"sets":         []string{"1:32ether:0x00"},
+				"forkversion":  "0x1000",
+				"genesis-file": "genesis.txt",
+			},
+			err: "fork version must be exactly 4 bytes in length",
+		},
+		{
+			name: "DepositContractInvalid",
+			vars: map[string]interface{}{
+				"mnemonic":         testMnemonic,
+				"sets":             []string{"1:32ether:0x00"},
+				"forkversion":      "0x10000038",
+				"deposit-contract": "0x01",
+				"genesis-file":     "genesis.txt",
+			},
+			err: "invalid deposit contract: address must be exactly 20 bytes in length",
+		},
+		{
+			name: "FilesMissing",
+			vars: map[string]interface{}{
+				"mnemonic":    testMnemonic,
+				"sets":        []string{"1:32ether:0x00"},
+				"forkversion": "0x10000038",
+			},
+			err: "genesis-file or transactions-file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"mnemonic":          testMnemonic,
+				"sets":              []string{"1:32ether:0x00", "2:2048ether:0x02:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"},
+				"forkversion":       "0x10000038",
+				"deposit-contract":  "0x4242424242424242424242424242424242424242",
+				"transactions-file": "deposits.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *validatorSet
+		err      string
+	}{
+		{
+			name:  "CountInvalid",
+			input: "0:32ether:0x00",
+			err:   "count must be a positive integer",
+		},
+		{
+			name:  "AmountInvalid",
+			input: "1:bad:0x00",
+			err:   "invalid amount: failed to parse numeric value of  bad",
+		},
+		{
+			name:  "AmountLow",
+			input: "1:0.5ether:0x00",
+			err:   "amount must be at least 1 Ether",
+		},
+		{
+			name:  "AmountHigh",
+			input: "1:33ether:0x01:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			err:   "amount must be at most 32 Ether for non-compounding withdrawal credentials",
+		},
+		{
+			name:  "AmountHighCompounding",
+			input: "1:2049ether:0x02:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			err:   "amount must be at most 2048 Ether",
+		},
+		{
+			name:  "CredentialsInvalid",
+			input: "1:32ether:0x03",
+			err:   `unsupported withdrawal credentials "0x03"`,
+		},
+		{
+			name:  "AddressWithBLS",
+			input: "1:32ether:0x00:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			err:   "address cannot be supplied for BLS withdrawal credentials",
+		},
+		{
+			name:  "AddressMissing",
+			input: "1:32ether:0x01",
+			err:   "address is required for execution withdrawal credentials",
+		},
+		{
+			name:  "AddressInvalid",
+			input: "1:32ether:0x01:0x8c1f",
+			err:   "address must be exactly 20 bytes in length",
+		},
+		{
+			name:  "BLS",
+			input: "64:32ether:0x00",
+			expected: &validatorSet{
+				count:  64,
+				amount: phase0.Gwei(32000000000),
+			},
+		},
+		{
+			name:  "Compounding",
+			input: "16:2048 Ether:0x02:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			expected: &validatorSet{
+				count:             16,
+				amount:            phase0.Gwei(2048000000000),
+				credentialsPrefix: 0x02,
+				address:           []byte{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseSet(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositgenerate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if c.verify {
+		for _, mismatch := range c.mismatches {
+			builder.WriteString(mismatch)
+			builder.WriteString("\n")
+		}
+		if len(c.mismatches) == 0 {
+			builder.WriteString(fmt.Sprintf("Verified %d deposits", len(c.deposits)))
+		} else {
+			builder.WriteString("Verification failed")
+		}
+
+		return builder.String(), nil
+	}
+
+	total := phase0.Gwei(0)
+	for _, deposit := range c.deposits {
+		total += deposit.depositData.Amount
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Validator %d: %#x (%s, 0x%02x credentials)\n",
+				deposit.index,
+				deposit.depositData.PublicKey,
+				string2eth.GWeiToString(uint64(deposit.depositData.Amount), true),
+				deposit.depositData.WithdrawalCredentials[0],
+			))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Generated %d deposits totalling %s", len(c.deposits), string2eth.GWeiToString(uint64(total), true)))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,254 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositgenerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2util "github.com/wealdtech/go-eth2-util"
+)
+
+// transactionJSON is a deposit transaction, suitable for eth_sendTransaction
+// once the sender is added.
+type transactionJSON struct {
+	To    string `json:"to,omitempty"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+func (c *command) process(_ context.Context) error {
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return err
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, c.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	index := c.startIndex
+	for _, set := range c.sets {
+		for i := uint64(0); i < set.count; i++ {
+			deposit, err := generateDeposit(seed, index, set, domain)
+			if err != nil {
+				return errors.Wrapf(err, "failed to generate deposit for validator %d", index)
+			}
+			c.deposits = append(c.deposits, deposit)
+			index++
+		}
+	}
+
+	if c.genesisFile != "" {
+		if err := c.handleFile(c.genesisFile, c.genesisData(), c.verifyGenesis); err != nil {
+			return err
+		}
+	}
+	if c.transactionsFile != "" {
+		data, err := c.transactionsData()
+		if err != nil {
+			return err
+		}
+		if err := c.handleFile(c.transactionsFile, data, c.verifyTransactions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleFile writes the data to the file, or verifies the file against the
+// data if verifying.
+func (c *command) handleFile(file string, data []byte, verify func(file string, data []byte) []string) error {
+	if c.verify {
+		existing, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", file)
+		}
+		c.mismatches = append(c.mismatches, verify(file, existing)...)
+
+		return nil
+	}
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", file)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "failed to write %s", file)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write %s", file)
+	}
+
+	return nil
+}
+
+// generateDeposit generates the deposit for the validator with the given index,
+// using the EIP-2334 paths for its validator and withdrawal keys.
+func generateDeposit(seed []byte, index uint64, set *validatorSet, domain phase0.Domain) (*deposit, error) {
+	validatorKey, err := eth2util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0/0", index))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate validator key")
+	}
+
+	withdrawalCredentials := make([]byte, 32)
+	if set.credentialsPrefix == 0x00 {
+		withdrawalKey, err := eth2util.PrivateKeyFromSeedAndPath(seed, fmt.Sprintf("m/12381/3600/%d/0", index))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate withdrawal key")
+		}
+		copy(withdrawalCredentials, eth2util.SHA256(withdrawalKey.PublicKey().Marshal()))
+	} else {
+		copy(withdrawalCredentials[12:], set.address)
+	}
+	withdrawalCredentials[0] = set.credentialsPrefix
+
+	depositData := &phase0.DepositData{
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                set.amount,
+	}
+	copy(depositData.PublicKey[:], validatorKey.PublicKey().Marshal())
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	messageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+	signingData := &phase0.SigningData{
+		ObjectRoot: messageRoot,
+		Domain:     domain,
+	}
+	signingRoot, err := signingData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate signing root")
+	}
+	signature := validatorKey.Sign(signingRoot[:])
+	// Check the signature, so that a deposit that would be rejected is never produced.
+	if !signature.Verify(signingRoot[:], validatorKey.PublicKey()) {
+		return nil, errors.New("generated signature does not verify")
+	}
+	copy(depositData.Signature[:], signature.Marshal())
+
+	root, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	return &deposit{
+		index:       index,
+		depositData: depositData,
+		root:        root,
+	}, nil
+}
+
+// genesisData returns the validators for the genesis state, one per line in
+// the form pubkey:withdrawal_credentials:amount.
+func (c *command) genesisData() []byte {
+	builder := strings.Builder{}
+	for _, deposit := range c.deposits {
+		builder.WriteString(genesisLine(deposit))
+		builder.WriteString("\n")
+	}
+
+	return []byte(builder.String())
+}
+
+func genesisLine(deposit *deposit) string {
+	return fmt.Sprintf("%#x:%#x:%d", deposit.depositData.PublicKey, deposit.depositData.WithdrawalCredentials, deposit.depositData.Amount)
+}
+
+// transactionsData returns the deposit transactions, as a JSON array.
+func (c *command) transactionsData() ([]byte, error) {
+	transactions := c.transactions()
+	lines := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		data, err := json.Marshal(transaction)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal transaction")
+		}
+		lines = append(lines, string(data))
+	}
+
+	return []byte(fmt.Sprintf("[\n%s\n]\n", strings.Join(lines, ",\n"))), nil
+}
+
+func (c *command) transactions() []*transactionJSON {
+	transactions := make([]*transactionJSON, 0, len(c.deposits))
+	for _, deposit := range c.deposits {
+		transaction := &transactionJSON{
+			// The deposit contract takes the amount in wei.
+			Value: fmt.Sprintf("%#x", new(big.Int).Mul(big.NewInt(int64(deposit.depositData.Amount)), big.NewInt(1e9))),
+			Data:  fmt.Sprintf("%#x", util.DepositCallData(deposit.depositData, deposit.root)),
+		}
+		if c.depositContract != nil {
+			transaction.To = fmt.Sprintf("%#x", c.depositContract)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions
+}
+
+// verifyGenesis verifies existing genesis data against the generated deposits.
+func (c *command) verifyGenesis(file string, data []byte) []string {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != len(c.deposits) {
+		return []string{fmt.Sprintf("%s has %d validators, expected %d", file, len(lines), len(c.deposits))}
+	}
+
+	mismatches := make([]string, 0)
+	for i, deposit := range c.deposits {
+		if !strings.EqualFold(strings.TrimSpace(lines[i]), genesisLine(deposit)) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: validator %d does not match", file, deposit.index))
+		}
+	}
+
+	return mismatches
+}
+
+// verifyTransactions verifies existing transactions against the generated deposits.
+func (c *command) verifyTransactions(file string, data []byte) []string {
+	existing := make([]*transactionJSON, 0)
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return []string{fmt.Sprintf("%s: invalid transactions: %v", file, err)}
+	}
+	expected := c.transactions()
+	if len(existing) != len(expected) {
+		return []string{fmt.Sprintf("%s has %d transactions, expected %d", file, len(existing), len(expected))}
+	}
+
+	mismatches := make([]string, 0)
+	for i := range expected {
+		if !strings.EqualFold(existing[i].To, expected[i].To) ||
+			!strings.EqualFold(existing[i].Value, expected[i].Value) ||
+			!strings.EqualFold(existing[i].Data, expected[i].Data) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: validator %d does not match", file, c.deposits[i].index))
+		}
+	}
+
+	return mismatches
+}
@@ -0,0 +1,104 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositgenerate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	dir := t.TempDir()
+	genesisFile := filepath.Join(dir, "genesis.txt")
+	transactionsFile := filepath.Join(dir, "deposits.json")
+	address := []byte{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15}
+	newTestCommand := func(verify bool) *command {
+		return &command{
+			mnemonic: testMnemonic,
+			sets: []*validatorSet{
+				{count: 2, amount: 32000000000},
+				{count: 1, amount: 2048000000000, credentialsPrefix: 0x02, address: address},
+			},
+			startIndex:       1,
+			forkVersion:      phase0.Version{0x10, 0x00, 0x00, 0x38},
+			depositContract:  []byte{0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42},
+			genesisFile:      genesisFile,
+			transactionsFile: transactionsFile,
+			verify:           verify,
+		}
+	}
+
+	// Generate.
+	c := newTestCommand(false)
+	require.NoError(t, c.process(context.Background()))
+	require.Len(t, c.deposits, 3)
+	require.Equal(t, uint64(1), c.deposits[0].index)
+	require.Equal(t, uint64(3), c.deposits[2].index)
+
+	genesis, err := os.ReadFile(genesisFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(genesis)), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], fmt.Sprintf("%#x:0x00", c.deposits[0].depositData.PublicKey)))
+	require.True(t, strings.HasSuffix(lines[2], ":0x0200000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15:2048000000000"))
+
+	transactions, err := os.ReadFile(transactionsFile)
+	require.NoError(t, err)
+	require.Contains(t, string(transactions), `"to":"0x4242424242424242424242424242424242424242","value":"0x6f05b59d3b20000000"`)
+
+	// Files are not overwritten.
+	require.ErrorContains(t, newTestCommand(false).process(context.Background()), "failed to create")
+
+	// Verify.
+	c = newTestCommand(true)
+	require.NoError(t, c.process(context.Background()))
+	require.Empty(t, c.mismatches)
+
+	// Verify with changed files.
+	lines[1] = strings.Replace(lines[1], ":32000000000", ":31000000000", 1)
+	require.NoError(t, os.WriteFile(genesisFile, []byte(strings.Join(lines, "\n")), 0o600))
+	require.NoError(t, os.WriteFile(transactionsFile, []byte("[]"), 0o600))
+	c = newTestCommand(true)
+	require.NoError(t, c.process(context.Background()))
+	require.Equal(t, []string{
+		fmt.Sprintf("%s: validator 2 does not match", genesisFile),
+		fmt.Sprintf("%s has 0 transactions, expected 3", transactionsFile),
+	}, c.mismatches)
+}
+
+func TestGenerateDeposit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	// Deposits are deterministic, so check against known values.
+	c := &command{
+		mnemonic: testMnemonic,
+		sets: []*validatorSet{
+			{count: 1, amount: 32000000000},
+		},
+		forkVersion: phase0.Version{0x10, 0x00, 0x00, 0x38},
+		genesisFile: filepath.Join(t.TempDir(), "genesis.txt"),
+	}
+	require.NoError(t, c.process(context.Background()))
+	require.Equal(t, "0xb3e445d43871965d890a398f719348a1405ac72e35b92727cc570026f54471af7ea7b2040622a8fd0b5bfb2a209b5911:0x00eca1f12f398e3ceef109f5f76d8e99f9105e800a90390f1a18895919fd4b3b:32000000000", genesisLine(c.deposits[0]))
+}
@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositreconcile
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection  string
+	depositContract      string
+	depositContractBlock uint64
+
+	// Input.
+	dataDir string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Processing.
+	deposits []*depositEntry
+
+	// Output.
+	results    []*entry
+	reconciled bool
+}
+
+// depositEntry is a single deposit from a deposit data file.
+type depositEntry struct {
+	file    string
+	index   int
+	deposit *util.DepositInfo
+}
+
+// entry is the reconciliation of a single deposit with the chain.
+type entry struct {
+	File                         string  `json:"file"`
+	Index                        int     `json:"index"`
+	Pubkey                       string  `json:"pubkey"`
+	Amount                       uint64  `json:"amount"`
+	WithdrawalCredentials        string  `json:"withdrawal_credentials"`
+	Deposited                    bool    `json:"deposited"`
+	DepositPending               bool    `json:"deposit_pending"`
+	DepositBlock                 uint64  `json:"deposit_block,omitempty"`
+	DepositTransaction           string  `json:"deposit_transaction,omitempty"`
+	ValidatorExists              bool    `json:"validator_exists"`
+	ValidatorIndex               *uint64 `json:"validator_index,omitempty"`
+	ValidatorState               string  `json:"validator_state,omitempty"`
+	Active                       bool    `json:"active"`
+	OnchainWithdrawalCredentials string  `json:"onchain_withdrawal_credentials,omitempty"`
+	WithdrawalCredentialsMatch   bool    `json:"withdrawal_credentials_match"`
+	Reconciled                   bool    `json:"reconciled"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.executionConnection = viper.GetString("execution-connection")
+	c.depositContract = viper.GetString("deposit-contract")
+	c.depositContractBlock = viper.GetUint64("deposit-contract-block")
+	if c.depositContract != "" && c.executionConnection == "" {
+		return nil, errors.New("deposit-contract requires execution-connection")
+	}
+
+	c.dataDir = viper.GetString("data-dir")
+	if c.dataDir == "" {
+		return nil, errors.New("data-dir is required")
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
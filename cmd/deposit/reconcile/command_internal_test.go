@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositreconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"data-dir": "validator_keys",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "DataDirMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "data-dir is required",
+		},
+		{
+			name: "DepositContractWithoutExecutionConnection",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"data-dir":         "validator_keys",
+				"deposit-contract": "0x00000000219ab540356cbb839cbe05303d7705fa",
+			},
+			err: "deposit-contract requires execution-connection",
+		},
+		{
+			name: "OutputInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"data-dir": "validator_keys",
+				"output":   "xml",
+			},
+			err: `unsupported output format "xml"`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"data-dir": "validator_keys",
+			},
+		},
+		{
+			name: "GoodDepositContract",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data-dir":             "validator_keys",
+				"execution-connection": "http://localhost:8545",
+				"deposit-contract":     "0x00000000219ab540356cbb839cbe05303d7705fa",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositreconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type jsonOutput struct {
+	Reconciled bool     `json:"reconciled"`
+	Deposits   []*entry `json:"deposits"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch c.format {
+	case output.Text:
+		return c.outputText(ctx)
+	case output.JSON:
+		data, err := json.Marshal(&jsonOutput{
+			Reconciled: c.reconciled,
+			Deposits:   c.results,
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	// Other formats output each deposit as a separate item, so that NDJSON
+	// provides one deposit per line and CSV one per row.
+	stream := output.NewStream(c.format)
+	lines := make([]string, 0, len(c.results))
+	for _, result := range c.results {
+		line, err := stream.Render(ctx, result)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (c *command) outputText(ctx context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	deposited := 0
+	validators := 0
+	active := 0
+	mismatched := 0
+	for _, result := range c.results {
+		if result.Deposited {
+			deposited++
+		}
+		if result.ValidatorExists {
+			validators++
+		}
+		if result.Active {
+			active++
+		}
+		if result.OnchainWithdrawalCredentials != "" && !result.WithdrawalCredentialsMatch {
+			mismatched++
+		}
+		if result.Reconciled && !c.verbose {
+			continue
+		}
+		line, err := result.RenderText(ctx)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(line)
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("%d deposits: %d deposited, %d validators, %d active, %d withdrawal credentials mismatched\n", len(c.results), deposited, validators, active, mismatched))
+	if c.reconciled {
+		builder.WriteString("Result: reconciled\n")
+	} else {
+		builder.WriteString("Result: not reconciled\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// RenderJSON renders the deposit as JSON.
+func (e *entry) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// RenderText renders the deposit as text.
+func (e *entry) RenderText(_ context.Context) (string, error) {
+	var status string
+	switch {
+	case e.ValidatorExists:
+		status = fmt.Sprintf("validator %d %s", *e.ValidatorIndex, e.ValidatorState)
+	case e.DepositPending:
+		status = "deposit pending"
+	case e.Deposited:
+		status = fmt.Sprintf("deposited in block %d, validator not yet created", e.DepositBlock)
+	default:
+		status = "not deposited"
+	}
+	if e.OnchainWithdrawalCredentials != "" && !e.WithdrawalCredentialsMatch {
+		status = fmt.Sprintf("%s; withdrawal credentials %s do not match %s", status, e.OnchainWithdrawalCredentials, e.WithdrawalCredentials)
+	}
+
+	return fmt.Sprintf("%s deposit %d (%s): %s", e.File, e.Index, e.Pubkey, status), nil
+}
+
+// CSVHeader returns the names of the columns.
+func (*entry) CSVHeader() []string {
+	return []string{
+		"file",
+		"index",
+		"pubkey",
+		"amount",
+		"withdrawal_credentials",
+		"deposited",
+		"deposit_pending",
+		"deposit_block",
+		"deposit_transaction",
+		"validator_exists",
+		"validator_index",
+		"validator_state",
+		"active",
+		"onchain_withdrawal_credentials",
+		"withdrawal_credentials_match",
+		"reconciled",
+	}
+}
+
+// CSVRecords returns the deposit as a single record.
+func (e *entry) CSVRecords(_ context.Context) ([][]string, error) {
+	depositBlock := ""
+	if e.DepositBlock != 0 {
+		depositBlock = fmt.Sprintf("%d", e.DepositBlock)
+	}
+	validatorIndex := ""
+	if e.ValidatorIndex != nil {
+		validatorIndex = fmt.Sprintf("%d", *e.ValidatorIndex)
+	}
+
+	return [][]string{{
+		e.File,
+		fmt.Sprintf("%d", e.Index),
+		e.Pubkey,
+		fmt.Sprintf("%d", e.Amount),
+		e.WithdrawalCredentials,
+		fmt.Sprintf("%t", e.Deposited),
+		fmt.Sprintf("%t", e.DepositPending),
+		depositBlock,
+		e.DepositTransaction,
+		fmt.Sprintf("%t", e.ValidatorExists),
+		validatorIndex,
+		e.ValidatorState,
+		fmt.Sprintf("%t", e.Active),
+		e.OnchainWithdrawalCredentials,
+		fmt.Sprintf("%t", e.WithdrawalCredentialsMatch),
+		fmt.Sprintf("%t", e.Reconciled),
+	}}, nil
+}
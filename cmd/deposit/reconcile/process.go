@@ -0,0 +1,293 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositreconcile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+type pendingDepositJSON struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.deposits, err = c.obtainDeposits()
+	if err != nil {
+		return err
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := c.obtainValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	pendingDeposits, err := c.obtainPendingDeposits(ctx)
+	if err != nil {
+		return err
+	}
+
+	var onchainDeposits map[phase0.BLSPubKey]*util.DepositEvent
+	if c.executionConnection != "" {
+		onchainDeposits, err = c.obtainOnchainDeposits(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.results = reconcile(c.deposits, validators, pendingDeposits, onchainDeposits)
+	c.reconciled = true
+	for _, result := range c.results {
+		if !result.Reconciled {
+			c.reconciled = false
+			break
+		}
+	}
+
+	return nil
+}
+
+// obtainDeposits obtains the deposits from the deposit data files in the data
+// directory.  Files that do not contain deposit data, such as keystores, are
+// ignored.
+func (c *command) obtainDeposits() ([]*depositEntry, error) {
+	files, err := os.ReadDir(c.dataDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read data directory")
+	}
+
+	res := make([]*depositEntry, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".json") {
+			continue
+		}
+		path := filepath.Join(c.dataDir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+		}
+		deposits, err := util.DepositInfoFromJSON(data)
+		if err != nil {
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Ignoring %s: %v\n", path, err)
+			}
+			continue
+		}
+		for i, deposit := range deposits {
+			res = append(res, &depositEntry{
+				file:    file.Name(),
+				index:   i,
+				deposit: deposit,
+			})
+		}
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no deposit data found in %s", c.dataDir)
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
+
+// obtainValidators obtains the validators for the deposits, keyed by public key.
+func (c *command) obtainValidators(ctx context.Context) (map[phase0.BLSPubKey]*apiv1.Validator, error) {
+	pubKeys := make([]phase0.BLSPubKey, 0, len(c.deposits))
+	for _, deposit := range c.deposits {
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], deposit.deposit.PublicKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head", PubKeys: pubKeys})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+
+	res := make(map[phase0.BLSPubKey]*apiv1.Validator, len(validators))
+	for _, validator := range validators {
+		res[validator.Validator.PublicKey] = validator
+	}
+
+	return res, nil
+}
+
+// obtainPendingDeposits obtains the withdrawal credentials of the first
+// pending deposit for each public key in the pending deposits queue.  Chains
+// prior to Electra do not have a pending deposits queue, in which case no
+// pending deposits are returned.
+func (c *command) obtainPendingDeposits(ctx context.Context) (map[phase0.BLSPubKey][]byte, error) {
+	data := make([]*pendingDepositJSON, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/beacon/states/head/pending_deposits", &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	if !found {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Beacon node does not provide pending deposits\n")
+		}
+		return map[phase0.BLSPubKey][]byte{}, nil
+	}
+
+	return parsePendingDeposits(data)
+}
+
+func parsePendingDeposits(data []*pendingDepositJSON) (map[phase0.BLSPubKey][]byte, error) {
+	res := make(map[phase0.BLSPubKey][]byte)
+	for _, deposit := range data {
+		pubKeyBytes, err := bytesutil.FromHexString(deposit.Pubkey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit public key")
+		}
+		withdrawalCredentials, err := bytesutil.FromHexString(deposit.WithdrawalCredentials)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pending deposit withdrawal credentials")
+		}
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], pubKeyBytes)
+		if _, exists := res[pubKey]; !exists {
+			res[pubKey] = withdrawalCredentials
+		}
+	}
+
+	return res, nil
+}
+
+// obtainOnchainDeposits obtains the first deposit made to the deposit contract
+// for each public key.
+func (c *command) obtainOnchainDeposits(ctx context.Context) (map[phase0.BLSPubKey]*util.DepositEvent, error) {
+	client, err := util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	contract, err := util.ObtainDepositContract(ctx, client, c.depositContract, c.depositContractBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := util.DepositEvents(ctx, client, contract.Address, contract.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit events")
+	}
+
+	res := make(map[phase0.BLSPubKey]*util.DepositEvent, len(events))
+	for _, event := range events {
+		if _, exists := res[event.PublicKey]; !exists {
+			res[event.PublicKey] = event
+		}
+	}
+
+	return res, nil
+}
+
+// reconcile reconciles deposits with the state of the chain.  A deposit is
+// reconciled when its validator is active with the withdrawal credentials of
+// the deposit.
+func reconcile(deposits []*depositEntry,
+	validators map[phase0.BLSPubKey]*apiv1.Validator,
+	pendingDeposits map[phase0.BLSPubKey][]byte,
+	onchainDeposits map[phase0.BLSPubKey]*util.DepositEvent,
+) []*entry {
+	res := make([]*entry, 0, len(deposits))
+	for _, deposit := range deposits {
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], deposit.deposit.PublicKey)
+		result := &entry{
+			File:                  deposit.file,
+			Index:                 deposit.index,
+			Pubkey:                fmt.Sprintf("%#x", pubKey),
+			Amount:                deposit.deposit.Amount,
+			WithdrawalCredentials: fmt.Sprintf("%#x", deposit.deposit.WithdrawalCredentials),
+		}
+
+		// The withdrawal credentials on-chain are those of the validator if
+		// it exists, otherwise those of its first deposit.
+		var onchainCredentials []byte
+		if event, exists := onchainDeposits[pubKey]; exists {
+			result.Deposited = true
+			result.DepositBlock = event.BlockNumber
+			result.DepositTransaction = event.TransactionHash
+			onchainCredentials = event.WithdrawalCredentials
+		}
+		if withdrawalCredentials, exists := pendingDeposits[pubKey]; exists {
+			result.Deposited = true
+			result.DepositPending = true
+			onchainCredentials = withdrawalCredentials
+		}
+		if validator, exists := validators[pubKey]; exists {
+			index := uint64(validator.Index)
+			result.Deposited = true
+			result.ValidatorExists = true
+			result.ValidatorIndex = &index
+			result.ValidatorState = validator.Status.String()
+			result.Active = validator.Status.IsActive()
+			onchainCredentials = validator.Validator.WithdrawalCredentials
+		}
+
+		if onchainCredentials != nil {
+			result.OnchainWithdrawalCredentials = fmt.Sprintf("%#x", onchainCredentials)
+			result.WithdrawalCredentialsMatch = bytes.Equal(onchainCredentials, deposit.deposit.WithdrawalCredentials)
+		}
+		result.Reconciled = result.Active && result.WithdrawalCredentialsMatch
+
+		res = append(res, result)
+	}
+
+	return res
+}
@@ -0,0 +1,175 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositreconcile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+const testDeposit = `{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"a9ac65fdd32e9ea916127b5c307a4abde9bde12e751f372c5f0aa84f62f09eba673b25949673c5c5d01527ecff90205e02389d709a74715b5f3f30d3defd0fc559e9480eae522463d7c9e6b77649132ba1fa3b4b33f7b1f471d22829df9f9416","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"97f892cc0b7e6ac39e28c650ea91c06c32ffcf6a37f9fffd30998d1faf7767d3","fork_version":"00000000","eth2_network_name":"mainnet","deposit_cli_version":"2.5.0"}`
+
+func TestObtainDeposits(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deposit_data-1.json"), []byte(fmt.Sprintf("[%s,%s]", testDeposit, testDeposit)), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore-m_12381_3600_0_0_0.json"), []byte(`{"crypto":{},"pubkey":"a99a","version":4}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(testDeposit), 0o600))
+
+	c := &command{dataDir: dir}
+	deposits, err := c.obtainDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 2)
+	require.Equal(t, "deposit_data-1.json", deposits[1].file)
+	require.Equal(t, 1, deposits[1].index)
+
+	c = &command{dataDir: t.TempDir()}
+	_, err = c.obtainDeposits()
+	require.EqualError(t, err, fmt.Sprintf("no deposit data found in %s", c.dataDir))
+
+	c = &command{dataDir: filepath.Join(dir, "missing")}
+	_, err = c.obtainDeposits()
+	require.ErrorContains(t, err, "failed to read data directory")
+}
+
+func TestParsePendingDeposits(t *testing.T) {
+	res, err := parsePendingDeposits([]*pendingDepositJSON{
+		{Pubkey: "0x01", WithdrawalCredentials: "0x0101"},
+		{Pubkey: "0x01", WithdrawalCredentials: "0x0202"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, []byte{0x01, 0x01}, res[phase0.BLSPubKey{0x01}])
+
+	_, err = parsePendingDeposits([]*pendingDepositJSON{{Pubkey: "0xzz"}})
+	require.ErrorContains(t, err, "invalid pending deposit public key")
+}
+
+func TestReconcile(t *testing.T) {
+	credentials := []byte{0x01, 0x02}
+	otherCredentials := []byte{0x01, 0x03}
+	deposits := make([]*depositEntry, 0)
+	for i := 1; i <= 6; i++ {
+		deposits = append(deposits, &depositEntry{
+			file:  "deposit_data.json",
+			index: i - 1,
+			deposit: &util.DepositInfo{
+				PublicKey:             []byte{byte(i)},
+				WithdrawalCredentials: credentials,
+				Amount:                32000000000,
+			},
+		})
+	}
+
+	validators := map[phase0.BLSPubKey]*apiv1.Validator{
+		{0x01}: {
+			Index:  10,
+			Status: apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey:             phase0.BLSPubKey{0x01},
+				WithdrawalCredentials: credentials,
+			},
+		},
+		{0x02}: {
+			Index:  11,
+			Status: apiv1.ValidatorStatePendingQueued,
+			Validator: &phase0.Validator{
+				PublicKey:             phase0.BLSPubKey{0x02},
+				WithdrawalCredentials: credentials,
+			},
+		},
+		{0x03}: {
+			Index:  12,
+			Status: apiv1.ValidatorStateActiveOngoing,
+			Validator: &phase0.Validator{
+				PublicKey:             phase0.BLSPubKey{0x03},
+				WithdrawalCredentials: otherCredentials,
+			},
+		},
+	}
+	pendingDeposits := map[phase0.BLSPubKey][]byte{
+		{0x04}: credentials,
+	}
+	onchainDeposits := map[phase0.BLSPubKey]*util.DepositEvent{
+		{0x05}: {
+			WithdrawalCredentials: otherCredentials,
+			BlockNumber:           100,
+			TransactionHash:       "0x1234",
+		},
+	}
+
+	res := reconcile(deposits, validators, pendingDeposits, onchainDeposits)
+	require.Len(t, res, 6)
+
+	// Active with matching credentials.
+	require.True(t, res[0].Reconciled)
+	require.Equal(t, uint64(10), *res[0].ValidatorIndex)
+	require.Equal(t, "active_ongoing", res[0].ValidatorState)
+
+	// Not yet active.
+	require.True(t, res[1].ValidatorExists)
+	require.False(t, res[1].Active)
+	require.True(t, res[1].WithdrawalCredentialsMatch)
+	require.False(t, res[1].Reconciled)
+
+	// Active with mismatched credentials.
+	require.True(t, res[2].Active)
+	require.False(t, res[2].WithdrawalCredentialsMatch)
+	require.Equal(t, "0x0103", res[2].OnchainWithdrawalCredentials)
+	require.False(t, res[2].Reconciled)
+
+	// Pending deposit.
+	require.True(t, res[3].Deposited)
+	require.True(t, res[3].DepositPending)
+	require.False(t, res[3].ValidatorExists)
+	require.True(t, res[3].WithdrawalCredentialsMatch)
+
+	// Deposit contract only.
+	require.True(t, res[4].Deposited)
+	require.Equal(t, uint64(100), res[4].DepositBlock)
+	require.False(t, res[4].WithdrawalCredentialsMatch)
+
+	// Not deposited.
+	require.False(t, res[5].Deposited)
+	require.Equal(t, "", res[5].OnchainWithdrawalCredentials)
+	require.Nil(t, res[5].ValidatorIndex)
+
+	c := &command{
+		format:  output.Text,
+		results: res,
+	}
+	text, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `deposit_data.json deposit 1 (0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000): validator 11 pending_queued
+deposit_data.json deposit 2 (0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000): validator 12 active_ongoing; withdrawal credentials 0x0103 do not match 0x0102
+deposit_data.json deposit 3 (0x040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000): deposit pending
+deposit_data.json deposit 4 (0x050000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000): deposited in block 100, validator not yet created; withdrawal credentials 0x0103 do not match 0x0102
+deposit_data.json deposit 5 (0x060000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000): not deposited
+6 deposits: 5 deposited, 3 validators, 2 active, 2 withdrawal credentials mismatched
+Result: not reconciled`, text)
+
+	c.format = output.CSV
+	text, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, text, "file,index,pubkey,amount,")
+	require.Contains(t, text, "deposit_data.json,0,0x01")
+}
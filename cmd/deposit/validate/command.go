@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositvalidate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	files   []string
+	network string
+
+	// Output.
+	reports []*fileReport
+	valid   bool
+}
+
+// fileReport is the report for a single deposit data file.
+type fileReport struct {
+	File     string           `json:"file"`
+	Failures []string         `json:"failures,omitempty"`
+	Deposits []*depositReport `json:"deposits"`
+}
+
+// depositReport is the report for a single deposit within a file.
+type depositReport struct {
+	Index    int      `json:"index"`
+	Pubkey   string   `json:"pubkey,omitempty"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		files:   viper.GetStringSlice("data"),
+		network: viper.GetString("network"),
+	}
+
+	if len(c.files) == 0 {
+		return nil, errors.New("data is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Valid bool          `json:"valid"`
+	Files []*fileReport `json:"files"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Valid: c.valid,
+		Files: c.reports,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	deposits := 0
+	failed := 0
+	for _, report := range c.reports {
+		for _, failure := range report.Failures {
+			builder.WriteString(fmt.Sprintf("%s: %s\n", report.File, failure))
+		}
+		for _, deposit := range report.Deposits {
+			deposits++
+			if len(deposit.Failures) == 0 {
+				if c.verbose {
+					builder.WriteString(fmt.Sprintf("%s deposit %d (%s): valid\n", report.File, deposit.Index, deposit.Pubkey))
+				}
+				continue
+			}
+			failed++
+			description := fmt.Sprintf("%s deposit %d", report.File, deposit.Index)
+			if deposit.Pubkey != "" {
+				description = fmt.Sprintf("%s (%s)", description, deposit.Pubkey)
+			}
+			for _, failure := range deposit.Failures {
+				builder.WriteString(fmt.Sprintf("%s: %s\n", description, failure))
+			}
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("%d of %d deposits valid\n", deposits-failed, deposits))
+	if c.valid {
+		builder.WriteString("Result: valid\n")
+	} else {
+		builder.WriteString("Result: invalid\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,322 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositvalidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// minDepositAmount is the minimum amount of a deposit, in Gwei.
+	minDepositAmount = 1_000_000_000
+	// launchpadDepositAmount is the amount of a deposit from the launchpad, in Gwei.
+	launchpadDepositAmount = 32_000_000_000
+	// maxCompoundingDepositAmount is the maximum amount of a deposit with compounding credentials, in Gwei.
+	maxCompoundingDepositAmount = 2_048_000_000_000
+)
+
+// launchpadField is a field in the launchpad deposit data schema.
+type launchpadField struct {
+	name string
+	// hexLen is the length of the hex string, or 0 if the field is not hex.
+	hexLen int
+	number bool
+}
+
+// launchpadFields are the fields required by the launchpad in each deposit.
+var launchpadFields = []*launchpadField{
+	{name: "pubkey", hexLen: 96},
+	{name: "withdrawal_credentials", hexLen: 64},
+	{name: "amount", number: true},
+	{name: "signature", hexLen: 192},
+	{name: "deposit_message_root", hexLen: 64},
+	{name: "deposit_data_root", hexLen: 64},
+	{name: "fork_version", hexLen: 8},
+	{name: "eth2_network_name"},
+	{name: "deposit_cli_version"},
+}
+
+// hexRegexp matches lower-case hex strings without a prefix, as used by the launchpad.
+var hexRegexp = regexp.MustCompile("^[0-9a-f]*$")
+
+// launchpadDeposit is a deposit in the launchpad format.
+type launchpadDeposit struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"eth2_network_name"`
+	CLIVersion            string `json:"deposit_cli_version"`
+}
+
+func (c *command) process(_ context.Context) error {
+	c.valid = true
+	c.reports = make([]*fileReport, 0, len(c.files))
+
+	// Public keys already seen, to detect duplicates across the batch.
+	seen := make(map[string]string)
+
+	for _, file := range c.files {
+		report := c.validateFile(file, seen)
+		if len(report.Failures) > 0 {
+			c.valid = false
+		}
+		for _, deposit := range report.Deposits {
+			if len(deposit.Failures) > 0 {
+				c.valid = false
+			}
+		}
+		c.reports = append(c.reports, report)
+	}
+
+	return nil
+}
+
+func (c *command) validateFile(file string, seen map[string]string) *fileReport {
+	report := &fileReport{
+		File:     file,
+		Failures: make([]string, 0),
+		Deposits: make([]*depositReport, 0),
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		report.Failures = append(report.Failures, fmt.Sprintf("failed to read file: %v", err))
+		return report
+	}
+
+	entries := make([]map[string]json.RawMessage, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		report.Failures = append(report.Failures, "file is not a JSON array of deposits")
+		return report
+	}
+	if len(entries) == 0 {
+		report.Failures = append(report.Failures, "file contains no deposits")
+		return report
+	}
+
+	for i, entry := range entries {
+		deposit := &depositReport{
+			Index:    i,
+			Failures: validateSchema(entry),
+		}
+		report.Deposits = append(report.Deposits, deposit)
+		if len(deposit.Failures) > 0 {
+			// Semantic checks require the deposit to match the schema.
+			continue
+		}
+
+		launchpad := &launchpadDeposit{}
+		raw, err := json.Marshal(entry)
+		if err == nil {
+			err = json.Unmarshal(raw, launchpad)
+		}
+		if err != nil {
+			deposit.Failures = append(deposit.Failures, fmt.Sprintf("failed to parse deposit: %v", err))
+			continue
+		}
+		deposit.Pubkey = fmt.Sprintf("0x%s", launchpad.PublicKey)
+		deposit.Failures = c.validateDeposit(launchpad)
+
+		location := fmt.Sprintf("%s deposit %d", file, i)
+		if previous, exists := seen[launchpad.PublicKey]; exists {
+			deposit.Failures = append(deposit.Failures, fmt.Sprintf("duplicate public key, also in %s", previous))
+		} else {
+			seen[launchpad.PublicKey] = location
+		}
+	}
+
+	return report
+}
+
+// validateSchema validates a deposit against the launchpad schema.
+func validateSchema(entry map[string]json.RawMessage) []string {
+	failures := make([]string, 0)
+
+	for _, field := range launchpadFields {
+		value, exists := entry[field.name]
+		if !exists {
+			failures = append(failures, fmt.Sprintf("%s missing", field.name))
+			continue
+		}
+
+		if field.number {
+			var number uint64
+			if err := json.Unmarshal(value, &number); err != nil {
+				failures = append(failures, fmt.Sprintf("%s is not a positive integer", field.name))
+			}
+			continue
+		}
+
+		var str string
+		if err := json.Unmarshal(value, &str); err != nil {
+			failures = append(failures, fmt.Sprintf("%s is not a string", field.name))
+			continue
+		}
+		if field.hexLen == 0 {
+			if str == "" {
+				failures = append(failures, fmt.Sprintf("%s is empty", field.name))
+			}
+			continue
+		}
+		if !hexRegexp.MatchString(str) {
+			failures = append(failures, fmt.Sprintf("%s is not a lower-case hex string without 0x prefix", field.name))
+			continue
+		}
+		if len(str) != field.hexLen {
+			failures = append(failures, fmt.Sprintf("%s has %d hex characters, expected %d", field.name, len(str), field.hexLen))
+		}
+	}
+
+	return failures
+}
+
+// validateDeposit validates the contents of a deposit that matches the launchpad schema.
+func (c *command) validateDeposit(deposit *launchpadDeposit) []string {
+	failures := make([]string, 0)
+
+	// Schema validation has already confirmed that the hex fields decode.
+	var pubKey phase0.BLSPubKey
+	copy(pubKey[:], mustDecodeHex(deposit.PublicKey))
+	withdrawalCredentials := mustDecodeHex(deposit.WithdrawalCredentials)
+	var signature phase0.BLSSignature
+	copy(signature[:], mustDecodeHex(deposit.Signature))
+	var forkVersion phase0.Version
+	copy(forkVersion[:], mustDecodeHex(deposit.ForkVersion))
+
+	// Network and fork version.
+	if c.network != "" && deposit.NetworkName != c.network {
+		failures = append(failures, fmt.Sprintf("network %s does not match expected network %s", deposit.NetworkName, c.network))
+	}
+	networkForkVersion, err := beacon.GenesisForkVersion(deposit.NetworkName)
+	if err != nil {
+		failures = append(failures, err.Error())
+	} else if !bytes.Equal(networkForkVersion[:], forkVersion[:]) {
+		failures = append(failures, fmt.Sprintf("fork version %#x does not match fork version %#x of network %s", forkVersion, networkForkVersion, deposit.NetworkName))
+	}
+
+	// Withdrawal credentials and amount.
+	switch withdrawalCredentials[0] {
+	case 0x00:
+		if deposit.Amount != launchpadDepositAmount {
+			failures = append(failures, fmt.Sprintf("amount %d is not %d", deposit.Amount, uint64(launchpadDepositAmount)))
+		}
+	case 0x01:
+		if !bytes.Equal(withdrawalCredentials[1:12], make([]byte, 11)) {
+			failures = append(failures, "execution withdrawal credentials are not zero-padded")
+		}
+		if deposit.Amount != launchpadDepositAmount {
+			failures = append(failures, fmt.Sprintf("amount %d is not %d", deposit.Amount, uint64(launchpadDepositAmount)))
+		}
+	case 0x02:
+		if !bytes.Equal(withdrawalCredentials[1:12], make([]byte, 11)) {
+			failures = append(failures, "compounding withdrawal credentials are not zero-padded")
+		}
+		if deposit.Amount < minDepositAmount || deposit.Amount > maxCompoundingDepositAmount {
+			failures = append(failures, fmt.Sprintf("amount %d is not between %d and %d", deposit.Amount, uint64(minDepositAmount), uint64(maxCompoundingDepositAmount)))
+		}
+	default:
+		failures = append(failures, fmt.Sprintf("withdrawal credentials prefix %#02x is unknown", withdrawalCredentials[0]))
+	}
+
+	// Roots.
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+	}
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return append(failures, fmt.Sprintf("failed to generate deposit message root: %v", err))
+	}
+	if fmt.Sprintf("%x", depositMessageRoot) != deposit.DepositMessageRoot {
+		failures = append(failures, fmt.Sprintf("deposit message root incorrect; expected %x", depositMessageRoot))
+	}
+
+	depositData := &phase0.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+		Signature:             signature,
+	}
+	depositDataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return append(failures, fmt.Sprintf("failed to generate deposit data root: %v", err))
+	}
+	if fmt.Sprintf("%x", depositDataRoot) != deposit.DepositDataRoot {
+		failures = append(failures, fmt.Sprintf("deposit data root incorrect; expected %x", depositDataRoot))
+	}
+
+	// Signature.
+	if failure := verifySignature(pubKey, signature, depositMessageRoot, forkVersion); failure != "" {
+		failures = append(failures, failure)
+	}
+
+	return failures
+}
+
+// verifySignature verifies the signature of the deposit message, returning a
+// failure if it does not verify.
+func verifySignature(pubKey phase0.BLSPubKey,
+	signature phase0.BLSSignature,
+	depositMessageRoot phase0.Root,
+	forkVersion phase0.Version,
+) string {
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+	container := &phase0.SigningData{
+		ObjectRoot: depositMessageRoot,
+		Domain:     domain,
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return fmt.Sprintf("failed to generate signing root: %v", err)
+	}
+
+	blsPubKey, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
+	if err != nil {
+		return fmt.Sprintf("public key is invalid: %v", err)
+	}
+	blsSig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return fmt.Sprintf("signature is invalid: %v", err)
+	}
+	if !blsSig.Verify(signingRoot[:], blsPubKey) {
+		return "signature does not verify"
+	}
+
+	return ""
+}
+
+func mustDecodeHex(input string) []byte {
+	res, err := hex.DecodeString(input)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
@@ -0,0 +1,183 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositvalidate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	mainnetDeposit = `{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"a9ac65fdd32e9ea916127b5c307a4abde9bde12e751f372c5f0aa84f62f09eba673b25949673c5c5d01527ecff90205e02389d709a74715b5f3f30d3defd0fc559e9480eae522463d7c9e6b77649132ba1fa3b4b33f7b1f471d22829df9f9416","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"97f892cc0b7e6ac39e28c650ea91c06c32ffcf6a37f9fffd30998d1faf7767d3","fork_version":"00000000","eth2_network_name":"mainnet","deposit_cli_version":"2.5.0"}`
+	holeskyDeposit = `{"pubkey":"b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"a850f4c387a5e6e1f6289b74b4bbf9cdc7979b8a67ebf0c3060b3c329d4738108b4755069241c2fbb33e3077c0728eac11c02267b0c11fec2d1491add1bf38ddae24ccca973ed085f898d92eff4ac66e3e8b7d382148994fbc58546a5b63b107","deposit_message_root":"1dc5053486d74f5c91fa90e1e86d718d3fb42bb92e5cfdce98e994eb2bff2c46","deposit_data_root":"40e8627b5af8981feeb31ac126981eb24f2f487f4202a6d73e7fcbd3f8882b9c","fork_version":"01017000","eth2_network_name":"holesky","deposit_cli_version":"2.5.0"}`
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		files    []string
+		network  string
+		valid    bool
+		failures [][]string
+	}{
+		{
+			name:     "Missing",
+			files:    []string{"missing"},
+			failures: [][]string{{"failed to read file: open DIR/missing: no such file or directory"}},
+		},
+		{
+			name:     "NotArray",
+			files:    []string{mainnetDeposit},
+			failures: [][]string{{"file is not a JSON array of deposits"}},
+		},
+		{
+			name:     "Empty",
+			files:    []string{`[]`},
+			failures: [][]string{{"file contains no deposits"}},
+		},
+		{
+			name:  "Good",
+			files: []string{"[" + mainnetDeposit + "]", "[" + holeskyDeposit + "]"},
+			valid: true,
+			failures: [][]string{
+				{},
+				{},
+			},
+		},
+		{
+			name:    "NetworkMismatch",
+			files:   []string{"[" + mainnetDeposit + "," + holeskyDeposit + "]"},
+			network: "mainnet",
+			failures: [][]string{
+				{},
+				{"network holesky does not match expected network mainnet"},
+			},
+		},
+		{
+			name: "Schema",
+			files: []string{"[" + strings.NewReplacer(
+				`"pubkey":"a99a`, `"pubkey":"0xa99a`,
+				`"amount":32000000000`, `"amount":"32000000000"`,
+				`"fork_version":"00000000"`, `"fork_version":"000000"`,
+				`,"deposit_cli_version":"2.5.0"`, ``,
+			).Replace(mainnetDeposit) + "]"},
+			failures: [][]string{
+				{
+					"pubkey is not a lower-case hex string without 0x prefix",
+					"amount is not a positive integer",
+					"fork_version has 6 hex characters, expected 8",
+					"deposit_cli_version missing",
+				},
+			},
+		},
+		{
+			name:  "ForkVersionMismatch",
+			files: []string{"[" + strings.Replace(mainnetDeposit, `"eth2_network_name":"mainnet"`, `"eth2_network_name":"holesky"`, 1) + "]"},
+			failures: [][]string{
+				{"fork version 0x00000000 does not match fork version 0x01017000 of network holesky"},
+			},
+		},
+		{
+			name:  "UnknownNetwork",
+			files: []string{"[" + strings.Replace(mainnetDeposit, `"eth2_network_name":"mainnet"`, `"eth2_network_name":"pyrmont"`, 1) + "]"},
+			failures: [][]string{
+				{"unknown network pyrmont; known networks are goerli, holesky, hoodi, mainnet, sepolia"},
+			},
+		},
+		{
+			name:  "Amount",
+			files: []string{"[" + strings.Replace(mainnetDeposit, `"amount":32000000000`, `"amount":16000000000`, 1) + "]"},
+			failures: [][]string{
+				{
+					"amount 16000000000 is not 32000000000",
+					"deposit message root incorrect; expected afb295c6e97813a1a38e2285af5aa1577b343d1d3018fe5668701c9c12f96ecd",
+					"deposit data root incorrect; expected b40e7ff79197b5d722b0a56e88dc6daef705694c1fe92a5e3b96e51179a5ad59",
+					"signature does not verify",
+				},
+			},
+		},
+		{
+			name:  "DepositDataRoot",
+			files: []string{"[" + strings.Replace(mainnetDeposit, `"deposit_data_root":"97f8`, `"deposit_data_root":"07f8`, 1) + "]"},
+			failures: [][]string{
+				{"deposit data root incorrect; expected 97f892cc0b7e6ac39e28c650ea91c06c32ffcf6a37f9fffd30998d1faf7767d3"},
+			},
+		},
+		{
+			name:  "Signature",
+			files: []string{"[" + strings.Replace(mainnetDeposit, `"signature":"a9ac65fdd32e9ea916127b5c307a4abde9bde12e751f372c5f0aa84f62f09eba673b25949673c5c5d01527ecff90205e02389d709a74715b5f3f30d3defd0fc559e9480eae522463d7c9e6b77649132ba1fa3b4b33f7b1f471d22829df9f9416"`, `"signature":"add6791f26dd5ea96824028d178d19f1f690c3383ac8dcb5de8310c2936fcb9bae48dd864cc9b3cf94bd72d6281b19a7102fee3d85e9aef93ca9c7718b757392a0fd1426d3ddf34e9d4d6734c1f5bd39aeeed901b68315c4ed52e7d28a488f2d"`, 1) + "]"},
+			failures: [][]string{
+				{
+					"deposit data root incorrect; expected 2a0516085f2d81e03b540be018d440e497115bc91b1fdca02a60e7084e772475",
+					"signature does not verify",
+				},
+			},
+		},
+		{
+			name:  "Duplicate",
+			files: []string{"[" + mainnetDeposit + "]", "[" + mainnetDeposit + "]"},
+			failures: [][]string{
+				{},
+				{"duplicate public key, also in DIR/file0 deposit 0"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := make([]string, 0, len(test.files))
+			for i, contents := range test.files {
+				if contents == "missing" {
+					files = append(files, filepath.Join(dir, "missing"))
+					continue
+				}
+				file := filepath.Join(dir, fmt.Sprintf("file%d", i))
+				require.NoError(t, os.WriteFile(file, []byte(contents), 0o600))
+				files = append(files, file)
+			}
+
+			c := &command{
+				files:   files,
+				network: test.network,
+			}
+			require.NoError(t, c.process(context.Background()))
+			require.Equal(t, test.valid, c.valid)
+
+			failures := make([]string, 0)
+			for _, report := range c.reports {
+				failures = append(failures, report.Failures...)
+				for _, deposit := range report.Deposits {
+					failures = append(failures, deposit.Failures...)
+				}
+			}
+			expected := make([]string, 0)
+			for _, fileFailures := range test.failures {
+				for _, failure := range fileFailures {
+					expected = append(expected, strings.ReplaceAll(failure, "DIR", dir))
+				}
+			}
+			require.Equal(t, expected, failures)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositgenerate "github.com/wealdtech/ethdo/cmd/deposit/generate"
+)
+
+var depositGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate deposits for a set of validators derived from a mnemonic",
+	Long: `Generate deposits for a set of validators derived from a mnemonic, for use with devnets.  For example:
+
+    ethdo deposit generate --mnemonic="..." --forkversion=0x10000038 --sets=64:32ether:0x00,16:2048ether:0x02:0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15 --genesis-file=genesis-validators.txt --transactions-file=deposits.json
+
+Each set is of the form count:amount:credentials[:address], where credentials is 0x00, 0x01 or 0x02 and the address is required for 0x01 and 0x02 credentials.  Validator keys are derived with EIP-2334 paths, with indices increasing across the sets from --start-index.
+
+The output is deterministic, so with --verify existing files are checked against the deposits generated from the same inputs rather than being written.
+
+In quiet mode this will return 0 if the deposits are generated or verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := depositgenerate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositGenerateCmd)
+	depositFlags(depositGenerateCmd)
+	depositGenerateCmd.Flags().StringSlice("sets", nil, "Sets of validators, each of the form count:amount:credentials[:address]")
+	depositGenerateCmd.Flags().Uint64("start-index", 0, "Index of the first validator key to derive")
+	depositGenerateCmd.Flags().String("forkversion", "", "Genesis fork version of the devnet")
+	depositGenerateCmd.Flags().String("deposit-contract", "", "Address of the deposit contract, added to the deposit transactions")
+	depositGenerateCmd.Flags().String("genesis-file", "", "File for the genesis validators")
+	depositGenerateCmd.Flags().String("transactions-file", "", "File for the deposit transactions")
+	depositGenerateCmd.Flags().Bool("verify", false, "Verify existing files rather than writing them")
+}
+
+func depositGenerateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("sets", cmd.Flags().Lookup("sets")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("start-index", cmd.Flags().Lookup("start-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("forkversion", cmd.Flags().Lookup("forkversion")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-contract", cmd.Flags().Lookup("deposit-contract")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-file", cmd.Flags().Lookup("genesis-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("transactions-file", cmd.Flags().Lookup("transactions-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("verify", cmd.Flags().Lookup("verify")); err != nil {
+		panic(err)
+	}
+}
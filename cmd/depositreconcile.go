@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositreconcile "github.com/wealdtech/ethdo/cmd/deposit/reconcile"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var depositReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconcile deposit data files with the chain",
+	Long: `Reconcile the deposits in a directory of deposit data files with the state of the chain.  For example:
+
+    ethdo deposit reconcile --data-dir=validator_keys
+
+For each deposit this reports if the deposit has been made, if its validator exists and is active, and if the withdrawal credentials of the validator on-chain match those in the deposit data.  Deposits are found on the beacon chain, either as validators or in the pending deposits queue.  If an execution node is supplied with --execution-connection the deposit contract is also checked, to find deposits that have been made but not yet seen by the beacon chain.
+
+By default only deposits that are not reconciled are listed, followed by a summary; --verbose lists all deposits.  --output=ndjson provides one deposit per line and --output=csv one per row.
+
+In quiet mode this will return 0 if all deposits are for active validators with matching withdrawal credentials, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,csv,yaml"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := depositreconcile.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositReconcileCmd)
+	depositFlags(depositReconcileCmd)
+	depositReconcileCmd.Flags().String("data-dir", "", "Directory containing deposit data files")
+	depositReconcileCmd.Flags().String("deposit-contract", "", "Address of the deposit contract to check for deposits (defaults to the deposit contract of the execution node's chain)")
+	depositReconcileCmd.Flags().Uint64("deposit-contract-block", 0, "Block at which the deposit contract supplied with --deposit-contract was deployed")
+}
+
+func depositReconcileBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("data-dir", cmd.Flags().Lookup("data-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-contract", cmd.Flags().Lookup("deposit-contract")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("deposit-contract-block", cmd.Flags().Lookup("deposit-contract-block")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositvalidate "github.com/wealdtech/ethdo/cmd/deposit/validate"
+)
+
+var depositValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate deposit data files against the launchpad's requirements",
+	Long: `Validate one or more deposit data files against the launchpad's schema and rules.  For example:
+
+    ethdo deposit validate --data=deposit_data-1.json,deposit_data-2.json
+
+Each deposit is checked for the fields required by the launchpad, a fork version that matches its network, the amount, the deposit message and data roots, and the signature.  Public keys that appear more than once across the files are also reported.
+
+In quiet mode this will return 0 if all deposits are valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := depositvalidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositValidateCmd)
+	depositFlags(depositValidateCmd)
+	depositValidateCmd.Flags().StringSlice("data", nil, "Deposit data file(s) to validate")
+	depositValidateCmd.Flags().String("network", "", "Network to which all deposits must belong (mainnet, holesky, hoodi, sepolia, goerli)")
+}
+
+func depositValidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("data", cmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+}
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -36,8 +37,11 @@ var (
 	depositVerifyWithdrawalPubKey  string
 	depositVerifyWithdrawalAddress string
 	depositVerifyValidatorPubKey   string
+	depositVerifyCompounding       bool
 	depositVerifyDepositAmount     string
 	depositVerifyForkVersion       string
+	depositVerifyContract          string
+	depositVerifyContractBlock     uint64
 )
 
 var depositVerifyCmd = &cobra.Command{
@@ -47,7 +51,7 @@ var depositVerifyCmd = &cobra.Command{
 
     ethdo deposit verify --data=depositdata.json --withdrawalaccount=primary/current --value="32 Ether"
 
-The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.
+The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.  Deposits with compounding (0x02) withdrawal credentials can be verified by supplying --compounding along with the withdrawal address.  If an execution node is supplied with --execution-connection the deposit contract is also checked, and verification fails if a deposit has already been made for the validator.
 
 In quiet mode this will return 0 if the data is verified correctly, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -97,9 +101,14 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 			errCheck(err, "Invalid withdrawal address")
 			assert(len(withdrawalAddressBytes) == 20, "address should be 20 bytes")
 			withdrawalCredentials = make([]byte, 32)
-			withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+			if depositVerifyCompounding {
+				withdrawalCredentials[0] = 0x02 // COMPOUNDING_WITHDRAWAL_PREFIX
+			} else {
+				withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+			}
 			copy(withdrawalCredentials[12:], withdrawalAddressBytes)
 		}
+		assert(!depositVerifyCompounding || depositVerifyWithdrawalAddress != "", "--compounding requires --withdrawaladdress")
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Withdrawal credentials are %#x", withdrawalCredentials))
 
 		depositAmount := uint64(0)
@@ -107,6 +116,11 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 			depositAmount, err = string2eth.StringToGWei(depositVerifyDepositAmount)
 			errCheck(err, "Invalid value")
 			assert(depositAmount >= 1000000000, "deposit amount must be at least 1 Ether") // MIN_DEPOSIT_AMOUNT
+			if depositVerifyCompounding {
+				assert(depositAmount <= 2048000000000, "deposit amount must be at most 2048 Ether") // MAX_EFFECTIVE_BALANCE_ELECTRA
+			} else {
+				assert(depositAmount <= 32000000000, "deposit amount must be at most 32 Ether for non-compounding withdrawal credentials") // MIN_ACTIVATION_BALANCE
+			}
 		}
 
 		validatorPubKeys := make(map[[48]byte]bool)
@@ -115,12 +129,18 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 			errCheck(err, "Failed to obtain validator public key(s))")
 		}
 
+		var onchainDeposits map[phase0.BLSPubKey]*util.DepositEvent
+		if viper.GetString("execution-connection") != "" {
+			onchainDeposits, err = depositVerifyOnchainDeposits(context.Background())
+			errCheck(err, "Failed to obtain on-chain deposits")
+		}
+
 		failures := false
 		for _, deposit := range deposits {
 			if deposit.Amount == 0 {
 				deposit.Amount = depositAmount
 			}
-			verified, err := verifyDeposit(deposit, withdrawalCredentials, validatorPubKeys, depositAmount)
+			verified, err := verifyDeposit(deposit, withdrawalCredentials, validatorPubKeys, depositAmount, onchainDeposits)
 			errCheck(err, fmt.Sprintf("Error attempting to verify deposit %q", deposit.Name))
 			depositName := deposit.Name
 			if depositName == "" {
@@ -196,7 +216,37 @@ func validatorPubKeysFromInput(input string) (map[[48]byte]bool, error) {
 	return pubKeys, nil
 }
 
-func verifyDeposit(deposit *util.DepositInfo, withdrawalCredentials []byte, validatorPubKeys map[[48]byte]bool, amount uint64) (bool, error) {
+// depositVerifyOnchainDeposits obtains the first deposit made on-chain for each validator.
+func depositVerifyOnchainDeposits(ctx context.Context) (map[phase0.BLSPubKey]*util.DepositEvent, error) {
+	client, err := util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: viper.GetString("execution-connection"),
+		Timeout: viper.GetDuration("timeout"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	contract, err := util.ObtainDepositContract(ctx, client, depositVerifyContract, depositVerifyContractBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := util.DepositEvents(ctx, client, contract.Address, contract.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit events")
+	}
+
+	res := make(map[phase0.BLSPubKey]*util.DepositEvent, len(events))
+	for _, event := range events {
+		if _, exists := res[event.PublicKey]; !exists {
+			res[event.PublicKey] = event
+		}
+	}
+
+	return res, nil
+}
+
+func verifyDeposit(deposit *util.DepositInfo, withdrawalCredentials []byte, validatorPubKeys map[[48]byte]bool, amount uint64, onchainDeposits map[phase0.BLSPubKey]*util.DepositEvent) (bool, error) {
 	if withdrawalCredentials == nil {
 		outputIf(!viper.GetBool("quiet"), "Withdrawal public key or address not supplied; withdrawal credentials NOT checked")
 	} else {
@@ -325,6 +375,16 @@ func verifyDeposit(deposit *util.DepositInfo, withdrawalCredentials []byte, vali
 		}
 	}
 
+	if onchainDeposits == nil {
+		outputIf(!viper.GetBool("quiet"), "Execution connection not supplied; on-chain deposits NOT checked")
+	} else {
+		if event, exists := onchainDeposits[pubKey]; exists {
+			outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit of %s already made on-chain in block %d (transaction %s)", string2eth.GWeiToString(uint64(event.Amount), true), event.BlockNumber, event.TransactionHash))
+			return false, nil
+		}
+		outputIf(!viper.GetBool("quiet"), "No existing on-chain deposit")
+	}
+
 	return true, nil
 }
 
@@ -334,7 +394,10 @@ func init() {
 	depositVerifyCmd.Flags().StringVar(&depositVerifyData, "data", "", "JSON data, or path to JSON data")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyWithdrawalPubKey, "withdrawalpubkey", "", "Public key of the account to which the validator funds will be withdrawn")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyWithdrawalAddress, "withdrawaladdress", "", "Ethereum 1 address of the account to which the validator funds will be withdrawn")
+	depositVerifyCmd.Flags().BoolVar(&depositVerifyCompounding, "compounding", false, "Expect compounding (0x02) withdrawal credentials for the withdrawal address")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyDepositAmount, "depositvalue", "32 Ether", "Value of the amount to be deposited")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyValidatorPubKey, "validatorpubkey", "", "Public key(s) of the account(s) that will be carrying out validation")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyForkVersion, "forkversion", "0x00000000", "Fork version of the chain of the deposit")
+	depositVerifyCmd.Flags().StringVar(&depositVerifyContract, "deposit-contract", "", "Address of the deposit contract to check for existing deposits (defaults to the deposit contract of the execution node's chain)")
+	depositVerifyCmd.Flags().Uint64Var(&depositVerifyContractBlock, "deposit-contract-block", 0, "Block at which the deposit contract supplied with --deposit-contract was deployed")
 }
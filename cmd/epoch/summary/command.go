@@ -15,6 +15,7 @@ package epochsummary
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
@@ -23,6 +24,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
 )
 
 type command struct {
@@ -36,9 +39,15 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	stream     bool
-	jsonOutput bool
+	epoch         string
+	targetEpoch   phase0.Epoch
+	stream        bool
+	format        output.Format
+	balanceFormat *output.BalanceFormat
+	compare       string
+	validators    []string
+	sample        int
+	seed          string
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -53,12 +62,19 @@ type command struct {
 	// Caches.
 	blocksCache map[string]*spec.VersionedSignedBeaconBlock
 
+	// Processing.
+	validatorIndices   []phase0.ValidatorIndex
+	validatorSummaries map[phase0.ValidatorIndex]*validatorSummary
+	sampled            map[phase0.ValidatorIndex]struct{}
+	inclusionDistances map[phase0.ValidatorIndex]phase0.Slot
+
 	// Results.
-	summary *epochSummary
+	summary  *epochSummary
+	previous *epochSummary
 }
 
 type epochSummary struct {
-	Epoch                      phase0.Epoch                 `json:"epoch"`
+	Epoch                      uint64                       `json:"epoch"`
 	FirstSlot                  phase0.Slot                  `json:"first_slot"`
 	LastSlot                   phase0.Slot                  `json:"last_slot"`
 	Proposals                  []*epochProposal             `json:"proposals"`
@@ -72,6 +88,47 @@ type epochSummary struct {
 	TargetTimelyValidators     int                          `json:"target_timely_validators"`
 	NonParticipatingValidators []*nonParticipatingValidator `json:"nonparticipating_validators"`
 	Blobs                      int                          `json:"blobs"`
+	ActiveBalance              phase0.Gwei                  `json:"active_balance"`
+	SlashedValidators          int                          `json:"slashed_validators"`
+	ActivationQueue            int                          `json:"activation_queue"`
+	ExitQueue                  int                          `json:"exit_queue"`
+	Comparison                 *epochComparison             `json:"comparison,omitempty"`
+	Validators                 []*validatorSummary          `json:"validators,omitempty"`
+	Sample                     *sampleSummary               `json:"sample,omitempty"`
+}
+
+// sampleSummary contains the attestation performance of the validators
+// estimated from a sample of the active validators.  Proportions are between
+// 0 and 1.
+type sampleSummary struct {
+	Size              int            `json:"size"`
+	Seed              string         `json:"seed"`
+	Participation     *util.Estimate `json:"participation"`
+	SourceTimely      *util.Estimate `json:"source_timely"`
+	TargetCorrect     *util.Estimate `json:"target_correct"`
+	TargetTimely      *util.Estimate `json:"target_timely"`
+	HeadCorrect       *util.Estimate `json:"head_correct"`
+	HeadTimely        *util.Estimate `json:"head_timely"`
+	InclusionDistance *util.Estimate `json:"inclusion_distance"`
+}
+
+// epochComparison contains the changes from a previous epoch.
+// Participation deltas are in percentage points.
+type epochComparison struct {
+	Epoch                 uint64  `json:"epoch"`
+	ProposalsDelta        int     `json:"proposals_delta"`
+	ActiveValidatorsDelta int     `json:"active_validators_delta"`
+	ParticipationDelta    float64 `json:"participation_delta"`
+	SourceTimelyDelta     float64 `json:"source_timely_delta"`
+	TargetCorrectDelta    float64 `json:"target_correct_delta"`
+	TargetTimelyDelta     float64 `json:"target_timely_delta"`
+	HeadCorrectDelta      float64 `json:"head_correct_delta"`
+	HeadTimelyDelta       float64 `json:"head_timely_delta"`
+	SyncCommitteeDelta    float64 `json:"sync_committee_delta"`
+	BalanceDelta          int64   `json:"balance_delta"`
+	NewSlashings          int     `json:"new_slashings"`
+	ActivationQueueDelta  int     `json:"activation_queue_delta"`
+	ExitQueueDelta        int     `json:"exit_queue_delta"`
 }
 
 type epochProposal struct {
@@ -85,6 +142,34 @@ type epochSyncCommittee struct {
 	Missed int                   `json:"missed"`
 }
 
+// validatorSummary contains the performance of an individual validator in the epoch.
+type validatorSummary struct {
+	Index         phase0.ValidatorIndex   `json:"index"`
+	Active        bool                    `json:"active"`
+	Attestation   *validatorAttestation   `json:"attestation,omitempty"`
+	Proposals     []*epochProposal        `json:"proposals,omitempty"`
+	SyncCommittee *validatorSyncCommittee `json:"sync_committee,omitempty"`
+}
+
+// validatorAttestation contains the first inclusion of a validator's attestation for the epoch.
+type validatorAttestation struct {
+	Slot              phase0.Slot           `json:"slot,omitempty"`
+	Committee         phase0.CommitteeIndex `json:"committee_index"`
+	Included          bool                  `json:"included"`
+	InclusionSlot     phase0.Slot           `json:"inclusion_slot,omitempty"`
+	InclusionDistance phase0.Slot           `json:"inclusion_distance,omitempty"`
+	HeadCorrect       bool                  `json:"head_correct"`
+	HeadTimely        bool                  `json:"head_timely"`
+	SourceTimely      bool                  `json:"source_timely"`
+	TargetCorrect     bool                  `json:"target_correct"`
+	TargetTimely      bool                  `json:"target_timely"`
+}
+
+type validatorSyncCommittee struct {
+	Included int `json:"included"`
+	Missed   int `json:"missed"`
+}
+
 type nonParticipatingValidator struct {
 	Validator phase0.ValidatorIndex `json:"validator_index"`
 	Slot      phase0.Slot           `json:"slot"`
@@ -111,7 +196,31 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.epoch = viper.GetString("epoch")
 	c.stream = viper.GetBool("stream")
-	c.jsonOutput = viper.GetBool("json")
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+	c.balanceFormat, err = output.BalanceFormatFromViper()
+	if err != nil {
+		return nil, err
+	}
+	c.compare = viper.GetString("compare")
+	c.validators = viper.GetStringSlice("validators")
+	if c.compare != "" && c.compare != "previous" {
+		return nil, fmt.Errorf("unsupported compare value %s", c.compare)
+	}
+	c.sample = viper.GetInt("sample")
+	c.seed = viper.GetString("seed")
+	if c.sample < 0 {
+		return nil, errors.New("sample cannot be negative")
+	}
+	if c.sample > 0 && c.compare != "" {
+		return nil, errors.New("sample cannot be used with compare")
+	}
+	if c.sample > 0 && len(c.validators) > 0 {
+		return nil, errors.New("sample cannot be used with validators")
+	}
 
 	return c, nil
 }
@@ -37,6 +37,44 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{},
 			err:  "timeout is required",
 		},
+		{
+			name: "CompareInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"compare":    "next",
+			},
+			err: "unsupported compare value next",
+		},
+		{
+			name: "SampleNegative",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"sample":     -1,
+			},
+			err: "sample cannot be negative",
+		},
+		{
+			name: "SampleWithCompare",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"sample":     100,
+				"compare":    "previous",
+			},
+			err: "sample cannot be used with compare",
+		},
+		{
+			name: "SampleWithValidators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"sample":     100,
+				"validators": []string{"1"},
+			},
+			err: "sample cannot be used with validators",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+// syncCommitteeSize is SYNC_COMMITTEE_SIZE.
+const syncCommitteeSize = 512
+
+// blocksProposed returns the number of blocks proposed in the epoch.
+func blocksProposed(summary *epochSummary) int {
+	proposed := 0
+	for _, proposal := range summary.Proposals {
+		if proposal.Block {
+			proposed++
+		}
+	}
+
+	return proposed
+}
+
+// syncCommitteeParticipation returns the number of included and total
+// sync committee contributions in the epoch.
+func syncCommitteeParticipation(summary *epochSummary) (int, int) {
+	contributions := blocksProposed(summary) * syncCommitteeSize
+	totalMissed := 0
+	for _, contribution := range summary.SyncCommittee {
+		totalMissed += contribution.Missed
+	}
+
+	return contributions - totalMissed, contributions
+}
+
+// percentage returns the value as a percentage of the total, or 0 if the total is 0.
+func percentage(value int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return 100.0 * float64(value) / float64(total)
+}
+
+// percentageDelta returns the change in percentage points between two ratios,
+// rounded to two decimal places.
+func percentageDelta(prevValue int, prevTotal int, value int, total int) float64 {
+	return math.Round((percentage(value, total)-percentage(prevValue, prevTotal))*100) / 100
+}
+
+// compareSummaries returns the changes from the previous summary to the current summary.
+// Sync committee participation is compared only if both epochs have sync committees.
+func compareSummaries(previous *epochSummary, current *epochSummary, syncCommittees bool) *epochComparison {
+	comparison := &epochComparison{
+		Epoch:                 previous.Epoch,
+		ProposalsDelta:        blocksProposed(current) - blocksProposed(previous),
+		ActiveValidatorsDelta: current.ActiveValidators - previous.ActiveValidators,
+		ParticipationDelta:    percentageDelta(previous.ParticipatingValidators, previous.ActiveValidators, current.ParticipatingValidators, current.ActiveValidators),
+		SourceTimelyDelta:     percentageDelta(previous.SourceTimelyValidators, previous.ActiveValidators, current.SourceTimelyValidators, current.ActiveValidators),
+		TargetCorrectDelta:    percentageDelta(previous.TargetCorrectValidators, previous.ActiveValidators, current.TargetCorrectValidators, current.ActiveValidators),
+		TargetTimelyDelta:     percentageDelta(previous.TargetTimelyValidators, previous.ActiveValidators, current.TargetTimelyValidators, current.ActiveValidators),
+		HeadCorrectDelta:      percentageDelta(previous.HeadCorrectValidators, previous.ActiveValidators, current.HeadCorrectValidators, current.ActiveValidators),
+		HeadTimelyDelta:       percentageDelta(previous.HeadTimelyValidators, previous.ActiveValidators, current.HeadTimelyValidators, current.ActiveValidators),
+		BalanceDelta:          int64(current.ActiveBalance) - int64(previous.ActiveBalance),
+		NewSlashings:          current.SlashedValidators - previous.SlashedValidators,
+		ActivationQueueDelta:  current.ActivationQueue - previous.ActivationQueue,
+		ExitQueueDelta:        current.ExitQueue - previous.ExitQueue,
+	}
+	if syncCommittees {
+		prevIncluded, prevTotal := syncCommitteeParticipation(previous)
+		included, total := syncCommitteeParticipation(current)
+		comparison.SyncCommitteeDelta = percentageDelta(prevIncluded, prevTotal, included, total)
+	}
+
+	return comparison
+}
+
+// indicator returns an up or down indicator for a change.
+func indicator(delta float64) string {
+	switch {
+	case delta > 0:
+		return "▲"
+	case delta < 0:
+		return "▼"
+	default:
+		return "="
+	}
+}
+
+// countDelta formats a change in a count.
+func countDelta(delta int) string {
+	return fmt.Sprintf(" %s %+d", indicator(float64(delta)), delta)
+}
+
+// percentageDeltaString formats a change in percentage points.
+func percentageDeltaString(delta float64) string {
+	return fmt.Sprintf(" %s %+0.2f%%", indicator(delta), delta)
+}
+
+// balanceDelta formats a change in balance.
+func balanceDelta(balanceFormat *output.BalanceFormat, delta int64) string {
+	sign := "+"
+	magnitude := uint64(delta)
+	if delta < 0 {
+		sign = "-"
+		magnitude = uint64(-delta)
+	}
+
+	return fmt.Sprintf(" %s %s%s", indicator(float64(delta)), sign, balanceFormat.Gwei(magnitude))
+}
@@ -0,0 +1,113 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareSummaries(t *testing.T) {
+	previous := &epochSummary{
+		Epoch: 99,
+		Proposals: []*epochProposal{
+			{Slot: 3168, Block: true},
+			{Slot: 3169, Block: false},
+		},
+		SyncCommittee: []*epochSyncCommittee{
+			{Index: 1, Missed: 1},
+		},
+		ActiveValidators:        1000,
+		ParticipatingValidators: 950,
+		SourceTimelyValidators:  950,
+		TargetCorrectValidators: 940,
+		TargetTimelyValidators:  930,
+		HeadCorrectValidators:   920,
+		HeadTimelyValidators:    900,
+		ActiveBalance:           32000000000000,
+		SlashedValidators:       2,
+		ActivationQueue:         10,
+		ExitQueue:               5,
+	}
+
+	tests := []struct {
+		name           string
+		current        *epochSummary
+		syncCommittees bool
+		res            *epochComparison
+	}{
+		{
+			name:    "Same",
+			current: previous,
+			res: &epochComparison{
+				Epoch: 99,
+			},
+		},
+		{
+			name: "Changes",
+			current: &epochSummary{
+				Epoch: 100,
+				Proposals: []*epochProposal{
+					{Slot: 3200, Block: true},
+					{Slot: 3201, Block: true},
+				},
+				ActiveValidators:        1001,
+				ParticipatingValidators: 990,
+				SourceTimelyValidators:  990,
+				TargetCorrectValidators: 900,
+				TargetTimelyValidators:  900,
+				HeadCorrectValidators:   920,
+				HeadTimelyValidators:    900,
+				ActiveBalance:           31999000000000,
+				SlashedValidators:       3,
+				ActivationQueue:         8,
+				ExitQueue:               6,
+			},
+			syncCommittees: true,
+			res: &epochComparison{
+				Epoch:                 99,
+				ProposalsDelta:        1,
+				ActiveValidatorsDelta: 1,
+				ParticipationDelta:    3.9,
+				SourceTimelyDelta:     3.9,
+				TargetCorrectDelta:    -4.09,
+				TargetTimelyDelta:     -3.09,
+				HeadCorrectDelta:      -0.09,
+				HeadTimelyDelta:       -0.09,
+				SyncCommitteeDelta:    0.2,
+				BalanceDelta:          -1000000000,
+				NewSlashings:          1,
+				ActivationQueueDelta:  -2,
+				ExitQueueDelta:        1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, compareSummaries(previous, test.current, test.syncCommittees))
+		})
+	}
+}
+
+func TestDeltaStrings(t *testing.T) {
+	require.Equal(t, " ▲ +2", countDelta(2))
+	require.Equal(t, " ▼ -1", countDelta(-1))
+	require.Equal(t, " = +0", countDelta(0))
+	require.Equal(t, " ▲ +0.25%", percentageDeltaString(0.25))
+	require.Equal(t, " ▼ -1.50%", percentageDeltaString(-1.5))
+	require.Equal(t, " ▲ +1 Ether", balanceDelta(nil, 1000000000))
+	require.Equal(t, " ▼ -0.5 Ether", balanceDelta(nil, -500000000))
+}
@@ -18,6 +18,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
 )
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -25,26 +29,173 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
-	if c.jsonOutput {
-		return c.outputJSON(ctx)
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the epoch summary as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.summary)
+}
+
+// epochCSVHeader is the header of the CSV output for an epoch.
+var epochCSVHeader = []string{
+	"epoch",
+	"first_slot",
+	"last_slot",
+	"proposals",
+	"blocks",
+	"active_validators",
+	"participating_validators",
+	"source_timely_validators",
+	"target_correct_validators",
+	"target_timely_validators",
+	"head_correct_validators",
+	"head_timely_validators",
+	"sync_committee_included",
+	"sync_committee_contributions",
+	"blobs",
+	"active_balance",
+	"slashed_validators",
+	"activation_queue",
+	"exit_queue",
+}
+
+// validatorCSVHeader is the header of the CSV output for individual validators.
+var validatorCSVHeader = []string{
+	"epoch",
+	"validator_index",
+	"active",
+	"attestation_slot",
+	"committee_index",
+	"included",
+	"inclusion_slot",
+	"inclusion_distance",
+	"source_timely",
+	"target_correct",
+	"target_timely",
+	"head_correct",
+	"head_timely",
+	"proposals",
+	"blocks",
+	"sync_committee_included",
+	"sync_committee_missed",
+}
+
+// CSVHeader returns the header of the CSV output, which is per-validator if
+// validators were supplied.
+func (c *command) CSVHeader() []string {
+	if len(c.validators) > 0 {
+		return validatorCSVHeader
+	}
+
+	return epochCSVHeader
+}
+
+// CSVRecords returns the epoch summary as CSV, or the performance of
+// individual validators if validators were supplied.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	if len(c.validators) > 0 {
+		records := make([][]string, 0, len(c.summary.Validators))
+		for _, validator := range c.summary.Validators {
+			records = append(records, validatorCSVRecord(c.targetEpoch, validator))
+		}
+
+		return records, nil
+	}
+
+	included, contributions := syncCommitteeParticipation(c.summary)
+	if c.targetEpoch < c.chainTime.AltairInitialEpoch() {
+		included, contributions = 0, 0
 	}
 
-	return c.outputTxt(ctx)
+	return [][]string{{
+		fmt.Sprintf("%d", c.summary.Epoch),
+		fmt.Sprintf("%d", c.summary.FirstSlot),
+		fmt.Sprintf("%d", c.summary.LastSlot),
+		fmt.Sprintf("%d", len(c.summary.Proposals)),
+		fmt.Sprintf("%d", blocksProposed(c.summary)),
+		fmt.Sprintf("%d", c.summary.ActiveValidators),
+		fmt.Sprintf("%d", c.summary.ParticipatingValidators),
+		fmt.Sprintf("%d", c.summary.SourceTimelyValidators),
+		fmt.Sprintf("%d", c.summary.TargetCorrectValidators),
+		fmt.Sprintf("%d", c.summary.TargetTimelyValidators),
+		fmt.Sprintf("%d", c.summary.HeadCorrectValidators),
+		fmt.Sprintf("%d", c.summary.HeadTimelyValidators),
+		fmt.Sprintf("%d", included),
+		fmt.Sprintf("%d", contributions),
+		fmt.Sprintf("%d", c.summary.Blobs),
+		fmt.Sprintf("%d", c.summary.ActiveBalance),
+		fmt.Sprintf("%d", c.summary.SlashedValidators),
+		fmt.Sprintf("%d", c.summary.ActivationQueue),
+		fmt.Sprintf("%d", c.summary.ExitQueue),
+	}}, nil
 }
 
-func (c *command) outputJSON(_ context.Context) (string, error) {
-	data, err := json.Marshal(c.summary)
-	if err != nil {
-		return "", err
+// validatorCSVRecord returns the CSV record for an individual validator.
+// Fields that do not apply to the validator are left empty.
+func validatorCSVRecord(epoch phase0.Epoch, validator *validatorSummary) []string {
+	record := []string{
+		fmt.Sprintf("%d", epoch),
+		fmt.Sprintf("%d", validator.Index),
+		fmt.Sprintf("%t", validator.Active),
+	}
+
+	if attestation := validator.Attestation; attestation != nil {
+		record = append(record,
+			fmt.Sprintf("%d", attestation.Slot),
+			fmt.Sprintf("%d", attestation.Committee),
+			fmt.Sprintf("%t", attestation.Included),
+		)
+		if attestation.Included {
+			record = append(record,
+				fmt.Sprintf("%d", attestation.InclusionSlot),
+				fmt.Sprintf("%d", attestation.InclusionDistance),
+				fmt.Sprintf("%t", attestation.SourceTimely),
+				fmt.Sprintf("%t", attestation.TargetCorrect),
+				fmt.Sprintf("%t", attestation.TargetTimely),
+				fmt.Sprintf("%t", attestation.HeadCorrect),
+				fmt.Sprintf("%t", attestation.HeadTimely),
+			)
+		} else {
+			record = append(record, "", "", "", "", "", "", "")
+		}
+	} else {
+		record = append(record, "", "", "", "", "", "", "", "", "", "")
+	}
+
+	blocks := 0
+	for _, proposal := range validator.Proposals {
+		if proposal.Block {
+			blocks++
+		}
+	}
+	record = append(record,
+		fmt.Sprintf("%d", len(validator.Proposals)),
+		fmt.Sprintf("%d", blocks),
+	)
+
+	if validator.SyncCommittee != nil {
+		record = append(record,
+			fmt.Sprintf("%d", validator.SyncCommittee.Included),
+			fmt.Sprintf("%d", validator.SyncCommittee.Missed),
+		)
+	} else {
+		record = append(record, "", "")
 	}
-	return string(data), nil
+
+	return record
 }
 
-func (c *command) outputTxt(_ context.Context) (string, error) {
+// RenderText renders the epoch summary as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
 	builder := strings.Builder{}
 
 	builder.WriteString("Epoch ")
-	builder.WriteString(fmt.Sprintf("%d:\n", c.summary.Epoch))
+	if c.summary.Comparison != nil {
+		builder.WriteString(fmt.Sprintf("%d (compared with epoch %d):\n", c.summary.Epoch, c.summary.Comparison.Epoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("%d:\n", c.summary.Epoch))
+	}
 
 	proposedBlocks := 0
 	missedProposals := make([]string, 0, len(c.summary.Proposals))
@@ -56,6 +207,10 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 		}
 	}
 	builder.WriteString(fmt.Sprintf("  Proposals: %d/%d (%0.2f%%)", proposedBlocks, len(missedProposals)+proposedBlocks, 100.0*float64(proposedBlocks)/float64(len(missedProposals)+proposedBlocks)))
+	comparison := c.summary.Comparison
+	if comparison != nil {
+		builder.WriteString(countDelta(comparison.ProposalsDelta))
+	}
 	if c.verbose {
 		for _, proposal := range c.summary.Proposals {
 			if proposal.Block {
@@ -69,12 +224,34 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 		}
 	}
 
-	builder.WriteString(fmt.Sprintf("\n  Attestations: %d/%d (%0.2f%%)", c.summary.ParticipatingValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.ParticipatingValidators)/float64(c.summary.ActiveValidators)))
-	builder.WriteString(fmt.Sprintf("\n    Source timely: %d/%d (%0.2f%%)", c.summary.SourceTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.SourceTimelyValidators)/float64(c.summary.ActiveValidators)))
-	builder.WriteString(fmt.Sprintf("\n    Target correct: %d/%d (%0.2f%%)", c.summary.TargetCorrectValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.TargetCorrectValidators)/float64(c.summary.ActiveValidators)))
-	builder.WriteString(fmt.Sprintf("\n    Target timely: %d/%d (%0.2f%%)", c.summary.TargetTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.TargetTimelyValidators)/float64(c.summary.ActiveValidators)))
-	builder.WriteString(fmt.Sprintf("\n    Head correct: %d/%d (%0.2f%%)", c.summary.HeadCorrectValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.HeadCorrectValidators)/float64(c.summary.ActiveValidators)))
-	builder.WriteString(fmt.Sprintf("\n    Head timely: %d/%d (%0.2f%%)", c.summary.HeadTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.HeadTimelyValidators)/float64(c.summary.ActiveValidators)))
+	if c.summary.Sample != nil {
+		builder.WriteString(sampleTxt(c.summary))
+	} else {
+		builder.WriteString(fmt.Sprintf("\n  Attestations: %d/%d (%0.2f%%)", c.summary.ParticipatingValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.ParticipatingValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.ParticipationDelta))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Source timely: %d/%d (%0.2f%%)", c.summary.SourceTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.SourceTimelyValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.SourceTimelyDelta))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Target correct: %d/%d (%0.2f%%)", c.summary.TargetCorrectValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.TargetCorrectValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.TargetCorrectDelta))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Target timely: %d/%d (%0.2f%%)", c.summary.TargetTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.TargetTimelyValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.TargetTimelyDelta))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Head correct: %d/%d (%0.2f%%)", c.summary.HeadCorrectValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.HeadCorrectValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.HeadCorrectDelta))
+		}
+		builder.WriteString(fmt.Sprintf("\n    Head timely: %d/%d (%0.2f%%)", c.summary.HeadTimelyValidators, c.summary.ActiveValidators, 100.0*float64(c.summary.HeadTimelyValidators)/float64(c.summary.ActiveValidators)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.HeadTimelyDelta))
+		}
+	}
 	if c.verbose {
 		// Sort list by validator index.
 		for _, validator := range c.summary.NonParticipatingValidators {
@@ -88,13 +265,12 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 		}
 	}
 
-	if c.summary.Epoch >= c.chainTime.AltairInitialEpoch() {
-		contributions := proposedBlocks * 512 // SYNC_COMMITTEE_SIZE
-		totalMissed := 0
-		for _, contribution := range c.summary.SyncCommittee {
-			totalMissed += contribution.Missed
+	if c.targetEpoch >= c.chainTime.AltairInitialEpoch() {
+		included, contributions := syncCommitteeParticipation(c.summary)
+		builder.WriteString(fmt.Sprintf("\n  Sync committees: %d/%d (%0.2f%%)", included, contributions, 100.0*float64(included)/float64(contributions)))
+		if comparison != nil {
+			builder.WriteString(percentageDeltaString(comparison.SyncCommitteeDelta))
 		}
-		builder.WriteString(fmt.Sprintf("\n  Sync committees: %d/%d (%0.2f%%)", contributions-totalMissed, contributions, 100.0*float64(contributions-totalMissed)/float64(contributions)))
 		if c.verbose {
 			for _, syncCommittee := range c.summary.SyncCommittee {
 				builder.WriteString("\n    Validator ")
@@ -106,5 +282,93 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 		}
 	}
 
+	if comparison != nil {
+		builder.WriteString(fmt.Sprintf("\n  Active balance: %s", c.balanceFormat.Gwei(uint64(c.summary.ActiveBalance))))
+		builder.WriteString(balanceDelta(c.balanceFormat, comparison.BalanceDelta))
+		builder.WriteString(fmt.Sprintf("\n  Slashed validators: %d", c.summary.SlashedValidators))
+		builder.WriteString(countDelta(comparison.NewSlashings))
+		builder.WriteString(fmt.Sprintf("\n  Activation queue: %d", c.summary.ActivationQueue))
+		builder.WriteString(countDelta(comparison.ActivationQueueDelta))
+		builder.WriteString(fmt.Sprintf("\n  Exit queue: %d", c.summary.ExitQueue))
+		builder.WriteString(countDelta(comparison.ExitQueueDelta))
+	}
+
+	for _, validator := range c.summary.Validators {
+		builder.WriteString(validatorSummaryTxt(validator))
+	}
+
 	return builder.String(), nil
 }
+
+// sampleTxt provides the text output for attestation performance estimated
+// from a sample of validators.
+func sampleTxt(summary *epochSummary) string {
+	builder := strings.Builder{}
+
+	sample := summary.Sample
+	builder.WriteString(fmt.Sprintf("\n  Attestations (estimated from %d/%d validators): %s", sample.Size, summary.ActiveValidators, proportionEstimateTxt(sample.Participation)))
+	builder.WriteString(fmt.Sprintf("\n    Source timely: %s", proportionEstimateTxt(sample.SourceTimely)))
+	builder.WriteString(fmt.Sprintf("\n    Target correct: %s", proportionEstimateTxt(sample.TargetCorrect)))
+	builder.WriteString(fmt.Sprintf("\n    Target timely: %s", proportionEstimateTxt(sample.TargetTimely)))
+	builder.WriteString(fmt.Sprintf("\n    Head correct: %s", proportionEstimateTxt(sample.HeadCorrect)))
+	builder.WriteString(fmt.Sprintf("\n    Head timely: %s", proportionEstimateTxt(sample.HeadTimely)))
+	builder.WriteString(fmt.Sprintf("\n    Inclusion distance: %0.2f (95%% CI %0.2f-%0.2f)", sample.InclusionDistance.Value, sample.InclusionDistance.Lower, sample.InclusionDistance.Upper))
+
+	return builder.String()
+}
+
+// proportionEstimateTxt describes an estimated proportion as a percentage.
+func proportionEstimateTxt(estimate *util.Estimate) string {
+	return fmt.Sprintf("%0.2f%% (95%% CI %0.2f%%-%0.2f%%)", 100.0*estimate.Value, 100.0*estimate.Lower, 100.0*estimate.Upper)
+}
+
+// validatorSummaryTxt provides the text output for an individual validator.
+func validatorSummaryTxt(validator *validatorSummary) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("\n  Validator %d:", validator.Index))
+	if !validator.Active {
+		builder.WriteString(" not active")
+		return builder.String()
+	}
+
+	attestation := validator.Attestation
+	switch {
+	case attestation.Included:
+		builder.WriteString(fmt.Sprintf("\n    Attestation: slot %d committee %d, included in slot %d (distance %d)", attestation.Slot, attestation.Committee, attestation.InclusionSlot, attestation.InclusionDistance))
+		builder.WriteString(fmt.Sprintf("\n      Source: %s", voteTxt(true, attestation.SourceTimely)))
+		builder.WriteString(fmt.Sprintf("\n      Target: %s", voteTxt(attestation.TargetCorrect, attestation.TargetTimely)))
+		builder.WriteString(fmt.Sprintf("\n      Head: %s", voteTxt(attestation.HeadCorrect, attestation.HeadTimely)))
+	case attestation.Slot != 0:
+		builder.WriteString(fmt.Sprintf("\n    Attestation: slot %d committee %d, not included", attestation.Slot, attestation.Committee))
+	default:
+		builder.WriteString("\n    Attestation: not included")
+	}
+
+	for _, proposal := range validator.Proposals {
+		if proposal.Block {
+			builder.WriteString(fmt.Sprintf("\n    Proposal: slot %d, proposed", proposal.Slot))
+		} else {
+			builder.WriteString(fmt.Sprintf("\n    Proposal: slot %d, not proposed or not included", proposal.Slot))
+		}
+	}
+
+	if validator.SyncCommittee != nil {
+		contributions := validator.SyncCommittee.Included + validator.SyncCommittee.Missed
+		builder.WriteString(fmt.Sprintf("\n    Sync committee: %d/%d (%0.2f%%)", validator.SyncCommittee.Included, contributions, 100.0*float64(validator.SyncCommittee.Included)/float64(contributions)))
+	}
+
+	return builder.String()
+}
+
+// voteTxt describes an attestation vote.
+func voteTxt(correct bool, timely bool) string {
+	switch {
+	case !correct:
+		return "incorrect"
+	case timely:
+		return "correct, timely"
+	default:
+		return "correct, late"
+	}
+}
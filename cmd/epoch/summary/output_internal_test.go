@@ -0,0 +1,179 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestValidatorSummaryTxt(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator *validatorSummary
+		expected  string
+	}{
+		{
+			name: "Inactive",
+			validator: &validatorSummary{
+				Index: 1,
+			},
+			expected: "\n  Validator 1: not active",
+		},
+		{
+			name: "Included",
+			validator: &validatorSummary{
+				Index:  2,
+				Active: true,
+				Attestation: &validatorAttestation{
+					Slot:              100,
+					Committee:         3,
+					Included:          true,
+					InclusionSlot:     102,
+					InclusionDistance: 2,
+					HeadCorrect:       true,
+					SourceTimely:      true,
+					TargetCorrect:     true,
+					TargetTimely:      true,
+				},
+				Proposals: []*epochProposal{
+					{
+						Slot:     105,
+						Proposer: 2,
+						Block:    true,
+					},
+				},
+				SyncCommittee: &validatorSyncCommittee{
+					Included: 30,
+					Missed:   2,
+				},
+			},
+			expected: "\n  Validator 2:\n    Attestation: slot 100 committee 3, included in slot 102 (distance 2)\n      Source: correct, timely\n      Target: correct, timely\n      Head: correct, late\n    Proposal: slot 105, proposed\n    Sync committee: 30/32 (93.75%)",
+		},
+		{
+			name: "NotIncluded",
+			validator: &validatorSummary{
+				Index:  3,
+				Active: true,
+				Attestation: &validatorAttestation{
+					Slot:      101,
+					Committee: 4,
+				},
+				Proposals: []*epochProposal{
+					{
+						Slot:     106,
+						Proposer: 3,
+					},
+				},
+			},
+			expected: "\n  Validator 3:\n    Attestation: slot 101 committee 4, not included\n    Proposal: slot 106, not proposed or not included",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, validatorSummaryTxt(test.validator))
+		})
+	}
+}
+
+func TestValidatorCSVRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator *validatorSummary
+		expected  []string
+	}{
+		{
+			name: "Inactive",
+			validator: &validatorSummary{
+				Index: 1,
+			},
+			expected: []string{"10", "1", "false", "", "", "", "", "", "", "", "", "", "", "0", "0", "", ""},
+		},
+		{
+			name: "Included",
+			validator: &validatorSummary{
+				Index:  2,
+				Active: true,
+				Attestation: &validatorAttestation{
+					Slot:              320,
+					Committee:         3,
+					Included:          true,
+					InclusionSlot:     321,
+					InclusionDistance: 1,
+					HeadCorrect:       true,
+					HeadTimely:        true,
+					SourceTimely:      true,
+					TargetCorrect:     true,
+				},
+				Proposals: []*epochProposal{
+					{
+						Slot:     325,
+						Proposer: 2,
+						Block:    true,
+					},
+					{
+						Slot:     330,
+						Proposer: 2,
+					},
+				},
+				SyncCommittee: &validatorSyncCommittee{
+					Included: 30,
+					Missed:   2,
+				},
+			},
+			expected: []string{"10", "2", "true", "320", "3", "true", "321", "1", "true", "true", "false", "true", "true", "2", "1", "30", "2"},
+		},
+		{
+			name: "NotIncluded",
+			validator: &validatorSummary{
+				Index:  3,
+				Active: true,
+				Attestation: &validatorAttestation{
+					Slot:      321,
+					Committee: 4,
+				},
+			},
+			expected: []string{"10", "3", "true", "321", "4", "false", "", "", "", "", "", "", "", "0", "0", "", ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := validatorCSVRecord(10, test.validator)
+			require.Len(t, record, len(validatorCSVHeader))
+			require.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestSampleTxt(t *testing.T) {
+	summary := &epochSummary{
+		ActiveValidators: 1000000,
+		Sample: &sampleSummary{
+			Size:              1000,
+			Participation:     &util.Estimate{Value: 0.985, Lower: 0.9754, Upper: 0.9909},
+			SourceTimely:      &util.Estimate{Value: 0.98, Lower: 0.9693, Upper: 0.9870},
+			TargetCorrect:     &util.Estimate{Value: 0.975, Lower: 0.9633, Upper: 0.9831},
+			TargetTimely:      &util.Estimate{Value: 0.975, Lower: 0.9633, Upper: 0.9831},
+			HeadCorrect:       &util.Estimate{Value: 0.96, Lower: 0.9459, Upper: 0.9707},
+			HeadTimely:        &util.Estimate{Value: 0.95, Lower: 0.9346, Upper: 0.9620},
+			InclusionDistance: &util.Estimate{Value: 1.052, Lower: 1.031, Upper: 1.073},
+		},
+	}
+
+	require.Equal(t, "\n  Attestations (estimated from 1000/1000000 validators): 98.50% (95% CI 97.54%-99.09%)\n    Source timely: 98.00% (95% CI 96.93%-98.70%)\n    Target correct: 97.50% (95% CI 96.33%-98.31%)\n    Target timely: 97.50% (95% CI 96.33%-98.31%)\n    Head correct: 96.00% (95% CI 94.59%-97.07%)\n    Head timely: 95.00% (95% CI 93.46%-96.20%)\n    Inclusion distance: 1.05 (95% CI 1.03-1.07)", sampleTxt(summary))
+}
@@ -19,9 +19,9 @@ import (
 	"sort"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
-	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -35,12 +35,53 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
-	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
-	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
+
+	if len(c.validators) > 0 {
+		if err := c.resolveValidators(ctx, epoch); err != nil {
+			return err
+		}
+	}
+
+	if c.compare == "previous" {
+		if epoch == 0 {
+			return errors.New("no previous epoch to compare against")
+		}
+		if err := c.summarise(ctx, epoch-1); err != nil {
+			return errors.Wrap(err, "failed to summarise previous epoch")
+		}
+		c.previous = c.summary
+	}
+
+	if err := c.summarise(ctx, epoch); err != nil {
+		return err
+	}
+	if c.previous != nil {
+		c.summary.Comparison = compareSummaries(c.previous, c.summary, phase0.Epoch(c.previous.Epoch) >= c.chainTime.AltairInitialEpoch())
+	}
+
+	return nil
+}
+
+// summarise generates the summary for the given epoch.
+func (c *command) summarise(ctx context.Context, epoch phase0.Epoch) error {
+	c.targetEpoch = epoch
+	c.summary = &epochSummary{
+		Epoch: uint64(epoch),
+	}
+	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.targetEpoch)
+	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.targetEpoch+1) - 1
+	c.validatorSummaries = make(map[phase0.ValidatorIndex]*validatorSummary, len(c.validatorIndices))
+	for _, index := range c.validatorIndices {
+		validator := &validatorSummary{
+			Index: index,
+		}
+		c.validatorSummaries[index] = validator
+		c.summary.Validators = append(c.summary.Validators, validator)
+	}
 
 	if err := c.processProposerDuties(ctx); err != nil {
 		return err
@@ -55,10 +96,11 @@ func (c *command) process(ctx context.Context) error {
 }
 
 func (c *command) processProposerDuties(ctx context.Context) error {
-	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, c.summary.Epoch, nil)
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: c.targetEpoch})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain proposer duties")
 	}
+	duties := dutiesResponse.Data
 	if duties == nil {
 		return errors.New("empty proposer duties")
 	}
@@ -68,25 +110,42 @@ func (c *command) processProposerDuties(ctx context.Context) error {
 			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", duty.Slot))
 		}
 		present := block != nil
-		c.summary.Proposals = append(c.summary.Proposals, &epochProposal{
+		proposal := &epochProposal{
 			Slot:     duty.Slot,
 			Proposer: duty.ValidatorIndex,
 			Block:    present,
-		})
+		}
+		c.summary.Proposals = append(c.summary.Proposals, proposal)
+		if validator, exists := c.validatorSummaries[duty.ValidatorIndex]; exists {
+			validator.Proposals = append(validator.Proposals, proposal)
+		}
 	}
 
 	return nil
 }
 
+// activeValidators returns the validators active in the epoch, and
+// summarises their balances along with the state of the validator set.
 func (c *command) activeValidators(ctx context.Context) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
-	validators, err := c.validatorsProvider.Validators(ctx, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)), nil)
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.targetEpoch))})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain validators for epoch")
 	}
+	validators := validatorsResponse.Data
 	activeValidators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
 	for _, validator := range validators {
-		if validator.Validator.ActivationEpoch <= c.summary.Epoch && validator.Validator.ExitEpoch > c.summary.Epoch {
+		if validator.Validator.ActivationEpoch <= c.targetEpoch && validator.Validator.ExitEpoch > c.targetEpoch {
 			activeValidators[validator.Index] = validator
+			c.summary.ActiveBalance += validator.Balance
+		}
+		if validator.Validator.Slashed {
+			c.summary.SlashedValidators++
+		}
+		switch validator.Status {
+		case apiv1.ValidatorStatePendingQueued:
+			c.summary.ActivationQueue++
+		case apiv1.ValidatorStateActiveExiting:
+			c.summary.ExitQueue++
 		}
 	}
 
@@ -94,17 +153,27 @@ func (c *command) activeValidators(ctx context.Context) (map[phase0.ValidatorInd
 }
 
 func (c *command) processAttesterDuties(ctx context.Context) error {
+	if c.sample > 0 {
+		return c.processSampledAttesterDuties(ctx)
+	}
+
 	activeValidators, err := c.activeValidators(ctx)
 	if err != nil {
 		return err
 	}
 	c.summary.ActiveValidators = len(activeValidators)
+	for index, validator := range c.validatorSummaries {
+		if _, exists := activeValidators[index]; exists {
+			validator.Active = true
+			validator.Attestation = &validatorAttestation{}
+		}
+	}
 
 	// Obtain number of validators that voted for blocks in the epoch.
 	// These votes can be included anywhere from the second slot of
 	// the epoch to the first slot of the next-but-one epoch.
-	firstSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) + 1
-	lastSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch + 2)
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch) + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch + 2)
 	if lastSlot > c.chainTime.CurrentSlot() {
 		lastSlot = c.chainTime.CurrentSlot()
 	}
@@ -134,6 +203,17 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 		return c.summary.NonParticipatingValidators[i].Validator < c.summary.NonParticipatingValidators[j].Validator
 	})
 
+	// Fill in the duties of individual validators whose attestations were not included.
+	for index, validator := range c.validatorSummaries {
+		if validator.Attestation == nil || validator.Attestation.Included {
+			continue
+		}
+		if participation, exists := participations[index]; exists {
+			validator.Attestation.Slot = participation.Slot
+			validator.Attestation.Committee = participation.Committee
+		}
+	}
+
 	return nil
 }
 
@@ -181,16 +261,21 @@ func (c *command) processSlots(ctx context.Context,
 			return 0, 0, 0, 0, 0, 0, nil, nil, err
 		}
 		for _, attestation := range attestations {
-			if attestation.Data.Slot < c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) || attestation.Data.Slot >= c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) {
+			data, err := attestation.Data()
+			if err != nil {
+				return 0, 0, 0, 0, 0, 0, nil, nil, errors.Wrap(err, "failed to obtain attestation data")
+			}
+			if data.Slot < c.chainTime.FirstSlotOfEpoch(c.targetEpoch) || data.Slot >= c.chainTime.FirstSlotOfEpoch(c.targetEpoch+1) {
 				// Outside of this epoch's range.
 				continue
 			}
-			slotCommittees, exists := allCommittees[attestation.Data.Slot]
+			slotCommittees, exists := allCommittees[data.Slot]
 			if !exists {
-				beaconCommittees, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, fmt.Sprintf("%d", attestation.Data.Slot))
+				beaconCommitteesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", data.Slot)})
 				if err != nil {
-					return 0, 0, 0, 0, 0, 0, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", attestation.Data.Slot))
+					return 0, 0, 0, 0, 0, 0, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", data.Slot))
 				}
+				beaconCommittees := beaconCommitteesResponse.Data
 				for _, beaconCommittee := range beaconCommittees {
 					if _, exists := allCommittees[beaconCommittee.Slot]; !exists {
 						allCommittees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
@@ -204,23 +289,67 @@ func (c *command) processSlots(ctx context.Context,
 						}
 					}
 				}
-				slotCommittees = allCommittees[attestation.Data.Slot]
+				slotCommittees = allCommittees[data.Slot]
+			}
+			committeeIndices, err := util.AttestationCommitteeIndices(attestation)
+			if err != nil {
+				return 0, 0, 0, 0, 0, 0, nil, nil, err
+			}
+			// The members of each committee covered by the attestation are concatenated.
+			committee := make([]phase0.ValidatorIndex, 0)
+			memberCommittees := make([]phase0.CommitteeIndex, 0)
+			for _, committeeIndex := range committeeIndices {
+				for _, member := range slotCommittees[committeeIndex] {
+					committee = append(committee, member)
+					memberCommittees = append(memberCommittees, committeeIndex)
+				}
+			}
+			if c.sampled != nil && !c.committeeSampled(committee) {
+				// No need to process attestations without sampled validators.
+				continue
 			}
-			committee := slotCommittees[attestation.Data.Index]
 
-			inclusionDistance := slot - attestation.Data.Slot
-			headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+			inclusionDistance := slot - data.Slot
+			headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, data)
 			if err != nil {
 				return 0, 0, 0, 0, 0, 0, nil, nil, err
 			}
-			targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+			targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, data)
 			if err != nil {
 				return 0, 0, 0, 0, 0, 0, nil, nil, err
 			}
 
-			for i := uint64(0); i < attestation.AggregationBits.Len(); i++ {
-				if attestation.AggregationBits.BitAt(i) {
+			aggregationBits, err := attestation.AggregationBits()
+			if err != nil {
+				return 0, 0, 0, 0, 0, 0, nil, nil, errors.Wrap(err, "failed to obtain aggregation bits")
+			}
+			for i := uint64(0); i < aggregationBits.Len() && i < uint64(len(committee)); i++ {
+				if aggregationBits.BitAt(i) {
+					if c.sampled != nil {
+						if _, exists := c.sampled[committee[int(i)]]; !exists {
+							continue
+						}
+						if _, exists := c.inclusionDistances[committee[int(i)]]; !exists {
+							// Blocks are processed in order, so this is the first inclusion of the attestation.
+							c.inclusionDistances[committee[int(i)]] = inclusionDistance
+						}
+					}
 					votes[committee[int(i)]] = struct{}{}
+					if validator, exists := c.validatorSummaries[committee[int(i)]]; exists && validator.Attestation != nil && !validator.Attestation.Included {
+						// Blocks are processed in order, so this is the first inclusion of the attestation.
+						validator.Attestation = &validatorAttestation{
+							Slot:              data.Slot,
+							Committee:         memberCommittees[i],
+							Included:          true,
+							InclusionSlot:     slot,
+							InclusionDistance: inclusionDistance,
+							HeadCorrect:       headCorrect,
+							HeadTimely:        headCorrect && inclusionDistance == 1,
+							SourceTimely:      inclusionDistance <= 5,
+							TargetCorrect:     targetCorrect,
+							TargetTimely:      targetCorrect && inclusionDistance <= 32,
+						}
+					}
 					if _, exists := headCorrects[committee[int(i)]]; !exists && headCorrect {
 						headCorrects[committee[int(i)]] = struct{}{}
 					}
@@ -252,15 +381,16 @@ func (c *command) processSlots(ctx context.Context,
 }
 
 func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
-	if c.summary.Epoch < c.chainTime.AltairInitialEpoch() {
+	if c.targetEpoch < c.chainTime.AltairInitialEpoch() {
 		// The epoch is pre-Altair.  No info but no error.
 		return nil
 	}
 
-	committee, err := c.syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", c.summary.FirstSlot))
+	committeeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{State: fmt.Sprintf("%d", c.summary.FirstSlot)})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain sync committee")
 	}
+	committee := committeeResponse.Data
 	if len(committee.Validators) == 0 {
 		return errors.Wrap(err, "empty sync committee")
 	}
@@ -269,6 +399,7 @@ func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
 	for _, index := range committee.Validators {
 		missed[index] = 0
 	}
+	contributions := make(map[phase0.ValidatorIndex]int)
 
 	for slot := c.summary.FirstSlot; slot <= c.summary.LastSlot; slot++ {
 		block, err := c.fetchBlock(ctx, fmt.Sprintf("%d", slot))
@@ -279,29 +410,31 @@ func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
 			// If the block is missed we don't count the sync aggregate miss.
 			continue
 		}
-		var aggregate *altair.SyncAggregate
-		switch block.Version {
-		case spec.DataVersionPhase0:
+		if block.Version == spec.DataVersionPhase0 {
 			// No sync committees in this fork.
 			return nil
-		case spec.DataVersionAltair:
-			aggregate = block.Altair.Message.Body.SyncAggregate
-		case spec.DataVersionBellatrix:
-			aggregate = block.Bellatrix.Message.Body.SyncAggregate
-		case spec.DataVersionCapella:
-			aggregate = block.Capella.Message.Body.SyncAggregate
-		case spec.DataVersionDeneb:
-			aggregate = block.Deneb.Message.Body.SyncAggregate
-		default:
-			return fmt.Errorf("unhandled block version %v", block.Version)
+		}
+		aggregate, err := block.SyncAggregate()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync aggregate for slot %d", slot))
 		}
 		for i := uint64(0); i < aggregate.SyncCommitteeBits.Len(); i++ {
+			contributions[committee.Validators[int(i)]]++
 			if !aggregate.SyncCommitteeBits.BitAt(i) {
 				missed[committee.Validators[int(i)]]++
 			}
 		}
 	}
 
+	for index, validator := range c.validatorSummaries {
+		if count, exists := contributions[index]; exists {
+			validator.SyncCommittee = &validatorSyncCommittee{
+				Included: count - missed[index],
+				Missed:   missed[index],
+			}
+		}
+	}
+
 	c.summary.SyncCommittee = make([]*epochSyncCommittee, 0, len(missed))
 	for index, count := range missed {
 		if count > 0 {
@@ -385,14 +518,15 @@ func (c *command) processBlobs(ctx context.Context) error {
 		if block == nil {
 			continue
 		}
-		switch block.Version {
-		case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella:
-			// No blobs in these forks.
-		case spec.DataVersionDeneb:
-			c.summary.Blobs += len(block.Deneb.Message.Body.BlobKzgCommitments)
-		default:
-			return fmt.Errorf("unhandled block version %v", block.Version)
+		if block.Version < spec.DataVersionDeneb {
+			// No blobs prior to Deneb.
+			continue
+		}
+		commitments, err := block.BlobKZGCommitments()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain blob KZG commitments for slot %d", slot))
 		}
+		c.summary.Blobs += len(commitments)
 	}
 
 	return nil
@@ -407,7 +541,7 @@ func (c *command) fetchBlock(ctx context.Context,
 	block, exists := c.blocksCache[blockID]
 	if !exists {
 		var err error
-		block, err = c.blocksProvider.SignedBeaconBlock(ctx, blockID)
+		block, err = util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to fetch block")
 		}
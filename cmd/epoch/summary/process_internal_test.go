@@ -18,6 +18,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +64,75 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessBlobs(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   map[string]*spec.VersionedSignedBeaconBlock
+		expected int
+		err      string
+	}{
+		{
+			name: "Mixed",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"10": {
+					Version: spec.DataVersionCapella,
+					Capella: &capella.SignedBeaconBlock{},
+				},
+				"11": nil,
+				"12": {
+					Version: spec.DataVersionDeneb,
+					Deneb: &deneb.SignedBeaconBlock{
+						Message: &deneb.BeaconBlock{
+							Body: &deneb.BeaconBlockBody{
+								BlobKZGCommitments: []deneb.KZGCommitment{{}, {}},
+							},
+						},
+					},
+				},
+				"13": {
+					Version: spec.DataVersionElectra,
+					Electra: &electra.SignedBeaconBlock{
+						Message: &electra.BeaconBlock{
+							Body: &electra.BeaconBlockBody{
+								BlobKZGCommitments: []deneb.KZGCommitment{{}, {}, {}},
+							},
+						},
+					},
+				},
+			},
+			expected: 5,
+		},
+		{
+			name: "ElectraMissing",
+			blocks: map[string]*spec.VersionedSignedBeaconBlock{
+				"10": nil,
+				"11": nil,
+				"12": nil,
+				"13": {
+					Version: spec.DataVersionElectra,
+				},
+			},
+			err: "failed to obtain blob KZG commitments for slot 13: no electra block",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				blocksCache: test.blocks,
+				summary: &epochSummary{
+					FirstSlot: 10,
+					LastSlot:  13,
+				},
+			}
+			err := c.processBlobs(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, c.summary.Blobs)
+			}
+		})
+	}
+}
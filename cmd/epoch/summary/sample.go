@@ -0,0 +1,157 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// processSampledAttesterDuties estimates the attestation performance of the
+// active validators from a random sample of them.  The active validators are
+// obtained from the epoch's committees, avoiding the need to fetch the full
+// validator set, and only attestations that contain sampled validators are
+// checked for correctness.
+func (c *command) processSampledAttesterDuties(ctx context.Context) error {
+	committeesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", c.summary.FirstSlot), Epoch: &c.targetEpoch})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain committees for epoch")
+	}
+	committees := committeesResponse.Data
+	indices := make([]phase0.ValidatorIndex, 0)
+	participations := make(map[phase0.ValidatorIndex]*nonParticipatingValidator)
+	for _, committee := range committees {
+		for _, index := range committee.Validators {
+			indices = append(indices, index)
+			participations[index] = &nonParticipatingValidator{
+				Validator: index,
+				Slot:      committee.Slot,
+				Committee: committee.Index,
+			}
+		}
+	}
+	if len(indices) == 0 {
+		return errors.New("no active validators for epoch")
+	}
+	c.summary.ActiveValidators = len(indices)
+
+	sample := util.SampleValidators(indices, c.sample, c.seed)
+	c.sampled = make(map[phase0.ValidatorIndex]struct{}, len(sample))
+	for _, index := range sample {
+		c.sampled[index] = struct{}{}
+	}
+	c.inclusionDistances = make(map[phase0.ValidatorIndex]phase0.Slot, len(sample))
+
+	if err := c.estimateActiveBalance(ctx, sample); err != nil {
+		return err
+	}
+
+	// Votes can be included anywhere from the second slot of the epoch to
+	// the first slot of the next-but-one epoch.
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch) + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch + 2)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	participating, headCorrect, headTimely, sourceTimely, targetCorrect, targetTimely, votes, _, err := c.processSlots(ctx, firstSlot, lastSlot)
+	if err != nil {
+		return err
+	}
+
+	c.summary.NonParticipatingValidators = make([]*nonParticipatingValidator, 0, len(sample)-len(votes))
+	for _, index := range sample {
+		if _, exists := votes[index]; !exists {
+			c.summary.NonParticipatingValidators = append(c.summary.NonParticipatingValidators, participations[index])
+		}
+	}
+	sort.Slice(c.summary.NonParticipatingValidators, func(i int, j int) bool {
+		if c.summary.NonParticipatingValidators[i].Slot != c.summary.NonParticipatingValidators[j].Slot {
+			return c.summary.NonParticipatingValidators[i].Slot < c.summary.NonParticipatingValidators[j].Slot
+		}
+		if c.summary.NonParticipatingValidators[i].Committee != c.summary.NonParticipatingValidators[j].Committee {
+			return c.summary.NonParticipatingValidators[i].Committee < c.summary.NonParticipatingValidators[j].Committee
+		}
+		return c.summary.NonParticipatingValidators[i].Validator < c.summary.NonParticipatingValidators[j].Validator
+	})
+
+	distances := make([]float64, 0, len(c.inclusionDistances))
+	for _, distance := range c.inclusionDistances {
+		distances = append(distances, float64(distance))
+	}
+	c.summary.Sample = &sampleSummary{
+		Size:              len(sample),
+		Seed:              c.seed,
+		Participation:     util.ProportionEstimate(participating, len(sample)),
+		SourceTimely:      util.ProportionEstimate(sourceTimely, len(sample)),
+		TargetCorrect:     util.ProportionEstimate(targetCorrect, len(sample)),
+		TargetTimely:      util.ProportionEstimate(targetTimely, len(sample)),
+		HeadCorrect:       util.ProportionEstimate(headCorrect, len(sample)),
+		HeadTimely:        util.ProportionEstimate(headTimely, len(sample)),
+		InclusionDistance: util.MeanEstimate(distances),
+	}
+
+	// Scale the sampled counts up to estimates for all active validators.
+	c.summary.ParticipatingValidators = c.scaleSampleCount(participating)
+	c.summary.SourceTimelyValidators = c.scaleSampleCount(sourceTimely)
+	c.summary.TargetCorrectValidators = c.scaleSampleCount(targetCorrect)
+	c.summary.TargetTimelyValidators = c.scaleSampleCount(targetTimely)
+	c.summary.HeadCorrectValidators = c.scaleSampleCount(headCorrect)
+	c.summary.HeadTimelyValidators = c.scaleSampleCount(headTimely)
+
+	return nil
+}
+
+// estimateActiveBalance estimates the active balance of all validators from
+// the balances of the sampled validators.
+func (c *command) estimateActiveBalance(ctx context.Context, sample []phase0.ValidatorIndex) error {
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", c.summary.FirstSlot), Indices: sample})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sampled validators")
+	}
+	validators := validatorsResponse.Data
+	if len(validators) == 0 {
+		return nil
+	}
+	total := phase0.Gwei(0)
+	for _, validator := range validators {
+		total += validator.Balance
+	}
+	c.summary.ActiveBalance = phase0.Gwei(float64(total) * float64(c.summary.ActiveValidators) / float64(len(validators)))
+
+	return nil
+}
+
+// committeeSampled returns true if the committee contains a sampled validator.
+func (c *command) committeeSampled(committee []phase0.ValidatorIndex) bool {
+	for _, index := range committee {
+		if _, exists := c.sampled[index]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scaleSampleCount scales a count of sampled validators to an estimate for
+// all active validators.
+func (c *command) scaleSampleCount(count int) int {
+	return int(math.Round(float64(count) * float64(c.summary.ActiveValidators) / float64(len(c.sampled))))
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package epochsummary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// resolveValidators resolves the validators for which to provide individual
+// performance.
+func (c *command) resolveValidators(ctx context.Context, epoch phase0.Epoch) error {
+	specifiers, err := util.ExpandWallets(ctx, c.validators)
+	if err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, specifiers, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	seen := make(map[phase0.ValidatorIndex]struct{}, len(validators))
+	c.validatorIndices = make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		if _, exists := seen[validator.Index]; exists {
+			continue
+		}
+		seen[validator.Index] = struct{}{}
+		c.validatorIndices = append(c.validatorIndices, validator.Index)
+	}
+	sort.Slice(c.validatorIndices, func(i int, j int) bool {
+		return c.validatorIndices[i] < c.validatorIndices[j]
+	})
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	epochsummary "github.com/wealdtech/ethdo/cmd/epoch/summary"
+	"github.com/wealdtech/ethdo/util/output"
 )
 
 var epochSummaryCmd = &cobra.Command{
@@ -28,7 +29,14 @@ var epochSummaryCmd = &cobra.Command{
 
     ethdo epoch summary --epoch=12345
 
+With --validators the summary also shows the attestation, proposal and sync committee performance of each of the given validators in the epoch.  Validators can be supplied as indices, public keys, accounts or wallets, in which case all accounts in the wallet are used.
+
+With --sample=N the attestation performance of the active validators is estimated from a random sample of N of them, along with 95% confidence intervals.  This is much faster than examining all validators on large networks.  The sample is selected using --seed, so the same seed always selects the same validators.
+
+With --compare=previous the summary also shows the changes from the previous epoch in participation, active balance, slashings and the activation and exit queues.
+
 In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,csv,yaml"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := epochsummary.Run(cmd)
 		if err != nil {
@@ -47,8 +55,24 @@ In quiet mode this will return 0 if information for the epoch is found, otherwis
 func init() {
 	epochCmd.AddCommand(epochSummaryCmd)
 	epochFlags(epochSummaryCmd)
+	epochSummaryCmd.Flags().String("compare", "", "compare the epoch with another epoch (supported value: 'previous')")
+	epochSummaryCmd.Flags().StringSlice("validators", nil, "the validators for which to provide individual performance")
+	epochSummaryCmd.Flags().Int("sample", 0, "estimate attestation performance from a random sample of this many validators")
+	epochSummaryCmd.Flags().String("seed", "", "the seed used to select the sample of validators")
 }
 
 func epochSummaryBindings(cmd *cobra.Command) {
 	epochBindings(cmd)
+	if err := viper.BindPFlag("compare", cmd.Flags().Lookup("compare")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("sample", cmd.Flags().Lookup("sample")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("seed", cmd.Flags().Lookup("seed")); err != nil {
+		panic(err)
+	}
 }
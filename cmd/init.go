@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	ethdoinit "github.com/wealdtech/ethdo/cmd/init"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up ethdo for first use",
+	Long: `Set up ethdo for first use.  For example:
+
+    ethdo init
+
+This tests the connection to the beacon node, detects the network to which it is connected, and writes a profile with the connection details to the configuration file (default is $HOME/.ethdo.yaml).  It can optionally create a first wallet, and finishes with a self-check of the written configuration.
+
+When run from a terminal this will ask for each item in turn, offering the values supplied on the command line as defaults.  Otherwise it uses the values supplied on the command line without asking, for example:
+
+    ethdo init --connection=http://localhost:5052 --profile-name=mainnet --wallet=Primary
+
+In quiet mode this will return 0 if the configuration was written and passed its self-check, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		res, err := ethdoinit.Run(cmd, configFile)
+		if res != "" {
+			fmt.Println(res)
+		}
+		return err
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(initCmd)
+	initCmd.Flags().String("profile-name", "", "Name of the profile to write (defaults to the name of the network)")
+	initCmd.Flags().String("wallet", "", "Name of a non-deterministic wallet to create")
+	initCmd.Flags().Bool("overwrite", false, "Replace an existing profile of the same name without asking")
+}
+
+func initBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("profile-name", cmd.Flags().Lookup("profile-name")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("wallet", cmd.Flags().Lookup("wallet")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("overwrite", cmd.Flags().Lookup("overwrite")); err != nil {
+		panic(err)
+	}
+}
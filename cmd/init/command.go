@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	configFile               string
+	connection               string
+	timeout                  time.Duration
+	allowInsecureConnections bool
+	baseDir                  string
+	profileName              string
+	walletName               string
+	overwrite                bool
+	interactive              bool
+	in                       *bufio.Reader
+	out                      io.Writer
+
+	// Output.
+	nodeVersion    string
+	network        string
+	profileWritten bool
+	defaultProfile bool
+	walletCreated  bool
+	walletExisted  bool
+	checks         []*check
+}
+
+// check is the result of a single self-check.
+type check struct {
+	name    string
+	passed  bool
+	warning bool
+	detail  string
+}
+
+func newCommand(_ context.Context, configFile string) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		configFile:               configFile,
+		connection:               viper.GetString("connection"),
+		timeout:                  viper.GetDuration("timeout"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		baseDir:                  viper.GetString("base-dir"),
+		profileName:              viper.GetString("profile-name"),
+		walletName:               viper.GetString("wallet"),
+		overwrite:                viper.GetBool("overwrite"),
+		in:                       bufio.NewReader(os.Stdin),
+		out:                      os.Stderr,
+		checks:                   make([]*check, 0),
+	}
+
+	// Only ask questions if there is someone to answer them.
+	c.interactive = !c.quiet && term.IsTerminal(int(os.Stdin.Fd()))
+
+	return c, nil
+}
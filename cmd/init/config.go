@@ -0,0 +1,44 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"github.com/wealdtech/ethdo/util"
+)
+
+// writeProfile writes the named profile to the configuration file, replacing
+// any existing profile of the same name and retaining all other settings.
+func writeProfile(path string,
+	name string,
+	settings map[string]any,
+	makeDefault bool,
+) error {
+	current, err := util.ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	config := current.AllSettings()
+	profiles, isMap := config["profiles"].(map[string]any)
+	if !isMap {
+		profiles = make(map[string]any)
+	}
+	profiles[name] = settings
+	config["profiles"] = profiles
+	if makeDefault {
+		config["profile"] = name
+	}
+
+	return util.WriteConfigFile(path, config)
+}
@@ -0,0 +1,130 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestWriteProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    string
+		profile     string
+		settings    map[string]any
+		makeDefault bool
+		expected    map[string]string
+		absent      []string
+	}{
+		{
+			name:    "New",
+			profile: "mainnet",
+			settings: map[string]any{
+				"connection": "http://localhost:5052",
+			},
+			makeDefault: true,
+			expected: map[string]string{
+				"profile":                     "mainnet",
+				"profiles.mainnet.connection": "http://localhost:5052",
+			},
+		},
+		{
+			name: "Existing",
+			existing: `timeout: 10s
+profile: mainnet
+profiles:
+  mainnet:
+    connection: http://localhost:5052
+`,
+			profile: "holesky",
+			settings: map[string]any{
+				"connection":                 "http://holesky:5052",
+				"allow-insecure-connections": true,
+			},
+			expected: map[string]string{
+				"timeout":                     "10s",
+				"profile":                     "mainnet",
+				"profiles.mainnet.connection": "http://localhost:5052",
+				"profiles.holesky.connection": "http://holesky:5052",
+				"profiles.holesky.allow-insecure-connections": "true",
+			},
+		},
+		{
+			name: "Replace",
+			existing: `profile: mainnet
+profiles:
+  mainnet:
+    connection: http://localhost:5052
+    base-dir: /tmp/wallets
+`,
+			profile: "mainnet",
+			settings: map[string]any{
+				"connection": "http://remote:5052",
+			},
+			expected: map[string]string{
+				"profile":                     "mainnet",
+				"profiles.mainnet.connection": "http://remote:5052",
+			},
+			absent: []string{
+				"profiles.mainnet.base-dir",
+			},
+		},
+		{
+			name: "NewDefault",
+			existing: `profile: mainnet
+profiles:
+  mainnet:
+    connection: http://localhost:5052
+`,
+			profile: "holesky",
+			settings: map[string]any{
+				"connection": "http://holesky:5052",
+			},
+			makeDefault: true,
+			expected: map[string]string{
+				"profile":                     "holesky",
+				"profiles.mainnet.connection": "http://localhost:5052",
+				"profiles.holesky.connection": "http://holesky:5052",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base, err := os.MkdirTemp("", "")
+			require.NoError(t, err)
+			defer os.RemoveAll(base)
+			path := filepath.Join(base, ".ethdo.yaml")
+			if test.existing != "" {
+				require.NoError(t, os.WriteFile(path, []byte(test.existing), 0o600))
+			}
+
+			require.NoError(t, writeProfile(path, test.profile, test.settings, test.makeDefault))
+
+			config, err := util.ReadConfigFile(path)
+			require.NoError(t, err)
+			for k, v := range test.expected {
+				require.Equal(t, v, config.GetString(k), k)
+			}
+			for _, k := range test.absent {
+				require.False(t, config.IsSet(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Beacon node: %s\n", c.connection))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Beacon node version: %s\n", c.nodeVersion))
+	}
+	builder.WriteString(fmt.Sprintf("Network: %s\n", c.network))
+	if c.profileWritten {
+		if c.defaultProfile {
+			builder.WriteString(fmt.Sprintf("Profile %s written to %s as the default profile\n", c.profileName, c.configFile))
+		} else {
+			builder.WriteString(fmt.Sprintf("Profile %s written to %s\n", c.profileName, c.configFile))
+		}
+	}
+	switch {
+	case c.walletCreated:
+		builder.WriteString(fmt.Sprintf("Wallet %s created\n", c.walletName))
+	case c.walletExisted:
+		builder.WriteString(fmt.Sprintf("Wallet %s already exists\n", c.walletName))
+	}
+
+	builder.WriteString("Self-check:\n")
+	for _, check := range c.checks {
+		switch {
+		case check.passed:
+			builder.WriteString(fmt.Sprintf("  %s: ok\n", check.name))
+		case check.warning:
+			builder.WriteString(fmt.Sprintf("  %s: warning (%s)\n", check.name, check.detail))
+		default:
+			builder.WriteString(fmt.Sprintf("  %s: failed (%s)\n", check.name, check.detail))
+		}
+	}
+
+	if c.passed() && !c.defaultProfile {
+		builder.WriteString(fmt.Sprintf("Select this profile with --profile=%s\n", c.profileName))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
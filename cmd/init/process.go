@@ -0,0 +1,268 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if c.configFile == "" {
+		return errors.New("no configuration file specified")
+	}
+
+	if err := c.setupConnection(ctx); err != nil {
+		return err
+	}
+
+	if err := c.setupProfile(ctx); err != nil {
+		return err
+	}
+
+	if err := c.setupWallet(ctx); err != nil {
+		return err
+	}
+
+	c.selfCheck(ctx)
+
+	return nil
+}
+
+// setupConnection tests the connection to the beacon node and detects its network.
+func (c *command) setupConnection(ctx context.Context) error {
+	connection, err := c.prompt("Beacon node address (leave blank to search for a local beacon node)", c.connection)
+	if err != nil {
+		return err
+	}
+	c.connection = connection
+
+	client, err := c.connect(ctx, c.connection)
+	if err != nil {
+		return err
+	}
+	if c.connection == "" {
+		// Record the address of the beacon node that was found.
+		c.connection = client.Address()
+	}
+	c.network, err = obtainNetwork(ctx, client)
+	if err != nil {
+		return err
+	}
+	nodeVersionResponse, err := client.(eth2client.NodeVersionProvider).NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node version")
+	}
+	c.nodeVersion = nodeVersionResponse.Data
+	if c.interactive {
+		fmt.Fprintf(c.out, "Connected to %s beacon node at %s\n", c.network, c.connection)
+	}
+
+	return nil
+}
+
+// connect connects to the beacon node.
+func (c *command) connect(ctx context.Context, connection string) (eth2client.Service, error) {
+	client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// obtainNetwork returns the name of the network of the beacon node.
+func obtainNetwork(ctx context.Context, client eth2client.Service) (string, error) {
+	genesisResponse, err := client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain genesis")
+	}
+	genesis := genesisResponse.Data
+
+	return beacon.NetworkName(genesis.GenesisValidatorsRoot), nil
+}
+
+// setupProfile writes the profile to the configuration file.
+func (c *command) setupProfile(_ context.Context) error {
+	current, err := util.ReadConfigFile(c.configFile)
+	if err != nil {
+		return err
+	}
+
+	if c.profileName == "" {
+		c.profileName = c.network
+		if strings.HasPrefix(c.profileName, "0x") {
+			// Unknown network.
+			c.profileName = "default"
+		}
+	}
+	profileName, err := c.prompt("Profile name", c.profileName)
+	if err != nil {
+		return err
+	}
+	c.profileName = strings.ToLower(profileName)
+	if strings.ContainsAny(c.profileName, ". ") {
+		return fmt.Errorf("invalid profile name %s", c.profileName)
+	}
+
+	if current.IsSet(fmt.Sprintf("profiles.%s", c.profileName)) && !c.overwrite {
+		replace, err := c.confirm(fmt.Sprintf("Profile %s already exists in %s; replace it?", c.profileName, c.configFile), false)
+		if err != nil {
+			return err
+		}
+		if !replace {
+			return fmt.Errorf("profile %s already exists; use --overwrite to replace it", c.profileName)
+		}
+	}
+
+	c.defaultProfile = true
+	currentDefault := current.GetString("profile")
+	if currentDefault != "" && currentDefault != c.profileName {
+		c.defaultProfile, err = c.confirm(fmt.Sprintf("Make %s the default profile in place of %s?", c.profileName, currentDefault), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	settings := map[string]any{
+		"connection": c.connection,
+	}
+	if c.allowInsecureConnections {
+		settings["allow-insecure-connections"] = true
+	}
+	if c.baseDir != "" {
+		settings["base-dir"] = c.baseDir
+	}
+
+	if err := writeProfile(c.configFile, c.profileName, settings, c.defaultProfile); err != nil {
+		return err
+	}
+	c.profileWritten = true
+
+	return nil
+}
+
+// setupWallet creates the first wallet, if requested.
+func (c *command) setupWallet(ctx context.Context) error {
+	walletName, err := c.prompt("Name of wallet to create (leave blank to skip)", c.walletName)
+	if err != nil {
+		return err
+	}
+	c.walletName = walletName
+	if c.walletName == "" {
+		return nil
+	}
+
+	if _, err := e2wallet.OpenWallet(c.walletName); err == nil {
+		c.walletExisted = true
+		return nil
+	}
+
+	store, isStore := viper.Get("store").(e2wtypes.Store)
+	if !isStore {
+		return errors.New("wallet store is not available")
+	}
+	if _, err := nd.CreateWallet(ctx, c.walletName, store, keystorev4.New()); err != nil {
+		return errors.Wrap(err, "failed to create wallet")
+	}
+	c.walletCreated = true
+
+	return nil
+}
+
+// selfCheck confirms that the written configuration works.
+func (c *command) selfCheck(ctx context.Context) {
+	config, err := util.ReadConfigFile(c.configFile)
+	if err != nil {
+		c.checks = append(c.checks, &check{name: "configuration readable", detail: err.Error()})
+		return
+	}
+	key := fmt.Sprintf("profiles.%s.connection", c.profileName)
+	if !config.IsSet(key) {
+		c.checks = append(c.checks, &check{name: "configuration readable", detail: "profile not found"})
+		return
+	}
+	c.checks = append(c.checks, &check{name: "configuration readable", passed: true})
+
+	client, err := c.connect(ctx, config.GetString(key))
+	if err != nil {
+		c.checks = append(c.checks, &check{name: "beacon node reachable", detail: err.Error()})
+		return
+	}
+	c.checks = append(c.checks, &check{name: "beacon node reachable", passed: true})
+
+	network, err := obtainNetwork(ctx, client)
+	switch {
+	case err != nil:
+		c.checks = append(c.checks, &check{name: "network consistent", detail: err.Error()})
+	case network != c.network:
+		c.checks = append(c.checks, &check{name: "network consistent", detail: fmt.Sprintf("expected %s, found %s", c.network, network)})
+	default:
+		c.checks = append(c.checks, &check{name: "network consistent", passed: true})
+	}
+
+	c.checkSyncState(ctx, client)
+
+	if c.walletName != "" {
+		if _, err := e2wallet.OpenWallet(c.walletName); err != nil {
+			c.checks = append(c.checks, &check{name: "wallet accessible", detail: err.Error()})
+		} else {
+			c.checks = append(c.checks, &check{name: "wallet accessible", passed: true})
+		}
+	}
+}
+
+// checkSyncState checks if the beacon node is synced.  This results in a
+// warning rather than a failure, as a new node will take time to sync.
+func (c *command) checkSyncState(ctx context.Context, client eth2client.Service) {
+	syncStateResponse, err := client.(eth2client.NodeSyncingProvider).NodeSyncing(ctx, &api.NodeSyncingOpts{})
+	if err != nil {
+		c.checks = append(c.checks, &check{name: "beacon node synced", warning: true, detail: err.Error()})
+		return
+	}
+	syncState := syncStateResponse.Data
+	if syncState.SyncDistance != 0 {
+		c.checks = append(c.checks, &check{name: "beacon node synced", warning: true, detail: fmt.Sprintf("%d slots behind", syncState.SyncDistance)})
+		return
+	}
+	c.checks = append(c.checks, &check{name: "beacon node synced", passed: true})
+}
+
+// passed returns true if all self-checks passed.
+func (c *command) passed() bool {
+	for _, check := range c.checks {
+		if !check.passed && !check.warning {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// prompt asks the user a question, returning the supplied default if the
+// command is not interactive or the user does not provide an answer.
+func (c *command) prompt(question string, def string) (string, error) {
+	if !c.interactive {
+		return def, nil
+	}
+
+	if def == "" {
+		fmt.Fprintf(c.out, "%s: ", question)
+	} else {
+		fmt.Fprintf(c.out, "%s [%s]: ", question, def)
+	}
+	answer, err := c.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", errors.Wrap(err, "failed to read answer")
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// confirm asks the user a yes/no question, returning the supplied default if
+// the command is not interactive or the user does not provide an answer.
+func (c *command) confirm(question string, def bool) (bool, error) {
+	if !c.interactive {
+		return def, nil
+	}
+
+	options := "y/N"
+	if def {
+		options = "Y/n"
+	}
+	for {
+		answer, err := c.prompt(fmt.Sprintf("%s (%s)", question, options), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		if _, err := c.in.Peek(1); err != nil {
+			// No further input, so no point asking again.
+			return def, nil
+		}
+		fmt.Fprintf(c.out, "Please answer yes or no\n")
+	}
+}
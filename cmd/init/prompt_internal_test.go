@@ -0,0 +1,141 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethdoinit
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompt(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		input       string
+		def         string
+		expected    string
+	}{
+		{
+			name:     "NotInteractive",
+			input:    "answer\n",
+			def:      "default",
+			expected: "default",
+		},
+		{
+			name:        "Answer",
+			interactive: true,
+			input:       "answer\n",
+			def:         "default",
+			expected:    "answer",
+		},
+		{
+			name:        "Default",
+			interactive: true,
+			input:       "\n",
+			def:         "default",
+			expected:    "default",
+		},
+		{
+			name:        "EOF",
+			interactive: true,
+			def:         "default",
+			expected:    "default",
+		},
+		{
+			name:        "NoNewline",
+			interactive: true,
+			input:       "  answer ",
+			expected:    "answer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				interactive: test.interactive,
+				in:          bufio.NewReader(strings.NewReader(test.input)),
+				out:         &bytes.Buffer{},
+			}
+			answer, err := c.prompt("Question", test.def)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, answer)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		input       string
+		def         bool
+		expected    bool
+	}{
+		{
+			name:     "NotInteractive",
+			input:    "y\n",
+			expected: false,
+		},
+		{
+			name:        "Yes",
+			interactive: true,
+			input:       "yes\n",
+			expected:    true,
+		},
+		{
+			name:        "No",
+			interactive: true,
+			input:       "N\n",
+			def:         true,
+			expected:    false,
+		},
+		{
+			name:        "Default",
+			interactive: true,
+			input:       "\n",
+			def:         true,
+			expected:    true,
+		},
+		{
+			name:        "Retry",
+			interactive: true,
+			input:       "maybe\ny\n",
+			expected:    true,
+		},
+		{
+			name:        "InvalidEOF",
+			interactive: true,
+			input:       "maybe\n",
+			def:         true,
+			expected:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				interactive: test.interactive,
+				in:          bufio.NewReader(strings.NewReader(test.input)),
+				out:         &bytes.Buffer{},
+			}
+			answer, err := c.confirm("Question", test.def)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, answer)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// keymanagerCmd represents the keymanager command.
+var keymanagerCmd = &cobra.Command{
+	Use:   "keymanager",
+	Short: "Manage keys held by a validator client",
+	Long:  "Manage the keys held by a validator client or remote signer such as Web3Signer, through its standard keymanager API.  The API is found with --keymanager-connection.",
+}
+
+func init() {
+	RootCmd.AddCommand(keymanagerCmd)
+}
@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerdelete
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/keymanager"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Keymanager connection.
+	timeout              time.Duration
+	keymanagerConnection string
+	keymanagerToken      string
+
+	// Input.
+	keys               []*key
+	slashingProtection string
+
+	// Data access.
+	keymanager keymanager.Service
+
+	// Output.
+	results *results
+}
+
+// key is a key to delete, with the name of its account if known.
+type key struct {
+	account string
+	pubKey  []byte
+}
+
+type results struct {
+	Deletions []*deleteResult `json:"deletions"`
+}
+
+type deleteResult struct {
+	Account string `json:"account,omitempty"`
+	PubKey  string `json:"pubkey"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.keymanagerConnection = viper.GetString("keymanager-connection")
+	if c.keymanagerConnection == "" {
+		return nil, errors.New("keymanager-connection is required")
+	}
+	c.keymanagerToken = viper.GetString("keymanager-token")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	c.slashingProtection = viper.GetString("slashing-protection")
+
+	// Keys.
+	if viper.GetString("account") == "" && len(viper.GetStringSlice("pubkeys")) == 0 {
+		return nil, errors.New("account or pubkeys is required")
+	}
+	if viper.GetString("account") != "" {
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		wallet, accounts, err := util.WalletAndAccountsFromPath(ctx, viper.GetString("account"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain accounts")
+		}
+		if len(accounts) == 0 {
+			return nil, errors.New("no matching accounts")
+		}
+		for _, account := range accounts {
+			pubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for %s/%s", wallet.Name(), account.Name()))
+			}
+			c.keys = append(c.keys, &key{
+				account: fmt.Sprintf("%s/%s", wallet.Name(), account.Name()),
+				pubKey:  pubKey.Marshal(),
+			})
+		}
+	}
+	for _, input := range viper.GetStringSlice("pubkeys") {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil || len(pubKey) != 48 {
+			return nil, fmt.Errorf("invalid public key %q", input)
+		}
+		c.keys = append(c.keys, &key{
+			pubKey: pubKey,
+		})
+	}
+
+	return c, nil
+}
@@ -0,0 +1,141 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerdelete
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Interop 0", "Interop 1"} {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(), name, key.Marshal(), []byte("pass"))
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		keys     int
+		accounts int
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"keymanager-connection": "http://localhost:7500",
+				"account":               "Test wallet/Interop.*",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Interop.*",
+			},
+			err: "keymanager-connection is required",
+		},
+		{
+			name: "KeysMissing",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager-connection": "http://localhost:7500",
+			},
+			err: "account or pubkeys is required",
+		},
+		{
+			name: "AccountsNoMatch",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager-connection": "http://localhost:7500",
+				"account":               "Test wallet/Other.*",
+			},
+			err: "no matching accounts",
+		},
+		{
+			name: "PubKeyInvalid",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager-connection": "http://localhost:7500",
+				"pubkeys":               []string{"0x0102"},
+			},
+			err: `invalid public key "0x0102"`,
+		},
+		{
+			name: "Accounts",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager-connection": "http://localhost:7500",
+				"account":               "Test wallet/Interop.*",
+			},
+			keys:     2,
+			accounts: 2,
+		},
+		{
+			name: "AccountsAndPubKeys",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"keymanager-connection": "http://localhost:7500",
+				"account":               "Test wallet/Interop 0",
+				"pubkeys":               []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			},
+			keys:     2,
+			accounts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.keys, test.keys)
+				accounts := 0
+				for _, key := range res.keys {
+					require.Len(t, key.pubKey, 48)
+					if key.account != "" {
+						accounts++
+					}
+				}
+				require.Equal(t, test.accounts, accounts)
+			}
+		})
+	}
+}
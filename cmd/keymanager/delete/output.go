@@ -0,0 +1,57 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerdelete
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the deletions as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the deletions as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	for _, result := range c.results.Deletions {
+		switch {
+		case result.Account == "":
+			builder.WriteString(fmt.Sprintf("%s: %s", result.PubKey, result.Status))
+		case c.verbose:
+			builder.WriteString(fmt.Sprintf("%s (%s): %s", result.Account, result.PubKey, result.Status))
+		default:
+			builder.WriteString(fmt.Sprintf("%s: %s", result.Account, result.Status))
+		}
+		if result.Message != "" {
+			builder.WriteString(fmt.Sprintf(": %s", result.Message))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerdelete
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// The slashing protection data is only returned when keys are deleted, so
+	// ensure that it can be written before deleting them.
+	if c.slashingProtection != "" {
+		if _, err := os.Stat(c.slashingProtection); err == nil {
+			return fmt.Errorf("slashing protection file %s already exists", c.slashingProtection)
+		}
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	pubKeys := make([][]byte, 0, len(c.keys))
+	for _, key := range c.keys {
+		pubKeys = append(pubKeys, key.pubKey)
+	}
+
+	deleteResults, slashingProtection, err := c.keymanager.DeleteKeystores(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete keystores")
+	}
+
+	c.results = &results{
+		Deletions: make([]*deleteResult, 0, len(c.keys)),
+	}
+	for i, key := range c.keys {
+		c.results.Deletions = append(c.results.Deletions, &deleteResult{
+			Account: key.account,
+			PubKey:  fmt.Sprintf("%#x", key.pubKey),
+			Status:  deleteResults[i].Status,
+			Message: deleteResults[i].Message,
+		})
+	}
+
+	// Slashing protection data is written even if some deletions failed.
+	if c.slashingProtection != "" && slashingProtection != "" {
+		if err := writeSlashingProtection(c.slashingProtection, slashingProtection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSlashingProtection writes slashing protection data to a file, which
+// must not already exist.
+func writeSlashingProtection(file string, data string) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create slashing protection file")
+	}
+	if _, err := f.WriteString(data); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "failed to write slashing protection file")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write slashing protection file")
+	}
+
+	return nil
+}
+
+// failed returns true if any deletion failed.
+func (c *command) failed() bool {
+	for _, result := range c.results.Deletions {
+		if result.Status == "error" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.keymanager != nil {
+		// Already set up.
+		return nil
+	}
+
+	var err error
+	c.keymanager, err = util.ConnectToKeymanager(ctx, &util.KeymanagerConnectOpts{
+		Address: c.keymanagerConnection,
+		Token:   c.keymanagerToken,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to keymanager")
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerdelete
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func TestProcess(t *testing.T) {
+	pubKey0 := hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	pubKey1 := hexToBytes("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+
+	// The keymanager holds only the first key, and cannot delete the key
+	// 0x8000...
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		req := struct {
+			PubKeys []string `json:"pubkeys"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		data := make([]map[string]string, 0, len(req.PubKeys))
+		for _, pubKey := range req.PubKeys {
+			switch {
+			case strings.HasPrefix(pubKey, "0xa99a"):
+				data = append(data, map[string]string{"status": "deleted"})
+			case strings.HasPrefix(pubKey, "0x8000"):
+				data = append(data, map[string]string{"status": "error", "message": "key is read-only"})
+			default:
+				data = append(data, map[string]string{"status": "not_found"})
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data, "slashing_protection": `{"metadata":{},"data":[]}`}))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.json")
+	require.NoError(t, os.WriteFile(existing, []byte("{}"), 0o600))
+
+	tests := []struct {
+		name               string
+		c                  *command
+		err                string
+		text               string
+		failed             bool
+		slashingProtection string
+	}{
+		{
+			name: "SlashingProtectionExists",
+			c: &command{
+				keys:               []*key{{pubKey: pubKey0}},
+				slashingProtection: existing,
+			},
+			err: "slashing protection file " + existing + " already exists",
+		},
+		{
+			name: "Good",
+			c: &command{
+				keys: []*key{
+					{account: "Test/Interop 0", pubKey: pubKey0},
+					{pubKey: pubKey1},
+				},
+				slashingProtection: filepath.Join(dir, "slashing-protection.json"),
+			},
+			text:               "Test/Interop 0: deleted\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b: not_found",
+			slashingProtection: `{"metadata":{},"data":[]}`,
+		},
+		{
+			name: "Verbose",
+			c: &command{
+				verbose: true,
+				keys: []*key{
+					{account: "Test/Interop 0", pubKey: pubKey0},
+				},
+			},
+			text: "Test/Interop 0 (0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c): deleted",
+		},
+		{
+			name: "Failed",
+			c: &command{
+				keys: []*key{
+					{account: "Test/Interop 0", pubKey: pubKey0},
+					{account: "Test/Interop 2", pubKey: append([]byte{0x80}, make([]byte, 47)...)},
+				},
+			},
+			text:   "Test/Interop 0: deleted\nTest/Interop 2: error: key is read-only",
+			failed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.timeout = 5 * time.Second
+			test.c.keymanagerConnection = server.URL
+			err := test.c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.failed, test.c.failed())
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.text, res)
+
+			if test.slashingProtection != "" {
+				data, err := os.ReadFile(test.c.slashingProtection)
+				require.NoError(t, err)
+				require.Equal(t, test.slashingProtection, string(data))
+			}
+		})
+	}
+}
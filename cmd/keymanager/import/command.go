@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/keymanager"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Keymanager connection.
+	timeout              time.Duration
+	keymanagerConnection string
+	keymanagerToken      string
+
+	// Input.
+	walletName         string
+	accounts           []e2wtypes.Account
+	passphrases        []string
+	approvals          []string
+	keystorePassphrase string
+	encryptor          *keystorev4.Encryptor
+	slashingProtection string
+
+	// Data access.
+	keymanager keymanager.Service
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Imports []*importResult `json:"imports"`
+}
+
+type importResult struct {
+	Account string `json:"account"`
+	PubKey  string `json:"pubkey"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.keymanagerConnection = viper.GetString("keymanager-connection")
+	if c.keymanagerConnection == "" {
+		return nil, errors.New("keymanager-connection is required")
+	}
+	c.keymanagerToken = viper.GetString("keymanager-token")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	// Key derivation function.
+	switch viper.GetString("kdf") {
+	case "scrypt", "pbkdf2":
+		c.encryptor = keystorev4.New(keystorev4.WithCipher(viper.GetString("kdf")))
+	default:
+		return nil, fmt.Errorf("unsupported key derivation function %q", viper.GetString("kdf"))
+	}
+
+	// Keystore passphrase, generated if not supplied as the validator client
+	// stores it alongside the keystore.
+	c.keystorePassphrase = viper.GetString("keystore-passphrase")
+	if c.keystorePassphrase != "" && !util.AcceptablePassphrase(c.keystorePassphrase) {
+		return nil, errors.New("supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	// Slashing protection.
+	if viper.GetString("slashing-protection") != "" {
+		data, err := os.ReadFile(viper.GetString("slashing-protection"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read slashing protection data")
+		}
+		if !json.Valid(data) {
+			return nil, errors.New("slashing protection data is not valid JSON")
+		}
+		c.slashingProtection = string(data)
+	}
+
+	// Accounts.
+	if viper.GetString("account") == "" {
+		return nil, errors.New("account is required")
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	wallet, accounts, err := util.WalletAndAccountsFromPath(ctx, viper.GetString("account"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts")
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("no matching accounts")
+	}
+	c.walletName = wallet.Name()
+	c.accounts = accounts
+
+	// Passphrases.
+	c.passphrases = util.GetPassphrases()
+
+	// Approvals.
+	c.approvals = viper.GetStringSlice("approvals")
+
+	return c, nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerimport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	testWallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Interop 0", "Interop 1"} {
+		key, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		_, err = testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(), name, key.Marshal(), []byte("pass"))
+		require.NoError(t, err)
+	}
+
+	dir := t.TempDir()
+	slashingProtection := filepath.Join(dir, "slashing-protection.json")
+	require.NoError(t, os.WriteFile(slashingProtection, []byte(`{"metadata":{"interchange_format_version":"5"},"data":[]}`), 0o600))
+	invalidSlashingProtection := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidSlashingProtection, []byte(`{"metadata":`), 0o600))
+
+	good := map[string]interface{}{
+		"timeout":               "5s",
+		"keymanager-connection": "http://localhost:7500",
+		"account":               "Test wallet/Interop.*",
+		"passphrase":            "pass",
+		"kdf":                   "scrypt",
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		vars := make(map[string]interface{}, len(good))
+		for k, v := range good {
+			vars[k] = v
+		}
+		if value == nil {
+			delete(vars, key)
+		} else {
+			vars[key] = value
+		}
+		return vars
+	}
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		accounts int
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: with("timeout", nil),
+			err:  "timeout is required",
+		},
+		{
+			name: "ConnectionMissing",
+			vars: with("keymanager-connection", nil),
+			err:  "keymanager-connection is required",
+		},
+		{
+			name: "KDFInvalid",
+			vars: with("kdf", "argon2"),
+			err:  `unsupported key derivation function "argon2"`,
+		},
+		{
+			name: "KeystorePassphraseWeak",
+			vars: with("keystore-passphrase", "pass"),
+			err:  "supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "SlashingProtectionMissing",
+			vars: with("slashing-protection", filepath.Join(dir, "missing.json")),
+			err:  "failed to read slashing protection data: open " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "SlashingProtectionInvalid",
+			vars: with("slashing-protection", invalidSlashingProtection),
+			err:  "slashing protection data is not valid JSON",
+		},
+		{
+			name: "AccountMissing",
+			vars: with("account", nil),
+			err:  "account is required",
+		},
+		{
+			name: "AccountsNoMatch",
+			vars: with("account", "Test wallet/Other.*"),
+			err:  "no matching accounts",
+		},
+		{
+			name:     "Good",
+			vars:     with("slashing-protection", slashingProtection),
+			accounts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res.accounts, test.accounts)
+				require.Equal(t, "Test wallet", res.walletName)
+				require.Contains(t, res.slashingProtection, "interchange_format_version")
+			}
+		})
+	}
+}
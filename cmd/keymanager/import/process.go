@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerimport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if len(c.passphrases) == 0 {
+		return errors.New("passphrase is required")
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	keystorePassphrase := c.keystorePassphrase
+	if keystorePassphrase == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return errors.Wrap(err, "failed to generate keystore passphrase")
+		}
+		keystorePassphrase = hex.EncodeToString(secret)
+	}
+
+	c.results = &results{
+		Imports: make([]*importResult, 0, len(c.accounts)),
+	}
+	keystores := make([]string, 0, len(c.accounts))
+	passwords := make([]string, 0, len(c.accounts))
+	for _, account := range c.accounts {
+		accountName := fmt.Sprintf("%s/%s", c.walletName, account.Name())
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for %s", accountName))
+		}
+		if err := util.CheckCompositeApprovals(ctx, util.CompositeOperationKey, accountName, pubKey.Marshal(), c.approvals); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to import %s", accountName))
+		}
+
+		keystore, err := util.NewAccountKeystore(ctx, account, accountName, c.passphrases, c.encryptor, keystorePassphrase)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to import %s", accountName))
+		}
+		data, err := json.Marshal(keystore)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal keystore JSON")
+		}
+		keystores = append(keystores, string(data))
+		passwords = append(passwords, keystorePassphrase)
+		c.results.Imports = append(c.results.Imports, &importResult{
+			Account: accountName,
+			PubKey:  fmt.Sprintf("%#x", pubKey.Marshal()),
+		})
+	}
+
+	importResults, err := c.keymanager.ImportKeystores(ctx, keystores, passwords, c.slashingProtection)
+	if err != nil {
+		return errors.Wrap(err, "failed to import keystores")
+	}
+	for i := range importResults {
+		c.results.Imports[i].Status = importResults[i].Status
+		c.results.Imports[i].Message = importResults[i].Message
+	}
+
+	return nil
+}
+
+// failed returns true if any import failed.
+func (c *command) failed() bool {
+	for _, result := range c.results.Imports {
+		if result.Status == "error" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.keymanager != nil {
+		// Already set up.
+		return nil
+	}
+
+	var err error
+	c.keymanager, err = util.ConnectToKeymanager(ctx, &util.KeymanagerConnectOpts{
+		Address: c.keymanagerConnection,
+		Token:   c.keymanagerToken,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to keymanager")
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerimport
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+type importRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testNDWallet, err := nd.CreateWallet(context.Background(),
+		"Test",
+		scratch.New(),
+		keystorev4.New(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, testNDWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	interop1, err := testNDWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 1",
+		hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	// The keymanager reports the second keystore of a request as a duplicate,
+	// and fails any keystore supplied with the password "fail".
+	var received *importRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = &importRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		data := make([]map[string]string, 0, len(received.Keystores))
+		for i := range received.Keystores {
+			switch {
+			case received.Passwords[i] == "fail":
+				data = append(data, map[string]string{"status": "error", "message": "failed to decrypt"})
+			case i == 1:
+				data = append(data, map[string]string{"status": "duplicate"})
+			default:
+				data = append(data, map[string]string{"status": "imported"})
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name               string
+		c                  *command
+		err                string
+		text               string
+		failed             bool
+		keystorePassphrase string
+	}{
+		{
+			name: "PassphrasesMissing",
+			c: &command{
+				accounts: []e2wtypes.Account{interop0},
+			},
+			err: "passphrase is required",
+		},
+		{
+			name: "PassphraseIncorrect",
+			c: &command{
+				walletName:  "Test",
+				accounts:    []e2wtypes.Account{interop0},
+				passphrases: []string{"wrong"},
+			},
+			err: "failed to import Test/Interop 0: failed to unlock account",
+		},
+		{
+			name: "Good",
+			c: &command{
+				walletName:         "Test",
+				accounts:           []e2wtypes.Account{interop0, interop1},
+				passphrases:        []string{"pass"},
+				keystorePassphrase: "keystore secret",
+				slashingProtection: `{"metadata":{}}`,
+			},
+			text:               "Test/Interop 0: imported\nTest/Interop 1: duplicate",
+			keystorePassphrase: "keystore secret",
+		},
+		{
+			name: "RandomPassphrase",
+			c: &command{
+				verbose:     true,
+				walletName:  "Test",
+				accounts:    []e2wtypes.Account{interop0},
+				passphrases: []string{"pass"},
+			},
+			text: "Test/Interop 0 (0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c): imported",
+		},
+		{
+			name: "Failed",
+			c: &command{
+				walletName:         "Test",
+				accounts:           []e2wtypes.Account{interop0},
+				passphrases:        []string{"pass"},
+				keystorePassphrase: "fail",
+			},
+			text:               "Test/Interop 0: error: failed to decrypt",
+			failed:             true,
+			keystorePassphrase: "fail",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.timeout = 5 * time.Second
+			test.c.keymanagerConnection = server.URL
+			test.c.encryptor = keystorev4.New(keystorev4.WithCost(t, 10))
+			err := test.c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.failed, test.c.failed())
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.text, res)
+
+			require.Equal(t, test.c.slashingProtection, received.SlashingProtection)
+			require.Len(t, received.Keystores, len(test.c.accounts))
+			for i, account := range test.c.accounts {
+				if test.keystorePassphrase != "" {
+					require.Equal(t, test.keystorePassphrase, received.Passwords[i])
+				} else {
+					require.Len(t, received.Passwords[i], 64)
+				}
+				keystore := &util.Keystore{}
+				require.NoError(t, json.Unmarshal([]byte(received.Keystores[i]), keystore))
+				secret, err := keystorev4.New().Decrypt(keystore.Crypto, received.Passwords[i])
+				require.NoError(t, err)
+				key, err := e2types.BLSPrivateKeyFromBytes(secret)
+				require.NoError(t, err)
+				require.Equal(t, account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal(), key.PublicKey().Marshal())
+			}
+		})
+	}
+
+	// JSON output.
+	c := &command{
+		format: output.JSON,
+		results: &results{
+			Imports: []*importResult{
+				{Account: "Test/Interop 0", PubKey: "0x01", Status: "imported"},
+			},
+		},
+	}
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `{"imports":[{"account":"Test/Interop 0","pubkey":"0x01","status":"imported"}]}`, res)
+}
@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/keymanager"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Keymanager connection.
+	timeout              time.Duration
+	keymanagerConnection string
+	keymanagerToken      string
+
+	// Data access.
+	keymanager keymanager.Service
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Keystores []*keystore `json:"keystores"`
+}
+
+type keystore struct {
+	PubKey   string `json:"pubkey"`
+	Path     string `json:"derivation_path,omitempty"`
+	ReadOnly bool   `json:"readonly"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.keymanagerConnection = viper.GetString("keymanager-connection")
+	if c.keymanagerConnection == "" {
+		return nil, errors.New("keymanager-connection is required")
+	}
+	c.keymanagerToken = viper.GetString("keymanager-token")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
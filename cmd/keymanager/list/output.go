@@ -0,0 +1,79 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the keystores as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// CSVHeader returns the header of the CSV output.
+func (*command) CSVHeader() []string {
+	return []string{
+		"pubkey",
+		"derivation_path",
+		"readonly",
+	}
+}
+
+// CSVRecords returns the keystores as CSV.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	records := make([][]string, 0, len(c.results.Keystores))
+	for _, keystore := range c.results.Keystores {
+		records = append(records, []string{
+			keystore.PubKey,
+			keystore.Path,
+			fmt.Sprintf("%t", keystore.ReadOnly),
+		})
+	}
+
+	return records, nil
+}
+
+// RenderText renders the keystores as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	for _, keystore := range c.results.Keystores {
+		builder.WriteString(keystore.PubKey)
+		if keystore.Path != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", keystore.Path))
+		}
+		if keystore.ReadOnly {
+			builder.WriteString(" read-only")
+		}
+		builder.WriteString("\n")
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("%d keystores\n", len(c.results.Keystores)))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	keystores, err := c.keymanager.ListKeystores(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list keystores")
+	}
+
+	c.results = &results{
+		Keystores: make([]*keystore, 0, len(keystores)),
+	}
+	for _, ks := range keystores {
+		c.results.Keystores = append(c.results.Keystores, &keystore{
+			PubKey:   fmt.Sprintf("%#x", ks.PubKey),
+			Path:     ks.Path,
+			ReadOnly: ks.ReadOnly,
+		})
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.keymanager != nil {
+		// Already set up.
+		return nil
+	}
+
+	var err error
+	c.keymanager, err = util.ConnectToKeymanager(ctx, &util.KeymanagerConnectOpts{
+		Address: c.keymanagerConnection,
+		Token:   c.keymanagerToken,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to keymanager")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerlist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestProcess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"validating_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","derivation_path":"m/12381/3600/0/0/0","readonly":false},{"validating_pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","readonly":true}]}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		c    *command
+		err  string
+		text string
+		csv  string
+	}{
+		{
+			name: "Unauthorized",
+			c: &command{
+				timeout:              5 * time.Second,
+				keymanagerConnection: server.URL,
+			},
+			err: "failed to list keystores: keymanager refused access (status 401); check the keymanager token",
+		},
+		{
+			name: "Good",
+			c: &command{
+				timeout:              5 * time.Second,
+				keymanagerConnection: server.URL,
+				keymanagerToken:      "secret",
+			},
+			text: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c (m/12381/3600/0/0/0)\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b read-only",
+			csv:  "pubkey,derivation_path,readonly\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,m/12381/3600/0/0/0,false\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b,,true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			test.c.format = output.Text
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.text, res)
+
+			test.c.format = output.CSV
+			res, err = test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.csv, res)
+		})
+	}
+}
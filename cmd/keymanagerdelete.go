@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerdelete "github.com/wealdtech/ethdo/cmd/keymanager/delete"
+)
+
+var keymanagerDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete keys from a validator client",
+	Long: `Delete one or more keys from a running validator client through its keymanager API.  For example:
+
+    ethdo keymanager delete --account="Validators/.*" --slashing-protection=slashing-protection.json --keymanager-connection=http://localhost:7500 --keymanager-token=$(cat api-token.txt)
+
+Keys are selected with --account, where the account name can be a regular expression to select multiple accounts from a wallet, and/or with --pubkeys.  The validator client returns EIP-3076 slashing protection data for the deleted keys, which is written to the file supplied with --slashing-protection; this should be kept to import along with the keys in to another validator client.
+
+In quiet mode this will return 0 if no deletion failed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := keymanagerdelete.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerCmd.AddCommand(keymanagerDeleteCmd)
+	keymanagerDeleteCmd.Flags().StringSlice("pubkeys", nil, "Public keys of the keys to delete")
+	keymanagerDeleteCmd.Flags().String("slashing-protection", "", "File to which to write the EIP-3076 slashing protection interchange data for the deleted keys")
+}
+
+func keymanagerDeleteBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("pubkeys", cmd.Flags().Lookup("pubkeys")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("slashing-protection", cmd.Flags().Lookup("slashing-protection")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerimport "github.com/wealdtech/ethdo/cmd/keymanager/import"
+)
+
+var keymanagerImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import accounts in to a validator client",
+	Long: `Import one or more accounts in to a running validator client through its keymanager API.  For example:
+
+    ethdo keymanager import --account="Validators/.*" --passphrase="my account secret" --keymanager-connection=http://localhost:7500 --keymanager-token=$(cat api-token.txt)
+
+The account name can be a regular expression to import multiple accounts from a wallet.  The accounts are sent to the validator client as EIP-2335 keystores, encrypted with --keystore-passphrase or, if not supplied, a random passphrase; the validator client stores the passphrase with the keystore.  EIP-3076 slashing protection data for the accounts can be supplied with --slashing-protection.
+
+Distributed accounts cannot be imported, as no single participant holds their private key.
+
+In quiet mode this will return 0 if no import failed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := keymanagerimport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerCmd.AddCommand(keymanagerImportCmd)
+	keymanagerImportCmd.Flags().String("keystore-passphrase", "", "Passphrase with which to encrypt the keystores sent to the validator client (defaults to a random passphrase)")
+	keymanagerImportCmd.Flags().String("kdf", "scrypt", "Key derivation function of the keystores (scrypt, pbkdf2)")
+	keymanagerImportCmd.Flags().String("slashing-protection", "", "File containing EIP-3076 slashing protection interchange data for the accounts")
+	keymanagerImportCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the accounts are members, as JSON or files containing JSON")
+}
+
+func keymanagerImportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kdf", cmd.Flags().Lookup("kdf")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("slashing-protection", cmd.Flags().Lookup("slashing-protection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
+}
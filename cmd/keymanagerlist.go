@@ -0,0 +1,51 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerlist "github.com/wealdtech/ethdo/cmd/keymanager/list"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var keymanagerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the keys held by a validator client",
+	Long: `List the keys held by a validator client through its keymanager API.  For example:
+
+    ethdo keymanager list --keymanager-connection=http://localhost:7500 --keymanager-token=$(cat api-token.txt)
+
+In quiet mode this will return 0 if the keys can be listed, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "csv"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := keymanagerlist.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerCmd.AddCommand(keymanagerListCmd)
+}
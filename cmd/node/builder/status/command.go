@@ -0,0 +1,121 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	relays                     []string
+	maxConsecutiveMissedSlots  uint64
+	maxEpochMissedSlots        uint64
+	maxEpochsSinceFinalization uint64
+
+	// Data access.
+	eth2Client          eth2client.Service
+	chainTime           chaintime.Service
+	nodeVersionProvider eth2client.NodeVersionProvider
+	finalityProvider    eth2client.FinalityProvider
+	blocksProvider      eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Node                    string         `json:"node"`
+	Slot                    phase0.Slot    `json:"slot"`
+	CircuitBreakerActive    bool           `json:"circuit_breaker_active"`
+	Reasons                 []string       `json:"reasons,omitempty"`
+	ConsecutiveMissedSlots  uint64         `json:"consecutive_missed_slots"`
+	EpochMissedSlots        uint64         `json:"epoch_missed_slots"`
+	EpochsSinceFinalization uint64         `json:"epochs_since_finalization"`
+	Blocks                  *blockSummary  `json:"blocks"`
+	Relays                  []*relayStatus `json:"relays,omitempty"`
+}
+
+// blockSummary summarises the blocks in the slots prior to the current slot.
+type blockSummary struct {
+	Slots    uint64 `json:"slots"`
+	Proposed uint64 `json:"proposed"`
+	Missed   uint64 `json:"missed"`
+	// Builder and Local are only present if relays are supplied, as
+	// without them the source of a block cannot be determined.
+	Builder *uint64 `json:"builder,omitempty"`
+	Local   *uint64 `json:"local,omitempty"`
+}
+
+type relayStatus struct {
+	Address   string `json:"address"`
+	Healthy   bool   `json:"healthy"`
+	Detail    string `json:"detail"`
+	Delivered uint64 `json:"delivered"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.relays = viper.GetStringSlice("relays")
+
+	c.maxConsecutiveMissedSlots = viper.GetUint64("max-consecutive-missed-slots")
+	if c.maxConsecutiveMissedSlots == 0 {
+		return nil, errors.New("max-consecutive-missed-slots must be greater than 0")
+	}
+	c.maxEpochMissedSlots = viper.GetUint64("max-epoch-missed-slots")
+	if c.maxEpochMissedSlots == 0 {
+		return nil, errors.New("max-epoch-missed-slots must be greater than 0")
+	}
+	c.maxEpochsSinceFinalization = viper.GetUint64("max-epochs-since-finalization")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
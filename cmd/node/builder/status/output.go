@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the status as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the status as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Node: %s\n", c.results.Node))
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.results.Slot))
+	blocks := c.results.Blocks
+	if blocks.Builder != nil && blocks.Local != nil {
+		builder.WriteString(fmt.Sprintf("Blocks in last %d slots: %d proposed (%d builder, %d local), %d missed\n", blocks.Slots, blocks.Proposed, *blocks.Builder, *blocks.Local, blocks.Missed))
+	} else {
+		builder.WriteString(fmt.Sprintf("Blocks in last %d slots: %d proposed, %d missed\n", blocks.Slots, blocks.Proposed, blocks.Missed))
+	}
+	builder.WriteString(fmt.Sprintf("Consecutive missed slots: %d (limit %d)\n", c.results.ConsecutiveMissedSlots, c.maxConsecutiveMissedSlots))
+	builder.WriteString(fmt.Sprintf("Missed slots in last epoch: %d (limit %d)\n", c.results.EpochMissedSlots, c.maxEpochMissedSlots))
+	builder.WriteString(fmt.Sprintf("Epochs since finalization: %d (limit %d)\n", c.results.EpochsSinceFinalization, c.maxEpochsSinceFinalization))
+	for _, relay := range c.results.Relays {
+		health := "healthy"
+		if !relay.Healthy {
+			health = "unhealthy"
+		}
+		builder.WriteString(fmt.Sprintf("Relay %s: %s (%s), delivered %d payloads\n", relay.Address, health, relay.Detail, relay.Delivered))
+	}
+	if c.results.CircuitBreakerActive {
+		builder.WriteString("Circuit breaker: active, blocks will be built locally\n")
+		for _, reason := range c.results.Reasons {
+			builder.WriteString(fmt.Sprintf("  %s\n", reason))
+		}
+	} else {
+		builder.WriteString("Circuit breaker: inactive\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutput(t *testing.T) {
+	builder := uint64(29)
+	local := uint64(2)
+
+	tests := []struct {
+		name   string
+		c      *command
+		format output.Format
+		res    string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+			res: "",
+		},
+		{
+			name: "Inactive",
+			c: &command{
+				maxConsecutiveMissedSlots:  3,
+				maxEpochMissedSlots:        8,
+				maxEpochsSinceFinalization: 3,
+				results: &results{
+					Node:                    "Lighthouse/v4.5.0",
+					Slot:                    1000,
+					EpochMissedSlots:        1,
+					EpochsSinceFinalization: 2,
+					Blocks: &blockSummary{
+						Slots:    32,
+						Proposed: 31,
+						Missed:   1,
+						Builder:  &builder,
+						Local:    &local,
+					},
+					Relays: []*relayStatus{
+						{Address: "https://relay.example.com", Healthy: true, Detail: "available", Delivered: 29},
+					},
+				},
+			},
+			format: output.Text,
+			res:    "Node: Lighthouse/v4.5.0\nSlot: 1000\nBlocks in last 32 slots: 31 proposed (29 builder, 2 local), 1 missed\nConsecutive missed slots: 0 (limit 3)\nMissed slots in last epoch: 1 (limit 8)\nEpochs since finalization: 2 (limit 3)\nRelay https://relay.example.com: healthy (available), delivered 29 payloads\nCircuit breaker: inactive",
+		},
+		{
+			name: "Active",
+			c: &command{
+				maxConsecutiveMissedSlots:  3,
+				maxEpochMissedSlots:        8,
+				maxEpochsSinceFinalization: 3,
+				results: &results{
+					Node:                   "Lighthouse/v4.5.0",
+					Slot:                   1000,
+					CircuitBreakerActive:   true,
+					Reasons:                []string{"4 consecutive missed slots, limit is 3"},
+					ConsecutiveMissedSlots: 4,
+					EpochMissedSlots:       4,
+					Blocks: &blockSummary{
+						Slots:    32,
+						Proposed: 28,
+						Missed:   4,
+					},
+				},
+			},
+			format: output.Text,
+			res:    "Node: Lighthouse/v4.5.0\nSlot: 1000\nBlocks in last 32 slots: 28 proposed, 4 missed\nConsecutive missed slots: 4 (limit 3)\nMissed slots in last epoch: 4 (limit 8)\nEpochs since finalization: 0 (limit 3)\nCircuit breaker: active, blocks will be built locally\n  4 consecutive missed slots, limit is 3",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				results: &results{
+					Node:                 "Lighthouse/v4.5.0",
+					Slot:                 1000,
+					CircuitBreakerActive: true,
+					Reasons:              []string{"4 epochs since finalization, limit is 3"},
+					Blocks: &blockSummary{
+						Slots:    32,
+						Proposed: 31,
+						Missed:   1,
+						Builder:  &builder,
+						Local:    &local,
+					},
+				},
+			},
+			format: output.JSON,
+			res:    `{"node":"Lighthouse/v4.5.0","slot":"1000","circuit_breaker_active":true,"reasons":["4 epochs since finalization, limit is 3"],"consecutive_missed_slots":0,"epoch_missed_slots":0,"epochs_since_finalization":0,"blocks":{"slots":32,"proposed":31,"missed":1,"builder":29,"local":2}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.format = test.format
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
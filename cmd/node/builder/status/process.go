@@ -0,0 +1,228 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.results = &results{
+		Slot: c.chainTime.CurrentSlot(),
+	}
+
+	nodeVersionResponse, err := c.nodeVersionProvider.NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node version")
+	}
+	c.results.Node = nodeVersionResponse.Data
+
+	// Obtain the blocks for the epoch's worth of slots prior to the current slot,
+	// as these are what the circuit breaker considers.
+	startSlot := phase0.Slot(0)
+	if uint64(c.results.Slot) > c.chainTime.SlotsPerEpoch() {
+		startSlot = c.results.Slot - phase0.Slot(c.chainTime.SlotsPerEpoch())
+	}
+	blockHashes := make(map[phase0.Slot]phase0.Hash32)
+	for slot := startSlot; slot < c.results.Slot; slot++ {
+		block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+		}
+		if block == nil {
+			// Missed slot.
+			continue
+		}
+		blockHashes[slot], err = executionBlockHash(block)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain execution block hash for slot %d", slot)
+		}
+	}
+	c.results.ConsecutiveMissedSlots, c.results.EpochMissedSlots = missedSlots(startSlot, c.results.Slot, blockHashes)
+	c.results.Blocks = &blockSummary{
+		Slots:    uint64(c.results.Slot - startSlot),
+		Proposed: uint64(len(blockHashes)),
+		Missed:   c.results.EpochMissedSlots,
+	}
+
+	finalityResponse, err := c.finalityProvider.Finality(ctx, &api.FinalityOpts{State: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	finality := finalityResponse.Data
+	if finality.Finalized != nil && c.chainTime.CurrentEpoch() > finality.Finalized.Epoch {
+		c.results.EpochsSinceFinalization = uint64(c.chainTime.CurrentEpoch() - finality.Finalized.Epoch)
+	}
+
+	if len(c.relays) > 0 {
+		c.processRelays(ctx, blockHashes)
+	}
+
+	c.results.Reasons = circuitBreakerReasons(c.results,
+		c.maxConsecutiveMissedSlots,
+		c.maxEpochMissedSlots,
+		c.maxEpochsSinceFinalization,
+	)
+	c.results.CircuitBreakerActive = len(c.results.Reasons) > 0
+
+	return nil
+}
+
+// processRelays obtains the health of the relays, and uses the payloads they
+// have delivered to split the proposed blocks in to builder and local blocks.
+func (c *command) processRelays(ctx context.Context, blockHashes map[phase0.Slot]phase0.Hash32) {
+	builderBlocks := make(map[phase0.Slot]bool)
+	for _, relay := range c.relays {
+		status := &relayStatus{
+			Address: relayAddress(relay),
+		}
+		c.results.Relays = append(c.results.Relays, status)
+
+		if err := obtainRelayStatus(ctx, relay, c.timeout); err != nil {
+			status.Detail = err.Error()
+		} else {
+			status.Healthy = true
+			status.Detail = "available"
+		}
+
+		delivered, err := obtainRelayDeliveries(ctx, relay, c.timeout, c.chainTime.SlotsPerEpoch())
+		if err != nil {
+			status.Detail = fmt.Sprintf("%s; failed to obtain delivered payloads: %v", status.Detail, err)
+			continue
+		}
+		for slot, blockHash := range blockHashes {
+			if _, exists := delivered[blockHash]; exists {
+				status.Delivered++
+				builderBlocks[slot] = true
+			}
+		}
+	}
+
+	builder := uint64(len(builderBlocks))
+	local := c.results.Blocks.Proposed - builder
+	c.results.Blocks.Builder = &builder
+	c.results.Blocks.Local = &local
+}
+
+// missedSlots returns the number of consecutive missed slots immediately prior
+// to the end slot, and the total number of missed slots, from the start slot
+// up to but not including the end slot.
+func missedSlots(startSlot phase0.Slot,
+	endSlot phase0.Slot,
+	blockHashes map[phase0.Slot]phase0.Hash32,
+) (
+	uint64,
+	uint64,
+) {
+	consecutive := uint64(0)
+	total := uint64(0)
+	for slot := startSlot; slot < endSlot; slot++ {
+		if _, exists := blockHashes[slot]; exists {
+			consecutive = 0
+			continue
+		}
+		consecutive++
+		total++
+	}
+
+	return consecutive, total
+}
+
+// circuitBreakerReasons returns the reasons that the circuit breaker is
+// active, or nil if it is not.
+func circuitBreakerReasons(res *results,
+	maxConsecutiveMissedSlots uint64,
+	maxEpochMissedSlots uint64,
+	maxEpochsSinceFinalization uint64,
+) []string {
+	var reasons []string
+	if res.ConsecutiveMissedSlots >= maxConsecutiveMissedSlots {
+		reasons = append(reasons, fmt.Sprintf("%d consecutive missed slots, limit is %d", res.ConsecutiveMissedSlots, maxConsecutiveMissedSlots))
+	}
+	if res.EpochMissedSlots >= maxEpochMissedSlots {
+		reasons = append(reasons, fmt.Sprintf("%d missed slots in the last epoch, limit is %d", res.EpochMissedSlots, maxEpochMissedSlots))
+	}
+	if res.EpochsSinceFinalization > maxEpochsSinceFinalization {
+		reasons = append(reasons, fmt.Sprintf("%d epochs since finalization, limit is %d", res.EpochsSinceFinalization, maxEpochsSinceFinalization))
+	}
+
+	return reasons
+}
+
+// executionBlockHash returns the hash of the execution payload in a block,
+// or a zero hash if the block does not contain an execution payload.
+func executionBlockHash(block *spec.VersionedSignedBeaconBlock) (phase0.Hash32, error) {
+	if block.Version == spec.DataVersionPhase0 || block.Version == spec.DataVersionAltair {
+		return phase0.Hash32{}, nil
+	}
+	blockHash, err := block.ExecutionBlockHash()
+	if err != nil {
+		return phase0.Hash32{}, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+
+	return blockHash, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.nodeVersionProvider, isProvider = c.eth2Client.(eth2client.NodeVersionProvider)
+	if !isProvider {
+		return errors.New("connection does not provide node version")
+	}
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
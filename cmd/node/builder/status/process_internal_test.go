@@ -0,0 +1,204 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedSlots(t *testing.T) {
+	tests := []struct {
+		name        string
+		startSlot   phase0.Slot
+		endSlot     phase0.Slot
+		proposed    []phase0.Slot
+		consecutive uint64
+		total       uint64
+	}{
+		{
+			name:      "Empty",
+			startSlot: 100,
+			endSlot:   100,
+		},
+		{
+			name:      "AllProposed",
+			startSlot: 100,
+			endSlot:   104,
+			proposed:  []phase0.Slot{100, 101, 102, 103},
+		},
+		{
+			name:      "EarlierMissed",
+			startSlot: 100,
+			endSlot:   104,
+			proposed:  []phase0.Slot{102, 103},
+			total:     2,
+		},
+		{
+			name:        "RecentMissed",
+			startSlot:   100,
+			endSlot:     104,
+			proposed:    []phase0.Slot{101},
+			consecutive: 2,
+			total:       3,
+		},
+		{
+			name:        "AllMissed",
+			startSlot:   100,
+			endSlot:     104,
+			consecutive: 4,
+			total:       4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			blockHashes := make(map[phase0.Slot]phase0.Hash32)
+			for _, slot := range test.proposed {
+				blockHashes[slot] = phase0.Hash32{0x01}
+			}
+			consecutive, total := missedSlots(test.startSlot, test.endSlot, blockHashes)
+			require.Equal(t, test.consecutive, consecutive)
+			require.Equal(t, test.total, total)
+		})
+	}
+}
+
+func TestCircuitBreakerReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		res     *results
+		reasons []string
+	}{
+		{
+			name: "Inactive",
+			res: &results{
+				ConsecutiveMissedSlots:  2,
+				EpochMissedSlots:        7,
+				EpochsSinceFinalization: 3,
+			},
+		},
+		{
+			name: "ConsecutiveMissedSlots",
+			res: &results{
+				ConsecutiveMissedSlots: 3,
+				EpochMissedSlots:       3,
+			},
+			reasons: []string{"3 consecutive missed slots, limit is 3"},
+		},
+		{
+			name: "EpochMissedSlots",
+			res: &results{
+				EpochMissedSlots: 8,
+			},
+			reasons: []string{"8 missed slots in the last epoch, limit is 8"},
+		},
+		{
+			name: "EpochsSinceFinalization",
+			res: &results{
+				EpochsSinceFinalization: 4,
+			},
+			reasons: []string{"4 epochs since finalization, limit is 3"},
+		},
+		{
+			name: "Multiple",
+			res: &results{
+				ConsecutiveMissedSlots:  10,
+				EpochMissedSlots:        10,
+				EpochsSinceFinalization: 5,
+			},
+			reasons: []string{
+				"10 consecutive missed slots, limit is 3",
+				"10 missed slots in the last epoch, limit is 8",
+				"5 epochs since finalization, limit is 3",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.reasons, circuitBreakerReasons(test.res, 3, 8, 3))
+		})
+	}
+}
+
+func TestExecutionBlockHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    *spec.VersionedSignedBeaconBlock
+		expected phase0.Hash32
+		err      string
+	}{
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+		},
+		{
+			name: "Deneb",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionDeneb,
+				Deneb: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						Body: &deneb.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{BlockHash: phase0.Hash32{0x01}},
+						},
+					},
+				},
+			},
+			expected: phase0.Hash32{0x01},
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Body: &electra.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{BlockHash: phase0.Hash32{0x02}},
+						},
+					},
+				},
+			},
+			expected: phase0.Hash32{0x02},
+		},
+		{
+			name: "ElectraMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+			},
+			err: "failed to obtain execution block hash: no electra block",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := executionBlockHash(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
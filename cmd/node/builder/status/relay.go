@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// obtainRelayStatus obtains the status of the relay from its builder API,
+// returning an error if the relay is not available.
+func obtainRelayStatus(ctx context.Context, relay string, timeout time.Duration) error {
+	_, err := util.RelayGet(ctx, relay, "/eth/v1/builder/status", timeout)
+
+	return err
+}
+
+// obtainRelayDeliveries obtains the hashes of the most recent payloads
+// delivered by the relay, using the relay data API.
+func obtainRelayDeliveries(ctx context.Context,
+	relay string,
+	timeout time.Duration,
+	limit uint64,
+) (
+	map[phase0.Hash32]struct{},
+	error,
+) {
+	traces, err := util.ObtainRelayBidTraces(ctx, relay, fmt.Sprintf("proposer_payload_delivered?limit=%d", limit), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[phase0.Hash32]struct{}, len(traces))
+	for _, trace := range traces {
+		res[trace.BlockHash] = struct{}{}
+	}
+
+	return res, nil
+}
+
+// relayAddress returns the address of the relay without any credentials,
+// such as the relay public key, for display.
+func relayAddress(relay string) string {
+	relayURL, err := url.Parse(relay)
+	if err != nil {
+		return relay
+	}
+	relayURL.User = nil
+
+	return relayURL.String()
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodebuilderstatus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObtainRelayDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" || r.URL.Query().Get("limit") != "32" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"slot":"2","block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000"},{"slot":"1","block_hash":"0x0304000000000000000000000000000000000000000000000000000000000000"}]`))
+	}))
+	defer server.Close()
+
+	res, err := obtainRelayDeliveries(context.Background(), server.URL, 5*time.Second, 32)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.Hash32]struct{}{
+		{0x01, 0x02}: {},
+		{0x03, 0x04}: {},
+	}, res)
+}
+
+func TestObtainRelayStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/builder/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	err := obtainRelayStatus(context.Background(), server.URL, 5*time.Second)
+	require.EqualError(t, err, "relay returned status 503: unavailable")
+}
+
+func TestRelayAddress(t *testing.T) {
+	require.Equal(t, "https://relay.example.com", relayAddress("https://0xabcd@relay.example.com"))
+	require.Equal(t, "https://relay.example.com/", relayAddress("https://relay.example.com/"))
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecrosscheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutput(t *testing.T) {
+	checks := []*check{
+		{Name: "execution node synced", Passed: true, Detail: "not syncing"},
+		{Name: "chain ID", Passed: false, Detail: "execution node chain ID 5 does not match deposit chain ID 1"},
+	}
+
+	tests := []struct {
+		name   string
+		c      *command
+		format output.Format
+		res    string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+			res: "",
+		},
+		{
+			name: "Healthy",
+			c: &command{
+				results: &results{
+					Healthy: true,
+					Checks:  checks[:1],
+				},
+			},
+			format: output.Text,
+			res:    "[PASS] execution node synced: not syncing\nResult: consensus and execution nodes are healthy and paired",
+		},
+		{
+			name: "Unhealthy",
+			c: &command{
+				results: &results{
+					Checks: checks,
+				},
+			},
+			format: output.Text,
+			res:    "[PASS] execution node synced: not syncing\n[FAIL] chain ID: execution node chain ID 5 does not match deposit chain ID 1\nResult: consensus and execution nodes are not ready for use",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				results: &results{
+					Checks: checks[1:],
+				},
+			},
+			format: output.JSON,
+			res:    `{"healthy":false,"checks":[{"name":"chain ID","passed":false,"detail":"execution node chain ID 5 does not match deposit chain ID 1"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.format = test.format
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecrosscheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type executionBlockJSON struct {
+	Number string `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+type executionSyncingJSON struct {
+	CurrentBlock string `json:"currentBlock"`
+	HighestBlock string `json:"highestBlock"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.results = &results{
+		Checks: []*check{
+			c.checkConsensusSync(ctx),
+			c.checkExecutionSync(ctx),
+			c.checkChainID(ctx),
+			c.checkHead(ctx),
+		},
+	}
+	c.results.Healthy = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Healthy = false
+		}
+	}
+
+	return nil
+}
+
+// checkConsensusSync checks that the beacon node is synced and not optimistic.
+func (c *command) checkConsensusSync(ctx context.Context) *check {
+	res := &check{
+		Name: "consensus node synced",
+	}
+
+	syncStateResponse, err := c.nodeSyncingProvider.NodeSyncing(ctx, &api.NodeSyncingOpts{})
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain sync state: %v", err)
+		return res
+	}
+	syncState := syncStateResponse.Data
+	switch {
+	case syncState.IsSyncing || syncState.SyncDistance > 1:
+		res.Detail = fmt.Sprintf("syncing, %d slots behind", syncState.SyncDistance)
+	case syncState.IsOptimistic:
+		res.Detail = fmt.Sprintf("optimistic at slot %d, execution payloads not verified", syncState.HeadSlot)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("synced at slot %d", syncState.HeadSlot)
+	}
+
+	return res
+}
+
+// checkExecutionSync checks that the execution node is not syncing.
+func (c *command) checkExecutionSync(ctx context.Context) *check {
+	res := &check{
+		Name: "execution node synced",
+	}
+
+	var syncing json.RawMessage
+	if _, err := c.executionClient.Call(ctx, "eth_syncing", nil, &syncing); err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain sync state: %v", err)
+		return res
+	}
+	res.Passed, res.Detail = executionSyncState(syncing)
+
+	return res
+}
+
+// executionSyncState interprets the result of eth_syncing, which is false if
+// the node is not syncing and an object describing progress otherwise.
+func executionSyncState(syncing json.RawMessage) (bool, string) {
+	if string(syncing) == "false" {
+		return true, "not syncing"
+	}
+
+	progress := &executionSyncingJSON{}
+	if err := json.Unmarshal(syncing, progress); err != nil {
+		return false, fmt.Sprintf("unrecognised sync state %s", string(syncing))
+	}
+	currentBlock, err := hexToUint64(progress.CurrentBlock)
+	if err != nil {
+		return false, "syncing"
+	}
+	highestBlock, err := hexToUint64(progress.HighestBlock)
+	if err != nil {
+		return false, "syncing"
+	}
+
+	return false, fmt.Sprintf("syncing, at block %d of %d", currentBlock, highestBlock)
+}
+
+// checkChainID checks that the execution node's chain ID matches the beacon
+// chain's deposit chain ID.
+func (c *command) checkChainID(ctx context.Context) *check {
+	res := &check{
+		Name: "chain ID",
+	}
+
+	specDataResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain spec: %v", err)
+		return res
+	}
+	specData := specDataResponse.Data
+	depositChainID, exists := specData["DEPOSIT_CHAIN_ID"].(uint64)
+	if !exists {
+		res.Detail = "consensus node does not provide DEPOSIT_CHAIN_ID"
+		return res
+	}
+
+	var chainIDStr string
+	if _, err := c.executionClient.Call(ctx, "eth_chainId", nil, &chainIDStr); err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain chain ID: %v", err)
+		return res
+	}
+	chainID, err := hexToUint64(chainIDStr)
+	if err != nil {
+		res.Detail = fmt.Sprintf("invalid chain ID: %v", err)
+		return res
+	}
+
+	if chainID != depositChainID {
+		res.Detail = fmt.Sprintf("execution node chain ID %d does not match deposit chain ID %d", chainID, depositChainID)
+		return res
+	}
+	res.Passed = true
+	res.Detail = fmt.Sprintf("both %d", chainID)
+
+	return res
+}
+
+// checkHead checks that the execution node has the execution block of the
+// beacon node's head as its canonical block at that height.
+func (c *command) checkHead(ctx context.Context) *check {
+	res := &check{
+		Name: "head execution block",
+	}
+
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "head"}))
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain head block: %v", err)
+		return res
+	}
+	if block == nil {
+		res.Detail = "consensus node has no head block"
+		return res
+	}
+	blockNumber, blockHash, err := executionPayload(block)
+	if err != nil {
+		res.Detail = err.Error()
+		return res
+	}
+
+	executionBlock := &executionBlockJSON{}
+	found, err := c.executionClient.Call(ctx, "eth_getBlockByNumber", []interface{}{fmt.Sprintf("%#x", blockNumber), false}, executionBlock)
+	if err != nil {
+		res.Detail = fmt.Sprintf("failed to obtain execution block %d: %v", blockNumber, err)
+		return res
+	}
+	res.Passed, res.Detail = headAgreement(blockNumber, blockHash, found, executionBlock)
+
+	return res
+}
+
+// headAgreement compares the beacon node's head execution block with the
+// execution node's canonical block at the same height.
+func headAgreement(blockNumber uint64,
+	blockHash phase0.Hash32,
+	found bool,
+	executionBlock *executionBlockJSON,
+) (
+	bool,
+	string,
+) {
+	if !found {
+		return false, fmt.Sprintf("execution node does not have block %d", blockNumber)
+	}
+	if !strings.EqualFold(executionBlock.Hash, blockHash.String()) {
+		return false, fmt.Sprintf("execution node block %d is %s, consensus node head is %s", blockNumber, executionBlock.Hash, blockHash.String())
+	}
+
+	return true, fmt.Sprintf("both at block %d (%s)", blockNumber, blockHash.String())
+}
+
+// executionPayload returns the number and hash of the execution payload in a block.
+func executionPayload(block *spec.VersionedSignedBeaconBlock) (uint64, phase0.Hash32, error) {
+	if block.Version == spec.DataVersionPhase0 || block.Version == spec.DataVersionAltair {
+		return 0, phase0.Hash32{}, errors.New("chain has not reached the merge")
+	}
+	blockNumber, err := block.ExecutionBlockNumber()
+	if err != nil {
+		return 0, phase0.Hash32{}, errors.Wrap(err, "failed to obtain execution block number")
+	}
+	blockHash, err := block.ExecutionBlockHash()
+	if err != nil {
+		return 0, phase0.Hash32{}, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+	if blockHash == (phase0.Hash32{}) {
+		return 0, phase0.Hash32{}, errors.New("chain has not reached the merge")
+	}
+
+	return blockNumber, blockHash, nil
+}
+
+// hexToUint64 parses a JSON-RPC quantity.
+func hexToUint64(input string) (uint64, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return 0, fmt.Errorf("invalid quantity %q", input)
+	}
+	res, err := strconv.ParseUint(strings.TrimPrefix(input, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec")
+	}
+	c.nodeSyncingProvider, isProvider = c.eth2Client.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return errors.New("connection does not provide node syncing")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecrosscheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExecutionSyncState(t *testing.T) {
+	tests := []struct {
+		name    string
+		syncing string
+		passed  bool
+		detail  string
+	}{
+		{
+			name:    "NotSyncing",
+			syncing: `false`,
+			passed:  true,
+			detail:  "not syncing",
+		},
+		{
+			name:    "Syncing",
+			syncing: `{"startingBlock":"0x0","currentBlock":"0x64","highestBlock":"0xc8"}`,
+			detail:  "syncing, at block 100 of 200",
+		},
+		{
+			name:    "SyncingNoProgress",
+			syncing: `{"startingBlock":"0x0"}`,
+			detail:  "syncing",
+		},
+		{
+			name:    "Unrecognised",
+			syncing: `true`,
+			detail:  "unrecognised sync state true",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			passed, detail := executionSyncState(json.RawMessage(test.syncing))
+			require.Equal(t, test.passed, passed)
+			require.Equal(t, test.detail, detail)
+		})
+	}
+}
+
+func TestCheckExecutionSync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":false}`))
+	}))
+	defer server.Close()
+
+	executionClient, err := util.ConnectToExecutionNode(context.Background(), &util.ExecutionConnectOpts{
+		Address: server.URL,
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	c := &command{
+		executionClient: executionClient,
+		timeout:         5 * time.Second,
+	}
+	require.Equal(t, &check{
+		Name:   "execution node synced",
+		Passed: true,
+		Detail: "not syncing",
+	}, c.checkExecutionSync(context.Background()))
+}
+
+func TestHeadAgreement(t *testing.T) {
+	hash := phase0.Hash32{0x01, 0x02}
+	otherHash := phase0.Hash32{0x03}
+
+	tests := []struct {
+		name           string
+		found          bool
+		executionBlock *executionBlockJSON
+		passed         bool
+		detail         string
+	}{
+		{
+			name:   "NotFound",
+			detail: "execution node does not have block 100",
+		},
+		{
+			name:  "Mismatch",
+			found: true,
+			executionBlock: &executionBlockJSON{
+				Number: "0x64",
+				Hash:   otherHash.String(),
+			},
+			detail: "execution node block 100 is 0x0300000000000000000000000000000000000000000000000000000000000000, consensus node head is 0x0102000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:  "Match",
+			found: true,
+			executionBlock: &executionBlockJSON{
+				Number: "0x64",
+				Hash:   "0x0102000000000000000000000000000000000000000000000000000000000000",
+			},
+			passed: true,
+			detail: "both at block 100 (0x0102000000000000000000000000000000000000000000000000000000000000)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			passed, detail := headAgreement(100, hash, test.found, test.executionBlock)
+			require.Equal(t, test.passed, passed)
+			require.Equal(t, test.detail, detail)
+		})
+	}
+}
+
+func TestExecutionPayload(t *testing.T) {
+	_, _, err := executionPayload(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionAltair,
+	})
+	require.EqualError(t, err, "chain has not reached the merge")
+
+	number, hash, err := executionPayload(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Body: &capella.BeaconBlockBody{
+					ExecutionPayload: &capella.ExecutionPayload{
+						BlockNumber: 100,
+						BlockHash:   phase0.Hash32{0x01},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), number)
+	require.Equal(t, phase0.Hash32{0x01}, hash)
+
+	number, hash, err = executionPayload(&spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Body: &electra.BeaconBlockBody{
+					ExecutionPayload: &deneb.ExecutionPayload{
+						BlockNumber: 200,
+						BlockHash:   phase0.Hash32{0x02},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), number)
+	require.Equal(t, phase0.Hash32{0x02}, hash)
+}
+
+func TestHexToUint64(t *testing.T) {
+	res, err := hexToUint64("0x1a")
+	require.NoError(t, err)
+	require.Equal(t, uint64(26), res)
+
+	_, err = hexToUint64("26")
+	require.EqualError(t, err, `invalid quantity "26"`)
+
+	_, err = hexToUint64("0xzz")
+	require.EqualError(t, err, `invalid quantity "0xzz"`)
+}
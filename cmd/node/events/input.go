@@ -31,6 +31,7 @@ type dataIn struct {
 	debug   bool
 	// Operation.
 	topics     []string
+	sinks      []string
 	eth2Client eth2client.Service
 	jsonOutput bool
 }
@@ -48,6 +49,7 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.jsonOutput = viper.GetBool("json")
 
 	data.topics = viper.GetStringSlice("topics")
+	data.sinks = viper.GetStringSlice("sinks")
 
 	var err error
 	data.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
@@ -17,10 +17,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	eth2api "github.com/attestantio/go-eth2-client/api"
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/sink"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func process(ctx context.Context, data *dataIn) error {
@@ -28,7 +32,22 @@ func process(ctx context.Context, data *dataIn) error {
 		return errors.New("no data")
 	}
 
-	err := data.eth2Client.(eth2client.EventsProvider).Events(ctx, data.topics, eventHandler)
+	sink, err := util.NewSink(ctx, data.sinks, data.timeout)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up sinks")
+	}
+	defer func() {
+		if err := sink.Close(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+	}()
+
+	err = data.eth2Client.(eth2client.EventsProvider).Events(ctx, &eth2api.EventsOpts{
+		Topics: data.topics,
+		Handler: func(event *api.Event) {
+			eventHandler(ctx, sink, event)
+		},
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to connect for events")
 	}
@@ -38,13 +57,16 @@ func process(ctx context.Context, data *dataIn) error {
 	return nil
 }
 
-func eventHandler(event *api.Event) {
+func eventHandler(ctx context.Context, sink sink.Service, event *api.Event) {
 	if event.Data == nil {
 		return
 	}
 
 	data, err := json.Marshal(event)
-	if err == nil {
-		fmt.Println(string(data))
+	if err != nil {
+		return
+	}
+	if err := sink.Write(ctx, data); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 	}
 }
@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeexpectedwithdrawals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	validatorsFile := filepath.Join(dir, "validators.txt")
+	require.NoError(t, os.WriteFile(validatorsFile, []byte("# My validators\n1\n\n 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c \n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("# None\n\n"), 0o600))
+	missingFile := filepath.Join(dir, "missing.txt")
+
+	tests := []struct {
+		name       string
+		vars       map[string]interface{}
+		validators []string
+		err        string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "All",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+		},
+		{
+			name: "Validators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1", "2"},
+			},
+			validators: []string{"1", "2"},
+		},
+		{
+			name: "ValidatorsFileMissing",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"validators-file": missingFile,
+			},
+			err: "failed to read validators file: open " + missingFile + ": no such file or directory",
+		},
+		{
+			name: "ValidatorsFileEmpty",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"validators-file": emptyFile,
+			},
+			err: "validators file does not contain any validators",
+		},
+		{
+			name: "ValidatorsFile",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"validators":      []string{"2"},
+				"validators-file": validatorsFile,
+			},
+			validators: []string{"2", "1", "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.validators, cmd.validators)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeexpectedwithdrawals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.results.Withdrawals) == 0 {
+		if len(c.validators) > 0 {
+			return "No expected withdrawals for the supplied validators", nil
+		}
+		return "No expected withdrawals", nil
+	}
+
+	builder := strings.Builder{}
+
+	for _, withdrawal := range c.results.Withdrawals {
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Withdrawal %d: ", withdrawal.Index))
+		}
+		builder.WriteString(fmt.Sprintf("Validator %d: %s to %s\n",
+			withdrawal.ValidatorIndex,
+			string2eth.GWeiToString(uint64(withdrawal.Amount), true),
+			withdrawal.Address.String(),
+		))
+	}
+	builder.WriteString(fmt.Sprintf("Total: %s in %d withdrawals\n", string2eth.GWeiToString(uint64(c.results.TotalAmount), true), len(c.results.Withdrawals)))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeexpectedwithdrawals
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	withdrawals := make([]*capella.Withdrawal, 0)
+	found, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/builder/states/head/expected_withdrawals", &withdrawals)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain expected withdrawals")
+	}
+	if !found {
+		return errors.New("beacon node does not provide expected withdrawals")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Beacon node returned %d expected withdrawals\n", len(withdrawals))
+	}
+
+	if len(c.validators) > 0 {
+		validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validators")
+		}
+		indices := make(map[phase0.ValidatorIndex]bool, len(validators))
+		for _, validator := range validators {
+			indices[validator.Index] = true
+		}
+		withdrawals = filterWithdrawals(withdrawals, indices)
+	}
+
+	c.results = &results{
+		Withdrawals: withdrawals,
+	}
+	for _, withdrawal := range withdrawals {
+		c.results.TotalAmount += withdrawal.Amount
+	}
+
+	return nil
+}
+
+// filterWithdrawals returns the withdrawals for the given validator indices.
+func filterWithdrawals(withdrawals []*capella.Withdrawal,
+	indices map[phase0.ValidatorIndex]bool,
+) []*capella.Withdrawal {
+	res := make([]*capella.Withdrawal, 0)
+	for _, withdrawal := range withdrawals {
+		if indices[withdrawal.ValidatorIndex] {
+			res = append(res, withdrawal)
+		}
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
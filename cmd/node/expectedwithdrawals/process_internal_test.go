@@ -0,0 +1,58 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeexpectedwithdrawals
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterWithdrawals(t *testing.T) {
+	withdrawals := []*capella.Withdrawal{
+		{Index: 100, ValidatorIndex: 1, Amount: 1000},
+		{Index: 101, ValidatorIndex: 2, Amount: 2000},
+		{Index: 102, ValidatorIndex: 3, Amount: 3000},
+	}
+
+	tests := []struct {
+		name     string
+		indices  map[phase0.ValidatorIndex]bool
+		expected []*capella.Withdrawal
+	}{
+		{
+			name:     "None",
+			indices:  map[phase0.ValidatorIndex]bool{},
+			expected: []*capella.Withdrawal{},
+		},
+		{
+			name:     "NotPresent",
+			indices:  map[phase0.ValidatorIndex]bool{4: true},
+			expected: []*capella.Withdrawal{},
+		},
+		{
+			name:     "Some",
+			indices:  map[phase0.ValidatorIndex]bool{1: true, 3: true, 4: true},
+			expected: []*capella.Withdrawal{withdrawals[0], withdrawals[2]},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, filterWithdrawals(withdrawals, test.indices))
+		})
+	}
+}
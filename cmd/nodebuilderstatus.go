@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodebuilderstatus "github.com/wealdtech/ethdo/cmd/node/builder/status"
+)
+
+var nodeBuilderStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show if a node's builder circuit breaker is active",
+	Long: `Show the recent health of the chain and of relays, and if the builder circuit breaker is active.  For example:
+
+    ethdo node builder status --relays=https://relay.example.com
+
+The circuit breaker stops a beacon node from using external block builders when the chain is unhealthy, falling back to building blocks locally.  The node's builder configuration is not exposed by the standard API, so the circuit breaker is evaluated against the limits supplied, which default to those of Lighthouse and should be set to match the node's configuration.
+
+If relays are supplied their health is checked, and the payloads that they delivered are used to show which recent blocks were built by builders and which were built locally.
+
+In quiet mode this will return 0 if the circuit breaker is not active, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := nodebuilderstatus.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeBuilderCmd.AddCommand(nodeBuilderStatusCmd)
+	nodeBuilderFlags(nodeBuilderStatusCmd)
+	nodeBuilderStatusCmd.Flags().StringSlice("relays", nil, "URLs of relays to check")
+	nodeBuilderStatusCmd.Flags().Uint64("max-consecutive-missed-slots", 3, "number of consecutive missed slots at which the circuit breaker activates")
+	nodeBuilderStatusCmd.Flags().Uint64("max-epoch-missed-slots", 8, "number of missed slots in the last epoch at which the circuit breaker activates")
+	nodeBuilderStatusCmd.Flags().Uint64("max-epochs-since-finalization", 3, "number of epochs since finalization above which the circuit breaker activates")
+}
+
+func nodeBuilderStatusBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("relays", cmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-consecutive-missed-slots", cmd.Flags().Lookup("max-consecutive-missed-slots")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-epoch-missed-slots", cmd.Flags().Lookup("max-epoch-missed-slots")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-epochs-since-finalization", cmd.Flags().Lookup("max-epochs-since-finalization")); err != nil {
+		panic(err)
+	}
+}
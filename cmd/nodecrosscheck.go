@@ -0,0 +1,52 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodecrosscheck "github.com/wealdtech/ethdo/cmd/node/crosscheck"
+)
+
+var nodeCrosscheckCmd = &cobra.Command{
+	Use:   "crosscheck",
+	Short: "Check a consensus node and execution node pairing",
+	Long: `Check that a consensus node and its execution node are healthy and agree with each other.  For example:
+
+    ethdo node crosscheck --execution-connection=http://localhost:8545
+
+This checks that neither node is syncing, that the execution node's chain ID matches the deposit chain ID of the beacon chain, and that the execution node has the execution block of the consensus node's head as its canonical block.
+
+In quiet mode this will return 0 if all checks pass, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := nodecrosscheck.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeCrosscheckCmd)
+	nodeFlags(nodeCrosscheckCmd)
+}
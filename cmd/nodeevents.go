@@ -26,7 +26,11 @@ var nodeEventsCmd = &cobra.Command{
 	Short: "Report events from a node",
 	Long: `Report events from a node.  For example:
 
-    ethdo node events --events=head,chain_reorg.`,
+    ethdo node events --events=head,chain_reorg.
+
+Events can be sent to other destinations with --sinks, for example:
+
+    ethdo node events --topics=head --sinks=stdout,https://example.com/events,kafka://localhost:9092/events`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := nodeevents.Run(cmd)
 		if err != nil {
@@ -43,10 +47,14 @@ func init() {
 	nodeCmd.AddCommand(nodeEventsCmd)
 	nodeFlags(nodeEventsCmd)
 	nodeEventsCmd.Flags().StringSlice("topics", nil, "The topics of events for which to listen (attestation,block,chain_reorg,finalized_checkpoint,head,voluntary_exit)")
+	nodeEventsCmd.Flags().StringSlice("sinks", nil, "The destinations to which to send events (stdout, file://<path>, http(s)://<url>, kafka://<brokers>/<topic>); defaults to stdout")
 }
 
 func nodeEventsBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("topics", cmd.Flags().Lookup("topics")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("sinks", cmd.Flags().Lookup("sinks")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodeexpectedwithdrawals "github.com/wealdtech/ethdo/cmd/node/expectedwithdrawals"
+)
+
+var nodeExpectedWithdrawalsCmd = &cobra.Command{
+	Use:   "expectedwithdrawals",
+	Short: "Show the withdrawals expected in the next block",
+	Long: `Show the withdrawals expected in the next block, as reported by the node.  For example:
+
+    ethdo node expectedwithdrawals --validators=1,2,3
+
+or, for validators listed in a file:
+
+    ethdo node expectedwithdrawals --validators-file=validators.txt
+
+In quiet mode this will return 0 if the expected withdrawals can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := nodeexpectedwithdrawals.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeExpectedWithdrawalsCmd)
+	nodeFlags(nodeExpectedWithdrawalsCmd)
+	nodeExpectedWithdrawalsCmd.Flags().StringSlice("validators", nil, "the validators for which to show expected withdrawals")
+	nodeExpectedWithdrawalsCmd.Flags().String("validators-file", "", "a file containing the validators for which to show expected withdrawals, one per line")
+}
+
+func nodeExpectedWithdrawalsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators-file", cmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
+}
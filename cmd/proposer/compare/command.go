@@ -0,0 +1,170 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	slot   string
+	relays []string
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Slot          phase0.Slot
+	Upcoming      bool
+	ProposerIndex phase0.ValidatorIndex
+	// BlockHash is the hash of the execution payload in the block, for past slots.
+	BlockHash *phase0.Hash32
+	// PayloadSource is the source of the execution payload in the block, for past slots.
+	PayloadSource string
+	LocalValue    *big.Int
+	BuilderValue  *big.Int
+	RelayBids     []*relayBids
+}
+
+type resultsJSON struct {
+	Slot          phase0.Slot           `json:"slot"`
+	Upcoming      bool                  `json:"upcoming"`
+	ProposerIndex phase0.ValidatorIndex `json:"proposer_index"`
+	BlockHash     *phase0.Hash32        `json:"block_hash,omitempty"`
+	PayloadSource string                `json:"payload_source,omitempty"`
+	LocalValue    string                `json:"local_value,omitempty"`
+	BuilderValue  string                `json:"builder_value,omitempty"`
+	Premium       string                `json:"premium,omitempty"`
+	RelayBids     []*relayBids          `json:"relay_bids"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *results) MarshalJSON() ([]byte, error) {
+	data := &resultsJSON{
+		Slot:          r.Slot,
+		Upcoming:      r.Upcoming,
+		ProposerIndex: r.ProposerIndex,
+		BlockHash:     r.BlockHash,
+		PayloadSource: r.PayloadSource,
+		LocalValue:    optionalWeiString(r.LocalValue),
+		BuilderValue:  optionalWeiString(r.BuilderValue),
+		Premium:       optionalWeiString(r.premium()),
+		RelayBids:     r.RelayBids,
+	}
+
+	return json.Marshal(data)
+}
+
+// premium returns the additional value of the builder payload over the
+// locally-built payload, or nil if either value is unavailable.
+func (r *results) premium() *big.Int {
+	if r.LocalValue == nil || r.BuilderValue == nil {
+		return nil
+	}
+
+	return new(big.Int).Sub(r.BuilderValue, r.LocalValue)
+}
+
+// relayBids are the bids received by a relay for the slot.
+type relayBids struct {
+	Relay string
+	bids  []*util.RelayBidTrace
+	// Best is the highest value bid received by the relay.
+	Best *util.RelayBidTrace
+	// Error is the error returned when querying the relay, if any.
+	Error string
+}
+
+type relayBidsJSON struct {
+	Relay     string         `json:"relay"`
+	Bids      int            `json:"bids"`
+	BestValue string         `json:"best_value,omitempty"`
+	BlockHash *phase0.Hash32 `json:"best_block_hash,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *relayBids) MarshalJSON() ([]byte, error) {
+	data := &relayBidsJSON{
+		Relay: r.Relay,
+		Bids:  len(r.bids),
+		Error: r.Error,
+	}
+	if r.Best != nil {
+		data.BestValue = r.Best.Value.String()
+		data.BlockHash = &r.Best.BlockHash
+	}
+
+	return json.Marshal(data)
+}
+
+// optionalWeiString returns the string representation of a wei value, or an
+// empty string if it is not present.
+func optionalWeiString(val *big.Int) string {
+	if val == nil {
+		return ""
+	}
+	return val.String()
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.slot = viper.GetString("slot")
+	c.relays = viper.GetStringSlice("relays")
+
+	return c, nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.Upcoming {
+		builder.WriteString(fmt.Sprintf("Slot: %d (upcoming)\n", c.results.Slot))
+	} else {
+		builder.WriteString(fmt.Sprintf("Slot: %d\n", c.results.Slot))
+	}
+	builder.WriteString(fmt.Sprintf("Proposer index: %d\n", c.results.ProposerIndex))
+	if c.results.BlockHash != nil && c.verbose {
+		builder.WriteString(fmt.Sprintf("Execution block hash: %#x\n", *c.results.BlockHash))
+	}
+	if c.results.PayloadSource != "" {
+		builder.WriteString(fmt.Sprintf("Payload source: %s\n", c.results.PayloadSource))
+	}
+
+	if c.results.LocalValue != nil {
+		builder.WriteString(fmt.Sprintf("Local payload value: %s\n", string2eth.WeiToString(c.results.LocalValue, true)))
+	} else {
+		builder.WriteString("Local payload value: not available\n")
+	}
+	if c.results.BuilderValue != nil {
+		builder.WriteString(fmt.Sprintf("Builder payload value: %s\n", string2eth.WeiToString(c.results.BuilderValue, true)))
+	} else {
+		builder.WriteString("Builder payload value: not available\n")
+	}
+	if premium := c.results.premium(); premium != nil {
+		builder.WriteString(fmt.Sprintf("MEV premium: %s", signedWeiString(premium)))
+		if c.results.LocalValue.Sign() > 0 {
+			percent, _ := new(big.Float).Quo(new(big.Float).SetInt(premium), new(big.Float).SetInt(c.results.LocalValue)).Float64()
+			builder.WriteString(fmt.Sprintf(" (%.2f%%)", percent*100))
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(c.results.RelayBids) > 0 {
+		builder.WriteString("Relay bids:\n")
+		for _, relayBids := range c.results.RelayBids {
+			switch {
+			case relayBids.Error != "":
+				builder.WriteString(fmt.Sprintf("  %s: error (%s)\n", relayBids.Relay, relayBids.Error))
+			case relayBids.Best == nil:
+				builder.WriteString(fmt.Sprintf("  %s: no bids\n", relayBids.Relay))
+			default:
+				builder.WriteString(fmt.Sprintf("  %s: %s (best of %d)\n", relayBids.Relay, string2eth.WeiToString(relayBids.Best.Value, true), len(relayBids.bids)))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// signedWeiString returns the string representation of a wei value that may be negative.
+func signedWeiString(val *big.Int) string {
+	if val.Sign() < 0 {
+		return fmt.Sprintf("-%s", string2eth.WeiToString(new(big.Int).Neg(val), true))
+	}
+
+	return string2eth.WeiToString(val, true)
+}
@@ -0,0 +1,229 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	payloadSourceUnknown = "unknown"
+	payloadSourceLocal   = "local or unlisted builder"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.results = &results{
+		RelayBids: make([]*relayBids, 0, len(c.relays)),
+	}
+
+	if c.slot == "" {
+		c.results.Slot = c.chainTime.CurrentSlot() + 1
+	} else {
+		slot, err := strconv.ParseUint(c.slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid slot")
+		}
+		c.results.Slot = phase0.Slot(slot)
+	}
+	c.results.Upcoming = c.results.Slot > c.chainTime.CurrentSlot()
+
+	for _, relay := range c.relays {
+		entry := &relayBids{
+			Relay: relay,
+		}
+		bids, err := obtainRelayBids(ctx, relay, c.timeout, c.results.Slot)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.bids = bids
+			entry.Best = bestBid(bids)
+		}
+		c.results.RelayBids = append(c.results.RelayBids, entry)
+	}
+
+	if c.results.Upcoming {
+		return c.processUpcoming(ctx)
+	}
+
+	return c.processPast(ctx)
+}
+
+// processUpcoming obtains proposals that favour local and builder payloads
+// for an upcoming slot.
+func (c *command) processUpcoming(ctx context.Context) error {
+	epoch := c.chainTime.SlotToEpoch(c.results.Slot)
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer duties")
+	}
+	duties := dutiesResponse.Data
+	for _, duty := range duties {
+		if duty.Slot == c.results.Slot {
+			c.results.ProposerIndex = duty.ValidatorIndex
+			break
+		}
+	}
+
+	// The proposer's randao reveal is not available, so the proposals are
+	// requested without randao verification.
+	graffiti := make([]byte, util.GraffitiLength)
+
+	// A builder boost factor of 0 requests a locally-built payload.
+	localBoostFactor := uint64(0)
+	localValues, found, err := util.BlockProposalValues(ctx, c.eth2Client, c.timeout, c.results.Slot, nil, graffiti, &localBoostFactor)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain local proposal")
+	}
+	if !found {
+		return errors.New("node does not support v3 of the block production API")
+	}
+	c.results.LocalValue = localValues.ExecutionPayloadValue
+
+	// The maximum builder boost factor requests a builder payload where available.
+	builderBoostFactor := uint64(math.MaxUint64)
+	builderValues, found, err := util.BlockProposalValues(ctx, c.eth2Client, c.timeout, c.results.Slot, nil, graffiti, &builderBoostFactor)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain builder proposal")
+	}
+	if found && builderValues.Blinded {
+		c.results.BuilderValue = builderValues.ExecutionPayloadValue
+	} else if c.debug {
+		fmt.Fprintf(os.Stderr, "No builder payload available for slot %d\n", c.results.Slot)
+	}
+
+	return nil
+}
+
+// processPast obtains the source and value of the payload included in the
+// block at a past slot.
+func (c *command) processPast(ctx context.Context) error {
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", c.results.Slot)}))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block")
+	}
+	if block == nil {
+		return fmt.Errorf("no block at slot %d", c.results.Slot)
+	}
+	proposerIndex, blockHash, err := payloadInfo(block)
+	if err != nil {
+		return err
+	}
+	c.results.ProposerIndex = proposerIndex
+	c.results.BlockHash = &blockHash
+
+	if len(c.relays) == 0 {
+		c.results.PayloadSource = payloadSourceUnknown
+		return nil
+	}
+
+	c.results.PayloadSource, c.results.BuilderValue = payloadSource(c.results.RelayBids, blockHash)
+
+	return nil
+}
+
+// payloadSource returns the source of the payload with the given block hash,
+// along with the value of the builder payload: the value of the payload if it
+// was supplied by a relay, otherwise the value of the best bid forgone.
+func payloadSource(relays []*relayBids, blockHash phase0.Hash32) (string, *big.Int) {
+	for _, relay := range relays {
+		if delivered := matchingBid(relay.bids, blockHash); delivered != nil {
+			return fmt.Sprintf("relay %s", relay.Relay), delivered.Value
+		}
+	}
+
+	var best *util.RelayBidTrace
+	for _, relay := range relays {
+		if relay.Best != nil && (best == nil || relay.Best.Value.Cmp(best.Value) > 0) {
+			best = relay.Best
+		}
+	}
+	if best == nil {
+		return payloadSourceLocal, nil
+	}
+
+	return payloadSourceLocal, best.Value
+}
+
+// payloadInfo obtains the proposer index and execution payload block hash of a block.
+func payloadInfo(block *spec.VersionedSignedBeaconBlock) (phase0.ValidatorIndex, phase0.Hash32, error) {
+	if block.Version == spec.DataVersionPhase0 || block.Version == spec.DataVersionAltair {
+		return 0, phase0.Hash32{}, errors.New("block does not have an execution payload")
+	}
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return 0, phase0.Hash32{}, errors.Wrap(err, "failed to obtain proposer index")
+	}
+	blockHash, err := block.ExecutionBlockHash()
+	if err != nil {
+		return 0, phase0.Hash32{}, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+	if blockHash == (phase0.Hash32{}) {
+		return 0, phase0.Hash32{}, errors.New("block does not have an execution payload")
+	}
+
+	return proposerIndex, blockHash, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+
+	return nil
+}
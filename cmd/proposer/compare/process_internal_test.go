@@ -0,0 +1,233 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestPayloadSource(t *testing.T) {
+	relay1Bids := []*util.RelayBidTrace{
+		{BlockHash: phase0.Hash32{0x01}, Value: big.NewInt(1000)},
+		{BlockHash: phase0.Hash32{0x02}, Value: big.NewInt(3000)},
+	}
+	relay2Bids := []*util.RelayBidTrace{
+		{BlockHash: phase0.Hash32{0x03}, Value: big.NewInt(2000)},
+	}
+	relays := []*relayBids{
+		{Relay: "https://relay1", bids: relay1Bids, Best: bestBid(relay1Bids)},
+		{Relay: "https://relay2", bids: relay2Bids, Best: bestBid(relay2Bids)},
+		{Relay: "https://relay3", Error: "failed to call relay"},
+	}
+
+	tests := []struct {
+		name      string
+		relays    []*relayBids
+		blockHash phase0.Hash32
+		source    string
+		value     *big.Int
+	}{
+		{
+			name:      "Relay",
+			relays:    relays,
+			blockHash: phase0.Hash32{0x03},
+			source:    "relay https://relay2",
+			value:     big.NewInt(2000),
+		},
+		{
+			name:      "RelayNotBest",
+			relays:    relays,
+			blockHash: phase0.Hash32{0x01},
+			source:    "relay https://relay1",
+			value:     big.NewInt(1000),
+		},
+		{
+			name:      "Local",
+			relays:    relays,
+			blockHash: phase0.Hash32{0x04},
+			source:    payloadSourceLocal,
+			value:     big.NewInt(3000),
+		},
+		{
+			name:      "LocalNoBids",
+			relays:    []*relayBids{{Relay: "https://relay1"}},
+			blockHash: phase0.Hash32{0x04},
+			source:    payloadSourceLocal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source, value := payloadSource(test.relays, test.blockHash)
+			require.Equal(t, test.source, source)
+			require.Equal(t, test.value, value)
+		})
+	}
+}
+
+func TestPayloadInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		block         *spec.VersionedSignedBeaconBlock
+		proposerIndex phase0.ValidatorIndex
+		blockHash     phase0.Hash32
+		err           string
+	}{
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+			err: "block does not have an execution payload",
+		},
+		{
+			name: "Deneb",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionDeneb,
+				Deneb: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						ProposerIndex: 1,
+						Body: &deneb.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{BlockHash: phase0.Hash32{0x01}},
+						},
+					},
+				},
+			},
+			proposerIndex: 1,
+			blockHash:     phase0.Hash32{0x01},
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						ProposerIndex: 2,
+						Body: &electra.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{BlockHash: phase0.Hash32{0x02}},
+						},
+					},
+				},
+			},
+			proposerIndex: 2,
+			blockHash:     phase0.Hash32{0x02},
+		},
+		{
+			name: "ElectraEmptyPayload",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Body: &electra.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{},
+						},
+					},
+				},
+			},
+			err: "block does not have an execution payload",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proposerIndex, blockHash, err := payloadInfo(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.proposerIndex, proposerIndex)
+				require.Equal(t, test.blockHash, blockHash)
+			}
+		})
+	}
+}
+
+func TestPremium(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  *results
+		expected *big.Int
+	}{
+		{
+			name:    "NoValues",
+			results: &results{},
+		},
+		{
+			name: "NoBuilder",
+			results: &results{
+				LocalValue: big.NewInt(1000),
+			},
+		},
+		{
+			name: "Premium",
+			results: &results{
+				LocalValue:   big.NewInt(1000),
+				BuilderValue: big.NewInt(1500),
+			},
+			expected: big.NewInt(500),
+		},
+		{
+			name: "Negative",
+			results: &results{
+				LocalValue:   big.NewInt(1500),
+				BuilderValue: big.NewInt(1000),
+			},
+			expected: big.NewInt(-500),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.results.premium())
+		})
+	}
+}
+
+func TestOutputText(t *testing.T) {
+	c := &command{
+		results: &results{
+			Slot:          100,
+			Upcoming:      true,
+			ProposerIndex: 5,
+			LocalValue:    big.NewInt(40000000000000000),
+			BuilderValue:  big.NewInt(50000000000000000),
+			RelayBids: []*relayBids{
+				{Relay: "https://relay1"},
+				{Relay: "https://relay2", Error: "failed to call relay"},
+			},
+		},
+	}
+
+	res, err := c.outputText(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Slot: 100 (upcoming)
+Proposer index: 5
+Local payload value: 0.04 Ether
+Builder payload value: 0.05 Ether
+MEV premium: 0.01 Ether (25.00%)
+Relay bids:
+  https://relay1: no bids
+  https://relay2: error (failed to call relay)`, res)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// obtainRelayBids obtains the bids received by the relay for the given slot,
+// using the relay data API.
+func obtainRelayBids(ctx context.Context,
+	relay string,
+	timeout time.Duration,
+	slot phase0.Slot,
+) (
+	[]*util.RelayBidTrace,
+	error,
+) {
+	return util.ObtainRelayBidTraces(ctx, relay, fmt.Sprintf("builder_blocks_received?slot=%d", slot), timeout)
+}
+
+// bestBid returns the highest value bid, or nil if there are no bids.
+func bestBid(bids []*util.RelayBidTrace) *util.RelayBidTrace {
+	var res *util.RelayBidTrace
+	for _, entry := range bids {
+		if res == nil || entry.Value.Cmp(res.Value) > 0 {
+			res = entry
+		}
+	}
+
+	return res
+}
+
+// matchingBid returns the bid for the given block hash, or nil if there is none.
+func matchingBid(bids []*util.RelayBidTrace, blockHash phase0.Hash32) *util.RelayBidTrace {
+	for _, entry := range bids {
+		if entry.BlockHash == blockHash {
+			return entry
+		}
+	}
+
+	return nil
+}
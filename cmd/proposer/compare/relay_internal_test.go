@@ -0,0 +1,36 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposercompare
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestBestBid(t *testing.T) {
+	bids := []*util.RelayBidTrace{
+		{BlockHash: phase0.Hash32{0x01}, Value: big.NewInt(1000)},
+		{BlockHash: phase0.Hash32{0x02}, Value: big.NewInt(3000)},
+		{BlockHash: phase0.Hash32{0x03}, Value: big.NewInt(2000)},
+	}
+
+	require.Nil(t, bestBid(nil))
+	require.Equal(t, bids[1], bestBid(bids))
+	require.Equal(t, bids[2], matchingBid(bids, phase0.Hash32{0x03}))
+	require.Nil(t, matchingBid(bids, phase0.Hash32{0x04}))
+}
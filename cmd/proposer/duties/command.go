@@ -19,7 +19,6 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
-	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
@@ -49,7 +48,7 @@ type command struct {
 }
 
 type results struct {
-	Epoch  phase0.Epoch          `json:"epoch"`
+	Epoch  uint64                `json:"epoch"`
 	Duties []*apiv1.ProposerDuty `json:"duties"`
 }
 
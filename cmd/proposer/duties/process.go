@@ -17,6 +17,7 @@ import (
 	"context"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -29,15 +30,17 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.results.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
+	c.results.Epoch = uint64(epoch)
 
-	c.results.Duties, err = c.proposerDutiesProvider.ProposerDuties(ctx, c.results.Epoch, nil)
+	proposerDutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain proposer duties")
 	}
+	c.results.Duties = proposerDutiesResponse.Data
 
 	return nil
 }
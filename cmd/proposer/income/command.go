@@ -0,0 +1,236 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util/output"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+	executionClient     execution.Service
+
+	// Input.
+	feeRecipient bellatrix.ExecutionAddress
+	fromEpoch    string
+	toEpoch      string
+	validators   []string
+	relays       []string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Output.
+	results *results
+	fiat    *output.Fiat
+}
+
+type results struct {
+	FeeRecipient  bellatrix.ExecutionAddress
+	FromSlot      phase0.Slot
+	ToSlot        phase0.Slot
+	Blocks        []*blockIncome
+	Proposers     []*proposerIncome
+	PriorityFees  *big.Int
+	MEVPayments   *big.Int
+	Received      *big.Int
+	Expected      *big.Int
+	Discrepancies int
+}
+
+type resultsJSON struct {
+	FeeRecipient  string            `json:"fee_recipient"`
+	FromSlot      phase0.Slot       `json:"from_slot"`
+	ToSlot        phase0.Slot       `json:"to_slot"`
+	Blocks        []*blockIncome    `json:"blocks"`
+	Proposers     []*proposerIncome `json:"proposers"`
+	PriorityFees  string            `json:"priority_fees"`
+	MEVPayments   string            `json:"mev_payments"`
+	Received      string            `json:"received"`
+	Expected      string            `json:"expected"`
+	Discrepancies int               `json:"discrepancies"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *results) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&resultsJSON{
+		FeeRecipient:  r.FeeRecipient.String(),
+		FromSlot:      r.FromSlot,
+		ToSlot:        r.ToSlot,
+		Blocks:        r.Blocks,
+		Proposers:     r.Proposers,
+		PriorityFees:  weiString(r.PriorityFees),
+		MEVPayments:   weiString(r.MEVPayments),
+		Received:      weiString(r.Received),
+		Expected:      weiString(r.Expected),
+		Discrepancies: r.Discrepancies,
+	})
+}
+
+// blockIncome is the income from a single block.
+type blockIncome struct {
+	Slot          phase0.Slot
+	ProposerIndex phase0.ValidatorIndex
+	BlockNumber   uint64
+	BlockHash     phase0.Hash32
+	PriorityFees  *big.Int
+	MEVPayment    *big.Int
+	// Expected is the value that a relay reports as delivered to the proposer, if any.
+	Expected *big.Int
+	// Issue is a description of any discrepancy between the expected and received values.
+	Issue string
+}
+
+type blockIncomeJSON struct {
+	Slot          phase0.Slot           `json:"slot"`
+	ProposerIndex phase0.ValidatorIndex `json:"proposer_index"`
+	BlockNumber   uint64                `json:"block_number"`
+	BlockHash     phase0.Hash32         `json:"block_hash"`
+	PriorityFees  string                `json:"priority_fees"`
+	MEVPayment    string                `json:"mev_payment"`
+	Received      string                `json:"received"`
+	Expected      string                `json:"expected,omitempty"`
+	Issue         string                `json:"issue,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *blockIncome) MarshalJSON() ([]byte, error) {
+	data := &blockIncomeJSON{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		BlockNumber:   b.BlockNumber,
+		BlockHash:     b.BlockHash,
+		PriorityFees:  weiString(b.PriorityFees),
+		MEVPayment:    weiString(b.MEVPayment),
+		Received:      weiString(b.received()),
+		Issue:         b.Issue,
+	}
+	if b.Expected != nil {
+		data.Expected = b.Expected.String()
+	}
+
+	return json.Marshal(data)
+}
+
+// received returns the total value received from the block.
+func (b *blockIncome) received() *big.Int {
+	return new(big.Int).Add(weiOrZero(b.PriorityFees), weiOrZero(b.MEVPayment))
+}
+
+// proposerIncome is the income attributed to a single proposer.
+type proposerIncome struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Blocks         int
+	Received       *big.Int
+}
+
+type proposerIncomeJSON struct {
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Blocks         int                   `json:"blocks"`
+	Received       string                `json:"received"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *proposerIncome) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&proposerIncomeJSON{
+		ValidatorIndex: p.ValidatorIndex,
+		Blocks:         p.Blocks,
+		Received:       weiString(p.Received),
+	})
+}
+
+// weiString returns the string representation of a wei value.
+func weiString(val *big.Int) string {
+	return weiOrZero(val).String()
+}
+
+// weiOrZero returns the wei value, or zero if it is not present.
+func weiOrZero(val *big.Int) *big.Int {
+	if val == nil {
+		return new(big.Int)
+	}
+	return val
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.executionConnection = viper.GetString("execution-connection")
+	if c.executionConnection == "" {
+		return nil, errors.New("execution connection is required")
+	}
+
+	if viper.GetString("fee-recipient") == "" {
+		return nil, errors.New("fee recipient is required")
+	}
+	feeRecipient, err := bytesutil.FromHexString(viper.GetString("fee-recipient"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid fee recipient")
+	}
+	if len(feeRecipient) != bellatrix.ExecutionAddressLength {
+		return nil, errors.New("fee recipient must be 20 bytes")
+	}
+	copy(c.feeRecipient[:], feeRecipient)
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+	c.validators = viper.GetStringSlice("validators")
+	c.relays = viper.GetStringSlice("relays")
+
+	return c, nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"execution-connection": "http://localhost:8545",
+				"fee-recipient":        "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				"from-epoch":           "1",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ExecutionConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"fee-recipient": "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				"from-epoch":    "1",
+			},
+			err: "execution connection is required",
+		},
+		{
+			name: "FeeRecipientMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"from-epoch":           "1",
+			},
+			err: "fee recipient is required",
+		},
+		{
+			name: "FeeRecipientInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"fee-recipient":        "invalid",
+				"from-epoch":           "1",
+			},
+			err: "invalid fee recipient: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "FeeRecipientShort",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"fee-recipient":        "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac",
+				"from-epoch":           "1",
+			},
+			err: "fee recipient must be 20 bytes",
+		},
+		{
+			name: "FromEpochMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"fee-recipient":        "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			},
+			err: "from epoch is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"fee-recipient":        "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				"from-epoch":           "-10",
+				"relays":               []string{"https://relay.example.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
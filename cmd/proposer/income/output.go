@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Fee recipient: %s\n", c.results.FeeRecipient.String()))
+	builder.WriteString(fmt.Sprintf("Slots: %d-%d\n", c.results.FromSlot, c.results.ToSlot))
+	builder.WriteString(fmt.Sprintf("Blocks: %d\n", len(c.results.Blocks)))
+
+	if c.verbose {
+		for _, block := range c.results.Blocks {
+			builder.WriteString(fmt.Sprintf("Slot %d (block %d, proposer %d): priority fees %s, MEV payment %s",
+				block.Slot,
+				block.BlockNumber,
+				block.ProposerIndex,
+				string2eth.WeiToString(weiOrZero(block.PriorityFees), true),
+				string2eth.WeiToString(weiOrZero(block.MEVPayment), true),
+			))
+			if block.Expected != nil {
+				builder.WriteString(fmt.Sprintf(", relay value %s", string2eth.WeiToString(block.Expected, true)))
+			}
+			builder.WriteString("\n")
+		}
+		for _, proposer := range c.results.Proposers {
+			builder.WriteString(fmt.Sprintf("Validator %d: %d blocks, received %s\n", proposer.ValidatorIndex, proposer.Blocks, c.formatWei(proposer.Received)))
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf("Priority fees: %s\n", c.formatWei(c.results.PriorityFees)))
+	builder.WriteString(fmt.Sprintf("MEV payments: %s\n", c.formatWei(c.results.MEVPayments)))
+	builder.WriteString(fmt.Sprintf("Received: %s\n", c.formatWei(c.results.Received)))
+	builder.WriteString(fmt.Sprintf("Expected: %s\n", c.formatWei(c.results.Expected)))
+
+	if c.results.Discrepancies > 0 {
+		builder.WriteString(fmt.Sprintf("Discrepancies: %d\n", c.results.Discrepancies))
+		for _, block := range c.results.Blocks {
+			if block.Issue != "" {
+				builder.WriteString(fmt.Sprintf("  Slot %d (block %d, proposer %d): %s\n", block.Slot, block.BlockNumber, block.ProposerIndex, block.Issue))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// formatWei formats a value in Wei, with its fiat equivalent if requested.
+func (c *command) formatWei(value *big.Int) string {
+	if c.fiat != nil {
+		return fmt.Sprintf("%s (%s)", string2eth.WeiToString(value, true), c.fiat.Wei(value))
+	}
+
+	return string2eth.WeiToString(value, true)
+}
@@ -0,0 +1,418 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// payloadInfo is the information about an execution payload required for reconciliation.
+type payloadInfo struct {
+	proposerIndex phase0.ValidatorIndex
+	blockNumber   uint64
+	blockHash     phase0.Hash32
+	feeRecipient  bellatrix.ExecutionAddress
+}
+
+type executionBlockJSON struct {
+	BaseFeePerGas string                      `json:"baseFeePerGas"`
+	Transactions  []*executionTransactionJSON `json:"transactions"`
+}
+
+type executionTransactionJSON struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+}
+
+type executionReceiptJSON struct {
+	GasUsed           string `json:"gasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch must not be before from epoch")
+	}
+	fromSlot := c.chainTime.FirstSlotOfEpoch(fromEpoch)
+	toSlot := c.chainTime.LastSlotOfEpoch(toEpoch)
+	if toSlot > c.chainTime.CurrentSlot() {
+		toSlot = c.chainTime.CurrentSlot()
+	}
+
+	expectedProposers := make(map[phase0.ValidatorIndex]bool)
+	if len(c.validators) > 0 {
+		validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+		if err != nil {
+			return errors.Wrap(err, "failed to parse validators")
+		}
+		for _, validator := range validators {
+			expectedProposers[validator.Index] = true
+		}
+	}
+
+	c.results = &results{
+		FeeRecipient: c.feeRecipient,
+		FromSlot:     fromSlot,
+		ToSlot:       toSlot,
+		Blocks:       make([]*blockIncome, 0),
+		PriorityFees: new(big.Int),
+		MEVPayments:  new(big.Int),
+		Received:     new(big.Int),
+		Expected:     new(big.Int),
+	}
+	proposers := make(map[phase0.ValidatorIndex]*proposerIncome)
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		income, err := c.processSlot(ctx, slot, expectedProposers)
+		if err != nil {
+			return err
+		}
+		if income == nil {
+			continue
+		}
+		c.results.Blocks = append(c.results.Blocks, income)
+
+		received := income.received()
+		if income.PriorityFees != nil {
+			c.results.PriorityFees.Add(c.results.PriorityFees, income.PriorityFees)
+		}
+		if income.MEVPayment != nil {
+			c.results.MEVPayments.Add(c.results.MEVPayments, income.MEVPayment)
+		}
+		c.results.Received.Add(c.results.Received, received)
+		if income.Expected != nil {
+			c.results.Expected.Add(c.results.Expected, income.Expected)
+		} else {
+			c.results.Expected.Add(c.results.Expected, received)
+		}
+		if income.Issue != "" {
+			c.results.Discrepancies++
+		}
+
+		if _, exists := proposers[income.ProposerIndex]; !exists {
+			proposers[income.ProposerIndex] = &proposerIncome{
+				ValidatorIndex: income.ProposerIndex,
+				Received:       new(big.Int),
+			}
+		}
+		proposers[income.ProposerIndex].Blocks++
+		proposers[income.ProposerIndex].Received.Add(proposers[income.ProposerIndex].Received, received)
+	}
+
+	c.results.Proposers = make([]*proposerIncome, 0, len(proposers))
+	for _, proposer := range proposers {
+		c.results.Proposers = append(c.results.Proposers, proposer)
+	}
+	sort.Slice(c.results.Proposers, func(i, j int) bool {
+		return c.results.Proposers[i].ValidatorIndex < c.results.Proposers[j].ValidatorIndex
+	})
+
+	if !c.json {
+		c.fiat, err = util.FiatFromViper(ctx, c.chainTime.StartOfSlot(toSlot+1))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processSlot processes a single slot, returning the income for the fee
+// recipient from the block at the slot, or nil if the block is not relevant.
+func (c *command) processSlot(ctx context.Context,
+	slot phase0.Slot,
+	expectedProposers map[phase0.ValidatorIndex]bool,
+) (
+	*blockIncome,
+	error,
+) {
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block == nil {
+		// Missed slot.
+		return nil, nil
+	}
+	payload, err := obtainPayloadInfo(block)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		// Pre-merge block.
+		return nil, nil
+	}
+
+	executionBlock := &executionBlockJSON{}
+	found, err := c.executionClient.Call(ctx, "eth_getBlockByHash", []interface{}{payload.blockHash.String(), true}, executionBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain execution block %d", payload.blockNumber))
+	}
+	if !found {
+		return nil, fmt.Errorf("execution node does not have block %d", payload.blockNumber)
+	}
+
+	local := payload.feeRecipient == c.feeRecipient
+	mev, err := mevPayment(executionBlock.Transactions, payload.feeRecipient, c.feeRecipient)
+	if err != nil {
+		return nil, err
+	}
+	expectedProposer := expectedProposers[payload.proposerIndex]
+	if !local && mev == nil && !expectedProposer {
+		return nil, nil
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Slot %d: block %d proposed by %d is relevant\n", slot, payload.blockNumber, payload.proposerIndex)
+	}
+
+	income := &blockIncome{
+		Slot:          slot,
+		ProposerIndex: payload.proposerIndex,
+		BlockNumber:   payload.blockNumber,
+		BlockHash:     payload.blockHash,
+		MEVPayment:    mev,
+	}
+
+	if local {
+		receipts := make([]*executionReceiptJSON, 0)
+		found, err := c.executionClient.Call(ctx, "eth_getBlockReceipts", []interface{}{payload.blockHash.String()}, &receipts)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain receipts for execution block %d", payload.blockNumber))
+		}
+		if !found {
+			return nil, fmt.Errorf("execution node does not have receipts for block %d", payload.blockNumber)
+		}
+		income.PriorityFees, err = priorityFees(executionBlock.BaseFeePerGas, receipts)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to calculate priority fees for execution block %d", payload.blockNumber))
+		}
+	}
+
+	var delivery *util.RelayBidTrace
+	for _, relay := range c.relays {
+		delivery, err = obtainRelayDelivery(ctx, relay, c.timeout, slot, payload.blockHash)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain delivery from relay %s", relay))
+		}
+		if delivery != nil {
+			break
+		}
+	}
+	if delivery != nil {
+		income.Expected = delivery.Value
+	}
+	income.Issue = assess(income, delivery, expectedProposer, c.feeRecipient)
+
+	return income, nil
+}
+
+// obtainPayloadInfo obtains information about the execution payload of a block.
+// It returns nil if the block does not contain an execution payload.
+func obtainPayloadInfo(block *spec.VersionedSignedBeaconBlock) (*payloadInfo, error) {
+	if block.Version == spec.DataVersionPhase0 || block.Version == spec.DataVersionAltair {
+		return nil, nil
+	}
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer index")
+	}
+	payload, err := block.ExecutionPayload()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution payload")
+	}
+	res := &payloadInfo{
+		proposerIndex: proposerIndex,
+	}
+	res.blockNumber, err = payload.BlockNumber()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block number")
+	}
+	res.blockHash, err = payload.BlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+	res.feeRecipient, err = payload.FeeRecipient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain fee recipient")
+	}
+	if res.blockHash == (phase0.Hash32{}) {
+		// Pre-merge Bellatrix block.
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+// mevPayment returns the value of the builder payment to the fee recipient in
+// the given transactions, or nil if there is no such payment.
+//
+// A builder payment is the final transaction in the block, sent from the
+// block's fee recipient (the builder) to the proposer's fee recipient.
+func mevPayment(transactions []*executionTransactionJSON,
+	blockFeeRecipient bellatrix.ExecutionAddress,
+	feeRecipient bellatrix.ExecutionAddress,
+) (
+	*big.Int,
+	error,
+) {
+	if len(transactions) == 0 || blockFeeRecipient == feeRecipient {
+		return nil, nil
+	}
+
+	tx := transactions[len(transactions)-1]
+	if !strings.EqualFold(tx.To, fmt.Sprintf("%#x", feeRecipient)) ||
+		!strings.EqualFold(tx.From, fmt.Sprintf("%#x", blockFeeRecipient)) {
+		return nil, nil
+	}
+	value, err := hexToBigInt(tx.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid transaction value")
+	}
+
+	return value, nil
+}
+
+// priorityFees returns the priority fees paid to the fee recipient by the
+// transactions with the given receipts.
+func priorityFees(baseFeePerGasStr string, receipts []*executionReceiptJSON) (*big.Int, error) {
+	baseFeePerGas, err := hexToBigInt(baseFeePerGasStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base fee per gas")
+	}
+
+	res := new(big.Int)
+	for _, receipt := range receipts {
+		gasUsed, err := hexToBigInt(receipt.GasUsed)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid gas used")
+		}
+		effectiveGasPrice, err := hexToBigInt(receipt.EffectiveGasPrice)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid effective gas price")
+		}
+		fee := new(big.Int).Sub(effectiveGasPrice, baseFeePerGas)
+		res.Add(res, fee.Mul(fee, gasUsed))
+	}
+
+	return res, nil
+}
+
+// assess returns a description of any discrepancy between the income received
+// for a block and the income expected, or an empty string if there is none.
+func assess(income *blockIncome,
+	delivery *util.RelayBidTrace,
+	expectedProposer bool,
+	feeRecipient bellatrix.ExecutionAddress,
+) string {
+	received := income.received()
+
+	if delivery != nil {
+		if delivery.ProposerFeeRecipient != feeRecipient {
+			return fmt.Sprintf("misrouted: relay delivered payload for fee recipient %s", delivery.ProposerFeeRecipient.String())
+		}
+		if received.Cmp(delivery.Value) < 0 {
+			return fmt.Sprintf("underpaid: received %s less than relay value", string2eth.WeiToString(new(big.Int).Sub(delivery.Value, received), true))
+		}
+	}
+	if expectedProposer && received.Sign() == 0 {
+		return "misrouted: no payment to fee recipient"
+	}
+
+	return ""
+}
+
+// hexToBigInt converts a hex quantity to a big integer.
+func hexToBigInt(input string) (*big.Int, error) {
+	input = strings.TrimPrefix(input, "0x")
+	if input == "" {
+		return new(big.Int), nil
+	}
+	res, success := new(big.Int).SetString(input, 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block information")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
@@ -0,0 +1,299 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+var (
+	testFeeRecipient = bellatrix.ExecutionAddress{0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15}
+	testBuilder      = bellatrix.ExecutionAddress{0x95, 0x22, 0x22, 0x90, 0xdd, 0x72, 0x78, 0xaa, 0x3d, 0xdd, 0x38, 0x9c, 0xc1, 0xe1, 0xd1, 0x65, 0xcc, 0x4b, 0xaf, 0xe5}
+)
+
+func TestObtainPayloadInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		block    *spec.VersionedSignedBeaconBlock
+		expected *payloadInfo
+		err      string
+	}{
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+		},
+		{
+			name: "PreMergeBellatrix",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionBellatrix,
+				Bellatrix: &bellatrix.SignedBeaconBlock{
+					Message: &bellatrix.BeaconBlock{
+						Body: &bellatrix.BeaconBlockBody{
+							ExecutionPayload: &bellatrix.ExecutionPayload{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Deneb",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionDeneb,
+				Deneb: &deneb.SignedBeaconBlock{
+					Message: &deneb.BeaconBlock{
+						ProposerIndex: 1,
+						Body: &deneb.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{
+								BlockNumber:  100,
+								BlockHash:    phase0.Hash32{0x01},
+								FeeRecipient: testFeeRecipient,
+							},
+						},
+					},
+				},
+			},
+			expected: &payloadInfo{
+				proposerIndex: 1,
+				blockNumber:   100,
+				blockHash:     phase0.Hash32{0x01},
+				feeRecipient:  testFeeRecipient,
+			},
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						ProposerIndex: 2,
+						Body: &electra.BeaconBlockBody{
+							ExecutionPayload: &deneb.ExecutionPayload{
+								BlockNumber:  200,
+								BlockHash:    phase0.Hash32{0x02},
+								FeeRecipient: testBuilder,
+							},
+						},
+					},
+				},
+			},
+			expected: &payloadInfo{
+				proposerIndex: 2,
+				blockNumber:   200,
+				blockHash:     phase0.Hash32{0x02},
+				feeRecipient:  testBuilder,
+			},
+		},
+		{
+			name: "ElectraMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+			},
+			err: "failed to obtain proposer index: no electra block",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := obtainPayloadInfo(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestMEVPayment(t *testing.T) {
+	payment := &executionTransactionJSON{
+		From:  "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+		To:    "0x8C1FF978036F2E9D7CC382EFF7B4C8C53C22AC15",
+		Value: "0xde0b6b3a7640000",
+	}
+	other := &executionTransactionJSON{
+		From:  "0x0000000000000000000000000000000000000001",
+		To:    "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+		Value: "0x1",
+	}
+
+	tests := []struct {
+		name              string
+		transactions      []*executionTransactionJSON
+		blockFeeRecipient bellatrix.ExecutionAddress
+		expected          *big.Int
+		err               string
+	}{
+		{
+			name:              "NoTransactions",
+			blockFeeRecipient: testBuilder,
+		},
+		{
+			name:              "LocalBlock",
+			transactions:      []*executionTransactionJSON{payment},
+			blockFeeRecipient: testFeeRecipient,
+		},
+		{
+			name:              "NotFromBuilder",
+			transactions:      []*executionTransactionJSON{payment, other},
+			blockFeeRecipient: testBuilder,
+		},
+		{
+			name:              "NotLast",
+			transactions:      []*executionTransactionJSON{payment, {From: "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5", To: "0x0000000000000000000000000000000000000002"}},
+			blockFeeRecipient: testBuilder,
+		},
+		{
+			name:              "InvalidValue",
+			transactions:      []*executionTransactionJSON{{From: payment.From, To: payment.To, Value: "0xzz"}},
+			blockFeeRecipient: testBuilder,
+			err:               `invalid transaction value: invalid quantity "zz"`,
+		},
+		{
+			name:              "Good",
+			transactions:      []*executionTransactionJSON{other, payment},
+			blockFeeRecipient: testBuilder,
+			expected:          big.NewInt(1000000000000000000),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := mevPayment(test.transactions, test.blockFeeRecipient, testFeeRecipient)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestPriorityFees(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseFeePerGas string
+		receipts      []*executionReceiptJSON
+		expected      *big.Int
+		err           string
+	}{
+		{
+			name:          "Empty",
+			baseFeePerGas: "0x3b9aca00",
+			expected:      big.NewInt(0),
+		},
+		{
+			name:          "InvalidBaseFee",
+			baseFeePerGas: "0xzz",
+			err:           `invalid base fee per gas: invalid quantity "zz"`,
+		},
+		{
+			name:          "InvalidGasUsed",
+			baseFeePerGas: "0x3b9aca00",
+			receipts:      []*executionReceiptJSON{{GasUsed: "0xzz", EffectiveGasPrice: "0x3b9aca00"}},
+			err:           `invalid gas used: invalid quantity "zz"`,
+		},
+		{
+			name:          "Good",
+			baseFeePerGas: "0x3b9aca00",
+			receipts: []*executionReceiptJSON{
+				// 21000 gas at 2 gwei.
+				{GasUsed: "0x5208", EffectiveGasPrice: "0x77359400"},
+				// 100000 gas at the base fee.
+				{GasUsed: "0x186a0", EffectiveGasPrice: "0x3b9aca00"},
+			},
+			expected: big.NewInt(21000000000000),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := priorityFees(test.baseFeePerGas, test.receipts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, 0, test.expected.Cmp(res))
+			}
+		})
+	}
+}
+
+func TestAssess(t *testing.T) {
+	tests := []struct {
+		name             string
+		income           *blockIncome
+		delivery         *util.RelayBidTrace
+		expectedProposer bool
+		expected         string
+	}{
+		{
+			name:   "Local",
+			income: &blockIncome{PriorityFees: big.NewInt(1000)},
+		},
+		{
+			name:   "PaidInFull",
+			income: &blockIncome{MEVPayment: big.NewInt(1000)},
+			delivery: &util.RelayBidTrace{
+				ProposerFeeRecipient: testFeeRecipient,
+				Value:                big.NewInt(1000),
+			},
+			expectedProposer: true,
+		},
+		{
+			name:   "Underpaid",
+			income: &blockIncome{MEVPayment: big.NewInt(1000)},
+			delivery: &util.RelayBidTrace{
+				ProposerFeeRecipient: testFeeRecipient,
+				Value:                big.NewInt(1000000000000001000),
+			},
+			expected: "underpaid: received 1 Ether less than relay value",
+		},
+		{
+			name:   "RelayMisrouted",
+			income: &blockIncome{},
+			delivery: &util.RelayBidTrace{
+				ProposerFeeRecipient: testBuilder,
+				Value:                big.NewInt(1000),
+			},
+			expected: "misrouted: relay delivered payload for fee recipient 0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5",
+		},
+		{
+			name:             "ProposerMisrouted",
+			income:           &blockIncome{},
+			expectedProposer: true,
+			expected:         "misrouted: no payment to fee recipient",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, assess(test.income, test.delivery, test.expectedProposer, testFeeRecipient))
+		})
+	}
+}
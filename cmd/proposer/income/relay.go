@@ -0,0 +1,55 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// obtainRelayDelivery obtains the payload delivered by the relay for the given
+// slot, using the relay data API.
+// It returns nil if the relay did not deliver the payload with the given hash.
+func obtainRelayDelivery(ctx context.Context,
+	relay string,
+	timeout time.Duration,
+	slot phase0.Slot,
+	blockHash phase0.Hash32,
+) (
+	*util.RelayBidTrace,
+	error,
+) {
+	traces, err := util.ObtainRelayBidTraces(ctx, relay, fmt.Sprintf("proposer_payload_delivered?slot=%d", slot), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchingDelivery(traces, blockHash), nil
+}
+
+// matchingDelivery returns the delivery for the given block hash, or nil if
+// it is not present.
+func matchingDelivery(traces []*util.RelayBidTrace, blockHash phase0.Hash32) *util.RelayBidTrace {
+	for _, trace := range traces {
+		if trace.BlockHash == blockHash {
+			return trace
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerincome
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestMatchingDelivery(t *testing.T) {
+	traces := []*util.RelayBidTrace{
+		{BlockHash: phase0.Hash32{0x03, 0x03}, ProposerFeeRecipient: testFeeRecipient, Value: big.NewInt(2000)},
+		{BlockHash: phase0.Hash32{0x01, 0x02}, ProposerFeeRecipient: testFeeRecipient, Value: big.NewInt(1000)},
+	}
+
+	require.Nil(t, matchingDelivery(nil, phase0.Hash32{0x01, 0x02}))
+	require.Nil(t, matchingDelivery(traces, phase0.Hash32{0x04}))
+	require.Equal(t, traces[1], matchingDelivery(traces, phase0.Hash32{0x01, 0x02}))
+}
@@ -0,0 +1,175 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposersimulate
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	slot         string
+	randaoReveal string
+	privateKey   string
+	graffiti     string
+	full         bool
+	blinded      bool
+
+	// Data access.
+	eth2Client              eth2client.Service
+	chainTime               chaintime.Service
+	specProvider            eth2client.SpecProvider
+	proposerDutiesProvider  eth2client.ProposerDutiesProvider
+	domainProvider          eth2client.DomainProvider
+	proposalProvider        eth2client.ProposalProvider
+	blindedProposalProvider blindedProposalProvider
+
+	// Output.
+	results *results
+}
+
+// blindedProposalProvider obtains blinded proposals.  The client no longer
+// defines an interface for this, as it prefers v3 of the block production API.
+type blindedProposalProvider interface {
+	BlindedProposal(ctx context.Context,
+		opts *api.BlindedProposalOpts,
+	) (
+		*api.Response[*api.VersionedBlindedProposal],
+		error,
+	)
+}
+
+type results struct {
+	Slot         phase0.Slot         `json:"slot"`
+	RandaoReveal phase0.BLSSignature `json:"randao_reveal"`
+	Proposals    []*proposal         `json:"proposals"`
+	Values       *values             `json:"values,omitempty"`
+}
+
+type values struct {
+	Blinded               bool
+	ExecutionPayloadValue *big.Int
+	ConsensusBlockValue   *big.Int
+}
+
+type valuesJSON struct {
+	Blinded               bool   `json:"execution_payload_blinded"`
+	ExecutionPayloadValue string `json:"execution_payload_value,omitempty"`
+	ConsensusBlockValue   string `json:"consensus_block_value,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v *values) MarshalJSON() ([]byte, error) {
+	data := &valuesJSON{
+		Blinded: v.Blinded,
+	}
+	if v.ExecutionPayloadValue != nil {
+		data.ExecutionPayloadValue = v.ExecutionPayloadValue.String()
+	}
+	if v.ConsensusBlockValue != nil {
+		data.ConsensusBlockValue = v.ConsensusBlockValue.String()
+	}
+
+	return json.Marshal(data)
+}
+
+type proposal struct {
+	Type                      string                `json:"type"`
+	Version                   string                `json:"version"`
+	Slot                      phase0.Slot           `json:"slot"`
+	ProposerIndex             phase0.ValidatorIndex `json:"proposer_index"`
+	ParentRoot                phase0.Root           `json:"parent_root"`
+	StateRoot                 phase0.Root           `json:"state_root"`
+	Graffiti                  *util.GraffitiInfo    `json:"graffiti"`
+	Attestations              int                   `json:"attestations"`
+	AttesterSlashings         int                   `json:"attester_slashings"`
+	ProposerSlashings         int                   `json:"proposer_slashings"`
+	Deposits                  int                   `json:"deposits"`
+	VoluntaryExits            int                   `json:"voluntary_exits"`
+	BLSToExecutionChanges     int                   `json:"bls_to_execution_changes"`
+	SyncCommitteeParticipants uint64                `json:"sync_committee_participants"`
+	BlobKZGCommitments        int                   `json:"blob_kzg_commitments"`
+	ExecutionPayload          *executionPayload     `json:"execution_payload,omitempty"`
+}
+
+type executionPayload struct {
+	BlockNumber   uint64        `json:"block_number"`
+	BlockHash     phase0.Hash32 `json:"block_hash"`
+	FeeRecipient  string        `json:"fee_recipient"`
+	GasUsed       uint64        `json:"gas_used"`
+	GasLimit      uint64        `json:"gas_limit"`
+	BaseFeePerGas *big.Int      `json:"base_fee_per_gas"`
+	// Transactions and withdrawals are only available for full proposals.
+	Transactions *int `json:"transactions,omitempty"`
+	Withdrawals  *int `json:"withdrawals,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.slot = viper.GetString("slot")
+	c.randaoReveal = viper.GetString("randao-reveal")
+	c.privateKey = viper.GetString("private-key")
+	if c.randaoReveal != "" && c.privateKey != "" {
+		return nil, errors.New("only one of randao-reveal and private-key can be supplied")
+	}
+	c.graffiti = viper.GetString("graffiti")
+	if len(c.graffiti) > util.GraffitiLength {
+		return nil, errors.New("graffiti is too long")
+	}
+
+	c.full = viper.GetBool("full")
+	c.blinded = viper.GetBool("blinded")
+	if !c.full && !c.blinded {
+		// Default to a full proposal.
+		c.full = true
+	}
+
+	return c, nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposersimulate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    map[string]interface{}
+		full    bool
+		blinded bool
+		err     string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "RandaoRevealAndPrivateKey",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"randao-reveal": "0xc000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				"private-key":   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+			},
+			err: "only one of randao-reveal and private-key can be supplied",
+		},
+		{
+			name: "GraffitiTooLong",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"graffiti": "this graffiti is far too long to fit in to a block",
+			},
+			err: "graffiti is too long",
+		},
+		{
+			name: "Default",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			full: true,
+		},
+		{
+			name: "Blinded",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"blinded": true,
+			},
+			blinded: true,
+		},
+		{
+			name: "Both",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"full":    true,
+				"blinded": true,
+			},
+			full:    true,
+			blinded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.full, cmd.full)
+				require.Equal(t, test.blinded, cmd.blinded)
+			}
+		})
+	}
+}
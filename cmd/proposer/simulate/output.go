@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposersimulate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.results.Slot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Randao reveal: %#x\n", c.results.RandaoReveal))
+	}
+
+	for _, proposal := range c.results.Proposals {
+		builder.WriteString(fmt.Sprintf("Proposal (%s, %s):\n", proposal.Type, proposal.Version))
+		builder.WriteString(fmt.Sprintf("  Proposer index: %d\n", proposal.ProposerIndex))
+		builder.WriteString(fmt.Sprintf("  Parent root: %#x\n", proposal.ParentRoot))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  State root: %#x\n", proposal.StateRoot))
+		}
+		if proposal.Graffiti != nil && proposal.Graffiti.Text != "" {
+			builder.WriteString(fmt.Sprintf("  Graffiti: %s\n", proposal.Graffiti.Text))
+		}
+		builder.WriteString(fmt.Sprintf("  Attestations: %d\n", proposal.Attestations))
+		builder.WriteString(fmt.Sprintf("  Sync committee participants: %d\n", proposal.SyncCommitteeParticipants))
+		if c.verbose || proposal.AttesterSlashings > 0 {
+			builder.WriteString(fmt.Sprintf("  Attester slashings: %d\n", proposal.AttesterSlashings))
+		}
+		if c.verbose || proposal.ProposerSlashings > 0 {
+			builder.WriteString(fmt.Sprintf("  Proposer slashings: %d\n", proposal.ProposerSlashings))
+		}
+		if c.verbose || proposal.Deposits > 0 {
+			builder.WriteString(fmt.Sprintf("  Deposits: %d\n", proposal.Deposits))
+		}
+		if c.verbose || proposal.VoluntaryExits > 0 {
+			builder.WriteString(fmt.Sprintf("  Voluntary exits: %d\n", proposal.VoluntaryExits))
+		}
+		if c.verbose || proposal.BLSToExecutionChanges > 0 {
+			builder.WriteString(fmt.Sprintf("  BLS to execution changes: %d\n", proposal.BLSToExecutionChanges))
+		}
+		if c.verbose || proposal.BlobKZGCommitments > 0 {
+			builder.WriteString(fmt.Sprintf("  Blob KZG commitments: %d\n", proposal.BlobKZGCommitments))
+		}
+		if payload := proposal.ExecutionPayload; payload != nil {
+			builder.WriteString("  Execution payload:\n")
+			builder.WriteString(fmt.Sprintf("    Block number: %d\n", payload.BlockNumber))
+			builder.WriteString(fmt.Sprintf("    Block hash: %#x\n", payload.BlockHash))
+			builder.WriteString(fmt.Sprintf("    Fee recipient: %s\n", payload.FeeRecipient))
+			builder.WriteString(fmt.Sprintf("    Gas used: %d / %d\n", payload.GasUsed, payload.GasLimit))
+			builder.WriteString(fmt.Sprintf("    Base fee per gas: %s\n", string2eth.WeiToGWeiString(payload.BaseFeePerGas)))
+			if payload.Transactions != nil {
+				builder.WriteString(fmt.Sprintf("    Transactions: %d\n", *payload.Transactions))
+			}
+			if payload.Withdrawals != nil {
+				builder.WriteString(fmt.Sprintf("    Withdrawals: %d\n", *payload.Withdrawals))
+			}
+		}
+	}
+
+	if c.results.Values == nil {
+		builder.WriteString("Value: not reported by node\n")
+	} else {
+		if c.results.Values.ExecutionPayloadValue != nil {
+			builder.WriteString(fmt.Sprintf("Execution payload value: %s", string2eth.WeiToString(c.results.Values.ExecutionPayloadValue, true)))
+			if c.results.Values.Blinded {
+				builder.WriteString(" (blinded)")
+			}
+			builder.WriteString("\n")
+		}
+		if c.results.Values.ConsensusBlockValue != nil {
+			builder.WriteString(fmt.Sprintf("Consensus block value: %s\n", string2eth.WeiToString(c.results.Values.ConsensusBlockValue, true)))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
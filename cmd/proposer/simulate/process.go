@@ -0,0 +1,471 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposersimulate
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.results = &results{
+		Proposals: make([]*proposal, 0, 2),
+	}
+
+	if c.slot == "" {
+		c.results.Slot = c.chainTime.CurrentSlot() + 1
+	} else {
+		slot, err := strconv.ParseUint(c.slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid slot")
+		}
+		c.results.Slot = phase0.Slot(slot)
+	}
+
+	proposerRandaoReveal, err := c.obtainRandaoReveal(ctx, c.results.Slot)
+	if err != nil {
+		return err
+	}
+	randaoReveal, skipRandaoVerification := util.ProposalRandaoReveal(proposerRandaoReveal)
+	c.results.RandaoReveal = randaoReveal
+
+	var graffiti [util.GraffitiLength]byte
+	copy(graffiti[:], c.graffiti)
+
+	// The proposals obtained here are never signed or broadcast.
+	if c.full {
+		// A builder boost factor of 0 requests a locally-built block.
+		builderBoostFactor := uint64(0)
+		proposalResponse, err := c.proposalProvider.Proposal(ctx, &api.ProposalOpts{
+			Slot:                   c.results.Slot,
+			RandaoReveal:           randaoReveal,
+			Graffiti:               graffiti,
+			SkipRandaoVerification: skipRandaoVerification,
+			BuilderBoostFactor:     &builderBoostFactor,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain full block proposal")
+		}
+		block, err := proposalBlock(proposalResponse.Data)
+		if err != nil {
+			return err
+		}
+		proposal, err := summarizeBlock(block)
+		if err != nil {
+			return err
+		}
+		c.results.Proposals = append(c.results.Proposals, proposal)
+	}
+	if c.blinded {
+		proposalResponse, err := c.blindedProposalProvider.BlindedProposal(ctx, &api.BlindedProposalOpts{
+			Slot:                   c.results.Slot,
+			RandaoReveal:           randaoReveal,
+			Graffiti:               graffiti,
+			SkipRandaoVerification: skipRandaoVerification,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain blinded block proposal")
+		}
+		proposal, err := summarizeBlindedBlock(proposalResponse.Data)
+		if err != nil {
+			return err
+		}
+		c.results.Proposals = append(c.results.Proposals, proposal)
+	}
+
+	// Block values are only available from nodes that support v3 of the block production API.
+	proposalValues, found, err := util.BlockProposalValues(ctx, c.eth2Client, c.timeout, c.results.Slot, proposerRandaoReveal, graffiti[:], nil)
+	switch {
+	case err != nil:
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Failed to obtain block values: %v\n", err)
+		}
+	case !found:
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Node does not support v3 of the block production API\n")
+		}
+	default:
+		c.results.Values = &values{
+			Blinded:               proposalValues.Blinded,
+			ExecutionPayloadValue: proposalValues.ExecutionPayloadValue,
+			ConsensusBlockValue:   proposalValues.ConsensusBlockValue,
+		}
+	}
+
+	return nil
+}
+
+// obtainRandaoReveal obtains the proposer's randao reveal to use for the
+// proposal.  It returns nil if the proposer's randao reveal is not available,
+// in which case the beacon node is asked to skip its verification.
+func (c *command) obtainRandaoReveal(ctx context.Context, slot phase0.Slot) (*phase0.BLSSignature, error) {
+	switch {
+	case c.randaoReveal != "":
+		data, err := hex.DecodeString(strings.TrimPrefix(c.randaoReveal, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid randao reveal")
+		}
+		if len(data) != phase0.SignatureLength {
+			return nil, errors.New("randao reveal must be 96 bytes")
+		}
+		var randaoReveal phase0.BLSSignature
+		copy(randaoReveal[:], data)
+		return &randaoReveal, nil
+	case c.privateKey != "":
+		return c.signRandaoReveal(ctx, slot)
+	default:
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "No randao reveal supplied; skipping randao verification\n")
+		}
+		return nil, nil
+	}
+}
+
+// signRandaoReveal signs the epoch of the slot with the supplied private key.
+// It returns nil if the key is not that of the proposer for the slot, as the
+// beacon node would reject the resultant randao reveal.
+func (c *command) signRandaoReveal(ctx context.Context, slot phase0.Slot) (*phase0.BLSSignature, error) {
+	account, err := util.ParseAccount(ctx, c.privateKey, nil, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain account from private key")
+	}
+
+	isProposer, err := c.isProposer(ctx, slot, account.PublicKey().Marshal())
+	if err != nil {
+		return nil, err
+	}
+	if !isProposer {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Private key is not that of the proposer for slot %d; skipping randao verification\n", slot)
+		}
+		return nil, nil
+	}
+
+	specDataResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+	domainType, exists := specData["DOMAIN_RANDAO"].(phase0.DomainType)
+	if !exists {
+		return nil, errors.New("failed to obtain DOMAIN_RANDAO")
+	}
+
+	epoch := c.chainTime.SlotToEpoch(slot)
+	domain, err := c.domainProvider.Domain(ctx, domainType, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain domain")
+	}
+
+	// The hash tree root of an epoch is its little-endian representation.
+	var root phase0.Root
+	binary.LittleEndian.PutUint64(root[:], uint64(epoch))
+
+	signature, err := util.SignRoot(account, root, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+	var randaoReveal phase0.BLSSignature
+	copy(randaoReveal[:], signature.Marshal())
+
+	return &randaoReveal, nil
+}
+
+// isProposer returns true if the given public key is that of the proposer for the slot.
+func (c *command) isProposer(ctx context.Context, slot phase0.Slot, pubKey []byte) (bool, error) {
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: c.chainTime.SlotToEpoch(slot),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain proposer duties")
+	}
+	for _, duty := range dutiesResponse.Data {
+		if duty.Slot == slot {
+			return bytes.Equal(duty.PubKey[:], pubKey), nil
+		}
+	}
+
+	return false, nil
+}
+
+// proposalBlock obtains the block from a full block proposal.
+func proposalBlock(proposal *api.VersionedProposal) (*spec.VersionedBeaconBlock, error) {
+	if proposal == nil || proposal.IsEmpty() {
+		return nil, errors.New("no full block proposal returned")
+	}
+	if proposal.Blinded {
+		return nil, errors.New("blinded block proposal returned when full block proposal requested")
+	}
+
+	block := &spec.VersionedBeaconBlock{
+		Version:   proposal.Version,
+		Phase0:    proposal.Phase0,
+		Altair:    proposal.Altair,
+		Bellatrix: proposal.Bellatrix,
+		Capella:   proposal.Capella,
+	}
+	if proposal.Deneb != nil {
+		block.Deneb = proposal.Deneb.Block
+	}
+	if proposal.Electra != nil {
+		block.Electra = proposal.Electra.Block
+	}
+	if proposal.Fulu != nil {
+		block.Fulu = proposal.Fulu.Block
+	}
+
+	return block, nil
+}
+
+// summarizeBlock summarizes a full block proposal.
+func summarizeBlock(block *spec.VersionedBeaconBlock) (*proposal, error) {
+	if block == nil || block.IsEmpty() {
+		return nil, errors.New("no full block proposal returned")
+	}
+
+	res := &proposal{
+		Type:    "full",
+		Version: block.Version.String(),
+	}
+	switch block.Version {
+	case spec.DataVersionBellatrix:
+		b := block.Bellatrix
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		payload := b.Body.ExecutionPayload
+		res.ExecutionPayload = summarizePayload(payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.GasUsed, payload.GasLimit, littleEndianToBig(payload.BaseFeePerGas))
+		res.ExecutionPayload.Transactions = intPtr(len(payload.Transactions))
+	case spec.DataVersionCapella:
+		b := block.Capella
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		payload := b.Body.ExecutionPayload
+		res.ExecutionPayload = summarizePayload(payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.GasUsed, payload.GasLimit, littleEndianToBig(payload.BaseFeePerGas))
+		res.ExecutionPayload.Transactions = intPtr(len(payload.Transactions))
+		res.ExecutionPayload.Withdrawals = intPtr(len(payload.Withdrawals))
+	case spec.DataVersionDeneb:
+		b := block.Deneb
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		res.BlobKZGCommitments = len(b.Body.BlobKZGCommitments)
+		payload := b.Body.ExecutionPayload
+		res.ExecutionPayload = summarizePayload(payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.GasUsed, payload.GasLimit, denebBaseFee(payload.BaseFeePerGas))
+		res.ExecutionPayload.Transactions = intPtr(len(payload.Transactions))
+		res.ExecutionPayload.Withdrawals = intPtr(len(payload.Withdrawals))
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		b := block.Electra
+		if block.Version == spec.DataVersionFulu {
+			b = block.Fulu
+		}
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		res.BlobKZGCommitments = len(b.Body.BlobKZGCommitments)
+		payload := b.Body.ExecutionPayload
+		res.ExecutionPayload = summarizePayload(payload.BlockNumber, payload.BlockHash, payload.FeeRecipient, payload.GasUsed, payload.GasLimit, denebBaseFee(payload.BaseFeePerGas))
+		res.ExecutionPayload.Transactions = intPtr(len(payload.Transactions))
+		res.ExecutionPayload.Withdrawals = intPtr(len(payload.Withdrawals))
+	default:
+		return nil, fmt.Errorf("unsupported block version %s", block.Version)
+	}
+
+	return res, nil
+}
+
+// summarizeBlindedBlock summarizes a blinded block proposal.
+func summarizeBlindedBlock(block *api.VersionedBlindedProposal) (*proposal, error) {
+	if block == nil {
+		return nil, errors.New("no blinded block proposal returned")
+	}
+	// IsEmpty() does not check Electra and later blinded proposals, so check
+	// for the data of the proposal's version directly.
+	if _, err := block.Slot(); err != nil {
+		return nil, errors.New("no blinded block proposal returned")
+	}
+
+	res := &proposal{
+		Type:    "blinded",
+		Version: block.Version.String(),
+	}
+	switch block.Version {
+	case spec.DataVersionBellatrix:
+		b := block.Bellatrix
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		header := b.Body.ExecutionPayloadHeader
+		res.ExecutionPayload = summarizePayload(header.BlockNumber, header.BlockHash, header.FeeRecipient, header.GasUsed, header.GasLimit, littleEndianToBig(header.BaseFeePerGas))
+	case spec.DataVersionCapella:
+		b := block.Capella
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		header := b.Body.ExecutionPayloadHeader
+		res.ExecutionPayload = summarizePayload(header.BlockNumber, header.BlockHash, header.FeeRecipient, header.GasUsed, header.GasLimit, littleEndianToBig(header.BaseFeePerGas))
+	case spec.DataVersionDeneb:
+		b := block.Deneb
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		res.BlobKZGCommitments = len(b.Body.BlobKZGCommitments)
+		header := b.Body.ExecutionPayloadHeader
+		res.ExecutionPayload = summarizePayload(header.BlockNumber, header.BlockHash, header.FeeRecipient, header.GasUsed, header.GasLimit, denebBaseFee(header.BaseFeePerGas))
+	case spec.DataVersionElectra, spec.DataVersionFulu:
+		b := block.Electra
+		if block.Version == spec.DataVersionFulu {
+			b = block.Fulu
+		}
+		res.Slot, res.ProposerIndex, res.ParentRoot, res.StateRoot = b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot
+		summarizeBody(res, b.Body.Graffiti, len(b.Body.Attestations), len(b.Body.AttesterSlashings), len(b.Body.ProposerSlashings), len(b.Body.Deposits), len(b.Body.VoluntaryExits), b.Body.SyncAggregate)
+		res.BLSToExecutionChanges = len(b.Body.BLSToExecutionChanges)
+		res.BlobKZGCommitments = len(b.Body.BlobKZGCommitments)
+		header := b.Body.ExecutionPayloadHeader
+		res.ExecutionPayload = summarizePayload(header.BlockNumber, header.BlockHash, header.FeeRecipient, header.GasUsed, header.GasLimit, denebBaseFee(header.BaseFeePerGas))
+	default:
+		return nil, fmt.Errorf("unsupported blinded block version %s", block.Version)
+	}
+
+	return res, nil
+}
+
+func summarizeBody(res *proposal,
+	graffiti [32]byte,
+	attestations int,
+	attesterSlashings int,
+	proposerSlashings int,
+	deposits int,
+	voluntaryExits int,
+	syncAggregate *altair.SyncAggregate,
+) {
+	res.Graffiti = util.DecodeGraffiti(graffiti[:])
+	res.Attestations = attestations
+	res.AttesterSlashings = attesterSlashings
+	res.ProposerSlashings = proposerSlashings
+	res.Deposits = deposits
+	res.VoluntaryExits = voluntaryExits
+	if syncAggregate != nil {
+		res.SyncCommitteeParticipants = syncAggregate.SyncCommitteeBits.Count()
+	}
+}
+
+func summarizePayload(blockNumber uint64,
+	blockHash phase0.Hash32,
+	feeRecipient bellatrix.ExecutionAddress,
+	gasUsed uint64,
+	gasLimit uint64,
+	baseFeePerGas *big.Int,
+) *executionPayload {
+	return &executionPayload{
+		BlockNumber:   blockNumber,
+		BlockHash:     blockHash,
+		FeeRecipient:  feeRecipient.String(),
+		GasUsed:       gasUsed,
+		GasLimit:      gasLimit,
+		BaseFeePerGas: baseFeePerGas,
+	}
+}
+
+// littleEndianToBig converts a little-endian 32-byte value to a big integer.
+func littleEndianToBig(input [32]byte) *big.Int {
+	data := make([]byte, len(input))
+	for i := range input {
+		data[len(input)-1-i] = input[i]
+	}
+
+	return new(big.Int).SetBytes(data)
+}
+
+// denebBaseFee converts a Deneb base fee to a big integer.
+func denebBaseFee(input *uint256.Int) *big.Int {
+	if input == nil {
+		return new(big.Int)
+	}
+
+	return input.ToBig()
+}
+
+func intPtr(val int) *int {
+	return &val
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.domainProvider, isProvider = c.eth2Client.(eth2client.DomainProvider)
+	if !isProvider {
+		return errors.New("connection does not provide domain information")
+	}
+	c.proposalProvider, isProvider = c.eth2Client.(eth2client.ProposalProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block proposals")
+	}
+	c.blindedProposalProvider, isProvider = c.eth2Client.(blindedProposalProvider)
+	if !isProvider {
+		return errors.New("connection does not provide blinded block proposals")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposersimulate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeBlock(t *testing.T) {
+	syncBits := bitfield.NewBitvector512()
+	syncBits.SetBitAt(1, true)
+	syncBits.SetBitAt(5, true)
+
+	block := &spec.VersionedBeaconBlock{
+		Version: spec.DataVersionCapella,
+		Capella: &capella.BeaconBlock{
+			Slot:          123,
+			ProposerIndex: 456,
+			Body: &capella.BeaconBlockBody{
+				Graffiti:      [32]byte{'t', 'e', 's', 't'},
+				Attestations:  make([]*phase0.Attestation, 3),
+				Deposits:      make([]*phase0.Deposit, 1),
+				SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: syncBits},
+				ExecutionPayload: &capella.ExecutionPayload{
+					BlockNumber:   789,
+					GasUsed:       1000,
+					GasLimit:      30000000,
+					BaseFeePerGas: [32]byte{0x00, 0xca, 0x9a, 0x3b},
+					Transactions:  make([]bellatrix.Transaction, 2),
+					Withdrawals:   make([]*capella.Withdrawal, 16),
+				},
+			},
+		},
+	}
+
+	res, err := summarizeBlock(block)
+	require.NoError(t, err)
+	require.Equal(t, "full", res.Type)
+	require.Equal(t, phase0.Slot(123), res.Slot)
+	require.Equal(t, phase0.ValidatorIndex(456), res.ProposerIndex)
+	require.Equal(t, "test", res.Graffiti.Text)
+	require.Equal(t, 3, res.Attestations)
+	require.Equal(t, 1, res.Deposits)
+	require.Equal(t, uint64(2), res.SyncCommitteeParticipants)
+	require.Equal(t, uint64(789), res.ExecutionPayload.BlockNumber)
+	require.Equal(t, big.NewInt(1000000000), res.ExecutionPayload.BaseFeePerGas)
+	require.Equal(t, 2, *res.ExecutionPayload.Transactions)
+	require.Equal(t, 16, *res.ExecutionPayload.Withdrawals)
+
+	_, err = summarizeBlock(&spec.VersionedBeaconBlock{Version: spec.DataVersionCapella})
+	require.EqualError(t, err, "no full block proposal returned")
+}
+
+func TestSummarizeElectraBlock(t *testing.T) {
+	syncBits := bitfield.NewBitvector512()
+	syncBits.SetBitAt(1, true)
+
+	res, err := summarizeBlock(&spec.VersionedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.BeaconBlock{
+			Slot:          123,
+			ProposerIndex: 456,
+			Body: &electra.BeaconBlockBody{
+				Graffiti:           [32]byte{'t', 'e', 's', 't'},
+				Attestations:       make([]*electra.Attestation, 2),
+				SyncAggregate:      &altair.SyncAggregate{SyncCommitteeBits: syncBits},
+				BlobKZGCommitments: make([]deneb.KZGCommitment, 3),
+				ExecutionPayload: &deneb.ExecutionPayload{
+					BlockNumber:   789,
+					BaseFeePerGas: uint256.NewInt(7),
+					Transactions:  make([]bellatrix.Transaction, 4),
+				},
+				ExecutionRequests: &electra.ExecutionRequests{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "full", res.Type)
+	require.Equal(t, phase0.Slot(123), res.Slot)
+	require.Equal(t, 2, res.Attestations)
+	require.Equal(t, 3, res.BlobKZGCommitments)
+	require.Equal(t, uint64(1), res.SyncCommitteeParticipants)
+	require.Equal(t, uint64(789), res.ExecutionPayload.BlockNumber)
+	require.Equal(t, big.NewInt(7), res.ExecutionPayload.BaseFeePerGas)
+	require.Equal(t, 4, *res.ExecutionPayload.Transactions)
+
+	res, err = summarizeBlindedBlock(&api.VersionedBlindedProposal{
+		Version: spec.DataVersionFulu,
+		Fulu: &apiv1electra.BlindedBeaconBlock{
+			Slot:          124,
+			ProposerIndex: 457,
+			Body: &apiv1electra.BlindedBeaconBlockBody{
+				Attestations:  make([]*electra.Attestation, 1),
+				SyncAggregate: &altair.SyncAggregate{SyncCommitteeBits: syncBits},
+				ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+					BlockNumber:   790,
+					BaseFeePerGas: uint256.NewInt(8),
+				},
+				ExecutionRequests: &electra.ExecutionRequests{},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "blinded", res.Type)
+	require.Equal(t, phase0.Slot(124), res.Slot)
+	require.Equal(t, 1, res.Attestations)
+	require.Equal(t, uint64(790), res.ExecutionPayload.BlockNumber)
+	require.Equal(t, big.NewInt(8), res.ExecutionPayload.BaseFeePerGas)
+
+	_, err = summarizeBlindedBlock(&api.VersionedBlindedProposal{Version: spec.DataVersionElectra})
+	require.EqualError(t, err, "no blinded block proposal returned")
+}
@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proposercompare "github.com/wealdtech/ethdo/cmd/proposer/compare"
+)
+
+var proposerCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare builder and locally-built payload values for a slot",
+	Long: `Compare the value of a locally-built execution payload with that offered by builders for a slot.  For example:
+
+    ethdo proposer compare --slot=12345 --relays=https://relay.example.com
+
+For an upcoming slot the beacon node is asked for proposals that favour local and builder payloads in turn, and the difference in their values is reported as the MEV premium.  For a past slot the bids received by the supplied relays are reported, along with the source of the payload that was included in the block.  The proposals are never signed or broadcast.
+
+In quiet mode this will return 0 if the values can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := proposercompare.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proposerCmd.AddCommand(proposerCompareCmd)
+	proposerFlags(proposerCompareCmd)
+	proposerCompareCmd.Flags().String("slot", "", "the slot for which to compare payload values (defaults to the next slot)")
+	proposerCompareCmd.Flags().StringSlice("relays", nil, "URLs of relays to query for the bids received for the slot")
+}
+
+func proposerCompareBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("relays", cmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proposerincome "github.com/wealdtech/ethdo/cmd/proposer/income"
+)
+
+var proposerIncomeCmd = &cobra.Command{
+	Use:   "income",
+	Short: "Reconcile income received by a fee recipient",
+	Long: `Reconcile the priority fees and MEV payments received by a fee recipient over a range of epochs.  For example:
+
+    ethdo proposer income --fee-recipient=0x... --execution-connection=http://localhost:8545 --from-epoch=-225
+
+In quiet mode this will return 0 if no discrepancies are found, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := proposerincome.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proposerCmd.AddCommand(proposerIncomeCmd)
+	proposerFlags(proposerIncomeCmd)
+	proposerIncomeCmd.Flags().String("fee-recipient", "", "the fee recipient address for which to reconcile income")
+	proposerIncomeCmd.Flags().String("from-epoch", "", "the first epoch of the range to reconcile")
+	proposerIncomeCmd.Flags().String("to-epoch", "", "the last epoch of the range to reconcile (defaults to current)")
+	proposerIncomeCmd.Flags().StringSlice("validators", nil, "validators whose proposals are expected to pay the fee recipient")
+	proposerIncomeCmd.Flags().StringSlice("relays", nil, "URLs of relays to query for the values of delivered payloads")
+}
+
+func proposerIncomeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("fee-recipient", cmd.Flags().Lookup("fee-recipient")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("relays", cmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+}
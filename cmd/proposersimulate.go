@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proposersimulate "github.com/wealdtech/ethdo/cmd/proposer/simulate"
+)
+
+var proposerSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate the production of a block",
+	Long: `Request an unsigned block proposal from the beacon node and display its contents.  For example:
+
+    ethdo proposer simulate --blinded
+
+The proposal is never signed or broadcast.
+
+In quiet mode this will return 0 if the proposal can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := proposersimulate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proposerCmd.AddCommand(proposerSimulateCmd)
+	proposerFlags(proposerSimulateCmd)
+	proposerSimulateCmd.Flags().String("slot", "", "the slot for which to obtain the proposal (defaults to the next slot)")
+	proposerSimulateCmd.Flags().String("randao-reveal", "", "the randao reveal to supply to the beacon node, as hex")
+	proposerSimulateCmd.Flags().String("graffiti", "", "the graffiti to supply to the beacon node")
+	proposerSimulateCmd.Flags().Bool("full", false, "obtain a full block proposal")
+	proposerSimulateCmd.Flags().Bool("blinded", false, "obtain a blinded block proposal")
+}
+
+func proposerSimulateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("randao-reveal", cmd.Flags().Lookup("randao-reveal")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("graffiti", cmd.Flags().Lookup("graffiti")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("full", cmd.Flags().Lookup("full")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blinded", cmd.Flags().Lookup("blinded")); err != nil {
+		panic(err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	dirk "github.com/wealdtech/go-eth2-wallet-dirk"
@@ -45,42 +47,98 @@ var RootCmd = &cobra.Command{
 
 // bindings are the command-specific bindings.
 var bindings = map[string]func(cmd *cobra.Command){
-	"account/create":     accountCreateBindings,
-	"account/derive":     accountDeriveBindings,
-	"account/import":     accountImportBindings,
-	"attester/duties":    attesterDutiesBindings,
-	"attester/inclusion": attesterInclusionBindings,
-	"block/analyze":      blockAnalyzeBindings,
-	"block/info":         blockInfoBindings,
-	"chain/eth1votes":    chainEth1VotesBindings,
-	"chain/info":         chainInfoBindings,
-	"chain/queues":       chainQueuesBindings,
-	"chain/spec":         chainSpecBindings,
-	"chain/time":         chainTimeBindings,
+	"account/composite/approve":              accountCompositeApproveBindings,
+	"account/create":                         accountCreateBindings,
+	"account/derive":                         accountDeriveBindings,
+	"account/export":                         accountExportBindings,
+	"account/import":                         accountImportBindings,
+	"account/key":                            accountKeyBindings,
+	"agent/start":                            agentStartBindings,
+	"alias/rm":                               aliasRmBindings,
+	"alias/set":                              aliasSetBindings,
+	"artifact/fetch":                         artifactFetchBindings,
+	"artifact/inventory":                     artifactInventoryBindings,
+	"artifact/publish":                       artifactPublishBindings,
+	"attestation/inclusion":                  attestationInclusionBindings,
+	"attestation/info":                       attestationInfoBindings,
+	"attester/duties":                        attesterDutiesBindings,
+	"attester/inclusion":                     attesterInclusionBindings,
+	"attester/slashing-protection/preflight": attesterSlashingProtectionPreflightBindings,
+	"blob/info":                              blobInfoBindings,
+	"block/analyze":                          blockAnalyzeBindings,
+	"block/compare":                          blockCompareBindings,
+	"block/info":                             blockInfoBindings,
+	"block/proof":                            blockProofBindings,
+	"block/proof/verify":                     blockProofVerifyBindings,
+	"block/stats":                            blockStatsBindings,
+	"chain/apr":                              chainAPRBindings,
+	"chain/blocktimes":                       chainBlockTimesBindings,
+	"chain/eth1votes":                        chainEth1VotesBindings,
+	"chain/exitrate":                         chainExitRateBindings,
+	"chain/finality-delay":                   chainFinalityDelayBindings,
+	"chain/info":                             chainInfoBindings,
+	"chain/penalty":                          chainPenaltyBindings,
+	"chain/pending":                          chainPendingBindings,
+	"chain/proposerstats":                    chainProposerStatsBindings,
+	"chain/queues":                           chainQueuesBindings,
+	"chain/spec":                             chainSpecBindings,
+	"chain/statediff":                        chainStateDiffBindings,
+	"chain/supply":                           chainSupplyBindings,
+	"chain/time":                             chainTimeBindings,
+	"chain/withdrawalsqueue":                 chainWithdrawalsQueueBindings,
 	"chain/verify/signedcontributionandproof": chainVerifySignedContributionAndProofBindings,
-	"epoch/summary":             epochSummaryBindings,
-	"exit/verify":               exitVerifyBindings,
-	"node/events":               nodeEventsBindings,
-	"proposer/duties":           proposerDutiesBindings,
-	"slot/time":                 slotTimeBindings,
-	"synccommittee/inclusion":   synccommitteeInclusionBindings,
-	"synccommittee/members":     synccommitteeMembersBindings,
-	"validator/credentials/get": validatorCredentialsGetBindings,
-	"validator/credentials/set": validatorCredentialsSetBindings,
-	"validator/depositdata":     validatorDepositdataBindings,
-	"validator/duties":          validatorDutiesBindings,
-	"validator/exit":            validatorExitBindings,
-	"validator/info":            validatorInfoBindings,
-	"validator/keycheck":        validatorKeycheckBindings,
-	"validator/summary":         validatorSummaryBindings,
-	"validator/yield":           validatorYieldBindings,
-	"validator/expectation":     validatorExpectationBindings,
-	"validator/withdrawal":      validatorWithdrawalBindings,
-	"wallet/batch":              walletBatchBindings,
-	"wallet/create":             walletCreateBindings,
-	"wallet/import":             walletImportBindings,
-	"wallet/sharedexport":       walletSharedExportBindings,
-	"wallet/sharedimport":       walletSharedImportBindings,
+	"deposit/generate":                        depositGenerateBindings,
+	"deposit/reconcile":                       depositReconcileBindings,
+	"deposit/validate":                        depositValidateBindings,
+	"epoch/summary":                           epochSummaryBindings,
+	"exit/verify":                             exitVerifyBindings,
+	"init":                                    initBindings,
+	"keymanager/delete":                       keymanagerDeleteBindings,
+	"keymanager/import":                       keymanagerImportBindings,
+	"node/builder/status":                     nodeBuilderStatusBindings,
+	"node/events":                             nodeEventsBindings,
+	"node/expectedwithdrawals":                nodeExpectedWithdrawalsBindings,
+	"proposer/compare":                        proposerCompareBindings,
+	"proposer/duties":                         proposerDutiesBindings,
+	"proposer/income":                         proposerIncomeBindings,
+	"proposer/simulate":                       proposerSimulateBindings,
+	"slot/time":                               slotTimeBindings,
+	"synccommittee/inclusion":                 synccommitteeInclusionBindings,
+	"synccommittee/members":                   synccommitteeMembersBindings,
+	"synccommittee/subnets":                   synccommitteeSubnetsBindings,
+	"util/beaconroot":                         utilBeaconRootBindings,
+	"util/graffiti/decode":                    utilGraffitiDecodeBindings,
+	"util/graffiti/encode":                    utilGraffitiEncodeBindings,
+	"util/graffiti/pool":                      utilGraffitiPoolBindings,
+	"util/kzg/verify":                         utilKZGVerifyBindings,
+	"validator/attestations/export":           validatorAttestationsExportBindings,
+	"validator/consolidate":                   validatorConsolidateBindings,
+	"validator/credentials/get":               validatorCredentialsGetBindings,
+	"validator/credentials/set":               validatorCredentialsSetBindings,
+	"validator/credentials/set/compounding":   validatorCredentialsSetCompoundingBindings,
+	"validator/depositdata":                   validatorDepositdataBindings,
+	"validator/duties":                        validatorDutiesBindings,
+	"validator/exit":                          validatorExitBindings,
+	"validator/exit/preflight":                validatorExitPreflightBindings,
+	"validator/info":                          validatorInfoBindings,
+	"validator/keycheck":                      validatorKeycheckBindings,
+	"validator/migrate-check":                 validatorMigrateCheckBindings,
+	"validator/performance":                   validatorPerformanceBindings,
+	"validator/rewards":                       validatorRewardsBindings,
+	"validator/slashing-protection/export":    validatorSlashingProtectionExportBindings,
+	"validator/slashing-protection/import":    validatorSlashingProtectionImportBindings,
+	"validator/summary":                       validatorSummaryBindings,
+	"validator/yield":                         validatorYieldBindings,
+	"validator/expectation":                   validatorExpectationBindings,
+	"validator/withdraw":                      validatorWithdrawBindings,
+	"validator/withdrawal":                    validatorWithdrawalBindings,
+	"wallet/batch":                            walletBatchBindings,
+	"wallet/create":                           walletCreateBindings,
+	"wallet/export":                           walletExportBindings,
+	"wallet/import":                           walletImportBindings,
+	"wallet/sharedexport":                     walletSharedExportBindings,
+	"wallet/sharedimport":                     walletSharedImportBindings,
+	"wallet/unlock":                           walletUnlockBindings,
 }
 
 func persistentPreRunE(cmd *cobra.Command, _ []string) error {
@@ -107,6 +165,16 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		bindingsFunc(cmd)
 	}
 
+	resolveAliases(cmd)
+
+	if viper.GetBool("schema") {
+		return outputSchema(cmd)
+	}
+
+	if _, err := output.Configure(cmd.Annotations[output.FormatsAnnotation]); err != nil {
+		return err
+	}
+
 	if quiet && verbose {
 		fmt.Println("Cannot supply both quiet and verbose flags")
 	}
@@ -114,13 +182,19 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		fmt.Println("Cannot supply both quiet and debug flags")
 	}
 
+	if err := util.StartTimings(); err != nil {
+		return err
+	}
+
 	return util.SetupStore()
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	err := RootCmd.Execute()
+	util.StopTimings(os.Stderr)
+	if err != nil {
 		os.Exit(_exitFailure)
 	}
 }
@@ -138,6 +212,10 @@ func init() {
 
 func addPersistentFlags() {
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ethdo.yaml)")
+	RootCmd.PersistentFlags().String("profile", "", "profile in the config file from which to take settings (default is the config file's profile setting, if any)")
+	if err := viper.BindPFlag("profile", RootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		panic(err)
+	}
 
 	RootCmd.PersistentFlags().String("log", "", "log activity to the named file (default $HOME/ethdo.log).  Logs are written for every action that generates a transaction")
 	if err := viper.BindPFlag("log", RootCmd.PersistentFlags().Lookup("log")); err != nil {
@@ -216,14 +294,101 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("json", RootCmd.PersistentFlags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := RootCmd.PersistentFlags().MarkDeprecated("json", "use --output=json"); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("output", "", "the format of the output where available: text, json, ndjson, ssz, csv or yaml (default text)")
+	if err := viper.BindPFlag("output", RootCmd.PersistentFlags().Lookup("output")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().StringSlice("fields", nil, "comma-separated list of dot-separated paths of the fields to include in JSON output where available, for example message.slot,signature")
+	if err := viper.BindPFlag("fields", RootCmd.PersistentFlags().Lookup("fields")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("balance-unit", "auto", "the unit in which balances are shown in text output: auto, ether or gwei")
+	if err := viper.BindPFlag("balance-unit", RootCmd.PersistentFlags().Lookup("balance-unit")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Int("balance-decimals", -1, "the number of decimal places with which balances are shown in text output (default as many as required)")
+	if err := viper.BindPFlag("balance-decimals", RootCmd.PersistentFlags().Lookup("balance-decimals")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("balance-separators", false, "show balances in text output with thousands separators")
+	if err := viper.BindPFlag("balance-separators", RootCmd.PersistentFlags().Lookup("balance-separators")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("balance-locale", "en", "the locale that defines the thousands separator and decimal point of balances in text output: en, de, de-ch, es, fr, it or nl")
+	if err := viper.BindPFlag("balance-locale", RootCmd.PersistentFlags().Lookup("balance-locale")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("fiat", "", "the fiat currency in which to show the equivalent of amounts of Ether in text output, for example usd")
+	if err := viper.BindPFlag("fiat", RootCmd.PersistentFlags().Lookup("fiat")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("fiat-historical", false, "show fiat equivalents at the price when the amounts were earned rather than the current price")
+	if err := viper.BindPFlag("fiat-historical", RootCmd.PersistentFlags().Lookup("fiat-historical")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("price-oracle", "coingecko", "the oracle from which to obtain fiat prices: coingecko or chainlink (requires --execution-connection)")
+	if err := viper.BindPFlag("price-oracle", RootCmd.PersistentFlags().Lookup("price-oracle")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("price-oracle-api-key", "", "the key with which to access the price oracle, if required")
+	if err := viper.BindPFlag("price-oracle-api-key", RootCmd.PersistentFlags().Lookup("price-oracle-api-key")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("schema", false, "output the JSON schema of the command's JSON output rather than running the command")
+	if err := viper.BindPFlag("schema", RootCmd.PersistentFlags().Lookup("schema")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("debug", false, "generate debug output")
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint")
+	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint, or unix:// path to its socket")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("execution-connection", "", "URL to an Ethereum 1 node's JSON-RPC endpoint, for commands that require execution layer data")
+	if err := viper.BindPFlag("execution-connection", RootCmd.PersistentFlags().Lookup("execution-connection")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("keymanager-connection", "", "URL to a validator client's or remote signer's keymanager API endpoint, for commands that manage its keys")
+	if err := viper.BindPFlag("keymanager-connection", RootCmd.PersistentFlags().Lookup("keymanager-connection")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("keymanager-token", "", "Bearer token with which to authenticate to the keymanager API, if required")
+	if err := viper.BindPFlag("keymanager-token", RootCmd.PersistentFlags().Lookup("keymanager-token")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-client-cert", "", "location of a client certificate file when connecting to a beacon node over https")
+	if err := viper.BindPFlag("connection-client-cert", RootCmd.PersistentFlags().Lookup("connection-client-cert")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-client-key", "", "location of a client key file when connecting to a beacon node over https")
+	if err := viper.BindPFlag("connection-client-key", RootCmd.PersistentFlags().Lookup("connection-client-key")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("connection-ca-cert", "", "location of the certificate authority certificate when connecting to a beacon node over https")
+	if err := viper.BindPFlag("connection-ca-cert", RootCmd.PersistentFlags().Lookup("connection-ca-cert")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("trace-http", false, "log every request to the beacon node to stderr")
+	if err := viper.BindPFlag("trace-http", RootCmd.PersistentFlags().Lookup("trace-http")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("trace-http-dir", "", "directory in which to write the bodies of requests to, and responses from, the beacon node (implies --trace-http)")
+	if err := viper.BindPFlag("trace-http-dir", RootCmd.PersistentFlags().Lookup("trace-http-dir")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Bool("timings", false, "report where time was spent when the command completes")
+	if err := viper.BindPFlag("timings", RootCmd.PersistentFlags().Lookup("timings")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("cpu-profile", "", "write a pprof CPU profile of the command to the named file")
+	if err := viper.BindPFlag("cpu-profile", RootCmd.PersistentFlags().Lookup("cpu-profile")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "the time after which a network request will be considered failed.  Increase this if you are running on an error-prone, high-latency or low-bandwidth connection")
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
@@ -232,6 +397,10 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("remote", RootCmd.PersistentFlags().Lookup("remote")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("agent-socket", "", "the socket of the key agent (default $HOME/.ethdo-agent.sock)")
+	if err := viper.BindPFlag("agent-socket", RootCmd.PersistentFlags().Lookup("agent-socket")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("client-cert", "", "location of a client certificate file when connecting to the remote wallet daemon")
 	if err := viper.BindPFlag("client-cert", RootCmd.PersistentFlags().Lookup("client-cert")); err != nil {
 		panic(err)
@@ -275,15 +444,80 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
-		// Don't report lack of config file...
-		assert(strings.Contains(err.Error(), "Not Found"), "failed to read configuration")
+		// Don't report lack of config file, or lack of an explicit config file
+		// if we are about to create it...
+		assert(strings.Contains(err.Error(), "Not Found") ||
+			(errors.Is(err, os.ErrNotExist) && (initCmd.CalledAs() != "" || aliasSetCmd.CalledAs() != "")),
+			"failed to read configuration")
 	}
+
+	// Merge in the settings of the selected profile, if any.
+	errCheck(util.ApplyProfile(viper.GetString("profile")), "failed to apply profile")
 }
 
 //
 // Helpers
 //
 
+// aliasedFlags are the flags whose values can be aliases.
+var aliasedFlags = []string{
+	"validator",
+	"validators",
+	"withdrawal-address",
+	"withdrawaladdress",
+	"source-validator",
+	"target-validator",
+}
+
+// resolveAliases replaces any aliases supplied to the command's validator
+// and address flags with their values.
+func resolveAliases(cmd *cobra.Command) {
+	if len(util.Aliases()) == 0 {
+		return
+	}
+
+	for _, name := range aliasedFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+		switch flag.Value.Type() {
+		case "string":
+			value := viper.GetString(name)
+			if resolved := util.ResolveAlias(value); resolved != value {
+				viper.Set(name, resolved)
+			}
+		case "stringSlice":
+			values := viper.GetStringSlice(name)
+			resolved := util.ResolveAliases(values)
+			for i := range values {
+				if resolved[i] != values[i] {
+					viper.Set(name, resolved)
+					break
+				}
+			}
+		}
+	}
+}
+
+// configFilePath returns the path of the configuration file to which to write
+// settings: the file supplied with --config, otherwise the file from which the
+// configuration was read, otherwise the default location.
+func configFilePath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if configFile := viper.ConfigFileUsed(); configFile != "" {
+		return configFile, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".ethdo.yaml"), nil
+}
+
 func outputIf(condition bool, msg string) {
 	if condition {
 		fmt.Println(msg)
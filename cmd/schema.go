@@ -0,0 +1,178 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	agentstatus "github.com/wealdtech/ethdo/cmd/agent/status"
+	aliaslist "github.com/wealdtech/ethdo/cmd/alias/list"
+	artifactinventory "github.com/wealdtech/ethdo/cmd/artifact/inventory"
+	artifactpublish "github.com/wealdtech/ethdo/cmd/artifact/publish"
+	attestationinclusion "github.com/wealdtech/ethdo/cmd/attestation/inclusion"
+	attestationinfo "github.com/wealdtech/ethdo/cmd/attestation/info"
+	attesterduties "github.com/wealdtech/ethdo/cmd/attester/duties"
+	attesterslashingprotectionpreflight "github.com/wealdtech/ethdo/cmd/attester/slashingprotection/preflight"
+	blobinfo "github.com/wealdtech/ethdo/cmd/blob/info"
+	blockanalyze "github.com/wealdtech/ethdo/cmd/block/analyze"
+	blockcompare "github.com/wealdtech/ethdo/cmd/block/compare"
+	blockinfo "github.com/wealdtech/ethdo/cmd/block/info"
+	blockproof "github.com/wealdtech/ethdo/cmd/block/proof"
+	blockproofverify "github.com/wealdtech/ethdo/cmd/block/proof/verify"
+	blockstats "github.com/wealdtech/ethdo/cmd/block/stats"
+	chainapr "github.com/wealdtech/ethdo/cmd/chain/apr"
+	chainblocktimes "github.com/wealdtech/ethdo/cmd/chain/blocktimes"
+	chaineth1votes "github.com/wealdtech/ethdo/cmd/chain/eth1votes"
+	chainexitrate "github.com/wealdtech/ethdo/cmd/chain/exitrate"
+	chainfinalitydelay "github.com/wealdtech/ethdo/cmd/chain/finalitydelay"
+	chainpenalty "github.com/wealdtech/ethdo/cmd/chain/penalty"
+	chainpending "github.com/wealdtech/ethdo/cmd/chain/pending"
+	chainproposerstats "github.com/wealdtech/ethdo/cmd/chain/proposerstats"
+	chainqueues "github.com/wealdtech/ethdo/cmd/chain/queues"
+	chainsafeblock "github.com/wealdtech/ethdo/cmd/chain/safeblock"
+	chainstatediff "github.com/wealdtech/ethdo/cmd/chain/statediff"
+	chainsupply "github.com/wealdtech/ethdo/cmd/chain/supply"
+	chainwithdrawalsqueue "github.com/wealdtech/ethdo/cmd/chain/withdrawalsqueue"
+	depositreconcile "github.com/wealdtech/ethdo/cmd/deposit/reconcile"
+	depositvalidate "github.com/wealdtech/ethdo/cmd/deposit/validate"
+	epochsummary "github.com/wealdtech/ethdo/cmd/epoch/summary"
+	keymanagerdelete "github.com/wealdtech/ethdo/cmd/keymanager/delete"
+	keymanagerimport "github.com/wealdtech/ethdo/cmd/keymanager/import"
+	keymanagerlist "github.com/wealdtech/ethdo/cmd/keymanager/list"
+	nodebuilderstatus "github.com/wealdtech/ethdo/cmd/node/builder/status"
+	nodecrosscheck "github.com/wealdtech/ethdo/cmd/node/crosscheck"
+	nodeevents "github.com/wealdtech/ethdo/cmd/node/events"
+	nodeexpectedwithdrawals "github.com/wealdtech/ethdo/cmd/node/expectedwithdrawals"
+	proposercompare "github.com/wealdtech/ethdo/cmd/proposer/compare"
+	proposerduties "github.com/wealdtech/ethdo/cmd/proposer/duties"
+	proposerincome "github.com/wealdtech/ethdo/cmd/proposer/income"
+	proposersimulate "github.com/wealdtech/ethdo/cmd/proposer/simulate"
+	synccommitteemembers "github.com/wealdtech/ethdo/cmd/synccommittee/members"
+	synccommitteesubnets "github.com/wealdtech/ethdo/cmd/synccommittee/subnets"
+	utilbeaconroot "github.com/wealdtech/ethdo/cmd/util/beaconroot"
+	utilgraffitidecode "github.com/wealdtech/ethdo/cmd/util/graffiti/decode"
+	utilgraffitipool "github.com/wealdtech/ethdo/cmd/util/graffiti/pool"
+	utilkzgverify "github.com/wealdtech/ethdo/cmd/util/kzg/verify"
+	validatorattestationsexport "github.com/wealdtech/ethdo/cmd/validator/attestations/export"
+	validatorconsolidate "github.com/wealdtech/ethdo/cmd/validator/consolidate"
+	validatorcredentialsset "github.com/wealdtech/ethdo/cmd/validator/credentials/set"
+	validatorcredentialssetcompounding "github.com/wealdtech/ethdo/cmd/validator/credentials/set/compounding"
+	validatorexit "github.com/wealdtech/ethdo/cmd/validator/exit"
+	validatorexitpreflight "github.com/wealdtech/ethdo/cmd/validator/exit/preflight"
+	validatorexpectation "github.com/wealdtech/ethdo/cmd/validator/expectation"
+	validatormigratecheck "github.com/wealdtech/ethdo/cmd/validator/migratecheck"
+	validatorperformance "github.com/wealdtech/ethdo/cmd/validator/performance"
+	validatorrewards "github.com/wealdtech/ethdo/cmd/validator/rewards"
+	validatorslashingprotectionimport "github.com/wealdtech/ethdo/cmd/validator/slashingprotection/import"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	validatorwithdraw "github.com/wealdtech/ethdo/cmd/validator/withdraw"
+	validatorwithdrawal "github.com/wealdtech/ethdo/cmd/validator/withdrawal"
+	validatoryield "github.com/wealdtech/ethdo/cmd/validator/yield"
+	walletstats "github.com/wealdtech/ethdo/cmd/wallet/stats"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// schemas are the JSON schemas for commands that provide JSON output.
+var schemas = map[string]func() (*util.JSONSchema, error){
+	"agent/status":                           agentstatus.Schema,
+	"alias/list":                             aliaslist.Schema,
+	"artifact/inventory":                     artifactinventory.Schema,
+	"artifact/publish":                       artifactpublish.Schema,
+	"attestation/inclusion":                  attestationinclusion.Schema,
+	"attestation/info":                       attestationinfo.Schema,
+	"attester/duties":                        attesterduties.Schema,
+	"attester/slashing-protection/preflight": attesterslashingprotectionpreflight.Schema,
+	"blob/info":                              blobinfo.Schema,
+	"block/analyze":                          blockanalyze.Schema,
+	"block/compare":                          blockcompare.Schema,
+	"block/info":                             blockinfo.Schema,
+	"block/proof":                            blockproof.Schema,
+	"block/proof/verify":                     blockproofverify.Schema,
+	"block/stats":                            blockstats.Schema,
+	"chain/apr":                              chainapr.Schema,
+	"chain/blocktimes":                       chainblocktimes.Schema,
+	"chain/eth1votes":                        chaineth1votes.Schema,
+	"chain/exitrate":                         chainexitrate.Schema,
+	"chain/finality-delay":                   chainfinalitydelay.Schema,
+	"chain/penalty":                          chainpenalty.Schema,
+	"chain/pending":                          chainpending.Schema,
+	"chain/proposerstats":                    chainproposerstats.Schema,
+	"chain/queues":                           chainqueues.Schema,
+	"chain/safeblock":                        chainsafeblock.Schema,
+	"chain/spec":                             chainSpecSchema,
+	"chain/statediff":                        chainstatediff.Schema,
+	"chain/supply":                           chainsupply.Schema,
+	"chain/withdrawalsqueue":                 chainwithdrawalsqueue.Schema,
+	"deposit/reconcile":                      depositreconcile.Schema,
+	"deposit/validate":                       depositvalidate.Schema,
+	"epoch/summary":                          epochsummary.Schema,
+	"keymanager/delete":                      keymanagerdelete.Schema,
+	"keymanager/import":                      keymanagerimport.Schema,
+	"keymanager/list":                        keymanagerlist.Schema,
+	"node/builder/status":                    nodebuilderstatus.Schema,
+	"node/crosscheck":                        nodecrosscheck.Schema,
+	"node/events":                            nodeevents.Schema,
+	"node/expectedwithdrawals":               nodeexpectedwithdrawals.Schema,
+	"proposer/compare":                       proposercompare.Schema,
+	"proposer/duties":                        proposerduties.Schema,
+	"proposer/income":                        proposerincome.Schema,
+	"proposer/simulate":                      proposersimulate.Schema,
+	"signature/verify":                       signatureVerifySchema,
+	"synccommittee/members":                  synccommitteemembers.Schema,
+	"synccommittee/subnets":                  synccommitteesubnets.Schema,
+	"util/beaconroot":                        utilbeaconroot.Schema,
+	"util/graffiti/decode":                   utilgraffitidecode.Schema,
+	"util/graffiti/pool":                     utilgraffitipool.Schema,
+	"util/kzg/verify":                        utilkzgverify.Schema,
+	"validator/attestations/export":          validatorattestationsexport.Schema,
+	"validator/consolidate":                  validatorconsolidate.Schema,
+	"validator/credentials/set":              validatorcredentialsset.Schema,
+	"validator/credentials/set/compounding":  validatorcredentialssetcompounding.Schema,
+	"validator/exit":                         validatorexit.Schema,
+	"validator/exit/preflight":               validatorexitpreflight.Schema,
+	"validator/expectation":                  validatorexpectation.Schema,
+	"validator/migrate-check":                validatormigratecheck.Schema,
+	"validator/performance":                  validatorperformance.Schema,
+	"validator/rewards":                      validatorrewards.Schema,
+	"validator/slashing-protection/import":   validatorslashingprotectionimport.Schema,
+	"validator/summary":                      validatorsummary.Schema,
+	"validator/withdraw":                     validatorwithdraw.Schema,
+	"validator/withdrawal":                   validatorwithdrawal.Schema,
+	"validator/yield":                        validatoryield.Schema,
+	"wallet/stats":                           walletstats.Schema,
+}
+
+// outputSchema outputs the JSON schema for the JSON output of the command.
+func outputSchema(cmd *cobra.Command) error {
+	schemaFunc, exists := schemas[commandPath(cmd)]
+	if !exists {
+		return fmt.Errorf("%s does not provide JSON output", cmd.CommandPath())
+	}
+
+	schema, err := schemaFunc()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	os.Exit(_exitSuccess)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonOutputRegexp matches source that uses the json option or the JSON output
+// format.
+var jsonOutputRegexp = regexp.MustCompile(`viper\.GetBool\("json"\)|\bc\.json\b|\bdata\.json\b|output\.JSON\b|\) RenderJSON\(`)
+
+// providesJSON returns true if the source of the command provides JSON
+// output.  Commands are implemented either in their own package, in a
+// directory matching their path, or in a file in this package.
+func providesJSON(t *testing.T, path string) bool {
+	t.Helper()
+
+	name := strings.ReplaceAll(path, "-", "")
+	files, err := filepath.Glob(filepath.Join(name, "*.go"))
+	require.NoError(t, err)
+	files = append(files, strings.ReplaceAll(name, "/", "")+".go")
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		require.NoError(t, err)
+		if jsonOutputRegexp.Match(data) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TestSchemas checks that every command that provides JSON output has a schema,
+// and that every schema is for a command.
+func TestSchemas(t *testing.T) {
+	commands := make(map[string]bool)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+		if cmd == RootCmd || !cmd.Runnable() {
+			return
+		}
+		path := commandPath(cmd)
+		commands[path] = true
+		if !providesJSON(t, path) {
+			return
+		}
+		t.Run(path, func(t *testing.T) {
+			schemaFunc, exists := schemas[path]
+			require.True(t, exists, "no schema for %s", path)
+			schema, err := schemaFunc()
+			require.NoError(t, err)
+			require.NotNil(t, schema)
+		})
+	}
+	walk(RootCmd)
+
+	for path := range schemas {
+		require.True(t, commands[path], "schema for unknown command %s", path)
+	}
+}
@@ -30,6 +30,7 @@ import (
 var (
 	signatureVerifySignature string
 	signatureVerifySigner    string
+	signatureVerifyManifest  string
 )
 
 // signatureVerifyCmd represents the signature verify command.
@@ -40,11 +41,26 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethdo signature verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --account="Personal wallet/Operations"
 
-In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
+Multiple signatures can be verified at once by supplying a manifest file in CSV or JSON format, containing the public key, root, signature and (optionally) domain for each signature.  For example:
+
+    ethdo signature verify --manifest=signatures.csv
+
+In quiet mode this will return 0 if the data can be signed, or all signatures in the manifest verify, otherwise 1.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
+		if signatureVerifyManifest != "" {
+			domain, err := bytesutil.FromHexString(viper.GetString("signature-domain"))
+			errCheck(err, "Failed to parse domain")
+			verified, err := verifySignatureManifest(signatureVerifyManifest, domain, viper.GetBool("json"), viper.GetBool("quiet"))
+			errCheck(err, "Failed to verify manifest")
+			if !verified {
+				os.Exit(_exitFailure)
+			}
+			os.Exit(_exitSuccess)
+		}
+
 		assert(viper.GetString("signature-data") != "", "--data is required")
 		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
 		errCheck(err, "Failed to parse data")
@@ -93,4 +109,5 @@ func init() {
 	signatureFlags(signatureVerifyCmd)
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySignature, "signature", "", "the signature to verify")
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySigner, "signer", "", "the public key of the signer (only if --account is not supplied)")
+	signatureVerifyCmd.Flags().StringVar(&signatureVerifyManifest, "manifest", "", "path to a CSV or JSON manifest of signatures to verify")
 }
@@ -0,0 +1,276 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// signatureManifestColumns are the default columns of a CSV manifest.
+var signatureManifestColumns = []string{"pubkey", "root", "signature", "domain"}
+
+// signatureManifestEntry is a single entry in a signature manifest.
+type signatureManifestEntry struct {
+	Pubkey    string `json:"pubkey"`
+	Root      string `json:"root"`
+	Signature string `json:"signature"`
+	Domain    string `json:"domain,omitempty"`
+}
+
+// signatureManifestResult is the result of verifying a single entry in a signature manifest.
+type signatureManifestResult struct {
+	Row      int    `json:"row"`
+	Pubkey   string `json:"pubkey"`
+	Root     string `json:"root"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// signatureManifestResults are the results of verifying a signature manifest.
+type signatureManifestResults struct {
+	Total    int                        `json:"total"`
+	Verified int                        `json:"verified"`
+	Results  []*signatureManifestResult `json:"results"`
+}
+
+// signatureVerifySchemaVersion is the version of the JSON output of signature verification with a manifest.
+const signatureVerifySchemaVersion = 1
+
+func signatureVerifySchema() (*util.JSONSchema, error) {
+	return util.GenerateJSONSchema("signature/verify", signatureVerifySchemaVersion, &signatureManifestResults{})
+}
+
+// verifySignatureManifest verifies all entries in the manifest at the given path,
+// returning true if all of them were verified.
+func verifySignatureManifest(path string, defaultDomain []byte, jsonOutput bool, quiet bool) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read manifest")
+	}
+	entries, err := parseSignatureManifest(data)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) == 0 {
+		return false, errors.New("manifest contains no entries")
+	}
+
+	results := verifySignatureManifestEntries(entries, defaultDomain)
+
+	if !quiet {
+		output, err := signatureManifestOutput(results, jsonOutput)
+		if err != nil {
+			return false, err
+		}
+		fmt.Println(output)
+	}
+
+	return results.Verified == results.Total, nil
+}
+
+// parseSignatureManifest parses a manifest in either JSON or CSV format.
+func parseSignatureManifest(data []byte) ([]*signatureManifestEntry, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		entries := make([]*signatureManifestEntry, 0)
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, errors.Wrap(err, "failed to parse JSON manifest")
+		}
+		return entries, nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	entries := make([]*signatureManifestEntry, 0)
+	columns := signatureManifestColumns
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse CSV manifest")
+		}
+		if line == 1 && !strings.HasPrefix(record[0], "0x") {
+			// Header line; use it to define the columns.
+			columns = make([]string, len(record))
+			for i := range record {
+				columns[i] = strings.ToLower(strings.TrimSpace(record[i]))
+			}
+			continue
+		}
+		if len(record) > len(columns) {
+			return nil, fmt.Errorf("line %d has too many fields", line)
+		}
+		entry := &signatureManifestEntry{}
+		for i, value := range record {
+			switch columns[i] {
+			case "pubkey":
+				entry.Pubkey = value
+			case "root":
+				entry.Root = value
+			case "signature":
+				entry.Signature = value
+			case "domain":
+				entry.Domain = value
+			default:
+				return nil, fmt.Errorf("unknown column %q", columns[i])
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// verifySignatureManifestEntries verifies manifest entries in parallel.
+func verifySignatureManifestEntries(entries []*signatureManifestEntry, defaultDomain []byte) *signatureManifestResults {
+	results := &signatureManifestResults{
+		Total:   len(entries),
+		Results: make([]*signatureManifestResult, len(entries)),
+	}
+
+	rows := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range rows {
+				results.Results[row] = verifySignatureManifestEntry(row+1, entries[row], defaultDomain)
+			}
+		}()
+	}
+	for row := range entries {
+		rows <- row
+	}
+	close(rows)
+	wg.Wait()
+
+	for _, result := range results.Results {
+		if result.Verified {
+			results.Verified++
+		}
+	}
+
+	return results
+}
+
+// verifySignatureManifestEntry verifies a single manifest entry.
+func verifySignatureManifestEntry(row int, entry *signatureManifestEntry, defaultDomain []byte) *signatureManifestResult {
+	result := &signatureManifestResult{
+		Row:    row,
+		Pubkey: entry.Pubkey,
+		Root:   entry.Root,
+	}
+
+	verified, err := verifySignatureManifestSignature(entry, defaultDomain)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Verified = verified
+	}
+
+	return result
+}
+
+func verifySignatureManifestSignature(entry *signatureManifestEntry, defaultDomain []byte) (bool, error) {
+	pubKeyBytes, err := bytesutil.FromHexString(entry.Pubkey)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid public key")
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid public key")
+	}
+
+	root, err := bytesutil.FromHexString(entry.Root)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid root")
+	}
+	if len(root) != 32 {
+		return false, errors.New("root must be 32 bytes")
+	}
+
+	signatureBytes, err := bytesutil.FromHexString(entry.Signature)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid signature")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid signature")
+	}
+
+	domain := defaultDomain
+	if entry.Domain != "" {
+		domain, err = bytesutil.FromHexString(entry.Domain)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid domain")
+		}
+	}
+	if len(domain) != 32 {
+		return false, errors.New("domain must be 32 bytes")
+	}
+
+	container := &spec.SigningData{}
+	copy(container.ObjectRoot[:], root)
+	copy(container.Domain[:], domain)
+	signingRoot, err := ssz.HashTreeRoot(container)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	return signature.Verify(signingRoot[:], pubKey), nil
+}
+
+func signatureManifestOutput(results *signatureManifestResults, jsonOutput bool) (string, error) {
+	if jsonOutput {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal JSON")
+		}
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for _, result := range results.Results {
+		switch {
+		case result.Error != "":
+			builder.WriteString(fmt.Sprintf("Row %d: error: %s\n", result.Row, result.Error))
+		case result.Verified:
+			builder.WriteString(fmt.Sprintf("Row %d: verified\n", result.Row))
+		default:
+			builder.WriteString(fmt.Sprintf("Row %d: not verified\n", result.Row))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Verified %d of %d signatures", results.Verified, results.Total))
+
+	return builder.String(), nil
+}
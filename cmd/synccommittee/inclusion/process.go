@@ -18,8 +18,7 @@ import (
 	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	"github.com/attestantio/go-eth2-client/spec"
-	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -41,10 +40,11 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	syncCommittee, err := c.eth2Client.(eth2client.SyncCommitteesProvider).SyncCommitteeAtEpoch(ctx, "head", c.epoch)
+	syncCommitteeResponse, err := c.eth2Client.(eth2client.SyncCommitteesProvider).SyncCommittee(ctx, &api.SyncCommitteeOpts{State: "head", Epoch: &c.epoch})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain sync committee information")
 	}
+	syncCommittee := syncCommitteeResponse.Data
 
 	if syncCommittee == nil {
 		return errors.New("no sync committee returned")
@@ -67,7 +67,7 @@ func (c *command) process(ctx context.Context) error {
 			lastSlot = c.chainTime.CurrentSlot()
 		}
 		for slot := firstSlot; slot <= lastSlot; slot++ {
-			block, err := c.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+			block, err := util.ResponseData(c.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
 			if err != nil {
 				return err
 			}
@@ -75,38 +75,14 @@ func (c *command) process(ctx context.Context) error {
 				c.inclusions = append(c.inclusions, 0)
 				continue
 			}
-			var aggregate *altair.SyncAggregate
-			switch block.Version {
-			case spec.DataVersionAltair:
-				aggregate = block.Altair.Message.Body.SyncAggregate
-				if aggregate.SyncCommitteeBits.BitAt(c.committeeIndex) {
-					c.inclusions = append(c.inclusions, 1)
-				} else {
-					c.inclusions = append(c.inclusions, 2)
-				}
-			case spec.DataVersionBellatrix:
-				aggregate = block.Bellatrix.Message.Body.SyncAggregate
-				if aggregate.SyncCommitteeBits.BitAt(c.committeeIndex) {
-					c.inclusions = append(c.inclusions, 1)
-				} else {
-					c.inclusions = append(c.inclusions, 2)
-				}
-			case spec.DataVersionCapella:
-				aggregate = block.Capella.Message.Body.SyncAggregate
-				if aggregate.SyncCommitteeBits.BitAt(c.committeeIndex) {
-					c.inclusions = append(c.inclusions, 1)
-				} else {
-					c.inclusions = append(c.inclusions, 2)
-				}
-			case spec.DataVersionDeneb:
-				aggregate = block.Deneb.Message.Body.SyncAggregate
-				if aggregate.SyncCommitteeBits.BitAt(c.committeeIndex) {
-					c.inclusions = append(c.inclusions, 1)
-				} else {
-					c.inclusions = append(c.inclusions, 2)
-				}
-			default:
-				return fmt.Errorf("unhandled block version %v", block.Version)
+			aggregate, err := block.SyncAggregate()
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain sync aggregate for slot %d", slot))
+			}
+			if aggregate.SyncCommitteeBits.BitAt(c.committeeIndex) {
+				c.inclusions = append(c.inclusions, 1)
+			} else {
+				c.inclusions = append(c.inclusions, 2)
 			}
 		}
 	}
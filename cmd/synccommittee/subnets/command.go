@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesubnets
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	validator string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	specProvider           eth2client.SpecProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Validator   string       `json:"validator"`
+	Committees  []*committee `json:"committees"`
+	NodeSubnets []uint64     `json:"node_subnets"`
+	Checks      []*check     `json:"checks"`
+	Subscribed  bool         `json:"subscribed"`
+}
+
+// committee is the validator's membership of a sync committee.
+type committee struct {
+	Period      string        `json:"period"`
+	Number      uint64        `json:"number"`
+	StartEpoch  string        `json:"start_epoch"`
+	EndEpoch    string        `json:"end_epoch"`
+	Memberships []*membership `json:"memberships"`
+	Subnets     []uint64      `json:"subnets"`
+}
+
+// membership is a single position of the validator in a sync committee.
+type membership struct {
+	Position          uint64 `json:"position"`
+	Subcommittee      uint64 `json:"subcommittee"`
+	SubcommitteeIndex uint64 `json:"subcommittee_index"`
+}
+
+// check is the result of a single subscription check.
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+	}
+
+	// Timeout is required.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
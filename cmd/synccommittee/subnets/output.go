@@ -0,0 +1,96 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesubnets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the results as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the results as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	member := false
+	for _, committee := range c.results.Committees {
+		builder.WriteString(fmt.Sprintf("%s%s sync committee (period %d, epochs %s-%s): ",
+			strings.ToUpper(committee.Period[:1]), committee.Period[1:],
+			committee.Number, committee.StartEpoch, committee.EndEpoch))
+		if len(committee.Memberships) == 0 {
+			builder.WriteString("not a member\n")
+			continue
+		}
+		member = true
+		builder.WriteString(fmt.Sprintf("subnets %s\n", formatSubnets(committee.Subnets)))
+		if c.verbose {
+			for _, membership := range committee.Memberships {
+				builder.WriteString(fmt.Sprintf("  Position %d: subcommittee %d, index %d\n", membership.Position, membership.Subcommittee, membership.SubcommitteeIndex))
+			}
+		}
+	}
+
+	if !member {
+		builder.WriteString(fmt.Sprintf("Validator %s is not in the current or next sync committee\n", c.results.Validator))
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	if len(c.results.NodeSubnets) == 0 {
+		builder.WriteString("Node sync subnets: none\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("Node sync subnets: %s\n", formatSubnets(c.results.NodeSubnets)))
+	}
+	for _, check := range c.results.Checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("[%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+	switch {
+	case len(c.results.Checks) == 0:
+		builder.WriteString("Result: validator is not in the current sync committee; subscriptions for the next sync committee start shortly before it does\n")
+	case c.results.Subscribed:
+		builder.WriteString("Result: node is subscribed to all required sync subnets\n")
+	default:
+		builder.WriteString("Result: node is not subscribed to all required sync subnets\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func formatSubnets(subnets []uint64) string {
+	res := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		res = append(res, fmt.Sprintf("%d", subnet))
+	}
+
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,267 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesubnets
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// nodeIdentityJSON is the part of the node identity that holds its subscriptions.
+type nodeIdentityJSON struct {
+	Metadata struct {
+		Syncnets string `json:"syncnets"`
+	} `json:"metadata"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	syncCommitteeSize, err := c.specValue(ctx, "SYNC_COMMITTEE_SIZE")
+	if err != nil {
+		return err
+	}
+	subnetCount, err := c.specValue(ctx, "SYNC_COMMITTEE_SUBNET_COUNT")
+	if err != nil {
+		return err
+	}
+	if subnetCount == 0 || syncCommitteeSize%subnetCount != 0 {
+		return fmt.Errorf("invalid sync committee subnet count %d", subnetCount)
+	}
+	subcommitteeSize := syncCommitteeSize / subnetCount
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return err
+	}
+
+	c.results = &results{
+		Validator:  fmt.Sprintf("%d", validator.Index),
+		Committees: make([]*committee, 0, 2),
+		Checks:     make([]*check, 0),
+	}
+
+	currentPeriod := c.chainTime.SlotToSyncCommitteePeriod(c.chainTime.CurrentSlot())
+	for i, period := range []string{"current", "next"} {
+		committee, err := c.committee(ctx, period, currentPeriod+uint64(i), validator.Index, subcommitteeSize)
+		if err != nil {
+			return err
+		}
+		c.results.Committees = append(c.results.Committees, committee)
+	}
+
+	identity := &nodeIdentityJSON{}
+	if _, err := util.BeaconNodeData(ctx, c.eth2Client, c.timeout, "/eth/v1/node/identity", identity); err != nil {
+		return errors.Wrap(err, "failed to obtain node identity")
+	}
+	c.results.NodeSubnets, err = parseSyncnets(identity.Metadata.Syncnets, subnetCount)
+	if err != nil {
+		return err
+	}
+
+	// Only subscriptions for the current sync committee can be checked; nodes
+	// subscribe to the subnets of the next sync committee shortly before it starts.
+	c.results.Checks = subscriptionChecks(c.results.Committees[0].Subnets, c.results.NodeSubnets)
+	c.results.Subscribed = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Subscribed = false
+		}
+	}
+
+	return nil
+}
+
+// committee obtains the validator's membership of the sync committee for the given period.
+func (c *command) committee(ctx context.Context,
+	name string,
+	period uint64,
+	index phase0.ValidatorIndex,
+	subcommitteeSize uint64,
+) (
+	*committee,
+	error,
+) {
+	startEpoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+	syncCommitteeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{State: "head", Epoch: &startEpoch})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain %s sync committee", name))
+	}
+	syncCommittee := syncCommitteeResponse.Data
+	if syncCommittee == nil {
+		return nil, fmt.Errorf("no %s sync committee returned", name)
+	}
+
+	res := &committee{
+		Period:      name,
+		Number:      period,
+		StartEpoch:  fmt.Sprintf("%d", startEpoch),
+		EndEpoch:    fmt.Sprintf("%d", c.chainTime.FirstEpochOfSyncPeriod(period+1)-1),
+		Memberships: memberships(syncCommittee.Validators, index, subcommitteeSize),
+	}
+	res.Subnets = subnets(res.Memberships)
+
+	return res, nil
+}
+
+// memberships returns the positions of the validator in the sync committee.
+func memberships(validators []phase0.ValidatorIndex,
+	index phase0.ValidatorIndex,
+	subcommitteeSize uint64,
+) []*membership {
+	res := make([]*membership, 0)
+	for i, validator := range validators {
+		if validator != index {
+			continue
+		}
+		position := uint64(i)
+		res = append(res, &membership{
+			Position:          position,
+			Subcommittee:      position / subcommitteeSize,
+			SubcommitteeIndex: position % subcommitteeSize,
+		})
+	}
+
+	return res
+}
+
+// subnets returns the sync subnets required by the given memberships.
+// The subnet for a membership is the index of its subcommittee.
+func subnets(memberships []*membership) []uint64 {
+	required := make(map[uint64]bool)
+	for _, membership := range memberships {
+		required[membership.Subcommittee] = true
+	}
+
+	res := make([]uint64, 0, len(required))
+	for subnet := range required {
+		res = append(res, subnet)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+
+	return res
+}
+
+// parseSyncnets parses the syncnets bitvector from node metadata.
+func parseSyncnets(input string, subnetCount uint64) ([]uint64, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid syncnets in node metadata")
+	}
+	if uint64(len(data)) != (subnetCount+7)/8 {
+		return nil, fmt.Errorf("syncnets in node metadata has incorrect length %d", len(data))
+	}
+
+	res := make([]uint64, 0)
+	for subnet := uint64(0); subnet < subnetCount; subnet++ {
+		if data[subnet/8]&(1<<(subnet%8)) != 0 {
+			res = append(res, subnet)
+		}
+	}
+
+	return res, nil
+}
+
+// subscriptionChecks checks that the node is subscribed to each of the required subnets.
+func subscriptionChecks(required []uint64, subscribed []uint64) []*check {
+	subscriptions := make(map[uint64]bool, len(subscribed))
+	for _, subnet := range subscribed {
+		subscriptions[subnet] = true
+	}
+
+	res := make([]*check, 0, len(required))
+	for _, subnet := range required {
+		res = append(res, &check{
+			Name:   fmt.Sprintf("subscribed to sync subnet %d", subnet),
+			Passed: subscriptions[subnet],
+		})
+		if subscriptions[subnet] {
+			res[len(res)-1].Detail = "node metadata includes the subnet"
+		} else {
+			res[len(res)-1].Detail = "node metadata does not include the subnet; sync committee messages will not be published or aggregated"
+		}
+	}
+
+	return res
+}
+
+func (c *command) specValue(ctx context.Context, name string) (uint64, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+
+	tmp, exists := spec[name]
+	if !exists {
+		return 0, fmt.Errorf("spec does not contain %s", name)
+	}
+	value, isUint64 := tmp.(uint64)
+	if !isUint64 {
+		return 0, fmt.Errorf("spec returned non-integer value for %s", name)
+	}
+
+	return value, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee information")
+	}
+
+	return nil
+}
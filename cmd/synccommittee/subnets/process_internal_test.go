@@ -0,0 +1,162 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesubnets
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemberships(t *testing.T) {
+	validators := []phase0.ValidatorIndex{5, 1, 2, 5, 3, 4, 5, 6}
+
+	tests := []struct {
+		name             string
+		index            phase0.ValidatorIndex
+		subcommitteeSize uint64
+		expected         []*membership
+	}{
+		{
+			name:             "Absent",
+			index:            7,
+			subcommitteeSize: 2,
+			expected:         []*membership{},
+		},
+		{
+			name:             "Single",
+			index:            4,
+			subcommitteeSize: 2,
+			expected: []*membership{
+				{Position: 5, Subcommittee: 2, SubcommitteeIndex: 1},
+			},
+		},
+		{
+			name:             "Multiple",
+			index:            5,
+			subcommitteeSize: 2,
+			expected: []*membership{
+				{Position: 0, Subcommittee: 0, SubcommitteeIndex: 0},
+				{Position: 3, Subcommittee: 1, SubcommitteeIndex: 1},
+				{Position: 6, Subcommittee: 3, SubcommitteeIndex: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, memberships(validators, test.index, test.subcommitteeSize))
+		})
+	}
+}
+
+func TestSubnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		memberships []*membership
+		expected    []uint64
+	}{
+		{
+			name:        "Empty",
+			memberships: []*membership{},
+			expected:    []uint64{},
+		},
+		{
+			name: "Deduplicated",
+			memberships: []*membership{
+				{Position: 300, Subcommittee: 2},
+				{Position: 10, Subcommittee: 0},
+				{Position: 310, Subcommittee: 2},
+			},
+			expected: []uint64{0, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, subnets(test.memberships))
+		})
+	}
+}
+
+func TestParseSyncnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		subnetCount uint64
+		expected    []uint64
+		err         string
+	}{
+		{
+			name:        "Invalid",
+			input:       "0xzz",
+			subnetCount: 4,
+			err:         "invalid syncnets in node metadata: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:        "IncorrectLength",
+			input:       "0x0000",
+			subnetCount: 4,
+			err:         "syncnets in node metadata has incorrect length 2",
+		},
+		{
+			name:        "None",
+			input:       "0x00",
+			subnetCount: 4,
+			expected:    []uint64{},
+		},
+		{
+			name:        "Some",
+			input:       "0x05",
+			subnetCount: 4,
+			expected:    []uint64{0, 2},
+		},
+		{
+			name:        "NoPrefix",
+			input:       "0f",
+			subnetCount: 4,
+			expected:    []uint64{0, 1, 2, 3},
+		},
+		{
+			name:        "ExcessBitsIgnored",
+			input:       "0xf8",
+			subnetCount: 4,
+			expected:    []uint64{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseSyncnets(test.input, test.subnetCount)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestSubscriptionChecks(t *testing.T) {
+	res := subscriptionChecks([]uint64{0, 2}, []uint64{2, 3})
+	require.Len(t, res, 2)
+	require.Equal(t, "subscribed to sync subnet 0", res[0].Name)
+	require.False(t, res[0].Passed)
+	require.Equal(t, "subscribed to sync subnet 2", res[1].Name)
+	require.True(t, res[1].Passed)
+
+	require.Empty(t, subscriptionChecks([]uint64{}, []uint64{1}))
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	synccommitteesubnets "github.com/wealdtech/ethdo/cmd/synccommittee/subnets"
+)
+
+var synccommitteeSubnetsCmd = &cobra.Command{
+	Use:   "subnets",
+	Short: "Obtain the sync subnets for a validator",
+	Long: `Obtain the sync subnets required by a validator in the current or next sync committee, and check that the node is subscribed to them.  For example:
+
+    ethdo synccommittee subnets --validator=1234
+
+The validator's positions in the sync committees are mapped to subcommittees, each of which has its own sync subnet.  The node's metadata is checked to confirm that it is subscribed to the subnets required for the current sync committee; if it is not, the validator's sync committee messages are unlikely to be included.
+
+In quiet mode this will return 0 if the node is subscribed to all required subnets, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := synccommitteesubnets.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	synccommitteeCmd.AddCommand(synccommitteeSubnetsCmd)
+	synccommitteeFlags(synccommitteeSubnetsCmd)
+	synccommitteeSubnetsCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
+}
+
+func synccommitteeSubnetsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
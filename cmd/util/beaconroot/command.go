@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilbeaconroot
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	timestamp uint64
+	proof     string
+	validator string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+	executionClient     execution.Service
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	specProvider               eth2client.SpecProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+	beaconStateProvider        eth2client.BeaconStateProvider
+	validatorsProvider         eth2client.ValidatorsProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Timestamp string   `json:"timestamp"`
+	Slot      string   `json:"slot"`
+	Root      string   `json:"root"`
+	RootSlot  string   `json:"root_slot,omitempty"`
+	Checks    []*check `json:"checks"`
+	Verified  bool     `json:"verified"`
+	Proof     *proof   `json:"proof,omitempty"`
+}
+
+// check is the result of a single cross-check.
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// proof is a proof of beacon chain data against the beacon root.
+type proof struct {
+	Field            string   `json:"field"`
+	Leaf             string   `json:"leaf"`
+	GeneralizedIndex string   `json:"generalized_index"`
+	Branch           []string `json:"branch"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		proof:                    viper.GetString("proof"),
+		validator:                viper.GetString("validator"),
+	}
+
+	// Timeout is required.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("timestamp") == "" {
+		return nil, errors.New("timestamp is required")
+	}
+	var err error
+	c.timestamp, err = strconv.ParseUint(viper.GetString("timestamp"), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+
+	c.executionConnection = viper.GetString("execution-connection")
+	if c.executionConnection == "" {
+		return nil, errors.New("execution-connection is required")
+	}
+
+	if c.proof != "" {
+		if _, exists := headerFields[c.proof]; !exists && c.proof != validatorProof {
+			return nil, errors.New("proof must be one of slot, proposer_index, parent_root, state_root, body_root or validator")
+		}
+	}
+	if c.proof == validatorProof && c.validator == "" {
+		return nil, errors.New("validator is required for a validator proof")
+	}
+
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
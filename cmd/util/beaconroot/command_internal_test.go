@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilbeaconroot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"timestamp":            "1700000003",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "TimestampMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "timestamp is required",
+		},
+		{
+			name: "TimestampInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"timestamp":            "yesterday",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "invalid timestamp: strconv.ParseUint: parsing \"yesterday\": invalid syntax",
+		},
+		{
+			name: "ExecutionConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"timestamp": "1700000003",
+			},
+			err: "execution-connection is required",
+		},
+		{
+			name: "ProofInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"timestamp":            "1700000003",
+				"execution-connection": "http://localhost:8545",
+				"proof":                "graffiti",
+			},
+			err: "proof must be one of slot, proposer_index, parent_root, state_root, body_root or validator",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"timestamp":            "1700000003",
+				"execution-connection": "http://localhost:8545",
+				"proof":                "validator",
+			},
+			err: "validator is required for a validator proof",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"timestamp":            "1700000003",
+				"execution-connection": "http://localhost:8545",
+			},
+		},
+		{
+			name: "GoodProof",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"timestamp":            "1700000003",
+				"execution-connection": "http://localhost:8545",
+				"proof":                "validator",
+				"validator":            "1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
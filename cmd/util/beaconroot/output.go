@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilbeaconroot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the beacon root as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the beacon root as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Timestamp: %s (slot %s)\n", c.results.Timestamp, c.results.Slot))
+	if c.results.RootSlot != "" {
+		builder.WriteString(fmt.Sprintf("Beacon root: %s (slot %s)\n", c.results.Root, c.results.RootSlot))
+	} else {
+		builder.WriteString(fmt.Sprintf("Beacon root: %s\n", c.results.Root))
+	}
+	for _, check := range c.results.Checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("[%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+
+	if c.results.Proof != nil {
+		builder.WriteString(fmt.Sprintf("Proof of %s\n", c.results.Proof.Field))
+		builder.WriteString(fmt.Sprintf("  Leaf: %s\n", c.results.Proof.Leaf))
+		builder.WriteString(fmt.Sprintf("  Generalized index: %s\n", c.results.Proof.GeneralizedIndex))
+		builder.WriteString("  Branch:\n")
+		for _, root := range c.results.Proof.Branch {
+			builder.WriteString(fmt.Sprintf("    %s\n", root))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,405 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilbeaconroot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// validatorProof is the proof of a validator in the state.
+const validatorProof = "validator"
+
+// headerFields are the indices of the fields of a beacon block header.
+var headerFields = map[string]uint64{
+	"slot":           0,
+	"proposer_index": 1,
+	"parent_root":    2,
+	"state_root":     3,
+	"body_root":      4,
+}
+
+// validatorsFieldIndex is the index of the validators field in the beacon state.
+const validatorsFieldIndex = 11
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	root, err := util.BeaconRootAtTimestamp(ctx, c.executionClient, c.timestamp)
+	if err != nil {
+		return err
+	}
+	slot := c.chainTime.TimestampToSlot(time.Unix(int64(c.timestamp), 0))
+	c.results = &results{
+		Timestamp: fmt.Sprintf("%d", c.timestamp),
+		Slot:      fmt.Sprintf("%d", slot),
+		Root:      fmt.Sprintf("%#x", root),
+		Checks: []*check{
+			slotCheck(c.timestamp, slot, c.chainTime.StartOfSlot(slot)),
+		},
+	}
+
+	// The root is the parent of the block whose execution payload has the timestamp.
+	header, err := c.blockHeader(ctx, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return err
+	}
+	c.results.Checks = append(c.results.Checks, parentRootCheck(slot, header, root))
+
+	rootHeader, err := c.blockHeader(ctx, fmt.Sprintf("%#x", root))
+	if err != nil {
+		return err
+	}
+	c.results.Checks = append(c.results.Checks, rootBlockCheck(root, rootHeader))
+	if rootHeader != nil {
+		c.results.RootSlot = fmt.Sprintf("%d", rootHeader.Slot)
+	}
+
+	c.results.Verified = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Verified = false
+		}
+	}
+	if !c.results.Verified || c.proof == "" {
+		return nil
+	}
+
+	if c.proof == validatorProof {
+		c.results.Proof, err = c.generateValidatorProof(ctx, rootHeader)
+	} else {
+		c.results.Proof, err = generateHeaderProof(rootHeader, c.proof)
+	}
+
+	return err
+}
+
+// blockHeader obtains a block header, returning nil if there is no block.
+func (c *command) blockHeader(ctx context.Context, blockID string) (*phase0.BeaconBlockHeader, error) {
+	header, err := util.ResponseData(c.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: blockID}))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block header %s", blockID))
+	}
+	if header == nil || header.Header == nil || header.Header.Message == nil {
+		return nil, nil
+	}
+
+	return header.Header.Message, nil
+}
+
+func slotCheck(timestamp uint64, slot phase0.Slot, slotStart time.Time) *check {
+	res := &check{
+		Name:   "slot",
+		Passed: slotStart.Unix() == int64(timestamp),
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("timestamp is the start of slot %d", slot)
+	} else {
+		res.Detail = fmt.Sprintf("timestamp is not the start of a slot; slot %d started at %d", slot, slotStart.Unix())
+	}
+
+	return res
+}
+
+func parentRootCheck(slot phase0.Slot, header *phase0.BeaconBlockHeader, root phase0.Root) *check {
+	res := &check{
+		Name: "parent root",
+	}
+	switch {
+	case header == nil:
+		res.Detail = fmt.Sprintf("no block at slot %d", slot)
+	case header.ParentRoot != root:
+		res.Detail = fmt.Sprintf("block at slot %d has parent root %#x", slot, header.ParentRoot)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("root is the parent of the block at slot %d", slot)
+	}
+
+	return res
+}
+
+func rootBlockCheck(root phase0.Root, header *phase0.BeaconBlockHeader) *check {
+	res := &check{
+		Name: "root block",
+	}
+	if header == nil {
+		res.Detail = fmt.Sprintf("no block with root %#x", root)
+		return res
+	}
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		res.Detail = err.Error()
+		return res
+	}
+	res.Passed = headerRoot == root
+	if res.Passed {
+		res.Detail = fmt.Sprintf("root is the block at slot %d", header.Slot)
+	} else {
+		res.Detail = fmt.Sprintf("block header hashes to %#x", headerRoot)
+	}
+
+	return res
+}
+
+// generateHeaderProof generates the proof of a field of the block header.
+func generateHeaderProof(header *phase0.BeaconBlockHeader, field string) (*proof, error) {
+	fieldIndex, exists := headerFields[field]
+	if !exists {
+		return nil, fmt.Errorf("unknown block header field %s", field)
+	}
+	headerRoots, err := util.ContainerFieldRoots(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block header field roots")
+	}
+	headerDepth := util.MerkleDepth(uint64(len(headerRoots)))
+	branch, err := util.MerkleBranch(headerRoots, headerDepth, fieldIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildProof(field, headerRoots[fieldIndex], branch, 1<<headerDepth|fieldIndex, header)
+}
+
+// generateValidatorProof generates the proof of a validator in the state of
+// the block.
+func (c *command) generateValidatorProof(ctx context.Context, header *phase0.BeaconBlockHeader) (*proof, error) {
+	stateID := fmt.Sprintf("%d", header.Slot)
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, stateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validator")
+	}
+
+	registryLimit := uint64(1099511627776)
+	specDataResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	specData := specDataResponse.Data
+	if val, exists := specData["VALIDATOR_REGISTRY_LIMIT"].(uint64); exists {
+		registryLimit = val
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching state %s\n", stateID)
+	}
+	stateResponse, err := c.beaconStateProvider.BeaconState(ctx, &api.BeaconStateOpts{State: stateID})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain state %s", stateID))
+	}
+	state := stateResponse.Data
+	if state == nil || state.IsEmpty() {
+		return nil, fmt.Errorf("no state %s", stateID)
+	}
+	container, validators, err := stateValidators(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return validatorStateProof(header, container, validators, validator.Index, registryLimit)
+}
+
+// validatorStateProof generates the proof of a validator through the state
+// to the block header.
+func validatorStateProof(header *phase0.BeaconBlockHeader,
+	state ssz.HashRoot,
+	validators []*phase0.Validator,
+	index phase0.ValidatorIndex,
+	registryLimit uint64,
+) (
+	*proof,
+	error,
+) {
+	if uint64(index) >= uint64(len(validators)) {
+		return nil, fmt.Errorf("validator %d not in state", index)
+	}
+
+	fieldRoots, err := util.ContainerFieldRoots(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain state field roots")
+	}
+	stateDepth := util.MerkleDepth(uint64(len(fieldRoots)))
+	stateRoot, err := util.MerkleRoot(fieldRoots, stateDepth)
+	if err != nil {
+		return nil, err
+	}
+	if stateRoot != header.StateRoot {
+		return nil, fmt.Errorf("state root %#x does not match block state root %#x", stateRoot, header.StateRoot)
+	}
+
+	// Validator within the validators list, and the list's length.
+	validatorRoots := make([]phase0.Root, len(validators))
+	for i, validator := range validators {
+		if validatorRoots[i], err = validator.HashTreeRoot(); err != nil {
+			return nil, errors.Wrap(err, "failed to hash validator")
+		}
+	}
+	listDepth := util.MerkleDepth(registryLimit)
+	branch, err := util.MerkleBranch(validatorRoots, listDepth, uint64(index))
+	if err != nil {
+		return nil, err
+	}
+	lengthRoot := phase0.Root{}
+	ssz.MarshalUint64(lengthRoot[:0], uint64(len(validators)))
+	branch = append(branch, lengthRoot)
+	// The list data is the left child of the list root.
+	gindex, err := util.ConcatGeneralizedIndices(2, 1<<listDepth|uint64(index))
+	if err != nil {
+		return nil, err
+	}
+
+	// Validators within the state.
+	stateBranch, err := util.MerkleBranch(fieldRoots, stateDepth, validatorsFieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	branch = append(branch, stateBranch...)
+	if gindex, err = util.ConcatGeneralizedIndices(1<<stateDepth|validatorsFieldIndex, gindex); err != nil {
+		return nil, err
+	}
+
+	// State within the block header.
+	headerRoots, err := util.ContainerFieldRoots(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block header field roots")
+	}
+	headerDepth := util.MerkleDepth(uint64(len(headerRoots)))
+	headerBranch, err := util.MerkleBranch(headerRoots, headerDepth, headerFields["state_root"])
+	if err != nil {
+		return nil, err
+	}
+	branch = append(branch, headerBranch...)
+	if gindex, err = util.ConcatGeneralizedIndices(1<<headerDepth|headerFields["state_root"], gindex); err != nil {
+		return nil, err
+	}
+
+	return buildProof(fmt.Sprintf("validator %d", index), validatorRoots[index], branch, gindex, header)
+}
+
+// buildProof verifies a proof against the block root and creates its output.
+func buildProof(field string,
+	leaf phase0.Root,
+	branch []phase0.Root,
+	gindex uint64,
+	header *phase0.BeaconBlockHeader,
+) (
+	*proof,
+	error,
+) {
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block root")
+	}
+	if !util.VerifyMerkleProof(leaf, branch, gindex, root) {
+		return nil, errors.New("generated proof does not verify")
+	}
+
+	res := &proof{
+		Field:            field,
+		Leaf:             fmt.Sprintf("%#x", leaf),
+		GeneralizedIndex: fmt.Sprintf("%d", gindex),
+		Branch:           make([]string, len(branch)),
+	}
+	for i := range branch {
+		res.Branch[i] = fmt.Sprintf("%#x", branch[i])
+	}
+
+	return res, nil
+}
+
+// stateValidators obtains the validators of a versioned state.
+func stateValidators(state *spec.VersionedBeaconState) (ssz.HashRoot, []*phase0.Validator, error) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0, state.Phase0.Validators, nil
+	case spec.DataVersionAltair:
+		return state.Altair, state.Altair.Validators, nil
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix, state.Bellatrix.Validators, nil
+	case spec.DataVersionCapella:
+		return state.Capella, state.Capella.Validators, nil
+	case spec.DataVersionDeneb:
+		return state.Deneb, state.Deneb.Validators, nil
+	case spec.DataVersionElectra:
+		return state.Electra, state.Electra.Validators, nil
+	case spec.DataVersionFulu:
+		return state.Fulu, state.Fulu.Validators, nil
+	default:
+		return nil, nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide block headers")
+	}
+	c.beaconStateProvider, isProvider = c.eth2Client.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon state")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilbeaconroot
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testState returns a Deneb state with the given number of validators, and
+// the header of the block that produced it.
+func testState(t *testing.T, validators int) (*deneb.BeaconState, *phase0.BeaconBlockHeader) {
+	t.Helper()
+
+	syncCommittee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 512),
+	}
+	state := &deneb.BeaconState{
+		Slot:                         1000,
+		Fork:                         &phase0.Fork{},
+		LatestBlockHeader:            &phase0.BeaconBlockHeader{Slot: 1000, ParentRoot: phase0.Root{0x01}, BodyRoot: phase0.Root{0x02}},
+		BlockRoots:                   make([]phase0.Root, 8192),
+		StateRoots:                   make([]phase0.Root, 8192),
+		ETH1Data:                     &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		RANDAOMixes:                  make([]phase0.Root, 65536),
+		Slashings:                    make([]phase0.Gwei, 8192),
+		JustificationBits:            bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint:  &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:   &phase0.Checkpoint{},
+		FinalizedCheckpoint:          &phase0.Checkpoint{},
+		CurrentSyncCommittee:         syncCommittee,
+		NextSyncCommittee:            syncCommittee,
+		LatestExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{BaseFeePerGas: uint256.NewInt(7)},
+	}
+	for i := 0; i < validators; i++ {
+		state.Validators = append(state.Validators, &phase0.Validator{
+			PublicKey:             phase0.BLSPubKey{byte(i)},
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      phase0.Gwei(32000000000 + i),
+		})
+		state.Balances = append(state.Balances, 32000000000)
+		state.PreviousEpochParticipation = append(state.PreviousEpochParticipation, 7)
+		state.CurrentEpochParticipation = append(state.CurrentEpochParticipation, 3)
+		state.InactivityScores = append(state.InactivityScores, 0)
+	}
+
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	header := &phase0.BeaconBlockHeader{
+		Slot:          1000,
+		ProposerIndex: 2,
+		ParentRoot:    phase0.Root{0x01},
+		StateRoot:     stateRoot,
+		BodyRoot:      phase0.Root{0x02},
+	}
+
+	return state, header
+}
+
+func TestChecks(t *testing.T) {
+	header := &phase0.BeaconBlockHeader{
+		Slot:       1000,
+		ParentRoot: phase0.Root{0x01},
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	require.Equal(t, &check{Name: "slot", Passed: true, Detail: "timestamp is the start of slot 1000"}, slotCheck(1700000000, 1000, time.Unix(1700000000, 0)))
+	require.Equal(t, &check{Name: "slot", Detail: "timestamp is not the start of a slot; slot 1000 started at 1700000000"}, slotCheck(1700000005, 1000, time.Unix(1700000000, 0)))
+
+	require.Equal(t, &check{Name: "parent root", Passed: true, Detail: "root is the parent of the block at slot 1000"}, parentRootCheck(1000, header, phase0.Root{0x01}))
+	require.False(t, parentRootCheck(1000, header, phase0.Root{0x02}).Passed)
+	require.Equal(t, &check{Name: "parent root", Detail: "no block at slot 1000"}, parentRootCheck(1000, nil, phase0.Root{0x01}))
+
+	require.Equal(t, &check{Name: "root block", Passed: true, Detail: "root is the block at slot 1000"}, rootBlockCheck(root, header))
+	require.False(t, rootBlockCheck(phase0.Root{0x03}, header).Passed)
+	require.False(t, rootBlockCheck(root, nil).Passed)
+}
+
+func TestHeaderProof(t *testing.T) {
+	_, header := testState(t, 1)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	for field, index := range headerFields {
+		t.Run(field, func(t *testing.T) {
+			res, err := generateHeaderProof(header, field)
+			require.NoError(t, err)
+			require.Equal(t, field, res.Field)
+			require.Equal(t, strconv.FormatUint(8+index, 10), res.GeneralizedIndex)
+			require.Len(t, res.Branch, 3)
+		})
+	}
+
+	res, err := generateHeaderProof(header, "state_root")
+	require.NoError(t, err)
+	require.Equal(t, header.StateRoot.String(), res.Leaf)
+	require.True(t, verify(t, res, root))
+
+	_, err = generateHeaderProof(header, "graffiti")
+	require.EqualError(t, err, "unknown block header field graffiti")
+}
+
+func TestValidatorStateProof(t *testing.T) {
+	state, header := testState(t, 5)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	for index := range state.Validators {
+		res, err := validatorStateProof(header, state, state.Validators, phase0.ValidatorIndex(index), 1099511627776)
+		require.NoError(t, err)
+		validatorRoot, err := state.Validators[index].HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, phase0.Root(validatorRoot).String(), res.Leaf)
+		// Validator list (40), length (1), state (5) and header (3).
+		require.Len(t, res.Branch, 49)
+		require.True(t, verify(t, res, root))
+	}
+
+	_, err = validatorStateProof(header, state, state.Validators, 5, 1099511627776)
+	require.EqualError(t, err, "validator 5 not in state")
+
+	header.StateRoot = phase0.Root{0x04}
+	_, err = validatorStateProof(header, state, state.Validators, 1, 1099511627776)
+	require.ErrorContains(t, err, "does not match block state root")
+}
+
+func TestValidatorStateProofElectra(t *testing.T) {
+	denebState, _ := testState(t, 5)
+	electraState := &electra.BeaconState{
+		Slot:                         denebState.Slot,
+		Fork:                         denebState.Fork,
+		LatestBlockHeader:            denebState.LatestBlockHeader,
+		BlockRoots:                   denebState.BlockRoots,
+		StateRoots:                   denebState.StateRoots,
+		ETH1Data:                     denebState.ETH1Data,
+		Validators:                   denebState.Validators,
+		Balances:                     denebState.Balances,
+		RANDAOMixes:                  denebState.RANDAOMixes,
+		Slashings:                    denebState.Slashings,
+		PreviousEpochParticipation:   denebState.PreviousEpochParticipation,
+		CurrentEpochParticipation:    denebState.CurrentEpochParticipation,
+		JustificationBits:            denebState.JustificationBits,
+		PreviousJustifiedCheckpoint:  denebState.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:   denebState.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:          denebState.FinalizedCheckpoint,
+		InactivityScores:             denebState.InactivityScores,
+		CurrentSyncCommittee:         denebState.CurrentSyncCommittee,
+		NextSyncCommittee:            denebState.NextSyncCommittee,
+		LatestExecutionPayloadHeader: denebState.LatestExecutionPayloadHeader,
+	}
+	stateRoot, err := electraState.HashTreeRoot()
+	require.NoError(t, err)
+	header := &phase0.BeaconBlockHeader{
+		Slot:       1000,
+		ParentRoot: phase0.Root{0x01},
+		StateRoot:  stateRoot,
+		BodyRoot:   phase0.Root{0x02},
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	container, validators, err := stateValidators(&spec.VersionedBeaconState{
+		Version: spec.DataVersionElectra,
+		Electra: electraState,
+	})
+	require.NoError(t, err)
+	res, err := validatorStateProof(header, container, validators, 3, 1099511627776)
+	require.NoError(t, err)
+	// Validator list (40), length (1), state (6) and header (3).
+	require.Len(t, res.Branch, 50)
+	require.True(t, verify(t, res, root))
+}
+
+// verify verifies a proof against a root.
+func verify(t *testing.T, res *proof, root phase0.Root) bool {
+	t.Helper()
+
+	leaf := phase0.Root{}
+	require.NoError(t, leaf.UnmarshalJSON([]byte(`"`+res.Leaf+`"`)))
+	branch := make([]phase0.Root, len(res.Branch))
+	for i := range res.Branch {
+		require.NoError(t, branch[i].UnmarshalJSON([]byte(`"`+res.Branch[i]+`"`)))
+	}
+	gindex, err := strconv.ParseUint(res.GeneralizedIndex, 10, 64)
+	require.NoError(t, err)
+
+	return util.VerifyMerkleProof(leaf, branch, gindex, root)
+}
@@ -0,0 +1,87 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitidecode
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	graffiti []byte
+	blockID  string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client                eth2client.Service
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	info *util.GraffitiInfo
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		blockID: viper.GetString("blockid"),
+	}
+
+	graffiti := viper.GetString("graffiti")
+	switch {
+	case graffiti == "" && c.blockID == "":
+		return nil, errors.New("one of graffiti or blockid is required")
+	case graffiti != "" && c.blockID != "":
+		return nil, errors.New("only one of graffiti or blockid can be supplied")
+	case graffiti != "":
+		var err error
+		c.graffiti, err = hex.DecodeString(strings.TrimPrefix(graffiti, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid graffiti")
+		}
+		if len(c.graffiti) > util.GraffitiLength {
+			return nil, fmt.Errorf("graffiti is %d bytes, maximum is %d", len(c.graffiti), util.GraffitiLength)
+		}
+	default:
+		// Timeout is only required if we are fetching a block.
+		if viper.GetDuration("timeout") == 0 {
+			return nil, errors.New("timeout is required")
+		}
+		c.timeout = viper.GetDuration("timeout")
+		c.connection = viper.GetString("connection")
+		c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	}
+
+	return c, nil
+}
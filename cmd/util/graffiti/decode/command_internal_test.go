@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitidecode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "Missing",
+			vars: map[string]interface{}{},
+			err:  "one of graffiti or blockid is required",
+		},
+		{
+			name: "Both",
+			vars: map[string]interface{}{
+				"graffiti": "0x68656c6c6f",
+				"blockid":  "head",
+			},
+			err: "only one of graffiti or blockid can be supplied",
+		},
+		{
+			name: "GraffitiInvalid",
+			vars: map[string]interface{}{
+				"graffiti": "hello",
+			},
+			err: "invalid graffiti: encoding/hex: invalid byte: U+0068 'h'",
+		},
+		{
+			name: "GraffitiTooLong",
+			vars: map[string]interface{}{
+				"graffiti": "0x68656c6c6f000000000000000000000000000000000000000000000000000000ff",
+			},
+			err: "graffiti is 33 bytes, maximum is 32",
+		},
+		{
+			name: "Graffiti",
+			vars: map[string]interface{}{
+				"graffiti": "0x68656c6c6f",
+			},
+		},
+		{
+			name: "BlockTimeoutMissing",
+			vars: map[string]interface{}{
+				"blockid": "head",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "Block",
+			vars: map[string]interface{}{
+				"blockid": "head",
+				"timeout": "5s",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitidecode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.info)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	switch c.info.Encoding {
+	case util.GraffitiEncodingEmpty:
+		builder.WriteString("Graffiti: none\n")
+	default:
+		builder.WriteString(fmt.Sprintf("Graffiti: %s\n", c.info.Text))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Encoding: %s\n", c.info.Encoding))
+	}
+	if c.info.ExecutionClient != "" {
+		builder.WriteString(fmt.Sprintf("Execution client: %s", c.info.ExecutionClient))
+		if c.info.ExecutionClientCommit != "" {
+			builder.WriteString(fmt.Sprintf(" (commit %s)", c.info.ExecutionClientCommit))
+		}
+		builder.WriteString("\n")
+	}
+	if c.info.ConsensusClient != "" {
+		builder.WriteString(fmt.Sprintf("Consensus client: %s", c.info.ConsensusClient))
+		if c.info.ConsensusClientCommit != "" {
+			builder.WriteString(fmt.Sprintf(" (commit %s)", c.info.ConsensusClientCommit))
+		}
+		builder.WriteString("\n")
+	}
+	if len(c.info.Clients) > 0 {
+		builder.WriteString(fmt.Sprintf("Clients mentioned: %s\n", strings.Join(c.info.Clients, ", ")))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitidecode
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if c.blockID != "" {
+		if err := c.setup(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		c.graffiti, err = c.blockGraffiti(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.info = util.DecodeGraffiti(c.graffiti)
+
+	return nil
+}
+
+// blockGraffiti obtains the graffiti from the requested block.
+func (c *command) blockGraffiti(ctx context.Context) ([]byte, error) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching block %s\n", c.blockID)
+	}
+	block, err := util.ResponseData(c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: c.blockID}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block")
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", c.blockID)
+	}
+
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain graffiti")
+	}
+
+	return graffiti[:], nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitiencode
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	graffiti string
+	elClient string
+	elCommit string
+	clClient string
+	clCommit string
+
+	// Output.
+	encoded []byte
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		graffiti: viper.GetString("graffiti"),
+		elClient: viper.GetString("el-client"),
+		elCommit: viper.GetString("el-commit"),
+		clClient: viper.GetString("cl-client"),
+		clCommit: viper.GetString("cl-commit"),
+	}
+
+	return c, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitiencode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		output string
+		err    string
+	}{
+		{
+			name:   "Empty",
+			vars:   map[string]interface{}{},
+			output: "0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "Text",
+			vars: map[string]interface{}{
+				"graffiti": "hello",
+			},
+			output: "0x68656c6c6f000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "ClientVersion",
+			vars: map[string]interface{}{
+				"graffiti":  "hello",
+				"el-client": "GE",
+				"el-commit": "1234abcd",
+				"cl-client": "LH",
+				"cl-commit": "5678ef01",
+			},
+			output: "0x4745313233344c48353637382068656c6c6f0000000000000000000000000000",
+		},
+		{
+			name: "TooLong",
+			vars: map[string]interface{}{
+				"graffiti": "this graffiti is far too long to fit in a block",
+			},
+			err: "failed to encode graffiti: graffiti is 47 bytes, maximum is 32",
+		},
+		{
+			name: "ClientCodeUnknown",
+			vars: map[string]interface{}{
+				"el-client": "XX",
+				"cl-client": "LH",
+			},
+			err: "failed to encode graffiti: unknown execution client code \"XX\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			cmd, err := newCommand(context.Background())
+			require.NoError(t, err)
+			err = cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				output, err := cmd.output(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, test.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"context"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	pool       []string
+	seed       string
+	fromEpoch  string
+	toEpoch    string
+	verify     bool
+	validators []string
+
+	// Data access.
+	eth2Client                eth2client.Service
+	chainTime                 chaintime.Service
+	validatorsProvider        eth2client.ValidatorsProvider
+	proposerDutiesProvider    eth2client.ProposerDutiesProvider
+	signedBeaconBlockProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	FromEpoch uint64          `json:"from_epoch"`
+	ToEpoch   uint64          `json:"to_epoch"`
+	Graffiti  []*slotGraffiti `json:"graffiti,omitempty"`
+	Proposals []*proposal     `json:"proposals,omitempty"`
+	Summary   *summary        `json:"summary,omitempty"`
+}
+
+// slotGraffiti is the graffiti generated from the pool for a slot.
+type slotGraffiti struct {
+	Slot     phase0.Slot `json:"slot"`
+	Epoch    uint64      `json:"epoch"`
+	Graffiti string      `json:"graffiti"`
+}
+
+// Proposal statuses.
+const (
+	statusIncluded = "included"
+	statusMismatch = "mismatch"
+	statusMissed   = "missed"
+)
+
+// proposal is the result of verifying the graffiti of a proposal.
+type proposal struct {
+	Slot      phase0.Slot           `json:"slot"`
+	Validator phase0.ValidatorIndex `json:"validator_index"`
+	Expected  string                `json:"expected"`
+	Actual    string                `json:"actual,omitempty"`
+	Status    string                `json:"status"`
+}
+
+type summary struct {
+	Proposals  int `json:"proposals"`
+	Included   int `json:"included"`
+	Mismatched int `json:"mismatched"`
+	Missed     int `json:"missed"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	poolFile := viper.GetString("pool")
+	if poolFile == "" {
+		return nil, errors.New("pool is required")
+	}
+	data, err := os.ReadFile(poolFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read pool")
+	}
+	c.pool, err = parsePool(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.seed = viper.GetString("seed")
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	c.verify = viper.GetBool("verify")
+	c.validators = viper.GetStringSlice("validators")
+	if c.verify && len(c.validators) == 0 {
+		return nil, errors.New("validators are required to verify")
+	}
+	if !c.verify && len(c.validators) > 0 {
+		return nil, errors.New("validators can only be supplied with verify")
+	}
+
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	poolFile := filepath.Join(dir, "pool.txt")
+	require.NoError(t, os.WriteFile(poolFile, []byte("hello {slot}\nworld {epoch}\n"), 0o600))
+	emptyPoolFile := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyPoolFile, []byte("# comment only\n\n"), 0o600))
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"pool": poolFile,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "PoolMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "pool is required",
+		},
+		{
+			name: "PoolNotFound",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"pool":    filepath.Join(dir, "missing.txt"),
+			},
+			err: "failed to read pool: open " + filepath.Join(dir, "missing.txt") + ": no such file or directory",
+		},
+		{
+			name: "PoolEmpty",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"pool":    emptyPoolFile,
+			},
+			err: "pool contains no graffiti",
+		},
+		{
+			name: "VerifyValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"pool":    poolFile,
+				"verify":  true,
+			},
+			err: "validators are required to verify",
+		},
+		{
+			name: "ValidatorsWithoutVerify",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"pool":       poolFile,
+				"validators": []string{"1"},
+			},
+			err: "validators can only be supplied with verify",
+		},
+		{
+			name: "OutputInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"pool":    poolFile,
+				"output":  "bad",
+			},
+			err: `unsupported output format "bad"`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"pool":       poolFile,
+				"seed":       "experiment",
+				"from-epoch": "100",
+				"to-epoch":   "101",
+			},
+		},
+		{
+			name: "GoodVerify",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"pool":       poolFile,
+				"verify":     true,
+				"validators": []string{"1", "2"},
+				"output":     "csv",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the graffiti or verification results as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// CSVHeader returns the header of the CSV output.
+func (c *command) CSVHeader() []string {
+	if c.verify {
+		return []string{
+			"slot",
+			"validator_index",
+			"expected",
+			"actual",
+			"status",
+		}
+	}
+
+	return []string{
+		"slot",
+		"epoch",
+		"graffiti",
+	}
+}
+
+// CSVRecords returns the graffiti for each slot, or the verification result
+// for each proposal, as CSV.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	if c.verify {
+		records := make([][]string, 0, len(c.results.Proposals))
+		for _, proposal := range c.results.Proposals {
+			records = append(records, []string{
+				fmt.Sprintf("%d", proposal.Slot),
+				fmt.Sprintf("%d", proposal.Validator),
+				proposal.Expected,
+				proposal.Actual,
+				proposal.Status,
+			})
+		}
+
+		return records, nil
+	}
+
+	records := make([][]string, 0, len(c.results.Graffiti))
+	for _, graffiti := range c.results.Graffiti {
+		records = append(records, []string{
+			fmt.Sprintf("%d", graffiti.Slot),
+			fmt.Sprintf("%d", graffiti.Epoch),
+			graffiti.Graffiti,
+		})
+	}
+
+	return records, nil
+}
+
+// RenderText renders the graffiti or verification results as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verify {
+		for _, proposal := range c.results.Proposals {
+			switch proposal.Status {
+			case statusIncluded:
+				if c.verbose {
+					builder.WriteString(fmt.Sprintf("Slot %d (validator %d): included %q\n", proposal.Slot, proposal.Validator, proposal.Expected))
+				}
+			case statusMismatch:
+				builder.WriteString(fmt.Sprintf("Slot %d (validator %d): expected %q, found %q\n", proposal.Slot, proposal.Validator, proposal.Expected, proposal.Actual))
+			case statusMissed:
+				builder.WriteString(fmt.Sprintf("Slot %d (validator %d): missed\n", proposal.Slot, proposal.Validator))
+			}
+		}
+		summary := c.results.Summary
+		builder.WriteString(fmt.Sprintf("Graffiti included in %d of %d proposals", summary.Included, summary.Proposals))
+		if summary.Mismatched > 0 || summary.Missed > 0 {
+			builder.WriteString(fmt.Sprintf(" (%d mismatched, %d missed)", summary.Mismatched, summary.Missed))
+		}
+		builder.WriteString("\n")
+
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	for _, graffiti := range c.results.Graffiti {
+		builder.WriteString(fmt.Sprintf("%d: %s\n", graffiti.Slot, graffiti.Graffiti))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// parsePool parses a pool of graffiti templates, one per line.  Blank lines
+// and lines starting with '#' are ignored.
+func parsePool(data []byte) ([]string, error) {
+	pool := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pool = append(pool, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse pool")
+	}
+	if len(pool) == 0 {
+		return nil, errors.New("pool contains no graffiti")
+	}
+
+	return pool, nil
+}
+
+// selectTemplate selects a template from the pool for the given slot.  The
+// selection is random but deterministic for a given seed, allowing the same
+// graffiti to be regenerated when verifying proposals.
+func selectTemplate(pool []string, seed string, slot phase0.Slot) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(seed))
+	slotBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slotBytes, uint64(slot))
+	hasher.Write(slotBytes)
+	hash := hasher.Sum(nil)
+
+	return pool[binary.LittleEndian.Uint64(hash[:8])%uint64(len(pool))]
+}
+
+// expandTemplate substitutes the slot and epoch in to a template.
+func expandTemplate(template string, slot phase0.Slot, epoch phase0.Epoch) string {
+	return strings.NewReplacer(
+		"{slot}", fmt.Sprintf("%d", slot),
+		"{epoch}", fmt.Sprintf("%d", epoch),
+	).Replace(template)
+}
+
+// graffiti returns the graffiti for the given slot.
+func (c *command) graffiti(slot phase0.Slot) (string, error) {
+	graffiti := expandTemplate(selectTemplate(c.pool, c.seed, slot), slot, c.chainTime.SlotToEpoch(slot))
+	if len(graffiti) > util.GraffitiLength {
+		return "", fmt.Errorf("graffiti %q for slot %d is %d bytes, maximum is %d", graffiti, slot, len(graffiti), util.GraffitiLength)
+	}
+
+	return graffiti, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePool(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		pool []string
+		err  string
+	}{
+		{
+			name: "Empty",
+			data: "",
+			err:  "pool contains no graffiti",
+		},
+		{
+			name: "CommentsOnly",
+			data: "# a comment\n\n   \n",
+			err:  "pool contains no graffiti",
+		},
+		{
+			name: "Good",
+			data: "# experiment pool\nhello {slot}\n\n  world {epoch}  \n",
+			pool: []string{"hello {slot}", "world {epoch}"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool, err := parsePool([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.pool, pool)
+			}
+		})
+	}
+}
+
+func TestSelectTemplate(t *testing.T) {
+	pool := []string{"a", "b", "c", "d"}
+
+	// Selection must be deterministic for a given seed and slot.
+	for slot := phase0.Slot(0); slot < 64; slot++ {
+		require.Equal(t, selectTemplate(pool, "seed", slot), selectTemplate(pool, "seed", slot))
+	}
+
+	// Selection should use all of the pool.
+	seen := make(map[string]bool)
+	for slot := phase0.Slot(0); slot < 64; slot++ {
+		seen[selectTemplate(pool, "seed", slot)] = true
+	}
+	require.Len(t, seen, len(pool))
+
+	// Selection should depend on the seed.
+	differs := false
+	for slot := phase0.Slot(0); slot < 64; slot++ {
+		if selectTemplate(pool, "seed", slot) != selectTemplate(pool, "other", slot) {
+			differs = true
+			break
+		}
+	}
+	require.True(t, differs)
+}
+
+func TestExpandTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		slot     phase0.Slot
+		epoch    phase0.Epoch
+		res      string
+	}{
+		{
+			name:     "None",
+			template: "hello",
+			slot:     100,
+			epoch:    3,
+			res:      "hello",
+		},
+		{
+			name:     "Slot",
+			template: "hello {slot}",
+			slot:     100,
+			epoch:    3,
+			res:      "hello 100",
+		},
+		{
+			name:     "Both",
+			template: "{epoch}/{slot} {slot}",
+			slot:     100,
+			epoch:    3,
+			res:      "3/100 100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, expandTemplate(test.template, test.slot, test.epoch))
+		})
+	}
+}
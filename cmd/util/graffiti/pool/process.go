@@ -0,0 +1,207 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilgraffitipool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	fromEpoch := toEpoch
+	if c.fromEpoch != "" {
+		fromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse from epoch")
+		}
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch cannot be before from epoch")
+	}
+
+	c.results = &results{
+		FromEpoch: uint64(fromEpoch),
+		ToEpoch:   uint64(toEpoch),
+	}
+
+	if c.verify {
+		return c.verifyProposals(ctx, fromEpoch, toEpoch)
+	}
+
+	return c.generateGraffiti(fromEpoch, toEpoch)
+}
+
+func (c *command) generateGraffiti(fromEpoch phase0.Epoch, toEpoch phase0.Epoch) error {
+	for slot := c.chainTime.FirstSlotOfEpoch(fromEpoch); slot <= c.chainTime.LastSlotOfEpoch(toEpoch); slot++ {
+		graffiti, err := c.graffiti(slot)
+		if err != nil {
+			return err
+		}
+		c.results.Graffiti = append(c.results.Graffiti, &slotGraffiti{
+			Slot:     slot,
+			Epoch:    uint64(c.chainTime.SlotToEpoch(slot)),
+			Graffiti: graffiti,
+		})
+	}
+
+	return nil
+}
+
+func (c *command) verifyProposals(ctx context.Context, fromEpoch phase0.Epoch, toEpoch phase0.Epoch) error {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+
+	c.results.Summary = &summary{}
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Fetching proposer duties for epoch %d\n", epoch)
+		}
+		dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch, Indices: indices})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+		}
+		duties := dutiesResponse.Data
+		for _, duty := range duties {
+			proposal, err := c.verifyProposal(ctx, duty.Slot, duty.ValidatorIndex)
+			if err != nil {
+				return err
+			}
+			c.results.Proposals = append(c.results.Proposals, proposal)
+			c.results.Summary.Proposals++
+			switch proposal.Status {
+			case statusIncluded:
+				c.results.Summary.Included++
+			case statusMismatch:
+				c.results.Summary.Mismatched++
+			case statusMissed:
+				c.results.Summary.Missed++
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) verifyProposal(ctx context.Context,
+	slot phase0.Slot,
+	validatorIndex phase0.ValidatorIndex,
+) (
+	*proposal,
+	error,
+) {
+	expected, err := c.graffiti(slot)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &proposal{
+		Slot:      slot,
+		Validator: validatorIndex,
+		Expected:  expected,
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching block %d\n", slot)
+	}
+	block, err := util.ResponseData(c.signedBeaconBlockProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+	}
+	if block == nil {
+		proposal.Status = statusMissed
+		return proposal, nil
+	}
+	graffiti, err := block.Graffiti()
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain graffiti for slot %d", slot))
+	}
+
+	proposal.Actual = util.DecodeGraffiti(graffiti[:]).Text
+	if bytes.Equal(bytes.TrimRight(graffiti[:], "\u0000"), []byte(expected)) {
+		proposal.Status = statusIncluded
+	} else {
+		proposal.Status = statusMismatch
+	}
+
+	return proposal, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	if !c.verify {
+		return nil
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.signedBeaconBlockProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilkzgverify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// kzgPointLength is the length of a compressed KZG commitment or proof.
+const kzgPointLength = 48
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	blob       []byte
+	commitment []byte
+	proof      []byte
+	setup      *util.KZGSetup
+
+	// Output.
+	valid  bool
+	reason string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	var err error
+	if viper.GetString("blob") == "" {
+		return nil, errors.New("blob is required")
+	}
+	c.blob, err = parseBlob(viper.GetString("blob"))
+	if err != nil {
+		return nil, err
+	}
+
+	c.commitment, err = parsePoint("commitment", viper.GetString("commitment"))
+	if err != nil {
+		return nil, err
+	}
+	c.proof, err = parsePoint("proof", viper.GetString("proof"))
+	if err != nil {
+		return nil, err
+	}
+
+	if viper.GetString("trusted-setup") == "" {
+		c.setup, err = util.MainnetKZGSetup()
+	} else {
+		c.setup, err = util.LoadKZGSetup(viper.GetString("trusted-setup"))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load trusted setup")
+	}
+
+	return c, nil
+}
+
+// parseBlob parses a blob, supplied either as hex or as the name of a file
+// containing the blob in binary or hex.
+func parseBlob(input string) ([]byte, error) {
+	var blob []byte
+	if strings.HasPrefix(input, "0x") {
+		blob = []byte(input)
+	} else {
+		var err error
+		blob, err = os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read blob")
+		}
+	}
+
+	if trimmed := bytes.TrimSpace(blob); bytes.HasPrefix(trimmed, []byte("0x")) {
+		decoded, err := hex.DecodeString(string(trimmed[2:]))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid blob")
+		}
+		blob = decoded
+	}
+	if len(blob) != util.BytesPerBlob {
+		return nil, fmt.Errorf("blob is %d bytes, expected %d", len(blob), util.BytesPerBlob)
+	}
+
+	return blob, nil
+}
+
+// parsePoint parses a hex KZG commitment or proof.
+func parsePoint(name string, input string) ([]byte, error) {
+	if input == "" {
+		return nil, fmt.Errorf("%s is required", name)
+	}
+	point, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", name))
+	}
+	if len(point) != kzgPointLength {
+		return nil, fmt.Errorf("%s is %d bytes, expected %d", name, len(point), kzgPointLength)
+	}
+
+	return point, nil
+}
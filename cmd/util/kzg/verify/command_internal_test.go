@@ -0,0 +1,183 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilkzgverify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testG2Generator is the compressed generator of G2.
+const testG2Generator = "0x93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+
+// testPointAtInfinity is the commitment and proof of the empty blob.
+const testPointAtInfinity = "0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+
+// writeTestFiles writes a trusted setup whose secret is 1, along with an empty
+// blob in binary and hex form, returning their paths.
+func writeTestFiles(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	setupPath := filepath.Join(dir, "trusted_setup.json")
+	setup := fmt.Sprintf(`{"g2_monomial":["%s","%s"]}`, testG2Generator, testG2Generator)
+	require.NoError(t, os.WriteFile(setupPath, []byte(setup), 0o600))
+	blobPath := filepath.Join(dir, "blob.ssz")
+	require.NoError(t, os.WriteFile(blobPath, make([]byte, util.BytesPerBlob), 0o600))
+	hexBlobPath := filepath.Join(dir, "blob.txt")
+	require.NoError(t, os.WriteFile(hexBlobPath, []byte(fmt.Sprintf("0x%s\n", strings.Repeat("00", util.BytesPerBlob))), 0o600))
+
+	return setupPath, blobPath, hexBlobPath
+}
+
+func TestInput(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	setupPath, blobPath, hexBlobPath := writeTestFiles(t)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "BlobMissing",
+			vars: map[string]interface{}{
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "blob is required",
+		},
+		{
+			name: "BlobFileMissing",
+			vars: map[string]interface{}{
+				"blob":          "/nonexistent/blob.ssz",
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "failed to read blob: open /nonexistent/blob.ssz: no such file or directory",
+		},
+		{
+			name: "BlobInvalid",
+			vars: map[string]interface{}{
+				"blob":          "0xinvalid",
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "invalid blob: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "BlobShort",
+			vars: map[string]interface{}{
+				"blob":          "0x0000",
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "blob is 2 bytes, expected 131072",
+		},
+		{
+			name: "CommitmentMissing",
+			vars: map[string]interface{}{
+				"blob":          blobPath,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "commitment is required",
+		},
+		{
+			name: "CommitmentInvalid",
+			vars: map[string]interface{}{
+				"blob":          blobPath,
+				"commitment":    "invalid",
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+			err: "invalid commitment: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "ProofShort",
+			vars: map[string]interface{}{
+				"blob":          blobPath,
+				"commitment":    testPointAtInfinity,
+				"proof":         "0xc0",
+				"trusted-setup": setupPath,
+			},
+			err: "proof is 1 bytes, expected 48",
+		},
+		{
+			name: "TrustedSetupDefault",
+			vars: map[string]interface{}{
+				"blob":       blobPath,
+				"commitment": testPointAtInfinity,
+				"proof":      testPointAtInfinity,
+			},
+		},
+		{
+			name: "TrustedSetupNotFound",
+			vars: map[string]interface{}{
+				"blob":          blobPath,
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": "/nonexistent/trusted_setup.txt",
+			},
+			err: "failed to load trusted setup: failed to read trusted setup: open /nonexistent/trusted_setup.txt: no such file or directory",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"blob":          blobPath,
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+		},
+		{
+			name: "GoodHexFile",
+			vars: map[string]interface{}{
+				"blob":          hexBlobPath,
+				"commitment":    testPointAtInfinity,
+				"proof":         testPointAtInfinity,
+				"trusted-setup": setupPath,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
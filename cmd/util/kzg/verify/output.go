@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilkzgverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonOutput struct {
+	Valid         bool   `json:"valid"`
+	VersionedHash string `json:"versioned_hash"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Valid:         c.valid,
+		VersionedHash: fmt.Sprintf("%#x", util.KZGCommitmentToVersionedHash(c.commitment)),
+		Reason:        c.reason,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Versioned hash: %#x\n", util.KZGCommitmentToVersionedHash(c.commitment)))
+	}
+	if c.valid {
+		builder.WriteString("KZG proof is valid")
+	} else {
+		builder.WriteString(fmt.Sprintf("KZG proof is invalid: %s", c.reason))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,41 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilkzgverify
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Verifying blob with versioned hash %#x\n", util.KZGCommitmentToVersionedHash(c.commitment))
+	}
+
+	valid, err := c.setup.VerifyBlobKZGProof(c.blob, c.commitment, c.proof)
+	if err != nil {
+		// Malformed data is a verification failure rather than an error.
+		c.reason = err.Error()
+		return nil
+	}
+	c.valid = valid
+	if !valid {
+		c.reason = "proof does not match blob and commitment"
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utilkzgverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	setupPath, blobPath, _ := writeTestFiles(t)
+
+	tests := []struct {
+		name       string
+		commitment string
+		proof      string
+		valid      bool
+		reason     string
+		output     string
+	}{
+		{
+			name:       "Valid",
+			commitment: testPointAtInfinity,
+			proof:      testPointAtInfinity,
+			valid:      true,
+			output:     "KZG proof is valid",
+		},
+		{
+			name:       "ProofMismatch",
+			commitment: testPointAtInfinity,
+			proof:      "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb",
+			reason:     "proof does not match blob and commitment",
+			output:     "KZG proof is invalid: proof does not match blob and commitment",
+		},
+		{
+			name:       "CommitmentNotOnCurve",
+			commitment: "0x800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			proof:      testPointAtInfinity,
+			reason:     "invalid commitment: err mclBnG1_deserialize 800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+			output:     "KZG proof is invalid: invalid commitment: err mclBnG1_deserialize 800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("blob", blobPath)
+			viper.Set("commitment", test.commitment)
+			viper.Set("proof", test.proof)
+			viper.Set("trusted-setup", setupPath)
+
+			c, err := newCommand(context.Background())
+			require.NoError(t, err)
+			require.NoError(t, c.process(context.Background()))
+			require.Equal(t, test.valid, c.valid)
+			require.Equal(t, test.reason, c.reason)
+			output, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.output, output)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	utilbeaconroot "github.com/wealdtech/ethdo/cmd/util/beaconroot"
+)
+
+var utilBeaconRootCmd = &cobra.Command{
+	Use:   "beaconroot",
+	Short: "Obtain and cross-check a beacon root from the EIP-4788 contract",
+	Long: `Obtain the beacon root for a timestamp from the EIP-4788 beacon roots contract, and cross-check it against the consensus chain.  For example:
+
+    ethdo util beaconroot --timestamp=1700000003 --execution-connection=http://localhost:8545
+
+The root held for a timestamp is the parent root of the block at the slot starting at that timestamp.  The contract only holds roots for the most recent 8191 blocks.
+
+A proof anchored to the root can be generated for smart contracts with --proof, which can be a field of the block header (slot, proposer_index, parent_root, state_root or body_root) or "validator" for the validator supplied with --validator.
+
+In quiet mode this will return 0 if the root matches the consensus chain, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := utilbeaconroot.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	utilCmd.AddCommand(utilBeaconRootCmd)
+	utilFlags(utilBeaconRootCmd)
+	utilBeaconRootCmd.Flags().String("timestamp", "", "the timestamp of the execution block for which to obtain the beacon root")
+	utilBeaconRootCmd.Flags().String("proof", "", "the data for which to generate a proof anchored to the beacon root")
+	utilBeaconRootCmd.Flags().String("validator", "", "the validator for which to generate a proof")
+}
+
+func utilBeaconRootBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("timestamp", cmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
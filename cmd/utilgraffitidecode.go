@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	utilgraffitidecode "github.com/wealdtech/ethdo/cmd/util/graffiti/decode"
+)
+
+var utilGraffitiDecodeCmd = &cobra.Command{
+	Use:   "decode",
+	Short: "Decode block graffiti",
+	Long: `Decode block graffiti, either supplied as hex or obtained from a block.  For example:
+
+    ethdo util graffiti decode --graffiti=0x4745313233344c48353637382068656c6c6f0000000000000000000000000000
+
+    ethdo util graffiti decode --blockid=head
+
+In quiet mode this will return 0 if the graffiti can be decoded, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := utilgraffitidecode.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	utilGraffitiCmd.AddCommand(utilGraffitiDecodeCmd)
+	utilGraffitiFlags(utilGraffitiDecodeCmd)
+	utilGraffitiDecodeCmd.Flags().String("graffiti", "", "Graffiti to decode, as hex")
+	utilGraffitiDecodeCmd.Flags().String("blockid", "", "ID of the block from which to decode graffiti")
+}
+
+func utilGraffitiDecodeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("graffiti", cmd.Flags().Lookup("graffiti")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+}
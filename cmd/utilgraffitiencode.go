@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	utilgraffitiencode "github.com/wealdtech/ethdo/cmd/util/graffiti/encode"
+)
+
+var utilGraffitiEncodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Encode text as block graffiti",
+	Long: `Encode text as 32-byte block graffiti, optionally prefixed with client version information.  For example:
+
+    ethdo util graffiti encode --graffiti="hello" --el-client=GE --el-commit=1234abcd --cl-client=LH --cl-commit=5678ef01
+
+In quiet mode this will return 0 if the graffiti can be encoded, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := utilgraffitiencode.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	utilGraffitiCmd.AddCommand(utilGraffitiEncodeCmd)
+	utilGraffitiFlags(utilGraffitiEncodeCmd)
+	utilGraffitiEncodeCmd.Flags().String("graffiti", "", "Text to encode as graffiti")
+	utilGraffitiEncodeCmd.Flags().String("el-client", "", "Two-letter code of the execution client for the client version prefix (e.g. GE)")
+	utilGraffitiEncodeCmd.Flags().String("el-commit", "", "Commit of the execution client for the client version prefix")
+	utilGraffitiEncodeCmd.Flags().String("cl-client", "", "Two-letter code of the consensus client for the client version prefix (e.g. LH)")
+	utilGraffitiEncodeCmd.Flags().String("cl-commit", "", "Commit of the consensus client for the client version prefix")
+}
+
+func utilGraffitiEncodeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("graffiti", cmd.Flags().Lookup("graffiti")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("el-client", cmd.Flags().Lookup("el-client")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("el-commit", cmd.Flags().Lookup("el-commit")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("cl-client", cmd.Flags().Lookup("cl-client")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("cl-commit", cmd.Flags().Lookup("cl-commit")); err != nil {
+		panic(err)
+	}
+}
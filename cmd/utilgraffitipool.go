@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	utilgraffitipool "github.com/wealdtech/ethdo/cmd/util/graffiti/pool"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var utilGraffitiPoolCmd = &cobra.Command{
+	Use:   "pool",
+	Short: "Generate per-slot graffiti from a pool, and verify proposals",
+	Long: `Generate per-slot graffiti from a pool of templates, and verify which graffiti was included in proposals.  For example:
+
+    ethdo util graffiti pool --pool=pool.txt --seed=experiment1 --from-epoch=200000 --to-epoch=200010
+
+    ethdo util graffiti pool --pool=pool.txt --seed=experiment1 --from-epoch=200000 --to-epoch=200010 --verify --validators=1,2,3
+
+The pool file contains one graffiti template per line; blank lines and lines starting with '#' are ignored.  Templates can contain {slot} and {epoch}, which are replaced with the slot and epoch of the proposal.  A template is selected randomly for each slot, using the seed so that the same graffiti is generated each time.  When verifying, the same pool and seed must be supplied as when the graffiti was generated.
+
+In quiet mode this will return 0 if the graffiti is generated or verified, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "csv"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := utilgraffitipool.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	utilGraffitiCmd.AddCommand(utilGraffitiPoolCmd)
+	utilGraffitiFlags(utilGraffitiPoolCmd)
+	utilGraffitiPoolCmd.Flags().String("pool", "", "the file containing the pool of graffiti templates")
+	utilGraffitiPoolCmd.Flags().String("seed", "", "the seed used to select graffiti from the pool")
+	utilGraffitiPoolCmd.Flags().String("from-epoch", "", "the first epoch for which to generate graffiti (defaults to to-epoch)")
+	utilGraffitiPoolCmd.Flags().String("to-epoch", "", "the last epoch for which to generate graffiti (defaults to the current epoch)")
+	utilGraffitiPoolCmd.Flags().Bool("verify", false, "verify the graffiti included in proposals rather than generating it")
+	utilGraffitiPoolCmd.Flags().StringSlice("validators", nil, "the validators whose proposals to verify")
+}
+
+func utilGraffitiPoolBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("pool", cmd.Flags().Lookup("pool")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("seed", cmd.Flags().Lookup("seed")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("verify", cmd.Flags().Lookup("verify")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
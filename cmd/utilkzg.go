@@ -0,0 +1,39 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var utilKZGCmd = &cobra.Command{
+	Use:   "kzg",
+	Short: "Work with KZG commitments and proofs",
+	Long:  `Work with KZG commitments and proofs for blobs.`,
+}
+
+func init() {
+	utilCmd.AddCommand(utilKZGCmd)
+}
+
+func utilKZGFlags(cmd *cobra.Command) {
+	cmd.Flags().String("trusted-setup", "", "KZG trusted setup file, in JSON or c-kzg text format (defaults to the mainnet setup)")
+}
+
+func utilKZGBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("trusted-setup", cmd.Flags().Lookup("trusted-setup")); err != nil {
+		panic(err)
+	}
+}
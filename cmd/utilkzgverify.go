@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	utilkzgverify "github.com/wealdtech/ethdo/cmd/util/kzg/verify"
+)
+
+var utilKZGVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a blob against its KZG commitment and proof",
+	Long: `Verify a blob against its KZG commitment and proof.  For example:
+
+    ethdo util kzg verify --blob=blob.txt --commitment=0x8f59... --proof=0xa4b1...
+
+The blob can be supplied as hex, or as the name of a file containing the blob in binary or hex.  The mainnet trusted setup, which is also used by the public testnets, is used by default.  A different setup, such as that of a devnet, can be supplied with --trusted-setup in either the JSON format of the consensus specifications or the text format used by c-kzg.  It can also be set with "trusted-setup" in the configuration file.
+
+In quiet mode this will return 0 if the proof is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := utilkzgverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	utilKZGCmd.AddCommand(utilKZGVerifyCmd)
+	utilKZGFlags(utilKZGVerifyCmd)
+	utilKZGVerifyCmd.Flags().String("blob", "", "Blob to verify, as hex or the name of a file containing the blob")
+	utilKZGVerifyCmd.Flags().String("commitment", "", "KZG commitment of the blob")
+	utilKZGVerifyCmd.Flags().String("proof", "", "KZG proof of the blob")
+}
+
+func utilKZGVerifyBindings(cmd *cobra.Command) {
+	utilKZGBindings(cmd)
+	if err := viper.BindPFlag("blob", cmd.Flags().Lookup("blob")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("commitment", cmd.Flags().Lookup("commitment")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorattestationsexport
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validator string
+	fromEpoch string
+	toEpoch   string
+
+	// Data access.
+	eth2Client               eth2client.Service
+	chainTime                chaintime.Service
+	validatorsProvider       eth2client.ValidatorsProvider
+	attesterDutiesProvider   eth2client.AttesterDutiesProvider
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+	blocksCache              *util.BlockAttestationsCache
+	headersCache             *util.BeaconBlockHeaderCache
+
+	// Processing.
+	// committeeSizes are the sizes of the committees at each slot.
+	committeeSizes map[phase0.Slot]map[phase0.CommitteeIndex]uint64
+
+	// Output.
+	results []*attestation
+}
+
+// attestation is the attestation of the validator for a single duty.
+type attestation struct {
+	ValidatorIndex    uint64 `json:"validator_index"`
+	Epoch             uint64 `json:"epoch"`
+	Slot              uint64 `json:"slot"`
+	CommitteeIndex    uint64 `json:"committee_index"`
+	CommitteePosition uint64 `json:"committee_position"`
+	Included          bool   `json:"included"`
+	InclusionSlot     uint64 `json:"inclusion_slot,omitempty"`
+	InclusionDistance uint64 `json:"inclusion_distance,omitempty"`
+	HeadCorrect       bool   `json:"head_correct"`
+	HeadTimely        bool   `json:"head_timely"`
+	TargetCorrect     bool   `json:"target_correct"`
+	TargetTimely      bool   `json:"target_timely"`
+	SourceCorrect     bool   `json:"source_correct"`
+	SourceTimely      bool   `json:"source_timely"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:          viper.GetBool("quiet"),
+		verbose:        viper.GetBool("verbose"),
+		debug:          viper.GetBool("debug"),
+		committeeSizes: make(map[phase0.Slot]map[phase0.CommitteeIndex]uint64),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorattestationsexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.format == output.JSON {
+		data, err := json.Marshal(c.results)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	// Other formats output each attestation as a separate item, so that
+	// NDJSON provides one attestation per line and CSV one per row.
+	stream := output.NewStream(c.format)
+	lines := make([]string, 0, len(c.results))
+	for _, result := range c.results {
+		line, err := stream.Render(ctx, result)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// RenderJSON renders the attestation as JSON.
+func (a *attestation) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// RenderText renders the attestation as text.
+func (a *attestation) RenderText(_ context.Context) (string, error) {
+	if !a.Included {
+		return fmt.Sprintf("Epoch %d slot %d committee %d: not included", a.Epoch, a.Slot, a.CommitteeIndex), nil
+	}
+
+	return fmt.Sprintf("Epoch %d slot %d committee %d: included at slot %d (distance %d); head %s, target %s, source %s",
+		a.Epoch,
+		a.Slot,
+		a.CommitteeIndex,
+		a.InclusionSlot,
+		a.InclusionDistance,
+		voteState(a.HeadCorrect, a.HeadTimely),
+		voteState(a.TargetCorrect, a.TargetTimely),
+		voteState(a.SourceCorrect, a.SourceTimely),
+	), nil
+}
+
+// CSVHeader returns the names of the columns.
+func (*attestation) CSVHeader() []string {
+	return []string{
+		"validator_index",
+		"epoch",
+		"slot",
+		"committee_index",
+		"committee_position",
+		"included",
+		"inclusion_slot",
+		"inclusion_distance",
+		"head_correct",
+		"head_timely",
+		"target_correct",
+		"target_timely",
+		"source_correct",
+		"source_timely",
+	}
+}
+
+// CSVRecords returns the attestation as a single record.
+func (a *attestation) CSVRecords(_ context.Context) ([][]string, error) {
+	inclusionSlot := ""
+	inclusionDistance := ""
+	if a.Included {
+		inclusionSlot = fmt.Sprintf("%d", a.InclusionSlot)
+		inclusionDistance = fmt.Sprintf("%d", a.InclusionDistance)
+	}
+
+	return [][]string{{
+		fmt.Sprintf("%d", a.ValidatorIndex),
+		fmt.Sprintf("%d", a.Epoch),
+		fmt.Sprintf("%d", a.Slot),
+		fmt.Sprintf("%d", a.CommitteeIndex),
+		fmt.Sprintf("%d", a.CommitteePosition),
+		fmt.Sprintf("%t", a.Included),
+		inclusionSlot,
+		inclusionDistance,
+		fmt.Sprintf("%t", a.HeadCorrect),
+		fmt.Sprintf("%t", a.HeadTimely),
+		fmt.Sprintf("%t", a.TargetCorrect),
+		fmt.Sprintf("%t", a.TargetTimely),
+		fmt.Sprintf("%t", a.SourceCorrect),
+		fmt.Sprintf("%t", a.SourceTimely),
+	}}, nil
+}
+
+// voteState describes the state of a vote.
+func voteState(correct bool, timely bool) string {
+	switch {
+	case !correct:
+		return "incorrect"
+	case !timely:
+		return "correct, late"
+	default:
+		return "correct"
+	}
+}
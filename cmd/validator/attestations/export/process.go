@@ -0,0 +1,258 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorattestationsexport
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return err
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return err
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch must not be before from epoch")
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return err
+	}
+
+	currentSlot := c.chainTime.CurrentSlot()
+	c.results = make([]*attestation, 0, int(toEpoch-fromEpoch)+1)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if c.debug && (epoch-fromEpoch)%100 == 0 {
+			fmt.Fprintf(os.Stderr, "Processing epoch %d of %d-%d\n", epoch, fromEpoch, toEpoch)
+		}
+		dutiesResponse, err := c.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: []phase0.ValidatorIndex{validator.Index}})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+		}
+		duties := dutiesResponse.Data
+		for _, duty := range duties {
+			if duty.Slot >= currentSlot {
+				// Attestation cannot yet have been included.
+				continue
+			}
+			res, err := c.processDuty(ctx, duty, currentSlot)
+			if err != nil {
+				return err
+			}
+			c.results = append(c.results, res)
+		}
+	}
+
+	return nil
+}
+
+// processDuty scans the blocks in which the attestation for the duty could be
+// included, and assesses the attestation if found.
+func (c *command) processDuty(ctx context.Context,
+	duty *apiv1.AttesterDuty,
+	currentSlot phase0.Slot,
+) (
+	*attestation,
+	error,
+) {
+	epoch := c.chainTime.SlotToEpoch(duty.Slot)
+	res := &attestation{
+		ValidatorIndex:    uint64(duty.ValidatorIndex),
+		Epoch:             uint64(epoch),
+		Slot:              uint64(duty.Slot),
+		CommitteeIndex:    uint64(duty.CommitteeIndex),
+		CommitteePosition: duty.ValidatorCommitteeIndex,
+	}
+
+	// Attestations can be included up to the end of the following epoch.
+	lastSlot := c.chainTime.LastSlotOfEpoch(epoch + 1)
+	if lastSlot > currentSlot {
+		lastSlot = currentSlot
+	}
+
+	committeeSize := func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+		return c.committeeSize(ctx, duty.Slot, committeeIndex)
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		attestations, found, err := c.blocksCache.Fetch(ctx, slot)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block at slot %d", slot))
+		}
+		if !found {
+			continue
+		}
+		index, err := locateAttestation(attestations, duty, committeeSize)
+		if err != nil {
+			return nil, err
+		}
+		if index == -1 {
+			continue
+		}
+
+		data := attestations[index].Data
+		res.Included = true
+		res.InclusionSlot = uint64(slot)
+		res.InclusionDistance = uint64(slot - duty.Slot)
+		res.HeadCorrect, err = util.AttestationHeadCorrect(ctx, c.headersCache, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain head vote correctness")
+		}
+		res.TargetCorrect, err = util.AttestationTargetCorrect(ctx, c.headersCache, c.chainTime, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain target vote correctness")
+		}
+		// An attestation with an incorrect source cannot be included.
+		res.SourceCorrect = true
+		assessTimeliness(res, c.chainTime.SlotsPerEpoch(), epoch >= c.chainTime.DenebInitialEpoch())
+
+		break
+	}
+
+	return res, nil
+}
+
+// locateAttestation returns the index of the attestation that contains the
+// vote for the given duty, or -1 if there is no such attestation.
+func locateAttestation(attestations []*util.BlockAttestation,
+	duty *apiv1.AttesterDuty,
+	committeeSize func(phase0.CommitteeIndex) (uint64, error),
+) (
+	int,
+	error,
+) {
+	for i, attestation := range attestations {
+		if attestation.Data.Slot != duty.Slot {
+			continue
+		}
+		attested, err := attestation.Attested(duty.CommitteeIndex, duty.ValidatorCommitteeIndex, committeeSize)
+		if err != nil {
+			return -1, err
+		}
+		if attested {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// committeeSize returns the size of the given committee at the given slot.
+// The committees are obtained for the whole epoch at a time.
+func (c *command) committeeSize(ctx context.Context,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+) (
+	uint64,
+	error,
+) {
+	if _, exists := c.committeeSizes[slot]; !exists {
+		committeesResponse, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", slot)})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to obtain beacon committees")
+		}
+		committees := committeesResponse.Data
+		for _, committee := range committees {
+			if _, exists := c.committeeSizes[committee.Slot]; !exists {
+				c.committeeSizes[committee.Slot] = make(map[phase0.CommitteeIndex]uint64)
+			}
+			c.committeeSizes[committee.Slot][committee.Index] = uint64(len(committee.Validators))
+		}
+	}
+
+	size, exists := c.committeeSizes[slot][committeeIndex]
+	if !exists {
+		return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, slot)
+	}
+
+	return size, nil
+}
+
+// assessTimeliness sets the timeliness of each vote of the included
+// attestation, according to its inclusion distance.
+func assessTimeliness(res *attestation, slotsPerEpoch uint64, deneb bool) {
+	res.SourceTimely = res.InclusionDistance <= uint64(math.Sqrt(float64(slotsPerEpoch)))
+	// From Deneb the target vote is timely whenever the attestation is included.
+	res.TargetTimely = res.TargetCorrect && (deneb || res.InclusionDistance <= slotsPerEpoch)
+	res.HeadTimely = res.HeadCorrect && res.InclusionDistance == 1
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	if _, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider); !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	beaconBlockHeadersProvider, isProvider := c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committees")
+	}
+
+	c.blocksCache = util.NewBlockAttestationsCache(c.eth2Client)
+	c.headersCache = util.NewBeaconBlockHeaderCache(beaconBlockHeadersProvider)
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorattestationsexport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func testAttestation(slot phase0.Slot, index phase0.CommitteeIndex, bits ...uint64) *util.BlockAttestation {
+	aggregationBits := bitfield.NewBitlist(8)
+	for _, bit := range bits {
+		aggregationBits.SetBitAt(bit, true)
+	}
+
+	return util.NewBlockAttestation(&phase0.Attestation{
+		AggregationBits: aggregationBits,
+		Data: &phase0.AttestationData{
+			Slot:   slot,
+			Index:  index,
+			Source: &phase0.Checkpoint{},
+			Target: &phase0.Checkpoint{},
+		},
+	})
+}
+
+func testCommitteeSize(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+	if committeeIndex > 4 {
+		return 0, fmt.Errorf("no committee %d", committeeIndex)
+	}
+
+	return 8, nil
+}
+
+func TestLocateAttestation(t *testing.T) {
+	duty := &apiv1.AttesterDuty{
+		Slot:                    100,
+		CommitteeIndex:          2,
+		ValidatorCommitteeIndex: 3,
+	}
+
+	tests := []struct {
+		name         string
+		attestations []*util.BlockAttestation
+		expected     int
+	}{
+		{
+			name:     "Empty",
+			expected: -1,
+		},
+		{
+			name: "Found",
+			attestations: []*util.BlockAttestation{
+				testAttestation(99, 2, 3),
+				testAttestation(100, 1, 3),
+				testAttestation(100, 2, 3, 4),
+			},
+			expected: 2,
+		},
+		{
+			name: "NotSet",
+			attestations: []*util.BlockAttestation{
+				testAttestation(100, 2, 1, 2),
+			},
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := locateAttestation(test.attestations, duty, testCommitteeSize)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestCommitteeSize(t *testing.T) {
+	c := &command{
+		committeeSizes: map[phase0.Slot]map[phase0.CommitteeIndex]uint64{
+			100: {0: 120, 1: 121},
+		},
+	}
+
+	size, err := c.committeeSize(context.Background(), 100, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(121), size)
+
+	_, err = c.committeeSize(context.Background(), 100, 2)
+	require.EqualError(t, err, "no committee 2 at slot 100")
+}
+
+func TestAssessTimeliness(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *attestation
+		deneb    bool
+		expected *attestation
+	}{
+		{
+			name:     "Optimal",
+			input:    &attestation{InclusionDistance: 1, HeadCorrect: true, TargetCorrect: true},
+			expected: &attestation{InclusionDistance: 1, HeadCorrect: true, HeadTimely: true, TargetCorrect: true, TargetTimely: true, SourceTimely: true},
+		},
+		{
+			name:     "SourceLate",
+			input:    &attestation{InclusionDistance: 6, HeadCorrect: true, TargetCorrect: true},
+			expected: &attestation{InclusionDistance: 6, HeadCorrect: true, TargetCorrect: true, TargetTimely: true},
+		},
+		{
+			name:     "TargetLate",
+			input:    &attestation{InclusionDistance: 40, TargetCorrect: true},
+			expected: &attestation{InclusionDistance: 40, TargetCorrect: true},
+		},
+		{
+			name:     "TargetLateDeneb",
+			input:    &attestation{InclusionDistance: 40, TargetCorrect: true},
+			deneb:    true,
+			expected: &attestation{InclusionDistance: 40, TargetCorrect: true, TargetTimely: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assessTimeliness(test.input, 32, test.deneb)
+			require.Equal(t, test.expected, test.input)
+		})
+	}
+}
+
+func TestOutput(t *testing.T) {
+	results := []*attestation{
+		{
+			ValidatorIndex:    1234,
+			Epoch:             3,
+			Slot:              100,
+			CommitteeIndex:    2,
+			CommitteePosition: 7,
+			Included:          true,
+			InclusionSlot:     102,
+			InclusionDistance: 2,
+			HeadCorrect:       true,
+			TargetCorrect:     true,
+			TargetTimely:      true,
+			SourceCorrect:     true,
+			SourceTimely:      true,
+		},
+		{
+			ValidatorIndex:    1234,
+			Epoch:             4,
+			Slot:              140,
+			CommitteeIndex:    1,
+			CommitteePosition: 12,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   output.Format
+		expected string
+	}{
+		{
+			name:   "Text",
+			format: output.Text,
+			expected: `Epoch 3 slot 100 committee 2: included at slot 102 (distance 2); head correct, late, target correct, source correct
+Epoch 4 slot 140 committee 1: not included`,
+		},
+		{
+			name:   "NDJSON",
+			format: output.NDJSON,
+			expected: `{"validator_index":1234,"epoch":3,"slot":100,"committee_index":2,"committee_position":7,"included":true,"inclusion_slot":102,"inclusion_distance":2,"head_correct":true,"head_timely":false,"target_correct":true,"target_timely":true,"source_correct":true,"source_timely":true}
+{"validator_index":1234,"epoch":4,"slot":140,"committee_index":1,"committee_position":12,"included":false,"head_correct":false,"head_timely":false,"target_correct":false,"target_timely":false,"source_correct":false,"source_timely":false}`,
+		},
+		{
+			name:   "CSV",
+			format: output.CSV,
+			expected: `validator_index,epoch,slot,committee_index,committee_position,included,inclusion_slot,inclusion_distance,head_correct,head_timely,target_correct,target_timely,source_correct,source_timely
+1234,3,100,2,7,true,102,2,true,false,true,true,true,true
+1234,4,140,1,12,false,,,false,false,false,false,false,false`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				format:  test.format,
+				results: results,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
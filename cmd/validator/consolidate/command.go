@@ -0,0 +1,153 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	sourceValidator      string
+	targetValidator      string
+	withdrawalPrivateKey []byte
+	dryRun               bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+	executionClient     execution.Service
+
+	// Processing.
+	consensusClient    consensusclient.Service
+	chainTime          chaintime.Service
+	validatorsProvider consensusclient.ValidatorsProvider
+	specProvider       consensusclient.SpecProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Source      *validatorSummary `json:"source"`
+	Target      *validatorSummary `json:"target"`
+	Checks      []*check          `json:"checks"`
+	Ready       bool              `json:"ready"`
+	Outcome     *outcome          `json:"outcome"`
+	Transaction *transaction      `json:"transaction,omitempty"`
+}
+
+// validatorSummary is the information about a validator relevant to consolidation.
+type validatorSummary struct {
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Balance               string `json:"balance"`
+	EffectiveBalance      string `json:"effective_balance"`
+}
+
+// check is the result of a single consolidation check.
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// outcome is the expected result of the consolidation, in Gwei.
+type outcome struct {
+	TransferredBalance     string `json:"transferred_balance"`
+	TargetBalance          string `json:"target_balance"`
+	TargetEffectiveBalance string `json:"target_effective_balance"`
+	ExcessBalance          string `json:"excess_balance"`
+}
+
+// transaction is the execution layer transaction that makes the consolidation request.
+type transaction struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data"`
+	Signed    string `json:"signed,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Submitted bool   `json:"submitted"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		sourceValidator:          viper.GetString("source-validator"),
+		targetValidator:          viper.GetString("target-validator"),
+		dryRun:                   viper.GetBool("dry-run"),
+	}
+
+	// Timeout is required.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.sourceValidator == "" {
+		return nil, errors.New("source-validator is required")
+	}
+	if c.targetValidator == "" {
+		return nil, errors.New("target-validator is required")
+	}
+
+	c.executionConnection = viper.GetString("execution-connection")
+	if c.executionConnection == "" {
+		return nil, errors.New("execution-connection is required")
+	}
+
+	if viper.GetString("withdrawal-private-key") != "" {
+		var err error
+		c.withdrawalPrivateKey, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("withdrawal-private-key"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal private key")
+		}
+		if len(c.withdrawalPrivateKey) != 32 {
+			return nil, errors.New("withdrawal private key must be 32 bytes")
+		}
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"source-validator":     "1",
+				"target-validator":     "2",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "SourceValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"target-validator":     "2",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "source-validator is required",
+		},
+		{
+			name: "TargetValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"source-validator":     "1",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "target-validator is required",
+		},
+		{
+			name: "ExecutionConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"source-validator": "1",
+				"target-validator": "2",
+			},
+			err: "execution-connection is required",
+		},
+		{
+			name: "WithdrawalPrivateKeyInvalid",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"source-validator":       "1",
+				"target-validator":       "2",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0xinvalid",
+			},
+			err: "invalid withdrawal private key: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "WithdrawalPrivateKeyShort",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"source-validator":       "1",
+				"target-validator":       "2",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646",
+			},
+			err: "withdrawal private key must be 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"source-validator":       "1",
+				"target-validator":       "2",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
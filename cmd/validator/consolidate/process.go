@@ -0,0 +1,394 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Withdrawal credential prefixes.
+const (
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// parameters are the chain parameters used to check and model the consolidation.
+type parameters struct {
+	shardCommitteePeriod       phase0.Epoch
+	electraForkEpoch           phase0.Epoch
+	electraKnown               bool
+	depositChainID             uint64
+	effectiveBalanceIncrement  phase0.Gwei
+	maxEffectiveBalanceElectra phase0.Gwei
+}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	source, err := util.ParseValidator(ctx, c.validatorsProvider, c.sourceValidator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain source validator")
+	}
+	target, err := util.ParseValidator(ctx, c.validatorsProvider, c.targetValidator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain target validator")
+	}
+
+	params, err := c.obtainParameters(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, err := util.ExecutionQuantity(ctx, c.executionClient, "eth_chainId", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution chain ID")
+	}
+	currentEpoch := c.chainTime.CurrentEpoch()
+
+	c.results = &results{
+		Source: summarise(source),
+		Target: summarise(target),
+		Checks: []*check{
+			electraCheck(params, currentEpoch),
+			chainIDCheck(params.depositChainID, chainID),
+			distinctCheck(source, target),
+			activeCheck("source active", source),
+			activeCheck("target active", target),
+			sourceCredentialsCheck(source),
+			targetCredentialsCheck(target),
+			shardCommitteePeriodCheck(source, currentEpoch, params.shardCommitteePeriod),
+		},
+		Outcome: consolidationOutcome(source, target, params),
+	}
+	if c.withdrawalPrivateKey != nil {
+		c.results.Checks = append(c.results.Checks, withdrawalKeyCheck(source, c.withdrawalPrivateKey))
+	}
+
+	c.results.Ready = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Ready = false
+		}
+	}
+	if !c.results.Ready {
+		return nil
+	}
+
+	return c.generateTransaction(ctx, source, target, chainID)
+}
+
+func summarise(validator *apiv1.Validator) *validatorSummary {
+	return &validatorSummary{
+		Index:                 fmt.Sprintf("%d", validator.Index),
+		Pubkey:                fmt.Sprintf("%#x", validator.Validator.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+		Balance:               fmt.Sprintf("%d", validator.Balance),
+		EffectiveBalance:      fmt.Sprintf("%d", validator.Validator.EffectiveBalance),
+	}
+}
+
+func electraCheck(params *parameters, currentEpoch phase0.Epoch) *check {
+	res := &check{
+		Name: "electra",
+	}
+	switch {
+	case !params.electraKnown:
+		res.Detail = "beacon node does not know of the Electra fork"
+	case currentEpoch < params.electraForkEpoch:
+		res.Detail = fmt.Sprintf("Electra is not active until epoch %d", params.electraForkEpoch)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("Electra active since epoch %d", params.electraForkEpoch)
+	}
+
+	return res
+}
+
+func chainIDCheck(depositChainID uint64, chainID *big.Int) *check {
+	res := &check{
+		Name: "chain ID",
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != depositChainID {
+		res.Detail = fmt.Sprintf("execution node chain ID %s does not match deposit chain ID %d", chainID.String(), depositChainID)
+		return res
+	}
+	res.Passed = true
+	res.Detail = fmt.Sprintf("both %d", depositChainID)
+
+	return res
+}
+
+func distinctCheck(source *apiv1.Validator, target *apiv1.Validator) *check {
+	res := &check{
+		Name:   "distinct validators",
+		Passed: source.Index != target.Index,
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("consolidating validator %d into validator %d", source.Index, target.Index)
+	} else {
+		res.Detail = "source and target validators are the same"
+	}
+
+	return res
+}
+
+func activeCheck(name string, validator *apiv1.Validator) *check {
+	return &check{
+		Name:   name,
+		Passed: validator.Status == apiv1.ValidatorStateActiveOngoing,
+		Detail: fmt.Sprintf("validator %d is in state %v", validator.Index, validator.Status),
+	}
+}
+
+func sourceCredentialsCheck(validator *apiv1.Validator) *check {
+	res := &check{
+		Name: "source credentials",
+	}
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix, compoundingWithdrawalPrefix:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("withdrawal address %s", util.WithdrawalAddress(validator).String())
+	default:
+		res.Detail = "source validator does not have execution withdrawal credentials"
+	}
+
+	return res
+}
+
+func targetCredentialsCheck(validator *apiv1.Validator) *check {
+	res := &check{
+		Name:   "target credentials",
+		Passed: validator.Validator.WithdrawalCredentials[0] == compoundingWithdrawalPrefix,
+	}
+	if res.Passed {
+		res.Detail = "target validator has compounding credentials"
+	} else {
+		res.Detail = fmt.Sprintf("target validator has %#02x credentials; compounding (0x02) credentials are required", validator.Validator.WithdrawalCredentials[0])
+	}
+
+	return res
+}
+
+func shardCommitteePeriodCheck(validator *apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) *check {
+	res := &check{
+		Name: "shard committee period",
+	}
+	if validator.Validator.ActivationEpoch == util.FarFutureEpoch {
+		res.Detail = "source validator has not been activated"
+		return res
+	}
+
+	eligibleEpoch := validator.Validator.ActivationEpoch + shardCommitteePeriod
+	res.Passed = currentEpoch >= eligibleEpoch
+	if res.Passed {
+		res.Detail = fmt.Sprintf("source validator has been active since epoch %d", validator.Validator.ActivationEpoch)
+	} else {
+		res.Detail = fmt.Sprintf("source validator cannot consolidate until epoch %d", eligibleEpoch)
+	}
+
+	return res
+}
+
+func withdrawalKeyCheck(validator *apiv1.Validator, privateKey []byte) *check {
+	res := &check{
+		Name: "withdrawal key",
+	}
+	address, err := util.ExecutionAddressFromPrivateKey(privateKey)
+	if err != nil {
+		res.Detail = err.Error()
+		return res
+	}
+	expected := util.WithdrawalAddress(validator)
+	res.Passed = bytes.Equal(address[:], expected[:])
+	if res.Passed {
+		res.Detail = fmt.Sprintf("key is for withdrawal address %s", address.String())
+	} else {
+		res.Detail = fmt.Sprintf("key is for %s, source withdrawal address is %s", address.String(), expected.String())
+	}
+
+	return res
+}
+
+// consolidationOutcome calculates the expected state of the target validator
+// once the consolidation has been processed.  The source validator's active
+// balance moves to the target, the target's effective balance is capped at the
+// Electra maximum, and any balance above the maximum is withdrawn by the sweep.
+func consolidationOutcome(source *apiv1.Validator, target *apiv1.Validator, params *parameters) *outcome {
+	transferred := source.Balance
+	if source.Validator.EffectiveBalance < transferred {
+		transferred = source.Validator.EffectiveBalance
+	}
+	targetBalance := target.Balance + transferred
+
+	effectiveBalance := targetBalance - targetBalance%params.effectiveBalanceIncrement
+	if effectiveBalance > params.maxEffectiveBalanceElectra {
+		effectiveBalance = params.maxEffectiveBalanceElectra
+	}
+	excess := phase0.Gwei(0)
+	if targetBalance > params.maxEffectiveBalanceElectra {
+		excess = targetBalance - params.maxEffectiveBalanceElectra
+	}
+
+	return &outcome{
+		TransferredBalance:     fmt.Sprintf("%d", transferred),
+		TargetBalance:          fmt.Sprintf("%d", targetBalance),
+		TargetEffectiveBalance: fmt.Sprintf("%d", effectiveBalance),
+		ExcessBalance:          fmt.Sprintf("%d", excess),
+	}
+}
+
+// generateTransaction creates the transaction for the consolidation request,
+// and signs and submits it if a withdrawal key is available.
+func (c *command) generateTransaction(ctx context.Context,
+	source *apiv1.Validator,
+	target *apiv1.Validator,
+	chainID *big.Int,
+) error {
+	from := util.WithdrawalAddress(source)
+	data := util.ConsolidationRequestData(source.Validator.PublicKey, target.Validator.PublicKey)
+	fee, err := util.ConsolidationRequestFee(ctx, c.executionClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain consolidation fee")
+	}
+
+	c.results.Transaction = &transaction{
+		From:  from.String(),
+		To:    util.ConsolidationRequestAddress.String(),
+		Value: fee.String(),
+		Data:  fmt.Sprintf("%#x", data),
+	}
+	if c.withdrawalPrivateKey == nil {
+		return nil
+	}
+
+	tx, err := util.BuildDynamicFeeTransaction(ctx, c.executionClient, chainID, from, util.ConsolidationRequestAddress, fee, data)
+	if err != nil {
+		return err
+	}
+	signed, err := util.SignDynamicFeeTransaction(tx, c.withdrawalPrivateKey)
+	if err != nil {
+		return err
+	}
+	c.results.Transaction.Signed = fmt.Sprintf("%#x", signed)
+	if c.dryRun {
+		return nil
+	}
+
+	var hash string
+	if _, err := c.executionClient.Call(ctx, "eth_sendRawTransaction", []interface{}{c.results.Transaction.Signed}, &hash); err != nil {
+		return errors.Wrap(err, "failed to submit transaction")
+	}
+	c.results.Transaction.Hash = hash
+	c.results.Transaction.Submitted = true
+
+	return nil
+}
+
+func (c *command) obtainParameters(ctx context.Context) (*parameters, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+
+	params := &parameters{
+		effectiveBalanceIncrement:  1000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+	}
+	tmp, exists := spec["SHARD_COMMITTEE_PERIOD"]
+	if !exists {
+		return nil, errors.New("SHARD_COMMITTEE_PERIOD not found in spec")
+	}
+	period, isPeriod := tmp.(uint64)
+	if !isPeriod {
+		return nil, errors.New("SHARD_COMMITTEE_PERIOD of unexpected type")
+	}
+	params.shardCommitteePeriod = phase0.Epoch(period)
+	depositChainID, exists := spec["DEPOSIT_CHAIN_ID"].(uint64)
+	if !exists {
+		return nil, errors.New("DEPOSIT_CHAIN_ID not found in spec")
+	}
+	params.depositChainID = depositChainID
+	if val, exists := spec["ELECTRA_FORK_EPOCH"].(uint64); exists {
+		params.electraForkEpoch = phase0.Epoch(val)
+		params.electraKnown = true
+	}
+	if val, exists := spec["EFFECTIVE_BALANCE_INCREMENT"].(uint64); exists {
+		params.effectiveBalanceIncrement = phase0.Gwei(val)
+	}
+	if val, exists := spec["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		params.maxEffectiveBalanceElectra = phase0.Gwei(val)
+	}
+
+	return params, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.specProvider, isProvider = c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// withdrawalAddressCredentials are 0x01 credentials for the address of the
+// private key 0x4646…46.
+var withdrawalAddressCredentials = []byte{
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x9d, 0x8a, 0x62, 0xf6, 0x56, 0xa8, 0xd1, 0x61, 0x5c, 0x12,
+	0x94, 0xfd, 0x71, 0xe9, 0xcf, 0xb3, 0xe4, 0x85, 0x5a, 0x4f,
+}
+
+func testValidator(index phase0.ValidatorIndex,
+	prefix byte,
+	balance phase0.Gwei,
+	effectiveBalance phase0.Gwei,
+) *apiv1.Validator {
+	credentials := make([]byte, 32)
+	copy(credentials, withdrawalAddressCredentials)
+	credentials[0] = prefix
+	pubKey := phase0.BLSPubKey{}
+	pubKey[0] = byte(index)
+
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Status:  apiv1.ValidatorStateActiveOngoing,
+		Validator: &phase0.Validator{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: credentials,
+			EffectiveBalance:      effectiveBalance,
+			ActivationEpoch:       100,
+			ExitEpoch:             util.FarFutureEpoch,
+			WithdrawableEpoch:     util.FarFutureEpoch,
+		},
+	}
+}
+
+func TestChecks(t *testing.T) {
+	params := &parameters{
+		shardCommitteePeriod: 256,
+		electraForkEpoch:     1000,
+		electraKnown:         true,
+	}
+	source := testValidator(1, ethWithdrawalPrefix, 32000000000, 32000000000)
+	target := testValidator(2, compoundingWithdrawalPrefix, 64000000000, 64000000000)
+	blsTarget := testValidator(3, 0x00, 32000000000, 32000000000)
+	exiting := testValidator(4, ethWithdrawalPrefix, 32000000000, 32000000000)
+	exiting.Status = apiv1.ValidatorStateActiveExiting
+
+	require.Equal(t, &check{Name: "electra", Passed: true, Detail: "Electra active since epoch 1000"}, electraCheck(params, 1000))
+	require.Equal(t, &check{Name: "electra", Detail: "Electra is not active until epoch 1000"}, electraCheck(params, 999))
+	require.Equal(t, &check{Name: "electra", Detail: "beacon node does not know of the Electra fork"}, electraCheck(&parameters{}, 999))
+
+	require.Equal(t, &check{Name: "chain ID", Passed: true, Detail: "both 17000"}, chainIDCheck(17000, big.NewInt(17000)))
+	require.Equal(t, &check{Name: "chain ID", Detail: "execution node chain ID 1 does not match deposit chain ID 17000"}, chainIDCheck(17000, big.NewInt(1)))
+
+	require.True(t, distinctCheck(source, target).Passed)
+	require.Equal(t, &check{Name: "distinct validators", Detail: "source and target validators are the same"}, distinctCheck(source, source))
+
+	require.True(t, activeCheck("source active", source).Passed)
+	require.Equal(t, &check{Name: "target active", Detail: "validator 4 is in state active_exiting"}, activeCheck("target active", exiting))
+
+	require.Equal(t, &check{Name: "source credentials", Passed: true, Detail: "withdrawal address 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"}, sourceCredentialsCheck(source))
+	require.False(t, sourceCredentialsCheck(blsTarget).Passed)
+
+	require.True(t, targetCredentialsCheck(target).Passed)
+	require.Equal(t, &check{Name: "target credentials", Detail: "target validator has 0x01 credentials; compounding (0x02) credentials are required"}, targetCredentialsCheck(source))
+
+	require.True(t, shardCommitteePeriodCheck(source, 356, 256).Passed)
+	require.Equal(t, &check{Name: "shard committee period", Detail: "source validator cannot consolidate until epoch 356"}, shardCommitteePeriodCheck(source, 355, 256))
+
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = 0x46
+	}
+	require.Equal(t, &check{Name: "withdrawal key", Passed: true, Detail: "key is for withdrawal address 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"}, withdrawalKeyCheck(source, privateKey))
+	privateKey[31] = 0x01
+	require.False(t, withdrawalKeyCheck(source, privateKey).Passed)
+}
+
+func TestConsolidationOutcome(t *testing.T) {
+	params := &parameters{
+		effectiveBalanceIncrement:  1000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+	}
+
+	tests := []struct {
+		name     string
+		source   *apiv1.Validator
+		target   *apiv1.Validator
+		expected *outcome
+	}{
+		{
+			name:   "Simple",
+			source: testValidator(1, ethWithdrawalPrefix, 32000000000, 32000000000),
+			target: testValidator(2, compoundingWithdrawalPrefix, 32000000000, 32000000000),
+			expected: &outcome{
+				TransferredBalance:     "32000000000",
+				TargetBalance:          "64000000000",
+				TargetEffectiveBalance: "64000000000",
+				ExcessBalance:          "0",
+			},
+		},
+		{
+			name:   "SourceRewards",
+			source: testValidator(1, ethWithdrawalPrefix, 32500000000, 32000000000),
+			target: testValidator(2, compoundingWithdrawalPrefix, 33700000000, 33000000000),
+			expected: &outcome{
+				TransferredBalance:     "32000000000",
+				TargetBalance:          "65700000000",
+				TargetEffectiveBalance: "65000000000",
+				ExcessBalance:          "0",
+			},
+		},
+		{
+			name:   "SourcePenalised",
+			source: testValidator(1, ethWithdrawalPrefix, 31500000000, 32000000000),
+			target: testValidator(2, compoundingWithdrawalPrefix, 32000000000, 32000000000),
+			expected: &outcome{
+				TransferredBalance:     "31500000000",
+				TargetBalance:          "63500000000",
+				TargetEffectiveBalance: "63000000000",
+				ExcessBalance:          "0",
+			},
+		},
+		{
+			name:   "AboveMaximum",
+			source: testValidator(1, ethWithdrawalPrefix, 32000000000, 32000000000),
+			target: testValidator(2, compoundingWithdrawalPrefix, 2040000000000, 2040000000000),
+			expected: &outcome{
+				TransferredBalance:     "32000000000",
+				TargetBalance:          "2072000000000",
+				TargetEffectiveBalance: "2048000000000",
+				ExcessBalance:          "24000000000",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, consolidationOutcome(test.source, test.target, params))
+		})
+	}
+}
+
+// executionServer returns a server that provides canned responses to the
+// JSON-RPC methods used to build the consolidation transaction.
+func executionServer(t *testing.T, submitted *string) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"eth_call":                 `"0x0000000000000000000000000000000000000000000000000000000000000001"`,
+		"eth_getTransactionCount":  `"0x5"`,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"eth_getBlockByNumber":     `{"baseFeePerGas":"0x2540be400"}`,
+		"eth_estimateGas":          `"0x22b7e"`,
+		"eth_sendRawTransaction":   `"0x1234"`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, req))
+		if req.Method == "eth_sendRawTransaction" {
+			require.NoError(t, json.Unmarshal(req.Params[0], submitted))
+		}
+		response, exists := responses[req.Method]
+		require.True(t, exists, req.Method)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + response + `}`))
+	}))
+}
+
+func TestGenerateTransaction(t *testing.T) {
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = 0x46
+	}
+	source := testValidator(1, ethWithdrawalPrefix, 32000000000, 32000000000)
+	target := testValidator(2, compoundingWithdrawalPrefix, 32000000000, 32000000000)
+
+	tests := []struct {
+		name       string
+		privateKey []byte
+		dryRun     bool
+		signed     bool
+		submitted  bool
+	}{
+		{
+			name: "Unsigned",
+		},
+		{
+			name:       "DryRun",
+			privateKey: privateKey,
+			dryRun:     true,
+			signed:     true,
+		},
+		{
+			name:       "Submitted",
+			privateKey: privateKey,
+			signed:     true,
+			submitted:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var submitted string
+			server := executionServer(t, &submitted)
+			defer server.Close()
+
+			executionClient, err := util.ConnectToExecutionNode(context.Background(), &util.ExecutionConnectOpts{
+				Address: server.URL,
+				Timeout: 5 * time.Second,
+			})
+			require.NoError(t, err)
+
+			c := &command{
+				executionClient:      executionClient,
+				timeout:              5 * time.Second,
+				withdrawalPrivateKey: test.privateKey,
+				dryRun:               test.dryRun,
+				results:              &results{},
+			}
+			require.NoError(t, c.generateTransaction(context.Background(), source, target, big.NewInt(17000)))
+
+			tx := c.results.Transaction
+			require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", tx.From)
+			require.Equal(t, "0x0000BBdDc7CE488642fb579F8B00f3a590007251", tx.To)
+			require.Equal(t, "1", tx.Value)
+			require.Len(t, tx.Data, 2+2*96)
+			require.Equal(t, test.submitted, tx.Submitted)
+			if !test.signed {
+				require.Empty(t, tx.Signed)
+				return
+			}
+
+			raw, err := hex.DecodeString(strings.TrimPrefix(tx.Signed, "0x"))
+			require.NoError(t, err)
+			decoded, err := util.DecodeTransaction(raw)
+			require.NoError(t, err)
+			require.Equal(t, tx.From, decoded.From.String())
+			require.Equal(t, tx.To, decoded.To.String())
+			require.Equal(t, big.NewInt(17000), decoded.ChainID)
+			require.Equal(t, uint64(5), decoded.Nonce)
+			require.Equal(t, uint64(0x22b7e), decoded.Gas)
+			require.Equal(t, big.NewInt(21000000000), decoded.MaxFeePerGas)
+			if test.submitted {
+				require.Equal(t, tx.Signed, submitted)
+				require.Equal(t, "0x1234", tx.Hash)
+			} else {
+				require.Empty(t, submitted)
+			}
+		})
+	}
+}
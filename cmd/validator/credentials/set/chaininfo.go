@@ -15,12 +15,18 @@ package validatorcredentialsset
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
 )
 
 // obtainChainInfo obtains the chain information required to create a withdrawal credentials change operation.
@@ -34,6 +40,10 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 	}
 
 	if c.offline {
+		if c.network != "" {
+			// Use the bundled information for the network.
+			return c.obtainChainInfoFromNetwork(ctx)
+		}
 		// If we are here it means that we are offline without chain information, and cannot continue.
 		return fmt.Errorf("failed to obtain offline preparation file: %w", err)
 	}
@@ -87,6 +97,52 @@ func (c *command) obtainChainInfoFromNode(ctx context.Context) error {
 	return nil
 }
 
+// obtainChainInfoFromNetwork obtains chain info from the bundled network information.
+// As this does not contain any validators, the validator supplied on the command
+// line is added to it with withdrawal credentials derived from the withdrawal key.
+func (c *command) obtainChainInfoFromNetwork(ctx context.Context) error {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from bundled information for network %s\n", c.network)
+	}
+
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNetwork(c.network, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if c.mnemonic != "" || c.account != "" || c.privateKey == "" {
+		return errors.New("validator index and withdrawal private key are required when using bundled network information")
+	}
+	if !numeric.MatchString(c.validator) {
+		return errors.New("validator must be supplied as an index when using bundled network information")
+	}
+	index, err := strconv.ParseUint(c.validator, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid validator index")
+	}
+
+	withdrawalAccount, err := util.ParseAccount(ctx, c.privateKey, nil, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain withdrawal account")
+	}
+	withdrawalPubKey, err := util.BestPublicKey(withdrawalAccount)
+	if err != nil {
+		return err
+	}
+	withdrawalCredentials := sha256.Sum256(withdrawalPubKey.Marshal())
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+
+	// The validator's state cannot be known offline, so it is assumed to be active.
+	c.chainInfo.Validators = append(c.chainInfo.Validators, &beacon.ValidatorInfo{
+		Index:                 phase0.ValidatorIndex(index),
+		State:                 apiv1.ValidatorStateActiveOngoing,
+		WithdrawalCredentials: withdrawalCredentials[:],
+	})
+
+	return nil
+}
+
 // writeChainInfoToFile prepares for an offline run of this command by dumping
 // the chain information to a file.
 func (c *command) writeChainInfoToFile(_ context.Context) error {
@@ -15,6 +15,8 @@ package validatorcredentialsset
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -28,6 +30,16 @@ import (
 	"github.com/wealdtech/ethdo/util"
 )
 
+// defaultScanPaths are the path templates searched for withdrawal keys when
+// scanning a seed phrase and no templates are supplied.
+var defaultScanPaths = []string{
+	"m/12381/3600/{index}/0",
+	"m/12381/3600/0/{index}",
+	"m/12381/3600/{index}/0/0",
+	"m/12381/60/{index}/0",
+	"m/12381/60/0/{index}",
+}
+
 type command struct {
 	quiet   bool
 	verbose bool
@@ -46,8 +58,13 @@ type command struct {
 	withdrawalAddressStr  string
 	forkVersion           string
 	genesisValidatorsRoot string
+	network               string
 	prepareOffline        bool
 	signedOperationsInput string
+	allowNetworkMismatch  bool
+	fromSeedPhraseScan    bool
+	scanPaths             []string
+	scanGapLimit          int
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -55,9 +72,11 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Information required to generate the operations.
-	withdrawalAddress bellatrix.ExecutionAddress
-	chainInfo         *beacon.ChainInfo
-	domain            phase0.Domain
+	withdrawalAddress           bellatrix.ExecutionAddress
+	chainInfo                   *beacon.ChainInfo
+	domain                      phase0.Domain
+	domainGenesisValidatorsRoot phase0.Root
+	signedOperationsNetwork     *phase0.Root
 
 	// Processing.
 	consensusClient consensusclient.Service
@@ -85,11 +104,16 @@ func newCommand(_ context.Context) (*command, error) {
 		path:                     viper.GetString("path"),
 		privateKey:               viper.GetString("private-key"),
 		signedOperationsInput:    viper.GetString("signed-operations"),
+		allowNetworkMismatch:     viper.GetBool("allow-network-mismatch"),
+		fromSeedPhraseScan:       viper.GetBool("from-seed-phrase-scan"),
+		scanPaths:                viper.GetStringSlice("scan-paths"),
+		scanGapLimit:             viper.GetInt("scan-gap-limit"),
 
 		validator:             viper.GetString("validator"),
 		withdrawalAddressStr:  viper.GetString("withdrawal-address"),
 		forkVersion:           viper.GetString("fork-version"),
 		genesisValidatorsRoot: viper.GetString("genesis-validators-root"),
+		network:               viper.GetString("network"),
 	}
 
 	// Timeout is required.
@@ -97,12 +121,33 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if c.network != "" && !c.offline {
+		return nil, errors.New("network can only be supplied when offline")
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
 		return c, nil
 	}
 
+	if c.fromSeedPhraseScan {
+		if c.mnemonic == "" {
+			return nil, errors.New("mnemonic is required with from-seed-phrase-scan")
+		}
+		if len(c.scanPaths) == 0 {
+			c.scanPaths = defaultScanPaths
+		}
+		for _, scanPath := range c.scanPaths {
+			if !strings.Contains(scanPath, "{index}") {
+				return nil, fmt.Errorf("scan path %q does not contain {index}", scanPath)
+			}
+		}
+		if c.scanGapLimit < 1 {
+			return nil, errors.New("scan gap limit must be at least 1")
+		}
+	}
+
 	if c.withdrawalAccount != "" && len(c.passphrases) == 0 {
 		return nil, errors.New("passphrase required with withdrawal-account")
 	}
@@ -37,6 +37,34 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{},
 			err:  "timeout is required",
 		},
+		{
+			name: "SeedPhraseScanMnemonicMissing",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"from-seed-phrase-scan": true,
+			},
+			err: "mnemonic is required with from-seed-phrase-scan",
+		},
+		{
+			name: "SeedPhraseScanPathInvalid",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"from-seed-phrase-scan": true,
+				"mnemonic":              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"scan-paths":            []string{"m/12381/3600/0/0"},
+				"scan-gap-limit":        16,
+			},
+			err: `scan path "m/12381/3600/0/0" does not contain {index}`,
+		},
+		{
+			name: "SeedPhraseScanGapLimitZero",
+			vars: map[string]interface{}{
+				"timeout":               "5s",
+				"from-seed-phrase-scan": true,
+				"mnemonic":              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "scan gap limit must be at least 1",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
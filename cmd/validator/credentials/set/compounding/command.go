@@ -0,0 +1,167 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialssetcompounding
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	validator             string
+	withdrawalPrivateKey  []byte
+	dryRun                bool
+	prepareOffline        bool
+	offline               bool
+	signedOperationsInput string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+	executionClient     execution.Service
+
+	// Processing.
+	consensusClient    consensusclient.Service
+	chainTime          chaintime.Service
+	validatorsProvider consensusclient.ValidatorsProvider
+	specProvider       consensusclient.SpecProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Validator   *validatorSummary `json:"validator"`
+	Checks      []*check          `json:"checks"`
+	Ready       bool              `json:"ready"`
+	Outcome     *outcome          `json:"outcome,omitempty"`
+	Transaction *transaction      `json:"transaction,omitempty"`
+}
+
+// validatorSummary is the information about a validator relevant to the change.
+type validatorSummary struct {
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Balance               string `json:"balance,omitempty"`
+	EffectiveBalance      string `json:"effective_balance,omitempty"`
+}
+
+// check is the result of a single check.
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// outcome is the expected result of the change to compounding credentials, in Gwei.
+type outcome struct {
+	QueuedBalance       string `json:"queued_balance"`
+	MaxEffectiveBalance string `json:"max_effective_balance"`
+}
+
+// transaction is the execution layer transaction that makes the request.
+type transaction struct {
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	Value                string `json:"value"`
+	Data                 string `json:"data"`
+	Nonce                string `json:"nonce,omitempty"`
+	Gas                  string `json:"gas,omitempty"`
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"`
+	Signed               string `json:"signed,omitempty"`
+	Hash                 string `json:"hash,omitempty"`
+	Submitted            bool   `json:"submitted"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+		dryRun:                   viper.GetBool("dry-run"),
+		prepareOffline:           viper.GetBool("prepare-offline"),
+		offline:                  viper.GetBool("offline"),
+		signedOperationsInput:    viper.GetString("signed-operations"),
+	}
+
+	// Timeout is required.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.prepareOffline && c.offline {
+		return nil, errors.New("cannot use both --prepare-offline and --offline")
+	}
+	if c.signedOperationsInput != "" && (c.prepareOffline || c.offline) {
+		return nil, errors.New("cannot use --signed-operations with --prepare-offline or --offline")
+	}
+
+	if viper.GetString("withdrawal-private-key") != "" {
+		var err error
+		c.withdrawalPrivateKey, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("withdrawal-private-key"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal private key")
+		}
+		if len(c.withdrawalPrivateKey) != 32 {
+			return nil, errors.New("withdrawal private key must be 32 bytes")
+		}
+	}
+
+	if c.offline {
+		if c.withdrawalPrivateKey == nil {
+			return nil, errors.New("withdrawal-private-key is required when offline")
+		}
+	} else {
+		if c.validator == "" && c.signedOperationsInput == "" {
+			return nil, errors.New("validator is required")
+		}
+		c.executionConnection = viper.GetString("execution-connection")
+		if c.executionConnection == "" {
+			return nil, errors.New("execution-connection is required")
+		}
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialssetcompounding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validator":            "1",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "ExecutionConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+			},
+			err: "execution-connection is required",
+		},
+		{
+			name: "WithdrawalPrivateKeyInvalid",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"validator":              "1",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0xinvalid",
+			},
+			err: "invalid withdrawal private key: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "WithdrawalPrivateKeyShort",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"validator":              "1",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646",
+			},
+			err: "withdrawal private key must be 32 bytes",
+		},
+		{
+			name: "PrepareOfflineAndOffline",
+			vars: map[string]interface{}{
+				"timeout":         "5s",
+				"prepare-offline": true,
+				"offline":         true,
+			},
+			err: "cannot use both --prepare-offline and --offline",
+		},
+		{
+			name: "SignedOperationsOffline",
+			vars: map[string]interface{}{
+				"timeout":           "5s",
+				"offline":           true,
+				"signed-operations": "compounding-operations.json",
+			},
+			err: "cannot use --signed-operations with --prepare-offline or --offline",
+		},
+		{
+			name: "OfflineWithdrawalPrivateKeyMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"offline": true,
+			},
+			err: "withdrawal-private-key is required when offline",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"validator":              "1",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+		},
+		{
+			name: "GoodOffline",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"offline":                true,
+				"withdrawal-private-key": "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+		},
+		{
+			name: "GoodSignedOperations",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"execution-connection": "http://localhost:8545",
+				"signed-operations":    "compounding-operations.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialssetcompounding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the request as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the request as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.Validator.Balance != "" {
+		builder.WriteString(fmt.Sprintf("Validator: %s (%s)\n", c.results.Validator.Index, util.GweiString(c.results.Validator.Balance)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Validator: %s\n", c.results.Validator.Index))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("  Public key: %s\n", c.results.Validator.Pubkey))
+		builder.WriteString(fmt.Sprintf("  Withdrawal credentials: %s\n", c.results.Validator.WithdrawalCredentials))
+		if c.results.Validator.EffectiveBalance != "" {
+			builder.WriteString(fmt.Sprintf("  Effective balance: %s\n", util.GweiString(c.results.Validator.EffectiveBalance)))
+		}
+	}
+
+	for _, check := range c.results.Checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("[%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+
+	if c.results.Outcome != nil {
+		if c.results.Outcome.QueuedBalance != "0" {
+			builder.WriteString(fmt.Sprintf("Balance to be queued as a pending deposit: %s\n", util.GweiString(c.results.Outcome.QueuedBalance)))
+		}
+		builder.WriteString(fmt.Sprintf("Maximum effective balance after change: %s\n", util.GweiString(c.results.Outcome.MaxEffectiveBalance)))
+	}
+
+	if !c.results.Ready {
+		builder.WriteString("Result: validator cannot be switched to compounding credentials\n")
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	tx := c.results.Transaction
+	switch {
+	case c.prepareOffline:
+		builder.WriteString(fmt.Sprintf("%s generated\n", preparationFilename))
+	case c.offline:
+		builder.WriteString(fmt.Sprintf("%s generated\n", operationsFilename))
+	case tx.Submitted:
+		builder.WriteString(fmt.Sprintf("Compounding request submitted in transaction %s\n", tx.Hash))
+	case tx.Signed != "":
+		builder.WriteString(fmt.Sprintf("Signed transaction: %s\n", tx.Signed))
+	default:
+		builder.WriteString(fmt.Sprintf("Send the following transaction from %s to request compounding credentials:\n", tx.From))
+		builder.WriteString(fmt.Sprintf("  To: %s\n", tx.To))
+		builder.WriteString(fmt.Sprintf("  Value: %s wei\n", tx.Value))
+		builder.WriteString(fmt.Sprintf("  Data: %s\n", tx.Data))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,640 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialssetcompounding
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	preparationFilename = "compounding-preparation.json"
+	operationsFilename  = "compounding-operations.json"
+)
+
+// Withdrawal credential prefixes.
+const (
+	blsWithdrawalPrefix         = 0x00
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// parameters are the chain parameters used to check and model the change.
+type parameters struct {
+	electraForkEpoch           phase0.Epoch
+	electraKnown               bool
+	depositChainID             uint64
+	minActivationBalance       phase0.Gwei
+	maxEffectiveBalanceElectra phase0.Gwei
+}
+
+// preparation is the information required to sign the request offline.
+type preparation struct {
+	ChainID     string            `json:"chain_id"`
+	Validator   *validatorSummary `json:"validator"`
+	Transaction *transaction      `json:"transaction"`
+}
+
+// signedOperation is a signed request, ready for broadcast.
+type signedOperation struct {
+	Validator   string `json:"validator"`
+	Transaction string `json:"transaction"`
+}
+
+func (c *command) process(ctx context.Context) error {
+	if c.offline {
+		return c.processOffline(ctx)
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.signedOperationsInput != "" {
+		return c.processSignedOperation(ctx)
+	}
+
+	return c.processOnline(ctx)
+}
+
+func (c *command) processOnline(ctx context.Context) error {
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+
+	params, err := c.obtainParameters(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, err := util.ExecutionQuantity(ctx, c.executionClient, "eth_chainId", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution chain ID")
+	}
+
+	c.results = &results{
+		Validator: summarise(validator),
+		Checks:    validatorChecks(validator, params, chainID, c.chainTime.CurrentEpoch()),
+		Outcome:   compoundingOutcome(validator, params),
+	}
+	if c.withdrawalPrivateKey != nil {
+		c.results.Checks = append(c.results.Checks, withdrawalKeyCheck(util.WithdrawalAddress(validator), c.withdrawalPrivateKey))
+	}
+	c.results.Ready = allPassed(c.results.Checks)
+	if !c.results.Ready {
+		return nil
+	}
+
+	return c.generateTransaction(ctx, validator, chainID)
+}
+
+// processOffline signs the request in the preparation file without
+// connecting to any node.
+func (c *command) processOffline(ctx context.Context) error {
+	data, err := os.ReadFile(preparationFilename)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read %s", preparationFilename))
+	}
+	prep := &preparation{}
+	if err := json.Unmarshal(data, prep); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to parse %s", preparationFilename))
+	}
+	tx, from, pubkey, err := prep.parse()
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("invalid %s", preparationFilename))
+	}
+
+	c.results = &results{
+		Validator: prep.Validator,
+		Checks: []*check{
+			requestCheck(pubkey, &tx.To, tx.Data),
+			withdrawalKeyCheck(from, c.withdrawalPrivateKey),
+		},
+		Transaction: prep.Transaction,
+	}
+	c.results.Ready = allPassed(c.results.Checks)
+	if !c.results.Ready {
+		return nil
+	}
+
+	if err := c.signTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(&signedOperation{
+		Validator:   prep.Validator.Pubkey,
+		Transaction: c.results.Transaction.Signed,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signed operation")
+	}
+	if err := os.WriteFile(operationsFilename, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", operationsFilename))
+	}
+
+	return nil
+}
+
+// processSignedOperation checks and broadcasts a previously signed request.
+func (c *command) processSignedOperation(ctx context.Context) error {
+	op, err := c.obtainSignedOperation()
+	if err != nil {
+		return err
+	}
+	signed, err := hex.DecodeString(strings.TrimPrefix(op.Transaction, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "invalid signed transaction")
+	}
+	tx, err := util.DecodeTransaction(signed)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signed transaction")
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, op.Validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+	params, err := c.obtainParameters(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, err := util.ExecutionQuantity(ctx, c.executionClient, "eth_chainId", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution chain ID")
+	}
+
+	c.results = &results{
+		Validator: summarise(validator),
+		Checks:    validatorChecks(validator, params, chainID, c.chainTime.CurrentEpoch()),
+		Outcome:   compoundingOutcome(validator, params),
+		Transaction: &transaction{
+			From:   tx.From.String(),
+			Value:  tx.Value.String(),
+			Data:   fmt.Sprintf("%#x", tx.Data),
+			Signed: fmt.Sprintf("%#x", signed),
+			Hash:   fmt.Sprintf("%#x", tx.Hash),
+		},
+	}
+	if tx.To != nil {
+		c.results.Transaction.To = tx.To.String()
+	}
+	c.results.Checks = append(c.results.Checks,
+		transactionChainIDCheck(chainID, tx.ChainID),
+		requestCheck(validator.Validator.PublicKey, tx.To, tx.Data),
+		senderCheck(util.WithdrawalAddress(validator), tx.From),
+	)
+	c.results.Ready = allPassed(c.results.Checks)
+	if !c.results.Ready || c.dryRun {
+		return nil
+	}
+
+	return c.submitTransaction(ctx)
+}
+
+func (c *command) obtainSignedOperation() (*signedOperation, error) {
+	input := c.signedOperationsInput
+	if !strings.HasPrefix(input, "{") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read input file")
+		}
+		input = string(data)
+	}
+
+	op := &signedOperation{}
+	if err := json.Unmarshal([]byte(input), op); err != nil {
+		return nil, errors.Wrap(err, "failed to parse signed operation")
+	}
+	if op.Validator == "" {
+		return nil, errors.New("signed operation missing validator")
+	}
+	if op.Transaction == "" {
+		return nil, errors.New("signed operation missing transaction")
+	}
+
+	return op, nil
+}
+
+// parse obtains the unsigned transaction, sender and validator public key
+// from the preparation.
+func (p *preparation) parse() (*util.DynamicFeeTransaction, bellatrix.ExecutionAddress, phase0.BLSPubKey, error) {
+	var from bellatrix.ExecutionAddress
+	var pubkey phase0.BLSPubKey
+	if p.Validator == nil {
+		return nil, from, pubkey, errors.New("validator missing")
+	}
+	if p.Transaction == nil {
+		return nil, from, pubkey, errors.New("transaction missing")
+	}
+
+	tmp, err := hex.DecodeString(strings.TrimPrefix(p.Validator.Pubkey, "0x"))
+	if err != nil || len(tmp) != len(pubkey) {
+		return nil, from, pubkey, errors.New("invalid validator public key")
+	}
+	copy(pubkey[:], tmp)
+	tmp, err = hex.DecodeString(strings.TrimPrefix(p.Transaction.From, "0x"))
+	if err != nil || len(tmp) != len(from) {
+		return nil, from, pubkey, errors.New("invalid from address")
+	}
+	copy(from[:], tmp)
+	tmp, err = hex.DecodeString(strings.TrimPrefix(p.Transaction.To, "0x"))
+	if err != nil || len(tmp) != len(from) {
+		return nil, from, pubkey, errors.New("invalid to address")
+	}
+	tx := &util.DynamicFeeTransaction{}
+	copy(tx.To[:], tmp)
+	tx.Data, err = hex.DecodeString(strings.TrimPrefix(p.Transaction.Data, "0x"))
+	if err != nil {
+		return nil, from, pubkey, errors.New("invalid data")
+	}
+
+	quantities := []struct {
+		name  string
+		input string
+		dest  **big.Int
+	}{
+		{name: "chain ID", input: p.ChainID, dest: &tx.ChainID},
+		{name: "value", input: p.Transaction.Value, dest: &tx.Value},
+		{name: "max fee per gas", input: p.Transaction.MaxFeePerGas, dest: &tx.MaxFeePerGas},
+		{name: "max priority fee per gas", input: p.Transaction.MaxPriorityFeePerGas, dest: &tx.MaxPriorityFeePerGas},
+	}
+	for _, quantity := range quantities {
+		val, success := new(big.Int).SetString(quantity.input, 10)
+		if !success {
+			return nil, from, pubkey, fmt.Errorf("invalid %s", quantity.name)
+		}
+		*quantity.dest = val
+	}
+	nonce, success := new(big.Int).SetString(p.Transaction.Nonce, 10)
+	if !success || !nonce.IsUint64() {
+		return nil, from, pubkey, errors.New("invalid nonce")
+	}
+	tx.Nonce = nonce.Uint64()
+	gas, success := new(big.Int).SetString(p.Transaction.Gas, 10)
+	if !success || !gas.IsUint64() {
+		return nil, from, pubkey, errors.New("invalid gas")
+	}
+	tx.Gas = gas.Uint64()
+
+	return tx, from, pubkey, nil
+}
+
+func summarise(validator *apiv1.Validator) *validatorSummary {
+	return &validatorSummary{
+		Index:                 fmt.Sprintf("%d", validator.Index),
+		Pubkey:                fmt.Sprintf("%#x", validator.Validator.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+		Balance:               fmt.Sprintf("%d", validator.Balance),
+		EffectiveBalance:      fmt.Sprintf("%d", validator.Validator.EffectiveBalance),
+	}
+}
+
+// validatorChecks are the checks that the chain will carry out on the request.
+func validatorChecks(validator *apiv1.Validator,
+	params *parameters,
+	chainID *big.Int,
+	currentEpoch phase0.Epoch,
+) []*check {
+	return []*check{
+		electraCheck(params, currentEpoch),
+		chainIDCheck(params.depositChainID, chainID),
+		activeCheck(validator),
+		credentialsCheck(validator),
+	}
+}
+
+func allPassed(checks []*check) bool {
+	for _, check := range checks {
+		if !check.Passed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func electraCheck(params *parameters, currentEpoch phase0.Epoch) *check {
+	res := &check{
+		Name: "electra",
+	}
+	switch {
+	case !params.electraKnown:
+		res.Detail = "beacon node does not know of the Electra fork"
+	case currentEpoch < params.electraForkEpoch:
+		res.Detail = fmt.Sprintf("Electra is not active until epoch %d", params.electraForkEpoch)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("Electra active since epoch %d", params.electraForkEpoch)
+	}
+
+	return res
+}
+
+func chainIDCheck(depositChainID uint64, chainID *big.Int) *check {
+	res := &check{
+		Name: "chain ID",
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != depositChainID {
+		res.Detail = fmt.Sprintf("execution node chain ID %s does not match deposit chain ID %d", chainID.String(), depositChainID)
+		return res
+	}
+	res.Passed = true
+	res.Detail = fmt.Sprintf("both %d", depositChainID)
+
+	return res
+}
+
+func activeCheck(validator *apiv1.Validator) *check {
+	return &check{
+		Name:   "active",
+		Passed: validator.Status == apiv1.ValidatorStateActiveOngoing,
+		Detail: fmt.Sprintf("validator %d is in state %v", validator.Index, validator.Status),
+	}
+}
+
+func credentialsCheck(validator *apiv1.Validator) *check {
+	res := &check{
+		Name: "credentials",
+	}
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("withdrawal address %s", util.WithdrawalAddress(validator).String())
+	case compoundingWithdrawalPrefix:
+		res.Detail = "validator already has compounding credentials"
+	case blsWithdrawalPrefix:
+		res.Detail = "validator has BLS credentials; set execution credentials with \"validator credentials set\" first"
+	default:
+		res.Detail = fmt.Sprintf("validator has unknown credentials type %#02x", validator.Validator.WithdrawalCredentials[0])
+	}
+
+	return res
+}
+
+func withdrawalKeyCheck(expected bellatrix.ExecutionAddress, privateKey []byte) *check {
+	res := &check{
+		Name: "withdrawal key",
+	}
+	address, err := util.ExecutionAddressFromPrivateKey(privateKey)
+	if err != nil {
+		res.Detail = err.Error()
+		return res
+	}
+	res.Passed = bytes.Equal(address[:], expected[:])
+	if res.Passed {
+		res.Detail = fmt.Sprintf("key is for withdrawal address %s", address.String())
+	} else {
+		res.Detail = fmt.Sprintf("key is for %s, withdrawal address is %s", address.String(), expected.String())
+	}
+
+	return res
+}
+
+func transactionChainIDCheck(chainID *big.Int, txChainID *big.Int) *check {
+	res := &check{
+		Name:   "transaction chain ID",
+		Passed: txChainID != nil && txChainID.Cmp(chainID) == 0,
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("transaction is for chain %s", chainID.String())
+	} else {
+		res.Detail = fmt.Sprintf("transaction is not for chain %s", chainID.String())
+	}
+
+	return res
+}
+
+// requestCheck confirms that the transaction is a request to switch the
+// validator to compounding credentials.
+func requestCheck(pubkey phase0.BLSPubKey, to *bellatrix.ExecutionAddress, data []byte) *check {
+	res := &check{
+		Name: "request",
+	}
+	switch {
+	case to == nil || !bytes.Equal(to[:], util.ConsolidationRequestAddress[:]):
+		res.Detail = fmt.Sprintf("transaction is not to the consolidation request contract %s", util.ConsolidationRequestAddress.String())
+	case !bytes.Equal(data, util.ConsolidationRequestData(pubkey, pubkey)):
+		res.Detail = fmt.Sprintf("transaction is not a compounding request for %#x", pubkey)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("compounding request for %#x", pubkey)
+	}
+
+	return res
+}
+
+func senderCheck(expected bellatrix.ExecutionAddress, from bellatrix.ExecutionAddress) *check {
+	res := &check{
+		Name:   "sender",
+		Passed: bytes.Equal(from[:], expected[:]),
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("transaction is from withdrawal address %s", from.String())
+	} else {
+		res.Detail = fmt.Sprintf("transaction is from %s, withdrawal address is %s", from.String(), expected.String())
+	}
+
+	return res
+}
+
+// compoundingOutcome calculates the effect of switching to compounding
+// credentials.  Any balance above the minimum activation balance is queued
+// as a pending deposit, and the effective balance can then rise to the
+// Electra maximum.
+func compoundingOutcome(validator *apiv1.Validator, params *parameters) *outcome {
+	queued := phase0.Gwei(0)
+	if validator.Balance > params.minActivationBalance {
+		queued = validator.Balance - params.minActivationBalance
+	}
+
+	return &outcome{
+		QueuedBalance:       fmt.Sprintf("%d", queued),
+		MaxEffectiveBalance: fmt.Sprintf("%d", params.maxEffectiveBalanceElectra),
+	}
+}
+
+// generateTransaction creates the transaction for the request.  The
+// transaction is written to the preparation file if preparing for offline
+// signing, or signed and submitted if a withdrawal key is available.
+func (c *command) generateTransaction(ctx context.Context,
+	validator *apiv1.Validator,
+	chainID *big.Int,
+) error {
+	from := util.WithdrawalAddress(validator)
+	data := util.ConsolidationRequestData(validator.Validator.PublicKey, validator.Validator.PublicKey)
+	fee, err := util.ConsolidationRequestFee(ctx, c.executionClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain request fee")
+	}
+
+	c.results.Transaction = &transaction{
+		From:  from.String(),
+		To:    util.ConsolidationRequestAddress.String(),
+		Value: fee.String(),
+		Data:  fmt.Sprintf("%#x", data),
+	}
+	if c.withdrawalPrivateKey == nil && !c.prepareOffline {
+		return nil
+	}
+
+	tx, err := util.BuildDynamicFeeTransaction(ctx, c.executionClient, chainID, from, util.ConsolidationRequestAddress, fee, data)
+	if err != nil {
+		return err
+	}
+
+	if c.prepareOffline {
+		c.results.Transaction.Nonce = fmt.Sprintf("%d", tx.Nonce)
+		c.results.Transaction.Gas = fmt.Sprintf("%d", tx.Gas)
+		c.results.Transaction.MaxFeePerGas = tx.MaxFeePerGas.String()
+		c.results.Transaction.MaxPriorityFeePerGas = tx.MaxPriorityFeePerGas.String()
+		data, err := json.Marshal(&preparation{
+			ChainID:     chainID.String(),
+			Validator:   c.results.Validator,
+			Transaction: c.results.Transaction,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal preparation")
+		}
+		if err := os.WriteFile(preparationFilename, data, 0o600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", preparationFilename))
+		}
+
+		return nil
+	}
+
+	if err := c.signTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if c.dryRun {
+		return nil
+	}
+
+	return c.submitTransaction(ctx)
+}
+
+func (c *command) signTransaction(_ context.Context, tx *util.DynamicFeeTransaction) error {
+	signed, err := util.SignDynamicFeeTransaction(tx, c.withdrawalPrivateKey)
+	if err != nil {
+		return err
+	}
+	decoded, err := util.DecodeTransaction(signed)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signed transaction")
+	}
+	c.results.Transaction.Signed = fmt.Sprintf("%#x", signed)
+	c.results.Transaction.Hash = fmt.Sprintf("%#x", decoded.Hash)
+
+	return nil
+}
+
+func (c *command) submitTransaction(ctx context.Context) error {
+	var hash string
+	if _, err := c.executionClient.Call(ctx, "eth_sendRawTransaction", []interface{}{c.results.Transaction.Signed}, &hash); err != nil {
+		return errors.Wrap(err, "failed to submit transaction")
+	}
+	c.results.Transaction.Hash = hash
+	c.results.Transaction.Submitted = true
+
+	return nil
+}
+
+func (c *command) obtainParameters(ctx context.Context) (*parameters, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+
+	params := &parameters{
+		minActivationBalance:       32000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+	}
+	depositChainID, exists := spec["DEPOSIT_CHAIN_ID"].(uint64)
+	if !exists {
+		return nil, errors.New("DEPOSIT_CHAIN_ID not found in spec")
+	}
+	params.depositChainID = depositChainID
+	if val, exists := spec["ELECTRA_FORK_EPOCH"].(uint64); exists {
+		params.electraForkEpoch = phase0.Epoch(val)
+		params.electraKnown = true
+	}
+	if val, exists := spec["MIN_ACTIVATION_BALANCE"].(uint64); exists {
+		params.minActivationBalance = phase0.Gwei(val)
+	}
+	if val, exists := spec["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		params.maxEffectiveBalanceElectra = phase0.Gwei(val)
+	}
+
+	return params, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.specProvider, isProvider = c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
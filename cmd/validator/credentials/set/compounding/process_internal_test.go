@@ -0,0 +1,300 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorcredentialssetcompounding
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// withdrawalAddressCredentials are 0x01 credentials for the address of the
+// private key 0x4646…46.
+var withdrawalAddressCredentials = []byte{
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x9d, 0x8a, 0x62, 0xf6, 0x56, 0xa8, 0xd1, 0x61, 0x5c, 0x12,
+	0x94, 0xfd, 0x71, 0xe9, 0xcf, 0xb3, 0xe4, 0x85, 0x5a, 0x4f,
+}
+
+func testPrivateKey() []byte {
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = 0x46
+	}
+
+	return privateKey
+}
+
+func testValidator(index phase0.ValidatorIndex, prefix byte, balance phase0.Gwei) *apiv1.Validator {
+	credentials := make([]byte, 32)
+	copy(credentials, withdrawalAddressCredentials)
+	credentials[0] = prefix
+	pubKey := phase0.BLSPubKey{}
+	pubKey[0] = byte(index)
+
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Status:  apiv1.ValidatorStateActiveOngoing,
+		Validator: &phase0.Validator{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: credentials,
+			EffectiveBalance:      32000000000,
+			ActivationEpoch:       100,
+		},
+	}
+}
+
+func TestChecks(t *testing.T) {
+	params := &parameters{
+		electraForkEpoch: 1000,
+		electraKnown:     true,
+		depositChainID:   17000,
+	}
+	validator := testValidator(1, ethWithdrawalPrefix, 32000000000)
+	compounding := testValidator(2, compoundingWithdrawalPrefix, 32000000000)
+	bls := testValidator(3, blsWithdrawalPrefix, 32000000000)
+	exiting := testValidator(4, ethWithdrawalPrefix, 32000000000)
+	exiting.Status = apiv1.ValidatorStateActiveExiting
+
+	require.True(t, allPassed(validatorChecks(validator, params, big.NewInt(17000), 1000)))
+	require.False(t, allPassed(validatorChecks(validator, params, big.NewInt(17000), 999)))
+	require.False(t, allPassed(validatorChecks(validator, params, big.NewInt(1), 1000)))
+
+	require.Equal(t, &check{Name: "active", Detail: "validator 4 is in state active_exiting"}, activeCheck(exiting))
+
+	require.Equal(t, &check{Name: "credentials", Passed: true, Detail: "withdrawal address 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"}, credentialsCheck(validator))
+	require.Equal(t, &check{Name: "credentials", Detail: "validator already has compounding credentials"}, credentialsCheck(compounding))
+	require.False(t, credentialsCheck(bls).Passed)
+
+	privateKey := testPrivateKey()
+	address := util.WithdrawalAddress(validator)
+	require.Equal(t, &check{Name: "withdrawal key", Passed: true, Detail: "key is for withdrawal address 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"}, withdrawalKeyCheck(address, privateKey))
+	privateKey[31] = 0x01
+	require.False(t, withdrawalKeyCheck(address, privateKey).Passed)
+
+	require.True(t, senderCheck(address, address).Passed)
+	require.False(t, senderCheck(address, bellatrix.ExecutionAddress{}).Passed)
+
+	require.True(t, transactionChainIDCheck(big.NewInt(17000), big.NewInt(17000)).Passed)
+	require.False(t, transactionChainIDCheck(big.NewInt(17000), big.NewInt(1)).Passed)
+	require.False(t, transactionChainIDCheck(big.NewInt(17000), nil).Passed)
+
+	pubkey := validator.Validator.PublicKey
+	to := util.ConsolidationRequestAddress
+	require.True(t, requestCheck(pubkey, &to, util.ConsolidationRequestData(pubkey, pubkey)).Passed)
+	require.False(t, requestCheck(pubkey, nil, util.ConsolidationRequestData(pubkey, pubkey)).Passed)
+	require.False(t, requestCheck(pubkey, &address, util.ConsolidationRequestData(pubkey, pubkey)).Passed)
+	require.False(t, requestCheck(pubkey, &to, util.ConsolidationRequestData(pubkey, compounding.Validator.PublicKey)).Passed)
+}
+
+func TestCompoundingOutcome(t *testing.T) {
+	params := &parameters{
+		minActivationBalance:       32000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+	}
+
+	require.Equal(t, &outcome{QueuedBalance: "0", MaxEffectiveBalance: "2048000000000"}, compoundingOutcome(testValidator(1, ethWithdrawalPrefix, 31900000000), params))
+	require.Equal(t, &outcome{QueuedBalance: "0", MaxEffectiveBalance: "2048000000000"}, compoundingOutcome(testValidator(1, ethWithdrawalPrefix, 32000000000), params))
+	require.Equal(t, &outcome{QueuedBalance: "1234567890", MaxEffectiveBalance: "2048000000000"}, compoundingOutcome(testValidator(1, ethWithdrawalPrefix, 33234567890), params))
+}
+
+// executionServer returns a server that provides canned responses to the
+// JSON-RPC methods used to build the transaction.
+func executionServer(t *testing.T, submitted *string) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"eth_call":                 `"0x0000000000000000000000000000000000000000000000000000000000000001"`,
+		"eth_getTransactionCount":  `"0x5"`,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"eth_getBlockByNumber":     `{"baseFeePerGas":"0x2540be400"}`,
+		"eth_estimateGas":          `"0x22b7e"`,
+		"eth_sendRawTransaction":   `"0x1234"`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, req))
+		if req.Method == "eth_sendRawTransaction" {
+			require.NoError(t, json.Unmarshal(req.Params[0], submitted))
+		}
+		response, exists := responses[req.Method]
+		require.True(t, exists, req.Method)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + response + `}`))
+	}))
+}
+
+func TestGenerateTransaction(t *testing.T) {
+	validator := testValidator(1, ethWithdrawalPrefix, 32000000000)
+
+	tests := []struct {
+		name       string
+		privateKey []byte
+		dryRun     bool
+		signed     bool
+		submitted  bool
+	}{
+		{
+			name: "Unsigned",
+		},
+		{
+			name:       "DryRun",
+			privateKey: testPrivateKey(),
+			dryRun:     true,
+			signed:     true,
+		},
+		{
+			name:       "Submitted",
+			privateKey: testPrivateKey(),
+			signed:     true,
+			submitted:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var submitted string
+			server := executionServer(t, &submitted)
+			defer server.Close()
+
+			executionClient, err := util.ConnectToExecutionNode(context.Background(), &util.ExecutionConnectOpts{
+				Address: server.URL,
+				Timeout: 5 * time.Second,
+			})
+			require.NoError(t, err)
+
+			c := &command{
+				executionClient:      executionClient,
+				timeout:              5 * time.Second,
+				withdrawalPrivateKey: test.privateKey,
+				dryRun:               test.dryRun,
+				results:              &results{},
+			}
+			require.NoError(t, c.generateTransaction(context.Background(), validator, big.NewInt(17000)))
+
+			tx := c.results.Transaction
+			require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", tx.From)
+			require.Equal(t, "0x0000BBdDc7CE488642fb579F8B00f3a590007251", tx.To)
+			require.Equal(t, "1", tx.Value)
+			require.Equal(t, fmt.Sprintf("%#x", util.ConsolidationRequestData(validator.Validator.PublicKey, validator.Validator.PublicKey)), tx.Data)
+			require.Equal(t, test.submitted, tx.Submitted)
+			if !test.signed {
+				require.Empty(t, tx.Signed)
+				return
+			}
+
+			raw, err := hex.DecodeString(strings.TrimPrefix(tx.Signed, "0x"))
+			require.NoError(t, err)
+			decoded, err := util.DecodeTransaction(raw)
+			require.NoError(t, err)
+			require.Equal(t, tx.From, decoded.From.String())
+			require.Equal(t, big.NewInt(17000), decoded.ChainID)
+			require.True(t, requestCheck(validator.Validator.PublicKey, decoded.To, decoded.Data).Passed)
+			if test.submitted {
+				require.Equal(t, tx.Signed, submitted)
+				require.Equal(t, "0x1234", tx.Hash)
+			} else {
+				require.Empty(t, submitted)
+				require.Equal(t, fmt.Sprintf("%#x", decoded.Hash), tx.Hash)
+			}
+		})
+	}
+}
+
+func TestPreparation(t *testing.T) {
+	validator := testValidator(1, ethWithdrawalPrefix, 32000000000)
+	data := util.ConsolidationRequestData(validator.Validator.PublicKey, validator.Validator.PublicKey)
+	prep := &preparation{
+		ChainID:   "17000",
+		Validator: summarise(validator),
+		Transaction: &transaction{
+			From:                 "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+			To:                   "0x0000BBdDc7CE488642fb579F8B00f3a590007251",
+			Value:                "1",
+			Data:                 fmt.Sprintf("%#x", data),
+			Nonce:                "5",
+			Gas:                  "142206",
+			MaxFeePerGas:         "21000000000",
+			MaxPriorityFeePerGas: "1000000000",
+		},
+	}
+
+	tx, from, pubkey, err := prep.parse()
+	require.NoError(t, err)
+	require.Equal(t, util.WithdrawalAddress(validator), from)
+	require.Equal(t, validator.Validator.PublicKey, pubkey)
+	require.Equal(t, big.NewInt(17000), tx.ChainID)
+	require.Equal(t, uint64(5), tx.Nonce)
+	require.Equal(t, uint64(142206), tx.Gas)
+	require.Equal(t, big.NewInt(1), tx.Value)
+	require.Equal(t, data, tx.Data)
+
+	// Sign the transaction as it would be offline.
+	c := &command{
+		withdrawalPrivateKey: testPrivateKey(),
+		results:              &results{Transaction: prep.Transaction},
+	}
+	require.NoError(t, c.signTransaction(context.Background(), tx))
+	raw, err := hex.DecodeString(strings.TrimPrefix(c.results.Transaction.Signed, "0x"))
+	require.NoError(t, err)
+	decoded, err := util.DecodeTransaction(raw)
+	require.NoError(t, err)
+	require.Equal(t, from, decoded.From)
+	require.Equal(t, big.NewInt(21000000000), decoded.MaxFeePerGas)
+	require.True(t, requestCheck(pubkey, decoded.To, decoded.Data).Passed)
+
+	prep.Transaction.Nonce = "-1"
+	_, _, _, err = prep.parse()
+	require.EqualError(t, err, "invalid nonce")
+	prep.Transaction.From = "0x1234"
+	_, _, _, err = prep.parse()
+	require.EqualError(t, err, "invalid from address")
+}
+
+func TestObtainSignedOperation(t *testing.T) {
+	c := &command{
+		signedOperationsInput: `{"validator":"0x01","transaction":"0x02"}`,
+	}
+	op, err := c.obtainSignedOperation()
+	require.NoError(t, err)
+	require.Equal(t, &signedOperation{Validator: "0x01", Transaction: "0x02"}, op)
+
+	c.signedOperationsInput = `{"validator":"0x01"}`
+	_, err = c.obtainSignedOperation()
+	require.EqualError(t, err, "signed operation missing transaction")
+
+	c.signedOperationsInput = "/nonexistent/compounding-operations.json"
+	_, err = c.obtainSignedOperation()
+	require.ErrorContains(t, err, "failed to read input file")
+}
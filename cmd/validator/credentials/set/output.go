@@ -20,6 +20,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
 )
 
 //nolint:unparam
@@ -37,6 +38,10 @@ func (c *command) output(_ context.Context) (string, error) {
 		if err != nil {
 			return "", errors.Wrap(err, "failed to marshal signed operations")
 		}
+		data, err = beacon.AddNetworkTag(data, c.domainGenesisValidatorsRoot)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to add network to signed operations")
+		}
 		if c.json {
 			return string(data), nil
 		}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -92,6 +93,10 @@ func (c *command) process(ctx context.Context) error {
 		return nil
 	}
 
+	if err := c.verifyNetwork(ctx); err != nil {
+		return err
+	}
+
 	return c.broadcastOperations(ctx)
 }
 
@@ -111,6 +116,9 @@ func (c *command) obtainOperations(ctx context.Context) error {
 
 	if c.mnemonic != "" {
 		switch {
+		case c.fromSeedPhraseScan:
+			// Have a mnemonic and want to scan path templates for withdrawal keys.
+			return c.generateOperationsFromSeedPhraseScan(ctx)
 		case c.path != "":
 			// Have a mnemonic and path.
 			return c.generateOperationFromMnemonicAndPath(ctx)
@@ -423,6 +431,10 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err := json.Unmarshal(data, &c.signedOperations); err != nil {
 		return errors.Wrap(err, "failed to parse change operations file")
 	}
+	c.signedOperationsNetwork, err = beacon.ObtainNetworkTag(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain network of change operations file")
+	}
 
 	for _, op := range c.signedOperations {
 		if err := c.verifyOperation(ctx, op); err != nil {
@@ -451,6 +463,11 @@ func (c *command) obtainOperationsFromInput(ctx context.Context) error {
 	if err := json.Unmarshal([]byte(c.signedOperationsInput), &c.signedOperations); err != nil {
 		return errors.Wrap(err, "failed to parse change operations input")
 	}
+	var err error
+	c.signedOperationsNetwork, err = beacon.ObtainNetworkTag([]byte(c.signedOperationsInput))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain network of change operations input")
+	}
 
 	for _, op := range c.signedOperations {
 		if err := c.verifyOperation(ctx, op); err != nil {
@@ -460,6 +477,93 @@ func (c *command) obtainOperationsFromInput(ctx context.Context) error {
 	return nil
 }
 
+func (c *command) generateOperationsFromSeedPhraseScan(ctx context.Context) error {
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return err
+	}
+
+	// Map the BLS withdrawal credentials to the validators that use them.
+	// Multiple validators can share the same withdrawal credentials.
+	validators := make(map[string][]*beacon.ValidatorInfo)
+	for _, validator := range c.chainInfo.Validators {
+		if len(validator.WithdrawalCredentials) == 0 || validator.WithdrawalCredentials[0] != byte(0) {
+			continue
+		}
+		key := fmt.Sprintf("%#x", validator.WithdrawalCredentials)
+		validators[key] = append(validators[key], validator)
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators with BLS withdrawal credentials found")
+	}
+
+	foundKeyCount := 0
+	for _, template := range c.scanPaths {
+		lastFoundIndex := -1
+		for i := 0; i-lastFoundIndex <= c.scanGapLimit && len(validators) > 0; i++ {
+			path := strings.ReplaceAll(template, "{index}", strconv.Itoa(i))
+			found, err := c.generateOperationsFromSeedAndWithdrawalPath(ctx, validators, seed, path)
+			if err != nil {
+				return errors.Wrap(err, "failed to generate operations from seed and withdrawal path")
+			}
+			if found {
+				lastFoundIndex = i
+				foundKeyCount++
+			}
+		}
+	}
+
+	if foundKeyCount == 0 {
+		return fmt.Errorf("failed to find withdrawal keys using the provided mnemonic: searched %d path templates with a gap limit of %d", len(c.scanPaths), c.scanGapLimit)
+	}
+
+	return nil
+}
+
+// generateOperationsFromSeedAndWithdrawalPath generates operations for all validators
+// whose withdrawal credentials match the key at the given path.  Validators for which
+// operations are generated are removed from the map.
+func (c *command) generateOperationsFromSeedAndWithdrawalPath(ctx context.Context,
+	validators map[string][]*beacon.ValidatorInfo,
+	seed []byte,
+	path string,
+) (
+	bool,
+	error,
+) {
+	withdrawalPrivkey, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate withdrawal private key")
+	}
+	withdrawalCredentials := ethutil.SHA256(withdrawalPrivkey.PublicKey().Marshal())
+	withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+	key := fmt.Sprintf("%#x", withdrawalCredentials)
+	matches, exists := validators[key]
+	if !exists {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "no validator found with withdrawal credentials %s at path %s\n", key, path)
+		}
+		return false, nil
+	}
+
+	withdrawalAccount, err := util.ParseAccount(ctx, c.mnemonic, []string{path}, true)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create withdrawal account")
+	}
+
+	for _, validator := range matches {
+		if c.verbose {
+			fmt.Fprintf(os.Stderr, "Validator %d found with withdrawal credentials %s at path %s\n", validator.Index, key, path)
+		}
+		if err := c.generateOperationFromAccount(ctx, validator, withdrawalAccount); err != nil {
+			return false, err
+		}
+	}
+	delete(validators, key)
+
+	return true, nil
+}
+
 func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 	validators map[string]*beacon.ValidatorInfo,
 	seed []byte,
@@ -701,6 +805,28 @@ func (c *command) validateOperation(_ context.Context,
 	return true, ""
 }
 
+// verifyNetwork ensures that the beacon node is on the network for which the
+// operations were generated.
+func (c *command) verifyNetwork(ctx context.Context) error {
+	genesisValidatorsRoot := c.domainGenesisValidatorsRoot
+	if c.signedOperationsNetwork != nil {
+		genesisValidatorsRoot = *c.signedOperationsNetwork
+	}
+
+	err := beacon.VerifyNetwork(ctx, c.consensusClient, genesisValidatorsRoot)
+	if err == nil {
+		return nil
+	}
+	if !c.allowNetworkMismatch {
+		return errors.Wrap(err, "refusing to broadcast (use --allow-network-mismatch to override)")
+	}
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return nil
+}
+
 func (c *command) broadcastOperations(ctx context.Context) error {
 	return c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter).SubmitBLSToExecutionChanges(ctx, c.signedOperations)
 }
@@ -760,6 +886,7 @@ func (c *command) generateDomain(ctx context.Context) error {
 		return errors.Wrap(err, "failed to calculate signature domain")
 	}
 
+	c.domainGenesisValidatorsRoot = genesisValidatorsRoot
 	copy(c.domain[:], c.chainInfo.BLSToExecutionChangeDomainType[:])
 	copy(c.domain[4:], root[:])
 	if c.debug {
@@ -17,7 +17,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/mock"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -267,6 +269,142 @@ func TestGenerateOperationsFromMnemonic(t *testing.T) {
 	}
 }
 
+func TestGenerateOperationsFromSeedPhraseScan(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:                 0,
+				Pubkey:                phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				WithdrawalCredentials: []byte{0x00, 0x8b, 0xa1, 0xcc, 0x4b, 0x09, 0x1b, 0x91, 0xc1, 0x20, 0x2b, 0xba, 0x3f, 0x50, 0x80, 0x75, 0xd6, 0xff, 0x56, 0x5c, 0x77, 0xe5, 0x59, 0xf0, 0x80, 0x3c, 0x07, 0x92, 0xe0, 0x30, 0x2b, 0xf1},
+			},
+			{
+				Index:                 1,
+				Pubkey:                phase0.BLSPubKey{0xb4, 0xd8, 0x9e, 0x2f, 0x29, 0xc7, 0x12, 0xc6, 0xa9, 0xf8, 0xe5, 0xa2, 0x69, 0xb9, 0x76, 0x17, 0xc4, 0xa9, 0x4d, 0xd6, 0xf6, 0x66, 0x2a, 0xb3, 0xb0, 0x7c, 0xe9, 0xe5, 0x43, 0x45, 0x73, 0xf1, 0x5b, 0x5c, 0x98, 0x8c, 0xd1, 0x4b, 0xbd, 0x58, 0x04, 0xf7, 0x71, 0x56, 0xa8, 0xaf, 0x1c, 0xfa},
+				WithdrawalCredentials: []byte{0x00, 0x1c, 0x08, 0x9b, 0xe2, 0x56, 0x27, 0xe5, 0x1e, 0x79, 0xe1, 0xa8, 0x1b, 0x1f, 0xa9, 0x26, 0xb7, 0xc6, 0x43, 0x08, 0xac, 0x83, 0x49, 0x2f, 0x32, 0x4b, 0x22, 0xd9, 0x8b, 0x50, 0xc4, 0xd6},
+			},
+		},
+		GenesisValidatorsRoot: phase0.Root{},
+		Epoch:                 1,
+		CurrentForkVersion:    phase0.Version{},
+	}
+
+	executionChainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:                 0,
+				Pubkey:                phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				WithdrawalCredentials: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+		},
+		GenesisValidatorsRoot: phase0.Root{},
+		Epoch:                 1,
+		CurrentForkVersion:    phase0.Version{},
+	}
+
+	tests := []struct {
+		name      string
+		command   *command
+		validator []phase0.ValidatorIndex
+		pubkeys   []phase0.BLSPubKey
+		err       string
+	}{
+		{
+			name: "MnemonicInvalid",
+			command: &command{
+				mnemonic:             "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+				chainInfo:            chainInfo,
+				scanPaths:            defaultScanPaths,
+				scanGapLimit:         16,
+				withdrawalAddressStr: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "mnemonic is invalid",
+		},
+		{
+			name: "NoBLSValidators",
+			command: &command{
+				mnemonic:             "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				chainInfo:            executionChainInfo,
+				scanPaths:            defaultScanPaths,
+				scanGapLimit:         16,
+				withdrawalAddressStr: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "no validators with BLS withdrawal credentials found",
+		},
+		{
+			name: "NoWithdrawalAddressProvided",
+			command: &command{
+				mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				chainInfo:    chainInfo,
+				scanPaths:    defaultScanPaths,
+				scanGapLimit: 16,
+			},
+			err: "failed to generate operations from seed and withdrawal path: invalid withdrawal address: no withdrawal address provided",
+		},
+		{
+			name: "NotFound",
+			command: &command{
+				mnemonic:             "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				chainInfo:            chainInfo,
+				scanPaths:            []string{"m/12381/60/{index}/0"},
+				scanGapLimit:         16,
+				withdrawalAddressStr: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			err: "failed to find withdrawal keys using the provided mnemonic: searched 1 path templates with a gap limit of 16",
+		},
+		{
+			name: "BeyondGapLimit",
+			command: &command{
+				mnemonic:             "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				chainInfo:            chainInfo,
+				scanPaths:            []string{"m/12381/3600/0/{index}"},
+				scanGapLimit:         2,
+				withdrawalAddressStr: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			validator: []phase0.ValidatorIndex{0},
+			pubkeys: []phase0.BLSPubKey{
+				{0x99, 0xb1, 0xf1, 0xd8, 0x4d, 0x76, 0x18, 0x54, 0x66, 0xd8, 0x6c, 0x34, 0xbd, 0xe1, 0x10, 0x13, 0x16, 0xaf, 0xdd, 0xae, 0x76, 0x21, 0x7a, 0xa8, 0x6c, 0xd0, 0x66, 0x97, 0x9b, 0x19, 0x85, 0x8c, 0x2c, 0x9d, 0x9e, 0x56, 0xee, 0xbc, 0x1e, 0x06, 0x7a, 0xc5, 0x42, 0x77, 0xa6, 0x17, 0x90, 0xdb},
+			},
+		},
+		{
+			name: "Good",
+			command: &command{
+				mnemonic:             "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				chainInfo:            chainInfo,
+				scanPaths:            defaultScanPaths,
+				scanGapLimit:         16,
+				withdrawalAddressStr: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+			},
+			validator: []phase0.ValidatorIndex{0, 1},
+			pubkeys: []phase0.BLSPubKey{
+				{0x99, 0xb1, 0xf1, 0xd8, 0x4d, 0x76, 0x18, 0x54, 0x66, 0xd8, 0x6c, 0x34, 0xbd, 0xe1, 0x10, 0x13, 0x16, 0xaf, 0xdd, 0xae, 0x76, 0x21, 0x7a, 0xa8, 0x6c, 0xd0, 0x66, 0x97, 0x9b, 0x19, 0x85, 0x8c, 0x2c, 0x9d, 0x9e, 0x56, 0xee, 0xbc, 0x1e, 0x06, 0x7a, 0xc5, 0x42, 0x77, 0xa6, 0x17, 0x90, 0xdb},
+				{0x83, 0x91, 0x8a, 0x1a, 0xe1, 0x55, 0x66, 0x4d, 0xd0, 0xde, 0x94, 0x78, 0x14, 0x28, 0x6e, 0x3d, 0x66, 0x22, 0x3b, 0x2a, 0xe4, 0xb4, 0x48, 0x4e, 0xd1, 0x5c, 0x4c, 0x54, 0x18, 0x2c, 0xcd, 0x22, 0x2a, 0x4e, 0x55, 0xd5, 0x1c, 0xe6, 0xbc, 0x22, 0x1e, 0xa4, 0xb1, 0xda, 0x1d, 0x72, 0x9c, 0xaf},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.generateOperationsFromSeedPhraseScan(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, test.command.signedOperations, len(test.validator))
+				for i := range test.validator {
+					require.Equal(t, test.validator[i], test.command.signedOperations[i].Message.ValidatorIndex)
+					require.Equal(t, test.pubkeys[i], test.command.signedOperations[i].Message.FromBLSPubkey)
+				}
+			}
+		})
+	}
+}
+
 func TestGenerateOperationFromMnemonicAndPath(t *testing.T) {
 	ctx := context.Background()
 
@@ -871,3 +1009,64 @@ func TestObtainValidatorInfoFromValidatorSpecifier(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	consensusClient, err := mock.New(ctx, mock.WithGenesisTime(time.Now()))
+	require.NoError(t, err)
+	// Genesis validators root of the mock client.
+	mockRoot := phase0.Root{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f}
+	mainnetRoot := phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95}
+
+	tests := []struct {
+		name    string
+		command *command
+		err     string
+	}{
+		{
+			name: "Match",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mockRoot,
+			},
+		},
+		{
+			name: "Mismatch",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mainnetRoot,
+			},
+			err: "refusing to broadcast (use --allow-network-mismatch to override): operations are for network mainnet but beacon node is on network 0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			name: "SignedOperationsMismatch",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mockRoot,
+				signedOperationsNetwork:     &mainnetRoot,
+			},
+			err: "refusing to broadcast (use --allow-network-mismatch to override): operations are for network mainnet but beacon node is on network 0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			name: "MismatchAllowed",
+			command: &command{
+				quiet:                       true,
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mainnetRoot,
+				allowNetworkMismatch:        true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.verifyNetwork(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -14,14 +14,18 @@
 package depositdata
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"strings"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
 	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -34,11 +38,17 @@ type dataIn struct {
 	withdrawalAccount string
 	withdrawalPubKey  string
 	withdrawalAddress string
+	compounding       bool
 	amount            spec.Gwei
 	validatorAccounts []e2wtypes.Account
 	forkVersion       *spec.Version
 	domain            *spec.Domain
 	passphrases       []string
+	// Top-up details, obtained from the chain.
+	topUp                      bool
+	topUpIndex                 spec.ValidatorIndex
+	topUpPubKey                spec.BLSPubKey
+	topUpWithdrawalCredentials []byte
 }
 
 func input() (*dataIn, error) {
@@ -48,7 +58,12 @@ func input() (*dataIn, error) {
 		domain:      &spec.Domain{},
 	}
 
-	if viper.GetString("validatoraccount") == "" {
+	data.topUp = viper.GetBool("top-up")
+	if data.topUp {
+		if viper.GetString("validator") == "" {
+			return nil, errors.New("validator is required for a top-up")
+		}
+	} else if viper.GetString("validatoraccount") == "" {
 		return nil, errors.New("validator account is required")
 	}
 
@@ -59,12 +74,17 @@ func input() (*dataIn, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 	defer cancel()
-	_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
-	if err != nil {
-		return nil, errors.New("failed to obtain validator account")
-	}
-	if len(data.validatorAccounts) == 0 {
-		return nil, errors.New("unknown validator account")
+	if viper.GetString("validatoraccount") != "" {
+		_, data.validatorAccounts, err = ethdoutil.WalletAndAccountsFromPath(ctx, viper.GetString("validatoraccount"))
+		if err != nil {
+			return nil, errors.New("failed to obtain validator account")
+		}
+		if len(data.validatorAccounts) == 0 {
+			return nil, errors.New("unknown validator account")
+		}
+		if data.topUp && len(data.validatorAccounts) > 1 {
+			return nil, errors.New("only one validator account can be supplied for a top-up")
+		}
 	}
 
 	switch {
@@ -81,6 +101,11 @@ func input() (*dataIn, error) {
 	data.withdrawalAccount = viper.GetString("withdrawalaccount")
 	data.withdrawalPubKey = viper.GetString("withdrawalpubkey")
 	data.withdrawalAddress = viper.GetString("withdrawaladdress")
+	data.compounding = viper.GetBool("compounding")
+	if data.topUp {
+		return inputTopUp(ctx, data)
+	}
+
 	withdrawalDetailsPresent := 0
 	if data.withdrawalAccount != "" {
 		withdrawalDetailsPresent++
@@ -97,30 +122,124 @@ func input() (*dataIn, error) {
 	if withdrawalDetailsPresent > 1 {
 		return nil, errors.New("only one of withdrawal account, public key or address is allowed")
 	}
-
-	if viper.GetString("depositvalue") == "" {
-		return nil, errors.New("deposit value is required")
+	if data.compounding && data.withdrawalAddress == "" {
+		return nil, errors.New("compounding withdrawal credentials require a withdrawal address")
 	}
-	amount, err := string2eth.StringToGWei(viper.GetString("depositvalue"))
+
+	data.amount, err = inputAmount()
 	if err != nil {
-		return nil, errors.Wrap(err, "deposit value is invalid")
+		return nil, err
 	}
-	data.amount = spec.Gwei(amount)
-	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-	if data.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
-		return nil, errors.New("deposit value must be at least 1 Ether")
+	if data.compounding {
+		if data.amount > 2048000000000 { // MAX_EFFECTIVE_BALANCE_ELECTRA
+			return nil, errors.New("deposit value must be at most 2048 Ether")
+		}
+	} else if data.amount > 32000000000 { // MIN_ACTIVATION_BALANCE
+		return nil, errors.New("deposit value must be at most 32 Ether for non-compounding withdrawal credentials")
 	}
 
 	data.forkVersion, err = inputForkVersion(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain fork version")
 	}
+	if data.compounding {
+		// Networks that are not known are assumed to support compounding withdrawal credentials.
+		if active, known := beacon.ForkActive(*data.forkVersion, "electra", time.Now()); known && !active {
+			return nil, errors.New("compounding withdrawal credentials require the Electra fork to be active on the network")
+		}
+	}
+
+	copy(data.domain[:], e2types.Domain(e2types.DomainDeposit, data.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	return data, nil
+}
+
+// inputTopUp obtains the details of a top-up deposit from the chain.
+func inputTopUp(ctx context.Context, data *dataIn) (*dataIn, error) {
+	if data.withdrawalAccount != "" || data.withdrawalPubKey != "" || data.withdrawalAddress != "" || data.compounding {
+		return nil, errors.New("withdrawal details cannot be supplied for a top-up; they are obtained from the chain")
+	}
+
+	var err error
+	data.amount, err = inputAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	eth2Client, err := ethdoutil.ConnectToBeaconNode(ctx, &ethdoutil.ConnectOpts{
+		Address:       viper.GetString("connection"),
+		Timeout:       viper.GetDuration("timeout"),
+		AllowInsecure: viper.GetBool("allow-insecure-connections"),
+		LogFallback:   !viper.GetBool("quiet"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon node")
+	}
+	validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide validator information")
+	}
+	validator, err := ethdoutil.ParseValidator(ctx, validatorsProvider, viper.GetString("validator"), "head")
+	if err != nil {
+		return nil, err
+	}
+	if validator.Validator == nil {
+		return nil, errors.New("no validator information returned")
+	}
+	data.topUpIndex = validator.Index
+	data.topUpPubKey = validator.Validator.PublicKey
+	data.topUpWithdrawalCredentials = validator.Validator.WithdrawalCredentials
+
+	if len(data.validatorAccounts) == 1 {
+		pubKey, err := ethdoutil.BestPublicKey(data.validatorAccounts[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "validator account does not provide a public key")
+		}
+		if !bytes.Equal(pubKey.Marshal(), data.topUpPubKey[:]) {
+			return nil, errors.New("validator account does not match the validator")
+		}
+	}
+
+	if viper.GetString("forkversion") != "" {
+		data.forkVersion, err = inputForkVersion(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain fork version")
+		}
+	} else {
+		genesisProvider, isProvider := eth2Client.(eth2client.GenesisProvider)
+		if !isProvider {
+			return nil, errors.New("connection does not provide genesis information")
+		}
+		genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain genesis")
+		}
+		genesis := genesisResponse.Data
+		data.forkVersion = &genesis.GenesisForkVersion
+	}
 
 	copy(data.domain[:], e2types.Domain(e2types.DomainDeposit, data.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
 
 	return data, nil
 }
 
+// inputAmount obtains the amount of the deposit.
+func inputAmount() (spec.Gwei, error) {
+	if viper.GetString("depositvalue") == "" {
+		return 0, errors.New("deposit value is required")
+	}
+	amount, err := string2eth.StringToGWei(viper.GetString("depositvalue"))
+	if err != nil {
+		return 0, errors.Wrap(err, "deposit value is invalid")
+	}
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	if amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+		return 0, errors.New("deposit value must be at least 1 Ether")
+	}
+
+	return spec.Gwei(amount), nil
+}
+
 func inputForkVersion(_ context.Context) (*spec.Version, error) {
 	// Default to mainnet.
 	forkVersion := &spec.Version{0x00, 0x00, 0x00, 0x00}
@@ -201,6 +201,94 @@ func TestInput(t *testing.T) {
 			},
 			err: "deposit value must be at least 1 Ether",
 		},
+		{
+			name: "DepositValueTooLarge",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"depositvalue":      "33 Ether",
+				"forkversion":       "0x01020304",
+			},
+			err: "deposit value must be at most 32 Ether for non-compounding withdrawal credentials",
+		},
+		{
+			name: "CompoundingWithdrawalAddressMissing",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"compounding":       true,
+				"depositvalue":      "32 Ether",
+				"forkversion":       "0x01020304",
+			},
+			err: "compounding withdrawal credentials require a withdrawal address",
+		},
+		{
+			name: "CompoundingDepositValueTooLarge",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"compounding":       true,
+				"depositvalue":      "2049 Ether",
+				"forkversion":       "0x01020304",
+			},
+			err: "deposit value must be at most 2048 Ether",
+		},
+		{
+			name: "CompoundingPreElectra",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"compounding":       true,
+				"depositvalue":      "32 Ether",
+				"forkversion":       "0x00001020",
+			},
+			err: "compounding withdrawal credentials require the Electra fork to be active on the network",
+		},
+		{
+			name: "TopUpValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"top-up":       true,
+				"depositvalue": "10 Ether",
+			},
+			err: "validator is required for a top-up",
+		},
+		{
+			name: "TopUpMultipleValidatorAccounts",
+			vars: map[string]interface{}{
+				"timeout":          "10s",
+				"top-up":           true,
+				"validator":        "1",
+				"validatoraccount": "Test",
+				"depositvalue":     "10 Ether",
+			},
+			err: "only one validator account can be supplied for a top-up",
+		},
+		{
+			name: "TopUpWithdrawalDetails",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"top-up":            true,
+				"validator":         "1",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"depositvalue":      "10 Ether",
+			},
+			err: "withdrawal details cannot be supplied for a top-up; they are obtained from the chain",
+		},
+		{
+			name: "TopUpDepositValueTooSmall",
+			vars: map[string]interface{}{
+				"timeout":      "10s",
+				"top-up":       true,
+				"validator":    "1",
+				"depositvalue": "0.5 Ether",
+			},
+			err: "deposit value must be at least 1 Ether",
+		},
 		{
 			name: "DepositValueInvalid",
 			vars: map[string]interface{}{
@@ -287,6 +375,26 @@ func TestInput(t *testing.T) {
 				domain:            domain,
 			},
 		},
+		{
+			name: "GoodCompounding",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"compounding":       true,
+				"depositvalue":      "2048 Ether",
+				"forkversion":       "0x01020304",
+			},
+			res: &dataIn{
+				format:            "json",
+				withdrawalAddress: "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				compounding:       true,
+				amount:            2048000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -305,6 +413,7 @@ func TestInput(t *testing.T) {
 				require.Equal(t, test.res.withdrawalAccount, res.withdrawalAccount)
 				require.Equal(t, test.res.withdrawalAddress, res.withdrawalAddress)
 				require.Equal(t, test.res.withdrawalPubKey, res.withdrawalPubKey)
+				require.Equal(t, test.res.compounding, res.compounding)
 				require.Equal(t, test.res.amount, res.amount)
 				require.Equal(t, test.res.forkVersion, res.forkVersion)
 				require.Equal(t, test.res.domain, res.domain)
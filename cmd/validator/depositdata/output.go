@@ -19,6 +19,7 @@ import (
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	ethdoutil "github.com/wealdtech/ethdo/util"
 )
 
 type dataOut struct {
@@ -74,33 +75,13 @@ func validatorDepositDataOutputRaw(datum *dataOut) (string, error) {
 		return "", errors.New("deposit data root required")
 	}
 
-	output := fmt.Sprintf(
-		`"`+
-			// Function signature.
-			"0x22895118"+
-			// Pointer to validator public key.
-			"0000000000000000000000000000000000000000000000000000000000000080"+
-			// Pointer to withdrawal credentials.
-			"00000000000000000000000000000000000000000000000000000000000000e0"+
-			// Pointer to validator signature.
-			"0000000000000000000000000000000000000000000000000000000000000120"+
-			// Deposit data root.
-			"%x"+
-			// Validator public key (padded).
-			"0000000000000000000000000000000000000000000000000000000000000030"+
-			"%x00000000000000000000000000000000"+
-			// Withdrawal credentials.
-			"0000000000000000000000000000000000000000000000000000000000000020"+
-			"%x"+
-			// Deposit signature.
-			"0000000000000000000000000000000000000000000000000000000000000060"+
-			"%x"+
-			`"`,
-		*datum.depositDataRoot,
-		*datum.validatorPubKey,
-		datum.withdrawalCredentials,
-		*datum.signature,
-	)
+	callData := ethdoutil.DepositCallData(&spec.DepositData{
+		PublicKey:             *datum.validatorPubKey,
+		WithdrawalCredentials: datum.withdrawalCredentials,
+		Amount:                datum.amount,
+		Signature:             *datum.signature,
+	}, *datum.depositDataRoot)
+	output := fmt.Sprintf(`"%#x"`, callData)
 	return output, nil
 }
 
@@ -112,6 +93,7 @@ func validatorDepositDataOutputLaunchpad(datum *dataOut) (string, error) {
 		[4]byte{0x00, 0x00, 0x10, 0x20}: "goerli",
 		[4]byte{0x80, 0x00, 0x00, 0x69}: "ropsten",
 		[4]byte{0x90, 0x00, 0x00, 0x69}: "sepolia",
+		[4]byte{0x10, 0x00, 0x09, 0x10}: "hoodi",
 	}
 
 	if datum.validatorPubKey == nil {
@@ -33,6 +33,10 @@ func process(data *dataIn) ([]*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
+	if data.topUp {
+		return processTopUp(data)
+	}
+
 	results := make([]*dataOut, 0)
 
 	withdrawalCredentials, err := createWithdrawalCredentials(data)
@@ -48,51 +52,100 @@ func process(data *dataIn) ([]*dataOut, error) {
 
 		var pubKey spec.BLSPubKey
 		copy(pubKey[:], validatorPubKey.Marshal())
-		depositMessage := &spec.DepositMessage{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
-		}
-		root, err := depositMessage.HashTreeRoot()
+		validatorWallet := validatorAccount.(e2wtypes.AccountWalletProvider).Wallet()
+		result, err := generateDepositData(data,
+			fmt.Sprintf("%s/%s", validatorWallet.Name(), validatorAccount.Name()),
+			pubKey,
+			withdrawalCredentials,
+			validatorAccount,
+		)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate deposit message root")
+			return nil, err
 		}
-		var depositMessageRoot spec.Root
-		copy(depositMessageRoot[:], root[:])
+		results = append(results, result)
+	}
+	return results, nil
+}
 
-		sig, err := signing.SignRoot(context.Background(), validatorAccount, data.passphrases, depositMessageRoot, *data.domain)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to sign deposit message")
-		}
+// processTopUp generates deposit data to top up an existing validator.
+func processTopUp(data *dataIn) ([]*dataOut, error) {
+	// Signatures of top-up deposits are not checked, so the deposit is only
+	// signed if the validator account is available.
+	var validatorAccount e2wtypes.Account
+	if len(data.validatorAccounts) > 0 {
+		validatorAccount = data.validatorAccounts[0]
+	}
 
-		depositData := &spec.DepositData{
-			PublicKey:             pubKey,
-			WithdrawalCredentials: withdrawalCredentials,
-			Amount:                data.amount,
-			Signature:             sig,
-		}
+	result, err := generateDepositData(data,
+		fmt.Sprintf("validator %d", data.topUpIndex),
+		data.topUpPubKey,
+		data.topUpWithdrawalCredentials,
+		validatorAccount,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		root, err = depositData.HashTreeRoot()
+	return []*dataOut{result}, nil
+}
+
+// generateDepositData generates deposit data for a single validator.  If
+// no validator account is supplied the deposit is not signed, and carries
+// the infinity signature.
+func generateDepositData(data *dataIn,
+	account string,
+	pubKey spec.BLSPubKey,
+	withdrawalCredentials []byte,
+	validatorAccount e2wtypes.Account,
+) (
+	*dataOut,
+	error,
+) {
+	depositMessage := &spec.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                data.amount,
+	}
+	root, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+	var depositMessageRoot spec.Root
+	copy(depositMessageRoot[:], root[:])
+
+	sig := spec.BLSSignature{0xc0}
+	if validatorAccount != nil {
+		sig, err = signing.SignRoot(context.Background(), validatorAccount, data.passphrases, depositMessageRoot, *data.domain)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to generate deposit data root")
+			return nil, errors.Wrap(err, "failed to sign deposit message")
 		}
-		var depositDataRoot spec.Root
-		copy(depositDataRoot[:], root[:])
+	}
 
-		validatorWallet := validatorAccount.(e2wtypes.AccountWalletProvider).Wallet()
-		results = append(results, &dataOut{
-			format:                data.format,
-			account:               fmt.Sprintf("%s/%s", validatorWallet.Name(), validatorAccount.Name()),
-			validatorPubKey:       &pubKey,
-			withdrawalCredentials: withdrawalCredentials,
-			amount:                data.amount,
-			signature:             &sig,
-			forkVersion:           data.forkVersion,
-			depositMessageRoot:    &depositMessageRoot,
-			depositDataRoot:       &depositDataRoot,
-		})
+	depositData := &spec.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                data.amount,
+		Signature:             sig,
 	}
-	return results, nil
+
+	root, err = depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+	var depositDataRoot spec.Root
+	copy(depositDataRoot[:], root[:])
+
+	return &dataOut{
+		format:                data.format,
+		account:               account,
+		validatorPubKey:       &pubKey,
+		withdrawalCredentials: withdrawalCredentials,
+		amount:                data.amount,
+		signature:             &sig,
+		forkVersion:           data.forkVersion,
+		depositMessageRoot:    &depositMessageRoot,
+		depositDataRoot:       &depositDataRoot,
+	}, nil
 }
 
 // createWithdrawalCredentials creates withdrawal credentials given an account, public key or Ethereum 1 address.
@@ -145,7 +198,11 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		withdrawalCredentials = make([]byte, 32)
 		copy(withdrawalCredentials[12:32], withdrawalAddressBytes)
 		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		if data.compounding {
+			withdrawalCredentials[0] = byte(2) // COMPOUNDING_WITHDRAWAL_PREFIX
+		} else {
+			withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		}
 	default:
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
@@ -123,6 +123,29 @@ func TestProcess(t *testing.T) {
 		signature3 = &tmp
 	}
 
+	var depositDataRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0x3ec3dd80701aca35278ebf9f28b19f1182f3cff09507591d528fd755e9cff8f6")
+		depositDataRoot4 = &tmp
+	}
+	var depositMessageRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0x02a07512db85ec3572fa9c496217639a0c65e6c943664ad2eb33b6d1f216d97e")
+		depositMessageRoot4 = &tmp
+	}
+	var signature4 *spec.BLSSignature
+	{
+		tmp := testutil.HexToSignature("0x8d2ec4e11cb78b7abb8d0327581066c0b5a7bda086b3e1f6250b9d1d1a47958edf810c116a79d4769b99318edc8ef6bc021c0924449fbda551759507e745bb1a13463a19a1e0e4029ec69ce767e20df776ff98cc4950f25483fd233446f55e3f")
+		signature4 = &tmp
+	}
+
+	infinitySignature := spec.BLSSignature{0xc0}
+	var depositDataRoot5 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0x1e6dae424ebe8bd823f3d526fb038f84c756af16466c08384912ebebb1c3223a")
+		depositDataRoot5 = &tmp
+	}
+
 	tests := []struct {
 		name   string
 		dataIn *dataIn
@@ -322,6 +345,86 @@ func TestProcess(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithdrawalAddressCompounding",
+			dataIn: &dataIn{
+				format:            "raw",
+				passphrases:       []string{"pass"},
+				withdrawalAddress: withdrawalAddress,
+				compounding:       true,
+				amount:            2048000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+			res: []*dataOut{
+				{
+					format:                "raw",
+					account:               "Test/Interop 0",
+					validatorPubKey:       validatorPubKey,
+					amount:                2048000000000,
+					withdrawalCredentials: testutil.HexToBytes("0x02000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+					signature:             signature4,
+					forkVersion:           forkVersion,
+					depositDataRoot:       depositDataRoot4,
+					depositMessageRoot:    depositMessageRoot4,
+				},
+			},
+		},
+		{
+			name: "TopUpSigned",
+			dataIn: &dataIn{
+				format:                     "raw",
+				passphrases:                []string{"pass"},
+				amount:                     32000000000,
+				validatorAccounts:          []e2wtypes.Account{interop0},
+				forkVersion:                forkVersion,
+				domain:                     domain,
+				topUp:                      true,
+				topUpIndex:                 1234,
+				topUpPubKey:                *validatorPubKey,
+				topUpWithdrawalCredentials: testutil.HexToBytes("0x01000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+			},
+			res: []*dataOut{
+				{
+					format:                "raw",
+					account:               "validator 1234",
+					validatorPubKey:       validatorPubKey,
+					amount:                32000000000,
+					withdrawalCredentials: testutil.HexToBytes("0x01000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+					signature:             signature3,
+					forkVersion:           forkVersion,
+					depositDataRoot:       depositDataRoot3,
+					depositMessageRoot:    depositMessageRoot3,
+				},
+			},
+		},
+		{
+			name: "TopUpUnsigned",
+			dataIn: &dataIn{
+				format:                     "raw",
+				amount:                     32000000000,
+				forkVersion:                forkVersion,
+				domain:                     domain,
+				topUp:                      true,
+				topUpIndex:                 1234,
+				topUpPubKey:                *validatorPubKey,
+				topUpWithdrawalCredentials: testutil.HexToBytes("0x01000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+			},
+			res: []*dataOut{
+				{
+					format:                "raw",
+					account:               "validator 1234",
+					validatorPubKey:       validatorPubKey,
+					amount:                32000000000,
+					withdrawalCredentials: testutil.HexToBytes("0x01000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+					signature:             &infinitySignature,
+					forkVersion:           forkVersion,
+					depositDataRoot:       depositDataRoot5,
+					depositMessageRoot:    depositMessageRoot3,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,420 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// exitOperationFilenameFormat is the format of the name of the file to which
+// a single signed exit is written in dry run mode.
+var exitOperationFilenameFormat = "exit-%d.json"
+
+// Statuses of the validators in a batch.
+const (
+	batchStatusGenerated = "generated"
+	batchStatusWritten   = "written"
+	batchStatusBroadcast = "broadcast"
+	batchStatusSkipped   = "skipped"
+	batchStatusFailed    = "failed"
+)
+
+// batchResult is the result of exiting a single validator in a batch.
+type batchResult struct {
+	validator string
+	index     *phase0.ValidatorIndex
+	status    string
+	err       error
+	file      string
+	operation *phase0.SignedVoluntaryExit
+	checks    []*util.ExitCheck
+}
+
+// processBatch generates, and if requested writes or broadcasts, exits for a
+// batch of validators.  Failures for individual validators are recorded
+// rather than halting the batch.
+func (c *command) processBatch(ctx context.Context) error {
+	specifiers, err := c.batchSpecifiers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.offline {
+		if err := c.setupBatchPreflight(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, specifier := range specifiers {
+		c.batchResults = append(c.batchResults, c.generateBatchOperation(ctx, specifier))
+	}
+
+	switch {
+	case c.dryRun:
+		for _, result := range c.batchResults {
+			if result.status != batchStatusGenerated {
+				continue
+			}
+			result.file, result.err = writeOperationFile(result.operation, c.domainGenesisValidatorsRoot)
+			if result.err != nil {
+				result.status = batchStatusFailed
+				continue
+			}
+			result.status = batchStatusWritten
+		}
+	case c.json || c.offline:
+		// Operations are output rather than broadcast.
+	default:
+		if err := c.verifyNetwork(ctx); err != nil {
+			return err
+		}
+		for _, result := range c.batchResults {
+			if result.status != batchStatusGenerated {
+				continue
+			}
+			if err := c.broadcastOperation(ctx, result.operation); err != nil {
+				result.status = batchStatusFailed
+				result.err = err
+				continue
+			}
+			result.status = batchStatusBroadcast
+		}
+	}
+
+	// Only operations that succeeded are output or watched.
+	for _, result := range c.batchResults {
+		if result.status != batchStatusFailed && result.operation != nil {
+			c.signedOperations = append(c.signedOperations, result.operation)
+		}
+	}
+
+	failed := c.batchFailures()
+	if c.quiet {
+		if failed > 0 {
+			return fmt.Errorf("%d of %d exits failed", failed, len(c.batchResults))
+		}
+		if len(c.signedOperations) == 0 {
+			return errors.New("no suitable validators found; no operations generated")
+		}
+	}
+	if failed > 0 && c.json {
+		// JSON output contains only the operations, so report failures separately.
+		for _, result := range c.batchResults {
+			if result.status == batchStatusFailed {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", result.validator, result.err)
+			}
+		}
+	}
+
+	if c.watch && len(c.signedOperations) > 0 {
+		return c.watchOperations(ctx)
+	}
+
+	return nil
+}
+
+// batchSpecifiers returns the specifiers of the validators in the batch.
+func (c *command) batchSpecifiers(ctx context.Context) ([]string, error) {
+	if len(c.validators) > 0 {
+		return c.validators, nil
+	}
+
+	// Exit all validators in the wallet.
+	wallet, err := util.WalletFromPath(ctx, c.wallet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wallet")
+	}
+	specifiers := make([]string, 0)
+	for account := range wallet.Accounts(ctx) {
+		specifiers = append(specifiers, fmt.Sprintf("%s/%s", wallet.Name(), account.Name()))
+	}
+	if len(specifiers) == 0 {
+		return nil, errors.New("wallet does not contain any accounts")
+	}
+
+	return specifiers, nil
+}
+
+// generateBatchOperation generates the signed exit for a single validator in a batch.
+func (c *command) generateBatchOperation(ctx context.Context, specifier string) *batchResult {
+	result := &batchResult{
+		validator: specifier,
+	}
+
+	info, err := c.chainInfo.FetchValidatorInfo(ctx, specifier)
+	if err != nil {
+		if errors.Is(err, beacon.ErrUnknownValidator) {
+			result.status = batchStatusSkipped
+			result.err = errors.New("not a validator on chain")
+		} else {
+			result.status = batchStatusFailed
+			result.err = err
+		}
+		return result
+	}
+	index := info.Index
+	result.index = &index
+
+	if c.validatorsProvider != nil {
+		checks, err := c.batchPreflightChecks(ctx, index)
+		if err != nil {
+			result.status = batchStatusFailed
+			result.err = err
+			return result
+		}
+		result.checks = checks
+		if failed := util.FailedExitChecks(checks); len(failed) > 0 {
+			details := make([]string, 0, len(failed))
+			for _, check := range failed {
+				details = append(details, check.Detail)
+			}
+			result.status = batchStatusSkipped
+			result.err = errors.New(strings.Join(details, "; "))
+			return result
+		}
+	}
+
+	account, accountName, err := c.batchAccount(ctx, specifier, info)
+	if err != nil {
+		result.status = batchStatusFailed
+		result.err = err
+		return result
+	}
+
+	operation, err := c.signedOperationFromAccount(ctx, account, accountName)
+	if err != nil {
+		result.status = batchStatusFailed
+		result.err = err
+		return result
+	}
+	if valid, reason := c.validateOperation(ctx, operation); !valid {
+		result.status = batchStatusSkipped
+		result.err = errors.New(reason)
+		return result
+	}
+
+	result.status = batchStatusGenerated
+	result.operation = operation
+
+	return result
+}
+
+// setupBatchPreflight sets up the information required to run the exit
+// preflight checks for each validator in a batch.
+func (c *command) setupBatchPreflight(ctx context.Context) error {
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	specProvider, isProvider := c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+
+	var err error
+	c.shardCommitteePeriod, err = util.ObtainShardCommitteePeriod(ctx, specProvider)
+	if err != nil {
+		return err
+	}
+	c.currentEpoch = c.chainTime.CurrentEpoch()
+
+	return nil
+}
+
+// batchPreflightChecks runs the exit preflight checks for a validator in a batch.
+func (c *command) batchPreflightChecks(ctx context.Context, index phase0.ValidatorIndex) ([]*util.ExitCheck, error) {
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, fmt.Sprintf("%d", index), "head")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validator for preflight checks")
+	}
+
+	return util.ExitChecks(validator, c.currentEpoch, c.shardCommitteePeriod), nil
+}
+
+// batchAccount obtains the account with which to sign the exit for a
+// validator in a batch, along with its name if it has one.
+func (c *command) batchAccount(ctx context.Context,
+	specifier string,
+	info *beacon.ValidatorInfo,
+) (
+	e2wtypes.Account,
+	string,
+	error,
+) {
+	if strings.Contains(specifier, "/") {
+		account, err := util.ParseAccount(ctx, specifier, c.passphrases, true)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to obtain account")
+		}
+		return account, specifier, nil
+	}
+
+	pubkey := fmt.Sprintf("%#x", info.Pubkey)
+	if c.wallet != "" {
+		accountName, err := c.walletAccountName(ctx, pubkey)
+		if err != nil {
+			return nil, "", err
+		}
+		if accountName != "" {
+			account, err := util.ParseAccount(ctx, accountName, c.passphrases, true)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "failed to obtain account")
+			}
+			return account, accountName, nil
+		}
+	}
+
+	if c.mnemonic != "" {
+		path, err := c.mnemonicPath(pubkey)
+		if err != nil {
+			return nil, "", err
+		}
+		if path != "" {
+			account, err := util.ParseAccount(ctx, c.mnemonic, []string{path}, true)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "failed to obtain account from mnemonic")
+			}
+			return account, "", nil
+		}
+	}
+
+	return nil, "", errors.New("no key available for validator")
+}
+
+// walletAccountName returns the name of the account in the wallet with the
+// given public key, or an empty string if there is no such account.
+func (c *command) walletAccountName(ctx context.Context, pubkey string) (string, error) {
+	if c.walletAccounts == nil {
+		wallet, err := util.WalletFromPath(ctx, c.wallet)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to open wallet")
+		}
+		c.walletAccounts = make(map[string]string)
+		for account := range wallet.Accounts(ctx) {
+			accountPubkey, err := util.BestPublicKey(account)
+			if err != nil {
+				return "", errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s/%s", wallet.Name(), account.Name()))
+			}
+			c.walletAccounts[fmt.Sprintf("%#x", accountPubkey.Marshal())] = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+		}
+	}
+
+	return c.walletAccounts[pubkey], nil
+}
+
+// mnemonicPath returns the path of the validator key with the given public
+// key derived from the mnemonic, or an empty string if it is not found.
+func (c *command) mnemonicPath(pubkey string) (string, error) {
+	if c.mnemonicPaths == nil {
+		seed, err := util.SeedFromMnemonic(c.mnemonic)
+		if err != nil {
+			return "", err
+		}
+
+		validators := make(map[string]bool, len(c.chainInfo.Validators))
+		for _, validator := range c.chainInfo.Validators {
+			validators[fmt.Sprintf("%#x", validator.Pubkey)] = true
+		}
+
+		// Scan the keys from the seed until maxDistance indices have passed without a validator.
+		maxDistance := 1024
+		c.mnemonicPaths = make(map[string]string)
+		lastFoundIndex := 0
+		for i := 0; i-lastFoundIndex <= maxDistance; i++ {
+			path := fmt.Sprintf("m/12381/3600/%d/0/0", i)
+			privkey, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to generate validator private key")
+			}
+			keyPubkey := fmt.Sprintf("%#x", privkey.PublicKey().Marshal())
+			if validators[keyPubkey] {
+				c.mnemonicPaths[keyPubkey] = path
+				lastFoundIndex = i
+			}
+		}
+	}
+
+	return c.mnemonicPaths[pubkey], nil
+}
+
+// batchFailures returns the number of validators in the batch whose exits failed.
+func (c *command) batchFailures() int {
+	failures := 0
+	for _, result := range c.batchResults {
+		if result.status == batchStatusFailed {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+// broadcastOperation broadcasts a single signed exit.
+func (c *command) broadcastOperation(ctx context.Context, op *phase0.SignedVoluntaryExit) error {
+	if c.debug {
+		data, err := json.Marshal(op)
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "Broadcasting %s\n", string(data))
+		}
+	}
+
+	return c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, op)
+}
+
+// writeOperationFiles writes each signed exit to its own file.
+func (c *command) writeOperationFiles() error {
+	for _, op := range c.signedOperations {
+		filename, err := writeOperationFile(op, c.domainGenesisValidatorsRoot)
+		if err != nil {
+			return err
+		}
+		c.writtenFiles = append(c.writtenFiles, filename)
+	}
+
+	return nil
+}
+
+// writeOperationFile writes a signed exit to a file named after its
+// validator, tagged with the network for which it was generated so that it
+// can later be broadcast with --signed-operations.
+func writeOperationFile(op *phase0.SignedVoluntaryExit, genesisValidatorsRoot phase0.Root) (string, error) {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal signed operation")
+	}
+	data, err = beacon.AddNetworkTag(data, genesisValidatorsRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to add network to signed operation")
+	}
+
+	filename := fmt.Sprintf(exitOperationFilenameFormat, op.Message.ValidatorIndex)
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", filename))
+	}
+
+	return filename, nil
+}
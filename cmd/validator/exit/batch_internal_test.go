@@ -0,0 +1,405 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestBatchInput(t *testing.T) {
+	dir := t.TempDir()
+	validatorsFile := filepath.Join(dir, "validators.txt")
+	require.NoError(t, os.WriteFile(validatorsFile, []byte("# Validators to exit\n1\n\n0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("# Nothing here\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		vars       map[string]any
+		validators []string
+		batch      bool
+		err        string
+	}{
+		{
+			name: "NotBatch",
+			vars: map[string]any{
+				"timeout":   "5s",
+				"validator": "1",
+			},
+		},
+		{
+			name: "ValidatorsFile",
+			vars: map[string]any{
+				"timeout":         "5s",
+				"validators-file": validatorsFile,
+			},
+			validators: []string{"1", "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87"},
+			batch:      true,
+		},
+		{
+			name: "ValidatorsFileMissing",
+			vars: map[string]any{
+				"timeout":         "5s",
+				"validators-file": filepath.Join(dir, "missing.txt"),
+			},
+			err: "failed to read validators file: open " + filepath.Join(dir, "missing.txt") + ": no such file or directory",
+		},
+		{
+			name: "ValidatorsFileEmpty",
+			vars: map[string]any{
+				"timeout":         "5s",
+				"validators-file": emptyFile,
+			},
+			err: "validators file does not contain any validators",
+		},
+		{
+			name: "Wallet",
+			vars: map[string]any{
+				"timeout": "5s",
+				"wallet":  "Test wallet",
+			},
+			batch: true,
+		},
+		{
+			name: "WalletAndValidator",
+			vars: map[string]any{
+				"timeout":   "5s",
+				"wallet":    "Test wallet",
+				"validator": "1",
+			},
+			err: "validators-file and wallet cannot be supplied with validator, private-key, path or signed-operations",
+		},
+		{
+			name: "DryRunJSON",
+			vars: map[string]any{
+				"timeout": "5s",
+				"wallet":  "Test wallet",
+				"dry-run": true,
+				"json":    true,
+			},
+			err: "dry-run cannot be supplied with json",
+		},
+		{
+			name: "DryRunWatch",
+			vars: map[string]any{
+				"timeout": "5s",
+				"wallet":  "Test wallet",
+				"dry-run": true,
+				"watch":   true,
+			},
+			err: "cannot watch exits in a dry run",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.batch, c.batch)
+				if test.validators != nil {
+					require.Equal(t, test.validators, c.validators)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateBatchOperation(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  0,
+				Pubkey: phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  1,
+				Pubkey: phase0.BLSPubKey{0xb3, 0xd8, 0x9e, 0x2f, 0x29, 0xc7, 0x12, 0xc6, 0xa9, 0xf8, 0xe5, 0xa2, 0x69, 0xb9, 0x76, 0x17, 0xc4, 0xa9, 0x4d, 0xd6, 0xf6, 0x66, 0x2a, 0xb3, 0xb0, 0x7c, 0xe9, 0xe5, 0x43, 0x45, 0x73, 0xf1, 0x5b, 0x5c, 0x98, 0x8c, 0xd1, 0x4b, 0xbd, 0x58, 0x04, 0xf7, 0x71, 0x56, 0xa8, 0xaf, 0x1c, 0xfa},
+				State:  apiv1.ValidatorStateExitedUnslashed,
+			},
+			{
+				Index:  2,
+				Pubkey: phase0.BLSPubKey{0xa9, 0x9a, 0x76, 0xed, 0x77, 0x96, 0xf7, 0xbe, 0x22, 0xd5, 0xb7, 0xe8, 0x5d, 0xee, 0xb7, 0xc5, 0x67, 0x7e, 0x88, 0xe5, 0x11, 0xe0, 0xb3, 0x37, 0x61, 0x8f, 0x8c, 0x4e, 0xb6, 0x13, 0x49, 0xb4, 0xbf, 0x2d, 0x15, 0x3f, 0x64, 0x9f, 0x7b, 0x53, 0x35, 0x9f, 0xe8, 0xb9, 0x4a, 0x38, 0xe4, 0x4c},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+		},
+		GenesisValidatorsRoot: phase0.Root{},
+		Epoch:                 1,
+		CurrentForkVersion:    phase0.Version{},
+	}
+
+	c := &command{
+		mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		chainInfo: chainInfo,
+	}
+
+	tests := []struct {
+		name      string
+		specifier string
+		status    string
+		index     *phase0.ValidatorIndex
+		err       string
+		expected  *phase0.SignedVoluntaryExit
+	}{
+		{
+			name:      "Unknown",
+			specifier: "5",
+			status:    batchStatusSkipped,
+			err:       "not a validator on chain",
+		},
+		{
+			name:      "Invalid",
+			specifier: "bad",
+			status:    batchStatusFailed,
+			err:       `failed to parse validator index: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name:      "Exited",
+			specifier: "1",
+			status:    batchStatusSkipped,
+			index:     validatorIndex(1),
+			err:       "validator is in state exited_unslashed, not suitable to generate an exit",
+		},
+		{
+			name:      "NoKey",
+			specifier: "2",
+			status:    batchStatusFailed,
+			index:     validatorIndex(2),
+			err:       "no key available for validator",
+		},
+		{
+			name:      "Good",
+			specifier: "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87",
+			status:    batchStatusGenerated,
+			index:     validatorIndex(0),
+			expected: &phase0.SignedVoluntaryExit{
+				Message: &phase0.VoluntaryExit{
+					Epoch:          1,
+					ValidatorIndex: 0,
+				},
+				Signature: phase0.BLSSignature{0x89, 0xf5, 0xc4, 0x42, 0x88, 0xf9, 0x5e, 0x19, 0xb6, 0xc1, 0x39, 0xf2, 0x62, 0x30, 0x05, 0x66, 0x5b, 0x98, 0x34, 0x62, 0xa2, 0x28, 0x12, 0x09, 0x77, 0xd8, 0x1f, 0x2e, 0xf5, 0x47, 0x56, 0x0b, 0xe2, 0x24, 0x46, 0xde, 0x21, 0xa8, 0xa9, 0x37, 0xd9, 0xdd, 0xa4, 0xe2, 0xd2, 0xec, 0x41, 0x75, 0x19, 0x64, 0x96, 0xcd, 0xd1, 0x30, 0x6d, 0xec, 0x4a, 0x12, 0x5f, 0x8c, 0x86, 0x1f, 0x80, 0x61, 0x71, 0x50, 0x4a, 0x9d, 0x6a, 0x61, 0x0e, 0xc4, 0xe1, 0x35, 0x04, 0x7e, 0x4f, 0xb6, 0x70, 0x52, 0xec, 0xc4, 0x56, 0x13, 0x60, 0xd0, 0xc3, 0xde, 0x04, 0xb6, 0xfb, 0xc4, 0x47, 0x42, 0x23, 0xff},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := c.generateBatchOperation(ctx, test.specifier)
+			require.Equal(t, test.specifier, result.validator)
+			require.Equal(t, test.status, result.status)
+			require.Equal(t, test.index, result.index)
+			if test.err != "" {
+				require.EqualError(t, result.err, test.err)
+			} else {
+				require.NoError(t, result.err)
+			}
+			require.Equal(t, test.expected, result.operation)
+		})
+	}
+}
+
+// batchValidatorsProvider provides fixed validator information for batch tests.
+type batchValidatorsProvider struct {
+	validators map[phase0.ValidatorIndex]*apiv1.Validator
+}
+
+func (p *batchValidatorsProvider) Validators(_ context.Context,
+	opts *api.ValidatorsOpts,
+) (
+	*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator],
+	error,
+) {
+	validators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for _, index := range opts.Indices {
+		if validator, exists := p.validators[index]; exists {
+			validators[index] = validator
+		}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data:     validators,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestGenerateBatchOperationPreflight(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  0,
+				Pubkey: phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  2,
+				Pubkey: phase0.BLSPubKey{0xa9, 0x9a, 0x76, 0xed, 0x77, 0x96, 0xf7, 0xbe, 0x22, 0xd5, 0xb7, 0xe8, 0x5d, 0xee, 0xb7, 0xc5, 0x67, 0x7e, 0x88, 0xe5, 0x11, 0xe0, 0xb3, 0x37, 0x61, 0x8f, 0x8c, 0x4e, 0xb6, 0x13, 0x49, 0xb4, 0xbf, 0x2d, 0x15, 0x3f, 0x64, 0x9f, 0x7b, 0x53, 0x35, 0x9f, 0xe8, 0xb9, 0x4a, 0x38, 0xe4, 0x4c},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  3,
+				Pubkey: phase0.BLSPubKey{0xb3, 0xd8, 0x9e, 0x2f, 0x29, 0xc7, 0x12, 0xc6, 0xa9, 0xf8, 0xe5, 0xa2, 0x69, 0xb9, 0x76, 0x17, 0xc4, 0xa9, 0x4d, 0xd6, 0xf6, 0x66, 0x2a, 0xb3, 0xb0, 0x7c, 0xe9, 0xe5, 0x43, 0x45, 0x73, 0xf1, 0x5b, 0x5c, 0x98, 0x8c, 0xd1, 0x4b, 0xbd, 0x58, 0x04, 0xf7, 0x71, 0x56, 0xa8, 0xaf, 0x1c, 0xfa},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+		},
+		Epoch: 300,
+	}
+
+	c := &command{
+		mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		chainInfo: chainInfo,
+		validatorsProvider: &batchValidatorsProvider{
+			validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+				0: {
+					Index:  0,
+					Status: apiv1.ValidatorStateActiveOngoing,
+					Validator: &phase0.Validator{
+						ActivationEpoch: 10,
+						ExitEpoch:       util.FarFutureEpoch,
+					},
+				},
+				2: {
+					Index:  2,
+					Status: apiv1.ValidatorStateActiveOngoing,
+					Validator: &phase0.Validator{
+						ActivationEpoch: 100,
+						ExitEpoch:       util.FarFutureEpoch,
+					},
+				},
+			},
+		},
+		currentEpoch:         300,
+		shardCommitteePeriod: 256,
+	}
+
+	tests := []struct {
+		name      string
+		specifier string
+		status    string
+		err       string
+		checks    int
+	}{
+		{
+			name:      "Go",
+			specifier: "0",
+			status:    batchStatusGenerated,
+			checks:    4,
+		},
+		{
+			name:      "NoGo",
+			specifier: "2",
+			status:    batchStatusSkipped,
+			err:       "validator cannot exit until epoch 356",
+			checks:    4,
+		},
+		{
+			name:      "Unavailable",
+			specifier: "3",
+			status:    batchStatusFailed,
+			err:       "failed to obtain validator for preflight checks: unknown validator",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := c.generateBatchOperation(ctx, test.specifier)
+			require.Equal(t, test.status, result.status)
+			if test.err != "" {
+				require.EqualError(t, result.err, test.err)
+			} else {
+				require.NoError(t, result.err)
+			}
+			require.Len(t, result.checks, test.checks)
+		})
+	}
+}
+
+func validatorIndex(index phase0.ValidatorIndex) *phase0.ValidatorIndex {
+	return &index
+}
+
+func TestWriteOperationFile(t *testing.T) {
+	exitOperationFilenameFormat = filepath.Join(t.TempDir(), "exit-%d.json")
+	defer func() {
+		exitOperationFilenameFormat = "exit-%d.json"
+	}()
+
+	op := &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{
+			Epoch:          1,
+			ValidatorIndex: 12345,
+		},
+	}
+	filename, err := writeOperationFile(op, phase0.Root{0x01})
+	require.NoError(t, err)
+	require.Equal(t, "exit-12345.json", filepath.Base(filename))
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	var written phase0.SignedVoluntaryExit
+	require.NoError(t, json.Unmarshal(data, &written))
+	require.Equal(t, op.Message, written.Message)
+	network, err := beacon.ObtainNetworkTag(data)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root{0x01}, *network)
+}
+
+func TestOutputBatch(t *testing.T) {
+	c := &command{
+		batch: true,
+		batchResults: []*batchResult{
+			{validator: "1", index: validatorIndex(1), status: batchStatusBroadcast, checks: []*util.ExitCheck{{Name: "active", Passed: true}}},
+			{validator: "Wallet/Account", index: validatorIndex(2), status: batchStatusWritten, file: "exit-2.json"},
+			{validator: "5", status: batchStatusSkipped, err: errors.New("not a validator on chain")},
+			{validator: "3", index: validatorIndex(3), status: batchStatusFailed, err: errors.New("no key available for validator")},
+			{validator: "4", index: validatorIndex(4), status: batchStatusSkipped, err: errors.New("validator cannot exit until epoch 356"), checks: []*util.ExitCheck{{Name: "shard committee period", Passed: false}}},
+		},
+		timeout: time.Second,
+	}
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "1: broadcast [preflight: go]\nWallet/Account (index 2): written to exit-2.json\n5: skipped (not a validator on chain)\n3: failed (no key available for validator)\n4: skipped (validator cannot exit until epoch 356) [preflight: no-go]\nExits: 1 written, 1 broadcast, 2 skipped, 1 failed", res)
+}
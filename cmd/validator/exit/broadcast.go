@@ -0,0 +1,180 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+// processBroadcastFiles broadcasts signed exits that were generated earlier,
+// for example on an offline machine.  Each exit is verified and validated
+// before it is broadcast, and failures for individual exits are recorded
+// rather than halting the broadcast of the remainder.
+func (c *command) processBroadcastFiles(ctx context.Context) error {
+	filenames, err := broadcastFilenames(c.broadcastFile)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[phase0.ValidatorIndex]bool)
+	for _, filename := range filenames {
+		ops, network, err := readSignedOperationsFile(filename)
+		if err != nil {
+			return err
+		}
+		if network != nil {
+			if c.signedOperationsNetwork != nil && *c.signedOperationsNetwork != *network {
+				return fmt.Errorf("%s is for a different network to the other signed exits", filename)
+			}
+			c.signedOperationsNetwork = network
+		}
+
+		for _, op := range ops {
+			index := op.Message.ValidatorIndex
+			result := &batchResult{
+				validator: filepath.Base(filename),
+				index:     &index,
+				operation: op,
+			}
+			c.batchResults = append(c.batchResults, result)
+			c.checkBroadcastOperation(ctx, result, seen[index])
+			seen[index] = true
+		}
+	}
+	if len(c.batchResults) == 0 {
+		return errors.New("no signed exits found")
+	}
+
+	if err := c.verifyNetwork(ctx); err != nil {
+		return err
+	}
+	for _, result := range c.batchResults {
+		if result.status != batchStatusGenerated {
+			continue
+		}
+		if err := c.broadcastOperation(ctx, result.operation); err != nil {
+			result.status = batchStatusFailed
+			result.err = err
+			continue
+		}
+		result.status = batchStatusBroadcast
+		c.signedOperations = append(c.signedOperations, result.operation)
+	}
+
+	failed := c.batchFailures()
+	if c.quiet && failed > 0 {
+		return fmt.Errorf("%d of %d exits failed", failed, len(c.batchResults))
+	}
+
+	if c.watch && len(c.signedOperations) > 0 {
+		return c.watchOperations(ctx)
+	}
+
+	return nil
+}
+
+// checkBroadcastOperation checks that a previously generated signed exit is
+// suitable for broadcast, setting the status of its result accordingly.
+func (c *command) checkBroadcastOperation(ctx context.Context, result *batchResult, duplicate bool) {
+	if duplicate {
+		result.status = batchStatusSkipped
+		result.err = errors.New("duplicate exit for validator")
+		return
+	}
+	if err := c.verifySignedOperation(ctx, result.operation); err != nil {
+		result.status = batchStatusFailed
+		result.err = errors.Wrap(err, "failed to verify signed exit")
+		return
+	}
+	if valid, reason := c.validateOperation(ctx, result.operation); !valid {
+		result.status = batchStatusSkipped
+		result.err = errors.New(reason)
+		return
+	}
+
+	result.status = batchStatusGenerated
+}
+
+// broadcastFilenames returns the files containing signed exits to broadcast.
+// If the path is a directory then all JSON files within it are returned,
+// excluding the offline preparation file.
+func broadcastFilenames(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to access broadcast file")
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read broadcast directory")
+	}
+	filenames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() ||
+			!strings.HasSuffix(entry.Name(), ".json") ||
+			entry.Name() == offlinePreparationFilename {
+			continue
+		}
+		filenames = append(filenames, filepath.Join(path, entry.Name()))
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no signed exit files found in %s", path)
+	}
+	sort.Strings(filenames)
+
+	return filenames, nil
+}
+
+// readSignedOperationsFile reads a file containing either a single signed
+// exit or an array of signed exits, along with the network for which they
+// were generated if present.
+func readSignedOperationsFile(filename string) ([]*phase0.SignedVoluntaryExit, *phase0.Root, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", filename))
+	}
+	data = bytes.TrimSpace(data)
+
+	ops := make([]*phase0.SignedVoluntaryExit, 0)
+	if bytes.HasPrefix(data, []byte("{")) {
+		op := &phase0.SignedVoluntaryExit{}
+		if err := json.Unmarshal(data, op); err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to parse %s", filename))
+		}
+		ops = append(ops, op)
+	} else if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to parse %s", filename))
+	}
+
+	network, err := beacon.ObtainNetworkTag(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain network of %s", filename))
+	}
+
+	return ops, network, nil
+}
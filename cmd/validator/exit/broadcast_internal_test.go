@@ -0,0 +1,261 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestBroadcastInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]any
+		err  string
+	}{
+		{
+			name: "Good",
+			vars: map[string]any{
+				"timeout":        "5s",
+				"broadcast-file": "exits",
+			},
+		},
+		{
+			name: "Validator",
+			vars: map[string]any{
+				"timeout":        "5s",
+				"broadcast-file": "exits",
+				"validator":      "1",
+			},
+			err: "broadcast-file cannot be supplied with validator, validators-file, wallet, mnemonic, private-key or signed-operations",
+		},
+		{
+			name: "Offline",
+			vars: map[string]any{
+				"timeout":        "5s",
+				"broadcast-file": "exits",
+				"offline":        true,
+			},
+			err: "cannot broadcast exits when offline",
+		},
+		{
+			name: "DryRun",
+			vars: map[string]any{
+				"timeout":        "5s",
+				"broadcast-file": "exits",
+				"dry-run":        true,
+			},
+			err: "broadcast-file cannot be supplied with json or dry-run",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.vars["broadcast-file"], c.broadcastFile)
+			}
+		})
+	}
+}
+
+func TestBroadcastFilenames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"exit-2.json", "exit-1.json", offlinePreparationFilename, "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub.json"), 0o700))
+	emptyDir := t.TempDir()
+
+	tests := []struct {
+		name string
+		path string
+		res  []string
+		err  string
+	}{
+		{
+			name: "Missing",
+			path: filepath.Join(dir, "missing.json"),
+			err:  "failed to access broadcast file: stat " + filepath.Join(dir, "missing.json") + ": no such file or directory",
+		},
+		{
+			name: "File",
+			path: filepath.Join(dir, "exit-2.json"),
+			res:  []string{filepath.Join(dir, "exit-2.json")},
+		},
+		{
+			name: "Directory",
+			path: dir,
+			res:  []string{filepath.Join(dir, "exit-1.json"), filepath.Join(dir, "exit-2.json")},
+		},
+		{
+			name: "DirectoryEmpty",
+			path: emptyDir,
+			err:  "no signed exit files found in " + emptyDir,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := broadcastFilenames(test.path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestReadSignedOperationsFile(t *testing.T) {
+	dir := t.TempDir()
+	single := `{"message":{"epoch":"1","validator_index":"2"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000"}`
+	multiple := `[{"message":{"epoch":"1","validator_index":"2"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},{"message":{"epoch":"1","validator_index":"3"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}]`
+	files := map[string]string{
+		"single.json":   single + "\n",
+		"multiple.json": multiple,
+		"invalid.json":  "not JSON",
+	}
+	for name, contents := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600))
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		indices []phase0.ValidatorIndex
+		network *phase0.Root
+		err     string
+	}{
+		{
+			name: "Invalid",
+			file: "invalid.json",
+			err:  "failed to parse " + filepath.Join(dir, "invalid.json") + ": invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			name:    "Single",
+			file:    "single.json",
+			indices: []phase0.ValidatorIndex{2},
+			network: &phase0.Root{0x01},
+		},
+		{
+			name:    "Multiple",
+			file:    "multiple.json",
+			indices: []phase0.ValidatorIndex{2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ops, network, err := readSignedOperationsFile(filepath.Join(dir, test.file))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				indices := make([]phase0.ValidatorIndex, 0, len(ops))
+				for _, op := range ops {
+					indices = append(indices, op.Message.ValidatorIndex)
+				}
+				require.Equal(t, test.indices, indices)
+				require.Equal(t, test.network, network)
+			}
+		})
+	}
+}
+
+func TestCheckBroadcastOperation(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	c := &command{
+		chainInfo: &beacon.ChainInfo{
+			Version: 1,
+			Validators: []*beacon.ValidatorInfo{
+				{
+					Index:  0,
+					Pubkey: phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+					State:  apiv1.ValidatorStateActiveOngoing,
+				},
+			},
+			Epoch: 1,
+		},
+	}
+	good := &phase0.SignedVoluntaryExit{
+		Message: &phase0.VoluntaryExit{
+			Epoch:          1,
+			ValidatorIndex: 0,
+		},
+		Signature: phase0.BLSSignature{0x89, 0xf5, 0xc4, 0x42, 0x88, 0xf9, 0x5e, 0x19, 0xb6, 0xc1, 0x39, 0xf2, 0x62, 0x30, 0x05, 0x66, 0x5b, 0x98, 0x34, 0x62, 0xa2, 0x28, 0x12, 0x09, 0x77, 0xd8, 0x1f, 0x2e, 0xf5, 0x47, 0x56, 0x0b, 0xe2, 0x24, 0x46, 0xde, 0x21, 0xa8, 0xa9, 0x37, 0xd9, 0xdd, 0xa4, 0xe2, 0xd2, 0xec, 0x41, 0x75, 0x19, 0x64, 0x96, 0xcd, 0xd1, 0x30, 0x6d, 0xec, 0x4a, 0x12, 0x5f, 0x8c, 0x86, 0x1f, 0x80, 0x61, 0x71, 0x50, 0x4a, 0x9d, 0x6a, 0x61, 0x0e, 0xc4, 0xe1, 0x35, 0x04, 0x7e, 0x4f, 0xb6, 0x70, 0x52, 0xec, 0xc4, 0x56, 0x13, 0x60, 0xd0, 0xc3, 0xde, 0x04, 0xb6, 0xfb, 0xc4, 0x47, 0x42, 0x23, 0xff},
+	}
+
+	tests := []struct {
+		name      string
+		operation *phase0.SignedVoluntaryExit
+		duplicate bool
+		status    string
+		err       string
+	}{
+		{
+			name:      "Duplicate",
+			operation: good,
+			duplicate: true,
+			status:    batchStatusSkipped,
+			err:       "duplicate exit for validator",
+		},
+		{
+			name: "SignatureMissing",
+			operation: &phase0.SignedVoluntaryExit{
+				Message: good.Message,
+			},
+			status: batchStatusFailed,
+			err:    "failed to verify signed exit: invalid signature",
+		},
+		{
+			name:      "Good",
+			operation: good,
+			status:    batchStatusGenerated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := &batchResult{operation: test.operation}
+			c.checkBroadcastOperation(ctx, result, test.duplicate)
+			require.Equal(t, test.status, result.status)
+			if test.err != "" {
+				require.EqualError(t, result.err, test.err)
+			} else {
+				require.NoError(t, result.err)
+			}
+		})
+	}
+}
@@ -18,9 +18,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 // obtainChainInfo obtains the chain information required to create an exit operation.
@@ -34,6 +40,10 @@ func (c *command) obtainChainInfo(ctx context.Context) error {
 	}
 
 	if c.offline {
+		if c.network != "" {
+			// Use the bundled information for the network.
+			return c.obtainChainInfoFromNetwork(ctx)
+		}
 		// If we are here it means that we are offline without chain information, and cannot continue.
 		return fmt.Errorf("failed to obtain offline preparation file: %w", err)
 	}
@@ -87,6 +97,56 @@ func (c *command) obtainChainInfoFromNode(ctx context.Context) error {
 	return nil
 }
 
+// obtainChainInfoFromNetwork obtains chain info from the bundled network information.
+// As this does not contain any validators, the validator supplied on the command
+// line is added to it.
+func (c *command) obtainChainInfoFromNetwork(ctx context.Context) error {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from bundled information for network %s\n", c.network)
+	}
+
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNetwork(c.network, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !numeric.MatchString(c.validator) {
+		return errors.New("validator must be supplied as an index when using bundled network information")
+	}
+	index, err := strconv.ParseUint(c.validator, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid validator index")
+	}
+
+	var account e2wtypes.Account
+	switch {
+	case c.privateKey != "":
+		account, err = util.ParseAccount(ctx, c.privateKey, nil, true)
+	case c.mnemonic != "" && c.path != "":
+		account, err = util.ParseAccount(ctx, c.mnemonic, []string{c.path}, true)
+	default:
+		return errors.New("private key, or mnemonic and path, required when using bundled network information")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator account")
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return err
+	}
+
+	// The validator's state cannot be known offline, so it is assumed to be active.
+	validatorInfo := &beacon.ValidatorInfo{
+		Index: phase0.ValidatorIndex(index),
+		State: apiv1.ValidatorStateActiveOngoing,
+	}
+	copy(validatorInfo.Pubkey[:], pubKey.Marshal())
+	c.chainInfo.Validators = append(c.chainInfo.Validators, validatorInfo)
+
+	return nil
+}
+
 // writeChainInfoToFile prepares for an offline run of this command by dumping
 // the chain information to a file.
 func (c *command) writeChainInfoToFile(_ context.Context) error {
@@ -15,6 +15,8 @@ package validatorexit
 
 import (
 	"context"
+	"os"
+	"strings"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -41,9 +43,19 @@ type command struct {
 	validator             string
 	forkVersion           string
 	genesisValidatorsRoot string
+	network               string
 	prepareOffline        bool
 	signedOperationsInput string
 	epoch                 string
+	watch                 bool
+	watchTimeout          time.Duration
+	allowNetworkMismatch  bool
+	approvals             []string
+	validators            []string
+	wallet                string
+	dryRun                bool
+	batch                 bool
+	broadcastFile         string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -51,8 +63,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Information required to generate the operations.
-	chainInfo *beacon.ChainInfo
-	domain    phase0.Domain
+	chainInfo                   *beacon.ChainInfo
+	domain                      phase0.Domain
+	domainGenesisValidatorsRoot phase0.Root
+	signedOperationsNetwork     *phase0.Root
 
 	// Processing.
 	consensusClient consensusclient.Service
@@ -60,6 +74,18 @@ type command struct {
 
 	// Output.
 	signedOperations []*phase0.SignedVoluntaryExit
+	watchComplete    bool
+	batchResults     []*batchResult
+	writtenFiles     []string
+
+	// Batch key lookups, populated on demand.
+	walletAccounts map[string]string
+	mnemonicPaths  map[string]string
+
+	// Batch preflight checks, run when connected to a beacon node.
+	validatorsProvider   consensusclient.ValidatorsProvider
+	currentEpoch         phase0.Epoch
+	shardCommitteePeriod phase0.Epoch
 }
 
 func newCommand(_ context.Context) (*command, error) {
@@ -79,12 +105,25 @@ func newCommand(_ context.Context) (*command, error) {
 		privateKey:               viper.GetString("private-key"),
 		signedOperationsInput:    viper.GetString("signed-operations"),
 		validator:                viper.GetString("validator"),
-		forkVersion:              viper.GetString("fork-version"),
+		forkVersion:              viper.GetString("domain-fork-version"),
 		genesisValidatorsRoot:    viper.GetString("genesis-validators-root"),
+		network:                  viper.GetString("network"),
 		epoch:                    viper.GetString("epoch"),
+		watch:                    viper.GetBool("watch"),
+		watchTimeout:             viper.GetDuration("watch-timeout"),
+		allowNetworkMismatch:     viper.GetBool("allow-network-mismatch"),
+		approvals:                viper.GetStringSlice("approvals"),
+		wallet:                   viper.GetString("wallet"),
+		dryRun:                   viper.GetBool("dry-run"),
+		broadcastFile:            viper.GetString("broadcast-file"),
 		signedOperations:         make([]*phase0.SignedVoluntaryExit, 0),
 	}
 
+	// Fork version is the deprecated name for domain fork version.
+	if c.forkVersion == "" {
+		c.forkVersion = viper.GetString("fork-version")
+	}
+
 	// Account and validator are synonymous.
 	if c.validator == "" {
 		c.validator = viper.GetString("account")
@@ -95,6 +134,55 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if c.network != "" && !c.offline {
+		return nil, errors.New("network can only be supplied when offline")
+	}
+
+	if viper.GetString("validators-file") != "" {
+		var err error
+		c.validators, err = readValidatorsFile(viper.GetString("validators-file"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.batch = len(c.validators) > 0 || c.wallet != ""
+	if c.batch && (c.validator != "" || c.privateKey != "" || c.path != "" || c.signedOperationsInput != "") {
+		return nil, errors.New("validators-file and wallet cannot be supplied with validator, private-key, path or signed-operations")
+	}
+
+	if c.broadcastFile != "" {
+		if c.batch || c.validator != "" || c.mnemonic != "" || c.privateKey != "" || c.signedOperationsInput != "" {
+			return nil, errors.New("broadcast-file cannot be supplied with validator, validators-file, wallet, mnemonic, private-key or signed-operations")
+		}
+		if c.offline || c.prepareOffline {
+			return nil, errors.New("cannot broadcast exits when offline")
+		}
+		if c.json || c.dryRun {
+			return nil, errors.New("broadcast-file cannot be supplied with json or dry-run")
+		}
+	}
+
+	if c.dryRun {
+		if c.json {
+			return nil, errors.New("dry-run cannot be supplied with json")
+		}
+		if c.watch {
+			return nil, errors.New("cannot watch exits in a dry run")
+		}
+	}
+
+	if c.watch {
+		if c.offline {
+			return nil, errors.New("cannot watch exits when offline")
+		}
+		if c.json {
+			return nil, errors.New("cannot watch exits when generating JSON output")
+		}
+		if c.watchTimeout == 0 {
+			return nil, errors.New("watch timeout is required")
+		}
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
@@ -103,3 +191,26 @@ func newCommand(_ context.Context) (*command, error) {
 
 	return c, nil
 }
+
+// readValidatorsFile reads validators from a file, one per line.
+// Empty lines and lines starting with '#' are ignored.
+func readValidatorsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read validators file")
+	}
+
+	res := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		res = append(res, line)
+	}
+	if len(res) == 0 {
+		return nil, errors.New("validators file does not contain any validators")
+	}
+
+	return res, nil
+}
@@ -18,8 +18,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
 )
 
 //nolint:unparam
@@ -32,25 +35,127 @@ func (c *command) output(_ context.Context) (string, error) {
 		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
 	}
 
-	if c.json || c.offline {
-		var data []byte
-		var err error
-		if len(c.signedOperations) == 1 {
-			data, err = json.Marshal(c.signedOperations[0])
-		} else {
-			data, err = json.Marshal(c.signedOperations)
+	if (c.batch || c.broadcastFile != "") && !c.json {
+		if c.offline && !c.dryRun && len(c.signedOperations) > 0 {
+			if err := c.writeSignedOperations(); err != nil {
+				return "", err
+			}
 		}
+		return c.outputBatch(), nil
+	}
+
+	if c.dryRun {
+		builder := strings.Builder{}
+		for _, filename := range c.writtenFiles {
+			builder.WriteString(fmt.Sprintf("Signed exit written to %s\n", filename))
+		}
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	if c.json {
+		data, err := c.signedOperationsJSON()
 		if err != nil {
-			return "", errors.Wrap(err, "failed to marshal signed operations")
+			return "", err
 		}
-		if c.json {
-			return string(data), nil
+		return string(data), nil
+	}
+
+	if c.offline {
+		return "", c.writeSignedOperations()
+	}
+
+	if c.watchComplete {
+		return "All exits confirmed", nil
+	}
+
+	return "", nil
+}
+
+// signedOperationsJSON returns the signed operations as JSON, tagged with the
+// network for which they were generated.
+func (c *command) signedOperationsJSON() ([]byte, error) {
+	var data []byte
+	var err error
+	if len(c.signedOperations) == 1 {
+		data, err = json.Marshal(c.signedOperations[0])
+	} else {
+		data, err = json.Marshal(c.signedOperations)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal signed operations")
+	}
+	data, err = beacon.AddNetworkTag(data, c.domainGenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add network to signed operations")
+	}
+
+	return data, nil
+}
+
+// writeSignedOperations writes the signed operations to the exit operations file.
+func (c *command) writeSignedOperations() error {
+	data, err := c.signedOperationsJSON()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(exitOperationsFilename, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", exitOperationsFilename))
+	}
+
+	return nil
+}
+
+// outputBatch outputs the result for each validator in a batch, followed by a summary.
+func (c *command) outputBatch() string {
+	builder := strings.Builder{}
+
+	counts := make(map[string]int)
+	for _, result := range c.batchResults {
+		counts[result.status]++
+		label := result.validator
+		if result.index != nil && label != fmt.Sprintf("%d", *result.index) {
+			label = fmt.Sprintf("%s (index %d)", label, *result.index)
 		}
-		if err := os.WriteFile(exitOperationsFilename, data, 0o600); err != nil {
-			return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", exitOperationsFilename))
+		preflight := ""
+		if result.checks != nil {
+			if len(util.FailedExitChecks(result.checks)) == 0 {
+				preflight = " [preflight: go]"
+			} else {
+				preflight = " [preflight: no-go]"
+			}
+		}
+		switch {
+		case result.status == batchStatusWritten:
+			builder.WriteString(fmt.Sprintf("%s: written to %s%s\n", label, result.file, preflight))
+		case result.err != nil:
+			builder.WriteString(fmt.Sprintf("%s: %s (%v)%s\n", label, result.status, result.err, preflight))
+		default:
+			builder.WriteString(fmt.Sprintf("%s: %s%s\n", label, result.status, preflight))
+		}
+		if c.verbose {
+			for _, check := range result.checks {
+				outcome := "PASS"
+				if !check.Passed {
+					outcome = "FAIL"
+				}
+				builder.WriteString(fmt.Sprintf("  [%s] %s: %s\n", outcome, check.Name, check.Detail))
+			}
 		}
-		return "", nil
 	}
 
-	return "", nil
+	summary := make([]string, 0)
+	for _, status := range []string{batchStatusGenerated, batchStatusWritten, batchStatusBroadcast, batchStatusSkipped, batchStatusFailed} {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Exits: %s", strings.Join(summary, ", ")))
+	if c.offline && !c.dryRun && len(c.signedOperations) > 0 {
+		builder.WriteString(fmt.Sprintf("\nSigned exits written to %s", exitOperationsFilename))
+	}
+	if c.watchComplete {
+		builder.WriteString("\nAll exits confirmed")
+	}
+
+	return builder.String()
 }
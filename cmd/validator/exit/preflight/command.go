@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitpreflight
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	validator  string
+	mnemonic   string
+	privateKey string
+	remote     string
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient      consensusclient.Service
+	chainTime            chaintime.Service
+	validatorsProvider   consensusclient.ValidatorsProvider
+	specProvider         consensusclient.SpecProvider
+	genesisProvider      consensusclient.GenesisProvider
+	forkScheduleProvider consensusclient.ForkScheduleProvider
+
+	// Output.
+	validatorInfo     *apiv1.Validator
+	currentEpoch      phase0.Epoch
+	keySource         string
+	forkVersion       phase0.Version
+	forkVersionSource string
+	domain            phase0.Domain
+	checks            []*util.ExitCheck
+	ready             bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+		mnemonic:                 viper.GetString("mnemonic"),
+		privateKey:               viper.GetString("private-key"),
+		remote:                   viper.GetString("remote"),
+	}
+
+	// Account and validator are synonymous.
+	if c.validator == "" {
+		c.validator = viper.GetString("account")
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	return c, nil
+}
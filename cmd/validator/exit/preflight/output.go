@@ -0,0 +1,93 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitpreflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type jsonOutput struct {
+	Index             string            `json:"index"`
+	Pubkey            string            `json:"pubkey"`
+	Epoch             string            `json:"epoch"`
+	KeySource         string            `json:"key_source"`
+	ForkVersion       string            `json:"fork_version"`
+	ForkVersionSource string            `json:"fork_version_source"`
+	Domain            string            `json:"domain"`
+	Ready             bool              `json:"ready"`
+	Checks            []*util.ExitCheck `json:"checks"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		Index:             fmt.Sprintf("%d", c.validatorInfo.Index),
+		Pubkey:            fmt.Sprintf("%#x", c.validatorInfo.Validator.PublicKey),
+		Epoch:             fmt.Sprintf("%d", c.currentEpoch),
+		KeySource:         c.keySource,
+		ForkVersion:       fmt.Sprintf("%#x", c.forkVersion),
+		ForkVersionSource: c.forkVersionSource,
+		Domain:            fmt.Sprintf("%#x", c.domain),
+		Ready:             c.ready,
+		Checks:            c.checks,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validator: %d\n", c.validatorInfo.Index))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Public key: %#x\n", c.validatorInfo.Validator.PublicKey))
+		builder.WriteString(fmt.Sprintf("Current epoch: %d\n", c.currentEpoch))
+	}
+	for _, check := range c.checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("  [%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+	builder.WriteString(fmt.Sprintf("Fork version: %#x (%s)\n", c.forkVersion, c.forkVersionSource))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Domain: %#x\n", c.domain))
+	}
+	if c.ready {
+		builder.WriteString("Result: go\n")
+	} else {
+		builder.WriteString("Result: no-go\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
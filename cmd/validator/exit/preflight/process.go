@@ -0,0 +1,186 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitpreflight
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// Key sources.
+const (
+	keySourceNone       = "none"
+	keySourcePrivateKey = "private key"
+	keySourceMnemonic   = "mnemonic"
+	keySourceWallet     = "local wallet"
+	keySourceDirk       = "Dirk"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.validatorInfo, err = util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+	c.currentEpoch = c.chainTime.CurrentEpoch()
+
+	shardCommitteePeriod, err := util.ObtainShardCommitteePeriod(ctx, c.specProvider)
+	if err != nil {
+		return err
+	}
+
+	if err := c.generateDomain(ctx); err != nil {
+		return err
+	}
+
+	c.keySource = c.obtainKeySource()
+
+	c.checks = append(util.ExitChecks(c.validatorInfo, c.currentEpoch, shardCommitteePeriod), keySourceCheck(c.keySource))
+	c.ready = len(util.FailedExitChecks(c.checks)) == 0
+
+	return nil
+}
+
+func keySourceCheck(keySource string) *util.ExitCheck {
+	res := &util.ExitCheck{
+		Name:   "signing key",
+		Passed: keySource != keySourceNone,
+	}
+	if res.Passed {
+		res.Detail = fmt.Sprintf("exit will be signed by %s", keySource)
+	} else {
+		res.Detail = "no signing key available; supply the validator as an account, or supply --mnemonic or --private-key"
+	}
+
+	return res
+}
+
+// obtainKeySource works out the source of the key that will sign the exit,
+// following the order of precedence used by "validator exit".
+func (c *command) obtainKeySource() string {
+	switch {
+	case c.mnemonic != "":
+		return keySourceMnemonic
+	case c.privateKey != "":
+		return keySourcePrivateKey
+	case strings.Contains(c.validator, "/") || strings.HasPrefix(c.validator, "{"):
+		if c.remote != "" {
+			return fmt.Sprintf("%s (%s)", keySourceDirk, c.remote)
+		}
+		return keySourceWallet
+	default:
+		return keySourceNone
+	}
+}
+
+// generateDomain generates the domain used to sign the exit, in the same
+// manner as "validator exit".
+func (c *command) generateDomain(ctx context.Context) error {
+	genesisResponse, err := c.genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis information")
+	}
+	genesis := genesisResponse.Data
+
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+
+	forkScheduleResponse, err := c.forkScheduleProvider.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	forkSchedule := forkScheduleResponse.Data
+	c.forkVersion, c.forkVersionSource = beacon.VoluntaryExitForkVersion(spec, forkSchedule, c.currentEpoch)
+	domainType, exists := spec["DOMAIN_VOLUNTARY_EXIT"].(phase0.DomainType)
+	if !exists {
+		return errors.New("failed to obtain DOMAIN_VOLUNTARY_EXIT")
+	}
+
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        c.forkVersion,
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate signature domain")
+	}
+
+	copy(c.domain[:], domainType[:])
+	copy(c.domain[4:], root[:])
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.specProvider, isProvider = c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.genesisProvider, isProvider = c.consensusClient.(consensusclient.GenesisProvider)
+	if !isProvider {
+		return errors.New("connection does not provide genesis information")
+	}
+	c.forkScheduleProvider, isProvider = c.consensusClient.(consensusclient.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("connection does not provide fork schedule information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
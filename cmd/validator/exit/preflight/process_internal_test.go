@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexitpreflight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySource(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Index",
+			c:    &command{validator: "1"},
+			res:  keySourceNone,
+		},
+		{
+			name: "PublicKey",
+			c:    &command{validator: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			res:  keySourceNone,
+		},
+		{
+			name: "Wallet",
+			c:    &command{validator: "Test wallet/Test account"},
+			res:  keySourceWallet,
+		},
+		{
+			name: "Dirk",
+			c:    &command{validator: "Test wallet/Test account", remote: "dirk.example.com:9091"},
+			res:  "Dirk (dirk.example.com:9091)",
+		},
+		{
+			name: "Mnemonic",
+			c:    &command{validator: "1", mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"},
+			res:  keySourceMnemonic,
+		},
+		{
+			name: "PrivateKey",
+			c:    &command{validator: "1", privateKey: "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"},
+			res:  keySourcePrivateKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, test.c.obtainKeySource())
+		})
+	}
+}
@@ -47,6 +47,9 @@ var minTimeout = 5 * time.Minute
 // validatorPath is the regular expression that matches a validator  path.
 var validatorPath = regexp.MustCompile("^m/12381/3600/[0-9]+/0/0$")
 
+// numeric is the regular expression that matches a number.
+var numeric = regexp.MustCompile(`^[0-9]+$`)
+
 var (
 	offlinePreparationFilename = "offline-preparation.json"
 	exitOperationsFilename     = "exit-operations.json"
@@ -69,6 +72,14 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	if c.broadcastFile != "" {
+		return c.processBroadcastFiles(ctx)
+	}
+
+	if c.batch {
+		return c.processBatch(ctx)
+	}
+
 	if err := c.obtainOperations(ctx); err != nil {
 		return err
 	}
@@ -81,6 +92,10 @@ func (c *command) process(ctx context.Context) error {
 		return fmt.Errorf("operations failed validation: %s", reason)
 	}
 
+	if c.dryRun {
+		return c.writeOperationFiles()
+	}
+
 	if c.json || c.offline {
 		if c.debug {
 			fmt.Fprintf(os.Stderr, "Not broadcasting exit operations\n")
@@ -89,7 +104,19 @@ func (c *command) process(ctx context.Context) error {
 		return nil
 	}
 
-	return c.broadcastOperations(ctx)
+	if err := c.verifyNetwork(ctx); err != nil {
+		return err
+	}
+
+	if err := c.broadcastOperations(ctx); err != nil {
+		return err
+	}
+
+	if c.watch {
+		return c.watchOperations(ctx)
+	}
+
+	return nil
 }
 
 func (c *command) obtainOperations(ctx context.Context) error {
@@ -292,6 +319,10 @@ func (c *command) obtainOperationsFromFile(ctx context.Context) error {
 	if err := json.Unmarshal(data, &c.signedOperations); err != nil {
 		return errors.Wrap(err, "failed to parse exit operations file")
 	}
+	c.signedOperationsNetwork, err = beacon.ObtainNetworkTag(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain network of exit operations file")
+	}
 
 	return c.verifySignedOperations(ctx)
 }
@@ -315,6 +346,11 @@ func (c *command) obtainOperationsFromInput(ctx context.Context) error {
 	if err := json.Unmarshal([]byte(c.signedOperationsInput), &c.signedOperations); err != nil {
 		return errors.Wrap(err, "failed to parse exit operation input")
 	}
+	var err error
+	c.signedOperationsNetwork, err = beacon.ObtainNetworkTag([]byte(c.signedOperationsInput))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain network of exit operation input")
+	}
 
 	return c.verifySignedOperations(ctx)
 }
@@ -371,31 +407,53 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 func (c *command) generateOperationFromAccount(ctx context.Context,
 	account e2wtypes.Account,
 ) error {
-	pubKey, err := util.BestPublicKey(account)
+	// Only an account specifier provides a name to match against composites.
+	accountName := ""
+	if strings.Contains(c.validator, "/") {
+		accountName = c.validator
+	}
+
+	signedOperation, err := c.signedOperationFromAccount(ctx, account, accountName)
 	if err != nil {
 		return err
 	}
+	c.signedOperations = append(c.signedOperations, signedOperation)
+
+	return nil
+}
+
+// signedOperationFromAccount creates a signed exit operation for the validator
+// of the given account.
+func (c *command) signedOperationFromAccount(ctx context.Context,
+	account e2wtypes.Account,
+	accountName string,
+) (
+	*phase0.SignedVoluntaryExit,
+	error,
+) {
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := util.CheckCompositeApprovals(ctx, util.CompositeOperationExit, accountName, pubKey.Marshal(), c.approvals); err != nil {
+		return nil, err
+	}
 
 	info, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%#x", pubKey.Marshal()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	epoch, err := c.selectEpoch()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Using %d for epoch\n", epoch)
 	}
 
-	signedOperation, err := c.createSignedOperation(ctx, info, account, epoch)
-	if err != nil {
-		return err
-	}
-	c.signedOperations = append(c.signedOperations, signedOperation)
-
-	return nil
+	return c.createSignedOperation(ctx, info, account, epoch)
 }
 
 func (c *command) selectEpoch() (phase0.Epoch, error) {
@@ -556,6 +614,28 @@ func (c *command) validateOperation(_ context.Context,
 	return true, ""
 }
 
+// verifyNetwork ensures that the beacon node is on the network for which the
+// operations were generated.
+func (c *command) verifyNetwork(ctx context.Context) error {
+	genesisValidatorsRoot := c.domainGenesisValidatorsRoot
+	if c.signedOperationsNetwork != nil {
+		genesisValidatorsRoot = *c.signedOperationsNetwork
+	}
+
+	err := beacon.VerifyNetwork(ctx, c.consensusClient, genesisValidatorsRoot)
+	if err == nil {
+		return nil
+	}
+	if !c.allowNetworkMismatch {
+		return errors.Wrap(err, "refusing to broadcast (use --allow-network-mismatch to override)")
+	}
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return nil
+}
+
 func (c *command) broadcastOperations(ctx context.Context) error {
 	for _, op := range c.signedOperations {
 		if c.debug {
@@ -614,7 +694,7 @@ func (c *command) generateDomain(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	forkVersion, err := c.obtainForkVersion(ctx)
+	forkVersion, forkVersionSource, err := c.obtainForkVersion(ctx)
 	if err != nil {
 		return err
 	}
@@ -627,10 +707,11 @@ func (c *command) generateDomain(ctx context.Context) error {
 		return errors.Wrap(err, "failed to calculate signature domain")
 	}
 
+	c.domainGenesisValidatorsRoot = genesisValidatorsRoot
 	copy(c.domain[:], c.chainInfo.VoluntaryExitDomainType[:])
 	copy(c.domain[4:], root[:])
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "Signing with fork version %#x (%s); domain is %#x\n", forkVersion, forkVersionSource, c.domain)
 	}
 
 	return nil
@@ -664,8 +745,9 @@ func (c *command) obtainGenesisValidatorsRoot(_ context.Context) (phase0.Root, e
 	return genesisValidatorsRoot, nil
 }
 
-func (c *command) obtainForkVersion(_ context.Context) (phase0.Version, error) {
+func (c *command) obtainForkVersion(_ context.Context) (phase0.Version, string, error) {
 	forkVersion := phase0.Version{}
+	var source string
 
 	if c.forkVersion != "" {
 		if c.debug {
@@ -673,22 +755,28 @@ func (c *command) obtainForkVersion(_ context.Context) (phase0.Version, error) {
 		}
 		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
 		if err != nil {
-			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
+			return phase0.Version{}, "", errors.Wrap(err, "invalid fork version supplied")
 		}
 		if len(version) != phase0.ForkVersionLength {
-			return phase0.Version{}, errors.New("invalid length for fork version")
+			return phase0.Version{}, "", errors.New("invalid length for fork version")
 		}
 		copy(forkVersion[:], version)
+		source = beacon.ExitForkVersionSupplied
 	} else {
 		if c.debug {
 			fmt.Fprintf(os.Stderr, "Fork version obtained from chain info\n")
 		}
-		// Use the current fork version for generating an exit as per the spec.
-		copy(forkVersion[:], c.chainInfo.CurrentForkVersion[:])
+		// Use the exit fork version as per the spec, which is the Capella fork
+		// version from Capella onwards and the current fork version before that.
+		copy(forkVersion[:], c.chainInfo.ExitForkVersion[:])
+		source = beacon.ExitForkVersionCurrent
+		if c.chainInfo.ExitForkVersion != c.chainInfo.CurrentForkVersion {
+			source = beacon.ExitForkVersionCapella
+		}
 	}
 
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Using fork version %#x\n", forkVersion)
 	}
-	return forkVersion, nil
+	return forkVersion, source, nil
 }
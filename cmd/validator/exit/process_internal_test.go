@@ -16,8 +16,10 @@ package validatorexit
 import (
 	"context"
 	"testing"
+	"time"
 
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/mock"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/beacon"
@@ -459,6 +461,7 @@ func TestObtainOperationFromInput(t *testing.T) {
 	tests := []struct {
 		name    string
 		command *command
+		network *phase0.Root
 		err     string
 	}{
 		{
@@ -492,11 +495,146 @@ func TestObtainOperationFromInput(t *testing.T) {
 				chainInfo:             chainInfo,
 			},
 		},
+		{
+			name: "GoodNetworkTag",
+			command: &command{
+				signedOperationsInput: `{"genesis_validators_root":"0x0000000000000000000000000000000000000000000000000000000000000000","message":{"epoch":"1","validator_index":"0"},"signature":"0x89f5c44288f95e19b6c139f2623005665b983462a228120977d81f2ef547560be22446de21a8a937d9dda4e2d2ec4175196496cdd1306dec4a125f8c861f806171504a9d6a610ec4e135047e4fb67052ecc4561360d0c3de04b6fbc4474223ff"}`,
+				chainInfo:             chainInfo,
+			},
+			network: &phase0.Root{},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := test.command.obtainOperationsFromFileOrInput(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.network, test.command.signedOperationsNetwork)
+			}
+		})
+	}
+}
+
+func TestObtainChainInfoFromNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected *beacon.ValidatorInfo
+		err      string
+	}{
+		{
+			name: "NetworkUnknown",
+			command: &command{
+				network:   "unknown",
+				validator: "0",
+			},
+			err: "unknown network unknown; known networks are goerli, holesky, hoodi, mainnet, sepolia",
+		},
+		{
+			name: "ValidatorNotIndex",
+			command: &command{
+				network:   "mainnet",
+				validator: "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87",
+			},
+			err: "validator must be supplied as an index when using bundled network information",
+		},
+		{
+			name: "KeyMissing",
+			command: &command{
+				network:   "mainnet",
+				validator: "0",
+			},
+			err: "private key, or mnemonic and path, required when using bundled network information",
+		},
+		{
+			name: "Good",
+			command: &command{
+				network:   "mainnet",
+				validator: "12345",
+				mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				path:      "m/12381/3600/0/0/0",
+			},
+			expected: &beacon.ValidatorInfo{
+				Index:  12345,
+				Pubkey: phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.obtainChainInfoFromNetwork(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, test.command.chainInfo.Validators, 1)
+				require.Equal(t, test.expected, test.command.chainInfo.Validators[0])
+			}
+		})
+	}
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	ctx := context.Background()
+
+	consensusClient, err := mock.New(ctx, mock.WithGenesisTime(time.Now()))
+	require.NoError(t, err)
+	// Genesis validators root of the mock client.
+	mockRoot := phase0.Root{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f}
+	mainnetRoot := phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95}
+
+	tests := []struct {
+		name    string
+		command *command
+		err     string
+	}{
+		{
+			name: "Match",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mockRoot,
+			},
+		},
+		{
+			name: "Mismatch",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mainnetRoot,
+			},
+			err: "refusing to broadcast (use --allow-network-mismatch to override): operations are for network mainnet but beacon node is on network 0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			name: "SignedOperationsMismatch",
+			command: &command{
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mockRoot,
+				signedOperationsNetwork:     &mainnetRoot,
+			},
+			err: "refusing to broadcast (use --allow-network-mismatch to override): operations are for network mainnet but beacon node is on network 0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{
+			name: "MismatchAllowed",
+			command: &command{
+				quiet:                       true,
+				consensusClient:             consensusClient,
+				domainGenesisValidatorsRoot: mainnetRoot,
+				allowNetworkMismatch:        true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.verifyNetwork(ctx)
 			if test.err != "" {
 				require.EqualError(t, err, test.err)
 			} else {
@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// exitStage is the stage an exit has reached after broadcast.
+type exitStage int
+
+const (
+	exitStageBroadcast exitStage = iota
+	exitStagePooled
+	exitStageIncluded
+	exitStageExiting
+)
+
+// exitProgress tracks the progress of a single exit.
+type exitProgress struct {
+	op        *phase0.SignedVoluntaryExit
+	stage     exitStage
+	slot      phase0.Slot
+	exitEpoch phase0.Epoch
+}
+
+// watchOperations watches the broadcast operations until all validators have
+// an exit epoch, or the watch timeout is reached.
+func (c *command) watchOperations(ctx context.Context) error {
+	progress := make(map[phase0.ValidatorIndex]*exitProgress, len(c.signedOperations))
+	indices := make([]phase0.ValidatorIndex, 0, len(c.signedOperations))
+	for _, op := range c.signedOperations {
+		progress[op.Message.ValidatorIndex] = &exitProgress{
+			op:    op,
+			stage: exitStageBroadcast,
+		}
+		indices = append(indices, op.Message.ValidatorIndex)
+	}
+
+	lastSlot := c.chainTime.CurrentSlot()
+	if lastSlot > 0 {
+		// Start from the previous slot in case the exit is already included.
+		lastSlot--
+	}
+	deadline := time.Now().Add(c.watchTimeout)
+	for {
+		if err := c.watchPool(ctx, progress); err != nil {
+			return err
+		}
+		var err error
+		lastSlot, err = c.watchBlocks(ctx, progress, lastSlot)
+		if err != nil {
+			return err
+		}
+		if err := c.watchValidators(ctx, progress, indices); err != nil {
+			return err
+		}
+
+		complete := true
+		for _, exit := range progress {
+			if exit.stage != exitStageExiting {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			c.watchComplete = true
+			return nil
+		}
+
+		nextSlot := c.chainTime.StartOfSlot(c.chainTime.CurrentSlot() + 1)
+		if nextSlot.After(deadline) {
+			return errors.New("timed out waiting for exits to be confirmed")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(nextSlot)):
+		}
+	}
+}
+
+// watchPool marks exits that are present in the beacon node's operation pool.
+func (c *command) watchPool(ctx context.Context, progress map[phase0.ValidatorIndex]*exitProgress) error {
+	pool := make([]*phase0.SignedVoluntaryExit, 0)
+	if _, err := util.BeaconNodeData(ctx, c.consensusClient, c.timeout, "/eth/v1/beacon/pool/voluntary_exits", &pool); err != nil {
+		// Not all beacon nodes expose their pool, so this is not fatal.
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Failed to obtain voluntary exit pool: %v\n", err)
+		}
+		return nil
+	}
+
+	for _, op := range pool {
+		exit, exists := progress[op.Message.ValidatorIndex]
+		if !exists || exit.stage != exitStageBroadcast {
+			continue
+		}
+		exit.stage = exitStagePooled
+		c.watchEvent(fmt.Sprintf("Validator %d: exit seen in operation pool", op.Message.ValidatorIndex))
+	}
+
+	return nil
+}
+
+// watchBlocks marks exits that are included in blocks since the last slot checked,
+// returning the last slot checked.
+func (c *command) watchBlocks(ctx context.Context,
+	progress map[phase0.ValidatorIndex]*exitProgress,
+	lastSlot phase0.Slot,
+) (
+	phase0.Slot,
+	error,
+) {
+	currentSlot := c.chainTime.CurrentSlot()
+	for slot := lastSlot + 1; slot <= currentSlot; slot++ {
+		block, err := util.ResponseData(c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return lastSlot, errors.Wrap(err, "failed to obtain block")
+		}
+		if block == nil {
+			// Empty slot.
+			lastSlot = slot
+			continue
+		}
+
+		exits, err := blockVoluntaryExits(block)
+		if err != nil {
+			return lastSlot, err
+		}
+		for _, op := range exits {
+			exit, exists := progress[op.Message.ValidatorIndex]
+			if !exists || exit.stage >= exitStageIncluded {
+				continue
+			}
+			exit.stage = exitStageIncluded
+			exit.slot = slot
+			c.watchEvent(fmt.Sprintf("Validator %d: exit included in block at slot %d", op.Message.ValidatorIndex, slot))
+		}
+		lastSlot = slot
+	}
+
+	return lastSlot, nil
+}
+
+// watchValidators marks exits whose validators have obtained an exit epoch.
+func (c *command) watchValidators(ctx context.Context,
+	progress map[phase0.ValidatorIndex]*exitProgress,
+	indices []phase0.ValidatorIndex,
+) error {
+	validatorsResponse, err := c.consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: indices})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := validatorsResponse.Data
+
+	for index, validator := range validators {
+		exit, exists := progress[index]
+		if !exists || exit.stage == exitStageExiting {
+			continue
+		}
+		if validator.Validator == nil || validator.Validator.ExitEpoch == util.FarFutureEpoch {
+			continue
+		}
+		exit.stage = exitStageExiting
+		exit.exitEpoch = validator.Validator.ExitEpoch
+		c.watchEvent(fmt.Sprintf("Validator %d: status %v; exit epoch %d (%s)",
+			index,
+			validator.Status,
+			exit.exitEpoch,
+			c.chainTime.StartOfEpoch(exit.exitEpoch).Format("2006-01-02 15:04:05"),
+		))
+	}
+
+	return nil
+}
+
+// watchEvent reports a watch event.
+func (c *command) watchEvent(msg string) {
+	if c.quiet {
+		return
+	}
+	fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), msg)
+}
+
+// blockVoluntaryExits returns the voluntary exits in a block.
+func blockVoluntaryExits(block *spec.VersionedSignedBeaconBlock) ([]*phase0.SignedVoluntaryExit, error) {
+	exits, err := block.VoluntaryExits()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain voluntary exits")
+	}
+
+	return exits, nil
+}
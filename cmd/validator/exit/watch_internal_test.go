@@ -0,0 +1,104 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockVoluntaryExits(t *testing.T) {
+	exits := []*phase0.SignedVoluntaryExit{
+		{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          1,
+				ValidatorIndex: 2,
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		block *spec.VersionedSignedBeaconBlock
+		exits []*phase0.SignedVoluntaryExit
+		err   string
+	}{
+		{
+			name: "Phase0",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionPhase0,
+				Phase0: &phase0.SignedBeaconBlock{
+					Message: &phase0.BeaconBlock{
+						Body: &phase0.BeaconBlockBody{
+							VoluntaryExits: exits,
+						},
+					},
+				},
+			},
+			exits: exits,
+		},
+		{
+			name: "Capella",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionCapella,
+				Capella: &capella.SignedBeaconBlock{
+					Message: &capella.BeaconBlock{
+						Body: &capella.BeaconBlockBody{
+							VoluntaryExits: exits,
+						},
+					},
+				},
+			},
+			exits: exits,
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Body: &electra.BeaconBlockBody{
+							VoluntaryExits: exits,
+						},
+					},
+				},
+			},
+			exits: exits,
+		},
+		{
+			name: "UnknownVersion",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: 99,
+			},
+			err: "failed to obtain voluntary exits: unknown version",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := blockVoluntaryExits(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.exits, res)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -45,10 +46,11 @@ func (c *command) calculateProposalChance(ctx context.Context) error {
 	// Chance of proposing a block is 1/activeValidators.
 	// Expectation of number of slots before proposing a block is 1/p, == activeValidators slots.
 
-	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return err
 	}
+	spec := specResponse.Data
 
 	tmp, exists := spec["SECONDS_PER_SLOT"]
 	if !exists {
@@ -68,10 +70,11 @@ func (c *command) calculateSyncCommitteeChance(ctx context.Context) error {
 	// Chance of being in a sync committee is SYNC_COMMITTEE_SIZE/activeValidators.
 	// Expectation of number of periods before being in a sync committee is 1/p, activeValidators/SYNC_COMMITTEE_SIZE periods.
 
-	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return err
 	}
+	spec := specResponse.Data
 
 	tmp, exists := spec["SECONDS_PER_SLOT"]
 	if !exists {
@@ -148,10 +151,11 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.New("connection does not provide validator information")
 	}
 
-	validators, err := c.validatorsProvider.Validators(ctx, "head", nil)
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: "head"})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
 	}
+	validators := validatorsResponse.Data
 
 	currentEpoch := chainTime.CurrentEpoch()
 	for _, validator := range validators {
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormigratecheck
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	validators []string
+	fromHost   string
+	toHost     string
+	epochs     uint64
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	consensusClient    consensusclient.Service
+	chainTime          chaintime.Service
+	validatorsProvider consensusclient.ValidatorsProvider
+	genesisProvider    consensusclient.GenesisProvider
+
+	// Output.
+	validatorIndices []phase0.ValidatorIndex
+	currentEpoch     phase0.Epoch
+	checks           []*check
+	ready            bool
+}
+
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validators:               viper.GetStringSlice("validators"),
+		fromHost:                 viper.GetString("from-host"),
+		toHost:                   viper.GetString("to-host"),
+		epochs:                   viper.GetUint64("epochs"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	if c.fromHost == "" {
+		return nil, errors.New("from-host is required")
+	}
+	if c.toHost == "" {
+		return nil, errors.New("to-host is required")
+	}
+
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormigratecheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Validators []string `json:"validators"`
+	Epoch      string   `json:"epoch"`
+	Ready      bool     `json:"ready"`
+	Checks     []*check `json:"checks"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	validators := make([]string, len(c.validatorIndices))
+	for i := range c.validatorIndices {
+		validators[i] = fmt.Sprintf("%d", c.validatorIndices[i])
+	}
+	data, err := json.Marshal(&jsonOutput{
+		Validators: validators,
+		Epoch:      fmt.Sprintf("%d", c.currentEpoch),
+		Ready:      c.ready,
+		Checks:     c.checks,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", len(c.validatorIndices)))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Current epoch: %d\n", c.currentEpoch))
+	}
+	for _, check := range c.checks {
+		result := "PASS"
+		if !check.Passed {
+			result = "FAIL"
+		}
+		builder.WriteString(fmt.Sprintf("  [%s] %s: %s\n", result, check.Name, check.Detail))
+	}
+	if c.ready {
+		builder.WriteString("Result: safe to start new validator client\n")
+	} else {
+		builder.WriteString("Result: not safe to start new validator client\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
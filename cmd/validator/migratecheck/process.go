@@ -0,0 +1,276 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormigratecheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// digester provides digests of slashing protection histories.
+type digester interface {
+	Digest(pubkey phase0.BLSPubKey) (phase0.Root, bool)
+}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return err
+	}
+	pubkeys := make(map[phase0.ValidatorIndex]phase0.BLSPubKey, len(validators))
+	for _, validator := range validators {
+		if _, exists := pubkeys[validator.Index]; exists {
+			continue
+		}
+		pubkeys[validator.Index] = validator.Validator.PublicKey
+		c.validatorIndices = append(c.validatorIndices, validator.Index)
+	}
+	sort.Slice(c.validatorIndices, func(i int, j int) bool {
+		return c.validatorIndices[i] < c.validatorIndices[j]
+	})
+
+	c.currentEpoch = c.chainTime.CurrentEpoch()
+	if uint64(c.currentEpoch) < c.epochs {
+		return fmt.Errorf("chain has not yet reached epoch %d", c.epochs)
+	}
+	firstEpoch := c.currentEpoch - phase0.Epoch(c.epochs)
+
+	liveness := make(map[phase0.Epoch]map[phase0.ValidatorIndex]bool)
+	for epoch := firstEpoch; epoch <= c.currentEpoch; epoch++ {
+		epochLiveness, found, err := util.ValidatorLiveness(ctx, c.consensusClient, c.timeout, epoch, c.validatorIndices)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain validator liveness for epoch %d", epoch))
+		}
+		if !found {
+			return errors.New("connection does not provide validator liveness")
+		}
+		liveness[epoch] = epochLiveness
+	}
+
+	genesisResponse, err := c.genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis information")
+	}
+	genesis := genesisResponse.Data
+	fromHost, err := interchange.New(ctx,
+		interchange.WithSource(c.fromHost),
+		interchange.WithTimeout(c.timeout),
+		interchange.WithGenesisValidatorsRoot(genesis.GenesisValidatorsRoot),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain slashing protection data from old host")
+	}
+	toHost, err := interchange.New(ctx,
+		interchange.WithSource(c.toHost),
+		interchange.WithTimeout(c.timeout),
+		interchange.WithGenesisValidatorsRoot(genesis.GenesisValidatorsRoot),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain slashing protection data from new host")
+	}
+
+	c.checks = []*check{
+		stoppedCheck(c.validatorIndices, liveness, firstEpoch, c.currentEpoch-1),
+		slashingProtectionCheck(c.validatorIndices, pubkeys, fromHost, toHost),
+		doppelgangerCheck(c.validatorIndices, liveness, c.currentEpoch),
+	}
+
+	c.ready = true
+	for _, check := range c.checks {
+		if !check.Passed {
+			c.ready = false
+		}
+	}
+
+	return nil
+}
+
+// stoppedCheck confirms that none of the validators were live in the given
+// range of epochs, showing that the old validator client has stopped.
+func stoppedCheck(validators []phase0.ValidatorIndex,
+	liveness map[phase0.Epoch]map[phase0.ValidatorIndex]bool,
+	firstEpoch phase0.Epoch,
+	lastEpoch phase0.Epoch,
+) *check {
+	res := &check{
+		Name:   "old validator client stopped",
+		Passed: true,
+	}
+
+	problems := make([]string, 0)
+	for epoch := firstEpoch; epoch <= lastEpoch; epoch++ {
+		if live := liveValidators(validators, liveness[epoch]); len(live) > 0 {
+			problems = append(problems, fmt.Sprintf("%s active in epoch %d", describeValidators(live), epoch))
+		}
+	}
+	if len(problems) > 0 {
+		res.Passed = false
+		res.Detail = strings.Join(problems, "; ")
+		return res
+	}
+
+	if firstEpoch == lastEpoch {
+		res.Detail = fmt.Sprintf("no activity from %d validators in epoch %d", len(validators), firstEpoch)
+	} else {
+		res.Detail = fmt.Sprintf("no activity from %d validators in epochs %d-%d", len(validators), firstEpoch, lastEpoch)
+	}
+
+	return res
+}
+
+// slashingProtectionCheck confirms that the slashing protection history for
+// each validator on the new host matches that exported from the old host.
+func slashingProtectionCheck(validators []phase0.ValidatorIndex,
+	pubkeys map[phase0.ValidatorIndex]phase0.BLSPubKey,
+	fromHost digester,
+	toHost digester,
+) *check {
+	res := &check{
+		Name:   "slashing protection migrated",
+		Passed: true,
+	}
+
+	missingFrom := make([]phase0.ValidatorIndex, 0)
+	missingTo := make([]phase0.ValidatorIndex, 0)
+	mismatched := make([]phase0.ValidatorIndex, 0)
+	for _, index := range validators {
+		fromDigest, fromFound := fromHost.Digest(pubkeys[index])
+		toDigest, toFound := toHost.Digest(pubkeys[index])
+		switch {
+		case !fromFound:
+			missingFrom = append(missingFrom, index)
+		case !toFound:
+			missingTo = append(missingTo, index)
+		case fromDigest != toDigest:
+			mismatched = append(mismatched, index)
+		}
+	}
+
+	problems := make([]string, 0)
+	if len(missingFrom) > 0 {
+		problems = append(problems, fmt.Sprintf("old host has no data for %s", describeValidators(missingFrom)))
+	}
+	if len(missingTo) > 0 {
+		problems = append(problems, fmt.Sprintf("new host has no data for %s", describeValidators(missingTo)))
+	}
+	if len(mismatched) > 0 {
+		problems = append(problems, fmt.Sprintf("data for %s differs between old and new hosts", describeValidators(mismatched)))
+	}
+	if len(problems) > 0 {
+		res.Passed = false
+		res.Detail = strings.Join(problems, "; ")
+		return res
+	}
+
+	res.Detail = fmt.Sprintf("slashing protection for %d validators matches between old and new hosts", len(validators))
+
+	return res
+}
+
+// doppelgangerCheck confirms that none of the validators are live in the
+// current epoch, so no other instance is signing with their keys.
+func doppelgangerCheck(validators []phase0.ValidatorIndex,
+	liveness map[phase0.Epoch]map[phase0.ValidatorIndex]bool,
+	epoch phase0.Epoch,
+) *check {
+	res := &check{
+		Name:   "doppelganger",
+		Passed: true,
+	}
+
+	if live := liveValidators(validators, liveness[epoch]); len(live) > 0 {
+		res.Passed = false
+		res.Detail = fmt.Sprintf("%s active in current epoch %d", describeValidators(live), epoch)
+		return res
+	}
+
+	res.Detail = fmt.Sprintf("no activity from %d validators in current epoch %d", len(validators), epoch)
+
+	return res
+}
+
+func liveValidators(validators []phase0.ValidatorIndex,
+	liveness map[phase0.ValidatorIndex]bool,
+) []phase0.ValidatorIndex {
+	res := make([]phase0.ValidatorIndex, 0)
+	for _, index := range validators {
+		if liveness[index] {
+			res = append(res, index)
+		}
+	}
+
+	return res
+}
+
+func describeValidators(validators []phase0.ValidatorIndex) string {
+	indices := make([]string, len(validators))
+	for i := range validators {
+		indices[i] = fmt.Sprintf("%d", validators[i])
+	}
+	if len(indices) == 1 {
+		return fmt.Sprintf("validator %s", indices[0])
+	}
+
+	return fmt.Sprintf("validators %s", strings.Join(indices, ", "))
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.genesisProvider, isProvider = c.consensusClient.(consensusclient.GenesisProvider)
+	if !isProvider {
+		return errors.New("connection does not provide genesis information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatormigratecheck
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+type testDigester struct {
+	digests map[phase0.BLSPubKey]phase0.Root
+}
+
+func (d *testDigester) Digest(pubkey phase0.BLSPubKey) (phase0.Root, bool) {
+	digest, exists := d.digests[pubkey]
+	return digest, exists
+}
+
+func TestStoppedCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		liveness map[phase0.Epoch]map[phase0.ValidatorIndex]bool
+		first    phase0.Epoch
+		last     phase0.Epoch
+		passed   bool
+		detail   string
+	}{
+		{
+			name: "Stopped",
+			liveness: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+				10: {1: false, 2: false},
+				11: {1: false, 2: false},
+			},
+			first:  10,
+			last:   11,
+			passed: true,
+			detail: "no activity from 2 validators in epochs 10-11",
+		},
+		{
+			name: "SingleEpoch",
+			liveness: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+				11: {1: false, 2: false},
+			},
+			first:  11,
+			last:   11,
+			passed: true,
+			detail: "no activity from 2 validators in epoch 11",
+		},
+		{
+			name: "Active",
+			liveness: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+				10: {1: true, 2: true},
+				11: {1: false, 2: true},
+			},
+			first:  10,
+			last:   11,
+			detail: "validators 1, 2 active in epoch 10; validator 2 active in epoch 11",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := stoppedCheck([]phase0.ValidatorIndex{1, 2}, test.liveness, test.first, test.last)
+			require.Equal(t, test.passed, res.Passed)
+			require.Equal(t, test.detail, res.Detail)
+		})
+	}
+}
+
+func TestSlashingProtectionCheck(t *testing.T) {
+	pubkeys := map[phase0.ValidatorIndex]phase0.BLSPubKey{
+		1: {0x01},
+		2: {0x02},
+		3: {0x03},
+	}
+
+	tests := []struct {
+		name   string
+		from   map[phase0.BLSPubKey]phase0.Root
+		to     map[phase0.BLSPubKey]phase0.Root
+		passed bool
+		detail string
+	}{
+		{
+			name: "Match",
+			from: map[phase0.BLSPubKey]phase0.Root{{0x01}: {0x11}, {0x02}: {0x12}, {0x03}: {0x13}},
+			to: map[phase0.BLSPubKey]phase0.Root{
+				{0x01}: {0x11},
+				{0x02}: {0x12},
+				{0x03}: {0x13},
+				{0x04}: {0x14},
+			},
+			passed: true,
+			detail: "slashing protection for 3 validators matches between old and new hosts",
+		},
+		{
+			name:   "Problems",
+			from:   map[phase0.BLSPubKey]phase0.Root{{0x02}: {0x12}, {0x03}: {0x13}},
+			to:     map[phase0.BLSPubKey]phase0.Root{{0x01}: {0x11}, {0x03}: {0x23}},
+			detail: "old host has no data for validator 1; new host has no data for validator 2; data for validator 3 differs between old and new hosts",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := slashingProtectionCheck([]phase0.ValidatorIndex{1, 2, 3},
+				pubkeys,
+				&testDigester{digests: test.from},
+				&testDigester{digests: test.to},
+			)
+			require.Equal(t, test.passed, res.Passed)
+			require.Equal(t, test.detail, res.Detail)
+		})
+	}
+}
+
+func TestDoppelgangerCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		liveness map[phase0.Epoch]map[phase0.ValidatorIndex]bool
+		passed   bool
+		detail   string
+	}{
+		{
+			name: "Clear",
+			liveness: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+				12: {1: false, 2: false},
+			},
+			passed: true,
+			detail: "no activity from 2 validators in current epoch 12",
+		},
+		{
+			name: "Active",
+			liveness: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+				12: {1: true, 2: false},
+			},
+			detail: "validator 1 active in current epoch 12",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := doppelgangerCheck([]phase0.ValidatorIndex{1, 2}, test.liveness, 12)
+			require.Equal(t, test.passed, res.Passed)
+			require.Equal(t, test.detail, res.Detail)
+		})
+	}
+}
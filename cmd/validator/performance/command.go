@@ -0,0 +1,244 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	fromEpoch  string
+	toEpoch    string
+	window     time.Duration
+	series     bool
+	slaTarget  float64
+	groups     []*groupInput
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	validatorsProvider         eth2client.ValidatorsProvider
+	proposerDutiesProvider     eth2client.ProposerDutiesProvider
+	attesterDutiesProvider     eth2client.AttesterDutiesProvider
+	syncCommitteesProvider     eth2client.SyncCommitteesProvider
+	blocksProvider             eth2client.SignedBeaconBlockProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Processing.
+	validatorsByIndex map[phase0.ValidatorIndex]*apiv1.Validator
+	blocksCache       map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	syncCommittees    map[uint64][]phase0.ValidatorIndex
+	committeeSizes    *util.BeaconCommitteeSizeCache
+	validatorTotals   map[phase0.ValidatorIndex]*performance
+	groupIndices      map[string][]phase0.ValidatorIndex
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	FromEpoch  uint64                  `json:"from_epoch"`
+	ToEpoch    uint64                  `json:"to_epoch"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+	Totals     *performance            `json:"totals"`
+	Epochs     []*epochPerformance     `json:"epochs,omitempty"`
+	SLA        *slaReport              `json:"sla,omitempty"`
+}
+
+// groupInput is a named group of validators.
+type groupInput struct {
+	name       string
+	validators []string
+}
+
+// slaReport is the compliance of validators, individually and in their groups,
+// with a target attestation inclusion rate over the range of epochs.
+type slaReport struct {
+	Target float64 `json:"target"`
+	// Compliant is true if all validators meet the target.
+	Compliant  bool            `json:"compliant"`
+	Validators []*validatorSLA `json:"validators"`
+	Groups     []*groupSLA     `json:"groups,omitempty"`
+}
+
+type validatorSLA struct {
+	Index     phase0.ValidatorIndex `json:"index"`
+	Compliant bool                  `json:"compliant"`
+	performance
+}
+
+type groupSLA struct {
+	Name       string                  `json:"name"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+	Compliant  bool                    `json:"compliant"`
+	performance
+}
+
+// performance is the performance of the validators over one or more epochs.
+type performance struct {
+	AttestationsExpected int `json:"attestations_expected"`
+	AttestationsIncluded int `json:"attestations_included"`
+	// AttestationHitRate is the proportion of expected attestations that were included.
+	AttestationHitRate float64 `json:"attestation_hit_rate"`
+	// InclusionDistance is the sum of the inclusion distances of included
+	// attestations, used to calculate the average.
+	InclusionDistance        uint64  `json:"-"`
+	AverageInclusionDistance float64 `json:"average_inclusion_distance"`
+	HeadCorrect              int     `json:"head_correct"`
+	TargetCorrect            int     `json:"target_correct"`
+	SourceTimely             int     `json:"source_timely"`
+	ProposalsExpected        int     `json:"proposals_expected"`
+	Proposals                int     `json:"proposals"`
+	MissedProposals          int     `json:"missed_proposals"`
+	SyncCommitteeExpected    int     `json:"sync_committee_expected"`
+	SyncCommitteeIncluded    int     `json:"sync_committee_included"`
+	// SyncParticipation is the proportion of expected sync committee
+	// contributions that were included.
+	SyncParticipation float64 `json:"sync_participation"`
+}
+
+type epochPerformance struct {
+	Epoch uint64 `json:"epoch"`
+	performance
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:             viper.GetBool("quiet"),
+		verbose:           viper.GetBool("verbose"),
+		debug:             viper.GetBool("debug"),
+		validatorsByIndex: make(map[phase0.ValidatorIndex]*apiv1.Validator),
+		blocksCache:       make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock),
+		syncCommittees:    make(map[uint64][]phase0.ValidatorIndex),
+		validatorTotals:   make(map[phase0.ValidatorIndex]*performance),
+		groupIndices:      make(map[string][]phase0.ValidatorIndex),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	for _, group := range viper.GetStringSlice("group") {
+		input, err := parseGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range c.groups {
+			if existing.name == input.name {
+				return nil, fmt.Errorf("duplicate group %q", input.name)
+			}
+		}
+		c.groups = append(c.groups, input)
+	}
+	if len(c.validators) == 0 && len(c.groups) == 0 {
+		return nil, errors.New("validators are required")
+	}
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	if viper.GetString("window") != "" {
+		var err error
+		c.window, err = parseWindow(viper.GetString("window"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.window > 0 && c.fromEpoch != "" {
+		return nil, errors.New("from epoch cannot be supplied with window")
+	}
+	c.series = viper.GetBool("series")
+	c.slaTarget = viper.GetFloat64("sla")
+	if c.slaTarget < 0 || c.slaTarget > 1 {
+		return nil, errors.New("SLA must be between 0 and 1")
+	}
+	if len(c.groups) > 0 && c.slaTarget == 0 {
+		return nil, errors.New("groups require an SLA")
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// parseWindow parses a window, either as a number of days (e.g. "30d") or a
+// duration (e.g. "12h").
+func parseWindow(input string) (time.Duration, error) {
+	var window time.Duration
+	if strings.HasSuffix(input, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(input, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", input)
+		}
+		window = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		window, err = time.ParseDuration(input)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q", input)
+		}
+	}
+	if window <= 0 {
+		return 0, errors.New("window must be positive")
+	}
+
+	return window, nil
+}
+
+// parseGroup parses a group of the form name=validator[,validator...].
+func parseGroup(input string) (*groupInput, error) {
+	name, validators, found := strings.Cut(input, "=")
+	if !found || name == "" || validators == "" {
+		return nil, fmt.Errorf("invalid group %q", input)
+	}
+
+	return &groupInput{
+		name:       name,
+		validators: strings.Split(validators, ","),
+	}, nil
+}
@@ -0,0 +1,160 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validators": []string{"1"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "validators are required",
+		},
+		{
+			name: "OutputInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"output":     "bad",
+			},
+			err: `unsupported output format "bad"`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1", "2"},
+			},
+		},
+		{
+			name: "GoodRange",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"from-epoch": "100",
+				"to-epoch":   "200",
+				"series":     true,
+				"output":     "csv",
+			},
+		},
+		{
+			name: "GroupInvalid",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"group":   []string{"1,2"},
+				"sla":     0.999,
+			},
+			err: `invalid group "1,2"`,
+		},
+		{
+			name: "GroupDuplicate",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"group":   []string{"a=1,2", "a=3"},
+				"sla":     0.999,
+			},
+			err: `duplicate group "a"`,
+		},
+		{
+			name: "GroupWithoutSLA",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"group":   []string{"a=1,2"},
+			},
+			err: "groups require an SLA",
+		},
+		{
+			name: "SLAInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"sla":        99.9,
+			},
+			err: "SLA must be between 0 and 1",
+		},
+		{
+			name: "WindowInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"window":     "xd",
+			},
+			err: `invalid window "xd"`,
+		},
+		{
+			name: "WindowZero",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"window":     "0d",
+			},
+			err: "window must be positive",
+		},
+		{
+			name: "WindowWithFromEpoch",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"window":     "30d",
+				"from-epoch": "100",
+			},
+			err: "from epoch cannot be supplied with window",
+		},
+		{
+			name: "GoodSLA",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"group":   []string{"a=1,2", "b=3"},
+				"sla":     0.999,
+				"window":  "30d",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
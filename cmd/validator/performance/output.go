@@ -0,0 +1,187 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the performance as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// CSVHeader returns the header of the CSV output.
+func (*command) CSVHeader() []string {
+	return []string{
+		"from_epoch",
+		"to_epoch",
+		"attestations_expected",
+		"attestations_included",
+		"attestation_hit_rate",
+		"average_inclusion_distance",
+		"head_correct",
+		"target_correct",
+		"source_timely",
+		"proposals_expected",
+		"proposals",
+		"missed_proposals",
+		"sync_committee_expected",
+		"sync_committee_included",
+		"sync_participation",
+	}
+}
+
+// CSVRecords returns the performance as CSV.  If a time series was requested
+// there is a record for each epoch, otherwise a single record for the range.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	if !c.series {
+		return [][]string{csvRecord(phase0.Epoch(c.results.FromEpoch), phase0.Epoch(c.results.ToEpoch), c.results.Totals)}, nil
+	}
+
+	records := make([][]string, 0, len(c.results.Epochs))
+	for _, epoch := range c.results.Epochs {
+		records = append(records, csvRecord(phase0.Epoch(epoch.Epoch), phase0.Epoch(epoch.Epoch), &epoch.performance))
+	}
+
+	return records, nil
+}
+
+func csvRecord(fromEpoch phase0.Epoch, toEpoch phase0.Epoch, performance *performance) []string {
+	return []string{
+		fmt.Sprintf("%d", fromEpoch),
+		fmt.Sprintf("%d", toEpoch),
+		fmt.Sprintf("%d", performance.AttestationsExpected),
+		fmt.Sprintf("%d", performance.AttestationsIncluded),
+		fmt.Sprintf("%.4f", performance.AttestationHitRate),
+		fmt.Sprintf("%.4f", performance.AverageInclusionDistance),
+		fmt.Sprintf("%d", performance.HeadCorrect),
+		fmt.Sprintf("%d", performance.TargetCorrect),
+		fmt.Sprintf("%d", performance.SourceTimely),
+		fmt.Sprintf("%d", performance.ProposalsExpected),
+		fmt.Sprintf("%d", performance.Proposals),
+		fmt.Sprintf("%d", performance.MissedProposals),
+		fmt.Sprintf("%d", performance.SyncCommitteeExpected),
+		fmt.Sprintf("%d", performance.SyncCommitteeIncluded),
+		fmt.Sprintf("%.4f", performance.SyncParticipation),
+	}
+}
+
+// RenderText renders the performance as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.FromEpoch == c.results.ToEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d", c.results.FromEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d to %d", c.results.FromEpoch, c.results.ToEpoch))
+	}
+	if len(c.results.Validators) == 1 {
+		builder.WriteString(fmt.Sprintf(", validator %d:\n", c.results.Validators[0]))
+	} else {
+		builder.WriteString(fmt.Sprintf(", %d validators:\n", len(c.results.Validators)))
+	}
+
+	totals := c.results.Totals
+	builder.WriteString(fmt.Sprintf("  Attestations: %d/%d included (%.2f%%)\n", totals.AttestationsIncluded, totals.AttestationsExpected, 100*totals.AttestationHitRate))
+	if totals.AttestationsIncluded > 0 {
+		builder.WriteString(fmt.Sprintf("  Average inclusion distance: %.2f\n", totals.AverageInclusionDistance))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Correct head votes: %d\n", totals.HeadCorrect))
+			builder.WriteString(fmt.Sprintf("  Correct target votes: %d\n", totals.TargetCorrect))
+			builder.WriteString(fmt.Sprintf("  Timely source votes: %d\n", totals.SourceTimely))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("  Proposals: %d/%d (%d missed)\n", totals.Proposals, totals.ProposalsExpected, totals.MissedProposals))
+	if totals.SyncCommitteeExpected > 0 {
+		builder.WriteString(fmt.Sprintf("  Sync committee: %d/%d included (%.2f%%)\n", totals.SyncCommitteeIncluded, totals.SyncCommitteeExpected, 100*totals.SyncParticipation))
+	}
+
+	if c.results.SLA != nil {
+		c.renderSLA(&builder)
+	}
+
+	for _, epoch := range c.results.Epochs {
+		builder.WriteString(fmt.Sprintf("Epoch %d: attestations %d/%d", epoch.Epoch, epoch.AttestationsIncluded, epoch.AttestationsExpected))
+		if epoch.AttestationsIncluded > 0 {
+			builder.WriteString(fmt.Sprintf(" (inclusion distance %.2f)", epoch.AverageInclusionDistance))
+		}
+		if epoch.ProposalsExpected > 0 {
+			builder.WriteString(fmt.Sprintf(", proposals %d/%d", epoch.Proposals, epoch.ProposalsExpected))
+		}
+		if epoch.SyncCommitteeExpected > 0 {
+			builder.WriteString(fmt.Sprintf(", sync committee %d/%d", epoch.SyncCommitteeIncluded, epoch.SyncCommitteeExpected))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// renderSLA renders the SLA report.  Validators that do not meet the target
+// are always listed; those that do are listed only with verbose output.
+func (c *command) renderSLA(builder *strings.Builder) {
+	sla := c.results.SLA
+	compliant := 0
+	for _, validator := range sla.Validators {
+		if validator.Compliant {
+			compliant++
+		}
+	}
+	builder.WriteString(fmt.Sprintf("SLA target of %.2f%% attestations included: %d/%d validators compliant\n", 100*sla.Target, compliant, len(sla.Validators)))
+	for _, validator := range sla.Validators {
+		if validator.Compliant && !c.verbose {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("  Validator %d: %d/%d included (%.2f%%), %s\n",
+			validator.Index,
+			validator.AttestationsIncluded,
+			validator.AttestationsExpected,
+			100*validator.AttestationHitRate,
+			complianceString(validator.Compliant),
+		))
+	}
+	for _, group := range sla.Groups {
+		builder.WriteString(fmt.Sprintf("  Group %s (%d validators): %d/%d included (%.2f%%), %s\n",
+			group.Name,
+			len(group.Validators),
+			group.AttestationsIncluded,
+			group.AttestationsExpected,
+			100*group.AttestationHitRate,
+			complianceString(group.Compliant),
+		))
+	}
+}
+
+func complianceString(compliant bool) string {
+	if compliant {
+		return "compliant"
+	}
+
+	return "non-compliant"
+}
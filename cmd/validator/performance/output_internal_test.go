@@ -0,0 +1,180 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutput(t *testing.T) {
+	epoch1 := &epochPerformance{
+		Epoch: 100,
+		performance: performance{
+			AttestationsExpected:     2,
+			AttestationsIncluded:     2,
+			AttestationHitRate:       1,
+			InclusionDistance:        3,
+			AverageInclusionDistance: 1.5,
+			HeadCorrect:              1,
+			TargetCorrect:            2,
+			SourceTimely:             2,
+			ProposalsExpected:        1,
+			Proposals:                1,
+		},
+	}
+	epoch2 := &epochPerformance{
+		Epoch: 101,
+		performance: performance{
+			AttestationsExpected: 2,
+			AttestationsIncluded: 0,
+			ProposalsExpected:    1,
+			MissedProposals:      1,
+		},
+	}
+	totals := &performance{
+		AttestationsExpected:     4,
+		AttestationsIncluded:     2,
+		AttestationHitRate:       0.5,
+		InclusionDistance:        3,
+		AverageInclusionDistance: 1.5,
+		HeadCorrect:              1,
+		TargetCorrect:            2,
+		SourceTimely:             2,
+		ProposalsExpected:        2,
+		Proposals:                1,
+		MissedProposals:          1,
+	}
+
+	tests := []struct {
+		name   string
+		c      *command
+		format output.Format
+		res    string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+			res: "",
+		},
+		{
+			name: "Text",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1, 2},
+					Totals:     totals,
+				},
+			},
+			format: output.Text,
+			res:    "Epochs 100 to 101, 2 validators:\n  Attestations: 2/4 included (50.00%)\n  Average inclusion distance: 1.50\n  Proposals: 1/2 (1 missed)",
+		},
+		{
+			name: "TextSeries",
+			c: &command{
+				verbose: true,
+				series:  true,
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1},
+					Totals:     totals,
+					Epochs:     []*epochPerformance{epoch1, epoch2},
+				},
+			},
+			format: output.Text,
+			res:    "Epochs 100 to 101, validator 1:\n  Attestations: 2/4 included (50.00%)\n  Average inclusion distance: 1.50\n  Correct head votes: 1\n  Correct target votes: 2\n  Timely source votes: 2\n  Proposals: 1/2 (1 missed)\nEpoch 100: attestations 2/2 (inclusion distance 1.50), proposals 1/1\nEpoch 101: attestations 0/2, proposals 0/1",
+		},
+		{
+			name: "TextSLA",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1, 2},
+					Totals:     totals,
+					SLA: &slaReport{
+						Target: 0.999,
+						Validators: []*validatorSLA{
+							{Index: 1, Compliant: true, performance: epoch1.performance},
+							{Index: 2, performance: epoch2.performance},
+						},
+						Groups: []*groupSLA{
+							{Name: "a", Validators: []phase0.ValidatorIndex{1, 2}, performance: *totals},
+						},
+					},
+				},
+			},
+			format: output.Text,
+			res:    "Epochs 100 to 101, 2 validators:\n  Attestations: 2/4 included (50.00%)\n  Average inclusion distance: 1.50\n  Proposals: 1/2 (1 missed)\nSLA target of 99.90% attestations included: 1/2 validators compliant\n  Validator 2: 0/2 included (0.00%), non-compliant\n  Group a (2 validators): 2/4 included (50.00%), non-compliant",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				results: &results{
+					FromEpoch:  101,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1},
+					Totals:     &epoch2.performance,
+				},
+			},
+			format: output.JSON,
+			res:    `{"from_epoch":101,"to_epoch":101,"validators":["1"],"totals":{"attestations_expected":2,"attestations_included":0,"attestation_hit_rate":0,"average_inclusion_distance":0,"head_correct":0,"target_correct":0,"source_timely":0,"proposals_expected":1,"proposals":0,"missed_proposals":1,"sync_committee_expected":0,"sync_committee_included":0,"sync_participation":0}}`,
+		},
+		{
+			name: "CSVSeries",
+			c: &command{
+				series: true,
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1},
+					Totals:     totals,
+					Epochs:     []*epochPerformance{epoch1, epoch2},
+				},
+			},
+			format: output.CSV,
+			res:    "from_epoch,to_epoch,attestations_expected,attestations_included,attestation_hit_rate,average_inclusion_distance,head_correct,target_correct,source_timely,proposals_expected,proposals,missed_proposals,sync_committee_expected,sync_committee_included,sync_participation\n100,100,2,2,1.0000,1.5000,1,2,2,1,1,0,0,0,0.0000\n101,101,2,0,0.0000,0.0000,0,0,0,1,0,1,0,0,0.0000",
+		},
+		{
+			name: "CSVTotals",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []phase0.ValidatorIndex{1},
+					Totals:     totals,
+				},
+			},
+			format: output.CSV,
+			res:    "from_epoch,to_epoch,attestations_expected,attestations_included,attestation_hit_rate,average_inclusion_distance,head_correct,target_correct,source_timely,proposals_expected,proposals,missed_proposals,sync_committee_expected,sync_committee_included,sync_participation\n100,101,4,2,0.5000,1.5000,1,2,2,2,1,1,0,0,0.0000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.format = test.format
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,538 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultToEpoch is the latest epoch for which all attestations have
+// generally been included.
+const defaultToEpoch = "-2"
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	toEpochStr := c.toEpoch
+	if toEpochStr == "" {
+		toEpochStr = defaultToEpoch
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, toEpochStr)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	fromEpoch := toEpoch
+	if c.window > 0 {
+		epochs := phase0.Epoch(c.window / (c.chainTime.SlotDuration() * time.Duration(c.chainTime.SlotsPerEpoch())))
+		if epochs == 0 {
+			return errors.New("window must be at least one epoch")
+		}
+		if epochs > toEpoch {
+			epochs = toEpoch + 1
+		}
+		fromEpoch = toEpoch + 1 - epochs
+	}
+	if c.fromEpoch != "" {
+		fromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse from epoch")
+		}
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch cannot be before from epoch")
+	}
+
+	if len(c.validators) > 0 {
+		if _, err := c.parseValidators(ctx, c.validators); err != nil {
+			return err
+		}
+	}
+	for _, group := range c.groups {
+		indices, err := c.parseValidators(ctx, group.validators)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to parse validators for group %s", group.name))
+		}
+		c.groupIndices[group.name] = indices
+	}
+
+	c.results = &results{
+		FromEpoch:  uint64(fromEpoch),
+		ToEpoch:    uint64(toEpoch),
+		Validators: make([]phase0.ValidatorIndex, 0, len(c.validatorsByIndex)),
+		Totals:     &performance{},
+	}
+	for index := range c.validatorsByIndex {
+		c.results.Validators = append(c.results.Validators, index)
+	}
+	sort.Slice(c.results.Validators, func(i int, j int) bool {
+		return c.results.Validators[i] < c.results.Validators[j]
+	})
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		epochPerformance, err := c.processEpoch(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		c.results.Totals.add(&epochPerformance.performance)
+		if c.series {
+			epochPerformance.finalise()
+			c.results.Epochs = append(c.results.Epochs, epochPerformance)
+		}
+	}
+	c.results.Totals.finalise()
+
+	if c.slaTarget > 0 {
+		c.results.SLA = c.slaReport()
+	}
+
+	return nil
+}
+
+// parseValidators parses the validator specifiers, adding the validators to
+// those for which performance is calculated and returning their indices.
+func (c *command) parseValidators(ctx context.Context, validatorSpecifiers []string) ([]phase0.ValidatorIndex, error) {
+	specifiers, err := util.ExpandWallets(ctx, validatorSpecifiers)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, specifiers, "head")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return nil, errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		c.validatorsByIndex[validator.Index] = validator
+		indices = append(indices, validator.Index)
+	}
+	sort.Slice(indices, func(i int, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	return indices, nil
+}
+
+// slaReport builds the report of compliance with the SLA target from the
+// performance of the individual validators.
+func (c *command) slaReport() *slaReport {
+	report := &slaReport{
+		Target:     c.slaTarget,
+		Compliant:  true,
+		Validators: make([]*validatorSLA, 0, len(c.results.Validators)),
+	}
+	for _, index := range c.results.Validators {
+		validatorPerformance := c.validatorPerformance(index)
+		validatorPerformance.finalise()
+		compliant := c.meetsSLA(validatorPerformance)
+		if !compliant {
+			report.Compliant = false
+		}
+		report.Validators = append(report.Validators, &validatorSLA{
+			Index:       index,
+			Compliant:   compliant,
+			performance: *validatorPerformance,
+		})
+	}
+
+	for _, group := range c.groups {
+		indices := c.groupIndices[group.name]
+		groupPerformance := &performance{}
+		for _, index := range indices {
+			groupPerformance.add(c.validatorPerformance(index))
+		}
+		groupPerformance.finalise()
+		report.Groups = append(report.Groups, &groupSLA{
+			Name:        group.name,
+			Validators:  indices,
+			Compliant:   c.meetsSLA(groupPerformance),
+			performance: *groupPerformance,
+		})
+	}
+
+	return report
+}
+
+// meetsSLA returns true if the performance meets the SLA target.  Validators
+// without attestation duties in the range are considered to meet the target.
+func (c *command) meetsSLA(performance *performance) bool {
+	if performance.AttestationsExpected == 0 {
+		return true
+	}
+
+	return performance.AttestationHitRate >= c.slaTarget
+}
+
+// validatorPerformance returns the performance of the validator over the
+// epochs processed so far.
+func (c *command) validatorPerformance(index phase0.ValidatorIndex) *performance {
+	res, exists := c.validatorTotals[index]
+	if !exists {
+		res = &performance{}
+		c.validatorTotals[index] = res
+	}
+
+	return res
+}
+
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) (*epochPerformance, error) {
+	res := &epochPerformance{
+		Epoch: uint64(epoch),
+	}
+
+	// Drop blocks that are no longer required.
+	for slot := range c.blocksCache {
+		if slot < c.chainTime.FirstSlotOfEpoch(epoch) {
+			delete(c.blocksCache, slot)
+		}
+	}
+
+	if err := c.processProposerDuties(ctx, epoch, res); err != nil {
+		return nil, err
+	}
+
+	if err := c.processAttesterDuties(ctx, epoch, res); err != nil {
+		return nil, err
+	}
+
+	if epoch >= c.chainTime.AltairInitialEpoch() {
+		if err := c.processSyncCommitteeDuties(ctx, epoch, res); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (c *command) processProposerDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	res *epochPerformance,
+) error {
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+	duties := dutiesResponse.Data
+	for _, duty := range duties {
+		if _, exists := c.validatorsByIndex[duty.ValidatorIndex]; !exists {
+			continue
+		}
+		res.ProposalsExpected++
+		c.validatorPerformance(duty.ValidatorIndex).ProposalsExpected++
+		block, err := c.fetchBlock(ctx, duty.Slot)
+		if err != nil {
+			return err
+		}
+		if block != nil {
+			res.Proposals++
+			c.validatorPerformance(duty.ValidatorIndex).Proposals++
+		}
+	}
+
+	return nil
+}
+
+// activeIndices returns the indices of the validators that are active in the epoch.
+func (c *command) activeIndices(epoch phase0.Epoch) []phase0.ValidatorIndex {
+	indices := make([]phase0.ValidatorIndex, 0, len(c.validatorsByIndex))
+	for _, index := range c.results.Validators {
+		validator := c.validatorsByIndex[index].Validator
+		if validator.ActivationEpoch <= epoch && validator.ExitEpoch > epoch {
+			indices = append(indices, index)
+		}
+	}
+
+	return indices
+}
+
+func (c *command) processAttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	res *epochPerformance,
+) error {
+	indices := c.activeIndices(epoch)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	dutiesResponse, err := c.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: indices})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+	}
+	duties := dutiesResponse.Data
+	dutiesBySlot := make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+	for _, duty := range duties {
+		if _, exists := dutiesBySlot[duty.Slot]; !exists {
+			dutiesBySlot[duty.Slot] = make(map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+		}
+		dutiesBySlot[duty.Slot][duty.CommitteeIndex] = append(dutiesBySlot[duty.Slot][duty.CommitteeIndex], duty)
+		c.validatorPerformance(duty.ValidatorIndex).AttestationsExpected++
+	}
+	res.AttestationsExpected = len(duties)
+
+	// Attestations for the epoch can be included anywhere from the second
+	// slot of the epoch to the first slot of the next-but-one epoch.
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch) + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(epoch + 2)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	// Need a cache of beacon block headers to reduce lookup times.
+	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+
+	votes := make(map[phase0.ValidatorIndex]struct{}, len(duties))
+	for slot := firstSlot; slot <= lastSlot && len(votes) < len(duties); slot++ {
+		block, err := c.fetchBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			// No block at this slot; that's fine.
+			continue
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return err
+		}
+		for _, attestation := range attestations {
+			data, err := attestation.Data()
+			if err != nil {
+				return errors.Wrap(err, "failed to obtain attestation data")
+			}
+			slotDuties, exists := dutiesBySlot[data.Slot]
+			if !exists {
+				// Not an attestation in which we are interested.
+				continue
+			}
+			attestationVotes, err := util.AttestationCommitteeVotes(attestation, func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+				return c.committeeSizes.Fetch(ctx, data.Slot, committeeIndex)
+			})
+			if err != nil {
+				return err
+			}
+			for committeeIndex, committeeVotes := range attestationVotes {
+				for _, duty := range slotDuties[committeeIndex] {
+					if _, exists := votes[duty.ValidatorIndex]; exists {
+						// Already included.
+						continue
+					}
+					if !committeeVotes.BitAt(duty.ValidatorCommitteeIndex) {
+						continue
+					}
+					votes[duty.ValidatorIndex] = struct{}{}
+
+					headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, data)
+					if err != nil {
+						return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+					}
+					targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, data)
+					if err != nil {
+						return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+					}
+					inclusionDistance := slot - data.Slot
+					for _, performance := range []*performance{&res.performance, c.validatorPerformance(duty.ValidatorIndex)} {
+						performance.AttestationsIncluded++
+						performance.InclusionDistance += uint64(inclusionDistance)
+						if inclusionDistance <= 5 {
+							performance.SourceTimely++
+						}
+						if headCorrect {
+							performance.HeadCorrect++
+						}
+						if targetCorrect {
+							performance.TargetCorrect++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	res *epochPerformance,
+) error {
+	committee, err := c.syncCommittee(ctx, epoch)
+	if err != nil {
+		return err
+	}
+	positions := make([]int, 0)
+	for i, index := range committee {
+		if _, exists := c.validatorsByIndex[index]; exists {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	for slot := c.chainTime.FirstSlotOfEpoch(epoch); slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		block, err := c.fetchBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			// If the block is missed we don't count the sync aggregate miss.
+			continue
+		}
+		aggregate, err := block.SyncAggregate()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync aggregate for slot %d", slot))
+		}
+		for _, position := range positions {
+			res.SyncCommitteeExpected++
+			c.validatorPerformance(committee[position]).SyncCommitteeExpected++
+			if aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				res.SyncCommitteeIncluded++
+				c.validatorPerformance(committee[position]).SyncCommitteeIncluded++
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncCommittee returns the sync committee for the period of the epoch.
+func (c *command) syncCommittee(ctx context.Context, epoch phase0.Epoch) ([]phase0.ValidatorIndex, error) {
+	period := c.chainTime.SlotToSyncCommitteePeriod(c.chainTime.FirstSlotOfEpoch(epoch))
+	if committee, exists := c.syncCommittees[period]; exists {
+		return committee, nil
+	}
+
+	syncCommitteeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(epoch)), Epoch: &epoch})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for epoch %d", epoch))
+	}
+	syncCommittee := syncCommitteeResponse.Data
+	c.syncCommittees[period] = syncCommittee.Validators
+
+	return syncCommittee.Validators, nil
+}
+
+func (c *command) fetchBlock(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	block, exists := c.blocksCache[slot]
+	if !exists {
+		var err error
+		block, err = util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		c.blocksCache[slot] = block
+	}
+
+	return block, nil
+}
+
+// add adds the counts of another performance to this one.
+func (p *performance) add(other *performance) {
+	p.AttestationsExpected += other.AttestationsExpected
+	p.AttestationsIncluded += other.AttestationsIncluded
+	p.InclusionDistance += other.InclusionDistance
+	p.HeadCorrect += other.HeadCorrect
+	p.TargetCorrect += other.TargetCorrect
+	p.SourceTimely += other.SourceTimely
+	p.ProposalsExpected += other.ProposalsExpected
+	p.Proposals += other.Proposals
+	p.SyncCommitteeExpected += other.SyncCommitteeExpected
+	p.SyncCommitteeIncluded += other.SyncCommitteeIncluded
+}
+
+// finalise calculates the derived metrics from the counts.
+func (p *performance) finalise() {
+	if p.AttestationsExpected > 0 {
+		p.AttestationHitRate = float64(p.AttestationsIncluded) / float64(p.AttestationsExpected)
+	}
+	if p.AttestationsIncluded > 0 {
+		p.AverageInclusionDistance = float64(p.InclusionDistance) / float64(p.AttestationsIncluded)
+	}
+	p.MissedProposals = p.ProposalsExpected - p.Proposals
+	if p.SyncCommitteeExpected > 0 {
+		p.SyncParticipation = float64(p.SyncCommitteeIncluded) / float64(p.SyncCommitteeExpected)
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committees")
+	}
+	c.committeeSizes = util.NewBeaconCommitteeSizeCache(beaconCommitteesProvider)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformance(t *testing.T) {
+	totals := &performance{}
+	totals.add(&performance{
+		AttestationsExpected:  2,
+		AttestationsIncluded:  2,
+		InclusionDistance:     3,
+		HeadCorrect:           1,
+		TargetCorrect:         2,
+		SourceTimely:          2,
+		ProposalsExpected:     1,
+		Proposals:             1,
+		SyncCommitteeExpected: 32,
+		SyncCommitteeIncluded: 30,
+	})
+	totals.add(&performance{
+		AttestationsExpected:  2,
+		AttestationsIncluded:  1,
+		InclusionDistance:     1,
+		HeadCorrect:           1,
+		TargetCorrect:         1,
+		SourceTimely:          1,
+		ProposalsExpected:     1,
+		SyncCommitteeExpected: 32,
+		SyncCommitteeIncluded: 32,
+	})
+	totals.finalise()
+
+	require.Equal(t, &performance{
+		AttestationsExpected:     4,
+		AttestationsIncluded:     3,
+		AttestationHitRate:       0.75,
+		InclusionDistance:        4,
+		AverageInclusionDistance: float64(4) / float64(3),
+		HeadCorrect:              2,
+		TargetCorrect:            3,
+		SourceTimely:             3,
+		ProposalsExpected:        2,
+		Proposals:                1,
+		MissedProposals:          1,
+		SyncCommitteeExpected:    64,
+		SyncCommitteeIncluded:    62,
+		SyncParticipation:        0.96875,
+	}, totals)
+}
+
+func TestPerformanceEmpty(t *testing.T) {
+	totals := &performance{}
+	totals.finalise()
+	require.Equal(t, &performance{}, totals)
+}
+
+func TestSLAReport(t *testing.T) {
+	c := &command{
+		slaTarget: 0.9,
+		groups: []*groupInput{
+			{name: "a", validators: []string{"1", "2"}},
+		},
+		groupIndices: map[string][]phase0.ValidatorIndex{
+			"a": {1, 2},
+		},
+		validatorTotals: map[phase0.ValidatorIndex]*performance{
+			1: {AttestationsExpected: 10, AttestationsIncluded: 10},
+			2: {AttestationsExpected: 10, AttestationsIncluded: 8},
+		},
+		results: &results{
+			Validators: []phase0.ValidatorIndex{1, 2, 3},
+		},
+	}
+
+	report := c.slaReport()
+	require.False(t, report.Compliant)
+	require.Len(t, report.Validators, 3)
+	require.True(t, report.Validators[0].Compliant)
+	require.False(t, report.Validators[1].Compliant)
+	require.InDelta(t, 0.8, report.Validators[1].AttestationHitRate, 1e-9)
+	// Validator 3 had no duties, so is compliant.
+	require.True(t, report.Validators[2].Compliant)
+	require.Len(t, report.Groups, 1)
+	require.Equal(t, "a", report.Groups[0].Name)
+	require.Equal(t, 20, report.Groups[0].AttestationsExpected)
+	require.Equal(t, 18, report.Groups[0].AttestationsIncluded)
+	require.True(t, report.Groups[0].Compliant)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet         bool
+	verbose       bool
+	debug         bool
+	format        output.Format
+	balanceFormat *output.BalanceFormat
+	fiat          *output.Fiat
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+	fromEpoch  string
+	toEpoch    string
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+
+	// Processing.
+	validatorRewards map[phase0.ValidatorIndex]*validatorRewards
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	FromEpoch  uint64              `json:"from_epoch"`
+	ToEpoch    uint64              `json:"to_epoch"`
+	Validators []*validatorRewards `json:"validators"`
+	Totals     *rewards            `json:"totals"`
+}
+
+// rewards are the rewards for one or more validators.  Values are in Gwei, and
+// are negative if penalties outweigh rewards.
+type rewards struct {
+	Attestations  int64 `json:"attestations"`
+	Proposals     int64 `json:"proposals"`
+	SyncCommittee int64 `json:"sync_committee"`
+	Total         int64 `json:"total"`
+}
+
+type validatorRewards struct {
+	Index phase0.ValidatorIndex `json:"index"`
+	rewards
+	Blocks       int `json:"blocks"`
+	MissedBlocks int `json:"missed_blocks"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:            viper.GetBool("quiet"),
+		verbose:          viper.GetBool("verbose"),
+		debug:            viper.GetBool("debug"),
+		validatorRewards: make(map[phase0.ValidatorIndex]*validatorRewards),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+	c.balanceFormat, err = output.BalanceFormatFromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
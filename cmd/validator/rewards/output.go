@@ -0,0 +1,128 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the rewards as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// CSVHeader returns the header of the CSV output.
+func (*command) CSVHeader() []string {
+	return []string{
+		"validator_index",
+		"attestations",
+		"proposals",
+		"sync_committee",
+		"total",
+		"total_eth",
+		"blocks",
+		"missed_blocks",
+	}
+}
+
+// CSVRecords returns the rewards of each validator as CSV.  Values are in Gwei
+// unless otherwise noted.
+func (c *command) CSVRecords(_ context.Context) ([][]string, error) {
+	records := make([][]string, 0, len(c.results.Validators))
+	for _, validator := range c.results.Validators {
+		records = append(records, []string{
+			fmt.Sprintf("%d", validator.Index),
+			fmt.Sprintf("%d", validator.Attestations),
+			fmt.Sprintf("%d", validator.Proposals),
+			fmt.Sprintf("%d", validator.SyncCommittee),
+			fmt.Sprintf("%d", validator.Total),
+			gweiToETH(validator.Total),
+			fmt.Sprintf("%d", validator.Blocks),
+			fmt.Sprintf("%d", validator.MissedBlocks),
+		})
+	}
+
+	return records, nil
+}
+
+// RenderText renders the rewards as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.results.FromEpoch == c.results.ToEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", c.results.FromEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d to %d:\n", c.results.FromEpoch, c.results.ToEpoch))
+	}
+
+	if c.verbose || len(c.results.Validators) > 1 {
+		for _, validator := range c.results.Validators {
+			builder.WriteString(fmt.Sprintf("  Validator %d:\n", validator.Index))
+			writeRewards(&builder, c.balanceFormat, c.fiat, "    ", &validator.rewards)
+			if validator.Blocks > 0 || validator.MissedBlocks > 0 {
+				builder.WriteString(fmt.Sprintf("    Blocks: %d proposed, %d missed\n", validator.Blocks, validator.MissedBlocks))
+			}
+		}
+		if len(c.results.Validators) > 1 {
+			builder.WriteString("  Totals:\n")
+			writeRewards(&builder, c.balanceFormat, c.fiat, "    ", c.results.Totals)
+		}
+	} else {
+		writeRewards(&builder, c.balanceFormat, c.fiat, "  ", c.results.Totals)
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func writeRewards(builder *strings.Builder, balanceFormat *output.BalanceFormat, fiat *output.Fiat, prefix string, rewards *rewards) {
+	builder.WriteString(fmt.Sprintf("%sAttestations: %s\n", prefix, formatGwei(balanceFormat, fiat, rewards.Attestations)))
+	builder.WriteString(fmt.Sprintf("%sProposals: %s\n", prefix, formatGwei(balanceFormat, fiat, rewards.Proposals)))
+	builder.WriteString(fmt.Sprintf("%sSync committee: %s\n", prefix, formatGwei(balanceFormat, fiat, rewards.SyncCommittee)))
+	builder.WriteString(fmt.Sprintf("%sTotal: %s\n", prefix, formatGwei(balanceFormat, fiat, rewards.Total)))
+}
+
+// formatGwei formats a value in Gwei, which may be negative.  Unless balance
+// options are supplied this is the value with its equivalent in Ether, and
+// its fiat equivalent if requested.
+func formatGwei(balanceFormat *output.BalanceFormat, fiat *output.Fiat, value int64) string {
+	if !balanceFormat.IsStandard() {
+		if fiat != nil {
+			return fmt.Sprintf("%s (%s)", balanceFormat.SignedGwei(value), fiat.Gwei(value))
+		}
+		return balanceFormat.SignedGwei(value)
+	}
+
+	if fiat != nil {
+		return fmt.Sprintf("%d Gwei (%s Ether, %s)", value, gweiToETH(value), fiat.Gwei(value))
+	}
+	return fmt.Sprintf("%d Gwei (%s Ether)", value, gweiToETH(value))
+}
+
+func gweiToETH(value int64) string {
+	return decimal.New(value, -9).String()
+}
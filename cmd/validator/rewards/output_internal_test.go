@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestOutput(t *testing.T) {
+	validator1 := &validatorRewards{
+		Index: 1,
+		rewards: rewards{
+			Attestations:  15000,
+			Proposals:     40000000,
+			SyncCommittee: -200,
+			Total:         40014800,
+		},
+		Blocks: 1,
+	}
+	validator2 := &validatorRewards{
+		Index: 2,
+		rewards: rewards{
+			Attestations: -3000,
+			Total:        -3000,
+		},
+		MissedBlocks: 1,
+	}
+
+	tests := []struct {
+		name   string
+		c      *command
+		format output.Format
+		res    string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+			res: "",
+		},
+		{
+			name: "Single",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator1},
+					Totals:     &validator1.rewards,
+				},
+			},
+			format: output.Text,
+			res:    "Epoch 100:\n  Attestations: 15000 Gwei (0.000015 Ether)\n  Proposals: 40000000 Gwei (0.04 Ether)\n  Sync committee: -200 Gwei (-0.0000002 Ether)\n  Total: 40014800 Gwei (0.0400148 Ether)",
+		},
+		{
+			name: "BalanceFormat",
+			c: &command{
+				balanceFormat: &output.BalanceFormat{
+					Unit:       output.Gwei,
+					Decimals:   -1,
+					Separators: true,
+					Locale:     "en",
+				},
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator1},
+					Totals:     &validator1.rewards,
+				},
+			},
+			format: output.Text,
+			res:    "Epoch 100:\n  Attestations: 15,000 Gwei\n  Proposals: 40,000,000 Gwei\n  Sync committee: -200 Gwei\n  Total: 40,014,800 Gwei",
+		},
+		{
+			name: "Fiat",
+			c: &command{
+				fiat: &output.Fiat{
+					Currency: "usd",
+					Price:    decimal.RequireFromString("2000"),
+				},
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator1},
+					Totals:     &validator1.rewards,
+				},
+			},
+			format: output.Text,
+			res:    "Epoch 100:\n  Attestations: 15000 Gwei (0.000015 Ether, 0.03 USD)\n  Proposals: 40000000 Gwei (0.04 Ether, 80.00 USD)\n  Sync committee: -200 Gwei (-0.0000002 Ether, 0.00 USD)\n  Total: 40014800 Gwei (0.0400148 Ether, 80.03 USD)",
+		},
+		{
+			name: "FiatBalanceFormat",
+			c: &command{
+				balanceFormat: &output.BalanceFormat{
+					Unit:     output.Ether,
+					Decimals: 4,
+					Locale:   "en",
+				},
+				fiat: &output.Fiat{
+					Currency: "eur",
+					Price:    decimal.RequireFromString("1500.5"),
+				},
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator1},
+					Totals:     &validator1.rewards,
+				},
+			},
+			format: output.Text,
+			res:    "Epoch 100:\n  Attestations: 0.0000 Ether (0.02 EUR)\n  Proposals: 0.0400 Ether (60.02 EUR)\n  Sync committee: -0.0000 Ether (0.00 EUR)\n  Total: 0.0400 Ether (60.04 EUR)",
+		},
+		{
+			name: "Multiple",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    101,
+					Validators: []*validatorRewards{validator1, validator2},
+					Totals: &rewards{
+						Attestations:  12000,
+						Proposals:     40000000,
+						SyncCommittee: -200,
+						Total:         40011800,
+					},
+				},
+			},
+			format: output.Text,
+			res:    "Epochs 100 to 101:\n  Validator 1:\n    Attestations: 15000 Gwei (0.000015 Ether)\n    Proposals: 40000000 Gwei (0.04 Ether)\n    Sync committee: -200 Gwei (-0.0000002 Ether)\n    Total: 40014800 Gwei (0.0400148 Ether)\n    Blocks: 1 proposed, 0 missed\n  Validator 2:\n    Attestations: -3000 Gwei (-0.000003 Ether)\n    Proposals: 0 Gwei (0 Ether)\n    Sync committee: 0 Gwei (0 Ether)\n    Total: -3000 Gwei (-0.000003 Ether)\n    Blocks: 0 proposed, 1 missed\n  Totals:\n    Attestations: 12000 Gwei (0.000012 Ether)\n    Proposals: 40000000 Gwei (0.04 Ether)\n    Sync committee: -200 Gwei (-0.0000002 Ether)\n    Total: 40011800 Gwei (0.0400118 Ether)",
+		},
+		{
+			name: "JSON",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator2},
+					Totals:     &validator2.rewards,
+				},
+			},
+			format: output.JSON,
+			res:    `{"from_epoch":100,"to_epoch":100,"validators":[{"index":"2","attestations":-3000,"proposals":0,"sync_committee":0,"total":-3000,"blocks":0,"missed_blocks":1}],"totals":{"attestations":-3000,"proposals":0,"sync_committee":0,"total":-3000}}`,
+		},
+		{
+			name: "CSV",
+			c: &command{
+				results: &results{
+					FromEpoch:  100,
+					ToEpoch:    100,
+					Validators: []*validatorRewards{validator1, validator2},
+				},
+			},
+			format: output.CSV,
+			res:    "validator_index,attestations,proposals,sync_committee,total,total_eth,blocks,missed_blocks\n1,15000,40000000,-200,40014800,0.0400148,1,0\n2,-3000,0,0,-3000,-0.000003,0,1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.format = test.format
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+// defaultToEpoch is the latest epoch for which attestation rewards are
+// generally available, as they require the state at the end of the
+// following epoch.
+const defaultToEpoch = "-2"
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	toEpochStr := c.toEpoch
+	if toEpochStr == "" {
+		toEpochStr = defaultToEpoch
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, toEpochStr)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	fromEpoch := toEpoch
+	if c.fromEpoch != "" {
+		fromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse from epoch")
+		}
+	}
+	if toEpoch < fromEpoch {
+		return errors.New("to epoch cannot be before from epoch")
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, fmt.Sprintf("%d", c.chainTime.LastSlotOfEpoch(toEpoch)))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+		c.validatorRewards[validator.Index] = &validatorRewards{
+			Index: validator.Index,
+		}
+	}
+	sort.Slice(indices, func(i int, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if err := c.processEpoch(ctx, epoch, indices); err != nil {
+			return err
+		}
+	}
+
+	c.results = &results{
+		FromEpoch:  uint64(fromEpoch),
+		ToEpoch:    uint64(toEpoch),
+		Validators: make([]*validatorRewards, 0, len(indices)),
+		Totals:     &rewards{},
+	}
+	for _, index := range indices {
+		validator := c.validatorRewards[index]
+		validator.Total = validator.Attestations + validator.Proposals + validator.SyncCommittee
+		c.results.Validators = append(c.results.Validators, validator)
+		c.results.Totals.Attestations += validator.Attestations
+		c.results.Totals.Proposals += validator.Proposals
+		c.results.Totals.SyncCommittee += validator.SyncCommittee
+		c.results.Totals.Total += validator.Total
+	}
+
+	if c.format == output.Text {
+		c.fiat, err = util.FiatFromViper(ctx, c.chainTime.StartOfEpoch(toEpoch+1))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processEpoch(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) error {
+	if err := c.processAttestationRewards(ctx, epoch, indices); err != nil {
+		return err
+	}
+
+	if err := c.processBlockRewards(ctx, epoch); err != nil {
+		return err
+	}
+
+	if epoch >= c.chainTime.AltairInitialEpoch() {
+		if err := c.processSyncCommitteeRewards(ctx, epoch, indices); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processAttestationRewards(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) error {
+	attestationRewards, found, err := util.AttestationRewards(ctx, c.eth2Client, c.timeout, epoch, indices)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain attestation rewards for epoch %d", epoch))
+	}
+	if !found {
+		return fmt.Errorf("attestation rewards for epoch %d are not available", epoch)
+	}
+	for _, reward := range attestationRewards {
+		if validator, exists := c.validatorRewards[reward.ValidatorIndex]; exists {
+			validator.Attestations += reward.Total()
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processBlockRewards(ctx context.Context, epoch phase0.Epoch) error {
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+	duties := dutiesResponse.Data
+	for _, duty := range duties {
+		validator, exists := c.validatorRewards[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		blockReward, found, err := util.BlockRewards(ctx, c.eth2Client, c.timeout, fmt.Sprintf("%d", duty.Slot))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block rewards for slot %d", duty.Slot))
+		}
+		if !found || blockReward.ProposerIndex != duty.ValidatorIndex {
+			validator.MissedBlocks++
+			continue
+		}
+		validator.Blocks++
+		validator.Proposals += blockReward.Total
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeRewards(ctx context.Context,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) error {
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	syncCommitteeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{State: fmt.Sprintf("%d", firstSlot), Epoch: &epoch})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee for epoch %d", epoch))
+	}
+	syncCommittee := syncCommitteeResponse.Data
+	inCommittee := make(map[phase0.ValidatorIndex]bool, len(syncCommittee.Validators))
+	for _, index := range syncCommittee.Validators {
+		inCommittee[index] = true
+	}
+	members := make([]phase0.ValidatorIndex, 0)
+	for _, index := range indices {
+		if inCommittee[index] {
+			members = append(members, index)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	for slot := firstSlot; slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		syncCommitteeRewards, found, err := util.SyncCommitteeRewards(ctx, c.eth2Client, c.timeout, fmt.Sprintf("%d", slot), members)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee rewards for slot %d", slot))
+		}
+		if !found {
+			// No block for this slot.
+			continue
+		}
+		for _, reward := range syncCommitteeRewards {
+			if validator, exists := c.validatorRewards[reward.ValidatorIndex]; exists {
+				validator.SyncCommittee += reward.Reward
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(c.eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	database string
+	file     string
+	pubKeys  []phase0.BLSPubKey
+
+	// Output.
+	data       []byte
+	validators int
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		file:    viper.GetString("file"),
+	}
+
+	var err error
+	c.database, err = util.SlashingProtectionDB()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, input := range viper.GetStringSlice("pubkeys") {
+		data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil || len(data) != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("invalid public key %q", input)
+		}
+		pubKey := phase0.BLSPubKey{}
+		copy(pubKey[:], data)
+		c.pubKeys = append(c.pubKeys, pubKey)
+	}
+
+	return c, nil
+}
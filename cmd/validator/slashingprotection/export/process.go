@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if c.file != "" {
+		if _, err := os.Stat(c.file); err == nil {
+			return fmt.Errorf("file %s already exists", c.file)
+		}
+	}
+
+	if _, err := os.Stat(c.database); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("slashing protection database %s does not exist", c.database)
+		}
+		return errors.Wrap(err, "failed to access slashing protection database")
+	}
+	db, err := interchange.New(ctx,
+		interchange.WithSource(c.database),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to load slashing protection database")
+	}
+
+	c.data, err = db.Export(c.pubKeys)
+	if err != nil {
+		return err
+	}
+	c.validators = len(c.pubKeys)
+	if c.validators == 0 {
+		c.validators = len(db.Pubkeys())
+	}
+
+	if c.file == "" {
+		return nil
+	}
+
+	return writeFile(c.file, c.data)
+}
+
+// writeFile writes the interchange data to a file, which must not already
+// exist.
+func writeFile(file string, data []byte) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "failed to write file")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write file")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionexport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const testDatabase = `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"81952"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007"}]}]}`
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	database := filepath.Join(dir, "slashing-protection.json")
+	require.NoError(t, os.WriteFile(database, []byte(testDatabase), 0o600))
+	existing := filepath.Join(dir, "existing.json")
+	require.NoError(t, os.WriteFile(existing, []byte("{}"), 0o600))
+
+	tests := []struct {
+		name     string
+		c        *command
+		err      string
+		expected string
+	}{
+		{
+			name: "DatabaseMissing",
+			c: &command{
+				database: filepath.Join(dir, "missing.json"),
+			},
+			err: fmt.Sprintf("slashing protection database %s does not exist", filepath.Join(dir, "missing.json")),
+		},
+		{
+			name: "FileExists",
+			c: &command{
+				database: database,
+				file:     existing,
+			},
+			err: fmt.Sprintf("file %s already exists", existing),
+		},
+		{
+			name: "UnknownPubKey",
+			c: &command{
+				database: database,
+				pubKeys:  []phase0.BLSPubKey{{0x01}},
+			},
+			err: "no slashing protection data for 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "Stdout",
+			c: &command{
+				database: database,
+			},
+			expected: testDatabase,
+		},
+		{
+			name: "File",
+			c: &command{
+				database: database,
+				file:     filepath.Join(dir, "export.json"),
+			},
+			expected: testDatabase,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, test.c.validators)
+			require.Equal(t, test.expected, string(test.c.data))
+			if test.c.file != "" {
+				data, err := os.ReadFile(test.c.file)
+				require.NoError(t, err)
+				require.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
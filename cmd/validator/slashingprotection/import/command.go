@@ -0,0 +1,88 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	timeout  time.Duration
+	database string
+	files    []string
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Database   string          `json:"database"`
+	Validators int             `json:"validators"`
+	Imports    []*importResult `json:"imports"`
+	Conflicts  []*conflict     `json:"conflicts,omitempty"`
+}
+
+type importResult struct {
+	File       string `json:"file"`
+	Validators int    `json:"validators"`
+}
+
+type conflict struct {
+	PubKey string `json:"pubkey"`
+	Reason string `json:"reason"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.files = viper.GetStringSlice("files")
+	if len(c.files) == 0 {
+		return nil, errors.New("files is required")
+	}
+
+	var err error
+	c.database, err = util.SlashingProtectionDB()
+	if err != nil {
+		return nil, err
+	}
+
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		database string
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"files": []string{"interchange.json"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "FilesMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "files is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"files":                  []string{"interchange.json"},
+				"slashing-protection-db": "/tmp/db.json",
+			},
+			database: "/tmp/db.json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.database, c.database)
+			}
+		})
+	}
+}
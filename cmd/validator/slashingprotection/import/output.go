@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	return output.Render(ctx, c, c.format)
+}
+
+// RenderJSON renders the import as JSON.
+func (c *command) RenderJSON(_ context.Context) ([]byte, error) {
+	return json.Marshal(c.results)
+}
+
+// RenderText renders the import as text.
+func (c *command) RenderText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.results.Conflicts) > 0 {
+		for _, conflict := range c.results.Conflicts {
+			builder.WriteString(fmt.Sprintf("Conflict for %s: %s\n", conflict.PubKey, conflict.Reason))
+		}
+		builder.WriteString("Slashing protection database not updated")
+
+		return builder.String(), nil
+	}
+
+	if c.verbose {
+		for _, imported := range c.results.Imports {
+			builder.WriteString(fmt.Sprintf("Imported data for %d validators from %s\n", imported.Validators, imported.File))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Slashing protection database %s holds data for %d validators", c.results.Database, c.results.Validators))
+
+	return builder.String(), nil
+}
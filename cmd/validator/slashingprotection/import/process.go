@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+)
+
+func (c *command) process(ctx context.Context) error {
+	c.results = &results{
+		Database: c.database,
+		Imports:  make([]*importResult, 0, len(c.files)),
+	}
+
+	var db *interchange.Service
+	if _, err := os.Stat(c.database); err == nil {
+		db, err = c.load(ctx, c.database)
+		if err != nil {
+			return errors.Wrap(err, "failed to load slashing protection database")
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to access slashing protection database")
+	}
+
+	for _, file := range c.files {
+		data, err := c.load(ctx, file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load %s", file)
+		}
+		c.results.Imports = append(c.results.Imports, &importResult{
+			File:       file,
+			Validators: len(data.Pubkeys()),
+		})
+		if db == nil {
+			db = data
+			continue
+		}
+		if err := db.Merge(data); err != nil {
+			return errors.Wrapf(err, "failed to merge %s", file)
+		}
+	}
+
+	if db.GenesisValidatorsRoot() == (phase0.Root{}) {
+		return errors.New("slashing protection data does not include a genesis validators root")
+	}
+
+	// Conflicts mean that the validator has already signed slashable messages,
+	// or that the data is corrupt, so the database is not updated.
+	for _, dbConflict := range db.Conflicts() {
+		c.results.Conflicts = append(c.results.Conflicts, &conflict{
+			PubKey: fmt.Sprintf("%#x", dbConflict.Pubkey),
+			Reason: dbConflict.Reason,
+		})
+	}
+	if len(c.results.Conflicts) > 0 {
+		return nil
+	}
+
+	c.results.Validators = len(db.Pubkeys())
+
+	return c.write(db)
+}
+
+func (c *command) load(ctx context.Context, source string) (*interchange.Service, error) {
+	return interchange.New(ctx,
+		interchange.WithSource(source),
+		interchange.WithTimeout(c.timeout),
+	)
+}
+
+// write writes the database, replacing it atomically so that a failure part
+// way through does not lose existing slashing protection data.
+func (c *command) write(db *interchange.Service) error {
+	data, err := db.Export(nil)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.database), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create slashing protection database directory")
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(c.database), ".slashing-protection-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() {
+		// Removes the temporary file if it has not been renamed.
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err, "failed to write slashing protection database")
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return errors.Wrap(err, "failed to sync slashing protection database")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "failed to close slashing protection database")
+	}
+	if err := os.Rename(tmpFile.Name(), c.database); err != nil {
+		return errors.Wrap(err, "failed to replace slashing protection database")
+	}
+
+	return nil
+}
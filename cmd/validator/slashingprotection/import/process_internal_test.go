@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashingprotectionimport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPubKey = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+
+func writeInterchange(t *testing.T, dir string, name string, genesisValidatorsRoot string, attestations string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	data := fmt.Sprintf(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"%s"},"data":[{"pubkey":"%s","signed_blocks":[],"signed_attestations":[%s]}]}`, genesisValidatorsRoot, testPubKey, attestations)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	return path
+}
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	genesisValidatorsRoot := "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	first := writeInterchange(t, dir, "first.json", genesisValidatorsRoot, `{"source_epoch":"1","target_epoch":"2"}`)
+	second := writeInterchange(t, dir, "second.json", genesisValidatorsRoot, `{"source_epoch":"2","target_epoch":"3"}`)
+	surround := writeInterchange(t, dir, "surround.json", genesisValidatorsRoot, `{"source_epoch":"0","target_epoch":"4"}`)
+	otherChain := writeInterchange(t, dir, "other.json", "0x0100000000000000000000000000000000000000000000000000000000000000", ``)
+	noRoot := writeInterchange(t, dir, "noroot.json", "", ``)
+
+	tests := []struct {
+		name      string
+		files     []string
+		err       string
+		conflicts int
+		db        string
+	}{
+		{
+			name:  "NoGenesisValidatorsRoot",
+			files: []string{noRoot},
+			err:   "slashing protection data does not include a genesis validators root",
+		},
+		{
+			name:  "Initial",
+			files: []string{first},
+			db:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`,
+		},
+		{
+			name:  "Merge",
+			files: []string{second, first},
+			db:    `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+		{
+			name:  "OtherChain",
+			files: []string{otherChain},
+			err:   fmt.Sprintf("failed to merge %s: cannot merge interchange data for genesis validators root 0x0100000000000000000000000000000000000000000000000000000000000000 with that for 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673", otherChain),
+		},
+		{
+			name:      "Conflict",
+			files:     []string{surround},
+			conflicts: 2,
+			// Database is unchanged.
+			db: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`,
+		},
+	}
+
+	// Tests run in order, building on the database.
+	database := filepath.Join(dir, "db", "slashing-protection.json")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				timeout:  5 * time.Second,
+				database: database,
+				files:    test.files,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.results.Conflicts, test.conflicts)
+			data, err := os.ReadFile(database)
+			require.NoError(t, err)
+			require.Equal(t, test.db, string(data))
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -36,9 +37,10 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Operation.
-	epoch      string
-	validators []string
-	jsonOutput bool
+	epoch       string
+	targetEpoch phase0.Epoch
+	validators  []string
+	jsonOutput  bool
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -53,13 +55,14 @@ type command struct {
 
 	// Processing.
 	validatorsByIndex map[phase0.ValidatorIndex]*apiv1.Validator
+	committeeSizes    *util.BeaconCommitteeSizeCache
 
 	// Results.
 	summary *validatorSummary
 }
 
 type validatorSummary struct {
-	Epoch                      phase0.Epoch                 `json:"epoch"`
+	Epoch                      uint64                       `json:"epoch"`
 	Validators                 []*apiv1.Validator           `json:"validators"`
 	FirstSlot                  phase0.Slot                  `json:"first_slot"`
 	LastSlot                   phase0.Slot                  `json:"last_slot"`
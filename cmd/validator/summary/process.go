@@ -19,9 +19,11 @@ import (
 	"sort"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -37,12 +39,13 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	c.targetEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
-	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
-	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
+	c.summary.Epoch = uint64(c.targetEpoch)
+	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.targetEpoch)
+	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.targetEpoch+1) - 1
 	c.summary.Slots = make([]*slot, 1+int(c.summary.LastSlot)-int(c.summary.FirstSlot))
 	for i := range c.summary.Slots {
 		c.summary.Slots[i] = &slot{
@@ -81,10 +84,11 @@ func (c *command) process(ctx context.Context) error {
 }
 
 func (c *command) processProposerDuties(ctx context.Context) error {
-	duties, err := c.proposerDutiesProvider.ProposerDuties(ctx, c.summary.Epoch, nil)
+	dutiesResponse, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: c.targetEpoch})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain proposer duties")
 	}
+	duties := dutiesResponse.Data
 	if duties == nil {
 		return errors.New("empty proposer duties")
 	}
@@ -92,7 +96,7 @@ func (c *command) processProposerDuties(ctx context.Context) error {
 		if _, exists := c.validatorsByIndex[duty.ValidatorIndex]; !exists {
 			continue
 		}
-		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", duty.Slot))
+		block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", duty.Slot)}))
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", duty.Slot))
 		}
@@ -111,7 +115,7 @@ func (c *command) activeValidators() (map[phase0.ValidatorIndex]*apiv1.Validator
 	activeValidators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
 	activeValidatorIndices := make([]phase0.ValidatorIndex, 0, len(c.validatorsByIndex))
 	for _, validator := range c.summary.Validators {
-		if validator.Validator.ActivationEpoch <= c.summary.Epoch && validator.Validator.ExitEpoch > c.summary.Epoch {
+		if validator.Validator.ActivationEpoch <= c.targetEpoch && validator.Validator.ExitEpoch > c.targetEpoch {
 			activeValidators[validator.Index] = validator
 			activeValidatorIndices = append(activeValidatorIndices, validator.Index)
 		}
@@ -126,19 +130,20 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 	// Obtain number of validators that voted for blocks in the epoch.
 	// These votes can be included anywhere from the second slot of
 	// the epoch to the first slot of the next-but-one epoch.
-	firstSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) + 1
-	lastSlot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch + 2)
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch) + 1
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch + 2)
 	if lastSlot > c.chainTime.CurrentSlot() {
 		lastSlot = c.chainTime.CurrentSlot()
 	}
 
 	// Obtain the duties for the validators to know where they should be attesting.
-	duties, err := c.attesterDutiesProvider.AttesterDuties(ctx, c.summary.Epoch, activeValidatorIndices)
+	dutiesResponse, err := c.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: c.targetEpoch, Indices: activeValidatorIndices})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain attester duties")
 	}
-	for slot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch); slot < c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1); slot++ {
-		index := int(slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
+	duties := dutiesResponse.Data
+	for slot := c.chainTime.FirstSlotOfEpoch(c.targetEpoch); slot < c.chainTime.FirstSlotOfEpoch(c.targetEpoch+1); slot++ {
+		index := int(slot - c.chainTime.FirstSlotOfEpoch(c.targetEpoch))
 		c.summary.Slots[index].Attestations = &slotAttestations{}
 	}
 
@@ -149,7 +154,7 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 	dutiesBySlot := make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
 	dutiesByValidatorIndex := make(map[phase0.ValidatorIndex]*apiv1.AttesterDuty)
 	for _, duty := range duties {
-		index := int(duty.Slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
+		index := int(duty.Slot - c.chainTime.FirstSlotOfEpoch(c.targetEpoch))
 		dutiesByValidatorIndex[duty.ValidatorIndex] = duty
 		c.summary.Slots[index].Attestations.Expected++
 		if _, exists := dutiesBySlot[duty.Slot]; !exists {
@@ -213,7 +218,7 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 	headersCache *util.BeaconBlockHeaderCache,
 	activeValidatorIndices []phase0.ValidatorIndex,
 ) error {
-	block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
+	block, err := util.ResponseData(c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: fmt.Sprintf("%d", slot)}))
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
 	}
@@ -226,82 +231,120 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 		return err
 	}
 	for _, attestation := range attestations {
-		if _, exists := dutiesBySlot[attestation.Data.Slot]; !exists {
+		data, err := attestation.Data()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation data")
+		}
+		if _, exists := dutiesBySlot[data.Slot]; !exists {
 			// We do not have any attestations for this slot.
 			continue
 		}
-		if _, exists := dutiesBySlot[attestation.Data.Slot][attestation.Data.Index]; !exists {
-			// We do not have any attestations for this committee.
-			continue
+		committeeIndices, err := util.AttestationCommitteeIndices(attestation)
+		if err != nil {
+			return err
+		}
+		aggregationBits, err := attestation.AggregationBits()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain aggregation bits")
 		}
-		for _, duty := range dutiesBySlot[attestation.Data.Slot][attestation.Data.Index] {
-			if attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
-				// Found it.
-				if _, exists := votes[duty.ValidatorIndex]; exists {
-					// Duplicate; ignore.
-					continue
+		// The members of each committee covered by the attestation are concatenated.
+		offset := uint64(0)
+		for i, committeeIndex := range committeeIndices {
+			if i > 0 {
+				size, err := c.committeeSizes.Fetch(ctx, data.Slot, committeeIndices[i-1])
+				if err != nil {
+					return err
 				}
-				votes[duty.ValidatorIndex] = struct{}{}
+				offset += size
+			}
+			if err := c.processAttestationCommittee(ctx, slot, data, aggregationBits, offset, dutiesBySlot[data.Slot][committeeIndex], votes, headersCache); err != nil {
+				return err
+			}
+		}
 
-				// Update the metrics for the attestation.
-				index := int(attestation.Data.Slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
-				c.summary.Slots[index].Attestations.Included++
-				inclusionDelay := slot - duty.Slot
+		if len(votes) == len(activeValidatorIndices) {
+			// Found them all.
+			break
+		}
+	}
 
-				fault := &validatorFault{
-					Validator:         duty.ValidatorIndex,
-					AttestationData:   attestation.Data,
-					InclusionDistance: int(inclusionDelay),
-				}
+	return nil
+}
 
-				headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
-				if err != nil {
-					return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
-				}
-				if headCorrect {
-					c.summary.Slots[index].Attestations.CorrectHead++
-					if inclusionDelay == 1 {
-						c.summary.Slots[index].Attestations.TimelyHead++
-					} else {
-						c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
-					}
-				} else {
-					c.summary.IncorrectHeadValidators = append(c.summary.IncorrectHeadValidators, fault)
-					if inclusionDelay > 1 {
-						c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
-					}
-				}
+// processAttestationCommittee processes the votes in an attestation for the
+// duties of a single committee, whose members start at the given offset in the
+// aggregation bits.
+func (c *command) processAttestationCommittee(ctx context.Context,
+	slot phase0.Slot,
+	data *phase0.AttestationData,
+	aggregationBits bitfield.Bitlist,
+	offset uint64,
+	duties []*apiv1.AttesterDuty,
+	votes map[phase0.ValidatorIndex]struct{},
+	headersCache *util.BeaconBlockHeaderCache,
+) error {
+	for _, duty := range duties {
+		if aggregationBits.BitAt(offset + duty.ValidatorCommitteeIndex) {
+			// Found it.
+			if _, exists := votes[duty.ValidatorIndex]; exists {
+				// Duplicate; ignore.
+				continue
+			}
+			votes[duty.ValidatorIndex] = struct{}{}
 
-				if inclusionDelay <= 5 {
-					c.summary.Slots[index].Attestations.TimelySource++
+			// Update the metrics for the attestation.
+			index := int(data.Slot - c.chainTime.FirstSlotOfEpoch(c.targetEpoch))
+			c.summary.Slots[index].Attestations.Included++
+			inclusionDelay := slot - duty.Slot
+
+			fault := &validatorFault{
+				Validator:         duty.ValidatorIndex,
+				AttestationData:   data,
+				InclusionDistance: int(inclusionDelay),
+			}
+
+			headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, data)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+			}
+			if headCorrect {
+				c.summary.Slots[index].Attestations.CorrectHead++
+				if inclusionDelay == 1 {
+					c.summary.Slots[index].Attestations.TimelyHead++
 				} else {
-					c.summary.UntimelySourceValidators = append(c.summary.UntimelySourceValidators, fault)
+					c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
 				}
-
-				targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
-				if err != nil {
-					return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+			} else {
+				c.summary.IncorrectHeadValidators = append(c.summary.IncorrectHeadValidators, fault)
+				if inclusionDelay > 1 {
+					c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
 				}
-				if targetCorrect {
-					c.summary.Slots[index].Attestations.CorrectTarget++
-					if inclusionDelay <= 32 {
-						c.summary.Slots[index].Attestations.TimelyTarget++
-					} else {
-						c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
-					}
+			}
+
+			if inclusionDelay <= 5 {
+				c.summary.Slots[index].Attestations.TimelySource++
+			} else {
+				c.summary.UntimelySourceValidators = append(c.summary.UntimelySourceValidators, fault)
+			}
+
+			targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, data)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+			}
+			if targetCorrect {
+				c.summary.Slots[index].Attestations.CorrectTarget++
+				if inclusionDelay <= 32 {
+					c.summary.Slots[index].Attestations.TimelyTarget++
 				} else {
-					c.summary.IncorrectTargetValidators = append(c.summary.IncorrectTargetValidators, fault)
-					if inclusionDelay > 32 {
-						c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
-					}
+					c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
+				}
+			} else {
+				c.summary.IncorrectTargetValidators = append(c.summary.IncorrectTargetValidators, fault)
+				if inclusionDelay > 32 {
+					c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
 				}
 			}
 		}
-
-		if len(votes) == len(activeValidatorIndices) {
-			// Found them all.
-			break
-		}
 	}
 
 	return nil
@@ -424,6 +467,7 @@ func (c *command) setup(ctx context.Context) error {
 	if !isProvider {
 		return errors.New("connection does not provide beacon committees")
 	}
+	c.committeeSizes = util.NewBeaconCommitteeSizeCache(c.beaconCommitteesProvider)
 	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
 	if !isProvider {
 		return errors.New("connection does not provide beacon block headers")
@@ -0,0 +1,171 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdraw
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	format  output.Format
+
+	// Input.
+	validator            string
+	amount               phase0.Gwei
+	full                 bool
+	withdrawalPrivateKey []byte
+	dryRun               bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	executionConnection string
+	executionClient     execution.Service
+
+	// Processing.
+	consensusClient    consensusclient.Service
+	chainTime          chaintime.Service
+	validatorsProvider consensusclient.ValidatorsProvider
+	specProvider       consensusclient.SpecProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Validator   *validatorSummary `json:"validator"`
+	Checks      []*check          `json:"checks"`
+	Ready       bool              `json:"ready"`
+	Outcome     *outcome          `json:"outcome"`
+	Transaction *transaction      `json:"transaction,omitempty"`
+}
+
+// validatorSummary is the information about a validator relevant to withdrawal.
+type validatorSummary struct {
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Balance               string `json:"balance"`
+	EffectiveBalance      string `json:"effective_balance"`
+}
+
+// check is the result of a single withdrawal check.
+type check struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// outcome is the expected result of the withdrawal request, in Gwei.
+type outcome struct {
+	FullExit        bool   `json:"full_exit"`
+	RequestedAmount string `json:"requested_amount"`
+	ExpectedAmount  string `json:"expected_amount"`
+	RemainingAmount string `json:"remaining_amount"`
+}
+
+// transaction is the execution layer transaction that makes the withdrawal request.
+type transaction struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Value     string `json:"value"`
+	Data      string `json:"data"`
+	Signed    string `json:"signed,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Submitted bool   `json:"submitted"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		validator:                viper.GetString("validator"),
+		full:                     viper.GetBool("full"),
+		dryRun:                   viper.GetBool("dry-run"),
+	}
+
+	// Timeout is required.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	switch {
+	case c.full && viper.GetString("amount") != "":
+		return nil, errors.New("cannot use both --amount and --full")
+	case c.full:
+		c.amount = util.FullExitRequestAmount
+	case viper.GetString("amount") == "":
+		return nil, errors.New("amount or full is required")
+	default:
+		amount, err := string2eth.StringToGWei(viper.GetString("amount"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid amount")
+		}
+		if amount == 0 {
+			return nil, errors.New("amount must be greater than 0; use --full to exit the validator")
+		}
+		c.amount = phase0.Gwei(amount)
+	}
+
+	c.executionConnection = viper.GetString("execution-connection")
+	if c.executionConnection == "" {
+		return nil, errors.New("execution-connection is required")
+	}
+
+	if viper.GetString("withdrawal-private-key") != "" {
+		var err error
+		c.withdrawalPrivateKey, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("withdrawal-private-key"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid withdrawal private key")
+		}
+		if len(c.withdrawalPrivateKey) != 32 {
+			return nil, errors.New("withdrawal private key must be 32 bytes")
+		}
+	}
+
+	var err error
+	c.format, err = output.FromViper()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,149 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdraw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		amount phase0.Gwei
+		err    string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validator":            "1",
+				"amount":               "1 Ether",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"amount":               "1 Ether",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "validator is required",
+		},
+		{
+			name: "AmountMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "amount or full is required",
+		},
+		{
+			name: "AmountAndFull",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"amount":               "1 Ether",
+				"full":                 true,
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "cannot use both --amount and --full",
+		},
+		{
+			name: "AmountInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"amount":               "lots",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "invalid amount: failed to parse numeric value of  lots",
+		},
+		{
+			name: "AmountZero",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"amount":               "0 Ether",
+				"execution-connection": "http://localhost:8545",
+			},
+			err: "amount must be greater than 0; use --full to exit the validator",
+		},
+		{
+			name: "ExecutionConnectionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"amount":    "1 Ether",
+			},
+			err: "execution-connection is required",
+		},
+		{
+			name: "WithdrawalPrivateKeyShort",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"validator":              "1",
+				"amount":                 "1 Ether",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646",
+			},
+			err: "withdrawal private key must be 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":                "5s",
+				"validator":              "1",
+				"amount":                 "1.5 Ether",
+				"execution-connection":   "http://localhost:8545",
+				"withdrawal-private-key": "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			amount: 1500000000,
+		},
+		{
+			name: "GoodFull",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"full":                 true,
+				"execution-connection": "http://localhost:8545",
+			},
+			amount: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.amount, c.amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdraw
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// Withdrawal credential prefixes.
+const (
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
+)
+
+// parameters are the chain parameters used to check and model the withdrawal.
+type parameters struct {
+	shardCommitteePeriod phase0.Epoch
+	electraForkEpoch     phase0.Epoch
+	electraKnown         bool
+	depositChainID       uint64
+	minActivationBalance phase0.Gwei
+}
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+
+	params, err := c.obtainParameters(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, err := util.ExecutionQuantity(ctx, c.executionClient, "eth_chainId", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution chain ID")
+	}
+	currentEpoch := c.chainTime.CurrentEpoch()
+
+	c.results = &results{
+		Validator: summarise(validator),
+		Checks: []*check{
+			electraCheck(params, currentEpoch),
+			chainIDCheck(params.depositChainID, chainID),
+			activeCheck(validator),
+			credentialsCheck(validator, c.full),
+			shardCommitteePeriodCheck(validator, currentEpoch, params.shardCommitteePeriod),
+		},
+		Outcome: withdrawalOutcome(validator, c.amount, params),
+	}
+	if !c.full {
+		c.results.Checks = append(c.results.Checks, balanceCheck(validator, params))
+	}
+	if c.withdrawalPrivateKey != nil {
+		c.results.Checks = append(c.results.Checks, withdrawalKeyCheck(validator, c.withdrawalPrivateKey))
+	}
+
+	c.results.Ready = true
+	for _, check := range c.results.Checks {
+		if !check.Passed {
+			c.results.Ready = false
+		}
+	}
+	if !c.results.Ready {
+		return nil
+	}
+
+	return c.generateTransaction(ctx, validator, chainID)
+}
+
+func summarise(validator *apiv1.Validator) *validatorSummary {
+	return &validatorSummary{
+		Index:                 fmt.Sprintf("%d", validator.Index),
+		Pubkey:                fmt.Sprintf("%#x", validator.Validator.PublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+		Balance:               fmt.Sprintf("%d", validator.Balance),
+		EffectiveBalance:      fmt.Sprintf("%d", validator.Validator.EffectiveBalance),
+	}
+}
+
+func electraCheck(params *parameters, currentEpoch phase0.Epoch) *check {
+	res := &check{
+		Name: "electra",
+	}
+	switch {
+	case !params.electraKnown:
+		res.Detail = "beacon node does not know of the Electra fork"
+	case currentEpoch < params.electraForkEpoch:
+		res.Detail = fmt.Sprintf("Electra is not active until epoch %d", params.electraForkEpoch)
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("Electra active since epoch %d", params.electraForkEpoch)
+	}
+
+	return res
+}
+
+func chainIDCheck(depositChainID uint64, chainID *big.Int) *check {
+	res := &check{
+		Name: "chain ID",
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != depositChainID {
+		res.Detail = fmt.Sprintf("execution node chain ID %s does not match deposit chain ID %d", chainID.String(), depositChainID)
+		return res
+	}
+	res.Passed = true
+	res.Detail = fmt.Sprintf("both %d", depositChainID)
+
+	return res
+}
+
+func activeCheck(validator *apiv1.Validator) *check {
+	return &check{
+		Name:   "active",
+		Passed: validator.Status == apiv1.ValidatorStateActiveOngoing,
+		Detail: fmt.Sprintf("validator %d is in state %v", validator.Index, validator.Status),
+	}
+}
+
+// credentialsCheck checks the validator's credentials.  Any execution
+// credentials can request a full exit, but only compounding credentials can
+// request a partial withdrawal.
+func credentialsCheck(validator *apiv1.Validator, full bool) *check {
+	res := &check{
+		Name: "credentials",
+	}
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case compoundingWithdrawalPrefix:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("withdrawal address %s", util.WithdrawalAddress(validator).String())
+	case ethWithdrawalPrefix:
+		res.Passed = full
+		if res.Passed {
+			res.Detail = fmt.Sprintf("withdrawal address %s", util.WithdrawalAddress(validator).String())
+		} else {
+			res.Detail = "partial withdrawals require compounding (0x02) credentials; balance above 32 Ether is withdrawn automatically"
+		}
+	default:
+		res.Detail = "validator does not have execution withdrawal credentials"
+	}
+
+	return res
+}
+
+func shardCommitteePeriodCheck(validator *apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) *check {
+	res := &check{
+		Name: "shard committee period",
+	}
+	if validator.Validator.ActivationEpoch == util.FarFutureEpoch {
+		res.Detail = "validator has not been activated"
+		return res
+	}
+
+	eligibleEpoch := validator.Validator.ActivationEpoch + shardCommitteePeriod
+	res.Passed = currentEpoch >= eligibleEpoch
+	if res.Passed {
+		res.Detail = fmt.Sprintf("validator has been active since epoch %d", validator.Validator.ActivationEpoch)
+	} else {
+		res.Detail = fmt.Sprintf("validator cannot request withdrawals until epoch %d", eligibleEpoch)
+	}
+
+	return res
+}
+
+// balanceCheck checks that the validator has balance available for a partial
+// withdrawal.
+func balanceCheck(validator *apiv1.Validator, params *parameters) *check {
+	res := &check{
+		Name: "balance",
+	}
+	switch {
+	case validator.Validator.EffectiveBalance < params.minActivationBalance:
+		res.Detail = fmt.Sprintf("effective balance is below %s", string2eth.GWeiToString(uint64(params.minActivationBalance), true))
+	case validator.Balance <= params.minActivationBalance:
+		res.Detail = fmt.Sprintf("no balance above %s to withdraw", string2eth.GWeiToString(uint64(params.minActivationBalance), true))
+	default:
+		res.Passed = true
+		res.Detail = fmt.Sprintf("%s available to withdraw", string2eth.GWeiToString(uint64(validator.Balance-params.minActivationBalance), true))
+	}
+
+	return res
+}
+
+func withdrawalKeyCheck(validator *apiv1.Validator, privateKey []byte) *check {
+	res := &check{
+		Name: "withdrawal key",
+	}
+	address, err := util.ExecutionAddressFromPrivateKey(privateKey)
+	if err != nil {
+		res.Detail = err.Error()
+		return res
+	}
+	expected := util.WithdrawalAddress(validator)
+	res.Passed = bytes.Equal(address[:], expected[:])
+	if res.Passed {
+		res.Detail = fmt.Sprintf("key is for withdrawal address %s", address.String())
+	} else {
+		res.Detail = fmt.Sprintf("key is for %s, withdrawal address is %s", address.String(), expected.String())
+	}
+
+	return res
+}
+
+// withdrawalOutcome calculates the expected result of the withdrawal request.
+// A full exit withdraws the entire balance.  A partial withdrawal is limited
+// to the balance above the minimum activation balance, ignoring any partial
+// withdrawals already pending for the validator.
+func withdrawalOutcome(validator *apiv1.Validator, amount phase0.Gwei, params *parameters) *outcome {
+	if amount == util.FullExitRequestAmount {
+		return &outcome{
+			FullExit:        true,
+			RequestedAmount: fmt.Sprintf("%d", validator.Balance),
+			ExpectedAmount:  fmt.Sprintf("%d", validator.Balance),
+			RemainingAmount: "0",
+		}
+	}
+
+	available := phase0.Gwei(0)
+	if validator.Balance > params.minActivationBalance && validator.Validator.EffectiveBalance >= params.minActivationBalance {
+		available = validator.Balance - params.minActivationBalance
+	}
+	expected := amount
+	if expected > available {
+		expected = available
+	}
+
+	return &outcome{
+		RequestedAmount: fmt.Sprintf("%d", amount),
+		ExpectedAmount:  fmt.Sprintf("%d", expected),
+		RemainingAmount: fmt.Sprintf("%d", validator.Balance-expected),
+	}
+}
+
+// generateTransaction creates the transaction for the withdrawal request,
+// and signs and submits it if a withdrawal key is available.
+func (c *command) generateTransaction(ctx context.Context,
+	validator *apiv1.Validator,
+	chainID *big.Int,
+) error {
+	from := util.WithdrawalAddress(validator)
+	data := util.WithdrawalRequestData(validator.Validator.PublicKey, c.amount)
+	fee, err := util.WithdrawalRequestFee(ctx, c.executionClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain withdrawal request fee")
+	}
+
+	c.results.Transaction = &transaction{
+		From:  from.String(),
+		To:    util.WithdrawalRequestAddress.String(),
+		Value: fee.String(),
+		Data:  fmt.Sprintf("%#x", data),
+	}
+	if c.withdrawalPrivateKey == nil {
+		return nil
+	}
+
+	tx, err := util.BuildDynamicFeeTransaction(ctx, c.executionClient, chainID, from, util.WithdrawalRequestAddress, fee, data)
+	if err != nil {
+		return err
+	}
+	signed, err := util.SignDynamicFeeTransaction(tx, c.withdrawalPrivateKey)
+	if err != nil {
+		return err
+	}
+	c.results.Transaction.Signed = fmt.Sprintf("%#x", signed)
+	if c.dryRun {
+		return nil
+	}
+
+	var hash string
+	if _, err := c.executionClient.Call(ctx, "eth_sendRawTransaction", []interface{}{c.results.Transaction.Signed}, &hash); err != nil {
+		return errors.Wrap(err, "failed to submit transaction")
+	}
+	c.results.Transaction.Hash = hash
+	c.results.Transaction.Submitted = true
+
+	return nil
+}
+
+func (c *command) obtainParameters(ctx context.Context) (*parameters, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+
+	params := &parameters{
+		minActivationBalance: 32000000000,
+	}
+	tmp, exists := spec["SHARD_COMMITTEE_PERIOD"]
+	if !exists {
+		return nil, errors.New("SHARD_COMMITTEE_PERIOD not found in spec")
+	}
+	period, isPeriod := tmp.(uint64)
+	if !isPeriod {
+		return nil, errors.New("SHARD_COMMITTEE_PERIOD of unexpected type")
+	}
+	params.shardCommitteePeriod = phase0.Epoch(period)
+	depositChainID, exists := spec["DEPOSIT_CHAIN_ID"].(uint64)
+	if !exists {
+		return nil, errors.New("DEPOSIT_CHAIN_ID not found in spec")
+	}
+	params.depositChainID = depositChainID
+	if val, exists := spec["ELECTRA_FORK_EPOCH"].(uint64); exists {
+		params.electraForkEpoch = phase0.Epoch(val)
+		params.electraKnown = true
+	}
+	if val, exists := spec["MIN_ACTIVATION_BALANCE"].(uint64); exists {
+		params.minActivationBalance = phase0.Gwei(val)
+	}
+
+	return params, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the consensus node.
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.executionClient, err = util.ConnectToExecutionNode(ctx, &util.ExecutionConnectOpts{
+		Address: c.executionConnection,
+		Timeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to execution node")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	c.specProvider, isProvider = c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisTimeProvider(c.consensusClient.(consensusclient.GenesisTimeProvider)),
+		standardchaintime.WithSpecProvider(c.specProvider),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdraw
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// withdrawalAddressCredentials are 0x01 credentials for the address of the
+// private key 0x4646…46.
+var withdrawalAddressCredentials = []byte{
+	0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x9d, 0x8a, 0x62, 0xf6, 0x56, 0xa8, 0xd1, 0x61, 0x5c, 0x12,
+	0x94, 0xfd, 0x71, 0xe9, 0xcf, 0xb3, 0xe4, 0x85, 0x5a, 0x4f,
+}
+
+func testValidator(index phase0.ValidatorIndex,
+	prefix byte,
+	balance phase0.Gwei,
+	effectiveBalance phase0.Gwei,
+) *apiv1.Validator {
+	credentials := make([]byte, 32)
+	copy(credentials, withdrawalAddressCredentials)
+	credentials[0] = prefix
+	pubKey := phase0.BLSPubKey{}
+	pubKey[0] = byte(index)
+
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Status:  apiv1.ValidatorStateActiveOngoing,
+		Validator: &phase0.Validator{
+			PublicKey:             pubKey,
+			WithdrawalCredentials: credentials,
+			EffectiveBalance:      effectiveBalance,
+			ActivationEpoch:       100,
+			ExitEpoch:             util.FarFutureEpoch,
+			WithdrawableEpoch:     util.FarFutureEpoch,
+		},
+	}
+}
+
+func TestChecks(t *testing.T) {
+	params := &parameters{
+		shardCommitteePeriod: 256,
+		electraForkEpoch:     1000,
+		electraKnown:         true,
+		minActivationBalance: 32000000000,
+	}
+	eth := testValidator(1, ethWithdrawalPrefix, 32000000000, 32000000000)
+	compounding := testValidator(2, compoundingWithdrawalPrefix, 40000000000, 40000000000)
+	bls := testValidator(3, 0x00, 32000000000, 32000000000)
+	exiting := testValidator(4, ethWithdrawalPrefix, 32000000000, 32000000000)
+	exiting.Status = apiv1.ValidatorStateActiveExiting
+
+	require.Equal(t, &check{Name: "electra", Passed: true, Detail: "Electra active since epoch 1000"}, electraCheck(params, 1000))
+	require.False(t, electraCheck(params, 999).Passed)
+
+	require.Equal(t, &check{Name: "chain ID", Passed: true, Detail: "both 17000"}, chainIDCheck(17000, big.NewInt(17000)))
+	require.False(t, chainIDCheck(17000, big.NewInt(1)).Passed)
+
+	require.True(t, activeCheck(eth).Passed)
+	require.Equal(t, &check{Name: "active", Detail: "validator 4 is in state active_exiting"}, activeCheck(exiting))
+
+	require.True(t, credentialsCheck(eth, true).Passed)
+	require.Equal(t, &check{Name: "credentials", Detail: "partial withdrawals require compounding (0x02) credentials; balance above 32 Ether is withdrawn automatically"}, credentialsCheck(eth, false))
+	require.True(t, credentialsCheck(compounding, false).Passed)
+	require.True(t, credentialsCheck(compounding, true).Passed)
+	require.False(t, credentialsCheck(bls, true).Passed)
+
+	require.True(t, shardCommitteePeriodCheck(eth, 356, 256).Passed)
+	require.Equal(t, &check{Name: "shard committee period", Detail: "validator cannot request withdrawals until epoch 356"}, shardCommitteePeriodCheck(eth, 355, 256))
+
+	require.Equal(t, &check{Name: "balance", Passed: true, Detail: "8 Ether available to withdraw"}, balanceCheck(compounding, params))
+	require.Equal(t, &check{Name: "balance", Detail: "no balance above 32 Ether to withdraw"}, balanceCheck(eth, params))
+	require.Equal(t, &check{Name: "balance", Detail: "effective balance is below 32 Ether"}, balanceCheck(testValidator(5, compoundingWithdrawalPrefix, 33000000000, 31000000000), params))
+
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = 0x46
+	}
+	require.Equal(t, &check{Name: "withdrawal key", Passed: true, Detail: "key is for withdrawal address 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"}, withdrawalKeyCheck(eth, privateKey))
+	privateKey[31] = 0x01
+	require.False(t, withdrawalKeyCheck(eth, privateKey).Passed)
+}
+
+func TestWithdrawalOutcome(t *testing.T) {
+	params := &parameters{
+		minActivationBalance: 32000000000,
+	}
+
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		amount    phase0.Gwei
+		expected  *outcome
+	}{
+		{
+			name:      "Full",
+			validator: testValidator(1, ethWithdrawalPrefix, 32100000000, 32000000000),
+			amount:    util.FullExitRequestAmount,
+			expected: &outcome{
+				FullExit:        true,
+				RequestedAmount: "32100000000",
+				ExpectedAmount:  "32100000000",
+				RemainingAmount: "0",
+			},
+		},
+		{
+			name:      "Partial",
+			validator: testValidator(1, compoundingWithdrawalPrefix, 40000000000, 40000000000),
+			amount:    5000000000,
+			expected: &outcome{
+				RequestedAmount: "5000000000",
+				ExpectedAmount:  "5000000000",
+				RemainingAmount: "35000000000",
+			},
+		},
+		{
+			name:      "PartialLimited",
+			validator: testValidator(1, compoundingWithdrawalPrefix, 40000000000, 40000000000),
+			amount:    10000000000,
+			expected: &outcome{
+				RequestedAmount: "10000000000",
+				ExpectedAmount:  "8000000000",
+				RemainingAmount: "32000000000",
+			},
+		},
+		{
+			name:      "PartialNoneAvailable",
+			validator: testValidator(1, compoundingWithdrawalPrefix, 31000000000, 31000000000),
+			amount:    1000000000,
+			expected: &outcome{
+				RequestedAmount: "1000000000",
+				ExpectedAmount:  "0",
+				RemainingAmount: "31000000000",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, withdrawalOutcome(test.validator, test.amount, params))
+		})
+	}
+}
+
+// executionServer returns a server that provides canned responses to the
+// JSON-RPC methods used to build the withdrawal request transaction.
+func executionServer(t *testing.T, submitted *string) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"eth_call":                 `"0x0000000000000000000000000000000000000000000000000000000000000001"`,
+		"eth_getTransactionCount":  `"0x5"`,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"eth_getBlockByNumber":     `{"baseFeePerGas":"0x2540be400"}`,
+		"eth_estimateGas":          `"0x1d4c0"`,
+		"eth_sendRawTransaction":   `"0x1234"`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, req))
+		if req.Method == "eth_sendRawTransaction" {
+			require.NoError(t, json.Unmarshal(req.Params[0], submitted))
+		}
+		response, exists := responses[req.Method]
+		require.True(t, exists, req.Method)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + response + `}`))
+	}))
+}
+
+func TestGenerateTransaction(t *testing.T) {
+	privateKey := make([]byte, 32)
+	for i := range privateKey {
+		privateKey[i] = 0x46
+	}
+	validator := testValidator(1, compoundingWithdrawalPrefix, 40000000000, 40000000000)
+
+	tests := []struct {
+		name       string
+		privateKey []byte
+		dryRun     bool
+		signed     bool
+		submitted  bool
+	}{
+		{
+			name: "Unsigned",
+		},
+		{
+			name:       "DryRun",
+			privateKey: privateKey,
+			dryRun:     true,
+			signed:     true,
+		},
+		{
+			name:       "Submitted",
+			privateKey: privateKey,
+			signed:     true,
+			submitted:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var submitted string
+			server := executionServer(t, &submitted)
+			defer server.Close()
+
+			executionClient, err := util.ConnectToExecutionNode(context.Background(), &util.ExecutionConnectOpts{
+				Address: server.URL,
+				Timeout: 5 * time.Second,
+			})
+			require.NoError(t, err)
+
+			c := &command{
+				executionClient:      executionClient,
+				timeout:              5 * time.Second,
+				amount:               5000000000,
+				withdrawalPrivateKey: test.privateKey,
+				dryRun:               test.dryRun,
+				results:              &results{},
+			}
+			require.NoError(t, c.generateTransaction(context.Background(), validator, big.NewInt(17000)))
+
+			tx := c.results.Transaction
+			require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", tx.From)
+			require.Equal(t, "0x00000961Ef480Eb55e80D19ad83579A64c007002", tx.To)
+			require.Equal(t, "1", tx.Value)
+			require.True(t, strings.HasSuffix(tx.Data, "000000012a05f200"))
+			require.Equal(t, test.submitted, tx.Submitted)
+			if !test.signed {
+				require.Empty(t, tx.Signed)
+				return
+			}
+
+			raw, err := hex.DecodeString(strings.TrimPrefix(tx.Signed, "0x"))
+			require.NoError(t, err)
+			decoded, err := util.DecodeTransaction(raw)
+			require.NoError(t, err)
+			require.Equal(t, tx.From, decoded.From.String())
+			require.Equal(t, tx.To, decoded.To.String())
+			require.Equal(t, big.NewInt(17000), decoded.ChainID)
+			require.Equal(t, uint64(0x1d4c0), decoded.Gas)
+			if test.submitted {
+				require.Equal(t, tx.Signed, submitted)
+				require.Equal(t, "0x1234", tx.Hash)
+			} else {
+				require.Empty(t, submitted)
+			}
+		})
+	}
+}
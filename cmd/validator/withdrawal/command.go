@@ -16,6 +16,7 @@ package validatorwithdrawl
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
@@ -41,10 +42,9 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Processing.
-	consensusClient          consensusclient.Service
-	chainTime                chaintime.Service
-	maxWithdrawalsPerPayload uint64
-	maxEffectiveBalance      phase0.Gwei
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	params          *parameters
 
 	// Output.
 	res *res
@@ -77,25 +77,40 @@ func newCommand(_ context.Context) (*command, error) {
 }
 
 type res struct {
-	WithdrawalsToGo uint64
-	BlocksToGo      uint64
-	Block           uint64
-	Wait            time.Duration
-	Expected        time.Time
+	CredentialsType  byte
+	WithdrawalsToGo  uint64
+	BlocksToGo       uint64
+	Block            uint64
+	Wait             time.Duration
+	Expected         time.Time
+	WithdrawalAmount phase0.Gwei
+	FullWithdrawal   bool
+	LastWithdrawal   *lastWithdrawal
+}
+
+// lastWithdrawal is the most recent withdrawal for the validator.
+type lastWithdrawal struct {
+	Slot   phase0.Slot `json:"slot"`
+	Amount phase0.Gwei `json:"amount"`
 }
 
 type resJSON struct {
-	WithdrawalsToGo   uint64 `json:"withdrawals_to_go"`
-	BlocksToGo        uint64 `json:"blocks_to_go"`
-	Block             uint64 `json:"block"`
-	Wait              string `json:"wait"`
-	WaitSecs          uint64 `json:"wait_secs"`
-	Expected          string `json:"expected"`
-	ExpectedTimestamp int64  `json:"expected_timestamp"`
+	CredentialsType   string          `json:"credentials_type"`
+	WithdrawalsToGo   uint64          `json:"withdrawals_to_go"`
+	BlocksToGo        uint64          `json:"blocks_to_go"`
+	Block             uint64          `json:"block"`
+	Wait              string          `json:"wait"`
+	WaitSecs          uint64          `json:"wait_secs"`
+	Expected          string          `json:"expected"`
+	ExpectedTimestamp int64           `json:"expected_timestamp"`
+	WithdrawalAmount  phase0.Gwei     `json:"withdrawal_amount"`
+	FullWithdrawal    bool            `json:"full_withdrawal"`
+	LastWithdrawal    *lastWithdrawal `json:"last_withdrawal,omitempty"`
 }
 
 func (r *res) MarshalJSON() ([]byte, error) {
 	data := resJSON{
+		CredentialsType:   fmt.Sprintf("0x%02x", r.CredentialsType),
 		WithdrawalsToGo:   r.WithdrawalsToGo,
 		BlocksToGo:        r.BlocksToGo,
 		Block:             r.Block,
@@ -103,6 +118,9 @@ func (r *res) MarshalJSON() ([]byte, error) {
 		WaitSecs:          uint64(r.Wait.Round(time.Second).Seconds()),
 		Expected:          r.Expected.Format("2006-01-02T15:04:05"),
 		ExpectedTimestamp: r.Expected.Unix(),
+		WithdrawalAmount:  r.WithdrawalAmount,
+		FullWithdrawal:    r.FullWithdrawal,
+		LastWithdrawal:    r.LastWithdrawal,
 	}
 	return json.Marshal(data)
 }
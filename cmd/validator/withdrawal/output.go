@@ -17,8 +17,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 //nolint:unparam
@@ -35,5 +38,44 @@ func (c *command) output(_ context.Context) (string, error) {
 		return string(data), nil
 	}
 
-	return fmt.Sprintf("Withdrawal expected at %s in block %d", c.res.Expected.Format("2006-01-02T15:04:05"), c.res.Block), nil
+	builder := strings.Builder{}
+
+	switch c.res.CredentialsType {
+	case blsWithdrawalPrefix:
+		builder.WriteString("Withdrawal credentials: BLS (0x00)\n")
+	case ethWithdrawalPrefix:
+		builder.WriteString("Withdrawal credentials: execution address (0x01)\n")
+	case compoundingWithdrawalPrefix:
+		builder.WriteString("Withdrawal credentials: compounding (0x02)\n")
+	default:
+		builder.WriteString(fmt.Sprintf("Withdrawal credentials: unknown (0x%02x)\n", c.res.CredentialsType))
+	}
+
+	builder.WriteString(fmt.Sprintf("Next sweep at %s in block %d\n", c.res.Expected.Format("2006-01-02T15:04:05"), c.res.Block))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Time until next sweep: %s\n", c.res.Wait.Round(time.Second)))
+		builder.WriteString(fmt.Sprintf("Blocks until next sweep: %d\n", c.res.BlocksToGo))
+		builder.WriteString(fmt.Sprintf("Withdrawals before next sweep: %d\n", c.res.WithdrawalsToGo))
+	}
+
+	switch {
+	case c.res.CredentialsType == blsWithdrawalPrefix:
+		builder.WriteString("No withdrawal expected: withdrawal credentials must be set to an execution address\n")
+	case c.res.WithdrawalAmount == 0:
+		builder.WriteString("No withdrawal expected: no balance above the maximum effective balance\n")
+	case c.res.FullWithdrawal:
+		builder.WriteString(fmt.Sprintf("Full withdrawal of %s expected\n", string2eth.GWeiToString(uint64(c.res.WithdrawalAmount), true)))
+	default:
+		builder.WriteString(fmt.Sprintf("Partial withdrawal of %s expected\n", string2eth.GWeiToString(uint64(c.res.WithdrawalAmount), true)))
+	}
+
+	if c.res.CredentialsType != blsWithdrawalPrefix {
+		if c.res.LastWithdrawal == nil {
+			builder.WriteString("Last withdrawal: not found\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("Last withdrawal: %s in slot %d\n", string2eth.GWeiToString(uint64(c.res.LastWithdrawal.Amount), true), c.res.LastWithdrawal.Slot))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
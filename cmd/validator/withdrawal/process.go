@@ -20,8 +20,9 @@ import (
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
-	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -29,9 +30,23 @@ import (
 )
 
 const (
-	ethWithdrawalPrefix = 0x01
+	blsWithdrawalPrefix         = 0x00
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
 )
 
+// lastWithdrawalSearchBlocks is the maximum number of blocks to fetch when
+// searching for the last withdrawal of the validator.
+const lastWithdrawalSearchBlocks = 64
+
+// parameters are the chain parameters that define the withdrawals sweep.
+type parameters struct {
+	maxWithdrawalsPerPayload         uint64
+	maxValidatorsPerWithdrawalsSweep uint64
+	maxEffectiveBalance              phase0.Gwei
+	maxEffectiveBalanceElectra       phase0.Gwei
+}
+
 func (c *command) process(ctx context.Context) error {
 	if err := c.setup(ctx); err != nil {
 		return err
@@ -41,81 +56,194 @@ func (c *command) process(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to parse validator")
 	}
+	c.res.CredentialsType = validator.Validator.WithdrawalCredentials[0]
 
-	if validator.Validator.WithdrawalCredentials[0] != ethWithdrawalPrefix {
-		return errors.New("validator does not have suitable withdrawal credentials")
-	}
-	if validator.Balance == 0 {
-		return errors.New("validator has nothing to withdraw")
-	}
-
-	block, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, "head")
+	slot, withdrawals, found, err := util.BlockWithdrawals(ctx, c.consensusClient, "head")
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain block")
 	}
-	slot, err := block.Slot()
-	if err != nil {
-		return errors.Wrap(err, "failed to obtain block slot")
+	if !found {
+		return errors.New("head block not found")
 	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Slot is %d\n", slot)
 	}
+	if len(withdrawals) == 0 {
+		return errors.New("block without withdrawals; cannot obtain next withdrawal validator index")
+	}
 
-	validatorsMap, err := c.consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, fmt.Sprintf("%d", slot), nil)
+	validatorsMapResponse, err := c.consensusClient.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", slot)})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain validators")
 	}
+	validatorsMap := validatorsMapResponse.Data
 	validators := make([]*apiv1.Validator, len(validatorsMap))
 	for _, validator := range validatorsMap {
 		validators[validator.Index] = validator
 	}
 
-	var nextWithdrawalValidatorIndex phase0.ValidatorIndex
-	switch block.Version {
-	case spec.DataVersionCapella:
-		withdrawals := block.Capella.Message.Body.ExecutionPayload.Withdrawals
-		if len(withdrawals) == 0 {
-			return errors.New("block without withdrawals; cannot obtain next withdrawal validator index")
+	nextWithdrawalValidatorIndex := phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % len(validators))
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Next withdrawal validator index is %d\n", nextWithdrawalValidatorIndex)
+	}
+
+	c.res.WithdrawalsToGo, c.res.BlocksToGo = c.params.sweepToValidator(validators, nextWithdrawalValidatorIndex, validator.Index, c.chainTime.SlotToEpoch(slot))
+	c.res.Block = uint64(slot) + c.res.BlocksToGo
+	c.res.Expected = c.chainTime.StartOfSlot(phase0.Slot(c.res.Block))
+	c.res.Wait = time.Until(c.res.Expected)
+	c.res.WithdrawalAmount, c.res.FullWithdrawal = c.params.expectedWithdrawal(validators[validator.Index], c.chainTime.SlotToEpoch(phase0.Slot(c.res.Block)))
+
+	if c.res.CredentialsType != blsWithdrawalPrefix {
+		c.res.LastWithdrawal, err = c.lastWithdrawal(ctx, validators, nextWithdrawalValidatorIndex, validator.Index, slot)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sweepToValidator simulates the withdrawals sweep from the given validator
+// index until it reaches the target validator, returning the number of
+// withdrawals before the target validator and the number of blocks until the
+// sweep reaches it.
+//
+// This assumes that every slot contains a block, and that validator balances
+// do not change during the sweep.
+func (p *parameters) sweepToValidator(validators []*apiv1.Validator,
+	nextValidatorIndex phase0.ValidatorIndex,
+	target phase0.ValidatorIndex,
+	epoch phase0.Epoch,
+) (
+	uint64,
+	uint64,
+) {
+	withdrawalsToGo := uint64(0)
+	blocksToGo := uint64(1)
+	blockWithdrawals := uint64(0)
+	blockValidators := uint64(0)
+	for index := int(nextValidatorIndex) % len(validators); index != int(target); index = (index + 1) % len(validators) {
+		if amount, _ := p.expectedWithdrawal(validators[index], epoch); amount > 0 {
+			withdrawalsToGo++
+			blockWithdrawals++
 		}
-		nextWithdrawalValidatorIndex = phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % len(validators))
-	case spec.DataVersionDeneb:
-		withdrawals := block.Deneb.Message.Body.ExecutionPayload.Withdrawals
-		if len(withdrawals) == 0 {
-			return errors.New("block without withdrawals; cannot obtain next withdrawal validator index")
+		blockValidators++
+		if blockWithdrawals == p.maxWithdrawalsPerPayload || blockValidators == p.maxValidatorsPerWithdrawalsSweep {
+			blocksToGo++
+			blockWithdrawals = 0
+			blockValidators = 0
 		}
-		nextWithdrawalValidatorIndex = phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % len(validators))
+	}
+
+	return withdrawalsToGo, blocksToGo
+}
+
+// expectedWithdrawal returns the amount the validator is expected to withdraw
+// when the sweep reaches it, and if it is a full withdrawal.
+func (p *parameters) expectedWithdrawal(validator *apiv1.Validator, epoch phase0.Epoch) (phase0.Gwei, bool) {
+	if validator == nil || validator.Validator == nil {
+		return 0, false
+	}
+
+	var maxEffectiveBalance phase0.Gwei
+	switch validator.Validator.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix:
+		maxEffectiveBalance = p.maxEffectiveBalance
+	case compoundingWithdrawalPrefix:
+		maxEffectiveBalance = p.maxEffectiveBalanceElectra
 	default:
-		return fmt.Errorf("unhandled block version %v", block.Version)
+		return 0, false
 	}
 
+	switch {
+	case validator.Validator.WithdrawableEpoch <= epoch && validator.Balance > 0:
+		return validator.Balance, true
+	case validator.Validator.EffectiveBalance == maxEffectiveBalance && validator.Balance > maxEffectiveBalance:
+		return validator.Balance - maxEffectiveBalance, false
+	default:
+		return 0, false
+	}
+}
+
+// lastWithdrawal searches for the most recent withdrawal of the validator, by
+// estimating the slot at which the sweep last passed the validator and
+// checking blocks around it.
+// It returns nil if no withdrawal is found.
+func (c *command) lastWithdrawal(ctx context.Context,
+	validators []*apiv1.Validator,
+	nextValidatorIndex phase0.ValidatorIndex,
+	target phase0.ValidatorIndex,
+	headSlot phase0.Slot,
+) (
+	*lastWithdrawal,
+	error,
+) {
+	// The sweep passed the validator as many blocks ago as it takes to sweep
+	// from the validator to the current position.
+	_, blocksSince := c.params.sweepToValidator(validators, (target+1)%phase0.ValidatorIndex(len(validators)), nextValidatorIndex, c.chainTime.SlotToEpoch(headSlot))
+	if uint64(headSlot) < blocksSince {
+		return nil, nil
+	}
+	slot := headSlot - phase0.Slot(blocksSince)
 	if c.debug {
-		fmt.Fprintf(os.Stderr, "Next withdrawal validator index is %d\n", nextWithdrawalValidatorIndex)
+		fmt.Fprintf(os.Stderr, "Searching for last withdrawal from slot %d\n", slot)
 	}
 
-	index := int(nextWithdrawalValidatorIndex)
-	for {
-		if index == len(validators) {
-			index = 0
+	direction := 0
+	for i := 0; i < lastWithdrawalSearchBlocks; i++ {
+		if slot > headSlot || c.chainTime.SlotToEpoch(slot) < c.chainTime.CapellaInitialEpoch() {
+			return nil, nil
 		}
-		if index == int(validator.Index) {
-			break
+		_, withdrawals, found, err := util.BlockWithdrawals(ctx, c.consensusClient, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block %d", slot))
 		}
-		if validators[index].Validator.WithdrawalCredentials[0] == ethWithdrawalPrefix &&
-			validators[index].Validator.EffectiveBalance == c.maxEffectiveBalance {
-			c.res.WithdrawalsToGo++
+		if !found || len(withdrawals) == 0 {
+			// Carry on in the same direction.
+			if direction == 0 {
+				direction = -1
+			}
+			slot = phase0.Slot(int64(slot) + int64(direction))
+			continue
 		}
-		index++
+
+		for _, withdrawal := range withdrawals {
+			if withdrawal.ValidatorIndex == target {
+				return &lastWithdrawal{
+					Slot:   slot,
+					Amount: withdrawal.Amount,
+				}, nil
+			}
+		}
+
+		newDirection := sweepDirection(withdrawals, target, len(validators))
+		if newDirection == 0 || (direction != 0 && newDirection != direction) {
+			// The sweep passed the validator without a withdrawal.
+			return nil, nil
+		}
+		direction = newDirection
+		slot = phase0.Slot(int64(slot) + int64(direction))
 	}
 
-	c.res.BlocksToGo = c.res.WithdrawalsToGo / c.maxWithdrawalsPerPayload
-	if c.res.WithdrawalsToGo%c.maxWithdrawalsPerPayload != 0 {
-		c.res.BlocksToGo++
+	return nil, nil
+}
+
+// sweepDirection returns the direction in which to search for blocks to find
+// the withdrawal for the target validator, given the withdrawals of a block:
+// 1 if the sweep had yet to reach the validator, -1 if it had already passed
+// it, and 0 if the block's sweep covered the validator.
+func sweepDirection(withdrawals []*capella.Withdrawal, target phase0.ValidatorIndex, validators int) int {
+	first := int(withdrawals[0].ValidatorIndex)
+	last := int(withdrawals[len(withdrawals)-1].ValidatorIndex)
+	toTarget := (int(target) - first + validators) % validators
+	if toTarget <= (last-first+validators)%validators {
+		return 0
+	}
+	if toTarget < validators/2 {
+		return 1
 	}
-	c.res.Block = uint64(slot) + c.res.BlocksToGo
-	c.res.Expected = c.chainTime.StartOfSlot(phase0.Slot(c.res.Block))
-	c.res.Wait = time.Until(c.res.Expected)
 
-	return nil
+	return -1
 }
 
 func (c *command) setup(ctx context.Context) error {
@@ -140,21 +268,29 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create chaintime service")
 	}
 
-	spec, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx)
+	specResponse, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 
-	if val, exists := spec["MAX_WITHDRAWALS_PER_PAYLOAD"]; !exists {
-		c.maxWithdrawalsPerPayload = 16
-	} else {
-		c.maxWithdrawalsPerPayload = val.(uint64)
+	c.params = &parameters{
+		maxWithdrawalsPerPayload:         16,
+		maxValidatorsPerWithdrawalsSweep: 16384,
+		maxEffectiveBalance:              32000000000,
+		maxEffectiveBalanceElectra:       2048000000000,
 	}
-
-	if val, exists := spec["MAX_EFFECTIVE_BALANCE"]; !exists {
-		c.maxEffectiveBalance = 32000000000
-	} else {
-		c.maxEffectiveBalance = phase0.Gwei(val.(uint64))
+	if val, exists := spec["MAX_WITHDRAWALS_PER_PAYLOAD"].(uint64); exists {
+		c.params.maxWithdrawalsPerPayload = val
+	}
+	if val, exists := spec["MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP"].(uint64); exists {
+		c.params.maxValidatorsPerWithdrawalsSweep = val
+	}
+	if val, exists := spec["MAX_EFFECTIVE_BALANCE"].(uint64); exists {
+		c.params.maxEffectiveBalance = phase0.Gwei(val)
+	}
+	if val, exists := spec["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); exists {
+		c.params.maxEffectiveBalanceElectra = phase0.Gwei(val)
 	}
 
 	return nil
@@ -0,0 +1,224 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawl
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidator(index phase0.ValidatorIndex, prefix byte, balance phase0.Gwei, effectiveBalance phase0.Gwei, withdrawableEpoch phase0.Epoch) *apiv1.Validator {
+	withdrawalCredentials := make([]byte, 32)
+	withdrawalCredentials[0] = prefix
+
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Validator: &phase0.Validator{
+			WithdrawalCredentials: withdrawalCredentials,
+			EffectiveBalance:      effectiveBalance,
+			WithdrawableEpoch:     withdrawableEpoch,
+		},
+	}
+}
+
+func TestExpectedWithdrawal(t *testing.T) {
+	params := &parameters{
+		maxEffectiveBalance:        32000000000,
+		maxEffectiveBalanceElectra: 2048000000000,
+	}
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		amount    phase0.Gwei
+		full      bool
+	}{
+		{
+			name:      "BLS",
+			validator: testValidator(0, 0x00, 32100000000, 32000000000, farFuture),
+		},
+		{
+			name:      "Partial",
+			validator: testValidator(0, 0x01, 32100000000, 32000000000, farFuture),
+			amount:    100000000,
+		},
+		{
+			name:      "NotMaxEffectiveBalance",
+			validator: testValidator(0, 0x01, 31500000000, 31000000000, farFuture),
+		},
+		{
+			name:      "Full",
+			validator: testValidator(0, 0x01, 31000000000, 31000000000, 5),
+			amount:    31000000000,
+			full:      true,
+		},
+		{
+			name:      "CompoundingBelowMax",
+			validator: testValidator(0, 0x02, 64100000000, 64000000000, farFuture),
+		},
+		{
+			name:      "CompoundingPartial",
+			validator: testValidator(0, 0x02, 2048500000000, 2048000000000, farFuture),
+			amount:    500000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, full := params.expectedWithdrawal(test.validator, 10)
+			require.Equal(t, test.amount, amount)
+			require.Equal(t, test.full, full)
+		})
+	}
+}
+
+func TestSweepToValidator(t *testing.T) {
+	params := &parameters{
+		maxWithdrawalsPerPayload:         2,
+		maxValidatorsPerWithdrawalsSweep: 3,
+		maxEffectiveBalance:              32000000000,
+		maxEffectiveBalanceElectra:       2048000000000,
+	}
+	farFuture := phase0.Epoch(0xffffffffffffffff)
+
+	validators := []*apiv1.Validator{
+		// Partially withdrawable.
+		testValidator(0, 0x01, 32100000000, 32000000000, farFuture),
+		// BLS credentials.
+		testValidator(1, 0x00, 32100000000, 32000000000, farFuture),
+		// Partially withdrawable.
+		testValidator(2, 0x01, 32200000000, 32000000000, farFuture),
+		// Partially withdrawable.
+		testValidator(3, 0x01, 32300000000, 32000000000, farFuture),
+		// Not at maximum effective balance.
+		testValidator(4, 0x01, 31500000000, 31000000000, farFuture),
+		// Not at maximum effective balance.
+		testValidator(5, 0x02, 33000000000, 33000000000, farFuture),
+		// Partially withdrawable.
+		testValidator(6, 0x01, 32100000000, 32000000000, farFuture),
+	}
+
+	tests := []struct {
+		name            string
+		next            phase0.ValidatorIndex
+		target          phase0.ValidatorIndex
+		withdrawalsToGo uint64
+		blocksToGo      uint64
+	}{
+		{
+			name:            "Next",
+			next:            2,
+			target:          2,
+			withdrawalsToGo: 0,
+			blocksToGo:      1,
+		},
+		{
+			name:            "SameBlock",
+			next:            0,
+			target:          2,
+			withdrawalsToGo: 1,
+			blocksToGo:      1,
+		},
+		{
+			name:            "PayloadFull",
+			next:            0,
+			target:          3,
+			withdrawalsToGo: 2,
+			blocksToGo:      2,
+		},
+		{
+			name:            "SweepBound",
+			next:            4,
+			target:          0,
+			withdrawalsToGo: 1,
+			blocksToGo:      2,
+		},
+		{
+			name:            "Wrap",
+			next:            6,
+			target:          3,
+			withdrawalsToGo: 3,
+			blocksToGo:      2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withdrawalsToGo, blocksToGo := params.sweepToValidator(validators, test.next, test.target, 10)
+			require.Equal(t, test.withdrawalsToGo, withdrawalsToGo)
+			require.Equal(t, test.blocksToGo, blocksToGo)
+		})
+	}
+}
+
+func TestSweepDirection(t *testing.T) {
+	withdrawals := func(indices ...phase0.ValidatorIndex) []*capella.Withdrawal {
+		res := make([]*capella.Withdrawal, 0, len(indices))
+		for _, index := range indices {
+			res = append(res, &capella.Withdrawal{ValidatorIndex: index})
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		withdrawals []*capella.Withdrawal
+		target      phase0.ValidatorIndex
+		direction   int
+	}{
+		{
+			name:        "Covered",
+			withdrawals: withdrawals(100, 105, 110),
+			target:      107,
+			direction:   0,
+		},
+		{
+			name:        "Ahead",
+			withdrawals: withdrawals(100, 105, 110),
+			target:      200,
+			direction:   1,
+		},
+		{
+			name:        "Behind",
+			withdrawals: withdrawals(100, 105, 110),
+			target:      50,
+			direction:   -1,
+		},
+		{
+			name:        "CoveredWrap",
+			withdrawals: withdrawals(995, 2),
+			target:      999,
+			direction:   0,
+		},
+		{
+			name:        "AheadWrap",
+			withdrawals: withdrawals(990, 995),
+			target:      10,
+			direction:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.direction, sweepDirection(test.withdrawals, test.target, 1000))
+		})
+	}
+}
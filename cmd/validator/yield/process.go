@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -48,10 +49,11 @@ var (
 
 // calculateYield calculates yield from the number of active validators.
 func (c *command) calculateYield(ctx context.Context) error {
-	spec, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx)
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return err
 	}
+	spec := specResponse.Data
 
 	tmp, exists := spec["BASE_REWARD_FACTOR"]
 	if !exists {
@@ -143,10 +145,11 @@ func (c *command) setup(ctx context.Context) error {
 			return errors.Wrap(err, "failed to parse epoch")
 		}
 
-		validators, err := validatorsProvider.Validators(ctx, fmt.Sprintf("%d", chainTime.FirstSlotOfEpoch(epoch)), nil)
+		validatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: fmt.Sprintf("%d", chainTime.FirstSlotOfEpoch(epoch))})
 		if err != nil {
 			return err
 		}
+		validators := validatorsResponse.Data
 
 		activeValidators := decimal.Zero
 		activeValidatorBalance := decimal.Zero
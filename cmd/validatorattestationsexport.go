@@ -0,0 +1,69 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorattestationsexport "github.com/wealdtech/ethdo/cmd/validator/attestations/export"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var validatorAttestationsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the attestation history of a validator",
+	Long: `Export the attestation of a validator for each of its duties over a range of epochs.  For example:
+
+    ethdo validator attestations export --validator=Validators/1 --from-epoch=12000 --to-epoch=12100 --output=ndjson
+
+Each attestation is reported with its slot and committee, the slot at which it was included and whether its head, target and source votes were correct and timely.  Attestations that were not included are reported as such.  Blocks are fetched once and shared across duties, so ranges of many epochs require a single pass over the chain.
+
+--output=ndjson provides one attestation per line and --output=csv one per row, for analysis by external tools.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "ndjson,csv"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorattestationsexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorAttestationsCmd.AddCommand(validatorAttestationsExportCmd)
+	validatorFlags(validatorAttestationsExportCmd)
+	validatorAttestationsExportCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
+	validatorAttestationsExportCmd.Flags().String("from-epoch", "", "the first epoch of the range to export")
+	validatorAttestationsExportCmd.Flags().String("to-epoch", "", "the last epoch of the range to export (defaults to current)")
+}
+
+func validatorAttestationsExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+}
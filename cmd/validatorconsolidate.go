@@ -0,0 +1,73 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorconsolidate "github.com/wealdtech/ethdo/cmd/validator/consolidate"
+)
+
+var validatorConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Consolidate one validator into another",
+	Long: `Request the consolidation of a source validator into a target validator, as per EIP-7251.  For example:
+
+    ethdo validator consolidate --source-validator=1234 --target-validator=5678 --execution-connection=http://localhost:8545 --withdrawal-private-key=0x...
+
+The target validator must have compounding (0x02) withdrawal credentials, and the source validator must have execution withdrawal credentials and have passed the shard committee period.  The command reports the expected balance and effective balance of the target validator after consolidation.
+
+The consolidation request is a transaction sent from the source validator's withdrawal address.  If --withdrawal-private-key is supplied the transaction is signed and submitted to the execution node, unless --dry-run is supplied in which case the signed transaction is output.  Otherwise the details of the transaction are output so that it can be sent from a wallet.
+
+In quiet mode this will return 0 if the validators can be consolidated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorconsolidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorConsolidateCmd)
+	validatorFlags(validatorConsolidateCmd)
+	validatorConsolidateCmd.Flags().String("source-validator", "", "Validator to consolidate from")
+	validatorConsolidateCmd.Flags().String("target-validator", "", "Validator to consolidate into")
+	validatorConsolidateCmd.Flags().String("withdrawal-private-key", "", "Private key of the source validator's withdrawal address, to sign the consolidation request")
+	validatorConsolidateCmd.Flags().Bool("dry-run", false, "Output the signed consolidation request rather than submitting it")
+}
+
+func validatorConsolidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("source-validator", cmd.Flags().Lookup("source-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-validator", cmd.Flags().Lookup("target-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-private-key", cmd.Flags().Lookup("withdrawal-private-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+}
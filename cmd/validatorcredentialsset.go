@@ -22,8 +22,9 @@ import (
 )
 
 var validatorCredentialsSetCmd = &cobra.Command{
-	Use:   "set",
-	Short: "Set withdrawal credentials for an Ethereum consensus validator",
+	Use:     "set",
+	Aliases: []string{"generate"},
+	Short:   "Set withdrawal credentials for an Ethereum consensus validator",
 	Long: `Set withdrawal credentials for an Ethereum consensus validator via a "change credentials" operation.  For example:
 
     ethdo validator credentials set --validator=primary/validator --withdrawal-address=0x00...13 --private-key=0x00...1f
@@ -36,6 +37,7 @@ The validator account can be specified in one of a number of ways:
   - mnemonic and withdrawal private key using --mnemonic and --private-key; this will generate all applicable operations
   - validator and withdrawal private key using --validator and --private-key; this will generate a single operation
   - account and withdrawal account using --account and --withdrawal-account; this will generate a single operation
+  - mnemonic using --mnemonic and --from-seed-phrase-scan; this will search the path templates given by --scan-paths for withdrawal keys that match validators' BLS withdrawal credentials and generate all applicable operations
 
 In quiet mode this will return 0 if the credentials operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -61,9 +63,14 @@ func init() {
 	validatorCredentialsSetCmd.Flags().String("withdrawal-account", "", "Account with which the validator's withdrawal credentials were set")
 	validatorCredentialsSetCmd.Flags().String("withdrawal-address", "", "Execution address to which to direct withdrawals")
 	validatorCredentialsSetCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the credentials change operation (reads from change-operations.json if not present)")
+	validatorCredentialsSetCmd.Flags().Bool("allow-network-mismatch", false, "Broadcast operations even if the beacon node is on a different network to that for which they were generated")
+	validatorCredentialsSetCmd.Flags().Bool("from-seed-phrase-scan", false, "Scan the mnemonic across path templates for withdrawal keys matching BLS withdrawal credentials")
+	validatorCredentialsSetCmd.Flags().StringSlice("scan-paths", nil, "Path templates to scan with --from-seed-phrase-scan, containing {index} for the scanned index")
+	validatorCredentialsSetCmd.Flags().Int("scan-gap-limit", 1024, "Number of indices without a match after which to stop scanning a path template")
 	validatorCredentialsSetCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorCredentialsSetCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
 	validatorCredentialsSetCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
+	validatorCredentialsSetCmd.Flags().String("network", "", "Network for which to use bundled chain information when offline (mainnet, holesky, hoodi, sepolia, goerli)")
 }
 
 func validatorCredentialsSetBindings(cmd *cobra.Command) {
@@ -76,12 +83,24 @@ func validatorCredentialsSetBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("signed-operations", cmd.Flags().Lookup("signed-operations")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("allow-network-mismatch", cmd.Flags().Lookup("allow-network-mismatch")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("withdrawal-account", cmd.Flags().Lookup("withdrawal-account")); err != nil {
 		panic(err)
 	}
 	if err := viper.BindPFlag("withdrawal-address", cmd.Flags().Lookup("withdrawal-address")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-seed-phrase-scan", cmd.Flags().Lookup("from-seed-phrase-scan")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("scan-paths", cmd.Flags().Lookup("scan-paths")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("scan-gap-limit", cmd.Flags().Lookup("scan-gap-limit")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
 		panic(err)
 	}
@@ -91,4 +110,7 @@ func validatorCredentialsSetBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("genesis-validators-root", cmd.Flags().Lookup("genesis-validators-root")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,83 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorcredentialssetcompounding "github.com/wealdtech/ethdo/cmd/validator/credentials/set/compounding"
+)
+
+var validatorCredentialsSetCompoundingCmd = &cobra.Command{
+	Use:   "compounding",
+	Short: "Switch a validator to compounding withdrawal credentials",
+	Long: `Request that a validator's withdrawal credentials change from execution (0x01) to compounding (0x02), as per EIP-7251.  For example:
+
+    ethdo validator credentials set compounding --validator=1234 --execution-connection=http://localhost:8545 --withdrawal-private-key=0x...
+
+The request is a transaction sent from the validator's withdrawal address.  If --withdrawal-private-key is supplied the transaction is signed and submitted to the execution node, unless --dry-run is supplied in which case the signed transaction is output.  Otherwise the details of the transaction are output so that it can be sent from a wallet.
+
+The request can also be signed on an offline computer.  --prepare-offline writes the unsigned transaction to compounding-preparation.json; on the offline computer --offline signs it with --withdrawal-private-key and writes compounding-operations.json; back online --signed-operations checks and submits the signed transaction.
+
+Once the change is processed any balance above 32 Ether is queued as a pending deposit, and the validator's effective balance can grow up to 2048 Ether.
+
+In quiet mode this will return 0 if the request has been generated (and successfully submitted if online), otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorcredentialssetcompounding.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCredentialsSetCmd.AddCommand(validatorCredentialsSetCompoundingCmd)
+	validatorCredentialsFlags(validatorCredentialsSetCompoundingCmd)
+	validatorCredentialsSetCompoundingCmd.Flags().String("validator", "", "Validator to switch to compounding credentials")
+	validatorCredentialsSetCompoundingCmd.Flags().String("withdrawal-private-key", "", "Private key of the validator's withdrawal address, to sign the request")
+	validatorCredentialsSetCompoundingCmd.Flags().Bool("dry-run", false, "Output the signed request rather than submitting it")
+	validatorCredentialsSetCompoundingCmd.Flags().Bool("prepare-offline", false, "Create compounding-preparation.json for offline signing")
+	validatorCredentialsSetCompoundingCmd.Flags().Bool("offline", false, "Sign the request in compounding-preparation.json without connecting to any node")
+	validatorCredentialsSetCompoundingCmd.Flags().String("signed-operations", "", "Signed request as created by --offline, either JSON or a filename, to check and submit")
+}
+
+func validatorCredentialsSetCompoundingBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-private-key", cmd.Flags().Lookup("withdrawal-private-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("prepare-offline", cmd.Flags().Lookup("prepare-offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signed-operations", cmd.Flags().Lookup("signed-operations")); err != nil {
+		panic(err)
+	}
+}
@@ -30,6 +30,10 @@ var validatorDepositDataCmd = &cobra.Command{
 
 If validatoraccount is provided with an account path it will generate deposit data for all matching accounts.
 
+With --top-up deposit data is generated to add funds to an existing validator, with the public key and withdrawal credentials obtained from the chain.  For example:
+
+    ethdo validator depositdata --top-up --validator=1234 --depositvalue="10 Ether"
+
 The information generated can be passed to ethereal to create a deposit from the Ethereum 1 chain.
 
 In quiet mode this will return 0 if the data can be generated correctly, otherwise 1.`,
@@ -53,10 +57,13 @@ func init() {
 	validatorDepositDataCmd.Flags().String("withdrawalaccount", "", "Account to which the validator funds will be withdrawn")
 	validatorDepositDataCmd.Flags().String("withdrawalpubkey", "", "Public key of the account to which the validator funds will be withdrawn")
 	validatorDepositDataCmd.Flags().String("withdrawaladdress", "", "Ethereum 1 address of the account to which the validator funds will be withdrawn")
+	validatorDepositDataCmd.Flags().Bool("compounding", false, "Generate compounding (0x02) withdrawal credentials for the withdrawal address")
 	validatorDepositDataCmd.Flags().String("depositvalue", "", "Value of the amount to be deposited")
 	validatorDepositDataCmd.Flags().Bool("raw", false, "Print raw deposit data transaction data")
 	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use mainnet value)")
 	validatorDepositDataCmd.Flags().Bool("launchpad", false, "Print launchpad-compatible JSON")
+	validatorDepositDataCmd.Flags().Bool("top-up", false, "Generate deposit data to top up an existing validator")
+	validatorDepositDataCmd.Flags().String("validator", "", "Validator to top up, as an index, public key or account")
 }
 
 func validatorDepositdataBindings(cmd *cobra.Command) {
@@ -72,6 +79,9 @@ func validatorDepositdataBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("withdrawaladdress", cmd.Flags().Lookup("withdrawaladdress")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("compounding", cmd.Flags().Lookup("compounding")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("depositvalue", cmd.Flags().Lookup("depositvalue")); err != nil {
 		panic(err)
 	}
@@ -84,4 +94,10 @@ func validatorDepositdataBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("launchpad", cmd.Flags().Lookup("launchpad")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("top-up", cmd.Flags().Lookup("top-up")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
 }
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -38,7 +39,15 @@ The validator and key can be specified in one of a number of ways:
   - validator private key using --private-key
   - validator account using --validator
 
-In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
+From Capella onwards exits are signed with the Capella fork version (EIP-7044), so that they remain valid across all later forks; before Capella the current fork version is used.  The fork version and domain used are reported when generating the exits, and the fork version can be overridden with --domain-fork-version.
+
+Exits for a batch of validators can be generated with --validators-file, a file containing one validator per line as an index, public key or account, or with --wallet to exit all validators in a wallet.  Keys for validators supplied by index or public key are obtained from the wallet given by --wallet or from --mnemonic.  Each validator in the batch is reported as generated, broadcast, skipped (if it is not an active validator) or failed, and a failure for one validator does not stop the others.  With --dry-run each signed exit is written to its own file, named exit-<index>.json, rather than being broadcast; these files can later be broadcast with --broadcast-file.
+
+Exits generated earlier, for example on an offline machine, can be broadcast with --broadcast-file.  This takes either a single file or a directory of files, each containing a signed exit or an array of signed exits.  Each exit is verified and checked against the current state of the chain before it is broadcast, and a failure for one exit does not stop the others.
+
+Once broadcast, the exits can be tracked with --watch.  This reports when each exit is seen in the beacon node's operation pool, when it is included in a block, and when the validator obtains its exit epoch, until all exits are confirmed or --watch-timeout is reached.
+
+In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online, and confirmed if watching), otherwise 1.  For a batch this will return 0 only if no exit in the batch failed.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorexit.Run(cmd)
 		if err != nil {
@@ -61,9 +70,22 @@ func init() {
 	validatorExitCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
 	validatorExitCmd.Flags().String("validator", "", "Validator to exit")
 	validatorExitCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the exit operations (reads from exit-operations.json if not present)")
+	validatorExitCmd.Flags().Bool("allow-network-mismatch", false, "Broadcast operations even if the beacon node is on a different network to that for which they were generated")
 	validatorExitCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
+	validatorExitCmd.Flags().String("domain-fork-version", "", "Fork version with which to build the signing domain (overrides selecting it from chain information)")
 	validatorExitCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
+	if err := validatorExitCmd.Flags().MarkDeprecated("fork-version", "use --domain-fork-version"); err != nil {
+		panic(err)
+	}
 	validatorExitCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
+	validatorExitCmd.Flags().String("network", "", "Network for which to use bundled chain information when offline (mainnet, holesky, hoodi, sepolia, goerli)")
+	validatorExitCmd.Flags().Bool("watch", false, "Watch broadcast exits until they are confirmed")
+	validatorExitCmd.Flags().Duration("watch-timeout", time.Hour, "Time after which to stop watching broadcast exits")
+	validatorExitCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the validator is a member, as JSON or files containing JSON")
+	validatorExitCmd.Flags().String("validators-file", "", "File containing validators to exit, one per line (index, public key or account)")
+	validatorExitCmd.Flags().String("wallet", "", "Wallet whose validators to exit, or that holds the keys for the validators in validators-file")
+	validatorExitCmd.Flags().Bool("dry-run", false, "Write each signed exit to its own file rather than broadcasting it")
+	validatorExitCmd.Flags().String("broadcast-file", "", "File, or directory of files, containing signed exits to broadcast")
 }
 
 func validatorExitBindings(cmd *cobra.Command) {
@@ -79,13 +101,43 @@ func validatorExitBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("signed-operations", cmd.Flags().Lookup("signed-operations")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("allow-network-mismatch", cmd.Flags().Lookup("allow-network-mismatch")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("domain-fork-version", cmd.Flags().Lookup("domain-fork-version")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("fork-version", cmd.Flags().Lookup("fork-version")); err != nil {
 		panic(err)
 	}
 	if err := viper.BindPFlag("genesis-validators-root", cmd.Flags().Lookup("genesis-validators-root")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("network", cmd.Flags().Lookup("network")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("watch", cmd.Flags().Lookup("watch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("watch-timeout", cmd.Flags().Lookup("watch-timeout")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators-file", cmd.Flags().Lookup("validators-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("wallet", cmd.Flags().Lookup("wallet")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("broadcast-file", cmd.Flags().Lookup("broadcast-file")); err != nil {
+		panic(err)
+	}
 }
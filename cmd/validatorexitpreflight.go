@@ -0,0 +1,59 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorexitpreflight "github.com/wealdtech/ethdo/cmd/validator/exit/preflight"
+)
+
+var validatorExitPreflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check that a validator is ready to exit",
+	Long: `Check that a validator is ready to exit, without generating an exit operation.  For example:
+
+    ethdo validator exit preflight --validator=primary/validator
+
+The preflight confirms that the validator is active, has passed the shard committee period, is not slashed and is not already exiting.  It also reports the source of the key that will sign the exit and the fork version used for the signature domain.
+
+In quiet mode this will return 0 if the validator is ready to exit, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorexitpreflight.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorExitCmd.AddCommand(validatorExitPreflightCmd)
+	validatorFlags(validatorExitPreflightCmd)
+	validatorExitPreflightCmd.Flags().String("validator", "", "Validator to check")
+}
+
+func validatorExitPreflightBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
@@ -31,6 +32,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
 	string2eth "github.com/wealdtech/go-string2eth"
 )
 
@@ -41,7 +43,10 @@ var validatorInfoCmd = &cobra.Command{
 
     ethdo validator info --validator=primary/validator
 
+With --watch the validator's status, balance and proposals are shown again each epoch, with changes marked.  Alerts can be raised when watching with --alert-decreasing-epochs, --alert-effective-balance and --alert-below-ceiling; each alert is shown once when raised, and once when cleared after its condition has not held for --alert-clear-epochs epochs.
+
 In quiet mode this will return 0 if the validator information can be obtained, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "csv"},
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
@@ -58,10 +63,25 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			os.Exit(_exitFailure)
 		}
 
+		format, err := output.FromViper()
+		errCheck(err, "Invalid output format")
+		csvOutput := format == output.CSV
+		balanceFormat, err := output.BalanceFormatFromViper()
+		errCheck(err, "Invalid balance format")
+		assert(!(csvOutput && viper.GetBool("watch")), "watch cannot be supplied with CSV output")
+		alertRules, err := validatorInfoAlertRules()
+		errCheck(err, "Invalid alert rules")
+		assert(!alertRules.enabled() || viper.GetBool("watch"), "alerts require watch")
+		var fiat *output.Fiat
+		if !csvOutput && !viper.GetBool("quiet") && !viper.GetBool("watch") {
+			fiat, err = util.FiatFromViper(ctx, time.Time{})
+			errCheck(err, "Failed to obtain fiat price")
+		}
+
 		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), "head")
 		errCheck(err, "Failed to obtain validator")
 
-		if viper.GetBool("verbose") {
+		if viper.GetBool("verbose") && !csvOutput {
 			network, err := util.Network(ctx, eth2Client)
 			errCheck(err, "Failed to obtain network")
 			outputIf(viper.GetBool("debug"), fmt.Sprintf("Network is %s", network))
@@ -70,7 +90,7 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 				deposits, totalDeposited, err := graphData(network, pubKey[:])
 				if err == nil && deposits > 0 {
 					fmt.Printf("Number of deposits: %d\n", deposits)
-					fmt.Printf("Total deposited: %s\n", string2eth.GWeiToString(uint64(totalDeposited), true))
+					fmt.Printf("Total deposited: %s\n", validatorInfoBalance(balanceFormat, fiat, uint64(totalDeposited)))
 				}
 			}
 		}
@@ -79,6 +99,19 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			os.Exit(_exitSuccess)
 		}
 
+		if viper.GetBool("watch") {
+			err := validatorInfoWatch(ctx, eth2Client, validator.Index, balanceFormat, alertRules)
+			errCheck(err, "Failed to watch validator")
+			os.Exit(_exitSuccess)
+		}
+
+		if csvOutput {
+			res, err := output.CSVString(validatorInfoCSVHeader, [][]string{validatorInfoCSVRecord(validator)})
+			errCheck(err, "Failed to generate CSV")
+			fmt.Println(res)
+			os.Exit(_exitSuccess)
+		}
+
 		if validator.Status.IsPending() || validator.Status.HasActivated() {
 			fmt.Printf("Index: %d\n", validator.Index)
 		}
@@ -98,9 +131,9 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 		case api.ValidatorStateExitedUnslashed, api.ValidatorStateExitedSlashed:
 			fmt.Printf("Withdrawable epoch: %d\n", validator.Validator.WithdrawableEpoch)
 		}
-		fmt.Printf("Balance: %s\n", string2eth.GWeiToString(uint64(validator.Balance), true))
+		fmt.Printf("Balance: %s\n", validatorInfoBalance(balanceFormat, fiat, uint64(validator.Balance)))
 		if validator.Status.IsActive() {
-			fmt.Printf("Effective balance: %s\n", string2eth.GWeiToString(uint64(validator.Validator.EffectiveBalance), true))
+			fmt.Printf("Effective balance: %s\n", validatorInfoBalance(balanceFormat, fiat, uint64(validator.Validator.EffectiveBalance)))
 		}
 		if viper.GetBool("verbose") {
 			fmt.Printf("Withdrawal credentials: %#x\n", validator.Validator.WithdrawalCredentials)
@@ -110,6 +143,79 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 	},
 }
 
+// validatorInfoBalance formats a balance, with its fiat equivalent if requested.
+func validatorInfoBalance(balanceFormat *output.BalanceFormat, fiat *output.Fiat, balance uint64) string {
+	if fiat != nil {
+		return fmt.Sprintf("%s (%s)", balanceFormat.Gwei(balance), fiat.Gwei(int64(balance)))
+	}
+
+	return balanceFormat.Gwei(balance)
+}
+
+// validatorInfoAlertRules returns the alert rules for watching a validator.
+func validatorInfoAlertRules() (*validatorBalanceAlertRules, error) {
+	rules := &validatorBalanceAlertRules{
+		decreasingEpochs: viper.GetUint64("alert-decreasing-epochs"),
+		belowCeiling:     viper.GetBool("alert-below-ceiling"),
+		clearEpochs:      viper.GetUint64("alert-clear-epochs"),
+	}
+	if viper.GetString("alert-effective-balance") != "" {
+		minEffectiveBalance, err := string2eth.StringToGWei(viper.GetString("alert-effective-balance"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid alert effective balance")
+		}
+		rules.minEffectiveBalance = spec.Gwei(minEffectiveBalance)
+	}
+	if rules.clearEpochs == 0 {
+		return nil, errors.New("alert clear epochs must be at least 1")
+	}
+
+	return rules, nil
+}
+
+// farFutureEpoch is the epoch used for validator events that have not been scheduled.
+const farFutureEpoch = spec.Epoch(0xffffffffffffffff)
+
+// validatorInfoCSVHeader is the header of the CSV output.
+var validatorInfoCSVHeader = []string{
+	"index",
+	"public_key",
+	"status",
+	"balance",
+	"effective_balance",
+	"activation_eligibility_epoch",
+	"activation_epoch",
+	"exit_epoch",
+	"withdrawable_epoch",
+	"slashed",
+	"withdrawal_credentials",
+}
+
+// validatorInfoCSVRecord returns the CSV record for a validator.  Epochs that
+// have not been set are left empty.
+func validatorInfoCSVRecord(validator *api.Validator) []string {
+	epoch := func(epoch spec.Epoch) string {
+		if epoch == farFutureEpoch {
+			return ""
+		}
+		return fmt.Sprintf("%d", epoch)
+	}
+
+	return []string{
+		fmt.Sprintf("%d", validator.Index),
+		fmt.Sprintf("%#x", validator.Validator.PublicKey),
+		validator.Status.String(),
+		fmt.Sprintf("%d", validator.Balance),
+		fmt.Sprintf("%d", validator.Validator.EffectiveBalance),
+		epoch(validator.Validator.ActivationEligibilityEpoch),
+		epoch(validator.Validator.ActivationEpoch),
+		epoch(validator.Validator.ExitEpoch),
+		epoch(validator.Validator.WithdrawableEpoch),
+		fmt.Sprintf("%t", validator.Validator.Slashed),
+		fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+	}
+}
+
 // graphData returns data from the graph about number and amount of deposits.
 func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error) {
 	subgraph := ""
@@ -171,6 +277,11 @@ func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error
 func init() {
 	validatorCmd.AddCommand(validatorInfoCmd)
 	validatorInfoCmd.Flags().String("validator", "", "Public key for which to obtain status")
+	validatorInfoCmd.Flags().Bool("watch", false, "Refresh the information each epoch")
+	validatorInfoCmd.Flags().Uint64("alert-decreasing-epochs", 0, "When watching, alert if the balance decreases for this many consecutive epochs")
+	validatorInfoCmd.Flags().String("alert-effective-balance", "", "When watching, alert if the effective balance drops below this value (e.g. 32ether)")
+	validatorInfoCmd.Flags().Bool("alert-below-ceiling", false, "When watching, alert if the effective balance is below the maximum for the validator's withdrawal credentials")
+	validatorInfoCmd.Flags().Uint64("alert-clear-epochs", 2, "Number of consecutive epochs for which an alert's condition must not hold before the alert is cleared")
 	validatorFlags(validatorInfoCmd)
 }
 
@@ -178,4 +289,19 @@ func validatorInfoBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("watch", cmd.Flags().Lookup("watch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("alert-decreasing-epochs", cmd.Flags().Lookup("alert-decreasing-epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("alert-effective-balance", cmd.Flags().Lookup("alert-effective-balance")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("alert-below-ceiling", cmd.Flags().Lookup("alert-below-ceiling")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("alert-clear-epochs", cmd.Flags().Lookup("alert-clear-epochs")); err != nil {
+		panic(err)
+	}
 }
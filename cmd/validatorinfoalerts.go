@@ -0,0 +1,160 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+const (
+	// compoundingWithdrawalPrefix is the prefix for compounding withdrawal credentials.
+	compoundingWithdrawalPrefix = 0x02
+	// maxEffectiveBalance is the maximum effective balance for non-compounding validators.
+	maxEffectiveBalance = spec.Gwei(32000000000)
+	// maxEffectiveBalanceElectra is the maximum effective balance for compounding validators.
+	maxEffectiveBalanceElectra = spec.Gwei(2048000000000)
+)
+
+// validatorBalanceAlertRules are the rules for raising alerts about the
+// balance of a watched validator.
+type validatorBalanceAlertRules struct {
+	// decreasingEpochs raises an alert if the balance has decreased for this
+	// many consecutive epochs.  0 disables the rule.
+	decreasingEpochs uint64
+	// minEffectiveBalance raises an alert if the effective balance is below
+	// this value.  0 disables the rule.
+	minEffectiveBalance spec.Gwei
+	// belowCeiling raises an alert if the effective balance is below the
+	// maximum effective balance for the validator's withdrawal credentials.
+	belowCeiling bool
+	// clearEpochs is the number of consecutive epochs for which the condition
+	// of a raised alert must not hold before the alert is cleared.
+	clearEpochs uint64
+}
+
+// enabled returns true if any rule is enabled.
+func (r *validatorBalanceAlertRules) enabled() bool {
+	return r.decreasingEpochs > 0 || r.minEffectiveBalance > 0 || r.belowCeiling
+}
+
+// validatorBalanceAlert is the state of a single alert.
+type validatorBalanceAlert struct {
+	raised bool
+	// clearCount is the number of consecutive epochs for which the condition
+	// of the raised alert has not held.
+	clearCount uint64
+}
+
+// validatorBalanceAlerts tracks the alerts for a watched validator across
+// epochs.  Alerts are reported once when raised and once when cleared, rather
+// than every epoch for which their condition holds, to avoid alert storms.
+type validatorBalanceAlerts struct {
+	rules         *validatorBalanceAlertRules
+	balanceFormat *output.BalanceFormat
+
+	previousBalance *spec.Gwei
+	decreases       uint64
+	alerts          map[string]*validatorBalanceAlert
+}
+
+// newValidatorBalanceAlerts creates alerts for the given rules.
+func newValidatorBalanceAlerts(rules *validatorBalanceAlertRules, balanceFormat *output.BalanceFormat) *validatorBalanceAlerts {
+	return &validatorBalanceAlerts{
+		rules:         rules,
+		balanceFormat: balanceFormat,
+		alerts:        make(map[string]*validatorBalanceAlert),
+	}
+}
+
+// update updates the alerts with the validator's balances for an epoch,
+// returning lines for alerts that have been raised or cleared.
+func (a *validatorBalanceAlerts) update(balance spec.Gwei,
+	effectiveBalance spec.Gwei,
+	withdrawalCredentials []byte,
+) []*validatorInfoLine {
+	if a.previousBalance != nil {
+		if balance < *a.previousBalance {
+			a.decreases++
+		} else {
+			a.decreases = 0
+		}
+	}
+	a.previousBalance = &balance
+
+	lines := make([]*validatorInfoLine, 0)
+	if a.rules.decreasingEpochs > 0 {
+		lines = a.check(lines,
+			"balance decreasing",
+			a.decreases >= a.rules.decreasingEpochs,
+			fmt.Sprintf("balance decreased for %d consecutive epochs", a.decreases),
+		)
+	}
+	if a.rules.minEffectiveBalance > 0 {
+		lines = a.check(lines,
+			"effective balance",
+			effectiveBalance < a.rules.minEffectiveBalance,
+			fmt.Sprintf("effective balance %s below %s", a.balanceFormat.Gwei(uint64(effectiveBalance)), a.balanceFormat.Gwei(uint64(a.rules.minEffectiveBalance))),
+		)
+	}
+	if a.rules.belowCeiling {
+		ceiling := maxEffectiveBalance
+		if len(withdrawalCredentials) > 0 && withdrawalCredentials[0] == compoundingWithdrawalPrefix {
+			ceiling = maxEffectiveBalanceElectra
+		}
+		lines = a.check(lines,
+			"effective balance ceiling",
+			effectiveBalance < ceiling,
+			fmt.Sprintf("effective balance %s below ceiling of %s", a.balanceFormat.Gwei(uint64(effectiveBalance)), a.balanceFormat.Gwei(uint64(ceiling))),
+		)
+	}
+
+	return lines
+}
+
+// check updates the named alert with the current state of its condition,
+// appending a line to those supplied if the alert is raised or cleared.
+func (a *validatorBalanceAlerts) check(lines []*validatorInfoLine,
+	name string,
+	condition bool,
+	description string,
+) []*validatorInfoLine {
+	alert, exists := a.alerts[name]
+	if !exists {
+		alert = &validatorBalanceAlert{}
+		a.alerts[name] = alert
+	}
+
+	label := fmt.Sprintf("Alert (%s)", name)
+	switch {
+	case condition && !alert.raised:
+		alert.raised = true
+		alert.clearCount = 0
+		lines = append(lines, &validatorInfoLine{label: label, value: fmt.Sprintf("raised: %s", description)})
+	case condition:
+		// Alert remains raised.
+		alert.clearCount = 0
+	case alert.raised:
+		alert.clearCount++
+		if alert.clearCount >= a.rules.clearEpochs {
+			alert.raised = false
+			alert.clearCount = 0
+			lines = append(lines, &validatorInfoLine{label: label, value: "cleared"})
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,112 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorBalanceAlerts(t *testing.T) {
+	type epochBalances struct {
+		balance          spec.Gwei
+		effectiveBalance spec.Gwei
+		credentials      []byte
+	}
+
+	tests := []struct {
+		name     string
+		rules    *validatorBalanceAlertRules
+		epochs   []epochBalances
+		expected [][]string
+	}{
+		{
+			name: "Decreasing",
+			rules: &validatorBalanceAlertRules{
+				decreasingEpochs: 2,
+				clearEpochs:      1,
+			},
+			epochs: []epochBalances{
+				{balance: 32000000010},
+				{balance: 32000000009},
+				{balance: 32000000008},
+				{balance: 32000000007},
+				{balance: 32000000008},
+			},
+			expected: [][]string{
+				{},
+				{},
+				{"Alert (balance decreasing): raised: balance decreased for 2 consecutive epochs"},
+				{},
+				{"Alert (balance decreasing): cleared"},
+			},
+		},
+		{
+			name: "Hysteresis",
+			rules: &validatorBalanceAlertRules{
+				minEffectiveBalance: 32000000000,
+				clearEpochs:         2,
+			},
+			epochs: []epochBalances{
+				{effectiveBalance: 31000000000},
+				{effectiveBalance: 32000000000},
+				{effectiveBalance: 31000000000},
+				{effectiveBalance: 32000000000},
+				{effectiveBalance: 32000000000},
+				{effectiveBalance: 32000000000},
+			},
+			expected: [][]string{
+				{"Alert (effective balance): raised: effective balance 31 Ether below 32 Ether"},
+				{},
+				{},
+				{},
+				{"Alert (effective balance): cleared"},
+				{},
+			},
+		},
+		{
+			name: "BelowCeiling",
+			rules: &validatorBalanceAlertRules{
+				belowCeiling: true,
+				clearEpochs:  1,
+			},
+			epochs: []epochBalances{
+				{effectiveBalance: 32000000000, credentials: []byte{0x01}},
+				{effectiveBalance: 32000000000, credentials: []byte{0x02}},
+				{effectiveBalance: 2048000000000, credentials: []byte{0x02}},
+			},
+			expected: [][]string{
+				{},
+				{"Alert (effective balance ceiling): raised: effective balance 32 Ether below ceiling of 2048 Ether"},
+				{"Alert (effective balance ceiling): cleared"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alerts := newValidatorBalanceAlerts(test.rules, nil)
+			for i, epoch := range test.epochs {
+				lines := alerts.update(epoch.balance, epoch.effectiveBalance, epoch.credentials)
+				rendered := make([]string, 0, len(lines))
+				for _, line := range lines {
+					rendered = append(rendered, line.label+": "+line.value)
+				}
+				require.Equal(t, test.expected[i], rendered, "epoch %d", i)
+			}
+		})
+	}
+}
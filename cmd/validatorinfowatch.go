@@ -0,0 +1,305 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+// validatorInfoLine is a single line of watched validator information.
+type validatorInfoLine struct {
+	label string
+	value string
+}
+
+// validatorInfoWatch re-renders information about a validator each epoch,
+// driven by head events from the beacon node, until the context is cancelled.
+// Alerts are raised and cleared according to the supplied rules.
+func validatorInfoWatch(ctx context.Context,
+	eth2Client eth2client.Service,
+	index spec.ValidatorIndex,
+	balanceFormat *output.BalanceFormat,
+	rules *validatorBalanceAlertRules,
+) error {
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisTimeProvider(eth2Client.(eth2client.GenesisTimeProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+	eventsProvider, isProvider := eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+
+	// Render the current state immediately, then again on each new epoch.
+	epochs := make(chan spec.Epoch, 1)
+	lastEpoch := chainTime.CurrentEpoch()
+	epochs <- lastEpoch
+	if err := eventsProvider.Events(ctx, &api.EventsOpts{
+		Topics: []string{"head"},
+		Handler: func(event *apiv1.Event) {
+			headEvent, isHeadEvent := event.Data.(*apiv1.HeadEvent)
+			if !isHeadEvent {
+				return
+			}
+			epoch := chainTime.SlotToEpoch(headEvent.Slot)
+			if epoch <= lastEpoch {
+				return
+			}
+			lastEpoch = epoch
+			select {
+			case epochs <- epoch:
+			default:
+				// A render is already pending.
+			}
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to connect for events")
+	}
+
+	var alerts *validatorBalanceAlerts
+	if rules.enabled() {
+		alerts = newValidatorBalanceAlerts(rules, balanceFormat)
+	}
+
+	var previous map[string]string
+	var previousBalance *spec.Gwei
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case epoch := <-epochs:
+			lines, balance, err := validatorInfoWatchLines(ctx, eth2Client, index, epoch, chainTime.CurrentSlot(), previousBalance, balanceFormat, alerts)
+			if err != nil {
+				return err
+			}
+			fmt.Println(renderValidatorInfoLines(epoch, lines, previous))
+			previous = validatorInfoValues(lines)
+			previousBalance = &balance
+		}
+	}
+}
+
+// validatorInfoWatchLines obtains the lines of information for a validator at the given epoch.
+func validatorInfoWatchLines(ctx context.Context,
+	eth2Client eth2client.Service,
+	index spec.ValidatorIndex,
+	epoch spec.Epoch,
+	currentSlot spec.Slot,
+	previousBalance *spec.Gwei,
+	balanceFormat *output.BalanceFormat,
+	alerts *validatorBalanceAlerts,
+) (
+	[]*validatorInfoLine,
+	spec.Gwei,
+	error,
+) {
+	validatorsResponse, err := eth2Client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", Indices: []spec.ValidatorIndex{index}})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to obtain validator")
+	}
+	validators := validatorsResponse.Data
+	validator, exists := validators[index]
+	if !exists {
+		return nil, 0, fmt.Errorf("validator %d not found", index)
+	}
+
+	lines := []*validatorInfoLine{
+		{label: "Status", value: validator.Status.String()},
+		{label: "Balance", value: balanceFormat.Gwei(uint64(validator.Balance))},
+		{label: "Effective balance", value: balanceFormat.Gwei(uint64(validator.Validator.EffectiveBalance))},
+	}
+	switch {
+	case previousBalance == nil:
+		// No previous balance against which to compare.
+	case validator.Balance >= *previousBalance:
+		lines = append(lines, &validatorInfoLine{label: "Balance change", value: fmt.Sprintf("+%s", balanceFormat.Gwei(uint64(validator.Balance-*previousBalance)))})
+	default:
+		lines = append(lines, &validatorInfoLine{label: "Balance change", value: fmt.Sprintf("-%s", balanceFormat.Gwei(uint64(*previousBalance-validator.Balance)))})
+	}
+
+	// Report the outcome of any proposals in the previous epoch.
+	if epoch > 0 {
+		dutiesResponse, err := eth2Client.(eth2client.ProposerDutiesProvider).ProposerDuties(ctx, &api.ProposerDutiesOpts{Epoch: epoch - 1, Indices: []spec.ValidatorIndex{index}})
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to obtain proposer duties")
+		}
+		duties := dutiesResponse.Data
+		for _, duty := range duties {
+			if duty.ValidatorIndex != index {
+				continue
+			}
+			outcome := "missed"
+			header, err := util.ResponseData(eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: fmt.Sprintf("%d", duty.Slot)}))
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "failed to obtain block header")
+			}
+			if header != nil && header.Header != nil && header.Header.Message.ProposerIndex == index {
+				outcome = "proposed"
+			}
+			lines = append(lines, &validatorInfoLine{label: fmt.Sprintf("Proposal at slot %d", duty.Slot), value: outcome})
+		}
+	}
+
+	// Report the outcome of the attestation two epochs ago, as attestations in
+	// the previous epoch may not yet have been included.
+	if epoch > 1 {
+		line, err := validatorAttestationLine(ctx, eth2Client, index, epoch-2, currentSlot)
+		if err != nil {
+			return nil, 0, err
+		}
+		if line != nil {
+			lines = append(lines, line)
+		}
+	}
+
+	if alerts != nil {
+		lines = append(lines, alerts.update(validator.Balance, validator.Validator.EffectiveBalance, validator.Validator.WithdrawalCredentials)...)
+	}
+
+	return lines, validator.Balance, nil
+}
+
+// validatorAttestationLine obtains the outcome of the validator's attestation
+// in the given epoch.  It returns nil if the validator had no attester duty.
+func validatorAttestationLine(ctx context.Context,
+	eth2Client eth2client.Service,
+	index spec.ValidatorIndex,
+	epoch spec.Epoch,
+	currentSlot spec.Slot,
+) (
+	*validatorInfoLine,
+	error,
+) {
+	dutiesResponse, err := eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{Epoch: epoch, Indices: []spec.ValidatorIndex{index}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attester duties")
+	}
+	var duty *apiv1.AttesterDuty
+	for _, attesterDuty := range dutiesResponse.Data {
+		if attesterDuty.ValidatorIndex == index {
+			duty = attesterDuty
+			break
+		}
+	}
+	if duty == nil {
+		return nil, nil
+	}
+
+	// From Electra an attestation can cover multiple committees, in which case
+	// the sizes of the committees are required to locate the validator's vote.
+	var committees []*apiv1.BeaconCommittee
+	committeeSize := func(committeeIndex spec.CommitteeIndex) (uint64, error) {
+		if committees == nil {
+			committeesResponse, err := eth2Client.(eth2client.BeaconCommitteesProvider).BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: fmt.Sprintf("%d", duty.Slot)})
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to obtain beacon committees")
+			}
+			committees = committeesResponse.Data
+		}
+		for _, committee := range committees {
+			if committee.Slot == duty.Slot && committee.Index == committeeIndex {
+				return uint64(len(committee.Validators)), nil
+			}
+		}
+
+		return 0, fmt.Errorf("committee %d not found", committeeIndex)
+	}
+
+	// Attestations can be included in any block up to an epoch after their slot.
+	lastSlot := duty.Slot + 32
+	if currentSlot < lastSlot {
+		lastSlot = currentSlot
+	}
+	for slot := duty.Slot + 1; slot <= lastSlot; slot++ {
+		_, attestations, found, err := util.BlockAttestations(ctx, eth2Client, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attestations for slot %d", slot))
+		}
+		if !found {
+			continue
+		}
+		for _, attestation := range attestations {
+			if attestation.Data.Slot != duty.Slot {
+				continue
+			}
+			attested, err := attestation.Attested(duty.CommitteeIndex, duty.ValidatorCommitteeIndex, committeeSize)
+			if err != nil {
+				return nil, err
+			}
+			if attested {
+				return &validatorInfoLine{
+					label: fmt.Sprintf("Attestation at slot %d", duty.Slot),
+					value: fmt.Sprintf("included in slot %d (delay %d)", slot, slot-duty.Slot),
+				}, nil
+			}
+		}
+	}
+
+	outcome := "missed"
+	if lastSlot < duty.Slot+32 {
+		outcome = "not yet included"
+	}
+
+	return &validatorInfoLine{
+		label: fmt.Sprintf("Attestation at slot %d", duty.Slot),
+		value: outcome,
+	}, nil
+}
+
+// validatorInfoValues returns the values of the lines of information, keyed by
+// their labels, against which the next render is compared.
+func validatorInfoValues(lines []*validatorInfoLine) map[string]string {
+	res := make(map[string]string, len(lines))
+	for _, line := range lines {
+		res[line.label] = line.value
+	}
+
+	return res
+}
+
+// renderValidatorInfoLines renders the lines of information, marking those
+// that have changed since the previous render.
+func renderValidatorInfoLines(epoch spec.Epoch, lines []*validatorInfoLine, previous map[string]string) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Epoch %d:\n", epoch))
+	for _, line := range lines {
+		previousValue, exists := previous[line.label]
+		switch {
+		case previous == nil || line.label == "Balance change":
+			builder.WriteString(fmt.Sprintf("  %s: %s\n", line.label, line.value))
+		case !exists:
+			builder.WriteString(fmt.Sprintf("* %s: %s\n", line.label, line.value))
+		case previousValue != line.value:
+			builder.WriteString(fmt.Sprintf("* %s: %s (was %s)\n", line.label, line.value, previousValue))
+		default:
+			builder.WriteString(fmt.Sprintf("  %s: %s\n", line.label, line.value))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
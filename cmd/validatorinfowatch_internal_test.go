@@ -0,0 +1,121 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderValidatorInfoLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []*validatorInfoLine
+		previous map[string]string
+		expected string
+	}{
+		{
+			name: "Initial",
+			lines: []*validatorInfoLine{
+				{label: "Status", value: "active_ongoing"},
+				{label: "Balance", value: "32 Ether"},
+			},
+			expected: "Epoch 10:\n  Status: active_ongoing\n  Balance: 32 Ether",
+		},
+		{
+			name: "Unchanged",
+			lines: []*validatorInfoLine{
+				{label: "Status", value: "active_ongoing"},
+			},
+			previous: map[string]string{
+				"Status": "active_ongoing",
+			},
+			expected: "Epoch 10:\n  Status: active_ongoing",
+		},
+		{
+			name: "Changed",
+			lines: []*validatorInfoLine{
+				{label: "Status", value: "active_exiting"},
+			},
+			previous: map[string]string{
+				"Status": "active_ongoing",
+			},
+			expected: "Epoch 10:\n* Status: active_exiting (was active_ongoing)",
+		},
+		{
+			name: "New",
+			lines: []*validatorInfoLine{
+				{label: "Status", value: "active_ongoing"},
+				{label: "Attestation at slot 257", value: "included in slot 258 (delay 1)"},
+			},
+			previous: map[string]string{
+				"Status": "active_ongoing",
+			},
+			expected: "Epoch 10:\n  Status: active_ongoing\n* Attestation at slot 257: included in slot 258 (delay 1)",
+		},
+		{
+			name: "BalanceChangeNotMarked",
+			lines: []*validatorInfoLine{
+				{label: "Balance change", value: "+1 Gwei"},
+			},
+			previous: map[string]string{
+				"Balance change": "+2 Gwei",
+			},
+			expected: "Epoch 10:\n  Balance change: +1 Gwei",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, renderValidatorInfoLines(10, test.lines, test.previous))
+		})
+	}
+}
+
+func TestValidatorInfoValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []*validatorInfoLine
+		expected map[string]string
+	}{
+		{
+			name:     "Empty",
+			expected: map[string]string{},
+		},
+		{
+			name: "Lines",
+			lines: []*validatorInfoLine{
+				{label: "Status", value: "active_ongoing"},
+				{label: "Attestation at slot 257", value: "missed"},
+			},
+			expected: map[string]string{
+				"Status":                  "active_ongoing",
+				"Attestation at slot 257": "missed",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := validatorInfoValues(test.lines)
+			require.Equal(t, test.expected, values)
+
+			// The values of one render are the previous values of the next.
+			if len(test.lines) > 0 {
+				require.NotContains(t, renderValidatorInfoLines(11, test.lines, values), "*")
+			}
+		})
+	}
+}
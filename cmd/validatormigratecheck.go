@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatormigratecheck "github.com/wealdtech/ethdo/cmd/validator/migratecheck"
+)
+
+var validatorMigrateCheckCmd = &cobra.Command{
+	Use:   "migrate-check",
+	Short: "Check that validators are safe to start on a new validator client",
+	Long: `Check that validators being migrated from one validator client host to another are safe to start on the new host.  For example:
+
+    ethdo validator migrate-check --validators=1,2,3 --from-host=old-interchange.json --to-host=http://new-host:8080/interchange.json
+
+The check confirms that the beacon node has seen no activity from the validators for the last few epochs, showing that the old validator client has stopped; that the slashing protection data for the validators from the old host matches that on the new host; and that the validators have shown no activity in the current epoch, as a doppelganger check.  Slashing protection data is supplied as EIP-3076 interchange data, either as a local file or a URL.
+
+In quiet mode this will return 0 if the validators are safe to start on the new host, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatormigratecheck.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorMigrateCheckCmd)
+	validatorFlags(validatorMigrateCheckCmd)
+	validatorMigrateCheckCmd.Flags().StringSlice("validators", nil, "the list of validators being migrated")
+	validatorMigrateCheckCmd.Flags().String("from-host", "", "File or URL of slashing protection interchange data exported from the old host")
+	validatorMigrateCheckCmd.Flags().String("to-host", "", "File or URL of slashing protection interchange data held by the new host")
+	validatorMigrateCheckCmd.Flags().Uint64("epochs", 2, "the number of epochs for which the validators must have shown no activity")
+}
+
+func validatorMigrateCheckBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-host", cmd.Flags().Lookup("from-host")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-host", cmd.Flags().Lookup("to-host")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", cmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorperformance "github.com/wealdtech/ethdo/cmd/validator/performance"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var validatorPerformanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Report the historical performance of validators",
+	Long: `Report the attestation, block proposal and sync committee performance of validators over a range of epochs.  For example:
+
+    ethdo validator performance --validators=Validators --from-epoch=200000 --to-epoch=200224
+
+Validators can be supplied as indices, public keys, accounts or wallets, in which case all accounts in the wallet are used.  Performance is reported for the validators as a whole; with --series it is also reported for each epoch.  If no epochs are supplied the performance for the most recent epoch whose attestations have been fully included is returned.
+
+With --sla the proportion of expected attestations included is checked for each validator against the supplied target, for example 0.999 for 99.9%, to provide a compliance report.  Validators can be grouped for the report with --group, which takes a group name and a comma-separated list of validators, for example --group=operator-a=1,2,3, and can be supplied multiple times; the validators in groups are included in the report alongside any supplied with --validators.  --window sets the range of epochs to a rolling window ending at the last epoch, for example --window=30d.
+
+Each epoch requires the blocks of two epochs to be fetched, so long ranges can take some time.
+
+In quiet mode this will return 0 if the performance is obtained, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "csv"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorperformance.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorPerformanceCmd)
+	validatorFlags(validatorPerformanceCmd)
+	validatorPerformanceCmd.Flags().StringSlice("validators", nil, "the validators for which to report performance")
+	validatorPerformanceCmd.Flags().String("from-epoch", "", "the first epoch for which to report performance (defaults to to-epoch)")
+	validatorPerformanceCmd.Flags().String("to-epoch", "", "the last epoch for which to report performance (defaults to the most recent epoch with all attestations included)")
+	validatorPerformanceCmd.Flags().Bool("series", false, "also report performance for each epoch")
+	validatorPerformanceCmd.Flags().String("window", "", "a trailing window ending at to-epoch for which to report performance, in days or as a duration (e.g. 30d)")
+	validatorPerformanceCmd.Flags().Float64("sla", 0, "the target proportion of attestations included for which to report compliance (e.g. 0.999)")
+	validatorPerformanceCmd.Flags().StringArray("group", nil, "a named group of validators for the SLA report, of the form name=validator[,validator...]; can be supplied multiple times")
+}
+
+func validatorPerformanceBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("series", cmd.Flags().Lookup("series")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("window", cmd.Flags().Lookup("window")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("sla", cmd.Flags().Lookup("sla")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("group", cmd.Flags().Lookup("group")); err != nil {
+		panic(err)
+	}
+}
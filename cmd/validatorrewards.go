@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorrewards "github.com/wealdtech/ethdo/cmd/validator/rewards"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+var validatorRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain the rewards earned by validators",
+	Long: `Obtain the attestation, block proposal and sync committee rewards earned by validators over a range of epochs.  For example:
+
+    ethdo validator rewards --validators=1,2,3 --from-epoch=200000 --to-epoch=200224
+
+Validators can be supplied as indices, public keys, accounts or wallets, in which case all accounts in the wallet are used.  Rewards are obtained from the beacon node's rewards API, so the node must hold the states for the requested epochs; for older epochs this usually requires an archive node.  If no epochs are supplied the rewards for the most recent epoch with complete rewards information are returned.
+
+Rewards are reported in Gwei and Ether, and may be negative if penalties outweigh rewards.
+
+In quiet mode this will return 0 if the rewards are obtained, otherwise 1.`,
+	Annotations: map[string]string{output.FormatsAnnotation: "csv"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorrewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorRewardsCmd)
+	validatorFlags(validatorRewardsCmd)
+	validatorRewardsCmd.Flags().StringSlice("validators", nil, "the validators for which to obtain rewards")
+	validatorRewardsCmd.Flags().String("from-epoch", "", "the first epoch for which to obtain rewards (defaults to the last epoch)")
+	validatorRewardsCmd.Flags().String("to-epoch", "", "the last epoch for which to obtain rewards (defaults to the most recent epoch with complete rewards)")
+}
+
+func validatorRewardsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// validatorSlashingProtectionCmd represents the validator slashing-protection command.
+var validatorSlashingProtectionCmd = &cobra.Command{
+	Use:     "slashing-protection",
+	Aliases: []string{"slashingprotection"},
+	Short:   "Manage the local slashing protection database",
+	Long:    `Manage the local slashing protection database, which holds EIP-3076 slashing protection data for validators.  The database is found with --slashing-protection-db, and defaults to slashing-protection.json alongside the wallets.`,
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorSlashingProtectionCmd)
+}
+
+func validatorSlashingProtectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("slashing-protection-db", "", "Path to the local slashing protection database")
+}
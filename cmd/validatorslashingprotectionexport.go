@@ -0,0 +1,66 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashingprotectionexport "github.com/wealdtech/ethdo/cmd/validator/slashingprotection/export"
+)
+
+var validatorSlashingProtectionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export slashing protection data from the local database",
+	Long: `Export EIP-3076 slashing protection interchange data from the local slashing protection database.  For example:
+
+    ethdo validator slashing-protection export --file=slashing-protection.json
+
+If no file is supplied the data is written to standard output.
+
+In quiet mode this will return 0 if the data is exported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorslashingprotectionexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorSlashingProtectionCmd.AddCommand(validatorSlashingProtectionExportCmd)
+	validatorSlashingProtectionFlags(validatorSlashingProtectionExportCmd)
+	validatorSlashingProtectionExportCmd.Flags().String("file", "", "File to which to write the interchange data; it must not already exist")
+	validatorSlashingProtectionExportCmd.Flags().StringSlice("pubkeys", nil, "Public keys of the validators for which to export data (defaults to all)")
+}
+
+func validatorSlashingProtectionExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slashing-protection-db", cmd.Flags().Lookup("slashing-protection-db")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkeys", cmd.Flags().Lookup("pubkeys")); err != nil {
+		panic(err)
+	}
+}
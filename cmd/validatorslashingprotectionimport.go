@@ -0,0 +1,62 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashingprotectionimport "github.com/wealdtech/ethdo/cmd/validator/slashingprotection/import"
+)
+
+var validatorSlashingProtectionImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import slashing protection data in to the local database",
+	Long: `Import EIP-3076 slashing protection interchange data in to the local slashing protection database.  For example:
+
+    ethdo validator slashing-protection import --files=lighthouse.json,teku.json
+
+Data from multiple files, for example exported from different validator clients, is merged with that already in the database.  If the merged data contains slashable conflicts the database is not updated.
+
+In quiet mode this will return 0 if the data is imported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorslashingprotectionimport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorSlashingProtectionCmd.AddCommand(validatorSlashingProtectionImportCmd)
+	validatorSlashingProtectionFlags(validatorSlashingProtectionImportCmd)
+	validatorSlashingProtectionImportCmd.Flags().StringSlice("files", nil, "Files or URLs of EIP-3076 slashing protection interchange data to import")
+}
+
+func validatorSlashingProtectionImportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slashing-protection-db", cmd.Flags().Lookup("slashing-protection-db")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("files", cmd.Flags().Lookup("files")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorwithdraw "github.com/wealdtech/ethdo/cmd/validator/withdraw"
+)
+
+var validatorWithdrawCmd = &cobra.Command{
+	Use:   "withdraw",
+	Short: "Request a withdrawal from a validator via its withdrawal address",
+	Long: `Request a partial withdrawal or full exit of a validator from its withdrawal address, as per EIP-7002.  For example:
+
+    ethdo validator withdraw --validator=1234 --amount="5 Ether" --execution-connection=http://localhost:8545 --withdrawal-private-key=0x...
+
+--amount requests a partial withdrawal of the given amount, which requires compounding (0x02) withdrawal credentials and is limited to the balance above 32 Ether.  --full requests that the validator exits, and can be used with any execution withdrawal credentials.
+
+The withdrawal request is a transaction sent from the validator's withdrawal address.  If --withdrawal-private-key is supplied the transaction is signed and submitted to the execution node, unless --dry-run is supplied in which case the signed transaction is output.  Otherwise the details of the transaction are output so that it can be sent from a wallet.
+
+In quiet mode this will return 0 if the withdrawal can be requested, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := validatorwithdraw.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorWithdrawCmd)
+	validatorFlags(validatorWithdrawCmd)
+	validatorWithdrawCmd.Flags().String("validator", "", "Validator from which to withdraw")
+	validatorWithdrawCmd.Flags().String("amount", "", "Amount to withdraw, for example \"5 Ether\"")
+	validatorWithdrawCmd.Flags().Bool("full", false, "Request a full exit of the validator")
+	validatorWithdrawCmd.Flags().String("withdrawal-private-key", "", "Private key of the validator's withdrawal address, to sign the withdrawal request")
+	validatorWithdrawCmd.Flags().Bool("dry-run", false, "Output the signed withdrawal request rather than submitting it")
+}
+
+func validatorWithdrawBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("amount", cmd.Flags().Lookup("amount")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("full", cmd.Flags().Lookup("full")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawal-private-key", cmd.Flags().Lookup("withdrawal-private-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("dry-run", cmd.Flags().Lookup("dry-run")); err != nil {
+		panic(err)
+	}
+}
@@ -23,11 +23,13 @@ import (
 
 var validatorWithdrawalCmd = &cobra.Command{
 	Use:   "withdrawal",
-	Short: "Obtain next withdrawal for a validator",
-	Long: `Obtain next withdrawal for a validator.  For example:
+	Short: "Obtain withdrawal status for a validator",
+	Long: `Obtain withdrawal status for a validator: its withdrawal credentials type, when the withdrawals sweep will next reach it and the withdrawal expected at that time, and its last withdrawal.  For example:
 
     ethdo validator withdrawal --validator=primary/validator
 
+The time of the next sweep assumes that every slot contains a block and that validator balances do not change before the sweep reaches the validator.
+
 In quiet mode this will return 0 if the validator exists, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := validatorwithdrawal.Run(cmd)
@@ -38,6 +38,11 @@ type dataIn struct {
 	// For HD wallets.
 	passphrase string
 	mnemonic   string
+	// For watch-only wallets.
+	pubKeys                  string
+	validators               []string
+	connection               string
+	allowInsecureConnections bool
 }
 
 func input(_ context.Context) (*dataIn, error) {
@@ -86,5 +91,11 @@ func input(_ context.Context) (*dataIn, error) {
 	// Mnemonic.
 	data.mnemonic = viper.GetString("mnemonic")
 
+	// Watch-only accounts.
+	data.pubKeys = viper.GetString("pubkeys")
+	data.validators = viper.GetStringSlice("validators")
+	data.connection = viper.GetString("connection")
+	data.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
 	return data, nil
 }
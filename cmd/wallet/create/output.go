@@ -21,7 +21,8 @@ import (
 )
 
 type dataOut struct {
-	mnemonic string
+	mnemonic          string
+	watchOnlyAccounts int
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -38,6 +39,9 @@ Anyone with access to this mnemonic can recreate the accounts in this wallet, so
 Please note this mnemonic is not stored within the wallet, so cannot be retrieved or displayed again.  As such, this mnemonic should be stored securely, ideally offline, before proceeding.
 `, data.mnemonic), nil
 	}
+	if data.watchOnlyAccounts > 0 {
+		return fmt.Sprintf("Created watch-only wallet with %d accounts", data.watchOnlyAccounts), nil
+	}
 
 	return "", nil
 }
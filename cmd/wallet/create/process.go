@@ -40,6 +40,8 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return processHD(ctx, data)
 	case "distributed":
 		return processDistributed(ctx, data)
+	case "watch-only":
+		return processWatchOnly(ctx, data)
 	default:
 		return nil, errors.New("wallet type not supported")
 	}
@@ -128,6 +128,48 @@ func TestProcess(t *testing.T) {
 				walletName: "Test wallet",
 			},
 		},
+		{
+			name: "WatchOnlyPubKeysMissing",
+			dataIn: &dataIn{
+				timeout:    5 * time.Second,
+				store:      scratch.New(),
+				walletType: "watch-only",
+				walletName: "Test wallet",
+			},
+			err: "pubkeys or validators are required for watch-only wallets",
+		},
+		{
+			name: "WatchOnlyPubKeyInvalid",
+			dataIn: &dataIn{
+				timeout:    5 * time.Second,
+				store:      scratch.New(),
+				walletType: "watch-only",
+				walletName: "Test wallet",
+				pubKeys:    "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0x1234",
+			},
+			err: "invalid public key at entry 2: public key must be 48 bytes",
+		},
+		{
+			name: "WatchOnlyNameDuplicate",
+			dataIn: &dataIn{
+				timeout:    5 * time.Second,
+				store:      scratch.New(),
+				walletType: "watch-only",
+				walletName: "Test wallet",
+				pubKeys:    "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+			err: `account name "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c" supplied more than once`,
+		},
+		{
+			name: "WatchOnlyGood",
+			dataIn: &dataIn{
+				timeout:    5 * time.Second,
+				store:      scratch.New(),
+				walletType: "watch-only",
+				walletName: "Test wallet",
+				pubKeys:    "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -149,4 +191,6 @@ func TestNilData(t *testing.T) {
 	require.EqualError(t, err, "no data")
 	_, err = processDistributed(context.Background(), nil)
 	require.EqualError(t, err, "no data")
+	_, err = processWatchOnly(context.Background(), nil)
+	require.EqualError(t, err, "no data")
 }
@@ -0,0 +1,196 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcreate
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+)
+
+// watchOnlyEntry is a single account in a watch-only wallet.
+type watchOnlyEntry struct {
+	name     string
+	pubKey   []byte
+	metadata *util.WatchOnlyMetadata
+}
+
+// parsePubKeys parses a list of public keys.  The input can be a comma-separated
+// list of public keys, or the path to a file containing one public key per
+// line, optionally preceded by an account name and a comma.
+func parsePubKeys(input string) ([]*watchOnlyEntry, error) {
+	source := "pubkeys"
+	lines := strings.Split(input, ",")
+	if _, err := os.Stat(input); err == nil {
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read public keys file")
+		}
+		source = "file"
+		lines = strings.Split(string(data), "\n")
+	}
+
+	entries := make([]*watchOnlyEntry, 0, len(lines))
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := ""
+		pubKeyStr := line
+		if source == "file" && strings.Contains(line, ",") {
+			parts := strings.SplitN(line, ",", 2)
+			name = strings.TrimSpace(parts[0])
+			pubKeyStr = strings.TrimSpace(parts[1])
+		}
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyStr, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key at entry %d", i+1))
+		}
+		if _, err := e2types.BLSPublicKeyFromBytes(pubKey); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key at entry %d", i+1))
+		}
+		if name == "" {
+			name = fmt.Sprintf("%#x", pubKey)
+		}
+		entries = append(entries, &watchOnlyEntry{
+			name:   name,
+			pubKey: pubKey,
+			metadata: &util.WatchOnlyMetadata{
+				Source: source,
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// chainEntries obtains watch-only entries for validators from the chain.
+func chainEntries(ctx context.Context, data *dataIn) ([]*watchOnlyEntry, error) {
+	client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       data.connection,
+		Timeout:       data.timeout,
+		AllowInsecure: data.allowInsecureConnections,
+		LogFallback:   !data.quiet,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon node")
+	}
+	validatorsProvider, isProvider := client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide validator information")
+	}
+
+	validators, err := util.ParseValidators(ctx, validatorsProvider, data.validators, "head")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*watchOnlyEntry, 0, len(validators))
+	for _, validator := range validators {
+		entries = append(entries, &watchOnlyEntry{
+			name:   fmt.Sprintf("%d", validator.Index),
+			pubKey: validator.Validator.PublicKey[:],
+			metadata: &util.WatchOnlyMetadata{
+				Source: "chain",
+				Details: map[string]string{
+					"index": fmt.Sprintf("%d", validator.Index),
+				},
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+func processWatchOnly(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if data == nil {
+		return nil, errors.New("no data")
+	}
+	if data.pubKeys == "" && len(data.validators) == 0 {
+		return nil, errors.New("pubkeys or validators are required for watch-only wallets")
+	}
+
+	entries := make([]*watchOnlyEntry, 0)
+	if data.pubKeys != "" {
+		pubKeyEntries, err := parsePubKeys(data.pubKeys)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, pubKeyEntries...)
+	}
+	if len(data.validators) > 0 {
+		validatorEntries, err := chainEntries(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, validatorEntries...)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no public keys found")
+	}
+	// Validate all entries before the wallet is created, so that a bad entry
+	// does not leave a partially populated wallet behind.
+	if err := validateEntries(entries); err != nil {
+		return nil, err
+	}
+
+	// Watch-only wallets are non-deterministic wallets containing only
+	// watch-only accounts.
+	if _, err := nd.CreateWallet(ctx, data.walletName, data.store, keystorev4.New()); err != nil {
+		return nil, err
+	}
+	wallet, err := e2wallet.OpenWallet(data.walletName, e2wallet.WithStore(data.store))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wallet")
+	}
+	for _, entry := range entries {
+		if err := util.ImportWatchOnlyAccount(ctx, wallet, entry.name, entry.pubKey, entry.metadata); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to import account %s", entry.name))
+		}
+	}
+
+	return &dataOut{
+		watchOnlyAccounts: len(entries),
+	}, nil
+}
+
+// validateEntries ensures that the entries can all be imported as accounts.
+func validateEntries(entries []*watchOnlyEntry) error {
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.name == "" {
+			return errors.New("account name missing")
+		}
+		if names[entry.name] {
+			return fmt.Errorf("account name %q supplied more than once", entry.name)
+		}
+		names[entry.name] = true
+		if len(entry.pubKey) != 48 {
+			return fmt.Errorf("public key of length %d for account %s invalid", len(entry.pubKey), entry.name)
+		}
+	}
+
+	return nil
+}
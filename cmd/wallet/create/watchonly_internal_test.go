@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcreate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestParsePubKeys(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	pubKeysFile := filepath.Join(t.TempDir(), "pubkeys.txt")
+	require.NoError(t, os.WriteFile(pubKeysFile, []byte(`# Watched validators
+first,0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c
+
+0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b
+`), 0o600))
+
+	tests := []struct {
+		name   string
+		input  string
+		names  []string
+		source string
+		err    string
+	}{
+		{
+			name:   "List",
+			input:  "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c, 0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+			names:  []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+			source: "pubkeys",
+		},
+		{
+			name:   "File",
+			input:  pubKeysFile,
+			names:  []string{"first", "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+			source: "file",
+		},
+		{
+			name:  "Invalid",
+			input: "0xinvalid",
+			err:   "invalid public key at entry 1: encoding/hex: invalid byte: U+0069 'i'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := parsePubKeys(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, entries, len(test.names))
+				for i := range entries {
+					require.Equal(t, test.names[i], entries[i].name)
+					require.Equal(t, test.source, entries[i].metadata.Source)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEntries(t *testing.T) {
+	pubKey := make([]byte, 48)
+
+	tests := []struct {
+		name    string
+		entries []*watchOnlyEntry
+		err     string
+	}{
+		{
+			name:    "NameMissing",
+			entries: []*watchOnlyEntry{{pubKey: pubKey}},
+			err:     "account name missing",
+		},
+		{
+			name:    "NameDuplicate",
+			entries: []*watchOnlyEntry{{name: "1", pubKey: pubKey}, {name: "1", pubKey: pubKey}},
+			err:     `account name "1" supplied more than once`,
+		},
+		{
+			name:    "PubKeyShort",
+			entries: []*watchOnlyEntry{{name: "1", pubKey: pubKey[1:]}},
+			err:     "public key of length 47 for account 1 invalid",
+		},
+		{
+			name:    "Good",
+			entries: []*watchOnlyEntry{{name: "1", pubKey: pubKey}, {name: "2", pubKey: pubKey}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateEntries(test.entries)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@ package walletexport
 
 import (
 	"context"
+	"fmt"
+	"path"
 	"time"
 
 	"github.com/pkg/errors"
@@ -31,6 +33,12 @@ type dataIn struct {
 	debug      bool
 	wallet     e2wtypes.Wallet
 	passphrase string
+	// file is the file to which to stream a version 2 export.
+	file      string
+	accounts  []string
+	chunkSize int
+	progress  bool
+	approvals []string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -62,5 +70,29 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.Wrap(err, "failed to obtain export passphrase")
 	}
 
+	// File.
+	data.file = viper.GetString("file")
+
+	// Accounts.
+	data.accounts = viper.GetStringSlice("accounts")
+	if len(data.accounts) > 0 && data.file == "" {
+		return nil, errors.New("accounts can only be selected when exporting to a file")
+	}
+	for _, pattern := range data.accounts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid account pattern %q", pattern))
+		}
+	}
+
+	// Chunk size.
+	data.chunkSize = viper.GetInt("chunk-size")
+	if data.file != "" && data.chunkSize <= 0 {
+		return nil, errors.New("chunk size must be greater than 0")
+	}
+
+	data.progress = viper.GetBool("progress")
+
+	data.approvals = viper.GetStringSlice("approvals")
+
 	return data, nil
 }
@@ -79,6 +79,38 @@ func TestInput(t *testing.T) {
 			},
 			err: "failed to obtain export passphrase: passphrase is required",
 		},
+		{
+			name: "AccountsWithoutFile",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"wallet":     "Test wallet",
+				"passphrase": "export",
+				"accounts":   []string{"Validator *"},
+			},
+			err: "accounts can only be selected when exporting to a file",
+		},
+		{
+			name: "AccountPatternInvalid",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"wallet":     "Test wallet",
+				"passphrase": "export",
+				"file":       "export.dat",
+				"accounts":   []string{"Validator ["},
+				"chunk-size": 100,
+			},
+			err: `invalid account pattern "Validator [": syntax error in pattern`,
+		},
+		{
+			name: "ChunkSizeZero",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"wallet":     "Test wallet",
+				"passphrase": "export",
+				"file":       "export.dat",
+			},
+			err: "chunk size must be greater than 0",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -91,6 +123,21 @@ func TestInput(t *testing.T) {
 				wallet:  wallet,
 			},
 		},
+		{
+			name: "GoodStream",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"wallet":     "Test wallet",
+				"passphrase": "export",
+				"file":       "export.dat",
+				"accounts":   []string{"Validator *"},
+				"chunk-size": 100,
+			},
+			res: &dataIn{
+				timeout: 5 * time.Second,
+				wallet:  wallet,
+			},
+		},
 	}
 
 	for _, test := range tests {
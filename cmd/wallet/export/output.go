@@ -21,7 +21,11 @@ import (
 )
 
 type dataOut struct {
-	export []byte
+	verbose bool
+	export  []byte
+	// file is the file to which a version 2 export was streamed.
+	file     string
+	accounts uint64
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -29,5 +33,12 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
+	if data.file != "" {
+		if !data.verbose {
+			return "", nil
+		}
+		return fmt.Sprintf("Exported %d accounts to %s", data.accounts, data.file), nil
+	}
+
 	return fmt.Sprintf("%#x", data.export), nil
 }
@@ -15,6 +15,10 @@ package walletexport
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/util"
@@ -31,6 +35,18 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if !util.AcceptablePassphrase(data.passphrase) {
 		return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
 	}
+	// An export contains the private keys of its accounts, so is gated in the
+	// same way as exporting the key of a single account.
+	selected := func(name string) bool {
+		return nameSelected(name, data.accounts)
+	}
+	if err := util.CheckWalletCompositeApprovals(ctx, util.CompositeOperationKey, data.wallet, selected, data.approvals); err != nil {
+		return nil, err
+	}
+
+	if data.file != "" {
+		return processStream(ctx, data)
+	}
 
 	exporter, isExporter := data.wallet.(e2wtypes.WalletExporter)
 	if !isExporter {
@@ -48,3 +64,112 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 
 	return results, nil
 }
+
+// processStream streams a version 2 export of the wallet to a file, fetching
+// accounts from the store one at a time so that large wallets can be exported
+// without holding all of their accounts in memory.
+func processStream(_ context.Context, data *dataIn) (*dataOut, error) {
+	storeProvider, isStoreProvider := data.wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not provide its store")
+	}
+	store := storeProvider.Store()
+
+	walletData, err := store.RetrieveWalletByID(data.wallet.ID())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+
+	f, err := os.OpenFile(data.file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create export file")
+	}
+	defer f.Close()
+
+	writer, err := util.NewWalletExportWriter(f, []byte(data.passphrase))
+	if err != nil {
+		return nil, err
+	}
+	if err := writer.WriteWallet(walletData); err != nil {
+		return nil, errors.Wrap(err, "failed to write wallet")
+	}
+
+	results := &dataOut{
+		verbose: data.verbose,
+		file:    data.file,
+	}
+	chunk := make([][]byte, 0, data.chunkSize)
+	writeChunk := func() error {
+		if err := writer.WriteAccounts(chunk); err != nil {
+			return errors.Wrap(err, "failed to write accounts")
+		}
+		results.accounts += uint64(len(chunk))
+		if data.progress {
+			fmt.Fprintf(os.Stderr, "Exported %d accounts\n", results.accounts)
+		}
+		chunk = make([][]byte, 0, data.chunkSize)
+
+		return nil
+	}
+	for accountData := range store.RetrieveAccounts(data.wallet.ID()) {
+		selected, err := accountSelected(accountData, data.accounts)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		chunk = append(chunk, accountData)
+		if len(chunk) == data.chunkSize {
+			if err := writeChunk(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(chunk) > 0 {
+		if err := writeChunk(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to complete export")
+	}
+	if err := f.Sync(); err != nil {
+		return nil, errors.Wrap(err, "failed to write export file")
+	}
+
+	return results, nil
+}
+
+// accountSelected returns true if the name of the account matches any of the
+// patterns, or if there are no patterns.
+func accountSelected(accountData []byte, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+
+	account := &struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal(accountData, account); err != nil {
+		return false, errors.Wrap(err, "failed to obtain account name")
+	}
+
+	return nameSelected(account.Name, patterns), nil
+}
+
+// nameSelected returns true if the name matches any of the patterns, or if
+// there are no patterns.
+func nameSelected(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
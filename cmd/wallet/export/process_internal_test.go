@@ -15,16 +15,22 @@ package walletexport
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
 	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestProcess(t *testing.T) {
@@ -85,3 +91,211 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessStream(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+	store := filesystem.New(filesystem.WithLocation(base))
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Validator 1", "Validator 2", "Validator 3", "Other"} {
+		_, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), name, []byte("test"))
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		dataIn   *dataIn
+		accounts int
+		err      string
+	}{
+		{
+			name: "FileBad",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+				file:       "/bad/bad/bad/export.dat",
+				chunkSize:  2,
+			},
+			err: "failed to create export file: open /bad/bad/bad/export.dat: no such file or directory",
+		},
+		{
+			name: "All",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+				file:       filepath.Join(base, "all.dat"),
+				chunkSize:  3,
+			},
+			accounts: 4,
+		},
+		{
+			name: "Selected",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+				file:       filepath.Join(base, "selected.dat"),
+				accounts:   []string{"Validator *"},
+				chunkSize:  1,
+			},
+			accounts: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint64(test.accounts), res.accounts)
+
+			// Read the export back.
+			f, err := os.Open(test.dataIn.file)
+			require.NoError(t, err)
+			defer f.Close()
+			reader, err := util.NewWalletExportReader(f, []byte(test.dataIn.passphrase))
+			require.NoError(t, err)
+			_, err = reader.ReadWallet()
+			require.NoError(t, err)
+			accounts := 0
+			for {
+				chunk, err := reader.ReadAccounts()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(chunk), test.dataIn.chunkSize)
+				accounts += len(chunk)
+			}
+			require.Equal(t, test.accounts, accounts)
+		})
+	}
+}
+
+func TestProcessComposite(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	base := t.TempDir()
+	store := filesystem.New(filesystem.WithLocation(base))
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Validator 1", "Other"} {
+		_, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), name, []byte("test"))
+		require.NoError(t, err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("composites", map[string]any{
+		"ops": map[string]any{
+			"threshold": 1,
+			"accounts":  []string{"Test wallet/Validator *"},
+			"approvers": []string{"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "Export",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+			},
+			err: `key of Test wallet/Validator 1 requires 1 approvals from composite "ops" but 0 obtained`,
+		},
+		{
+			name: "Stream",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+				file:       filepath.Join(base, "stream.dat"),
+				chunkSize:  1,
+			},
+			err: `key of Test wallet/Validator 1 requires 1 approvals from composite "ops" but 0 obtained`,
+		},
+		{
+			name: "StreamNotMember",
+			dataIn: &dataIn{
+				wallet:     wallet,
+				passphrase: "ce%NohGhah4ye5ra",
+				file:       filepath.Join(base, "other.dat"),
+				accounts:   []string{"Other"},
+				chunkSize:  1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				if test.dataIn.file != "" {
+					// No export should have been written.
+					require.NoFileExists(t, test.dataIn.file)
+				}
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountSelected(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		patterns []string
+		selected bool
+		err      string
+	}{
+		{
+			name:     "NoPatterns",
+			data:     []byte(`invalid`),
+			selected: true,
+		},
+		{
+			name:     "DataInvalid",
+			data:     []byte(`invalid`),
+			patterns: []string{"*"},
+			err:      "failed to obtain account name: invalid character 'i' looking for beginning of value",
+		},
+		{
+			name:     "Match",
+			data:     []byte(`{"name":"Validator 1"}`),
+			patterns: []string{"Other", "Validator *"},
+			selected: true,
+		},
+		{
+			name:     "NoMatch",
+			data:     []byte(`{"name":"Other 1"}`),
+			patterns: []string{"Other", "Validator *"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected, err := accountSelected(test.data, test.patterns)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.selected, selected)
+		})
+	}
+}
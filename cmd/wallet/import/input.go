@@ -16,6 +16,7 @@ package walletimport
 import (
 	"context"
 	"encoding/hex"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 type dataIn struct {
@@ -34,6 +36,10 @@ type dataIn struct {
 	data       []byte
 	passphrase string
 	verify     bool
+	// file is the file containing a version 2 export, which is streamed.
+	file     string
+	store    e2wtypes.Store
+	progress bool
 }
 
 func input(_ context.Context) (*dataIn, error) {
@@ -57,16 +63,33 @@ func input(_ context.Context) (*dataIn, error) {
 		return nil, errors.New("data is required")
 	}
 	if !strings.HasPrefix(viper.GetString("data"), "0x") {
-		// Assume this is a path; read the file and replace the path with its contents.
-		fileData, err := os.ReadFile(viper.GetString("data"))
+		// Assume this is a path.
+		stream, err := isStreamExport(viper.GetString("data"))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to read wallet import data")
 		}
-		viper.Set("data", strings.TrimSpace(string(fileData)))
+		if stream {
+			data.file = viper.GetString("data")
+		} else {
+			// Read the file and replace the path with its contents.
+			fileData, err := os.ReadFile(viper.GetString("data"))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read wallet import data")
+			}
+			viper.Set("data", strings.TrimSpace(string(fileData)))
+		}
 	}
-	data.data, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("data"), "0x"))
-	if err != nil {
-		return nil, errors.Wrap(err, "data is invalid")
+	if data.file == "" {
+		data.data, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("data"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "data is invalid")
+		}
+	} else if !viper.GetBool("verify") {
+		store, isStore := viper.Get("store").(e2wtypes.Store)
+		if !isStore {
+			return nil, errors.New("store is required")
+		}
+		data.store = store
 	}
 
 	// Passphrase.
@@ -78,5 +101,26 @@ func input(_ context.Context) (*dataIn, error) {
 	// Verify.
 	data.verify = viper.GetBool("verify")
 
+	data.progress = viper.GetBool("progress")
+
 	return data, nil
 }
+
+// isStreamExport returns true if the file contains a version 2 export.
+func isStreamExport(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	start := make([]byte, 1)
+	if _, err := f.Read(start); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return util.IsWalletExportV2(start), nil
+}
@@ -16,11 +16,14 @@ package walletimport
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -40,6 +43,12 @@ func TestInput(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, e2wallet.UseStore(scratch.New()))
 
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+	streamFile := filepath.Join(base, "export.dat")
+	require.NoError(t, os.WriteFile(streamFile, []byte{util.WalletExportV2Version}, 0o600))
+
 	tests := []struct {
 		name string
 		vars map[string]interface{}
@@ -105,6 +114,24 @@ func TestInput(t *testing.T) {
 				timeout: 5 * time.Second,
 			},
 		},
+		{
+			name: "StreamStoreMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"data":       streamFile,
+				"passphrase": "export",
+			},
+			err: "store is required",
+		},
+		{
+			name: "StreamVerify",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"data":       streamFile,
+				"passphrase": "export",
+				"verify":     true,
+			},
+		},
 		{
 			name: "Verify",
 			vars: map[string]interface{}{
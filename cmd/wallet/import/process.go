@@ -16,16 +16,25 @@ package walletimport
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"os"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-ecodec"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	indexer "github.com/wealdtech/go-indexer"
 )
 
 func process(_ context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
 	}
+	if data.file != "" {
+		return processStream(data)
+	}
 	if data.data == nil {
 		return nil, errors.New("import data is required")
 	}
@@ -50,3 +59,96 @@ func process(_ context.Context, data *dataIn) (*dataOut, error) {
 
 	return results, nil
 }
+
+// storedItem contains the fields of stored wallet and account data that are
+// required to place them in the store.
+type storedItem struct {
+	ID   uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// processStream imports a version 2 export, storing accounts as they are read
+// so that large wallets can be imported without holding all of their accounts
+// in memory.
+func processStream(data *dataIn) (*dataOut, error) {
+	f, err := os.Open(data.file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open export file")
+	}
+	defer f.Close()
+
+	reader, err := util.NewWalletExportReader(f, []byte(data.passphrase))
+	if err != nil {
+		return nil, err
+	}
+	walletData, err := reader.ReadWallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet")
+	}
+	ext := &export{
+		Wallet:   &walletInfo{},
+		Accounts: make([]*accountInfo, 0),
+	}
+	if err := json.Unmarshal(walletData, ext.Wallet); err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet")
+	}
+	wallet := &storedItem{}
+	if err := json.Unmarshal(walletData, wallet); err != nil {
+		return nil, errors.Wrap(err, "failed to read wallet")
+	}
+
+	if !data.verify {
+		if _, err := data.store.RetrieveWallet(wallet.Name); err == nil {
+			return nil, fmt.Errorf("wallet %q already exists", wallet.Name)
+		}
+		if err := data.store.StoreWallet(wallet.ID, wallet.Name, walletData); err != nil {
+			return nil, errors.Wrapf(err, "failed to store wallet %q", wallet.Name)
+		}
+	}
+
+	index := indexer.New()
+	for {
+		accounts, err := reader.ReadAccounts()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read accounts")
+		}
+		for _, accountData := range accounts {
+			account := &storedItem{}
+			if err := json.Unmarshal(accountData, account); err != nil {
+				return nil, errors.Wrap(err, "failed to read account")
+			}
+			if !data.verify {
+				if err := data.store.StoreAccount(wallet.ID, account.ID, accountData); err != nil {
+					return nil, errors.Wrapf(err, "failed to store account %q", account.Name)
+				}
+				index.Add(account.ID, account.Name)
+			}
+			ext.Accounts = append(ext.Accounts, &accountInfo{Name: account.Name})
+		}
+		if data.progress {
+			fmt.Fprintf(os.Stderr, "Processed %d accounts\n", len(ext.Accounts))
+		}
+	}
+
+	if !data.verify {
+		serializedIndex, err := index.Serialize()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to serialize index")
+		}
+		if err := data.store.StoreAccountsIndex(wallet.ID, serializedIndex); err != nil {
+			return nil, errors.Wrap(err, "failed to store wallet index")
+		}
+	}
+
+	results := &dataOut{
+		verify:  data.verify,
+		quiet:   data.quiet,
+		verbose: data.verbose,
+		export:  ext,
+	}
+
+	return results, nil
+}
@@ -14,11 +14,15 @@
 package walletimport
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -130,3 +134,107 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessStream(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+
+	// Create a streamed export of a wallet with accounts.
+	store := scratch.New()
+	wallet, err := nd.CreateWallet(context.Background(), "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	for _, name := range []string{"Account 1", "Account 2", "Account 3"} {
+		_, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(context.Background(), name, []byte("test"))
+		require.NoError(t, err)
+	}
+	walletData, err := store.RetrieveWalletByID(wallet.ID())
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	writer, err := util.NewWalletExportWriter(buf, []byte("ce%NohGhah4ye5ra"))
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteWallet(walletData))
+	for accountData := range store.RetrieveAccounts(wallet.ID()) {
+		require.NoError(t, writer.WriteAccounts([][]byte{accountData}))
+	}
+	require.NoError(t, writer.Close())
+	file := filepath.Join(base, "export.dat")
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0o600))
+	truncatedFile := filepath.Join(base, "truncated.dat")
+	require.NoError(t, os.WriteFile(truncatedFile, buf.Bytes()[:buf.Len()-10], 0o600))
+
+	importStore := scratch.New()
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "PassphraseIncorrect",
+			dataIn: &dataIn{
+				file:       file,
+				passphrase: "weak",
+				store:      importStore,
+			},
+			err: "failed to read wallet: failed to decrypt export; passphrase incorrect or data corrupt",
+		},
+		{
+			name: "Truncated",
+			dataIn: &dataIn{
+				file:       truncatedFile,
+				passphrase: "ce%NohGhah4ye5ra",
+				verify:     true,
+			},
+			err: "failed to read accounts: export is truncated",
+		},
+		{
+			name: "Verify",
+			dataIn: &dataIn{
+				file:       file,
+				passphrase: "ce%NohGhah4ye5ra",
+				verify:     true,
+			},
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				file:       file,
+				passphrase: "ce%NohGhah4ye5ra",
+				store:      importStore,
+			},
+		},
+		{
+			name: "Duplicate",
+			dataIn: &dataIn{
+				file:       file,
+				passphrase: "ce%NohGhah4ye5ra",
+				store:      importStore,
+			},
+			err: `wallet "Test wallet" already exists`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Test wallet", res.export.Wallet.Name)
+			require.Len(t, res.export.Accounts, 3)
+		})
+	}
+
+	// Ensure the imported wallet is usable.
+	imported, err := nd.OpenWallet(context.Background(), "Test wallet", importStore, keystorev4.New())
+	require.NoError(t, err)
+	account, err := imported.(e2wtypes.WalletAccountByNameProvider).AccountByName(context.Background(), "Account 2")
+	require.NoError(t, err)
+	require.Equal(t, "Account 2", account.Name())
+}
@@ -0,0 +1,53 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletlock
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.removed, err = c.agent.RemoveKeys(ctx, c.walletName)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove keys from agent")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.agent != nil {
+		// Already set up.
+		return nil
+	}
+
+	var err error
+	c.agent, err = util.Agent(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to agent")
+	}
+	if c.agent == nil {
+		return errors.New("agent is not running")
+	}
+
+	return nil
+}
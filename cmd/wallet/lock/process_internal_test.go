@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletlock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+	keys := make([]*agent.Key, 0, 2)
+	for _, wallet := range []string{"Wallet 1", "Wallet 2"} {
+		privKey, err := e2types.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		keys = append(keys, &agent.Key{
+			Wallet:    wallet,
+			Account:   "Account",
+			PublicKey: privKey.PublicKey().Marshal(),
+			SecretKey: privKey.Marshal(),
+		})
+	}
+	require.NoError(t, store.AddKeys(ctx, keys, time.Minute))
+
+	c := &command{
+		timeout:    time.Second,
+		walletName: "Wallet 1",
+		agent:      store,
+	}
+	require.NoError(t, c.process(ctx))
+	require.Equal(t, 1, c.removed)
+
+	res, err := c.output(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Removed 1 keys for wallet Wallet 1 from the agent", res)
+
+	status, err := store.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status.Keys, 1)
+	require.Equal(t, "Wallet 2", status.Keys[0].Wallet)
+}
@@ -32,6 +32,7 @@ type dataIn struct {
 	file         string
 	participants uint32
 	threshold    uint32
+	approvals    []string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -79,5 +80,7 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, errors.New("threshold cannot be more than participants")
 	}
 
+	data.approvals = viper.GetStringSlice("approvals")
+
 	return data, nil
 }
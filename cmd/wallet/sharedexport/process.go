@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/shamir"
+	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -39,6 +40,11 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data.wallet == nil {
 		return nil, errors.New("wallet is required")
 	}
+	// An export contains the private keys of its accounts, so is gated in the
+	// same way as exporting the key of a single account.
+	if err := util.CheckWalletCompositeApprovals(ctx, util.CompositeOperationKey, data.wallet, nil, data.approvals); err != nil {
+		return nil, err
+	}
 
 	passphrase := make([]byte, 64)
 	n, err := rand.Read(passphrase)
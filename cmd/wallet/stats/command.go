@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	walletName string
+
+	// Output.
+	store         string
+	location      string
+	wallets       []*walletStats
+	accounts      int
+	diskUsage     int64
+	kdfs          map[string]int
+	lastModified  time.Time
+	orphanedFiles []string
+}
+
+// walletStats are the statistics for a single wallet.
+type walletStats struct {
+	name         string
+	id           string
+	walletType   string
+	accounts     int
+	diskUsage    int64
+	kdfs         map[string]int
+	lastModified time.Time
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		json:          viper.GetBool("json"),
+		walletName:    viper.GetString("wallet"),
+		wallets:       make([]*walletStats, 0),
+		kdfs:          make(map[string]int),
+		orphanedFiles: make([]string, 0),
+	}
+
+	return c, nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type jsonOutput struct {
+	Store         string         `json:"store"`
+	Location      string         `json:"location"`
+	Wallets       []*walletJSON  `json:"wallets"`
+	Accounts      int            `json:"accounts"`
+	DiskUsage     string         `json:"disk_usage"`
+	KDFs          map[string]int `json:"kdfs"`
+	LastModified  string         `json:"last_modified,omitempty"`
+	OrphanedFiles []string       `json:"orphaned_files"`
+}
+
+type walletJSON struct {
+	Name         string         `json:"name"`
+	ID           string         `json:"id"`
+	Type         string         `json:"type"`
+	Accounts     int            `json:"accounts"`
+	DiskUsage    string         `json:"disk_usage"`
+	KDFs         map[string]int `json:"kdfs"`
+	LastModified string         `json:"last_modified,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Store:         c.store,
+		Location:      c.location,
+		Wallets:       make([]*walletJSON, 0, len(c.wallets)),
+		Accounts:      c.accounts,
+		DiskUsage:     fmt.Sprintf("%d", c.diskUsage),
+		KDFs:          c.kdfs,
+		LastModified:  formatTime(c.lastModified),
+		OrphanedFiles: c.orphanedFiles,
+	}
+	for _, wallet := range c.wallets {
+		output.Wallets = append(output.Wallets, &walletJSON{
+			Name:         wallet.name,
+			ID:           wallet.id,
+			Type:         wallet.walletType,
+			Accounts:     wallet.accounts,
+			DiskUsage:    fmt.Sprintf("%d", wallet.diskUsage),
+			KDFs:         wallet.kdfs,
+			LastModified: formatTime(wallet.lastModified),
+		})
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal JSON")
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Store: %s\n", c.store))
+	builder.WriteString(fmt.Sprintf("Location: %s\n", c.location))
+	builder.WriteString(fmt.Sprintf("Wallets: %d\n", len(c.wallets)))
+	builder.WriteString(fmt.Sprintf("Accounts: %d\n", c.accounts))
+	builder.WriteString(fmt.Sprintf("Disk usage: %s\n", formatBytes(c.diskUsage)))
+	writeKDFs(&builder, "", c.kdfs)
+	if !c.lastModified.IsZero() {
+		builder.WriteString(fmt.Sprintf("Last modified: %s\n", formatTime(c.lastModified)))
+	}
+	builder.WriteString(fmt.Sprintf("Orphaned files: %d\n", len(c.orphanedFiles)))
+	if c.verbose {
+		for _, file := range c.orphanedFiles {
+			builder.WriteString(fmt.Sprintf("  %s\n", file))
+		}
+		for _, wallet := range c.wallets {
+			builder.WriteString(fmt.Sprintf("Wallet %s:\n", wallet.name))
+			builder.WriteString(fmt.Sprintf("  UUID: %s\n", wallet.id))
+			builder.WriteString(fmt.Sprintf("  Type: %s\n", wallet.walletType))
+			builder.WriteString(fmt.Sprintf("  Accounts: %d\n", wallet.accounts))
+			builder.WriteString(fmt.Sprintf("  Disk usage: %s\n", formatBytes(wallet.diskUsage)))
+			writeKDFs(&builder, "  ", wallet.kdfs)
+			if !wallet.lastModified.IsZero() {
+				builder.WriteString(fmt.Sprintf("  Last modified: %s\n", formatTime(wallet.lastModified)))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// writeKDFs writes the key derivation functions in use, most common first.
+func writeKDFs(builder *strings.Builder, indent string, kdfs map[string]int) {
+	if len(kdfs) == 0 {
+		return
+	}
+	names := make([]string, 0, len(kdfs))
+	for name := range kdfs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i int, j int) bool {
+		if kdfs[names[i]] != kdfs[names[j]] {
+			return kdfs[names[i]] > kdfs[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	builder.WriteString(fmt.Sprintf("%sKey derivation functions:\n", indent))
+	for _, name := range names {
+		builder.WriteString(fmt.Sprintf("%s  %s: %d\n", indent, name, kdfs[name]))
+	}
+}
+
+func formatTime(input time.Time) string {
+	if input.IsZero() {
+		return ""
+	}
+
+	return input.UTC().Format(time.RFC3339)
+}
+
+// formatBytes formats a number of bytes using binary units.
+func formatBytes(input int64) string {
+	const unit = 1024
+	if input < unit {
+		return fmt.Sprintf("%d B", input)
+	}
+	div, exp := int64(unit), 0
+	for n := input / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(input)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,214 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Files in a wallet directory that are not accounts.
+const (
+	indexFilename = "index"
+	batchFilename = "batch"
+)
+
+// kdfNone is the KDF reported for accounts without encrypted keys.
+const kdfNone = "none"
+
+func (c *command) process(_ context.Context) error {
+	if viper.GetString("remote") != "" {
+		return errors.New("wallet stats not available with remote wallets")
+	}
+	store, isStore := viper.Get("store").(e2wtypes.Store)
+	if !isStore {
+		return errors.New("store not set up")
+	}
+
+	return c.processStore(store)
+}
+
+func (c *command) processStore(store e2wtypes.Store) error {
+	c.store = store.Name()
+	if c.store != "filesystem" {
+		return fmt.Errorf("wallet stats not available for %s store", c.store)
+	}
+	storeLocationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
+	if !isProvider {
+		return errors.New("cannot obtain store location")
+	}
+	c.location = storeLocationProvider.Location()
+
+	entries, err := os.ReadDir(c.location)
+	if err != nil {
+		return errors.Wrap(err, "failed to read store")
+	}
+
+	walletFound := false
+	for _, entry := range entries {
+		path := filepath.Join(c.location, entry.Name())
+		walletID, err := uuid.Parse(entry.Name())
+		if err != nil || !entry.IsDir() {
+			if c.walletName == "" {
+				c.orphanedFiles = append(c.orphanedFiles, path)
+			}
+			continue
+		}
+
+		header, err := store.RetrieveWalletByID(walletID)
+		if err != nil {
+			// Directory without a readable wallet.
+			if c.walletName == "" {
+				c.orphanedFiles = append(c.orphanedFiles, path)
+			}
+			continue
+		}
+		wallet := &struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(header, wallet); err != nil {
+			if c.walletName == "" {
+				c.orphanedFiles = append(c.orphanedFiles, path)
+			}
+			continue
+		}
+		if c.walletName != "" && wallet.Name != c.walletName {
+			continue
+		}
+		walletFound = true
+
+		stats, err := c.processWallet(store, path, walletID, wallet.Name, wallet.Type)
+		if err != nil {
+			return err
+		}
+		c.wallets = append(c.wallets, stats)
+		c.accounts += stats.accounts
+		c.diskUsage += stats.diskUsage
+		for kdf, count := range stats.kdfs {
+			c.kdfs[kdf] += count
+		}
+		if stats.lastModified.After(c.lastModified) {
+			c.lastModified = stats.lastModified
+		}
+	}
+
+	if c.walletName != "" && !walletFound {
+		return fmt.Errorf("wallet %s not found", c.walletName)
+	}
+
+	sort.Slice(c.wallets, func(i int, j int) bool {
+		return c.wallets[i].name < c.wallets[j].name
+	})
+	sort.Strings(c.orphanedFiles)
+
+	return nil
+}
+
+func (c *command) processWallet(store e2wtypes.Store,
+	path string,
+	walletID uuid.UUID,
+	name string,
+	walletType string,
+) (
+	*walletStats,
+	error,
+) {
+	stats := &walletStats{
+		name:       name,
+		id:         walletID.String(),
+		walletType: walletType,
+		kdfs:       make(map[string]int),
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read wallet %s", name))
+	}
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			c.orphanedFiles = append(c.orphanedFiles, entryPath)
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain information for %s", entryPath))
+		}
+		stats.diskUsage += info.Size()
+		if info.ModTime().After(stats.lastModified) {
+			stats.lastModified = info.ModTime()
+		}
+
+		switch entry.Name() {
+		case walletID.String(), indexFilename, batchFilename:
+			// Not an account.
+			continue
+		}
+
+		accountID, err := uuid.Parse(entry.Name())
+		if err != nil {
+			c.orphanedFiles = append(c.orphanedFiles, entryPath)
+			continue
+		}
+		kdf, err := accountKDF(store, walletID, accountID)
+		if err != nil {
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Account file %s is not valid: %v\n", entryPath, err)
+			}
+			c.orphanedFiles = append(c.orphanedFiles, entryPath)
+			continue
+		}
+		stats.accounts++
+		stats.kdfs[kdf]++
+	}
+
+	return stats, nil
+}
+
+// accountKDF returns the key derivation function used to encrypt the key of an account.
+func accountKDF(store e2wtypes.Store, walletID uuid.UUID, accountID uuid.UUID) (string, error) {
+	data, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return "", err
+	}
+	account := &struct {
+		UUID   string `json:"uuid"`
+		Crypto *struct {
+			KDF *struct {
+				Function string `json:"function"`
+			} `json:"kdf"`
+		} `json:"crypto"`
+	}{}
+	if err := json.Unmarshal(data, account); err != nil {
+		return "", err
+	}
+	if account.UUID != accountID.String() {
+		return "", errors.New("account ID does not match filename")
+	}
+	if account.Crypto == nil || account.Crypto.KDF == nil || account.Crypto.KDF.Function == "" {
+		return kdfNone, nil
+	}
+
+	return account.Crypto.KDF.Function, nil
+}
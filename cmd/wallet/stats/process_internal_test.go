@@ -0,0 +1,148 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletstats
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcessStore(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	base, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(base)
+	store := filesystem.New(filesystem.WithLocation(base))
+	require.NoError(t, e2wallet.UseStore(store))
+
+	wallet1, err := nd.CreateWallet(context.Background(), "Wallet 1", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet1.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	_, err = wallet1.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	_, err = wallet1.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 1",
+		testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	wallet2, err := nd.CreateWallet(context.Background(), "Wallet 2", store, keystorev4.New(keystorev4.WithCipher("scrypt")))
+	require.NoError(t, err)
+	require.NoError(t, wallet2.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	_, err = wallet2.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 2",
+		testutil.HexToBytes("0x315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	// Add orphaned files.
+	require.NoError(t, os.WriteFile(filepath.Join(base, "notes.txt"), []byte("notes"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(base, "3c1e8ab0-0ad2-4b8c-9d5b-c4d1b1a5b2c1"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(base, wallet1.ID().String(), "backup"), []byte("backup"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(base, wallet1.ID().String(), "0b0fe1b2-8d1c-4c1e-9c4f-2d3d2b1b0a9f"), []byte("bad"), 0o600))
+
+	tests := []struct {
+		name          string
+		walletName    string
+		store         e2wtypes.Store
+		wallets       int
+		accounts      int
+		kdfs          map[string]int
+		orphanedFiles []string
+		err           string
+	}{
+		{
+			name:  "NotFilesystem",
+			store: scratch.New(),
+			err:   "wallet stats not available for scratch store",
+		},
+		{
+			name:     "All",
+			store:    store,
+			wallets:  2,
+			accounts: 3,
+			kdfs:     map[string]int{"pbkdf2": 2, "scrypt": 1},
+			orphanedFiles: []string{
+				filepath.Join(base, "3c1e8ab0-0ad2-4b8c-9d5b-c4d1b1a5b2c1"),
+				filepath.Join(base, wallet1.ID().String(), "0b0fe1b2-8d1c-4c1e-9c4f-2d3d2b1b0a9f"),
+				filepath.Join(base, wallet1.ID().String(), "backup"),
+				filepath.Join(base, "notes.txt"),
+			},
+		},
+		{
+			name:          "SingleWallet",
+			walletName:    "Wallet 2",
+			store:         store,
+			wallets:       1,
+			accounts:      1,
+			kdfs:          map[string]int{"scrypt": 1},
+			orphanedFiles: []string{},
+		},
+		{
+			name:       "WalletUnknown",
+			walletName: "Unknown",
+			store:      store,
+			err:        "wallet Unknown not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				walletName:    test.walletName,
+				wallets:       make([]*walletStats, 0),
+				kdfs:          make(map[string]int),
+				orphanedFiles: make([]string, 0),
+			}
+			err := c.processStore(test.store)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.wallets, test.wallets)
+			require.Equal(t, test.accounts, c.accounts)
+			require.Equal(t, test.kdfs, c.kdfs)
+			require.ElementsMatch(t, test.orphanedFiles, c.orphanedFiles)
+			require.Positive(t, c.diskUsage)
+			require.False(t, c.lastModified.IsZero())
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	require.Equal(t, "0 B", formatBytes(0))
+	require.Equal(t, "1023 B", formatBytes(1023))
+	require.Equal(t, "1.0 KiB", formatBytes(1024))
+	require.Equal(t, "1.5 MiB", formatBytes(1536*1024))
+}
@@ -0,0 +1,43 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletunlock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Unlocked %d accounts for %s\n", len(c.unlocked), c.lifetime))
+	if c.verbose {
+		for _, name := range c.unlocked {
+			builder.WriteString(fmt.Sprintf("  %s\n", name))
+		}
+	}
+	if len(c.failed) > 0 {
+		builder.WriteString(fmt.Sprintf("Failed to unlock %d accounts\n", len(c.failed)))
+		for _, name := range c.failed {
+			builder.WriteString(fmt.Sprintf("  %s\n", name))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
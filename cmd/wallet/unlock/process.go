@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletunlock
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	wallet, err := util.WalletFromInput(opCtx)
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	keys := c.unlockWallet(ctx, wallet)
+	if len(keys) == 0 {
+		return errors.New("no accounts could be unlocked")
+	}
+
+	if err := c.agent.AddKeys(ctx, keys, c.lifetime); err != nil {
+		return errors.Wrap(err, "failed to add keys to agent")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.agent != nil {
+		// Already set up.
+		return nil
+	}
+
+	var err error
+	c.agent, err = util.Agent(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to agent")
+	}
+	if c.agent == nil {
+		return errors.New(`agent is not running; start it with "ethdo agent start"`)
+	}
+
+	return nil
+}
+
+// unlockWallet unlocks the accounts in the wallet, returning their keys.
+// Accounts that cannot be unlocked with the supplied passphrases are noted
+// and skipped.
+func (c *command) unlockWallet(ctx context.Context, wallet e2wtypes.Wallet) []*agent.Key {
+	keys := make([]*agent.Key, 0)
+	for account := range wallet.Accounts(ctx) {
+		key, err := c.unlockAccount(ctx, account)
+		if err != nil {
+			util.Log.Debug().Str("account", account.Name()).Err(err).Msg("Failed to unlock account")
+			c.failed = append(c.failed, account.Name())
+			continue
+		}
+		key.Wallet = wallet.Name()
+		keys = append(keys, key)
+		c.unlocked = append(c.unlocked, account.Name())
+	}
+
+	return keys
+}
+
+// unlockAccount unlocks an account to obtain its key.
+func (c *command) unlockAccount(ctx context.Context, account e2wtypes.Account) (*agent.Key, error) {
+	privateKeyProvider, isPrivateKeyProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isPrivateKeyProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+
+	alreadyUnlocked, err := util.UnlockAccount(ctx, account, c.passphrases)
+	if err != nil {
+		return nil, err
+	}
+	if !alreadyUnlocked {
+		// Because we unlocked the account we should re-lock it when we're done.
+		defer func() {
+			if err := util.LockAccount(ctx, account); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock account")
+			}
+		}()
+	}
+
+	privKey, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+
+	return &agent.Key{
+		Account:   account.Name(),
+		PublicKey: privKey.PublicKey().Marshal(),
+		SecretKey: privKey.Marshal(),
+	}, nil
+}
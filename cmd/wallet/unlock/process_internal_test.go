@@ -0,0 +1,90 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletunlock
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func TestUnlockWallet(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wallet, err := nd.CreateWallet(ctx, "Test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	_, err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+		"Interop 0",
+		hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	_, err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx,
+		"Interop 1",
+		hexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("other"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Lock(ctx))
+
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+
+	c := &command{
+		timeout:     time.Second,
+		passphrases: []string{"pass"},
+		lifetime:    time.Minute,
+		agent:       store,
+	}
+	keys := c.unlockWallet(ctx, wallet)
+	require.Len(t, keys, 1)
+	require.Equal(t, "Test", keys[0].Wallet)
+	require.Equal(t, "Interop 0", keys[0].Account)
+	require.Equal(t, hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"), keys[0].PublicKey)
+	require.Equal(t, []string{"Interop 0"}, c.unlocked)
+	require.Equal(t, []string{"Interop 1"}, c.failed)
+
+	// Accounts are locked again afterwards.
+	account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Interop 0")
+	require.NoError(t, err)
+	unlocked, err := account.(e2wtypes.AccountLocker).IsUnlocked(ctx)
+	require.NoError(t, err)
+	require.False(t, unlocked)
+
+	require.NoError(t, c.agent.AddKeys(ctx, keys, c.lifetime))
+	res, err := c.output(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Unlocked 1 accounts for 1m0s\nFailed to unlock 1 accounts\n  Interop 1", res)
+}
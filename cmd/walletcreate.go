@@ -28,6 +28,10 @@ var walletCreateCmd = &cobra.Command{
 
     ethdo wallet create --wallet="Primary wallet" --type=non-deterministic
 
+A watch-only wallet, containing the public keys but no private keys of its accounts, can be created from a list of public keys or validators on the chain.  For example:
+
+    ethdo wallet create --wallet="Watched" --type=watch-only --pubkeys=pubkeys.txt --validators=1000-1099
+
 In quiet mode this will return 0 if the wallet is created successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := walletcreate.Run(cmd)
@@ -44,11 +48,19 @@ In quiet mode this will return 0 if the wallet is created successfully, otherwis
 func init() {
 	walletCmd.AddCommand(walletCreateCmd)
 	walletFlags(walletCreateCmd)
-	walletCreateCmd.Flags().String("type", "non-deterministic", "Type of wallet to create (non-deterministic or hierarchical deterministic)")
+	walletCreateCmd.Flags().String("type", "non-deterministic", "Type of wallet to create (non-deterministic, hierarchical deterministic or watch-only)")
+	walletCreateCmd.Flags().String("pubkeys", "", "Public keys for a watch-only wallet, as a comma-separated list or the path to a file with one public key per line")
+	walletCreateCmd.Flags().StringSlice("validators", nil, "Validators on the chain for a watch-only wallet, as indices, ranges or public keys")
 }
 
 func walletCreateBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("type", cmd.Flags().Lookup("type")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("pubkeys", cmd.Flags().Lookup("pubkeys")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
 }
@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	walletexport "github.com/wealdtech/ethdo/cmd/wallet/export"
 )
 
@@ -27,6 +28,10 @@ var walletExportCmd = &cobra.Command{
 
     ethdo wallet export --wallet=primary --passphrase="my export secret"
 
+With --file the wallet is streamed to the file in chunks of accounts, allowing large wallets to be exported without holding all of their accounts in memory.  --accounts selects the accounts to export by name, using glob patterns.  For example:
+
+    ethdo wallet export --wallet=primary --passphrase="my export secret" --file=primary.export --accounts="Validators/*" --progress
+
 In quiet mode this will return 0 if the wallet is able to be exported, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := walletexport.Run(cmd)
@@ -43,4 +48,27 @@ In quiet mode this will return 0 if the wallet is able to be exported, otherwise
 func init() {
 	walletCmd.AddCommand(walletExportCmd)
 	walletFlags(walletExportCmd)
+	walletExportCmd.Flags().String("file", "", "Name of the file to which to stream the export")
+	walletExportCmd.Flags().StringSlice("accounts", nil, "Glob patterns of the names of accounts to export (requires --file)")
+	walletExportCmd.Flags().Int("chunk-size", 1000, "Number of accounts in each chunk of a streamed export")
+	walletExportCmd.Flags().Bool("progress", false, "Report progress of a streamed export")
+	walletExportCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the accounts are members, as JSON or files containing JSON")
+}
+
+func walletExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("accounts", cmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("chunk-size", cmd.Flags().Lookup("chunk-size")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("progress", cmd.Flags().Lookup("progress")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
 }
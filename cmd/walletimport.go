@@ -28,6 +28,8 @@ var walletImportCmd = &cobra.Command{
 
     ethdo wallet import --data=primary --passphrase="my export secret"
 
+Exports streamed to a file by "wallet export --file" are detected and imported in chunks of accounts.
+
 In quiet mode this will return 0 if the wallet is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		res, err := walletimport.Run(cmd)
@@ -46,6 +48,7 @@ func init() {
 	walletFlags(walletImportCmd)
 	walletImportCmd.Flags().String("data", "", "The data to import, or the name of a data import file")
 	walletImportCmd.Flags().Bool("verify", false, "Verify the wallet can be imported, but do not import it")
+	walletImportCmd.Flags().Bool("progress", false, "Report progress of a streamed import")
 }
 
 func walletImportBindings(cmd *cobra.Command) {
@@ -55,4 +58,7 @@ func walletImportBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("verify", cmd.Flags().Lookup("verify")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("progress", cmd.Flags().Lookup("progress")); err != nil {
+		panic(err)
+	}
 }
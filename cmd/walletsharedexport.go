@@ -45,6 +45,7 @@ func init() {
 	walletSharedExportCmd.Flags().Uint32("participants", 0, "Number of participants in sharing scheme")
 	walletSharedExportCmd.Flags().Uint32("threshold", 0, "Number of participants required to recover the export")
 	walletSharedExportCmd.Flags().String("file", "", "Name of the file that stores the export")
+	walletSharedExportCmd.Flags().StringSlice("approvals", nil, "Approvals from composites of which the accounts are members, as JSON or files containing JSON")
 }
 
 func walletSharedExportBindings(cmd *cobra.Command) {
@@ -57,4 +58,7 @@ func walletSharedExportBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("approvals", cmd.Flags().Lookup("approvals")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,48 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	walletstats "github.com/wealdtech/ethdo/cmd/wallet/stats"
+)
+
+var walletStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Obtain statistics about the wallet store",
+	Long: `Obtain statistics about the wallet store, for use when auditing key management filesystems.  For example:
+
+    ethdo wallet stats
+
+This reports the number of wallets and accounts in the store, the disk space they use, the key derivation functions with which account keys are encrypted, when the store was last modified, and files in the store that do not belong to any wallet or account.  Statistics can be restricted to a single wallet with --wallet.  This is only available for the filesystem store.
+
+In quiet mode this will return 0 if the store could be examined, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletstats.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletStatsCmd)
+	walletFlags(walletStatsCmd)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletunlock "github.com/wealdtech/ethdo/cmd/wallet/unlock"
+)
+
+var walletUnlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Add the keys of a wallet's accounts to the key agent",
+	Long: `Unlock the accounts of a wallet and add their keys to the key agent.  For example:
+
+    ethdo wallet unlock --wallet=primary --passphrase="secret" --lifetime=30m
+
+The agent must have been started with "ethdo agent start".  Accounts that cannot be unlocked with the supplied passphrases are skipped.  While the agent holds an account's key the account can be used by other commands without supplying its passphrase; the key is removed when its lifetime expires, or with "ethdo wallet lock".
+
+In quiet mode this will return 0 if any accounts are unlocked, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := walletunlock.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletUnlockCmd)
+	walletFlags(walletUnlockCmd)
+	walletUnlockCmd.Flags().Duration("lifetime", 15*time.Minute, "the time for which the agent holds the keys")
+}
+
+func walletUnlockBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("lifetime", cmd.Flags().Lookup("lifetime")); err != nil {
+		panic(err)
+	}
+}
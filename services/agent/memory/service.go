@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/agent"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Service is an agent that holds keys in memory.
+type Service struct {
+	maxLifetime time.Duration
+	started     time.Time
+
+	mu   sync.Mutex
+	keys map[string]*heldKey
+}
+
+type heldKey struct {
+	wallet    string
+	account   string
+	publicKey []byte
+	key       e2types.PrivateKey
+	expiry    time.Time
+}
+
+// New creates a new in-memory agent.  Expired keys are removed periodically
+// until the context is cancelled.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	s := &Service{
+		maxLifetime: parameters.maxLifetime,
+		started:     time.Now(),
+		keys:        make(map[string]*heldKey),
+	}
+
+	go s.expire(ctx)
+
+	return s, nil
+}
+
+// AddKeys adds keys to the agent, to be held for the given lifetime.  The
+// lifetime is capped at the maximum lifetime of the agent.
+func (s *Service) AddKeys(_ context.Context, keys []*agent.Key, lifetime time.Duration) error {
+	if lifetime <= 0 {
+		return errors.New("lifetime must be positive")
+	}
+	if lifetime > s.maxLifetime {
+		lifetime = s.maxLifetime
+	}
+	expiry := time.Now().Add(lifetime)
+
+	held := make([]*heldKey, 0, len(keys))
+	for _, key := range keys {
+		privKey, err := e2types.BLSPrivateKeyFromBytes(key.SecretKey)
+		if err != nil {
+			return errors.Wrapf(err, "invalid secret key for %s/%s", key.Wallet, key.Account)
+		}
+		if !bytes.Equal(privKey.PublicKey().Marshal(), key.PublicKey) {
+			return fmt.Errorf("secret key for %s/%s does not match its public key", key.Wallet, key.Account)
+		}
+		held = append(held, &heldKey{
+			wallet:    key.Wallet,
+			account:   key.Account,
+			publicKey: privKey.PublicKey().Marshal(),
+			key:       privKey,
+			expiry:    expiry,
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range held {
+		s.keys[string(key.publicKey)] = key
+	}
+
+	return nil
+}
+
+// RemoveKeys removes the keys for the given wallet from the agent, returning
+// the number of keys removed.
+func (s *Service) RemoveKeys(_ context.Context, wallet string) (int, error) {
+	if wallet == "" {
+		return 0, errors.New("wallet is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.remove(func(key *heldKey) bool {
+		return key.wallet == wallet
+	}), nil
+}
+
+// Flush removes all keys from the agent, returning the number of keys removed.
+func (s *Service) Flush(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.remove(func(_ *heldKey) bool {
+		return true
+	}), nil
+}
+
+// Status returns the status of the agent.
+func (s *Service) Status(_ context.Context) (*agent.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired(time.Now())
+
+	status := &agent.Status{
+		Started:     s.started,
+		MaxLifetime: s.maxLifetime,
+		Keys:        make([]*agent.KeyInfo, 0, len(s.keys)),
+	}
+	for _, key := range s.keys {
+		status.Keys = append(status.Keys, &agent.KeyInfo{
+			Wallet:    key.wallet,
+			Account:   key.account,
+			PublicKey: key.publicKey,
+			Expiry:    key.expiry,
+		})
+	}
+	sort.Slice(status.Keys, func(i, j int) bool {
+		if status.Keys[i].Wallet != status.Keys[j].Wallet {
+			return status.Keys[i].Wallet < status.Keys[j].Wallet
+		}
+		return status.Keys[i].Account < status.Keys[j].Account
+	})
+
+	return status, nil
+}
+
+// Sign signs data with the key for the given public key.
+func (s *Service) Sign(_ context.Context, pubKey []byte, data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired(time.Now())
+
+	key, exists := s.keys[string(pubKey)]
+	if !exists {
+		return nil, agent.ErrKeyNotHeld
+	}
+
+	return key.key.Sign(data).Marshal(), nil
+}
+
+// expire removes expired keys periodically until the context is cancelled,
+// at which point all keys are removed.
+func (s *Service) expire(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.remove(func(_ *heldKey) bool {
+				return true
+			})
+			s.mu.Unlock()
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			s.removeExpired(now)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// removeExpired removes keys that have expired.  It must be called with the
+// lock held.
+func (s *Service) removeExpired(now time.Time) {
+	s.remove(func(key *heldKey) bool {
+		return !now.Before(key.expiry)
+	})
+}
+
+// remove removes keys that match the filter, returning the number removed.
+// It must be called with the lock held.
+func (s *Service) remove(filter func(*heldKey) bool) int {
+	removed := 0
+	for pubKey, key := range s.keys {
+		if filter(key) {
+			delete(s.keys, pubKey)
+			removed++
+		}
+	}
+
+	return removed
+}
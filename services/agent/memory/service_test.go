@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func testKey(t *testing.T, wallet string, account string) (*agent.Key, e2types.PublicKey) {
+	t.Helper()
+
+	privKey, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+
+	return &agent.Key{
+		Wallet:    wallet,
+		Account:   account,
+		PublicKey: privKey.PublicKey().Marshal(),
+		SecretKey: privKey.Marshal(),
+	}, privKey.PublicKey()
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := memory.New(ctx, memory.WithMaxLifetime(0))
+	require.EqualError(t, err, "problem with parameters: no maximum lifetime specified")
+
+	_, err = memory.New(ctx)
+	require.NoError(t, err)
+}
+
+func TestService(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := memory.New(ctx, memory.WithMaxLifetime(time.Hour))
+	require.NoError(t, err)
+
+	key1, pubKey1 := testKey(t, "Wallet 1", "Account 1")
+	key2, _ := testKey(t, "Wallet 1", "Account 2")
+	key3, _ := testKey(t, "Wallet 2", "Account 1")
+
+	mismatched, _ := testKey(t, "Wallet 1", "Mismatched")
+	mismatched.PublicKey = key1.PublicKey
+	require.EqualError(t, s.AddKeys(ctx, []*agent.Key{mismatched}, time.Minute), "secret key for Wallet 1/Mismatched does not match its public key")
+	require.EqualError(t, s.AddKeys(ctx, []*agent.Key{key1}, 0), "lifetime must be positive")
+
+	require.NoError(t, s.AddKeys(ctx, []*agent.Key{key1, key2}, time.Minute))
+	// Lifetime is capped at the maximum.
+	require.NoError(t, s.AddKeys(ctx, []*agent.Key{key3}, 2*time.Hour))
+
+	status, err := s.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, status.MaxLifetime)
+	require.Len(t, status.Keys, 3)
+	require.Equal(t, "Account 1", status.Keys[0].Account)
+	require.Equal(t, "Account 2", status.Keys[1].Account)
+	require.Equal(t, "Wallet 2", status.Keys[2].Wallet)
+	require.True(t, status.Keys[2].Expiry.Before(time.Now().Add(time.Hour+time.Second)))
+
+	data := []byte("data to sign")
+	sigData, err := s.Sign(ctx, key1.PublicKey, data)
+	require.NoError(t, err)
+	signature, err := e2types.BLSSignatureFromBytes(sigData)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(data, pubKey1))
+
+	_, err = s.RemoveKeys(ctx, "")
+	require.EqualError(t, err, "wallet is required")
+	removed, err := s.RemoveKeys(ctx, "Wallet 1")
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+	_, err = s.Sign(ctx, key1.PublicKey, data)
+	require.ErrorIs(t, err, agent.ErrKeyNotHeld)
+
+	removed, err = s.Flush(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	status, err = s.Status(ctx)
+	require.NoError(t, err)
+	require.Empty(t, status.Keys)
+}
+
+func TestExpiry(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := memory.New(ctx)
+	require.NoError(t, err)
+
+	key, _ := testKey(t, "Wallet", "Account")
+	require.NoError(t, s.AddKeys(ctx, []*agent.Key{key}, 50*time.Millisecond))
+	_, err = s.Sign(ctx, key.PublicKey, []byte("data"))
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = s.Sign(ctx, key.PublicKey, []byte("data"))
+	require.ErrorIs(t, err, agent.ErrKeyNotHeld)
+}
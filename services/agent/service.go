@@ -0,0 +1,65 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrKeyNotHeld is returned when the agent does not hold the requested key.
+var ErrKeyNotHeld = errors.New("key not held by agent")
+
+// Key is a decrypted key supplied to the agent.
+type Key struct {
+	Wallet    string `json:"wallet"`
+	Account   string `json:"account"`
+	PublicKey []byte `json:"public_key"`
+	SecretKey []byte `json:"secret_key"`
+}
+
+// KeyInfo is information about a key held by the agent.  It does not
+// contain the secret key.
+type KeyInfo struct {
+	Wallet    string    `json:"wallet"`
+	Account   string    `json:"account"`
+	PublicKey []byte    `json:"public_key"`
+	Expiry    time.Time `json:"expiry"`
+}
+
+// Status is the status of the agent.
+type Status struct {
+	Started     time.Time     `json:"started"`
+	MaxLifetime time.Duration `json:"max_lifetime"`
+	Keys        []*KeyInfo    `json:"keys"`
+}
+
+// Service holds decrypted keys in memory for a limited time, signing on
+// behalf of their accounts.  Secret keys are never returned by the service.
+type Service interface {
+	// AddKeys adds keys to the agent, to be held for the given lifetime.
+	AddKeys(ctx context.Context, keys []*Key, lifetime time.Duration) error
+	// RemoveKeys removes the keys for the given wallet from the agent,
+	// returning the number of keys removed.
+	RemoveKeys(ctx context.Context, wallet string) (int, error)
+	// Flush removes all keys from the agent, returning the number of keys
+	// removed.
+	Flush(ctx context.Context) (int, error)
+	// Status returns the status of the agent.
+	Status(ctx context.Context) (*Status, error)
+	// Sign signs data with the key for the given public key.  It returns
+	// ErrKeyNotHeld if the agent does not hold the key.
+	Sign(ctx context.Context, pubKey []byte, data []byte) ([]byte, error)
+}
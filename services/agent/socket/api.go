@@ -0,0 +1,45 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socket
+
+import (
+	"time"
+
+	"github.com/wealdtech/ethdo/services/agent"
+)
+
+const (
+	statusPath = "/status"
+	keysPath   = "/keys"
+	flushPath  = "/flush"
+	signPath   = "/sign"
+)
+
+type addKeysRequest struct {
+	Keys     []*agent.Key  `json:"keys"`
+	Lifetime time.Duration `json:"lifetime"`
+}
+
+type removeKeysResponse struct {
+	Removed int `json:"removed"`
+}
+
+type signRequest struct {
+	PublicKey []byte `json:"public_key"`
+	Data      []byte `json:"data"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
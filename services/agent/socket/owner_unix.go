@@ -0,0 +1,36 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package socket
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// checkOwner returns an error if the file is not owned by the current user.
+func checkOwner(info os.FileInfo) error {
+	stat, isStat := info.Sys().(*syscall.Stat_t)
+	if !isStat {
+		return errors.New("failed to obtain owner of socket")
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return errors.New("socket is owned by another user")
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/agent"
+)
+
+// Serve serves the agent on a unix socket at the given path until the
+// context is cancelled.  The socket is only accessible by the current user,
+// and is removed when serving finishes.
+func Serve(ctx context.Context, path string, service agent.Service) error {
+	if err := prepareSocketPath(path); err != nil {
+		return err
+	}
+
+	listener, err := listen(path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	server := &http.Server{
+		Handler:           handler(service),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "failed to serve")
+	}
+
+	return nil
+}
+
+// prepareSocketPath ensures that there is nothing at the socket path.  A stale
+// socket of the current user is removed, but anything else is left in place
+// and results in an error.
+func prepareSocketPath(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to access socket")
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if err := checkOwner(info); err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		conn.Close()
+		return errors.New("an agent is already listening on the socket")
+	}
+	if err := os.Remove(path); err != nil {
+		return errors.Wrap(err, "failed to remove stale socket")
+	}
+
+	return nil
+}
+
+// listen listens on a unix socket at the given path.  The socket is created
+// in a private directory and restricted to the current user before it is
+// moved to the path, so that it is never accessible to other users.
+func listen(path string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".ethdo-agent-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create directory for socket")
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", tmpPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen on socket")
+	}
+	// The socket is removed by Serve from its final path.
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "failed to set permissions of socket")
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "failed to move socket in to place")
+	}
+
+	return listener, nil
+}
+
+func handler(service agent.Service) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(statusPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		status, err := service.Status(r.Context())
+		respond(w, status, err)
+	})
+
+	mux.HandleFunc(keysPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			req := &addKeysRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				http.Error(w, "invalid request", http.StatusBadRequest)
+				return
+			}
+			respond(w, nil, service.AddKeys(r.Context(), req.Keys, req.Lifetime))
+		case http.MethodDelete:
+			removed, err := service.RemoveKeys(r.Context(), r.URL.Query().Get("wallet"))
+			respond(w, &removeKeysResponse{Removed: removed}, err)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc(flushPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		removed, err := service.Flush(r.Context())
+		respond(w, &removeKeysResponse{Removed: removed}, err)
+	})
+
+	mux.HandleFunc(signPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		req := &signRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		signature, err := service.Sign(r.Context(), req.PublicKey, req.Data)
+		if errors.Is(err, agent.ErrKeyNotHeld) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		respond(w, &signResponse{Signature: signature}, err)
+	})
+
+	return mux
+}
+
+// respond writes the response, or the error if present.
+func respond(w http.ResponseWriter, res any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if res == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
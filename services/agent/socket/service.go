@@ -0,0 +1,158 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/agent"
+)
+
+// Service is a client for an agent served on a unix socket.
+type Service struct {
+	timeout time.Duration
+	client  *http.Client
+}
+
+// New creates a new client for the agent served at the given socket.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	return &Service{
+		timeout: parameters.timeout,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					// Decrypted keys are sent to the agent, so refuse to talk
+					// to a socket belonging to another user.
+					info, err := os.Lstat(parameters.path)
+					if err != nil {
+						return nil, err
+					}
+					if err := checkOwner(info); err != nil {
+						return nil, err
+					}
+					dialer := &net.Dialer{}
+					return dialer.DialContext(ctx, "unix", parameters.path)
+				},
+			},
+		},
+	}, nil
+}
+
+// AddKeys adds keys to the agent, to be held for the given lifetime.
+func (s *Service) AddKeys(ctx context.Context, keys []*agent.Key, lifetime time.Duration) error {
+	return s.do(ctx, http.MethodPost, keysPath, &addKeysRequest{Keys: keys, Lifetime: lifetime}, nil)
+}
+
+// RemoveKeys removes the keys for the given wallet from the agent, returning
+// the number of keys removed.
+func (s *Service) RemoveKeys(ctx context.Context, wallet string) (int, error) {
+	res := &removeKeysResponse{}
+	if err := s.do(ctx, http.MethodDelete, fmt.Sprintf("%s?wallet=%s", keysPath, url.QueryEscape(wallet)), nil, res); err != nil {
+		return 0, err
+	}
+
+	return res.Removed, nil
+}
+
+// Flush removes all keys from the agent, returning the number of keys removed.
+func (s *Service) Flush(ctx context.Context) (int, error) {
+	res := &removeKeysResponse{}
+	if err := s.do(ctx, http.MethodPost, flushPath, nil, res); err != nil {
+		return 0, err
+	}
+
+	return res.Removed, nil
+}
+
+// Status returns the status of the agent.
+func (s *Service) Status(ctx context.Context) (*agent.Status, error) {
+	res := &agent.Status{}
+	if err := s.do(ctx, http.MethodGet, statusPath, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Sign signs data with the key for the given public key.
+func (s *Service) Sign(ctx context.Context, pubKey []byte, data []byte) ([]byte, error) {
+	res := &signResponse{}
+	if err := s.do(ctx, http.MethodPost, signPath, &signRequest{PublicKey: pubKey, Data: data}, res); err != nil {
+		return nil, err
+	}
+
+	return res.Signature, nil
+}
+
+// do carries out a request to the agent, decoding the response in to res if
+// supplied.
+func (s *Service) do(ctx context.Context, method string, path string, req any, res any) error {
+	opCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(data)
+	}
+	// The host is ignored as the connection is made over the socket.
+	httpReq, err := http.NewRequestWithContext(opCtx, method, "http://agent"+path, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return errors.Wrap(err, "failed to contact agent")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && path == signPath {
+		return agent.ErrKeyNotHeld
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("agent returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if res == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package socket_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	"github.com/wealdtech/ethdo/services/agent/socket"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := socket.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no path specified")
+
+	_, err = socket.New(ctx, socket.WithPath("agent.sock"), socket.WithTimeout(0))
+	require.EqualError(t, err, "problem with parameters: no timeout specified")
+}
+
+func TestService(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+
+	client, err := socket.New(ctx, socket.WithPath(path))
+	require.NoError(t, err)
+	_, err = client.Status(ctx)
+	require.ErrorContains(t, err, "failed to contact agent")
+
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+	served := make(chan error)
+	go func() {
+		served <- socket.Serve(ctx, path, store)
+	}()
+	require.Eventually(t, func() bool {
+		_, err := client.Status(ctx)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	privKey, err := e2types.GenerateBLSPrivateKey()
+	require.NoError(t, err)
+	key := &agent.Key{
+		Wallet:    "Wallet",
+		Account:   "Account",
+		PublicKey: privKey.PublicKey().Marshal(),
+		SecretKey: privKey.Marshal(),
+	}
+	require.NoError(t, client.AddKeys(ctx, []*agent.Key{key}, time.Minute))
+	require.ErrorContains(t, client.AddKeys(ctx, []*agent.Key{key}, 0), "lifetime must be positive")
+
+	status, err := client.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status.Keys, 1)
+	require.Equal(t, key.PublicKey, status.Keys[0].PublicKey)
+
+	data := []byte("data to sign")
+	sigData, err := client.Sign(ctx, key.PublicKey, data)
+	require.NoError(t, err)
+	signature, err := e2types.BLSSignatureFromBytes(sigData)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(data, privKey.PublicKey()))
+
+	removed, err := client.RemoveKeys(ctx, "Other wallet")
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+	removed, err = client.Flush(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	_, err = client.Sign(ctx, key.PublicKey, data)
+	require.ErrorIs(t, err, agent.ErrKeyNotHeld)
+
+	cancel()
+	require.NoError(t, <-served)
+	require.NoFileExists(t, path)
+}
+
+func TestServeSocketPath(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+
+	// A file that is not a socket is left alone.
+	filePath := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0o600))
+	require.EqualError(t, socket.Serve(ctx, filePath, store), filePath+" exists and is not a socket")
+	require.FileExists(t, filePath)
+
+	// A stale socket of the current user is replaced.
+	stalePath := filepath.Join(dir, "stale.sock")
+	listener, err := net.Listen("unix", stalePath)
+	require.NoError(t, err)
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+	served := make(chan error)
+	go func() {
+		served <- socket.Serve(ctx, stalePath, store)
+	}()
+	client, err := socket.New(ctx, socket.WithPath(stalePath))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := client.Status(ctx)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// The socket is only accessible by the current user.
+	info, err := os.Stat(stalePath)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	// A socket on which an agent is listening is not replaced.
+	require.EqualError(t, socket.Serve(ctx, stalePath, store), "an agent is already listening on the socket")
+
+	cancel()
+	require.NoError(t, <-served)
+
+	// No temporary directories are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestServeSocketOtherUser(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() != 0 {
+		t.Skip("requires root to create a socket owned by another user")
+	}
+	ctx := context.Background()
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+	require.NoError(t, os.Lchown(path, 65534, 65534))
+
+	require.EqualError(t, socket.Serve(ctx, path, store), "socket is owned by another user")
+
+	client, err := socket.New(ctx, socket.WithPath(path))
+	require.NoError(t, err)
+	_, err = client.Status(ctx)
+	require.ErrorContains(t, err, "socket is owned by another user")
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -55,10 +56,11 @@ func New(ctx context.Context, params ...Parameter) (*Service, error) {
 	}
 	log.Trace().Time("genesis_time", genesisTime).Msg("Obtained genesis time")
 
-	spec, err := parameters.specProvider.Spec(ctx)
+	specResponse, err := parameters.specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 
 	tmp, exists := spec["SECONDS_PER_SLOT"]
 	if !exists {
@@ -237,10 +239,11 @@ func fetchAltairForkEpoch(ctx context.Context,
 	error,
 ) {
 	// Fetch the fork version.
-	spec, err := specProvider.Spec(ctx)
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 	tmp, exists := spec["ALTAIR_FORK_EPOCH"]
 	if !exists {
 		return 0, errors.New("altair fork version not known by chain")
@@ -266,10 +269,11 @@ func fetchBellatrixForkEpoch(ctx context.Context,
 	error,
 ) {
 	// Fetch the fork version.
-	spec, err := specProvider.Spec(ctx)
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 	tmp, exists := spec["BELLATRIX_FORK_EPOCH"]
 	if !exists {
 		return 0, errors.New("bellatrix fork version not known by chain")
@@ -295,10 +299,11 @@ func fetchCapellaForkEpoch(ctx context.Context,
 	error,
 ) {
 	// Fetch the fork version.
-	spec, err := specProvider.Spec(ctx)
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 	tmp, exists := spec["CAPELLA_FORK_EPOCH"]
 	if !exists {
 		return 0, errors.New("capella fork version not known by chain")
@@ -324,10 +329,11 @@ func fetchDenebForkEpoch(ctx context.Context,
 	error,
 ) {
 	// Fetch the fork version.
-	spec, err := specProvider.Spec(ctx)
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to obtain spec")
 	}
+	spec := specResponse.Data
 	tmp, exists := spec["DENEB_FORK_EPOCH"]
 	if !exists {
 		return 0, errors.New("deneb fork version not known by chain")
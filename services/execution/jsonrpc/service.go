@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Service is an execution node accessed over JSON-RPC.
+type Service struct {
+	address string
+	timeout time.Duration
+	client  *http.Client
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// New creates a new JSON-RPC execution service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	address := parameters.address
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	return &Service{
+		address: address,
+		timeout: parameters.timeout,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Address provides the address of the execution node.
+func (s *Service) Address() string {
+	return s.address
+}
+
+// Call calls a JSON-RPC method on the execution node, and unmarshals
+// its result into res.
+// It returns false if the method returned a null result.
+func (s *Service) Call(ctx context.Context,
+	method string,
+	params []interface{},
+	res interface{},
+) (
+	bool,
+	error,
+) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	reqBody, err := json.Marshal(&request{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request body")
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodPost, s.address, bytes.NewReader(reqBody))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to call execution node")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, fmt.Errorf("execution node returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	data := &response{}
+	if err := json.Unmarshal(body, data); err != nil {
+		return false, errors.Wrap(err, "failed to parse response")
+	}
+	if data.Error != nil {
+		return false, fmt.Errorf("%s failed: %s (%d)", method, data.Error.Message, data.Error.Code)
+	}
+	if len(data.Result) == 0 || string(data.Result) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(data.Result, res); err != nil {
+		return false, errors.Wrap(err, "failed to parse result")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,120 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/services/execution/jsonrpc"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := jsonrpc.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no address specified")
+
+	_, err = jsonrpc.New(ctx, jsonrpc.WithAddress("localhost:8545"), jsonrpc.WithTimeout(0))
+	require.EqualError(t, err, "problem with parameters: no timeout specified")
+
+	service, err := jsonrpc.New(ctx, jsonrpc.WithAddress("localhost:8545"))
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8545", service.Address())
+
+	service, err = jsonrpc.New(ctx, jsonrpc.WithAddress("https://execution.example.com/"))
+	require.NoError(t, err)
+	require.Equal(t, "https://execution.example.com/", service.Address())
+}
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string `json:"method"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Method {
+		case "good":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		case "null":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+		case "rpcerror":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`failed`))
+		}
+	}))
+	defer server.Close()
+
+	var service execution.Service
+	service, err := jsonrpc.New(context.Background(),
+		jsonrpc.WithAddress(strings.TrimPrefix(server.URL, "http://")),
+		jsonrpc.WithTimeout(time.Second),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		method string
+		found  bool
+		value  string
+		err    string
+	}{
+		{
+			name:   "Good",
+			method: "good",
+			found:  true,
+			value:  "0x1",
+		},
+		{
+			name:   "Null",
+			method: "null",
+		},
+		{
+			name:   "RPCError",
+			method: "rpcerror",
+			err:    "rpcerror failed: method not found (-32601)",
+		},
+		{
+			name:   "Bad",
+			method: "bad",
+			err:    "execution node returned status 500: failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res string
+			found, err := service.Call(context.Background(), test.method, nil, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.found, found)
+				require.Equal(t, test.value, res)
+			}
+		})
+	}
+}
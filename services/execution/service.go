@@ -0,0 +1,29 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+)
+
+// Service provides access to an execution node.
+type Service interface {
+	// Address provides the address of the execution node.
+	Address() string
+
+	// Call calls a JSON-RPC method on the execution node, and unmarshals
+	// its result into res.
+	// It returns false if the method returned a null result.
+	Call(ctx context.Context, method string, params []interface{}, res interface{}) (bool, error)
+}
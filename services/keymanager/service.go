@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager
+
+import (
+	"context"
+)
+
+// Keystore is a keystore held by a validator client.
+type Keystore struct {
+	// PubKey is the public key of the keystore.
+	PubKey []byte
+	// Path is the derivation path of the keystore, if known.
+	Path string
+	// ReadOnly is true if the keystore cannot be deleted through the API.
+	ReadOnly bool
+}
+
+// Result is the result of importing or deleting a single keystore.
+type Result struct {
+	// Status is the status of the operation, as returned by the validator
+	// client, for example "imported", "duplicate", "deleted" or "error".
+	Status string
+	// Message is additional information about the operation, if any.
+	Message string
+}
+
+// Service provides access to the keymanager API of a validator client.
+type Service interface {
+	// Address provides the address of the keymanager API.
+	Address() string
+
+	// ListKeystores lists the keystores held by the validator client.
+	ListKeystores(ctx context.Context) ([]*Keystore, error)
+
+	// ImportKeystores imports EIP-2335 keystores in to the validator client,
+	// with their passwords and optional EIP-3076 slashing protection data.
+	// Results are in the same order as the keystores.
+	ImportKeystores(ctx context.Context, keystores []string, passwords []string, slashingProtection string) ([]*Result, error)
+
+	// DeleteKeystores deletes keystores from the validator client, returning
+	// the results in the same order as the public keys along with EIP-3076
+	// slashing protection data for the keys.
+	DeleteKeystores(ctx context.Context, pubKeys [][]byte) ([]*Result, string, error)
+}
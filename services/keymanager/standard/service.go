@@ -0,0 +1,229 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/keymanager"
+)
+
+// Service is a validator client accessed through the standard keymanager API.
+type Service struct {
+	address string
+	token   string
+	timeout time.Duration
+	client  *http.Client
+}
+
+type keystoreJSON struct {
+	ValidatingPubKey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path"`
+	ReadOnly         bool   `json:"readonly"`
+}
+
+type listResponseJSON struct {
+	Data []*keystoreJSON `json:"data"`
+}
+
+type importRequestJSON struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+type deleteRequestJSON struct {
+	PubKeys []string `json:"pubkeys"`
+}
+
+type resultJSON struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type resultsResponseJSON struct {
+	Data               []*resultJSON `json:"data"`
+	SlashingProtection string        `json:"slashing_protection"`
+}
+
+// New creates a new keymanager API service.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	address := strings.TrimSuffix(parameters.address, "/")
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	return &Service{
+		address: address,
+		token:   parameters.token,
+		timeout: parameters.timeout,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Address provides the address of the keymanager API.
+func (s *Service) Address() string {
+	return s.address
+}
+
+// ListKeystores lists the keystores held by the validator client.
+func (s *Service) ListKeystores(ctx context.Context) ([]*keymanager.Keystore, error) {
+	resp := &listResponseJSON{}
+	if err := s.do(ctx, http.MethodGet, nil, resp); err != nil {
+		return nil, err
+	}
+
+	res := make([]*keymanager.Keystore, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(data.ValidatingPubKey, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid public key %q", data.ValidatingPubKey))
+		}
+		res = append(res, &keymanager.Keystore{
+			PubKey:   pubKey,
+			Path:     data.DerivationPath,
+			ReadOnly: data.ReadOnly,
+		})
+	}
+
+	return res, nil
+}
+
+// ImportKeystores imports EIP-2335 keystores in to the validator client.
+func (s *Service) ImportKeystores(ctx context.Context,
+	keystores []string,
+	passwords []string,
+	slashingProtection string,
+) (
+	[]*keymanager.Result,
+	error,
+) {
+	if len(keystores) != len(passwords) {
+		return nil, errors.New("number of keystores and passwords differ")
+	}
+
+	resp := &resultsResponseJSON{}
+	if err := s.do(ctx, http.MethodPost, &importRequestJSON{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	}, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(keystores) {
+		return nil, fmt.Errorf("keymanager returned %d results for %d keystores", len(resp.Data), len(keystores))
+	}
+
+	return results(resp.Data), nil
+}
+
+// DeleteKeystores deletes keystores from the validator client.
+func (s *Service) DeleteKeystores(ctx context.Context,
+	pubKeys [][]byte,
+) (
+	[]*keymanager.Result,
+	string,
+	error,
+) {
+	req := &deleteRequestJSON{
+		PubKeys: make([]string, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		req.PubKeys = append(req.PubKeys, fmt.Sprintf("%#x", pubKey))
+	}
+
+	resp := &resultsResponseJSON{}
+	if err := s.do(ctx, http.MethodDelete, req, resp); err != nil {
+		return nil, "", err
+	}
+	if len(resp.Data) != len(pubKeys) {
+		return nil, "", fmt.Errorf("keymanager returned %d results for %d public keys", len(resp.Data), len(pubKeys))
+	}
+
+	return results(resp.Data), resp.SlashingProtection, nil
+}
+
+func results(data []*resultJSON) []*keymanager.Result {
+	res := make([]*keymanager.Result, 0, len(data))
+	for _, result := range data {
+		res = append(res, &keymanager.Result{
+			Status:  result.Status,
+			Message: result.Message,
+		})
+	}
+
+	return res
+}
+
+// do makes a request to the keystores endpoint of the keymanager API.
+func (s *Service) do(ctx context.Context, method string, body interface{}, res interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to create request body")
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, method, s.address+"/eth/v1/keystores", reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.token))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call keymanager")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("keymanager refused access (status %d); check the keymanager token", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("keymanager returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if err := json.Unmarshal(respBody, res); err != nil {
+		return errors.Wrap(err, "failed to parse response")
+	}
+
+	return nil
+}
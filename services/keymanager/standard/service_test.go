@@ -0,0 +1,161 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standard_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/keymanager"
+	"github.com/wealdtech/ethdo/services/keymanager/standard"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := standard.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no address specified")
+
+	_, err = standard.New(ctx, standard.WithAddress("localhost:7500"), standard.WithTimeout(0))
+	require.EqualError(t, err, "problem with parameters: no timeout specified")
+
+	service, err := standard.New(ctx, standard.WithAddress("localhost:7500/"))
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:7500", service.Address())
+}
+
+// newKeymanagerServer creates a keymanager that holds a single keystore and
+// requires the token "secret".
+func newKeymanagerServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/eth/v1/keystores" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":[{"validating_pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","derivation_path":"m/12381/3600/0/0/0","readonly":false},{"validating_pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","readonly":true}]}`))
+		case http.MethodPost:
+			req := struct {
+				Keystores          []string `json:"keystores"`
+				Passwords          []string `json:"passwords"`
+				SlashingProtection string   `json:"slashing_protection"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.SlashingProtection == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"invalid slashing protection"}`))
+				return
+			}
+			data := make([]map[string]string, 0, len(req.Keystores))
+			for i := range req.Keystores {
+				if req.Keystores[i] == "existing" {
+					data = append(data, map[string]string{"status": "duplicate"})
+				} else {
+					data = append(data, map[string]string{"status": "imported"})
+				}
+				require.NotEmpty(t, req.Passwords[i])
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+		case http.MethodDelete:
+			req := struct {
+				PubKeys []string `json:"pubkeys"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			data := make([]map[string]string, 0, len(req.PubKeys))
+			for _, pubKey := range req.PubKeys {
+				if pubKey == "0x01" {
+					data = append(data, map[string]string{"status": "not_found"})
+				} else {
+					data = append(data, map[string]string{"status": "deleted"})
+				}
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data, "slashing_protection": `{"metadata":{}}`}))
+		}
+	}))
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := newKeymanagerServer(t)
+	defer server.Close()
+
+	service, err := standard.New(context.Background(), standard.WithAddress(server.URL))
+	require.NoError(t, err)
+	_, err = service.ListKeystores(context.Background())
+	require.EqualError(t, err, "keymanager refused access (status 401); check the keymanager token")
+}
+
+func TestListKeystores(t *testing.T) {
+	server := newKeymanagerServer(t)
+	defer server.Close()
+
+	var service keymanager.Service
+	service, err := standard.New(context.Background(), standard.WithAddress(server.URL), standard.WithToken("secret"))
+	require.NoError(t, err)
+
+	keystores, err := service.ListKeystores(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keystores, 2)
+	require.Equal(t, byte(0xa9), keystores[0].PubKey[0])
+	require.Len(t, keystores[0].PubKey, 48)
+	require.Equal(t, "m/12381/3600/0/0/0", keystores[0].Path)
+	require.False(t, keystores[0].ReadOnly)
+	require.Equal(t, "", keystores[1].Path)
+	require.True(t, keystores[1].ReadOnly)
+}
+
+func TestImportKeystores(t *testing.T) {
+	server := newKeymanagerServer(t)
+	defer server.Close()
+
+	service, err := standard.New(context.Background(), standard.WithAddress(server.URL), standard.WithToken("secret"))
+	require.NoError(t, err)
+
+	_, err = service.ImportKeystores(context.Background(), []string{"new"}, nil, "")
+	require.EqualError(t, err, "number of keystores and passwords differ")
+
+	_, err = service.ImportKeystores(context.Background(), []string{"new"}, []string{"pass"}, "bad")
+	require.EqualError(t, err, `keymanager returned status 400: {"message":"invalid slashing protection"}`)
+
+	results, err := service.ImportKeystores(context.Background(), []string{"new", "existing"}, []string{"pass1", "pass2"}, "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "imported", results[0].Status)
+	require.Equal(t, "duplicate", results[1].Status)
+}
+
+func TestDeleteKeystores(t *testing.T) {
+	server := newKeymanagerServer(t)
+	defer server.Close()
+
+	service, err := standard.New(context.Background(), standard.WithAddress(server.URL), standard.WithToken("secret"))
+	require.NoError(t, err)
+
+	results, slashingProtection, err := service.DeleteKeystores(context.Background(), [][]byte{{0x02}, {0x01}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "deleted", results[0].Status)
+	require.Equal(t, "not_found", results[1].Status)
+	require.Equal(t, `{"metadata":{}}`, slashingProtection)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainlink
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// defaultFeeds are the Chainlink price feeds for Ether on mainnet.
+var defaultFeeds = map[string]string{
+	"usd": "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+}
+
+type parameters struct {
+	execution execution.Service
+	feeds     map[string]string
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithExecution sets the execution node through which the feeds are read.
+func WithExecution(execution execution.Service) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.execution = execution
+	})
+}
+
+// WithFeed sets the address of the price feed for a currency, replacing any
+// default feed for that currency.
+func WithFeed(currency string, address string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.feeds[strings.ToLower(currency)] = address
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		feeds: make(map[string]string),
+	}
+	for currency, address := range defaultFeeds {
+		parameters.feeds[currency] = address
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.execution == nil {
+		return nil, errors.New("no execution specified")
+	}
+	if len(parameters.feeds) == 0 {
+		return nil, errors.New("no feeds specified")
+	}
+
+	return &parameters, nil
+}
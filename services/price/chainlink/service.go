@@ -0,0 +1,206 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainlink
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+const (
+	// decimalsSelector is the selector of the aggregator's decimals() function.
+	decimalsSelector = "0x313ce567"
+	// latestRoundDataSelector is the selector of the aggregator's latestRoundData() function.
+	latestRoundDataSelector = "0xfeaf968c"
+)
+
+// Service is a price oracle that obtains prices from Chainlink price feeds,
+// read through an execution node.
+type Service struct {
+	execution execution.Service
+	feeds     map[string]string
+}
+
+type blockJSON struct {
+	Number    string `json:"number"`
+	Timestamp string `json:"timestamp"`
+}
+
+// New creates a new Chainlink price oracle.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	return &Service{
+		execution: parameters.execution,
+		feeds:     parameters.feeds,
+	}, nil
+}
+
+// Name provides the name of the price oracle.
+func (*Service) Name() string {
+	return "chainlink"
+}
+
+// Price provides the price of a single Ether in the given fiat currency.
+// Historical prices are those held by the feed at the last block at or before
+// the timestamp.
+func (s *Service) Price(ctx context.Context, currency string, timestamp time.Time) (decimal.Decimal, error) {
+	feed, exists := s.feeds[strings.ToLower(currency)]
+	if !exists {
+		return decimal.Zero, fmt.Errorf("no price feed for currency %q", strings.ToLower(currency))
+	}
+
+	block := "latest"
+	if !timestamp.IsZero() {
+		number, err := s.blockAt(ctx, timestamp)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		block = fmt.Sprintf("%#x", number)
+	}
+
+	data, err := s.call(ctx, feed, decimalsSelector, block)
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "failed to obtain feed decimals")
+	}
+	if len(data) < 32 {
+		return decimal.Zero, errors.New("feed returned invalid decimals")
+	}
+	decimals := new(big.Int).SetBytes(data[:32])
+	if !decimals.IsInt64() || decimals.Int64() > 77 {
+		return decimal.Zero, errors.New("feed returned invalid decimals")
+	}
+
+	data, err = s.call(ctx, feed, latestRoundDataSelector, block)
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "failed to obtain feed price")
+	}
+	// Round data is (roundId, answer, startedAt, updatedAt, answeredInRound);
+	// the answer is a signed value so a set top bit is a negative price.
+	if len(data) < 160 || data[32]&0x80 != 0 {
+		return decimal.Zero, errors.New("feed returned invalid price")
+	}
+	answer := new(big.Int).SetBytes(data[32:64])
+	if answer.Sign() == 0 {
+		return decimal.Zero, errors.New("feed returned invalid price")
+	}
+
+	return decimal.NewFromBigInt(answer, -int32(decimals.Int64())), nil
+}
+
+// call calls a function with no arguments on a contract.
+func (s *Service) call(ctx context.Context, address string, selector string, block string) ([]byte, error) {
+	var res string
+	found, err := s.execution.Call(ctx, "eth_call", []interface{}{
+		map[string]string{
+			"to":   address,
+			"data": selector,
+		},
+		block,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("eth_call returned no result")
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(res, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid result")
+	}
+
+	return data, nil
+}
+
+// blockAt returns the number of the last block at or before the timestamp.
+func (s *Service) blockAt(ctx context.Context, timestamp time.Time) (uint64, error) {
+	latest, latestTimestamp, err := s.block(ctx, "latest")
+	if err != nil {
+		return 0, err
+	}
+	if timestamp.Unix() >= int64(latestTimestamp) {
+		return latest, nil
+	}
+
+	_, firstTimestamp, err := s.block(ctx, "0x0")
+	if err != nil {
+		return 0, err
+	}
+	if timestamp.Unix() < int64(firstTimestamp) {
+		return 0, fmt.Errorf("no block at %s", timestamp.UTC().Format(time.RFC3339))
+	}
+
+	// Binary search for the block, with block low always at or before the
+	// timestamp and block high always after it.
+	low := uint64(0)
+	high := latest
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		_, midTimestamp, err := s.block(ctx, fmt.Sprintf("%#x", mid))
+		if err != nil {
+			return 0, err
+		}
+		if int64(midTimestamp) <= timestamp.Unix() {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
+
+// block returns the number and timestamp of a block.
+func (s *Service) block(ctx context.Context, id string) (uint64, uint64, error) {
+	block := &blockJSON{}
+	found, err := s.execution.Call(ctx, "eth_getBlockByNumber", []interface{}{id, false}, block)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to obtain block")
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("block %s not found", id)
+	}
+
+	number, err := parseQuantity(block.Number)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid block number")
+	}
+	timestamp, err := parseQuantity(block.Timestamp)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid block timestamp")
+	}
+
+	return number, timestamp, nil
+}
+
+func parseQuantity(input string) (uint64, error) {
+	res, success := new(big.Int).SetString(strings.TrimPrefix(input, "0x"), 16)
+	if !strings.HasPrefix(input, "0x") || !success || !res.IsUint64() {
+		return 0, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res.Uint64(), nil
+}
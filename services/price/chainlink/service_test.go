@@ -0,0 +1,183 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainlink_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/execution/jsonrpc"
+	"github.com/wealdtech/ethdo/services/price"
+	"github.com/wealdtech/ethdo/services/price/chainlink"
+)
+
+// newExecutionServer creates an execution node with 101 blocks 12 seconds
+// apart, starting at timestamp 1000, and a feed whose price in each block is
+// 1000 plus the block number.
+func newExecutionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	const latest = 100
+	blockNumber := func(id string) int64 {
+		if id == "latest" {
+			return latest
+		}
+		number, success := new(big.Int).SetString(strings.TrimPrefix(id, "0x"), 16)
+		require.True(t, success)
+		return number.Int64()
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result string
+		switch req.Method {
+		case "eth_getBlockByNumber":
+			var id string
+			require.NoError(t, json.Unmarshal(req.Params[0], &id))
+			number := blockNumber(id)
+			result = fmt.Sprintf(`{"number":"%#x","timestamp":"%#x"}`, number, 1000+12*number)
+		case "eth_call":
+			call := map[string]string{}
+			require.NoError(t, json.Unmarshal(req.Params[0], &call))
+			var id string
+			require.NoError(t, json.Unmarshal(req.Params[1], &id))
+			switch {
+			case call["to"] == "0x0000000000000000000000000000000000000001":
+				// Negative price.
+				result = fmt.Sprintf(`"0x%064x%s%0192x"`, 1, strings.Repeat("f", 64), 0)
+			case call["data"] == "0x313ce567":
+				result = fmt.Sprintf(`"0x%064x"`, 8)
+			default:
+				answer := new(big.Int).Mul(big.NewInt(1000+blockNumber(id)), big.NewInt(100000000))
+				answer.Add(answer, big.NewInt(12345678))
+				result = fmt.Sprintf(`"0x%064x%064x%0192x"`, 1, answer, 0)
+			}
+		default:
+			result = "null"
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%s}`, result)))
+	}))
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := chainlink.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no execution specified")
+
+	execution, err := jsonrpc.New(ctx, jsonrpc.WithAddress("localhost:8545"))
+	require.NoError(t, err)
+	service, err := chainlink.New(ctx, chainlink.WithExecution(execution))
+	require.NoError(t, err)
+	require.Equal(t, "chainlink", service.Name())
+}
+
+func TestPrice(t *testing.T) {
+	ctx := context.Background()
+	server := newExecutionServer(t)
+	defer server.Close()
+
+	execution, err := jsonrpc.New(ctx, jsonrpc.WithAddress(server.URL))
+	require.NoError(t, err)
+	var service price.Service
+	service, err = chainlink.New(ctx,
+		chainlink.WithExecution(execution),
+		chainlink.WithFeed("EUR", "0x0000000000000000000000000000000000000002"),
+		chainlink.WithFeed("xxx", "0x0000000000000000000000000000000000000001"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		currency  string
+		timestamp time.Time
+		price     string
+		err       string
+	}{
+		{
+			name:     "UnknownCurrency",
+			currency: "gbp",
+			err:      `no price feed for currency "gbp"`,
+		},
+		{
+			name:     "Current",
+			currency: "USD",
+			price:    "1100.12345678",
+		},
+		{
+			name:     "AdditionalFeed",
+			currency: "eur",
+			price:    "1100.12345678",
+		},
+		{
+			name:     "NegativePrice",
+			currency: "xxx",
+			err:      "feed returned invalid price",
+		},
+		{
+			name:      "Historical",
+			currency:  "usd",
+			timestamp: time.Unix(1000+12*37+5, 0),
+			price:     "1037.12345678",
+		},
+		{
+			name:      "HistoricalExact",
+			currency:  "usd",
+			timestamp: time.Unix(1000+12*38, 0),
+			price:     "1038.12345678",
+		},
+		{
+			name:      "HistoricalFirst",
+			currency:  "usd",
+			timestamp: time.Unix(1000, 0),
+			price:     "1000.12345678",
+		},
+		{
+			name:      "HistoricalFuture",
+			currency:  "usd",
+			timestamp: time.Unix(1000+12*200, 0),
+			price:     "1100.12345678",
+		},
+		{
+			name:      "HistoricalTooEarly",
+			currency:  "usd",
+			timestamp: time.Unix(999, 0),
+			err:       "no block at 1970-01-01T00:16:39Z",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := service.Price(ctx, test.currency, test.timestamp)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.price, res.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coingecko
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type parameters struct {
+	baseURL string
+	apiKey  string
+	timeout time.Duration
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(*parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithBaseURL sets the base URL of the Coingecko API.
+func WithBaseURL(baseURL string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.baseURL = baseURL
+	})
+}
+
+// WithAPIKey sets the key with which to access the Coingecko API.
+func WithAPIKey(apiKey string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.apiKey = apiKey
+	})
+}
+
+// WithTimeout sets the timeout for each request.
+func WithTimeout(timeout time.Duration) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.timeout = timeout
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		baseURL: "https://api.coingecko.com/api/v3",
+		timeout: 30 * time.Second,
+	}
+	for _, p := range params {
+		if params != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.baseURL == "" {
+		return nil, errors.New("no base URL specified")
+	}
+	if parameters.timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	return &parameters, nil
+}
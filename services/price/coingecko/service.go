@@ -0,0 +1,132 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Service is a price oracle that obtains prices from the Coingecko API.
+type Service struct {
+	baseURL string
+	apiKey  string
+	timeout time.Duration
+	client  *http.Client
+}
+
+type historyJSON struct {
+	MarketData *struct {
+		CurrentPrice map[string]json.Number `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// New creates a new Coingecko price oracle.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	return &Service{
+		baseURL: strings.TrimSuffix(parameters.baseURL, "/"),
+		apiKey:  parameters.apiKey,
+		timeout: parameters.timeout,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Name provides the name of the price oracle.
+func (*Service) Name() string {
+	return "coingecko"
+}
+
+// Price provides the price of a single Ether in the given fiat currency.
+// Historical prices are daily, so the price returned for a timestamp is that
+// at the start of its day (UTC).
+func (s *Service) Price(ctx context.Context, currency string, timestamp time.Time) (decimal.Decimal, error) {
+	currency = strings.ToLower(currency)
+
+	var price json.Number
+	if timestamp.IsZero() {
+		res := make(map[string]map[string]json.Number)
+		if err := s.get(ctx, fmt.Sprintf("/simple/price?ids=ethereum&vs_currencies=%s", url.QueryEscape(currency)), &res); err != nil {
+			return decimal.Zero, err
+		}
+		price = res["ethereum"][currency]
+	} else {
+		res := &historyJSON{}
+		if err := s.get(ctx, fmt.Sprintf("/coins/ethereum/history?date=%s&localization=false", timestamp.UTC().Format("02-01-2006")), res); err != nil {
+			return decimal.Zero, err
+		}
+		if res.MarketData != nil {
+			price = res.MarketData.CurrentPrice[currency]
+		}
+	}
+	if price == "" {
+		return decimal.Zero, fmt.Errorf("no price available for currency %q", currency)
+	}
+
+	res, err := decimal.NewFromString(price.String())
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "invalid price")
+	}
+
+	return res, nil
+}
+
+func (s *Service) get(ctx context.Context, path string, res interface{}) error {
+	opCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.apiKey != "" {
+		if strings.Contains(s.baseURL, "pro-api.coingecko.com") {
+			req.Header.Set("x-cg-pro-api-key", s.apiKey)
+		} else {
+			req.Header.Set("x-cg-demo-api-key", s.apiKey)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call price oracle")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("price oracle returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, res); err != nil {
+		return errors.Wrap(err, "failed to parse response")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coingecko_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/price"
+	"github.com/wealdtech/ethdo/services/price/coingecko"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := coingecko.New(ctx, coingecko.WithBaseURL(""))
+	require.EqualError(t, err, "problem with parameters: no base URL specified")
+
+	_, err = coingecko.New(ctx, coingecko.WithTimeout(0))
+	require.EqualError(t, err, "problem with parameters: no timeout specified")
+
+	service, err := coingecko.New(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "coingecko", service.Name())
+}
+
+func TestPrice(t *testing.T) {
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("x-cg-demo-api-key")
+		switch r.URL.Path {
+		case "/simple/price":
+			if r.URL.Query().Get("vs_currencies") == "xxx" {
+				_, _ = w.Write([]byte(`{"ethereum":{}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ethereum":{"usd":2345.67}}`))
+		case "/coins/ethereum/history":
+			if r.URL.Query().Get("date") != "14-11-2023" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"not found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"ethereum","market_data":{"current_price":{"eur":1834.0123,"usd":1987.65}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	var service price.Service
+	service, err := coingecko.New(ctx,
+		coingecko.WithBaseURL(server.URL+"/"),
+		coingecko.WithAPIKey("key"),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		currency  string
+		timestamp time.Time
+		price     string
+		err       string
+	}{
+		{
+			name:     "Current",
+			currency: "USD",
+			price:    "2345.67",
+		},
+		{
+			name:     "CurrentUnknownCurrency",
+			currency: "xxx",
+			err:      `no price available for currency "xxx"`,
+		},
+		{
+			name:      "Historical",
+			currency:  "eur",
+			timestamp: time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC),
+			price:     "1834.0123",
+		},
+		{
+			name:      "HistoricalUnknownCurrency",
+			currency:  "xxx",
+			timestamp: time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC),
+			err:       `no price available for currency "xxx"`,
+		},
+		{
+			name:      "HistoricalMissing",
+			currency:  "usd",
+			timestamp: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC),
+			err:       `price oracle returned status 404: {"error":"not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := service.Price(ctx, test.currency, test.timestamp)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.price, res.String())
+				require.Equal(t, "key", apiKey)
+			}
+		})
+	}
+}
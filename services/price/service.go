@@ -0,0 +1,33 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package price
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Service provides the price of Ether in fiat currencies.
+type Service interface {
+	// Name provides the name of the price oracle.
+	Name() string
+
+	// Price provides the price of a single Ether in the given fiat currency,
+	// for example "usd".
+	// If timestamp is zero the current price is returned, otherwise the price
+	// at the given time.
+	Price(ctx context.Context, currency string, timestamp time.Time) (decimal.Decimal, error)
+}
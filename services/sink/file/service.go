@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Service is a sink that appends records to a file, one per line.
+type Service struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// New creates a new file sink.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	file, err := os.OpenFile(parameters.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+
+	return &Service{
+		file: file,
+	}, nil
+}
+
+// Write writes a single record to the sink.
+func (s *Service) Write(_ context.Context, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := make([]byte, 0, len(record)+1)
+	data = append(data, record...)
+	data = append(data, '\n')
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write record")
+	}
+
+	return nil
+}
+
+// Close closes the sink.
+func (s *Service) Close(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
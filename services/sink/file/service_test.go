@@ -0,0 +1,50 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/sink"
+	"github.com/wealdtech/ethdo/services/sink/file"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+
+	_, err := file.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no path specified")
+
+	var service sink.Service
+	service, err = file.New(ctx, file.WithPath(path))
+	require.NoError(t, err)
+	require.NoError(t, service.Write(ctx, []byte(`{"a":1}`)))
+	require.NoError(t, service.Write(ctx, []byte(`{"b":2}`)))
+	require.NoError(t, service.Close(ctx))
+
+	// Further records are appended.
+	service, err = file.New(ctx, file.WithPath(path))
+	require.NoError(t, err)
+	require.NoError(t, service.Write(ctx, []byte(`{"c":3}`)))
+	require.NoError(t, service.Close(ctx))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "{\"a\":1}\n{\"b\":2}\n{\"c\":3}\n", string(data))
+}
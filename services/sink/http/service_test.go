@@ -0,0 +1,64 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/sink"
+	httpsink "github.com/wealdtech/ethdo/services/sink/http"
+)
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	received := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("rejected"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r.Header.Get("Content-Type")+" "+string(body))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	_, err := httpsink.New(ctx)
+	require.EqualError(t, err, "problem with parameters: no URL specified")
+
+	_, err = httpsink.New(ctx, httpsink.WithURL(server.URL), httpsink.WithTimeout(0))
+	require.EqualError(t, err, "problem with parameters: no timeout specified")
+
+	var service sink.Service
+	service, err = httpsink.New(ctx, httpsink.WithURL(server.URL+"/records"))
+	require.NoError(t, err)
+	require.NoError(t, service.Write(ctx, []byte(`{"a":1}`)))
+	require.NoError(t, service.Write(ctx, []byte(`{"b":2}`)))
+	require.NoError(t, service.Close(ctx))
+	require.Equal(t, []string{`application/json {"a":1}`, `application/json {"b":2}`}, received)
+
+	service, err = httpsink.New(ctx, httpsink.WithURL(server.URL+"/bad"))
+	require.NoError(t, err)
+	require.EqualError(t, service.Write(ctx, []byte(`{"a":1}`)), "endpoint returned status 400: rejected")
+}
@@ -0,0 +1,60 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// Service is a sink that produces each record to a Kafka topic.
+type Service struct {
+	writer *kafka.Writer
+}
+
+// New creates a new Kafka sink.
+func New(_ context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	return &Service{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(parameters.brokers...),
+			Topic:        parameters.topic,
+			Balancer:     &kafka.LeastBytes{},
+			WriteTimeout: parameters.timeout,
+			// Records are written as they arrive, so do not wait to fill a batch.
+			BatchTimeout: 10 * time.Millisecond,
+		},
+	}, nil
+}
+
+// Write writes a single record to the sink.
+func (s *Service) Write(ctx context.Context, record []byte) error {
+	if err := s.writer.WriteMessages(ctx, kafka.Message{Value: record}); err != nil {
+		return errors.Wrap(err, "failed to produce record")
+	}
+
+	return nil
+}
+
+// Close flushes any buffered records and closes the sink.
+func (s *Service) Close(_ context.Context) error {
+	return s.writer.Close()
+}
@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/sink/kafka"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params []kafka.Parameter
+		err    string
+	}{
+		{
+			name: "BrokersMissing",
+			params: []kafka.Parameter{
+				kafka.WithTopic("events"),
+			},
+			err: "problem with parameters: no brokers specified",
+		},
+		{
+			name: "TopicMissing",
+			params: []kafka.Parameter{
+				kafka.WithBrokers([]string{"localhost:9092"}),
+			},
+			err: "problem with parameters: no topic specified",
+		},
+		{
+			name: "TimeoutZero",
+			params: []kafka.Parameter{
+				kafka.WithBrokers([]string{"localhost:9092"}),
+				kafka.WithTopic("events"),
+				kafka.WithTimeout(0),
+			},
+			err: "problem with parameters: no timeout specified",
+		},
+		{
+			name: "Good",
+			params: []kafka.Parameter{
+				kafka.WithBrokers([]string{"localhost:9092"}),
+				kafka.WithTopic("events"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, err := kafka.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, service.Close(ctx))
+			}
+		})
+	}
+}
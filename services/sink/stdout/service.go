@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Service is a sink that prints records to standard output, one per line.
+type Service struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// New creates a new standard output sink.
+func New(_ context.Context) (*Service, error) {
+	return &Service{
+		out: os.Stdout,
+	}, nil
+}
+
+// Write writes a single record to the sink.
+func (s *Service) Write(_ context.Context, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := fmt.Fprintln(s.out, string(record)); err != nil {
+		return errors.Wrap(err, "failed to write record")
+	}
+
+	return nil
+}
+
+// Close closes the sink.
+func (*Service) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interchange
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// GenesisValidatorsRoot returns the genesis validators root of the interchange
+// data, or a zero root if it was not supplied.
+func (s *Service) GenesisValidatorsRoot() phase0.Root {
+	return s.genesisValidatorsRoot
+}
+
+// Pubkeys returns the public keys of the validators for which the service
+// holds histories, in order.
+func (s *Service) Pubkeys() []phase0.BLSPubKey {
+	pubkeys := make([]phase0.BLSPubKey, 0, len(s.histories))
+	for pubkey := range s.histories {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	sort.Slice(pubkeys, func(i int, j int) bool {
+		return bytes.Compare(pubkeys[i][:], pubkeys[j][:]) < 0
+	})
+
+	return pubkeys
+}
+
+// Export returns the EIP-3076 interchange data for the given validators, or
+// for all validators if none are supplied.
+func (s *Service) Export(pubkeys []phase0.BLSPubKey) ([]byte, error) {
+	if len(pubkeys) == 0 {
+		pubkeys = s.Pubkeys()
+	}
+
+	interchange := &interchangeJSON{
+		Metadata: &metadataJSON{
+			InterchangeFormatVersion: interchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", s.genesisValidatorsRoot),
+		},
+		Data: make([]*validatorDataJSON, 0, len(pubkeys)),
+	}
+	for _, pubkey := range pubkeys {
+		validatorHistory, exists := s.histories[pubkey]
+		if !exists {
+			return nil, fmt.Errorf("no slashing protection data for %#x", pubkey)
+		}
+		validatorHistory.dedupe()
+
+		validatorData := &validatorDataJSON{
+			Pubkey:             fmt.Sprintf("%#x", pubkey),
+			SignedBlocks:       make([]*signedBlockJSON, 0, len(validatorHistory.blocks)),
+			SignedAttestations: make([]*signedAttestationJSON, 0, len(validatorHistory.attestations)),
+		}
+		for _, block := range validatorHistory.blocks {
+			validatorData.SignedBlocks = append(validatorData.SignedBlocks, &signedBlockJSON{
+				Slot:        fmt.Sprintf("%d", block.slot),
+				SigningRoot: optionalRootString(block.signingRoot),
+			})
+		}
+		for _, attestation := range validatorHistory.attestations {
+			validatorData.SignedAttestations = append(validatorData.SignedAttestations, &signedAttestationJSON{
+				SourceEpoch: fmt.Sprintf("%d", attestation.sourceEpoch),
+				TargetEpoch: fmt.Sprintf("%d", attestation.targetEpoch),
+				SigningRoot: optionalRootString(attestation.signingRoot),
+			})
+		}
+		interchange.Data = append(interchange.Data, validatorData)
+	}
+
+	data, err := json.Marshal(interchange)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal interchange data")
+	}
+
+	return data, nil
+}
+
+func optionalRootString(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#x", *root)
+}
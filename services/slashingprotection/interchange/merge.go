@@ -0,0 +1,186 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interchange
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Conflict is a pair of signed messages in a validator's history that would
+// be slashable.
+type Conflict struct {
+	Pubkey phase0.BLSPubKey
+	Reason string
+}
+
+// Merge merges the histories of another service in to this service.  Entries
+// that are present in both are only held once.
+func (s *Service) Merge(other *Service) error {
+	if s.genesisValidatorsRoot != (phase0.Root{}) &&
+		other.genesisValidatorsRoot != (phase0.Root{}) &&
+		s.genesisValidatorsRoot != other.genesisValidatorsRoot {
+		return fmt.Errorf("cannot merge interchange data for genesis validators root %#x with that for %#x", other.genesisValidatorsRoot, s.genesisValidatorsRoot)
+	}
+	if s.genesisValidatorsRoot == (phase0.Root{}) {
+		s.genesisValidatorsRoot = other.genesisValidatorsRoot
+	}
+
+	for pubkey, otherHistory := range other.histories {
+		validatorHistory, exists := s.histories[pubkey]
+		if !exists {
+			validatorHistory = &history{}
+			s.histories[pubkey] = validatorHistory
+		}
+		validatorHistory.blocks = append(validatorHistory.blocks, otherHistory.blocks...)
+		validatorHistory.attestations = append(validatorHistory.attestations, otherHistory.attestations...)
+		validatorHistory.dedupe()
+	}
+
+	return nil
+}
+
+// Conflicts returns the slashable conflicts in the histories held by the
+// service, for example as a result of merging histories from validator
+// clients that have both signed for the same validator.
+func (s *Service) Conflicts() []*Conflict {
+	conflicts := make([]*Conflict, 0)
+	for _, pubkey := range s.Pubkeys() {
+		validatorHistory := s.histories[pubkey]
+		validatorHistory.dedupe()
+		for _, reason := range validatorHistory.conflicts() {
+			conflicts = append(conflicts, &Conflict{
+				Pubkey: pubkey,
+				Reason: reason,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// conflicts returns the reasons for any slashable conflicts in the history.
+// The history must be deduplicated.
+func (h *history) conflicts() []string {
+	reasons := make([]string, 0)
+
+	// Blocks are sorted by slot, so blocks for the same slot are adjacent.
+	for i := 1; i < len(h.blocks); i++ {
+		if h.blocks[i].slot == h.blocks[i-1].slot &&
+			h.blocks[i].signingRoot != nil && h.blocks[i-1].signingRoot != nil {
+			reasons = append(reasons, fmt.Sprintf("blocks with different signing roots signed for slot %d", h.blocks[i].slot))
+		}
+	}
+
+	// Attestations are sorted by target epoch, so attestations for the same
+	// target epoch are adjacent.
+	for i := 1; i < len(h.attestations); i++ {
+		prev := h.attestations[i-1]
+		cur := h.attestations[i]
+		if cur.targetEpoch != prev.targetEpoch {
+			continue
+		}
+		if cur.sourceEpoch != prev.sourceEpoch ||
+			(cur.signingRoot != nil && prev.signingRoot != nil) {
+			reasons = append(reasons, fmt.Sprintf("attestations with different data signed for target epoch %d", cur.targetEpoch))
+		}
+	}
+
+	// To find surround votes, walk the attestations in order of source epoch
+	// and keep track of the attestation with the highest target epoch from
+	// those with a lower source epoch.
+	bySource := make([]*signedAttestation, len(h.attestations))
+	copy(bySource, h.attestations)
+	sort.SliceStable(bySource, func(i int, j int) bool {
+		return bySource[i].sourceEpoch < bySource[j].sourceEpoch
+	})
+	var outer *signedAttestation
+	for i := 0; i < len(bySource); {
+		j := i
+		var groupOuter *signedAttestation
+		for ; j < len(bySource) && bySource[j].sourceEpoch == bySource[i].sourceEpoch; j++ {
+			if outer != nil && outer.targetEpoch > bySource[j].targetEpoch {
+				reasons = append(reasons, fmt.Sprintf("attestation with source epoch %d and target epoch %d surrounds attestation with source epoch %d and target epoch %d", outer.sourceEpoch, outer.targetEpoch, bySource[j].sourceEpoch, bySource[j].targetEpoch))
+			}
+			if groupOuter == nil || bySource[j].targetEpoch > groupOuter.targetEpoch {
+				groupOuter = bySource[j]
+			}
+		}
+		if outer == nil || groupOuter.targetEpoch > outer.targetEpoch {
+			outer = groupOuter
+		}
+		i = j
+	}
+
+	return reasons
+}
+
+// dedupe sorts the history and removes duplicate entries.
+func (h *history) dedupe() {
+	sort.Slice(h.blocks, func(i int, j int) bool {
+		if h.blocks[i].slot != h.blocks[j].slot {
+			return h.blocks[i].slot < h.blocks[j].slot
+		}
+		return compareRoots(h.blocks[i].signingRoot, h.blocks[j].signingRoot) < 0
+	})
+	blocks := make([]*signedBlock, 0, len(h.blocks))
+	for i, block := range h.blocks {
+		if i > 0 && block.slot == h.blocks[i-1].slot && compareRoots(block.signingRoot, h.blocks[i-1].signingRoot) == 0 {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	h.blocks = blocks
+
+	sort.Slice(h.attestations, func(i int, j int) bool {
+		if h.attestations[i].targetEpoch != h.attestations[j].targetEpoch {
+			return h.attestations[i].targetEpoch < h.attestations[j].targetEpoch
+		}
+		if h.attestations[i].sourceEpoch != h.attestations[j].sourceEpoch {
+			return h.attestations[i].sourceEpoch < h.attestations[j].sourceEpoch
+		}
+		return compareRoots(h.attestations[i].signingRoot, h.attestations[j].signingRoot) < 0
+	})
+	attestations := make([]*signedAttestation, 0, len(h.attestations))
+	for i, attestation := range h.attestations {
+		if i > 0 {
+			prev := h.attestations[i-1]
+			if attestation.targetEpoch == prev.targetEpoch &&
+				attestation.sourceEpoch == prev.sourceEpoch &&
+				compareRoots(attestation.signingRoot, prev.signingRoot) == 0 {
+				continue
+			}
+		}
+		attestations = append(attestations, attestation)
+	}
+	h.attestations = attestations
+}
+
+// compareRoots compares two optional signing roots, with a missing root
+// sorting before any present root.
+func compareRoots(a *phase0.Root, b *phase0.Root) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return bytes.Compare(a[:], b[:])
+	}
+}
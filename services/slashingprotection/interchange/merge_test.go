@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interchange_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+)
+
+const testGenesisValidatorsRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+
+func newTestService(t *testing.T, genesisValidatorsRoot string, blocks string, attestations string) *interchange.Service {
+	t.Helper()
+
+	data := fmt.Sprintf(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"%s"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[%s],"signed_attestations":[%s]}]}`, genesisValidatorsRoot, blocks, attestations)
+	s, err := interchange.New(context.Background(),
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, data)),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestMerge(t *testing.T) {
+	s := newTestService(t, testGenesisValidatorsRoot,
+		`{"slot":"1","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"}`,
+		`{"source_epoch":"1","target_epoch":"2"}`,
+	)
+	other := newTestService(t, testGenesisValidatorsRoot,
+		`{"slot":"1","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"slot":"3"}`,
+		`{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"1","target_epoch":"2"}`,
+	)
+	require.NoError(t, s.Merge(other))
+	require.Empty(t, s.Conflicts())
+
+	data, err := s.Export(nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed","signed_blocks":[{"slot":"1","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"slot":"3"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}]}`, string(data))
+}
+
+func TestMergeGenesisValidatorsRootMismatch(t *testing.T) {
+	s := newTestService(t, testGenesisValidatorsRoot, ``, ``)
+	other := newTestService(t, "0x0100000000000000000000000000000000000000000000000000000000000000", ``, ``)
+	require.EqualError(t, s.Merge(other), "cannot merge interchange data for genesis validators root 0x0100000000000000000000000000000000000000000000000000000000000000 with that for 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673")
+}
+
+func TestConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		blocks       string
+		attestations string
+		reasons      []string
+	}{
+		{
+			name:         "None",
+			blocks:       `{"slot":"1","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"slot":"1"}`,
+			attestations: `{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"1","target_epoch":"2","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"source_epoch":"2","target_epoch":"3"}`,
+		},
+		{
+			name:    "DoubleBlock",
+			blocks:  `{"slot":"1","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"slot":"1","signing_root":"0x0200000000000000000000000000000000000000000000000000000000000000"}`,
+			reasons: []string{"blocks with different signing roots signed for slot 1"},
+		},
+		{
+			name:         "DoubleVote",
+			attestations: `{"source_epoch":"1","target_epoch":"3"},{"source_epoch":"2","target_epoch":"3"}`,
+			reasons:      []string{"attestations with different data signed for target epoch 3"},
+		},
+		{
+			name:         "DoubleVoteSigningRoot",
+			attestations: `{"source_epoch":"1","target_epoch":"3","signing_root":"0x0100000000000000000000000000000000000000000000000000000000000000"},{"source_epoch":"1","target_epoch":"3","signing_root":"0x0200000000000000000000000000000000000000000000000000000000000000"}`,
+			reasons:      []string{"attestations with different data signed for target epoch 3"},
+		},
+		{
+			name:         "SurroundVote",
+			attestations: `{"source_epoch":"1","target_epoch":"5"},{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"3","target_epoch":"4"}`,
+			reasons: []string{
+				"attestation with source epoch 1 and target epoch 5 surrounds attestation with source epoch 2 and target epoch 3",
+				"attestation with source epoch 1 and target epoch 5 surrounds attestation with source epoch 3 and target epoch 4",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestService(t, testGenesisValidatorsRoot, test.blocks, test.attestations)
+			conflicts := s.Conflicts()
+			reasons := make([]string, 0, len(conflicts))
+			for _, conflict := range conflicts {
+				require.Equal(t, testPubkey, conflict.Pubkey)
+				reasons = append(reasons, conflict.Reason)
+			}
+			if len(test.reasons) == 0 {
+				require.Empty(t, reasons)
+			} else {
+				require.Equal(t, test.reasons, reasons)
+			}
+		})
+	}
+}
+
+func TestExportUnknownPubkey(t *testing.T) {
+	s := newTestService(t, testGenesisValidatorsRoot, ``, ``)
+	_, err := s.Export([]phase0.BLSPubKey{{0x01}})
+	require.EqualError(t, err, "no slashing protection data for 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
+}
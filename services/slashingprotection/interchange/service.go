@@ -0,0 +1,412 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interchange
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// interchangeFormatVersion is the version of the EIP-3076 interchange format supported.
+const interchangeFormatVersion = "5"
+
+// Service provides slashing protection checks from EIP-3076 interchange data.
+type Service struct {
+	genesisValidatorsRoot phase0.Root
+	histories             map[phase0.BLSPubKey]*history
+}
+
+type history struct {
+	blocks       []*signedBlock
+	attestations []*signedAttestation
+}
+
+type signedBlock struct {
+	slot        phase0.Slot
+	signingRoot *phase0.Root
+}
+
+type signedAttestation struct {
+	sourceEpoch phase0.Epoch
+	targetEpoch phase0.Epoch
+	signingRoot *phase0.Root
+}
+
+type interchangeJSON struct {
+	Metadata *metadataJSON        `json:"metadata"`
+	Data     []*validatorDataJSON `json:"data"`
+}
+
+type metadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type validatorDataJSON struct {
+	Pubkey             string                   `json:"pubkey"`
+	SignedBlocks       []*signedBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*signedAttestationJSON `json:"signed_attestations"`
+}
+
+type signedBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type signedAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new slashing protection service.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "slashingprotection").Str("impl", "interchange").Logger().Level(parameters.logLevel)
+
+	data, err := obtainData(ctx, parameters.source, parameters.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		histories: make(map[phase0.BLSPubKey]*history),
+	}
+	if err := s.load(data, parameters.genesisValidatorsRoot); err != nil {
+		return nil, err
+	}
+	log.Trace().Int("validators", len(s.histories)).Msg("Loaded interchange data")
+
+	return s, nil
+}
+
+// obtainData obtains interchange data from a file or URL.
+func obtainData(ctx context.Context, source string, timeout time.Duration) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read interchange file")
+		}
+
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request for interchange data")
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain interchange data")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to obtain interchange data: status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read interchange data")
+	}
+
+	return data, nil
+}
+
+// load loads interchange data in to the service.
+func (s *Service) load(data []byte, genesisValidatorsRoot *phase0.Root) error {
+	var interchange interchangeJSON
+	if err := json.Unmarshal(data, &interchange); err != nil {
+		return errors.Wrap(err, "failed to parse interchange data")
+	}
+	if interchange.Metadata == nil {
+		return errors.New("interchange metadata missing")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != interchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %s", interchange.Metadata.InterchangeFormatVersion)
+	}
+	if genesisValidatorsRoot != nil || interchange.Metadata.GenesisValidatorsRoot != "" {
+		root, err := parseRoot(interchange.Metadata.GenesisValidatorsRoot)
+		if err != nil {
+			return errors.Wrap(err, "invalid genesis validators root in interchange metadata")
+		}
+		if genesisValidatorsRoot != nil && *root != *genesisValidatorsRoot {
+			return fmt.Errorf("interchange data is for genesis validators root %#x, not %#x", *root, *genesisValidatorsRoot)
+		}
+		s.genesisValidatorsRoot = *root
+	}
+
+	for _, validatorData := range interchange.Data {
+		pubkeyBytes, err := hex.DecodeString(strings.TrimPrefix(validatorData.Pubkey, "0x"))
+		if err != nil {
+			return errors.Wrap(err, "invalid public key in interchange data")
+		}
+		if len(pubkeyBytes) != phase0.PublicKeyLength {
+			return fmt.Errorf("invalid length for public key %s in interchange data", validatorData.Pubkey)
+		}
+		pubkey := phase0.BLSPubKey{}
+		copy(pubkey[:], pubkeyBytes)
+
+		// A validator may appear more than once, so merge their histories.
+		validatorHistory, exists := s.histories[pubkey]
+		if !exists {
+			validatorHistory = &history{}
+			s.histories[pubkey] = validatorHistory
+		}
+
+		for _, block := range validatorData.SignedBlocks {
+			slot, err := strconv.ParseUint(block.Slot, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid slot for %s in interchange data", validatorData.Pubkey))
+			}
+			signingRoot, err := parseOptionalRoot(block.SigningRoot)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid block signing root for %s in interchange data", validatorData.Pubkey))
+			}
+			validatorHistory.blocks = append(validatorHistory.blocks, &signedBlock{
+				slot:        phase0.Slot(slot),
+				signingRoot: signingRoot,
+			})
+		}
+
+		for _, attestation := range validatorData.SignedAttestations {
+			sourceEpoch, err := strconv.ParseUint(attestation.SourceEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid source epoch for %s in interchange data", validatorData.Pubkey))
+			}
+			targetEpoch, err := strconv.ParseUint(attestation.TargetEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid target epoch for %s in interchange data", validatorData.Pubkey))
+			}
+			signingRoot, err := parseOptionalRoot(attestation.SigningRoot)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("invalid attestation signing root for %s in interchange data", validatorData.Pubkey))
+			}
+			validatorHistory.attestations = append(validatorHistory.attestations, &signedAttestation{
+				sourceEpoch: phase0.Epoch(sourceEpoch),
+				targetEpoch: phase0.Epoch(targetEpoch),
+				signingRoot: signingRoot,
+			})
+		}
+	}
+
+	return nil
+}
+
+// CheckAttestation checks if signing an attestation with the given source and target
+// epochs and signing root would be slashable for the given validator.
+func (s *Service) CheckAttestation(_ context.Context,
+	pubkey phase0.BLSPubKey,
+	sourceEpoch phase0.Epoch,
+	targetEpoch phase0.Epoch,
+	signingRoot *phase0.Root,
+) (
+	string,
+	error,
+) {
+	if sourceEpoch > targetEpoch {
+		return fmt.Sprintf("source epoch %d is after target epoch %d", sourceEpoch, targetEpoch), nil
+	}
+
+	validatorHistory, exists := s.histories[pubkey]
+	if !exists || len(validatorHistory.attestations) == 0 {
+		return "", nil
+	}
+
+	minSourceEpoch := validatorHistory.attestations[0].sourceEpoch
+	minTargetEpoch := validatorHistory.attestations[0].targetEpoch
+	for _, attestation := range validatorHistory.attestations {
+		if attestation.targetEpoch == targetEpoch {
+			if sameRoot(signingRoot, attestation.signingRoot) {
+				// This is the same attestation signed previously.
+				return "", nil
+			}
+			return fmt.Sprintf("an attestation has already been signed with target epoch %d", targetEpoch), nil
+		}
+		if attestation.sourceEpoch < sourceEpoch && targetEpoch < attestation.targetEpoch {
+			return fmt.Sprintf("attestation would be surrounded by the signed attestation with source epoch %d and target epoch %d", attestation.sourceEpoch, attestation.targetEpoch), nil
+		}
+		if sourceEpoch < attestation.sourceEpoch && attestation.targetEpoch < targetEpoch {
+			return fmt.Sprintf("attestation would surround the signed attestation with source epoch %d and target epoch %d", attestation.sourceEpoch, attestation.targetEpoch), nil
+		}
+		if attestation.sourceEpoch < minSourceEpoch {
+			minSourceEpoch = attestation.sourceEpoch
+		}
+		if attestation.targetEpoch < minTargetEpoch {
+			minTargetEpoch = attestation.targetEpoch
+		}
+	}
+
+	// Interchange data can be pruned, so anything older than the signed
+	// attestations could conflict with attestations that are no longer present.
+	if sourceEpoch < minSourceEpoch {
+		return fmt.Sprintf("source epoch %d is lower than the lowest signed source epoch %d", sourceEpoch, minSourceEpoch), nil
+	}
+	if targetEpoch <= minTargetEpoch {
+		return fmt.Sprintf("target epoch %d is not higher than the lowest signed target epoch %d", targetEpoch, minTargetEpoch), nil
+	}
+
+	return "", nil
+}
+
+// CheckBlock checks if signing a block at the given slot with the given signing root
+// would be slashable for the given validator.
+func (s *Service) CheckBlock(_ context.Context,
+	pubkey phase0.BLSPubKey,
+	slot phase0.Slot,
+	signingRoot *phase0.Root,
+) (
+	string,
+	error,
+) {
+	validatorHistory, exists := s.histories[pubkey]
+	if !exists || len(validatorHistory.blocks) == 0 {
+		return "", nil
+	}
+
+	minSlot := validatorHistory.blocks[0].slot
+	for _, block := range validatorHistory.blocks {
+		if block.slot == slot {
+			if sameRoot(signingRoot, block.signingRoot) {
+				// This is the same block signed previously.
+				return "", nil
+			}
+			return fmt.Sprintf("a block has already been signed for slot %d", slot), nil
+		}
+		if block.slot < minSlot {
+			minSlot = block.slot
+		}
+	}
+
+	// Interchange data can be pruned, so anything older than the signed
+	// blocks could conflict with blocks that are no longer present.
+	if slot <= minSlot {
+		return fmt.Sprintf("slot %d is not higher than the lowest signed slot %d", slot, minSlot), nil
+	}
+
+	return "", nil
+}
+
+// Digest returns a digest of the slashing protection history held for the given
+// validator, allowing histories from different sources to be compared.  Entries
+// are sorted and duplicates removed before hashing, so the digest does not depend
+// on the order in which they appear in the interchange data.
+// It returns false if there is no history for the validator.
+func (s *Service) Digest(pubkey phase0.BLSPubKey) (phase0.Root, bool) {
+	validatorHistory, exists := s.histories[pubkey]
+	if !exists {
+		return phase0.Root{}, false
+	}
+
+	blocks := make([][]byte, 0, len(validatorHistory.blocks))
+	for _, block := range validatorHistory.blocks {
+		entry := make([]byte, 8+1+phase0.RootLength)
+		binary.BigEndian.PutUint64(entry, uint64(block.slot))
+		if block.signingRoot != nil {
+			entry[8] = 1
+			copy(entry[9:], block.signingRoot[:])
+		}
+		blocks = append(blocks, entry)
+	}
+	attestations := make([][]byte, 0, len(validatorHistory.attestations))
+	for _, attestation := range validatorHistory.attestations {
+		entry := make([]byte, 8+8+1+phase0.RootLength)
+		binary.BigEndian.PutUint64(entry, uint64(attestation.sourceEpoch))
+		binary.BigEndian.PutUint64(entry[8:], uint64(attestation.targetEpoch))
+		if attestation.signingRoot != nil {
+			entry[16] = 1
+			copy(entry[17:], attestation.signingRoot[:])
+		}
+		attestations = append(attestations, entry)
+	}
+
+	hash := sha256.New()
+	hash.Write(pubkey[:])
+	for _, entries := range [][][]byte{blocks, attestations} {
+		sort.Slice(entries, func(i int, j int) bool {
+			return bytes.Compare(entries[i], entries[j]) < 0
+		})
+		count := make([]byte, 8)
+		unique := make([][]byte, 0, len(entries))
+		for i := range entries {
+			if i > 0 && bytes.Equal(entries[i], entries[i-1]) {
+				continue
+			}
+			unique = append(unique, entries[i])
+		}
+		binary.BigEndian.PutUint64(count, uint64(len(unique)))
+		hash.Write(count)
+		for _, entry := range unique {
+			hash.Write(entry)
+		}
+	}
+
+	res := phase0.Root{}
+	copy(res[:], hash.Sum(nil))
+
+	return res, true
+}
+
+// sameRoot returns true if both signing roots are known and equal.
+func sameRoot(a *phase0.Root, b *phase0.Root) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func parseRoot(input string) (*phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != phase0.RootLength {
+		return nil, errors.New("incorrect length")
+	}
+	root := phase0.Root{}
+	copy(root[:], data)
+
+	return &root, nil
+}
+
+func parseOptionalRoot(input string) (*phase0.Root, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	return parseRoot(input)
+}
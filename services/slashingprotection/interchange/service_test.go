@@ -0,0 +1,392 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interchange_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/slashingprotection"
+	"github.com/wealdtech/ethdo/services/slashingprotection/interchange"
+)
+
+const testInterchange = `{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+  },
+  "data": [
+    {
+      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+      "signed_blocks": [
+        {
+          "slot": "81952",
+          "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+        },
+        {
+          "slot": "81951"
+        }
+      ],
+      "signed_attestations": [
+        {
+          "source_epoch": "2290",
+          "target_epoch": "3007",
+          "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"
+        },
+        {
+          "source_epoch": "2290",
+          "target_epoch": "3008"
+        }
+      ]
+    }
+  ]
+}`
+
+var (
+	testPubkey = phase0.BLSPubKey{0xb8, 0x45, 0x08, 0x9a, 0x14, 0x57, 0xf8, 0x11, 0xbf, 0xc0, 0x00, 0x58, 0x8f, 0xbb, 0x4e, 0x71, 0x36, 0x69, 0xbe, 0x8c, 0xe0, 0x60, 0xea, 0x6b, 0xe3, 0xc6, 0xec, 0xe0, 0x9a, 0xfc, 0x37, 0x94, 0x10, 0x6c, 0x91, 0xca, 0x73, 0xac, 0xda, 0x5e, 0x54, 0x57, 0x12, 0x2d, 0x58, 0x72, 0x3b, 0xed}
+	testRoot   = phase0.Root{0x04, 0x70, 0x00, 0x07, 0xfa, 0xbc, 0x82, 0x82, 0x64, 0x4a, 0xed, 0x6d, 0x1c, 0x7c, 0x9e, 0x21, 0xd3, 0x8a, 0x03, 0xa0, 0xc4, 0xba, 0x19, 0x3f, 0x3a, 0xfe, 0x42, 0x88, 0x24, 0xb3, 0xa6, 0x73}
+)
+
+func writeInterchange(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "interchange.json")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	return path
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/interchange" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testInterchange))
+	}))
+	defer server.Close()
+
+	missingPath := filepath.Join(t.TempDir(), "missing.json")
+
+	tests := []struct {
+		name   string
+		params []interchange.Parameter
+		err    string
+	}{
+		{
+			name: "SourceMissing",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+			},
+			err: "problem with parameters: no source specified",
+		},
+		{
+			name: "FileMissing",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(missingPath),
+			},
+			err: "failed to read interchange file: open " + missingPath + ": no such file or directory",
+		},
+		{
+			name: "URLNotFound",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(server.URL + "/missing"),
+			},
+			err: "failed to obtain interchange data: status code 404",
+		},
+		{
+			name: "Invalid",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, `{`)),
+			},
+			err: "failed to parse interchange data: unexpected end of JSON input",
+		},
+		{
+			name: "MetadataMissing",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, `{"data":[]}`)),
+			},
+			err: "interchange metadata missing",
+		},
+		{
+			name: "VersionUnsupported",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, `{"metadata":{"interchange_format_version":"4"},"data":[]}`)),
+			},
+			err: "unsupported interchange format version 4",
+		},
+		{
+			name: "PubkeyInvalid",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, `{"metadata":{"interchange_format_version":"5"},"data":[{"pubkey":"0x01"}]}`)),
+			},
+			err: "invalid length for public key 0x01 in interchange data",
+		},
+		{
+			name: "GenesisValidatorsRootMismatch",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, testInterchange)),
+				interchange.WithGenesisValidatorsRoot(phase0.Root{}),
+			},
+			err: "interchange data is for genesis validators root 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673, not 0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "Good",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(writeInterchange(t, testInterchange)),
+				interchange.WithGenesisValidatorsRoot(testRoot),
+			},
+		},
+		{
+			name: "GoodURL",
+			params: []interchange.Parameter{
+				interchange.WithLogLevel(zerolog.Disabled),
+				interchange.WithSource(server.URL + "/interchange"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := interchange.New(ctx, test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckAttestation(t *testing.T) {
+	ctx := context.Background()
+
+	var s slashingprotection.Service
+	s, err := interchange.New(ctx,
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, testInterchange)),
+	)
+	require.NoError(t, err)
+
+	signedRoot := phase0.Root{0x58, 0x7d, 0x6a, 0x4f, 0x59, 0xa5, 0x8f, 0xe2, 0x4f, 0x40, 0x6e, 0x05, 0x02, 0x41, 0x3e, 0x77, 0xfe, 0x1b, 0xab, 0xdd, 0xee, 0x64, 0x1f, 0xda, 0x30, 0x03, 0x4e, 0xd3, 0x7e, 0xcc, 0x88, 0x4d}
+	otherRoot := phase0.Root{0x01}
+
+	tests := []struct {
+		name        string
+		pubkey      phase0.BLSPubKey
+		sourceEpoch phase0.Epoch
+		targetEpoch phase0.Epoch
+		signingRoot *phase0.Root
+		reason      string
+	}{
+		{
+			name:        "UnknownValidator",
+			pubkey:      phase0.BLSPubKey{0x01},
+			sourceEpoch: 1,
+			targetEpoch: 2,
+		},
+		{
+			name:        "SourceAfterTarget",
+			pubkey:      testPubkey,
+			sourceEpoch: 3010,
+			targetEpoch: 3009,
+			reason:      "source epoch 3010 is after target epoch 3009",
+		},
+		{
+			name:        "DoubleVote",
+			pubkey:      testPubkey,
+			sourceEpoch: 3006,
+			targetEpoch: 3007,
+			signingRoot: &otherRoot,
+			reason:      "an attestation has already been signed with target epoch 3007",
+		},
+		{
+			name:        "DoubleVoteUnknownRoot",
+			pubkey:      testPubkey,
+			sourceEpoch: 2290,
+			targetEpoch: 3008,
+			signingRoot: &signedRoot,
+			reason:      "an attestation has already been signed with target epoch 3008",
+		},
+		{
+			name:        "Repeat",
+			pubkey:      testPubkey,
+			sourceEpoch: 2290,
+			targetEpoch: 3007,
+			signingRoot: &signedRoot,
+		},
+		{
+			name:        "Surrounding",
+			pubkey:      testPubkey,
+			sourceEpoch: 2289,
+			targetEpoch: 3009,
+			reason:      "attestation would surround the signed attestation with source epoch 2290 and target epoch 3007",
+		},
+		{
+			name:        "Surrounded",
+			pubkey:      testPubkey,
+			sourceEpoch: 2291,
+			targetEpoch: 3005,
+			reason:      "attestation would be surrounded by the signed attestation with source epoch 2290 and target epoch 3007",
+		},
+		{
+			name:        "BelowMinimumTarget",
+			pubkey:      testPubkey,
+			sourceEpoch: 2290,
+			targetEpoch: 3006,
+			reason:      "target epoch 3006 is not higher than the lowest signed target epoch 3007",
+		},
+		{
+			name:        "Good",
+			pubkey:      testPubkey,
+			sourceEpoch: 3008,
+			targetEpoch: 3009,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, err := s.CheckAttestation(ctx, test.pubkey, test.sourceEpoch, test.targetEpoch, test.signingRoot)
+			require.NoError(t, err)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func TestCheckBlock(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := interchange.New(ctx,
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, testInterchange)),
+	)
+	require.NoError(t, err)
+
+	signedRoot := phase0.Root{0x4f, 0xf6, 0xf7, 0x43, 0xa4, 0x3f, 0x3b, 0x4f, 0x95, 0x35, 0x08, 0x31, 0xae, 0xaf, 0x0a, 0x12, 0x2a, 0x1a, 0x39, 0x29, 0x22, 0xc4, 0x5d, 0x80, 0x42, 0x80, 0x28, 0x4a, 0x69, 0xeb, 0x85, 0x0b}
+	otherRoot := phase0.Root{0x01}
+
+	tests := []struct {
+		name        string
+		pubkey      phase0.BLSPubKey
+		slot        phase0.Slot
+		signingRoot *phase0.Root
+		reason      string
+	}{
+		{
+			name:   "UnknownValidator",
+			pubkey: phase0.BLSPubKey{0x01},
+			slot:   1,
+		},
+		{
+			name:        "DoubleProposal",
+			pubkey:      testPubkey,
+			slot:        81952,
+			signingRoot: &otherRoot,
+			reason:      "a block has already been signed for slot 81952",
+		},
+		{
+			name:        "Repeat",
+			pubkey:      testPubkey,
+			slot:        81952,
+			signingRoot: &signedRoot,
+		},
+		{
+			name:   "BelowMinimum",
+			pubkey: testPubkey,
+			slot:   81950,
+			reason: "slot 81950 is not higher than the lowest signed slot 81951",
+		},
+		{
+			name:   "Good",
+			pubkey: testPubkey,
+			slot:   81953,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, err := s.CheckBlock(ctx, test.pubkey, test.slot, test.signingRoot)
+			require.NoError(t, err)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := interchange.New(ctx,
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, testInterchange)),
+	)
+	require.NoError(t, err)
+	digest, found := s.Digest(testPubkey)
+	require.True(t, found)
+
+	_, found = s.Digest(phase0.BLSPubKey{0x01})
+	require.False(t, found)
+
+	// Reordered and duplicated entries.
+	reordered := strings.NewReplacer(
+		`"slot": "81952",
+          "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"`, `"slot": "81951"`,
+		`"slot": "81951"
+        }`, `"slot": "81952",
+          "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+        },
+        {
+          "slot": "81951"
+        }`,
+	).Replace(testInterchange)
+	s, err = interchange.New(ctx,
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, reordered)),
+	)
+	require.NoError(t, err)
+	reorderedDigest, found := s.Digest(testPubkey)
+	require.True(t, found)
+	require.Equal(t, digest, reorderedDigest)
+
+	// Missing entry.
+	missing := strings.Replace(testInterchange, `,
+        {
+          "slot": "81951"
+        }`, "", 1)
+	s, err = interchange.New(ctx,
+		interchange.WithLogLevel(zerolog.Disabled),
+		interchange.WithSource(writeInterchange(t, missing)),
+	)
+	require.NoError(t, err)
+	missingDigest, found := s.Digest(testPubkey)
+	require.True(t, found)
+	require.NotEqual(t, digest, missingDigest)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Service provides slashing protection checks for signing operations.
+type Service interface {
+	// CheckAttestation checks if signing an attestation with the given source and target
+	// epochs and signing root would be slashable for the given validator.
+	// The signing root is optional.
+	// It returns a reason if the attestation should not be signed, otherwise an empty string.
+	CheckAttestation(ctx context.Context,
+		pubkey phase0.BLSPubKey,
+		sourceEpoch phase0.Epoch,
+		targetEpoch phase0.Epoch,
+		signingRoot *phase0.Root,
+	) (
+		string,
+		error,
+	)
+
+	// CheckBlock checks if signing a block at the given slot with the given signing root
+	// would be slashable for the given validator.
+	// The signing root is optional.
+	// It returns a reason if the block should not be signed, otherwise an empty string.
+	CheckBlock(ctx context.Context,
+		pubkey phase0.BLSPubKey,
+		slot phase0.Slot,
+		signingRoot *phase0.Root,
+	) (
+		string,
+		error,
+	)
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -28,6 +29,9 @@ func Unlock(ctx context.Context, account e2wtypes.Account, passphrases []string)
 		// This account doesn't support unlocking; return okay.
 		return true, nil
 	}
+	if err := util.CheckNotWatchOnly(account); err != nil {
+		return false, err
+	}
 
 	alreadyUnlocked, err := locker.IsUnlocked(ctx)
 	if err != nil {
@@ -18,7 +18,8 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
@@ -60,8 +61,16 @@ func NewSpecProvider(slotDuration time.Duration,
 }
 
 // Spec is a mock.
-func (m *SpecProvider) Spec(_ context.Context) (map[string]interface{}, error) {
-	return m.spec, nil
+func (m *SpecProvider) Spec(_ context.Context,
+	_ *api.SpecOpts,
+) (
+	*api.Response[map[string]any],
+	error,
+) {
+	return &api.Response[map[string]any]{
+		Data:     m.spec,
+		Metadata: make(map[string]any),
+	}, nil
 }
 
 // ForkScheduleProvider is a mock for eth2client.ForkScheduleProvider.
@@ -77,8 +86,16 @@ func NewForkScheduleProvider(schedule []*phase0.Fork) eth2client.ForkSchedulePro
 }
 
 // ForkSchedule is a mock.
-func (m *ForkScheduleProvider) ForkSchedule(_ context.Context) ([]*phase0.Fork, error) {
-	return m.schedule, nil
+func (m *ForkScheduleProvider) ForkSchedule(_ context.Context,
+	_ *api.ForkScheduleOpts,
+) (
+	*api.Response[[]*phase0.Fork],
+	error,
+) {
+	return &api.Response[[]*phase0.Fork]{
+		Data:     m.schedule,
+		Metadata: make(map[string]any),
+	}, nil
 }
 
 // SlotsPerEpochProvider is a mock for eth2client.SlotsPerEpochProvider.
@@ -107,7 +124,7 @@ func NewAttestationSubmitter() eth2client.AttestationsSubmitter {
 }
 
 // SubmitAttestations is a mock.
-func (m *AttestationsSubmitter) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+func (m *AttestationsSubmitter) SubmitAttestations(_ context.Context, _ *api.SubmitAttestationsOpts) error {
 	return nil
 }
 
@@ -133,7 +150,7 @@ func NewAggregateAttestationsSubmitter() eth2client.AggregateAttestationsSubmitt
 }
 
 // SubmitAggregateAttestations is a mock.
-func (m *AggregateAttestationsSubmitter) SubmitAggregateAttestations(_ context.Context, _ []*phase0.SignedAggregateAndProof) error {
+func (m *AggregateAttestationsSubmitter) SubmitAggregateAttestations(_ context.Context, _ *api.SubmitAggregateAttestationsOpts) error {
 	return nil
 }
 
@@ -146,6 +163,6 @@ func NewBeaconCommitteeSubscriptionsSubmitter() eth2client.BeaconCommitteeSubscr
 }
 
 // SubmitBeaconCommitteeSubscriptions is a mock.
-func (m *BeaconCommitteeSubscriptionsSubmitter) SubmitBeaconCommitteeSubscriptions(_ context.Context, _ []*api.BeaconCommitteeSubscription) error {
+func (m *BeaconCommitteeSubscriptionsSubmitter) SubmitBeaconCommitteeSubscriptions(_ context.Context, _ []*apiv1.BeaconCommitteeSubscription) error {
 	return nil
 }
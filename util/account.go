@@ -139,6 +139,10 @@ func parseAccountFromSpecifier(ctx context.Context,
 		// Supplementary will be the unlock passphrase(s).
 		_, err = UnlockAccount(ctx, account, supplementary)
 		if err != nil {
+			// The key may be held by the agent.
+			if agentAccount, held := agentAccount(ctx, account); held {
+				return agentAccount, nil
+			}
 			return nil, err
 		}
 	}
@@ -279,6 +283,9 @@ func UnlockAccount(ctx context.Context, account e2wtypes.Account, passphrases []
 		// This account doesn't support unlocking; return okay.
 		return true, nil
 	}
+	if err := CheckNotWatchOnly(account); err != nil {
+		return false, err
+	}
 
 	alreadyUnlocked, err := locker.IsUnlocked(ctx)
 	if err != nil {
@@ -0,0 +1,144 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/socket"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// AgentSocket returns the path of the socket of the key agent.
+func AgentSocket() (string, error) {
+	if path := viper.GetString("agent-socket"); path != "" {
+		return path, nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain home directory")
+	}
+
+	return filepath.Join(home, ".ethdo-agent.sock"), nil
+}
+
+// Agent returns a client for the key agent.  It returns nil if the agent is
+// not running.
+func Agent(ctx context.Context) (agent.Service, error) {
+	path, err := AgentSocket()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to access agent socket")
+	}
+
+	return socket.New(ctx,
+		socket.WithPath(path),
+		socket.WithTimeout(viper.GetDuration("timeout")),
+	)
+}
+
+// AgentAccount is an account whose key is held by the key agent.
+type AgentAccount struct {
+	id     uuid.UUID
+	name   string
+	pubKey e2types.PublicKey
+	agent  agent.Service
+}
+
+// agentAccount returns an account backed by the key agent for the given
+// account, if the agent holds its key.
+func agentAccount(ctx context.Context, account e2wtypes.Account) (*AgentAccount, bool) {
+	pubKeyProvider, isPubKeyProvider := account.(e2wtypes.AccountPublicKeyProvider)
+	if !isPubKeyProvider {
+		return nil, false
+	}
+	keyAgent, err := Agent(ctx)
+	if err != nil || keyAgent == nil {
+		return nil, false
+	}
+
+	status, err := keyAgent.Status(ctx)
+	if err != nil {
+		return nil, false
+	}
+	pubKey := pubKeyProvider.PublicKey().Marshal()
+	for _, key := range status.Keys {
+		if bytes.Equal(key.PublicKey, pubKey) {
+			return &AgentAccount{
+				id:     account.ID(),
+				name:   account.Name(),
+				pubKey: pubKeyProvider.PublicKey(),
+				agent:  keyAgent,
+			}, true
+		}
+	}
+
+	return nil, false
+}
+
+// ID returns the account ID.
+func (a *AgentAccount) ID() uuid.UUID {
+	return a.id
+}
+
+// Name returns the account name.
+func (a *AgentAccount) Name() string {
+	return a.name
+}
+
+// PublicKey returns the account public key.
+func (a *AgentAccount) PublicKey() e2types.PublicKey {
+	return a.pubKey
+}
+
+// Sign signs data with the key held by the agent.
+func (a *AgentAccount) Sign(ctx context.Context, data []byte) (e2types.Signature, error) {
+	sig, err := a.agent.Sign(ctx, a.pubKey.Marshal(), data)
+	if err != nil {
+		return nil, err
+	}
+
+	return e2types.BLSSignatureFromBytes(sig)
+}
+
+// IsUnlocked returns true, as the key is unlocked for as long as the agent
+// holds it.
+func (*AgentAccount) IsUnlocked(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+// Unlock is a no-op, as the key is held unlocked by the agent.
+func (*AgentAccount) Unlock(_ context.Context, _ []byte) error {
+	return nil
+}
+
+// Lock is a no-op; keys are removed from the agent with "wallet lock".
+func (*AgentAccount) Lock(_ context.Context) error {
+	return nil
+}
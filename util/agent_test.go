@@ -0,0 +1,95 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/agent"
+	"github.com/wealdtech/ethdo/services/agent/memory"
+	"github.com/wealdtech/ethdo/services/agent/socket"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestAgentSignRoot(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	viper.Reset()
+	viper.Set("timeout", "5s")
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	viper.Set("agent-socket", path)
+
+	// No agent running.
+	keyAgent, err := util.Agent(ctx)
+	require.NoError(t, err)
+	require.Nil(t, keyAgent)
+
+	wallet, err := nd.CreateWallet(ctx, "Test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	secretKey := testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	account, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", secretKey, []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Lock(ctx))
+
+	root := phase0.Root{0x01}
+	domain := phase0.Domain{0x02}
+
+	// Locked account without the agent.
+	_, err = util.SignRoot(account, root, domain)
+	require.EqualError(t, err, "failed to unlock account")
+
+	store, err := memory.New(ctx)
+	require.NoError(t, err)
+	go func() {
+		_ = socket.Serve(ctx, path, store)
+	}()
+	require.Eventually(t, func() bool {
+		keyAgent, err := util.Agent(ctx)
+		if err != nil || keyAgent == nil {
+			return false
+		}
+		_, err = keyAgent.Status(ctx)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, store.AddKeys(ctx, []*agent.Key{
+		{
+			Wallet:    "Test",
+			Account:   "Interop 0",
+			PublicKey: account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal(),
+			SecretKey: secretKey,
+		},
+	}, time.Minute))
+
+	// Locked account with the agent holding its key.
+	signature, err := util.SignRoot(account, root, domain)
+	require.NoError(t, err)
+	verified, err := util.VerifyRoot(account, root, domain, signature)
+	require.NoError(t, err)
+	require.True(t, verified)
+}
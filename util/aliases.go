@@ -0,0 +1,166 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// AliasesKey is the configuration key under which aliases are stored.
+const AliasesKey = "aliases"
+
+var (
+	// aliasNameRegexp matches a valid alias name.  Names must start with a
+	// letter so that they cannot be confused with validator indices, and are
+	// lower-case as configuration keys are case-insensitive.
+	aliasNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	// aliasValueRegexp matches a valid alias value: a validator index, a range
+	// of validator indices, a validator public key or an execution address.
+	aliasValueRegexp = regexp.MustCompile(`^([0-9]+|[0-9]+-[0-9]+|0x[0-9a-fA-F]{96}|0x[0-9a-fA-F]{40})$`)
+)
+
+// ValidateAliasName checks that the name is suitable for an alias.
+func ValidateAliasName(name string) error {
+	if name == "" {
+		return errors.New("alias name is required")
+	}
+	if !aliasNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q; names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'", name)
+	}
+
+	return nil
+}
+
+// ValidateAliasValue checks that the value is suitable for an alias.
+func ValidateAliasValue(value string) error {
+	if value == "" {
+		return errors.New("alias value is required")
+	}
+	if !aliasValueRegexp.MatchString(value) {
+		return fmt.Errorf("invalid alias value %q; values must be a validator index, range of indices, public key or address", value)
+	}
+
+	return nil
+}
+
+// Aliases returns the aliases in the configuration.
+func Aliases() map[string]string {
+	return viper.GetStringMapString(AliasesKey)
+}
+
+// ResolveAlias returns the value of the alias with the given name, or the
+// name itself if it is not an alias.
+func ResolveAlias(name string) string {
+	if !aliasNameRegexp.MatchString(name) {
+		return name
+	}
+	if value, exists := Aliases()[name]; exists {
+		return value
+	}
+
+	return name
+}
+
+// ResolveAliases resolves any aliases in the list of names.
+func ResolveAliases(names []string) []string {
+	res := make([]string, len(names))
+	for i := range names {
+		res[i] = ResolveAlias(names[i])
+	}
+
+	return res
+}
+
+// ParseAliasesCSV parses aliases from CSV data with one "name,value" pair per
+// line.  Empty lines, lines starting with '#' and a "name,value" header line
+// are ignored.
+func ParseAliasesCSV(data []byte) (map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	aliases := make(map[string]string)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse aliases")
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) != 2 {
+			return nil, fmt.Errorf("line %d: expected name and value, found %d fields", line, len(record))
+		}
+		name := strings.TrimSpace(record[0])
+		value := strings.TrimSpace(record[1])
+		if line == 1 && strings.EqualFold(name, "name") && strings.EqualFold(value, "value") {
+			// Header.
+			continue
+		}
+		if err := ValidateAliasName(name); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("line %d", line))
+		}
+		if err := ValidateAliasValue(value); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("line %d", line))
+		}
+		if _, exists := aliases[name]; exists {
+			return nil, fmt.Errorf("line %d: duplicate alias %s", line, name)
+		}
+		aliases[name] = value
+	}
+
+	return aliases, nil
+}
+
+// ReadAliases reads the aliases from the configuration file at the given path.
+func ReadAliases(path string) (map[string]string, error) {
+	config, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.GetStringMapString(AliasesKey), nil
+}
+
+// WriteAliases writes the aliases to the configuration file at the given
+// path, replacing any existing aliases and retaining all other settings.
+func WriteAliases(path string, aliases map[string]string) error {
+	current, err := ReadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	config := current.AllSettings()
+	if len(aliases) == 0 {
+		delete(config, AliasesKey)
+	} else {
+		values := make(map[string]any, len(aliases))
+		for name, value := range aliases {
+			values[name] = value
+		}
+		config[AliasesKey] = values
+	}
+
+	return WriteConfigFile(path, config)
+}
@@ -0,0 +1,209 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestValidateAliasName(t *testing.T) {
+	tests := []struct {
+		name  string
+		alias string
+		err   string
+	}{
+		{
+			name: "Missing",
+			err:  "alias name is required",
+		},
+		{
+			name:  "Numeric",
+			alias: "123",
+			err:   `invalid alias name "123"; names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'`,
+		},
+		{
+			name:  "UpperCase",
+			alias: "Node",
+			err:   `invalid alias name "Node"; names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'`,
+		},
+		{
+			name:  "Dot",
+			alias: "node.1",
+			err:   `invalid alias name "node.1"; names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'`,
+		},
+		{
+			name:  "Good",
+			alias: "node-1_a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.ValidateAliasName(test.alias)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAliasValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{
+			name: "Missing",
+			err:  "alias value is required",
+		},
+		{
+			name:  "Account",
+			value: "Wallet/Account",
+			err:   `invalid alias value "Wallet/Account"; values must be a validator index, range of indices, public key or address`,
+		},
+		{
+			name:  "ShortHex",
+			value: "0x1234",
+			err:   `invalid alias value "0x1234"; values must be a validator index, range of indices, public key or address`,
+		},
+		{
+			name:  "Index",
+			value: "12345",
+		},
+		{
+			name:  "Range",
+			value: "1000-1099",
+		},
+		{
+			name:  "PublicKey",
+			value: "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87",
+		},
+		{
+			name:  "Address",
+			value: "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.ValidateAliasValue(test.value)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolveAliases(t *testing.T) {
+	viper.Reset()
+	viper.Set("aliases", map[string]any{
+		"node-1":   "12345",
+		"treasury": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+	})
+
+	require.Equal(t, "12345", util.ResolveAlias("node-1"))
+	require.Equal(t, "0x30C99930617B7b793beaB603ecEB08691005f2E5", util.ResolveAlias("treasury"))
+	require.Equal(t, "node-2", util.ResolveAlias("node-2"))
+	require.Equal(t, "1", util.ResolveAlias("1"))
+	require.Equal(t, []string{"12345", "1", "Wallet/Account"}, util.ResolveAliases([]string{"node-1", "1", "Wallet/Account"}))
+}
+
+func TestWriteAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ethdo.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("timeout: 10s\n"), 0o600))
+
+	require.NoError(t, util.WriteAliases(path, map[string]string{"node-1": "12345"}))
+	aliases, err := util.ReadAliases(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"node-1": "12345"}, aliases)
+
+	// Removing all aliases retains other settings.
+	require.NoError(t, util.WriteAliases(path, map[string]string{}))
+	config, err := util.ReadConfigFile(path)
+	require.NoError(t, err)
+	require.False(t, config.IsSet(util.AliasesKey))
+	require.Equal(t, "10s", config.GetString("timeout"))
+}
+
+func TestParseAliasesCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		aliases map[string]string
+		err     string
+	}{
+		{
+			name:    "Empty",
+			aliases: map[string]string{},
+		},
+		{
+			name: "Good",
+			data: `name,value
+# Validators
+node-1,12345
+node-2, 0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87
+
+treasury,0x30C99930617B7b793beaB603ecEB08691005f2E5
+`,
+			aliases: map[string]string{
+				"node-1":   "12345",
+				"node-2":   "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87",
+				"treasury": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+			},
+		},
+		{
+			name: "FieldsMissing",
+			data: "node-1,12345\nnode-2\n",
+			err:  "line 2: expected name and value, found 1 fields",
+		},
+		{
+			name: "NameInvalid",
+			data: "Node-1,12345\n",
+			err:  `line 1: invalid alias name "Node-1"; names must start with a lower-case letter and contain only lower-case letters, digits, '-' and '_'`,
+		},
+		{
+			name: "ValueInvalid",
+			data: "node-1,Wallet/Account\n",
+			err:  `line 1: invalid alias value "Wallet/Account"; values must be a validator index, range of indices, public key or address`,
+		},
+		{
+			name: "Duplicate",
+			data: "node-1,1\nnode-1,2\n",
+			err:  "line 2: duplicate alias node-1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aliases, err := util.ParseAliasesCSV([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.aliases, aliases)
+			}
+		})
+	}
+}
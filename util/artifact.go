@@ -0,0 +1,174 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ipfsScheme is the URL scheme used to refer to artifacts published to IPFS.
+const ipfsScheme = "ipfs://"
+
+// PublishArtifactHTTP publishes an artifact to an HTTP endpoint with a PUT request.
+func PublishArtifactHTTP(ctx context.Context,
+	url string,
+	timeout time.Duration,
+	data []byte,
+) error {
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call endpoint")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// PublishArtifactIPFS adds an artifact to IPFS using the HTTP API of an IPFS
+// node, pinning it on the node.  It returns the content identifier (CID) of
+// the artifact.
+func PublishArtifactIPFS(ctx context.Context,
+	api string,
+	timeout time.Duration,
+	name string,
+	data []byte,
+) (
+	string,
+	error,
+) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request body")
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", errors.Wrap(err, "failed to create request body")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to create request body")
+	}
+
+	url := fmt.Sprintf("%s/api/v0/add?pin=true&cid-version=1", strings.TrimSuffix(api, "/"))
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodPost, url, body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to call IPFS node")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("IPFS node returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	res := struct {
+		Hash string `json:"Hash"`
+	}{}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return "", errors.Wrap(err, "failed to parse response")
+	}
+	if res.Hash == "" {
+		return "", errors.New("IPFS node did not return a CID")
+	}
+
+	return res.Hash, nil
+}
+
+// IPFSLocation returns the location of an artifact published to IPFS.
+func IPFSLocation(cid string) string {
+	return fmt.Sprintf("%s%s", ipfsScheme, cid)
+}
+
+// FetchArtifact fetches an artifact from a location, which can be an HTTP(S)
+// URL, an IPFS location as returned by IPFSLocation, or a local file.  IPFS
+// artifacts are fetched through the supplied gateway.
+func FetchArtifact(ctx context.Context,
+	location string,
+	ipfsGateway string,
+	timeout time.Duration,
+) (
+	[]byte,
+	error,
+) {
+	url := location
+	switch {
+	case strings.HasPrefix(location, ipfsScheme):
+		url = fmt.Sprintf("%s/ipfs/%s", strings.TrimSuffix(ipfsGateway, "/"), strings.TrimPrefix(location, ipfsScheme))
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+	default:
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read artifact file")
+		}
+		return data, nil
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch artifact")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read artifact")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("artifact source returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
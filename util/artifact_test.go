@@ -0,0 +1,138 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestPublishArtifactHTTP(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("not allowed"))
+			return
+		}
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	require.NoError(t, util.PublishArtifactHTTP(context.Background(), server.URL+"/artifact", time.Second, []byte("data")))
+	require.Equal(t, []byte("data"), received)
+
+	err := util.PublishArtifactHTTP(context.Background(), server.URL+"/forbidden", time.Second, []byte("data"))
+	require.EqualError(t, err, "endpoint returned status 403: not allowed")
+}
+
+func TestPublishArtifactIPFS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/add" || r.URL.Query().Get("pin") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		switch string(data) {
+		case "data":
+			_, _ = w.Write([]byte(`{"Name":"` + header.Filename + `","Hash":"bafkreitest","Size":"4"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	cid, err := util.PublishArtifactIPFS(context.Background(), server.URL, time.Second, "exits.json", []byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, "bafkreitest", cid)
+	require.Equal(t, "ipfs://bafkreitest", util.IPFSLocation(cid))
+
+	_, err = util.PublishArtifactIPFS(context.Background(), server.URL, time.Second, "exits.json", []byte("other"))
+	require.EqualError(t, err, "IPFS node did not return a CID")
+}
+
+func TestFetchArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifact", "/ipfs/bafkreitest":
+			_, _ = w.Write([]byte("data"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "artifact")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	tests := []struct {
+		name     string
+		location string
+		err      string
+	}{
+		{
+			name:     "HTTP",
+			location: server.URL + "/artifact",
+		},
+		{
+			name:     "IPFS",
+			location: "ipfs://bafkreitest",
+		},
+		{
+			name:     "File",
+			location: path,
+		},
+		{
+			name:     "HTTPMissing",
+			location: server.URL + "/missing",
+			err:      "artifact source returned status 404: not found",
+		},
+		{
+			name:     "FileMissing",
+			location: filepath.Join(t.TempDir(), "missing"),
+			err:      "failed to read artifact file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := util.FetchArtifact(context.Background(), test.location, server.URL, time.Second)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []byte("data"), data)
+		})
+	}
+}
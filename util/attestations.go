@@ -16,20 +16,26 @@ package util
 import (
 	"bytes"
 	"context"
+	"fmt"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/wealdtech/ethdo/services/chaintime"
 )
 
-// AttestationHeadCorrect returns true if the given attestation had the correct head.
+// AttestationHeadCorrect returns true if the given attestation data had the correct head.
 func AttestationHeadCorrect(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
-	attestation *phase0.Attestation,
+	attestationData *phase0.AttestationData,
 ) (
 	bool,
 	error,
 ) {
-	slot := attestation.Data.Slot
+	slot := attestationData.Slot
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -45,21 +51,21 @@ func AttestationHeadCorrect(ctx context.Context,
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.BeaconBlockRoot[:]), nil
+		return bytes.Equal(header.Root[:], attestationData.BeaconBlockRoot[:]), nil
 	}
 }
 
-// AttestationTargetCorrect returns true if the given attestation had the correct target.
+// AttestationTargetCorrect returns true if the given attestation data had the correct target.
 func AttestationTargetCorrect(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
 	chainTime chaintime.Service,
-	attestation *phase0.Attestation,
+	attestationData *phase0.AttestationData,
 ) (
 	bool,
 	error,
 ) {
 	// Start with first slot of the target epoch.
-	slot := chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+	slot := chainTime.FirstSlotOfEpoch(attestationData.Target.Epoch)
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -75,6 +81,238 @@ func AttestationTargetCorrect(ctx context.Context,
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.Target.Root[:]), nil
+		return bytes.Equal(header.Root[:], attestationData.Target.Root[:]), nil
 	}
 }
+
+// AttestationCommitteeIndices returns the indices of the committees covered by
+// the given attestation, in the order that their members appear in its
+// aggregation bits.  Prior to Electra this is the single committee in its data;
+// from Electra it is the committees in its committee bits.
+func AttestationCommitteeIndices(attestation *spec.VersionedAttestation) ([]phase0.CommitteeIndex, error) {
+	switch attestation.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella, spec.DataVersionDeneb:
+		data, err := attestation.Data()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain attestation data")
+		}
+
+		return []phase0.CommitteeIndex{data.Index}, nil
+	default:
+		committeeBits, err := attestation.CommitteeBits()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain committee bits")
+		}
+		committeeIndices := make([]phase0.CommitteeIndex, 0, committeeBits.Count())
+		for _, index := range committeeBits.BitIndices() {
+			committeeIndices = append(committeeIndices, phase0.CommitteeIndex(index))
+		}
+
+		return committeeIndices, nil
+	}
+}
+
+// AttestationCommitteeVotes splits the aggregation bits of the given attestation
+// into the votes of each committee that it covers.  committeeSize provides the
+// size of a committee at the slot of the attestation; it is only required for
+// attestations that cover more than one committee.
+func AttestationCommitteeVotes(attestation *spec.VersionedAttestation,
+	committeeSize func(phase0.CommitteeIndex) (uint64, error),
+) (
+	map[phase0.CommitteeIndex]bitfield.Bitlist,
+	error,
+) {
+	aggregationBits, err := attestation.AggregationBits()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain aggregation bits")
+	}
+	committeeIndices, err := AttestationCommitteeIndices(attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[phase0.CommitteeIndex]bitfield.Bitlist, len(committeeIndices))
+	offset := uint64(0)
+	for i, committeeIndex := range committeeIndices {
+		// The final committee covers the remaining bits.
+		size := aggregationBits.Len() - offset
+		if i < len(committeeIndices)-1 {
+			size, err = committeeSize(committeeIndex)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain size of committee %d", committeeIndex))
+			}
+		}
+		if offset+size > aggregationBits.Len() {
+			return nil, fmt.Errorf("aggregation bits do not cover committee %d", committeeIndex)
+		}
+		votes := bitfield.NewBitlist(size)
+		for j := uint64(0); j < size; j++ {
+			if aggregationBits.BitAt(offset + j) {
+				votes.SetBitAt(j, true)
+			}
+		}
+		res[committeeIndex] = votes
+		offset += size
+	}
+
+	return res, nil
+}
+
+// AttestationValidators resolves the aggregation bits of an attestation to the
+// indices of the validators that attested.  The committees are the members of
+// each committee covered by the attestation, in order; prior to Electra this is
+// a single committee, and from Electra it is the committees in the committee bits.
+func AttestationValidators(aggregationBits bitfield.Bitlist,
+	committees ...[]phase0.ValidatorIndex,
+) (
+	[]phase0.ValidatorIndex,
+	error,
+) {
+	members := make([]phase0.ValidatorIndex, 0)
+	for _, committee := range committees {
+		members = append(members, committee...)
+	}
+	if uint64(len(members)) != aggregationBits.Len() {
+		return nil, fmt.Errorf("aggregation bits cover %d validators but committees contain %d", aggregationBits.Len(), len(members))
+	}
+
+	res := make([]phase0.ValidatorIndex, 0, aggregationBits.Count())
+	for i, member := range members {
+		if aggregationBits.BitAt(uint64(i)) {
+			res = append(res, member)
+		}
+	}
+
+	return res, nil
+}
+
+// BlockAttestation is an attestation included in a block, in a form that is
+// common to all forks.
+type BlockAttestation struct {
+	AggregationBits bitfield.Bitlist
+	Data            *phase0.AttestationData
+	// CommitteeIndices are the indices of the committees covered by the
+	// attestation, in the order that their members appear in the aggregation
+	// bits.
+	CommitteeIndices []phase0.CommitteeIndex
+}
+
+// NewBlockAttestation creates a block attestation from an attestation prior to
+// Electra, which covers the single committee in its data.
+func NewBlockAttestation(attestation *phase0.Attestation) *BlockAttestation {
+	return &BlockAttestation{
+		AggregationBits:  attestation.AggregationBits,
+		Data:             attestation.Data,
+		CommitteeIndices: []phase0.CommitteeIndex{attestation.Data.Index},
+	}
+}
+
+// NewVersionedBlockAttestation creates a block attestation from a versioned
+// attestation.
+func NewVersionedBlockAttestation(attestation *spec.VersionedAttestation) (*BlockAttestation, error) {
+	aggregationBits, err := attestation.AggregationBits()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain aggregation bits")
+	}
+	data, err := attestation.Data()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attestation data")
+	}
+	committeeIndices, err := AttestationCommitteeIndices(attestation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockAttestation{
+		AggregationBits:  aggregationBits,
+		Data:             data,
+		CommitteeIndices: committeeIndices,
+	}, nil
+}
+
+// AggregationBitIndex returns the index in the aggregation bits of the member
+// at the given position in the given committee.  As the members of each
+// committee covered by the attestation are concatenated, committeeSize is used
+// to obtain the sizes of the committees that precede the given committee.
+// It returns false if the attestation does not cover the committee.
+func (a *BlockAttestation) AggregationBitIndex(committeeIndex phase0.CommitteeIndex,
+	position uint64,
+	committeeSize func(phase0.CommitteeIndex) (uint64, error),
+) (
+	uint64,
+	bool,
+	error,
+) {
+	offset := uint64(0)
+	for _, index := range a.CommitteeIndices {
+		if index == committeeIndex {
+			return offset + position, true, nil
+		}
+		size, err := committeeSize(index)
+		if err != nil {
+			return 0, false, errors.Wrap(err, fmt.Sprintf("failed to obtain size of committee %d", index))
+		}
+		offset += size
+	}
+
+	return 0, false, nil
+}
+
+// Attested returns true if the member at the given position in the given
+// committee is included in the attestation.
+func (a *BlockAttestation) Attested(committeeIndex phase0.CommitteeIndex,
+	position uint64,
+	committeeSize func(phase0.CommitteeIndex) (uint64, error),
+) (
+	bool,
+	error,
+) {
+	index, covered, err := a.AggregationBitIndex(committeeIndex, position, committeeSize)
+	if err != nil {
+		return false, err
+	}
+	if !covered || index >= a.AggregationBits.Len() {
+		return false, nil
+	}
+
+	return a.AggregationBits.BitAt(index), nil
+}
+
+// BlockAttestations obtains the slot and attestations of a block.
+// It returns false if the block is not found.
+func BlockAttestations(ctx context.Context,
+	eth2Client eth2client.Service,
+	blockID string,
+) (
+	phase0.Slot,
+	[]*BlockAttestation,
+	bool,
+	error,
+) {
+	block, err := ResponseData(eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return 0, nil, false, errors.Wrap(err, "failed to obtain beacon block")
+	}
+	if block == nil {
+		return 0, nil, false, nil
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return 0, nil, false, errors.Wrap(err, "failed to obtain block slot")
+	}
+	blockAttestations, err := block.Attestations()
+	if err != nil {
+		return 0, nil, false, errors.Wrap(err, "failed to obtain block attestations")
+	}
+	attestations := make([]*BlockAttestation, 0, len(blockAttestations))
+	for _, attestation := range blockAttestations {
+		blockAttestation, err := NewVersionedBlockAttestation(attestation)
+		if err != nil {
+			return 0, nil, false, err
+		}
+		attestations = append(attestations, blockAttestation)
+	}
+
+	return slot, attestations, true, nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestAttestationValidators(t *testing.T) {
+	// Bits 0 and 2 of 4, and bits 1, 4 and 7 of 8.
+	fourBits := bitfield.Bitlist{0x15}
+	eightBits := bitfield.Bitlist{0x92, 0x01}
+
+	tests := []struct {
+		name            string
+		aggregationBits bitfield.Bitlist
+		committees      [][]phase0.ValidatorIndex
+		res             []phase0.ValidatorIndex
+		err             string
+	}{
+		{
+			name:            "SingleCommittee",
+			aggregationBits: fourBits,
+			committees:      [][]phase0.ValidatorIndex{{10, 11, 12, 13}},
+			res:             []phase0.ValidatorIndex{10, 12},
+		},
+		{
+			name:            "MultipleCommittees",
+			aggregationBits: eightBits,
+			committees:      [][]phase0.ValidatorIndex{{10, 11, 12, 13}, {20, 21, 22, 23}},
+			res:             []phase0.ValidatorIndex{11, 20, 23},
+		},
+		{
+			name:            "SizeMismatch",
+			aggregationBits: eightBits,
+			committees:      [][]phase0.ValidatorIndex{{10, 11, 12, 13}},
+			err:             "aggregation bits cover 8 validators but committees contain 4",
+		},
+		{
+			name:            "NoCommittees",
+			aggregationBits: fourBits,
+			err:             "aggregation bits cover 4 validators but committees contain 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.AttestationValidators(test.aggregationBits, test.committees...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestBlockAttestationAttested(t *testing.T) {
+	committeeSize := func(committeeIndex phase0.CommitteeIndex) (uint64, error) {
+		if committeeIndex == 9 {
+			return 0, errors.New("unknown committee")
+		}
+		return 4, nil
+	}
+
+	// Bits 1 and 6 of 8.
+	aggregationBits := bitfield.Bitlist{0x42, 0x01}
+	phase0Attestation := util.NewBlockAttestation(&phase0.Attestation{
+		AggregationBits: bitfield.Bitlist{0x12},
+		Data: &phase0.AttestationData{
+			Index: 3,
+		},
+	})
+	electraAttestation, err := util.NewVersionedBlockAttestation(&spec.VersionedAttestation{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.Attestation{
+			AggregationBits: aggregationBits,
+			Data:            &phase0.AttestationData{},
+			CommitteeBits:   bitfield.Bitvector64{0x14, 0, 0, 0, 0, 0, 0, 0},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []phase0.CommitteeIndex{2, 4}, electraAttestation.CommitteeIndices)
+
+	tests := []struct {
+		name           string
+		attestation    *util.BlockAttestation
+		committeeIndex phase0.CommitteeIndex
+		position       uint64
+		res            bool
+		err            string
+	}{
+		{
+			name:           "Phase0Attested",
+			attestation:    phase0Attestation,
+			committeeIndex: 3,
+			position:       1,
+			res:            true,
+		},
+		{
+			name:           "Phase0NotAttested",
+			attestation:    phase0Attestation,
+			committeeIndex: 3,
+			position:       2,
+		},
+		{
+			name:           "Phase0OtherCommittee",
+			attestation:    phase0Attestation,
+			committeeIndex: 2,
+			position:       1,
+		},
+		{
+			name:           "ElectraFirstCommittee",
+			attestation:    electraAttestation,
+			committeeIndex: 2,
+			position:       1,
+			res:            true,
+		},
+		{
+			name:           "ElectraSecondCommittee",
+			attestation:    electraAttestation,
+			committeeIndex: 4,
+			position:       2,
+			res:            true,
+		},
+		{
+			name:           "ElectraSecondCommitteeNotAttested",
+			attestation:    electraAttestation,
+			committeeIndex: 4,
+			position:       1,
+		},
+		{
+			name:           "ElectraOtherCommittee",
+			attestation:    electraAttestation,
+			committeeIndex: 3,
+			position:       1,
+		},
+		{
+			name: "CommitteeSizeError",
+			attestation: &util.BlockAttestation{
+				AggregationBits:  aggregationBits,
+				Data:             &phase0.AttestationData{},
+				CommitteeIndices: []phase0.CommitteeIndex{9, 10},
+			},
+			committeeIndex: 10,
+			position:       1,
+			err:            "failed to obtain size of committee 9: unknown committee",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.attestation.Attested(test.committeeIndex, test.position, committeeSize)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// BeaconCommitteeSizeCache is a cache of beacon committee sizes.
+type BeaconCommitteeSizeCache struct {
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+	entries                  map[phase0.Slot]map[phase0.CommitteeIndex]uint64
+}
+
+// NewBeaconCommitteeSizeCache makes a new beacon committee size cache.
+func NewBeaconCommitteeSizeCache(provider eth2client.BeaconCommitteesProvider) *BeaconCommitteeSizeCache {
+	return &BeaconCommitteeSizeCache{
+		beaconCommitteesProvider: provider,
+		entries:                  make(map[phase0.Slot]map[phase0.CommitteeIndex]uint64),
+	}
+}
+
+// Fetch the size of the given committee at the given slot.
+func (b *BeaconCommitteeSizeCache) Fetch(ctx context.Context,
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+) (
+	uint64,
+	error,
+) {
+	if _, exists := b.entries[slot]; !exists {
+		// Committees are returned for the entire epoch, so cache them all.
+		committees, err := ResponseData(b.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+			State: fmt.Sprintf("%d", slot),
+		}))
+		if err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", slot))
+		}
+		for _, committee := range committees {
+			if _, exists := b.entries[committee.Slot]; !exists {
+				b.entries[committee.Slot] = make(map[phase0.CommitteeIndex]uint64)
+			}
+			b.entries[committee.Slot][committee.Index] = uint64(len(committee.Validators))
+		}
+	}
+
+	size, exists := b.entries[slot][committeeIndex]
+	if !exists {
+		return 0, fmt.Errorf("no committee %d at slot %d", committeeIndex, slot)
+	}
+
+	return size, nil
+}
@@ -16,6 +16,7 @@ package util
 import (
 	"context"
 	"fmt"
+	nethttp "net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"github.com/attestantio/go-eth2-client/http"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 )
 
 // defaultBeaconNodeAddresses are default REST endpoint addresses for beacon nodes.
@@ -80,6 +82,18 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 }
 
 func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	// transport is set if the beacon node cannot be reached with a standard transport.
+	var transport *nethttp.Transport
+	if strings.HasPrefix(address, unixSocketPrefix) {
+		// Unix sockets are local, so there is no need to check for security.
+		var err error
+		transport, err = unixSocketTransport(address, timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to connect to unix socket")
+		}
+		address = unixSocketAddress
+		allowInsecure = true
+	}
 	if !strings.HasPrefix(address, "http") {
 		address = fmt.Sprintf("http://%s", address)
 	}
@@ -97,14 +111,49 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 			fmt.Println("Connections to remote beacon nodes should be secure.  This warning can be silenced with --allow-insecure-connections")
 		}
 	}
-	eth2Client, err := http.New(ctx,
+	if strings.HasPrefix(address, "https://") {
+		tlsConfig, err := connectionTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			transport = tlsTransport(timeout, tlsConfig)
+		}
+	}
+
+	params := []http.Parameter{
 		http.WithLogLevel(zerolog.Disabled),
 		http.WithAddress(address),
 		http.WithTimeout(timeout),
-	)
+	}
+	var client *nethttp.Client
+	if transport != nil || connectionTraceEnabled() || commandTimings != nil {
+		var roundTripper nethttp.RoundTripper = nethttp.DefaultTransport
+		if transport != nil {
+			roundTripper = transport
+		}
+		var err error
+		roundTripper, err = tracedTransport(roundTripper, viper.GetString("trace-http-dir"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up tracing")
+		}
+		client = &nethttp.Client{
+			Transport: roundTripper,
+		}
+		params = append(params, http.WithHTTPClient(client))
+	}
+	eth2Client, err := http.New(ctx, params...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to connect to beacon node")
 	}
+	if client != nil {
+		// Events must also be streamed over the client.
+		return &eventsService{
+			Service: eth2Client.(*http.Service),
+			address: address,
+			client:  client,
+		}, nil
+	}
 
 	return eth2Client, nil
 }
@@ -0,0 +1,707 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	apiv1electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// BeaconNodeData fetches data from a beacon node REST API endpoint that is
+// not provided by the client, and unmarshals its "data" field into res.
+// It returns false if the endpoint returned no data.
+func BeaconNodeData(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	endpoint string,
+	res interface{},
+) (
+	bool,
+	error,
+) {
+	body, _, found, err := beaconNodeGet(ctx, eth2Client, timeout, endpoint)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	data := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return false, errors.Wrap(err, "failed to parse response")
+	}
+	if len(data.Data) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(data.Data, res); err != nil {
+		return false, errors.Wrap(err, "failed to parse response data")
+	}
+
+	return true, nil
+}
+
+// ProposalValues are the values of a block proposal, as reported by the beacon node.
+type ProposalValues struct {
+	Blinded               bool
+	ExecutionPayloadValue *big.Int
+	ConsensusBlockValue   *big.Int
+}
+
+// ProposalRandaoReveal returns the randao reveal to supply with a request for
+// a block proposal, and whether the beacon node should skip its verification.
+// If the proposer's randao reveal is not available then the point at infinity
+// is returned, as beacon nodes require when skipping verification.
+func ProposalRandaoReveal(randaoReveal *phase0.BLSSignature) (phase0.BLSSignature, bool) {
+	if randaoReveal == nil {
+		return phase0.BLSSignature{0xc0}, true
+	}
+
+	return *randaoReveal, false
+}
+
+// BlockProposalValues requests a block proposal from the beacon node using
+// version 3 of the block production endpoint, and returns the values that
+// the node reports for it.  The proposal itself is discarded.
+// The randao reveal should be nil unless it is that of the proposer for the
+// slot, in which case the beacon node skips its verification.
+// If supplied, the builder boost factor is passed to the node to weight its
+// choice between builder and locally-built payloads: 0 requests a local
+// payload, and the maximum value requests a builder payload where available.
+// It returns false if the endpoint is not supported by the node.
+func BlockProposalValues(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	slot phase0.Slot,
+	randaoReveal *phase0.BLSSignature,
+	graffiti []byte,
+	builderBoostFactor *uint64,
+) (
+	*ProposalValues,
+	bool,
+	error,
+) {
+	reveal, skipRandaoVerification := ProposalRandaoReveal(randaoReveal)
+	endpoint := fmt.Sprintf("/eth/v3/validator/blocks/%d?randao_reveal=%#x&graffiti=%#x", slot, reveal, graffiti)
+	if skipRandaoVerification {
+		endpoint = fmt.Sprintf("%s&skip_randao_verification", endpoint)
+	}
+	if builderBoostFactor != nil {
+		endpoint = fmt.Sprintf("%s&builder_boost_factor=%d", endpoint, *builderBoostFactor)
+	}
+	body, header, found, err := beaconNodeGet(ctx, eth2Client, timeout, endpoint)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Blinded               bool   `json:"execution_payload_blinded"`
+		ExecutionPayloadValue string `json:"execution_payload_value"`
+		ConsensusBlockValue   string `json:"consensus_block_value"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+	// Values may be supplied in the headers rather than the body.
+	if data.ExecutionPayloadValue == "" {
+		data.ExecutionPayloadValue = header.Get("Eth-Execution-Payload-Value")
+	}
+	if data.ConsensusBlockValue == "" {
+		data.ConsensusBlockValue = header.Get("Eth-Consensus-Block-Value")
+	}
+	if header.Get("Eth-Execution-Payload-Blinded") == "true" {
+		data.Blinded = true
+	}
+
+	res := &ProposalValues{
+		Blinded: data.Blinded,
+	}
+	if data.ExecutionPayloadValue != "" {
+		value, success := new(big.Int).SetString(data.ExecutionPayloadValue, 10)
+		if !success {
+			return nil, false, fmt.Errorf("invalid execution payload value %q", data.ExecutionPayloadValue)
+		}
+		res.ExecutionPayloadValue = value
+	}
+	if data.ConsensusBlockValue != "" {
+		value, success := new(big.Int).SetString(data.ConsensusBlockValue, 10)
+		if !success {
+			return nil, false, fmt.Errorf("invalid consensus block value %q", data.ConsensusBlockValue)
+		}
+		res.ConsensusBlockValue = value
+	}
+
+	return res, true, nil
+}
+
+// SignedBlindedBeaconBlock fetches a signed blinded beacon block from the beacon node.
+// Blocks prior to bellatrix have no execution payload and so no blinded form; for these
+// only the version of the returned block is set.
+// It returns false if the block is not found.
+func SignedBlindedBeaconBlock(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	blockID string,
+) (
+	*api.VersionedSignedBlindedBeaconBlock,
+	bool,
+	error,
+) {
+	body, header, found, err := beaconNodeGet(ctx, eth2Client, timeout, fmt.Sprintf("/eth/v1/beacon/blinded_blocks/%s", blockID))
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Version string          `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+	if data.Version == "" {
+		data.Version = header.Get("Eth-Consensus-Version")
+	}
+	if len(data.Data) == 0 {
+		return nil, false, nil
+	}
+
+	res := &api.VersionedSignedBlindedBeaconBlock{}
+	switch strings.ToLower(data.Version) {
+	case "phase0":
+		res.Version = spec.DataVersionPhase0
+	case "altair":
+		res.Version = spec.DataVersionAltair
+	case "bellatrix":
+		res.Version = spec.DataVersionBellatrix
+		res.Bellatrix = &apiv1bellatrix.SignedBlindedBeaconBlock{}
+		err = json.Unmarshal(data.Data, res.Bellatrix)
+	case "capella":
+		res.Version = spec.DataVersionCapella
+		res.Capella = &apiv1capella.SignedBlindedBeaconBlock{}
+		err = json.Unmarshal(data.Data, res.Capella)
+	case "deneb":
+		res.Version = spec.DataVersionDeneb
+		res.Deneb = &apiv1deneb.SignedBlindedBeaconBlock{}
+		err = json.Unmarshal(data.Data, res.Deneb)
+	case "electra":
+		res.Version = spec.DataVersionElectra
+		res.Electra = &apiv1electra.SignedBlindedBeaconBlock{}
+		err = json.Unmarshal(data.Data, res.Electra)
+	case "fulu":
+		res.Version = spec.DataVersionFulu
+		res.Fulu = &apiv1electra.SignedBlindedBeaconBlock{}
+		err = json.Unmarshal(data.Data, res.Fulu)
+	default:
+		return nil, false, fmt.Errorf("unhandled block version %q", data.Version)
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse blinded block")
+	}
+
+	return res, true, nil
+}
+
+// BlobSidecar is a blob sidecar as returned by the beacon node, including the
+// block header and the proof of inclusion of its commitment in the block body.
+type BlobSidecar struct {
+	Index                       deneb.BlobIndex                 `json:"index"`
+	Blob                        deneb.Blob                      `json:"blob"`
+	KZGCommitment               deneb.KZGCommitment             `json:"kzg_commitment"`
+	KZGProof                    deneb.KZGProof                  `json:"kzg_proof"`
+	SignedBlockHeader           *phase0.SignedBeaconBlockHeader `json:"signed_block_header"`
+	KZGCommitmentInclusionProof []phase0.Root                   `json:"kzg_commitment_inclusion_proof"`
+}
+
+// BlobSidecars fetches the blob sidecars for a block from the beacon node.
+// It returns false if the block is not found.
+func BlobSidecars(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	blockID string,
+) (
+	[]*BlobSidecar,
+	bool,
+	error,
+) {
+	res := make([]*BlobSidecar, 0)
+	found, err := BeaconNodeData(ctx, eth2Client, timeout, fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%s", blockID), &res)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return res, found, nil
+}
+
+// ValidatorLiveness fetches the liveness of the given validators in the given
+// epoch from the beacon node.  A validator is live if the beacon node has seen
+// it attest or propose during the epoch.
+// It returns false if the endpoint is not supported by the node.
+func ValidatorLiveness(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	map[phase0.ValidatorIndex]bool,
+	bool,
+	error,
+) {
+	request := make([]string, len(indices))
+	for i := range indices {
+		request[i] = fmt.Sprintf("%d", indices[i])
+	}
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create request")
+	}
+
+	body, _, found, err := beaconNodeRequest(ctx, eth2Client, timeout, http.MethodPost, fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch), reqBody, "application/json")
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Data []struct {
+			Index  string `json:"index"`
+			IsLive bool   `json:"is_live"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+
+	res := make(map[phase0.ValidatorIndex]bool, len(data.Data))
+	for _, liveness := range data.Data {
+		index, err := strconv.ParseUint(liveness.Index, 10, 64)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "invalid validator index in response")
+		}
+		res[phase0.ValidatorIndex(index)] = liveness.IsLive
+	}
+
+	return res, true, nil
+}
+
+// AttestationReward is the reward or penalty for a validator's attestation in
+// an epoch, as reported by the beacon node.  Values are in Gwei, and are
+// negative for penalties.
+type AttestationReward struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Head           int64
+	Target         int64
+	Source         int64
+	// InclusionDelay is only present prior to Altair.
+	InclusionDelay *int64
+	Inactivity     int64
+}
+
+// Total returns the total reward for the attestation.
+func (r *AttestationReward) Total() int64 {
+	res := r.Head + r.Target + r.Source + r.Inactivity
+	if r.InclusionDelay != nil {
+		res += *r.InclusionDelay
+	}
+
+	return res
+}
+
+// AttestationRewards fetches the attestation rewards for the given validators
+// in the given epoch from the beacon node.
+// It returns false if the endpoint is not supported by the node, or the
+// rewards for the epoch are not available.
+func AttestationRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	epoch phase0.Epoch,
+	indices []phase0.ValidatorIndex,
+) (
+	[]*AttestationReward,
+	bool,
+	error,
+) {
+	request := make([]string, len(indices))
+	for i := range indices {
+		request[i] = fmt.Sprintf("%d", indices[i])
+	}
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create request")
+	}
+
+	body, _, found, err := beaconNodeRequest(ctx, eth2Client, timeout, http.MethodPost, fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), reqBody, "application/json")
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Data struct {
+			TotalRewards []struct {
+				ValidatorIndex string `json:"validator_index"`
+				Head           string `json:"head"`
+				Target         string `json:"target"`
+				Source         string `json:"source"`
+				InclusionDelay string `json:"inclusion_delay"`
+				Inactivity     string `json:"inactivity"`
+			} `json:"total_rewards"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+
+	res := make([]*AttestationReward, 0, len(data.Data.TotalRewards))
+	for _, reward := range data.Data.TotalRewards {
+		index, err := strconv.ParseUint(reward.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "invalid validator index in response")
+		}
+		entry := &AttestationReward{
+			ValidatorIndex: phase0.ValidatorIndex(index),
+		}
+		for _, value := range []struct {
+			name  string
+			input string
+			dest  *int64
+		}{
+			{name: "head", input: reward.Head, dest: &entry.Head},
+			{name: "target", input: reward.Target, dest: &entry.Target},
+			{name: "source", input: reward.Source, dest: &entry.Source},
+			{name: "inactivity", input: reward.Inactivity, dest: &entry.Inactivity},
+		} {
+			if value.input == "" {
+				continue
+			}
+			*value.dest, err = strconv.ParseInt(value.input, 10, 64)
+			if err != nil {
+				return nil, false, errors.Wrap(err, fmt.Sprintf("invalid %s reward in response", value.name))
+			}
+		}
+		if reward.InclusionDelay != "" {
+			inclusionDelay, err := strconv.ParseInt(reward.InclusionDelay, 10, 64)
+			if err != nil {
+				return nil, false, errors.Wrap(err, "invalid inclusion delay reward in response")
+			}
+			entry.InclusionDelay = &inclusionDelay
+		}
+		res = append(res, entry)
+	}
+
+	return res, true, nil
+}
+
+// BlockReward is the reward for proposing a block, as reported by the beacon
+// node.  Values are in Gwei.
+type BlockReward struct {
+	ProposerIndex     phase0.ValidatorIndex
+	Total             int64
+	Attestations      int64
+	SyncAggregate     int64
+	ProposerSlashings int64
+	AttesterSlashings int64
+}
+
+// BlockRewards fetches the reward for proposing the given block from the
+// beacon node.
+// It returns false if the endpoint is not supported by the node, or the
+// block is not found.
+func BlockRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	blockID string,
+) (
+	*BlockReward,
+	bool,
+	error,
+) {
+	body, _, found, err := beaconNodeGet(ctx, eth2Client, timeout, fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%s", blockID))
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Data struct {
+			ProposerIndex     string `json:"proposer_index"`
+			Total             string `json:"total"`
+			Attestations      string `json:"attestations"`
+			SyncAggregate     string `json:"sync_aggregate"`
+			ProposerSlashings string `json:"proposer_slashings"`
+			AttesterSlashings string `json:"attester_slashings"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+
+	proposerIndex, err := strconv.ParseUint(data.Data.ProposerIndex, 10, 64)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "invalid proposer index in response")
+	}
+	res := &BlockReward{
+		ProposerIndex: phase0.ValidatorIndex(proposerIndex),
+	}
+	for _, value := range []struct {
+		name  string
+		input string
+		dest  *int64
+	}{
+		{name: "total", input: data.Data.Total, dest: &res.Total},
+		{name: "attestations", input: data.Data.Attestations, dest: &res.Attestations},
+		{name: "sync aggregate", input: data.Data.SyncAggregate, dest: &res.SyncAggregate},
+		{name: "proposer slashings", input: data.Data.ProposerSlashings, dest: &res.ProposerSlashings},
+		{name: "attester slashings", input: data.Data.AttesterSlashings, dest: &res.AttesterSlashings},
+	} {
+		if value.input == "" {
+			continue
+		}
+		*value.dest, err = strconv.ParseInt(value.input, 10, 64)
+		if err != nil {
+			return nil, false, errors.Wrap(err, fmt.Sprintf("invalid %s reward in response", value.name))
+		}
+	}
+
+	return res, true, nil
+}
+
+// SyncCommitteeReward is the reward or penalty for a validator's
+// participation in the sync committee for a block, as reported by the beacon
+// node.  Values are in Gwei, and are negative for penalties.
+type SyncCommitteeReward struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Reward         int64
+}
+
+// SyncCommitteeRewards fetches the sync committee rewards for the given
+// validators in the given block from the beacon node.  Validators that are not
+// in the sync committee are not included in the result.
+// It returns false if the endpoint is not supported by the node, or the
+// block is not found.
+func SyncCommitteeRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	blockID string,
+	indices []phase0.ValidatorIndex,
+) (
+	[]*SyncCommitteeReward,
+	bool,
+	error,
+) {
+	request := make([]string, len(indices))
+	for i := range indices {
+		request[i] = fmt.Sprintf("%d", indices[i])
+	}
+	reqBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to create request")
+	}
+
+	body, _, found, err := beaconNodeRequest(ctx, eth2Client, timeout, http.MethodPost, fmt.Sprintf("/eth/v1/beacon/rewards/sync_committee/%s", blockID), reqBody, "application/json")
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	data := struct {
+		Data []struct {
+			ValidatorIndex string `json:"validator_index"`
+			Reward         string `json:"reward"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, false, errors.Wrap(err, "failed to parse response")
+	}
+
+	res := make([]*SyncCommitteeReward, 0, len(data.Data))
+	for _, reward := range data.Data {
+		index, err := strconv.ParseUint(reward.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "invalid validator index in response")
+		}
+		value, err := strconv.ParseInt(reward.Reward, 10, 64)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "invalid sync committee reward in response")
+		}
+		res = append(res, &SyncCommitteeReward{
+			ValidatorIndex: phase0.ValidatorIndex(index),
+			Reward:         value,
+		})
+	}
+
+	return res, true, nil
+}
+
+// beaconNodeGet fetches the body of a beacon node REST API endpoint as JSON.
+// It returns false if the endpoint is not found.
+func beaconNodeGet(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	endpoint string,
+) (
+	[]byte,
+	http.Header,
+	bool,
+	error,
+) {
+	return beaconNodeGetContent(ctx, eth2Client, timeout, endpoint, "application/json")
+}
+
+// beaconNodeGetContent fetches the body of a beacon node REST API endpoint
+// with the given content type.
+// It returns false if the endpoint is not found.
+func beaconNodeGetContent(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	endpoint string,
+	contentType string,
+) (
+	[]byte,
+	http.Header,
+	bool,
+	error,
+) {
+	return beaconNodeRequest(ctx, eth2Client, timeout, http.MethodGet, endpoint, nil, contentType)
+}
+
+// beaconNodeRequest calls a beacon node REST API endpoint with the given method,
+// sending the supplied body as JSON if present.
+// It returns false if the endpoint is not found.
+func beaconNodeRequest(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	method string,
+	endpoint string,
+	reqBody []byte,
+	contentType string,
+) (
+	[]byte,
+	http.Header,
+	bool,
+	error,
+) {
+	resp, cancel, err := beaconNodeResponse(ctx, eth2Client, timeout, method, endpoint, reqBody, contentType)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if resp == nil {
+		return nil, nil, false, nil
+	}
+	defer cancel()
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, false, errors.Wrap(err, "failed to read response")
+	}
+
+	return body, resp.Header, true, nil
+}
+
+// beaconNodeResponse calls a beacon node REST API endpoint with the given method,
+// sending the supplied body as JSON if present, and returns the successful
+// response for the caller to read.  The caller must close the body of the
+// response and then call the returned cancel function.
+// It returns a nil response if the endpoint is not found.
+func beaconNodeResponse(ctx context.Context,
+	eth2Client eth2client.Service,
+	timeout time.Duration,
+	method string,
+	endpoint string,
+	reqBody []byte,
+	contentType string,
+) (
+	*http.Response,
+	context.CancelFunc,
+	error,
+) {
+	address := eth2Client.Address()
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(address, "/"), strings.TrimPrefix(endpoint, "/"))
+
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	var reader io.Reader
+	if reqBody != nil {
+		reader = bytes.NewReader(reqBody)
+	}
+	req, err := http.NewRequestWithContext(opCtx, method, url, reader)
+	if err != nil {
+		cancel()
+		return nil, nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", contentType)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, nil, errors.Wrap(err, "failed to call endpoint")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		cancel()
+		return nil, nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer cancel()
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read response")
+		}
+		return nil, nil, fmt.Errorf("endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, cancel, nil
+}
@@ -0,0 +1,696 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1electra "github.com/attestantio/go-eth2-client/api/v1/electra"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type testService struct {
+	address string
+}
+
+func (s *testService) Name() string    { return "test" }
+func (s *testService) Address() string { return s.address }
+func (s *testService) IsActive() bool  { return true }
+func (s *testService) IsSynced() bool  { return true }
+
+func TestBeaconNodeData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/good":
+			_, _ = w.Write([]byte(`{"data":{"value":"1"}}`))
+		case "/empty":
+			_, _ = w.Write([]byte(`{}`))
+		case "/bad":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`failed`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		found    bool
+		value    string
+		err      string
+	}{
+		{
+			name:     "Good",
+			endpoint: "/good",
+			found:    true,
+			value:    "1",
+		},
+		{
+			name:     "Empty",
+			endpoint: "/empty",
+		},
+		{
+			name:     "NotFound",
+			endpoint: "/missing",
+		},
+		{
+			name:     "Bad",
+			endpoint: "/bad",
+			err:      "endpoint returned status 500: failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := struct {
+				Value string `json:"value"`
+			}{}
+			found, err := util.BeaconNodeData(context.Background(), &testService{address: server.URL}, time.Second, test.endpoint, &res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.found, found)
+				require.Equal(t, test.value, res.Value)
+			}
+		})
+	}
+}
+
+func TestBlockProposalValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v3/validator/blocks/1":
+			_, _ = w.Write([]byte(`{"version":"capella","execution_payload_blinded":true,"execution_payload_value":"12345","consensus_block_value":"678","data":{}}`))
+		case "/eth/v3/validator/blocks/2":
+			w.Header().Set("Eth-Execution-Payload-Value", "23456")
+			w.Header().Set("Eth-Consensus-Block-Value", "789")
+			_, _ = w.Write([]byte(`{"version":"capella","data":{}}`))
+		case "/eth/v3/validator/blocks/3":
+			_, _ = w.Write([]byte(`{"version":"capella","execution_payload_value":"bad","data":{}}`))
+		case "/eth/v3/validator/blocks/6":
+			// Only the point at infinity may be supplied when skipping randao verification.
+			_, skip := r.URL.Query()["skip_randao_verification"]
+			if skip != (r.URL.Query().Get("randao_reveal") == fmt.Sprintf("%#x", phase0.BLSSignature{0xc0})) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"version":"capella","execution_payload_blinded":false,"execution_payload_value":"300","consensus_block_value":"30","data":{}}`))
+		case "/eth/v3/validator/blocks/5":
+			if r.URL.Query().Get("builder_boost_factor") == "0" {
+				_, _ = w.Write([]byte(`{"version":"capella","execution_payload_blinded":false,"execution_payload_value":"100","consensus_block_value":"10","data":{}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"version":"capella","execution_payload_blinded":true,"execution_payload_value":"200","consensus_block_value":"10","data":{}}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	localBoost := uint64(0)
+	proposerRandaoReveal := phase0.BLSSignature{0x01}
+
+	tests := []struct {
+		name               string
+		slot               phase0.Slot
+		randaoReveal       *phase0.BLSSignature
+		builderBoostFactor *uint64
+		found              bool
+		blinded            bool
+		execution          string
+		consensus          string
+		err                string
+	}{
+		{
+			name:      "Body",
+			slot:      1,
+			found:     true,
+			blinded:   true,
+			execution: "12345",
+			consensus: "678",
+		},
+		{
+			name:      "Headers",
+			slot:      2,
+			found:     true,
+			execution: "23456",
+			consensus: "789",
+		},
+		{
+			name: "InvalidValue",
+			slot: 3,
+			err:  `invalid execution payload value "bad"`,
+		},
+		{
+			name: "NotSupported",
+			slot: 4,
+		},
+		{
+			name:      "DefaultBoost",
+			slot:      5,
+			found:     true,
+			blinded:   true,
+			execution: "200",
+			consensus: "10",
+		},
+		{
+			name:               "LocalBoost",
+			slot:               5,
+			builderBoostFactor: &localBoost,
+			found:              true,
+			execution:          "100",
+			consensus:          "10",
+		},
+		{
+			name:      "SkipRandaoVerification",
+			slot:      6,
+			found:     true,
+			execution: "300",
+			consensus: "30",
+		},
+		{
+			name:         "ProposerRandaoReveal",
+			slot:         6,
+			randaoReveal: &proposerRandaoReveal,
+			found:        true,
+			execution:    "300",
+			consensus:    "30",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, found, err := util.BlockProposalValues(context.Background(), &testService{address: server.URL}, time.Second, test.slot, test.randaoReveal, make([]byte, 32), test.builderBoostFactor)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			if found {
+				require.Equal(t, test.blinded, values.Blinded)
+				require.Equal(t, test.execution, values.ExecutionPayloadValue.String())
+				require.Equal(t, test.consensus, values.ConsensusBlockValue.String())
+			}
+		})
+	}
+}
+
+func TestSignedBlindedBeaconBlock(t *testing.T) {
+	block := &apiv1capella.SignedBlindedBeaconBlock{
+		Message: &apiv1capella.BlindedBeaconBlock{
+			Slot:          5,
+			ProposerIndex: 6,
+			Body: &apiv1capella.BlindedBeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				ProposerSlashings:     []*phase0.ProposerSlashing{},
+				AttesterSlashings:     []*phase0.AttesterSlashing{},
+				Attestations:          []*phase0.Attestation{},
+				Deposits:              []*phase0.Deposit{},
+				VoluntaryExits:        []*phase0.SignedVoluntaryExit{},
+				BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
+				ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
+					BlockNumber: 7,
+				},
+			},
+		},
+	}
+	blockJSON, err := json.Marshal(block)
+	require.NoError(t, err)
+	electraBlock := &apiv1electra.SignedBlindedBeaconBlock{
+		Message: &apiv1electra.BlindedBeaconBlock{
+			Slot:          8,
+			ProposerIndex: 9,
+			Body: &apiv1electra.BlindedBeaconBlockBody{
+				ETH1Data: &phase0.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				ProposerSlashings:     []*phase0.ProposerSlashing{},
+				AttesterSlashings:     []*electra.AttesterSlashing{},
+				Attestations:          []*electra.Attestation{},
+				Deposits:              []*phase0.Deposit{},
+				VoluntaryExits:        []*phase0.SignedVoluntaryExit{},
+				BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: bitfield.NewBitvector512(),
+				},
+				ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+					BlockNumber:   10,
+					BaseFeePerGas: uint256.NewInt(1),
+				},
+				BlobKZGCommitments: []deneb.KZGCommitment{},
+				ExecutionRequests:  &electra.ExecutionRequests{},
+			},
+		},
+	}
+	electraBlockJSON, err := json.Marshal(electraBlock)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/blinded_blocks/capella":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"version":"capella","data":%s}`, string(blockJSON))))
+		case "/eth/v1/beacon/blinded_blocks/electra":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"version":"electra","data":%s}`, string(electraBlockJSON))))
+		case "/eth/v1/beacon/blinded_blocks/header":
+			w.Header().Set("Eth-Consensus-Version", "capella")
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"data":%s}`, string(blockJSON))))
+		case "/eth/v1/beacon/blinded_blocks/phase0":
+			_, _ = w.Write([]byte(`{"version":"phase0","data":{}}`))
+		case "/eth/v1/beacon/blinded_blocks/unknown":
+			_, _ = w.Write([]byte(`{"version":"future","data":{}}`))
+		case "/eth/v1/beacon/blinded_blocks/invalid":
+			_, _ = w.Write([]byte(`{"version":"capella","data":{"message":{}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		blockID string
+		found   bool
+		version spec.DataVersion
+		err     string
+	}{
+		{
+			name:    "Capella",
+			blockID: "capella",
+			found:   true,
+			version: spec.DataVersionCapella,
+		},
+		{
+			name:    "Electra",
+			blockID: "electra",
+			found:   true,
+			version: spec.DataVersionElectra,
+		},
+		{
+			name:    "VersionInHeader",
+			blockID: "header",
+			found:   true,
+			version: spec.DataVersionCapella,
+		},
+		{
+			name:    "Phase0",
+			blockID: "phase0",
+			found:   true,
+			version: spec.DataVersionPhase0,
+		},
+		{
+			name:    "UnknownVersion",
+			blockID: "unknown",
+			err:     `unhandled block version "future"`,
+		},
+		{
+			name:    "Invalid",
+			blockID: "invalid",
+			err:     "failed to parse blinded block: invalid JSON: slot missing",
+		},
+		{
+			name:    "NotFound",
+			blockID: "missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.SignedBlindedBeaconBlock(context.Background(), &testService{address: server.URL}, time.Second, test.blockID)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			if found {
+				require.Equal(t, test.version, res.Version)
+				if test.version == spec.DataVersionCapella {
+					slot, err := res.Slot()
+					require.NoError(t, err)
+					require.Equal(t, phase0.Slot(5), slot)
+				}
+			}
+		})
+	}
+}
+
+func TestBlobSidecars(t *testing.T) {
+	blob := fmt.Sprintf("0x%0262144x", 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/blob_sidecars/good":
+			_, _ = fmt.Fprintf(w, `{"data":[{"index":"1","blob":"%s","kzg_commitment":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","kzg_proof":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","signed_block_header":{"message":{"slot":"5","proposer_index":"2","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0101010101010101010101010101010101010101010101010101010101010101"},"signature":"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},"kzg_commitment_inclusion_proof":["0x0202020202020202020202020202020202020202020202020202020202020202"]}]}`, blob)
+		case "/eth/v1/beacon/blob_sidecars/none":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "/eth/v1/beacon/blob_sidecars/bad":
+			_, _ = w.Write([]byte(`{"data":[{"index":"x"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		blockID  string
+		found    bool
+		sidecars int
+		err      string
+	}{
+		{
+			name:     "Good",
+			blockID:  "good",
+			found:    true,
+			sidecars: 1,
+		},
+		{
+			name:    "None",
+			blockID: "none",
+			found:   true,
+		},
+		{
+			name:    "Bad",
+			blockID: "bad",
+			err:     "failed to parse response data: invalid value x: strconv.ParseUint: parsing \"x\": invalid syntax",
+		},
+		{
+			name:    "NotFound",
+			blockID: "missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.BlobSidecars(context.Background(), &testService{address: server.URL}, time.Second, test.blockID)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			require.Len(t, res, test.sidecars)
+			if test.sidecars > 0 {
+				require.Equal(t, deneb.BlobIndex(1), res[0].Index)
+				require.Equal(t, phase0.Root{0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01}, res[0].SignedBlockHeader.Message.BodyRoot)
+				require.Len(t, res[0].KZGCommitmentInclusionProof, 1)
+			}
+		})
+	}
+}
+
+func TestValidatorLiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var indices []string
+		if err := json.NewDecoder(r.Body).Decode(&indices); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/eth/v1/validator/liveness/10":
+			data := make([]string, len(indices))
+			for i := range indices {
+				data[i] = fmt.Sprintf(`{"index":"%s","is_live":%t}`, indices[i], indices[i] == "2")
+			}
+			_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(data, ","))
+		case "/eth/v1/validator/liveness/11":
+			_, _ = w.Write([]byte(`{"data":[{"index":"x","is_live":true}]}`))
+		case "/eth/v1/validator/liveness/12":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`bad epoch`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		epoch    phase0.Epoch
+		indices  []phase0.ValidatorIndex
+		found    bool
+		expected map[phase0.ValidatorIndex]bool
+		err      string
+	}{
+		{
+			name:     "Good",
+			epoch:    10,
+			indices:  []phase0.ValidatorIndex{1, 2},
+			found:    true,
+			expected: map[phase0.ValidatorIndex]bool{1: false, 2: true},
+		},
+		{
+			name:    "BadIndex",
+			epoch:   11,
+			indices: []phase0.ValidatorIndex{1},
+			err:     "invalid validator index in response: strconv.ParseUint: parsing \"x\": invalid syntax",
+		},
+		{
+			name:    "BadRequest",
+			epoch:   12,
+			indices: []phase0.ValidatorIndex{1},
+			err:     "endpoint returned status 400: bad epoch",
+		},
+		{
+			name:    "NotSupported",
+			epoch:   13,
+			indices: []phase0.ValidatorIndex{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.ValidatorLiveness(context.Background(), &testService{address: server.URL}, time.Second, test.epoch, test.indices)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			if test.found {
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestAttestationRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/attestations/10":
+			_, _ = w.Write([]byte(`{"data":{"ideal_rewards":[],"total_rewards":[{"validator_index":"1","head":"2000","target":"4000","source":"3000","inactivity":"0"},{"validator_index":"2","head":"0","target":"-4000","source":"-3000","inactivity":"-100"}]}}`))
+		case "/eth/v1/beacon/rewards/attestations/11":
+			_, _ = w.Write([]byte(`{"data":{"total_rewards":[{"validator_index":"1","head":"2000","target":"4000","source":"3000","inclusion_delay":"500","inactivity":"0"}]}}`))
+		case "/eth/v1/beacon/rewards/attestations/12":
+			_, _ = w.Write([]byte(`{"data":{"total_rewards":[{"validator_index":"1","head":"bad"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		epoch  phase0.Epoch
+		found  bool
+		totals []int64
+		err    string
+	}{
+		{
+			name:   "Good",
+			epoch:  10,
+			found:  true,
+			totals: []int64{9000, -7100},
+		},
+		{
+			name:   "InclusionDelay",
+			epoch:  11,
+			found:  true,
+			totals: []int64{9500},
+		},
+		{
+			name:  "BadValue",
+			epoch: 12,
+			err:   "invalid head reward in response: strconv.ParseInt: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:  "NotFound",
+			epoch: 13,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.AttestationRewards(context.Background(), &testService{address: server.URL}, time.Second, test.epoch, []phase0.ValidatorIndex{1, 2})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			require.Len(t, res, len(test.totals))
+			for i := range test.totals {
+				require.Equal(t, test.totals[i], res[i].Total())
+			}
+		})
+	}
+}
+
+func TestBlockRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/blocks/100":
+			_, _ = w.Write([]byte(`{"execution_optimistic":false,"finalized":true,"data":{"proposer_index":"12","total":"40000","attestations":"30000","sync_aggregate":"9000","proposer_slashings":"1000","attester_slashings":"0"}}`))
+		case "/eth/v1/beacon/rewards/blocks/101":
+			_, _ = w.Write([]byte(`{"data":{"proposer_index":"12","total":"bad"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		blockID  string
+		found    bool
+		expected *util.BlockReward
+		err      string
+	}{
+		{
+			name:    "Good",
+			blockID: "100",
+			found:   true,
+			expected: &util.BlockReward{
+				ProposerIndex:     12,
+				Total:             40000,
+				Attestations:      30000,
+				SyncAggregate:     9000,
+				ProposerSlashings: 1000,
+			},
+		},
+		{
+			name:    "BadValue",
+			blockID: "101",
+			err:     "invalid total reward in response: strconv.ParseInt: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:    "NotFound",
+			blockID: "102",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.BlockRewards(context.Background(), &testService{address: server.URL}, time.Second, test.blockID)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestSyncCommitteeRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/sync_committee/100":
+			_, _ = w.Write([]byte(`{"data":[{"validator_index":"1","reward":"2000"},{"validator_index":"2","reward":"-2000"}]}`))
+		case "/eth/v1/beacon/rewards/sync_committee/101":
+			_, _ = w.Write([]byte(`{"data":[{"validator_index":"1","reward":"bad"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		blockID  string
+		found    bool
+		expected []*util.SyncCommitteeReward
+		err      string
+	}{
+		{
+			name:    "Good",
+			blockID: "100",
+			found:   true,
+			expected: []*util.SyncCommitteeReward{
+				{ValidatorIndex: 1, Reward: 2000},
+				{ValidatorIndex: 2, Reward: -2000},
+			},
+		},
+		{
+			name:    "BadValue",
+			blockID: "101",
+			err:     "invalid sync committee reward in response: strconv.ParseInt: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:    "NotFound",
+			blockID: "102",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, found, err := util.SyncCommitteeRewards(context.Background(), &testService{address: server.URL}, time.Second, test.blockID, []phase0.ValidatorIndex{1, 2})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
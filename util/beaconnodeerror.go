@@ -0,0 +1,44 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+)
+
+// IsNotFound returns true if the error is the beacon node reporting that the
+// requested item, such as the block at an empty slot, does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *api.Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ResponseData returns the data from a beacon node response.  A response
+// reporting that the requested item does not exist is returned as empty data
+// rather than as an error, so callers can treat it as they would an empty slot.
+func ResponseData[T any](response *api.Response[T], err error) (T, error) {
+	var data T
+	if IsNotFound(err) {
+		return data, nil
+	}
+	if err != nil {
+		return data, err
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/r3labs/sse/v2"
+)
+
+// eventData provides the structure into which the data for each event topic is decoded.
+var eventData = map[string]func() any{
+	"attestation":             func() any { return &spec.VersionedAttestation{} },
+	"attester_slashing":       func() any { return &electra.AttesterSlashing{} },
+	"blob_sidecar":            func() any { return &apiv1.BlobSidecarEvent{} },
+	"block":                   func() any { return &apiv1.BlockEvent{} },
+	"block_gossip":            func() any { return &apiv1.BlockGossipEvent{} },
+	"bls_to_execution_change": func() any { return &capella.SignedBLSToExecutionChange{} },
+	"chain_reorg":             func() any { return &apiv1.ChainReorgEvent{} },
+	"contribution_and_proof":  func() any { return &altair.SignedContributionAndProof{} },
+	"data_column_sidecar":     func() any { return &apiv1.DataColumnSidecarEvent{} },
+	"finalized_checkpoint":    func() any { return &apiv1.FinalizedCheckpointEvent{} },
+	"head":                    func() any { return &apiv1.HeadEvent{} },
+	"payload_attributes":      func() any { return &apiv1.PayloadAttributesEvent{} },
+	"proposer_slashing":       func() any { return &phase0.ProposerSlashing{} },
+	"single_attestation":      func() any { return &electra.SingleAttestation{} },
+	"voluntary_exit":          func() any { return &phase0.SignedVoluntaryExit{} },
+}
+
+// eventsService is a beacon node service that streams events over the same
+// HTTP client as its other requests.  The HTTP service always streams events
+// over its own transport, which cannot reach beacon nodes that require a
+// custom transport.
+type eventsService struct {
+	*http.Service
+	address string
+	client  *nethttp.Client
+}
+
+// Events feeds requested events with the given topics to the supplied handler.
+// Only the generic handler is supported.
+func (s *eventsService) Events(ctx context.Context, opts *api.EventsOpts) error {
+	if opts == nil {
+		return errors.New("no options supplied")
+	}
+	if len(opts.Topics) == 0 {
+		return errors.New("no topics supplied")
+	}
+	topics := opts.Topics
+	for _, topic := range topics {
+		if _, exists := eventData[topic]; !exists {
+			return fmt.Errorf("unsupported event topic %s", topic)
+		}
+	}
+	handler := opts.Handler
+	if handler == nil {
+		return errors.New("no handler supplied")
+	}
+
+	client := sse.NewClient(fmt.Sprintf("%s/eth/v1/events?topics=%s", strings.TrimSuffix(s.address, "/"), strings.Join(topics, "&topics=")))
+	client.Connection = s.client
+	client.Headers["Accept"] = "text/event-stream"
+
+	go func() {
+		for {
+			select {
+			case <-time.After(time.Second):
+				// Errors result in a reconnection, as per the HTTP service.
+				_ = client.SubscribeRawWithContext(ctx, func(msg *sse.Event) {
+					handleEvent(msg, handler)
+				})
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// handleEvent decodes an event and passes it on to the handler.
+func handleEvent(msg *sse.Event, handler api.EventHandlerFunc) {
+	if msg == nil || handler == nil {
+		return
+	}
+	dataFunc, exists := eventData[string(msg.Event)]
+	if !exists {
+		return
+	}
+	data := dataFunc()
+	if err := json.Unmarshal(msg.Data, data); err != nil {
+		return
+	}
+
+	handler(&apiv1.Event{
+		Topic: string(msg.Event),
+		Data:  data,
+	})
+}
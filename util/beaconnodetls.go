@@ -0,0 +1,86 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// connectionTLSConfig creates a TLS configuration for beacon node connections
+// from the connection-client-cert, connection-client-key and connection-ca-cert
+// settings.  It returns nil if none of the settings are present.
+func connectionTLSConfig() (*tls.Config, error) {
+	clientCert := viper.GetString("connection-client-cert")
+	clientKey := viper.GetString("connection-client-key")
+	caCert := viper.GetString("connection-ca-cert")
+
+	if clientCert == "" && clientKey == "" && caCert == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" {
+			return nil, errors.New("connection client key supplied without client certificate")
+		}
+		if clientKey == "" {
+			return nil, errors.New("connection client certificate supplied without client key")
+		}
+		certificate, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load connection client certificate")
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if caCert != "" {
+		data, err := os.ReadFile(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read connection CA certificate")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("failed to parse connection CA certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// tlsTransport creates a transport that secures connections to beacon nodes
+// with the given TLS configuration.
+func tlsTransport(timeout time.Duration, config *tls.Config) *http.Transport {
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     config,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        64,
+		IdleConnTimeout:     600 * time.Second,
+	}
+}
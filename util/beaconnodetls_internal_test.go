@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionTLSConfig(t *testing.T) {
+	badCA := filepath.Join(t.TempDir(), "bad.pem")
+	require.NoError(t, os.WriteFile(badCA, []byte("bad"), 0o600))
+
+	tests := []struct {
+		name   string
+		vars   map[string]interface{}
+		isNil  bool
+		errStr string
+	}{
+		{
+			name:  "None",
+			isNil: true,
+		},
+		{
+			name: "KeyOnly",
+			vars: map[string]interface{}{
+				"connection-client-key": "client.key",
+			},
+			errStr: "connection client key supplied without client certificate",
+		},
+		{
+			name: "CertOnly",
+			vars: map[string]interface{}{
+				"connection-client-cert": "client.crt",
+			},
+			errStr: "connection client certificate supplied without client key",
+		},
+		{
+			name: "CAMissing",
+			vars: map[string]interface{}{
+				"connection-ca-cert": filepath.Join(t.TempDir(), "missing.pem"),
+			},
+			errStr: "failed to read connection CA certificate",
+		},
+		{
+			name: "CABad",
+			vars: map[string]interface{}{
+				"connection-ca-cert": badCA,
+			},
+			errStr: "failed to parse connection CA certificate",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			config, err := connectionTLSConfig()
+			if test.errStr != "" {
+				require.ErrorContains(t, err, test.errStr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.isNil, config == nil)
+			}
+		})
+	}
+}
+
+func TestTLSTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0o600))
+
+	viper.Reset()
+	viper.Set("connection-ca-cert", caCert)
+	config, err := connectionTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, config)
+
+	// The server's certificate is not trusted without the configuration.
+	_, err = http.Get(server.URL + "/eth/v1/node/version")
+	require.Error(t, err)
+
+	client := &http.Client{
+		Transport: tlsTransport(time.Second, config),
+	}
+
+	resp, err := client.Get(server.URL + "/eth/v1/node/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "/eth/v1/node/version", string(body))
+}
@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// maxTraceNameLength is the maximum length of the path component of a trace file name.
+const maxTraceNameLength = 100
+
+var traceNameRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// traceTransport is a transport that logs requests to a beacon node, and
+// optionally writes their bodies to a directory.
+type traceTransport struct {
+	base     http.RoundTripper
+	out      io.Writer
+	dir      string
+	requests atomic.Uint64
+}
+
+// connectionTraceEnabled returns true if requests to the beacon node should be traced.
+func connectionTraceEnabled() bool {
+	return viper.GetBool("trace-http") || viper.GetString("trace-http-dir") != ""
+}
+
+// tracedTransport wraps the given transport to trace requests to the beacon
+// node, and to record their timings if timings are enabled.
+func tracedTransport(base http.RoundTripper, dir string) (http.RoundTripper, error) {
+	transport := base
+	if connectionTraceEnabled() {
+		var err error
+		transport, err = newTraceTransport(transport, os.Stderr, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if commandTimings != nil {
+		transport = &timingTransport{
+			base:    transport,
+			timings: commandTimings,
+		}
+	}
+
+	return transport, nil
+}
+
+func newTraceTransport(base http.RoundTripper, out io.Writer, dir string) (*traceTransport, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, errors.Wrap(err, "failed to create trace directory")
+		}
+	}
+
+	return &traceTransport{
+		base: base,
+		out:  out,
+		dir:  dir,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := t.requests.Add(1)
+	path := req.URL.RequestURI()
+
+	if t.dir != "" && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		t.writeFile(id, req.Method, path, "request", body)
+	}
+
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		fmt.Fprintf(t.out, "HTTP [%d] %s %s error=%q duration=%s\n", id, req.Method, path, err.Error(), time.Since(started).Round(time.Millisecond))
+		return nil, err
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Streams do not end, so report them immediately and copy them as they are read.
+		fmt.Fprintf(t.out, "HTTP [%d] %s %s status=%d duration=%s bytes=stream\n", id, req.Method, path, resp.StatusCode, time.Since(started).Round(time.Millisecond))
+		if t.dir != "" {
+			file, err := os.OpenFile(t.fileName(id, req.Method, path, "response"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				fmt.Fprintf(t.out, "HTTP [%d] failed to create response trace file: %v\n", id, err)
+			} else {
+				resp.Body = &traceStream{
+					ReadCloser: resp.Body,
+					file:       file,
+				}
+			}
+		}
+
+		return resp, nil
+	}
+
+	// Read the full body so that its size is known when the request is reported.
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		fmt.Fprintf(t.out, "HTTP [%d] %s %s status=%d error=%q duration=%s\n", id, req.Method, path, resp.StatusCode, err.Error(), time.Since(started).Round(time.Millisecond))
+		return nil, err
+	}
+	fmt.Fprintf(t.out, "HTTP [%d] %s %s status=%d duration=%s bytes=%d\n", id, req.Method, path, resp.StatusCode, time.Since(started).Round(time.Millisecond), len(body))
+	if t.dir != "" {
+		t.writeFile(id, req.Method, path, "response", body)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// fileName returns the name of the file to which a body is written.
+func (t *traceTransport) fileName(id uint64, method string, path string, kind string) string {
+	name := traceNameRegex.ReplaceAllString(path, "_")
+	if len(name) > maxTraceNameLength {
+		name = name[:maxTraceNameLength]
+	}
+
+	return filepath.Join(t.dir, fmt.Sprintf("%06d-%s%s.%s", id, method, name, kind))
+}
+
+func (t *traceTransport) writeFile(id uint64, method string, path string, kind string, data []byte) {
+	if err := os.WriteFile(t.fileName(id, method, path, kind), data, 0o600); err != nil {
+		fmt.Fprintf(t.out, "HTTP [%d] failed to write %s trace file: %v\n", id, kind, err)
+	}
+}
+
+// traceStream is a streamed response body that is copied to a file as it is read.
+type traceStream struct {
+	io.ReadCloser
+	file *os.File
+}
+
+func (s *traceStream) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = s.file.Write(p[:n])
+	}
+
+	return n, err
+}
+
+func (s *traceStream) Close() error {
+	_ = s.file.Close()
+
+	return s.ReadCloser.Close()
+}
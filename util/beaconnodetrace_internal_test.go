@@ -0,0 +1,123 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"version":"test"}}`))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "trace")
+	out := &bytes.Buffer{}
+	transport, err := newTraceTransport(http.DefaultTransport, out, dir)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL + "/eth/v1/node/version")
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	resp, err = client.Post(server.URL+"/eth/v1/beacon/pool/voluntary_exits", "application/json", strings.NewReader(`{"message":{}}`))
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	resp, err = client.Get(server.URL + "/missing?id=1")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Regexp(t, regexp.MustCompile(`^HTTP \[1\] GET /eth/v1/node/version status=200 duration=\S+ bytes=27$`), lines[0])
+	require.Regexp(t, regexp.MustCompile(`^HTTP \[2\] POST /eth/v1/beacon/pool/voluntary_exits status=200 duration=\S+ bytes=14$`), lines[1])
+	require.Regexp(t, regexp.MustCompile(`^HTTP \[3\] GET /missing\?id=1 status=404 duration=\S+ bytes=0$`), lines[2])
+
+	data, err := os.ReadFile(filepath.Join(dir, "000001-GET_eth_v1_node_version.response"))
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{"version":"test"}}`, string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "000002-POST_eth_v1_beacon_pool_voluntary_exits.request"))
+	require.NoError(t, err)
+	require.Equal(t, `{"message":{}}`, string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "000003-GET_missing_id_1.response"))
+	require.NoError(t, err)
+	require.Empty(t, data)
+}
+
+func TestTraceTransportError(t *testing.T) {
+	out := &bytes.Buffer{}
+	transport, err := newTraceTransport(http.DefaultTransport, out, "")
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1/eth/v1/node/version", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.Error(t, err)
+	require.Regexp(t, regexp.MustCompile(`^HTTP \[1\] GET /eth/v1/node/version error="context canceled" duration=\S+\n$`), out.String())
+}
+
+func TestTraceTransportStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: head\ndata: {}\n\n"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+	transport, err := newTraceTransport(http.DefaultTransport, out, dir)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL + "/eth/v1/events?topics=head")
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^HTTP \[1\] GET /eth/v1/events\?topics=head status=200 duration=\S+ bytes=stream\n$`), out.String())
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, "000001-GET_eth_v1_events_topics_head.response"))
+	require.NoError(t, err)
+	require.Equal(t, body, data)
+}
@@ -0,0 +1,52 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// unixSocketPrefix is the prefix for connections to beacon nodes over unix sockets.
+const unixSocketPrefix = "unix://"
+
+// unixSocketAddress is the address used for requests to beacon nodes over unix
+// sockets.  The host is ignored by the transport, but is required for a valid URL.
+const unixSocketAddress = "http://localhost"
+
+// unixSocketTransport creates a transport that connects to the beacon node at
+// the given unix socket address.
+func unixSocketTransport(address string, timeout time.Duration) (*http.Transport, error) {
+	socketPath := strings.TrimPrefix(address, unixSocketPrefix)
+	if socketPath == "" {
+		return nil, errors.New("no unix socket path supplied")
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+
+	return &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+		MaxIdleConns:    64,
+		IdleConnTimeout: 600 * time.Second,
+	}, nil
+}
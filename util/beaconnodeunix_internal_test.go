@@ -0,0 +1,119 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSocketTransport(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "beacon.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.URL.Path))
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	_, err = unixSocketTransport("unix://", time.Second)
+	require.EqualError(t, err, "no unix socket path supplied")
+
+	transport, err := unixSocketTransport("unix://"+socketPath, time.Second)
+	require.NoError(t, err)
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	resp, err := client.Get(unixSocketAddress + "/eth/v1/node/version")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "/eth/v1/node/version", string(body))
+}
+
+func TestUnixSocketEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	socketPath := filepath.Join(t.TempDir(), "beacon.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/eth/v1/events" || r.URL.Query().Get("topics") != "head" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: head\ndata: {\"slot\":\"12\",\"block\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"state\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"epoch_transition\":false,\"previous_duty_dependent_root\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"current_duty_dependent_root\":\"0x0000000000000000000000000000000000000000000000000000000000000000\",\"execution_optimistic\":false}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	transport, err := unixSocketTransport("unix://"+socketPath, time.Second)
+	require.NoError(t, err)
+	service := &eventsService{
+		address: unixSocketAddress,
+		client: &http.Client{
+			Transport: transport,
+		},
+	}
+
+	require.EqualError(t, service.Events(ctx, &api.EventsOpts{Topics: []string{"unknown"}}), "unsupported event topic unknown")
+
+	events := make(chan *apiv1.Event, 1)
+	require.NoError(t, service.Events(ctx, &api.EventsOpts{
+		Topics: []string{"head"},
+		Handler: func(event *apiv1.Event) {
+			select {
+			case events <- event:
+			default:
+			}
+		},
+	}))
+
+	select {
+	case event := <-events:
+		require.Equal(t, "head", event.Topic)
+		headEvent, isHeadEvent := event.Data.(*apiv1.HeadEvent)
+		require.True(t, isHeadEvent)
+		require.Equal(t, uint64(12), uint64(headEvent.Slot))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no event received")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// BeaconRootsAddress is the address of the EIP-4788 beacon roots contract.
+var BeaconRootsAddress = bellatrix.ExecutionAddress{
+	0x00, 0x0f, 0x3d, 0xf6, 0xd7, 0x32, 0x80, 0x7e, 0xf1, 0x31,
+	0x9f, 0xb7, 0xb8, 0xbb, 0x85, 0x22, 0xd0, 0xbe, 0xac, 0x02,
+}
+
+// BeaconRootsHistoryBufferLength is the number of roots held by the beacon
+// roots contract; older roots are overwritten.
+const BeaconRootsHistoryBufferLength = 8191
+
+// BeaconRootsCallData returns the call data to obtain the root for the
+// execution block with the given timestamp.
+func BeaconRootsCallData(timestamp uint64) []byte {
+	data := make([]byte, 32)
+	binary.BigEndian.PutUint64(data[24:], timestamp)
+
+	return data
+}
+
+// BeaconRootAtTimestamp obtains the parent beacon block root of the execution
+// block with the given timestamp from the beacon roots contract.
+func BeaconRootAtTimestamp(ctx context.Context,
+	client execution.Service,
+	timestamp uint64,
+) (
+	phase0.Root,
+	error,
+) {
+	// The contract reverts if it does not hold a root for the timestamp.
+	var res string
+	found, err := client.Call(ctx, "eth_call", []interface{}{
+		map[string]string{
+			"to":   BeaconRootsAddress.String(),
+			"data": fmt.Sprintf("%#x", BeaconRootsCallData(timestamp)),
+		},
+		"latest",
+	}, &res)
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, fmt.Sprintf("no beacon root for timestamp %d", timestamp))
+	}
+	if !found {
+		return phase0.Root{}, fmt.Errorf("eth_call returned no result")
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(res, "0x"))
+	if err != nil || len(data) != len(phase0.Root{}) {
+		return phase0.Root{}, fmt.Errorf("invalid beacon root %q", res)
+	}
+
+	return phase0.Root(data), nil
+}
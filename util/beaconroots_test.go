@@ -0,0 +1,89 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestBeaconRootsCallData(t *testing.T) {
+	data := util.BeaconRootsCallData(0x65a1b2c3)
+	require.Len(t, data, 32)
+	require.Equal(t, make([]byte, 28), data[:28])
+	require.Equal(t, []byte{0x65, 0xa1, 0xb2, 0xc3}, data[28:])
+}
+
+func TestBeaconRootAtTimestamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		root     string
+		err      string
+	}{
+		{
+			name:     "Good",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"}`,
+			root:     "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+		},
+		{
+			name:     "Reverted",
+			response: `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted"}}`,
+			err:      "no beacon root for timestamp 1700000000: eth_call failed: execution reverted (3)",
+		},
+		{
+			name:     "Short",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0x01"}`,
+			err:      `invalid beacon root "0x01"`,
+		},
+		{
+			name:     "Null",
+			response: `{"jsonrpc":"2.0","id":1,"result":null}`,
+			err:      "eth_call returned no result",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				req := &struct {
+					Params []map[string]string `json:"params"`
+				}{}
+				// The second parameter is a block tag, so only check the first.
+				_ = json.Unmarshal(body, req)
+				require.Equal(t, "0x000F3df6D732807Ef1319fB7B8bB8522d0Beac02", req.Params[0]["to"])
+				require.Equal(t, "0x000000000000000000000000000000000000000000000000000000006553f100", req.Params[0]["data"])
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer server.Close()
+
+			root, err := util.BeaconRootAtTimestamp(context.Background(), executionClient(t, server.URL), 1700000000)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.root, root.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// KZGCommitmentInclusionProofDepth is the depth of the proof of a blob KZG commitment in a block body.
+	KZGCommitmentInclusionProofDepth = 17
+	// blobKZGCommitmentsSubtreeIndex is the index of the blob KZG commitments data within the
+	// block body subtree; the body has 16 leaves, commitments are field 11, and the list data
+	// root sits beneath its length mix-in with room for 4096 commitments.
+	blobKZGCommitmentsSubtreeIndex = 11 << 13
+)
+
+// VerifyKZGCommitmentInclusionProof verifies that a blob KZG commitment with the given index is
+// included in the block body with the given root.
+func VerifyKZGCommitmentInclusionProof(commitment deneb.KZGCommitment,
+	index deneb.BlobIndex,
+	proof []phase0.Root,
+	bodyRoot phase0.Root,
+) bool {
+	if len(proof) != KZGCommitmentInclusionProofDepth {
+		return false
+	}
+	if index >= 1<<12 {
+		return false
+	}
+
+	// The leaf is the hash tree root of the commitment, which spans two chunks.
+	chunks := make([]byte, 64)
+	copy(chunks, commitment[:])
+	value := sha256.Sum256(chunks)
+
+	subtreeIndex := uint64(blobKZGCommitmentsSubtreeIndex) | uint64(index)
+	for i := range proof {
+		if (subtreeIndex>>i)&1 == 1 {
+			value = sha256.Sum256(append(proof[i][:], value[:]...))
+		} else {
+			value = sha256.Sum256(append(value[:], proof[i][:]...))
+		}
+	}
+
+	return value == bodyRoot
+}
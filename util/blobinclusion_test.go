@@ -0,0 +1,138 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testBlockBody returns a block body with the given number of blob commitments,
+// along with the inclusion proofs for the commitments.
+func testBlockBody(t *testing.T, commitments int) (*deneb.BeaconBlockBody, [][]phase0.Root) {
+	t.Helper()
+
+	body := &deneb.BeaconBlockBody{
+		ETH1Data: &phase0.ETH1Data{
+			BlockHash: make([]byte, 32),
+		},
+		SyncAggregate: &altair.SyncAggregate{
+			SyncCommitteeBits: bitfield.NewBitvector512(),
+		},
+		ExecutionPayload: &deneb.ExecutionPayload{
+			BaseFeePerGas: uint256.NewInt(7),
+		},
+	}
+	for i := 0; i < commitments; i++ {
+		commitment := deneb.KZGCommitment{}
+		commitment[0] = 0xc0
+		commitment[47] = byte(i + 1)
+		body.BlobKZGCommitments = append(body.BlobKZGCommitments, commitment)
+	}
+
+	tree, err := body.GetTree()
+	require.NoError(t, err)
+	proofs := make([][]phase0.Root, commitments)
+	for i := 0; i < commitments; i++ {
+		// Generalized index of the commitment: field 11 of 16, list data, 4096 entries.
+		proof, err := tree.Prove((16+11)*2*4096 + i)
+		require.NoError(t, err)
+		for _, hash := range proof.Hashes {
+			proofs[i] = append(proofs[i], phase0.Root(hash))
+		}
+	}
+
+	return body, proofs
+}
+
+func TestVerifyKZGCommitmentInclusionProof(t *testing.T) {
+	body, proofs := testBlockBody(t, 3)
+	bodyRoot, err := body.HashTreeRoot()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		commitment deneb.KZGCommitment
+		index      deneb.BlobIndex
+		proof      []phase0.Root
+		bodyRoot   phase0.Root
+		res        bool
+	}{
+		{
+			name:       "First",
+			commitment: body.BlobKZGCommitments[0],
+			index:      0,
+			proof:      proofs[0],
+			bodyRoot:   bodyRoot,
+			res:        true,
+		},
+		{
+			name:       "Last",
+			commitment: body.BlobKZGCommitments[2],
+			index:      2,
+			proof:      proofs[2],
+			bodyRoot:   bodyRoot,
+			res:        true,
+		},
+		{
+			name:       "WrongIndex",
+			commitment: body.BlobKZGCommitments[2],
+			index:      1,
+			proof:      proofs[2],
+			bodyRoot:   bodyRoot,
+		},
+		{
+			name:       "WrongCommitment",
+			commitment: body.BlobKZGCommitments[1],
+			index:      2,
+			proof:      proofs[2],
+			bodyRoot:   bodyRoot,
+		},
+		{
+			name:       "WrongBodyRoot",
+			commitment: body.BlobKZGCommitments[0],
+			index:      0,
+			proof:      proofs[0],
+			bodyRoot:   phase0.Root{0x01},
+		},
+		{
+			name:       "ProofShort",
+			commitment: body.BlobKZGCommitments[0],
+			index:      0,
+			proof:      proofs[0][:16],
+			bodyRoot:   bodyRoot,
+		},
+		{
+			name:       "IndexTooHigh",
+			commitment: body.BlobKZGCommitments[0],
+			index:      4096,
+			proof:      proofs[0],
+			bodyRoot:   bodyRoot,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := util.VerifyKZGCommitmentInclusionProof(test.commitment, test.index, test.proof, test.bodyRoot)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
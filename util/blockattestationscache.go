@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BlockAttestationsCache is a cache of the attestations in blocks, allowing
+// commands that scan a range of blocks for multiple duties to fetch each block
+// once.
+type BlockAttestationsCache struct {
+	eth2Client eth2client.Service
+	entries    map[phase0.Slot]*blockAttestationsEntry
+}
+
+// NewBlockAttestationsCache makes a new block attestations cache.
+func NewBlockAttestationsCache(eth2Client eth2client.Service) *BlockAttestationsCache {
+	return &BlockAttestationsCache{
+		eth2Client: eth2Client,
+		entries:    make(map[phase0.Slot]*blockAttestationsEntry),
+	}
+}
+
+type blockAttestationsEntry struct {
+	present bool
+	value   []*BlockAttestation
+}
+
+// Fetch the attestations in the block at the given slot.
+// It returns false if there is no block at the slot.
+func (b *BlockAttestationsCache) Fetch(ctx context.Context,
+	slot phase0.Slot,
+) (
+	[]*BlockAttestation,
+	bool,
+	error,
+) {
+	entry, exists := b.entries[slot]
+	if !exists {
+		blockSlot, attestations, found, err := BlockAttestations(ctx, b.eth2Client, fmt.Sprintf("%d", slot))
+		if err != nil {
+			return nil, false, err
+		}
+		if !found || blockSlot != slot {
+			// Empty slot.
+			entry = &blockAttestationsEntry{
+				present: false,
+			}
+		} else {
+			entry = &blockAttestationsEntry{
+				present: true,
+				value:   attestations,
+			}
+		}
+		b.entries[slot] = entry
+	}
+
+	return entry.value, entry.present, nil
+}
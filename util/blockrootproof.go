@@ -0,0 +1,149 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Sources of block root proofs.
+const (
+	// BlockRootProofSourceBlockRoots proves a root in a state's block_roots vector.
+	BlockRootProofSourceBlockRoots = "block_roots"
+	// BlockRootProofSourceHistoricalSummaries proves a root through a state's historical_summaries list.
+	BlockRootProofSourceHistoricalSummaries = "historical_summaries"
+)
+
+// Anchors of block root proofs.
+const (
+	// BlockRootProofAnchorState is a proof against a state root.
+	BlockRootProofAnchorState = "state"
+	// BlockRootProofAnchorBlock is a proof against the root of the block that produced the state.
+	BlockRootProofAnchorBlock = "block"
+)
+
+// BlockRootProof is a merkle proof that a block root is the root of the block
+// at a historical slot.
+type BlockRootProof struct {
+	Slot             phase0.Slot
+	BlockRoot        phase0.Root
+	StateSlot        phase0.Slot
+	Source           string
+	Anchor           string
+	Root             phase0.Root
+	GeneralizedIndex uint64
+	Branch           []phase0.Root
+}
+
+type blockRootProofJSON struct {
+	Slot             string   `json:"slot"`
+	BlockRoot        string   `json:"block_root"`
+	StateSlot        string   `json:"state_slot"`
+	Source           string   `json:"source"`
+	Anchor           string   `json:"anchor"`
+	Root             string   `json:"root"`
+	GeneralizedIndex string   `json:"generalized_index"`
+	Branch           []string `json:"branch"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *BlockRootProof) MarshalJSON() ([]byte, error) {
+	branch := make([]string, len(p.Branch))
+	for i := range p.Branch {
+		branch[i] = fmt.Sprintf("%#x", p.Branch[i])
+	}
+
+	return json.Marshal(&blockRootProofJSON{
+		Slot:             fmt.Sprintf("%d", p.Slot),
+		BlockRoot:        fmt.Sprintf("%#x", p.BlockRoot),
+		StateSlot:        fmt.Sprintf("%d", p.StateSlot),
+		Source:           p.Source,
+		Anchor:           p.Anchor,
+		Root:             fmt.Sprintf("%#x", p.Root),
+		GeneralizedIndex: fmt.Sprintf("%d", p.GeneralizedIndex),
+		Branch:           branch,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *BlockRootProof) UnmarshalJSON(input []byte) error {
+	data := &blockRootProofJSON{}
+	if err := json.Unmarshal(input, data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	slot, err := strconv.ParseUint(data.Slot, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid slot")
+	}
+	p.Slot = phase0.Slot(slot)
+	if p.BlockRoot, err = parseRoot(data.BlockRoot); err != nil {
+		return errors.Wrap(err, "invalid block root")
+	}
+	stateSlot, err := strconv.ParseUint(data.StateSlot, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid state slot")
+	}
+	p.StateSlot = phase0.Slot(stateSlot)
+	switch data.Source {
+	case BlockRootProofSourceBlockRoots, BlockRootProofSourceHistoricalSummaries:
+		p.Source = data.Source
+	default:
+		return fmt.Errorf("invalid source %q", data.Source)
+	}
+	switch data.Anchor {
+	case BlockRootProofAnchorState, BlockRootProofAnchorBlock:
+		p.Anchor = data.Anchor
+	default:
+		return fmt.Errorf("invalid anchor %q", data.Anchor)
+	}
+	if p.Root, err = parseRoot(data.Root); err != nil {
+		return errors.Wrap(err, "invalid root")
+	}
+	if p.GeneralizedIndex, err = strconv.ParseUint(data.GeneralizedIndex, 10, 64); err != nil {
+		return errors.Wrap(err, "invalid generalized index")
+	}
+	p.Branch = make([]phase0.Root, len(data.Branch))
+	for i := range data.Branch {
+		if p.Branch[i], err = parseRoot(data.Branch[i]); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("invalid branch root %d", i))
+		}
+	}
+
+	return nil
+}
+
+// Verify returns true if the proof's branch links its block root to its root.
+func (p *BlockRootProof) Verify() bool {
+	return VerifyMerkleProof(p.BlockRoot, p.Branch, p.GeneralizedIndex, p.Root)
+}
+
+func parseRoot(input string) (phase0.Root, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	if len(data) != len(phase0.Root{}) {
+		return phase0.Root{}, errors.New("root must be 32 bytes")
+	}
+
+	return phase0.Root(data), nil
+}
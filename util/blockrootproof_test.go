@@ -0,0 +1,75 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestBlockRootProofJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "Good",
+			input: `{"slot":"5","block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_slot":"100","source":"block_roots","anchor":"state","root":"0x0202020202020202020202020202020202020202020202020202020202020202","generalized_index":"8197","branch":["0x0303030303030303030303030303030303030303030303030303030303030303"]}`,
+		},
+		{
+			name:  "SlotInvalid",
+			input: `{"slot":"x"}`,
+			err:   `invalid slot: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			name:  "BlockRootShort",
+			input: `{"slot":"5","block_root":"0x01"}`,
+			err:   "invalid block root: root must be 32 bytes",
+		},
+		{
+			name:  "SourceInvalid",
+			input: `{"slot":"5","block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_slot":"100","source":"state_roots"}`,
+			err:   `invalid source "state_roots"`,
+		},
+		{
+			name:  "AnchorInvalid",
+			input: `{"slot":"5","block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_slot":"100","source":"block_roots","anchor":"execution"}`,
+			err:   `invalid anchor "execution"`,
+		},
+		{
+			name:  "BranchInvalid",
+			input: `{"slot":"5","block_root":"0x0101010101010101010101010101010101010101010101010101010101010101","state_slot":"100","source":"block_roots","anchor":"state","root":"0x0202020202020202020202020202020202020202020202020202020202020202","generalized_index":"8197","branch":["0x03"]}`,
+			err:   "invalid branch root 0: root must be 32 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof := &util.BlockRootProof{}
+			err := json.Unmarshal([]byte(test.input), proof)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			output, err := json.Marshal(proof)
+			require.NoError(t, err)
+			require.Equal(t, test.input, string(output))
+		})
+	}
+}
@@ -15,8 +15,10 @@ package util
 
 import (
 	"encoding/binary"
+	"sort"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 )
 
 // BLSID turns a uint64 in to a BLS identifier.
@@ -29,3 +31,34 @@ func BLSID(id uint64) *bls.ID {
 	}
 	return &res
 }
+
+// RecoverPublicKey recovers the composite public key of a threshold key from
+// the public key shares of its participants, keyed by participant ID.  At
+// least the signing threshold number of shares must be supplied.
+func RecoverPublicKey(shares map[uint64][]byte) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no public key shares supplied")
+	}
+
+	participantIDs := make([]uint64, 0, len(shares))
+	for id := range shares {
+		participantIDs = append(participantIDs, id)
+	}
+	sort.Slice(participantIDs, func(i, j int) bool { return participantIDs[i] < participantIDs[j] })
+
+	ids := make([]bls.ID, len(participantIDs))
+	pubKeys := make([]bls.PublicKey, len(participantIDs))
+	for i, id := range participantIDs {
+		ids[i] = *BLSID(id)
+		if err := pubKeys[i].Deserialize(shares[id]); err != nil {
+			return nil, errors.Wrapf(err, "invalid public key share for participant %d", id)
+		}
+	}
+
+	var compositePubKey bls.PublicKey
+	if err := compositePubKey.Recover(pubKeys, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to recover composite public key")
+	}
+
+	return compositePubKey.Serialize(), nil
+}
@@ -16,6 +16,7 @@ package util_test
 import (
 	"testing"
 
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -53,3 +54,58 @@ func TestBLSID(t *testing.T) {
 		})
 	}
 }
+
+func TestRecoverPublicKey(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	// Generate a 2-of-3 threshold key.
+	var masterKey bls.SecretKey
+	masterKey.SetByCSPRNG()
+	masterKeys := masterKey.GetMasterSecretKey(2)
+	compositePubKey := masterKey.GetPublicKey().Serialize()
+	shares := make(map[uint64][]byte)
+	for id := uint64(1); id <= 3; id++ {
+		var share bls.SecretKey
+		require.NoError(t, share.Set(masterKeys, util.BLSID(id)))
+		shares[id] = share.GetPublicKey().Serialize()
+	}
+
+	tests := []struct {
+		name   string
+		shares map[uint64][]byte
+		res    []byte
+		err    string
+	}{
+		{
+			name: "Empty",
+			err:  "no public key shares supplied",
+		},
+		{
+			name:   "InvalidShare",
+			shares: map[uint64][]byte{1: shares[1], 2: {0x01}},
+			err:    "invalid public key share for participant 2: err blsPublicKeyDeserialize 01",
+		},
+		{
+			name:   "Threshold",
+			shares: map[uint64][]byte{1: shares[1], 3: shares[3]},
+			res:    compositePubKey,
+		},
+		{
+			name:   "All",
+			shares: shares,
+			res:    compositePubKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.RecoverPublicKey(test.shares)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,565 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"golang.org/x/term"
+)
+
+const (
+	// CompositeOperationExit is the operation to exit a validator.
+	CompositeOperationExit = "exit"
+	// CompositeOperationKey is the operation to export the private key of an account.
+	CompositeOperationKey = "key"
+)
+
+// compositeApprovalDomain separates composite approval signatures from other signatures.
+var compositeApprovalDomain = []byte("ethdo composite approval")
+
+// Composite is a group of accounts for which a number of approvals are
+// required before destructive operations are carried out.
+type Composite struct {
+	Name       string
+	Threshold  int      `mapstructure:"threshold"`
+	Accounts   []string `mapstructure:"accounts"`
+	Approvers  []string `mapstructure:"approvers"`
+	Operations []string `mapstructure:"operations"`
+}
+
+// CompositeApproval is a signed approval for an operation on an account.
+type CompositeApproval struct {
+	Composite string
+	Operation string
+	Account   []byte
+	Approver  []byte
+	Expiry    time.Time
+	Signature []byte
+}
+
+type compositeApprovalJSON struct {
+	Composite string `json:"composite"`
+	Operation string `json:"operation"`
+	Account   string `json:"account"`
+	Approver  string `json:"approver"`
+	Expiry    string `json:"expiry"`
+	Signature string `json:"signature"`
+}
+
+// MarshalJSON implements custom JSON marshaller.
+func (a *CompositeApproval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&compositeApprovalJSON{
+		Composite: a.Composite,
+		Operation: a.Operation,
+		Account:   fmt.Sprintf("%#x", a.Account),
+		Approver:  fmt.Sprintf("%#x", a.Approver),
+		Expiry:    a.Expiry.UTC().Format(time.RFC3339),
+		Signature: fmt.Sprintf("%#x", a.Signature),
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaller.
+func (a *CompositeApproval) UnmarshalJSON(data []byte) error {
+	approvalJSON := &compositeApprovalJSON{}
+	if err := json.Unmarshal(data, approvalJSON); err != nil {
+		return errors.Wrap(err, "failed to unmarshal JSON")
+	}
+
+	if approvalJSON.Composite == "" {
+		return errors.New("composite missing")
+	}
+	a.Composite = approvalJSON.Composite
+
+	if approvalJSON.Operation == "" {
+		return errors.New("operation missing")
+	}
+	a.Operation = approvalJSON.Operation
+
+	var err error
+	if approvalJSON.Account == "" {
+		return errors.New("account missing")
+	}
+	a.Account, err = hex.DecodeString(strings.TrimPrefix(approvalJSON.Account, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "account invalid")
+	}
+
+	if approvalJSON.Approver == "" {
+		return errors.New("approver missing")
+	}
+	a.Approver, err = hex.DecodeString(strings.TrimPrefix(approvalJSON.Approver, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "approver invalid")
+	}
+
+	if approvalJSON.Expiry == "" {
+		return errors.New("expiry missing")
+	}
+	a.Expiry, err = time.Parse(time.RFC3339, approvalJSON.Expiry)
+	if err != nil {
+		return errors.Wrap(err, "expiry invalid")
+	}
+
+	if approvalJSON.Signature == "" {
+		return errors.New("signature missing")
+	}
+	a.Signature, err = hex.DecodeString(strings.TrimPrefix(approvalJSON.Signature, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "signature invalid")
+	}
+
+	return nil
+}
+
+// SigningRoot returns the data that is signed by the approver.
+func (a *CompositeApproval) SigningRoot() []byte {
+	hash := sha256.New()
+	for _, field := range [][]byte{
+		compositeApprovalDomain,
+		[]byte(a.Composite),
+		[]byte(a.Operation),
+		a.Account,
+		a.Approver,
+	} {
+		// Length-prefix each field so that boundaries cannot be shifted.
+		length := make([]byte, 8)
+		binary.LittleEndian.PutUint64(length, uint64(len(field)))
+		hash.Write(length)
+		hash.Write(field)
+	}
+	expiry := make([]byte, 8)
+	binary.LittleEndian.PutUint64(expiry, uint64(a.Expiry.Unix()))
+	hash.Write(expiry)
+
+	return hash.Sum(nil)
+}
+
+// Verify verifies the signature of the approval.
+func (a *CompositeApproval) Verify() error {
+	pubKey, err := e2types.BLSPublicKeyFromBytes(a.Approver)
+	if err != nil {
+		return errors.Wrap(err, "invalid approver public key")
+	}
+	sig, err := e2types.BLSSignatureFromBytes(a.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(a.SigningRoot(), pubKey) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// SignCompositeApproval creates an approval for the given operation on the
+// given account, signed by the supplied unlocked approver account.
+func SignCompositeApproval(ctx context.Context,
+	approver e2wtypes.Account,
+	composite string,
+	operation string,
+	account []byte,
+	expiry time.Time,
+) (
+	*CompositeApproval,
+	error,
+) {
+	signer, isSigner := approver.(e2wtypes.AccountSigner)
+	if !isSigner {
+		return nil, errors.New("approver account does not support signing")
+	}
+	approverPubKey, err := BestPublicKey(approver)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain approver public key")
+	}
+
+	approval := &CompositeApproval{
+		Composite: composite,
+		Operation: operation,
+		Account:   account,
+		Approver:  approverPubKey.Marshal(),
+		Expiry:    time.Unix(expiry.Unix(), 0).UTC(),
+	}
+	sig, err := signer.Sign(ctx, approval.SigningRoot())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign approval")
+	}
+	approval.Signature = sig.Marshal()
+
+	return approval, nil
+}
+
+// Composites returns the composites defined in configuration.
+func Composites() (map[string]*Composite, error) {
+	composites := make(map[string]*Composite)
+	if !viper.IsSet("composites") {
+		return composites, nil
+	}
+	if err := viper.UnmarshalKey("composites", &composites); err != nil {
+		return nil, errors.Wrap(err, "failed to parse composites")
+	}
+
+	for name, composite := range composites {
+		if composite == nil {
+			return nil, fmt.Errorf("composite %q has no definition", name)
+		}
+		composite.Name = name
+		if len(composite.Accounts) == 0 {
+			return nil, fmt.Errorf("composite %q has no accounts", name)
+		}
+		if len(composite.Approvers) == 0 {
+			return nil, fmt.Errorf("composite %q has no approvers", name)
+		}
+		if composite.Threshold < 1 {
+			return nil, fmt.Errorf("composite %q threshold must be at least 1", name)
+		}
+		if composite.Threshold > len(composite.Approvers) {
+			return nil, fmt.Errorf("composite %q threshold of %d is more than its %d approvers", name, composite.Threshold, len(composite.Approvers))
+		}
+		if len(composite.Operations) == 0 {
+			composite.Operations = []string{CompositeOperationExit, CompositeOperationKey}
+		}
+		for i := range composite.Operations {
+			composite.Operations[i] = strings.ToLower(composite.Operations[i])
+			switch composite.Operations[i] {
+			case CompositeOperationExit, CompositeOperationKey:
+			default:
+				return nil, fmt.Errorf("composite %q has unknown operation %q", name, composite.Operations[i])
+			}
+		}
+	}
+
+	return composites, nil
+}
+
+// Covers returns true if the composite gates the given operation on the account.
+// The account is matched either by its "wallet/account" name, which can contain
+// wildcards, or by its public key.
+func (c *Composite) Covers(operation string, accountName string, pubKey []byte) bool {
+	covered := false
+	for _, op := range c.Operations {
+		if op == operation {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return false
+	}
+
+	for _, member := range c.Accounts {
+		if strings.HasPrefix(member, "0x") {
+			memberPubKey, err := hex.DecodeString(strings.TrimPrefix(member, "0x"))
+			if err == nil && bytes.Equal(memberPubKey, pubKey) {
+				return true
+			}
+			continue
+		}
+		if accountName == "" {
+			continue
+		}
+		if matched, err := path.Match(member, accountName); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+type compositeApprover struct {
+	name    string
+	account e2wtypes.Account
+	pubKey  []byte
+}
+
+// approvers resolves the approvers of the composite to their public keys.
+func (c *Composite) approvers(ctx context.Context) ([]*compositeApprover, error) {
+	approvers := make([]*compositeApprover, 0, len(c.Approvers))
+	for _, name := range c.Approvers {
+		approver := &compositeApprover{
+			name: name,
+		}
+		if strings.HasPrefix(name, "0x") {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(name, "0x"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid public key for approver %s", name)
+			}
+			if _, err := e2types.BLSPublicKeyFromBytes(pubKey); err != nil {
+				return nil, errors.Wrapf(err, "invalid public key for approver %s", name)
+			}
+			approver.pubKey = pubKey
+		} else {
+			_, account, err := WalletAndAccountFromPath(ctx, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to obtain approver %s", name)
+			}
+			pubKey, err := BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to obtain public key for approver %s", name)
+			}
+			approver.account = account
+			approver.pubKey = pubKey.Marshal()
+		}
+		approvers = append(approvers, approver)
+	}
+
+	return approvers, nil
+}
+
+// IsApprover returns true if the public key is that of an approver of the composite.
+func (c *Composite) IsApprover(ctx context.Context, pubKey []byte) (bool, error) {
+	approvers, err := c.approvers(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, approver := range approvers {
+		if bytes.Equal(approver.pubKey, pubKey) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ParseCompositeApprovals parses approvals, each of which is either JSON or
+// the name of a file containing JSON.  The JSON can be a single approval or
+// an array of approvals.
+func ParseCompositeApprovals(inputs []string) ([]*CompositeApproval, error) {
+	approvals := make([]*CompositeApproval, 0, len(inputs))
+	for _, input := range inputs {
+		data := []byte(strings.TrimSpace(input))
+		if !bytes.HasPrefix(data, []byte("{")) && !bytes.HasPrefix(data, []byte("[")) {
+			var err error
+			data, err = os.ReadFile(input)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read approvals file")
+			}
+			data = bytes.TrimSpace(data)
+		}
+		if bytes.HasPrefix(data, []byte("[")) {
+			fileApprovals := make([]*CompositeApproval, 0)
+			if err := json.Unmarshal(data, &fileApprovals); err != nil {
+				return nil, errors.Wrap(err, "failed to parse approvals")
+			}
+			approvals = append(approvals, fileApprovals...)
+		} else {
+			approval := &CompositeApproval{}
+			if err := json.Unmarshal(data, approval); err != nil {
+				return nil, errors.Wrap(err, "failed to parse approval")
+			}
+			approvals = append(approvals, approval)
+		}
+	}
+
+	return approvals, nil
+}
+
+// compositePassphrase obtains an approver passphrase interactively; it returns
+// false if interactive approval is not possible.
+var compositePassphrase = func(prompt string) (string, bool, error) {
+	if viper.GetBool("quiet") || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", false, nil
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to read passphrase")
+	}
+
+	return string(passphrase), true, nil
+}
+
+// CheckCompositeApprovals ensures that an operation on an account has been
+// approved by sufficient approvers of every composite that covers it.
+// Approvals are taken from the supplied approvals; if more are required and
+// the terminal is interactive, local approvers are prompted for their
+// passphrases.
+func CheckCompositeApprovals(ctx context.Context,
+	operation string,
+	accountName string,
+	pubKey []byte,
+	approvalInputs []string,
+) error {
+	composites, err := Composites()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(composites))
+	for name, composite := range composites {
+		if composite.Covers(operation, accountName, pubKey) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	approvals, err := ParseCompositeApprovals(approvalInputs)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := checkCompositeApprovals(ctx, composites[name], operation, accountName, pubKey, approvals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckWalletCompositeApprovals ensures that an operation on each account in
+// a wallet has been approved, as for CheckCompositeApprovals.  If selected is
+// supplied then only accounts whose names it selects are checked.
+func CheckWalletCompositeApprovals(ctx context.Context,
+	operation string,
+	wallet e2wtypes.Wallet,
+	selected func(name string) bool,
+	approvalInputs []string,
+) error {
+	composites, err := Composites()
+	if err != nil {
+		return err
+	}
+	if len(composites) == 0 {
+		// Avoid iterating over the accounts when there is nothing to check.
+		return nil
+	}
+
+	for account := range wallet.Accounts(ctx) {
+		if selected != nil && !selected(account.Name()) {
+			continue
+		}
+		pubKey, err := BestPublicKey(account)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		accountName := fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+		if err := CheckCompositeApprovals(ctx, operation, accountName, pubKey.Marshal(), approvalInputs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkCompositeApprovals(ctx context.Context,
+	composite *Composite,
+	operation string,
+	accountName string,
+	pubKey []byte,
+	approvals []*CompositeApproval,
+) error {
+	approvers, err := composite.approvers(ctx)
+	if err != nil {
+		return err
+	}
+
+	approved := make(map[string]bool)
+	now := time.Now()
+	for _, approval := range approvals {
+		if approval.Composite != composite.Name ||
+			approval.Operation != operation ||
+			!bytes.Equal(approval.Account, pubKey) {
+			continue
+		}
+		if approval.Expiry.Before(now) {
+			Log.Debug().Str("composite", composite.Name).Str("approver", fmt.Sprintf("%#x", approval.Approver)).Msg("Approval has expired")
+			continue
+		}
+		for _, approver := range approvers {
+			if !bytes.Equal(approver.pubKey, approval.Approver) {
+				continue
+			}
+			if err := approval.Verify(); err != nil {
+				Log.Debug().Str("composite", composite.Name).Str("approver", approver.name).Err(err).Msg("Approval is invalid")
+				continue
+			}
+			approved[approver.name] = true
+		}
+	}
+
+	description := fmt.Sprintf("%#x", pubKey)
+	if accountName != "" {
+		description = accountName
+	}
+
+	for _, approver := range approvers {
+		if len(approved) >= composite.Threshold {
+			break
+		}
+		if approved[approver.name] || approver.account == nil {
+			continue
+		}
+		prompt := fmt.Sprintf("%s of %s requires approval from composite %q (%d of %d obtained).\nPassphrase for approver %s (blank to skip): ",
+			operation, description, composite.Name, len(approved), composite.Threshold, approver.name)
+		passphrase, interactive, err := compositePassphrase(prompt)
+		if err != nil {
+			return err
+		}
+		if !interactive {
+			break
+		}
+		if passphrase == "" {
+			continue
+		}
+		if !unlockCompositeApprover(ctx, approver.account, passphrase) {
+			fmt.Fprintf(os.Stderr, "Failed to unlock approver %s\n", approver.name)
+			continue
+		}
+		approved[approver.name] = true
+	}
+
+	if len(approved) < composite.Threshold {
+		return fmt.Errorf("%s of %s requires %d approvals from composite %q but %d obtained", operation, description, composite.Threshold, composite.Name, len(approved))
+	}
+
+	return nil
+}
+
+// unlockCompositeApprover confirms that the passphrase unlocks the approver's account.
+func unlockCompositeApprover(ctx context.Context, account e2wtypes.Account, passphrase string) bool {
+	locker, isLocker := account.(e2wtypes.AccountLocker)
+	if !isLocker {
+		return false
+	}
+	if err := CheckNotWatchOnly(account); err != nil {
+		return false
+	}
+	if err := locker.Unlock(ctx, []byte(passphrase)); err != nil {
+		return false
+	}
+	if err := locker.Lock(ctx); err != nil {
+		Log.Trace().Err(err).Msg("Failed to lock approver account")
+	}
+
+	return true
+}
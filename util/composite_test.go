@@ -0,0 +1,348 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func compositeTestAccount(t *testing.T, key string) (e2wtypes.Account, string) {
+	t.Helper()
+	account, err := util.ParseAccount(context.Background(), key, nil, true)
+	require.NoError(t, err)
+	pubKey, err := util.BestPublicKey(account)
+	require.NoError(t, err)
+
+	return account, fmt.Sprintf("%#x", pubKey.Marshal())
+}
+
+func TestComposites(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	_, approver := compositeTestAccount(t, "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+
+	tests := []struct {
+		name       string
+		composites map[string]interface{}
+		operations []string
+		err        string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name: "AccountsMissing",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold": 1,
+					"approvers": []string{approver},
+				},
+			},
+			err: `composite "ops" has no accounts`,
+		},
+		{
+			name: "ApproversMissing",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold": 1,
+					"accounts":  []string{"Validators/*"},
+				},
+			},
+			err: `composite "ops" has no approvers`,
+		},
+		{
+			name: "ThresholdZero",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"accounts":  []string{"Validators/*"},
+					"approvers": []string{approver},
+				},
+			},
+			err: `composite "ops" threshold must be at least 1`,
+		},
+		{
+			name: "ThresholdTooHigh",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold": 2,
+					"accounts":  []string{"Validators/*"},
+					"approvers": []string{approver},
+				},
+			},
+			err: `composite "ops" threshold of 2 is more than its 1 approvers`,
+		},
+		{
+			name: "OperationUnknown",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold":  1,
+					"accounts":   []string{"Validators/*"},
+					"approvers":  []string{approver},
+					"operations": []string{"delete"},
+				},
+			},
+			err: `composite "ops" has unknown operation "delete"`,
+		},
+		{
+			name: "DefaultOperations",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold": 1,
+					"accounts":  []string{"Validators/*"},
+					"approvers": []string{approver},
+				},
+			},
+			operations: []string{"exit", "key"},
+		},
+		{
+			name: "Operations",
+			composites: map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold":  1,
+					"accounts":   []string{"Validators/*"},
+					"approvers":  []string{approver},
+					"operations": []string{"Exit"},
+				},
+			},
+			operations: []string{"exit"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			if test.composites != nil {
+				viper.Set("composites", test.composites)
+			}
+			composites, err := util.Composites()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				if test.operations != nil {
+					require.Equal(t, "ops", composites["ops"].Name)
+					require.Equal(t, test.operations, composites["ops"].Operations)
+				}
+			}
+		})
+	}
+}
+
+func TestCompositeCovers(t *testing.T) {
+	composite := &util.Composite{
+		Accounts: []string{
+			"Validators/*",
+			"Other/Validator 1",
+			"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		},
+		Operations: []string{"exit"},
+	}
+
+	tests := []struct {
+		name        string
+		operation   string
+		accountName string
+		pubKey      string
+		covered     bool
+	}{
+		{
+			name:        "Wildcard",
+			operation:   "exit",
+			accountName: "Validators/Validator 5",
+			covered:     true,
+		},
+		{
+			name:        "Exact",
+			operation:   "exit",
+			accountName: "Other/Validator 1",
+			covered:     true,
+		},
+		{
+			name:        "NoMatch",
+			operation:   "exit",
+			accountName: "Other/Validator 2",
+		},
+		{
+			name:      "PubKey",
+			operation: "exit",
+			pubKey:    "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			covered:   true,
+		},
+		{
+			name:        "OperationNotCovered",
+			operation:   "key",
+			accountName: "Validators/Validator 5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.covered, composite.Covers(test.operation, test.accountName, hexToBytes(test.pubKey)))
+		})
+	}
+}
+
+func TestCompositeApprovalJSON(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	approver, _ := compositeTestAccount(t, "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	_, member := compositeTestAccount(t, "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000")
+
+	approval, err := util.SignCompositeApproval(context.Background(), approver, "ops", "exit", hexToBytes(member), time.Unix(1700000000, 0))
+	require.NoError(t, err)
+	require.NoError(t, approval.Verify())
+
+	data, err := json.Marshal(approval)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"expiry":"2023-11-14T22:13:20Z"`)
+
+	res := &util.CompositeApproval{}
+	require.NoError(t, json.Unmarshal(data, res))
+	require.Equal(t, approval, res)
+	require.NoError(t, res.Verify())
+
+	res.Operation = "key"
+	require.EqualError(t, res.Verify(), "signature does not verify")
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"operation":"exit"}`), res), "composite missing")
+}
+
+func TestCheckCompositeApprovals(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+	approver1, approver1PubKey := compositeTestAccount(t, "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	approver2, approver2PubKey := compositeTestAccount(t, "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000")
+	outsider, _ := compositeTestAccount(t, "0x299295ecc51162a0a3f6cab7d2d8b8a4aaf2aa3d5a84728b7c4e08a3e7d6fd4a")
+	member := hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+
+	other := hexToBytes("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		accountName string
+		pubKey      []byte
+		approvals   []string
+		err         string
+	}{
+		{
+			name:        "NotCovered",
+			accountName: "Other/Validator 1",
+			pubKey:      other,
+		},
+		{
+			name:   "ApprovalsMissing",
+			pubKey: member,
+			err:    `exit of 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c requires 2 approvals from composite "ops" but 0 obtained`,
+		},
+		{
+			name:        "InsufficientApprovals",
+			accountName: "Validators/Validator 1",
+			pubKey:      member,
+			approvals:   []string{compositeApproval(t, approver1, "exit", member, future)},
+			err:         `exit of Validators/Validator 1 requires 2 approvals from composite "ops" but 1 obtained`,
+		},
+		{
+			name:      "DuplicateApprovals",
+			pubKey:    member,
+			approvals: []string{compositeApproval(t, approver1, "exit", member, future), compositeApproval(t, approver1, "exit", member, future)},
+			err:       `exit of 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c requires 2 approvals from composite "ops" but 1 obtained`,
+		},
+		{
+			name:      "NonApprover",
+			pubKey:    member,
+			approvals: []string{compositeApproval(t, approver1, "exit", member, future), compositeApproval(t, outsider, "exit", member, future)},
+			err:       `exit of 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c requires 2 approvals from composite "ops" but 1 obtained`,
+		},
+		{
+			name:      "WrongOperation",
+			pubKey:    member,
+			approvals: []string{compositeApproval(t, approver1, "exit", member, future), compositeApproval(t, approver2, "key", member, future)},
+			err:       `exit of 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c requires 2 approvals from composite "ops" but 1 obtained`,
+		},
+		{
+			name:      "Expired",
+			pubKey:    member,
+			approvals: []string{compositeApproval(t, approver1, "exit", member, future), compositeApproval(t, approver2, "exit", member, time.Now().Add(-time.Hour))},
+			err:       `exit of 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c requires 2 approvals from composite "ops" but 1 obtained`,
+		},
+		{
+			name:      "BadApproval",
+			pubKey:    member,
+			approvals: []string{`{"composite":"ops"`},
+			err:       "failed to parse approval: unexpected end of JSON input",
+		},
+		{
+			name:      "Good",
+			pubKey:    member,
+			approvals: []string{compositeApproval(t, approver1, "exit", member, future), compositeApproval(t, approver2, "exit", member, future)},
+		},
+		{
+			name:        "GoodByName",
+			accountName: "Validators/Validator 1",
+			pubKey:      other,
+			approvals: []string{fmt.Sprintf("[%s,%s]",
+				compositeApproval(t, approver1, "exit", other, future),
+				compositeApproval(t, approver2, "exit", other, future),
+			)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("composites", map[string]interface{}{
+				"ops": map[string]interface{}{
+					"threshold": 2,
+					"accounts":  []string{"Validators/*", fmt.Sprintf("%#x", member)},
+					"approvers": []string{approver1PubKey, approver2PubKey},
+				},
+			})
+			err := util.CheckCompositeApprovals(ctx, "exit", test.accountName, test.pubKey, test.approvals)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func compositeApproval(t *testing.T, account e2wtypes.Account, operation string, member []byte, expiry time.Time) string {
+	t.Helper()
+	approval, err := util.SignCompositeApproval(context.Background(), account, "ops", operation, member, expiry)
+	require.NoError(t, err)
+	data, err := json.Marshal(approval)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func hexToBytes(input string) []byte {
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ReadConfigFile reads the configuration file at the given path, returning an
+// empty configuration if the file does not exist.
+func ReadConfigFile(path string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		v.SetConfigType("yaml")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return v, nil
+		}
+		return nil, errors.Wrap(err, "failed to access configuration file")
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrap(err, "failed to read configuration file")
+	}
+
+	return v, nil
+}
+
+// WriteConfigFile writes the configuration to the file at the given path,
+// replacing its existing contents.
+func WriteConfigFile(path string, config map[string]any) error {
+	v := viper.New()
+	// Configuration can contain passphrases, so keep it private.
+	v.SetConfigPermissions(0o600)
+	if filepath.Ext(path) == "" {
+		v.SetConfigType("yaml")
+	}
+	if err := v.MergeConfigMap(config); err != nil {
+		return errors.Wrap(err, "failed to build configuration")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create configuration directory")
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return errors.Wrap(err, "failed to write configuration file")
+	}
+
+	return nil
+}
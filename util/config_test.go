@@ -0,0 +1,43 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestReadConfigFileMissing(t *testing.T) {
+	config, err := util.ReadConfigFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	require.Empty(t, config.AllKeys())
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", ".ethdo.yaml")
+	require.NoError(t, util.WriteConfigFile(path, map[string]any{
+		"timeout": "10s",
+		"aliases": map[string]any{
+			"node-1": "12345",
+		},
+	}))
+
+	config, err := util.ReadConfigFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "10s", config.GetString("timeout"))
+	require.Equal(t, "12345", config.GetString("aliases.node-1"))
+}
@@ -0,0 +1,78 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// ConsolidationRequestAddress is the address of the EIP-7251 consolidation request contract.
+var ConsolidationRequestAddress = bellatrix.ExecutionAddress{
+	0x00, 0x00, 0xbb, 0xdd, 0xc7, 0xce, 0x48, 0x86, 0x42, 0xfb,
+	0x57, 0x9f, 0x8b, 0x00, 0xf3, 0xa5, 0x90, 0x00, 0x72, 0x51,
+}
+
+// ConsolidationRequestData returns the call data for a consolidation request.
+// A request with the same source and target switches the validator to
+// compounding withdrawal credentials.
+func ConsolidationRequestData(source phase0.BLSPubKey, target phase0.BLSPubKey) []byte {
+	data := make([]byte, 0, 2*len(phase0.BLSPubKey{}))
+	data = append(data, source[:]...)
+	data = append(data, target[:]...)
+
+	return data
+}
+
+// ConsolidationRequestFee obtains the current fee for a consolidation request
+// from an execution node.
+func ConsolidationRequestFee(ctx context.Context, client execution.Service) (*big.Int, error) {
+	return requestContractFee(ctx, client, ConsolidationRequestAddress)
+}
+
+// requestContractFee obtains the current fee from an execution layer request
+// contract.
+func requestContractFee(ctx context.Context,
+	client execution.Service,
+	contract bellatrix.ExecutionAddress,
+) (
+	*big.Int,
+	error,
+) {
+	// The contract returns its current fee when called without data.
+	var res string
+	found, err := client.Call(ctx, "eth_call", []interface{}{
+		map[string]string{"to": contract.String()},
+		"latest",
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("eth_call returned no result")
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(res, "0x"))
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid fee %q", res)
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
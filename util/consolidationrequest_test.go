@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestConsolidationRequestData(t *testing.T) {
+	source := phase0.BLSPubKey{0x01}
+	target := phase0.BLSPubKey{0x02}
+
+	data := util.ConsolidationRequestData(source, target)
+	require.Len(t, data, 96)
+	require.Equal(t, source[:], data[:48])
+	require.Equal(t, target[:], data[48:])
+}
+
+func TestConsolidationRequestFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		fee      string
+		err      string
+	}{
+		{
+			name:     "Good",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000011"}`,
+			fee:      "17",
+		},
+		{
+			name:     "Empty",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0x"}`,
+			err:      `invalid fee "0x"`,
+		},
+		{
+			name:     "Null",
+			response: `{"jsonrpc":"2.0","id":1,"result":null}`,
+			err:      "eth_call returned no result",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer server.Close()
+
+			fee, err := util.ConsolidationRequestFee(context.Background(), executionClient(t, server.URL))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.fee, fee.String())
+			}
+		})
+	}
+}
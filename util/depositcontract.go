@@ -0,0 +1,279 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// DepositEventTopic is the topic of the deposit contract's DepositEvent log.
+const DepositEventTopic = "0x649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5"
+
+// depositEventBatchSize is the number of blocks for which to request logs at a time.
+const depositEventBatchSize = uint64(10000)
+
+// DepositContract is the deposit contract of a chain.
+type DepositContract struct {
+	Address bellatrix.ExecutionAddress
+	// Block is the block at which the contract was deployed.
+	Block uint64
+}
+
+// depositContracts are the deposit contracts of known chains, keyed by chain ID.
+var depositContracts = map[uint64]*DepositContract{
+	// Mainnet.
+	1: {
+		Address: bellatrix.ExecutionAddress{0x00, 0x00, 0x00, 0x00, 0x21, 0x9a, 0xb5, 0x40, 0x35, 0x6c, 0xbb, 0x83, 0x9c, 0xbe, 0x05, 0x30, 0x3d, 0x77, 0x05, 0xfa},
+		Block:   11052984,
+	},
+	// Holesky.
+	17000: {
+		Address: bellatrix.ExecutionAddress{0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42},
+	},
+	// Sepolia.
+	11155111: {
+		Address: bellatrix.ExecutionAddress{0x7f, 0x02, 0xc3, 0xe3, 0xc9, 0x8b, 0x13, 0x30, 0x55, 0xb8, 0xb3, 0x48, 0xb2, 0xac, 0x62, 0x56, 0x69, 0xed, 0x29, 0x5d},
+		Block:   1273020,
+	},
+	// Hoodi.
+	560048: {
+		Address: bellatrix.ExecutionAddress{0x00, 0x00, 0x00, 0x00, 0x21, 0x9a, 0xb5, 0x40, 0x35, 0x6c, 0xbb, 0x83, 0x9c, 0xbe, 0x05, 0x30, 0x3d, 0x77, 0x05, 0xfa},
+	},
+}
+
+// DepositContractForChain returns the deposit contract for the given chain ID.
+func DepositContractForChain(chainID uint64) (*DepositContract, error) {
+	contract, exists := depositContracts[chainID]
+	if !exists {
+		return nil, fmt.Errorf("deposit contract not known for chain %d", chainID)
+	}
+
+	return contract, nil
+}
+
+// ObtainDepositContract obtains the deposit contract to use with an execution
+// node.  If an address is supplied then it is used along with the supplied
+// deployment block, otherwise the deposit contract of the node's chain is used.
+func ObtainDepositContract(ctx context.Context,
+	client execution.Service,
+	address string,
+	block uint64,
+) (
+	*DepositContract,
+	error,
+) {
+	if address != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deposit contract address")
+		}
+		if len(data) != bellatrix.ExecutionAddressLength {
+			return nil, errors.New("deposit contract address should be 20 bytes")
+		}
+		return &DepositContract{
+			Address: bellatrix.ExecutionAddress(data),
+			Block:   block,
+		}, nil
+	}
+
+	chainID, err := ExecutionQuantity(ctx, client, "eth_chainId", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain chain ID")
+	}
+	contract, err := DepositContractForChain(chainID.Uint64())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit contract; supply it with --deposit-contract")
+	}
+
+	return contract, nil
+}
+
+// DepositEvent is a deposit made to the deposit contract.
+type DepositEvent struct {
+	PublicKey             phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Signature             phase0.BLSSignature
+	Index                 uint64
+	BlockNumber           uint64
+	TransactionHash       string
+}
+
+type depositLogJSON struct {
+	Data            string `json:"data"`
+	BlockNumber     string `json:"blockNumber"`
+	TransactionHash string `json:"transactionHash"`
+	Removed         bool   `json:"removed"`
+}
+
+// DepositEvents obtains the deposits made to the deposit contract from the
+// given block onwards.
+func DepositEvents(ctx context.Context,
+	client execution.Service,
+	contract bellatrix.ExecutionAddress,
+	fromBlock uint64,
+) (
+	[]*DepositEvent,
+	error,
+) {
+	latestBlock, err := ExecutionQuantity(ctx, client, "eth_blockNumber", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain latest block")
+	}
+	toBlock := latestBlock.Uint64()
+
+	// Nodes limit the range of blocks that can be searched in a single call,
+	// so fetch logs in batches.
+	events := make([]*DepositEvent, 0)
+	for start := fromBlock; start <= toBlock; start += depositEventBatchSize {
+		end := start + depositEventBatchSize - 1
+		if end > toBlock {
+			end = toBlock
+		}
+
+		logs := make([]*depositLogJSON, 0)
+		if _, err := client.Call(ctx, "eth_getLogs", []interface{}{
+			map[string]interface{}{
+				"address":   contract.String(),
+				"fromBlock": fmt.Sprintf("%#x", start),
+				"toBlock":   fmt.Sprintf("%#x", end),
+				"topics":    []string{DepositEventTopic},
+			},
+		}, &logs); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain logs for blocks %d to %d", start, end))
+		}
+
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid log data")
+			}
+			event, err := ParseDepositEventData(data)
+			if err != nil {
+				return nil, err
+			}
+			blockNumber, err := ParseExecutionQuantity(log.BlockNumber)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid log block number")
+			}
+			event.BlockNumber = blockNumber.Uint64()
+			event.TransactionHash = log.TransactionHash
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// ParseDepositEventData parses the ABI-encoded data of a DepositEvent log.
+func ParseDepositEventData(data []byte) (*DepositEvent, error) {
+	fields := make([][]byte, 5)
+	for i := range fields {
+		var err error
+		fields[i], err = abiBytesField(data, i)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid deposit event field %d", i))
+		}
+	}
+
+	if len(fields[0]) != phase0.PublicKeyLength {
+		return nil, errors.New("invalid deposit event public key")
+	}
+	if len(fields[1]) != 32 {
+		return nil, errors.New("invalid deposit event withdrawal credentials")
+	}
+	if len(fields[2]) != 8 {
+		return nil, errors.New("invalid deposit event amount")
+	}
+	if len(fields[3]) != phase0.SignatureLength {
+		return nil, errors.New("invalid deposit event signature")
+	}
+	if len(fields[4]) != 8 {
+		return nil, errors.New("invalid deposit event index")
+	}
+
+	event := &DepositEvent{
+		WithdrawalCredentials: fields[1],
+		// The deposit contract encodes amount and index as little-endian.
+		Amount: phase0.Gwei(binary.LittleEndian.Uint64(fields[2])),
+		Index:  binary.LittleEndian.Uint64(fields[4]),
+	}
+	copy(event.PublicKey[:], fields[0])
+	copy(event.Signature[:], fields[3])
+
+	return event, nil
+}
+
+// DepositCallData returns the ABI-encoded data for a call to the deposit
+// contract's deposit function with the given deposit data.
+func DepositCallData(depositData *phase0.DepositData, depositDataRoot phase0.Root) []byte {
+	data := make([]byte, 0, 4+7*32+64+96)
+	// Function signature.
+	data = append(data, 0x22, 0x89, 0x51, 0x18)
+	// Offsets of the validator public key, withdrawal credentials and signature.
+	data = append(data, abiUint64(0x80)...)
+	data = append(data, abiUint64(0xe0)...)
+	data = append(data, abiUint64(0x120)...)
+	data = append(data, depositDataRoot[:]...)
+	// Validator public key, padded.
+	data = append(data, abiUint64(phase0.PublicKeyLength)...)
+	data = append(data, depositData.PublicKey[:]...)
+	data = append(data, make([]byte, 64-phase0.PublicKeyLength)...)
+	// Withdrawal credentials.
+	data = append(data, abiUint64(uint64(len(depositData.WithdrawalCredentials)))...)
+	data = append(data, depositData.WithdrawalCredentials...)
+	// Signature.
+	data = append(data, abiUint64(phase0.SignatureLength)...)
+	data = append(data, depositData.Signature[:]...)
+
+	return data
+}
+
+// abiUint64 returns the ABI encoding of a uint64.
+func abiUint64(value uint64) []byte {
+	res := make([]byte, 32)
+	binary.BigEndian.PutUint64(res[24:], value)
+
+	return res
+}
+
+// abiBytesField returns the value of the dynamic bytes field at the given
+// position in ABI-encoded data.
+func abiBytesField(data []byte, position int) ([]byte, error) {
+	if len(data) < (position+1)*32 {
+		return nil, errors.New("data too short for offset")
+	}
+	offset := binary.BigEndian.Uint64(data[position*32+24 : (position+1)*32])
+	if offset+32 > uint64(len(data)) {
+		return nil, errors.New("offset out of range")
+	}
+	length := binary.BigEndian.Uint64(data[offset+24 : offset+32])
+	if length > uint64(len(data)) || offset+32+length > uint64(len(data)) {
+		return nil, errors.New("length out of range")
+	}
+
+	return data[offset+32 : offset+32+length], nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// depositEventData ABI-encodes the data of a DepositEvent log.
+func depositEventData(pubkey phase0.BLSPubKey, amount phase0.Gwei, index uint64) []byte {
+	amountBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amountBytes, uint64(amount))
+	indexBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(indexBytes, index)
+	withdrawalCredentials := make([]byte, 32)
+	withdrawalCredentials[0] = 0x01
+	signature := make([]byte, 96)
+	signature[0] = 0xaa
+	fields := [][]byte{pubkey[:], withdrawalCredentials, amountBytes, signature, indexBytes}
+
+	head := make([]byte, 0, 5*32)
+	tail := make([]byte, 0)
+	for _, field := range fields {
+		word := make([]byte, 32)
+		binary.BigEndian.PutUint64(word[24:], uint64(5*32+len(tail)))
+		head = append(head, word...)
+
+		length := make([]byte, 32)
+		binary.BigEndian.PutUint64(length[24:], uint64(len(field)))
+		tail = append(tail, length...)
+		padded := make([]byte, (len(field)+31)/32*32)
+		copy(padded, field)
+		tail = append(tail, padded...)
+	}
+
+	return append(head, tail...)
+}
+
+func TestDepositContractForChain(t *testing.T) {
+	contract, err := util.DepositContractForChain(1)
+	require.NoError(t, err)
+	require.Equal(t, "0x00000000219ab540356cBB839Cbe05303d7705Fa", contract.Address.String())
+	require.Equal(t, uint64(11052984), contract.Block)
+
+	_, err = util.DepositContractForChain(12345)
+	require.EqualError(t, err, "deposit contract not known for chain 12345")
+}
+
+func TestParseDepositEventData(t *testing.T) {
+	pubkey := phase0.BLSPubKey{0x01, 0x02}
+	data := depositEventData(pubkey, 32000000000, 7)
+
+	event, err := util.ParseDepositEventData(data)
+	require.NoError(t, err)
+	require.Equal(t, pubkey, event.PublicKey)
+	require.Equal(t, phase0.Gwei(32000000000), event.Amount)
+	require.Equal(t, uint64(7), event.Index)
+	require.Equal(t, byte(0x01), event.WithdrawalCredentials[0])
+	require.Equal(t, byte(0xaa), event.Signature[0])
+
+	_, err = util.ParseDepositEventData(data[:100])
+	require.EqualError(t, err, "invalid deposit event field 0: offset out of range")
+
+	_, err = util.ParseDepositEventData(nil)
+	require.EqualError(t, err, "invalid deposit event field 0: data too short for offset")
+
+	_, err = util.ParseDepositEventData(data[:len(data)-32])
+	require.EqualError(t, err, "invalid deposit event field 4: length out of range")
+}
+
+func TestDepositEvents(t *testing.T) {
+	pubkey1 := phase0.BLSPubKey{0x01}
+	pubkey2 := phase0.BLSPubKey{0x02}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string                   `json:"method"`
+			Params []map[string]interface{} `json:"params"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch req.Method {
+		case "eth_blockNumber":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x4e20"}`))
+		case "eth_getLogs":
+			requests++
+			switch req.Params[0]["fromBlock"] {
+			case "0x0":
+				require.Equal(t, "0x270f", req.Params[0]["toBlock"])
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":[{"data":"0x%s","blockNumber":"0x10","transactionHash":"0x01"}]}`,
+					hex.EncodeToString(depositEventData(pubkey1, 32000000000, 0)))))
+			case "0x2710":
+				require.Equal(t, "0x4e1f", req.Params[0]["toBlock"])
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":[{"data":"0x%s","blockNumber":"0x2711","transactionHash":"0x02","removed":true}]}`,
+					hex.EncodeToString(depositEventData(pubkey1, 1000000000, 1)))))
+			default:
+				require.Equal(t, "0x4e20", req.Params[0]["toBlock"])
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":[{"data":"0x%s","blockNumber":"0x4e20","transactionHash":"0x03"}]}`,
+					hex.EncodeToString(depositEventData(pubkey2, 1000000000, 1)))))
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	contract, err := util.DepositContractForChain(17000)
+	require.NoError(t, err)
+	events, err := util.DepositEvents(context.Background(), executionClient(t, server.URL), contract.Address, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, requests)
+	require.Len(t, events, 2)
+	require.Equal(t, pubkey1, events[0].PublicKey)
+	require.Equal(t, uint64(16), events[0].BlockNumber)
+	require.Equal(t, "0x01", events[0].TransactionHash)
+	require.Equal(t, pubkey2, events[1].PublicKey)
+	require.Equal(t, uint64(20000), events[1].BlockNumber)
+	require.Equal(t, phase0.Gwei(1000000000), events[1].Amount)
+}
+
+func TestDepositCallData(t *testing.T) {
+	depositData := &phase0.DepositData{
+		PublicKey:             phase0.BLSPubKey{0x01, 0x02},
+		WithdrawalCredentials: append([]byte{0x02}, make([]byte, 31)...),
+		Amount:                32000000000,
+		Signature:             phase0.BLSSignature{0xaa},
+	}
+	data := util.DepositCallData(depositData, phase0.Root{0x03})
+	require.Len(t, data, 420)
+	require.Equal(t, []byte{0x22, 0x89, 0x51, 0x18}, data[:4])
+	require.Equal(t, byte(0x03), data[100])
+
+	// The fields are at their ABI offsets after the function signature.
+	fields := data[4:]
+	require.Equal(t, byte(0x30), fields[159])
+	require.Equal(t, depositData.PublicKey[:], fields[160:208])
+	require.Equal(t, byte(0x20), fields[255])
+	require.Equal(t, depositData.WithdrawalCredentials, fields[256:288])
+	require.Equal(t, byte(0x60), fields[319])
+	require.Equal(t, depositData.Signature[:], fields[320:416])
+}
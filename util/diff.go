@@ -0,0 +1,270 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// Difference is a single difference between two values.
+type Difference struct {
+	// Path is the dot-separated path to the value, for example
+	// "message.body.attestations[3].signature".
+	Path         string
+	Left         any
+	LeftPresent  bool
+	Right        any
+	RightPresent bool
+}
+
+// DecodeJSONValue decodes JSON in to a generic value suitable for DiffValues.
+// Numbers are retained exactly rather than converted to floats.
+func DecodeJSONValue(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JSON")
+	}
+
+	return value, nil
+}
+
+// SignedBeaconBlockValue returns the block in a versioned signed beacon block
+// as a generic value suitable for DiffValues.
+func SignedBeaconBlockValue(block *spec.VersionedSignedBeaconBlock) (any, error) {
+	var data []byte
+	var err error
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		data, err = json.Marshal(block.Phase0)
+	case spec.DataVersionAltair:
+		data, err = json.Marshal(block.Altair)
+	case spec.DataVersionBellatrix:
+		data, err = json.Marshal(block.Bellatrix)
+	case spec.DataVersionCapella:
+		data, err = json.Marshal(block.Capella)
+	case spec.DataVersionDeneb:
+		data, err = json.Marshal(block.Deneb)
+	case spec.DataVersionElectra:
+		data, err = json.Marshal(block.Electra)
+	case spec.DataVersionFulu:
+		data, err = json.Marshal(block.Fulu)
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return DecodeJSONValue(data)
+}
+
+// DiffJSON returns the differences between two JSON-encoded values.
+func DiffJSON(left []byte, right []byte) ([]*Difference, error) {
+	leftValue, err := DecodeJSONValue(left)
+	if err != nil {
+		return nil, errors.Wrap(err, "left")
+	}
+	rightValue, err := DecodeJSONValue(right)
+	if err != nil {
+		return nil, errors.Wrap(err, "right")
+	}
+
+	return DiffValues("", leftValue, rightValue), nil
+}
+
+// DiffValues returns the differences between two decoded JSON values,
+// walking maps by key and arrays by index.
+// Values are compared as SSZ types rather than JSON text, so hex-encoded
+// bytes that differ only in case and integers that differ only in whether
+// they are quoted are considered equal.
+func DiffValues(path string, left any, right any) []*Difference {
+	differences := make([]*Difference, 0)
+
+	leftMap, leftIsMap := left.(map[string]any)
+	rightMap, rightIsMap := right.(map[string]any)
+	leftArray, leftIsArray := left.([]any)
+	rightArray, rightIsArray := right.([]any)
+
+	switch {
+	case leftIsMap && rightIsMap:
+		for _, key := range unionKeys(leftMap, rightMap) {
+			childPath := key
+			if path != "" {
+				childPath = fmt.Sprintf("%s.%s", path, key)
+			}
+			leftValue, leftPresent := leftMap[key]
+			rightValue, rightPresent := rightMap[key]
+			if leftPresent && rightPresent {
+				differences = append(differences, DiffValues(childPath, leftValue, rightValue)...)
+				continue
+			}
+			differences = append(differences, &Difference{
+				Path:         childPath,
+				Left:         leftValue,
+				LeftPresent:  leftPresent,
+				Right:        rightValue,
+				RightPresent: rightPresent,
+			})
+		}
+	case leftIsArray && rightIsArray:
+		for i := 0; i < len(leftArray) || i < len(rightArray); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(leftArray):
+				differences = append(differences, &Difference{
+					Path:         childPath,
+					Right:        rightArray[i],
+					RightPresent: true,
+				})
+			case i >= len(rightArray):
+				differences = append(differences, &Difference{
+					Path:        childPath,
+					Left:        leftArray[i],
+					LeftPresent: true,
+				})
+			default:
+				differences = append(differences, DiffValues(childPath, leftArray[i], rightArray[i])...)
+			}
+		}
+	case !scalarsEqual(left, right):
+		differences = append(differences, &Difference{
+			Path:         path,
+			Left:         left,
+			LeftPresent:  true,
+			Right:        right,
+			RightPresent: true,
+		})
+	}
+
+	return differences
+}
+
+// DiffLists compares two lists as unordered collections, returning the
+// indices of the items that are present only in the left list and only in
+// the right list.  This is suitable for lists such as attestations or
+// transactions, where the same items can appear in a different order.
+func DiffLists(left []any, right []any) ([]int, []int) {
+	rightKeys := make(map[string][]int, len(right))
+	for i := range right {
+		key := canonicalValue(right[i])
+		rightKeys[key] = append(rightKeys[key], i)
+	}
+
+	leftOnly := make([]int, 0)
+	for i := range left {
+		key := canonicalValue(left[i])
+		if indices := rightKeys[key]; len(indices) > 0 {
+			rightKeys[key] = indices[1:]
+			continue
+		}
+		leftOnly = append(leftOnly, i)
+	}
+
+	rightOnly := make([]int, 0)
+	for _, indices := range rightKeys {
+		rightOnly = append(rightOnly, indices...)
+	}
+	sort.Ints(rightOnly)
+
+	return leftOnly, rightOnly
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys(left map[string]any, right map[string]any) []string {
+	keys := make(map[string]bool, len(left))
+	for key := range left {
+		keys[key] = true
+	}
+	for key := range right {
+		keys[key] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	return sortedKeys
+}
+
+// scalarsEqual returns true if two values are equal when considered as SSZ types.
+func scalarsEqual(left any, right any) bool {
+	if reflect.DeepEqual(left, right) {
+		return true
+	}
+	leftScalar, leftIsScalar := sszScalar(left)
+	rightScalar, rightIsScalar := sszScalar(right)
+
+	return leftIsScalar && rightIsScalar && leftScalar == rightScalar
+}
+
+// sszScalar returns the canonical form of a scalar value, if it is one.
+func sszScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return strings.ToLower(v), true
+		}
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return fmt.Sprintf("%v", v), true
+	case bool:
+		return fmt.Sprintf("%t", v), true
+	default:
+		return "", false
+	}
+}
+
+// canonicalValue returns a canonical string form of a value, such that two
+// values have the same form if DiffValues reports no differences between them.
+func canonicalValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		builder := strings.Builder{}
+		builder.WriteString("{")
+		for i, key := range unionKeys(v, nil) {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			builder.WriteString(fmt.Sprintf("%q:%s", key, canonicalValue(v[key])))
+		}
+		builder.WriteString("}")
+		return builder.String()
+	case []any:
+		items := make([]string, len(v))
+		for i := range v {
+			items[i] = canonicalValue(v[i])
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ","))
+	case nil:
+		return "null"
+	default:
+		if scalar, isScalar := sszScalar(v); isScalar {
+			return fmt.Sprintf("%q", scalar)
+		}
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		left     string
+		right    string
+		expected []*util.Difference
+		err      string
+	}{
+		{
+			name:  "LeftInvalid",
+			left:  `{`,
+			right: `{}`,
+			err:   "left: failed to decode JSON: unexpected EOF",
+		},
+		{
+			name:     "Identical",
+			left:     `{"message":{"slot":"1"},"signature":"0x01"}`,
+			right:    `{"message":{"slot":"1"},"signature":"0x01"}`,
+			expected: []*util.Difference{},
+		},
+		{
+			name:     "HexCase",
+			left:     `{"root":"0xABCD"}`,
+			right:    `{"root":"0xabcd"}`,
+			expected: []*util.Difference{},
+		},
+		{
+			name:     "QuotedNumber",
+			left:     `{"slot":"12"}`,
+			right:    `{"slot":12}`,
+			expected: []*util.Difference{},
+		},
+		{
+			name:  "Value",
+			left:  `{"message":{"slot":"1","state_root":"0x01"}}`,
+			right: `{"message":{"slot":"2","state_root":"0x01"}}`,
+			expected: []*util.Difference{
+				{Path: "message.slot", Left: "1", LeftPresent: true, Right: "2", RightPresent: true},
+			},
+		},
+		{
+			name:  "Array",
+			left:  `{"a":["0x01","0x02"]}`,
+			right: `{"a":["0x01"]}`,
+			expected: []*util.Difference{
+				{Path: "a[1]", Left: "0x02", LeftPresent: true},
+			},
+		},
+		{
+			name:  "Missing",
+			left:  `{"a":"1"}`,
+			right: `{"b":"1"}`,
+			expected: []*util.Difference{
+				{Path: "a", Left: "1", LeftPresent: true},
+				{Path: "b", Right: "1", RightPresent: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.DiffJSON([]byte(test.left), []byte(test.right))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestDiffLists(t *testing.T) {
+	tests := []struct {
+		name      string
+		left      string
+		right     string
+		leftOnly  []int
+		rightOnly []int
+	}{
+		{
+			name:      "Empty",
+			left:      `[]`,
+			right:     `[]`,
+			leftOnly:  []int{},
+			rightOnly: []int{},
+		},
+		{
+			name:      "Reordered",
+			left:      `[{"a":"1"},{"a":"2"}]`,
+			right:     `[{"a":"2"},{"a":"1"}]`,
+			leftOnly:  []int{},
+			rightOnly: []int{},
+		},
+		{
+			name:      "Differing",
+			left:      `[{"a":"1"},{"a":"2"},{"a":"3"}]`,
+			right:     `[{"a":"3"},{"a":"4"},{"a":"1"}]`,
+			leftOnly:  []int{1},
+			rightOnly: []int{1},
+		},
+		{
+			name:      "Duplicates",
+			left:      `["0x01","0x01"]`,
+			right:     `["0x01"]`,
+			leftOnly:  []int{1},
+			rightOnly: []int{},
+		},
+		{
+			name:      "SSZEquivalent",
+			left:      `[{"root":"0xAB","slot":"1"}]`,
+			right:     `[{"slot":1,"root":"0xab"}]`,
+			leftOnly:  []int{},
+			rightOnly: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var left []any
+			require.NoError(t, json.Unmarshal([]byte(test.left), &left))
+			var right []any
+			require.NoError(t, json.Unmarshal([]byte(test.right), &right))
+			leftOnly, rightOnly := util.DiffLists(left, right)
+			require.Equal(t, test.leftOnly, leftOnly)
+			require.Equal(t, test.rightOnly, rightOnly)
+		})
+	}
+}
+
+func TestSignedBeaconBlockValue(t *testing.T) {
+	_, err := util.SignedBeaconBlockValue(&spec.VersionedSignedBeaconBlock{})
+	require.EqualError(t, err, "unhandled block version unknown")
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot: 5,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+				},
+			},
+		},
+	}
+	value, err := util.SignedBeaconBlockValue(block)
+	require.NoError(t, err)
+	slot := value.(map[string]any)["message"].(map[string]any)["slot"]
+	require.Equal(t, "5", slot)
+}
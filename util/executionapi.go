@@ -0,0 +1,121 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// ExecutionQuantity calls a JSON-RPC method on an execution node that
+// returns a quantity.
+func ExecutionQuantity(ctx context.Context,
+	client execution.Service,
+	method string,
+	params []interface{},
+) (
+	*big.Int,
+	error,
+) {
+	var res string
+	found, err := client.Call(ctx, method, params, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s returned no result", method)
+	}
+
+	return ParseExecutionQuantity(res)
+}
+
+// ParseExecutionQuantity parses a JSON-RPC quantity.
+func ParseExecutionQuantity(input string) (*big.Int, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+	res, success := new(big.Int).SetString(strings.TrimPrefix(input, "0x"), 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity %q", input)
+	}
+
+	return res, nil
+}
+
+type executionBlockFeeJSON struct {
+	BaseFeePerGas string `json:"baseFeePerGas"`
+}
+
+// BuildDynamicFeeTransaction obtains the nonce, fees and gas for a
+// transaction from an execution node.
+func BuildDynamicFeeTransaction(ctx context.Context,
+	client execution.Service,
+	chainID *big.Int,
+	from bellatrix.ExecutionAddress,
+	to bellatrix.ExecutionAddress,
+	value *big.Int,
+	data []byte,
+) (
+	*DynamicFeeTransaction,
+	error,
+) {
+	nonce, err := ExecutionQuantity(ctx, client, "eth_getTransactionCount", []interface{}{from.String(), "pending"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain nonce")
+	}
+	maxPriorityFeePerGas, err := ExecutionQuantity(ctx, client, "eth_maxPriorityFeePerGas", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain priority fee")
+	}
+	block := &executionBlockFeeJSON{}
+	if _, err := client.Call(ctx, "eth_getBlockByNumber", []interface{}{"latest", false}, block); err != nil {
+		return nil, errors.Wrap(err, "failed to obtain latest block")
+	}
+	baseFeePerGas, err := ParseExecutionQuantity(block.BaseFeePerGas)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base fee")
+	}
+	gas, err := ExecutionQuantity(ctx, client, "eth_estimateGas", []interface{}{
+		map[string]string{
+			"from":  from.String(),
+			"to":    to.String(),
+			"value": fmt.Sprintf("%#x", value),
+			"data":  fmt.Sprintf("%#x", data),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to estimate gas")
+	}
+
+	// Allow for the base fee doubling before the transaction is included.
+	maxFeePerGas := new(big.Int).Mul(baseFeePerGas, big.NewInt(2))
+	maxFeePerGas.Add(maxFeePerGas, maxPriorityFeePerGas)
+
+	return &DynamicFeeTransaction{
+		ChainID:              chainID,
+		Nonce:                nonce.Uint64(),
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+		MaxFeePerGas:         maxFeePerGas,
+		Gas:                  gas.Uint64(),
+		To:                   to,
+		Value:                value,
+		Data:                 data,
+	}, nil
+}
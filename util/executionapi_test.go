@@ -0,0 +1,68 @@
+// Copyright © 2023 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseExecutionQuantity(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		value string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   `invalid quantity ""`,
+		},
+		{
+			name:  "NoPrefix",
+			input: "10",
+			err:   `invalid quantity "10"`,
+		},
+		{
+			name:  "Invalid",
+			input: "0xzz",
+			err:   `invalid quantity "0xzz"`,
+		},
+		{
+			name:  "Zero",
+			input: "0x0",
+			value: "0",
+		},
+		{
+			name:  "Large",
+			input: "0x1000000000000000000000000",
+			value: "79228162514264337593543950336",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := util.ParseExecutionQuantity(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.value, value.String())
+			}
+		})
+	}
+}
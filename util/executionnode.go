@@ -0,0 +1,54 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/services/execution/jsonrpc"
+)
+
+// ExecutionConnectOpts are the options for connecting to an execution node.
+type ExecutionConnectOpts struct {
+	Address string
+	Timeout time.Duration
+}
+
+// ConnectToExecutionNode connects to an execution node at the given address.
+func ConnectToExecutionNode(ctx context.Context, opts *ExecutionConnectOpts) (execution.Service, error) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	if opts.Address == "" {
+		return nil, errors.New("no address specified")
+	}
+
+	if opts.Timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	client, err := jsonrpc.New(ctx,
+		jsonrpc.WithAddress(opts.Address),
+		jsonrpc.WithTimeout(opts.Timeout),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create execution client")
+	}
+
+	return client, nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/execution"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// executionClient creates a client for the execution node at the given address.
+func executionClient(t *testing.T, address string) execution.Service {
+	t.Helper()
+
+	client, err := util.ConnectToExecutionNode(context.Background(), &util.ExecutionConnectOpts{
+		Address: address,
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestConnectToExecutionNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *util.ExecutionConnectOpts
+		address string
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no options specified",
+		},
+		{
+			name: "AddressMissing",
+			opts: &util.ExecutionConnectOpts{
+				Timeout: time.Second,
+			},
+			err: "no address specified",
+		},
+		{
+			name: "TimeoutMissing",
+			opts: &util.ExecutionConnectOpts{
+				Address: "localhost:8545",
+			},
+			err: "no timeout specified",
+		},
+		{
+			name: "Good",
+			opts: &util.ExecutionConnectOpts{
+				Address: "localhost:8545",
+				Timeout: time.Second,
+			},
+			address: "http://localhost:8545",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := util.ConnectToExecutionNode(context.Background(), test.opts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, client.Address())
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ExitCheck is the result of a single check of whether a validator can exit.
+type ExitCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+// ExitChecks checks the on-chain state of a validator to confirm that a
+// voluntary exit for it would be accepted.
+func ExitChecks(validator *apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) []*ExitCheck {
+	return []*ExitCheck{
+		activeExitCheck(validator),
+		shardCommitteePeriodExitCheck(validator, currentEpoch, shardCommitteePeriod),
+		slashedExitCheck(validator),
+		exitingExitCheck(validator),
+	}
+}
+
+// FailedExitChecks returns the checks that did not pass.
+func FailedExitChecks(checks []*ExitCheck) []*ExitCheck {
+	failed := make([]*ExitCheck, 0)
+	for _, check := range checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+
+	return failed
+}
+
+// ObtainShardCommitteePeriod obtains the number of epochs a validator must be
+// active before it can exit.
+func ObtainShardCommitteePeriod(ctx context.Context, specProvider consensusclient.SpecProvider) (phase0.Epoch, error) {
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+	spec := specResponse.Data
+	tmp, exists := spec["SHARD_COMMITTEE_PERIOD"]
+	if !exists {
+		return 0, errors.New("SHARD_COMMITTEE_PERIOD not found in spec")
+	}
+	period, isPeriod := tmp.(uint64)
+	if !isPeriod {
+		return 0, errors.New("SHARD_COMMITTEE_PERIOD of unexpected type")
+	}
+
+	return phase0.Epoch(period), nil
+}
+
+func activeExitCheck(validator *apiv1.Validator) *ExitCheck {
+	return &ExitCheck{
+		Name:   "active",
+		Passed: validator.Status == apiv1.ValidatorStateActiveOngoing,
+		Detail: fmt.Sprintf("validator is in state %v", validator.Status),
+	}
+}
+
+func shardCommitteePeriodExitCheck(validator *apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) *ExitCheck {
+	res := &ExitCheck{
+		Name: "shard committee period",
+	}
+	if validator.Validator.ActivationEpoch == FarFutureEpoch {
+		res.Detail = "validator has not been activated"
+		return res
+	}
+
+	eligibleEpoch := validator.Validator.ActivationEpoch + shardCommitteePeriod
+	res.Passed = currentEpoch >= eligibleEpoch
+	if res.Passed {
+		res.Detail = fmt.Sprintf("validator has been active since epoch %d", validator.Validator.ActivationEpoch)
+	} else {
+		res.Detail = fmt.Sprintf("validator cannot exit until epoch %d", eligibleEpoch)
+	}
+
+	return res
+}
+
+func slashedExitCheck(validator *apiv1.Validator) *ExitCheck {
+	res := &ExitCheck{
+		Name:   "not slashed",
+		Passed: !validator.Validator.Slashed,
+	}
+	if res.Passed {
+		res.Detail = "validator has not been slashed"
+	} else {
+		res.Detail = "validator has been slashed"
+	}
+
+	return res
+}
+
+func exitingExitCheck(validator *apiv1.Validator) *ExitCheck {
+	res := &ExitCheck{
+		Name:   "not exiting",
+		Passed: validator.Validator.ExitEpoch == FarFutureEpoch,
+	}
+	if res.Passed {
+		res.Detail = "validator has no exit epoch"
+	} else {
+		res.Detail = fmt.Sprintf("validator is already exiting at epoch %d", validator.Validator.ExitEpoch)
+	}
+
+	return res
+}
@@ -0,0 +1,118 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExitChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		epoch     phase0.Epoch
+		passed    []bool
+	}{
+		{
+			name: "Ready",
+			validator: &apiv1.Validator{
+				Status: apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					ActivationEpoch: 10,
+					ExitEpoch:       util.FarFutureEpoch,
+				},
+			},
+			epoch:  266,
+			passed: []bool{true, true, true, true},
+		},
+		{
+			name: "ShardCommitteePeriod",
+			validator: &apiv1.Validator{
+				Status: apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					ActivationEpoch: 10,
+					ExitEpoch:       util.FarFutureEpoch,
+				},
+			},
+			epoch:  265,
+			passed: []bool{true, false, true, true},
+		},
+		{
+			name: "Pending",
+			validator: &apiv1.Validator{
+				Status: apiv1.ValidatorStatePendingQueued,
+				Validator: &phase0.Validator{
+					ActivationEpoch: util.FarFutureEpoch,
+					ExitEpoch:       util.FarFutureEpoch,
+				},
+			},
+			epoch:  100,
+			passed: []bool{false, false, true, true},
+		},
+		{
+			name: "Slashed",
+			validator: &apiv1.Validator{
+				Status: apiv1.ValidatorStateActiveSlashed,
+				Validator: &phase0.Validator{
+					ActivationEpoch: 10,
+					ExitEpoch:       300,
+					Slashed:         true,
+				},
+			},
+			epoch:  280,
+			passed: []bool{false, true, false, false},
+		},
+		{
+			name: "Exiting",
+			validator: &apiv1.Validator{
+				Status: apiv1.ValidatorStateActiveExiting,
+				Validator: &phase0.Validator{
+					ActivationEpoch: 10,
+					ExitEpoch:       300,
+				},
+			},
+			epoch:  280,
+			passed: []bool{false, true, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checks := util.ExitChecks(test.validator, test.epoch, 256)
+			require.Len(t, checks, len(test.passed))
+			for i := range checks {
+				require.Equal(t, test.passed[i], checks[i].Passed, checks[i].Name)
+			}
+		})
+	}
+}
+
+func TestFailedExitChecks(t *testing.T) {
+	checks := []*util.ExitCheck{
+		{Name: "a", Passed: true},
+		{Name: "b", Passed: false},
+		{Name: "c", Passed: false},
+	}
+	failed := util.FailedExitChecks(checks)
+	require.Len(t, failed, 2)
+	require.Equal(t, "b", failed[0].Name)
+	require.Equal(t, "c", failed[1].Name)
+
+	require.Empty(t, util.FailedExitChecks(checks[:1]))
+}
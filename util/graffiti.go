@@ -0,0 +1,201 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// GraffitiLength is the length of block graffiti.
+const GraffitiLength = 32
+
+// Graffiti encodings.
+const (
+	GraffitiEncodingEmpty = "empty"
+	GraffitiEncodingUTF8  = "utf-8"
+	GraffitiEncodingHex   = "hex"
+)
+
+// clientCodes are the two-letter client codes used in client version graffiti.
+var clientCodes = map[string]string{
+	"BU": "Besu",
+	"EJ": "EthereumJS",
+	"EG": "Erigon",
+	"GE": "Geth",
+	"GR": "Grandine",
+	"LH": "Lighthouse",
+	"LS": "Lodestar",
+	"NM": "Nethermind",
+	"NB": "Nimbus",
+	"TK": "Teku",
+	"PM": "Prysm",
+	"RH": "Reth",
+}
+
+// clientVersionGraffiti matches the client version prefix of graffiti, in the
+// form <EL code><EL commit><CL code><CL commit>.
+var clientVersionGraffiti = regexp.MustCompile(`^([A-Z]{2})([0-9a-f]{0,4})([A-Z]{2})([0-9a-f]{0,4})(?:\s|$)`)
+
+// clientNames are the names of clients that are commonly included in graffiti.
+var clientNames = []string{
+	"besu",
+	"erigon",
+	"geth",
+	"grandine",
+	"lighthouse",
+	"lodestar",
+	"nethermind",
+	"nimbus",
+	"prysm",
+	"reth",
+	"teku",
+}
+
+// GraffitiInfo is decoded information about block graffiti.
+type GraffitiInfo struct {
+	// Encoding is the detected encoding of the graffiti.
+	Encoding string `json:"encoding"`
+	// Text is the graffiti as text, or as hex if it is not valid text.
+	Text string `json:"text"`
+	// ExecutionClient is the execution client identified in the graffiti, if any.
+	ExecutionClient string `json:"execution_client,omitempty"`
+	// ExecutionClientCommit is the execution client commit identified in the graffiti, if any.
+	ExecutionClientCommit string `json:"execution_client_commit,omitempty"`
+	// ConsensusClient is the consensus client identified in the graffiti, if any.
+	ConsensusClient string `json:"consensus_client,omitempty"`
+	// ConsensusClientCommit is the consensus client commit identified in the graffiti, if any.
+	ConsensusClientCommit string `json:"consensus_client_commit,omitempty"`
+	// Clients are the names of clients mentioned in the graffiti, if any.
+	Clients []string `json:"clients,omitempty"`
+}
+
+// DecodeGraffiti decodes block graffiti.
+func DecodeGraffiti(graffiti []byte) *GraffitiInfo {
+	trimmed := bytes.TrimRight(graffiti, "\u0000")
+	if len(trimmed) == 0 {
+		return &GraffitiInfo{
+			Encoding: GraffitiEncodingEmpty,
+		}
+	}
+
+	if !utf8.Valid(trimmed) {
+		return &GraffitiInfo{
+			Encoding: GraffitiEncodingHex,
+			Text:     fmt.Sprintf("%#x", trimmed),
+		}
+	}
+
+	info := &GraffitiInfo{
+		Encoding: GraffitiEncodingUTF8,
+		Text:     string(trimmed),
+	}
+
+	if match := clientVersionGraffiti.FindStringSubmatch(info.Text); match != nil {
+		elClient, elKnown := clientCodes[match[1]]
+		clClient, clKnown := clientCodes[match[3]]
+		if elKnown && clKnown {
+			info.ExecutionClient = elClient
+			info.ExecutionClientCommit = match[2]
+			info.ConsensusClient = clClient
+			info.ConsensusClientCommit = match[4]
+		}
+	}
+
+	lowerText := strings.ToLower(info.Text)
+	for _, name := range clientNames {
+		if strings.Contains(lowerText, name) {
+			info.Clients = append(info.Clients, name)
+		}
+	}
+
+	return info
+}
+
+// EncodeGraffiti encodes text as block graffiti, optionally prefixed with
+// client version information.  Client codes and commits are optional,
+// but if a commit is supplied its code must be as well.
+func EncodeGraffiti(text string,
+	elCode string,
+	elCommit string,
+	clCode string,
+	clCommit string,
+) (
+	[]byte,
+	error,
+) {
+	prefix := ""
+	if elCode != "" || clCode != "" || elCommit != "" || clCommit != "" {
+		var err error
+		prefix, err = clientVersionPrefix(elCode, elCommit, clCode, clCommit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data := text
+	if prefix != "" {
+		data = strings.TrimSpace(fmt.Sprintf("%s %s", prefix, text))
+	}
+	if len(data) > GraffitiLength {
+		return nil, fmt.Errorf("graffiti is %d bytes, maximum is %d", len(data), GraffitiLength)
+	}
+
+	res := make([]byte, GraffitiLength)
+	copy(res, data)
+
+	return res, nil
+}
+
+func clientVersionPrefix(elCode string, elCommit string, clCode string, clCommit string) (string, error) {
+	elCode = strings.ToUpper(elCode)
+	clCode = strings.ToUpper(clCode)
+	if _, exists := clientCodes[elCode]; !exists {
+		return "", fmt.Errorf("unknown execution client code %q", elCode)
+	}
+	if _, exists := clientCodes[clCode]; !exists {
+		return "", fmt.Errorf("unknown consensus client code %q", clCode)
+	}
+
+	elCommit, err := graffitiCommit(elCommit)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid execution client commit")
+	}
+	clCommit, err = graffitiCommit(clCommit)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid consensus client commit")
+	}
+
+	return fmt.Sprintf("%s%s%s%s", elCode, elCommit, clCode, clCommit), nil
+}
+
+// graffitiCommit returns the first four characters of a commit hash.
+func graffitiCommit(commit string) (string, error) {
+	commit = strings.ToLower(strings.TrimPrefix(commit, "0x"))
+	for _, c := range commit {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return "", fmt.Errorf("commit %q is not hex", commit)
+		}
+	}
+	if len(commit) > 4 {
+		commit = commit[:4]
+	}
+
+	return commit, nil
+}
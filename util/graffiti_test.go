@@ -0,0 +1,185 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func graffiti(input string) []byte {
+	res := make([]byte, util.GraffitiLength)
+	copy(res, input)
+
+	return res
+}
+
+func TestDecodeGraffiti(t *testing.T) {
+	tests := []struct {
+		name     string
+		graffiti []byte
+		res      *util.GraffitiInfo
+	}{
+		{
+			name:     "Nil",
+			graffiti: nil,
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingEmpty,
+			},
+		},
+		{
+			name:     "Zero",
+			graffiti: make([]byte, util.GraffitiLength),
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingEmpty,
+			},
+		},
+		{
+			name:     "Text",
+			graffiti: graffiti("hello world"),
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingUTF8,
+				Text:     "hello world",
+			},
+		},
+		{
+			name:     "Binary",
+			graffiti: []byte{0xff, 0xfe, 0x00},
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingHex,
+				Text:     "0xfffe",
+			},
+		},
+		{
+			name:     "ClientVersion",
+			graffiti: graffiti("GE1234LH5678 hello"),
+			res: &util.GraffitiInfo{
+				Encoding:              util.GraffitiEncodingUTF8,
+				Text:                  "GE1234LH5678 hello",
+				ExecutionClient:       "Geth",
+				ExecutionClientCommit: "1234",
+				ConsensusClient:       "Lighthouse",
+				ConsensusClientCommit: "5678",
+			},
+		},
+		{
+			name:     "ClientVersionNoCommits",
+			graffiti: graffiti("NMTK"),
+			res: &util.GraffitiInfo{
+				Encoding:        util.GraffitiEncodingUTF8,
+				Text:            "NMTK",
+				ExecutionClient: "Nethermind",
+				ConsensusClient: "Teku",
+			},
+		},
+		{
+			name:     "ClientVersionUnknownCode",
+			graffiti: graffiti("XX1234LH5678"),
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingUTF8,
+				Text:     "XX1234LH5678",
+			},
+		},
+		{
+			name:     "ClientNames",
+			graffiti: graffiti("Lighthouse/v4.5.0 + Geth"),
+			res: &util.GraffitiInfo{
+				Encoding: util.GraffitiEncodingUTF8,
+				Text:     "Lighthouse/v4.5.0 + Geth",
+				Clients:  []string{"geth", "lighthouse"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.DecodeGraffiti(test.graffiti))
+		})
+	}
+}
+
+func TestEncodeGraffiti(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		elCode   string
+		elCommit string
+		clCode   string
+		clCommit string
+		res      []byte
+		err      string
+	}{
+		{
+			name: "Empty",
+			res:  make([]byte, util.GraffitiLength),
+		},
+		{
+			name: "Text",
+			text: "hello world",
+			res:  graffiti("hello world"),
+		},
+		{
+			name: "TooLong",
+			text: "this graffiti is far too long to fit in a block",
+			err:  "graffiti is 47 bytes, maximum is 32",
+		},
+		{
+			name:     "ClientVersion",
+			text:     "hello",
+			elCode:   "ge",
+			elCommit: "0x1234abcd",
+			clCode:   "LH",
+			clCommit: "5678",
+			res:      graffiti("GE1234LH5678 hello"),
+		},
+		{
+			name:   "ClientVersionNoText",
+			elCode: "NM",
+			clCode: "TK",
+			res:    graffiti("NMTK"),
+		},
+		{
+			name:   "ELCodeUnknown",
+			elCode: "XX",
+			clCode: "LH",
+			err:    `unknown execution client code "XX"`,
+		},
+		{
+			name:   "CLCodeMissing",
+			elCode: "GE",
+			err:    `unknown consensus client code ""`,
+		},
+		{
+			name:     "ELCommitInvalid",
+			elCode:   "GE",
+			elCommit: "xyz",
+			clCode:   "LH",
+			err:      `invalid execution client commit: commit "xyz" is not hex`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.EncodeGraffiti(test.text, test.elCode, test.elCommit, test.clCode, test.clCommit)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
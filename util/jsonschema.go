@@ -0,0 +1,215 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonSchemaDialect is the JSON schema dialect used for generated schemas.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// maxSampleDepth is the maximum depth to which sample values are populated.
+const maxSampleDepth = 32
+
+// sampleMapKey is the key used for populated maps, allowing them to be
+// distinguished from objects with fixed properties.
+const sampleMapKey = "\x00"
+
+// JSONSchema is a JSON schema describing the JSON output of a command.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Version              uint64                 `json:"version,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+// GenerateJSONSchema generates a JSON schema for the named command's output
+// from one or more sample values of the types that the command outputs.
+//
+// The schema is generated from the JSON encoding of the samples, after all of
+// their fields have been populated, so it reflects any custom JSON marshalling
+// of the types.  If more than one sample is supplied the output can be any one
+// of them.
+//
+// The version should be increased whenever a change is made to the output of
+// the command that is not backwards-compatible.
+func GenerateJSONSchema(name string, version uint64, samples ...any) (*JSONSchema, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("no samples supplied")
+	}
+
+	schemas := make([]*JSONSchema, 0, len(samples))
+	for _, sample := range samples {
+		schema, err := jsonSchemaFromSample(sample)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	res := schemas[0]
+	if len(schemas) > 1 {
+		res = &JSONSchema{
+			OneOf: schemas,
+		}
+	}
+	res.Schema = jsonSchemaDialect
+	res.ID = fmt.Sprintf("urn:ethdo:%s:v%d", name, version)
+	res.Title = fmt.Sprintf("ethdo %s output", strings.ReplaceAll(name, "/", " "))
+	res.Version = version
+
+	return res, nil
+}
+
+// JSONSchemaFromSample generates a JSON schema for part of a command's output
+// from a sample value, for commands that add to the output of other types.
+func JSONSchemaFromSample(sample any) (*JSONSchema, error) {
+	return jsonSchemaFromSample(sample)
+}
+
+func jsonSchemaFromSample(sample any) (schema *JSONSchema, err error) {
+	if sample == nil {
+		return nil, errors.New("nil sample supplied")
+	}
+
+	// Custom marshallers can panic with unexpected data, so ensure that we
+	// return an error rather than crash.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to generate schema for %T: %v", sample, r)
+		}
+	}()
+
+	val := reflect.New(reflect.TypeOf(sample)).Elem()
+	populateSample(val, 0)
+
+	data, err := json.Marshal(val.Interface())
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to marshal sample %T", sample))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal sample")
+	}
+
+	return jsonSchemaFromValue(generic), nil
+}
+
+// populateSample populates a value such that all of its fields show up in its JSON encoding.
+func populateSample(val reflect.Value, depth int) {
+	if depth > maxSampleDepth || !val.CanSet() {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		populateSample(val.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			populateSample(val.Field(i), depth+1)
+		}
+	case reflect.Slice:
+		slice := reflect.MakeSlice(val.Type(), 1, 1)
+		populateSample(slice.Index(0), depth+1)
+		val.Set(slice)
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			populateSample(val.Index(i), depth+1)
+		}
+	case reflect.Map:
+		key := reflect.New(val.Type().Key()).Elem()
+		if key.Kind() == reflect.String {
+			key.SetString(sampleMapKey)
+		} else {
+			populateSample(key, depth+1)
+		}
+		elem := reflect.New(val.Type().Elem()).Elem()
+		populateSample(elem, depth+1)
+		m := reflect.MakeMap(val.Type())
+		m.SetMapIndex(key, elem)
+		val.Set(m)
+	case reflect.String:
+		val.SetString("x")
+	case reflect.Bool:
+		val.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		val.SetFloat(0.5)
+	default:
+		// Interfaces and other kinds cannot be usefully populated, so are left as-is.
+	}
+}
+
+// jsonSchemaFromValue generates a schema from a decoded JSON value.
+func jsonSchemaFromValue(val any) *JSONSchema {
+	switch v := val.(type) {
+	case bool:
+		return &JSONSchema{Type: "boolean"}
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return &JSONSchema{Type: "number"}
+		}
+		return &JSONSchema{Type: "integer"}
+	case string:
+		return &JSONSchema{Type: "string"}
+	case []any:
+		res := &JSONSchema{
+			Type:  "array",
+			Items: &JSONSchema{},
+		}
+		if len(v) > 0 {
+			res.Items = jsonSchemaFromValue(v[0])
+		}
+		return res
+	case map[string]any:
+		if elem, exists := v[sampleMapKey]; exists && len(v) == 1 {
+			return &JSONSchema{
+				Type:                 "object",
+				AdditionalProperties: jsonSchemaFromValue(elem),
+			}
+		}
+		res := &JSONSchema{
+			Type:       "object",
+			Properties: make(map[string]*JSONSchema, len(v)),
+		}
+		for k, elem := range v {
+			res.Properties[k] = jsonSchemaFromValue(elem)
+		}
+		return res
+	default:
+		// Null, which means that we do not know anything about the value.
+		return &JSONSchema{}
+	}
+}
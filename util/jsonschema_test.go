@@ -0,0 +1,109 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type schemaTestInner struct {
+	Name  string  `json:"name"`
+	Ratio float64 `json:"ratio"`
+}
+
+type schemaTestOuter struct {
+	Count    int                         `json:"count"`
+	Enabled  bool                        `json:"enabled,omitempty"`
+	Root     phase0.Root                 `json:"root"`
+	Inner    *schemaTestInner            `json:"inner"`
+	Inners   []*schemaTestInner          `json:"inners"`
+	Mapped   map[string]uint64           `json:"mapped"`
+	Anything any                         `json:"anything"`
+	Custom   *schemaTestCustom           `json:"custom"`
+	Ignored  string                      `json:"-"`
+	Nested   map[string]*schemaTestInner `json:"nested,omitempty"`
+}
+
+type schemaTestCustom struct {
+	Value uint64
+}
+
+func (s *schemaTestCustom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"value": fmt.Sprintf("%d", s.Value)})
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []any
+		res     string
+		err     string
+	}{
+		{
+			name: "SamplesMissing",
+			err:  "no samples supplied",
+		},
+		{
+			name:    "SampleNil",
+			samples: []any{nil},
+			err:     "nil sample supplied",
+		},
+		{
+			name:    "Simple",
+			samples: []any{&schemaTestInner{}},
+			res:     `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"urn:ethdo:test/command:v2","title":"ethdo test command output","version":2,"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}}`,
+		},
+		{
+			name:    "Complex",
+			samples: []any{&schemaTestOuter{}},
+			res:     `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"urn:ethdo:test/command:v2","title":"ethdo test command output","version":2,"type":"object","properties":{"anything":{},"count":{"type":"integer"},"custom":{"type":"object","properties":{"value":{"type":"string"}}},"enabled":{"type":"boolean"},"inner":{"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}},"inners":{"type":"array","items":{"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}}},"mapped":{"type":"object","additionalProperties":{"type":"integer"}},"nested":{"type":"object","additionalProperties":{"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}}},"root":{"type":"string"}}}`,
+		},
+		{
+			name:    "Multiple",
+			samples: []any{&schemaTestInner{}, []string{}},
+			res:     `{"$schema":"https://json-schema.org/draft/2020-12/schema","$id":"urn:ethdo:test/command:v2","title":"ethdo test command output","version":2,"oneOf":[{"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}},{"type":"array","items":{"type":"string"}}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.GenerateJSONSchema("test/command", 2, test.samples...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				data, err := json.Marshal(res)
+				require.NoError(t, err)
+				require.Equal(t, test.res, string(data))
+			}
+		})
+	}
+}
+
+func TestJSONSchemaFromSample(t *testing.T) {
+	_, err := util.JSONSchemaFromSample(nil)
+	require.EqualError(t, err, "nil sample supplied")
+
+	res, err := util.JSONSchemaFromSample(&schemaTestInner{})
+	require.NoError(t, err)
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.Equal(t, `{"type":"object","properties":{"name":{"type":"string"},"ratio":{"type":"number"}}}`, string(data))
+}
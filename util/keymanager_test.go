@@ -0,0 +1,72 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestConnectToKeymanager(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *util.KeymanagerConnectOpts
+		address string
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no options specified",
+		},
+		{
+			name: "AddressMissing",
+			opts: &util.KeymanagerConnectOpts{
+				Timeout: time.Second,
+			},
+			err: "no address specified",
+		},
+		{
+			name: "TimeoutMissing",
+			opts: &util.KeymanagerConnectOpts{
+				Address: "localhost:7500",
+			},
+			err: "no timeout specified",
+		},
+		{
+			name: "Good",
+			opts: &util.KeymanagerConnectOpts{
+				Address: "localhost:7500",
+				Token:   "secret",
+				Timeout: time.Second,
+			},
+			address: "http://localhost:7500",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := util.ConnectToKeymanager(context.Background(), test.opts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, client.Address())
+			}
+		})
+	}
+}
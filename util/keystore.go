@@ -0,0 +1,129 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// Keystore is an EIP-2335 keystore, with fields in the order used by the
+// deposit CLI.
+type Keystore struct {
+	Crypto      map[string]any `json:"crypto"`
+	Description string         `json:"description"`
+	PubKey      string         `json:"pubkey"`
+	Path        string         `json:"path"`
+	UUID        string         `json:"uuid"`
+	Version     uint           `json:"version"`
+}
+
+// NewAccountKeystore creates an EIP-2335 keystore holding the private key of
+// an account, encrypted with the keystore passphrase.  The account is unlocked
+// with one of the supplied passphrases if required, and relocked afterwards.
+func NewAccountKeystore(ctx context.Context,
+	account e2wtypes.Account,
+	description string,
+	passphrases []string,
+	encryptor *keystorev4.Encryptor,
+	keystorePassphrase string,
+) (
+	*Keystore,
+	error,
+) {
+	if _, isComposite := account.(e2wtypes.AccountCompositePublicKeyProvider); isComposite {
+		return nil, errors.New("distributed accounts cannot be exported as keystores")
+	}
+
+	key, err := accountPrivateKey(ctx, account, passphrases)
+	if err != nil {
+		return nil, err
+	}
+
+	crypto, err := encryptor.Encrypt(key.Marshal(), keystorePassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt private key")
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate UUID")
+	}
+	keystore := &Keystore{
+		Crypto:      crypto,
+		Description: description,
+		PubKey:      fmt.Sprintf("%x", key.PublicKey().Marshal()),
+		UUID:        id.String(),
+		Version:     4,
+	}
+	if pathProvider, isProvider := account.(e2wtypes.AccountPathProvider); isProvider {
+		keystore.Path = pathProvider.Path()
+	}
+
+	return keystore, nil
+}
+
+// accountPrivateKey obtains the private key of an account, unlocking it with
+// one of the supplied passphrases if required.
+func accountPrivateKey(ctx context.Context,
+	account e2wtypes.Account,
+	passphrases []string,
+) (
+	e2types.PrivateKey,
+	error,
+) {
+	privateKeyProvider, isPrivateKeyProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isPrivateKeyProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+
+	if locker, isLocker := account.(e2wtypes.AccountLocker); isLocker {
+		unlocked, err := locker.IsUnlocked(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find out if account is locked")
+		}
+		if !unlocked {
+			if err := CheckNotWatchOnly(account); err != nil {
+				return nil, err
+			}
+			for _, passphrase := range passphrases {
+				err = locker.Unlock(ctx, []byte(passphrase))
+				if err == nil {
+					unlocked = true
+					break
+				}
+			}
+			if !unlocked {
+				return nil, errors.New("failed to unlock account")
+			}
+			// Because we unlocked the account we should re-lock it when we're done.
+			defer func() {
+				if err := locker.Lock(ctx); err != nil {
+					Log.Trace().Err(err).Msg("Failed to lock account")
+				}
+			}()
+		}
+	}
+	key, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+
+	return key, nil
+}
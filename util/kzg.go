@@ -0,0 +1,372 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FieldElementsPerBlob is FIELD_ELEMENTS_PER_BLOB.
+	FieldElementsPerBlob = 4096
+	// BytesPerFieldElement is BYTES_PER_FIELD_ELEMENT.
+	BytesPerFieldElement = 32
+	// BytesPerBlob is BYTES_PER_BLOB.
+	BytesPerBlob = FieldElementsPerBlob * BytesPerFieldElement
+
+	// fiatShamirProtocolDomain is FIAT_SHAMIR_PROTOCOL_DOMAIN.
+	fiatShamirProtocolDomain = "FSBLOBVERIFY_V1_"
+	// primitiveRootOfUnity is PRIMITIVE_ROOT_OF_UNITY.
+	primitiveRootOfUnity = 7
+	// versionedHashVersionKZG is VERSIONED_HASH_VERSION_KZG.
+	versionedHashVersionKZG = 0x01
+
+	// g1GeneratorHex is the compressed generator of G1.
+	g1GeneratorHex = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	// g2GeneratorHex is the compressed generator of G2.
+	g2GeneratorHex = "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+	// mainnetG2TauHex is [τ]G2 from the mainnet trusted setup, the second
+	// point of g2_monomial in the setup produced by the KZG ceremony.
+	mainnetG2TauHex = "b5bfd7dd8cdeb128843bc287230af38926187075cbfbefa81009a2ce615ac53d2914e5870cb452d2afaaab24f3499f72185cbfee53492714734429b7b38608e23926c911cceceac9a36851477ba4c60b087041de621000edc98edada20c1def2"
+)
+
+// blsModulus is BLS_MODULUS.
+var blsModulus, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+var (
+	rootsOfUnityOnce sync.Once
+	rootsOfUnityBRP  []*big.Int
+)
+
+// KZGSetup is the part of a KZG trusted setup required to verify proofs.
+type KZGSetup struct {
+	// g2Tau is the second G2 point of the monomial setup, [τ]G2.
+	g2Tau bls.G2
+}
+
+type kzgSetupJSON struct {
+	G2Monomial []string `json:"g2_monomial"`
+	// SetupG2 is the name of the G2 points in earlier versions of the setup.
+	SetupG2 []string `json:"setup_G2"`
+}
+
+// MainnetKZGSetup returns the mainnet KZG trusted setup, which is also used by
+// the public testnets.
+func MainnetKZGSetup() (*KZGSetup, error) {
+	point, err := hex.DecodeString(mainnetG2TauHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid G2 point in trusted setup")
+	}
+
+	return kzgSetupFromG2Tau(point)
+}
+
+// LoadKZGSetup loads a KZG trusted setup from a file, in either the JSON
+// format of the consensus specifications or the text format of c-kzg.
+func LoadKZGSetup(path string) (*KZGSetup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read trusted setup")
+	}
+
+	return ParseKZGSetup(data)
+}
+
+// ParseKZGSetup parses a KZG trusted setup.
+func ParseKZGSetup(data []byte) (*KZGSetup, error) {
+	var g2Points []string
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		setup := &kzgSetupJSON{}
+		if err := json.Unmarshal(trimmed, setup); err != nil {
+			return nil, errors.Wrap(err, "invalid trusted setup JSON")
+		}
+		g2Points = setup.G2Monomial
+		if len(g2Points) == 0 {
+			g2Points = setup.SetupG2
+		}
+	} else {
+		var err error
+		g2Points, err = parseKZGSetupText(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(g2Points) < 2 {
+		return nil, errors.New("trusted setup does not contain G2 points")
+	}
+
+	point, err := hex.DecodeString(strings.TrimPrefix(g2Points[1], "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid G2 point in trusted setup")
+	}
+
+	return kzgSetupFromG2Tau(point)
+}
+
+// kzgSetupFromG2Tau creates a KZG trusted setup from its [τ]G2 point.
+func kzgSetupFromG2Tau(point []byte) (*KZGSetup, error) {
+	setup := &KZGSetup{}
+	if err := setup.g2Tau.Deserialize(point); err != nil {
+		return nil, errors.Wrap(err, "invalid G2 point in trusted setup")
+	}
+	if setup.g2Tau.IsZero() || !setup.g2Tau.IsValidOrder() {
+		return nil, errors.New("invalid G2 point in trusted setup")
+	}
+
+	return setup, nil
+}
+
+// parseKZGSetupText parses the text format of a trusted setup, returning its
+// G2 points.  The format is the number of G1 points, the number of G2 points,
+// the G1 points in Lagrange form and then the G2 points, one per line.
+func parseKZGSetupText(data []byte) ([]string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 2 {
+		return nil, errors.New("trusted setup too short")
+	}
+	var g1Count, g2Count int
+	if _, err := fmt.Sscanf(lines[0], "%d", &g1Count); err != nil {
+		return nil, errors.New("invalid G1 point count in trusted setup")
+	}
+	if _, err := fmt.Sscanf(lines[1], "%d", &g2Count); err != nil {
+		return nil, errors.New("invalid G2 point count in trusted setup")
+	}
+	if g1Count < 0 || g2Count < 0 || len(lines) < 2+g1Count+g2Count {
+		return nil, errors.New("trusted setup too short")
+	}
+
+	return lines[2+g1Count : 2+g1Count+g2Count], nil
+}
+
+// KZGCommitmentToVersionedHash returns the versioned hash of a KZG commitment,
+// as used to reference blobs from execution payload transactions.
+func KZGCommitmentToVersionedHash(commitment []byte) deneb.VersionedHash {
+	hash := sha256.Sum256(commitment)
+	hash[0] = versionedHashVersionKZG
+
+	return deneb.VersionedHash(hash)
+}
+
+// VerifyBlobKZGProof verifies that a blob matches its KZG commitment, using
+// the supplied proof, as per verify_blob_kzg_proof in the consensus specifications.
+func (s *KZGSetup) VerifyBlobKZGProof(blob []byte, commitment []byte, proof []byte) (bool, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return false, err
+	}
+	commitmentPoint, err := g1Point(commitment)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid commitment")
+	}
+	proofPoint, err := g1Point(proof)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid proof")
+	}
+
+	z := computeChallenge(blob, commitment)
+	y := evaluatePolynomial(polynomial, z)
+
+	return s.verifyKZGProof(commitmentPoint, z, y, proofPoint)
+}
+
+// verifyKZGProof verifies that the polynomial committed to has the value y at z.
+func (s *KZGSetup) verifyKZGProof(commitment *bls.G1, z *big.Int, y *big.Int, proof *bls.G1) (bool, error) {
+	g1, g2, err := kzgGenerators()
+	if err != nil {
+		return false, err
+	}
+
+	// [p(τ) - y]G1.
+	var pMinusY, yG1 bls.G1
+	bls.G1Mul(&yG1, g1, frElement(y))
+	bls.G1Sub(&pMinusY, commitment, &yG1)
+
+	// [τ - z]G2.
+	var xMinusZ, zG2 bls.G2
+	bls.G2Mul(&zG2, g2, frElement(z))
+	bls.G2Sub(&xMinusZ, &s.g2Tau, &zG2)
+
+	// Check e(p(τ) - y, -G2) · e(proof, τ - z) = 1.
+	var negG2 bls.G2
+	bls.G2Neg(&negG2, g2)
+	var res bls.GT
+	bls.MillerLoopVec(&res, []bls.G1{pMinusY, *proof}, []bls.G2{negG2, xMinusZ})
+	bls.FinalExp(&res, &res)
+
+	return res.IsOne(), nil
+}
+
+// blobToPolynomial converts a blob to its field elements.
+func blobToPolynomial(blob []byte) ([]*big.Int, error) {
+	if len(blob) != BytesPerBlob {
+		return nil, fmt.Errorf("blob has %d bytes, expected %d", len(blob), BytesPerBlob)
+	}
+	polynomial := make([]*big.Int, FieldElementsPerBlob)
+	for i := range polynomial {
+		polynomial[i] = new(big.Int).SetBytes(blob[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement])
+		if polynomial[i].Cmp(blsModulus) >= 0 {
+			return nil, fmt.Errorf("blob field element %d is not canonical", i)
+		}
+	}
+
+	return polynomial, nil
+}
+
+// computeChallenge computes the Fiat-Shamir challenge for a blob and its commitment.
+func computeChallenge(blob []byte, commitment []byte) *big.Int {
+	degree := make([]byte, 16)
+	new(big.Int).SetUint64(FieldElementsPerBlob).FillBytes(degree)
+
+	hash := sha256.New()
+	hash.Write([]byte(fiatShamirProtocolDomain))
+	hash.Write(degree)
+	hash.Write(blob)
+	hash.Write(commitment)
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(hash.Sum(nil)), blsModulus)
+}
+
+// evaluatePolynomial evaluates a polynomial in evaluation form at z, using
+// the barycentric formula.
+func evaluatePolynomial(polynomial []*big.Int, z *big.Int) *big.Int {
+	roots := kzgRootsOfUnity()
+	for i, root := range roots {
+		if root.Cmp(z) == 0 {
+			return new(big.Int).Set(polynomial[i])
+		}
+	}
+
+	res := new(big.Int)
+	for i, root := range roots {
+		numerator := new(big.Int).Mul(polynomial[i], root)
+		denominator := new(big.Int).Sub(z, root)
+		denominator.Mod(denominator, blsModulus)
+		denominator.ModInverse(denominator, blsModulus)
+		numerator.Mul(numerator, denominator)
+		res.Add(res, numerator)
+	}
+	res.Mod(res, blsModulus)
+
+	width := big.NewInt(int64(len(polynomial)))
+	factor := new(big.Int).Exp(z, width, blsModulus)
+	factor.Sub(factor, big.NewInt(1))
+	factor.Mul(factor, new(big.Int).ModInverse(width, blsModulus))
+	res.Mul(res, factor)
+
+	return res.Mod(res, blsModulus)
+}
+
+// kzgRootsOfUnity returns the roots of unity of the blob domain in bit-reversed order.
+func kzgRootsOfUnity() []*big.Int {
+	rootsOfUnityOnce.Do(func() {
+		exponent := new(big.Int).Sub(blsModulus, big.NewInt(1))
+		exponent.Div(exponent, big.NewInt(FieldElementsPerBlob))
+		root := new(big.Int).Exp(big.NewInt(primitiveRootOfUnity), exponent, blsModulus)
+
+		roots := make([]*big.Int, FieldElementsPerBlob)
+		current := big.NewInt(1)
+		for i := range roots {
+			roots[i] = new(big.Int).Set(current)
+			current.Mul(current, root)
+			current.Mod(current, blsModulus)
+		}
+
+		// Bit-reversal permutation.
+		bits := 0
+		for 1<<bits < FieldElementsPerBlob {
+			bits++
+		}
+		rootsOfUnityBRP = make([]*big.Int, FieldElementsPerBlob)
+		for i := range roots {
+			reversed := 0
+			for j := 0; j < bits; j++ {
+				if i&(1<<j) != 0 {
+					reversed |= 1 << (bits - 1 - j)
+				}
+			}
+			rootsOfUnityBRP[reversed] = roots[i]
+		}
+	})
+
+	return rootsOfUnityBRP
+}
+
+func kzgGenerators() (*bls.G1, *bls.G2, error) {
+	g1Data, err := hex.DecodeString(g1GeneratorHex)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid G1 generator")
+	}
+	var g1 bls.G1
+	if err := g1.Deserialize(g1Data); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid G1 generator")
+	}
+	g2Data, err := hex.DecodeString(g2GeneratorHex)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid G2 generator")
+	}
+	var g2 bls.G2
+	if err := g2.Deserialize(g2Data); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid G2 generator")
+	}
+
+	return &g1, &g2, nil
+}
+
+// g1Point deserializes a compressed G1 point, ensuring it is in the subgroup.
+func g1Point(data []byte) (*bls.G1, error) {
+	// Copy the data, as it may be part of a structure containing Go pointers
+	// that cannot be passed to the underlying library.
+	buf := make([]byte, len(data))
+	copy(buf, data)
+	var point bls.G1
+	if err := point.Deserialize(buf); err != nil {
+		return nil, err
+	}
+	if !point.IsValidOrder() {
+		return nil, errors.New("point not in G1")
+	}
+
+	return &point, nil
+}
+
+// frElement converts a field element to its BLS representation.
+func frElement(input *big.Int) *bls.Fr {
+	var res bls.Fr
+	if err := res.SetString(input.String(), 10); err != nil {
+		// Only called with reduced values, so cannot happen.
+		panic(err)
+	}
+
+	return &res
+}
@@ -0,0 +1,275 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testKZGSecret is the secret of the test trusted setup.
+var testKZGSecret = big.NewInt(1234567)
+
+// testKZGSetup returns the JSON of a trusted setup with a known secret.
+func testKZGSetup(t *testing.T) []byte {
+	t.Helper()
+
+	_, g2, err := kzgGenerators()
+	require.NoError(t, err)
+	var tau bls.G2
+	bls.G2Mul(&tau, g2, frElement(testKZGSecret))
+
+	return []byte(fmt.Sprintf(`{"g1_monomial":[],"g1_lagrange":[],"g2_monomial":["0x%s","0x%s"]}`,
+		hex.EncodeToString(g2.Serialize()),
+		hex.EncodeToString(tau.Serialize()),
+	))
+}
+
+// testBlob returns a blob along with its commitment and proof under the test setup.
+func testBlob(t *testing.T) ([]byte, []byte, []byte) {
+	t.Helper()
+
+	blob := make([]byte, BytesPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		new(big.Int).SetUint64(uint64(i*i + 7)).FillBytes(blob[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement])
+	}
+	polynomial, err := blobToPolynomial(blob)
+	require.NoError(t, err)
+
+	g1, _, err := kzgGenerators()
+	require.NoError(t, err)
+
+	// Commitment is [p(s)]G1.
+	ps := evaluatePolynomial(polynomial, testKZGSecret)
+	var commitment bls.G1
+	bls.G1Mul(&commitment, g1, frElement(ps))
+	commitmentData := commitment.Serialize()
+
+	// Proof is [(p(s) - y) / (s - z)]G1.
+	z := computeChallenge(blob, commitmentData)
+	y := evaluatePolynomial(polynomial, z)
+	numerator := new(big.Int).Sub(ps, y)
+	denominator := new(big.Int).Sub(testKZGSecret, z)
+	denominator.Mod(denominator, blsModulus)
+	quotient := numerator.Mul(numerator, denominator.ModInverse(denominator, blsModulus))
+	quotient.Mod(quotient, blsModulus)
+	var proof bls.G1
+	bls.G1Mul(&proof, g1, frElement(quotient))
+
+	return blob, commitmentData, proof.Serialize()
+}
+
+func TestKZGGenerators(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	g1, g2, err := kzgGenerators()
+	require.NoError(t, err)
+	require.True(t, g1.IsValidOrder())
+	require.True(t, g2.IsValidOrder())
+}
+
+func TestParseKZGSetup(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	setupJSON := testKZGSetup(t)
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "Empty",
+			data: []byte{},
+			err:  "trusted setup too short",
+		},
+		{
+			name: "JSONInvalid",
+			data: []byte(`{"g2_monomial":`),
+			err:  "invalid trusted setup JSON: unexpected end of JSON input",
+		},
+		{
+			name: "JSONNoG2",
+			data: []byte(`{"g1_monomial":[]}`),
+			err:  "trusted setup does not contain G2 points",
+		},
+		{
+			name: "JSONBadPoint",
+			data: []byte(`{"g2_monomial":["0x00","0x01"]}`),
+			err:  "invalid G2 point in trusted setup: err mclBnG2_deserialize 01",
+		},
+		{
+			name: "JSON",
+			data: setupJSON,
+		},
+		{
+			name: "TextShort",
+			data: []byte("4096\n65\n"),
+			err:  "trusted setup too short",
+		},
+		{
+			name: "TextBadCount",
+			data: []byte("abc\n65\n"),
+			err:  "invalid G1 point count in trusted setup",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseKZGSetup(test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseKZGSetupText(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	jsonSetup, err := ParseKZGSetup(testKZGSetup(t))
+	require.NoError(t, err)
+
+	// Text format with one G1 point and two G2 points.
+	_, g2, err := kzgGenerators()
+	require.NoError(t, err)
+	text := fmt.Sprintf("1\n2\n%s\n%s\n%s\n",
+		g1GeneratorHex,
+		hex.EncodeToString(g2.Serialize()),
+		hex.EncodeToString(jsonSetup.g2Tau.Serialize()),
+	)
+	textSetup, err := ParseKZGSetup([]byte(text))
+	require.NoError(t, err)
+	require.True(t, jsonSetup.g2Tau.IsEqual(&textSetup.g2Tau))
+}
+
+func TestVerifyBlobKZGProof(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	setup, err := ParseKZGSetup(testKZGSetup(t))
+	require.NoError(t, err)
+	blob, commitment, proof := testBlob(t)
+
+	badBlob := make([]byte, len(blob))
+	copy(badBlob, blob)
+	badBlob[100] ^= 0x01
+
+	nonCanonicalBlob := make([]byte, len(blob))
+	copy(nonCanonicalBlob, blob)
+	for i := 0; i < BytesPerFieldElement; i++ {
+		nonCanonicalBlob[i] = 0xff
+	}
+
+	tests := []struct {
+		name       string
+		blob       []byte
+		commitment []byte
+		proof      []byte
+		res        bool
+		err        string
+	}{
+		{
+			name:       "BlobShort",
+			blob:       blob[:100],
+			commitment: commitment,
+			proof:      proof,
+			err:        "blob has 100 bytes, expected 131072",
+		},
+		{
+			name:       "BlobNonCanonical",
+			blob:       nonCanonicalBlob,
+			commitment: commitment,
+			proof:      proof,
+			err:        "blob field element 0 is not canonical",
+		},
+		{
+			name:       "CommitmentInvalid",
+			blob:       blob,
+			commitment: []byte{0x01},
+			proof:      proof,
+			err:        "invalid commitment",
+		},
+		{
+			name:       "Good",
+			blob:       blob,
+			commitment: commitment,
+			proof:      proof,
+			res:        true,
+		},
+		{
+			name:       "BlobMismatch",
+			blob:       badBlob,
+			commitment: commitment,
+			proof:      proof,
+		},
+		{
+			name:       "ProofMismatch",
+			blob:       blob,
+			commitment: commitment,
+			proof:      commitment,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := setup.VerifyBlobKZGProof(test.blob, test.commitment, test.proof)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestMainnetKZGSetup(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	setup, err := MainnetKZGSetup()
+	require.NoError(t, err)
+
+	// Test vector from the c-kzg project: a blob with a single field element of 1.
+	blob := make([]byte, BytesPerBlob)
+	blob[3211*BytesPerFieldElement+BytesPerFieldElement-1] = 0x01
+	commitment, err := hex.DecodeString("93efc82d2017e9c57834a1246463e64774e56183bb247c8fc9dd98c56817e878d97b05f5c8d900acf1fbbbca6f146556")
+	require.NoError(t, err)
+	proof, err := hex.DecodeString("9720099d507280aba6a9c9e8c31187336d10dc6a4b04646d1aa42c8d38f891de36f939313cb99e9e7953606555db269a")
+	require.NoError(t, err)
+	incorrectProof, err := hex.DecodeString("8e5995b8136efc6e4a6d915ecfbeef542a44c1749afef58cac423e24e8dc2d03387faea0adc29ad454cdeae0be44d139")
+	require.NoError(t, err)
+
+	valid, err := setup.VerifyBlobKZGProof(blob, commitment, proof)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = setup.VerifyBlobKZGProof(blob, commitment, incorrectProof)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestKZGCommitmentToVersionedHash(t *testing.T) {
+	// Commitment to the empty blob, the point at infinity.
+	commitment := make([]byte, 48)
+	commitment[0] = 0xc0
+	hash := KZGCommitmentToVersionedHash(commitment)
+	require.Equal(t, "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014", fmt.Sprintf("%#x", hash))
+}
@@ -0,0 +1,234 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+)
+
+// maxMerkleDepth is the maximum depth of tree handled by the merkle functions.
+const maxMerkleDepth = 63
+
+// zeroHashes are the roots of empty trees of increasing depth.
+var zeroHashes = func() []phase0.Root {
+	res := make([]phase0.Root, maxMerkleDepth+1)
+	for i := 1; i <= maxMerkleDepth; i++ {
+		res[i] = sha256.Sum256(append(res[i-1][:], res[i-1][:]...))
+	}
+
+	return res
+}()
+
+// MerkleRoot returns the root of a tree of the given depth containing the
+// leaves, with absent leaves being zero.
+func MerkleRoot(leaves []phase0.Root, depth int) (phase0.Root, error) {
+	root, _, err := merkleize(leaves, depth, 0)
+
+	return root, err
+}
+
+// MerkleBranch returns the sibling roots required to prove the leaf at the
+// given index of a tree of the given depth, ordered from the leaf upwards.
+func MerkleBranch(leaves []phase0.Root, depth int, index uint64) ([]phase0.Root, error) {
+	if index>>depth != 0 {
+		return nil, fmt.Errorf("index %d out of range for depth %d", index, depth)
+	}
+	_, branch, err := merkleize(leaves, depth, index)
+
+	return branch, err
+}
+
+func merkleize(leaves []phase0.Root, depth int, index uint64) (phase0.Root, []phase0.Root, error) {
+	if depth < 0 || depth > maxMerkleDepth {
+		return phase0.Root{}, nil, fmt.Errorf("invalid depth %d", depth)
+	}
+	if uint64(len(leaves)) > 1<<depth {
+		return phase0.Root{}, nil, fmt.Errorf("%d leaves do not fit in a tree of depth %d", len(leaves), depth)
+	}
+
+	branch := make([]phase0.Root, 0, depth)
+	layer := leaves
+	for level := 0; level < depth; level++ {
+		sibling := index ^ 1
+		if sibling < uint64(len(layer)) {
+			branch = append(branch, layer[sibling])
+		} else {
+			branch = append(branch, zeroHashes[level])
+		}
+
+		next := make([]phase0.Root, (len(layer)+1)/2)
+		for i := range next {
+			right := zeroHashes[level]
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			next[i] = sha256.Sum256(append(layer[2*i][:], right[:]...))
+		}
+		layer = next
+		index >>= 1
+	}
+
+	if len(layer) == 0 {
+		return zeroHashes[depth], branch, nil
+	}
+
+	return layer[0], branch, nil
+}
+
+// MerkleDepth returns the depth of the smallest tree that holds the given
+// number of leaves.
+func MerkleDepth(leaves uint64) int {
+	if leaves <= 1 {
+		return 0
+	}
+
+	return bits.Len64(leaves - 1)
+}
+
+// ConcatGeneralizedIndices returns the generalized index of a node in a
+// subtree, given the generalized index of the subtree's root and the
+// generalized index of the node within the subtree.
+func ConcatGeneralizedIndices(outer uint64, inner uint64) (uint64, error) {
+	if outer == 0 || inner == 0 {
+		return 0, errors.New("generalized index cannot be 0")
+	}
+	innerDepth := bits.Len64(inner) - 1
+	if bits.Len64(outer)+innerDepth > 64 {
+		return 0, errors.New("generalized index overflow")
+	}
+
+	return outer<<innerDepth | (inner ^ 1<<innerDepth), nil
+}
+
+// VerifyMerkleProof verifies that the leaf is at the given generalized index
+// of the tree with the given root.
+func VerifyMerkleProof(leaf phase0.Root, branch []phase0.Root, generalizedIndex uint64, root phase0.Root) bool {
+	if len(branch) > maxMerkleDepth || generalizedIndex>>len(branch) != 1 {
+		return false
+	}
+
+	value := leaf
+	for i := range branch {
+		if (generalizedIndex>>i)&1 == 1 {
+			value = sha256.Sum256(append(branch[i][:], value[:]...))
+		} else {
+			value = sha256.Sum256(append(value[:], branch[i][:]...))
+		}
+	}
+
+	return value == root
+}
+
+// ContainerFieldRoots returns the hash tree roots of the top-level fields of
+// an SSZ container, such as a beacon state.
+func ContainerFieldRoots(container ssz.HashRoot) ([]phase0.Root, error) {
+	walker := &fieldRootsWalker{
+		Hasher: ssz.NewHasher(),
+	}
+	if err := container.HashTreeRootWith(walker); err != nil {
+		return nil, errors.Wrap(err, "failed to hash container")
+	}
+	if walker.depth != 0 {
+		return nil, errors.New("unbalanced container hashing")
+	}
+
+	return walker.roots, nil
+}
+
+// fieldRootsWalker records the roots of a container's fields as they are
+// hashed.  depth tracks the nesting of containers and lists; a write at depth 1
+// completes a top-level field.
+type fieldRootsWalker struct {
+	*ssz.Hasher
+	depth int
+	roots []phase0.Root
+}
+
+func (w *fieldRootsWalker) recordField() {
+	if w.depth == 1 {
+		w.roots = append(w.roots, phase0.Root(w.Hasher.Hash()))
+	}
+}
+
+// Index implements ssz.HashWalker.
+func (w *fieldRootsWalker) Index() int {
+	w.depth++
+
+	return w.Hasher.Index()
+}
+
+// Merkleize implements ssz.HashWalker.
+func (w *fieldRootsWalker) Merkleize(indx int) {
+	// The final merkleization of the container itself needs the field roots intact.
+	if w.depth > 1 {
+		w.Hasher.Merkleize(indx)
+	}
+	w.depth--
+	w.recordField()
+}
+
+// MerkleizeWithMixin implements ssz.HashWalker.
+func (w *fieldRootsWalker) MerkleizeWithMixin(indx int, num uint64, limit uint64) {
+	w.Hasher.MerkleizeWithMixin(indx, num, limit)
+	w.depth--
+	w.recordField()
+}
+
+// PutUint64 implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutUint64(i uint64) {
+	w.Hasher.PutUint64(i)
+	w.recordField()
+}
+
+// PutUint32 implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutUint32(i uint32) {
+	w.Hasher.PutUint32(i)
+	w.recordField()
+}
+
+// PutUint16 implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutUint16(i uint16) {
+	w.Hasher.PutUint16(i)
+	w.recordField()
+}
+
+// PutUint8 implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutUint8(i uint8) {
+	w.Hasher.PutUint8(i)
+	w.recordField()
+}
+
+// PutBitlist implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutBitlist(bb []byte, maxSize uint64) {
+	w.Hasher.PutBitlist(bb, maxSize)
+	w.recordField()
+}
+
+// PutBool implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutBool(b bool) {
+	w.Hasher.PutBool(b)
+	w.recordField()
+}
+
+// PutBytes implements ssz.HashWalker.
+func (w *fieldRootsWalker) PutBytes(b []byte) {
+	w.Hasher.PutBytes(b)
+	w.recordField()
+}
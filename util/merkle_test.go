@@ -0,0 +1,172 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func testRoots(count int) []phase0.Root {
+	res := make([]phase0.Root, count)
+	for i := range res {
+		res[i] = sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+	}
+
+	return res
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := testRoots(3)
+	zero := phase0.Root{}
+	left := sha256.Sum256(append(leaves[0][:], leaves[1][:]...))
+	right := sha256.Sum256(append(leaves[2][:], zero[:]...))
+	expected := phase0.Root(sha256.Sum256(append(left[:], right[:]...)))
+
+	root, err := util.MerkleRoot(leaves, 2)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	_, err = util.MerkleRoot(leaves, 1)
+	require.EqualError(t, err, "3 leaves do not fit in a tree of depth 1")
+
+	// An empty tree has the zero hash of its depth as its root.
+	zeroLayer := sha256.Sum256(make([]byte, 64))
+	root, err = util.MerkleRoot(nil, 1)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(zeroLayer), root)
+}
+
+func TestMerkleBranch(t *testing.T) {
+	leaves := testRoots(5)
+	for _, depth := range []int{3, 10} {
+		root, err := util.MerkleRoot(leaves, depth)
+		require.NoError(t, err)
+		for index := range leaves {
+			branch, err := util.MerkleBranch(leaves, depth, uint64(index))
+			require.NoError(t, err)
+			require.Len(t, branch, depth)
+			require.True(t, util.VerifyMerkleProof(leaves[index], branch, 1<<depth|uint64(index), root))
+			// Incorrect index.
+			require.False(t, util.VerifyMerkleProof(leaves[index], branch, 1<<depth|uint64(index^1), root))
+		}
+	}
+
+	_, err := util.MerkleBranch(leaves, 3, 8)
+	require.EqualError(t, err, "index 8 out of range for depth 3")
+}
+
+func TestVerifyMerkleProofDepthMismatch(t *testing.T) {
+	leaves := testRoots(4)
+	root, err := util.MerkleRoot(leaves, 2)
+	require.NoError(t, err)
+	branch, err := util.MerkleBranch(leaves, 2, 1)
+	require.NoError(t, err)
+
+	require.True(t, util.VerifyMerkleProof(leaves[1], branch, 5, root))
+	require.False(t, util.VerifyMerkleProof(leaves[1], branch, 9, root))
+	require.False(t, util.VerifyMerkleProof(leaves[1], branch, 0, root))
+}
+
+func TestMerkleDepth(t *testing.T) {
+	require.Equal(t, 0, util.MerkleDepth(0))
+	require.Equal(t, 0, util.MerkleDepth(1))
+	require.Equal(t, 1, util.MerkleDepth(2))
+	require.Equal(t, 5, util.MerkleDepth(28))
+	require.Equal(t, 5, util.MerkleDepth(32))
+	require.Equal(t, 13, util.MerkleDepth(8192))
+	require.Equal(t, 24, util.MerkleDepth(16777216))
+}
+
+func TestConcatGeneralizedIndices(t *testing.T) {
+	res, err := util.ConcatGeneralizedIndices(37, 8192+5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(37*8192+5), res)
+
+	res, err = util.ConcatGeneralizedIndices(1, 6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), res)
+
+	_, err = util.ConcatGeneralizedIndices(0, 6)
+	require.EqualError(t, err, "generalized index cannot be 0")
+
+	_, err = util.ConcatGeneralizedIndices(1<<40, 1<<30)
+	require.EqualError(t, err, "generalized index overflow")
+}
+
+func TestContainerFieldRoots(t *testing.T) {
+	pubKeys := make([]phase0.BLSPubKey, 512)
+	for i := range pubKeys {
+		pubKeys[i][0] = byte(i)
+	}
+
+	tests := []struct {
+		name      string
+		container ssz.HashRoot
+		fields    int
+	}{
+		{
+			name: "BeaconBlockHeader",
+			container: &phase0.BeaconBlockHeader{
+				Slot:          1,
+				ProposerIndex: 2,
+				ParentRoot:    phase0.Root{0x03},
+				StateRoot:     phase0.Root{0x04},
+				BodyRoot:      phase0.Root{0x05},
+			},
+			fields: 5,
+		},
+		{
+			name: "SyncCommittee",
+			container: &altair.SyncCommittee{
+				Pubkeys:         pubKeys,
+				AggregatePubkey: phase0.BLSPubKey{0x01},
+			},
+			fields: 2,
+		},
+		{
+			name: "ExecutionPayloadHeader",
+			container: &deneb.ExecutionPayloadHeader{
+				ParentHash:    phase0.Hash32{0x01},
+				BlockNumber:   2,
+				ExtraData:     []byte("extra data"),
+				BaseFeePerGas: uint256.NewInt(7),
+				BlobGasUsed:   131072,
+			},
+			fields: 17,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roots, err := util.ContainerFieldRoots(test.container)
+			require.NoError(t, err)
+			require.Len(t, roots, test.fields)
+
+			expected, err := test.container.HashTreeRoot()
+			require.NoError(t, err)
+			root, err := util.MerkleRoot(roots, util.MerkleDepth(uint64(len(roots))))
+			require.NoError(t, err)
+			require.Equal(t, phase0.Root(expected), root)
+		})
+	}
+}
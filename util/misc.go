@@ -33,13 +33,20 @@ import (
 
 // SetupStore sets up the account store.
 func SetupStore() error {
-	var store e2wtypes.Store
-	var err error
 	if viper.GetString("remote") != "" {
 		// We are using a remote account manager, so no local setup required.
 		return nil
 	}
 
+	return SetupLocalStore()
+}
+
+// SetupLocalStore sets up the local account store, regardless of the use
+// of a remote account manager.
+func SetupLocalStore() error {
+	var store e2wtypes.Store
+	var err error
+
 	// Set up our wallet store.
 	switch viper.GetString("store") {
 	case "s3":
@@ -93,33 +100,71 @@ func WalletFromInput(ctx context.Context) (e2wtypes.Wallet, error) {
 
 // WalletFromPath obtains a wallet given a path specification.
 func WalletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
+	if viper.GetString("remote") != "" {
+		return RemoteWalletFromPath(ctx, path)
+	}
+
+	return LocalWalletFromPath(ctx, path)
+}
+
+// RemoteWalletFromPath obtains a wallet from the remote account manager
+// given a path specification.
+func RemoteWalletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 	walletName, _, err := e2wallet.WalletAndAccountNames(path)
 	if err != nil {
 		return nil, err
 	}
-	if viper.GetString("remote") != "" {
-		if viper.GetString("client-cert") == "" {
-			return nil, errors.New("remote connections require client-cert")
-		}
-		if viper.GetString("client-key") == "" {
-			return nil, errors.New("remote connections require client-key")
-		}
-		credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to build dirk credentials")
-		}
+	if viper.GetString("remote") == "" {
+		return nil, errors.New("remote is required")
+	}
 
-		endpoints, err := remotesToEndpoints([]string{viper.GetString("remote")})
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to parse remote servers")
-		}
+	return remoteWallet(ctx, walletName, viper.GetString("remote"))
+}
 
-		return dirk.Open(ctx,
-			dirk.WithName(walletName),
-			dirk.WithCredentials(credentials),
-			dirk.WithEndpoints(endpoints),
-			dirk.WithTimeout(viper.GetDuration("timeout")),
-		)
+// RemoteWalletAtEndpoint obtains a wallet from a specific remote account
+// manager given a path specification, for example to query an individual
+// participant of a distributed account.
+func RemoteWalletAtEndpoint(ctx context.Context, path string, remote string) (e2wtypes.Wallet, error) {
+	walletName, _, err := e2wallet.WalletAndAccountNames(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return remoteWallet(ctx, walletName, remote)
+}
+
+// remoteWallet opens a wallet on a remote account manager.
+func remoteWallet(ctx context.Context, walletName string, remote string) (e2wtypes.Wallet, error) {
+	if viper.GetString("client-cert") == "" {
+		return nil, errors.New("remote connections require client-cert")
+	}
+	if viper.GetString("client-key") == "" {
+		return nil, errors.New("remote connections require client-key")
+	}
+	credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build dirk credentials")
+	}
+
+	endpoints, err := remotesToEndpoints([]string{remote})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse remote servers")
+	}
+
+	return dirk.Open(ctx,
+		dirk.WithName(walletName),
+		dirk.WithCredentials(credentials),
+		dirk.WithEndpoints(endpoints),
+		dirk.WithTimeout(viper.GetDuration("timeout")),
+	)
+}
+
+// LocalWalletFromPath obtains a wallet from the local store given a path
+// specification.
+func LocalWalletFromPath(_ context.Context, path string) (e2wtypes.Wallet, error) {
+	walletName, _, err := e2wallet.WalletAndAccountNames(path)
+	if err != nil {
+		return nil, err
 	}
 	wallet, err := e2wallet.OpenWallet(walletName)
 	if err != nil {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	"github.com/pkg/errors"
 )
 
@@ -43,10 +44,11 @@ func Network(ctx context.Context, eth2Client eth2client.Service) (string, error)
 	if !isProvider {
 		return "", errors.New("client does not provide deposit contract address")
 	}
-	config, err := provider.Spec(ctx)
+	configResponse, err := provider.Spec(ctx, &api.SpecOpts{})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to obtain chain specification")
 	}
+	config := configResponse.Data
 	if config == nil {
 		return "", errors.New("failed to return chain specification")
 	}
@@ -0,0 +1,188 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/viper"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// Unit is a unit in which balances are rendered.
+type Unit string
+
+const (
+	// Auto chooses the unit to suit the value.
+	Auto Unit = "auto"
+	// Ether renders balances in Ether.
+	Ether Unit = "ether"
+	// Gwei renders balances in Gwei.
+	Gwei Unit = "gwei"
+)
+
+// separators are the thousands separator and decimal point for each supported locale.
+var separators = map[string][2]string{
+	"en":    {",", "."},
+	"de":    {".", ","},
+	"de-ch": {"'", "."},
+	"es":    {".", ","},
+	"fr":    {" ", ","},
+	"it":    {".", ","},
+	"nl":    {".", ","},
+}
+
+// BalanceFormat is the format in which balances are rendered in text output.
+// A nil balance format renders balances as if no options were supplied.
+type BalanceFormat struct {
+	// Unit is the unit in which balances are rendered.
+	Unit Unit
+	// Decimals is the number of decimal places rendered, or -1 to render as many
+	// as are required.
+	Decimals int
+	// Separators is true if the integer part of balances is rendered with
+	// thousands separators.
+	Separators bool
+	// Locale is the locale that defines the thousands separator and decimal point.
+	Locale string
+}
+
+// ParseUnit parses a balance unit.  An empty input is auto.
+func ParseUnit(input string) (Unit, error) {
+	switch strings.ToLower(input) {
+	case "", "auto":
+		return Auto, nil
+	case "ether", "eth":
+		return Ether, nil
+	case "gwei":
+		return Gwei, nil
+	default:
+		return "", fmt.Errorf("unsupported balance unit %q", input)
+	}
+}
+
+// BalanceFormatFromViper obtains the balance format from the balance-unit,
+// balance-decimals, balance-separators and balance-locale options.
+func BalanceFormatFromViper() (*BalanceFormat, error) {
+	unit, err := ParseUnit(viper.GetString("balance-unit"))
+	if err != nil {
+		return nil, err
+	}
+
+	format := &BalanceFormat{
+		Unit:       unit,
+		Decimals:   -1,
+		Separators: viper.GetBool("balance-separators"),
+		Locale:     "en",
+	}
+
+	if viper.IsSet("balance-decimals") {
+		format.Decimals = viper.GetInt("balance-decimals")
+		if format.Decimals < -1 || format.Decimals > 18 {
+			return nil, fmt.Errorf("balance decimals must be between -1 and 18, not %d", format.Decimals)
+		}
+	}
+
+	if viper.GetString("balance-locale") != "" {
+		format.Locale = strings.ToLower(viper.GetString("balance-locale"))
+		if _, exists := separators[format.Locale]; !exists {
+			return nil, fmt.Errorf("unsupported balance locale %q", viper.GetString("balance-locale"))
+		}
+	}
+
+	return format, nil
+}
+
+// IsStandard returns true if the balance format is the format used when no
+// balance options are supplied, allowing commands to retain their own
+// formatting in that case.
+func (f *BalanceFormat) IsStandard() bool {
+	return f == nil ||
+		(f.Unit == Auto || f.Unit == "") &&
+			f.Decimals < 0 &&
+			!f.Separators &&
+			(f.Locale == "en" || f.Locale == "")
+}
+
+// Gwei renders a balance in Gwei.
+func (f *BalanceFormat) Gwei(value uint64) string {
+	if f.IsStandard() {
+		return string2eth.GWeiToString(value, true)
+	}
+
+	return f.render(decimal.NewFromBigInt(new(big.Int).SetUint64(value), 0))
+}
+
+// SignedGwei renders a balance in Gwei that may be negative.
+func (f *BalanceFormat) SignedGwei(value int64) string {
+	if value < 0 {
+		return fmt.Sprintf("-%s", f.Gwei(uint64(-value)))
+	}
+
+	return f.Gwei(uint64(value))
+}
+
+func (f *BalanceFormat) render(gwei decimal.Decimal) string {
+	amount := gwei.Shift(-9)
+	unit := "Ether"
+	if f.Unit == Gwei {
+		amount = gwei
+		unit = "Gwei"
+	}
+
+	var str string
+	if f.Decimals >= 0 {
+		str = amount.StringFixed(int32(f.Decimals))
+	} else {
+		str = amount.String()
+	}
+
+	chars, exists := separators[f.Locale]
+	if !exists {
+		chars = separators["en"]
+	}
+	integer, fraction, _ := strings.Cut(str, ".")
+	if f.Separators {
+		integer = groupThousands(integer, chars[0])
+	}
+	if fraction != "" {
+		integer = fmt.Sprintf("%s%s%s", integer, chars[1], fraction)
+	}
+
+	return fmt.Sprintf("%s %s", integer, unit)
+}
+
+// groupThousands inserts a separator between each group of three digits.
+func groupThousands(digits string, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	builder := strings.Builder{}
+	lead := len(digits) % 3
+	if lead > 0 {
+		builder.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if builder.Len() > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(digits[i : i+3])
+	}
+
+	return builder.String()
+}
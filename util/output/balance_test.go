@@ -0,0 +1,170 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestBalanceFormatFromViper(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  *output.BalanceFormat
+		err  string
+	}{
+		{
+			name: "Default",
+			res:  &output.BalanceFormat{Unit: output.Auto, Decimals: -1, Locale: "en"},
+		},
+		{
+			name: "Full",
+			vars: map[string]interface{}{
+				"balance-unit":       "ETH",
+				"balance-decimals":   4,
+				"balance-separators": true,
+				"balance-locale":     "DE",
+			},
+			res: &output.BalanceFormat{Unit: output.Ether, Decimals: 4, Separators: true, Locale: "de"},
+		},
+		{
+			name: "UnitInvalid",
+			vars: map[string]interface{}{
+				"balance-unit": "wei",
+			},
+			err: `unsupported balance unit "wei"`,
+		},
+		{
+			name: "DecimalsInvalid",
+			vars: map[string]interface{}{
+				"balance-decimals": -2,
+			},
+			err: "balance decimals must be between -1 and 18, not -2",
+		},
+		{
+			name: "LocaleInvalid",
+			vars: map[string]interface{}{
+				"balance-locale": "xx",
+			},
+			err: `unsupported balance locale "xx"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := output.BalanceFormatFromViper()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestBalanceFormatGwei(t *testing.T) {
+	tests := []struct {
+		name   string
+		format *output.BalanceFormat
+		value  int64
+		res    string
+	}{
+		{
+			name:  "Nil",
+			value: 32000123000,
+			res:   "32.000123 Ether",
+		},
+		{
+			name:   "Standard",
+			format: &output.BalanceFormat{Unit: output.Auto, Decimals: -1, Locale: "en"},
+			value:  1,
+			res:    "1 GWei",
+		},
+		{
+			name:   "StandardNegative",
+			format: &output.BalanceFormat{Unit: output.Auto, Decimals: -1, Locale: "en"},
+			value:  -32000123000,
+			res:    "-32.000123 Ether",
+		},
+		{
+			name:   "Gwei",
+			format: &output.BalanceFormat{Unit: output.Gwei, Decimals: -1, Locale: "en"},
+			value:  32000123000,
+			res:    "32000123000 Gwei",
+		},
+		{
+			name:   "GweiSeparators",
+			format: &output.BalanceFormat{Unit: output.Gwei, Decimals: -1, Separators: true, Locale: "en"},
+			value:  32000123000,
+			res:    "32,000,123,000 Gwei",
+		},
+		{
+			name:   "EtherDecimals",
+			format: &output.BalanceFormat{Unit: output.Ether, Decimals: 2, Locale: "en"},
+			value:  32005123000,
+			res:    "32.01 Ether",
+		},
+		{
+			name:   "EtherNoDecimals",
+			format: &output.BalanceFormat{Unit: output.Ether, Decimals: 0, Locale: "en"},
+			value:  32000123000,
+			res:    "32 Ether",
+		},
+		{
+			name:   "EtherPadded",
+			format: &output.BalanceFormat{Unit: output.Ether, Decimals: 4, Locale: "en"},
+			value:  1000000000,
+			res:    "1.0000 Ether",
+		},
+		{
+			name:   "LocaleGerman",
+			format: &output.BalanceFormat{Unit: output.Ether, Decimals: -1, Separators: true, Locale: "de"},
+			value:  1234567890123456,
+			res:    "1.234.567,890123456 Ether",
+		},
+		{
+			name:   "LocaleSwiss",
+			format: &output.BalanceFormat{Unit: output.Gwei, Decimals: -1, Separators: true, Locale: "de-ch"},
+			value:  1234,
+			res:    "1'234 Gwei",
+		},
+		{
+			name:   "AutoWithOptions",
+			format: &output.BalanceFormat{Unit: output.Auto, Decimals: 3, Locale: "en"},
+			value:  1,
+			res:    "0.000 Ether",
+		},
+		{
+			name:   "Negative",
+			format: &output.BalanceFormat{Unit: output.Gwei, Decimals: -1, Separators: true, Locale: "en"},
+			value:  -123456,
+			res:    "-123,456 Gwei",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, test.format.SignedGwei(test.value))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/pkg/errors"
+)
+
+// CSVString generates CSV data from a header and records.  The header is not
+// output if it is nil, allowing further records to be added to existing
+// output.  The output does not have a trailing newline.
+func CSVString(header []string, records [][]string) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	if header != nil {
+		if err := writer.Write(header); err != nil {
+			return "", errors.Wrap(err, "failed to write CSV header")
+		}
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", errors.Wrap(err, "failed to write CSV records")
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
@@ -0,0 +1,61 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestCSVString(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []string
+		records [][]string
+		res     string
+	}{
+		{
+			name:    "HeaderOnly",
+			header:  []string{"slot", "graffiti"},
+			records: [][]string{},
+			res:     "slot,graffiti",
+		},
+		{
+			name:   "Records",
+			header: []string{"slot", "graffiti"},
+			records: [][]string{
+				{"1", "plain"},
+				{"2", `with "quotes", and commas`},
+			},
+			res: "slot,graffiti\n1,plain\n2,\"with \"\"quotes\"\", and commas\"",
+		},
+		{
+			name: "NoHeader",
+			records: [][]string{
+				{"3", ""},
+			},
+			res: "3,",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output.CSVString(test.header, test.records)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
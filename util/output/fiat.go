@@ -0,0 +1,56 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Fiat renders amounts of Ether as their equivalent in a fiat currency.
+type Fiat struct {
+	// Currency is the fiat currency, for example "usd".
+	Currency string
+	// Price is the price of a single Ether in the currency.
+	Price decimal.Decimal
+}
+
+// Gwei renders the fiat equivalent of a value in Gwei, which may be negative.
+func (f *Fiat) Gwei(value int64) string {
+	return f.render(decimal.New(value, -9))
+}
+
+// Wei renders the fiat equivalent of a value in Wei.
+func (f *Fiat) Wei(value *big.Int) string {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return f.render(decimal.NewFromBigInt(value, -18))
+}
+
+func (f *Fiat) render(ether decimal.Decimal) string {
+	str := ether.Mul(f.Price).StringFixed(2)
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign = "-"
+		str = str[1:]
+	}
+	integer, fraction, _ := strings.Cut(str, ".")
+
+	return fmt.Sprintf("%s%s.%s %s", sign, groupThousands(integer, ","), fraction, strings.ToUpper(f.Currency))
+}
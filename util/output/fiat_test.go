@@ -0,0 +1,38 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestFiat(t *testing.T) {
+	fiat := &output.Fiat{
+		Currency: "usd",
+		Price:    decimal.RequireFromString("2345.67"),
+	}
+	require.Equal(t, "2,345.67 USD", fiat.Gwei(1000000000))
+	require.Equal(t, "0.00 USD", fiat.Gwei(0))
+	require.Equal(t, "-0.02 USD", fiat.Gwei(-10000))
+	require.Equal(t, "75,061.44 USD", fiat.Gwei(32000000000))
+	require.Equal(t, "-1,172.84 USD", fiat.Gwei(-500000000))
+	require.Equal(t, "0.00 USD", fiat.Wei(nil))
+	wei, _ := new(big.Int).SetString("1500000000000000000", 10)
+	require.Equal(t, "3,518.51 USD", fiat.Wei(wei))
+}
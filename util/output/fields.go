@@ -0,0 +1,172 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonField is a node in the tree of selected fields.
+type jsonField struct {
+	path     string
+	children map[string]*jsonField
+	found    bool
+}
+
+// parseJSONFields parses a list of dot-separated field paths, for example
+// "message.slot", into a tree of selected fields.
+func parseJSONFields(fields []string) (*jsonField, error) {
+	root := &jsonField{
+		children: make(map[string]*jsonField),
+	}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		node := root
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+			if node.children == nil {
+				// A parent of this field has already been selected in full.
+				break
+			}
+			child, exists := node.children[name]
+			if !exists {
+				child = &jsonField{
+					path:     strings.TrimPrefix(fmt.Sprintf("%s.%s", node.path, name), "."),
+					children: make(map[string]*jsonField),
+				}
+				node.children[name] = child
+			}
+			node = child
+		}
+		// The final element of the field is selected in full.
+		node.children = nil
+	}
+
+	return root, nil
+}
+
+// ProjectJSON reduces JSON data to the supplied fields.  Each field is a
+// dot-separated path to an element, for example "message.body.graffiti".
+// Arrays are transparent, so a path continues through each of the elements
+// of an array.  If no fields are supplied the data is returned unaltered.
+func ProjectJSON(data []byte, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	root, err := parseJSONFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(root.children) == 0 {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Retain numbers as supplied, to avoid loss of precision for large values.
+	decoder.UseNumber()
+	var input any
+	if err := decoder.Decode(&input); err != nil {
+		return nil, errors.Wrap(err, "failed to parse JSON")
+	}
+
+	output, _ := projectJSON(input, root)
+
+	if missing := missingJSONFields(root); len(missing) > 0 {
+		return nil, fmt.Errorf("field(s) not found: %s", strings.Join(missing, ", "))
+	}
+
+	res, err := json.Marshal(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return res, nil
+}
+
+// projectJSON returns the projection of the value, and true if anything was selected.
+func projectJSON(value any, field *jsonField) (any, bool) {
+	if field.children == nil {
+		field.found = true
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		res := make(map[string]any)
+		for name, child := range field.children {
+			childValue, exists := v[name]
+			if !exists {
+				continue
+			}
+			if projected, selected := projectJSON(childValue, child); selected {
+				res[name] = projected
+			}
+		}
+		return res, len(res) > 0
+	case []any:
+		if len(v) == 0 {
+			// Nothing to select, but the fields could exist had there been items.
+			markJSONFieldsFound(field)
+			return v, true
+		}
+		res := make([]any, 0, len(v))
+		for _, item := range v {
+			if projected, selected := projectJSON(item, field); selected {
+				res = append(res, projected)
+			}
+		}
+		return res, len(res) > 0
+	default:
+		// Cannot descend in to a scalar.
+		return nil, false
+	}
+}
+
+// missingJSONFields returns the selected fields that were not found.
+func missingJSONFields(field *jsonField) []string {
+	if field.children == nil {
+		if field.found {
+			return nil
+		}
+		return []string{field.path}
+	}
+
+	missing := make([]string, 0)
+	for _, child := range field.children {
+		missing = append(missing, missingJSONFields(child)...)
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// markJSONFieldsFound marks the field and all of its children as found.
+func markJSONFieldsFound(field *jsonField) {
+	field.found = true
+	for _, child := range field.children {
+		markJSONFieldsFound(child)
+	}
+}
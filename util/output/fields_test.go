@@ -0,0 +1,117 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestProjectJSON(t *testing.T) {
+	block := `{"message":{"slot":"123","proposer_index":"5","body":{"graffiti":"0x01","attestations":[{"data":{"slot":"122","index":"0"}},{"data":{"slot":"121","index":"1"}}],"deposits":[]}},"signature":"0x02"}`
+
+	tests := []struct {
+		name   string
+		data   string
+		fields []string
+		res    string
+		err    string
+	}{
+		{
+			name: "NoFields",
+			data: block,
+			res:  block,
+		},
+		{
+			name:   "EmptyFields",
+			data:   block,
+			fields: []string{""},
+			res:    block,
+		},
+		{
+			name:   "InvalidJSON",
+			data:   `{"message":`,
+			fields: []string{"message"},
+			err:    "failed to parse JSON: unexpected EOF",
+		},
+		{
+			name:   "InvalidField",
+			data:   block,
+			fields: []string{"message..slot"},
+			err:    `invalid field "message..slot"`,
+		},
+		{
+			name:   "Single",
+			data:   block,
+			fields: []string{"signature"},
+			res:    `{"signature":"0x02"}`,
+		},
+		{
+			name:   "Nested",
+			data:   block,
+			fields: []string{"message.slot", "message.body.graffiti", "signature"},
+			res:    `{"message":{"body":{"graffiti":"0x01"},"slot":"123"},"signature":"0x02"}`,
+		},
+		{
+			name:   "Object",
+			data:   block,
+			fields: []string{"message.body.attestations", "message.body"},
+			res:    `{"message":{"body":{"attestations":[{"data":{"index":"0","slot":"122"}},{"data":{"index":"1","slot":"121"}}],"deposits":[],"graffiti":"0x01"}}}`,
+		},
+		{
+			name:   "Array",
+			data:   block,
+			fields: []string{"message.body.attestations.data.slot"},
+			res:    `{"message":{"body":{"attestations":[{"data":{"slot":"122"}},{"data":{"slot":"121"}}]}}}`,
+		},
+		{
+			name:   "EmptyArray",
+			data:   block,
+			fields: []string{"message.body.deposits.data.amount"},
+			res:    `{"message":{"body":{"deposits":[]}}}`,
+		},
+		{
+			name:   "TopLevelArray",
+			data:   `[{"slot":"1","root":"0x01"},{"slot":"2","root":"0x02"}]`,
+			fields: []string{"slot"},
+			res:    `[{"slot":"1"},{"slot":"2"}]`,
+		},
+		{
+			name:   "LargeNumber",
+			data:   `{"value":123456789012345678901234567890,"other":1}`,
+			fields: []string{"value"},
+			res:    `{"value":123456789012345678901234567890}`,
+		},
+		{
+			name:   "Missing",
+			data:   block,
+			fields: []string{"message.slot", "message.body.grafiti", "message.proposer_index.value"},
+			err:    "field(s) not found: message.body.grafiti, message.proposer_index.value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output.ProjectJSON([]byte(test.data), test.fields)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, string(res))
+			}
+		})
+	}
+}
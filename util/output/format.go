@@ -0,0 +1,142 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output provides the output formats supported by commands, and the
+// rendering of command output in those formats.
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Format is an output format.
+type Format string
+
+const (
+	// Text is human-readable text.
+	Text Format = "text"
+	// JSON is JSON.
+	JSON Format = "json"
+	// NDJSON is newline-delimited JSON, with each item on a single line.
+	NDJSON Format = "ndjson"
+	// SSZ is hex-encoded simple serialize.
+	SSZ Format = "ssz"
+	// CSV is comma-separated values, with a header.
+	CSV Format = "csv"
+	// YAML is YAML.
+	YAML Format = "yaml"
+)
+
+// FormatsAnnotation is the command annotation that lists the formats, in
+// addition to text, JSON and SSZ, that the command supports.  Its value is a
+// comma-separated list of formats.
+const FormatsAnnotation = "output-formats"
+
+// formats are the supported output formats.
+var formats = map[Format]bool{
+	Text:   true,
+	JSON:   true,
+	NDJSON: true,
+	SSZ:    true,
+	CSV:    true,
+	YAML:   true,
+}
+
+// ParseFormat parses an output format.  An empty input is text.
+func ParseFormat(input string) (Format, error) {
+	if input == "" {
+		return Text, nil
+	}
+	format := Format(strings.ToLower(input))
+	if !formats[format] {
+		return "", fmt.Errorf("unsupported output format %q", input)
+	}
+
+	return format, nil
+}
+
+// FromViper obtains the output format from the output option.  If the output
+// option is not supplied then the deprecated json and ssz options are honoured.
+func FromViper() (Format, error) {
+	jsonOutput := viper.GetBool("json")
+	sszOutput := viper.GetBool("ssz")
+	if viper.GetString("output") == "" {
+		switch {
+		case jsonOutput && sszOutput:
+			return "", errors.New("only one of json and ssz can be supplied")
+		case jsonOutput:
+			return JSON, nil
+		case sszOutput:
+			return SSZ, nil
+		default:
+			return Text, nil
+		}
+	}
+
+	format, err := ParseFormat(viper.GetString("output"))
+	if err != nil {
+		return "", err
+	}
+	if jsonOutput && format != JSON {
+		return "", fmt.Errorf("json cannot be supplied with %s output", format)
+	}
+	if sszOutput && format != SSZ {
+		return "", fmt.Errorf("ssz cannot be supplied with %s output", format)
+	}
+
+	return format, nil
+}
+
+// Configure obtains the output format and checks that it is supported by a
+// command, given the command's formats annotation.  Text, JSON and SSZ output
+// are provided where available, as with the deprecated json and ssz options,
+// so these are always accepted.
+//
+// Fields can only be supplied for output based on JSON.
+//
+// The output, json and ssz options are set to match the format, so that
+// commands that read the deprecated options honour the output option.
+func Configure(annotation string) (Format, error) {
+	format, err := FromViper()
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case Text, JSON, SSZ:
+	default:
+		supported := false
+		for _, item := range strings.Split(annotation, ",") {
+			if Format(strings.TrimSpace(item)) == format {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return "", fmt.Errorf("%s output is not supported by this command", format)
+		}
+	}
+	if len(viper.GetStringSlice("fields")) > 0 && format != JSON && format != NDJSON && format != YAML {
+		return "", errors.New("fields can only be supplied with json, ndjson or yaml output")
+	}
+
+	viper.Set("output", string(format))
+	viper.Set("json", format == JSON)
+	viper.Set("ssz", format == SSZ)
+
+	return format, nil
+}
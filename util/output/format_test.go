@@ -0,0 +1,245 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   output.Format
+		err   string
+	}{
+		{
+			name: "Empty",
+			res:  output.Text,
+		},
+		{
+			name:  "YAML",
+			input: "yaml",
+			res:   output.YAML,
+		},
+		{
+			name:  "Uppercase",
+			input: "NDJSON",
+			res:   output.NDJSON,
+		},
+		{
+			name:  "Unsupported",
+			input: "xml",
+			err:   `unsupported output format "xml"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := output.ParseFormat(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestFromViper(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		res  output.Format
+		err  string
+	}{
+		{
+			name: "Default",
+			res:  output.Text,
+		},
+		{
+			name: "Output",
+			vars: map[string]interface{}{
+				"output": "csv",
+			},
+			res: output.CSV,
+		},
+		{
+			name: "DeprecatedJSON",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			res: output.JSON,
+		},
+		{
+			name: "DeprecatedSSZ",
+			vars: map[string]interface{}{
+				"ssz": true,
+			},
+			res: output.SSZ,
+		},
+		{
+			name: "DeprecatedJSONAndSSZ",
+			vars: map[string]interface{}{
+				"json": true,
+				"ssz":  true,
+			},
+			err: "only one of json and ssz can be supplied",
+		},
+		{
+			name: "OutputWithMatchingJSON",
+			vars: map[string]interface{}{
+				"output": "json",
+				"json":   true,
+			},
+			res: output.JSON,
+		},
+		{
+			name: "OutputWithJSON",
+			vars: map[string]interface{}{
+				"output": "csv",
+				"json":   true,
+			},
+			err: "json cannot be supplied with csv output",
+		},
+		{
+			name: "OutputWithSSZ",
+			vars: map[string]interface{}{
+				"output": "yaml",
+				"ssz":    true,
+			},
+			err: "ssz cannot be supplied with yaml output",
+		},
+		{
+			name: "Unsupported",
+			vars: map[string]interface{}{
+				"output": "xml",
+			},
+			err: `unsupported output format "xml"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := output.FromViper()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name       string
+		vars       map[string]interface{}
+		annotation string
+		res        output.Format
+		jsonOutput bool
+		sszOutput  bool
+		err        string
+	}{
+		{
+			name: "Default",
+			res:  output.Text,
+		},
+		{
+			name: "JSON",
+			vars: map[string]interface{}{
+				"output": "json",
+			},
+			res:        output.JSON,
+			jsonOutput: true,
+		},
+		{
+			name: "SSZ",
+			vars: map[string]interface{}{
+				"output": "ssz",
+			},
+			res:       output.SSZ,
+			sszOutput: true,
+		},
+		{
+			name: "DeprecatedJSON",
+			vars: map[string]interface{}{
+				"json": true,
+			},
+			res:        output.JSON,
+			jsonOutput: true,
+		},
+		{
+			name: "Supported",
+			vars: map[string]interface{}{
+				"output": "yaml",
+			},
+			annotation: "csv, ndjson, yaml",
+			res:        output.YAML,
+		},
+		{
+			name: "NotSupported",
+			vars: map[string]interface{}{
+				"output": "yaml",
+			},
+			annotation: "csv",
+			err:        "yaml output is not supported by this command",
+		},
+		{
+			name: "Fields",
+			vars: map[string]interface{}{
+				"output": "ndjson",
+				"fields": "slot",
+			},
+			annotation: "ndjson",
+			res:        output.NDJSON,
+		},
+		{
+			name: "FieldsText",
+			vars: map[string]interface{}{
+				"fields": "slot",
+			},
+			err: "fields can only be supplied with json, ndjson or yaml output",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			res, err := output.Configure(test.annotation)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+			require.Equal(t, string(test.res), viper.GetString("output"))
+			require.Equal(t, test.jsonOutput, viper.GetBool("json"))
+			require.Equal(t, test.sszOutput, viper.GetBool("ssz"))
+		})
+	}
+}
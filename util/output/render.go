@@ -0,0 +1,195 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Renderer renders the output of a command as text.  Renderers implement the
+// further interfaces in this package for the other formats that they support.
+type Renderer interface {
+	// RenderText renders the output as text.
+	RenderText(ctx context.Context) (string, error)
+}
+
+// JSONRenderer renders the output of a command as JSON.  NDJSON and YAML
+// output are generated from the JSON.
+type JSONRenderer interface {
+	// RenderJSON renders the output as JSON.
+	RenderJSON(ctx context.Context) ([]byte, error)
+}
+
+// SSZRenderer renders the output of a command as SSZ.
+type SSZRenderer interface {
+	// RenderSSZ renders the output as SSZ.
+	RenderSSZ(ctx context.Context) ([]byte, error)
+}
+
+// SSZStreamRenderer renders the output of a command as SSZ directly to a
+// writer, so that large outputs such as blocks with blobs do not need to be
+// held in memory in full.
+type SSZStreamRenderer interface {
+	// WriteSSZ writes the output as SSZ to the writer.
+	WriteSSZ(ctx context.Context, w io.Writer) error
+}
+
+// CSVRenderer renders the output of a command as CSV.
+type CSVRenderer interface {
+	// CSVHeader returns the names of the columns, in order.
+	CSVHeader() []string
+	// CSVRecords returns the records, with columns in the order of the header.
+	CSVRecords(ctx context.Context) ([][]string, error)
+}
+
+// Render renders output in the given format.
+func Render(ctx context.Context, renderer Renderer, format Format) (string, error) {
+	return NewStream(format).Render(ctx, renderer)
+}
+
+// Stream renders a sequence of outputs in a single format, for example the
+// blocks in a range.  CSV output only contains the header once, and YAML
+// output separates each output as a document.  JSON-based output is reduced
+// to the fields supplied with the fields option, if any.
+type Stream struct {
+	format   Format
+	fields   []string
+	rendered bool
+}
+
+// NewStream creates a stream for the given format.  An empty format is
+// treated as text.
+func NewStream(format Format) *Stream {
+	if format == "" {
+		format = Text
+	}
+
+	return &Stream{
+		format: format,
+		fields: viper.GetStringSlice("fields"),
+	}
+}
+
+// Format returns the format of the stream.
+func (s *Stream) Format() Format {
+	return s.format
+}
+
+// Render renders the next output in the stream.
+func (s *Stream) Render(ctx context.Context, renderer Renderer) (string, error) {
+	var res string
+	var err error
+	switch s.format {
+	case Text:
+		res, err = renderer.RenderText(ctx)
+	case JSON, NDJSON, YAML:
+		res, err = s.renderJSON(ctx, renderer)
+	case SSZ:
+		res, err = renderSSZ(ctx, renderer)
+	case CSV:
+		res, err = s.renderCSV(ctx, renderer)
+	default:
+		err = fmt.Errorf("unsupported output format %q", s.format)
+	}
+	if err != nil {
+		return "", err
+	}
+	s.rendered = true
+
+	return res, nil
+}
+
+func (s *Stream) renderJSON(ctx context.Context, renderer Renderer) (string, error) {
+	jsonRenderer, isRenderer := renderer.(JSONRenderer)
+	if !isRenderer {
+		return "", fmt.Errorf("%s output is not supported", s.format)
+	}
+	data, err := jsonRenderer.RenderJSON(ctx)
+	if err != nil {
+		return "", err
+	}
+	data, err = ProjectJSON(data, s.fields)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to select fields")
+	}
+
+	switch s.format {
+	case NDJSON:
+		buf := &bytes.Buffer{}
+		if err := json.Compact(buf, data); err != nil {
+			return "", errors.Wrap(err, "failed to compact JSON")
+		}
+		return buf.String(), nil
+	case YAML:
+		res, err := jsonToYAML(data)
+		if err != nil {
+			return "", err
+		}
+		if s.rendered {
+			res = "---\n" + res
+		}
+		return res, nil
+	default:
+		return string(data), nil
+	}
+}
+
+func renderSSZ(ctx context.Context, renderer Renderer) (string, error) {
+	if streamRenderer, isRenderer := renderer.(SSZStreamRenderer); isRenderer {
+		// Encode directly as hex, rather than holding both the binary and hex forms.
+		res := &strings.Builder{}
+		res.WriteString("0x")
+		if err := streamRenderer.WriteSSZ(ctx, hex.NewEncoder(res)); err != nil {
+			return "", err
+		}
+		return res.String(), nil
+	}
+
+	sszRenderer, isRenderer := renderer.(SSZRenderer)
+	if !isRenderer {
+		return "", errors.New("ssz output is not supported")
+	}
+	data, err := sszRenderer.RenderSSZ(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%#x", data), nil
+}
+
+func (s *Stream) renderCSV(ctx context.Context, renderer Renderer) (string, error) {
+	csvRenderer, isRenderer := renderer.(CSVRenderer)
+	if !isRenderer {
+		return "", errors.New("csv output is not supported")
+	}
+	records, err := csvRenderer.CSVRecords(ctx)
+	if err != nil {
+		return "", err
+	}
+	var header []string
+	if !s.rendered {
+		header = csvRenderer.CSVHeader()
+	}
+
+	return CSVString(header, records)
+}
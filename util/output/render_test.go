@@ -0,0 +1,256 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+type testTextRenderer struct{}
+
+func (*testTextRenderer) RenderText(_ context.Context) (string, error) {
+	return "Slot: 1", nil
+}
+
+type testRenderer struct {
+	Slot     uint64   `json:"slot"`
+	Root     string   `json:"root"`
+	Graffiti string   `json:"graffiti"`
+	Indices  []uint64 `json:"indices"`
+}
+
+func (r *testRenderer) RenderText(_ context.Context) (string, error) {
+	return "Slot: 1", nil
+}
+
+func (r *testRenderer) RenderJSON(_ context.Context) ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *testRenderer) RenderSSZ(_ context.Context) ([]byte, error) {
+	return []byte{0x01, 0x02}, nil
+}
+
+func (r *testRenderer) CSVHeader() []string {
+	return []string{"slot", "graffiti"}
+}
+
+func (r *testRenderer) CSVRecords(_ context.Context) ([][]string, error) {
+	return [][]string{{"1", r.Graffiti}}, nil
+}
+
+type testSSZStreamRenderer struct{}
+
+func (*testSSZStreamRenderer) RenderText(_ context.Context) (string, error) {
+	return "Slot: 1", nil
+}
+
+func (*testSSZStreamRenderer) WriteSSZ(_ context.Context, w io.Writer) error {
+	_, err := w.Write([]byte{0x01, 0x02, 0x03})
+
+	return err
+}
+
+type testErrorRenderer struct{}
+
+func (*testErrorRenderer) RenderText(_ context.Context) (string, error) {
+	return "", errors.New("render failed")
+}
+
+func TestRender(t *testing.T) {
+	renderer := &testRenderer{
+		Slot:     1,
+		Root:     "0x01",
+		Graffiti: "a, b",
+		Indices:  []uint64{1, 2},
+	}
+
+	tests := []struct {
+		name     string
+		renderer output.Renderer
+		format   output.Format
+		res      string
+		err      string
+	}{
+		{
+			name:     "Text",
+			renderer: renderer,
+			format:   output.Text,
+			res:      "Slot: 1",
+		},
+		{
+			name:     "Empty",
+			renderer: renderer,
+			res:      "Slot: 1",
+		},
+		{
+			name:     "JSON",
+			renderer: renderer,
+			format:   output.JSON,
+			res:      "{\n  \"slot\": 1,\n  \"root\": \"0x01\",\n  \"graffiti\": \"a, b\",\n  \"indices\": [\n    1,\n    2\n  ]\n}",
+		},
+		{
+			name:     "NDJSON",
+			renderer: renderer,
+			format:   output.NDJSON,
+			res:      `{"slot":1,"root":"0x01","graffiti":"a, b","indices":[1,2]}`,
+		},
+		{
+			name:     "YAML",
+			renderer: renderer,
+			format:   output.YAML,
+			res:      "slot: 1\nroot: \"0x01\"\ngraffiti: a, b\nindices:\n    - 1\n    - 2",
+		},
+		{
+			name:     "SSZ",
+			renderer: renderer,
+			format:   output.SSZ,
+			res:      "0x0102",
+		},
+		{
+			name:     "SSZStream",
+			renderer: &testSSZStreamRenderer{},
+			format:   output.SSZ,
+			res:      "0x010203",
+		},
+		{
+			name:     "SSZNotSupported",
+			renderer: &testTextRenderer{},
+			format:   output.SSZ,
+			err:      "ssz output is not supported",
+		},
+		{
+			name:     "CSV",
+			renderer: renderer,
+			format:   output.CSV,
+			res:      "slot,graffiti\n1,\"a, b\"",
+		},
+		{
+			name:     "JSONNotSupported",
+			renderer: &testTextRenderer{},
+			format:   output.YAML,
+			err:      "yaml output is not supported",
+		},
+		{
+			name:     "CSVNotSupported",
+			renderer: &testTextRenderer{},
+			format:   output.CSV,
+			err:      "csv output is not supported",
+		},
+		{
+			name:     "Error",
+			renderer: &testErrorRenderer{},
+			format:   output.Text,
+			err:      "render failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			res, err := output.Render(context.Background(), test.renderer, test.format)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	renderer := &testRenderer{
+		Slot:     1,
+		Graffiti: "test",
+		Indices:  []uint64{},
+	}
+
+	tests := []struct {
+		name   string
+		format output.Format
+		res    []string
+	}{
+		{
+			name:   "CSV",
+			format: output.CSV,
+			res:    []string{"slot,graffiti\n1,test", "1,test"},
+		},
+		{
+			name:   "YAML",
+			format: output.YAML,
+			res:    []string{"slot: 1\nroot: \"\"\ngraffiti: test\nindices: []", "---\nslot: 1\nroot: \"\"\ngraffiti: test\nindices: []"},
+		},
+		{
+			name:   "NDJSON",
+			format: output.NDJSON,
+			res:    []string{`{"slot":1,"root":"","graffiti":"test","indices":[]}`, `{"slot":1,"root":"","graffiti":"test","indices":[]}`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			stream := output.NewStream(test.format)
+			require.Equal(t, test.format, stream.Format())
+			for _, expected := range test.res {
+				res, err := stream.Render(context.Background(), renderer)
+				require.NoError(t, err)
+				require.Equal(t, expected, res)
+			}
+		})
+	}
+}
+
+func TestStreamFields(t *testing.T) {
+	renderer := &testRenderer{
+		Slot:     1,
+		Graffiti: "test",
+		Indices:  []uint64{},
+	}
+
+	viper.Reset()
+	viper.Set("fields", []string{"slot", "graffiti"})
+	defer viper.Reset()
+
+	res, err := output.NewStream(output.JSON).Render(context.Background(), renderer)
+	require.NoError(t, err)
+	require.Equal(t, `{"graffiti":"test","slot":1}`, res)
+
+	res, err = output.NewStream(output.YAML).Render(context.Background(), renderer)
+	require.NoError(t, err)
+	require.Equal(t, "graffiti: test\nslot: 1", res)
+
+	viper.Set("fields", []string{"missing"})
+	_, err = output.NewStream(output.JSON).Render(context.Background(), renderer)
+	require.ErrorContains(t, err, "failed to select fields")
+
+	// Text output is unaffected.
+	res, err = output.NewStream(output.Text).Render(context.Background(), renderer)
+	require.NoError(t, err)
+	require.Equal(t, "Slot: 1", res)
+}
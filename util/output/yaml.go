@@ -0,0 +1,47 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonToYAML converts JSON to YAML, retaining the order of fields.
+func jsonToYAML(data []byte) (string, error) {
+	// JSON is valid YAML, so can be parsed directly.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return "", errors.Wrap(err, "failed to parse JSON")
+	}
+	// Clear the JSON styles, so that the output uses YAML block style.  Strings
+	// that would otherwise be read as a different type remain quoted.
+	clearStyle(&node)
+
+	res, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate YAML")
+	}
+
+	return strings.TrimSuffix(string(res), "\n"), nil
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/price"
+	"github.com/wealdtech/ethdo/services/price/chainlink"
+	"github.com/wealdtech/ethdo/services/price/coingecko"
+	"github.com/wealdtech/ethdo/util/output"
+)
+
+// PriceOracleOpts are the options for connecting to a price oracle.
+type PriceOracleOpts struct {
+	// Oracle is the name of the oracle: coingecko or chainlink.
+	Oracle string
+	// APIKey is the key with which to access the oracle, if required.
+	APIKey string
+	// ExecutionAddress is the address of the execution node through which
+	// on-chain oracles are read.
+	ExecutionAddress string
+	Timeout          time.Duration
+}
+
+// ConnectToPriceOracle connects to a price oracle.
+func ConnectToPriceOracle(ctx context.Context, opts *PriceOracleOpts) (price.Service, error) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	if opts.Timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	switch strings.ToLower(opts.Oracle) {
+	case "", "coingecko":
+		return coingecko.New(ctx,
+			coingecko.WithAPIKey(opts.APIKey),
+			coingecko.WithTimeout(opts.Timeout),
+		)
+	case "chainlink":
+		if opts.ExecutionAddress == "" {
+			return nil, errors.New("chainlink price oracle requires an execution connection")
+		}
+		executionClient, err := ConnectToExecutionNode(ctx, &ExecutionConnectOpts{
+			Address: opts.ExecutionAddress,
+			Timeout: opts.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return chainlink.New(ctx,
+			chainlink.WithExecution(executionClient),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported price oracle %q", opts.Oracle)
+	}
+}
+
+// FiatFromViper obtains the price of Ether in the currency given by the fiat
+// option, for rendering fiat equivalents of amounts.  If the fiat-historical
+// option is set the price is that at the supplied timestamp, which should be
+// the time to which the amounts relate, otherwise it is the current price.
+// If the fiat option is not supplied this returns nil.
+func FiatFromViper(ctx context.Context, timestamp time.Time) (*output.Fiat, error) {
+	currency := strings.ToLower(viper.GetString("fiat"))
+	if currency == "" {
+		return nil, nil
+	}
+
+	oracle, err := ConnectToPriceOracle(ctx, &PriceOracleOpts{
+		Oracle:           viper.GetString("price-oracle"),
+		APIKey:           viper.GetString("price-oracle-api-key"),
+		ExecutionAddress: viper.GetString("execution-connection"),
+		Timeout:          viper.GetDuration("timeout"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to price oracle")
+	}
+
+	if !viper.GetBool("fiat-historical") {
+		timestamp = time.Time{}
+	}
+	price, err := oracle.Price(ctx, currency, timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain price from %s", oracle.Name()))
+	}
+
+	return &output.Fiat{
+		Currency: currency,
+		Price:    price,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestConnectToPriceOracle(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *util.PriceOracleOpts
+		oracle string
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no options specified",
+		},
+		{
+			name: "TimeoutMissing",
+			opts: &util.PriceOracleOpts{},
+			err:  "no timeout specified",
+		},
+		{
+			name: "Default",
+			opts: &util.PriceOracleOpts{
+				Timeout: time.Second,
+			},
+			oracle: "coingecko",
+		},
+		{
+			name: "Coingecko",
+			opts: &util.PriceOracleOpts{
+				Oracle:  "Coingecko",
+				APIKey:  "key",
+				Timeout: time.Second,
+			},
+			oracle: "coingecko",
+		},
+		{
+			name: "ChainlinkExecutionMissing",
+			opts: &util.PriceOracleOpts{
+				Oracle:  "chainlink",
+				Timeout: time.Second,
+			},
+			err: "chainlink price oracle requires an execution connection",
+		},
+		{
+			name: "Chainlink",
+			opts: &util.PriceOracleOpts{
+				Oracle:           "chainlink",
+				ExecutionAddress: "localhost:8545",
+				Timeout:          time.Second,
+			},
+			oracle: "chainlink",
+		},
+		{
+			name: "Unsupported",
+			opts: &util.PriceOracleOpts{
+				Oracle:  "magic",
+				Timeout: time.Second,
+			},
+			err: `unsupported price oracle "magic"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oracle, err := util.ConnectToPriceOracle(context.Background(), test.opts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.oracle, oracle.Name())
+			}
+		})
+	}
+}
+
+func TestFiatFromViper(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	fiat, err := util.FiatFromViper(context.Background(), time.Time{})
+	require.NoError(t, err)
+	require.Nil(t, fiat)
+
+	viper.Set("fiat", "usd")
+	viper.Set("price-oracle", "magic")
+	viper.Set("timeout", time.Second)
+	_, err = util.FiatFromViper(context.Background(), time.Time{})
+	require.EqualError(t, err, `failed to connect to price oracle: unsupported price oracle "magic"`)
+}
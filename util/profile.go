@@ -0,0 +1,41 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ApplyProfile merges the settings in the named profile of the configuration
+// file in to the configuration.  Settings supplied on the command line or in
+// the environment continue to take precedence over those in the profile.
+func ApplyProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("profiles.%s", profile)
+	if !viper.IsSet(key) {
+		return fmt.Errorf("profile %s not found", profile)
+	}
+
+	if err := viper.MergeConfigMap(viper.GetStringMap(key)); err != nil {
+		return errors.Wrap(err, "failed to merge profile")
+	}
+
+	return nil
+}
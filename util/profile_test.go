@@ -0,0 +1,101 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestApplyProfile(t *testing.T) {
+	config := `
+connection: http://localhost:5052
+timeout: 10s
+profiles:
+  mainnet:
+    connection: http://mainnet:5052
+  holesky:
+    connection: http://holesky:5052
+    stores:
+      s3:
+        region: eu-west-1
+`
+
+	tests := []struct {
+		name       string
+		profile    string
+		flags      map[string]interface{}
+		err        string
+		connection string
+		timeout    string
+		region     string
+	}{
+		{
+			name:       "None",
+			connection: "http://localhost:5052",
+			timeout:    "10s",
+		},
+		{
+			name:    "Unknown",
+			profile: "sepolia",
+			err:     "profile sepolia not found",
+		},
+		{
+			name:       "Mainnet",
+			profile:    "mainnet",
+			connection: "http://mainnet:5052",
+			timeout:    "10s",
+		},
+		{
+			name:       "Nested",
+			profile:    "holesky",
+			connection: "http://holesky:5052",
+			timeout:    "10s",
+			region:     "eu-west-1",
+		},
+		{
+			name:    "Override",
+			profile: "mainnet",
+			flags: map[string]interface{}{
+				"connection": "http://override:5052",
+			},
+			connection: "http://override:5052",
+			timeout:    "10s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			viper.SetConfigType("yaml")
+			require.NoError(t, viper.ReadConfig(strings.NewReader(config)))
+			for k, v := range test.flags {
+				viper.Set(k, v)
+			}
+			err := util.ApplyProfile(test.profile)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.connection, viper.GetString("connection"))
+				require.Equal(t, test.timeout, viper.GetString("timeout"))
+				require.Equal(t, test.region, viper.GetString("stores.s3.region"))
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+// RelayBidTrace is a bid trace returned by the relay data API.
+type RelayBidTrace struct {
+	BlockHash            phase0.Hash32
+	ProposerFeeRecipient bellatrix.ExecutionAddress
+	Value                *big.Int
+}
+
+type relayBidTraceJSON struct {
+	BlockHash            string `json:"block_hash"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	Value                string `json:"value"`
+}
+
+// RelayGet calls the given path on a relay, returning the body of the response.
+func RelayGet(ctx context.Context, relay string, path string, timeout time.Duration) ([]byte, error) {
+	url := fmt.Sprintf("%s%s", strings.TrimSuffix(relay, "/"), path)
+
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(opCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call relay")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("relay returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// ObtainRelayBidTraces obtains bid traces from the relay data API, for
+// example "proposer_payload_delivered?slot=1".
+func ObtainRelayBidTraces(ctx context.Context,
+	relay string,
+	endpoint string,
+	timeout time.Duration,
+) (
+	[]*RelayBidTrace,
+	error,
+) {
+	body, err := RelayGet(ctx, relay, fmt.Sprintf("/relay/v1/data/bidtraces/%s", endpoint), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRelayBidTraces(body)
+}
+
+// ParseRelayBidTraces parses bid traces returned by the relay data API.
+// Fee recipients and values are optional, and default to zero if not present.
+func ParseRelayBidTraces(data []byte) ([]*RelayBidTrace, error) {
+	traces := make([]*relayBidTraceJSON, 0)
+	if err := json.Unmarshal(data, &traces); err != nil {
+		return nil, errors.Wrap(err, "failed to parse bid traces")
+	}
+
+	res := make([]*RelayBidTrace, 0, len(traces))
+	for _, trace := range traces {
+		blockHash, err := bytesutil.FromHexString(trace.BlockHash)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid block hash")
+		}
+		if len(blockHash) != phase0.Hash32Length {
+			return nil, errors.New("block hash must be 32 bytes")
+		}
+		entry := &RelayBidTrace{
+			Value: big.NewInt(0),
+		}
+		copy(entry.BlockHash[:], blockHash)
+
+		if trace.ProposerFeeRecipient != "" {
+			feeRecipient, err := bytesutil.FromHexString(trace.ProposerFeeRecipient)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid proposer fee recipient")
+			}
+			if len(feeRecipient) != bellatrix.ExecutionAddressLength {
+				return nil, errors.New("proposer fee recipient must be 20 bytes")
+			}
+			copy(entry.ProposerFeeRecipient[:], feeRecipient)
+		}
+
+		if trace.Value != "" {
+			value, success := new(big.Int).SetString(trace.Value, 10)
+			if !success {
+				return nil, fmt.Errorf("invalid value %q", trace.Value)
+			}
+			entry.Value = value
+		}
+
+		res = append(res, entry)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,112 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseRelayBidTraces(t *testing.T) {
+	feeRecipient := bellatrix.ExecutionAddress{0x8c, 0x1f}
+
+	tests := []struct {
+		name     string
+		data     string
+		expected []*util.RelayBidTrace
+		err      string
+	}{
+		{
+			name: "Invalid",
+			data: `{`,
+			err:  "failed to parse bid traces: unexpected end of JSON input",
+		},
+		{
+			name:     "Empty",
+			data:     `[]`,
+			expected: []*util.RelayBidTrace{},
+		},
+		{
+			name: "InvalidBlockHash",
+			data: `[{"block_hash":"0x0102","value":"1000"}]`,
+			err:  "block hash must be 32 bytes",
+		},
+		{
+			name: "InvalidFeeRecipient",
+			data: `[{"block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000","proposer_fee_recipient":"0x8c1f","value":"1000"}]`,
+			err:  "proposer fee recipient must be 20 bytes",
+		},
+		{
+			name: "InvalidValue",
+			data: `[{"block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000","value":"bad"}]`,
+			err:  `invalid value "bad"`,
+		},
+		{
+			name: "HashOnly",
+			data: `[{"slot":"2","block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000"}]`,
+			expected: []*util.RelayBidTrace{
+				{BlockHash: phase0.Hash32{0x01, 0x02}, Value: big.NewInt(0)},
+			},
+		},
+		{
+			name: "Good",
+			data: `[{"block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000","proposer_fee_recipient":"0x8c1f000000000000000000000000000000000000","value":"1000"},{"block_hash":"0x0304000000000000000000000000000000000000000000000000000000000000","value":"2000"}]`,
+			expected: []*util.RelayBidTrace{
+				{BlockHash: phase0.Hash32{0x01, 0x02}, ProposerFeeRecipient: feeRecipient, Value: big.NewInt(1000)},
+				{BlockHash: phase0.Hash32{0x03, 0x04}, Value: big.NewInt(2000)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseRelayBidTraces([]byte(test.data))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+			}
+		})
+	}
+}
+
+func TestObtainRelayBidTraces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"block_hash":"0x0102000000000000000000000000000000000000000000000000000000000000","value":"1000"}]`))
+	}))
+	defer server.Close()
+
+	res, err := util.ObtainRelayBidTraces(context.Background(), server.URL+"/", "proposer_payload_delivered?slot=1", 5*time.Second)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, big.NewInt(1000), res[0].Value)
+
+	_, err = util.ObtainRelayBidTraces(context.Background(), server.URL, "builder_blocks_received?slot=1", 5*time.Second)
+	require.EqualError(t, err, "relay returned status 404: not found")
+}
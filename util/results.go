@@ -0,0 +1,41 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// GweiString formats a Gwei value held as a string.
+func GweiString(input string) string {
+	val, success := new(big.Int).SetString(input, 10)
+	if !success || !val.IsUint64() {
+		return input
+	}
+
+	return string2eth.GWeiToString(val.Uint64(), true)
+}
+
+// ExitWithResults prints any results and exits with failure.  It is used by
+// commands whose checks did not pass, allowing scripts to act on the result.
+func ExitWithResults(results string) {
+	if results != "" {
+		fmt.Println(results)
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,51 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestGweiString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   string
+	}{
+		{
+			name:  "Zero",
+			input: "0",
+			res:   "0",
+		},
+		{
+			name:  "Ether",
+			input: "32000000000",
+			res:   "32 Ether",
+		},
+		{
+			name:  "Invalid",
+			input: "bad",
+			res:   "bad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.GweiString(test.input))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// rlpItem is a decoded RLP item, either a byte string or a list.
+type rlpItem struct {
+	isList bool
+	// raw is the full encoding of the item, including its header.
+	raw []byte
+	// data is the content of a byte string.
+	data []byte
+	// items are the members of a list.
+	items []*rlpItem
+}
+
+// decodeRLP decodes a single RLP item that must span the entire input.
+func decodeRLP(input []byte) (*rlpItem, error) {
+	item, rest, err := decodeRLPItem(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after RLP item")
+	}
+
+	return item, nil
+}
+
+// decodeRLPItem decodes the first RLP item in the input, returning it along
+// with the remaining input.
+func decodeRLPItem(input []byte) (*rlpItem, []byte, error) {
+	if len(input) == 0 {
+		return nil, nil, errors.New("RLP input empty")
+	}
+
+	prefix := input[0]
+	var headerLen, contentLen int
+	isList := false
+	switch {
+	case prefix < 0x80:
+		// Single byte.
+		return &rlpItem{raw: input[:1], data: input[:1]}, input[1:], nil
+	case prefix <= 0xb7:
+		headerLen = 1
+		contentLen = int(prefix - 0x80)
+	case prefix < 0xc0:
+		lenLen := int(prefix - 0xb7)
+		length, err := rlpLength(input[1:], lenLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		headerLen = 1 + lenLen
+		contentLen = length
+	case prefix <= 0xf7:
+		isList = true
+		headerLen = 1
+		contentLen = int(prefix - 0xc0)
+	default:
+		isList = true
+		lenLen := int(prefix - 0xf7)
+		length, err := rlpLength(input[1:], lenLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		headerLen = 1 + lenLen
+		contentLen = length
+	}
+	if contentLen < 0 || len(input)-headerLen < contentLen {
+		return nil, nil, errors.New("RLP item exceeds input")
+	}
+
+	end := headerLen + contentLen
+	item := &rlpItem{
+		isList: isList,
+		raw:    input[:end],
+	}
+	content := input[headerLen:end]
+	if !isList {
+		item.data = content
+		return item, input[end:], nil
+	}
+
+	for len(content) > 0 {
+		member, rest, err := decodeRLPItem(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		item.items = append(item.items, member)
+		content = rest
+	}
+
+	return item, input[end:], nil
+}
+
+// rlpLength decodes a big-endian length of the given number of bytes.
+func rlpLength(input []byte, lenLen int) (int, error) {
+	if lenLen > 8 || len(input) < lenLen {
+		return 0, errors.New("invalid RLP length")
+	}
+	buf := make([]byte, 8)
+	copy(buf[8-lenLen:], input[:lenLen])
+	length := binary.BigEndian.Uint64(buf)
+	if length > uint64(len(input)) {
+		return 0, errors.New("RLP length exceeds input")
+	}
+
+	return int(length), nil
+}
+
+// encodeRLPBytes encodes a byte string.
+func encodeRLPBytes(input []byte) []byte {
+	if len(input) == 1 && input[0] < 0x80 {
+		return []byte{input[0]}
+	}
+
+	return append(rlpHeader(0x80, len(input)), input...)
+}
+
+// encodeRLPList encodes a list given the concatenated encodings of its members.
+func encodeRLPList(content []byte) []byte {
+	return append(rlpHeader(0xc0, len(content)), content...)
+}
+
+func rlpHeader(offset byte, length int) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+	lenBytes := new(big.Int).SetUint64(uint64(length)).Bytes()
+
+	return append([]byte{offset + 55 + byte(len(lenBytes))}, lenBytes...)
+}
+
+// bytes returns the content of a byte string item.
+func (i *rlpItem) bytes() ([]byte, error) {
+	if i.isList {
+		return nil, errors.New("expected RLP string, found list")
+	}
+
+	return i.data, nil
+}
+
+// bigInt returns the content of a byte string item as an integer.
+func (i *rlpItem) bigInt() (*big.Int, error) {
+	data, err := i.bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 32 {
+		return nil, errors.New("RLP integer too large")
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+// uint64 returns the content of a byte string item as a uint64.
+func (i *rlpItem) uint64() (uint64, error) {
+	val, err := i.bigInt()
+	if err != nil {
+		return 0, err
+	}
+	if !val.IsUint64() {
+		return 0, errors.New("RLP integer exceeds 64 bits")
+	}
+
+	return val.Uint64(), nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// confidenceZ is the z-score for a 95% confidence interval.
+const confidenceZ = 1.96
+
+// Estimate is a value estimated from a sample, with its 95% confidence interval.
+type Estimate struct {
+	Value float64 `json:"value"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// SampleValidators selects a random sample of the given size from the
+// validator indices.  The selection is deterministic for a given seed, so
+// that results can be reproduced.  The sample is returned in index order.
+func SampleValidators(indices []phase0.ValidatorIndex, size int, seed string) []phase0.ValidatorIndex {
+	sample := make([]phase0.ValidatorIndex, len(indices))
+	copy(sample, indices)
+	sort.Slice(sample, func(i int, j int) bool {
+		return sample[i] < sample[j]
+	})
+	if size >= len(sample) {
+		return sample
+	}
+
+	hash := sha256.Sum256([]byte(seed))
+	//nolint:gosec
+	rng := rand.New(rand.NewSource(int64(binary.LittleEndian.Uint64(hash[:8]))))
+	rng.Shuffle(len(sample), func(i int, j int) {
+		sample[i], sample[j] = sample[j], sample[i]
+	})
+	sample = sample[:size]
+	sort.Slice(sample, func(i int, j int) bool {
+		return sample[i] < sample[j]
+	})
+
+	return sample
+}
+
+// ProportionEstimate estimates a proportion from the number of successes in a
+// number of trials, using the Wilson score interval.
+func ProportionEstimate(successes int, trials int) *Estimate {
+	if trials == 0 {
+		return &Estimate{}
+	}
+
+	n := float64(trials)
+	p := float64(successes) / n
+	z2 := confidenceZ * confidenceZ
+	centre := (p + z2/(2*n)) / (1 + z2/n)
+	margin := confidenceZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / (1 + z2/n)
+
+	return &Estimate{
+		Value: p,
+		Lower: math.Max(0, centre-margin),
+		Upper: math.Min(1, centre+margin),
+	}
+}
+
+// MeanEstimate estimates a mean from a sample of values.
+func MeanEstimate(values []float64) *Estimate {
+	if len(values) == 0 {
+		return &Estimate{}
+	}
+
+	n := float64(len(values))
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / n
+	if len(values) == 1 {
+		return &Estimate{
+			Value: mean,
+			Lower: mean,
+			Upper: mean,
+		}
+	}
+
+	squares := 0.0
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	margin := confidenceZ * math.Sqrt(squares/(n-1)) / math.Sqrt(n)
+
+	return &Estimate{
+		Value: mean,
+		Lower: mean - margin,
+		Upper: mean + margin,
+	}
+}
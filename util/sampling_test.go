@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSampleValidators(t *testing.T) {
+	indices := make([]phase0.ValidatorIndex, 0, 1000)
+	for i := 999; i >= 0; i-- {
+		indices = append(indices, phase0.ValidatorIndex(i))
+	}
+
+	// Sample larger than the set returns the full set, in order.
+	all := util.SampleValidators(indices[:10], 20, "seed")
+	require.Len(t, all, 10)
+	require.Equal(t, phase0.ValidatorIndex(990), all[0])
+	require.Equal(t, phase0.ValidatorIndex(999), all[9])
+
+	sample := util.SampleValidators(indices, 50, "seed")
+	require.Len(t, sample, 50)
+	seen := make(map[phase0.ValidatorIndex]bool)
+	for i, index := range sample {
+		require.False(t, seen[index])
+		seen[index] = true
+		if i > 0 {
+			require.Less(t, sample[i-1], index)
+		}
+	}
+
+	// Same seed gives the same sample, different seed a different one.
+	require.Equal(t, sample, util.SampleValidators(indices, 50, "seed"))
+	require.NotEqual(t, sample, util.SampleValidators(indices, 50, "other"))
+
+	// Input is not modified.
+	require.Equal(t, phase0.ValidatorIndex(999), indices[0])
+}
+
+func TestProportionEstimate(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		trials    int
+		value     float64
+		lower     float64
+		upper     float64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:      "Half",
+			successes: 50,
+			trials:    100,
+			value:     0.5,
+			lower:     0.4038,
+			upper:     0.5962,
+		},
+		{
+			name:      "All",
+			successes: 100,
+			trials:    100,
+			value:     1,
+			lower:     0.9630,
+			upper:     1,
+		},
+		{
+			name:      "None",
+			successes: 0,
+			trials:    100,
+			value:     0,
+			lower:     0,
+			upper:     0.0370,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate := util.ProportionEstimate(test.successes, test.trials)
+			require.InDelta(t, test.value, estimate.Value, 0.0001)
+			require.InDelta(t, test.lower, estimate.Lower, 0.0001)
+			require.InDelta(t, test.upper, estimate.Upper, 0.0001)
+		})
+	}
+}
+
+func TestMeanEstimate(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		value  float64
+		lower  float64
+		upper  float64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:   "Single",
+			values: []float64{2},
+			value:  2,
+			lower:  2,
+			upper:  2,
+		},
+		{
+			name:   "Constant",
+			values: []float64{1, 1, 1, 1},
+			value:  1,
+			lower:  1,
+			upper:  1,
+		},
+		{
+			name:   "Varied",
+			values: []float64{1, 2, 3, 4},
+			value:  2.5,
+			lower:  1.2349,
+			upper:  3.7651,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate := util.MeanEstimate(test.values)
+			require.InDelta(t, test.value, estimate.Value, 0.0001)
+			require.InDelta(t, test.lower, estimate.Lower, 0.0001)
+			require.InDelta(t, test.upper, estimate.Upper, 0.0001)
+		})
+	}
+}
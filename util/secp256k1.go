@@ -0,0 +1,105 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// compactSignatureMagic is the offset of the recovery ID in the first byte of
+// a compact signature for an uncompressed public key.
+const compactSignatureMagic = 27
+
+// ecRecover recovers the Ethereum address of the key that generated the
+// signature (r, s) with recovery ID v over the given hash.
+func ecRecover(hash []byte, r *big.Int, s *big.Int, v uint64) ([]byte, error) {
+	if v > 1 {
+		return nil, errors.New("invalid recovery ID")
+	}
+	if r.Sign() <= 0 || r.BitLen() > 256 || s.Sign() <= 0 || s.BitLen() > 256 {
+		return nil, errors.New("invalid signature values")
+	}
+
+	signature := make([]byte, 65)
+	signature[0] = compactSignatureMagic + byte(v)
+	r.FillBytes(signature[1:33])
+	s.FillBytes(signature[33:])
+	pubKey, _, err := ecdsa.RecoverCompact(signature, hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover public key")
+	}
+
+	return pubKeyToAddress(pubKey), nil
+}
+
+// ecSign signs the hash with the private key, returning the signature values
+// (r, s) and the recovery ID.  Nonces are generated deterministically as per
+// RFC 6979, and s is normalised to the lower half of the curve order as
+// required by Ethereum.
+func ecSign(hash []byte, privateKey []byte) (*big.Int, *big.Int, uint64, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	signature := ecdsa.SignCompact(key, hash, false)
+	r := new(big.Int).SetBytes(signature[1:33])
+	s := new(big.Int).SetBytes(signature[33:])
+
+	return r, s, uint64(signature[0] - compactSignatureMagic), nil
+}
+
+// parsePrivateKey parses a secp256k1 private key, rejecting values that are
+// zero or not less than the curve order.
+func parsePrivateKey(privateKey []byte) (*secp256k1.PrivateKey, error) {
+	if len(privateKey) != 32 {
+		return nil, errors.New("private key must be 32 bytes")
+	}
+	var key secp256k1.ModNScalar
+	if overflow := key.SetByteSlice(privateKey); overflow || key.IsZero() {
+		return nil, errors.New("invalid private key")
+	}
+
+	return secp256k1.NewPrivateKey(&key), nil
+}
+
+// pubKeyToAddress returns the Ethereum address for a public key.
+func pubKeyToAddress(pubKey *secp256k1.PublicKey) []byte {
+	// Drop the 0x04 prefix of the uncompressed encoding.
+	return keccak256(pubKey.SerializeUncompressed()[1:])[12:]
+}
+
+// keccak256 returns the Keccak-256 hash of the input.
+func keccak256(input []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(input)
+
+	return hash.Sum(nil)
+}
+
+// ExecutionAddressFromPrivateKey returns the Ethereum address for a secp256k1 private key.
+func ExecutionAddressFromPrivateKey(privateKey []byte) (bellatrix.ExecutionAddress, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return bellatrix.ExecutionAddress{}, err
+	}
+
+	return bellatrix.ExecutionAddress(pubKeyToAddress(key.PubKey())), nil
+}
@@ -0,0 +1,173 @@
+// Copyright © 2025 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func secpHexToBytes(t *testing.T, input string) []byte {
+	t.Helper()
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	require.NoError(t, err)
+
+	return res
+}
+
+func parseBigInt(t *testing.T, input string) *big.Int {
+	t.Helper()
+	res, ok := new(big.Int).SetString(input, 0)
+	require.True(t, ok)
+
+	return res
+}
+
+func TestECSign(t *testing.T) {
+	satoshiHash := sha256.Sum256([]byte("Satoshi Nakamoto"))
+
+	tests := []struct {
+		name       string
+		privateKey []byte
+		hash       []byte
+		r          string
+		s          string
+		v          uint64
+		err        string
+	}{
+		{
+			name:       "Short",
+			privateKey: make([]byte, 31),
+			hash:       secpHexToBytes(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"),
+			err:        "private key must be 32 bytes",
+		},
+		{
+			name:       "Zero",
+			privateKey: make([]byte, 32),
+			hash:       secpHexToBytes(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"),
+			err:        "invalid private key",
+		},
+		{
+			name:       "CurveOrder",
+			privateKey: secpHexToBytes(t, "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+			hash:       secpHexToBytes(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"),
+			err:        "invalid private key",
+		},
+		{
+			// Signature from the EIP-155 example transaction.
+			name:       "EIP155",
+			privateKey: secpHexToBytes(t, "0x4646464646464646464646464646464646464646464646464646464646464646"),
+			hash:       secpHexToBytes(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"),
+			r:          "18515461264373351373200002665853028612451056578545711640558177340181847433846",
+			s:          "46948507304638947509940763649030358759909902576025900602547168820602576006531",
+			v:          0,
+		},
+		{
+			// RFC 6979 test vector for secp256k1 with SHA-256.
+			name:       "RFC6979",
+			privateKey: secpHexToBytes(t, "0x0000000000000000000000000000000000000000000000000000000000000001"),
+			hash:       satoshiHash[:],
+			r:          "0x934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			s:          "0x2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+			v:          1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, s, v, err := ecSign(test.hash, test.privateKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, parseBigInt(t, test.r), r)
+			require.Equal(t, parseBigInt(t, test.s), s)
+			require.Equal(t, test.v, v)
+
+			// Recovery must return the address of the signing key.
+			address, err := ExecutionAddressFromPrivateKey(test.privateKey)
+			require.NoError(t, err)
+			recovered, err := ecRecover(test.hash, r, s, v)
+			require.NoError(t, err)
+			require.Equal(t, address[:], recovered)
+		})
+	}
+}
+
+func TestECRecover(t *testing.T) {
+	hash := secpHexToBytes(t, "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53")
+	r := parseBigInt(t, "18515461264373351373200002665853028612451056578545711640558177340181847433846")
+	s := parseBigInt(t, "46948507304638947509940763649030358759909902576025900602547168820602576006531")
+
+	tests := []struct {
+		name    string
+		r       *big.Int
+		s       *big.Int
+		v       uint64
+		address string
+		err     string
+	}{
+		{
+			name: "RecoveryIDInvalid",
+			r:    r,
+			s:    s,
+			v:    2,
+			err:  "invalid recovery ID",
+		},
+		{
+			name: "RZero",
+			r:    big.NewInt(0),
+			s:    s,
+			err:  "invalid signature values",
+		},
+		{
+			name: "SOverflow",
+			r:    r,
+			s:    new(big.Int).Lsh(big.NewInt(1), 256),
+			err:  "invalid signature values",
+		},
+		{
+			name: "SCurveOrder",
+			r:    r,
+			s:    parseBigInt(t, "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+			err:  "failed to recover public key: invalid signature: S >= group order",
+		},
+		{
+			// Sender of the EIP-155 example transaction.
+			name:    "EIP155",
+			r:       r,
+			s:       s,
+			v:       0,
+			address: "9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := ecRecover(hash, test.r, test.s, test.v)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.address, hex.EncodeToString(address))
+		})
+	}
+}
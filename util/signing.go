@@ -92,13 +92,18 @@ func signGeneric(account e2wtypes.Account, data spec.Root, domain spec.Domain) (
 
 // sign signs arbitrary data, handling unlocking and locking as required.
 func sign(account e2wtypes.Account, data []byte) (e2types.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
+	defer cancel()
+
 	alreadyUnlocked, err := unlock(account)
 	if err != nil {
+		// The key may be held by the agent.
+		if agentAccount, held := agentAccount(ctx, account); held {
+			return agentAccount.Sign(ctx, data)
+		}
 		return nil, err
 	}
 	// outputIf(debug, fmt.Sprintf("Signing %x (%d)", data, len(data)))
-	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
-	defer cancel()
 
 	signer, isSigner := account.(e2wtypes.AccountSigner)
 	if !isSigner {
@@ -123,6 +128,9 @@ func unlock(account e2wtypes.Account) (bool, error) {
 		// This account doesn't support unlocking; return okay.
 		return true, nil
 	}
+	if err := CheckNotWatchOnly(account); err != nil {
+		return false, err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 	alreadyUnlocked, err := locker.IsUnlocked(ctx)
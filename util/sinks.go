@@ -0,0 +1,146 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/sink"
+	filesink "github.com/wealdtech/ethdo/services/sink/file"
+	httpsink "github.com/wealdtech/ethdo/services/sink/http"
+	kafkasink "github.com/wealdtech/ethdo/services/sink/kafka"
+	stdoutsink "github.com/wealdtech/ethdo/services/sink/stdout"
+)
+
+// multiSink writes records to multiple sinks.
+type multiSink struct {
+	names []string
+	sinks []sink.Service
+}
+
+// NewSink creates a sink for records emitted by streaming commands from a
+// list of destinations.  Destinations can be:
+//   - stdout
+//   - file:///path/to/file
+//   - http://host/path or https://host/path
+//   - kafka://broker1:9092,broker2:9092/topic
+//
+// If no destinations are supplied records are written to standard output.
+func NewSink(ctx context.Context, destinations []string, timeout time.Duration) (sink.Service, error) {
+	if len(destinations) == 0 {
+		destinations = []string{"stdout"}
+	}
+
+	res := &multiSink{
+		names: make([]string, 0, len(destinations)),
+		sinks: make([]sink.Service, 0, len(destinations)),
+	}
+	for _, destination := range destinations {
+		service, err := newSink(ctx, destination, timeout)
+		if err != nil {
+			_ = res.Close(ctx)
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid sink %q", destination))
+		}
+		res.names = append(res.names, destinationName(destination))
+		res.sinks = append(res.sinks, service)
+	}
+
+	return res, nil
+}
+
+func newSink(ctx context.Context, destination string, timeout time.Duration) (sink.Service, error) {
+	if destination == "stdout" {
+		return stdoutsink.New(ctx)
+	}
+
+	destinationURL, err := url.Parse(destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse")
+	}
+	switch destinationURL.Scheme {
+	case "file":
+		path := destinationURL.Path
+		if destinationURL.Host != "" {
+			// Relative path, e.g. file://events.log.
+			path = destinationURL.Host + path
+		}
+		return filesink.New(ctx,
+			filesink.WithPath(path),
+		)
+	case "http", "https":
+		return httpsink.New(ctx,
+			httpsink.WithURL(destination),
+			httpsink.WithTimeout(timeout),
+		)
+	case "kafka":
+		if destinationURL.Host == "" {
+			return nil, errors.New("no brokers specified")
+		}
+		return kafkasink.New(ctx,
+			kafkasink.WithBrokers(strings.Split(destinationURL.Host, ",")),
+			kafkasink.WithTopic(strings.Trim(destinationURL.Path, "/")),
+			kafkasink.WithTimeout(timeout),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported sink type %q", destinationURL.Scheme)
+	}
+}
+
+// destinationName returns a name for the destination suitable for error
+// messages, without any credentials.
+func destinationName(destination string) string {
+	destinationURL, err := url.Parse(destination)
+	if err != nil || destinationURL.User == nil {
+		return destination
+	}
+	destinationURL.User = nil
+
+	return destinationURL.String()
+}
+
+// Write writes a record to all sinks.  A failure to write to one sink does
+// not stop the record being written to the others.
+func (s *multiSink) Write(ctx context.Context, record []byte) error {
+	failures := make([]string, 0)
+	for i := range s.sinks {
+		if err := s.sinks[i].Write(ctx, record); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", s.names[i], err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to write record to sinks: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// Close closes all sinks.
+func (s *multiSink) Close(ctx context.Context) error {
+	failures := make([]string, 0)
+	for i := range s.sinks {
+		if err := s.sinks[i].Close(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", s.names[i], err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to close sinks: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
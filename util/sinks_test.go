@@ -0,0 +1,107 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestNewSink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	tests := []struct {
+		name         string
+		destinations []string
+		err          string
+		writeErr     string
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name:         "Stdout",
+			destinations: []string{"stdout"},
+		},
+		{
+			name:         "Unsupported",
+			destinations: []string{"ftp://example.com/"},
+			err:          `invalid sink "ftp://example.com/": unsupported sink type "ftp"`,
+		},
+		{
+			name:         "KafkaBrokersMissing",
+			destinations: []string{"kafka:///events"},
+			err:          `invalid sink "kafka:///events": no brokers specified`,
+		},
+		{
+			name:         "KafkaTopicMissing",
+			destinations: []string{"kafka://localhost:9092"},
+			err:          `invalid sink "kafka://localhost:9092": problem with parameters: no topic specified`,
+		},
+		{
+			name:         "Kafka",
+			destinations: []string{"kafka://localhost:9092,localhost:9093/events"},
+		},
+		{
+			name:         "File",
+			destinations: []string{"file://" + filepath.Join(dir, "events.jsonl")},
+		},
+		{
+			name:         "HTTPFailure",
+			destinations: []string{"file://" + filepath.Join(dir, "events2.jsonl"), "http://user:secret@" + server.Listener.Addr().String() + "/events"},
+			writeErr:     "failed to write record to sinks: http://" + server.Listener.Addr().String() + "/events: endpoint returned status 503: ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			sink, err := util.NewSink(ctx, test.destinations, time.Second)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.name == "Kafka" {
+				// No broker is available, so do not attempt to write.
+				require.NoError(t, sink.Close(ctx))
+				return
+			}
+			err = sink.Write(ctx, []byte(`{"test":true}`))
+			if test.writeErr != "" {
+				require.EqualError(t, err, test.writeErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, sink.Close(ctx))
+		})
+	}
+
+	// Records are written to files even if other sinks fail.
+	data, err := os.ReadFile(filepath.Join(dir, "events2.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, "{\"test\":true}\n", string(data))
+}
@@ -0,0 +1,46 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// slashingProtectionDBName is the name of the slashing protection database
+// file in its directory.
+const slashingProtectionDBName = "slashing-protection.json"
+
+// SlashingProtectionDB returns the path of the local slashing protection
+// database.  This is taken from the slashing-protection-db option if supplied,
+// otherwise it is alongside the wallets in the base directory.
+func SlashingProtectionDB() (string, error) {
+	if path := viper.GetString("slashing-protection-db"); path != "" {
+		return path, nil
+	}
+	if baseDir := GetBaseDir(); baseDir != "" {
+		return filepath.Join(baseDir, slashingProtectionDBName), nil
+	}
+
+	// This matches the default location of filesystem wallets.
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain configuration directory")
+	}
+
+	return filepath.Join(configDir, "ethereum2", slashingProtectionDBName), nil
+}
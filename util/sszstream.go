@@ -0,0 +1,63 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// SSZMarshaler is an item that can encode itself as SSZ in to a supplied buffer.
+type SSZMarshaler interface {
+	MarshalSSZTo(buf []byte) ([]byte, error)
+	SizeSSZ() int
+}
+
+// SSZEncoder writes the SSZ encoding of items to a writer.  The buffer used to
+// encode each item is reused, so encoding a sequence of items, such as the blob
+// sidecars of a block, only holds a single item in memory at a time rather than
+// the full encoding of the sequence.
+type SSZEncoder struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewSSZEncoder creates a new SSZ encoder that writes to the supplied writer.
+func NewSSZEncoder(w io.Writer) *SSZEncoder {
+	return &SSZEncoder{
+		w: w,
+	}
+}
+
+// Encode writes the SSZ encoding of the item to the writer.
+func (e *SSZEncoder) Encode(item SSZMarshaler) error {
+	size := item.SizeSSZ()
+	if cap(e.buf) < size {
+		e.buf = make([]byte, 0, size)
+	}
+	data, err := item.MarshalSSZTo(e.buf[:0])
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write SSZ")
+	}
+	// Retain the buffer if the encoding grew it, to avoid reallocating for the next item.
+	if cap(data) > cap(e.buf) {
+		e.buf = data
+	}
+
+	return nil
+}
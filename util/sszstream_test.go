@@ -0,0 +1,109 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// testBlobSidecars returns a number of populated blob sidecars.
+func testBlobSidecars(count int) []*deneb.BlobSidecar {
+	res := make([]*deneb.BlobSidecar, count)
+	for i := range res {
+		res[i] = &deneb.BlobSidecar{
+			Index: deneb.BlobIndex(i),
+			SignedBlockHeader: &phase0.SignedBeaconBlockHeader{
+				Message: &phase0.BeaconBlockHeader{
+					Slot: 5,
+				},
+			},
+		}
+		for j := range res[i].Blob {
+			res[i].Blob[j] = byte(i + j)
+		}
+	}
+
+	return res
+}
+
+type errorWriter struct{}
+
+func (*errorWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestSSZEncoder(t *testing.T) {
+	blobs := testBlobSidecars(3)
+	expected := make([]byte, 0)
+	for _, blob := range blobs {
+		data, err := blob.MarshalSSZ()
+		require.NoError(t, err)
+		expected = append(expected, data...)
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := util.NewSSZEncoder(buf)
+	for _, blob := range blobs {
+		require.NoError(t, encoder.Encode(blob))
+	}
+	require.Equal(t, expected, buf.Bytes())
+
+	encoder = util.NewSSZEncoder(&errorWriter{})
+	require.EqualError(t, encoder.Encode(blobs[0]), "failed to write SSZ: write failed")
+}
+
+// BenchmarkSSZMarshalBlobs encodes blob sidecars by building the full encoding
+// in memory before writing it, as a baseline for BenchmarkSSZEncoderBlobs.
+func BenchmarkSSZMarshalBlobs(b *testing.B) {
+	blobs := testBlobSidecars(6)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data := make([]byte, 0)
+		for _, blob := range blobs {
+			blobData, err := blob.MarshalSSZ()
+			if err != nil {
+				b.Fatal(err)
+			}
+			data = append(data, blobData...)
+		}
+		if _, err := io.Discard.Write(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSSZEncoderBlobs encodes blob sidecars with the streaming encoder,
+// which only holds a single sidecar in memory at a time.
+func BenchmarkSSZEncoderBlobs(b *testing.B) {
+	blobs := testBlobSidecars(6)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoder := util.NewSSZEncoder(io.Discard)
+		for _, blob := range blobs {
+			if err := encoder.Encode(blob); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// idSegmentRegex matches path segments that identify a specific item, such as
+// a slot, validator index or root.
+var idSegmentRegex = regexp.MustCompile(`^([0-9]+|0x[0-9a-fA-F]+)$`)
+
+// timings records where time is spent during a command.
+type timings struct {
+	mu        sync.Mutex
+	started   time.Time
+	endpoints map[string]*endpointTiming
+	phases    map[string]time.Duration
+}
+
+// endpointTiming records the calls made to an API endpoint.
+type endpointTiming struct {
+	calls    int
+	duration time.Duration
+}
+
+var (
+	commandTimings *timings
+	cpuProfile     *os.File
+)
+
+// TimingsEnabled returns true if timings are being recorded.
+func TimingsEnabled() bool {
+	return viper.GetBool("timings")
+}
+
+// StartTimings starts recording timings, and the CPU profile, if requested.
+func StartTimings() error {
+	if TimingsEnabled() {
+		commandTimings = newTimings(time.Now())
+	}
+
+	if viper.GetString("cpu-profile") != "" {
+		var err error
+		cpuProfile, err = os.Create(viper.GetString("cpu-profile"))
+		if err != nil {
+			return errors.Wrap(err, "failed to create CPU profile")
+		}
+		if err := pprof.StartCPUProfile(cpuProfile); err != nil {
+			return errors.Wrap(err, "failed to start CPU profile")
+		}
+	}
+
+	return nil
+}
+
+// StopTimings stops the CPU profile, if running, and writes the timings
+// report to the supplied writer, if recording.
+func StopTimings(out io.Writer) {
+	if cpuProfile != nil {
+		pprof.StopCPUProfile()
+		_ = cpuProfile.Close()
+		cpuProfile = nil
+	}
+
+	if commandTimings != nil {
+		fmt.Fprint(out, commandTimings.report(time.Now()))
+		commandTimings = nil
+	}
+}
+
+// TimePhase starts timing a phase of local computation, returning a
+// function to be called when the phase completes.  For example:
+//
+//	defer util.TimePhase("SSZ encoding")()
+func TimePhase(name string) func() {
+	if commandTimings == nil {
+		return func() {}
+	}
+	started := time.Now()
+	recorder := commandTimings
+
+	return func() {
+		recorder.recordPhase(name, time.Since(started))
+	}
+}
+
+func newTimings(started time.Time) *timings {
+	return &timings{
+		started:   started,
+		endpoints: make(map[string]*endpointTiming),
+		phases:    make(map[string]time.Duration),
+	}
+}
+
+func (t *timings) recordAPICall(method string, path string, duration time.Duration) {
+	endpoint := fmt.Sprintf("%s %s", method, timingEndpoint(path))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, exists := t.endpoints[endpoint]; !exists {
+		t.endpoints[endpoint] = &endpointTiming{}
+	}
+	t.endpoints[endpoint].calls++
+	t.endpoints[endpoint].duration += duration
+}
+
+func (t *timings) recordPhase(name string, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.phases[name] += duration
+}
+
+// timingEndpoint returns the endpoint for a request path, with the query
+// and any identifiers removed so that similar requests are grouped together.
+func timingEndpoint(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		if idSegmentRegex.MatchString(segments[i]) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// report returns a report of the timings.
+func (t *timings) report(finished time.Time) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := finished.Sub(t.started)
+	builder := strings.Builder{}
+	builder.WriteString("Timings:\n")
+	builder.WriteString(fmt.Sprintf("  Total: %s\n", roundDuration(total)))
+
+	endpoints := make([]string, 0, len(t.endpoints))
+	apiCalls := 0
+	apiDuration := time.Duration(0)
+	for endpoint, timing := range t.endpoints {
+		endpoints = append(endpoints, endpoint)
+		apiCalls += timing.calls
+		apiDuration += timing.duration
+	}
+	sort.Slice(endpoints, func(i int, j int) bool {
+		if t.endpoints[endpoints[i]].duration != t.endpoints[endpoints[j]].duration {
+			return t.endpoints[endpoints[i]].duration > t.endpoints[endpoints[j]].duration
+		}
+		return endpoints[i] < endpoints[j]
+	})
+	builder.WriteString(fmt.Sprintf("  API calls: %d (%s)\n", apiCalls, roundDuration(apiDuration)))
+	for _, endpoint := range endpoints {
+		timing := t.endpoints[endpoint]
+		builder.WriteString(fmt.Sprintf("    %s: %d in %s (average %s)\n",
+			endpoint,
+			timing.calls,
+			roundDuration(timing.duration),
+			roundDuration(timing.duration/time.Duration(timing.calls)),
+		))
+	}
+
+	phases := make([]string, 0, len(t.phases))
+	phasesDuration := time.Duration(0)
+	for phase, duration := range t.phases {
+		phases = append(phases, phase)
+		phasesDuration += duration
+	}
+	sort.Strings(phases)
+	for _, phase := range phases {
+		builder.WriteString(fmt.Sprintf("  %s: %s\n", phase, roundDuration(t.phases[phase])))
+	}
+
+	// API calls can run concurrently, so local computation is an estimate.
+	local := total - apiDuration - phasesDuration
+	if local < 0 {
+		local = 0
+	}
+	builder.WriteString(fmt.Sprintf("  Local computation: %s\n", roundDuration(local)))
+
+	return builder.String()
+}
+
+func roundDuration(duration time.Duration) time.Duration {
+	return duration.Round(time.Millisecond)
+}
+
+// timingTransport is a transport that records the time taken by requests to
+// a beacon node.
+type timingTransport struct {
+	base    http.RoundTripper
+	timings *timings
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.timings.recordAPICall(req.Method, req.URL.Path, time.Since(started))
+		return nil, err
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Streams do not end, so are not timed.
+		return resp, nil
+	}
+
+	// The call is complete once its body has been read.
+	resp.Body = &timedBody{
+		ReadCloser: resp.Body,
+		done: func() {
+			t.timings.recordAPICall(req.Method, req.URL.Path, time.Since(started))
+		},
+	}
+
+	return resp, nil
+}
+
+// timedBody is a response body that records the call when it has been read.
+type timedBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *timedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.once.Do(b.done)
+	}
+
+	return n, err
+}
+
+func (b *timedBody) Close() error {
+	b.once.Do(b.done)
+
+	return b.ReadCloser.Close()
+}
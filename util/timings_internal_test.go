@@ -0,0 +1,115 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimingEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		res  string
+	}{
+		{
+			name: "Plain",
+			path: "/eth/v1/node/version",
+			res:  "/eth/v1/node/version",
+		},
+		{
+			name: "Slot",
+			path: "/eth/v2/beacon/blocks/12345",
+			res:  "/eth/v2/beacon/blocks/{id}",
+		},
+		{
+			name: "Root",
+			path: "/eth/v2/beacon/blocks/0x0102030405",
+			res:  "/eth/v2/beacon/blocks/{id}",
+		},
+		{
+			name: "Named",
+			path: "/eth/v1/beacon/states/head/validators",
+			res:  "/eth/v1/beacon/states/head/validators",
+		},
+		{
+			name: "Query",
+			path: "/eth/v1/beacon/states/100/committees?epoch=3",
+			res:  "/eth/v1/beacon/states/{id}/committees",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, timingEndpoint(test.path))
+		})
+	}
+}
+
+func TestTimingsReport(t *testing.T) {
+	started := time.Unix(1700000000, 0)
+	timings := newTimings(started)
+	timings.recordAPICall("GET", "/eth/v2/beacon/blocks/1", 300*time.Millisecond)
+	timings.recordAPICall("GET", "/eth/v2/beacon/blocks/2", 100*time.Millisecond)
+	timings.recordAPICall("GET", "/eth/v1/node/version", 50*time.Millisecond)
+	timings.recordPhase("SSZ encoding", 150*time.Millisecond)
+
+	require.Equal(t, `Timings:
+  Total: 1s
+  API calls: 3 (450ms)
+    GET /eth/v2/beacon/blocks/{id}: 2 in 400ms (average 200ms)
+    GET /eth/v1/node/version: 1 in 50ms (average 50ms)
+  SSZ encoding: 150ms
+  Local computation: 400ms
+`, timings.report(started.Add(time.Second)))
+
+	// Concurrent API calls can take longer than the command.
+	require.Contains(t, timings.report(started.Add(100*time.Millisecond)), "Local computation: 0s\n")
+}
+
+func TestTimingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	timings := newTimings(time.Now())
+	client := &http.Client{Transport: &timingTransport{
+		base:    http.DefaultTransport,
+		timings: timings,
+	}}
+
+	for _, path := range []string{"/eth/v1/beacon/states/1/root", "/eth/v1/beacon/states/2/root"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	require.Len(t, timings.endpoints, 1)
+	require.Equal(t, 2, timings.endpoints["GET /eth/v1/beacon/states/{id}/root"].calls)
+}
+
+func TestTimePhaseDisabled(t *testing.T) {
+	commandTimings = nil
+	// Should not panic.
+	TimePhase("test")()
+}
@@ -0,0 +1,334 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/pkg/errors"
+)
+
+// Transaction types.
+const (
+	TransactionTypeLegacy     = 0
+	TransactionTypeAccessList = 1
+	TransactionTypeDynamicFee = 2
+	TransactionTypeBlob       = 3
+	TransactionTypeSetCode    = 4
+)
+
+var transactionTypeNames = map[uint8]string{
+	TransactionTypeLegacy:     "legacy",
+	TransactionTypeAccessList: "access list",
+	TransactionTypeDynamicFee: "dynamic fee",
+	TransactionTypeBlob:       "blob",
+	TransactionTypeSetCode:    "set code",
+}
+
+// transactionFields is the number of RLP fields in each type of transaction.
+var transactionFields = map[uint8]int{
+	TransactionTypeLegacy:     9,
+	TransactionTypeAccessList: 11,
+	TransactionTypeDynamicFee: 12,
+	TransactionTypeBlob:       14,
+	TransactionTypeSetCode:    13,
+}
+
+// Transaction is a decoded execution layer transaction.
+type Transaction struct {
+	Type    uint8
+	Hash    [32]byte
+	ChainID *big.Int
+	Nonce   uint64
+	From    bellatrix.ExecutionAddress
+	// To is nil for contract creation transactions.
+	To    *bellatrix.ExecutionAddress
+	Value *big.Int
+	Gas   uint64
+	// GasPrice is present for legacy and access list transactions.
+	GasPrice *big.Int
+	// MaxFeePerGas and MaxPriorityFeePerGas are present for later transactions.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	// MaxFeePerBlobGas and BlobVersionedHashes are present for blob transactions.
+	MaxFeePerBlobGas    *big.Int
+	BlobVersionedHashes []deneb.VersionedHash
+	Data                []byte
+}
+
+type transactionJSON struct {
+	Type                 string   `json:"type"`
+	Hash                 string   `json:"hash"`
+	ChainID              string   `json:"chain_id,omitempty"`
+	Nonce                string   `json:"nonce"`
+	From                 string   `json:"from"`
+	To                   string   `json:"to,omitempty"`
+	Value                string   `json:"value"`
+	Gas                  string   `json:"gas"`
+	GasPrice             string   `json:"gas_price,omitempty"`
+	MaxFeePerGas         string   `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas string   `json:"max_priority_fee_per_gas,omitempty"`
+	MaxFeePerBlobGas     string   `json:"max_fee_per_blob_gas,omitempty"`
+	BlobVersionedHashes  []string `json:"blob_versioned_hashes,omitempty"`
+	Data                 string   `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *Transaction) MarshalJSON() ([]byte, error) {
+	data := &transactionJSON{
+		Type:                 fmt.Sprintf("%d", t.Type),
+		Hash:                 fmt.Sprintf("%#x", t.Hash),
+		ChainID:              bigIntString(t.ChainID),
+		Nonce:                fmt.Sprintf("%d", t.Nonce),
+		From:                 t.From.String(),
+		Value:                bigIntString(t.Value),
+		Gas:                  fmt.Sprintf("%d", t.Gas),
+		GasPrice:             bigIntString(t.GasPrice),
+		MaxFeePerGas:         bigIntString(t.MaxFeePerGas),
+		MaxPriorityFeePerGas: bigIntString(t.MaxPriorityFeePerGas),
+		MaxFeePerBlobGas:     bigIntString(t.MaxFeePerBlobGas),
+		Data:                 fmt.Sprintf("0x%s", hex.EncodeToString(t.Data)),
+	}
+	if t.To != nil {
+		data.To = t.To.String()
+	}
+	for _, hash := range t.BlobVersionedHashes {
+		data.BlobVersionedHashes = append(data.BlobVersionedHashes, fmt.Sprintf("%#x", hash))
+	}
+
+	return json.Marshal(data)
+}
+
+func bigIntString(input *big.Int) string {
+	if input == nil {
+		return ""
+	}
+
+	return input.String()
+}
+
+// TypeName returns a human-readable name for the type of the transaction.
+func (t *Transaction) TypeName() string {
+	name, exists := transactionTypeNames[t.Type]
+	if !exists {
+		return "unknown"
+	}
+
+	return name
+}
+
+// DecodeTransaction decodes an RLP-encoded transaction, as found in an
+// execution payload, and recovers its sender.
+func DecodeTransaction(input []byte) (*Transaction, error) {
+	if len(input) == 0 {
+		return nil, errors.New("transaction empty")
+	}
+
+	tx := &Transaction{
+		Hash: [32]byte(keccak256(input)),
+	}
+	payload := input
+	if input[0] < 0xc0 {
+		// Typed transaction.
+		tx.Type = input[0]
+		payload = input[1:]
+	}
+	expectedFields, exists := transactionFields[tx.Type]
+	if !exists {
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type)
+	}
+
+	item, err := decodeRLP(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction")
+	}
+	if !item.isList {
+		return nil, errors.New("transaction is not an RLP list")
+	}
+	fields := item.items
+	if len(fields) != expectedFields {
+		return nil, fmt.Errorf("transaction has %d fields, expected %d", len(fields), expectedFields)
+	}
+
+	if tx.Type == TransactionTypeLegacy {
+		err = decodeLegacyTransaction(tx, fields)
+	} else {
+		err = decodeTypedTransaction(tx, fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// decodeLegacyTransaction decodes the fields of a legacy transaction:
+// [nonce, gasPrice, gas, to, value, data, v, r, s].
+func decodeLegacyTransaction(tx *Transaction, fields []*rlpItem) error {
+	var err error
+	if tx.Nonce, err = fields[0].uint64(); err != nil {
+		return errors.Wrap(err, "invalid nonce")
+	}
+	if tx.GasPrice, err = fields[1].bigInt(); err != nil {
+		return errors.Wrap(err, "invalid gas price")
+	}
+	if err := decodeTransactionCommon(tx, fields[2], fields[3], fields[4], fields[5]); err != nil {
+		return err
+	}
+
+	v, err := fields[6].bigInt()
+	if err != nil {
+		return errors.Wrap(err, "invalid v")
+	}
+	signingFields := concatRaw(fields[:6])
+	var recoveryID uint64
+	switch {
+	case v.IsUint64() && (v.Uint64() == 27 || v.Uint64() == 28):
+		// Pre-EIP-155 transaction, without a chain ID.
+		recoveryID = v.Uint64() - 27
+	case v.Cmp(big.NewInt(35)) >= 0:
+		// EIP-155 transaction, v = chainID * 2 + 35 + recoveryID.
+		offset := new(big.Int).Sub(v, big.NewInt(35))
+		recoveryID = uint64(offset.Bit(0))
+		tx.ChainID = offset.Rsh(offset, 1)
+		signingFields = append(signingFields, encodeRLPBytes(tx.ChainID.Bytes())...)
+		signingFields = append(signingFields, 0x80, 0x80)
+	default:
+		return fmt.Errorf("invalid v %s", v.String())
+	}
+
+	return recoverSender(tx, keccak256(encodeRLPList(signingFields)), recoveryID, fields[7], fields[8])
+}
+
+// decodeTypedTransaction decodes the fields of an EIP-2718 typed transaction.
+func decodeTypedTransaction(tx *Transaction, fields []*rlpItem) error {
+	var err error
+	if tx.ChainID, err = fields[0].bigInt(); err != nil {
+		return errors.Wrap(err, "invalid chain ID")
+	}
+	if tx.Nonce, err = fields[1].uint64(); err != nil {
+		return errors.Wrap(err, "invalid nonce")
+	}
+
+	if tx.Type == TransactionTypeAccessList {
+		// [chainID, nonce, gasPrice, gas, to, value, data, accessList, yParity, r, s]
+		if tx.GasPrice, err = fields[2].bigInt(); err != nil {
+			return errors.Wrap(err, "invalid gas price")
+		}
+		if err := decodeTransactionCommon(tx, fields[3], fields[4], fields[5], fields[6]); err != nil {
+			return err
+		}
+	} else {
+		// [chainID, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList, ...]
+		if tx.MaxPriorityFeePerGas, err = fields[2].bigInt(); err != nil {
+			return errors.Wrap(err, "invalid max priority fee per gas")
+		}
+		if tx.MaxFeePerGas, err = fields[3].bigInt(); err != nil {
+			return errors.Wrap(err, "invalid max fee per gas")
+		}
+		if err := decodeTransactionCommon(tx, fields[4], fields[5], fields[6], fields[7]); err != nil {
+			return err
+		}
+	}
+
+	if tx.Type == TransactionTypeBlob {
+		// [..., accessList, maxFeePerBlobGas, blobVersionedHashes, yParity, r, s]
+		if tx.MaxFeePerBlobGas, err = fields[9].bigInt(); err != nil {
+			return errors.Wrap(err, "invalid max fee per blob gas")
+		}
+		if !fields[10].isList {
+			return errors.New("invalid blob versioned hashes")
+		}
+		for _, hashItem := range fields[10].items {
+			hash, err := hashItem.bytes()
+			if err != nil || len(hash) != 32 {
+				return errors.New("invalid blob versioned hash")
+			}
+			tx.BlobVersionedHashes = append(tx.BlobVersionedHashes, deneb.VersionedHash(hash))
+		}
+	}
+	if tx.Type == TransactionTypeSetCode && tx.To == nil {
+		return errors.New("set code transaction cannot create a contract")
+	}
+
+	sigStart := len(fields) - 3
+	yParity, err := fields[sigStart].uint64()
+	if err != nil {
+		return errors.Wrap(err, "invalid y parity")
+	}
+	signingPayload := append([]byte{tx.Type}, encodeRLPList(concatRaw(fields[:sigStart]))...)
+
+	return recoverSender(tx, keccak256(signingPayload), yParity, fields[sigStart+1], fields[sigStart+2])
+}
+
+// decodeTransactionCommon decodes the fields common to all transactions.
+func decodeTransactionCommon(tx *Transaction, gas *rlpItem, to *rlpItem, value *rlpItem, data *rlpItem) error {
+	var err error
+	if tx.Gas, err = gas.uint64(); err != nil {
+		return errors.Wrap(err, "invalid gas")
+	}
+	toBytes, err := to.bytes()
+	if err != nil {
+		return errors.Wrap(err, "invalid to")
+	}
+	switch len(toBytes) {
+	case 0:
+		// Contract creation.
+	case len(bellatrix.ExecutionAddress{}):
+		address := bellatrix.ExecutionAddress(toBytes)
+		tx.To = &address
+	default:
+		return errors.New("invalid to")
+	}
+	if tx.Value, err = value.bigInt(); err != nil {
+		return errors.Wrap(err, "invalid value")
+	}
+	if tx.Data, err = data.bytes(); err != nil {
+		return errors.Wrap(err, "invalid data")
+	}
+
+	return nil
+}
+
+func recoverSender(tx *Transaction, hash []byte, recoveryID uint64, rItem *rlpItem, sItem *rlpItem) error {
+	r, err := rItem.bigInt()
+	if err != nil {
+		return errors.Wrap(err, "invalid r")
+	}
+	s, err := sItem.bigInt()
+	if err != nil {
+		return errors.Wrap(err, "invalid s")
+	}
+	from, err := ecRecover(hash, r, s, recoveryID)
+	if err != nil {
+		return errors.Wrap(err, "failed to recover sender")
+	}
+	tx.From = bellatrix.ExecutionAddress(from)
+
+	return nil
+}
+
+func concatRaw(items []*rlpItem) []byte {
+	res := make([]byte, 0)
+	for _, item := range items {
+		res = append(res, item.raw...)
+	}
+
+	return res
+}
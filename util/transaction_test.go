@@ -0,0 +1,160 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func transactionBytes(t *testing.T, input string) []byte {
+	t.Helper()
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestDecodeTransaction(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		err      string
+		txType   uint8
+		typeName string
+		hash     string
+		from     string
+		to       string
+		value    string
+		gas      uint64
+		blobs    int
+		json     string
+	}{
+		{
+			name: "Empty",
+			err:  "transaction empty",
+		},
+		{
+			name:  "UnknownType",
+			input: "0x7fc0",
+			err:   "unsupported transaction type 127",
+		},
+		{
+			name:  "NotList",
+			input: "0x0280",
+			err:   "transaction is not an RLP list",
+		},
+		{
+			name:  "Truncated",
+			input: "0xf86c098504a817c800",
+			err:   "failed to decode transaction: RLP length exceeds input",
+		},
+		{
+			name:  "WrongFieldCount",
+			input: "0xc3010203",
+			err:   "transaction has 3 fields, expected 9",
+		},
+		{
+			name:     "LegacyEIP155",
+			input:    "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			txType:   0,
+			typeName: "legacy",
+			hash:     "33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788",
+			from:     "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+			to:       "0x3535353535353535353535353535353535353535",
+			value:    "1000000000000000000",
+			gas:      21000,
+			json:     `{"type":"0","hash":"0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788","chain_id":"1","nonce":"9","from":"0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F","to":"0x3535353535353535353535353535353535353535","value":"1000000000000000000","gas":"21000","gas_price":"20000000000","data":"0x"}`,
+		},
+		{
+			name:     "AccessListContractCreation",
+			input:    "0x01f85501808504a817c800830186a08080826000c001a014601b8cdf761d4ed94554865ef0ef5c451e275f3dfc0a667fea04fa5a833beda067b55897d3d1988e1d402752eeb98c642bd2b3799fdfb70ccea811971fc29315",
+			txType:   1,
+			typeName: "access list",
+			from:     "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			value:    "0",
+			gas:      100000,
+		},
+		{
+			name:     "DynamicFee",
+			input:    "0x02f8730107843b9aca008506fc23ac00825208943535353535353535353535353535353535353535880de0b6b3a764000080c001a0f01d6b9018ab421dd410404cb869072065522bf85734008f105cf385a023a80fa03d897ee86b89b045df1de10022ff51b7d088f4e335ff92a90c02935b2d174f09",
+			txType:   2,
+			typeName: "dynamic fee",
+			from:     "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			to:       "0x3535353535353535353535353535353535353535",
+			value:    "1000000000000000000",
+			gas:      21000,
+		},
+		{
+			name:     "Blob",
+			input:    "0x03f8b80107843b9aca008506fc23ac00825208943535353535353535353535353535353535353535880de0b6b3a764000080c064f842a001000000000000000000000000000000000000000000000000000000000000aaa001000000000000000000000000000000000000000000000000000000000000bb80a09d2d304374a5d5571d7d8c39db99c8eed149767dcaf70be1a356a08376caa34fa0664523f575a4e5b2e5351ab64f07e7cdba6172a21f975a7bfaba16c9fc03e150",
+			txType:   3,
+			typeName: "blob",
+			from:     "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+			to:       "0x3535353535353535353535353535353535353535",
+			value:    "1000000000000000000",
+			gas:      21000,
+			blobs:    2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx, err := util.DecodeTransaction(transactionBytes(t, test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.txType, tx.Type)
+			require.Equal(t, test.typeName, tx.TypeName())
+			if test.hash != "" {
+				require.Equal(t, transactionBytes(t, test.hash), tx.Hash[:])
+			}
+			require.Equal(t, test.from, tx.From.String())
+			if test.to == "" {
+				require.Nil(t, tx.To)
+			} else {
+				require.Equal(t, test.to, tx.To.String())
+			}
+			require.Equal(t, test.value, tx.Value.String())
+			require.Equal(t, test.gas, tx.Gas)
+			require.Len(t, tx.BlobVersionedHashes, test.blobs)
+			if test.json != "" {
+				data, err := json.Marshal(tx)
+				require.NoError(t, err)
+				require.Equal(t, test.json, string(data))
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionBlobFees(t *testing.T) {
+	tx, err := util.DecodeTransaction(transactionBytes(t, "0x03f8b80107843b9aca008506fc23ac00825208943535353535353535353535353535353535353535880de0b6b3a764000080c064f842a001000000000000000000000000000000000000000000000000000000000000aaa001000000000000000000000000000000000000000000000000000000000000bb80a09d2d304374a5d5571d7d8c39db99c8eed149767dcaf70be1a356a08376caa34fa0664523f575a4e5b2e5351ab64f07e7cdba6172a21f975a7bfaba16c9fc03e150"))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), tx.ChainID)
+	require.Equal(t, uint64(7), tx.Nonce)
+	require.Equal(t, big.NewInt(1000000000), tx.MaxPriorityFeePerGas)
+	require.Equal(t, big.NewInt(30000000000), tx.MaxFeePerGas)
+	require.Equal(t, big.NewInt(100), tx.MaxFeePerBlobGas)
+	require.Nil(t, tx.GasPrice)
+	require.Equal(t, byte(0x01), tx.BlobVersionedHashes[0][0])
+	require.Equal(t, byte(0xbb), tx.BlobVersionedHashes[1][31])
+}
@@ -0,0 +1,71 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+)
+
+// DynamicFeeTransaction is an unsigned EIP-1559 transaction.
+type DynamicFeeTransaction struct {
+	ChainID              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   bellatrix.ExecutionAddress
+	Value                *big.Int
+	Data                 []byte
+}
+
+// SignDynamicFeeTransaction signs the transaction with the secp256k1 private
+// key, returning the encoded transaction ready for eth_sendRawTransaction.
+func SignDynamicFeeTransaction(tx *DynamicFeeTransaction, privateKey []byte) ([]byte, error) {
+	if tx == nil {
+		return nil, errors.New("no transaction supplied")
+	}
+	if tx.ChainID == nil || tx.MaxPriorityFeePerGas == nil || tx.MaxFeePerGas == nil || tx.Value == nil {
+		return nil, errors.New("transaction missing values")
+	}
+	if len(privateKey) != 32 {
+		return nil, errors.New("private key must be 32 bytes")
+	}
+
+	// [chainID, nonce, maxPriorityFeePerGas, maxFeePerGas, gas, to, value, data, accessList]
+	fields := make([]byte, 0)
+	fields = append(fields, encodeRLPBytes(tx.ChainID.Bytes())...)
+	fields = append(fields, encodeRLPBytes(new(big.Int).SetUint64(tx.Nonce).Bytes())...)
+	fields = append(fields, encodeRLPBytes(tx.MaxPriorityFeePerGas.Bytes())...)
+	fields = append(fields, encodeRLPBytes(tx.MaxFeePerGas.Bytes())...)
+	fields = append(fields, encodeRLPBytes(new(big.Int).SetUint64(tx.Gas).Bytes())...)
+	fields = append(fields, encodeRLPBytes(tx.To[:])...)
+	fields = append(fields, encodeRLPBytes(tx.Value.Bytes())...)
+	fields = append(fields, encodeRLPBytes(tx.Data)...)
+	fields = append(fields, encodeRLPList(nil)...)
+
+	signingPayload := append([]byte{TransactionTypeDynamicFee}, encodeRLPList(fields)...)
+	r, s, v, err := ecSign(keccak256(signingPayload), privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+
+	fields = append(fields, encodeRLPBytes(new(big.Int).SetUint64(v).Bytes())...)
+	fields = append(fields, encodeRLPBytes(r.Bytes())...)
+	fields = append(fields, encodeRLPBytes(s.Bytes())...)
+
+	return append([]byte{TransactionTypeDynamicFee}, encodeRLPList(fields)...), nil
+}
@@ -0,0 +1,145 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExecutionAddressFromPrivateKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		privateKey string
+		address    string
+		err        string
+	}{
+		{
+			name:       "Short",
+			privateKey: "0x4646",
+			err:        "private key must be 32 bytes",
+		},
+		{
+			name:       "Zero",
+			privateKey: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			err:        "invalid private key",
+		},
+		{
+			name:       "Good",
+			privateKey: "0x4646464646464646464646464646464646464646464646464646464646464646",
+			address:    "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+		},
+		{
+			name:       "One",
+			privateKey: "0x0000000000000000000000000000000000000000000000000000000000000001",
+			address:    "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := util.ExecutionAddressFromPrivateKey(transactionBytes(t, test.privateKey))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.address, address.String())
+			}
+		})
+	}
+}
+
+func TestSignDynamicFeeTransaction(t *testing.T) {
+	privateKey := transactionBytes(t, "0x4646464646464646464646464646464646464646464646464646464646464646")
+	to := bellatrix.ExecutionAddress(transactionBytes(t, "0x0000BBdDc7CE488642fb579F8B00f3a590007251"))
+	data := make([]byte, 96)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	tests := []struct {
+		name       string
+		tx         *util.DynamicFeeTransaction
+		privateKey []byte
+		err        string
+	}{
+		{
+			name:       "Nil",
+			privateKey: privateKey,
+			err:        "no transaction supplied",
+		},
+		{
+			name: "MissingValues",
+			tx: &util.DynamicFeeTransaction{
+				ChainID: big.NewInt(1),
+			},
+			privateKey: privateKey,
+			err:        "transaction missing values",
+		},
+		{
+			name: "BadPrivateKey",
+			tx: &util.DynamicFeeTransaction{
+				ChainID:              big.NewInt(1),
+				MaxPriorityFeePerGas: big.NewInt(1),
+				MaxFeePerGas:         big.NewInt(1),
+				Value:                big.NewInt(1),
+			},
+			privateKey: privateKey[:31],
+			err:        "private key must be 32 bytes",
+		},
+		{
+			name: "Good",
+			tx: &util.DynamicFeeTransaction{
+				ChainID:              big.NewInt(17000),
+				Nonce:                5,
+				MaxPriorityFeePerGas: big.NewInt(1000000000),
+				MaxFeePerGas:         big.NewInt(30000000000),
+				Gas:                  200000,
+				To:                   to,
+				Value:                big.NewInt(1),
+				Data:                 data,
+			},
+			privateKey: privateKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw, err := util.SignDynamicFeeTransaction(test.tx, test.privateKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Decoding the transaction recovers the signer.
+			tx, err := util.DecodeTransaction(raw)
+			require.NoError(t, err)
+			require.Equal(t, uint8(util.TransactionTypeDynamicFee), tx.Type)
+			require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", tx.From.String())
+			require.Equal(t, test.tx.ChainID, tx.ChainID)
+			require.Equal(t, test.tx.Nonce, tx.Nonce)
+			require.Equal(t, test.tx.MaxPriorityFeePerGas, tx.MaxPriorityFeePerGas)
+			require.Equal(t, test.tx.MaxFeePerGas, tx.MaxFeePerGas)
+			require.Equal(t, test.tx.Gas, tx.Gas)
+			require.Equal(t, &to, tx.To)
+			require.Equal(t, test.tx.Value, tx.Value)
+			require.Equal(t, test.tx.Data, tx.Data)
+		})
+	}
+}
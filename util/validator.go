@@ -22,11 +22,25 @@ import (
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+// FarFutureEpoch is the epoch used by the beacon chain to denote an unset epoch.
+const FarFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
+// WithdrawalAddress returns the execution address in a validator's withdrawal credentials.
+func WithdrawalAddress(validator *apiv1.Validator) bellatrix.ExecutionAddress {
+	var address bellatrix.ExecutionAddress
+	copy(address[:], validator.Validator.WithdrawalCredentials[12:])
+
+	return address
+}
+
 // ValidatorIndex obtains the index of a validator.
 func ValidatorIndex(ctx context.Context, client consensusclient.Service, account string, pubKey string, index string) (phase0.ValidatorIndex, error) {
 	switch {
@@ -67,10 +81,11 @@ func accountToIndex(ctx context.Context, account e2wtypes.Account, client consen
 
 	pubKeys := make([]phase0.BLSPubKey, 1)
 	copy(pubKeys[0][:], pubKey.Marshal())
-	validators, err := client.(consensusclient.ValidatorsProvider).ValidatorsByPubKey(ctx, "head", pubKeys)
+	validatorsResponse, err := client.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{State: "head", PubKeys: pubKeys})
 	if err != nil {
 		return 0, err
 	}
+	validators := validatorsResponse.Data
 
 	for index := range validators {
 		return index, nil
@@ -0,0 +1,41 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestWithdrawalAddress(t *testing.T) {
+	credentials := make([]byte, 32)
+	credentials[0] = 0x01
+	for i := 12; i < 32; i++ {
+		credentials[i] = byte(i)
+	}
+	validator := &apiv1.Validator{
+		Validator: &phase0.Validator{
+			WithdrawalCredentials: credentials,
+		},
+	}
+
+	expected := bellatrix.ExecutionAddress{}
+	copy(expected[:], credentials[12:])
+	require.Equal(t, expected, util.WithdrawalAddress(validator))
+}
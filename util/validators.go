@@ -16,15 +16,21 @@ package util
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
 	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
+// rangeRegexp matches a range of validator indices.
+var rangeRegexp = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
+
 // ParseValidators parses input to obtain the list of validators.
 func ParseValidators(ctx context.Context, validatorsProvider eth2client.ValidatorsProvider, validatorsStr []string, stateID string) ([]*apiv1.Validator, error) {
 	validators := make([]*apiv1.Validator, 0, len(validatorsStr))
@@ -47,10 +53,11 @@ func ParseValidators(ctx context.Context, validatorsProvider eth2client.Validato
 			for index := low; index <= high; index++ {
 				indices = append(indices, phase0.ValidatorIndex(index))
 			}
-			rangeValidators, err := validatorsProvider.Validators(ctx, stateID, indices)
+			rangeValidatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
 			if err != nil {
 				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %s", validatorsStr[i]))
 			}
+			rangeValidators := rangeValidatorsResponse.Data
 			for _, validator := range rangeValidators {
 				validators = append(validators, validator)
 			}
@@ -79,10 +86,11 @@ func ParseValidator(ctx context.Context,
 	// Could be a simple index.
 	index, err := strconv.ParseUint(validatorStr, 10, 64)
 	if err == nil {
-		validators, err = validatorsProvider.Validators(ctx, stateID, []phase0.ValidatorIndex{phase0.ValidatorIndex(index)})
+		validatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: []phase0.ValidatorIndex{phase0.ValidatorIndex(index)}})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to obtain validator information")
 		}
+		validators = validatorsResponse.Data
 	} else {
 		// Some sort of specifier.
 		account, err := ParseAccount(ctx, validatorStr, nil, false)
@@ -96,13 +104,14 @@ func ParseValidator(ctx context.Context,
 		}
 		pubKey := phase0.BLSPubKey{}
 		copy(pubKey[:], accPubKey.Marshal())
-		validators, err = validatorsProvider.ValidatorsByPubKey(ctx,
-			stateID,
-			[]phase0.BLSPubKey{pubKey},
-		)
+		validatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+			State:   stateID,
+			PubKeys: []phase0.BLSPubKey{pubKey},
+		})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to obtain validator information")
 		}
+		validators = validatorsResponse.Data
 	}
 
 	// Validator is first and only entry in the map.
@@ -112,3 +121,53 @@ func ParseValidator(ctx context.Context,
 
 	return nil, errors.New("unknown validator")
 }
+
+// ExpandWallets replaces any wallet names in the specifiers with the public
+// keys of the accounts in the wallet.
+func ExpandWallets(ctx context.Context, specifiers []string) ([]string, error) {
+	res := make([]string, 0, len(specifiers))
+	for _, specifier := range specifiers {
+		if !possibleWalletName(specifier) {
+			res = append(res, specifier)
+			continue
+		}
+		wallet, err := WalletFromPath(ctx, specifier)
+		if err != nil {
+			// Not a wallet; leave it to be parsed as a validator.
+			res = append(res, specifier)
+			continue
+		}
+		for account := range wallet.Accounts(ctx) {
+			pubKey, err := BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s/%s", wallet.Name(), account.Name()))
+			}
+			res = append(res, fmt.Sprintf("%#x", pubKey.Marshal()))
+		}
+	}
+
+	return res, nil
+}
+
+// possibleWalletName returns true if the specifier could be the name of a wallet
+// rather than any other form of validator specifier.
+func possibleWalletName(specifier string) bool {
+	switch {
+	case specifier == "",
+		strings.Contains(specifier, "/"),
+		strings.HasPrefix(specifier, "0x"),
+		strings.HasPrefix(specifier, "{"),
+		strings.Contains(specifier, " "),
+		rangeRegexp.MatchString(specifier):
+		return false
+	}
+	if _, err := strconv.ParseUint(specifier, 10, 64); err == nil {
+		return false
+	}
+	if _, err := os.Stat(specifier); err == nil {
+		// A keystore.
+		return false
+	}
+
+	return true
+}
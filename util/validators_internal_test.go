@@ -0,0 +1,77 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPossibleWalletName(t *testing.T) {
+	tests := []struct {
+		name      string
+		specifier string
+		expected  bool
+	}{
+		{
+			name:      "Empty",
+			specifier: "",
+		},
+		{
+			name:      "Index",
+			specifier: "123",
+		},
+		{
+			name:      "Range",
+			specifier: "100-200",
+		},
+		{
+			name:      "PubKey",
+			specifier: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		},
+		{
+			name:      "Account",
+			specifier: "Validators/1",
+		},
+		{
+			name:      "Mnemonic",
+			specifier: "abandon abandon abandon",
+		},
+		{
+			name:      "Wallet",
+			specifier: "Validators",
+			expected:  true,
+		},
+		{
+			name:      "HyphenatedWallet",
+			specifier: "my-validators",
+			expected:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, possibleWalletName(test.specifier))
+		})
+	}
+}
+
+func TestExpandWallets(t *testing.T) {
+	specifiers := []string{"1", "10-12", "Validators/1", "NonExistentWallet"}
+	res, err := ExpandWallets(context.Background(), specifiers)
+	require.NoError(t, err)
+	require.Equal(t, specifiers, res)
+}
@@ -0,0 +1,305 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// WalletExportV2Version is the first byte of a version 2 wallet export.  Version
+// 1 exports are a single encrypted blob whose first byte is 1.
+const WalletExportV2Version = byte(2)
+
+// A version 2 wallet export is a stream of encrypted frames, allowing wallets
+// to be exported and imported without holding all of their accounts in memory.
+// The stream starts with the version byte and a random salt, from which the
+// encryption key is derived.  Each frame is a frame type byte, the big-endian
+// length of its ciphertext, and the ciphertext.  Frames are encrypted with
+// AES-GCM using their sequence number as the nonce, so that frames cannot be
+// reordered or removed without detection, and the stream ends with a trailer
+// frame so that truncation is also detected.
+const (
+	walletExportSaltLen = 32
+	walletExportKeyLen  = 32
+	walletExportPBKDF2C = 262144
+	// walletExportMaxFrameLen is the maximum length of a frame, to avoid
+	// reading corrupt input in to memory.
+	walletExportMaxFrameLen = 256 * 1024 * 1024
+)
+
+const (
+	walletExportWalletFrame   = byte(1)
+	walletExportAccountsFrame = byte(2)
+	walletExportTrailerFrame  = byte(3)
+)
+
+// walletExportTrailer is the content of the final frame of an export.
+type walletExportTrailer struct {
+	Accounts uint64 `json:"accounts"`
+}
+
+// WalletExportWriter writes a version 2 wallet export.
+type WalletExportWriter struct {
+	writer   *bufio.Writer
+	aead     cipher.AEAD
+	sequence uint64
+	accounts uint64
+}
+
+// NewWalletExportWriter creates a writer of a version 2 wallet export,
+// encrypted with the given passphrase.
+func NewWalletExportWriter(writer io.Writer, passphrase []byte) (*WalletExportWriter, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("no passphrase")
+	}
+	salt := make([]byte, walletExportSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	aead, err := walletExportAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WalletExportWriter{
+		writer: bufio.NewWriter(writer),
+		aead:   aead,
+	}
+	if err := w.writer.WriteByte(WalletExportV2Version); err != nil {
+		return nil, errors.Wrap(err, "failed to write version")
+	}
+	if _, err := w.writer.Write(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to write salt")
+	}
+
+	return w, nil
+}
+
+// WriteWallet writes the wallet data.  This must be called before any accounts
+// are written.
+func (w *WalletExportWriter) WriteWallet(data []byte) error {
+	if w.sequence != 0 {
+		return errors.New("wallet already written")
+	}
+
+	return w.writeFrame(walletExportWalletFrame, data)
+}
+
+// WriteAccounts writes a chunk of account data.
+func (w *WalletExportWriter) WriteAccounts(accounts [][]byte) error {
+	if w.sequence == 0 {
+		return errors.New("wallet not written")
+	}
+	data, err := json.Marshal(accounts)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal accounts")
+	}
+	if err := w.writeFrame(walletExportAccountsFrame, data); err != nil {
+		return err
+	}
+	w.accounts += uint64(len(accounts))
+
+	return nil
+}
+
+// Close writes the trailer and flushes the export.  It does not close the
+// underlying writer.
+func (w *WalletExportWriter) Close() error {
+	if w.sequence == 0 {
+		return errors.New("wallet not written")
+	}
+	data, err := json.Marshal(&walletExportTrailer{Accounts: w.accounts})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal trailer")
+	}
+	if err := w.writeFrame(walletExportTrailerFrame, data); err != nil {
+		return err
+	}
+
+	return w.writer.Flush()
+}
+
+func (w *WalletExportWriter) writeFrame(frameType byte, data []byte) error {
+	ciphertext := w.aead.Seal(nil, walletExportNonce(w.aead, w.sequence), data, []byte{frameType})
+	if len(ciphertext) > walletExportMaxFrameLen {
+		return errors.New("frame too large")
+	}
+	header := make([]byte, 5)
+	header[0] = frameType
+	binary.BigEndian.PutUint32(header[1:], uint32(len(ciphertext)))
+	if _, err := w.writer.Write(header); err != nil {
+		return errors.Wrap(err, "failed to write frame header")
+	}
+	if _, err := w.writer.Write(ciphertext); err != nil {
+		return errors.Wrap(err, "failed to write frame")
+	}
+	w.sequence++
+
+	return nil
+}
+
+// WalletExportReader reads a version 2 wallet export.
+type WalletExportReader struct {
+	reader   *bufio.Reader
+	aead     cipher.AEAD
+	sequence uint64
+	accounts uint64
+	finished bool
+}
+
+// NewWalletExportReader creates a reader of a version 2 wallet export,
+// decrypted with the given passphrase.
+func NewWalletExportReader(reader io.Reader, passphrase []byte) (*WalletExportReader, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("no passphrase")
+	}
+	r := bufio.NewReader(reader)
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read version")
+	}
+	if version != WalletExportV2Version {
+		return nil, fmt.Errorf("unsupported export version %d", version)
+	}
+	salt := make([]byte, walletExportSaltLen)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return nil, errors.Wrap(err, "failed to read salt")
+	}
+	aead, err := walletExportAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletExportReader{
+		reader: r,
+		aead:   aead,
+	}, nil
+}
+
+// ReadWallet reads the wallet data.  This must be called before any accounts
+// are read.
+func (r *WalletExportReader) ReadWallet() ([]byte, error) {
+	if r.sequence != 0 {
+		return nil, errors.New("wallet already read")
+	}
+	frameType, data, err := r.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	if frameType != walletExportWalletFrame {
+		return nil, errors.New("export does not start with wallet")
+	}
+
+	return data, nil
+}
+
+// ReadAccounts reads the next chunk of account data.  It returns io.EOF once
+// all accounts have been read and the export has been verified as complete.
+func (r *WalletExportReader) ReadAccounts() ([][]byte, error) {
+	if r.sequence == 0 {
+		return nil, errors.New("wallet not read")
+	}
+	if r.finished {
+		return nil, io.EOF
+	}
+	frameType, data, err := r.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	switch frameType {
+	case walletExportAccountsFrame:
+		accounts := make([][]byte, 0)
+		if err := json.Unmarshal(data, &accounts); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal accounts")
+		}
+		r.accounts += uint64(len(accounts))
+		return accounts, nil
+	case walletExportTrailerFrame:
+		trailer := &walletExportTrailer{}
+		if err := json.Unmarshal(data, trailer); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal trailer")
+		}
+		if trailer.Accounts != r.accounts {
+			return nil, fmt.Errorf("export contains %d accounts but trailer expects %d", r.accounts, trailer.Accounts)
+		}
+		r.finished = true
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("unexpected frame type %d", frameType)
+	}
+}
+
+func (r *WalletExportReader) readFrame() (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, errors.New("export is truncated")
+		}
+		return 0, nil, errors.Wrap(err, "failed to read frame header")
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > walletExportMaxFrameLen {
+		return 0, nil, errors.New("frame too large")
+	}
+	ciphertext := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, ciphertext); err != nil {
+		return 0, nil, errors.New("export is truncated")
+	}
+	data, err := r.aead.Open(nil, walletExportNonce(r.aead, r.sequence), ciphertext, header[:1])
+	if err != nil {
+		return 0, nil, errors.New("failed to decrypt export; passphrase incorrect or data corrupt")
+	}
+	r.sequence++
+
+	return header[0], data, nil
+}
+
+// IsWalletExportV2 returns true if the data is the start of a version 2
+// wallet export.
+func IsWalletExportV2(data []byte) bool {
+	return len(data) > 0 && data[0] == WalletExportV2Version
+}
+
+func walletExportAEAD(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key := pbkdf2.Key(passphrase, salt, walletExportPBKDF2C, walletExportKeyLen, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AEAD")
+	}
+
+	return aead, nil
+}
+
+// walletExportNonce generates the nonce for a frame from its sequence number.
+func walletExportNonce(aead cipher.AEAD, sequence uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], sequence)
+
+	return nonce
+}
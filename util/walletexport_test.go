@@ -0,0 +1,146 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func walletExport(t *testing.T, passphrase string, chunks ...[][]byte) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	writer, err := util.NewWalletExportWriter(buf, []byte(passphrase))
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteWallet([]byte(`{"name":"Test wallet"}`)))
+	for _, chunk := range chunks {
+		require.NoError(t, writer.WriteAccounts(chunk))
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestWalletExportRoundTrip(t *testing.T) {
+	chunks := [][][]byte{
+		{[]byte(`{"name":"Account 1"}`), []byte(`{"name":"Account 2"}`)},
+		{[]byte(`{"name":"Account 3"}`)},
+	}
+	data := walletExport(t, "secret", chunks...)
+	require.True(t, util.IsWalletExportV2(data))
+
+	reader, err := util.NewWalletExportReader(bytes.NewReader(data), []byte("secret"))
+	require.NoError(t, err)
+	_, err = reader.ReadAccounts()
+	require.EqualError(t, err, "wallet not read")
+	wallet, err := reader.ReadWallet()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"name":"Test wallet"}`), wallet)
+
+	for _, chunk := range chunks {
+		accounts, err := reader.ReadAccounts()
+		require.NoError(t, err)
+		require.Equal(t, chunk, accounts)
+	}
+	_, err = reader.ReadAccounts()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestWalletExportWriterOrder(t *testing.T) {
+	writer, err := util.NewWalletExportWriter(&bytes.Buffer{}, []byte("secret"))
+	require.NoError(t, err)
+	require.EqualError(t, writer.WriteAccounts([][]byte{[]byte("{}")}), "wallet not written")
+	require.EqualError(t, writer.Close(), "wallet not written")
+	require.NoError(t, writer.WriteWallet([]byte("{}")))
+	require.EqualError(t, writer.WriteWallet([]byte("{}")), "wallet already written")
+
+	_, err = util.NewWalletExportWriter(&bytes.Buffer{}, nil)
+	require.EqualError(t, err, "no passphrase")
+}
+
+func TestWalletExportReaderErrors(t *testing.T) {
+	data := walletExport(t, "secret", [][]byte{[]byte(`{"name":"Account 1"}`)})
+
+	// frameLen is the length of the frame at the given offset.
+	frameLen := func(offset int) int {
+		return 5 + int(binary.BigEndian.Uint32(data[offset+1:offset+5]))
+	}
+	// Length of the version and salt.
+	start := 33
+	walletFrameLen := frameLen(start)
+	accountsFrameLen := frameLen(start + walletFrameLen)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		walletErr  string
+		err        string
+	}{
+		{
+			name:       "Version1",
+			data:       append([]byte{0x01}, data[1:]...),
+			passphrase: "secret",
+			err:        "unsupported export version 1",
+		},
+		{
+			name:       "PassphraseIncorrect",
+			data:       data,
+			passphrase: "wrong",
+			walletErr:  "failed to decrypt export; passphrase incorrect or data corrupt",
+		},
+		{
+			name:       "Truncated",
+			data:       data[:len(data)-10],
+			passphrase: "secret",
+			err:        "export is truncated",
+		},
+		{
+			name:       "TrailerMissing",
+			data:       data[:start+walletFrameLen+accountsFrameLen],
+			passphrase: "secret",
+			err:        "export is truncated",
+		},
+		{
+			name:       "FrameRemoved",
+			data:       append(append([]byte{}, data[:start]...), data[start+walletFrameLen:]...),
+			passphrase: "secret",
+			walletErr:  "failed to decrypt export; passphrase incorrect or data corrupt",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := util.NewWalletExportReader(bytes.NewReader(test.data), []byte(test.passphrase))
+			if err == nil {
+				_, err = reader.ReadWallet()
+				if test.walletErr != "" {
+					require.EqualError(t, err, test.walletErr)
+					return
+				}
+				require.NoError(t, err)
+				for err == nil {
+					_, err = reader.ReadAccounts()
+				}
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
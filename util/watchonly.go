@@ -0,0 +1,150 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	indexer "github.com/wealdtech/go-indexer"
+)
+
+// ErrWatchOnlyAccount is returned when an attempt is made to unlock, or sign
+// with, a watch-only account.
+var ErrWatchOnlyAccount = errors.New("account is watch-only; it has no private key so cannot be unlocked or used for signing")
+
+// WatchOnlyMetadata is metadata stored alongside a watch-only account.
+type WatchOnlyMetadata struct {
+	// Source is the source of the public key, for example "dirk".
+	Source string `json:"source"`
+	// Details are source-specific details about the public key.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// ImportWatchOnlyAccount imports an account to a non-deterministic wallet
+// containing only its public key and metadata.  The account can be used by
+// any command that only requires the public key of the account, but as it
+// holds no private key it cannot be unlocked or used for signing; attempts to
+// do so return ErrWatchOnlyAccount.
+func ImportWatchOnlyAccount(_ context.Context,
+	wallet e2wtypes.Wallet,
+	name string,
+	pubKey []byte,
+	metadata *WatchOnlyMetadata,
+) error {
+	if wallet.Type() != "non-deterministic" {
+		return fmt.Errorf("%s wallets do not support watch-only accounts", wallet.Type())
+	}
+	if name == "" {
+		return errors.New("account name missing")
+	}
+	if len(pubKey) != 48 {
+		return fmt.Errorf("public key of length %d invalid", len(pubKey))
+	}
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return errors.New("wallet does not provide its store")
+	}
+	store := storeProvider.Store()
+
+	// The index is read from the store rather than the wallet, as the wallet's
+	// view of its accounts is not updated when accounts are stored directly.
+	index := indexer.New()
+	serializedIndex, err := store.RetrieveAccountsIndex(wallet.ID())
+	if err == nil {
+		index, err = indexer.Deserialize(serializedIndex)
+		if err != nil {
+			return errors.Wrap(err, "failed to deserialize index")
+		}
+	}
+	if index.NameKnown(name) {
+		return fmt.Errorf("account with name %q already exists", name)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate UUID")
+	}
+
+	// The account is stored in the same format as a regular non-deterministic
+	// account, but with empty crypto so that it cannot be unlocked.
+	data, err := json.Marshal(map[string]any{
+		"uuid":       id.String(),
+		"name":       name,
+		"pubkey":     fmt.Sprintf("%x", pubKey),
+		"crypto":     map[string]any{},
+		"encryptor":  "keystore",
+		"version":    4,
+		"watch_only": metadata,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal account")
+	}
+
+	index.Add(id, name)
+	serializedIndex, err = index.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize index")
+	}
+
+	if err := store.StoreAccount(wallet.ID(), id, data); err != nil {
+		return errors.Wrap(err, "failed to store account")
+	}
+	if err := store.StoreAccountsIndex(wallet.ID(), serializedIndex); err != nil {
+		return errors.Wrap(err, "failed to store accounts index")
+	}
+
+	return nil
+}
+
+// WatchOnlyAccountMetadata returns the watch-only metadata for an account,
+// or nil if the account is not watch-only.
+func WatchOnlyAccountMetadata(wallet e2wtypes.Wallet, account e2wtypes.Account) *WatchOnlyMetadata {
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil
+	}
+	data, err := storeProvider.Store().RetrieveAccount(wallet.ID(), account.ID())
+	if err != nil {
+		return nil
+	}
+
+	res := &struct {
+		WatchOnly *WatchOnlyMetadata `json:"watch_only"`
+	}{}
+	if err := json.Unmarshal(data, res); err != nil {
+		return nil
+	}
+
+	return res.WatchOnly
+}
+
+// CheckNotWatchOnly returns ErrWatchOnlyAccount if the account is watch-only.
+// It should be called before unlocking an account, as the keystore of a
+// watch-only account holds no private key to decrypt.
+func CheckNotWatchOnly(account e2wtypes.Account) error {
+	walletProvider, isWalletProvider := account.(e2wtypes.AccountWalletProvider)
+	if !isWalletProvider {
+		return nil
+	}
+	if WatchOnlyAccountMetadata(walletProvider.Wallet(), account) != nil {
+		return ErrWatchOnlyAccount
+	}
+
+	return nil
+}
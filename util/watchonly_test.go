@@ -0,0 +1,137 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestImportWatchOnlyAccount(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	hdWallet, err := hd.CreateWallet(ctx, "Test HD wallet", []byte("pass"), store, keystorev4.New(), make([]byte, 64))
+	require.NoError(t, err)
+
+	pubKey, err := hex.DecodeString("a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	require.NoError(t, err)
+	metadata := &util.WatchOnlyMetadata{
+		Source: "dirk",
+		Details: map[string]string{
+			"wallet":  "Dirk wallet",
+			"account": "Dirk account",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		wallet      e2wtypes.Wallet
+		accountName string
+		pubKey      []byte
+		err         string
+	}{
+		{
+			name:        "HDWallet",
+			wallet:      hdWallet,
+			accountName: "Test account",
+			pubKey:      pubKey,
+			err:         "hierarchical deterministic wallets do not support watch-only accounts",
+		},
+		{
+			name:   "NameMissing",
+			wallet: wallet,
+			pubKey: pubKey,
+			err:    "account name missing",
+		},
+		{
+			name:        "PubKeyShort",
+			wallet:      wallet,
+			accountName: "Test account",
+			pubKey:      pubKey[1:],
+			err:         "public key of length 47 invalid",
+		},
+		{
+			name:        "Good",
+			wallet:      wallet,
+			accountName: "Test account",
+			pubKey:      pubKey,
+		},
+		{
+			name:        "Duplicate",
+			wallet:      wallet,
+			accountName: "Test account",
+			pubKey:      pubKey,
+			err:         `account with name "Test account" already exists`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.ImportWatchOnlyAccount(ctx, test.wallet, test.accountName, test.pubKey, metadata)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// Ensure that the account can be accessed as a regular account.
+	reopened, err := e2wallet.OpenWallet("Test wallet")
+	require.NoError(t, err)
+	account, err := reopened.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Test account")
+	require.NoError(t, err)
+	require.Equal(t, pubKey, account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal())
+	require.Equal(t, metadata, util.WatchOnlyAccountMetadata(reopened, account))
+
+	// Ensure that the account cannot be unlocked.
+	require.Error(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("")))
+	require.ErrorIs(t, util.CheckNotWatchOnly(account), util.ErrWatchOnlyAccount)
+	_, err = util.UnlockAccount(ctx, account, []string{""})
+	require.ErrorIs(t, err, util.ErrWatchOnlyAccount)
+}
+
+func TestCheckNotWatchOnly(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Test account", []byte("secret"))
+	require.NoError(t, err)
+
+	require.NoError(t, util.CheckNotWatchOnly(account))
+	alreadyUnlocked, err := util.UnlockAccount(ctx, account, []string{"secret"})
+	require.NoError(t, err)
+	require.False(t, alreadyUnlocked)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/services/execution"
+)
+
+// WithdrawalRequestAddress is the address of the EIP-7002 withdrawal request contract.
+var WithdrawalRequestAddress = bellatrix.ExecutionAddress{
+	0x00, 0x00, 0x09, 0x61, 0xef, 0x48, 0x0e, 0xb5, 0x5e, 0x80,
+	0xd1, 0x9a, 0xd8, 0x35, 0x79, 0xa6, 0x4c, 0x00, 0x70, 0x02,
+}
+
+// FullExitRequestAmount is the amount of a withdrawal request that exits the validator.
+const FullExitRequestAmount = phase0.Gwei(0)
+
+// WithdrawalRequestData returns the call data for a withdrawal request.  An
+// amount of FullExitRequestAmount requests that the validator exits.
+func WithdrawalRequestData(pubkey phase0.BLSPubKey, amount phase0.Gwei) []byte {
+	data := make([]byte, len(pubkey)+8)
+	copy(data, pubkey[:])
+	binary.BigEndian.PutUint64(data[len(pubkey):], uint64(amount))
+
+	return data
+}
+
+// WithdrawalRequestFee obtains the current fee for a withdrawal request from
+// an execution node.
+func WithdrawalRequestFee(ctx context.Context, client execution.Service) (*big.Int, error) {
+	return requestContractFee(ctx, client, WithdrawalRequestAddress)
+}
@@ -0,0 +1,48 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestWithdrawalRequestData(t *testing.T) {
+	pubkey := phase0.BLSPubKey{0x01}
+
+	data := util.WithdrawalRequestData(pubkey, 1500000000)
+	require.Len(t, data, 56)
+	require.Equal(t, pubkey[:], data[:48])
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x59, 0x68, 0x2f, 0x00}, data[48:])
+
+	data = util.WithdrawalRequestData(pubkey, util.FullExitRequestAmount)
+	require.Equal(t, make([]byte, 8), data[48:])
+}
+
+func TestWithdrawalRequestFee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x02"}`))
+	}))
+	defer server.Close()
+
+	fee, err := util.WithdrawalRequestFee(context.Background(), executionClient(t, server.URL))
+	require.NoError(t, err)
+	require.Equal(t, "2", fee.String())
+}
@@ -0,0 +1,74 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// BlockWithdrawals obtains the slot and withdrawals of a block.  Blocks prior
+// to Capella have no withdrawals.
+// It returns false if the block is not found.
+func BlockWithdrawals(ctx context.Context,
+	eth2Client eth2client.Service,
+	blockID string,
+) (
+	phase0.Slot,
+	[]*capella.Withdrawal,
+	bool,
+	error,
+) {
+	block, err := ResponseData(eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: blockID}))
+	if err != nil {
+		return 0, nil, false, errors.Wrap(err, "failed to obtain beacon block")
+	}
+	if block == nil {
+		return 0, nil, false, nil
+	}
+
+	slot, withdrawals, err := versionedBlockWithdrawals(block)
+	if err != nil {
+		return 0, nil, false, err
+	}
+
+	return slot, withdrawals, true, nil
+}
+
+// versionedBlockWithdrawals obtains the slot and withdrawals of a versioned block.
+func versionedBlockWithdrawals(block *spec.VersionedSignedBeaconBlock) (
+	phase0.Slot,
+	[]*capella.Withdrawal,
+	error,
+) {
+	slot, err := block.Slot()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to obtain block slot")
+	}
+	if block.Version < spec.DataVersionCapella {
+		return slot, []*capella.Withdrawal{}, nil
+	}
+	withdrawals, err := block.Withdrawals()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to obtain block withdrawals")
+	}
+
+	return slot, withdrawals, nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2023 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedBlockWithdrawals(t *testing.T) {
+	withdrawals := []*capella.Withdrawal{
+		{
+			Index:          5,
+			ValidatorIndex: 12,
+			Address:        bellatrix.ExecutionAddress{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13},
+			Amount:         12345,
+		},
+	}
+	electraBlock := &electra.SignedBeaconBlock{
+		Message: &electra.BeaconBlock{
+			Slot: 100,
+			Body: &electra.BeaconBlockBody{
+				ExecutionPayload: &deneb.ExecutionPayload{
+					Withdrawals: withdrawals,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		block       *spec.VersionedSignedBeaconBlock
+		slot        phase0.Slot
+		withdrawals []*capella.Withdrawal
+		err         string
+	}{
+		{
+			name: "UnknownVersion",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionUnknown,
+			},
+			err: "failed to obtain block slot: unknown version",
+		},
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair: &altair.SignedBeaconBlock{
+					Message: &altair.BeaconBlock{
+						Slot: 100,
+					},
+				},
+			},
+			slot:        100,
+			withdrawals: []*capella.Withdrawal{},
+		},
+		{
+			name: "ElectraPayloadMissing",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Slot: 100,
+						Body: &electra.BeaconBlockBody{},
+					},
+				},
+			},
+			err: "failed to obtain block withdrawals: no electra block",
+		},
+		{
+			name: "Electra",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: electraBlock,
+			},
+			slot:        100,
+			withdrawals: withdrawals,
+		},
+		{
+			name: "Fulu",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionFulu,
+				Fulu:    electraBlock,
+			},
+			slot:        100,
+			withdrawals: withdrawals,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slot, withdrawals, err := versionedBlockWithdrawals(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.slot, slot)
+				require.Equal(t, test.withdrawals, withdrawals)
+			}
+		})
+	}
+}